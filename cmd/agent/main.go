@@ -26,9 +26,11 @@
 	"github.com/mattn/go-colorable"
 	"github.com/rancher/rancher/pkg/agent/clean"
 	"github.com/rancher/rancher/pkg/agent/cluster"
+	"github.com/rancher/rancher/pkg/agent/failover"
 	"github.com/rancher/rancher/pkg/agent/node"
 	"github.com/rancher/rancher/pkg/agent/rancher"
 	"github.com/rancher/rancher/pkg/features"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/logserver"
 	"github.com/rancher/rancher/pkg/rkenodeconfigclient"
 	"github.com/rancher/remotedialer"
@@ -42,6 +44,11 @@
 
 const (
 	Token = "X-API-Tunnel-Token"
+
+	stableConnectionDuration = time.Minute
+	// preferredServerCheckInterval is how often agents connected to an additional server check whether a preferred
+	// server is healthy again, to switch back to it.
+	preferredServerCheckInterval = time.Minute
 )
 
 func main() {
@@ -58,7 +65,12 @@ func main() {
 		logrus.SetOutput(colorable.NewColorableStdout())
 		logserver.StartServerWithDefaults()
 		if os.Getenv("CATTLE_DEBUG") == "true" || os.Getenv("RANCHER_DEBUG") == "true" {
-			logrus.SetLevel(logrus.DebugLevel)
+			logging.SetLevel(logrus.DebugLevel)
+		}
+		if levels, err := logging.ParseLevels(os.Getenv("CATTLE_LOG_LEVELS")); err != nil {
+			logrus.Warn(err)
+		} else {
+			logging.SetLevels(levels)
 		}
 
 		initFeatures()
@@ -307,9 +319,13 @@ func run(ctx context.Context) error {
 		}
 	}
 
+	// Agents connect to the server URL, failing over to the additional server URLs in order when it is unreachable.
+	servers := failover.New(server, failover.ParseURLs(os.Getenv(failover.AdditionalServersEnv)))
+	var connectHost string
+
 	onConnect := func(ctx context.Context, _ *remotedialer.Session) error {
 		connected()
-		connectConfig := fmt.Sprintf("https://%s/v3/connect/config", serverURL.Host)
+		connectConfig := fmt.Sprintf("https://%s/v3/connect/config", connectHost)
 		interval, err := rkenodeconfigclient.ConfigClient(ctx, connectConfig, headers, writeCertsOnly)
 		if err != nil {
 			return err
@@ -359,15 +375,34 @@ func run(ctx context.Context) error {
 		}()
 	}
 
+	// reconnect with jitter so that agents don't all reconnect at once when rancher comes back from an outage, the
+	// backoff is reset once a connection was kept long enough to consider rancher available again
+	backoff := rkenodeconfigclient.RetryBackoff()
 	for {
-		wsURL := fmt.Sprintf("wss://%s/v3/connect", serverURL.Host)
+		current := servers.Current()
+		currentURL, err := url.Parse(current)
+		if err != nil {
+			return err
+		}
+		connectHost = currentURL.Host
+		wsURL := fmt.Sprintf("wss://%s/v3/connect", connectHost)
 		if !isConnect() {
 			wsURL += "/register"
 		}
 
+		// Agents connected to an additional server reconnect to a preferred server once it is healthy again.
+		connectCtx, cancel := context.WithCancel(ctx)
+		if !servers.Preferred() {
+			go servers.WatchPreferred(connectCtx, httpClient, preferredServerCheckInterval, func(preferred string) {
+				logrus.Infof("Server %s is healthy again, switching to it from %s", preferred, current)
+				cancel()
+			})
+		}
+
 		logrus.Infof("Connecting to %s with token starting with %s", wsURL, token[:len(token)/2])
 		logrus.Tracef("Connecting to %s with token %s", wsURL, token)
-		remotedialer.ClientConnect(ctx, wsURL, headers, nil, func(proto, address string) bool {
+		connectedAt := time.Now()
+		remotedialer.ClientConnect(connectCtx, wsURL, headers, nil, func(proto, address string) bool {
 			switch proto {
 			case "tcp":
 				return true
@@ -378,7 +413,20 @@ func run(ctx context.Context) error {
 			}
 			return false
 		}, onConnect)
-		time.Sleep(5 * time.Second)
+		cancel()
+		if servers.Current() != current {
+			// Switched back to a preferred server.
+			backoff = rkenodeconfigclient.RetryBackoff()
+			continue
+		}
+		if time.Since(connectedAt) > stableConnectionDuration {
+			backoff = rkenodeconfigclient.RetryBackoff()
+		} else if next := servers.Failover(); next != current {
+			logrus.Warnf("Could not keep a connection to %s, failing over to %s", current, next)
+		}
+		delay := backoff.Step()
+		logrus.Infof("Disconnected from %s, reconnecting in %v", wsURL, delay.Round(time.Second))
+		time.Sleep(delay)
 	}
 }
 
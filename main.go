@@ -9,10 +9,10 @@
 	"path/filepath"
 
 	"github.com/docker/docker/pkg/reexec"
-	"github.com/ehazlett/simplelog"
 	_ "github.com/rancher/norman/controller"
 	"github.com/rancher/norman/pkg/kwrapper/k8s"
 	"github.com/rancher/rancher/pkg/data/management"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/logserver"
 	"github.com/rancher/rancher/pkg/rancher"
 	"github.com/rancher/rancher/pkg/version"
@@ -160,6 +160,12 @@ func main() {
 			Usage:       "Declare specific feature values on start up. Example: \"kontainer-driver=true\" - kontainer driver feature will be enabled despite false default value",
 			Destination: &config.Features,
 		},
+		cli.BoolFlag{
+			Name:        "standby",
+			EnvVar:      "CATTLE_STANDBY",
+			Usage:       "Serve the UI, read requests and proxied requests to clusters without running the controllers, for example while upgrading rancher",
+			Destination: &config.Standby,
+		},
 	}
 
 	app.Action = func(c *cli.Context) error {
@@ -181,21 +187,16 @@ func main() {
 }
 
 func initLogs(c *cli.Context, cfg rancher.Options) {
-	switch c.String("log-format") {
-	case "simple":
-		logrus.SetFormatter(&simplelog.StandardFormatter{})
-	case "text":
-		logrus.SetFormatter(&logrus.TextFormatter{})
-	case "json":
-		logrus.SetFormatter(&logrus.JSONFormatter{})
+	if err := logging.SetDefaultFormat(c.String("log-format")); err != nil {
+		logrus.Warn(err)
 	}
 	logrus.SetOutput(os.Stdout)
 	if cfg.Debug {
-		logrus.SetLevel(logrus.DebugLevel)
+		logging.SetLevel(logrus.DebugLevel)
 		logrus.Debugf("Loglevel set to [%v]", logrus.DebugLevel)
 	}
 	if cfg.Trace {
-		logrus.SetLevel(logrus.TraceLevel)
+		logging.SetLevel(logrus.TraceLevel)
 		logrus.Tracef("Loglevel set to [%v]", logrus.TraceLevel)
 	}
 
@@ -0,0 +1,75 @@
+// Package admission serves the validating admission webhooks of rancher, so that the resources rancher provisions
+// clusters from are validated however they're changed, through the rancher API or directly through the Kubernetes API
+// of the local cluster. The webhooks are served by the internal listener of rancher, which the Kubernetes API server
+// reaches through the rancher service and trusts with the internal CA.
+package admission
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/rancher/rancher/pkg/admission/compatibility"
+	"github.com/rancher/rancher/pkg/admission/machineconfig"
+	"github.com/rancher/rancher/pkg/admission/plannerbehaviors"
+	"github.com/rancher/rancher/pkg/admission/provisioningquota"
+	"github.com/rancher/rancher/pkg/tls"
+	"github.com/rancher/rancher/pkg/wrangler"
+	"github.com/rancher/wrangler/pkg/webhook"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+)
+
+// Path is the path the webhooks are served under, each validator at the path followed by its name.
+const Path = "/v1-admission/"
+
+// Validator admits the requests of the operations on the resources of its rules. Requests are denied if it returns
+// an error.
+type Validator interface {
+	webhook.Handler
+	// Name is the name of the webhook of the validator, unique among validators.
+	Name() string
+	Rules() []admissionregistrationv1.RuleWithOperations
+}
+
+// FailurePolicyValidator is a validator whose requests aren't denied when its webhook can't be reached, or fails, if
+// its failure policy is to ignore them.
+type FailurePolicyValidator interface {
+	Validator
+	FailurePolicy() admissionregistrationv1.FailurePolicyType
+}
+
+// Validators returns the validators of the webhooks.
+func Validators(clients *wrangler.Context) []Validator {
+	return []Validator{
+		provisioningquota.NewValidator(clients),
+		provisioningquota.NewMachineDeploymentValidator(clients),
+		compatibility.NewValidator(clients),
+		machineconfig.NewValidator(clients),
+		plannerbehaviors.NewValidator(),
+	}
+}
+
+// NewHandler returns the handler serving the webhooks of the validators. Requests are only served on the internal
+// listener, which only the Kubernetes API server is expected to reach.
+func NewHandler(validators []Validator) http.Handler {
+	mux := http.NewServeMux()
+	for _, validator := range validators {
+		router := webhook.NewRouter()
+		for _, rule := range validator.Rules() {
+			for _, group := range rule.APIGroups {
+				for _, resource := range rule.Resources {
+					resource, subResource, _ := strings.Cut(resource, "/")
+					router.Group(group).Resource(resource).SubResource(subResource).Handle(validator)
+				}
+			}
+		}
+		mux.Handle(Path+validator.Name(), router)
+	}
+
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if internal, _ := req.Context().Value(tls.InternalAPI).(bool); !internal || req.Method != http.MethodPost {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		mux.ServeHTTP(rw, req)
+	})
+}
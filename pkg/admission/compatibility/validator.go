@@ -0,0 +1,71 @@
+// Package compatibility validates the Kubernetes versions of the provisioning clusters created and upgraded against
+// the compatibility matrix.
+package compatibility
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/compatibility"
+	"github.com/rancher/rancher/pkg/wrangler"
+	"github.com/rancher/wrangler/pkg/webhook"
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type Validator struct {
+	checker *compatibility.Checker
+}
+
+func NewValidator(clients *wrangler.Context) *Validator {
+	return &Validator{
+		checker: compatibility.NewChecker(clients),
+	}
+}
+
+func (v *Validator) Name() string {
+	return "compatibility"
+}
+
+func (v *Validator) Rules() []admissionregistrationv1.RuleWithOperations {
+	return []admissionregistrationv1.RuleWithOperations{
+		{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{provv1.SchemeGroupVersion.Group},
+				APIVersions: []string{provv1.SchemeGroupVersion.Version},
+				Resources:   []string{"clusters"},
+			},
+		},
+	}
+}
+
+// Admit denies the request if the Kubernetes version of the cluster it creates or updates is incompatible with the
+// cluster.
+func (v *Validator) Admit(resp *webhook.Response, req *webhook.Request) error {
+	cluster := &provv1.Cluster{}
+	if err := json.Unmarshal(req.Object.Raw, cluster); err != nil {
+		return err
+	}
+	var oldCluster *provv1.Cluster
+	if req.Operation == admissionv1.Update {
+		oldCluster = &provv1.Cluster{}
+		if err := json.Unmarshal(req.OldObject.Raw, oldCluster); err != nil {
+			return err
+		}
+	}
+
+	incompatibilities, err := v.checker.Check(req.Context, cluster, oldCluster)
+	if err != nil {
+		return err
+	}
+
+	resp.Allowed = len(incompatibilities) == 0
+	if !resp.Allowed {
+		resp.Result = &apierrors.NewBadRequest(fmt.Sprintf("incompatible Kubernetes version: %s", strings.Join(incompatibilities, "; "))).ErrStatus
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package admission
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/rancher/rancher/pkg/namespace"
+	"github.com/rancher/rancher/pkg/wrangler"
+	admissionregcontrollers "github.com/rancher/wrangler/pkg/generated/controllers/admissionregistration.k8s.io/v1"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/sirupsen/logrus"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ConfigurationName is the name of the validating webhook configuration of the webhooks.
+	ConfigurationName = "rancher-admission"
+
+	serviceName       = "rancher"
+	servicePort       = 443
+	internalCAName    = "tls-rancher-internal-ca"
+	webhookNameSuffix = ".admission.cattle.io"
+	timeoutSeconds    = 10
+)
+
+type handler struct {
+	validators     []Validator
+	secretsCache   corecontrollers.SecretCache
+	servicesCache  corecontrollers.ServiceCache
+	configurations admissionregcontrollers.ValidatingWebhookConfigurationController
+}
+
+// Register registers the controller keeping the validating webhook configuration of the webhooks up to date with the
+// validators and the internal CA. The webhooks are only registered once rancher runs behind its service with the
+// internal CA, as the Kubernetes API server couldn't reach them otherwise and every request they validate would fail.
+func Register(ctx context.Context, clients *wrangler.Context) {
+	h := &handler{
+		validators:     Validators(clients),
+		secretsCache:   clients.Core.Secret().Cache(),
+		servicesCache:  clients.Core.Service().Cache(),
+		configurations: clients.Admission.ValidatingWebhookConfiguration(),
+	}
+	clients.Core.Secret().OnChange(ctx, "admission-webhooks", h.onSecret)
+	clients.Admission.ValidatingWebhookConfiguration().OnChange(ctx, "admission-webhooks", h.onConfiguration)
+}
+
+func (h *handler) onSecret(_ string, secret *corev1.Secret) (*corev1.Secret, error) {
+	if secret == nil || secret.Namespace != namespace.System || secret.Name != internalCAName {
+		return secret, nil
+	}
+	return secret, h.ensure()
+}
+
+// onConfiguration reverts the changes made to the validating webhook configuration, and recreates it once deleted.
+func (h *handler) onConfiguration(key string, configuration *admissionregistrationv1.ValidatingWebhookConfiguration) (*admissionregistrationv1.ValidatingWebhookConfiguration, error) {
+	if key != ConfigurationName {
+		return configuration, nil
+	}
+	return configuration, h.ensure()
+}
+
+func (h *handler) ensure() error {
+	if _, err := h.servicesCache.Get(namespace.System, serviceName); apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	ca, err := h.secretsCache.Get(namespace.System, internalCAName)
+	if apierrors.IsNotFound(err) || (err == nil && len(ca.Data[corev1.TLSCertKey]) == 0) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	desired := webhookConfiguration(h.validators, ca.Data[corev1.TLSCertKey])
+	current, err := h.configurations.Cache().Get(ConfigurationName)
+	if apierrors.IsNotFound(err) {
+		logrus.Infof("[admission] creating validating webhook configuration [%s]", ConfigurationName)
+		_, err = h.configurations.Create(desired)
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(current.Webhooks, desired.Webhooks) {
+		return nil
+	}
+	current = current.DeepCopy()
+	current.Webhooks = desired.Webhooks
+	logrus.Infof("[admission] updating validating webhook configuration [%s]", ConfigurationName)
+	_, err = h.configurations.Update(current)
+	return err
+}
+
+// webhookConfiguration returns the validating webhook configuration of the webhooks of the validators, served through
+// the rancher service with a certificate signed by the CA. Requests are denied if their webhook can't be reached, so
+// that the validation can't be bypassed while rancher is unavailable, unless their validator has another failure policy.
+func webhookConfiguration(validators []Validator, caBundle []byte) *admissionregistrationv1.ValidatingWebhookConfiguration {
+	var (
+		sideEffects = admissionregistrationv1.SideEffectClassNone
+		matchPolicy = admissionregistrationv1.Equivalent
+		port        = int32(servicePort)
+		timeout     = int32(timeoutSeconds)
+	)
+
+	configuration := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ConfigurationName,
+		},
+	}
+	for _, validator := range validators {
+		path := Path + validator.Name()
+		failurePolicy := admissionregistrationv1.Fail
+		if v, ok := validator.(FailurePolicyValidator); ok {
+			failurePolicy = v.FailurePolicy()
+		}
+		configuration.Webhooks = append(configuration.Webhooks, admissionregistrationv1.ValidatingWebhook{
+			Name: fmt.Sprintf("%s%s", validator.Name(), webhookNameSuffix),
+			ClientConfig: admissionregistrationv1.WebhookClientConfig{
+				Service: &admissionregistrationv1.ServiceReference{
+					Namespace: namespace.System,
+					Name:      serviceName,
+					Path:      &path,
+					Port:      &port,
+				},
+				CABundle: caBundle,
+			},
+			Rules:                   validator.Rules(),
+			FailurePolicy:           &failurePolicy,
+			MatchPolicy:             &matchPolicy,
+			SideEffects:             &sideEffects,
+			TimeoutSeconds:          &timeout,
+			AdmissionReviewVersions: []string{"v1"},
+		})
+	}
+	return configuration
+}
@@ -0,0 +1,74 @@
+package admission
+
+import (
+	"testing"
+
+	"github.com/rancher/wrangler/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+)
+
+type testValidator struct {
+	name  string
+	rules []admissionregistrationv1.RuleWithOperations
+}
+
+func (v *testValidator) Admit(*webhook.Response, *webhook.Request) error {
+	return nil
+}
+
+func (v *testValidator) Name() string {
+	return v.name
+}
+
+func (v *testValidator) Rules() []admissionregistrationv1.RuleWithOperations {
+	return v.rules
+}
+
+type ignoredValidator struct {
+	testValidator
+}
+
+func (v *ignoredValidator) FailurePolicy() admissionregistrationv1.FailurePolicyType {
+	return admissionregistrationv1.Ignore
+}
+
+func TestWebhookConfiguration(t *testing.T) {
+	rules := []admissionregistrationv1.RuleWithOperations{
+		{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{"provisioning.cattle.io"},
+				APIVersions: []string{"v1"},
+				Resources:   []string{"clusters"},
+			},
+		},
+	}
+	configuration := webhookConfiguration([]Validator{
+		&testValidator{name: "first", rules: rules},
+		&testValidator{name: "second"},
+		&ignoredValidator{testValidator{name: "third"}},
+	}, []byte("ca"))
+
+	assert.Equal(t, ConfigurationName, configuration.Name)
+	assert.Len(t, configuration.Webhooks, 3)
+
+	first := configuration.Webhooks[0]
+	assert.Equal(t, "first.admission.cattle.io", first.Name)
+	assert.Equal(t, rules, first.Rules)
+	assert.Equal(t, []byte("ca"), first.ClientConfig.CABundle)
+	assert.Equal(t, "cattle-system", first.ClientConfig.Service.Namespace)
+	assert.Equal(t, "rancher", first.ClientConfig.Service.Name)
+	assert.Equal(t, "/v1-admission/first", *first.ClientConfig.Service.Path)
+	assert.Equal(t, int32(443), *first.ClientConfig.Service.Port)
+	assert.Equal(t, admissionregistrationv1.Fail, *first.FailurePolicy)
+	assert.Equal(t, admissionregistrationv1.SideEffectClassNone, *first.SideEffects)
+	assert.Equal(t, []string{"v1"}, first.AdmissionReviewVersions)
+
+	assert.Equal(t, "second.admission.cattle.io", configuration.Webhooks[1].Name)
+	assert.Equal(t, "/v1-admission/second", *configuration.Webhooks[1].ClientConfig.Service.Path)
+	assert.Equal(t, admissionregistrationv1.Fail, *configuration.Webhooks[1].FailurePolicy)
+
+	assert.Equal(t, "third.admission.cattle.io", configuration.Webhooks[2].Name)
+	assert.Equal(t, admissionregistrationv1.Ignore, *configuration.Webhooks[2].FailurePolicy)
+}
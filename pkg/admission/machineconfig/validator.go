@@ -0,0 +1,127 @@
+// Package machineconfig validates the machine configs of the node drivers created and updated, and the machine configs
+// of the new machine pools of the provisioning clusters created and updated against their cloud.
+package machineconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/machineconfigvalidation"
+	"github.com/rancher/rancher/pkg/wrangler"
+	"github.com/rancher/wrangler/pkg/data"
+	"github.com/rancher/wrangler/pkg/webhook"
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const machineConfigGroup = "rke-machine-config.cattle.io"
+
+type Validator struct {
+	checker *machineconfigvalidation.Checker
+}
+
+func NewValidator(clients *wrangler.Context) *Validator {
+	return &Validator{
+		checker: machineconfigvalidation.NewChecker(clients),
+	}
+}
+
+func (v *Validator) Name() string {
+	return "machineconfig"
+}
+
+func (v *Validator) Rules() []admissionregistrationv1.RuleWithOperations {
+	operations := []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update}
+	return []admissionregistrationv1.RuleWithOperations{
+		{
+			Operations: operations,
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{machineConfigGroup},
+				APIVersions: []string{"v1"},
+				Resources:   resources(machineconfigvalidation.Kinds()),
+			},
+		},
+		{
+			Operations: operations,
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{provv1.SchemeGroupVersion.Group},
+				APIVersions: []string{provv1.SchemeGroupVersion.Version},
+				Resources:   []string{"clusters"},
+			},
+		},
+	}
+}
+
+// Admit denies the request if the machine config it creates or updates violates the rules of its kind, or if the
+// cluster it creates or updates has machine pools whose machine config is found invalid by their cloud. Only the fields
+// of machine configs changed by updates are validated, and objects being deleted aren't validated, so that objects
+// valid when they were created can still be updated and have their finalizers removed once the rules or clouds change.
+func (v *Validator) Admit(resp *webhook.Response, req *webhook.Request) error {
+	var (
+		violations []string
+		err        error
+	)
+	if req.Resource.Group == machineConfigGroup {
+		violations, err = validateMachineConfig(req)
+	} else {
+		violations, resp.Warnings, err = v.checkCluster(req)
+	}
+	if err != nil {
+		return err
+	}
+
+	resp.Allowed = len(violations) == 0
+	if !resp.Allowed {
+		resp.Result = &apierrors.NewBadRequest(fmt.Sprintf("invalid %s: %s", req.Kind.Kind, strings.Join(violations, "; "))).ErrStatus
+	}
+	return nil
+}
+
+func (v *Validator) checkCluster(req *webhook.Request) ([]string, []string, error) {
+	cluster := &provv1.Cluster{}
+	if err := json.Unmarshal(req.Object.Raw, cluster); err != nil {
+		return nil, nil, err
+	}
+	if cluster.DeletionTimestamp != nil {
+		return nil, nil, nil
+	}
+	var oldCluster *provv1.Cluster
+	if req.Operation == admissionv1.Update {
+		oldCluster = &provv1.Cluster{}
+		if err := json.Unmarshal(req.OldObject.Raw, oldCluster); err != nil {
+			return nil, nil, err
+		}
+	}
+	return v.checker.Check(req.Context, cluster, oldCluster)
+}
+
+// validateMachineConfig returns the violations of the rules of its kind by the machine config of a request, only those
+// of the fields it changes on update.
+func validateMachineConfig(req *webhook.Request) ([]string, error) {
+	config := data.Object{}
+	if err := json.Unmarshal(req.Object.Raw, &config); err != nil {
+		return nil, err
+	}
+	if config.String("metadata", "deletionTimestamp") != "" {
+		return nil, nil
+	}
+	var oldConfig data.Object
+	if req.Operation == admissionv1.Update {
+		if err := json.Unmarshal(req.OldObject.Raw, &oldConfig); err != nil {
+			return nil, err
+		}
+	}
+	return machineconfigvalidation.ValidateUpdate(req.Kind.Kind, config, oldConfig), nil
+}
+
+// resources returns the resources of the kinds of machine configs.
+func resources(kinds []string) []string {
+	var result []string
+	for _, kind := range kinds {
+		result = append(result, strings.ToLower(kind)+"s")
+	}
+	return result
+}
@@ -0,0 +1,50 @@
+package machineconfig
+
+import (
+	"testing"
+
+	"github.com/rancher/wrangler/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func machineConfigRequest(operation admissionv1.Operation, object, oldObject string) *webhook.Request {
+	request := &webhook.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Kind:      metav1.GroupVersionKind{Group: machineConfigGroup, Version: "v1", Kind: "Amazonec2Config"},
+			Operation: operation,
+			Object:    runtime.RawExtension{Raw: []byte(object)},
+		},
+	}
+	if oldObject != "" {
+		request.OldObject = runtime.RawExtension{Raw: []byte(oldObject)}
+	}
+	return request
+}
+
+func TestResources(t *testing.T) {
+	assert.Equal(t, []string{"amazonec2configs", "vmwarevsphereconfigs"}, resources([]string{"Amazonec2Config", "VmwarevsphereConfig"}))
+}
+
+func TestValidateMachineConfig(t *testing.T) {
+	violations, err := validateMachineConfig(machineConfigRequest(admissionv1.Create, `{"metadata":{"name":"nc-pool"},"region":"us-east","instanceType":"t3.medium"}`, ""))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`region "us-east" must be an AWS region, such as us-east-1`}, violations)
+
+	violations, err = validateMachineConfig(machineConfigRequest(admissionv1.Update,
+		`{"metadata":{"name":"nc-pool"},"region":"us-east","instanceType":"t3.large"}`,
+		`{"metadata":{"name":"nc-pool"},"region":"us-east","instanceType":"t3.medium"}`))
+	assert.NoError(t, err)
+	assert.Empty(t, violations, "the fields unchanged by updates aren't validated")
+
+	violations, err = validateMachineConfig(machineConfigRequest(admissionv1.Update,
+		`{"metadata":{"name":"nc-pool","deletionTimestamp":"2023-03-01T12:00:00Z"},"region":"us-east","zone":"us-east-1a"}`,
+		`{"metadata":{"name":"nc-pool"},"region":"us-east"}`))
+	assert.NoError(t, err)
+	assert.Empty(t, violations, "objects being deleted aren't validated")
+
+	_, err = validateMachineConfig(machineConfigRequest(admissionv1.Create, `[]`, ""))
+	assert.Error(t, err)
+}
@@ -0,0 +1,63 @@
+// Package plannerbehaviors validates the planner behaviors of the RKE2 and K3s provisioning clusters created and
+// updated against the existing behaviors, and warns of the fields of the clusters ignored as their behaviors are
+// disabled.
+package plannerbehaviors
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/wrangler/pkg/webhook"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type Validator struct{}
+
+func NewValidator() *Validator {
+	return &Validator{}
+}
+
+func (v *Validator) Name() string {
+	return "plannerbehaviors"
+}
+
+func (v *Validator) Rules() []admissionregistrationv1.RuleWithOperations {
+	return []admissionregistrationv1.RuleWithOperations{
+		{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{provv1.SchemeGroupVersion.Group},
+				APIVersions: []string{provv1.SchemeGroupVersion.Version},
+				Resources:   []string{"clusters"},
+			},
+		},
+	}
+}
+
+// Admit denies the request if the cluster it creates or updates has planner behaviors that don't exist, and warns of
+// the fields it sets that are ignored as the planner behaviors using them are disabled, by the cluster or else by the
+// planner-behavior-defaults setting. An invalid setting is ignored, as it is by the planner.
+func (v *Validator) Admit(resp *webhook.Response, req *webhook.Request) error {
+	cluster := &provv1.Cluster{}
+	if err := json.Unmarshal(req.Object.Raw, cluster); err != nil {
+		return err
+	}
+
+	var violations []string
+	if rkeConfig := cluster.Spec.RKEConfig; rkeConfig != nil {
+		violations = capr.ValidatePlannerBehaviors(rkeConfig.PlannerBehaviors)
+		defaults, _ := capr.ParsePlannerBehaviorDefaults(settings.PlannerBehaviorDefaults.Get())
+		resp.Warnings = capr.IgnoredPlannerBehaviorFields(&rkeConfig.RKEClusterSpecCommon, capr.ResolvePlannerBehaviors(rkeConfig.PlannerBehaviors, defaults))
+	}
+
+	resp.Allowed = len(violations) == 0
+	if !resp.Allowed {
+		resp.Result = &apierrors.NewBadRequest(fmt.Sprintf("invalid planner behaviors: %s", strings.Join(violations, "; "))).ErrStatus
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package provisioningquota
+
+import (
+	"encoding/json"
+
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
+	provcontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	quota "github.com/rancher/rancher/pkg/provisioningquota"
+	"github.com/rancher/rancher/pkg/wrangler"
+	"github.com/rancher/wrangler/pkg/webhook"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// MachineDeploymentValidator validates the machine deployments of machine pools scaled. Its webhook matches every
+// machine deployment, including those of clusters rancher doesn't provision, so their requests are allowed rather than
+// denied when it can't be reached.
+type MachineDeploymentValidator struct {
+	checker                *quota.Checker
+	clusterCache           provcontrollers.ClusterCache
+	machineDeploymentCache capicontrollers.MachineDeploymentCache
+}
+
+func NewMachineDeploymentValidator(clients *wrangler.Context) *MachineDeploymentValidator {
+	return &MachineDeploymentValidator{
+		checker:                quota.NewChecker(clients),
+		clusterCache:           clients.Provisioning.Cluster().Cache(),
+		machineDeploymentCache: clients.CAPI.MachineDeployment().Cache(),
+	}
+}
+
+func (v *MachineDeploymentValidator) Name() string {
+	return "provisioningquota-machinedeployments"
+}
+
+func (v *MachineDeploymentValidator) Rules() []admissionregistrationv1.RuleWithOperations {
+	return []admissionregistrationv1.RuleWithOperations{
+		{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Update},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{capi.GroupVersion.Group},
+				APIVersions: []string{capi.GroupVersion.Version},
+				Resources:   []string{"machinedeployments", "machinedeployments/scale"},
+			},
+		},
+	}
+}
+
+func (v *MachineDeploymentValidator) FailurePolicy() admissionregistrationv1.FailurePolicyType {
+	return admissionregistrationv1.Ignore
+}
+
+// Admit denies the request if the provisioning quotas deny scaling up the machine deployment of a machine pool, checked
+// as scaling up the machine pool, as the machines it creates count against the quotas the same.
+func (v *MachineDeploymentValidator) Admit(resp *webhook.Response, req *webhook.Request) error {
+	violations, err := v.admitMachineDeployment(req)
+	if err != nil {
+		return err
+	}
+	deny(resp, req, violations)
+	return nil
+}
+
+func (v *MachineDeploymentValidator) admitMachineDeployment(req *webhook.Request) ([]string, error) {
+	var (
+		machineDeployment *capi.MachineDeployment
+		replicas          int32
+		oldReplicas       int32
+	)
+	if req.SubResource == "scale" {
+		scale, oldScale := &autoscalingv1.Scale{}, &autoscalingv1.Scale{}
+		if err := json.Unmarshal(req.Object.Raw, scale); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(req.OldObject.Raw, oldScale); err != nil {
+			return nil, err
+		}
+		var err error
+		machineDeployment, err = v.machineDeploymentCache.Get(req.Namespace, req.Name)
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		replicas, oldReplicas = scale.Spec.Replicas, oldScale.Spec.Replicas
+	} else {
+		machineDeployment = &capi.MachineDeployment{}
+		oldMachineDeployment := &capi.MachineDeployment{}
+		if err := json.Unmarshal(req.Object.Raw, machineDeployment); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(req.OldObject.Raw, oldMachineDeployment); err != nil {
+			return nil, err
+		}
+		replicas, oldReplicas = machineDeploymentReplicas(machineDeployment), machineDeploymentReplicas(oldMachineDeployment)
+	}
+	if replicas <= oldReplicas {
+		return nil, nil
+	}
+
+	clusterName := machineDeployment.Spec.Template.Labels[capr.ClusterNameLabel]
+	poolName := machineDeployment.Spec.Template.Labels[capr.RKEMachinePoolNameLabel]
+	if clusterName == "" || poolName == "" {
+		return nil, nil
+	}
+	cluster, err := v.clusterCache.Get(machineDeployment.Namespace, clusterName)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	scaled := scaledCluster(cluster, poolName, replicas)
+	if scaled == nil {
+		return nil, nil
+	}
+	return v.checker.Check(userInfo(req), scaled, cluster)
+}
+
+// scaledCluster returns the cluster with the quantity of a machine pool scaled to the replicas of its machine
+// deployment, less those of the machines being replaced. Nil is returned if the replicas don't exceed those of the
+// machine pool.
+func scaledCluster(cluster *provv1.Cluster, poolName string, replicas int32) *provv1.Cluster {
+	if cluster.Spec.RKEConfig == nil {
+		return nil
+	}
+	surge := machineReplacementSurge(cluster, poolName)
+	for i, pool := range cluster.Spec.RKEConfig.MachinePools {
+		if pool.Name != poolName {
+			continue
+		}
+		quantity := int32(1)
+		if pool.Quantity != nil {
+			quantity = *pool.Quantity
+		}
+		if replicas <= quantity+surge {
+			return nil
+		}
+		scaled := cluster.DeepCopy()
+		scaledQuantity := replicas - surge
+		scaled.Spec.RKEConfig.MachinePools[i].Quantity = &scaledQuantity
+		return scaled
+	}
+	return nil
+}
+
+// machineReplacementSurge returns the number of machines a machine pool is scaled up by for the substitutes of the
+// machines being replaced, as the machine deployment of the machine pool is by the provisioning cluster controller.
+func machineReplacementSurge(cluster *provv1.Cluster, poolName string) int32 {
+	var surge int32
+	for _, replacement := range cluster.Status.MachineReplacements {
+		if replacement.PoolName == poolName && replacement.ID != "" && replacement.Phase != provv1.MachineReplacementDeleting {
+			surge++
+		}
+	}
+	return surge
+}
+
+func machineDeploymentReplicas(machineDeployment *capi.MachineDeployment) int32 {
+	if machineDeployment.Spec.Replicas == nil {
+		// machine deployments default to one replica
+		return 1
+	}
+	return *machineDeployment.Spec.Replicas
+}
@@ -0,0 +1,63 @@
+package provisioningquota
+
+import (
+	"testing"
+
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func TestScaledCluster(t *testing.T) {
+	cluster := &provv1.Cluster{
+		Spec: provv1.ClusterSpec{
+			RKEConfig: &provv1.RKEConfig{
+				MachinePools: []provv1.RKEMachinePool{
+					{Name: "cp", Quantity: int32Ptr(3)},
+					{Name: "worker", Quantity: int32Ptr(2)},
+					{Name: "default"},
+				},
+			},
+		},
+		Status: provv1.ClusterStatus{
+			MachineReplacements: []provv1.MachineReplacementStatus{
+				{PoolName: "worker", ID: "1"},
+				{PoolName: "worker", ID: "2", Phase: provv1.MachineReplacementDeleting},
+				{PoolName: "worker"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		pool     string
+		replicas int32
+		expected *int32
+	}{
+		{name: "within the quantity", pool: "cp", replicas: 3},
+		{name: "scaled up", pool: "cp", replicas: 5, expected: int32Ptr(5)},
+		{name: "within the replacement surge", pool: "worker", replicas: 3},
+		{name: "scaled up past the replacement surge", pool: "worker", replicas: 4, expected: int32Ptr(3)},
+		{name: "default quantity", pool: "default", replicas: 2, expected: int32Ptr(2)},
+		{name: "unknown machine pool", pool: "unknown", replicas: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scaled := scaledCluster(cluster, tt.pool, tt.replicas)
+			if tt.expected == nil {
+				assert.Nil(t, scaled)
+				return
+			}
+			for _, pool := range scaled.Spec.RKEConfig.MachinePools {
+				if pool.Name == tt.pool {
+					assert.Equal(t, tt.expected, pool.Quantity)
+				}
+			}
+			assert.NotSame(t, cluster.Spec.RKEConfig, scaled.Spec.RKEConfig)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// Package provisioningquota validates the provisioning clusters created and scaled, the machine configs their machine
+// pools use updated, and the machine deployments of their machine pools scaled, against the provisioning quotas.
+package provisioningquota
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	quota "github.com/rancher/rancher/pkg/provisioningquota"
+	"github.com/rancher/rancher/pkg/wrangler"
+	"github.com/rancher/wrangler/pkg/data"
+	"github.com/rancher/wrangler/pkg/webhook"
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authentication/user"
+)
+
+const machineConfigGroup = "rke-machine-config.cattle.io"
+
+type Validator struct {
+	checker *quota.Checker
+}
+
+func NewValidator(clients *wrangler.Context) *Validator {
+	return &Validator{
+		checker: quota.NewChecker(clients),
+	}
+}
+
+func (v *Validator) Name() string {
+	return "provisioningquota"
+}
+
+func (v *Validator) Rules() []admissionregistrationv1.RuleWithOperations {
+	return []admissionregistrationv1.RuleWithOperations{
+		{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{provv1.SchemeGroupVersion.Group},
+				APIVersions: []string{provv1.SchemeGroupVersion.Version},
+				Resources:   []string{"clusters"},
+			},
+		},
+		{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Update},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{machineConfigGroup},
+				APIVersions: []string{"v1"},
+				Resources:   resources(quota.Kinds()),
+			},
+		},
+	}
+}
+
+// Admit denies the request if the provisioning quotas deny the cluster it creates or scales up, or the instance type or
+// region it changes a machine config to, as the machine pools using the machine config create their machines with it.
+func (v *Validator) Admit(resp *webhook.Response, req *webhook.Request) error {
+	var (
+		violations []string
+		err        error
+	)
+	if req.Resource.Group == machineConfigGroup {
+		violations, err = v.admitMachineConfig(req)
+	} else {
+		violations, err = v.admitCluster(req)
+	}
+	if err != nil {
+		return err
+	}
+	deny(resp, req, violations)
+	return nil
+}
+
+func (v *Validator) admitCluster(req *webhook.Request) ([]string, error) {
+	cluster := &provv1.Cluster{}
+	if err := json.Unmarshal(req.Object.Raw, cluster); err != nil {
+		return nil, err
+	}
+
+	var oldCluster *provv1.Cluster
+	if req.Operation == admissionv1.Update {
+		oldCluster = &provv1.Cluster{}
+		if err := json.Unmarshal(req.OldObject.Raw, oldCluster); err != nil {
+			return nil, err
+		}
+	}
+	return v.checker.Check(userInfo(req), cluster, oldCluster)
+}
+
+func (v *Validator) admitMachineConfig(req *webhook.Request) ([]string, error) {
+	config, oldConfig := data.Object{}, data.Object{}
+	if err := json.Unmarshal(req.Object.Raw, &config); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(req.OldObject.Raw, &oldConfig); err != nil {
+		return nil, err
+	}
+	if config.String("metadata", "deletionTimestamp") != "" {
+		return nil, nil
+	}
+	return v.checker.CheckMachineConfig(userInfo(req), req.Kind.Kind, req.Namespace, req.Name, config, oldConfig)
+}
+
+// deny denies the request if the provisioning quotas are violated.
+func deny(resp *webhook.Response, req *webhook.Request, violations []string) {
+	resp.Allowed = len(violations) == 0
+	if !resp.Allowed {
+		resp.Result = &apierrors.NewForbidden(schema.GroupResource{Group: req.Resource.Group, Resource: req.Resource.Resource}, req.Name,
+			fmt.Errorf("provisioning quota exceeded: %s", strings.Join(violations, "; "))).ErrStatus
+	}
+}
+
+func userInfo(req *webhook.Request) user.Info {
+	extra := map[string][]string{}
+	for k, v := range req.UserInfo.Extra {
+		extra[k] = v
+	}
+	return &user.DefaultInfo{
+		Name:   req.UserInfo.Username,
+		UID:    req.UserInfo.UID,
+		Groups: req.UserInfo.Groups,
+		Extra:  extra,
+	}
+}
+
+// resources returns the resources of the kinds of machine configs.
+func resources(kinds []string) []string {
+	var result []string
+	for _, kind := range kinds {
+		result = append(result, strings.ToLower(kind)+"s")
+	}
+	return result
+}
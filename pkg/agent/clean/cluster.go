@@ -27,9 +27,9 @@
 	"github.com/rancher/rancher/pkg/controllers/management/usercontrollers"
 	"github.com/rancher/rancher/pkg/controllers/managementagent/nslabels"
 	"github.com/rancher/rancher/pkg/controllers/managementuserlegacy/helm"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/monitoring"
 	"github.com/rancher/rancher/pkg/namespace"
-	"github.com/sirupsen/logrus"
 	apierror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -81,11 +81,11 @@ func Cluster() error {
 	if os.Getenv("SLEEP_FIRST") == "true" {
 		// The sleep allows Rancher server time to finish updating ownerReferences
 		// and close the connection.
-		logrus.Info("Starting sleep for 1 min to allow server time to disconnect....")
+		logger.Info("Starting sleep for 1 min to allow server time to disconnect....")
 		time.Sleep(time.Duration(1) * time.Minute)
 	}
 
-	logrus.Info("Starting cluster cleanup")
+	logger.Info("Starting cluster cleanup")
 	config, err := rest.InClusterConfig()
 	if err != nil {
 		return err
@@ -99,7 +99,7 @@ func Cluster() error {
 	if rancherInstalled, err := isRancherInstalled(client); err != nil {
 		return fmt.Errorf("checking for %s/rancher service: %w", namespace.System, err)
 	} else if rancherInstalled {
-		logrus.Info("Rancher is installed, not performing cleanup")
+		logger.Info("Rancher is installed, not performing cleanup")
 		return deleteJob(client)
 	}
 
@@ -159,7 +159,7 @@ func Cluster() error {
 }
 
 func removeNamespace(namespace string, client *kubernetes.Clientset) error {
-	logrus.Infof("Attempting to remove %s namespace", namespace)
+	logger.Infof("Attempting to remove %s namespace", namespace)
 	return tryUpdate(func() error {
 		ns, err := client.CoreV1().Namespaces().Get(context.TODO(), namespace, metav1.GetOptions{})
 		if err != nil {
@@ -172,7 +172,7 @@ func removeNamespace(namespace string, client *kubernetes.Clientset) error {
 			ns.Finalizers = []string{}
 		}
 
-		logrus.Infof("Updating namespace: %v", ns.Name)
+		logger.Infof("Updating namespace: %v", ns.Name)
 		if !dryRun {
 			ns, err = client.CoreV1().Namespaces().Update(context.TODO(), ns, metav1.UpdateOptions{})
 			if err != nil {
@@ -180,7 +180,7 @@ func removeNamespace(namespace string, client *kubernetes.Clientset) error {
 			}
 		}
 
-		logrus.Infof("Deleting namespace: %v", ns.Name)
+		logger.Infof("Deleting namespace: %v", ns.Name)
 		if !dryRun {
 			err = client.CoreV1().Namespaces().Delete(context.TODO(), namespace, metav1.DeleteOptions{})
 			if err != nil {
@@ -199,7 +199,7 @@ func removeNamespace(namespace string, client *kubernetes.Clientset) error {
 }
 
 func cleanupNamespaces(client *kubernetes.Clientset) []error {
-	logrus.Info("Starting cleanup of namespaces")
+	logger.Info("Starting cleanup of namespaces")
 	namespaces, err := client.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
 		return []error{err}
@@ -250,7 +250,7 @@ func cleanupNamespaces(client *kubernetes.Clientset) []error {
 			}
 
 			if updated {
-				logrus.Infof("Updating namespace: %v", nameSpace.Name)
+				logger.Infof("Updating namespace: %v", nameSpace.Name)
 				if !dryRun {
 					_, err = client.CoreV1().Namespaces().Update(context.TODO(), nameSpace, metav1.UpdateOptions{})
 					if err != nil {
@@ -272,7 +272,7 @@ func cleanupNamespaces(client *kubernetes.Clientset) []error {
 }
 
 func cleanupClusterRoleBindings(client *kubernetes.Clientset) []error {
-	logrus.Info("Starting cleanup of clusterRoleBindings")
+	logger.Info("Starting cleanup of clusterRoleBindings")
 	crbs, err := client.RbacV1().ClusterRoleBindings().List(context.TODO(), listOptions)
 	if err != nil {
 		return []error{err}
@@ -281,7 +281,7 @@ func cleanupClusterRoleBindings(client *kubernetes.Clientset) []error {
 	var errs []error
 
 	for _, crb := range crbs.Items {
-		logrus.Infof("Deleting clusterRoleBinding: %v", crb.Name)
+		logger.Infof("Deleting clusterRoleBinding: %v", crb.Name)
 		if !dryRun {
 			err = client.RbacV1().ClusterRoleBindings().Delete(context.TODO(), crb.Name, metav1.DeleteOptions{})
 			if err != nil {
@@ -293,7 +293,7 @@ func cleanupClusterRoleBindings(client *kubernetes.Clientset) []error {
 }
 
 func cleanupRoleBindings(client *kubernetes.Clientset) []error {
-	logrus.Info("Starting cleanup of roleBindings")
+	logger.Info("Starting cleanup of roleBindings")
 	rbs, err := client.RbacV1().RoleBindings("").List(context.TODO(), listOptions)
 	if err != nil {
 		return []error{err}
@@ -302,7 +302,7 @@ func cleanupRoleBindings(client *kubernetes.Clientset) []error {
 	var errs []error
 
 	for _, rb := range rbs.Items {
-		logrus.Infof("Deleting roleBinding: %v", rb.Name)
+		logger.Infof("Deleting roleBinding: %v", rb.Name)
 		if !dryRun {
 			err = client.RbacV1().RoleBindings(rb.Namespace).Delete(context.TODO(), rb.Name, metav1.DeleteOptions{})
 			if err != nil {
@@ -314,7 +314,7 @@ func cleanupRoleBindings(client *kubernetes.Clientset) []error {
 }
 
 func cleanupClusterRoles(client *kubernetes.Clientset) []error {
-	logrus.Info("Starting cleanup of clusterRoles")
+	logger.Info("Starting cleanup of clusterRoles")
 	crs, err := client.RbacV1().ClusterRoles().List(context.TODO(), listOptions)
 	if err != nil {
 		return []error{err}
@@ -323,7 +323,7 @@ func cleanupClusterRoles(client *kubernetes.Clientset) []error {
 	var errs []error
 
 	for _, cr := range crs.Items {
-		logrus.Infof("Deleting clusterRole: %v", cr.Name)
+		logger.Infof("Deleting clusterRole: %v", cr.Name)
 		if !dryRun {
 			err = client.RbacV1().ClusterRoles().Delete(context.TODO(), cr.Name, metav1.DeleteOptions{})
 			if err != nil {
@@ -335,7 +335,7 @@ func cleanupClusterRoles(client *kubernetes.Clientset) []error {
 }
 
 func cleanupRoles(client *kubernetes.Clientset) []error {
-	logrus.Info("Starting cleanup of roles")
+	logger.Info("Starting cleanup of roles")
 	rs, err := client.RbacV1().Roles("").List(context.TODO(), listOptions)
 	if err != nil {
 		return []error{err}
@@ -344,7 +344,7 @@ func cleanupRoles(client *kubernetes.Clientset) []error {
 	var errs []error
 
 	for _, r := range rs.Items {
-		logrus.Infof("Deleting role: %v", r.Name)
+		logger.Infof("Deleting role: %v", r.Name)
 		if !dryRun {
 			err = client.RbacV1().Roles(r.Namespace).Delete(context.TODO(), r.Name, metav1.DeleteOptions{})
 			if err != nil {
@@ -356,10 +356,10 @@ func cleanupRoles(client *kubernetes.Clientset) []error {
 }
 
 func cleanupWebhookResources(client *kubernetes.Clientset) []error {
-	logrus.Info("Starting cleanup of webhook-specific resources")
-	logrus.Infof("Deleting clusterrolebinding %s", usercontrollers.WebhookClusterRoleBindingName)
-	logrus.Infof("Deleting mutatingwebhookconfiguration %s", usercontrollers.WebhookConfigurationName)
-	logrus.Infof("Deleting validatingwebhookconfigurations %s", usercontrollers.WebhookConfigurationName)
+	logger.Info("Starting cleanup of webhook-specific resources")
+	logger.Infof("Deleting clusterrolebinding %s", usercontrollers.WebhookClusterRoleBindingName)
+	logger.Infof("Deleting mutatingwebhookconfiguration %s", usercontrollers.WebhookConfigurationName)
+	logger.Infof("Deleting validatingwebhookconfigurations %s", usercontrollers.WebhookConfigurationName)
 
 	var errs []error
 
@@ -383,7 +383,7 @@ func cleanupWebhookResources(client *kubernetes.Clientset) []error {
 }
 
 func deleteJob(client *kubernetes.Clientset) error {
-	logrus.Info("Starting cleanup of jobs")
+	logger.Info("Starting cleanup of jobs")
 	jobs, err := client.BatchV1().Jobs("default").List(context.TODO(), listOptions)
 	if err != nil {
 		return err
@@ -392,7 +392,7 @@ func deleteJob(client *kubernetes.Clientset) error {
 	for _, job := range jobs.Items {
 		prop := metav1.DeletePropagationBackground
 		if strings.HasPrefix(job.Name, "cattle-cleanup") {
-			logrus.Infof("Deleting job: %v", job.Name)
+			logger.Infof("Deleting job: %v", job.Name)
 			if !dryRun {
 				err = client.BatchV1().Jobs("default").Delete(context.TODO(), job.Name, metav1.DeleteOptions{
 					PropagationPolicy: &prop,
@@ -24,12 +24,12 @@
 	"github.com/rancher/rancher/pkg/controllers/management/auth"
 	"github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io"
 	v3 "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/logging"
 	pkgrbac "github.com/rancher/rancher/pkg/rbac"
 	"github.com/rancher/wrangler/pkg/generated/controllers/rbac"
 	v1 "github.com/rancher/wrangler/pkg/generated/controllers/rbac/v1"
 	"github.com/rancher/wrangler/pkg/ratelimit"
 	"github.com/rancher/wrangler/pkg/start"
-	"github.com/sirupsen/logrus"
 	k8srbacv1 "k8s.io/api/rbac/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -37,6 +37,8 @@
 	"k8s.io/client-go/tools/clientcmd"
 )
 
+var logger = logging.Logger(logging.Agent)
+
 const (
 	crtbType              = "crtb"
 	prtbType              = "prtb"
@@ -51,9 +53,9 @@ type dupeBindingsCleanup struct {
 }
 
 func DuplicateBindings(clientConfig *restclient.Config) error {
-	logrus.Infof("[%v] starting bindings cleanup", dupeBindingsOperation)
+	logger.Infof("[%v] starting bindings cleanup", dupeBindingsOperation)
 	if os.Getenv("DRY_RUN") == "true" {
-		logrus.Infof("[%v] DRY_RUN is true, no objects will be deleted/modified", dupeBindingsOperation)
+		logger.Infof("[%v] DRY_RUN is true, no objects will be deleted/modified", dupeBindingsOperation)
 		dryRun = true
 	}
 	var config *restclient.Config
@@ -63,7 +65,7 @@ func DuplicateBindings(clientConfig *restclient.Config) error {
 	} else {
 		config, err = clientcmd.BuildConfigFromFlags("", os.Getenv("KUBECONFIG"))
 		if err != nil {
-			logrus.Errorf("[%v] error in building the cluster config %v", dupeBindingsOperation, err)
+			logger.Errorf("[%v] error in building the cluster config %v", dupeBindingsOperation, err)
 			return err
 		}
 	}
@@ -121,7 +123,7 @@ func (bc *dupeBindingsCleanup) clean() error {
 			rancher25 = true
 		}
 	} else {
-		logrus.Infof("[%v] no clusterRoleTemplateBindings or projectRoleTemplateBindings found, exiting.", dupeBindingsOperation)
+		logger.Infof("[%v] no clusterRoleTemplateBindings or projectRoleTemplateBindings found, exiting.", dupeBindingsOperation)
 		return nil
 	}
 
@@ -130,14 +132,14 @@ func (bc *dupeBindingsCleanup) clean() error {
 	waitGroup.Add(2)
 	go func() {
 		if err := bc.cleanCRTB(rancher25, crtbs.Items); err != nil {
-			logrus.Errorf("[%v] %v", dupeBindingsOperation, err)
+			logger.Errorf("[%v] %v", dupeBindingsOperation, err)
 		}
 		waitGroup.Done()
 	}()
 
 	go func() {
 		if err := bc.cleanPRTB(rancher25, prtbs.Items); err != nil {
-			logrus.Errorf("[%v] %v", dupeBindingsOperation, err)
+			logger.Errorf("[%v] %v", dupeBindingsOperation, err)
 		}
 		waitGroup.Done()
 	}()
@@ -146,7 +148,7 @@ func (bc *dupeBindingsCleanup) clean() error {
 }
 
 func (bc *dupeBindingsCleanup) cleanCRTB(newLabel bool, crtbs []apiv3.ClusterRoleTemplateBinding) error {
-	logrus.Debugf("[%v] cleaning up duplicates for %v CRTBs", dupeBindingsOperation, len(crtbs))
+	logger.Debugf("[%v] cleaning up duplicates for %v CRTBs", dupeBindingsOperation, len(crtbs))
 	var objectMetas []metav1.ObjectMeta
 	for _, crtb := range crtbs {
 		objectMetas = append(objectMetas, crtb.ObjectMeta)
@@ -156,7 +158,7 @@ func (bc *dupeBindingsCleanup) cleanCRTB(newLabel bool, crtbs []apiv3.ClusterRol
 }
 
 func (bc *dupeBindingsCleanup) cleanPRTB(newLabel bool, prtbs []apiv3.ProjectRoleTemplateBinding) error {
-	logrus.Debugf("[%v] cleaning up duplicates for %v PRTBs", dupeBindingsOperation, len(prtbs))
+	logger.Debugf("[%v] cleaning up duplicates for %v PRTBs", dupeBindingsOperation, len(prtbs))
 	var objectMetas []metav1.ObjectMeta
 	for _, prtb := range prtbs {
 		objectMetas = append(objectMetas, prtb.ObjectMeta)
@@ -175,7 +177,7 @@ func (bc *dupeBindingsCleanup) cleanObjectDuplicates(bindingType string, newLabe
 	for _, meta := range objMetas {
 		labels := createLabelSelectors(newLabel, meta, bindingType)
 		for _, label := range labels {
-			logrus.Debugf("[%v] checking CRB/RB duplicates for: %v %v label: %v", dupeBindingsOperation, bindingUpper, meta.Name, label)
+			logger.Debugf("[%v] checking CRB/RB duplicates for: %v %v label: %v", dupeBindingsOperation, bindingUpper, meta.Name, label)
 
 			var CRBduplicates, RBDupes int
 
@@ -206,13 +208,13 @@ func (bc *dupeBindingsCleanup) cleanObjectDuplicates(bindingType string, newLabe
 			if CRBduplicates > 0 || RBDupes > 0 {
 				totalCRBDupes += CRBduplicates
 				totalRoleDupes += RBDupes
-				logrus.Infof("[%v] duplicates: CRB=%v, RB=%v for: %v %v label: %v", dupeBindingsOperation, CRBduplicates, RBDupes, bindingUpper, meta.Name, label)
+				logger.Infof("[%v] duplicates: CRB=%v, RB=%v for: %v %v label: %v", dupeBindingsOperation, CRBduplicates, RBDupes, bindingUpper, meta.Name, label)
 			} else {
-				logrus.Debugf("[%v] no CRB/RB duplicates found for: %v %v label: %v", dupeBindingsOperation, bindingUpper, meta.Name, label)
+				logger.Debugf("[%v] no CRB/RB duplicates found for: %v %v label: %v", dupeBindingsOperation, bindingUpper, meta.Name, label)
 			}
 		}
 	}
-	logrus.Infof("[%v] total %v duplicate clusterRoleBindings %v, roleBindings %v", dupeBindingsOperation, bindingUpper, totalCRBDupes, totalRoleDupes)
+	logger.Infof("[%v] total %v duplicate clusterRoleBindings %v, roleBindings %v", dupeBindingsOperation, bindingUpper, totalCRBDupes, totalRoleDupes)
 	return returnErr
 }
 
@@ -221,9 +223,9 @@ func (bc *dupeBindingsCleanup) dedupeCRB(bindings []k8srbacv1.ClusterRoleBinding
 	deterministicFound, crbName, err := bc.checkIfDeterministicCRBExists(bindings[0])
 	if err != nil {
 		if !k8sErrors.IsNotFound(err) {
-			logrus.Errorf("[%v] error attempting to lookup deterministic CRB: %v", dupeBindingsOperation, err)
+			logger.Errorf("[%v] error attempting to lookup deterministic CRB: %v", dupeBindingsOperation, err)
 		}
-		logrus.Infof("[%v] binding with deterministic name not found, will delete all except the oldest binding", dupeBindingsOperation)
+		logger.Infof("[%v] binding with deterministic name not found, will delete all except the oldest binding", dupeBindingsOperation)
 	}
 
 	duplicates := bindings
@@ -236,15 +238,15 @@ func (bc *dupeBindingsCleanup) dedupeCRB(bindings []k8srbacv1.ClusterRoleBinding
 
 	for _, binding := range duplicates {
 		if deterministicFound && strings.EqualFold(binding.Name, crbName) {
-			logrus.Infof("[%v] found the CRB with the deterministic name %v, will not delete this", dupeBindingsOperation, binding.Name)
+			logger.Infof("[%v] found the CRB with the deterministic name %v, will not delete this", dupeBindingsOperation, binding.Name)
 			continue
 		}
 		if !dryRun {
 			if err := bc.clusterRoleBindings.Delete(binding.Name, &metav1.DeleteOptions{}); err != nil {
-				logrus.Errorf("[%v] error attempting to delete CRB %v %v", dupeBindingsOperation, binding.Name, err)
+				logger.Errorf("[%v] error attempting to delete CRB %v %v", dupeBindingsOperation, binding.Name, err)
 			}
 		} else {
-			logrus.Infof("[%v] dryRun enabled, clusterRoleBinding %v would be deleted", dupeBindingsOperation, binding.Name)
+			logger.Infof("[%v] dryRun enabled, clusterRoleBinding %v would be deleted", dupeBindingsOperation, binding.Name)
 		}
 	}
 	return nil
@@ -267,9 +269,9 @@ func (bc *dupeBindingsCleanup) dedupeRB(roleBindings []k8srbacv1.RoleBinding) (i
 		deterministicFound, rbName, err := bc.checkIfDeterministicRBExists(bindings[0])
 		if err != nil {
 			if !k8sErrors.IsNotFound(err) {
-				logrus.Errorf("[%v] error attempting to lookup deterministic RB: %v", dupeBindingsOperation, err)
+				logger.Errorf("[%v] error attempting to lookup deterministic RB: %v", dupeBindingsOperation, err)
 			}
-			logrus.Infof("[%v] binding with deterministic name not found, will delete all except the oldest binding", dupeBindingsOperation)
+			logger.Infof("[%v] binding with deterministic name not found, will delete all except the oldest binding", dupeBindingsOperation)
 		}
 		duplicates := bindings
 		if !deterministicFound {
@@ -280,16 +282,16 @@ func (bc *dupeBindingsCleanup) dedupeRB(roleBindings []k8srbacv1.RoleBinding) (i
 		}
 		for _, binding := range duplicates {
 			if deterministicFound && strings.EqualFold(binding.Name, rbName) {
-				logrus.Infof("[%v] found the RB with the deterministic name %v in namespace %v, will not delete this", dupeBindingsOperation, binding.Name, binding.Namespace)
+				logger.Infof("[%v] found the RB with the deterministic name %v in namespace %v, will not delete this", dupeBindingsOperation, binding.Name, binding.Namespace)
 				continue
 			}
 			duplicatesFound++
 			if !dryRun {
 				if err := bc.roleBindings.Delete(binding.Namespace, binding.Name, &metav1.DeleteOptions{}); err != nil {
-					logrus.Errorf("[%v] error attempting to delete RB %v %v", dupeBindingsOperation, binding.Name, err)
+					logger.Errorf("[%v] error attempting to delete RB %v %v", dupeBindingsOperation, binding.Name, err)
 				}
 			} else {
-				logrus.Infof("[%v] dryRun enabled, roleBinding %v in namespace %v would be deleted", dupeBindingsOperation, binding.Name, binding.Namespace)
+				logger.Infof("[%v] dryRun enabled, roleBinding %v in namespace %v would be deleted", dupeBindingsOperation, binding.Name, binding.Namespace)
 			}
 		}
 	}
@@ -329,7 +331,7 @@ func getDeterministicBindingName(object interface{}) (string, error) {
 		}
 		subject := crb.Subjects[0]
 		crbName := pkgrbac.NameForClusterRoleBinding(crb.RoleRef, subject)
-		logrus.Debugf("[%v] deterministic crb name for %v is %v", dupeBindingsOperation, crb.Name, crbName)
+		logger.Debugf("[%v] deterministic crb name for %v is %v", dupeBindingsOperation, crb.Name, crbName)
 		return crbName, nil
 	} else if rb, ok := object.(k8srbacv1.RoleBinding); ok {
 		if len(crb.Subjects) > 1 {
@@ -337,7 +339,7 @@ func getDeterministicBindingName(object interface{}) (string, error) {
 		}
 		subject := rb.Subjects[0]
 		rbName := pkgrbac.NameForRoleBinding(rb.Namespace, rb.RoleRef, subject)
-		logrus.Debugf("[%v] deterministic rb name for %v in ns %v is %v", dupeBindingsOperation, rb.Name, rb.Namespace, rbName)
+		logger.Debugf("[%v] deterministic rb name for %v in ns %v is %v", dupeBindingsOperation, rb.Name, rb.Namespace, rbName)
 		return rbName, nil
 	}
 	return "", nil
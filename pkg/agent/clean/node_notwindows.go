@@ -18,8 +18,8 @@
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/client"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rke/hosts"
-	"github.com/sirupsen/logrus"
 	"github.com/vishvananda/netlink"
 )
 
@@ -65,7 +65,7 @@ func Run(ctx context.Context, args []string) error {
 }
 
 func job(ctx context.Context) error {
-	logrus.Infof("Starting clean container job: %s", NodeCleanupContainerName)
+	logger.Infof("Starting clean container job: %s", NodeCleanupContainerName)
 
 	c, err := client.NewClientWithOpts(client.WithAPIVersionNegotiation(), client.FromEnv)
 	if err != nil {
@@ -81,7 +81,7 @@ func job(ctx context.Context) error {
 	for _, c := range containerList {
 		for _, n := range c.Names {
 			if n == "/"+NodeCleanupContainerName {
-				logrus.Infof("container named %s already exists, exiting.", NodeCleanupContainerName)
+				logger.Infof("container named %s already exists, exiting.", NodeCleanupContainerName)
 				return nil
 			}
 		}
@@ -115,7 +115,7 @@ func job(ctx context.Context) error {
 }
 
 func Node(ctx context.Context) error {
-	logrus.Info("Cleaning up node...")
+	logger.Info("Cleaning up node...")
 
 	c, err := client.NewClientWithOpts(client.WithAPIVersionNegotiation(), client.FromEnv)
 	if err != nil {
@@ -154,29 +154,29 @@ func Node(ctx context.Context) error {
 
 func links() error {
 	for _, l := range []string{"flannel.1", "cni0", "tunl0", "weave", "datapath", "vxlan-6784"} {
-		logrus.Infof("checking for link %s", l)
+		logger.Infof("checking for link %s", l)
 		existing, err := netlink.LinkByName(l)
 		if err != nil {
 			if err.Error() == "Link not found" {
-				logrus.Infof("link %s not found", l)
+				logger.Infof("link %s not found", l)
 				continue // not found, nothing to do
 			}
 
 			return err
 		}
 
-		logrus.Infof("found link and will remove: %s", l)
+		logger.Infof("found link and will remove: %s", l)
 		if err := netlink.LinkDel(existing); err != nil {
 			return fmt.Errorf("failed to delete interface: %v", err)
 		}
-		logrus.Infof("link deleted: %s", l)
+		logger.Infof("link deleted: %s", l)
 	}
 
 	return nil
 }
 
 func paths() error {
-	logrus.Info("Cleaning up paths...")
+	logger.Info("Cleaning up paths...")
 
 	if err := umountTmpfs(); err != nil {
 		return err
@@ -185,11 +185,11 @@ func paths() error {
 	paths := getPaths()
 	for _, p := range paths {
 		hostPath := filepath.Join("/host", p)
-		logrus.Infof("trying to delete path: %s", hostPath)
+		logger.Infof("trying to delete path: %s", hostPath)
 		_, err := os.Stat(hostPath)
 		if err != nil {
 			if os.IsNotExist(err) {
-				logrus.Infof("path does not exist: %s", hostPath)
+				logger.Infof("path does not exist: %s", hostPath)
 				continue
 			}
 			return err
@@ -218,12 +218,12 @@ func umountTmpfs() error {
 			continue
 		}
 
-		logrus.Infof("trying to umount tmpfs: %s", t)
+		logger.Infof("trying to umount tmpfs: %s", t)
 		_, err := exec.Command("sh", "-c", fmt.Sprintf("umount %s", t)).Output()
 		if err != nil {
 			return fmt.Errorf("error trying to umount tmpfs %s: %s", t, err)
 		}
-		logrus.Infof("umount of tmpfs %s successful", t)
+		logger.Infof("umount of tmpfs %s successful", t)
 	}
 
 	return nil
@@ -295,7 +295,7 @@ func containers(ctx context.Context, c *client.Client) error {
 func waitForK8sPods(ctx context.Context, c *client.Client) error {
 	// wait for up to 5min for k8s pods to be dropped
 	for i := 0; i < 30; i++ {
-		logrus.Infof("checking for pods %d out of 30 times", i)
+		logger.Infof("checking for pods %d out of 30 times", i)
 		containerList, err := c.ContainerList(ctx, types.ContainerListOptions{})
 		if err != nil {
 			return err
@@ -315,12 +315,12 @@ func waitForK8sPods(ctx context.Context, c *client.Client) error {
 		}
 
 		if hasPods {
-			logrus.Info("pods found, waiting 10s and trying again")
+			logger.Info("pods found, waiting 10s and trying again")
 			time.Sleep(10 * time.Second)
 			continue
 		}
 
-		logrus.Info("all pods cleaned, continuing on to more rke cleanup")
+		logger.Info("all pods cleaned, continuing on to more rke cleanup")
 		return nil
 	}
 
@@ -345,7 +345,7 @@ func firewall() error {
 	}
 
 	for _, table := range []string{"nat", "mangle"} {
-		logrus.Infof("clearing and deleting iptables table: %s", table)
+		logger.Infof("clearing and deleting iptables table: %s", table)
 		if err := ipt.ClearAndDeleteChain(table, ""); err != nil {
 			return err
 		}
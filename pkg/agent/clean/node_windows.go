@@ -14,7 +14,7 @@
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/client"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 )
 
 const (
@@ -52,7 +52,7 @@ func Run(ctx context.Context, args []string) error {
 }
 
 func job(ctx context.Context) error {
-	logrus.Infof("Starting clean container job: %s", NodeCleanupContainerName)
+	logger.Infof("Starting clean container job: %s", NodeCleanupContainerName)
 
 	c, err := client.NewClientWithOpts(client.WithAPIVersionNegotiation(), client.FromEnv)
 	if err != nil {
@@ -68,7 +68,7 @@ func job(ctx context.Context) error {
 	for _, c := range containerList {
 		for _, n := range c.Names {
 			if n == "/"+NodeCleanupContainerName {
-				logrus.Infof("container named %s already exists, exiting.", NodeCleanupContainerName)
+				logger.Infof("container named %s already exists, exiting.", NodeCleanupContainerName)
 				return nil
 			}
 		}
@@ -100,7 +100,7 @@ func job(ctx context.Context) error {
 }
 
 func node(ctx context.Context) error {
-	logrus.Info("Cleaning up node...")
+	logger.Info("Cleaning up node...")
 
 	c, err := client.NewClientWithOpts(client.WithAPIVersionNegotiation(), client.FromEnv)
 	if err != nil {
@@ -122,7 +122,7 @@ func spawnCleanup() error {
 	winsArgs := createWinsArgs("Spawn")
 	output, err := exec.Command("wins.exe", winsArgs...).Output()
 	if err != nil {
-		logrus.Infof(string(output))
+		logger.Infof(string(output))
 		return err
 	}
 	return nil
@@ -150,19 +150,19 @@ func writeScript() error {
 			return err
 		}
 	} else {
-		logrus.Infof("powershell.exe already exists: %s", psPath)
+		logger.Infof("powershell.exe already exists: %s", psPath)
 	}
 
 	// write one to the host for wins cli to call
 	scriptBytes := []byte(script())
 	hostScriptPath := strings.Replace(getScriptPath(), "c:\\", HostMount, 1)
 	if !fileExists(hostScriptPath) {
-		logrus.Infof("writing file to host: %s", hostScriptPath)
+		logger.Infof("writing file to host: %s", hostScriptPath)
 		if err := ioutil.WriteFile(hostScriptPath, scriptBytes, 0777); err != nil {
 			return fmt.Errorf("error writing the cleanup script to the host: %s", err)
 		}
 	} else {
-		logrus.Infof("cleanup script already exists on host: %s", hostScriptPath)
+		logger.Infof("cleanup script already exists on host: %s", hostScriptPath)
 	}
 
 	return nil
@@ -188,7 +188,7 @@ func getAgentImage() string {
 func waitForK8sPods(ctx context.Context, c *client.Client) error {
 	// wait for up to 5min for k8s pods to be dropped
 	for i := 0; i < 30; i++ {
-		logrus.Infof("checking for pods %d out of 30 times", i)
+		logger.Infof("checking for pods %d out of 30 times", i)
 		containerList, err := c.ContainerList(ctx, types.ContainerListOptions{})
 		if err != nil {
 			return err
@@ -208,12 +208,12 @@ func waitForK8sPods(ctx context.Context, c *client.Client) error {
 		}
 
 		if hasPods {
-			logrus.Info("pods found, waiting 10s and trying again")
+			logger.Info("pods found, waiting 10s and trying again")
 			time.Sleep(10 * time.Second)
 			continue
 		}
 
-		logrus.Info("all pods cleaned, continuing on to more rke cleanup")
+		logger.Info("all pods cleaned, continuing on to more rke cleanup")
 		return nil
 	}
 
@@ -227,7 +227,7 @@ func createWinsArgs(tasks ...string) []string {
 	}
 
 	path := getPowershellPath()
-	logrus.Infof("path: %s, args: %s", path, args)
+	logger.Infof("path: %s, args: %s", path, args)
 
 	return []string{
 		"cli", "prc", "run",
@@ -18,6 +18,7 @@
 	mgmt "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io"
 	v3 "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
 	rbacv1 "github.com/rancher/rancher/pkg/generated/norman/rbac.authorization.k8s.io/v1"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/namespace"
 	"github.com/rancher/wrangler/pkg/generated/controllers/core"
 	corev1 "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
@@ -25,7 +26,6 @@
 	v1 "github.com/rancher/wrangler/pkg/generated/controllers/rbac/v1"
 	"github.com/rancher/wrangler/pkg/ratelimit"
 	"github.com/rancher/wrangler/pkg/start"
-	"github.com/sirupsen/logrus"
 	k8srbacv1 "k8s.io/api/rbac/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -53,7 +53,7 @@ func OrphanBindings(clientConfig *restclient.Config) error {
 		return err
 	}
 
-	logrus.Infof("[%v] cleaning up orphaned bindings", orphanBindingsOperation)
+	logger.Infof("[%v] cleaning up orphaned bindings", orphanBindingsOperation)
 	return bc.cleanOrphans()
 }
 
@@ -62,13 +62,13 @@ func OrphanCatalogBindings(clientConfig *restclient.Config) error {
 	if err != nil {
 		return err
 	}
-	logrus.Infof("[%v] cleaning up orphaned catalog bindings", orphanCatalogBindingsOperation)
+	logger.Infof("[%v] cleaning up orphaned catalog bindings", orphanCatalogBindingsOperation)
 	return bc.cleanOrphanedCatalogRolesAndRolebindings()
 }
 
 func newOrphanBindingsCleanup(clientConfig *restclient.Config) (*orphanBindingsCleanup, error) {
 	if os.Getenv("DRY_RUN") == "true" {
-		logrus.Infof("[%v] DRY_RUN is true, no objects will be deleted/modified", orphanBindingsOperation)
+		logger.Infof("[%v] DRY_RUN is true, no objects will be deleted/modified", orphanBindingsOperation)
 		dryRun = true
 	}
 
@@ -79,7 +79,7 @@ func newOrphanBindingsCleanup(clientConfig *restclient.Config) (*orphanBindingsC
 	} else {
 		config, err = clientcmd.BuildConfigFromFlags("", os.Getenv("KUBECONFIG"))
 		if err != nil {
-			logrus.Errorf("[%v] Error in building the cluster config %v", orphanBindingsOperation, err)
+			logger.Errorf("[%v] Error in building the cluster config %v", orphanBindingsOperation, err)
 			return nil, err
 		}
 	}
@@ -117,7 +117,7 @@ func newOrphanBindingsCleanup(clientConfig *restclient.Config) (*orphanBindingsC
 
 // cleanOrphans finds and deletes orphaned bindings
 func (bc *orphanBindingsCleanup) cleanOrphans() error {
-	logrus.Infof("[%v] checking for orphaned rolebindings", orphanBindingsOperation)
+	logger.Infof("[%v] checking for orphaned rolebindings", orphanBindingsOperation)
 
 	// build the prtb uid map for checking existence of rb owner in legacy label case
 	prtbs, err := bc.prtbs.List("", metav1.ListOptions{})
@@ -141,12 +141,12 @@ func (bc *orphanBindingsCleanup) cleanOrphans() error {
 			returnErr = multierror.Append(returnErr, err)
 		}
 		if isOrphan {
-			logrus.Infof("[%v] found orphaned binding: %s/%s", orphanBindingsOperation, rb.Namespace, rb.Name)
+			logger.Infof("[%v] found orphaned binding: %s/%s", orphanBindingsOperation, rb.Namespace, rb.Name)
 			if dryRun {
-				logrus.Infof("[%v] dryRun is enabled, skipping deletion for orphaned binding: %s/%s", orphanBindingsOperation, rb.Namespace, rb.Name)
+				logger.Infof("[%v] dryRun is enabled, skipping deletion for orphaned binding: %s/%s", orphanBindingsOperation, rb.Namespace, rb.Name)
 				continue
 			}
-			logrus.Infof("[%v] deleting orphaned binding: %s/%s", orphanBindingsOperation, rb.Namespace, rb.Name)
+			logger.Infof("[%v] deleting orphaned binding: %s/%s", orphanBindingsOperation, rb.Namespace, rb.Name)
 			err := bc.roleBindings.Delete(rb.Namespace, rb.Name, &metav1.DeleteOptions{})
 			if err != nil && !k8serrors.IsNotFound(err) {
 				returnErr = multierror.Append(returnErr, err)
@@ -217,30 +217,30 @@ func (bc *orphanBindingsCleanup) cleanOrphanedCatalogRolesAndRolebindings() erro
 	if err != nil {
 		return err
 	}
-	logrus.Infof("[%v] Processing %d rolebindings", orphanCatalogBindingsOperation, len(rbs.Items))
+	logger.Infof("[%v] Processing %d rolebindings", orphanCatalogBindingsOperation, len(rbs.Items))
 	for _, rb := range rbs.Items {
 		if rb.RoleRef.Name != auth.GlobalCatalogRole {
 			continue
 		}
 
 		if dryRun {
-			logrus.Infof("[%v] dryRun is enabled, skipping deletion for orphaned binding: %s/%s", orphanCatalogBindingsOperation, rb.Namespace, rb.Name)
+			logger.Infof("[%v] dryRun is enabled, skipping deletion for orphaned binding: %s/%s", orphanCatalogBindingsOperation, rb.Namespace, rb.Name)
 			continue
 		}
-		logrus.Infof("[%v] Deleting orphaned binding %s", orphanCatalogBindingsOperation, rb.Name)
+		logger.Infof("[%v] Deleting orphaned binding %s", orphanCatalogBindingsOperation, rb.Name)
 		err = bc.roleBindings.Delete(namespace.GlobalNamespace, rb.Name, &metav1.DeleteOptions{})
 		if err != nil {
-			logrus.Warnf("[%v] Error when deleting rolebinding %s, %s", orphanCatalogBindingsOperation, rb.Name, err.Error())
+			logger.Warnf("[%v] Error when deleting rolebinding %s, %s", orphanCatalogBindingsOperation, rb.Name, err.Error())
 		}
 	}
 
 	if dryRun {
-		logrus.Infof("[%v] dryRun is enabled, skipping deletion for orphaned role: %s/%s", orphanCatalogBindingsOperation, namespace.GlobalNamespace, auth.GlobalCatalogRole)
+		logger.Infof("[%v] dryRun is enabled, skipping deletion for orphaned role: %s/%s", orphanCatalogBindingsOperation, namespace.GlobalNamespace, auth.GlobalCatalogRole)
 	} else {
-		logrus.Infof("[%v] Deleting orphaned role %s", orphanCatalogBindingsOperation, auth.GlobalCatalogRole)
+		logger.Infof("[%v] Deleting orphaned role %s", orphanCatalogBindingsOperation, auth.GlobalCatalogRole)
 		err = bc.roles.Delete(namespace.GlobalNamespace, auth.GlobalCatalogRole, &metav1.DeleteOptions{})
 		if err != nil {
-			logrus.Warnf("[%v] Error when deleting role %s, %s", orphanCatalogBindingsOperation, auth.GlobalCatalogRole, err.Error())
+			logger.Warnf("[%v] Error when deleting role %s, %s", orphanCatalogBindingsOperation, auth.GlobalCatalogRole, err.Error())
 			return err
 		}
 	}
@@ -5,6 +5,7 @@
 	"encoding/base64"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"os"
 	"path"
 	"strings"
@@ -98,7 +99,7 @@ func Params() (map[string]interface{}, error) {
 
 	return map[string]interface{}{
 		"cluster": map[string]interface{}{
-			"address": fmt.Sprintf("%s:%s", kubernetesServiceHost, kubernetesServicePort),
+			"address": net.JoinHostPort(kubernetesServiceHost, kubernetesServicePort),
 			"token":   strings.TrimSpace(string(token)),
 			"caCert":  base64.StdEncoding.EncodeToString(caData),
 		},
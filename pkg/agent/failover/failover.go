@@ -0,0 +1,135 @@
+// Package failover picks the rancher endpoint agents connect to out of the server URL and the additional server URLs
+// rancher advertises, such as the URLs of other regions or load balancers. Agents fail over to the next endpoint in
+// order when the one they're connected to is unreachable, and switch back to a preferred endpoint once it is healthy.
+package failover
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AdditionalServersEnv is the environment variable holding the comma separated additional server URLs of agents.
+const AdditionalServersEnv = "CATTLE_ADDITIONAL_SERVERS"
+
+// Endpoints are the ordered endpoints of rancher, the first being preferred.
+type Endpoints struct {
+	lock    sync.Mutex
+	urls    []string
+	current int
+}
+
+// ParseURLs returns the URLs of a comma separated list, without their trailing slash.
+func ParseURLs(urls string) []string {
+	var result []string
+	for _, u := range strings.Split(urls, ",") {
+		u = strings.TrimSuffix(strings.TrimSpace(u), "/")
+		if u != "" {
+			result = append(result, u)
+		}
+	}
+	return result
+}
+
+// New returns the endpoints of the server URL followed by the additional URLs, duplicates removed.
+func New(server string, additional []string) *Endpoints {
+	e := &Endpoints{}
+	seen := map[string]bool{}
+	for _, u := range append([]string{server}, additional...) {
+		u = strings.TrimSuffix(u, "/")
+		if u == "" || seen[u] {
+			continue
+		}
+		seen[u] = true
+		e.urls = append(e.urls, u)
+	}
+	return e
+}
+
+// Current returns the URL of the endpoint to connect to.
+func (e *Endpoints) Current() string {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if len(e.urls) == 0 {
+		return ""
+	}
+	return e.urls[e.current]
+}
+
+// Preferred returns whether the current endpoint is the most preferred one.
+func (e *Endpoints) Preferred() bool {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.current == 0
+}
+
+// Failover moves to the next endpoint, wrapping around to the first after the last, and returns its URL.
+func (e *Endpoints) Failover() string {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if len(e.urls) == 0 {
+		return ""
+	}
+	e.current = (e.current + 1) % len(e.urls)
+	return e.urls[e.current]
+}
+
+// SwitchToPreferred moves to the first endpoint preferred over the current one that is healthy, and returns its URL.
+// It returns false if there is none.
+func (e *Endpoints) SwitchToPreferred(healthy func(url string) bool) (string, bool) {
+	e.lock.Lock()
+	preferred := append([]string(nil), e.urls[:e.current]...)
+	e.lock.Unlock()
+
+	// Health checks are made unlocked, as they can take up to their timeout.
+	for i, u := range preferred {
+		if !healthy(u) {
+			continue
+		}
+		e.lock.Lock()
+		defer e.lock.Unlock()
+		if i >= e.current {
+			// The endpoint was switched meanwhile.
+			return "", false
+		}
+		e.current = i
+		return u, true
+	}
+	return "", false
+}
+
+// Healthy returns whether rancher answers pong at the ping endpoint of url.
+func Healthy(ctx context.Context, client *http.Client, url string) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+"/ping", nil)
+	if err != nil {
+		return false
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64))
+	return resp.StatusCode == http.StatusOK && string(body) == "pong"
+}
+
+// WatchPreferred checks the endpoints preferred over the current one every interval, until the context is done. Once
+// one is healthy, it switches to it and calls switched, which is expected to reconnect agents to it.
+func (e *Endpoints) WatchPreferred(ctx context.Context, client *http.Client, interval time.Duration, switched func(url string)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if u, ok := e.SwitchToPreferred(func(u string) bool { return Healthy(ctx, client, u) }); ok {
+				switched(u)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package failover
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseURLs(t *testing.T) {
+	assert.Equal(t, []string{"https://dr.example.com", "https://lb.example.com"}, ParseURLs(" https://dr.example.com/, ,https://lb.example.com"))
+	assert.Empty(t, ParseURLs(""))
+}
+
+func TestFailover(t *testing.T) {
+	e := New("https://rancher.example.com/", []string{"https://dr.example.com", "https://rancher.example.com"})
+	assert.Equal(t, "https://rancher.example.com", e.Current())
+	assert.True(t, e.Preferred())
+
+	assert.Equal(t, "https://dr.example.com", e.Failover())
+	assert.Equal(t, "https://dr.example.com", e.Current())
+	assert.False(t, e.Preferred())
+
+	assert.Equal(t, "https://rancher.example.com", e.Failover(), "failover wraps around to the first endpoint")
+
+	single := New("https://rancher.example.com", nil)
+	assert.Equal(t, "https://rancher.example.com", single.Failover())
+}
+
+func TestSwitchToPreferred(t *testing.T) {
+	e := New("https://a", []string{"https://b", "https://c"})
+	_, ok := e.SwitchToPreferred(func(string) bool { return true })
+	assert.False(t, ok, "the preferred endpoint has nothing to switch to")
+
+	e.Failover()
+	e.Failover()
+	var checked []string
+	_, ok = e.SwitchToPreferred(func(u string) bool {
+		checked = append(checked, u)
+		return false
+	})
+	assert.False(t, ok)
+	assert.Equal(t, []string{"https://a", "https://b"}, checked)
+	assert.Equal(t, "https://c", e.Current())
+
+	u, ok := e.SwitchToPreferred(func(u string) bool { return u == "https://b" })
+	assert.True(t, ok)
+	assert.Equal(t, "https://b", u)
+	assert.Equal(t, "https://b", e.Current())
+}
+
+func TestHealthy(t *testing.T) {
+	healthy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/ping" {
+			_, _ = rw.Write([]byte("pong"))
+			return
+		}
+		rw.WriteHeader(http.StatusNotFound)
+	}))
+	defer healthy.Close()
+	unhealthy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unhealthy.Close()
+
+	assert.True(t, Healthy(context.Background(), healthy.Client(), healthy.URL))
+	assert.False(t, Healthy(context.Background(), unhealthy.Client(), unhealthy.URL))
+	assert.False(t, Healthy(context.Background(), http.DefaultClient, "http://127.0.0.1:1"))
+}
@@ -7,9 +7,11 @@
 
 	"github.com/docker/docker/client"
 	"github.com/rancher/norman/types/slice"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 )
 
+var logger = logging.Logger(logging.Agent)
+
 func TokenAndURL() (string, string, error) {
 	return os.Getenv("CATTLE_TOKEN"), os.Getenv("CATTLE_SERVER"), nil
 }
@@ -34,12 +36,12 @@ func Params() map[string]interface{} {
 
 	dclient, err := client.NewClientWithOpts(client.WithAPIVersionNegotiation(), client.FromEnv)
 	if err != nil {
-		logrus.Errorf("Error getting docker client: %v", err)
+		logger.Errorf("Error getting docker client: %v", err)
 	} else {
 		defer dclient.Close()
 		info, err := dclient.Info(context.Background())
 		if err != nil {
-			logrus.Errorf("Error getting docker info: %v", err)
+			logger.Errorf("Error getting docker info: %v", err)
 		} else {
 			params["dockerInfo"] = info
 		}
@@ -48,10 +50,10 @@ func Params() map[string]interface{} {
 	for k, v := range params {
 		if m, ok := v.(map[string]string); ok {
 			for k, v := range m {
-				logrus.Infof("Option %s=%s", k, v)
+				logger.Infof("Option %s=%s", k, v)
 			}
 		} else {
-			logrus.Infof("Option %s=%v", k, v)
+			logger.Infof("Option %s=%v", k, v)
 		}
 	}
 
@@ -73,7 +75,7 @@ func parseLabel(v string) map[string]string {
 		} else if len(kvs) == 1 {
 			labels[kvs[0]] = ""
 		} else {
-			logrus.Warnf("Invalid label format %v.", part)
+			logger.Warnf("Invalid label format %v.", part)
 		}
 	}
 	return labels
@@ -8,19 +8,21 @@
 
 	"github.com/rancher/rancher/pkg/agent/cluster"
 	"github.com/rancher/rancher/pkg/features"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/namespace"
 	"github.com/rancher/rancher/pkg/rancher"
 	"github.com/rancher/wrangler/pkg/apply"
 	corefactory "github.com/rancher/wrangler/pkg/generated/controllers/core"
 	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
 	"github.com/rancher/wrangler/pkg/kubeconfig"
-	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	apierror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
 )
 
+var logger = logging.Logger(logging.Agent)
+
 var (
 	started bool
 )
@@ -78,11 +80,11 @@ func (h *handler) startRancher() {
 		ClusterRegistry: os.Getenv("CATTLE_CLUSTER_REGISTRY"),
 	})
 	if err != nil {
-		logrus.Fatalf("Embedded rancher failed to initialize: %v", err)
+		logger.Fatalf("Embedded rancher failed to initialize: %v", err)
 	}
 	go func() {
 		err = server.ListenAndServe(h.ctx)
-		logrus.Fatalf("Embedded rancher failed to start: %v", err)
+		logger.Fatalf("Embedded rancher failed to start: %v", err)
 	}()
 }
 
@@ -103,11 +105,11 @@ func (h *handler) OnChange(key string, service *corev1.Service) (*corev1.Service
 
 	if service == nil {
 		if key == namespace.System+"/rancher" {
-			logrus.Info("Rancher has been uninstalled, restarting")
+			logger.Info("Rancher has been uninstalled, restarting")
 			os.Exit(0)
 		}
 	} else if service.Namespace == namespace.System && service.Name == "rancher" && *h.rancherNotFound {
-		logrus.Info("Rancher has been installed, restarting")
+		logger.Info("Rancher has been installed, restarting")
 		os.Exit(0)
 	}
 
@@ -169,6 +169,12 @@ func (h *handler) checkCredentials(req *http.Request) (int, error) {
 		return http.StatusBadRequest, fmt.Errorf("cannot parse request body: %v", err)
 	}
 
+	return ValidateCredentials(cred)
+}
+
+// ValidateCredentials checks that Azure credentials can access their subscription. It returns the HTTP status to
+// respond with along with the error.
+func ValidateCredentials(cred *Capabilities) (int, error) {
 	if cred.SubscriptionID == "" {
 		return http.StatusBadRequest, fmt.Errorf("must provide subscriptionId")
 	}
@@ -190,6 +196,7 @@ func (h *handler) checkCredentials(req *http.Request) (int, error) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
+	var err error
 	if cred.TenantID == "" {
 		cred.TenantID, err = azureutil.FindTenantID(ctx, azureEnvironment, cred.SubscriptionID)
 		if err != nil {
@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
@@ -14,6 +15,7 @@
 	mgmtv3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
 	"github.com/rancher/rancher/pkg/kubeconfig"
 	"github.com/rancher/rancher/pkg/namespace"
+	"github.com/rancher/rancher/pkg/securityevents"
 	"github.com/rancher/rancher/pkg/settings"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -81,6 +83,12 @@ func (a ActionHandler) GenerateKubeconfigActionHandler(actionName string, action
 		}
 	}
 
+	event := securityevents.NewEvent(securityevents.KubeconfigDownload, securityevents.OutcomeSuccess, apiContext.Request)
+	event.User = a.UserMgr.GetUser(apiContext)
+	event.Resource = "clusters/" + cluster.ID
+	event.Details = map[string]string{"tokenGenerated": fmt.Sprint(generateToken)}
+	securityevents.Emit(event)
+
 	data := map[string]interface{}{
 		"config": cfg,
 		"type":   "generateKubeconfigOutput",
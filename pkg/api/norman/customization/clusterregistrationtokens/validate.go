@@ -0,0 +1,179 @@
+package clusterregistrationtokens
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rancher/norman/types"
+	"github.com/rancher/norman/urlbuilder"
+	apimgmtv3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	schema "github.com/rancher/rancher/pkg/schemas/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/systemtemplate"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// maxValidations is the number of nodes the latest validation results are kept for in a token.
+	maxValidations   = 10
+	maxNodeName      = 253
+	maxChecks        = 20
+	maxMessageLength = 1024
+	maxReportSize    = 64 * 1024
+)
+
+var (
+	// rke2Ports are the ports the nodes of RKE2 and K3s clusters listen on.
+	rke2Ports = []int{2379, 2380, 6443, 9345, 10250}
+	// rkePorts are the ports the nodes of RKE clusters listen on.
+	rkePorts = []int{2379, 2380, 6443, 10250}
+)
+
+// ClusterValidate serves the script validating a node can be registered with a cluster registration token, and
+// receives the results of the script.
+type ClusterValidate struct {
+	Clusters                  v3.ClusterInterface
+	ClusterRegistrationTokens v3.ClusterRegistrationTokenInterface
+}
+
+type validationReport struct {
+	NodeName string                               `json:"nodeName"`
+	Checks   []apimgmtv3.ClusterRegistrationCheck `json:"checks"`
+}
+
+// ValidateScriptHandler serves the script validating a node can be registered with the token.
+func (cv *ClusterValidate) ValidateScriptHandler(resp http.ResponseWriter, req *http.Request) {
+	resp.Header().Set("Content-Type", "text/plain")
+	token := mux.Vars(req)["token"]
+	clusterID := mux.Vars(req)["clusterId"]
+
+	if _, err := cv.findToken(clusterID, token); err != nil {
+		http.Error(resp, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	urlBuilder, err := urlbuilder.New(req, schema.Version, types.NewSchemas())
+	if err != nil {
+		http.Error(resp, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	url := settings.ServerURL.Get()
+	if url == "" {
+		url = urlBuilder.RelativeToRoot("")
+	}
+
+	var ports []int
+	if cluster, err := cv.Clusters.Get(clusterID, metav1.GetOptions{}); err == nil {
+		if cluster.Annotations["objectset.rio.cattle.io/owner-gvk"] == "provisioning.cattle.io/v1, Kind=Cluster" {
+			ports = rke2Ports
+		} else if cluster.Status.Driver == apimgmtv3.ClusterDriverRKE {
+			ports = rkePorts
+		}
+	}
+
+	reportURL := fmt.Sprintf("%s/v3/import/%s_%s/validate", url, token, clusterID)
+	if err := systemtemplate.ValidateScript(resp, url, reportURL, ports); err != nil {
+		http.Error(resp, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// ValidateReportHandler records the results of the validate script of a node in the status of the token.
+func (cv *ClusterValidate) ValidateReportHandler(resp http.ResponseWriter, req *http.Request) {
+	token := mux.Vars(req)["token"]
+	clusterID := mux.Vars(req)["clusterId"]
+
+	crt, err := cv.findToken(clusterID, token)
+	if err != nil {
+		http.Error(resp, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	var report validationReport
+	if err := json.NewDecoder(io.LimitReader(req.Body, maxReportSize)).Decode(&report); err != nil {
+		http.Error(resp, fmt.Sprintf("invalid validation report: %v", err), http.StatusBadRequest)
+		return
+	}
+	validation, err := toValidation(report, time.Now())
+	if err != nil {
+		http.Error(resp, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		crt, err := cv.ClusterRegistrationTokens.GetNamespaced(crt.Namespace, crt.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		crt = crt.DeepCopy()
+		crt.Status.Validations = addValidation(crt.Status.Validations, validation)
+		_, err = cv.ClusterRegistrationTokens.Update(crt)
+		return err
+	})
+	if err != nil {
+		logrus.Errorf("[clusterregistrationtokens] failed to record validation of node [%s] in cluster [%s]: %v", validation.NodeName, clusterID, err)
+		http.Error(resp, "failed to record validation", http.StatusInternalServerError)
+		return
+	}
+	resp.WriteHeader(http.StatusNoContent)
+}
+
+// findToken returns the registration token of the cluster with the token value.
+func (cv *ClusterValidate) findToken(clusterID, token string) (*apimgmtv3.ClusterRegistrationToken, error) {
+	crts, err := cv.ClusterRegistrationTokens.Controller().Lister().List(clusterID, labels.Everything())
+	if err == nil && token != "" {
+		for _, crt := range crts {
+			if subtle.ConstantTimeCompare([]byte(crt.Status.Token), []byte(token)) == 1 {
+				return crt, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("cluster registration token not found")
+}
+
+// toValidation returns the validation of a report, passed if all of its checks passed.
+func toValidation(report validationReport, now time.Time) (apimgmtv3.ClusterRegistrationValidation, error) {
+	if report.NodeName == "" || len(report.NodeName) > maxNodeName {
+		return apimgmtv3.ClusterRegistrationValidation{}, fmt.Errorf("validation report has an invalid node name")
+	}
+	if len(report.Checks) > maxChecks {
+		return apimgmtv3.ClusterRegistrationValidation{}, fmt.Errorf("validation report has more than %d checks", maxChecks)
+	}
+	validation := apimgmtv3.ClusterRegistrationValidation{
+		NodeName: report.NodeName,
+		Time:     now.UTC().Format(time.RFC3339),
+		Passed:   true,
+		Checks:   report.Checks,
+	}
+	for i := range validation.Checks {
+		if len(validation.Checks[i].Message) > maxMessageLength {
+			validation.Checks[i].Message = validation.Checks[i].Message[:maxMessageLength]
+		}
+		validation.Passed = validation.Passed && validation.Checks[i].Passed
+	}
+	return validation, nil
+}
+
+// addValidation returns the validations with the validation first, replacing the previous validation of the node and
+// dropping the oldest validations past maxValidations.
+func addValidation(validations []apimgmtv3.ClusterRegistrationValidation, validation apimgmtv3.ClusterRegistrationValidation) []apimgmtv3.ClusterRegistrationValidation {
+	result := []apimgmtv3.ClusterRegistrationValidation{validation}
+	for _, v := range validations {
+		if v.NodeName == validation.NodeName {
+			continue
+		}
+		if len(result) == maxValidations {
+			break
+		}
+		result = append(result, v)
+	}
+	return result
+}
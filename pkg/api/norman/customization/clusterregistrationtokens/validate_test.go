@@ -0,0 +1,97 @@
+package clusterregistrationtokens
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	apimgmtv3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToValidation(t *testing.T) {
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		report         validationReport
+		expectedPassed bool
+		expectedErr    bool
+	}{
+		{
+			name: "all checks passed",
+			report: validationReport{
+				NodeName: "node1",
+				Checks:   []apimgmtv3.ClusterRegistrationCheck{{Name: "dns", Passed: true}, {Name: "ports", Passed: true}},
+			},
+			expectedPassed: true,
+		},
+		{
+			name: "a check failed",
+			report: validationReport{
+				NodeName: "node1",
+				Checks:   []apimgmtv3.ClusterRegistrationCheck{{Name: "dns", Passed: true}, {Name: "certificate", Passed: false}},
+			},
+			expectedPassed: false,
+		},
+		{
+			name:        "missing node name",
+			report:      validationReport{Checks: []apimgmtv3.ClusterRegistrationCheck{{Name: "dns", Passed: true}}},
+			expectedErr: true,
+		},
+		{
+			name: "too many checks",
+			report: validationReport{
+				NodeName: "node1",
+				Checks:   make([]apimgmtv3.ClusterRegistrationCheck, maxChecks+1),
+			},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validation, err := toValidation(tt.report, now)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedPassed, validation.Passed)
+			assert.Equal(t, "2023-05-01T12:00:00Z", validation.Time)
+			assert.Equal(t, tt.report.NodeName, validation.NodeName)
+		})
+	}
+}
+
+func TestToValidationTruncatesMessages(t *testing.T) {
+	validation, err := toValidation(validationReport{
+		NodeName: "node1",
+		Checks:   []apimgmtv3.ClusterRegistrationCheck{{Name: "dns", Message: strings.Repeat("a", maxMessageLength+1)}},
+	}, time.Now())
+	require.NoError(t, err)
+	assert.Len(t, validation.Checks[0].Message, maxMessageLength)
+}
+
+func TestAddValidation(t *testing.T) {
+	var validations []apimgmtv3.ClusterRegistrationValidation
+	for i := 0; i < maxValidations; i++ {
+		validations = addValidation(validations, apimgmtv3.ClusterRegistrationValidation{NodeName: fmt.Sprintf("node%d", i)})
+	}
+	require.Len(t, validations, maxValidations)
+	assert.Equal(t, "node9", validations[0].NodeName)
+	assert.Equal(t, "node0", validations[maxValidations-1].NodeName)
+
+	// the validation of a node replaces its previous validation
+	validations = addValidation(validations, apimgmtv3.ClusterRegistrationValidation{NodeName: "node5", Passed: true})
+	require.Len(t, validations, maxValidations)
+	assert.Equal(t, "node5", validations[0].NodeName)
+	assert.True(t, validations[0].Passed)
+	assert.Equal(t, "node0", validations[maxValidations-1].NodeName)
+
+	// the oldest validation is dropped past the maximum
+	validations = addValidation(validations, apimgmtv3.ClusterRegistrationValidation{NodeName: "node10"})
+	require.Len(t, validations, maxValidations)
+	assert.Equal(t, "node10", validations[0].NodeName)
+	assert.Equal(t, "node1", validations[maxValidations-1].NodeName)
+}
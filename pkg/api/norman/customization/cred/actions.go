@@ -0,0 +1,407 @@
+package cred
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rancher/norman/api/access"
+	"github.com/rancher/norman/httperror"
+	"github.com/rancher/norman/types"
+	"github.com/rancher/rancher/pkg/api/norman/customization/aks"
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	provv1api "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	client "github.com/rancher/rancher/pkg/client/generated/management/v3"
+	mgmtcluster "github.com/rancher/rancher/pkg/controllers/management/cluster"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/cluster"
+	mgmtv3 "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	provv1 "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	v1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/ref"
+	mgmtschema "github.com/rancher/rancher/pkg/schemas/management.cattle.io/v3"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	dependentCluster             = "cluster"
+	dependentProvisioningCluster = "provisioningCluster"
+	dependentMachinePool         = "machinePool"
+	dependentNodeTemplate        = "nodeTemplate"
+
+	credentialConfigSuffix = "credentialConfig-"
+)
+
+// providerValidators check cloud credentials against their provider, by driver. The credentials of drivers without a
+// validator are only checked to have the fields of the credential they replace.
+var providerValidators = map[string]func(secret *corev1.Secret) error{
+	"azure": validateAzureCredential,
+}
+
+// ActionHandler handles the actions listing the dependents of a cloud credential and rotating it.
+type ActionHandler struct {
+	SecretLister       v1.SecretLister
+	ClusterCache       mgmtv3.ClusterCache
+	ClusterClient      v3.ClusterInterface
+	ProvClusterCache   provv1.ClusterCache
+	ProvClusterClient  provv1.ClusterClient
+	NodeTemplateLister v3.NodeTemplateLister
+	NodeTemplateClient v3.NodeTemplateInterface
+}
+
+func (a ActionHandler) Formatter(apiContext *types.APIContext, resource *types.RawResource) {
+	resource.AddAction(apiContext, v32.CloudCredentialActionDependents)
+	resource.AddAction(apiContext, v32.CloudCredentialActionRotate)
+}
+
+func (a ActionHandler) ActionHandler(actionName string, action *types.Action, apiContext *types.APIContext) error {
+	var credential client.CloudCredential
+	if err := access.ByID(apiContext, apiContext.Version, apiContext.Type, apiContext.ID, &credential); err != nil {
+		return err
+	}
+
+	switch actionName {
+	case v32.CloudCredentialActionDependents:
+		dependents, err := a.dependents(apiContext.ID)
+		if err != nil {
+			return httperror.WrapAPIError(err, httperror.ServerError, "failed to list the dependents of the cloud credential")
+		}
+		return writeResponse(apiContext, actionName, map[string]interface{}{
+			"type": client.CloudCredentialDependentsOutputType,
+			client.CloudCredentialDependentsOutputFieldDependents: dependents,
+		})
+	case v32.CloudCredentialActionRotate:
+		return a.rotate(actionName, apiContext)
+	}
+	return httperror.NewAPIError(httperror.NotFound, "not found")
+}
+
+// rotate switches every dependent of a cloud credential to a replacement credential. The replacement is validated and
+// the caller must be able to update every dependent before any of them is switched, and the dependents already
+// switched are switched back if one of them fails, so that they keep using the same credential.
+func (a ActionHandler) rotate(actionName string, apiContext *types.APIContext) error {
+	data, err := ioutil.ReadAll(apiContext.Request.Body)
+	if err != nil {
+		return httperror.WrapAPIError(err, httperror.InvalidBodyContent, "failed to read request body")
+	}
+	input := v32.CloudCredentialRotateInput{}
+	if err := json.Unmarshal(data, &input); err != nil {
+		return httperror.WrapAPIError(err, httperror.InvalidBodyContent, "failed to parse request content")
+	}
+	if input.CloudCredentialID == "" {
+		return httperror.NewAPIError(httperror.MissingRequired, "cloudCredentialId is required")
+	}
+	if input.CloudCredentialID == apiContext.ID {
+		return httperror.NewAPIError(httperror.InvalidBodyContent, "a cloud credential can't be rotated to itself")
+	}
+
+	var replacementCredential client.CloudCredential
+	if err := access.ByID(apiContext, apiContext.Version, apiContext.Type, input.CloudCredentialID, &replacementCredential); err != nil {
+		return err
+	}
+	current, err := a.secret(apiContext.ID)
+	if err != nil {
+		return err
+	}
+	replacement, err := a.secret(input.CloudCredentialID)
+	if err != nil {
+		return err
+	}
+	if err := validateReplacement(current, replacement); err != nil {
+		return httperror.NewAPIError(httperror.InvalidBodyContent, err.Error())
+	}
+
+	dependents, err := a.dependents(apiContext.ID)
+	if err != nil {
+		return httperror.WrapAPIError(err, httperror.ServerError, "failed to list the dependents of the cloud credential")
+	}
+	for _, dependent := range dependents {
+		if !a.canUpdate(apiContext, dependent) {
+			return httperror.NewAPIError(httperror.PermissionDenied, fmt.Sprintf("can not update %s %s", dependent.Type, dependentName(dependent)))
+		}
+	}
+
+	if validate, ok := providerValidators[credentialDriver(replacement)]; ok {
+		if err := validate(replacement); err != nil {
+			return httperror.NewAPIError(httperror.InvalidBodyContent, fmt.Sprintf("cloud credential %s was rejected by its provider: %v", input.CloudCredentialID, err))
+		}
+	}
+
+	response := map[string]interface{}{
+		"type": client.CloudCredentialRotateOutputType,
+		client.CloudCredentialRotateOutputFieldDependents: dependents,
+	}
+	if input.DryRun {
+		response[client.CloudCredentialRotateOutputFieldMessage] = fmt.Sprintf("%d dependents would be switched to cloud credential %s", len(dependents), input.CloudCredentialID)
+		return writeResponse(apiContext, actionName, response)
+	}
+
+	for i, dependent := range dependents {
+		if err := a.switchDependent(dependent, apiContext.ID, input.CloudCredentialID); err != nil {
+			for j := i - 1; j >= 0; j-- {
+				if rollbackErr := a.switchDependent(dependents[j], input.CloudCredentialID, apiContext.ID); rollbackErr != nil {
+					logrus.Errorf("[cloud-credential-rotation] failed to switch %s %s back to cloud credential %s: %v",
+						dependents[j].Type, dependentName(dependents[j]), apiContext.ID, rollbackErr)
+				}
+			}
+			return httperror.WrapAPIError(err, httperror.ServerError,
+				fmt.Sprintf("failed to switch %s %s to cloud credential %s, the dependents were switched back", dependent.Type, dependentName(dependent), input.CloudCredentialID))
+		}
+	}
+
+	response[client.CloudCredentialRotateOutputFieldMessage] = fmt.Sprintf("%d dependents were switched to cloud credential %s", len(dependents), input.CloudCredentialID)
+	return writeResponse(apiContext, actionName, response)
+}
+
+// dependents returns the clusters, machine pools and node templates using a cloud credential.
+func (a ActionHandler) dependents(credID string) ([]v32.CloudCredentialDependent, error) {
+	var result []v32.CloudCredentialDependent
+
+	clusters, err := a.ClusterCache.GetByIndex(mgmtcluster.ByCloudCredential, credID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range clusters {
+		result = append(result, v32.CloudCredentialDependent{Type: dependentCluster, Name: c.Name})
+	}
+
+	provClusters, err := a.ProvClusterCache.GetByIndex(cluster.ByCloudCred, credID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range provClusters {
+		result = append(result, provClusterDependents(c, credID)...)
+	}
+
+	nodeTemplates, err := a.NodeTemplateLister.List("", labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, template := range nodeTemplates {
+		if template.Spec.CloudCredentialName == credID {
+			result = append(result, v32.CloudCredentialDependent{Type: dependentNodeTemplate, Namespace: template.Namespace, Name: template.Name})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Type != result[j].Type {
+			return result[i].Type < result[j].Type
+		}
+		return dependentName(result[i]) < dependentName(result[j])
+	})
+	return result, nil
+}
+
+func (a ActionHandler) secret(credID string) (*corev1.Secret, error) {
+	ns, name := ref.Parse(credID)
+	if ns == "" || name == "" {
+		return nil, httperror.NewAPIError(httperror.InvalidBodyContent, fmt.Sprintf("invalid cloud credential ID %s", credID))
+	}
+	secret, err := a.SecretLister.Get(ns, name)
+	if err != nil {
+		return nil, httperror.WrapAPIError(err, httperror.NotFound, fmt.Sprintf("cloud credential %s not found", credID))
+	}
+	return secret, nil
+}
+
+// canUpdate returns whether the caller can update a dependent. Provisioning clusters and their machine pools are
+// checked against their management cluster.
+func (a ActionHandler) canUpdate(apiContext *types.APIContext, dependent v32.CloudCredentialDependent) bool {
+	switch dependent.Type {
+	case dependentCluster:
+		return canUpdateCluster(apiContext, dependent.Name)
+	case dependentProvisioningCluster, dependentMachinePool:
+		provCluster, err := a.ProvClusterCache.Get(dependent.Namespace, dependent.Name)
+		if err != nil || provCluster.Status.ClusterName == "" {
+			return false
+		}
+		return canUpdateCluster(apiContext, provCluster.Status.ClusterName)
+	case dependentNodeTemplate:
+		nodeTemplateSchema := apiContext.Schemas.Schema(&mgmtschema.Version, client.NodeTemplateType)
+		return apiContext.AccessControl.CanDo(v3.NodeTemplateGroupVersionKind.Group, v3.NodeTemplateResource.Name, "update", apiContext, map[string]interface{}{
+			"id":          dependent.Namespace + ":" + dependent.Name,
+			"namespaceId": dependent.Namespace,
+		}, nodeTemplateSchema) == nil
+	}
+	return false
+}
+
+func canUpdateCluster(apiContext *types.APIContext, name string) bool {
+	clusterSchema := apiContext.Schemas.Schema(&mgmtschema.Version, client.ClusterType)
+	return apiContext.AccessControl.CanDo(v3.ClusterGroupVersionKind.Group, v3.ClusterResource.Name, "update", apiContext, map[string]interface{}{
+		"id": name,
+	}, clusterSchema) == nil
+}
+
+// switchDependent switches a dependent from a cloud credential to another.
+func (a ActionHandler) switchDependent(dependent v32.CloudCredentialDependent, from, to string) error {
+	switch dependent.Type {
+	case dependentCluster:
+		c, err := a.ClusterClient.Get(dependent.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if !setClusterCredential(c, from, to) {
+			return nil
+		}
+		_, err = a.ClusterClient.Update(c)
+		return err
+	case dependentProvisioningCluster, dependentMachinePool:
+		c, err := a.ProvClusterClient.Get(dependent.Namespace, dependent.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if !setProvClusterCredential(c, dependent.MachinePool, from, to) {
+			return nil
+		}
+		_, err = a.ProvClusterClient.Update(c)
+		return err
+	case dependentNodeTemplate:
+		template, err := a.NodeTemplateClient.GetNamespaced(dependent.Namespace, dependent.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if template.Spec.CloudCredentialName != from {
+			return nil
+		}
+		template.Spec.CloudCredentialName = to
+		_, err = a.NodeTemplateClient.Update(template)
+		return err
+	}
+	return fmt.Errorf("unknown dependent type %s", dependent.Type)
+}
+
+// provClusterDependents returns the dependents of a cloud credential in a provisioning cluster: the cluster itself if
+// it uses the credential, and each of its machine pools setting the credential.
+func provClusterDependents(c *provv1api.Cluster, credID string) []v32.CloudCredentialDependent {
+	var result []v32.CloudCredentialDependent
+	if c.Spec.CloudCredentialSecretName == credID {
+		result = append(result, v32.CloudCredentialDependent{Type: dependentProvisioningCluster, Namespace: c.Namespace, Name: c.Name})
+	}
+	if c.Spec.RKEConfig == nil {
+		return result
+	}
+	for _, pool := range c.Spec.RKEConfig.MachinePools {
+		if pool.CloudCredentialSecretName == credID {
+			result = append(result, v32.CloudCredentialDependent{Type: dependentMachinePool, Namespace: c.Namespace, Name: c.Name, MachinePool: pool.Name})
+		}
+	}
+	return result
+}
+
+// setProvClusterCredential switches a provisioning cluster, or one of its machine pools, from a cloud credential to
+// another. It returns whether the cluster changed.
+func setProvClusterCredential(c *provv1api.Cluster, machinePool, from, to string) bool {
+	if machinePool == "" {
+		if c.Spec.CloudCredentialSecretName != from {
+			return false
+		}
+		c.Spec.CloudCredentialSecretName = to
+		return true
+	}
+	if c.Spec.RKEConfig == nil {
+		return false
+	}
+	for i, pool := range c.Spec.RKEConfig.MachinePools {
+		if pool.Name == machinePool && pool.CloudCredentialSecretName == from {
+			c.Spec.RKEConfig.MachinePools[i].CloudCredentialSecretName = to
+			return true
+		}
+	}
+	return false
+}
+
+// setClusterCredential switches the hosted provider config of a cluster from a cloud credential to another. It returns
+// whether the cluster changed.
+func setClusterCredential(c *v32.Cluster, from, to string) bool {
+	switch {
+	case c.Spec.EKSConfig != nil && c.Spec.EKSConfig.AmazonCredentialSecret == from:
+		c.Spec.EKSConfig.AmazonCredentialSecret = to
+	case c.Spec.AKSConfig != nil && c.Spec.AKSConfig.AzureCredentialSecret == from:
+		c.Spec.AKSConfig.AzureCredentialSecret = to
+	case c.Spec.GKEConfig != nil && c.Spec.GKEConfig.GoogleCredentialSecret == from:
+		c.Spec.GKEConfig.GoogleCredentialSecret = to
+	default:
+		return false
+	}
+	return true
+}
+
+// credentialDriver returns the driver of a cloud credential. The fields of cloud credentials are stored in their secret
+// prefixed by the credential config of their driver, such as amazonec2credentialConfig-accessKey.
+func credentialDriver(secret *corev1.Secret) string {
+	for key := range secret.Data {
+		if i := strings.Index(key, credentialConfigSuffix); i > 0 {
+			return key[:i]
+		}
+	}
+	return ""
+}
+
+// validateReplacement returns an error if a cloud credential can't replace another, which requires a credential of the
+// same driver setting every field the replaced credential sets.
+func validateReplacement(current, replacement *corev1.Secret) error {
+	driver := credentialDriver(current)
+	if driver == "" {
+		return fmt.Errorf("cloud credential %s:%s has no credential config", current.Namespace, current.Name)
+	}
+	if replacementDriver := credentialDriver(replacement); replacementDriver != driver {
+		return fmt.Errorf("cloud credential %s:%s is not a credential of driver %s", replacement.Namespace, replacement.Name, driver)
+	}
+
+	prefix := driver + credentialConfigSuffix
+	var missing []string
+	for key, value := range current.Data {
+		if !strings.HasPrefix(key, prefix) || len(value) == 0 {
+			continue
+		}
+		if len(replacement.Data[key]) == 0 {
+			missing = append(missing, strings.TrimPrefix(key, prefix))
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("cloud credential %s:%s is missing %s", replacement.Namespace, replacement.Name, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func validateAzureCredential(secret *corev1.Secret) error {
+	_, err := aks.ValidateCredentials(&aks.Capabilities{
+		TenantID:       string(secret.Data["azurecredentialConfig-tenantId"]),
+		SubscriptionID: string(secret.Data["azurecredentialConfig-subscriptionId"]),
+		ClientID:       string(secret.Data["azurecredentialConfig-clientId"]),
+		ClientSecret:   string(secret.Data["azurecredentialConfig-clientSecret"]),
+		Environment:    string(secret.Data["azurecredentialConfig-environment"]),
+	})
+	return err
+}
+
+func dependentName(dependent v32.CloudCredentialDependent) string {
+	name := dependent.Name
+	if dependent.Namespace != "" {
+		name = dependent.Namespace + "/" + name
+	}
+	if dependent.MachinePool != "" {
+		name += "/" + dependent.MachinePool
+	}
+	return name
+}
+
+func writeResponse(apiContext *types.APIContext, actionName string, response map[string]interface{}) error {
+	res, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	apiContext.Response.Header().Set("Content-Type", "application/json")
+	http.ServeContent(apiContext.Response, apiContext.Request, actionName, time.Now(), bytes.NewReader(res))
+	return nil
+}
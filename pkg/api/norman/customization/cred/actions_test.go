@@ -0,0 +1,99 @@
+package cred
+
+import (
+	"testing"
+
+	aksv1 "github.com/rancher/aks-operator/pkg/apis/aks.cattle.io/v1"
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	provv1api "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestProvClusterDependents(t *testing.T) {
+	c := &provv1api.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "fleet-default", Name: "c1"},
+		Spec: provv1api.ClusterSpec{
+			CloudCredentialSecretName: "cattle-global-data:cc-a",
+			RKEConfig: &provv1api.RKEConfig{
+				MachinePools: []provv1api.RKEMachinePool{
+					{Name: "etcd"},
+					{Name: "worker", CloudCredentialSecretName: "cattle-global-data:cc-b"},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []v32.CloudCredentialDependent{
+		{Type: dependentProvisioningCluster, Namespace: "fleet-default", Name: "c1"},
+	}, provClusterDependents(c, "cattle-global-data:cc-a"))
+	assert.Equal(t, []v32.CloudCredentialDependent{
+		{Type: dependentMachinePool, Namespace: "fleet-default", Name: "c1", MachinePool: "worker"},
+	}, provClusterDependents(c, "cattle-global-data:cc-b"))
+	assert.Empty(t, provClusterDependents(c, "cattle-global-data:cc-c"))
+}
+
+func TestSetProvClusterCredential(t *testing.T) {
+	c := &provv1api.Cluster{
+		Spec: provv1api.ClusterSpec{
+			CloudCredentialSecretName: "cattle-global-data:cc-a",
+			RKEConfig: &provv1api.RKEConfig{
+				MachinePools: []provv1api.RKEMachinePool{
+					{Name: "etcd"},
+					{Name: "worker", CloudCredentialSecretName: "cattle-global-data:cc-a"},
+				},
+			},
+		},
+	}
+
+	assert.True(t, setProvClusterCredential(c, "worker", "cattle-global-data:cc-a", "cattle-global-data:cc-b"))
+	assert.Equal(t, "cattle-global-data:cc-b", c.Spec.RKEConfig.MachinePools[1].CloudCredentialSecretName)
+	assert.Equal(t, "cattle-global-data:cc-a", c.Spec.CloudCredentialSecretName)
+	assert.False(t, setProvClusterCredential(c, "worker", "cattle-global-data:cc-a", "cattle-global-data:cc-b"))
+	assert.False(t, setProvClusterCredential(c, "etcd", "cattle-global-data:cc-a", "cattle-global-data:cc-b"))
+
+	assert.True(t, setProvClusterCredential(c, "", "cattle-global-data:cc-a", "cattle-global-data:cc-b"))
+	assert.Equal(t, "cattle-global-data:cc-b", c.Spec.CloudCredentialSecretName)
+	assert.Equal(t, "", c.Spec.RKEConfig.MachinePools[0].CloudCredentialSecretName)
+}
+
+func TestSetClusterCredential(t *testing.T) {
+	c := &v32.Cluster{}
+	assert.False(t, setClusterCredential(c, "cattle-global-data:cc-a", "cattle-global-data:cc-b"))
+
+	c.Spec.AKSConfig = &aksv1.AKSClusterConfigSpec{AzureCredentialSecret: "cattle-global-data:cc-a"}
+	assert.True(t, setClusterCredential(c, "cattle-global-data:cc-a", "cattle-global-data:cc-b"))
+	assert.Equal(t, "cattle-global-data:cc-b", c.Spec.AKSConfig.AzureCredentialSecret)
+	assert.False(t, setClusterCredential(c, "cattle-global-data:cc-a", "cattle-global-data:cc-b"))
+}
+
+func TestValidateReplacement(t *testing.T) {
+	current := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "cattle-global-data", Name: "cc-a"},
+		Data: map[string][]byte{
+			"amazonec2credentialConfig-accessKey":     []byte("key"),
+			"amazonec2credentialConfig-secretKey":     []byte("secret"),
+			"amazonec2credentialConfig-defaultRegion": []byte(""),
+		},
+	}
+	replacement := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "cattle-global-data", Name: "cc-b"},
+		Data: map[string][]byte{
+			"amazonec2credentialConfig-accessKey": []byte("other-key"),
+			"amazonec2credentialConfig-secretKey": []byte("other-secret"),
+		},
+	}
+	assert.Equal(t, "amazonec2", credentialDriver(current))
+	assert.NoError(t, validateReplacement(current, replacement))
+
+	delete(replacement.Data, "amazonec2credentialConfig-secretKey")
+	replacement.Data["amazonec2credentialConfig-accessKey"] = nil
+	assert.EqualError(t, validateReplacement(current, replacement), "cloud credential cattle-global-data:cc-b is missing accessKey, secretKey")
+
+	replacement.Data = map[string][]byte{"azurecredentialConfig-clientId": []byte("id")}
+	assert.EqualError(t, validateReplacement(current, replacement), "cloud credential cattle-global-data:cc-b is not a credential of driver amazonec2")
+
+	assert.EqualError(t, validateReplacement(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "cattle-global-data", Name: "cc-c"}}, replacement),
+		"cloud credential cattle-global-data:cc-c has no credential config")
+}
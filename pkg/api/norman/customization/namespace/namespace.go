@@ -1,6 +1,7 @@
 package namespace
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/pkg/errors"
@@ -13,6 +14,7 @@
 	"github.com/rancher/rancher/pkg/clustermanager"
 	"github.com/rancher/rancher/pkg/controllers/managementagent/nslabels"
 	"github.com/rancher/rancher/pkg/controllers/managementuserlegacy/helm"
+	namespaceutil "github.com/rancher/rancher/pkg/namespace"
 	"github.com/rancher/rancher/pkg/rbac"
 	"github.com/rancher/rancher/pkg/ref"
 	schema "github.com/rancher/rancher/pkg/schemas/cluster.cattle.io/v3"
@@ -89,6 +91,14 @@ func (w ActionWrapper) ActionHandler(actionName string, action *types.Action, ap
 			if project.Spec.ResourceQuota != nil {
 				return errors.Errorf("can't move namespace. Project %s has resource quota set", project.Spec.DisplayName)
 			}
+			matches, err := namespaceutil.NamePatternMatches(project.Spec.NamespacePolicy, apiContext.ID)
+			if err != nil {
+				return err
+			}
+			if !matches {
+				return httperror.NewAPIError(httperror.InvalidFormat, fmt.Sprintf("can't move namespace. Its name does not match the pattern %s of project %s",
+					project.Spec.NamespacePolicy.NamePattern, project.Spec.DisplayName))
+			}
 		}
 		nsClient := userContext.Core.Namespaces("")
 		ns, err := nsClient.Get(apiContext.ID, metav1.GetOptions{})
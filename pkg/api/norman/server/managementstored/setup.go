@@ -462,6 +462,17 @@ func SecretTypes(ctx context.Context, schemas *types.Schemas, management *config
 		management.Wrangler.Provisioning.Cluster().Cache(),
 	)
 	credSchema.Validator = cred.Validator
+	credHandler := cred.ActionHandler{
+		SecretLister:       management.Core.Secrets("").Controller().Lister(),
+		ClusterCache:       management.Wrangler.Mgmt.Cluster().Cache(),
+		ClusterClient:      management.Management.Clusters(""),
+		ProvClusterCache:   management.Wrangler.Provisioning.Cluster().Cache(),
+		ProvClusterClient:  management.Wrangler.Provisioning.Cluster(),
+		NodeTemplateLister: management.Management.NodeTemplates("").Controller().Lister(),
+		NodeTemplateClient: management.Management.NodeTemplates(""),
+	}
+	credSchema.Formatter = credHandler.Formatter
+	credSchema.ActionHandler = credHandler.ActionHandler
 }
 
 func Preference(schemas *types.Schemas, management *config.ScaledContext) {
@@ -282,9 +282,7 @@ func (r *Store) Create(apiContext *types.APIContext, schema *types.Schema, data
 		return nil, err
 	}
 
-	if err := validateNetworkFlag(data, true); err != nil {
-		return nil, httperror.NewFieldAPIError(httperror.InvalidOption, "enableNetworkPolicy", err.Error())
-	}
+	setNetworkFlagDefault(data)
 
 	if driverName, _ := values.GetValue(data, "genericEngineConfig", "driverName"); driverName == "amazonelasticcontainerservice" {
 		sessionToken, _ := values.GetValue(data, "genericEngineConfig", "sessionToken")
@@ -770,10 +768,6 @@ func (r *Store) Update(apiContext *types.APIContext, schema *types.Schema, data
 		return nil, err
 	}
 
-	if err := validateNetworkFlag(data, false); err != nil {
-		return nil, httperror.NewFieldAPIError(httperror.InvalidOption, "enableNetworkPolicy", err.Error())
-	}
-
 	cleanPrivateRegistry(data)
 	dialer, err := r.DialerFactory.ClusterDialer(id)
 	if err != nil {
@@ -1251,23 +1245,11 @@ func getSupportedK8sVersion(k8sVersionRequest string) (string, error) {
 	return "", nil
 }
 
-func validateNetworkFlag(data map[string]interface{}, create bool) error {
-	enableNetworkPolicy := values.GetValueN(data, "enableNetworkPolicy")
-	if enableNetworkPolicy == nil && create {
-		// setting default values for new clusters if value not passed
+// setNetworkFlagDefault disables project network isolation for new clusters that don't set it.
+func setNetworkFlagDefault(data map[string]interface{}) {
+	if values.GetValueN(data, "enableNetworkPolicy") == nil {
 		values.PutValue(data, false, "enableNetworkPolicy")
-	} else if value := convert.ToBool(enableNetworkPolicy); value {
-		rke2Config := values.GetValueN(data, "rke2Config")
-		k3sConfig := values.GetValueN(data, "k3sConfig")
-		if rke2Config != nil || k3sConfig != nil {
-			if create {
-				values.PutValue(data, false, "enableNetworkPolicy")
-				return nil
-			}
-			return fmt.Errorf("enableNetworkPolicy should be false for k3s or rke2 clusters")
-		}
 	}
-	return nil
 }
 
 func setNodeUpgradeStrategy(newData, oldData map[string]interface{}) error {
@@ -12,6 +12,7 @@
 	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
 	clusterclient "github.com/rancher/rancher/pkg/client/generated/cluster/v3"
 	mgmtclient "github.com/rancher/rancher/pkg/client/generated/management/v3"
+	namespaceutil "github.com/rancher/rancher/pkg/namespace"
 	"github.com/rancher/rancher/pkg/resourcequota"
 	mgmtschema "github.com/rancher/rancher/pkg/schemas/management.cattle.io/v3"
 	"k8s.io/kubernetes/pkg/kubelet/util/format"
@@ -54,6 +55,10 @@ func (p *Store) Create(apiContext *types.APIContext, schema *types.Schema, data
 		return nil, err
 	}
 
+	if err := p.validateNamePattern(apiContext, data); err != nil {
+		return nil, err
+	}
+
 	return p.Store.Create(apiContext, schema, data)
 }
 
@@ -169,6 +174,30 @@ func (p *Store) validateResourceQuota(apiContext *types.APIContext, schema *type
 	return httperror.NewFieldAPIError(httperror.MaxLimitExceeded, quotaField, fmt.Sprintf("exceeds projectLimit on fields: %s", format.ResourceList(exceeded)))
 }
 
+// validateNamePattern checks that the name of a namespace created in a project matches the name pattern of the
+// namespace policy of the project.
+func (p *Store) validateNamePattern(apiContext *types.APIContext, data map[string]interface{}) error {
+	projectID := convert.ToString(data["projectId"])
+	if projectID == "" {
+		return nil
+	}
+	var project mgmtclient.Project
+	if err := access.ByID(apiContext, &mgmtschema.Version, mgmtclient.ProjectType, projectID, &project); err != nil {
+		return err
+	}
+	if project.NamespacePolicy == nil {
+		return nil
+	}
+	matches, err := namespaceutil.NamePatternMatches(&v32.NamespacePolicy{NamePattern: project.NamespacePolicy.NamePattern}, convert.ToString(data["name"]))
+	if err != nil {
+		return httperror.NewAPIError(httperror.ServerError, err.Error())
+	}
+	if !matches {
+		return httperror.NewFieldAPIError(httperror.InvalidFormat, "name", fmt.Sprintf("does not match the pattern %s of the project", project.NamespacePolicy.NamePattern))
+	}
+	return nil
+}
+
 func limitToLimit(from *mgmtclient.ResourceQuotaLimit) (*v32.ResourceQuotaLimit, error) {
 	var to v32.ResourceQuotaLimit
 	err := convert.ToObj(from, &to)
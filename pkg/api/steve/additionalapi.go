@@ -5,6 +5,7 @@
 	"net/http"
 
 	gmux "github.com/gorilla/mux"
+	"github.com/rancher/rancher/pkg/admission"
 	"github.com/rancher/rancher/pkg/api/steve/aggregation"
 	"github.com/rancher/rancher/pkg/api/steve/github"
 	"github.com/rancher/rancher/pkg/api/steve/health"
@@ -19,23 +20,24 @@
 )
 
 func AdditionalAPIsPreMCM(config *wrangler.Context) func(http.Handler) http.Handler {
+	mux := gmux.NewRouter()
+	mux.UseEncodedPath()
 	if features.RKE2.Enabled() {
 		connectHandler := configserver.New(config)
-		mux := gmux.NewRouter()
-		mux.UseEncodedPath()
 		mux.Handle(configserver.ConnectAgent, connectHandler)
 		mux.Handle(configserver.ConnectConfigYamlPath, connectHandler)
 		mux.Handle(configserver.ConnectClusterInfo, connectHandler)
+		mux.Handle(configserver.ConnectPlan, connectHandler)
 		mux.Handle(installer.SystemAgentInstallPath, installer.Handler)
 		mux.Handle(installer.WindowsRke2InstallPath, installer.Handler)
-		return func(next http.Handler) http.Handler {
-			mux.NotFoundHandler = next
-			return mux
-		}
+	}
+	if features.ProvisioningV2.Enabled() {
+		mux.PathPrefix(admission.Path).Handler(admission.NewHandler(admission.Validators(config)))
 	}
 
 	return func(next http.Handler) http.Handler {
-		return next
+		mux.NotFoundHandler = next
+		return mux
 	}
 }
 
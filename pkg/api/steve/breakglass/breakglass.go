@@ -0,0 +1,162 @@
+// Package breakglass provides a HTTPHandler allowing admins to retrieve the break glass kubeconfig escrowed for a
+// provisioned cluster. Every retrieval, allowed or not, is logged, emitted as a security event and recorded on the
+// escrow secret. This handler should be registered at Endpoint
+package breakglass
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rancher/rancher/pkg/auth/util"
+	"github.com/rancher/rancher/pkg/breakglass"
+	"github.com/rancher/rancher/pkg/capr/planencryption"
+	"github.com/rancher/rancher/pkg/securityevents"
+	"github.com/rancher/rancher/pkg/types/config"
+	corev1controllers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/sirupsen/logrus"
+	authzv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	authv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+const (
+	// Endpoint The endpoint that the break glass kubeconfig of a cluster is retrieved at - used for routing
+	Endpoint  = "/v1/breakglass/{namespace}/{cluster}"
+	logPrefix = "break-glass"
+	// maxReasonLength is the maximum length of the reason given for a retrieval.
+	maxReasonLength = 1024
+)
+
+// Handler implements http.Handler - and serves the break glass kubeconfigs of clusters
+type Handler struct {
+	Secrets              corev1controllers.SecretClient
+	SubjectAccessReviews authv1.SubjectAccessReviewInterface
+	Encryptor            *planencryption.Encryptor
+}
+
+// NewHandler creates a handler using the clients defined in scaledContext
+func NewHandler(scaledContext *config.ScaledContext) Handler {
+	return Handler{
+		Secrets:              scaledContext.Wrangler.Core.Secret(),
+		SubjectAccessReviews: scaledContext.K8sClient.AuthorizationV1().SubjectAccessReviews(),
+		Encryptor:            planencryption.NewEncryptor(scaledContext.Wrangler),
+	}
+}
+
+// ServeHTTP implements http.Handler - returns the break glass kubeconfig of the cluster if the user is an admin. The
+// reason query parameter, why the kubeconfig is retrieved, is required.
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	vars := mux.Vars(req)
+	namespace, clusterName := vars["namespace"], vars["cluster"]
+	reason := strings.TrimSpace(req.URL.Query().Get("reason"))
+
+	userInfo, ok := request.UserFrom(req.Context())
+	if !ok {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		return
+	}
+	audit := func(outcome securityevents.Outcome, message string) {
+		logrus.Warnf("[%s] user %s retrieving the break glass kubeconfig of cluster %s/%s from %s for reason %q: %s",
+			logPrefix, userInfo.GetName(), namespace, clusterName, util.SourceIP(req), reason, message)
+		event := securityevents.NewEvent(securityevents.BreakGlassKubeconfigRetrieval, outcome, req)
+		event.User = userInfo.GetName()
+		event.Resource = fmt.Sprintf("clusters/%s/%s", namespace, clusterName)
+		event.Message = message
+		event.Details = map[string]string{"reason": reason}
+		securityevents.Emit(event)
+	}
+
+	authorized, err := h.authorize(req, userInfo)
+	if err != nil {
+		audit(securityevents.OutcomeFailure, "authorization failed")
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		logrus.Errorf("[%s] Failed to authorize user with error: %s", logPrefix, err.Error())
+		return
+	}
+	if !authorized {
+		audit(securityevents.OutcomeFailure, "denied")
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		return
+	}
+	if reason == "" || len(reason) > maxReasonLength {
+		audit(securityevents.OutcomeFailure, "no valid reason given")
+		util.ReturnHTTPError(writer, req, http.StatusBadRequest, fmt.Sprintf("a reason of at most %d characters is required", maxReasonLength))
+		return
+	}
+
+	// The secret isn't read from the cache, so that the retrieval is recorded on its latest version.
+	secret, err := h.Secrets.Get(namespace, breakglass.SecretName(clusterName), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) || (err == nil && secret.Type != breakglass.SecretType) {
+		audit(securityevents.OutcomeFailure, "no break glass kubeconfig escrowed")
+		util.ReturnHTTPError(writer, req, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	} else if err != nil {
+		audit(securityevents.OutcomeFailure, "failed to get the escrow")
+		logrus.Errorf("[%s] Error getting break glass kubeconfig of cluster %s/%s: %v", logPrefix, namespace, clusterName, err)
+		util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	kubeconfig, err := h.Encryptor.Decrypt(namespace, clusterName, secret.Data[breakglass.KubeconfigField])
+	if err != nil {
+		audit(securityevents.OutcomeFailure, "failed to decrypt the escrow")
+		logrus.Errorf("[%s] Error decrypting break glass kubeconfig of cluster %s/%s: %v", logPrefix, namespace, clusterName, err)
+		util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	// The kubeconfig is only served once its retrieval is recorded.
+	secret = secret.DeepCopy()
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[breakglass.LastRetrievedByAnnotation] = userInfo.GetName()
+	secret.Annotations[breakglass.LastRetrievedAtAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	if _, err := h.Secrets.Update(secret); err != nil {
+		audit(securityevents.OutcomeFailure, "failed to record the retrieval")
+		logrus.Errorf("[%s] Error recording retrieval of break glass kubeconfig of cluster %s/%s: %v", logPrefix, namespace, clusterName, err)
+		util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+	audit(securityevents.OutcomeSuccess, "retrieved")
+
+	writer.Header().Set("Content-Type", "application/yaml")
+	writer.Header().Set("Content-Disposition", "attachment; filename="+strconv.Quote(clusterName+"-break-glass.yaml"))
+	writer.Header().Set("Cache-Control", "no-store")
+	if _, err := writer.Write(kubeconfig); err != nil {
+		logrus.Warnf("[%s] Failed to write break glass kubeconfig: %v", logPrefix, err)
+	}
+}
+
+// authorize checks to see if the user is an admin, that is can do anything to any resource. Returns a bool (if the
+// user is authorized) and optionally an error
+func (h *Handler) authorize(r *http.Request, userInfo user.Info) (bool, error) {
+	extra := map[string]authzv1.ExtraValue{}
+	for k, v := range userInfo.GetExtra() {
+		extra[k] = authzv1.ExtraValue(v)
+	}
+	response, err := h.SubjectAccessReviews.Create(r.Context(), &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Group:    "*",
+				Resource: "*",
+				Verb:     "*",
+			},
+			User:   userInfo.GetName(),
+			Groups: userInfo.GetGroups(),
+			Extra:  extra,
+			UID:    userInfo.GetUID(),
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to create sar %s", err)
+	}
+	return response.Status.Allowed, nil
+}
@@ -0,0 +1,159 @@
+// Package clusterarchive provides a HTTPHandler searching the archives of removed clusters. This handler should be
+// registered at Endpoint
+package clusterarchive
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/auth/util"
+	"github.com/rancher/rancher/pkg/clusterarchive"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/sirupsen/logrus"
+	authzv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	authv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+const (
+	// Endpoint The endpoint that the search of cluster archives is accessible at - used for routing
+	Endpoint  = "/v1/clusterarchivesearch"
+	logPrefix = "cluster-archive-search"
+)
+
+// Summary is a cluster archive found by a search, the full archive is read from the cluster archive of the name.
+type Summary struct {
+	Name              string `json:"name"`
+	ClusterName       string `json:"clusterName"`
+	ClusterUID        string `json:"clusterUID"`
+	DisplayName       string `json:"displayName,omitempty"`
+	Driver            string `json:"driver,omitempty"`
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	CreatorID         string `json:"creatorId,omitempty"`
+	CreatedAt         string `json:"createdAt"`
+	RemovedAt         string `json:"removedAt"`
+}
+
+// Handler implements http.Handler - and searches cluster archives
+type Handler struct {
+	ClusterArchives      mgmtcontrollers.ClusterArchiveCache
+	SubjectAccessReviews authv1.SubjectAccessReviewInterface
+}
+
+// NewHandler creates a handler using the clients defined in scaledContext
+func NewHandler(scaledContext *config.ScaledContext) Handler {
+	return Handler{
+		ClusterArchives:      scaledContext.Wrangler.Mgmt.ClusterArchive().Cache(),
+		SubjectAccessReviews: scaledContext.K8sClient.AuthorizationV1().SubjectAccessReviews(),
+	}
+}
+
+// ServeHTTP implements http.Handler - returns the summaries of the cluster archives selected by the clusterName,
+// displayName and creatorId query parameters, and of the clusters removed between the removedAfter and removedBefore
+// query parameters, as RFC3339 times or dates, if the user can list cluster archives.
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	removedAfter, err := parseTime(query.Get("removedAfter"))
+	if err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusBadRequest, fmt.Sprintf("invalid removedAfter: %v", err))
+		return
+	}
+	removedBefore, err := parseTime(query.Get("removedBefore"))
+	if err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusBadRequest, fmt.Sprintf("invalid removedBefore: %v", err))
+		return
+	}
+
+	userInfo, ok := request.UserFrom(req.Context())
+	if !ok {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		logrus.Errorf("[%s] Failed to authorize user: unable to extract user info from context", logPrefix)
+		return
+	}
+	authorized, err := h.authorize(req, userInfo)
+	if err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		logrus.Errorf("[%s] Failed to authorize user with error: %s", logPrefix, err.Error())
+		return
+	}
+	if !authorized {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		return
+	}
+
+	archives, err := h.ClusterArchives.List(labels.Everything())
+	if err != nil {
+		logrus.Errorf("[%s] Error listing cluster archives: %v", logPrefix, err)
+		util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+	summaries := []Summary{}
+	for _, archive := range clusterarchive.Filter(archives, clusterarchive.Query{
+		ClusterName:   query.Get("clusterName"),
+		DisplayName:   query.Get("displayName"),
+		CreatorID:     query.Get("creatorId"),
+		RemovedAfter:  removedAfter,
+		RemovedBefore: removedBefore,
+	}) {
+		summaries = append(summaries, Summary{
+			Name:              archive.Name,
+			ClusterName:       archive.Spec.ClusterName,
+			ClusterUID:        archive.Spec.ClusterUID,
+			DisplayName:       archive.Spec.DisplayName,
+			Driver:            archive.Spec.Driver,
+			KubernetesVersion: archive.Spec.KubernetesVersion,
+			CreatorID:         archive.Spec.CreatorID,
+			CreatedAt:         archive.Spec.CreatedAt,
+			RemovedAt:         archive.Spec.RemovedAt,
+		})
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(summaries); err != nil {
+		logrus.Warnf("[%s] Failed to write cluster archives: %v", logPrefix, err)
+	}
+}
+
+// parseTime parses an RFC3339 time or a date, returning the zero time if value is empty.
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// authorize checks to see if the user can list cluster archives. Returns a bool (if the user is authorized) and
+// optionally an error
+func (h *Handler) authorize(r *http.Request, userInfo user.Info) (bool, error) {
+	extra := map[string]authzv1.ExtraValue{}
+	for k, v := range userInfo.GetExtra() {
+		extra[k] = authzv1.ExtraValue(v)
+	}
+	response, err := h.SubjectAccessReviews.Create(r.Context(), &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Group:    v3.SchemeGroupVersion.Group,
+				Resource: v3.ClusterArchiveResourceName,
+				Verb:     "list",
+			},
+			User:   userInfo.GetName(),
+			Groups: userInfo.GetGroups(),
+			Extra:  extra,
+			UID:    userInfo.GetUID(),
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to create sar %s", err)
+	}
+	return response.Status.Allowed, nil
+}
@@ -0,0 +1,202 @@
+// Package clustergroups provides a HTTPHandler running an operation on all the clusters of a cluster group. This
+// handler should be registered at Endpoint
+package clustergroups
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/auth/util"
+	"github.com/rancher/rancher/pkg/controllers/dashboard/clusterindex"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	provcontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/sirupsen/logrus"
+	authzv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	authv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+const (
+	// Endpoint The endpoint that the operations on cluster groups are accessible at - used for routing
+	Endpoint  = "/v1/clustergroupoperations/{clustergroup}/{operation}"
+	logPrefix = "cluster-group-operations"
+
+	OperationRotateCertificates = "rotateCertificates"
+	OperationETCDSnapshot       = "etcdSnapshot"
+)
+
+// operations are the supported operations, which change the spec of a provisioning cluster to start them.
+var operations = map[string]func(rkeConfig *provv1.RKEConfig){
+	OperationRotateCertificates: func(rkeConfig *provv1.RKEConfig) {
+		var generation int64
+		if rkeConfig.RotateCertificates != nil {
+			generation = rkeConfig.RotateCertificates.Generation
+		}
+		rkeConfig.RotateCertificates = &rkev1.RotateCertificates{Generation: generation + 1}
+	},
+	OperationETCDSnapshot: func(rkeConfig *provv1.RKEConfig) {
+		var generation int
+		if rkeConfig.ETCDSnapshotCreate != nil {
+			generation = rkeConfig.ETCDSnapshotCreate.Generation
+		}
+		rkeConfig.ETCDSnapshotCreate = &rkev1.ETCDSnapshotCreate{Generation: generation + 1}
+	},
+}
+
+// Result is the result of an operation on the clusters of a cluster group.
+type Result struct {
+	ClusterGroupName string          `json:"clusterGroupName"`
+	Operation        string          `json:"operation"`
+	Clusters         []ClusterResult `json:"clusters"`
+}
+
+// ClusterResult is the result of an operation on a cluster, Error being empty if it was started.
+type ClusterResult struct {
+	ClusterName string `json:"clusterName"`
+	Error       string `json:"error,omitempty"`
+}
+
+// Handler implements http.Handler - and runs operations on the clusters of cluster groups
+type Handler struct {
+	ClusterGroups        mgmtcontrollers.ClusterGroupCache
+	ProvisioningClusters provcontrollers.ClusterClient
+	ProvisioningCache    provcontrollers.ClusterCache
+	SubjectAccessReviews authv1.SubjectAccessReviewInterface
+}
+
+// NewHandler creates a handler using the clients defined in scaledContext
+func NewHandler(scaledContext *config.ScaledContext) Handler {
+	return Handler{
+		ClusterGroups:        scaledContext.Wrangler.Mgmt.ClusterGroup().Cache(),
+		ProvisioningClusters: scaledContext.Wrangler.Provisioning.Cluster(),
+		ProvisioningCache:    scaledContext.Wrangler.Provisioning.Cluster().Cache(),
+		SubjectAccessReviews: scaledContext.K8sClient.AuthorizationV1().SubjectAccessReviews(),
+	}
+}
+
+// ServeHTTP implements http.Handler - runs the operation on each cluster of the cluster group the user can update, if
+// the user can get the cluster group
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	groupName, operationName := mux.Vars(req)["clustergroup"], mux.Vars(req)["operation"]
+	operation, ok := operations[operationName]
+	if !ok {
+		util.ReturnHTTPError(writer, req, http.StatusBadRequest, fmt.Sprintf("unknown operation %s, must be %s or %s", operationName, OperationRotateCertificates, OperationETCDSnapshot))
+		return
+	}
+
+	userInfo, ok := request.UserFrom(req.Context())
+	if !ok {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		logrus.Errorf("[%s] Failed to authorize user: unable to extract user info from context", logPrefix)
+		return
+	}
+	authorized, err := h.authorize(req, userInfo, &authzv1.ResourceAttributes{
+		Group:    v3.SchemeGroupVersion.Group,
+		Resource: v3.ClusterGroupResourceName,
+		Verb:     "get",
+		Name:     groupName,
+	})
+	if err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		logrus.Errorf("[%s] Failed to authorize user with error: %s", logPrefix, err.Error())
+		return
+	}
+	if !authorized {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		return
+	}
+
+	group, err := h.ClusterGroups.Get(groupName)
+	if apierrors.IsNotFound(err) {
+		util.ReturnHTTPError(writer, req, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	} else if err != nil {
+		logrus.Errorf("[%s] Error getting cluster group %s: %v", logPrefix, groupName, err)
+		util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	result := Result{
+		ClusterGroupName: group.Name,
+		Operation:        operationName,
+		Clusters:         []ClusterResult{},
+	}
+	for _, clusterName := range group.Status.Clusters {
+		clusterResult := ClusterResult{ClusterName: clusterName}
+		if err := h.run(req, userInfo, clusterName, operation); err != nil {
+			clusterResult.Error = err.Error()
+		}
+		result.Clusters = append(result.Clusters, clusterResult)
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(result); err != nil {
+		logrus.Warnf("[%s] Failed to write result of operation %s on cluster group %s: %v", logPrefix, operationName, groupName, err)
+	}
+}
+
+// run runs an operation on a cluster if the user can update its provisioning cluster.
+func (h *Handler) run(req *http.Request, userInfo user.Info, clusterName string, operation func(rkeConfig *provv1.RKEConfig)) error {
+	clusters, err := h.ProvisioningCache.GetByIndex(clusterindex.ClusterV1ByClusterV3Reference, clusterName)
+	if err != nil {
+		logrus.Errorf("[%s] Error getting cluster %s: %v", logPrefix, clusterName, err)
+		return fmt.Errorf("failed to get cluster")
+	}
+	if len(clusters) == 0 || clusters[0].Spec.RKEConfig == nil {
+		return fmt.Errorf("operation is only supported on RKE2 and K3s clusters provisioned by rancher")
+	}
+	cluster := clusters[0]
+
+	authorized, err := h.authorize(req, userInfo, &authzv1.ResourceAttributes{
+		Group:     provv1.SchemeGroupVersion.Group,
+		Resource:  "clusters",
+		Verb:      "update",
+		Namespace: cluster.Namespace,
+		Name:      cluster.Name,
+	})
+	if err != nil {
+		logrus.Errorf("[%s] Failed to authorize user with error: %s", logPrefix, err.Error())
+		return fmt.Errorf("failed to authorize user")
+	}
+	if !authorized {
+		return fmt.Errorf("not allowed to update the cluster")
+	}
+
+	cluster = cluster.DeepCopy()
+	operation(cluster.Spec.RKEConfig)
+	if _, err := h.ProvisioningClusters.Update(cluster); err != nil {
+		return fmt.Errorf("failed to update the cluster: %w", err)
+	}
+	return nil
+}
+
+// authorize checks to see if the user is allowed the access to the resource. Returns a bool (if the user is
+// authorized) and optionally an error
+func (h *Handler) authorize(r *http.Request, userInfo user.Info, attributes *authzv1.ResourceAttributes) (bool, error) {
+	extra := map[string]authzv1.ExtraValue{}
+	for k, v := range userInfo.GetExtra() {
+		extra[k] = authzv1.ExtraValue(v)
+	}
+	response, err := h.SubjectAccessReviews.Create(r.Context(), &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			ResourceAttributes: attributes,
+			User:               userInfo.GetName(),
+			Groups:             userInfo.GetGroups(),
+			Extra:              extra,
+			UID:                userInfo.GetUID(),
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to create sar %s", err)
+	}
+	return response.Status.Allowed, nil
+}
@@ -11,6 +11,7 @@
 	"github.com/rancher/rancher/pkg/auth/tokens"
 	"github.com/rancher/rancher/pkg/features"
 	"github.com/rancher/rancher/pkg/kubeconfig"
+	"github.com/rancher/rancher/pkg/securityevents"
 	"github.com/rancher/rancher/pkg/settings"
 	"github.com/rancher/rancher/pkg/user"
 	"github.com/rancher/wrangler/pkg/schemas/validation"
@@ -66,6 +67,13 @@ func (k kubeconfigDownload) ServeHTTP(rw http.ResponseWriter, req *http.Request)
 		apiRequest.WriteError(err)
 		return
 	}
+
+	event := securityevents.NewEvent(securityevents.KubeconfigDownload, securityevents.OutcomeSuccess, req)
+	event.User = userName.GetName()
+	event.Resource = "clusters/" + apiRequest.Name
+	event.Details = map[string]string{"tokenGenerated": fmt.Sprint(generateToken)}
+	securityevents.Emit(event)
+
 	apiRequest.WriteResponse(http.StatusOK, types.APIObject{
 		Type: "generateKubeconfigOutput",
 		Object: &GenerateKubeconfigOutput{
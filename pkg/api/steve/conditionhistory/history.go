@@ -0,0 +1,133 @@
+// Package conditionhistory provides a HTTPHandler serving the condition transitions recorded for a provisioning cluster
+// and its machines. This handler should be registered at Endpoint
+package conditionhistory
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/auth/util"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/conditionhistory"
+	"github.com/rancher/rancher/pkg/types/config"
+	corev1controllers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/sirupsen/logrus"
+	authzv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	authv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+const (
+	// Endpoint The endpoint that the condition history of a cluster is accessible at - used for routing
+	Endpoint  = "/v1/conditionhistory/{namespace}/{cluster}"
+	logPrefix = "condition-history"
+)
+
+// History is the condition history of a cluster, oldest transition first.
+type History struct {
+	Namespace string                   `json:"namespace"`
+	Cluster   string                   `json:"cluster"`
+	Entries   []conditionhistory.Entry `json:"entries"`
+}
+
+// Handler implements http.Handler - and serves the condition history of clusters
+type Handler struct {
+	ConfigMaps           corev1controllers.ConfigMapCache
+	SubjectAccessReviews authv1.SubjectAccessReviewInterface
+}
+
+// NewHandler creates a handler using the clients defined in scaledContext
+func NewHandler(scaledContext *config.ScaledContext) Handler {
+	return Handler{
+		ConfigMaps:           scaledContext.Wrangler.Core.ConfigMap().Cache(),
+		SubjectAccessReviews: scaledContext.K8sClient.AuthorizationV1().SubjectAccessReviews(),
+	}
+}
+
+// ServeHTTP implements http.Handler - returns the condition history of the cluster if the user can get the cluster.
+// The kind, name and type query parameters filter the transitions by the kind and name of the object and the type of
+// the condition.
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	vars := mux.Vars(req)
+	namespace, clusterName := vars["namespace"], vars["cluster"]
+
+	authorized, err := h.authorize(req, namespace, clusterName)
+	if err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		logrus.Errorf("[%s] Failed to authorize user with error: %s", logPrefix, err.Error())
+		return
+	}
+	if !authorized {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		return
+	}
+
+	cm, err := h.ConfigMaps.Get(namespace, conditionhistory.ConfigMapName(clusterName))
+	if err != nil && !apierrors.IsNotFound(err) {
+		logrus.Errorf("[%s] Error getting condition history of cluster %s/%s: %v", logPrefix, namespace, clusterName, err)
+		util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+	entries, err := conditionhistory.History(cm)
+	if err != nil {
+		logrus.Errorf("[%s] %v", logPrefix, err)
+		util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	history := History{
+		Namespace: namespace,
+		Cluster:   clusterName,
+		Entries:   []conditionhistory.Entry{},
+	}
+	query := req.URL.Query()
+	for _, entry := range entries {
+		if (query.Get("kind") != "" && query.Get("kind") != entry.Kind) ||
+			(query.Get("name") != "" && query.Get("name") != entry.Name) ||
+			(query.Get("type") != "" && query.Get("type") != entry.Type) {
+			continue
+		}
+		history.Entries = append(history.Entries, entry)
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(history); err != nil {
+		logrus.Warnf("[%s] Failed to write condition history of cluster %s/%s: %v", logPrefix, namespace, clusterName, err)
+	}
+}
+
+// authorize checks to see if the user can get the cluster. Returns a bool (if the user is authorized) and optionally an
+// error
+func (h *Handler) authorize(r *http.Request, namespace, clusterName string) (bool, error) {
+	userInfo, ok := request.UserFrom(r.Context())
+	if !ok {
+		return false, fmt.Errorf("unable to extract user info from context")
+	}
+	extra := map[string]authzv1.ExtraValue{}
+	for k, v := range userInfo.GetExtra() {
+		extra[k] = authzv1.ExtraValue(v)
+	}
+	response, err := h.SubjectAccessReviews.Create(r.Context(), &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Group:     rancherv1.SchemeGroupVersion.Group,
+				Resource:  "clusters",
+				Verb:      "get",
+				Namespace: namespace,
+				Name:      clusterName,
+			},
+			User:   userInfo.GetName(),
+			Groups: userInfo.GetGroups(),
+			Extra:  extra,
+			UID:    userInfo.GetUID(),
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to create sar %s", err)
+	}
+	return response.Status.Allowed, nil
+}
@@ -0,0 +1,110 @@
+// Package controllerdiagnostics provides a HTTPHandler summarizing the workqueues of the controllers of this rancher
+// replica, the controllers with the most failing reconciles first, so that admins can tell which controllers are
+// struggling. This handler should be registered at Endpoint
+package controllerdiagnostics
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/rancher/rancher/pkg/auth/util"
+	"github.com/rancher/rancher/pkg/metrics"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/sirupsen/logrus"
+	authzv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	authv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+const (
+	// Endpoint The endpoint that the diagnostics of the controllers are accessible at - used for routing
+	Endpoint  = "/v1/controllerdiagnostics"
+	logPrefix = "controller-diagnostics"
+
+	defaultLimit = 10
+)
+
+// Diagnostics are the summaries of the controllers with the most failing reconciles.
+type Diagnostics struct {
+	// RecentRetriesMinutes is the period over which the recent retries of the controllers are counted.
+	RecentRetriesMinutes int                         `json:"recentRetriesMinutes"`
+	Controllers          []metrics.ControllerSummary `json:"controllers"`
+}
+
+// Handler implements http.Handler - and summarizes the workqueues of the controllers
+type Handler struct {
+	SubjectAccessReviews authv1.SubjectAccessReviewInterface
+}
+
+// NewHandler creates a handler using the clients defined in scaledContext
+func NewHandler(scaledContext *config.ScaledContext) Handler {
+	return Handler{
+		SubjectAccessReviews: scaledContext.K8sClient.AuthorizationV1().SubjectAccessReviews(),
+	}
+}
+
+// ServeHTTP implements http.Handler - returns the summaries of the controllers with the most failing reconciles, at
+// most the number in the limit query parameter, if the user can get the rancher metrics
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	limit := defaultLimit
+	if value := req.URL.Query().Get("limit"); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 0 {
+			util.ReturnHTTPError(writer, req, http.StatusBadRequest, fmt.Sprintf("invalid limit %s", value))
+			return
+		}
+	}
+
+	authorized, err := h.authorize(req)
+	if err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		logrus.Errorf("[%s] Failed to authorize user with error: %s", logPrefix, err.Error())
+		return
+	}
+	if !authorized {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(Diagnostics{
+		RecentRetriesMinutes: metrics.RecentRetriesMinutes,
+		Controllers:          metrics.TopControllers(limit),
+	}); err != nil {
+		logrus.Warnf("[%s] Failed to write diagnostics: %v", logPrefix, err)
+	}
+}
+
+// authorize checks to see if the user can get the rancher metrics. Returns a bool (if the user is authorized) and
+// optionally an error
+func (h *Handler) authorize(r *http.Request) (bool, error) {
+	userInfo, ok := request.UserFrom(r.Context())
+	if !ok {
+		return false, fmt.Errorf("unable to extract user info from context")
+	}
+	extra := map[string]authzv1.ExtraValue{}
+	for k, v := range userInfo.GetExtra() {
+		extra[k] = authzv1.ExtraValue(v)
+	}
+	response, err := h.SubjectAccessReviews.Create(r.Context(), &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Group:    "management.cattle.io",
+				Resource: "ranchermetrics",
+				Verb:     "get",
+			},
+			User:   userInfo.GetName(),
+			Groups: userInfo.GetGroups(),
+			Extra:  extra,
+			UID:    userInfo.GetUID(),
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to create sar %s", err)
+	}
+	return response.Status.Allowed, nil
+}
@@ -0,0 +1,114 @@
+// Package controlplaneadvisor provides a HTTPHandler serving the machine size changes recommended for the control plane
+// machine pools of a cluster. This handler should be registered at Endpoint
+package controlplaneadvisor
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/auth/util"
+	"github.com/rancher/rancher/pkg/controllers/dashboard/clusterindex"
+	"github.com/rancher/rancher/pkg/controlplaneadvisor"
+	provcontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/sirupsen/logrus"
+	authzv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	authv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+const (
+	// Endpoint The endpoint that the recommendations for a cluster are accessible at - used for routing
+	Endpoint  = "/v1/controlplaneadvisor/clusters/{cluster}"
+	logPrefix = "control-plane-advisor"
+)
+
+// Handler implements http.Handler - and serves the recommendations for clusters
+type Handler struct {
+	ProvisioningClusters provcontrollers.ClusterCache
+	SubjectAccessReviews authv1.SubjectAccessReviewInterface
+	Advisor              *controlplaneadvisor.Advisor
+}
+
+// NewHandler creates a handler using the clients defined in scaledContext
+func NewHandler(scaledContext *config.ScaledContext) Handler {
+	return Handler{
+		ProvisioningClusters: scaledContext.Wrangler.Provisioning.Cluster().Cache(),
+		SubjectAccessReviews: scaledContext.K8sClient.AuthorizationV1().SubjectAccessReviews(),
+		Advisor:              controlplaneadvisor.NewAdvisor(scaledContext.Wrangler),
+	}
+}
+
+// ServeHTTP implements http.Handler - returns the recommendations for the cluster if the user can get the cluster
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	clusterName := mux.Vars(req)["cluster"]
+
+	authorized, err := h.authorize(req, clusterName)
+	if err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		logrus.Errorf("[%s] Failed to authorize user with error: %s", logPrefix, err.Error())
+		return
+	}
+	if !authorized {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		return
+	}
+
+	clusters, err := h.ProvisioningClusters.GetByIndex(clusterindex.ClusterV1ByClusterV3Reference, clusterName)
+	if err != nil {
+		logrus.Errorf("[%s] Error getting cluster %s: %v", logPrefix, clusterName, err)
+		util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+	if len(clusters) == 0 {
+		util.ReturnHTTPError(writer, req, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	advice, err := h.Advisor.Advise(clusters[0])
+	if err != nil {
+		logrus.Errorf("[%s] Error making recommendations for cluster %s: %v", logPrefix, clusterName, err)
+		util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(advice); err != nil {
+		logrus.Warnf("[%s] Failed to write recommendations for cluster %s: %v", logPrefix, clusterName, err)
+	}
+}
+
+// authorize checks to see if the user can get the cluster. Returns a bool (if the user is authorized) and optionally an
+// error
+func (h *Handler) authorize(r *http.Request, clusterName string) (bool, error) {
+	userInfo, ok := request.UserFrom(r.Context())
+	if !ok {
+		return false, fmt.Errorf("unable to extract user info from context")
+	}
+	extra := map[string]authzv1.ExtraValue{}
+	for k, v := range userInfo.GetExtra() {
+		extra[k] = authzv1.ExtraValue(v)
+	}
+	response, err := h.SubjectAccessReviews.Create(r.Context(), &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Group:    v3.SchemeGroupVersion.Group,
+				Resource: v3.ClusterResourceName,
+				Verb:     "get",
+				Name:     clusterName,
+			},
+			User:   userInfo.GetName(),
+			Groups: userInfo.GetGroups(),
+			Extra:  extra,
+			UID:    userInfo.GetUID(),
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to create sar %s", err)
+	}
+	return response.Status.Allowed, nil
+}
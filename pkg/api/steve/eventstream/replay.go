@@ -0,0 +1,149 @@
+// Package eventstream provides a HTTPHandler replaying the retained management events to an event subscription. This
+// handler should be registered at Endpoint
+package eventstream
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/auth/util"
+	"github.com/rancher/rancher/pkg/eventstream"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/sirupsen/logrus"
+	authzv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	authv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// Endpoint The endpoint that the replay of event subscriptions is accessible at - used for routing
+	Endpoint  = "/v1/eventsubscriptionreplay/{subscription}"
+	logPrefix = "event-subscription-replay"
+)
+
+// Result is the result of replaying the events to an event subscription.
+type Result struct {
+	SubscriptionName string `json:"subscriptionName"`
+	Since            string `json:"since"`
+	// Events is the number of retained events recorded since the time, delivered again if the subscription matches
+	// them.
+	Events int `json:"events"`
+}
+
+// Handler implements http.Handler - and replays events to event subscriptions
+type Handler struct {
+	EventSubscriptions   mgmtcontrollers.EventSubscriptionClient
+	ManagementEvents     mgmtcontrollers.ManagementEventCache
+	SubjectAccessReviews authv1.SubjectAccessReviewInterface
+}
+
+// NewHandler creates a handler using the clients defined in scaledContext
+func NewHandler(scaledContext *config.ScaledContext) Handler {
+	return Handler{
+		EventSubscriptions:   scaledContext.Wrangler.Mgmt.EventSubscription(),
+		ManagementEvents:     scaledContext.Wrangler.Mgmt.ManagementEvent().Cache(),
+		SubjectAccessReviews: scaledContext.K8sClient.AuthorizationV1().SubjectAccessReviews(),
+	}
+}
+
+// ServeHTTP implements http.Handler - delivers the retained events recorded since the RFC3339 time of the since query
+// parameter to the event subscription again, if the user can update the event subscription
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	subscriptionName := mux.Vars(req)["subscription"]
+	since, err := time.Parse(time.RFC3339Nano, req.URL.Query().Get("since"))
+	if err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusBadRequest, "since must be an RFC3339 time")
+		return
+	}
+
+	userInfo, ok := request.UserFrom(req.Context())
+	if !ok {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		logrus.Errorf("[%s] Failed to authorize user: unable to extract user info from context", logPrefix)
+		return
+	}
+	authorized, err := h.authorize(req, userInfo, subscriptionName)
+	if err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		logrus.Errorf("[%s] Failed to authorize user with error: %s", logPrefix, err.Error())
+		return
+	}
+	if !authorized {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		return
+	}
+
+	// the subscription delivers the events after its last delivered event, which sorts before all events at since
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		subscription, err := h.EventSubscriptions.Get(subscriptionName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		subscription = subscription.DeepCopy()
+		subscription.Status.LastDeliveredTime = since.UTC().Format(time.RFC3339Nano)
+		subscription.Status.LastDeliveredEvent = ""
+		_, err = h.EventSubscriptions.UpdateStatus(subscription)
+		return err
+	})
+	if apierrors.IsNotFound(err) {
+		util.ReturnHTTPError(writer, req, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	} else if err != nil {
+		logrus.Errorf("[%s] Error replaying events to event subscription %s: %v", logPrefix, subscriptionName, err)
+		util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	result := Result{
+		SubscriptionName: subscriptionName,
+		Since:            since.UTC().Format(time.RFC3339Nano),
+	}
+	events, err := h.ManagementEvents.List(labels.Everything())
+	if err != nil {
+		logrus.Warnf("[%s] Failed to count events to replay: %v", logPrefix, err)
+	}
+	pending, _ := eventstream.Pending(events, v3.EventSubscriptionStatus{LastDeliveredTime: result.Since}, time.Now())
+	result.Events = len(pending)
+
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(result); err != nil {
+		logrus.Warnf("[%s] Failed to write result of replay to event subscription %s: %v", logPrefix, subscriptionName, err)
+	}
+}
+
+// authorize checks to see if the user can update the event subscription. Returns a bool (if the user is authorized)
+// and optionally an error
+func (h *Handler) authorize(r *http.Request, userInfo user.Info, subscriptionName string) (bool, error) {
+	extra := map[string]authzv1.ExtraValue{}
+	for k, v := range userInfo.GetExtra() {
+		extra[k] = authzv1.ExtraValue(v)
+	}
+	response, err := h.SubjectAccessReviews.Create(r.Context(), &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Group:    v3.SchemeGroupVersion.Group,
+				Resource: v3.EventSubscriptionResourceName,
+				Verb:     "update",
+				Name:     subscriptionName,
+			},
+			User:   userInfo.GetName(),
+			Groups: userInfo.GetGroups(),
+			Extra:  extra,
+			UID:    userInfo.GetUID(),
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to create sar %s", err)
+	}
+	return response.Status.Allowed, nil
+}
@@ -0,0 +1,146 @@
+// Package landingpreferences provides a HTTPHandler for users to read and set their landing preference: the default
+// cluster, the favorite projects and the pinned namespaces the UI and CLI land on. This handler should be registered at
+// Endpoint
+package landingpreferences
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/auth/util"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/landingpreference"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+const (
+	// Endpoint The endpoint that the landing preference of the user is accessible at - used for routing
+	Endpoint  = "/v1/landingpreferences"
+	logPrefix = "landing-preferences"
+
+	maxBodySize = 1 << 20
+)
+
+// Input is the landing preference a user sets, its empty fields inherited from the default preferences of the groups
+// of the user.
+type Input struct {
+	DefaultClusterName string   `json:"defaultClusterName"`
+	FavoriteProjects   []string `json:"favoriteProjects"`
+	PinnedNamespaces   []string `json:"pinnedNamespaces"`
+}
+
+// Handler implements http.Handler - and serves the landing preference of the user making the request
+type Handler struct {
+	LandingPreferences     mgmtcontrollers.LandingPreferenceClient
+	LandingPreferenceCache mgmtcontrollers.LandingPreferenceCache
+	UserCache              mgmtcontrollers.UserCache
+}
+
+// NewHandler creates a handler using the clients defined in scaledContext
+func NewHandler(scaledContext *config.ScaledContext) Handler {
+	return Handler{
+		LandingPreferences:     scaledContext.Wrangler.Mgmt.LandingPreference(),
+		LandingPreferenceCache: scaledContext.Wrangler.Mgmt.LandingPreference().Cache(),
+		UserCache:              scaledContext.Wrangler.Mgmt.User().Cache(),
+	}
+}
+
+// ServeHTTP implements http.Handler - returns the landing preference of the user, resolved from the preference of the
+// user and the default preferences of the groups of the user, on GET, sets the preference of the user on PUT and
+// removes it on DELETE, the user then landing on the defaults of the groups.
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	userInfo, ok := request.UserFrom(req.Context())
+	if !ok {
+		util.ReturnHTTPError(writer, req, http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+		return
+	}
+	user, err := h.UserCache.Get(userInfo.GetName())
+	if err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, "landing preferences are only available to users")
+		return
+	}
+
+	preferences, err := h.LandingPreferenceCache.List(labels.Everything())
+	if err != nil {
+		logrus.Errorf("[%s] Error listing landing preferences: %v", logPrefix, err)
+		util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+	userPreference, groupPreferences := landingpreference.Select(preferences, user.Name, userInfo.GetGroups())
+
+	switch req.Method {
+	case http.MethodPut:
+		var input Input
+		if err := json.NewDecoder(http.MaxBytesReader(writer, req.Body, maxBodySize)).Decode(&input); err != nil {
+			util.ReturnHTTPError(writer, req, http.StatusBadRequest, fmt.Sprintf("failed to parse landing preference: %v", err))
+			return
+		}
+		spec := v3.LandingPreferenceSpec{
+			UserName:           user.Name,
+			DefaultClusterName: input.DefaultClusterName,
+			FavoriteProjects:   input.FavoriteProjects,
+			PinnedNamespaces:   input.PinnedNamespaces,
+		}
+		if err := landingpreference.Validate(spec); err != nil {
+			util.ReturnHTTPError(writer, req, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		userPreference, err = h.save(user, spec)
+		if err != nil {
+			logrus.Errorf("[%s] Error saving the landing preference of user %s: %v", logPrefix, user.Name, err)
+			util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+	case http.MethodDelete:
+		err := h.LandingPreferences.Delete(landingpreference.Name(user.Name), &metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			logrus.Errorf("[%s] Error removing the landing preference of user %s: %v", logPrefix, user.Name, err)
+			util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+		userPreference = nil
+	}
+	result := landingpreference.Resolve(userPreference, groupPreferences)
+
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(result); err != nil {
+		logrus.Warnf("[%s] Failed to write landing preference: %v", logPrefix, err)
+	}
+}
+
+// save creates or updates the preference of the user, owned by the user so that it's removed with the user.
+func (h *Handler) save(user *v3.User, spec v3.LandingPreferenceSpec) (*v3.LandingPreference, error) {
+	name := landingpreference.Name(user.Name)
+	existing, err := h.LandingPreferences.Get(name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return h.LandingPreferences.Create(&v3.LandingPreference{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: v3.SchemeGroupVersion.String(),
+						Kind:       "User",
+						Name:       user.Name,
+						UID:        user.UID,
+					},
+				},
+			},
+			Spec: spec,
+		})
+	} else if err != nil {
+		return nil, err
+	}
+	if existing.Spec.UserName != user.Name {
+		return nil, fmt.Errorf("landing preference %s isn't the preference of the user", name)
+	}
+	existing = existing.DeepCopy()
+	existing.Spec = spec
+	return h.LandingPreferences.Update(existing)
+}
@@ -8,6 +8,7 @@
 	"github.com/rancher/rancher/pkg/wrangler"
 	schema2 "github.com/rancher/steve/pkg/schema"
 	steve "github.com/rancher/steve/pkg/server"
+	"github.com/rancher/wrangler/pkg/schemas"
 )
 
 func Register(server *steve.Server, clients *wrangler.Context) {
@@ -15,6 +16,17 @@ func Register(server *steve.Server, clients *wrangler.Context) {
 		machines: clients.CAPI.Machine(),
 		secrets:  clients.Core.Secret(),
 	}
+	maintenance := &maintenance{
+		machines: clients.CAPI.Machine(),
+	}
+	replacement := &replacement{
+		machines: clients.CAPI.Machine(),
+	}
+
+	server.BaseSchemas.MustImportAndCustomize(DrainInput{}, nil)
+	server.BaseSchemas.MustImportAndCustomize(NodeMaintenanceOutput{}, nil)
+	server.BaseSchemas.MustImportAndCustomize(ReplaceInput{}, nil)
+	server.BaseSchemas.MustImportAndCustomize(MachineReplacementOutput{}, nil)
 
 	server.SchemaFactory.AddTemplate(schema2.Template{
 		Group: "cluster.x-k8s.io",
@@ -25,12 +37,47 @@ func Register(server *steve.Server, clients *wrangler.Context) {
 			}
 			schema.LinkHandlers["shell"] = sshClient
 			schema.LinkHandlers["sshkeys"] = sshClient
+			if schema.ActionHandlers == nil {
+				schema.ActionHandlers = map[string]http.Handler{}
+			}
+			schema.ActionHandlers["cordon"] = maintenance
+			schema.ActionHandlers["drain"] = maintenance
+			schema.ActionHandlers["uncordon"] = maintenance
+			schema.ActionHandlers["replace"] = replacement
+			if schema.ResourceActions == nil {
+				schema.ResourceActions = map[string]schemas.Action{}
+			}
+			schema.ResourceActions["cordon"] = schemas.Action{
+				Output: "nodeMaintenanceOutput",
+			}
+			schema.ResourceActions["drain"] = schemas.Action{
+				Input:  "drainInput",
+				Output: "nodeMaintenanceOutput",
+			}
+			schema.ResourceActions["uncordon"] = schemas.Action{
+				Output: "nodeMaintenanceOutput",
+			}
+			schema.ResourceActions["replace"] = schemas.Action{
+				Input:  "replaceInput",
+				Output: "machineReplacementOutput",
+			}
 			schema.Formatter = func(request *types.APIRequest, resource *types.RawResource) {
-				if err := request.AccessControl.CanUpdate(request, types.APIObject{}, request.Schema); err != nil ||
-					resource.APIObject.Data().String("spec", "infrastructureRef", "apiVersion") != capr.RKEMachineAPIVersion {
+				canUpdate := request.AccessControl.CanUpdate(request, types.APIObject{}, request.Schema) == nil
+				if !canUpdate || resource.APIObject.Data().String("spec", "infrastructureRef", "apiVersion") != capr.RKEMachineAPIVersion {
 					delete(resource.Links, "shell")
 					delete(resource.Links, "sshkeys")
 				}
+				if !canUpdate || resource.APIObject.Data().String("spec", "infrastructureRef", "apiVersion") != capr.RKEMachineAPIVersion ||
+					resource.APIObject.Data().String("metadata", "labels", capr.RKEMachinePoolNameLabel) == "" ||
+					resource.APIObject.Data().String("status", "nodeRef", "name") == "" {
+					delete(resource.Actions, "replace")
+				}
+				if !canUpdate || resource.APIObject.Data().String("spec", "bootstrap", "configRef", "kind") != "RKEBootstrap" ||
+					resource.APIObject.Data().String("status", "nodeRef", "name") == "" {
+					delete(resource.Actions, "cordon")
+					delete(resource.Actions, "drain")
+					delete(resource.Actions, "uncordon")
+				}
 			}
 		},
 	})
@@ -0,0 +1,137 @@
+package machine
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rancher/apiserver/pkg/apierror"
+	"github.com/rancher/apiserver/pkg/types"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
+	"github.com/rancher/wrangler/pkg/schemas/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// maintenance handles the cordon, drain and uncordon actions of machines. The action is recorded on the machine, and
+// performed on its node by the machine drain controller, which reports the progress in the
+// rke.cattle.io/node-maintenance-status annotation of the machine.
+type maintenance struct {
+	machines capicontrollers.MachineClient
+}
+
+func (m *maintenance) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	apiRequest := types.GetAPIContext(req.Context())
+	if err := apiRequest.AccessControl.CanUpdate(apiRequest, types.APIObject{}, apiRequest.Schema); err != nil {
+		apiRequest.WriteError(err)
+		return
+	}
+
+	request, err := maintenanceRequest(apiRequest.Action, req.Body)
+	if err != nil {
+		apiRequest.WriteError(err)
+		return
+	}
+
+	if err := m.requestMaintenance(apiRequest.Namespace, apiRequest.Name, request); err != nil {
+		apiRequest.WriteError(err)
+		return
+	}
+
+	apiRequest.WriteResponse(http.StatusAccepted, types.APIObject{
+		Type: "nodeMaintenanceOutput",
+		Object: &NodeMaintenanceOutput{
+			ID:     request.ID,
+			Action: string(request.Action),
+		},
+	})
+}
+
+// requestMaintenance records the request on the machine, replacing any previous request.
+func (m *maintenance) requestMaintenance(namespace, name string, request *rkev1.NodeMaintenance) error {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		machine, err := m.machines.Get(namespace, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if err := maintainable(machine); err != nil {
+			return err
+		}
+		machine = machine.DeepCopy()
+		if machine.Annotations == nil {
+			machine.Annotations = map[string]string{}
+		}
+		machine.Annotations[capr.MaintenanceAnnotation] = string(data)
+		_, err = m.machines.Update(machine)
+		return err
+	})
+}
+
+// maintainable returns an error if the node of the machine can't be cordoned, drained or uncordoned.
+func maintainable(machine *capi.Machine) error {
+	if machine.Spec.Bootstrap.ConfigRef == nil || machine.Spec.Bootstrap.ConfigRef.Kind != "RKEBootstrap" {
+		return apierror.NewAPIError(validation.InvalidAction, fmt.Sprintf("machine %s/%s is not provisioned by rancher", machine.Namespace, machine.Name))
+	}
+	if !machine.DeletionTimestamp.IsZero() {
+		return apierror.NewAPIError(validation.InvalidAction, fmt.Sprintf("machine %s/%s is being deleted", machine.Namespace, machine.Name))
+	}
+	if machine.Status.NodeRef == nil {
+		return apierror.NewAPIError(validation.InvalidAction, fmt.Sprintf("machine %s/%s has no node", machine.Namespace, machine.Name))
+	}
+	return nil
+}
+
+// maintenanceRequest returns the node maintenance requested by the action, whose body holds the options of a drain.
+func maintenanceRequest(action string, body io.Reader) (*rkev1.NodeMaintenance, error) {
+	now := time.Now()
+	request := &rkev1.NodeMaintenance{
+		ID:          strconv.FormatInt(now.UnixNano(), 36),
+		Action:      rkev1.NodeMaintenanceAction(action),
+		RequestedAt: metav1.NewTime(now),
+	}
+
+	switch request.Action {
+	case rkev1.NodeMaintenanceActionCordon, rkev1.NodeMaintenanceActionUncordon:
+		return request, nil
+	case rkev1.NodeMaintenanceActionDrain:
+	default:
+		return nil, apierror.NewAPIError(validation.InvalidAction, fmt.Sprintf("unknown action %s", action))
+	}
+
+	input := DrainInput{}
+	if body != nil {
+		if err := json.NewDecoder(body).Decode(&input); err != nil && err != io.EOF {
+			return nil, apierror.NewAPIError(validation.InvalidBodyContent, fmt.Sprintf("failed to parse drain options: %v", err))
+		}
+	}
+	gracePeriod := -1
+	if input.GracePeriod != nil {
+		gracePeriod = *input.GracePeriod
+	}
+	if gracePeriod < -1 || input.Timeout < 0 || input.SkipWaitForDeleteTimeoutSeconds < 0 {
+		return nil, apierror.NewAPIError(validation.InvalidBodyContent, "grace period must be at least -1, timeout and skip wait for delete timeout must not be negative")
+	}
+
+	request.DrainOptions = &rkev1.DrainOptions{
+		Enabled:                         true,
+		Force:                           input.Force,
+		IgnoreDaemonSets:                input.IgnoreDaemonSets,
+		DeleteEmptyDirData:              input.DeleteEmptyDirData,
+		DisableEviction:                 input.DisableEviction,
+		GracePeriod:                     gracePeriod,
+		Timeout:                         input.Timeout,
+		SkipWaitForDeleteTimeoutSeconds: input.SkipWaitForDeleteTimeoutSeconds,
+	}
+	return request, nil
+}
@@ -0,0 +1,105 @@
+package machine
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rancher/apiserver/pkg/apierror"
+	"github.com/rancher/apiserver/pkg/types"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
+	"github.com/rancher/wrangler/pkg/schemas/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// replacement handles the replace action of machines. The request is recorded on the machine, and the machine pool is
+// scaled up by one until the substitute is ready, the node of the machine is drained and its data volumes are moved to
+// the substitute, and the machine is deleted, by the machine-replacement controllers.
+type replacement struct {
+	machines capicontrollers.MachineClient
+}
+
+func (r *replacement) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	apiRequest := types.GetAPIContext(req.Context())
+	if err := apiRequest.AccessControl.CanUpdate(apiRequest, types.APIObject{}, apiRequest.Schema); err != nil {
+		apiRequest.WriteError(err)
+		return
+	}
+
+	input := ReplaceInput{}
+	if err := json.NewDecoder(req.Body).Decode(&input); err != nil && err != io.EOF {
+		apiRequest.WriteError(apierror.NewAPIError(validation.InvalidBodyContent, fmt.Sprintf("failed to parse replace options: %v", err)))
+		return
+	}
+
+	now := time.Now()
+	request := &rkev1.MachineReplacement{
+		ID:          strconv.FormatInt(now.UnixNano(), 36),
+		Force:       input.Force,
+		RequestedAt: metav1.NewTime(now),
+	}
+	if err := r.requestReplacement(apiRequest.Namespace, apiRequest.Name, request); err != nil {
+		apiRequest.WriteError(err)
+		return
+	}
+
+	apiRequest.WriteResponse(http.StatusAccepted, types.APIObject{
+		Type:   "machineReplacementOutput",
+		Object: &MachineReplacementOutput{ID: request.ID},
+	})
+}
+
+// requestReplacement records the request on the machine. A request for a machine already being replaced keeps the
+// time of the first request, so that the substitute provisioned for it is kept.
+func (r *replacement) requestReplacement(namespace, name string, request *rkev1.MachineReplacement) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		machine, err := r.machines.Get(namespace, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if err := replaceable(machine); err != nil {
+			return err
+		}
+		if data := machine.Annotations[capr.MachineReplacementAnnotation]; data != "" {
+			var previous rkev1.MachineReplacement
+			if err := json.Unmarshal([]byte(data), &previous); err == nil {
+				request.RequestedAt = previous.RequestedAt
+			}
+		}
+		data, err := json.Marshal(request)
+		if err != nil {
+			return err
+		}
+
+		machine = machine.DeepCopy()
+		if machine.Annotations == nil {
+			machine.Annotations = map[string]string{}
+		}
+		machine.Annotations[capr.MachineReplacementAnnotation] = string(data)
+		delete(machine.Annotations, capr.ReplacementErrorAnnotation)
+		_, err = r.machines.Update(machine)
+		return err
+	})
+}
+
+// replaceable returns an error if the machine can't be replaced: only the machines of the machine pools provisioned by
+// a node driver have a substitute provisioned for them.
+func replaceable(machine *capi.Machine) error {
+	if machine.Labels[capr.RKEMachinePoolNameLabel] == "" || machine.Spec.InfrastructureRef.APIVersion != capr.RKEMachineAPIVersion {
+		return apierror.NewAPIError(validation.InvalidAction, fmt.Sprintf("machine %s/%s is not part of a machine pool provisioned by rancher", machine.Namespace, machine.Name))
+	}
+	if !machine.DeletionTimestamp.IsZero() || machine.Annotations[capi.DeleteMachineAnnotation] != "" {
+		return apierror.NewAPIError(validation.InvalidAction, fmt.Sprintf("machine %s/%s is being deleted", machine.Namespace, machine.Name))
+	}
+	if machine.Status.NodeRef == nil {
+		return apierror.NewAPIError(validation.InvalidAction, fmt.Sprintf("machine %s/%s has no node", machine.Namespace, machine.Name))
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package machine
+
+type DrainInput struct {
+	Force                           bool  `json:"force,omitempty"`
+	IgnoreDaemonSets                *bool `json:"ignoreDaemonSets,omitempty"`
+	DeleteEmptyDirData              bool  `json:"deleteEmptyDirData,omitempty"`
+	DisableEviction                 bool  `json:"disableEviction,omitempty"`
+	GracePeriod                     *int  `json:"gracePeriod,omitempty"`
+	Timeout                         int   `json:"timeout,omitempty"`
+	SkipWaitForDeleteTimeoutSeconds int   `json:"skipWaitForDeleteTimeoutSeconds,omitempty"`
+}
+
+type NodeMaintenanceOutput struct {
+	ID     string `json:"id,omitempty"`
+	Action string `json:"action,omitempty"`
+}
+
+type ReplaceInput struct {
+	Force bool `json:"force,omitempty"`
+}
+
+type MachineReplacementOutput struct {
+	ID string `json:"id,omitempty"`
+}
@@ -0,0 +1,119 @@
+// Package managementgc provides a HTTPHandler listing and deleting the orphaned objects of the management cluster. This
+// handler should be registered at Endpoint
+package managementgc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rancher/rancher/pkg/auth/util"
+	"github.com/rancher/rancher/pkg/managementgc"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/rancher/rancher/pkg/wrangler"
+	"github.com/sirupsen/logrus"
+	authzv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	authv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+const (
+	// Endpoint The endpoint that the collection of orphaned objects is accessible at - used for routing
+	Endpoint  = "/v1/managementgc"
+	logPrefix = "management-gc"
+)
+
+// Handler implements http.Handler - and lists or deletes the orphaned objects of the management cluster
+type Handler struct {
+	Wrangler             *wrangler.Context
+	SubjectAccessReviews authv1.SubjectAccessReviewInterface
+}
+
+// NewHandler creates a handler using the clients defined in scaledContext
+func NewHandler(scaledContext *config.ScaledContext) Handler {
+	return Handler{
+		Wrangler:             scaledContext.Wrangler,
+		SubjectAccessReviews: scaledContext.K8sClient.AuthorizationV1().SubjectAccessReviews(),
+	}
+}
+
+// ServeHTTP implements http.Handler - lists the orphaned objects of the categories of the category query parameters,
+// all categories if there are none, on GET, and deletes them on POST unless the dryRun query parameter is true. Only
+// admins are allowed to list or delete them.
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	categories := query["category"]
+	if err := managementgc.ValidateCategories(categories); err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusBadRequest, err.Error())
+		return
+	}
+	dryRun := req.Method != http.MethodPost || query.Get("dryRun") == "true"
+
+	userInfo, ok := request.UserFrom(req.Context())
+	if !ok {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		logrus.Errorf("[%s] Failed to authorize user: unable to extract user info from context", logPrefix)
+		return
+	}
+	authorized, err := h.authorize(req, userInfo)
+	if err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		logrus.Errorf("[%s] Failed to authorize user with error: %s", logPrefix, err.Error())
+		return
+	}
+	if !authorized {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		return
+	}
+
+	collector, err := managementgc.NewCollector(h.Wrangler)
+	if err != nil {
+		logrus.Errorf("[%s] Failed to create collector: %v", logPrefix, err)
+		util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+	result, err := collector.Collect(req.Context(), categories, dryRun, time.Now())
+	if err != nil {
+		logrus.Errorf("[%s] Failed to collect orphaned objects: %v", logPrefix, err)
+		util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+	for _, candidate := range result.Candidates {
+		if candidate.Deleted {
+			logrus.Infof("[%s] %s deleted %s %s/%s: %s", logPrefix, userInfo.GetName(), candidate.Kind, candidate.Namespace, candidate.Name, candidate.Reason)
+		}
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(result); err != nil {
+		logrus.Warnf("[%s] Failed to write orphaned objects: %v", logPrefix, err)
+	}
+}
+
+// authorize checks to see if the user is an admin. Returns a bool (if the user is authorized) and optionally an error
+func (h *Handler) authorize(r *http.Request, userInfo user.Info) (bool, error) {
+	extra := map[string]authzv1.ExtraValue{}
+	for k, v := range userInfo.GetExtra() {
+		extra[k] = authzv1.ExtraValue(v)
+	}
+	response, err := h.SubjectAccessReviews.Create(r.Context(), &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Group:    "*",
+				Resource: "*",
+				Verb:     "*",
+			},
+			User:   userInfo.GetName(),
+			Groups: userInfo.GetGroups(),
+			Extra:  extra,
+			UID:    userInfo.GetUID(),
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to create sar %s", err)
+	}
+	return response.Status.Allowed, nil
+}
@@ -0,0 +1,177 @@
+// Package metering provides a HTTPHandler exporting the usage recorded in metering records as JSON or CSV for
+// chargeback. This handler should be registered at Endpoint
+package metering
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/auth/util"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/metering"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/sirupsen/logrus"
+	authzv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	authv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+const (
+	// Endpoint The endpoint that the metering export is accessible at - used for routing
+	Endpoint  = "/v1/metering/export"
+	logPrefix = "metering-export"
+
+	formatJSON = "json"
+	formatCSV  = "csv"
+)
+
+// Export is the usage of clusters, teams or projects over the periods of the metering records exported.
+type Export struct {
+	Aggregation string         `json:"aggregation"`
+	GroupBy     string         `json:"groupBy"`
+	Rows        []metering.Row `json:"rows"`
+}
+
+// Handler implements http.Handler - and exports metering records
+type Handler struct {
+	MeteringRecords      mgmtcontrollers.MeteringRecordCache
+	SubjectAccessReviews authv1.SubjectAccessReviewInterface
+}
+
+// NewHandler creates a handler using the clients defined in scaledContext
+func NewHandler(scaledContext *config.ScaledContext) Handler {
+	return Handler{
+		MeteringRecords:      scaledContext.Wrangler.Mgmt.MeteringRecord().Cache(),
+		SubjectAccessReviews: scaledContext.K8sClient.AuthorizationV1().SubjectAccessReviews(),
+	}
+}
+
+// ServeHTTP implements http.Handler - exports the usage of the metering records of the periods starting from the from
+// query parameter and before the to query parameter, as RFC3339 times or dates, grouped by cluster, team or project
+// according to the groupBy query parameter, as json or csv according to the format query parameter, if the user can
+// list metering records.
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	from, err := parseTime(query.Get("from"))
+	if err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusBadRequest, fmt.Sprintf("invalid from: %v", err))
+		return
+	}
+	to, err := parseTime(query.Get("to"))
+	if err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusBadRequest, fmt.Sprintf("invalid to: %v", err))
+		return
+	}
+	groupBy := query.Get("groupBy")
+	if groupBy == "" {
+		groupBy = metering.GroupByCluster
+	}
+	format := query.Get("format")
+	if format == "" {
+		format = formatJSON
+	}
+	if format != formatJSON && format != formatCSV {
+		util.ReturnHTTPError(writer, req, http.StatusBadRequest, fmt.Sprintf("invalid format %q, must be %s or %s", format, formatJSON, formatCSV))
+		return
+	}
+	aggregation := query.Get("aggregation")
+	if aggregation == "" {
+		aggregation = settings.MeteringAggregation.Get()
+	}
+
+	userInfo, ok := request.UserFrom(req.Context())
+	if !ok {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		logrus.Errorf("[%s] Failed to authorize user: unable to extract user info from context", logPrefix)
+		return
+	}
+	authorized, err := h.authorize(req, userInfo)
+	if err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		logrus.Errorf("[%s] Failed to authorize user with error: %s", logPrefix, err.Error())
+		return
+	}
+	if !authorized {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		return
+	}
+
+	records, err := h.MeteringRecords.List(labels.Everything())
+	if err != nil {
+		logrus.Errorf("[%s] Error listing metering records: %v", logPrefix, err)
+		util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+	var selected []*v3.MeteringRecord
+	for _, record := range metering.Between(records, from, to) {
+		if record.Spec.Aggregation == aggregation {
+			selected = append(selected, record)
+		}
+	}
+	rows, err := metering.Aggregate(selected, groupBy)
+	if err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if format == formatCSV {
+		writer.Header().Set("Content-Type", "text/csv")
+		writer.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "metering-"+groupBy+".csv"))
+		if err := metering.WriteCSV(writer, groupBy, rows); err != nil {
+			logrus.Warnf("[%s] Failed to write metering export: %v", logPrefix, err)
+		}
+		return
+	}
+
+	if rows == nil {
+		rows = []metering.Row{}
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(Export{Aggregation: aggregation, GroupBy: groupBy, Rows: rows}); err != nil {
+		logrus.Warnf("[%s] Failed to write metering export: %v", logPrefix, err)
+	}
+}
+
+// parseTime parses an RFC3339 time or a date, returning the zero time if value is empty.
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// authorize checks to see if the user can list metering records. Returns a bool (if the user is authorized) and
+// optionally an error
+func (h *Handler) authorize(r *http.Request, userInfo user.Info) (bool, error) {
+	extra := map[string]authzv1.ExtraValue{}
+	for k, v := range userInfo.GetExtra() {
+		extra[k] = authzv1.ExtraValue(v)
+	}
+	response, err := h.SubjectAccessReviews.Create(r.Context(), &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Group:    v3.SchemeGroupVersion.Group,
+				Resource: v3.MeteringRecordResourceName,
+				Verb:     "list",
+			},
+			User:   userInfo.GetName(),
+			Groups: userInfo.GetGroups(),
+			Extra:  extra,
+			UID:    userInfo.GetUID(),
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to create sar %s", err)
+	}
+	return response.Status.Allowed, nil
+}
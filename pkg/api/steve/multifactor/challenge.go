@@ -0,0 +1,52 @@
+package multifactor
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/rancher/norman/httperror"
+	"github.com/rancher/rancher/pkg/auth/providers"
+	"github.com/rancher/rancher/pkg/auth/providers/local"
+	"github.com/rancher/rancher/pkg/auth/util"
+)
+
+// ChallengeEndpoint The unauthenticated endpoint issuing security key challenges to log in - used for routing
+const ChallengeEndpoint = "/v1-public/mfa/challenge"
+
+type challengeInput struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// ChallengeHandler implements http.Handler - and issues a challenge for the security keys of a local user once their
+// password is verified. The response to the challenge is sent with the password to the login action of the local
+// provider. The password is verified like in the login action, so that failed attempts count towards the lockout of
+// the username.
+type ChallengeHandler struct{}
+
+// ServeHTTP implements http.Handler
+func (h *ChallengeHandler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	var input challengeInput
+	if err := json.NewDecoder(http.MaxBytesReader(writer, req.Body, maxBodySize)).Decode(&input); err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusUnprocessableEntity, "invalid body")
+		return
+	}
+
+	provider, err := providers.GetProvider(local.Name)
+	if err != nil {
+		writeError(writer, req, err)
+		return
+	}
+	localProvider, ok := provider.(*local.Provider)
+	if !ok {
+		writeError(writer, req, httperror.NewAPIError(httperror.ServerError, "unexpected local provider"))
+		return
+	}
+
+	options, err := localProvider.MFAChallenge(input.Username, input.Password, util.SourceIP(req))
+	if err != nil {
+		writeError(writer, req, err)
+		return
+	}
+	writeJSON(writer, http.StatusOK, options)
+}
@@ -0,0 +1,234 @@
+// Package multifactor provides HTTPHandlers for local users to enroll second factors, and to request a security key
+// challenge to log in. The handlers should be registered at Endpoint and ChallengeEndpoint.
+package multifactor
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rancher/norman/httperror"
+	"github.com/rancher/rancher/pkg/auth/mfa"
+	"github.com/rancher/rancher/pkg/auth/tokens"
+	"github.com/rancher/rancher/pkg/auth/util"
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apiserver/pkg/endpoints/request"
+)
+
+const (
+	// Endpoint The endpoint prefix of the enrollment of second factors - used for routing
+	Endpoint  = "/v1/mfa"
+	logPrefix = "mfa"
+
+	maxBodySize = 1 << 20
+)
+
+// Status is the multi-factor authentication status of the user.
+type Status struct {
+	Required        bool          `json:"required"`
+	Enrolled        bool          `json:"enrolled"`
+	SessionVerified bool          `json:"sessionVerified"`
+	TOTP            bool          `json:"totp"`
+	RecoveryCodes   int           `json:"recoveryCodes"`
+	SecurityKeys    []SecurityKey `json:"securityKeys"`
+}
+
+type SecurityKey struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+type codeInput struct {
+	Code string `json:"code"`
+}
+
+type securityKeyInput struct {
+	Name              string `json:"name"`
+	ClientDataJSON    string `json:"clientDataJSON"`
+	AttestationObject string `json:"attestationObject"`
+}
+
+type recoveryCodesOutput struct {
+	RecoveryCodes []string `json:"recoveryCodes,omitempty"`
+}
+
+type securityKeyOutput struct {
+	SecurityKey   SecurityKey `json:"securityKey"`
+	RecoveryCodes []string    `json:"recoveryCodes,omitempty"`
+}
+
+type userHandler func(writer http.ResponseWriter, req *http.Request, user *v3.User) (interface{}, error)
+
+// Handler implements http.Handler - and serves the second factors of the local user making the request
+type Handler struct {
+	mfa         *mfa.Manager
+	userLister  v3.UserLister
+	tokenLister v3.TokenLister
+	router      *mux.Router
+}
+
+// NewHandler creates a handler using the clients defined in scaledContext
+func NewHandler(scaledContext *config.ScaledContext) *Handler {
+	h := &Handler{
+		mfa:         scaledContext.MFA,
+		userLister:  scaledContext.Management.Users("").Controller().Lister(),
+		tokenLister: scaledContext.Management.Tokens("").Controller().Lister(),
+		router:      mux.NewRouter(),
+	}
+	h.router.UseEncodedPath()
+	h.router.Path(Endpoint).Methods(http.MethodGet).Handler(h.handle(h.status, false))
+	h.router.Path(Endpoint + "/totp").Methods(http.MethodPost).Handler(h.handle(h.beginTOTP, true))
+	h.router.Path(Endpoint + "/totp/confirm").Methods(http.MethodPost).Handler(h.handle(h.confirmTOTP, true))
+	h.router.Path(Endpoint + "/totp").Methods(http.MethodDelete).Handler(h.handle(h.removeTOTP, true))
+	h.router.Path(Endpoint + "/recoverycodes").Methods(http.MethodPost).Handler(h.handle(h.regenerateRecoveryCodes, true))
+	h.router.Path(Endpoint + "/webauthn").Methods(http.MethodPost).Handler(h.handle(h.beginWebAuthn, true))
+	h.router.Path(Endpoint + "/webauthn/confirm").Methods(http.MethodPost).Handler(h.handle(h.confirmWebAuthn, true))
+	h.router.Path(Endpoint + "/webauthn/{id}").Methods(http.MethodDelete).Handler(h.handle(h.removeWebAuthn, true))
+	return h
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	h.router.ServeHTTP(writer, req)
+}
+
+// handle resolves the local user making the request. Once a user has a second factor, their second factors can only be
+// changed from a session authenticated with one of them.
+func (h *Handler) handle(next userHandler, modifies bool) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		userInfo, ok := request.UserFrom(req.Context())
+		if !ok {
+			util.ReturnHTTPError(writer, req, http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+			return
+		}
+		user, err := h.userLister.Get("", userInfo.GetName())
+		if err != nil || user.Password == "" {
+			util.ReturnHTTPError(writer, req, http.StatusForbidden, "multi-factor authentication is only available to local users")
+			return
+		}
+
+		if modifies {
+			state, err := h.mfa.GetState(user.Name)
+			if err != nil {
+				writeError(writer, req, err)
+				return
+			}
+			if state.Enrolled() && !h.sessionVerified(req) {
+				util.ReturnHTTPError(writer, req, http.StatusForbidden, "second factors can only be changed from a session authenticated with a second factor")
+				return
+			}
+		}
+
+		req.Body = http.MaxBytesReader(writer, req.Body, maxBodySize)
+		result, err := next(writer, req, user)
+		if err != nil {
+			writeError(writer, req, err)
+			return
+		}
+		writeJSON(writer, http.StatusOK, result)
+	})
+}
+
+// sessionVerified returns true if the token of the request was issued after a second factor was verified. The token
+// was already authenticated.
+func (h *Handler) sessionVerified(req *http.Request) bool {
+	tokenName, _ := tokens.SplitTokenParts(tokens.GetTokenAuthFromRequest(req))
+	token, err := h.tokenLister.Get("", tokenName)
+	return err == nil && mfa.IsVerified(token.UserPrincipal)
+}
+
+func (h *Handler) status(_ http.ResponseWriter, req *http.Request, user *v3.User) (interface{}, error) {
+	state, err := h.mfa.GetState(user.Name)
+	if err != nil {
+		return nil, err
+	}
+	required, err := h.mfa.Required(user.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	status := Status{
+		Required:        required,
+		Enrolled:        state.Enrolled(),
+		SessionVerified: h.sessionVerified(req),
+		TOTP:            state.TOTPSecret != "",
+		RecoveryCodes:   len(state.RecoveryCodes),
+		SecurityKeys:    []SecurityKey{},
+	}
+	for _, credential := range state.WebAuthnCredentials {
+		status.SecurityKeys = append(status.SecurityKeys, SecurityKey{ID: credential.ID, Name: credential.Name})
+	}
+	return status, nil
+}
+
+func (h *Handler) beginTOTP(_ http.ResponseWriter, _ *http.Request, user *v3.User) (interface{}, error) {
+	return h.mfa.BeginTOTP(user.Name, user.Username)
+}
+
+func (h *Handler) confirmTOTP(_ http.ResponseWriter, req *http.Request, user *v3.User) (interface{}, error) {
+	var input codeInput
+	if err := json.NewDecoder(req.Body).Decode(&input); err != nil {
+		return nil, httperror.NewAPIError(httperror.InvalidBodyContent, err.Error())
+	}
+	codes, err := h.mfa.ConfirmTOTP(user.Name, input.Code)
+	if err != nil {
+		return nil, err
+	}
+	return recoveryCodesOutput{RecoveryCodes: codes}, nil
+}
+
+func (h *Handler) removeTOTP(_ http.ResponseWriter, _ *http.Request, user *v3.User) (interface{}, error) {
+	return struct{}{}, h.mfa.RemoveTOTP(user.Name)
+}
+
+func (h *Handler) regenerateRecoveryCodes(_ http.ResponseWriter, _ *http.Request, user *v3.User) (interface{}, error) {
+	codes, err := h.mfa.RegenerateRecoveryCodes(user.Name)
+	if err != nil {
+		return nil, err
+	}
+	return recoveryCodesOutput{RecoveryCodes: codes}, nil
+}
+
+func (h *Handler) beginWebAuthn(_ http.ResponseWriter, _ *http.Request, user *v3.User) (interface{}, error) {
+	return h.mfa.BeginWebAuthn(user.Name, user.Username, user.DisplayName)
+}
+
+func (h *Handler) confirmWebAuthn(_ http.ResponseWriter, req *http.Request, user *v3.User) (interface{}, error) {
+	var input securityKeyInput
+	if err := json.NewDecoder(req.Body).Decode(&input); err != nil {
+		return nil, httperror.NewAPIError(httperror.InvalidBodyContent, err.Error())
+	}
+	credential, codes, err := h.mfa.FinishWebAuthn(user.Name, input.Name, input.ClientDataJSON, input.AttestationObject)
+	if err != nil {
+		return nil, err
+	}
+	return securityKeyOutput{
+		SecurityKey:   SecurityKey{ID: credential.ID, Name: credential.Name},
+		RecoveryCodes: codes,
+	}, nil
+}
+
+func (h *Handler) removeWebAuthn(_ http.ResponseWriter, req *http.Request, user *v3.User) (interface{}, error) {
+	return struct{}{}, h.mfa.RemoveWebAuthn(user.Name, mux.Vars(req)["id"])
+}
+
+func writeJSON(writer http.ResponseWriter, status int, value interface{}) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	if err := json.NewEncoder(writer).Encode(value); err != nil {
+		logrus.Warnf("[%s] Failed to write response: %v", logPrefix, err)
+	}
+}
+
+func writeError(writer http.ResponseWriter, req *http.Request, err error) {
+	var apiErr *httperror.APIError
+	if errors.As(err, &apiErr) {
+		util.ReturnHTTPError(writer, req, apiErr.Code.Status, apiErr.Message)
+		return
+	}
+	logrus.Errorf("[%s] Error handling request %s %s: %v", logPrefix, req.Method, req.URL.Path, err)
+	util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+}
@@ -0,0 +1,154 @@
+// Package pipelinekubeconfig provides a HTTPHandler minting short-lived kubeconfigs scoped to a namespace of a cluster
+// for CI pipelines, which deploy to the namespace with the token of a service account instead of a Rancher token. This
+// handler should be registered at Endpoint
+package pipelinekubeconfig
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rancher/rancher/pkg/auth/util"
+	mgmtv3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/pipelinekubeconfig"
+	"github.com/rancher/rancher/pkg/securityevents"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// Endpoint The endpoint that the kubeconfigs of a namespace of a cluster are minted at - used for routing. The
+	// lifetime of the kubeconfig is set with the ttlSeconds query parameter.
+	Endpoint  = "/v1/pipelinekubeconfig/clusters/{cluster}/namespaces/{namespace}"
+	logPrefix = "pipeline-kubeconfig"
+)
+
+// UserContextGetter returns the clients of a downstream cluster.
+type UserContextGetter interface {
+	UserContextNoControllers(clusterName string) (*config.UserContext, error)
+}
+
+// Handler implements http.Handler - and mints the pipeline kubeconfigs of clusters
+type Handler struct {
+	Clusters     mgmtv3.ClusterLister
+	UserContexts UserContextGetter
+}
+
+// NewHandler creates a handler using the clients defined in scaledContext
+func NewHandler(scaledContext *config.ScaledContext, userContexts UserContextGetter) Handler {
+	return Handler{
+		Clusters:     scaledContext.Management.Clusters("").Controller().Lister(),
+		UserContexts: userContexts,
+	}
+}
+
+// ServeHTTP implements http.Handler - mints a kubeconfig for the namespace of the cluster. The service account of the
+// kubeconfig is bound to its role in the namespace on behalf of the user, so the user has to be allowed to grant the
+// role in the namespace.
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	vars := mux.Vars(req)
+	clusterName, namespace := vars["cluster"], vars["namespace"]
+
+	userInfo, ok := request.UserFrom(req.Context())
+	if !ok {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		return
+	}
+	audit := func(outcome securityevents.Outcome, message string, details map[string]string) {
+		event := securityevents.NewEvent(securityevents.PipelineKubeconfigMinted, outcome, req)
+		event.User = userInfo.GetName()
+		event.Resource = fmt.Sprintf("clusters/%s/namespaces/%s", clusterName, namespace)
+		event.Message = message
+		event.Details = details
+		securityevents.Emit(event)
+	}
+
+	ttl, err := pipelinekubeconfig.TTL(req.URL.Query().Get("ttlSeconds"))
+	if err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	cluster, err := h.Clusters.Get("", clusterName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			util.ReturnHTTPError(writer, req, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+			return
+		}
+		logrus.Errorf("[%s] Error getting cluster %s: %v", logPrefix, clusterName, err)
+		util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	if _, _, err := pipelinekubeconfig.Server(cluster); err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusConflict, err.Error())
+		return
+	}
+
+	userContext, err := h.UserContexts.UserContextNoControllers(cluster.Name)
+	if err != nil {
+		logrus.Errorf("[%s] Error getting clients of cluster %s: %v", logPrefix, clusterName, err)
+		util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+	restConfig := rest.CopyConfig(&userContext.RESTConfig)
+	restConfig.Impersonate = rest.ImpersonationConfig{
+		UserName: userInfo.GetName(),
+		Groups:   userInfo.GetGroups(),
+		Extra:    userInfo.GetExtra(),
+	}
+	userClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		logrus.Errorf("[%s] Error creating client of cluster %s: %v", logPrefix, clusterName, err)
+		util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	creds, err := pipelinekubeconfig.Mint(req.Context(), pipelinekubeconfig.Clients{
+		Admin: userContext.K8sClient,
+		User:  userClient,
+	}, pipelinekubeconfig.Request{
+		Namespace: namespace,
+		TTL:       ttl,
+		User:      userInfo.GetName(),
+	}, time.Now())
+	if err != nil {
+		switch {
+		case apierrors.IsForbidden(err):
+			audit(securityevents.OutcomeFailure, "denied", nil)
+			util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		case apierrors.IsNotFound(err):
+			util.ReturnHTTPError(writer, req, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		default:
+			audit(securityevents.OutcomeFailure, "failed to mint the kubeconfig", nil)
+			logrus.Errorf("[%s] Error minting kubeconfig for namespace %s of cluster %s: %v", logPrefix, namespace, clusterName, err)
+			util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		}
+		return
+	}
+
+	kubeconfig, err := pipelinekubeconfig.Kubeconfig(cluster, namespace, creds)
+	if err != nil {
+		logrus.Errorf("[%s] Error generating kubeconfig for namespace %s of cluster %s: %v", logPrefix, namespace, clusterName, err)
+		util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+	audit(securityevents.OutcomeSuccess, "minted", map[string]string{
+		"serviceAccount": creds.ServiceAccount,
+		"expires":        creds.Expires.Format(time.RFC3339),
+	})
+
+	writer.Header().Set("Content-Type", "application/yaml")
+	writer.Header().Set("Content-Disposition", "attachment; filename="+strconv.Quote(clusterName+"-"+namespace+".yaml"))
+	writer.Header().Set("Cache-Control", "no-store")
+	writer.Header().Set("X-Rancher-Kubeconfig-Expires", creds.Expires.Format(time.RFC3339))
+	if _, err := writer.Write([]byte(kubeconfig)); err != nil {
+		logrus.Warnf("[%s] Failed to write kubeconfig: %v", logPrefix, err)
+	}
+}
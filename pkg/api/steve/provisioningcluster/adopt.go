@@ -0,0 +1,193 @@
+package provisioningcluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rancher/apiserver/pkg/apierror"
+	"github.com/rancher/apiserver/pkg/types"
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
+	provcontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/machineadoption"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/rancher/wrangler/pkg/schemas/validation"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// machineAdoption handles the adoptMachines action of clusters. The running instances with the IDs or tags of the
+// request are found in the cloud of the machine pool, the adoptions are recorded on the cluster, and the machine pool
+// is scaled up by one for each instance. The new machines of the pool claim the adoptions, installing the system agent
+// on the instances over SSH instead of creating instances, and the machine-adoptions controller tracks their progress in
+// the status of the cluster.
+type machineAdoption struct {
+	clusters         provcontrollers.ClusterClient
+	capiMachineCache capicontrollers.MachineCache
+	secretCache      corecontrollers.SecretCache
+	resolver         *machineadoption.Resolver
+}
+
+func (m *machineAdoption) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	apiRequest := types.GetAPIContext(req.Context())
+	if err := apiRequest.AccessControl.CanUpdate(apiRequest, types.APIObject{}, apiRequest.Schema); err != nil {
+		apiRequest.WriteError(err)
+		return
+	}
+
+	input := AdoptMachinesInput{}
+	if err := json.NewDecoder(req.Body).Decode(&input); err != nil && err != io.EOF {
+		apiRequest.WriteError(apierror.NewAPIError(validation.InvalidBodyContent, fmt.Sprintf("failed to parse adoption options: %v", err)))
+		return
+	}
+	if input.PoolName == "" || input.SSHKeySecretName == "" || (len(input.InstanceIDs) == 0 && len(input.Tags) == 0) {
+		apiRequest.WriteError(apierror.NewAPIError(validation.MissingRequired, "poolName, sshKeySecretName and instanceIds or tags are required"))
+		return
+	}
+
+	cluster, err := m.clusters.Get(apiRequest.Namespace, apiRequest.Name, metav1.GetOptions{})
+	if err != nil {
+		apiRequest.WriteError(err)
+		return
+	}
+	requested, err := m.adoptions(req, cluster, input, time.Now())
+	if err != nil {
+		apiRequest.WriteError(err)
+		return
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cluster, err := m.clusters.Get(apiRequest.Namespace, apiRequest.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		pool := machinePool(cluster, input.PoolName)
+		if pool < 0 {
+			return apierror.NewAPIError(validation.NotFound, fmt.Sprintf("machine pool %s of cluster %s/%s not found", input.PoolName, cluster.Namespace, cluster.Name))
+		}
+		adoptions, err := capr.ParseMachineAdoptions(cluster.Annotations)
+		if err != nil {
+			return err
+		}
+		adoptions, err = capr.MergeMachineAdoptions(adoptions, requested, m.active(cluster.Namespace))
+		if err != nil {
+			return apierror.NewAPIError(validation.InvalidAction, err.Error())
+		}
+
+		cluster = cluster.DeepCopy()
+		if cluster.Annotations == nil {
+			cluster.Annotations = map[string]string{}
+		}
+		if err := capr.SetMachineAdoptions(cluster.Annotations, adoptions); err != nil {
+			return err
+		}
+		quantity := int32(1)
+		if q := cluster.Spec.RKEConfig.MachinePools[pool].Quantity; q != nil {
+			quantity = *q
+		}
+		quantity += int32(len(requested))
+		cluster.Spec.RKEConfig.MachinePools[pool].Quantity = &quantity
+		_, err = m.clusters.Update(cluster)
+		return err
+	})
+	if err != nil {
+		apiRequest.WriteError(err)
+		return
+	}
+
+	output := &AdoptMachinesOutput{}
+	for _, adoption := range requested {
+		output.Adoptions = append(output.Adoptions, MachineAdoptionOutput{
+			ID:         adoption.ID,
+			InstanceID: adoption.InstanceID,
+			ProviderID: adoption.ProviderID,
+			Address:    adoption.Address,
+		})
+	}
+	apiRequest.WriteResponse(http.StatusAccepted, types.APIObject{
+		Type:   "adoptMachinesOutput",
+		Object: output,
+	})
+}
+
+// adoptions returns the adoptions of the instances of the request, found in the cloud of the machine pool.
+func (m *machineAdoption) adoptions(req *http.Request, cluster *rancherv1.Cluster, input AdoptMachinesInput, now time.Time) ([]rkev1.MachineAdoption, error) {
+	if cluster.Spec.RKEConfig == nil {
+		return nil, apierror.NewAPIError(validation.InvalidAction, fmt.Sprintf("cluster %s/%s is not provisioned by rancher", cluster.Namespace, cluster.Name))
+	}
+	pool := machinePool(cluster, input.PoolName)
+	if pool < 0 {
+		return nil, apierror.NewAPIError(validation.NotFound, fmt.Sprintf("machine pool %s of cluster %s/%s not found", input.PoolName, cluster.Namespace, cluster.Name))
+	}
+	if nodeConfig := cluster.Spec.RKEConfig.MachinePools[pool].NodeConfig; nodeConfig == nil || !machineadoption.CanAdopt(nodeConfig.Kind) {
+		return nil, apierror.NewAPIError(validation.InvalidAction, fmt.Sprintf("machine pool %s can't adopt instances of its cloud", input.PoolName))
+	}
+
+	secret, err := m.secretCache.Get(cluster.Namespace, input.SSHKeySecretName)
+	if apierrors.IsNotFound(err) {
+		return nil, apierror.NewAPIError(validation.NotFound, fmt.Sprintf("secret %s/%s not found", cluster.Namespace, input.SSHKeySecretName))
+	} else if err != nil {
+		return nil, err
+	}
+	if len(secret.Data[corev1.SSHAuthPrivateKey]) == 0 {
+		return nil, apierror.NewAPIError(validation.InvalidBodyContent, fmt.Sprintf("secret %s/%s has no %s", cluster.Namespace, input.SSHKeySecretName, corev1.SSHAuthPrivateKey))
+	}
+
+	instances, sshUser, err := m.resolver.Resolve(req.Context(), cluster, cluster.Spec.RKEConfig.MachinePools[pool], input.InstanceIDs, input.Tags)
+	if err != nil {
+		return nil, apierror.NewAPIError(validation.InvalidBodyContent, err.Error())
+	}
+	if len(instances) == 0 {
+		return nil, apierror.NewAPIError(validation.NotFound, "no running instances match the request")
+	}
+	if input.SSHUser != "" {
+		sshUser = input.SSHUser
+	}
+
+	var adoptions []rkev1.MachineAdoption
+	for i, instance := range instances {
+		adoptions = append(adoptions, rkev1.MachineAdoption{
+			ID:               strconv.FormatInt(now.UnixNano(), 36) + "-" + strconv.Itoa(i),
+			PoolName:         input.PoolName,
+			InstanceID:       instance.ID,
+			ProviderID:       instance.ProviderID,
+			Address:          instance.Address,
+			SSHUser:          sshUser,
+			SSHKeySecretName: input.SSHKeySecretName,
+			RequestedAt:      metav1.NewTime(now),
+		})
+	}
+	return adoptions, nil
+}
+
+// active returns whether an adoption is pending or claimed by a machine that still exists.
+func (m *machineAdoption) active(namespace string) func(rkev1.MachineAdoption) bool {
+	return func(adoption rkev1.MachineAdoption) bool {
+		if adoption.MachineName == "" {
+			return true
+		}
+		_, err := m.capiMachineCache.Get(namespace, adoption.MachineName)
+		return !apierrors.IsNotFound(err)
+	}
+}
+
+// machinePool returns the index of the machine pool of the cluster, -1 if it doesn't exist.
+func machinePool(cluster *rancherv1.Cluster, name string) int {
+	if cluster.Spec.RKEConfig == nil {
+		return -1
+	}
+	for i, pool := range cluster.Spec.RKEConfig.MachinePools {
+		if pool.Name == name {
+			return i
+		}
+	}
+	return -1
+}
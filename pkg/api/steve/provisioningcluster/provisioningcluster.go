@@ -0,0 +1,60 @@
+package provisioningcluster
+
+import (
+	"net/http"
+
+	"github.com/rancher/apiserver/pkg/types"
+	"github.com/rancher/rancher/pkg/machineadoption"
+	"github.com/rancher/rancher/pkg/wrangler"
+	schema2 "github.com/rancher/steve/pkg/schema"
+	steve "github.com/rancher/steve/pkg/server"
+	"github.com/rancher/wrangler/pkg/schemas"
+)
+
+func Register(server *steve.Server, clients *wrangler.Context) {
+	rollback := &upgradeRollback{
+		clusters:      clients.Provisioning.Cluster(),
+		snapshotCache: clients.RKE.ETCDSnapshot().Cache(),
+	}
+	adopt := &machineAdoption{
+		clusters:         clients.Provisioning.Cluster(),
+		capiMachineCache: clients.CAPI.Machine().Cache(),
+		secretCache:      clients.Core.Secret().Cache(),
+		resolver:         machineadoption.NewResolver(clients),
+	}
+
+	server.BaseSchemas.MustImportAndCustomize(RollbackUpgradeInput{}, nil)
+	server.BaseSchemas.MustImportAndCustomize(UpgradeRollbackOutput{}, nil)
+	server.BaseSchemas.MustImportAndCustomize(AdoptMachinesInput{}, nil)
+	server.BaseSchemas.MustImportAndCustomize(AdoptMachinesOutput{}, nil)
+
+	server.SchemaFactory.AddTemplate(schema2.Template{
+		Group: "provisioning.cattle.io",
+		Kind:  "Cluster",
+		Customize: func(schema *types.APISchema) {
+			if schema.ActionHandlers == nil {
+				schema.ActionHandlers = map[string]http.Handler{}
+			}
+			schema.ActionHandlers["rollbackUpgrade"] = rollback
+			schema.ActionHandlers["adoptMachines"] = adopt
+			if schema.ResourceActions == nil {
+				schema.ResourceActions = map[string]schemas.Action{}
+			}
+			schema.ResourceActions["rollbackUpgrade"] = schemas.Action{
+				Input:  "rollbackUpgradeInput",
+				Output: "upgradeRollbackOutput",
+			}
+			schema.ResourceActions["adoptMachines"] = schemas.Action{
+				Input:  "adoptMachinesInput",
+				Output: "adoptMachinesOutput",
+			}
+		},
+		Formatter: func(request *types.APIRequest, resource *types.RawResource) {
+			canUpdate := request.AccessControl.CanUpdate(request, types.APIObject{}, request.Schema) == nil
+			if !canUpdate || resource.APIObject.Data().Map("spec", "rkeConfig") == nil {
+				delete(resource.Actions, "rollbackUpgrade")
+				delete(resource.Actions, "adoptMachines")
+			}
+		},
+	})
+}
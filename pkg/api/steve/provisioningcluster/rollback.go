@@ -0,0 +1,145 @@
+package provisioningcluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rancher/apiserver/pkg/apierror"
+	"github.com/rancher/apiserver/pkg/types"
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	provcontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	rkecontrollers "github.com/rancher/rancher/pkg/generated/controllers/rke.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/schemas/validation"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/retry"
+)
+
+// upgradeRollback handles the rollbackUpgrade action of clusters. The etcd snapshot taken before the upgrade is restored
+// with the Kubernetes version it was taken at, the planner then reconciling the control plane and the machines of all
+// the pools to the version, and the rollback is recorded on the cluster for the upgrade-rollback controller to track its
+// progress in the status of the cluster.
+type upgradeRollback struct {
+	clusters      provcontrollers.ClusterClient
+	snapshotCache rkecontrollers.ETCDSnapshotCache
+}
+
+func (u *upgradeRollback) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	apiRequest := types.GetAPIContext(req.Context())
+	if err := apiRequest.AccessControl.CanUpdate(apiRequest, types.APIObject{}, apiRequest.Schema); err != nil {
+		apiRequest.WriteError(err)
+		return
+	}
+
+	input := RollbackUpgradeInput{}
+	if err := json.NewDecoder(req.Body).Decode(&input); err != nil && err != io.EOF {
+		apiRequest.WriteError(apierror.NewAPIError(validation.InvalidBodyContent, fmt.Sprintf("failed to parse rollback options: %v", err)))
+		return
+	}
+
+	var rollback *rkev1.UpgradeRollback
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cluster, err := u.clusters.Get(apiRequest.Namespace, apiRequest.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		rollback, err = u.rollback(cluster, input.SnapshotName, time.Now())
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(rollback)
+		if err != nil {
+			return err
+		}
+
+		cluster = cluster.DeepCopy()
+		if cluster.Annotations == nil {
+			cluster.Annotations = map[string]string{}
+		}
+		cluster.Annotations[capr.UpgradeRollbackAnnotation] = string(data)
+		generation := 1
+		if cluster.Spec.RKEConfig.ETCDSnapshotRestore != nil {
+			generation = cluster.Spec.RKEConfig.ETCDSnapshotRestore.Generation + 1
+		}
+		cluster.Spec.RKEConfig.ETCDSnapshotRestore = &rkev1.ETCDSnapshotRestore{
+			Name:             rollback.SnapshotName,
+			Generation:       generation,
+			RestoreRKEConfig: capr.RestoreRKEConfigKubernetesVersion,
+		}
+		_, err = u.clusters.Update(cluster)
+		return err
+	})
+	if err != nil {
+		apiRequest.WriteError(err)
+		return
+	}
+
+	apiRequest.WriteResponse(http.StatusAccepted, types.APIObject{
+		Type: "upgradeRollbackOutput",
+		Object: &UpgradeRollbackOutput{
+			ID:           rollback.ID,
+			SnapshotName: rollback.SnapshotName,
+			Version:      rollback.ToVersion,
+		},
+	})
+}
+
+// rollback returns the rollback of the upgrade of the cluster to the version the snapshot was taken at, the snapshot
+// defaulting to the newest snapshot taken before the upgrade.
+func (u *upgradeRollback) rollback(cluster *rancherv1.Cluster, snapshotName string, now time.Time) (*rkev1.UpgradeRollback, error) {
+	if cluster.Spec.RKEConfig == nil {
+		return nil, apierror.NewAPIError(validation.InvalidAction, fmt.Sprintf("cluster %s/%s is not provisioned by rancher", cluster.Namespace, cluster.Name))
+	}
+	if status := cluster.Status.UpgradeRollback; status != nil &&
+		(status.Phase == rancherv1.UpgradeRollbackRestoring || status.Phase == rancherv1.UpgradeRollbackReconciling) {
+		return nil, apierror.NewAPIError(validation.InvalidAction, fmt.Sprintf("cluster %s/%s is being rolled back to kubernetes version %s", cluster.Namespace, cluster.Name, status.ToVersion))
+	}
+
+	var (
+		snapshot *rkev1.ETCDSnapshot
+		version  string
+	)
+	if snapshotName != "" {
+		var err error
+		snapshot, err = u.snapshotCache.Get(cluster.Namespace, snapshotName)
+		if apierrors.IsNotFound(err) || (err == nil && snapshot.Labels[capr.ClusterNameLabel] != cluster.Name) {
+			return nil, apierror.NewAPIError(validation.NotFound, fmt.Sprintf("etcd snapshot %s of cluster %s/%s not found", snapshotName, cluster.Namespace, cluster.Name))
+		} else if err != nil {
+			return nil, err
+		}
+		spec, err := capr.ParseSnapshotClusterSpecOrError(snapshot)
+		if err != nil {
+			return nil, apierror.NewAPIError(validation.InvalidBodyContent, fmt.Sprintf("etcd snapshot %s doesn't record the kubernetes version it was taken at", snapshotName))
+		}
+		if spec.KubernetesVersion == cluster.Spec.KubernetesVersion {
+			return nil, apierror.NewAPIError(validation.InvalidBodyContent, fmt.Sprintf("etcd snapshot %s was taken at the kubernetes version of the cluster", snapshotName))
+		}
+		version = spec.KubernetesVersion
+	} else {
+		snapshots, err := u.snapshotCache.List(cluster.Namespace, labels.SelectorFromSet(labels.Set{
+			capr.ClusterNameLabel: cluster.Name,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		snapshot, version, err = capr.PreUpgradeSnapshot(snapshots, cluster.Spec.KubernetesVersion)
+		if err != nil {
+			return nil, apierror.NewAPIError(validation.InvalidAction, err.Error())
+		}
+	}
+
+	return &rkev1.UpgradeRollback{
+		ID:           strconv.FormatInt(now.UnixNano(), 36),
+		SnapshotName: snapshot.Name,
+		FromVersion:  cluster.Spec.KubernetesVersion,
+		ToVersion:    version,
+		RequestedAt:  metav1.NewTime(now),
+	}, nil
+}
@@ -0,0 +1,37 @@
+package provisioningcluster
+
+type RollbackUpgradeInput struct {
+	// SnapshotName is the etcd snapshot to restore, defaults to the newest snapshot taken before the upgrade.
+	SnapshotName string `json:"snapshotName,omitempty"`
+}
+
+type UpgradeRollbackOutput struct {
+	ID           string `json:"id,omitempty"`
+	SnapshotName string `json:"snapshotName,omitempty"`
+	Version      string `json:"version,omitempty"`
+}
+
+type AdoptMachinesInput struct {
+	// PoolName is the machine pool the instances are adopted into.
+	PoolName string `json:"poolName"`
+	// InstanceIDs are the IDs of the running instances to adopt.
+	InstanceIDs []string `json:"instanceIds,omitempty"`
+	// Tags select the running instances with all the tags to adopt, in addition to the instances with the IDs.
+	Tags map[string]string `json:"tags,omitempty"`
+	// SSHUser is the user the system agent is installed as, defaults to the SSH user of the machine config of the pool.
+	SSHUser string `json:"sshUser,omitempty"`
+	// SSHKeySecretName is the secret of the namespace of the cluster holding the private SSH key of the instances in its
+	// ssh-privatekey key.
+	SSHKeySecretName string `json:"sshKeySecretName"`
+}
+
+type AdoptMachinesOutput struct {
+	Adoptions []MachineAdoptionOutput `json:"adoptions,omitempty"`
+}
+
+type MachineAdoptionOutput struct {
+	ID         string `json:"id,omitempty"`
+	InstanceID string `json:"instanceId,omitempty"`
+	ProviderID string `json:"providerId,omitempty"`
+	Address    string `json:"address,omitempty"`
+}
@@ -0,0 +1,140 @@
+package provisioningops
+
+import (
+	"fmt"
+	"sort"
+
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultNamespace = "fleet-default"
+
+	OperationRotateCertificates = "rotateCertificates"
+	OperationSnapshot           = "snapshot"
+)
+
+// newCluster returns the cluster created from the template by the request. The machine configs of the pools are still
+// those of the template.
+func newCluster(template *provv1.Cluster, input CreateClusterRequest) (*provv1.Cluster, error) {
+	if template.Spec.RKEConfig == nil {
+		return nil, fmt.Errorf("template %s/%s isn't provisioned by Rancher", template.Namespace, template.Name)
+	}
+	if template.Spec.RKEConfig.InfrastructureRef != nil {
+		return nil, fmt.Errorf("template %s/%s references its infrastructure cluster and can't be copied", template.Namespace, template.Name)
+	}
+
+	spec := template.Spec.DeepCopy()
+	if input.KubernetesVersion != "" {
+		spec.KubernetesVersion = input.KubernetesVersion
+	}
+	spec.ClusterAPIConfig = nil
+	spec.RedeploySystemAgentGeneration = 0
+	// the operations requested on the template aren't requested on the copy
+	spec.RKEConfig.ETCDSnapshotCreate = nil
+	spec.RKEConfig.ETCDSnapshotRestore = nil
+	spec.RKEConfig.RotateCertificates = nil
+	spec.RKEConfig.RotateEncryptionKeys = nil
+
+	for name, quantity := range input.MachinePoolQuantities {
+		if err := setPoolQuantity(spec, name, quantity); err != nil {
+			return nil, err
+		}
+	}
+
+	return &provv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      input.Name,
+			Namespace: input.Namespace,
+			Labels:    input.Labels,
+		},
+		Spec: *spec,
+	}, nil
+}
+
+// setPoolQuantity sets the number of machines of the machine pool of the spec.
+func setPoolQuantity(spec *provv1.ClusterSpec, name string, quantity int32) error {
+	if quantity < 0 {
+		return fmt.Errorf("invalid quantity %d for machine pool %s", quantity, name)
+	}
+	if spec.RKEConfig != nil {
+		for i := range spec.RKEConfig.MachinePools {
+			if spec.RKEConfig.MachinePools[i].Name == name {
+				spec.RKEConfig.MachinePools[i].Quantity = &quantity
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("machine pool %s not found", name)
+}
+
+// requestOperation requests the operation by incrementing its generation in the spec, and returns the new generation.
+func requestOperation(spec *provv1.ClusterSpec, operation string, services []string) (int64, error) {
+	if spec.RKEConfig == nil {
+		return 0, fmt.Errorf("cluster isn't provisioned by Rancher")
+	}
+	switch operation {
+	case OperationRotateCertificates:
+		generation := int64(1)
+		if spec.RKEConfig.RotateCertificates != nil {
+			generation = spec.RKEConfig.RotateCertificates.Generation + 1
+		}
+		spec.RKEConfig.RotateCertificates = &rkev1.RotateCertificates{
+			Generation: generation,
+			Services:   services,
+		}
+		return generation, nil
+	case OperationSnapshot:
+		generation := 1
+		if spec.RKEConfig.ETCDSnapshotCreate != nil {
+			generation = spec.RKEConfig.ETCDSnapshotCreate.Generation + 1
+		}
+		spec.RKEConfig.ETCDSnapshotCreate = &rkev1.ETCDSnapshotCreate{
+			Generation: generation,
+		}
+		return int64(generation), nil
+	}
+	return 0, fmt.Errorf("unknown operation %s", operation)
+}
+
+// toCluster returns the state of the cluster, its machine pools sorted by name.
+func toCluster(cluster *provv1.Cluster) Cluster {
+	result := Cluster{
+		ClusterReference: ClusterReference{
+			Namespace: cluster.Namespace,
+			Name:      cluster.Name,
+		},
+		ClusterID:         cluster.Status.ClusterName,
+		KubernetesVersion: cluster.Spec.KubernetesVersion,
+		Ready:             cluster.Status.Ready,
+	}
+	if cluster.Spec.RKEConfig != nil {
+		for _, pool := range cluster.Spec.RKEConfig.MachinePools {
+			p := MachinePool{
+				Name:             pool.Name,
+				EtcdRole:         pool.EtcdRole,
+				ControlPlaneRole: pool.ControlPlaneRole,
+				WorkerRole:       pool.WorkerRole,
+				Paused:           pool.Paused,
+			}
+			if pool.Quantity != nil {
+				p.Quantity = *pool.Quantity
+			}
+			result.MachinePools = append(result.MachinePools, p)
+		}
+	}
+	sort.Slice(result.MachinePools, func(i, j int) bool {
+		return result.MachinePools[i].Name < result.MachinePools[j].Name
+	})
+	return result
+}
+
+// reference returns the reference with its namespace defaulted.
+func reference(ref ClusterReference) ClusterReference {
+	if ref.Namespace == "" {
+		ref.Namespace = defaultNamespace
+	}
+	return ref
+}
@@ -0,0 +1,106 @@
+package provisioningops
+
+import (
+	"testing"
+
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func quantity(q int32) *int32 {
+	return &q
+}
+
+func template() *provv1.Cluster {
+	return &provv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "template", Namespace: "fleet-default"},
+		Spec: provv1.ClusterSpec{
+			KubernetesVersion: "v1.24.4+rke2r1",
+			RKEConfig: &provv1.RKEConfig{
+				ETCDSnapshotCreate: &rkev1.ETCDSnapshotCreate{Generation: 2},
+				RotateCertificates: &rkev1.RotateCertificates{Generation: 3},
+				MachinePools: []provv1.RKEMachinePool{
+					{Name: "workers", Quantity: quantity(3), WorkerRole: true},
+					{Name: "control-plane", Quantity: quantity(1), EtcdRole: true, ControlPlaneRole: true},
+				},
+			},
+		},
+	}
+}
+
+func TestNewCluster(t *testing.T) {
+	cluster, err := newCluster(template(), CreateClusterRequest{
+		ClusterReference:      ClusterReference{Namespace: "fleet-default", Name: "copy"},
+		KubernetesVersion:     "v1.25.3+rke2r1",
+		Labels:                map[string]string{"env": "dev"},
+		MachinePoolQuantities: map[string]int32{"workers": 5},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "copy", cluster.Name)
+	assert.Equal(t, map[string]string{"env": "dev"}, cluster.Labels)
+	assert.Equal(t, "v1.25.3+rke2r1", cluster.Spec.KubernetesVersion)
+	assert.Nil(t, cluster.Spec.RKEConfig.ETCDSnapshotCreate)
+	assert.Nil(t, cluster.Spec.RKEConfig.RotateCertificates)
+	assert.Equal(t, int32(5), *cluster.Spec.RKEConfig.MachinePools[0].Quantity)
+	assert.Equal(t, int32(1), *cluster.Spec.RKEConfig.MachinePools[1].Quantity)
+
+	_, err = newCluster(template(), CreateClusterRequest{
+		ClusterReference:      ClusterReference{Namespace: "fleet-default", Name: "copy"},
+		MachinePoolQuantities: map[string]int32{"missing": 1},
+	})
+	assert.Error(t, err)
+
+	imported := template()
+	imported.Spec.RKEConfig = nil
+	_, err = newCluster(imported, CreateClusterRequest{ClusterReference: ClusterReference{Name: "copy"}})
+	assert.Error(t, err)
+}
+
+func TestSetPoolQuantity(t *testing.T) {
+	spec := template().Spec
+	require.NoError(t, setPoolQuantity(&spec, "control-plane", 3))
+	assert.Equal(t, int32(3), *spec.RKEConfig.MachinePools[1].Quantity)
+	assert.Error(t, setPoolQuantity(&spec, "control-plane", -1))
+	assert.Error(t, setPoolQuantity(&spec, "missing", 1))
+}
+
+func TestRequestOperation(t *testing.T) {
+	spec := template().Spec
+
+	generation, err := requestOperation(&spec, OperationRotateCertificates, []string{"etcd"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), generation)
+	assert.Equal(t, &rkev1.RotateCertificates{Generation: 4, Services: []string{"etcd"}}, spec.RKEConfig.RotateCertificates)
+
+	generation, err = requestOperation(&spec, OperationSnapshot, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), generation)
+
+	spec.RKEConfig.ETCDSnapshotCreate = nil
+	generation, err = requestOperation(&spec, OperationSnapshot, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), generation)
+
+	_, err = requestOperation(&spec, "restore", nil)
+	assert.Error(t, err)
+}
+
+func TestToCluster(t *testing.T) {
+	cluster := template()
+	cluster.Status.ClusterName = "c-m-1"
+	cluster.Status.Ready = true
+
+	assert.Equal(t, Cluster{
+		ClusterReference:  ClusterReference{Namespace: "fleet-default", Name: "template"},
+		ClusterID:         "c-m-1",
+		KubernetesVersion: "v1.24.4+rke2r1",
+		Ready:             true,
+		MachinePools: []MachinePool{
+			{Name: "control-plane", Quantity: 1, EtcdRole: true, ControlPlaneRole: true},
+			{Name: "workers", Quantity: 3, WorkerRole: true},
+		},
+	}, toCluster(cluster))
+}
@@ -0,0 +1,422 @@
+// Package provisioningops provides a HTTPHandler serving the provisioning operations of the Rancher CLI and of scripts:
+// creating a cluster from a template, scaling a machine pool, rotating certificates, taking a snapshot and downloading
+// a kubeconfig. The operations take and return the types of a version of the API, which are decoupled from the
+// provisioning CRDs. This handler should be registered at Endpoint
+package provisioningops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/mux"
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/auth/tokens"
+	"github.com/rancher/rancher/pkg/auth/util"
+	provcontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	mgmtv3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/kubeconfig"
+	"github.com/rancher/rancher/pkg/securityevents"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/rancher/rancher/pkg/user"
+	"github.com/sirupsen/logrus"
+	authzv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/client-go/dynamic"
+	authv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// Endpoint The endpoint prefix of the provisioning operations - used for routing
+	Endpoint = "/v1/provisioningops"
+	// Version is the version of the request and response types of the operations, the operations are served under
+	// Endpoint/Version.
+	Version   = "v1"
+	logPrefix = "provisioning-ops"
+
+	maxBodySize = 1 << 20
+
+	machineConfigAPIVersion = "rke-machine-config.cattle.io/v1"
+)
+
+var clusterResource = schema.GroupResource{Group: "provisioning.cattle.io", Resource: "clusters"}
+
+type clusterOperation func(req *http.Request, ref ClusterReference) (interface{}, error)
+
+// Handler implements http.Handler - and serves the provisioning operations
+type Handler struct {
+	clusters             provcontrollers.ClusterController
+	clusterCache         provcontrollers.ClusterCache
+	machineConfigs       dynamic.Interface
+	tokens               mgmtv3.TokenLister
+	userManager          user.Manager
+	subjectAccessReviews authv1.SubjectAccessReviewInterface
+	router               *mux.Router
+}
+
+// NewHandler creates a handler using the clients defined in scaledContext
+func NewHandler(scaledContext *config.ScaledContext) (*Handler, error) {
+	machineConfigs, err := dynamic.NewForConfig(&scaledContext.RESTConfig)
+	if err != nil {
+		return nil, err
+	}
+	h := &Handler{
+		clusters:             scaledContext.Wrangler.Provisioning.Cluster(),
+		clusterCache:         scaledContext.Wrangler.Provisioning.Cluster().Cache(),
+		machineConfigs:       machineConfigs,
+		tokens:               scaledContext.Management.Tokens("").Controller().Lister(),
+		userManager:          scaledContext.UserManager,
+		subjectAccessReviews: scaledContext.K8sClient.AuthorizationV1().SubjectAccessReviews(),
+		router:               mux.NewRouter(),
+	}
+
+	prefix := Endpoint + "/" + Version
+	cluster := prefix + "/clusters/{namespace}/{name}"
+	h.router.UseEncodedPath()
+	h.router.Path(prefix + "/clusters").Methods(http.MethodPost).HandlerFunc(h.createCluster)
+	h.router.Path(cluster).Methods(http.MethodGet).Handler(h.handle("get", h.getCluster))
+	h.router.Path(cluster + "/pools/{pool}/scale").Methods(http.MethodPost).Handler(h.handle("update", h.scalePool))
+	h.router.Path(cluster + "/rotatecertificates").Methods(http.MethodPost).Handler(h.handle("update", h.rotateCertificates))
+	h.router.Path(cluster + "/snapshots").Methods(http.MethodPost).Handler(h.handle("update", h.takeSnapshot))
+	h.router.Path(cluster + "/kubeconfig").Methods(http.MethodGet).Handler(h.handle("get", h.kubeconfig))
+	return h, nil
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	h.router.ServeHTTP(writer, req)
+}
+
+// handle runs the operation on the cluster of the request if the user can use the verb on the cluster.
+func (h *Handler) handle(verb string, next clusterOperation) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		vars := mux.Vars(req)
+		ref := ClusterReference{Namespace: vars["namespace"], Name: vars["name"]}
+		if err := h.authorize(req, verb, ref.Namespace, ref.Name); err != nil {
+			writeError(writer, req, err)
+			return
+		}
+
+		req.Body = http.MaxBytesReader(writer, req.Body, maxBodySize)
+		result, err := next(req, ref)
+		if err != nil {
+			writeError(writer, req, err)
+			return
+		}
+		writeJSON(writer, http.StatusOK, result)
+	})
+}
+
+// createCluster creates a cluster from a template, if the user can create clusters in the namespace of the cluster and
+// can get the template.
+func (h *Handler) createCluster(writer http.ResponseWriter, req *http.Request) {
+	var input CreateClusterRequest
+	if err := json.NewDecoder(http.MaxBytesReader(writer, req.Body, maxBodySize)).Decode(&input); err != nil {
+		writeError(writer, req, apierrors.NewBadRequest(fmt.Sprintf("failed to parse request: %v", err)))
+		return
+	}
+	input.ClusterReference = reference(input.ClusterReference)
+	input.Template = reference(input.Template)
+	if input.Name == "" || input.Template.Name == "" {
+		writeError(writer, req, apierrors.NewBadRequest("name and template are required"))
+		return
+	}
+	if err := h.authorize(req, "create", input.Namespace, ""); err != nil {
+		writeError(writer, req, err)
+		return
+	}
+	if err := h.authorize(req, "get", input.Template.Namespace, input.Template.Name); err != nil {
+		writeError(writer, req, err)
+		return
+	}
+
+	template, err := h.clusterCache.Get(input.Template.Namespace, input.Template.Name)
+	if err != nil {
+		writeError(writer, req, err)
+		return
+	}
+	cluster, err := newCluster(template, input)
+	if err != nil {
+		writeError(writer, req, apierrors.NewBadRequest(err.Error()))
+		return
+	}
+
+	// the machine configs of the pools are copied, as they're owned by the cluster using them
+	var copies []*unstructured.Unstructured
+	for i := range cluster.Spec.RKEConfig.MachinePools {
+		pool := &cluster.Spec.RKEConfig.MachinePools[i]
+		if pool.NodeConfig == nil {
+			continue
+		}
+		machineConfig, err := h.copyMachineConfig(req.Context(), template, cluster, pool)
+		if err != nil {
+			h.deleteMachineConfigs(req.Context(), copies)
+			writeError(writer, req, err)
+			return
+		}
+		copies = append(copies, machineConfig)
+		pool.NodeConfig = &corev1.ObjectReference{
+			Kind:       machineConfig.GetKind(),
+			APIVersion: machineConfig.GetAPIVersion(),
+			Name:       machineConfig.GetName(),
+		}
+	}
+
+	created, err := h.clusters.Create(cluster)
+	if err != nil {
+		h.deleteMachineConfigs(req.Context(), copies)
+		writeError(writer, req, err)
+		return
+	}
+	writeJSON(writer, http.StatusCreated, toCluster(created))
+}
+
+func (h *Handler) getCluster(_ *http.Request, ref ClusterReference) (interface{}, error) {
+	cluster, err := h.clusterCache.Get(ref.Namespace, ref.Name)
+	if err != nil {
+		return nil, err
+	}
+	return toCluster(cluster), nil
+}
+
+func (h *Handler) scalePool(req *http.Request, ref ClusterReference) (interface{}, error) {
+	var input ScalePoolRequest
+	if err := json.NewDecoder(req.Body).Decode(&input); err != nil {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("failed to parse request: %v", err))
+	}
+	pool := mux.Vars(req)["pool"]
+
+	cluster, err := h.update(ref, func(spec *provv1.ClusterSpec) error {
+		if err := setPoolQuantity(spec, pool, input.Quantity); err != nil {
+			return apierrors.NewBadRequest(err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toCluster(cluster), nil
+}
+
+func (h *Handler) rotateCertificates(req *http.Request, ref ClusterReference) (interface{}, error) {
+	var input RotateCertificatesRequest
+	if err := json.NewDecoder(req.Body).Decode(&input); err != nil && !errors.Is(err, io.EOF) {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("failed to parse request: %v", err))
+	}
+	return h.requestOperation(ref, OperationRotateCertificates, input.Services)
+}
+
+func (h *Handler) takeSnapshot(_ *http.Request, ref ClusterReference) (interface{}, error) {
+	return h.requestOperation(ref, OperationSnapshot, nil)
+}
+
+func (h *Handler) requestOperation(ref ClusterReference, operation string, services []string) (interface{}, error) {
+	var generation int64
+	_, err := h.update(ref, func(spec *provv1.ClusterSpec) error {
+		var err error
+		generation, err = requestOperation(spec, operation, services)
+		if err != nil {
+			return apierrors.NewBadRequest(err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return Operation{
+		Cluster:    ref,
+		Operation:  operation,
+		Generation: generation,
+	}, nil
+}
+
+// kubeconfig returns a kubeconfig of the cluster proxied by Rancher. A kubeconfig token is generated for the user if
+// kubeconfig tokens are generated, the kubeconfig uses the credential plugin of the Rancher CLI otherwise.
+func (h *Handler) kubeconfig(req *http.Request, ref ClusterReference) (interface{}, error) {
+	cluster, err := h.clusterCache.Get(ref.Namespace, ref.Name)
+	if err != nil {
+		return nil, err
+	}
+	if cluster.Status.ClusterName == "" {
+		return nil, apierrors.NewConflict(clusterResource, ref.Name, fmt.Errorf("cluster isn't registered with Rancher yet"))
+	}
+
+	userName := ""
+	if userInfo, ok := request.UserFrom(req.Context()); ok {
+		userName = userInfo.GetName()
+	}
+
+	var tokenKey string
+	generateToken := strings.EqualFold(settings.KubeconfigGenerateToken.Get(), "true")
+	if generateToken {
+		if tokenKey, err = h.ensureToken(req, userName); err != nil {
+			return nil, err
+		}
+	}
+
+	host := req.Host
+	if u, err := url.Parse(settings.ServerURL.Get()); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	cfg, err := kubeconfig.ForTokenBased(cluster.Name, cluster.Status.ClusterName, host, tokenKey)
+	if err != nil {
+		return nil, err
+	}
+
+	event := securityevents.NewEvent(securityevents.KubeconfigDownload, securityevents.OutcomeSuccess, req)
+	event.User = userName
+	event.Resource = "clusters/" + cluster.Status.ClusterName
+	event.Details = map[string]string{"tokenGenerated": fmt.Sprint(generateToken)}
+	securityevents.Emit(event)
+
+	return Kubeconfig{Config: cfg}, nil
+}
+
+// ensureToken creates a kubeconfig token for the user, with the default TTL of kubeconfig tokens.
+func (h *Handler) ensureToken(req *http.Request, userName string) (string, error) {
+	tokenName, _ := tokens.SplitTokenParts(tokens.GetTokenAuthFromRequest(req))
+	authToken, err := h.tokens.Get("", tokenName)
+	if err != nil {
+		return "", apierrors.NewForbidden(clusterResource, "", fmt.Errorf("kubeconfigs can only be generated with a Rancher token"))
+	}
+	ttl, err := tokens.GetKubeconfigDefaultTokenTTLInMilliSeconds()
+	if err != nil {
+		return "", err
+	}
+	return h.userManager.EnsureToken(user.TokenInput{
+		TokenName:     "kubeconfig-" + userName,
+		Description:   "Kubeconfig token",
+		Kind:          "kubeconfig",
+		UserName:      userName,
+		AuthProvider:  authToken.AuthProvider,
+		TTL:           ttl,
+		Randomize:     true,
+		UserPrincipal: authToken.UserPrincipal,
+	})
+}
+
+// update applies the change to the spec of the cluster, retrying on conflicts.
+func (h *Handler) update(ref ClusterReference, change func(*provv1.ClusterSpec) error) (*provv1.Cluster, error) {
+	var result *provv1.Cluster
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cluster, err := h.clusters.Get(ref.Namespace, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		cluster = cluster.DeepCopy()
+		if err := change(&cluster.Spec); err != nil {
+			return err
+		}
+		result, err = h.clusters.Update(cluster)
+		return err
+	})
+	return result, err
+}
+
+// copyMachineConfig creates a copy of the machine config of the pool of the template for the pool of the cluster.
+func (h *Handler) copyMachineConfig(ctx context.Context, template, cluster *provv1.Cluster, pool *provv1.RKEMachinePool) (*unstructured.Unstructured, error) {
+	apiVersion := pool.NodeConfig.APIVersion
+	if apiVersion == "" {
+		apiVersion = machineConfigAPIVersion
+	}
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	resource := gv.WithResource(strings.ToLower(pool.NodeConfig.Kind) + "s")
+
+	source, err := h.machineConfigs.Resource(resource).Namespace(template.Namespace).Get(ctx, pool.NodeConfig.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("getting machine config of pool %s of template: %w", pool.Name, err)
+	}
+
+	machineConfig := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	for k, v := range source.Object {
+		if k != "metadata" && k != "status" {
+			machineConfig.Object[k] = v
+		}
+	}
+	machineConfig.SetNamespace(cluster.Namespace)
+	machineConfig.SetGenerateName("nc-" + cluster.Name + "-" + pool.Name + "-")
+	return h.machineConfigs.Resource(resource).Namespace(cluster.Namespace).Create(ctx, machineConfig, metav1.CreateOptions{})
+}
+
+func (h *Handler) deleteMachineConfigs(ctx context.Context, machineConfigs []*unstructured.Unstructured) {
+	for _, machineConfig := range machineConfigs {
+		gv, err := schema.ParseGroupVersion(machineConfig.GetAPIVersion())
+		if err != nil {
+			continue
+		}
+		resource := gv.WithResource(strings.ToLower(machineConfig.GetKind()) + "s")
+		err = h.machineConfigs.Resource(resource).Namespace(machineConfig.GetNamespace()).Delete(ctx, machineConfig.GetName(), metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			logrus.Warnf("[%s] Failed to delete machine config %s/%s: %v", logPrefix, machineConfig.GetNamespace(), machineConfig.GetName(), err)
+		}
+	}
+}
+
+// authorize returns a forbidden error unless the user can use the verb on the clusters of the namespace, or on the
+// cluster of the name if set.
+func (h *Handler) authorize(r *http.Request, verb, namespace, name string) error {
+	userInfo, ok := request.UserFrom(r.Context())
+	if !ok {
+		return apierrors.NewUnauthorized("unable to extract user info from context")
+	}
+	extra := map[string]authzv1.ExtraValue{}
+	for k, v := range userInfo.GetExtra() {
+		extra[k] = authzv1.ExtraValue(v)
+	}
+	response, err := h.subjectAccessReviews.Create(r.Context(), &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Group:     clusterResource.Group,
+				Resource:  clusterResource.Resource,
+				Namespace: namespace,
+				Name:      name,
+				Verb:      verb,
+			},
+			User:   userInfo.GetName(),
+			Groups: userInfo.GetGroups(),
+			Extra:  extra,
+			UID:    userInfo.GetUID(),
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create sar %s", err)
+	}
+	if !response.Status.Allowed {
+		return apierrors.NewForbidden(clusterResource, name, fmt.Errorf("user can not %s clusters in namespace %s", verb, namespace))
+	}
+	return nil
+}
+
+func writeJSON(writer http.ResponseWriter, status int, value interface{}) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	if err := json.NewEncoder(writer).Encode(value); err != nil {
+		logrus.Warnf("[%s] Failed to write response: %v", logPrefix, err)
+	}
+}
+
+// writeError writes the status of API errors, other errors are internal errors.
+func writeError(writer http.ResponseWriter, req *http.Request, err error) {
+	var status apierrors.APIStatus
+	if errors.As(err, &status) && status.Status().Code != 0 {
+		util.ReturnHTTPError(writer, req, int(status.Status().Code), status.Status().Message)
+		return
+	}
+	logrus.Errorf("[%s] Error handling request %s %s: %v", logPrefix, req.Method, req.URL.Path, err)
+	util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+}
@@ -0,0 +1,64 @@
+package provisioningops
+
+// The request and response types of the operations are part of the API of Version, and are kept compatible within it
+// whatever the changes of the provisioning CRDs they are mapped to.
+
+// ClusterReference references a provisioning cluster. The namespace defaults to fleet-default.
+type ClusterReference struct {
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// CreateClusterRequest creates a cluster from the configuration and machine pools of a template cluster. The machine
+// configs of the machine pools of the template are copied.
+type CreateClusterRequest struct {
+	ClusterReference
+	Template ClusterReference `json:"template"`
+	// KubernetesVersion overrides the Kubernetes version of the template.
+	KubernetesVersion string            `json:"kubernetesVersion,omitempty"`
+	Labels            map[string]string `json:"labels,omitempty"`
+	// MachinePoolQuantities overrides the quantities of the machine pools of the template, by pool name.
+	MachinePoolQuantities map[string]int32 `json:"machinePoolQuantities,omitempty"`
+}
+
+// ScalePoolRequest sets the number of machines of a machine pool.
+type ScalePoolRequest struct {
+	Quantity int32 `json:"quantity"`
+}
+
+// RotateCertificatesRequest rotates the certificates of the services of a cluster, of all services if empty.
+type RotateCertificatesRequest struct {
+	Services []string `json:"services,omitempty"`
+}
+
+// Cluster is the state of a provisioning cluster.
+type Cluster struct {
+	ClusterReference
+	// ClusterID is the ID of the management cluster of the cluster, used by the other APIs of Rancher.
+	ClusterID         string        `json:"clusterId,omitempty"`
+	KubernetesVersion string        `json:"kubernetesVersion,omitempty"`
+	Ready             bool          `json:"ready"`
+	MachinePools      []MachinePool `json:"machinePools,omitempty"`
+}
+
+// MachinePool is a machine pool of a cluster.
+type MachinePool struct {
+	Name             string `json:"name"`
+	Quantity         int32  `json:"quantity"`
+	EtcdRole         bool   `json:"etcdRole,omitempty"`
+	ControlPlaneRole bool   `json:"controlPlaneRole,omitempty"`
+	WorkerRole       bool   `json:"workerRole,omitempty"`
+	Paused           bool   `json:"paused,omitempty"`
+}
+
+// Operation is an operation requested on a cluster, which is carried out once the cluster reconciles the generation.
+type Operation struct {
+	Cluster    ClusterReference `json:"cluster"`
+	Operation  string           `json:"operation"`
+	Generation int64            `json:"generation"`
+}
+
+// Kubeconfig is a kubeconfig of a cluster for the user.
+type Kubeconfig struct {
+	Config string `json:"config"`
+}
@@ -11,11 +11,12 @@
 	gmux "github.com/gorilla/mux"
 	v3 "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
 	managementv3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/tracing"
 	"github.com/rancher/remotedialer"
 	"github.com/rancher/steve/pkg/auth"
 	"github.com/rancher/steve/pkg/proxy"
-	"github.com/sirupsen/logrus"
 	authzv1 "k8s.io/api/authorization/v1"
 	"k8s.io/apiserver/pkg/authentication/user"
 	"k8s.io/apiserver/pkg/authorization/authorizer"
@@ -169,6 +170,9 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	logging.FromContext(req.Context(), logging.Proxy).Debugf("steve.proxy: proxying %s %s to cluster %s", req.Method, req.URL.Path, clusterID)
+	req, span := tracing.StartProxySpan(req, clusterID)
+	defer span.End()
 	handler.ServeHTTP(rw, req)
 }
 
@@ -186,15 +190,16 @@ func (h *Handler) dialer(ctx context.Context, network, address string) (net.Conn
 		return nil, err
 	}
 	dialer := h.dialerFactory("stv-cluster-" + host)
+	log := logging.FromContext(ctx, logging.Proxy)
 	var conn net.Conn
 	for i := 0; i < 15; i++ {
 		conn, err = dialer(ctx, network, "127.0.0.1:6080")
 		if err != nil && strings.Contains(err.Error(), "failed to find Session for client") {
 			if i < 14 {
-				logrus.Tracef("steve.proxy.dialer: lost connection, retrying")
+				log.Tracef("steve.proxy.dialer: lost connection, retrying")
 				time.Sleep(time.Second)
 			} else {
-				logrus.Tracef("steve.proxy.dialer: lost connection, failed to reconnect after 15 attempts")
+				log.Tracef("steve.proxy.dialer: lost connection, failed to reconnect after 15 attempts")
 			}
 		} else {
 			break
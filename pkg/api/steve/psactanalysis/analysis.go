@@ -0,0 +1,173 @@
+// Package psactanalysis provides a HTTPHandler to evaluate the pods of a cluster against a pod security admission
+// configuration template before it is enforced. This handler should be registered at Endpoint
+package psactanalysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/auth/util"
+	mgmtv3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/podsecurity"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/sirupsen/logrus"
+	authzv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	authv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+const (
+	// Endpoint The endpoint that the analysis of a cluster is accessible at - used for routing. The template to evaluate
+	// is set with the template query parameter, and defaults to the template of the cluster.
+	Endpoint  = "/v1/psact/clusters/{cluster}/analysis"
+	logPrefix = "psact-analysis"
+)
+
+// UserContextGetter returns the clients of a downstream cluster.
+type UserContextGetter interface {
+	UserContextNoControllers(clusterName string) (*config.UserContext, error)
+}
+
+// Handler implements http.Handler - and serves the analysis of clusters
+type Handler struct {
+	Clusters             mgmtv3.ClusterLister
+	Templates            mgmtv3.PodSecurityAdmissionConfigurationTemplateLister
+	SubjectAccessReviews authv1.SubjectAccessReviewInterface
+	UserContexts         UserContextGetter
+}
+
+// NewHandler creates a handler using the clients defined in scaledContext
+func NewHandler(scaledContext *config.ScaledContext, userContexts UserContextGetter) Handler {
+	return Handler{
+		Clusters:             scaledContext.Management.Clusters("").Controller().Lister(),
+		Templates:            scaledContext.Management.PodSecurityAdmissionConfigurationTemplates("").Controller().Lister(),
+		SubjectAccessReviews: scaledContext.K8sClient.AuthorizationV1().SubjectAccessReviews(),
+		UserContexts:         userContexts,
+	}
+}
+
+// ServeHTTP implements http.Handler - returns the analysis of the cluster if the user can update the cluster, as only
+// users allowed to change the template of a cluster need to evaluate it
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	clusterName := mux.Vars(req)["cluster"]
+
+	authorized, err := h.authorize(req, clusterName)
+	if err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		logrus.Errorf("[%s] Failed to authorize user with error: %s", logPrefix, err.Error())
+		return
+	}
+	if !authorized {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		return
+	}
+
+	cluster, err := h.Clusters.Get("", clusterName)
+	if err != nil {
+		h.returnGetError(writer, req, err)
+		return
+	}
+
+	templateName := req.URL.Query().Get("template")
+	if templateName == "" {
+		templateName = cluster.Spec.DefaultPodSecurityAdmissionConfigurationTemplateName
+	}
+	if templateName == "" {
+		util.ReturnHTTPError(writer, req, http.StatusBadRequest, "template is required for a cluster without pod security admission configuration template")
+		return
+	}
+	template, err := h.Templates.Get("", templateName)
+	if err != nil {
+		h.returnGetError(writer, req, err)
+		return
+	}
+
+	analysis, err := h.analyze(req, cluster, template)
+	if err != nil {
+		logrus.Errorf("[%s] Error analyzing cluster %s: %v", logPrefix, clusterName, err)
+		util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(analysis); err != nil {
+		logrus.Warnf("[%s] Failed to write analysis of cluster %s: %v", logPrefix, clusterName, err)
+	}
+}
+
+func (h *Handler) analyze(req *http.Request, cluster *v3.Cluster, template *v3.PodSecurityAdmissionConfigurationTemplate) (*podsecurity.Analysis, error) {
+	userContext, err := h.UserContexts.UserContextNoControllers(cluster.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaceList, err := userContext.K8sClient.CoreV1().Namespaces().List(req.Context(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	podList, err := userContext.K8sClient.CoreV1().Pods("").List(req.Context(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := make([]*corev1.Namespace, 0, len(namespaceList.Items))
+	for i := range namespaceList.Items {
+		namespaces = append(namespaces, &namespaceList.Items[i])
+	}
+	pods := make([]*corev1.Pod, 0, len(podList.Items))
+	for i := range podList.Items {
+		pods = append(pods, &podList.Items[i])
+	}
+
+	evaluator, err := podsecurity.NewEvaluator()
+	if err != nil {
+		return nil, err
+	}
+	return evaluator.Analyze(template, namespaces, pods)
+}
+
+func (h *Handler) returnGetError(writer http.ResponseWriter, req *http.Request, err error) {
+	if apierrors.IsNotFound(err) {
+		util.ReturnHTTPError(writer, req, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+	logrus.Errorf("[%s] Error getting object: %v", logPrefix, err)
+	util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+}
+
+// authorize checks to see if the user can update the cluster. Returns a bool (if the user is authorized) and optionally
+// an error
+func (h *Handler) authorize(r *http.Request, clusterName string) (bool, error) {
+	userInfo, ok := request.UserFrom(r.Context())
+	if !ok {
+		return false, fmt.Errorf("unable to extract user info from context")
+	}
+	extra := map[string]authzv1.ExtraValue{}
+	for k, v := range userInfo.GetExtra() {
+		extra[k] = authzv1.ExtraValue(v)
+	}
+	response, err := h.SubjectAccessReviews.Create(r.Context(), &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Group:    v3.SchemeGroupVersion.Group,
+				Resource: v3.ClusterResourceName,
+				Verb:     "update",
+				Name:     clusterName,
+			},
+			User:   userInfo.GetName(),
+			Groups: userInfo.GetGroups(),
+			Extra:  extra,
+			UID:    userInfo.GetUID(),
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to create sar %s", err)
+	}
+	return response.Status.Allowed, nil
+}
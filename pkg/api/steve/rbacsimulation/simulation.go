@@ -0,0 +1,303 @@
+// Package rbacsimulation provides a HTTPHandler simulating a change of a cluster or project role template binding, and
+// returning the permissions the subject of the binding would gain and lose in each cluster and project, without applying
+// the change. This handler should be registered at Endpoint
+package rbacsimulation
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/auth/util"
+	mgmtv3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/rbac"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/sirupsen/logrus"
+	authzv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	authv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+const (
+	// Endpoint The endpoint that the simulation of role template binding changes is accessible at - used for routing
+	Endpoint  = "/v1/rbacsimulation"
+	logPrefix = "rbac-simulation"
+
+	maxBodySize = 1 << 20
+)
+
+// Input is the change of a binding to simulate, exactly one of the bindings must be set. The binding is created, or
+// updated if a binding of the same namespace and name exists, unless Delete is set.
+type Input struct {
+	ClusterRoleTemplateBinding *v3.ClusterRoleTemplateBinding `json:"clusterRoleTemplateBinding,omitempty"`
+	ProjectRoleTemplateBinding *v3.ProjectRoleTemplateBinding `json:"projectRoleTemplateBinding,omitempty"`
+	// Delete simulates the removal of the existing binding of the namespace and name of the binding.
+	Delete bool `json:"delete,omitempty"`
+}
+
+// Simulation is the net change of the permissions of the subject of the binding.
+type Simulation struct {
+	UserName           string `json:"userName,omitempty"`
+	UserPrincipalName  string `json:"userPrincipalName,omitempty"`
+	GroupPrincipalName string `json:"groupPrincipalName,omitempty"`
+	// Operation is create, update or delete.
+	Operation string                 `json:"operation"`
+	Deltas    []rbac.PermissionDelta `json:"deltas"`
+	// ExternalRoleTemplates are the external role templates of the bindings of the subject, whose permissions are those
+	// of the cluster role of the same name in each downstream cluster and aren't part of the deltas.
+	ExternalRoleTemplates []string `json:"externalRoleTemplates,omitempty"`
+}
+
+// Handler implements http.Handler - and simulates role template binding changes
+type Handler struct {
+	CRTBs                mgmtv3.ClusterRoleTemplateBindingLister
+	PRTBs                mgmtv3.ProjectRoleTemplateBindingLister
+	RoleTemplates        mgmtv3.RoleTemplateLister
+	UserAttributes       mgmtv3.UserAttributeLister
+	SubjectAccessReviews authv1.SubjectAccessReviewInterface
+}
+
+// NewHandler creates a handler using the clients defined in scaledContext
+func NewHandler(scaledContext *config.ScaledContext) Handler {
+	return Handler{
+		CRTBs:                scaledContext.Management.ClusterRoleTemplateBindings("").Controller().Lister(),
+		PRTBs:                scaledContext.Management.ProjectRoleTemplateBindings("").Controller().Lister(),
+		RoleTemplates:        scaledContext.Management.RoleTemplates("").Controller().Lister(),
+		UserAttributes:       scaledContext.Management.UserAttributes("").Controller().Lister(),
+		SubjectAccessReviews: scaledContext.K8sClient.AuthorizationV1().SubjectAccessReviews(),
+	}
+}
+
+// binding is the subject, target and role template of a cluster or project role template binding.
+type binding struct {
+	resource           string
+	namespace          string
+	name               string
+	userName           string
+	userPrincipalName  string
+	groupPrincipalName string
+	roleTemplateName   string
+}
+
+// ServeHTTP implements http.Handler - returns the permissions the subject of the binding would gain and lose if the
+// change of the binding was applied, if the user is allowed to apply it
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	var input Input
+	if err := json.NewDecoder(http.MaxBytesReader(writer, req.Body, maxBodySize)).Decode(&input); err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusBadRequest, fmt.Sprintf("failed to parse simulation: %v", err))
+		return
+	}
+	if (input.ClusterRoleTemplateBinding == nil) == (input.ProjectRoleTemplateBinding == nil) {
+		util.ReturnHTTPError(writer, req, http.StatusBadRequest, "exactly one of clusterRoleTemplateBinding and projectRoleTemplateBinding must be set")
+		return
+	}
+
+	crtbs, err := h.CRTBs.List("", labels.Everything())
+	if err != nil {
+		h.internalError(writer, req, "listing cluster role template bindings", err)
+		return
+	}
+	prtbs, err := h.PRTBs.List("", labels.Everything())
+	if err != nil {
+		h.internalError(writer, req, "listing project role template bindings", err)
+		return
+	}
+
+	// the bindings after the change, the proposed binding replacing the existing binding of the same namespace and name
+	var (
+		proposed     binding
+		existing     bool
+		afterCRTBs   = crtbs
+		afterPRTBs   = prtbs
+		proposedCRTB = input.ClusterRoleTemplateBinding
+		proposedPRTB = input.ProjectRoleTemplateBinding
+	)
+	if proposedCRTB != nil {
+		afterCRTBs = nil
+		if proposedCRTB.Namespace == "" {
+			proposedCRTB.Namespace = proposedCRTB.ClusterName
+		}
+		for _, crtb := range crtbs {
+			if crtb.Namespace == proposedCRTB.Namespace && crtb.Name == proposedCRTB.Name {
+				existing = true
+				if input.Delete {
+					proposedCRTB = crtb
+				}
+				continue
+			}
+			afterCRTBs = append(afterCRTBs, crtb)
+		}
+		if !input.Delete {
+			afterCRTBs = append(afterCRTBs, proposedCRTB)
+		}
+		proposed = binding{
+			resource:           v3.ClusterRoleTemplateBindingResourceName,
+			namespace:          proposedCRTB.Namespace,
+			name:               proposedCRTB.Name,
+			userName:           proposedCRTB.UserName,
+			userPrincipalName:  proposedCRTB.UserPrincipalName,
+			groupPrincipalName: proposedCRTB.GroupPrincipalName,
+			roleTemplateName:   proposedCRTB.RoleTemplateName,
+		}
+	} else {
+		afterPRTBs = nil
+		if _, projectName, ok := strings.Cut(proposedPRTB.ProjectName, ":"); ok && proposedPRTB.Namespace == "" {
+			proposedPRTB.Namespace = projectName
+		}
+		for _, prtb := range prtbs {
+			if prtb.Namespace == proposedPRTB.Namespace && prtb.Name == proposedPRTB.Name {
+				existing = true
+				if input.Delete {
+					proposedPRTB = prtb
+				}
+				continue
+			}
+			afterPRTBs = append(afterPRTBs, prtb)
+		}
+		if !input.Delete {
+			afterPRTBs = append(afterPRTBs, proposedPRTB)
+		}
+		proposed = binding{
+			resource:           v3.ProjectRoleTemplateBindingResourceName,
+			namespace:          proposedPRTB.Namespace,
+			name:               proposedPRTB.Name,
+			userName:           proposedPRTB.UserName,
+			userPrincipalName:  proposedPRTB.UserPrincipalName,
+			groupPrincipalName: proposedPRTB.GroupPrincipalName,
+			roleTemplateName:   proposedPRTB.RoleTemplateName,
+		}
+	}
+
+	operation := "create"
+	if input.Delete {
+		operation = "delete"
+	} else if existing {
+		operation = "update"
+	}
+
+	authorized, err := h.authorize(req, proposed, operation)
+	if err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		logrus.Errorf("[%s] Failed to authorize user with error: %s", logPrefix, err.Error())
+		return
+	}
+	if !authorized {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		return
+	}
+
+	if input.Delete && !existing {
+		util.ReturnHTTPError(writer, req, http.StatusNotFound, fmt.Sprintf("%s %s/%s not found", proposed.resource, proposed.namespace, proposed.name))
+		return
+	}
+	if proposed.userName == "" && proposed.userPrincipalName == "" && proposed.groupPrincipalName == "" {
+		util.ReturnHTTPError(writer, req, http.StatusUnprocessableEntity, "binding must have a userName, userPrincipalName or groupPrincipalName")
+		return
+	}
+	if _, err := h.RoleTemplates.Get("", proposed.roleTemplateName); apierrors.IsNotFound(err) {
+		util.ReturnHTTPError(writer, req, http.StatusUnprocessableEntity, fmt.Sprintf("role template %s not found", proposed.roleTemplateName))
+		return
+	}
+
+	subject, err := h.subject(proposed)
+	if err != nil {
+		h.internalError(writer, req, "getting the groups of user "+proposed.userName, err)
+		return
+	}
+	roleTemplates, err := h.RoleTemplates.List("", labels.Everything())
+	if err != nil {
+		h.internalError(writer, req, "listing role templates", err)
+		return
+	}
+
+	before := rbac.SubjectBindings(subject, crtbs, prtbs)
+	after := rbac.SubjectBindings(subject, afterCRTBs, afterPRTBs)
+	deltas, external, err := rbac.BindingsDelta(before, after, roleTemplates)
+	if err != nil {
+		h.internalError(writer, req, "resolving role templates", err)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(Simulation{
+		UserName:              proposed.userName,
+		UserPrincipalName:     proposed.userPrincipalName,
+		GroupPrincipalName:    proposed.groupPrincipalName,
+		Operation:             operation,
+		Deltas:                deltas,
+		ExternalRoleTemplates: external,
+	}); err != nil {
+		logrus.Warnf("[%s] Failed to write simulation: %v", logPrefix, err)
+	}
+}
+
+// subject returns the subject of the binding. The permissions a user gets from the groups it's a member of are part of
+// the permissions of the user, the groups of the user are those of its last login.
+func (h *Handler) subject(b binding) (rbac.BindingSubject, error) {
+	subject := rbac.BindingSubject{
+		UserName:          b.userName,
+		UserPrincipalName: b.userPrincipalName,
+	}
+	if b.userName == "" && b.userPrincipalName == "" {
+		subject.GroupPrincipalNames = []string{b.groupPrincipalName}
+		return subject, nil
+	}
+	if b.userName == "" {
+		return subject, nil
+	}
+
+	attribute, err := h.UserAttributes.Get("", b.userName)
+	if apierrors.IsNotFound(err) {
+		return subject, nil
+	} else if err != nil {
+		return subject, err
+	}
+	for _, principals := range attribute.GroupPrincipals {
+		for _, principal := range principals.Items {
+			subject.GroupPrincipalNames = append(subject.GroupPrincipalNames, principal.Name)
+		}
+	}
+	return subject, nil
+}
+
+func (h *Handler) internalError(writer http.ResponseWriter, req *http.Request, action string, err error) {
+	logrus.Errorf("[%s] Error %s: %v", logPrefix, action, err)
+	util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+}
+
+// authorize checks to see if the user can apply the change of the binding. Returns a bool (if the user is authorized)
+// and optionally an error
+func (h *Handler) authorize(r *http.Request, b binding, verb string) (bool, error) {
+	userInfo, ok := request.UserFrom(r.Context())
+	if !ok {
+		return false, fmt.Errorf("unable to extract user info from context")
+	}
+	extra := map[string]authzv1.ExtraValue{}
+	for k, v := range userInfo.GetExtra() {
+		extra[k] = authzv1.ExtraValue(v)
+	}
+	response, err := h.SubjectAccessReviews.Create(r.Context(), &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Group:     v3.SchemeGroupVersion.Group,
+				Resource:  b.resource,
+				Namespace: b.namespace,
+				Name:      b.name,
+				Verb:      verb,
+			},
+			User:   userInfo.GetName(),
+			Groups: userInfo.GetGroups(),
+			Extra:  extra,
+			UID:    userInfo.GetUID(),
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to create sar %s", err)
+	}
+	return response.Status.Allowed, nil
+}
@@ -0,0 +1,90 @@
+// Package readcache caches the lists of frequently polled management objects served by Steve, such as settings and
+// clusters, for a few seconds per user and query. The lists of a kind are invalidated as soon as an object of the kind
+// changes, so that the cache only saves the work of repeated identical requests.
+package readcache
+
+import (
+	"sync"
+	"time"
+)
+
+// maxEntries bounds the number of cached lists, the cache being cleared when it's full.
+const maxEntries = 2048
+
+type entry struct {
+	value      interface{}
+	generation uint64
+	expires    time.Time
+}
+
+// cache holds values by group and key. Each group has a generation, incremented when the group is invalidated, so that
+// values computed while the group changed aren't cached.
+type cache struct {
+	lock        sync.Mutex
+	entries     map[string]map[string]entry
+	generations map[string]uint64
+	size        int
+	now         func() time.Time
+}
+
+func newCache() *cache {
+	return &cache{
+		entries:     map[string]map[string]entry{},
+		generations: map[string]uint64{},
+		now:         time.Now,
+	}
+}
+
+// generation returns the current generation of a group, to pass to add once the value is computed.
+func (c *cache) generation(group string) uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.generations[group]
+}
+
+// get returns the value of a key of a group if it's cached and not expired.
+func (c *cache) get(group, key string) (interface{}, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	e, ok := c.entries[group][key]
+	if !ok || c.now().After(e.expires) || e.generation != c.generations[group] {
+		return nil, false
+	}
+	return e.value, true
+}
+
+// add caches the value of a key of a group for ttl, unless the group was invalidated since generation.
+func (c *cache) add(group, key string, value interface{}, generation uint64, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if generation != c.generations[group] {
+		return
+	}
+	if c.size >= maxEntries {
+		c.entries = map[string]map[string]entry{}
+		c.size = 0
+	}
+	if c.entries[group] == nil {
+		c.entries[group] = map[string]entry{}
+	}
+	if _, ok := c.entries[group][key]; !ok {
+		c.size++
+	}
+	c.entries[group][key] = entry{
+		value:      value,
+		generation: generation,
+		expires:    c.now().Add(ttl),
+	}
+}
+
+// invalidate drops the values of a group.
+func (c *cache) invalidate(group string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.generations[group]++
+	c.size -= len(c.entries[group])
+	delete(c.entries, group)
+}
@@ -0,0 +1,71 @@
+package readcache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	c := newCache()
+	c.now = func() time.Time { return now }
+
+	_, ok := c.get("settings", "admin")
+	assert.False(t, ok)
+
+	c.add("settings", "admin", "list", c.generation("settings"), 5*time.Second)
+	value, ok := c.get("settings", "admin")
+	assert.True(t, ok)
+	assert.Equal(t, "list", value)
+
+	_, ok = c.get("settings", "user")
+	assert.False(t, ok, "other keys are not cached")
+	_, ok = c.get("clusters", "admin")
+	assert.False(t, ok, "other groups are not cached")
+
+	now = now.Add(6 * time.Second)
+	_, ok = c.get("settings", "admin")
+	assert.False(t, ok, "expired values are not returned")
+
+	c.add("settings", "admin", "list", c.generation("settings"), 0)
+	_, ok = c.get("settings", "admin")
+	assert.False(t, ok, "values are not cached without a ttl")
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	c := newCache()
+
+	c.add("settings", "admin", "settings", c.generation("settings"), time.Minute)
+	c.add("clusters", "admin", "clusters", c.generation("clusters"), time.Minute)
+	c.invalidate("settings")
+
+	_, ok := c.get("settings", "admin")
+	assert.False(t, ok)
+	value, ok := c.get("clusters", "admin")
+	assert.True(t, ok, "other groups are kept")
+	assert.Equal(t, "clusters", value)
+	assert.Equal(t, 1, c.size)
+
+	generation := c.generation("settings")
+	c.invalidate("settings")
+	c.add("settings", "admin", "stale", generation, time.Minute)
+	_, ok = c.get("settings", "admin")
+	assert.False(t, ok, "values computed before an invalidation are not cached")
+}
+
+func TestCacheFull(t *testing.T) {
+	c := newCache()
+	for i := 0; i < maxEntries; i++ {
+		c.add("settings", string(rune('a'+i)), i, 0, time.Minute)
+	}
+	assert.Equal(t, maxEntries, c.size)
+
+	c.add("clusters", "admin", "clusters", 0, time.Minute)
+	assert.Equal(t, 1, c.size)
+	_, ok := c.get("settings", "a")
+	assert.False(t, ok)
+	_, ok = c.get("clusters", "admin")
+	assert.True(t, ok)
+}
@@ -0,0 +1,124 @@
+package readcache
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rancher/apiserver/pkg/types"
+	"github.com/rancher/rancher/pkg/settings"
+	schema2 "github.com/rancher/steve/pkg/schema"
+	steve "github.com/rancher/steve/pkg/server"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// cachedKinds are the kinds whose lists are cached.
+var cachedKinds = []schema.GroupVersionKind{
+	{Group: "management.cattle.io", Version: "v3", Kind: "Cluster"},
+	{Group: "management.cattle.io", Version: "v3", Kind: "Project"},
+	{Group: "management.cattle.io", Version: "v3", Kind: "Setting"},
+}
+
+// Register caches the lists of the cached kinds for the steve-read-cache-ttl-seconds setting, invalidating them when an
+// object of their kind is added, changed or removed.
+func Register(ctx context.Context, server *steve.Server) {
+	c := newCache()
+	for _, gvk := range cachedKinds {
+		group := gvk.String()
+		server.SchemaFactory.AddTemplate(schema2.Template{
+			Group: gvk.Group,
+			Kind:  gvk.Kind,
+			StoreFactory: func(innerStore types.Store) types.Store {
+				return &store{
+					Store: innerStore,
+					cache: c,
+					group: group,
+				}
+			},
+		})
+	}
+
+	invalidate := func(gvk schema.GroupVersionKind, _ string, _ runtime.Object) error {
+		c.invalidate(gvk.String())
+		return nil
+	}
+	server.ClusterCache.OnAdd(ctx, invalidate)
+	server.ClusterCache.OnRemove(ctx, invalidate)
+	server.ClusterCache.OnChange(ctx, func(gvk schema.GroupVersionKind, key string, obj, _ runtime.Object) error {
+		return invalidate(gvk, key, obj)
+	})
+}
+
+type store struct {
+	types.Store
+	cache *cache
+	group string
+}
+
+func (s *store) List(apiOp *types.APIRequest, schema *types.APISchema) (types.APIObjectList, error) {
+	ttl := time.Duration(settings.SteveReadCacheTTLSeconds.GetInt()) * time.Second
+	key, ok := listKey(apiOp)
+	if ttl <= 0 || !ok {
+		return s.Store.List(apiOp, schema)
+	}
+
+	if cached, ok := s.cache.get(s.group, key); ok {
+		return copyList(cached.(types.APIObjectList)), nil
+	}
+
+	generation := s.cache.generation(s.group)
+	list, err := s.Store.List(apiOp, schema)
+	if err != nil {
+		return list, err
+	}
+	s.cache.add(s.group, key, copyList(list), generation, ttl)
+	return list, nil
+}
+
+func (s *store) Create(apiOp *types.APIRequest, schema *types.APISchema, data types.APIObject) (types.APIObject, error) {
+	defer s.cache.invalidate(s.group)
+	return s.Store.Create(apiOp, schema, data)
+}
+
+func (s *store) Update(apiOp *types.APIRequest, schema *types.APISchema, data types.APIObject, id string) (types.APIObject, error) {
+	defer s.cache.invalidate(s.group)
+	return s.Store.Update(apiOp, schema, data, id)
+}
+
+func (s *store) Delete(apiOp *types.APIRequest, schema *types.APISchema, id string) (types.APIObject, error) {
+	defer s.cache.invalidate(s.group)
+	return s.Store.Delete(apiOp, schema, id)
+}
+
+// listKey returns the key of the lists of a request, which depend on the user, as lists are filtered by what the user
+// can see, and on the namespace and query of the request. Requests that aren't authenticated aren't cached.
+func listKey(apiOp *types.APIRequest) (string, bool) {
+	userInfo, ok := apiOp.GetUserInfo()
+	if !ok || userInfo.GetName() == "" {
+		return "", false
+	}
+	groups := append([]string(nil), userInfo.GetGroups()...)
+	sort.Strings(groups)
+
+	var query string
+	if apiOp.Request != nil && apiOp.Request.URL != nil {
+		query = apiOp.Request.URL.RawQuery
+	}
+	return strings.Join([]string{userInfo.GetName(), strings.Join(groups, ","), apiOp.Namespace, query}, "\x00"), true
+}
+
+// copyList returns a copy of a list, so that formatting the objects of a list served from the cache doesn't change the
+// cached objects.
+func copyList(list types.APIObjectList) types.APIObjectList {
+	result := list
+	result.Objects = make([]types.APIObject, len(list.Objects))
+	for i, obj := range list.Objects {
+		if o, ok := obj.Object.(runtime.Object); ok {
+			obj.Object = o.DeepCopyObject()
+		}
+		result.Objects[i] = obj
+	}
+	return result
+}
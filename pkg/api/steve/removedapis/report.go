@@ -0,0 +1,138 @@
+// Package removedapis provides a HTTPHandler to report the objects and the clients of a cluster using the APIs removed
+// in a Kubernetes version before the cluster is upgraded to it. This handler should be registered at Endpoint
+package removedapis
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/auth/util"
+	mgmtv3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/removedapis"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/sirupsen/logrus"
+	authzv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	authv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+const (
+	// Endpoint The endpoint that the removed APIs report of a cluster is accessible at - used for routing. The
+	// Kubernetes version the cluster is upgraded to is set with the version query parameter.
+	Endpoint  = "/v1/removedapis/clusters/{cluster}/report"
+	logPrefix = "removed-apis-report"
+)
+
+// UserContextGetter returns the clients of a downstream cluster.
+type UserContextGetter interface {
+	UserContextNoControllers(clusterName string) (*config.UserContext, error)
+}
+
+// Handler implements http.Handler - and serves the removed APIs reports of clusters
+type Handler struct {
+	Clusters             mgmtv3.ClusterLister
+	SubjectAccessReviews authv1.SubjectAccessReviewInterface
+	UserContexts         UserContextGetter
+}
+
+// NewHandler creates a handler using the clients defined in scaledContext
+func NewHandler(scaledContext *config.ScaledContext, userContexts UserContextGetter) Handler {
+	return Handler{
+		Clusters:             scaledContext.Management.Clusters("").Controller().Lister(),
+		SubjectAccessReviews: scaledContext.K8sClient.AuthorizationV1().SubjectAccessReviews(),
+		UserContexts:         userContexts,
+	}
+}
+
+// ServeHTTP implements http.Handler - returns the report of the cluster if the user can update the cluster, as only
+// users allowed to upgrade the cluster need to scan it
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	clusterName := mux.Vars(req)["cluster"]
+
+	authorized, err := h.authorize(req, clusterName)
+	if err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		logrus.Errorf("[%s] Failed to authorize user with error: %s", logPrefix, err.Error())
+		return
+	}
+	if !authorized {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		return
+	}
+
+	cluster, err := h.Clusters.Get("", clusterName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			util.ReturnHTTPError(writer, req, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+			return
+		}
+		logrus.Errorf("[%s] Error getting cluster %s: %v", logPrefix, clusterName, err)
+		util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+	if cluster.Status.Version == nil {
+		util.ReturnHTTPError(writer, req, http.StatusConflict, "kubernetes version of the cluster is unknown")
+		return
+	}
+
+	currentVersion := cluster.Status.Version.GitVersion
+	targetVersion := req.URL.Query().Get("version")
+	if _, err := removedapis.Between(currentVersion, targetVersion); err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusBadRequest, fmt.Sprintf("version is required to be a kubernetes version: %v", err))
+		return
+	}
+
+	userContext, err := h.UserContexts.UserContextNoControllers(cluster.Name)
+	if err != nil {
+		logrus.Errorf("[%s] Error getting clients of cluster %s: %v", logPrefix, clusterName, err)
+		util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+	report, err := removedapis.Scan(req.Context(), &userContext.RESTConfig, currentVersion, targetVersion)
+	if err != nil {
+		logrus.Errorf("[%s] Error scanning cluster %s: %v", logPrefix, clusterName, err)
+		util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(report); err != nil {
+		logrus.Warnf("[%s] Failed to write report of cluster %s: %v", logPrefix, clusterName, err)
+	}
+}
+
+// authorize checks to see if the user can update the cluster. Returns a bool (if the user is authorized) and optionally
+// an error
+func (h *Handler) authorize(r *http.Request, clusterName string) (bool, error) {
+	userInfo, ok := request.UserFrom(r.Context())
+	if !ok {
+		return false, fmt.Errorf("unable to extract user info from context")
+	}
+	extra := map[string]authzv1.ExtraValue{}
+	for k, v := range userInfo.GetExtra() {
+		extra[k] = authzv1.ExtraValue(v)
+	}
+	response, err := h.SubjectAccessReviews.Create(r.Context(), &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Group:    v3.SchemeGroupVersion.Group,
+				Resource: v3.ClusterResourceName,
+				Verb:     "update",
+				Name:     clusterName,
+			},
+			User:   userInfo.GetName(),
+			Groups: userInfo.GetGroups(),
+			Extra:  extra,
+			UID:    userInfo.GetUID(),
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to create sar %s", err)
+	}
+	return response.Status.Allowed, nil
+}
@@ -0,0 +1,116 @@
+// Package reportartifacts provides a HTTPHandler to download the artifacts of reports. This handler should be registered at
+// Endpoint
+package reportartifacts
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/auth/util"
+	v1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
+	"github.com/rancher/rancher/pkg/reports"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/sirupsen/logrus"
+	authzv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	authv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+const (
+	// Endpoint The endpoint that artifacts are accessible at - used for routing
+	Endpoint  = "/v1/reports/{report}/artifacts/{artifact}"
+	logPrefix = "report-download"
+)
+
+// Handler implements http.Handler - and serves the artifacts of reports
+type Handler struct {
+	Secrets              v1.SecretInterface
+	SubjectAccessReviews authv1.SubjectAccessReviewInterface
+}
+
+// NewHandler creates a handler using the clients defined in scaledContext
+func NewHandler(scaledContext *config.ScaledContext) Handler {
+	return Handler{
+		Secrets:              scaledContext.Core.Secrets(reports.ArtifactNamespace),
+		SubjectAccessReviews: scaledContext.K8sClient.AuthorizationV1().SubjectAccessReviews(),
+	}
+}
+
+// ServeHTTP implements http.Handler - returns the artifact if the user can get its report
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	vars := mux.Vars(req)
+	reportName, artifactName := vars["report"], vars["artifact"]
+
+	authorized, err := h.authorize(req, reportName)
+	if err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		logrus.Errorf("[%s] Failed to authorize user with error: %s", logPrefix, err.Error())
+		return
+	}
+	if !authorized {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		return
+	}
+
+	secret, err := h.Secrets.Get(artifactName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			util.ReturnHTTPError(writer, req, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+			return
+		}
+		logrus.Errorf("[%s] Error getting artifact %s: %v", logPrefix, artifactName, err)
+		util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+	// the label check prevents reading secrets of the namespace that are not an artifact of the authorized report
+	if secret.Labels[reports.ReportLabel] != reportName {
+		util.ReturnHTTPError(writer, req, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	format := v3.ReportFormat(secret.Annotations[reports.FormatAnnotation])
+	contentType, ok := reports.ContentTypes[format]
+	if !ok {
+		contentType = "application/octet-stream"
+	}
+	writer.Header().Set("Content-Type", contentType)
+	writer.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", artifactName+"."+string(format)))
+	if _, err := writer.Write(secret.Data[reports.ArtifactDataKey]); err != nil {
+		logrus.Warnf("[%s] Failed to write artifact %s: %v", logPrefix, artifactName, err)
+	}
+}
+
+// authorize checks to see if the user can get the report. Returns a bool (if the user is authorized) and optionally an
+// error
+func (h *Handler) authorize(r *http.Request, reportName string) (bool, error) {
+	userInfo, ok := request.UserFrom(r.Context())
+	if !ok {
+		return false, fmt.Errorf("unable to extract user info from context")
+	}
+	extra := map[string]authzv1.ExtraValue{}
+	for k, v := range userInfo.GetExtra() {
+		extra[k] = authzv1.ExtraValue(v)
+	}
+	response, err := h.SubjectAccessReviews.Create(r.Context(), &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Group:    v3.SchemeGroupVersion.Group,
+				Resource: v3.ReportResourceName,
+				Verb:     "get",
+				Name:     reportName,
+			},
+			User:   userInfo.GetName(),
+			Groups: userInfo.GetGroups(),
+			Extra:  extra,
+			UID:    userInfo.GetUID(),
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to create sar %s", err)
+	}
+	return response.Status.Allowed, nil
+}
@@ -0,0 +1,114 @@
+// Package roletemplates provides a HTTPHandler serving the resolved rules and the inheritance tree of role templates.
+// This handler should be registered at Endpoint
+package roletemplates
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/auth/util"
+	mgmtv3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/rbac"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/sirupsen/logrus"
+	authzv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	authv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+const (
+	// Endpoint The endpoint that the resolution of a role template is accessible at - used for routing
+	Endpoint  = "/v1/roletemplateresolution/{roletemplate}"
+	logPrefix = "roletemplate-resolution"
+)
+
+// Handler implements http.Handler - and serves the resolution of role templates
+type Handler struct {
+	RoleTemplates        mgmtv3.RoleTemplateLister
+	SubjectAccessReviews authv1.SubjectAccessReviewInterface
+}
+
+// NewHandler creates a handler using the clients defined in scaledContext
+func NewHandler(scaledContext *config.ScaledContext) Handler {
+	return Handler{
+		RoleTemplates:        scaledContext.Management.RoleTemplates("").Controller().Lister(),
+		SubjectAccessReviews: scaledContext.K8sClient.AuthorizationV1().SubjectAccessReviews(),
+	}
+}
+
+// ServeHTTP implements http.Handler - returns the resolved rules and the inheritance tree of the role template if the
+// user can get the role template
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	name := mux.Vars(req)["roletemplate"]
+
+	authorized, err := h.authorize(req, name)
+	if err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		logrus.Errorf("[%s] Failed to authorize user with error: %s", logPrefix, err.Error())
+		return
+	}
+	if !authorized {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		return
+	}
+
+	if _, err := h.RoleTemplates.Get("", name); apierrors.IsNotFound(err) {
+		util.ReturnHTTPError(writer, req, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+	roleTemplates, err := h.RoleTemplates.List("", labels.Everything())
+	if err != nil {
+		logrus.Errorf("[%s] Error listing role templates: %v", logPrefix, err)
+		util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	resolved, err := rbac.ResolveRoleTemplate(name, roleTemplates)
+	if err != nil {
+		logrus.Errorf("[%s] Error resolving role template %s: %v", logPrefix, name, err)
+		util.ReturnHTTPError(writer, req, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(resolved); err != nil {
+		logrus.Warnf("[%s] Failed to write resolution of role template %s: %v", logPrefix, name, err)
+	}
+}
+
+// authorize checks to see if the user can get the role template. Returns a bool (if the user is authorized) and
+// optionally an error
+func (h *Handler) authorize(r *http.Request, name string) (bool, error) {
+	userInfo, ok := request.UserFrom(r.Context())
+	if !ok {
+		return false, fmt.Errorf("unable to extract user info from context")
+	}
+	extra := map[string]authzv1.ExtraValue{}
+	for k, v := range userInfo.GetExtra() {
+		extra[k] = authzv1.ExtraValue(v)
+	}
+	response, err := h.SubjectAccessReviews.Create(r.Context(), &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Group:    v3.SchemeGroupVersion.Group,
+				Resource: v3.RoleTemplateResourceName,
+				Verb:     "get",
+				Name:     name,
+			},
+			User:   userInfo.GetName(),
+			Groups: userInfo.GetGroups(),
+			Extra:  extra,
+			UID:    userInfo.GetUID(),
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to create sar %s", err)
+	}
+	return response.Status.Allowed, nil
+}
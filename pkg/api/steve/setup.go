@@ -8,6 +8,8 @@
 	"github.com/rancher/rancher/pkg/api/steve/disallow"
 	"github.com/rancher/rancher/pkg/api/steve/machine"
 	"github.com/rancher/rancher/pkg/api/steve/navlinks"
+	"github.com/rancher/rancher/pkg/api/steve/provisioningcluster"
+	"github.com/rancher/rancher/pkg/api/steve/readcache"
 	"github.com/rancher/rancher/pkg/api/steve/settings"
 	"github.com/rancher/rancher/pkg/api/steve/userpreferences"
 	"github.com/rancher/rancher/pkg/wrangler"
@@ -21,6 +23,8 @@ func Setup(ctx context.Context, server *steve.Server, config *wrangler.Context)
 	}
 	machine.Register(server, config)
 	navlinks.Register(ctx, server)
+	provisioningcluster.Register(server, config)
+	readcache.Register(ctx, server)
 	settings.Register(server)
 	disallow.Register(server)
 	return catalog.Register(ctx,
@@ -0,0 +1,103 @@
+// Package streamsessions provides a HTTPHandler allowing admins to list and terminate the exec, attach and port-forward
+// sessions proxied to clusters by this rancher replica. This handler should be registered at Endpoint and
+// SessionEndpoint
+package streamsessions
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rancher/rancher/pkg/auth/util"
+	"github.com/rancher/rancher/pkg/clusterrouter/proxy"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/sirupsen/logrus"
+	authzv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	authv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+const (
+	// Endpoint The endpoint that the sessions are listed at - used for routing
+	Endpoint = "/v1/streamsessions"
+	// SessionEndpoint The endpoint that sessions are terminated at - used for routing
+	SessionEndpoint = "/v1/streamsessions/{id}"
+	logPrefix       = "stream-sessions"
+)
+
+// Handler implements http.Handler - and lists and terminates the sessions of the proxy
+type Handler struct {
+	SubjectAccessReviews authv1.SubjectAccessReviewInterface
+	Sessions             *proxy.SessionTracker
+}
+
+// NewHandler creates a handler using the clients defined in scaledContext
+func NewHandler(scaledContext *config.ScaledContext) Handler {
+	return Handler{
+		SubjectAccessReviews: scaledContext.K8sClient.AuthorizationV1().SubjectAccessReviews(),
+		Sessions:             proxy.Sessions,
+	}
+}
+
+// ServeHTTP implements http.Handler - lists the sessions, optionally of the cluster in the cluster query parameter, on
+// GET and terminates the session in the path on DELETE, if the user is an admin
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	authorized, err := h.authorize(req)
+	if err != nil {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		logrus.Errorf("[%s] Failed to authorize user with error: %s", logPrefix, err.Error())
+		return
+	}
+	if !authorized {
+		util.ReturnHTTPError(writer, req, http.StatusForbidden, http.StatusText(http.StatusForbidden))
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		writer.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(writer).Encode(h.Sessions.List(req.URL.Query().Get("cluster"))); err != nil {
+			logrus.Warnf("[%s] Failed to write sessions: %v", logPrefix, err)
+		}
+	case http.MethodDelete:
+		if !h.Sessions.Terminate(mux.Vars(req)["id"]) {
+			util.ReturnHTTPError(writer, req, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+			return
+		}
+		writer.WriteHeader(http.StatusNoContent)
+	default:
+		util.ReturnHTTPError(writer, req, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+	}
+}
+
+// authorize checks to see if the user is an admin, that is can do anything to any resource. Returns a bool (if the
+// user is authorized) and optionally an error
+func (h *Handler) authorize(r *http.Request) (bool, error) {
+	userInfo, ok := request.UserFrom(r.Context())
+	if !ok {
+		return false, fmt.Errorf("unable to extract user info from context")
+	}
+	extra := map[string]authzv1.ExtraValue{}
+	for k, v := range userInfo.GetExtra() {
+		extra[k] = authzv1.ExtraValue(v)
+	}
+	response, err := h.SubjectAccessReviews.Create(r.Context(), &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Group:    "*",
+				Resource: "*",
+				Verb:     "*",
+			},
+			User:   userInfo.GetName(),
+			Groups: userInfo.GetGroups(),
+			Extra:  extra,
+			UID:    userInfo.GetUID(),
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to create sar %s", err)
+	}
+	return response.Status.Allowed, nil
+}
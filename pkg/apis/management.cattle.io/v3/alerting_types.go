@@ -360,12 +360,24 @@ type NotifierSpec struct {
 	WechatConfig    *WechatConfig    `json:"wechatConfig,omitempty"`
 	DingtalkConfig  *DingtalkConfig  `json:"dingtalkConfig,omitempty"`
 	MSTeamsConfig   *MSTeamsConfig   `json:"msteamsConfig,omitempty"`
+	// LifecycleEvents routes cluster lifecycle events to the notifier. No lifecycle event is sent when it is empty.
+	LifecycleEvents []LifecycleEventRoute `json:"lifecycleEvents,omitempty"`
 }
 
 func (n *NotifierSpec) ObjClusterName() string {
 	return n.ClusterName
 }
 
+// LifecycleEventRoute sends the lifecycle events of the matching types to a recipient of the notifier.
+type LifecycleEventRoute struct {
+	// EventTypes the route matches, all event types are matched when it is empty.
+	EventTypes []string `json:"eventTypes,omitempty" norman:"type=array[enum],options=ClusterProvisioned|ClusterProvisioningFailed|ClusterUpgraded|CertificatesRotated|EtcdSnapshotFailed|NodeUnreachable"`
+	// Recipient overrides the default recipient of the notifier.
+	Recipient string `json:"recipient,omitempty"`
+	// Template is a go template of the message, the default message of the event type is sent when it is empty.
+	Template string `json:"template,omitempty"`
+}
+
 type Notification struct {
 	Message         string           `json:"message,omitempty"`
 	SMTPConfig      *SMTPConfig      `json:"smtpConfig,omitempty"`
@@ -32,6 +32,11 @@ type Token struct {
 	Current         bool              `json:"current"`
 	ClusterName     string            `json:"clusterName,omitempty" norman:"noupdate,type=reference[cluster]"`
 	Enabled         *bool             `json:"enabled,omitempty" norman:"default=true"`
+	// LastUsedAt is the time, in RFC 3339, the token last authenticated a request, recorded with a granularity of a
+	// minute.
+	LastUsedAt string `json:"lastUsedAt,omitempty" norman:"nocreate,noupdate"`
+	// LastUsedFrom is the source IP of the last request authenticated by the token.
+	LastUsedFrom string `json:"lastUsedFrom,omitempty" norman:"nocreate,noupdate"`
 }
 
 func (t *Token) ObjClusterName() string {
@@ -68,6 +68,28 @@ type ProjectSpec struct {
 	NamespaceDefaultResourceQuota *NamespaceResourceQuota `json:"namespaceDefaultResourceQuota,omitempty"`
 	ContainerDefaultResourceLimit *ContainerResourceLimit `json:"containerDefaultResourceLimit,omitempty"`
 	EnableProjectMonitoring       bool                    `json:"enableProjectMonitoring" norman:"default=false"`
+	NamespacePolicy               *NamespacePolicy        `json:"namespacePolicy,omitempty"`
+}
+
+// NamespacePolicy is the policy applied to the namespaces of a project in the downstream cluster.
+type NamespacePolicy struct {
+	// Labels and Annotations are set on the namespaces of the project that don't already have them.
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// NamePattern is a regular expression the whole names of the namespaces of the project must match. Namespaces
+	// not matching it can't be created in or moved to the project through the API, namespaces created otherwise are
+	// reported as not compliant.
+	NamePattern string `json:"namePattern,omitempty"`
+	// ResourceQuota is a resource quota created in every namespace of the project, in addition to the quota of the
+	// namespace. It can't be changed for individual namespaces.
+	ResourceQuota *ResourceQuotaLimit `json:"resourceQuota,omitempty"`
+	// ContainerResourceLimit are the default container resource requests and limits set by a limit range created in
+	// every namespace of the project. It can't be changed for individual namespaces.
+	ContainerResourceLimit *ContainerResourceLimit `json:"containerResourceLimit,omitempty"`
+	// DeleteOrphanedNamespaces deletes the namespaces of the project once the project is deleted, including the
+	// namespaces that weren't created by rancher, after OrphanedNamespaceGracePeriodSeconds, defaulting to an hour.
+	DeleteOrphanedNamespaces            bool  `json:"deleteOrphanedNamespaces,omitempty"`
+	OrphanedNamespaceGracePeriodSeconds int64 `json:"orphanedNamespaceGracePeriodSeconds,omitempty"`
 }
 
 func (p *ProjectSpec) ObjClusterName() string {
@@ -122,6 +144,17 @@ type RoleTemplate struct {
 	Context               string              `json:"context" norman:"type=string,options=project|cluster"`
 	RoleTemplateNames     []string            `json:"roleTemplateNames,omitempty" norman:"type=array[reference[roleTemplate]]"`
 	Administrative        bool                `json:"administrative,omitempty"`
+	// AggregationRule composes the rules of the role template from the role templates of the same context selected
+	// by their labels, like the aggregation of cluster roles. The rules of an aggregated role template are managed by
+	// the controller and any rules set on it are replaced.
+	AggregationRule *RoleTemplateAggregationRule `json:"aggregationRule,omitempty"`
+}
+
+type RoleTemplateAggregationRule struct {
+	// RoleTemplateSelectors select the role templates whose rules, including the rules they inherit, are aggregated.
+	// A role template is selected when any of the selectors match its labels. Aggregated role templates are never
+	// selected.
+	RoleTemplateSelectors []metav1.LabelSelector `json:"roleTemplateSelectors,omitempty"`
 }
 
 // +genclient
@@ -0,0 +1,66 @@
+package v3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterArchiveAnnotation on a cluster overrides the cluster-archive-enabled setting for the cluster, true to archive
+// it when it's removed and false not to.
+const ClusterArchiveAnnotation = "management.cattle.io/archive-on-removal"
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterArchive is the record of a removed cluster, kept after its infrastructure and its objects are removed to
+// satisfy retention requirements. The record is also kept in an immutable configmap, from which changes to the spec
+// of an archive are reverted and deleted archives are restored until they're past the cluster archive retention.
+type ClusterArchive struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterArchiveSpec `json:"spec"`
+}
+
+type ClusterArchiveSpec struct {
+	// ClusterName and ClusterUID identify the removed cluster, in the audit log among others.
+	ClusterName       string `json:"clusterName"`
+	ClusterUID        string `json:"clusterUID"`
+	DisplayName       string `json:"displayName,omitempty"`
+	Description       string `json:"description,omitempty"`
+	Driver            string `json:"driver,omitempty"`
+	Provider          string `json:"provider,omitempty"`
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	NodeCount         int    `json:"nodeCount,omitempty"`
+	// CreatorID is the user who created the cluster.
+	CreatorID string `json:"creatorId,omitempty"`
+	// CreatedAt and RemovedAt are the RFC3339 times the cluster was created and removed at.
+	CreatedAt string            `json:"createdAt"`
+	RemovedAt string            `json:"removedAt"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	// ClusterSpec is the JSON of the spec of the cluster when it was removed.
+	ClusterSpec string `json:"clusterSpec,omitempty"`
+	// Conditions are the conditions of the cluster when it was removed, the history of its provisioning.
+	Conditions []ClusterCondition `json:"conditions,omitempty"`
+	// ProvisioningLog is the end of the provisioning log of the cluster.
+	ProvisioningLog string `json:"provisioningLog,omitempty"`
+	// Members are the role template bindings of the cluster when it was removed.
+	Members  []ClusterArchiveMember  `json:"members,omitempty"`
+	Projects []ClusterArchiveProject `json:"projects,omitempty"`
+}
+
+type ClusterArchiveMember struct {
+	// Name is the name of the cluster role template binding.
+	Name               string `json:"name"`
+	RoleTemplateName   string `json:"roleTemplateName"`
+	UserName           string `json:"userName,omitempty"`
+	UserPrincipalName  string `json:"userPrincipalName,omitempty"`
+	GroupName          string `json:"groupName,omitempty"`
+	GroupPrincipalName string `json:"groupPrincipalName,omitempty"`
+}
+
+type ClusterArchiveProject struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName,omitempty"`
+	CreatorID   string `json:"creatorId,omitempty"`
+}
@@ -0,0 +1,49 @@
+package v3
+
+import (
+	"github.com/rancher/wrangler/pkg/genericcondition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterBundle instantiates a cluster from a cluster bundle, a signed OCI artifact packaging the spec of a
+// provisioning cluster with its machine configs, chart values and policies, so that versioned cluster blueprints can
+// be shared across Rancher installations. The cluster, machine configs and policy bundles are created in the fleet
+// workspace the cluster bundle is created in, and are owned by the cluster bundle: they're updated when another
+// version of the bundle is referenced, and deleted with the cluster bundle.
+type ClusterBundle struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterBundleSpec   `json:"spec"`
+	Status ClusterBundleStatus `json:"status,omitempty"`
+}
+
+type ClusterBundleSpec struct {
+	// Reference is the artifact of the bundle, as <registry>/<repository>:<tag> or <registry>/<repository>@<digest>.
+	// Tags are resolved when the reference changes, reference another tag or digest to update the cluster.
+	Reference string `json:"reference"`
+	// ClusterName is the name of the cluster instantiated from the bundle, the name of the cluster bundle if empty.
+	ClusterName string `json:"clusterName,omitempty"`
+	// RegistryCredentialSecretName is the name of a kubernetes.io/basic-auth or kubernetes.io/dockerconfigjson secret
+	// in the namespace of the cluster bundle, with the credentials of the registry.
+	RegistryCredentialSecretName string `json:"registryCredentialSecretName,omitempty"`
+	// CloudCredentialSecretName is the cloud credential of the cluster, overriding the one of the bundle, which is
+	// usually specific to the installation the bundle was created on.
+	CloudCredentialSecretName string `json:"cloudCredentialSecretName,omitempty"`
+}
+
+type ClusterBundleStatus struct {
+	Conditions         []genericcondition.GenericCondition `json:"conditions,omitempty"`
+	ObservedGeneration int64                               `json:"observedGeneration,omitempty"`
+	// Reference is the reference the digest was resolved from.
+	Reference string `json:"reference,omitempty"`
+	// Digest is the digest of the manifest of the artifact the cluster is instantiated from.
+	Digest string `json:"digest,omitempty"`
+	// Version is the version annotation of the artifact.
+	Version string `json:"version,omitempty"`
+	// ClusterName is the name of the provisioning cluster instantiated from the bundle.
+	ClusterName string `json:"clusterName,omitempty"`
+}
@@ -0,0 +1,69 @@
+package v3
+
+import (
+	"github.com/rancher/wrangler/pkg/genericcondition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterGroup is a set of clusters, selected by labels or listed by name, and the clusters of its child cluster
+// groups. Cluster groups can be bound to role templates to grant access to all their clusters, be the target of bulk
+// operations, and set defaults for their clusters.
+type ClusterGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterGroupSpecification `json:"spec"`
+	Status ClusterGroupStatus        `json:"status,omitempty"`
+}
+
+// ClusterGroupSpecification is the spec of a cluster group, ClusterGroupSpec being the one of cluster alert groups.
+type ClusterGroupSpecification struct {
+	DisplayName string `json:"displayName,omitempty"`
+	Description string `json:"description,omitempty"`
+	// ClusterSelector selects the clusters of the group by labels, no cluster being selected if it's not set.
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+	// ClusterNames are clusters of the group in addition to the selected ones.
+	ClusterNames []string `json:"clusterNames,omitempty"`
+	// ClusterGroupNames are child cluster groups, whose clusters are all in the group.
+	ClusterGroupNames []string `json:"clusterGroupNames,omitempty"`
+	// DefaultPodSecurityAdmissionConfigurationTemplateName is set on the clusters of the group that don't set a pod
+	// security admission configuration template. A cluster in several groups gets the one of the smallest group.
+	DefaultPodSecurityAdmissionConfigurationTemplateName string `json:"defaultPodSecurityAdmissionConfigurationTemplateName,omitempty"`
+}
+
+type ClusterGroupStatus struct {
+	Conditions []genericcondition.GenericCondition `json:"conditions,omitempty"`
+	// Clusters are the names of the clusters of the group, including those of its child groups.
+	Clusters     []string `json:"clusters,omitempty"`
+	ClusterCount int      `json:"clusterCount"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterGroupRoleTemplateBinding binds a cluster role template to a user or group on all the clusters of a cluster
+// group, with a cluster role template binding on each of them kept in sync with the clusters of the group.
+type ClusterGroupRoleTemplateBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	UserName           string `json:"userName,omitempty"`
+	UserPrincipalName  string `json:"userPrincipalName,omitempty"`
+	GroupName          string `json:"groupName,omitempty"`
+	GroupPrincipalName string `json:"groupPrincipalName,omitempty"`
+	ClusterGroupName   string `json:"clusterGroupName"`
+	RoleTemplateName   string `json:"roleTemplateName"`
+
+	Status ClusterGroupRoleTemplateBindingStatus `json:"status,omitempty"`
+}
+
+type ClusterGroupRoleTemplateBindingStatus struct {
+	Conditions []genericcondition.GenericCondition `json:"conditions,omitempty"`
+	// Clusters are the names of the clusters the role template is bound on.
+	Clusters []string `json:"clusters,omitempty"`
+}
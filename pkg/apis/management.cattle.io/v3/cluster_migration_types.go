@@ -0,0 +1,112 @@
+package v3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The steps of a cluster migration, in the order they're run.
+const (
+	// ClusterMigrationStepMapConfig maps the config of the RKE1 cluster to the config of the RKE2 cluster, reporting
+	// the options that can't be migrated as warnings.
+	ClusterMigrationStepMapConfig = "MapConfig"
+	// ClusterMigrationStepCreateCluster creates the RKE2 cluster.
+	ClusterMigrationStepCreateCluster = "CreateCluster"
+	// ClusterMigrationStepRegisterNodes reports the commands registering the nodes of the RKE1 cluster with the RKE2
+	// cluster, and waits for them to be registered. It's skipped unless the nodes are reused.
+	ClusterMigrationStepRegisterNodes = "RegisterNodes"
+	// ClusterMigrationStepWaitForCluster waits for the RKE2 cluster to be ready.
+	ClusterMigrationStepWaitForCluster = "WaitForCluster"
+	// ClusterMigrationStepMigrateProjects creates the projects of the RKE1 cluster in the RKE2 cluster, along with
+	// their namespaces.
+	ClusterMigrationStepMigrateProjects = "MigrateProjects"
+	// ClusterMigrationStepMigrateRBAC copies the cluster and project role template bindings of the RKE1 cluster to the
+	// RKE2 cluster.
+	ClusterMigrationStepMigrateRBAC = "MigrateRBAC"
+	// ClusterMigrationStepMigrateApps deploys the apps installed from cluster repositories on the RKE1 cluster to the
+	// RKE2 cluster, with managed charts.
+	ClusterMigrationStepMigrateApps = "MigrateApps"
+)
+
+// The states of a cluster migration and of its steps.
+const (
+	ClusterMigrationPending    = "Pending"
+	ClusterMigrationInProgress = "InProgress"
+	ClusterMigrationComplete   = "Complete"
+	ClusterMigrationSkipped    = "Skipped"
+	ClusterMigrationFailed     = "Failed"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterMigration migrates an RKE1 cluster to an RKE2 cluster it creates in its namespace, a fleet workspace. The
+// config of the RKE1 cluster is mapped to the equivalent RKE2 config, the nodes of the RKE1 cluster are optionally
+// reused, and its projects, role template bindings and apps are migrated once the RKE2 cluster is ready. The RKE1
+// cluster is left untouched, and the RKE2 cluster is kept when the migration is deleted.
+type ClusterMigration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterMigrationSpec   `json:"spec"`
+	Status ClusterMigrationStatus `json:"status,omitempty"`
+}
+
+type ClusterMigrationSpec struct {
+	// SourceClusterName is the name of the management cluster of the RKE1 cluster.
+	SourceClusterName string `json:"sourceClusterName"`
+	// ClusterName is the name of the provisioning cluster of the RKE2 cluster, created in the namespace of the
+	// migration.
+	ClusterName string `json:"clusterName"`
+	// KubernetesVersion is the RKE2 version of the RKE2 cluster.
+	KubernetesVersion string `json:"kubernetesVersion"`
+	// ReuseNodes registers the custom nodes of the RKE1 cluster with the RKE2 cluster, with their roles. Each node must
+	// be removed from the RKE1 cluster and cleaned up before it's registered. Otherwise, machine pools or custom nodes
+	// are added to the RKE2 cluster once it's created.
+	ReuseNodes bool `json:"reuseNodes,omitempty"`
+
+	SkipProjects bool `json:"skipProjects,omitempty"`
+	// SkipRBAC skips the migration of the cluster role template bindings, and of the project role template bindings
+	// of the migrated projects.
+	SkipRBAC bool `json:"skipRBAC,omitempty"`
+	SkipApps bool `json:"skipApps,omitempty"`
+}
+
+type ClusterMigrationStatus struct {
+	// State is Pending, InProgress, Complete or Failed.
+	State   string `json:"state,omitempty"`
+	Message string `json:"message,omitempty"`
+	// Steps is the progress of each step of the migration.
+	Steps []ClusterMigrationStep `json:"steps,omitempty"`
+	// ClusterName is the name of the management cluster of the RKE2 cluster.
+	ClusterName string `json:"clusterName,omitempty"`
+	// Warnings are the options of the RKE1 cluster that weren't migrated, and must be migrated by hand.
+	Warnings []string                  `json:"warnings,omitempty"`
+	Nodes    []ClusterMigrationNode    `json:"nodes,omitempty"`
+	Projects []ClusterMigrationProject `json:"projects,omitempty"`
+	// Apps are the apps of the RKE1 cluster that are deployed to the RKE2 cluster, as namespace/name.
+	Apps []string `json:"apps,omitempty"`
+}
+
+type ClusterMigrationStep struct {
+	Name string `json:"name"`
+	// State is Pending, InProgress, Complete, Skipped or Failed.
+	State          string       `json:"state"`
+	Message        string       `json:"message,omitempty"`
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// ClusterMigrationNode is a node of the RKE1 cluster reused by the RKE2 cluster.
+type ClusterMigrationNode struct {
+	// Name is the hostname of the node.
+	Name  string   `json:"name"`
+	Roles []string `json:"roles,omitempty"`
+	// Command is the command registering the node with the RKE2 cluster, with its roles.
+	Command    string `json:"command,omitempty"`
+	Registered bool   `json:"registered,omitempty"`
+}
+
+// ClusterMigrationProject is a project of the RKE1 cluster, and the project of the RKE2 cluster it was migrated to.
+type ClusterMigrationProject struct {
+	SourceName string `json:"sourceName"`
+	TargetName string `json:"targetName"`
+}
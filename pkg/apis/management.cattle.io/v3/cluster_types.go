@@ -53,6 +53,16 @@ func init() {
 	ClusterConditionNoDiskPressure condition.Cond = "NoDiskPressure"
 	// ClusterConditionNoMemoryPressure true when all cluster nodes have sufficient memory
 	ClusterConditionNoMemoryPressure condition.Cond = "NoMemoryPressure"
+	// ClusterConditionNodeLocalDNSReady true when the NodeLocal DNSCache of the cluster runs on all its nodes
+	ClusterConditionNodeLocalDNSReady condition.Cond = "NodeLocalDNSReady"
+	// ClusterConditionWorkloadDefaultsApplied true when the workload defaults of the cluster are applied to all its namespaces
+	ClusterConditionWorkloadDefaultsApplied condition.Cond = "WorkloadDefaultsApplied"
+	// ClusterConditionWebhookOverridesApplied true when the webhook overrides of the cluster are applied to its rancher-webhook
+	ClusterConditionWebhookOverridesApplied condition.Cond = "WebhookOverridesApplied"
+	// ClusterConditionNodeOSSupported true when no cluster node runs an end-of-life OS release of the advisory feed
+	ClusterConditionNodeOSSupported condition.Cond = "NodeOSSupported"
+	// ClusterConditionNodeKernelsPatched true when no cluster node runs a kernel affected by the CVEs of the advisory feed
+	ClusterConditionNodeKernelsPatched condition.Cond = "NodeKernelsPatched"
 	// ClusterConditionDefaultProjectCreated true when default project has been created
 	ClusterConditionDefaultProjectCreated condition.Cond = "DefaultProjectCreated"
 	// ClusterConditionSystemProjectCreated true when system project has been created
@@ -127,6 +137,8 @@ type ClusterSpecBase struct {
 	ClusterSecrets                                       ClusterSecrets                          `json:"clusterSecrets" norman:"nocreate,noupdate"`
 	ClusterAgentDeploymentCustomization                  *AgentDeploymentCustomization           `json:"clusterAgentDeploymentCustomization,omitempty"`
 	FleetAgentDeploymentCustomization                    *AgentDeploymentCustomization           `json:"fleetAgentDeploymentCustomization,omitempty"`
+	WorkloadDefaults                                     *WorkloadDefaults                       `json:"workloadDefaults,omitempty"`
+	WebhookOverrides                                     *WebhookOverrides                       `json:"webhookOverrides,omitempty"`
 }
 
 type AgentDeploymentCustomization struct {
@@ -209,6 +221,53 @@ type ClusterStatus struct {
 	AADClientCertSecret                  string                    `json:"aadClientCertSecret,omitempty" norman:"nocreate,noupdate"`   // Deprecated: use ClusterSpec.ClusterSecrets.AADClientCertSecret instead
 
 	AppliedClusterAgentDeploymentCustomization *AgentDeploymentCustomization `json:"appliedClusterAgentDeploymentCustomization,omitempty"`
+
+	// ControlPlaneUsage is the resource usage of the control plane of the cluster, sampled periodically.
+	ControlPlaneUsage *ControlPlaneUsage `json:"controlPlaneUsage,omitempty" norman:"nocreate,noupdate"`
+
+	// PolicyBundles is the state of the policies of the policy bundles targeting the cluster.
+	PolicyBundles []ClusterPolicyBundleStatus `json:"policyBundles,omitempty" norman:"nocreate,noupdate"`
+
+	// CircuitBreaker is the state of the circuit breaker of the cluster while it's open, nil while the cluster is
+	// reachable.
+	CircuitBreaker *ClusterCircuitBreaker `json:"circuitBreaker,omitempty" norman:"nocreate,noupdate"`
+}
+
+// ClusterCircuitBreakerOpen is the state of the circuit breaker of a cluster which failed to be reached too many times
+// in a row, whose controllers and health checks are stopped until the cluster is probed again.
+const ClusterCircuitBreakerOpen = "Open"
+
+type ClusterCircuitBreaker struct {
+	State string `json:"state"`
+	// ConsecutiveFailures is the number of times in a row the cluster failed to be reached.
+	ConsecutiveFailures int    `json:"consecutiveFailures"`
+	LastError           string `json:"lastError,omitempty"`
+	// OpenedAt is the time the circuit breaker opened at, and NextProbeTime the time the cluster is probed again at,
+	// in RFC 3339 format.
+	OpenedAt      string `json:"openedAt,omitempty"`
+	NextProbeTime string `json:"nextProbeTime,omitempty"`
+}
+
+type ControlPlaneUsage struct {
+	// CollectedAt is the time the usage was sampled at, in RFC 3339 format.
+	CollectedAt string `json:"collectedAt,omitempty"`
+	// Nodes is the usage of the control plane components of each control plane node.
+	Nodes []ControlPlaneNodeUsage `json:"nodes,omitempty"`
+	// EtcdDBSizeBytes is the size of the etcd database.
+	EtcdDBSizeBytes int64 `json:"etcdDbSizeBytes,omitempty"`
+	// RequestCount is the number of requests served by the kube-apiserver the sample was collected from since it
+	// started, and RequestsPerSecond the rate of requests it served since the previous sample.
+	RequestCount      int64 `json:"requestCount,omitempty"`
+	RequestsPerSecond int64 `json:"requestsPerSecond,omitempty"`
+}
+
+type ControlPlaneNodeUsage struct {
+	NodeName string `json:"nodeName"`
+	// Allocatable is the resources of the node available to pods.
+	Allocatable v1.ResourceList `json:"allocatable,omitempty"`
+	// APIServer and Etcd are the CPU and memory used by the kube-apiserver and etcd of the node.
+	APIServer v1.ResourceList `json:"apiServer,omitempty"`
+	Etcd      v1.ResourceList `json:"etcd,omitempty"`
 }
 
 type ClusterComponentStatus struct {
@@ -277,6 +336,17 @@ func (c *ClusterRegistrationToken) ObjClusterName() string {
 
 type ClusterRegistrationTokenSpec struct {
 	ClusterName string `json:"clusterName" norman:"required,type=reference[cluster]"`
+	// Proxy is the proxy the nodes registered with the commands of the token reach rancher through.
+	Proxy *ClusterRegistrationProxy `json:"proxy,omitempty"`
+	// AdditionalCA is the PEM bundle of CA certificates added to the trust store of the nodes registered with the
+	// commands of the token before they reach rancher, such as the CA of a TLS intercepting proxy.
+	AdditionalCA string `json:"additionalCA,omitempty"`
+}
+
+type ClusterRegistrationProxy struct {
+	HTTPProxy  string `json:"httpProxy,omitempty"`
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+	NoProxy    string `json:"noProxy,omitempty"`
 }
 
 func (c *ClusterRegistrationTokenSpec) ObjClusterName() string {
@@ -292,6 +362,26 @@ type ClusterRegistrationTokenStatus struct {
 	InsecureNodeCommand        string `json:"insecureNodeCommand"`
 	ManifestURL                string `json:"manifestUrl"`
 	Token                      string `json:"token"`
+	// ValidateCommand and InsecureValidateCommand run the checks of a node being able to be registered, without
+	// registering it, and report their results back in the validations.
+	ValidateCommand         string `json:"validateCommand"`
+	InsecureValidateCommand string `json:"insecureValidateCommand"`
+	// Validations are the latest results of the validate commands, one per node.
+	Validations []ClusterRegistrationValidation `json:"validations,omitempty"`
+}
+
+type ClusterRegistrationValidation struct {
+	NodeName string `json:"nodeName"`
+	// Time is the RFC3339 time the results were reported at.
+	Time   string                     `json:"time"`
+	Passed bool                       `json:"passed"`
+	Checks []ClusterRegistrationCheck `json:"checks,omitempty"`
+}
+
+type ClusterRegistrationCheck struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message,omitempty"`
 }
 
 type GenerateKubeConfigOutput struct {
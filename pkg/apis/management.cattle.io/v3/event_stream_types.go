@@ -0,0 +1,84 @@
+package v3
+
+import (
+	"github.com/rancher/wrangler/pkg/genericcondition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	EventSubscriptionProtocolHTTP = "http"
+	EventSubscriptionProtocolNATS = "nats"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EventSubscription delivers the management events, such as clusters being created or role bindings being deleted, to
+// an external system as CloudEvents, posted to an HTTP endpoint or published to NATS. Events are delivered in order
+// and retried until they are delivered, and can be replayed from a point in time while they are retained.
+type EventSubscription struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EventSubscriptionSpec   `json:"spec"`
+	Status EventSubscriptionStatus `json:"status,omitempty"`
+}
+
+type EventSubscriptionSpec struct {
+	DisplayName string `json:"displayName,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Protocol is http to post each event to URL in the structured content mode, or nats to publish each event to the
+	// NATS server at URL.
+	Protocol string `json:"protocol"`
+	// URL is the http or https URL events are posted to, or the nats or tls URL of the NATS server.
+	URL string `json:"url"`
+	// Subject is the prefix of the NATS subjects events are published to, followed by their type, for example
+	// rancher.cluster.created. Defaults to rancher.
+	Subject string `json:"subject,omitempty"`
+	// SecretName is the name of a secret in the cattle-global-data namespace holding the credentials of the endpoint:
+	// the Authorization header of HTTP requests in its authorization key, the token in its token key or the user in
+	// its username and password keys for NATS.
+	SecretName string `json:"secretName,omitempty"`
+	// EventTypes are the types of events delivered, for example cluster.created, all types if empty.
+	EventTypes []string `json:"eventTypes,omitempty"`
+	// ClusterNames are the clusters whose events are delivered. Events of all clusters and the events not related to a
+	// cluster, such as users being created, are delivered if empty.
+	ClusterNames []string `json:"clusterNames,omitempty"`
+	// Paused stops delivering events, which are delivered once unpaused if they are still retained.
+	Paused bool `json:"paused,omitempty"`
+}
+
+type EventSubscriptionStatus struct {
+	Conditions []genericcondition.GenericCondition `json:"conditions,omitempty"`
+	// LastDeliveredTime and LastDeliveredEvent are the time and name of the last event delivered, events after it
+	// being delivered next.
+	LastDeliveredTime  string `json:"lastDeliveredTime,omitempty"`
+	LastDeliveredEvent string `json:"lastDeliveredEvent,omitempty"`
+	DeliveredCount     int64  `json:"deliveredCount"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ManagementEvent is a change of the management resources, recorded while event subscriptions exist and retained for
+// the event stream retention to be delivered and replayed to event subscriptions.
+type ManagementEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ManagementEventSpec `json:"spec"`
+}
+
+type ManagementEventSpec struct {
+	// Type is the type of the event, for example cluster.created.
+	Type string `json:"type"`
+	// Time is the RFC3339 time with nanoseconds the event was recorded at.
+	Time string `json:"time"`
+	// Subject is the resource the event is about, for example clusters/c-m-abcde.
+	Subject string `json:"subject"`
+	// ClusterName is the cluster the resource belongs to, empty for resources not related to a cluster.
+	ClusterName string            `json:"clusterName,omitempty"`
+	Data        map[string]string `json:"data,omitempty"`
+}
@@ -0,0 +1,33 @@
+package v3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// LandingPreference holds the defaults a user lands on in the UI and CLI: the default cluster, the favorite projects
+// and the pinned namespaces. It's either the preference of a user, named after the user and managed by the user
+// through the landing preferences API, or the default preference of the members of a group, managed by admins.
+type LandingPreference struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec LandingPreferenceSpec `json:"spec"`
+}
+
+type LandingPreferenceSpec struct {
+	// UserName is the user the preference is of. Either UserName or GroupPrincipalName is set.
+	UserName string `json:"userName,omitempty" norman:"type=reference[user]"`
+	// GroupPrincipalName is the group the preference is the default of, for its members who don't set the fields of
+	// the preference themselves.
+	GroupPrincipalName string `json:"groupPrincipalName,omitempty" norman:"type=reference[principal]"`
+	// DefaultClusterName is the cluster to land on.
+	DefaultClusterName string `json:"defaultClusterName,omitempty"`
+	// FavoriteProjects are the favorite projects, as clusterName:projectName.
+	FavoriteProjects []string `json:"favoriteProjects,omitempty"`
+	// PinnedNamespaces are the pinned namespaces, as clusterName/namespace.
+	PinnedNamespaces []string `json:"pinnedNamespaces,omitempty"`
+}
@@ -374,3 +374,35 @@ type S3CredentialConfig struct {
 	DefaultBucket        string
 	DefaultFolder        string
 }
+
+const (
+	CloudCredentialActionDependents = "dependents"
+	CloudCredentialActionRotate     = "rotate"
+)
+
+// CloudCredentialDependent is a cluster, machine pool or node template using a cloud credential.
+type CloudCredentialDependent struct {
+	Type      string `json:"type" norman:"type=enum,options=cluster|provisioningCluster|machinePool|nodeTemplate"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// MachinePool is the name of the machine pool of a provisioning cluster.
+	MachinePool string `json:"machinePool,omitempty"`
+}
+
+type CloudCredentialDependentsOutput struct {
+	Dependents []CloudCredentialDependent `json:"dependents,omitempty"`
+}
+
+type CloudCredentialRotateInput struct {
+	// CloudCredentialID is the ID of the cloud credential replacing the rotated one. It must be a credential of the same
+	// provider, and is validated against the provider before any dependent is switched to it.
+	CloudCredentialID string `json:"cloudCredentialId" norman:"required,type=reference[cloudCredential]"`
+	// DryRun validates the replacement and returns the dependents that would be switched to it, without switching them.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+type CloudCredentialRotateOutput struct {
+	// Dependents are the dependents switched to the replacement cloud credential.
+	Dependents []CloudCredentialDependent `json:"dependents,omitempty"`
+	Message    string                     `json:"message,omitempty"`
+}
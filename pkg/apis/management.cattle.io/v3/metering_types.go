@@ -0,0 +1,58 @@
+package v3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	MeteringAggregationHourly = "hourly"
+	MeteringAggregationDaily  = "daily"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MeteringRecord is the usage of the clusters and projects managed by rancher over an aggregation period, an hour or a
+// day, recorded by sampling the clusters and projects while metering is enabled. Records are kept for the metering
+// retention and exported for chargeback from the metering endpoint and as prometheus metrics.
+type MeteringRecord struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec MeteringRecordSpec `json:"spec"`
+}
+
+type MeteringRecordSpec struct {
+	// Aggregation is the length of the period, hourly or daily.
+	Aggregation string `json:"aggregation"`
+	// Start and End are the RFC3339 times of the period.
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// LastSampled is the RFC3339 time of the last sample recorded.
+	LastSampled string                 `json:"lastSampled,omitempty"`
+	Clusters    []MeteringClusterUsage `json:"clusters,omitempty"`
+	Projects    []MeteringProjectUsage `json:"projects,omitempty"`
+}
+
+type MeteringClusterUsage struct {
+	ClusterName string `json:"clusterName"`
+	// Team is the value of the metering team label of the cluster.
+	Team string `json:"team,omitempty"`
+	// NodeSeconds and CoreSeconds are the number of nodes and of node cores of the cluster integrated over the period.
+	NodeSeconds int64 `json:"nodeSeconds"`
+	CoreSeconds int64 `json:"coreSeconds"`
+	// MaxNodes is the largest number of nodes of the cluster sampled in the period.
+	MaxNodes int `json:"maxNodes"`
+}
+
+type MeteringProjectUsage struct {
+	// ProjectName is the project, as <cluster>:<project>.
+	ProjectName string `json:"projectName"`
+	ClusterName string `json:"clusterName"`
+	// Team is the value of the metering team label of the project, or else of its cluster.
+	Team string `json:"team,omitempty"`
+	// RequestedMilliCoreSeconds is the CPU requested by the workloads of the project integrated over the period, as
+	// accounted in the resource quota of the project. Projects without a resource quota have no requested CPU.
+	RequestedMilliCoreSeconds int64 `json:"requestedMilliCoreSeconds"`
+}
@@ -0,0 +1,56 @@
+package v3
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NamespaceTemplate is a template of the namespaces of a downstream cluster, created by the cluster owners in the
+// namespace of the cluster. Project owners create namespaces from a template by annotating them with the template,
+// which pre-fills their quotas and keeps their network policies and role bindings in sync with the template, without
+// needing permissions on the cluster.
+type NamespaceTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NamespaceTemplateSpec `json:"spec"`
+}
+
+type NamespaceTemplateSpec struct {
+	DisplayName string `json:"displayName,omitempty"`
+	Description string `json:"description,omitempty"`
+	// ProjectNames are the names of the projects of the cluster whose namespaces can be created from the template.
+	// The namespaces of every project of the cluster can when neither ProjectNames nor ProjectSelector are set.
+	ProjectNames []string `json:"projectNames,omitempty"`
+	// ProjectSelector selects the projects whose namespaces can be created from the template by their labels, in
+	// addition to ProjectNames.
+	ProjectSelector *metav1.LabelSelector `json:"projectSelector,omitempty"`
+	// Labels and Annotations are set on the namespaces created from the template that don't already have them.
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// ResourceQuota and ContainerDefaultResourceLimit pre-fill the quota and default container resource limits of the
+	// namespaces created from the template that don't set their own. They're validated against the quota of the
+	// project like the quota of any namespace.
+	ResourceQuota                 *NamespaceResourceQuota `json:"resourceQuota,omitempty"`
+	ContainerDefaultResourceLimit *ContainerResourceLimit `json:"containerDefaultResourceLimit,omitempty"`
+	// NetworkPolicies and RoleBindings are created in the namespaces created from the template, and kept in sync with
+	// the template.
+	NetworkPolicies []NamespaceTemplateNetworkPolicy `json:"networkPolicies,omitempty"`
+	RoleBindings    []NamespaceTemplateRoleBinding   `json:"roleBindings,omitempty"`
+}
+
+type NamespaceTemplateNetworkPolicy struct {
+	Name string                         `json:"name"`
+	Spec networkingv1.NetworkPolicySpec `json:"spec"`
+}
+
+type NamespaceTemplateRoleBinding struct {
+	Name string `json:"name"`
+	// ClusterRoleName is the name of the cluster role of the downstream cluster bound in the namespace.
+	ClusterRoleName string           `json:"clusterRoleName"`
+	Subjects        []rbacv1.Subject `json:"subjects,omitempty"`
+}
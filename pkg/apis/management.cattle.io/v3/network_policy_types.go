@@ -10,6 +10,22 @@
 type ProjectNetworkPolicySpec struct {
 	ProjectName string `json:"projectName,omitempty" norman:"required,type=reference[project]"`
 	Description string `json:"description"`
+	// AllowedIngress are the peers traffic is allowed from into the namespaces of the project, in addition to the
+	// namespaces of the project and of the system project.
+	AllowedIngress []ProjectNetworkPolicyPeer `json:"allowedIngress,omitempty"`
+	// ExemptNamespaces are the namespaces of the project that aren't isolated, Rancher doesn't create any network
+	// policy in them.
+	ExemptNamespaces []string `json:"exemptNamespaces,omitempty"`
+}
+
+// ProjectNetworkPolicyPeer is a peer traffic is allowed from, only one of its fields can be set.
+type ProjectNetworkPolicyPeer struct {
+	// ProjectName is another project of the cluster, whose namespaces traffic is allowed from.
+	ProjectName string `json:"projectName,omitempty" norman:"type=reference[project]"`
+	// NamespaceSelector selects the namespaces of the cluster traffic is allowed from.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// CIDR is an IP block traffic is allowed from.
+	CIDR string `json:"cidr,omitempty"`
 }
 
 func (p *ProjectNetworkPolicySpec) ObjClusterName() string {
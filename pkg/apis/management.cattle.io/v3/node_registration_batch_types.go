@@ -0,0 +1,55 @@
+package v3
+
+import (
+	"github.com/rancher/wrangler/pkg/genericcondition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodeRegistrationBatch lists the nodes expected to register with a custom cluster, in the namespace of the cluster.
+// Its status has the command registering each node with its roles, labels and addresses, and tracks which of the
+// expected nodes have registered.
+type NodeRegistrationBatch struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NodeRegistrationBatchSpec   `json:"spec"`
+	Status NodeRegistrationBatchStatus `json:"status,omitempty"`
+}
+
+type NodeRegistrationBatchSpec struct {
+	// ClusterName is the custom cluster the nodes register with, the batch being in its namespace.
+	ClusterName string `json:"clusterName" norman:"type=reference[cluster]"`
+	// Insecure registers the nodes without verifying the certificate of Rancher.
+	Insecure bool                    `json:"insecure,omitempty"`
+	Nodes    []NodeRegistrationEntry `json:"nodes,omitempty"`
+}
+
+// NodeRegistrationEntry is a node expected to register, matched with the registered nodes by hostname or by address.
+type NodeRegistrationEntry struct {
+	Hostname string `json:"hostname"`
+	// Roles are the roles the node registers with: etcd, controlplane and worker.
+	Roles           []string          `json:"roles"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Address         string            `json:"address,omitempty"`
+	InternalAddress string            `json:"internalAddress,omitempty"`
+}
+
+type NodeRegistrationBatchStatus struct {
+	Conditions []genericcondition.GenericCondition `json:"conditions,omitempty"`
+	// ExpectedCount and RegisteredCount are the number of expected nodes and of those registered.
+	ExpectedCount   int                          `json:"expectedCount"`
+	RegisteredCount int                          `json:"registeredCount"`
+	Nodes           []NodeRegistrationNodeStatus `json:"nodes,omitempty"`
+}
+
+type NodeRegistrationNodeStatus struct {
+	Hostname string `json:"hostname"`
+	// Command is the command to run on the node to register it.
+	Command    string `json:"command,omitempty"`
+	Registered bool   `json:"registered"`
+	// NodeName is the name of the node the expected node registered as, in the namespace of the cluster.
+	NodeName string `json:"nodeName,omitempty"`
+}
@@ -0,0 +1,89 @@
+package v3
+
+import (
+	fleet "github.com/rancher/fleet/pkg/apis/fleet.cattle.io/v1alpha1"
+	"github.com/rancher/wrangler/pkg/genericcondition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	PolicyEngineGatekeeper = "opa-gatekeeper"
+	PolicyEngineKyverno    = "kyverno"
+
+	// PolicyBundleCompliant is the state of a cluster the current version of the policies is deployed to and
+	// unmodified on.
+	PolicyBundleCompliant = "Compliant"
+	// PolicyBundlePending is the state of a cluster the current version of the policies isn't deployed to yet.
+	PolicyBundlePending = "Pending"
+	// PolicyBundleDrifted is the state of a cluster the policies were modified or deleted on.
+	PolicyBundleDrifted = "Drifted"
+	// PolicyBundleError is the state of a cluster the policies couldn't be deployed to.
+	PolicyBundleError = "Error"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PolicyBundle assigns a versioned set of OPA Gatekeeper or Kyverno policies to the clusters and cluster groups of a
+// fleet workspace, in whose namespace it's created. The policies are deployed with a fleet bundle, and the state of
+// the policies on each targeted cluster is reported on the policy bundle and on the cluster.
+type PolicyBundle struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PolicyBundleSpec   `json:"spec"`
+	Status PolicyBundleStatus `json:"status,omitempty"`
+}
+
+type PolicyBundleSpec struct {
+	DisplayName string `json:"displayName,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Engine is the policy engine of the policies, opa-gatekeeper or kyverno. The engine must be installed on the
+	// targeted clusters.
+	Engine string `json:"engine"`
+	// Version is the version of the policies, reported on the clusters it's deployed to.
+	Version string `json:"version"`
+	// Policies are the manifests of the policies: constraint templates and constraints for OPA Gatekeeper, or
+	// policies and cluster policies for Kyverno.
+	Policies []PolicyBundlePolicy `json:"policies,omitempty"`
+	Paused   bool                 `json:"paused,omitempty"`
+
+	RolloutStrategy *fleet.RolloutStrategy `json:"rolloutStrategy,omitempty"`
+	// Targets select the clusters and cluster groups of the fleet workspace the policies are deployed to.
+	Targets []fleet.BundleTarget `json:"targets,omitempty"`
+}
+
+type PolicyBundlePolicy struct {
+	Name string `json:"name"`
+	// Content is the YAML of the manifests of the policy.
+	Content string `json:"content"`
+}
+
+type PolicyBundleStatus struct {
+	Conditions []genericcondition.GenericCondition `json:"conditions,omitempty"`
+	// Clusters is the state of the policies on each targeted cluster.
+	Clusters []PolicyBundleClusterStatus `json:"clusters,omitempty"`
+	// CompliantClusters and DriftedClusters are the number of targeted clusters in the Compliant and Drifted states.
+	CompliantClusters int `json:"compliantClusters"`
+	DriftedClusters   int `json:"driftedClusters"`
+}
+
+type PolicyBundleClusterStatus struct {
+	// ClusterName is the name of the management cluster.
+	ClusterName string `json:"clusterName"`
+	// Version is the version of the policies last deployed to the cluster.
+	Version string `json:"version,omitempty"`
+	// State is Compliant, Pending, Drifted or Error.
+	State   string `json:"state"`
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterPolicyBundleStatus is the state of the policies of a policy bundle on a cluster.
+type ClusterPolicyBundleStatus struct {
+	// Name is the policy bundle, as <namespace>/<name>.
+	Name    string `json:"name"`
+	Engine  string `json:"engine,omitempty"`
+	Version string `json:"version,omitempty"`
+	State   string `json:"state"`
+	Message string `json:"message,omitempty"`
+}
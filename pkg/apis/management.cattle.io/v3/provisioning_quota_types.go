@@ -0,0 +1,42 @@
+package v3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ProvisioningQuota limits the clusters users, groups and cluster groups can provision: their number, their total
+// number of nodes, and the instance types and regions of their machine pools. Quotas are enforced when provisioning
+// clusters are created or scaled, every quota of the user or cluster must be satisfied.
+type ProvisioningQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ProvisioningQuotaSpec `json:"spec"`
+}
+
+type ProvisioningQuotaSpec struct {
+	DisplayName string `json:"displayName,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// UserNames are the users the quota applies to, each user being limited on the clusters it created.
+	UserNames []string `json:"userNames,omitempty"`
+	// GroupPrincipalNames are the groups the quota applies to, each group being limited on the clusters created by
+	// its members.
+	GroupPrincipalNames []string `json:"groupPrincipalNames,omitempty"`
+	// ClusterGroupNames are the cluster groups the quota applies to, each cluster group being limited on its clusters.
+	ClusterGroupNames []string `json:"clusterGroupNames,omitempty"`
+
+	// MaxClusters is the maximum number of clusters, unlimited if not set.
+	MaxClusters *int `json:"maxClusters,omitempty"`
+	// MaxNodes is the maximum total number of nodes of the clusters, unlimited if not set. The nodes of a cluster
+	// are the machines of its machine pools, or its nodes if it has none.
+	MaxNodes *int `json:"maxNodes,omitempty"`
+	// AllowedInstanceTypes are the instance types the machine pools can use, any if empty.
+	AllowedInstanceTypes []string `json:"allowedInstanceTypes,omitempty"`
+	// AllowedRegions are the regions the machine pools can be in, any if empty.
+	AllowedRegions []string `json:"allowedRegions,omitempty"`
+}
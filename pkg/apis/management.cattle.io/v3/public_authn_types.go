@@ -37,6 +37,19 @@ type BasicLogin struct {
 	GenericLogin `json:",inline"`
 	Username     string `json:"username" norman:"type=string,required"`
 	Password     string `json:"password" norman:"type=string,required"`
+	// MFACode is a TOTP code or a recovery code of a local user with multi-factor authentication.
+	MFACode string `json:"mfaCode,omitempty"`
+	// WebAuthn is the response of a security key of a local user with multi-factor authentication.
+	WebAuthn *WebAuthnAssertion `json:"webauthn,omitempty"`
+}
+
+// WebAuthnAssertion is the response of a security key to an authentication challenge, binary fields are base64url
+// encoded.
+type WebAuthnAssertion struct {
+	CredentialID      string `json:"credentialId"`
+	ClientDataJSON    string `json:"clientDataJSON"`
+	AuthenticatorData string `json:"authenticatorData"`
+	Signature         string `json:"signature"`
 }
 
 // +genclient
@@ -0,0 +1,93 @@
+package v3
+
+import (
+	fleet "github.com/rancher/fleet/pkg/apis/fleet.cattle.io/v1alpha1"
+	"github.com/rancher/wrangler/pkg/genericcondition"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type RancherConfigResourceKind string
+
+const (
+	RancherConfigResourceSetting    RancherConfigResourceKind = "Setting"
+	RancherConfigResourceFeature    RancherConfigResourceKind = "Feature"
+	RancherConfigResourceAuthConfig RancherConfigResourceKind = "AuthConfig"
+	RancherConfigResourceGlobalRole RancherConfigResourceKind = "GlobalRole"
+)
+
+type RancherConfigResourceState string
+
+const (
+	// RancherConfigResourceApplied is the state of a resource matching its declaration.
+	RancherConfigResourceApplied RancherConfigResourceState = "Applied"
+	// RancherConfigResourceConflict is the state of a resource changed outside of the rancher config, such as from the
+	// UI, since the rancher config last applied it.
+	RancherConfigResourceConflict RancherConfigResourceState = "Conflict"
+	// RancherConfigResourceError is the state of a resource that couldn't be applied.
+	RancherConfigResourceError RancherConfigResourceState = "Error"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RancherConfig declares the configuration of rancher itself, so that it can be managed from git. The settings, feature
+// flags, auth configs and global roles it declares are reconciled, and changes made to them outside of the rancher
+// config are reported as conflicts.
+type RancherConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RancherConfigSpec   `json:"spec"`
+	Status RancherConfigStatus `json:"status,omitempty"`
+}
+
+type RancherConfigSpec struct {
+	// Settings are the values of settings, by name.
+	Settings map[string]string `json:"settings,omitempty"`
+	// Features are the values of feature flags, by name.
+	Features map[string]bool `json:"features,omitempty"`
+	// AuthConfigs are the auth providers configured.
+	AuthConfigs []RancherConfigAuthConfig `json:"authConfigs,omitempty"`
+	// GlobalRoles are the global roles created or updated. Global roles removed from the rancher config aren't deleted.
+	GlobalRoles []RancherConfigGlobalRole `json:"globalRoles,omitempty"`
+	// OverwriteConflicts overwrites the changes made outside of the rancher config instead of reporting them as
+	// conflicts.
+	OverwriteConflicts bool `json:"overwriteConflicts,omitempty"`
+}
+
+type RancherConfigAuthConfig struct {
+	// Name of the auth config, such as github or activedirectory.
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	// Fields of the auth config, specific to its provider. Secret fields reference a secret holding their value, in the
+	// same format as the auth config stores them.
+	Fields *fleet.GenericMap `json:"fields,omitempty"`
+}
+
+type RancherConfigGlobalRole struct {
+	Name           string              `json:"name"`
+	DisplayName    string              `json:"displayName,omitempty"`
+	Description    string              `json:"description,omitempty"`
+	Rules          []rbacv1.PolicyRule `json:"rules,omitempty"`
+	NewUserDefault bool                `json:"newUserDefault,omitempty"`
+}
+
+type RancherConfigStatus struct {
+	// ObservedGeneration is the generation of the spec last reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Resources is the state of every resource declared by the rancher config.
+	Resources  []RancherConfigResourceStatus       `json:"resources,omitempty"`
+	Conditions []genericcondition.GenericCondition `json:"conditions,omitempty"`
+}
+
+type RancherConfigResourceStatus struct {
+	Kind  RancherConfigResourceKind  `json:"kind"`
+	Name  string                     `json:"name"`
+	State RancherConfigResourceState `json:"state"`
+	// AppliedHash is the hash of the value of the resource last applied by the rancher config, a resource whose value
+	// differs from it was changed outside of the rancher config.
+	AppliedHash string `json:"appliedHash,omitempty"`
+	Message     string `json:"message,omitempty"`
+}
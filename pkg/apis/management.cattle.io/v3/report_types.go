@@ -0,0 +1,76 @@
+package v3
+
+import (
+	"github.com/rancher/wrangler/pkg/genericcondition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ReportSection string
+
+const (
+	ReportSectionClusterVersions      ReportSection = "clusterVersions"
+	ReportSectionNodeCounts           ReportSection = "nodeCounts"
+	ReportSectionExpiringCertificates ReportSection = "expiringCertificates"
+	ReportSectionAdminUsers           ReportSection = "adminUsers"
+	ReportSectionPSACTCompliance      ReportSection = "psactCompliance"
+)
+
+type ReportFormat string
+
+const (
+	ReportFormatJSON ReportFormat = "json"
+	ReportFormatCSV  ReportFormat = "csv"
+	ReportFormatPDF  ReportFormat = "pdf"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Report is a periodic report of the inventory and compliance of the clusters managed by rancher. Every generation is
+// stored as one artifact per format, which can be downloaded from the report download endpoint.
+type Report struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ReportSpec   `json:"spec"`
+	Status ReportStatus `json:"status,omitempty"`
+}
+
+type ReportSpec struct {
+	// Schedule is a standard cron schedule the report is generated on. The report is generated once when it is empty.
+	Schedule string `json:"schedule,omitempty"`
+	// Sections of the report, all sections are included when it is empty.
+	Sections []ReportSection `json:"sections,omitempty"`
+	// Formats the report is generated in, defaults to json.
+	Formats []ReportFormat `json:"formats,omitempty"`
+	// CertificateExpirationDays is the number of days before their expiration certificates are reported, defaults to 30.
+	CertificateExpirationDays int `json:"certificateExpirationDays,omitempty"`
+	// Retention is the number of generations kept, defaults to 5.
+	Retention int `json:"retention,omitempty"`
+	// Email optionally sends the report after every generation.
+	Email *ReportEmail `json:"email,omitempty"`
+}
+
+type ReportEmail struct {
+	// Notifier is the namespace:name of a notifier with a SMTP config the report is sent with.
+	Notifier string `json:"notifier"`
+	// Recipients of the report, the default recipient of the notifier is used when it is empty.
+	Recipients []string `json:"recipients,omitempty"`
+}
+
+type ReportStatus struct {
+	// LastGenerated is the RFC3339 time of the last generation of the report.
+	LastGenerated string `json:"lastGenerated,omitempty"`
+	// Artifacts of the retained generations, most recent first.
+	Artifacts  []ReportArtifact                    `json:"artifacts,omitempty"`
+	Conditions []genericcondition.GenericCondition `json:"conditions,omitempty"`
+}
+
+type ReportArtifact struct {
+	// Name of the artifact, used to download it.
+	Name      string       `json:"name"`
+	Format    ReportFormat `json:"format"`
+	Generated string       `json:"generated"`
+	Size      int          `json:"size,omitempty"`
+}
@@ -0,0 +1,59 @@
+package v3
+
+import (
+	"github.com/rancher/wrangler/pkg/genericcondition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SecretDistribution copies secrets of the management cluster, such as image pull secrets or CA bundles, to namespaces
+// of downstream clusters. The copies are kept in sync with the secrets, so that rotating a secret updates its copies,
+// and are deleted when they're no longer selected or the secret distribution is deleted.
+type SecretDistribution struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SecretDistributionSpec   `json:"spec"`
+	Status SecretDistributionStatus `json:"status,omitempty"`
+}
+
+type SecretDistributionSpec struct {
+	// Secrets are the secrets of the management cluster copied.
+	Secrets []SecretDistributionSource `json:"secrets"`
+	// ClusterNames are the names of the management clusters of the downstream clusters the secrets are copied to.
+	ClusterNames []string `json:"clusterNames,omitempty"`
+	// ClusterSelector selects the downstream clusters the secrets are copied to by the labels of their management
+	// clusters, in addition to ClusterNames.
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+	// Namespaces are the namespaces of the downstream clusters the secrets are copied to. Namespaces that don't exist
+	// are skipped until they're created.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// NamespaceSelector selects the namespaces of the downstream clusters the secrets are copied to by their labels, in
+	// addition to Namespaces.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+type SecretDistributionSource struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// TargetName is the name of the copies of the secret, the name of the secret by default.
+	TargetName string `json:"targetName,omitempty"`
+}
+
+type SecretDistributionStatus struct {
+	// Clusters is the state of the copies of the secrets in every downstream cluster selected.
+	Clusters   []SecretDistributionClusterStatus   `json:"clusters,omitempty"`
+	Conditions []genericcondition.GenericCondition `json:"conditions,omitempty"`
+}
+
+type SecretDistributionClusterStatus struct {
+	ClusterName string `json:"clusterName"`
+	// Secrets is the number of copies of secrets in the cluster.
+	Secrets int `json:"secrets"`
+	// Error is why the secrets couldn't be copied to the cluster, empty if they were.
+	Error        string      `json:"error,omitempty"`
+	LastSyncTime metav1.Time `json:"lastSyncTime,omitempty"`
+}
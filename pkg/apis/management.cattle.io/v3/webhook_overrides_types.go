@@ -0,0 +1,21 @@
+package v3
+
+// WebhookOverrides tune the rancher-webhook of a downstream cluster. Rancher applies them to the webhook
+// configurations the rancher-webhook registers, and keeps them applied when the rancher-webhook or anyone else
+// reverts them.
+type WebhookOverrides struct {
+	// Webhooks override the webhooks of the rancher-webhook, by name.
+	Webhooks []WebhookOverride `json:"webhooks,omitempty"`
+	// ExemptNamespaces are namespaces whose objects aren't validated or mutated by the rancher-webhook.
+	ExemptNamespaces []string `json:"exemptNamespaces,omitempty"`
+}
+
+type WebhookOverride struct {
+	// Name is the name of a webhook of the validating or mutating webhook configuration of the rancher-webhook, such
+	// as rancher.cattle.io.namespaces.
+	Name string `json:"name"`
+	// FailurePolicy is the failure policy of the webhook, Fail or Ignore.
+	FailurePolicy string `json:"failurePolicy,omitempty"`
+	// Disabled removes the webhook, so that the resources of its rules aren't validated or mutated.
+	Disabled bool `json:"disabled,omitempty"`
+}
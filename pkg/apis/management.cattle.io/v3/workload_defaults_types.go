@@ -0,0 +1,34 @@
+package v3
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// WorkloadDefaults are defaults rancher applies to the namespaces and workloads of a downstream cluster, and keeps
+// applied when they are changed in the downstream cluster. System namespaces are never changed.
+type WorkloadDefaults struct {
+	// ImagePullSecrets are secrets of type kubernetes.io/dockerconfigjson of the management cluster, in the namespace of
+	// the cluster or in its fleet workspace. They are copied to each namespace of the downstream cluster and added to
+	// the image pull secrets of the default service account of the namespace.
+	ImagePullSecrets []ImagePullSecretSource `json:"imagePullSecrets,omitempty"`
+	// DefaultRuntimeClass is created in the downstream cluster and set as the runtime class of the deployments,
+	// statefulsets and daemonsets that don't set one.
+	DefaultRuntimeClass *DefaultRuntimeClass `json:"defaultRuntimeClass,omitempty"`
+	// ExcludedNamespaces are namespaces the defaults aren't applied to, in addition to the system namespaces.
+	ExcludedNamespaces []string `json:"excludedNamespaces,omitempty"`
+}
+
+type ImagePullSecretSource struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+type DefaultRuntimeClass struct {
+	Name string `json:"name"`
+	// Handler is the name of the runtime configured in the container runtime of the nodes, such as runsc or kata.
+	Handler string `json:"handler"`
+	// NodeSelector schedules the pods of the runtime class onto the nodes supporting it.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations are added to the pods of the runtime class.
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+}
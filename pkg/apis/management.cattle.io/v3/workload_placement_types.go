@@ -0,0 +1,77 @@
+package v3
+
+import (
+	fleet "github.com/rancher/fleet/pkg/apis/fleet.cattle.io/v1alpha1"
+	"github.com/rancher/wrangler/pkg/genericcondition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// WorkloadPlacementReady is the state of a workload placement whose workload is ready on all the clusters it's
+	// placed on.
+	WorkloadPlacementReady = "Ready"
+	// WorkloadPlacementRollingOut is the state of a workload placement whose workload isn't deployed or ready on all
+	// the clusters it's placed on yet.
+	WorkloadPlacementRollingOut = "RollingOut"
+	// WorkloadPlacementError is the state of a workload placement whose workload couldn't be deployed to a cluster.
+	WorkloadPlacementError = "Error"
+	// WorkloadPlacementNoClusters is the state of a workload placement that selects no cluster.
+	WorkloadPlacementNoClusters = "NoClusters"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// WorkloadPlacement places a workload, a Helm chart of a cluster repository, on the clusters of a fleet workspace
+// selected by labels, in whose namespace it's created. The workload is deployed with a managed chart, and its rollout
+// across the clusters is summarized on the workload placement.
+type WorkloadPlacement struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WorkloadPlacementSpec   `json:"spec"`
+	Status WorkloadPlacementStatus `json:"status,omitempty"`
+}
+
+type WorkloadPlacementSpec struct {
+	DisplayName string `json:"displayName,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// RepoName is the cluster repository of the chart of the workload.
+	RepoName string `json:"repoName"`
+	Chart    string `json:"chart"`
+	Version  string `json:"version,omitempty"`
+	// ReleaseName is the name of the Helm release of the workload, the name of the workload placement by default.
+	ReleaseName string `json:"releaseName,omitempty"`
+	// Namespace is the namespace the workload is installed in on each cluster.
+	Namespace string            `json:"namespace,omitempty"`
+	Values    *fleet.GenericMap `json:"values,omitempty"`
+
+	// ClusterSelector selects the clusters of the fleet workspace the workload is placed on, an empty selector
+	// selecting all of them.
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector"`
+	// Overrides are values merged over the values of the workload on some of the selected clusters. Only the first
+	// override matching a cluster applies to it.
+	Overrides []WorkloadPlacementOverride `json:"overrides,omitempty"`
+
+	Paused          bool                   `json:"paused,omitempty"`
+	RolloutStrategy *fleet.RolloutStrategy `json:"rolloutStrategy,omitempty"`
+}
+
+// WorkloadPlacementOverride overrides values on the selected clusters with a given name or labels.
+type WorkloadPlacementOverride struct {
+	ClusterName     string                `json:"clusterName,omitempty"`
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+	Values          *fleet.GenericMap     `json:"values,omitempty"`
+}
+
+type WorkloadPlacementStatus struct {
+	Conditions []genericcondition.GenericCondition `json:"conditions,omitempty"`
+	// State is Ready, RollingOut, Error or NoClusters.
+	State   string `json:"state,omitempty"`
+	Message string `json:"message,omitempty"`
+	// DesiredReadyClusters is the number of clusters the workload is placed on, and ReadyClusters the number of them
+	// the workload is ready on.
+	DesiredReadyClusters int `json:"desiredReadyClusters"`
+	ReadyClusters        int `json:"readyClusters"`
+}
@@ -28,8 +28,11 @@
 	gkecattleiov1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
 	projectcattleiov3 "github.com/rancher/rancher/pkg/apis/project.cattle.io/v3"
 	types "github.com/rancher/rke/types"
+	genericcondition "github.com/rancher/wrangler/pkg/genericcondition"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	version "k8s.io/apimachinery/pkg/version"
 )
@@ -823,6 +826,11 @@ func (in *AzureADProviderList) DeepCopyObject() runtime.Object {
 func (in *BasicLogin) DeepCopyInto(out *BasicLogin) {
 	*out = *in
 	out.GenericLogin = in.GenericLogin
+	if in.WebAuthn != nil {
+		in, out := &in.WebAuthn, &out.WebAuthn
+		*out = new(WebAuthnAssertion)
+		**out = **in
+	}
 	return
 }
 
@@ -1208,6 +1216,43 @@ func (in *CloudCredential) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudCredentialDependent) DeepCopyInto(out *CloudCredentialDependent) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudCredentialDependent.
+func (in *CloudCredentialDependent) DeepCopy() *CloudCredentialDependent {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudCredentialDependent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudCredentialDependentsOutput) DeepCopyInto(out *CloudCredentialDependentsOutput) {
+	*out = *in
+	if in.Dependents != nil {
+		in, out := &in.Dependents, &out.Dependents
+		*out = make([]CloudCredentialDependent, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudCredentialDependentsOutput.
+func (in *CloudCredentialDependentsOutput) DeepCopy() *CloudCredentialDependentsOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudCredentialDependentsOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudCredentialList) DeepCopyInto(out *CloudCredentialList) {
 	*out = *in
@@ -1241,6 +1286,43 @@ func (in *CloudCredentialList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudCredentialRotateInput) DeepCopyInto(out *CloudCredentialRotateInput) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudCredentialRotateInput.
+func (in *CloudCredentialRotateInput) DeepCopy() *CloudCredentialRotateInput {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudCredentialRotateInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudCredentialRotateOutput) DeepCopyInto(out *CloudCredentialRotateOutput) {
+	*out = *in
+	if in.Dependents != nil {
+		in, out := &in.Dependents, &out.Dependents
+		*out = make([]CloudCredentialDependent, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudCredentialRotateOutput.
+func (in *CloudCredentialRotateOutput) DeepCopy() *CloudCredentialRotateOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudCredentialRotateOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudCredentialSpec) DeepCopyInto(out *CloudCredentialSpec) {
 	*out = *in
@@ -1579,25 +1661,26 @@ func (in *ClusterAlertSpec) DeepCopy() *ClusterAlertSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterCatalog) DeepCopyInto(out *ClusterCatalog) {
+func (in *ClusterArchive) DeepCopyInto(out *ClusterArchive) {
 	*out = *in
-	out.Namespaced = in.Namespaced
-	in.Catalog.DeepCopyInto(&out.Catalog)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCatalog.
-func (in *ClusterCatalog) DeepCopy() *ClusterCatalog {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterArchive.
+func (in *ClusterArchive) DeepCopy() *ClusterArchive {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterCatalog)
+	out := new(ClusterArchive)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterCatalog) DeepCopyObject() runtime.Object {
+func (in *ClusterArchive) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1605,13 +1688,13 @@ func (in *ClusterCatalog) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterCatalogList) DeepCopyInto(out *ClusterCatalogList) {
+func (in *ClusterArchiveList) DeepCopyInto(out *ClusterArchiveList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterCatalog, len(*in))
+		*out = make([]ClusterArchive, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1619,18 +1702,18 @@ func (in *ClusterCatalogList) DeepCopyInto(out *ClusterCatalogList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCatalogList.
-func (in *ClusterCatalogList) DeepCopy() *ClusterCatalogList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterArchiveList.
+func (in *ClusterArchiveList) DeepCopy() *ClusterArchiveList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterCatalogList)
+	out := new(ClusterArchiveList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterCatalogList) DeepCopyObject() runtime.Object {
+func (in *ClusterArchiveList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1638,72 +1721,111 @@ func (in *ClusterCatalogList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterComponentStatus) DeepCopyInto(out *ClusterComponentStatus) {
+func (in *ClusterArchiveMember) DeepCopyInto(out *ClusterArchiveMember) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]corev1.ComponentCondition, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterComponentStatus.
-func (in *ClusterComponentStatus) DeepCopy() *ClusterComponentStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterArchiveMember.
+func (in *ClusterArchiveMember) DeepCopy() *ClusterArchiveMember {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterComponentStatus)
+	out := new(ClusterArchiveMember)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterCondition) DeepCopyInto(out *ClusterCondition) {
+func (in *ClusterArchiveProject) DeepCopyInto(out *ClusterArchiveProject) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCondition.
-func (in *ClusterCondition) DeepCopy() *ClusterCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterArchiveProject.
+func (in *ClusterArchiveProject) DeepCopy() *ClusterArchiveProject {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterCondition)
+	out := new(ClusterArchiveProject)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterGroupSpec) DeepCopyInto(out *ClusterGroupSpec) {
+func (in *ClusterArchiveSpec) DeepCopyInto(out *ClusterArchiveSpec) {
 	*out = *in
-	if in.Recipients != nil {
-		in, out := &in.Recipients, &out.Recipients
-		*out = make([]Recipient, len(*in))
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ClusterCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]ClusterArchiveMember, len(*in))
+		copy(*out, *in)
+	}
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]ClusterArchiveProject, len(*in))
 		copy(*out, *in)
 	}
-	out.CommonGroupField = in.CommonGroupField
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGroupSpec.
-func (in *ClusterGroupSpec) DeepCopy() *ClusterGroupSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterArchiveSpec.
+func (in *ClusterArchiveSpec) DeepCopy() *ClusterArchiveSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterGroupSpec)
+	out := new(ClusterArchiveSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterList) DeepCopyInto(out *ClusterList) {
+func (in *ClusterBundle) DeepCopyInto(out *ClusterBundle) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBundle.
+func (in *ClusterBundle) DeepCopy() *ClusterBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterBundle) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBundleList) DeepCopyInto(out *ClusterBundleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Cluster, len(*in))
+		*out = make([]ClusterBundle, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1711,18 +1833,18 @@ func (in *ClusterList) DeepCopyInto(out *ClusterList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterList.
-func (in *ClusterList) DeepCopy() *ClusterList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBundleList.
+func (in *ClusterBundleList) DeepCopy() *ClusterBundleList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterList)
+	out := new(ClusterBundleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterList) DeepCopyObject() runtime.Object {
+func (in *ClusterBundleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1730,28 +1852,64 @@ func (in *ClusterList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterLogging) DeepCopyInto(out *ClusterLogging) {
+func (in *ClusterBundleSpec) DeepCopyInto(out *ClusterBundleSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBundleSpec.
+func (in *ClusterBundleSpec) DeepCopy() *ClusterBundleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBundleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterBundleStatus) DeepCopyInto(out *ClusterBundleStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterBundleStatus.
+func (in *ClusterBundleStatus) DeepCopy() *ClusterBundleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterBundleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCatalog) DeepCopyInto(out *ClusterCatalog) {
 	*out = *in
 	out.Namespaced = in.Namespaced
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.Catalog.DeepCopyInto(&out.Catalog)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterLogging.
-func (in *ClusterLogging) DeepCopy() *ClusterLogging {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCatalog.
+func (in *ClusterCatalog) DeepCopy() *ClusterCatalog {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterLogging)
+	out := new(ClusterCatalog)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterLogging) DeepCopyObject() runtime.Object {
+func (in *ClusterCatalog) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1759,13 +1917,13 @@ func (in *ClusterLogging) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterLoggingList) DeepCopyInto(out *ClusterLoggingList) {
+func (in *ClusterCatalogList) DeepCopyInto(out *ClusterCatalogList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterLogging, len(*in))
+		*out = make([]ClusterCatalog, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1773,18 +1931,18 @@ func (in *ClusterLoggingList) DeepCopyInto(out *ClusterLoggingList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterLoggingList.
-func (in *ClusterLoggingList) DeepCopy() *ClusterLoggingList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCatalogList.
+func (in *ClusterCatalogList) DeepCopy() *ClusterCatalogList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterLoggingList)
+	out := new(ClusterCatalogList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterLoggingList) DeepCopyObject() runtime.Object {
+func (in *ClusterCatalogList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1792,93 +1950,80 @@ func (in *ClusterLoggingList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterLoggingSpec) DeepCopyInto(out *ClusterLoggingSpec) {
+func (in *ClusterCircuitBreaker) DeepCopyInto(out *ClusterCircuitBreaker) {
 	*out = *in
-	in.LoggingTargets.DeepCopyInto(&out.LoggingTargets)
-	in.LoggingCommonField.DeepCopyInto(&out.LoggingCommonField)
-	if in.IncludeSystemComponent != nil {
-		in, out := &in.IncludeSystemComponent, &out.IncludeSystemComponent
-		*out = new(bool)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterLoggingSpec.
-func (in *ClusterLoggingSpec) DeepCopy() *ClusterLoggingSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCircuitBreaker.
+func (in *ClusterCircuitBreaker) DeepCopy() *ClusterCircuitBreaker {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterLoggingSpec)
+	out := new(ClusterCircuitBreaker)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterLoggingStatus) DeepCopyInto(out *ClusterLoggingStatus) {
+func (in *ClusterComponentStatus) DeepCopyInto(out *ClusterComponentStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]LoggingCondition, len(*in))
+		*out = make([]corev1.ComponentCondition, len(*in))
 		copy(*out, *in)
 	}
-	in.AppliedSpec.DeepCopyInto(&out.AppliedSpec)
-	if in.FailedSpec != nil {
-		in, out := &in.FailedSpec, &out.FailedSpec
-		*out = new(ClusterLoggingSpec)
-		(*in).DeepCopyInto(*out)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterLoggingStatus.
-func (in *ClusterLoggingStatus) DeepCopy() *ClusterLoggingStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterComponentStatus.
+func (in *ClusterComponentStatus) DeepCopy() *ClusterComponentStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterLoggingStatus)
+	out := new(ClusterComponentStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterMetricNamesInput) DeepCopyInto(out *ClusterMetricNamesInput) {
+func (in *ClusterCondition) DeepCopyInto(out *ClusterCondition) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMetricNamesInput.
-func (in *ClusterMetricNamesInput) DeepCopy() *ClusterMetricNamesInput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCondition.
+func (in *ClusterCondition) DeepCopy() *ClusterCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterMetricNamesInput)
+	out := new(ClusterCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterMonitorGraph) DeepCopyInto(out *ClusterMonitorGraph) {
+func (in *ClusterGroup) DeepCopyInto(out *ClusterGroup) {
 	*out = *in
-	out.Namespaced = in.Namespaced
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMonitorGraph.
-func (in *ClusterMonitorGraph) DeepCopy() *ClusterMonitorGraph {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGroup.
+func (in *ClusterGroup) DeepCopy() *ClusterGroup {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterMonitorGraph)
+	out := new(ClusterGroup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterMonitorGraph) DeepCopyObject() runtime.Object {
+func (in *ClusterGroup) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1886,13 +2031,13 @@ func (in *ClusterMonitorGraph) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterMonitorGraphList) DeepCopyInto(out *ClusterMonitorGraphList) {
+func (in *ClusterGroupList) DeepCopyInto(out *ClusterGroupList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterMonitorGraph, len(*in))
+		*out = make([]ClusterGroup, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1900,18 +2045,18 @@ func (in *ClusterMonitorGraphList) DeepCopyInto(out *ClusterMonitorGraphList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMonitorGraphList.
-func (in *ClusterMonitorGraphList) DeepCopy() *ClusterMonitorGraphList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGroupList.
+func (in *ClusterGroupList) DeepCopy() *ClusterGroupList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterMonitorGraphList)
+	out := new(ClusterGroupList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterMonitorGraphList) DeepCopyObject() runtime.Object {
+func (in *ClusterGroupList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1919,45 +2064,26 @@ func (in *ClusterMonitorGraphList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterMonitorGraphSpec) DeepCopyInto(out *ClusterMonitorGraphSpec) {
+func (in *ClusterGroupRoleTemplateBinding) DeepCopyInto(out *ClusterGroupRoleTemplateBinding) {
 	*out = *in
-	in.CommonMonitorGraphSpec.DeepCopyInto(&out.CommonMonitorGraphSpec)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMonitorGraphSpec.
-func (in *ClusterMonitorGraphSpec) DeepCopy() *ClusterMonitorGraphSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGroupRoleTemplateBinding.
+func (in *ClusterGroupRoleTemplateBinding) DeepCopy() *ClusterGroupRoleTemplateBinding {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterMonitorGraphSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRegistrationToken) DeepCopyInto(out *ClusterRegistrationToken) {
-	*out = *in
-	out.Namespaced = in.Namespaced
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRegistrationToken.
-func (in *ClusterRegistrationToken) DeepCopy() *ClusterRegistrationToken {
-	if in == nil {
-		return nil
-	}
-	out := new(ClusterRegistrationToken)
+	out := new(ClusterGroupRoleTemplateBinding)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterRegistrationToken) DeepCopyObject() runtime.Object {
+func (in *ClusterGroupRoleTemplateBinding) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1965,13 +2091,13 @@ func (in *ClusterRegistrationToken) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRegistrationTokenList) DeepCopyInto(out *ClusterRegistrationTokenList) {
+func (in *ClusterGroupRoleTemplateBindingList) DeepCopyInto(out *ClusterGroupRoleTemplateBindingList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterRegistrationToken, len(*in))
+		*out = make([]ClusterGroupRoleTemplateBinding, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1979,18 +2105,18 @@ func (in *ClusterRegistrationTokenList) DeepCopyInto(out *ClusterRegistrationTok
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRegistrationTokenList.
-func (in *ClusterRegistrationTokenList) DeepCopy() *ClusterRegistrationTokenList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGroupRoleTemplateBindingList.
+func (in *ClusterGroupRoleTemplateBindingList) DeepCopy() *ClusterGroupRoleTemplateBindingList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterRegistrationTokenList)
+	out := new(ClusterGroupRoleTemplateBindingList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterRegistrationTokenList) DeepCopyObject() runtime.Object {
+func (in *ClusterGroupRoleTemplateBindingList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1998,72 +2124,122 @@ func (in *ClusterRegistrationTokenList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRegistrationTokenSpec) DeepCopyInto(out *ClusterRegistrationTokenSpec) {
+func (in *ClusterGroupRoleTemplateBindingStatus) DeepCopyInto(out *ClusterGroupRoleTemplateBindingStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRegistrationTokenSpec.
-func (in *ClusterRegistrationTokenSpec) DeepCopy() *ClusterRegistrationTokenSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGroupRoleTemplateBindingStatus.
+func (in *ClusterGroupRoleTemplateBindingStatus) DeepCopy() *ClusterGroupRoleTemplateBindingStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterRegistrationTokenSpec)
+	out := new(ClusterGroupRoleTemplateBindingStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRegistrationTokenStatus) DeepCopyInto(out *ClusterRegistrationTokenStatus) {
+func (in *ClusterGroupSpec) DeepCopyInto(out *ClusterGroupSpec) {
 	*out = *in
+	if in.Recipients != nil {
+		in, out := &in.Recipients, &out.Recipients
+		*out = make([]Recipient, len(*in))
+		copy(*out, *in)
+	}
+	out.CommonGroupField = in.CommonGroupField
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRegistrationTokenStatus.
-func (in *ClusterRegistrationTokenStatus) DeepCopy() *ClusterRegistrationTokenStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGroupSpec.
+func (in *ClusterGroupSpec) DeepCopy() *ClusterGroupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterRegistrationTokenStatus)
+	out := new(ClusterGroupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRoleTemplateBinding) DeepCopyInto(out *ClusterRoleTemplateBinding) {
+func (in *ClusterGroupSpecification) DeepCopyInto(out *ClusterGroupSpecification) {
 	*out = *in
-	out.Namespaced = in.Namespaced
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterNames != nil {
+		in, out := &in.ClusterNames, &out.ClusterNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterGroupNames != nil {
+		in, out := &in.ClusterGroupNames, &out.ClusterGroupNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRoleTemplateBinding.
-func (in *ClusterRoleTemplateBinding) DeepCopy() *ClusterRoleTemplateBinding {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGroupSpecification.
+func (in *ClusterGroupSpecification) DeepCopy() *ClusterGroupSpecification {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterRoleTemplateBinding)
+	out := new(ClusterGroupSpecification)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterRoleTemplateBinding) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterGroupStatus) DeepCopyInto(out *ClusterGroupStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	return nil
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGroupStatus.
+func (in *ClusterGroupStatus) DeepCopy() *ClusterGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterGroupStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRoleTemplateBindingList) DeepCopyInto(out *ClusterRoleTemplateBindingList) {
+func (in *ClusterList) DeepCopyInto(out *ClusterList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterRoleTemplateBinding, len(*in))
+		*out = make([]Cluster, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -2071,18 +2247,18 @@ func (in *ClusterRoleTemplateBindingList) DeepCopyInto(out *ClusterRoleTemplateB
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRoleTemplateBindingList.
-func (in *ClusterRoleTemplateBindingList) DeepCopy() *ClusterRoleTemplateBindingList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterList.
+func (in *ClusterList) DeepCopy() *ClusterList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterRoleTemplateBindingList)
+	out := new(ClusterList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterRoleTemplateBindingList) DeepCopyObject() runtime.Object {
+func (in *ClusterList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2090,277 +2266,155 @@ func (in *ClusterRoleTemplateBindingList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterScanRule) DeepCopyInto(out *ClusterScanRule) {
+func (in *ClusterLogging) DeepCopyInto(out *ClusterLogging) {
 	*out = *in
+	out.Namespaced = in.Namespaced
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanRule.
-func (in *ClusterScanRule) DeepCopy() *ClusterScanRule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterLogging.
+func (in *ClusterLogging) DeepCopy() *ClusterLogging {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterScanRule)
+	out := new(ClusterLogging)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterLogging) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterSecrets) DeepCopyInto(out *ClusterSecrets) {
+func (in *ClusterLoggingList) DeepCopyInto(out *ClusterLoggingList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterLogging, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSecrets.
-func (in *ClusterSecrets) DeepCopy() *ClusterSecrets {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterLoggingList.
+func (in *ClusterLoggingList) DeepCopy() *ClusterLoggingList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterSecrets)
+	out := new(ClusterLoggingList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterLoggingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
+func (in *ClusterLoggingSpec) DeepCopyInto(out *ClusterLoggingSpec) {
 	*out = *in
-	in.ClusterSpecBase.DeepCopyInto(&out.ClusterSpecBase)
-	if in.K3sConfig != nil {
-		in, out := &in.K3sConfig, &out.K3sConfig
-		*out = new(K3sConfig)
-		**out = **in
-	}
-	if in.Rke2Config != nil {
-		in, out := &in.Rke2Config, &out.Rke2Config
-		*out = new(Rke2Config)
-		**out = **in
-	}
-	if in.ImportedConfig != nil {
-		in, out := &in.ImportedConfig, &out.ImportedConfig
-		*out = new(ImportedConfig)
+	in.LoggingTargets.DeepCopyInto(&out.LoggingTargets)
+	in.LoggingCommonField.DeepCopyInto(&out.LoggingCommonField)
+	if in.IncludeSystemComponent != nil {
+		in, out := &in.IncludeSystemComponent, &out.IncludeSystemComponent
+		*out = new(bool)
 		**out = **in
 	}
-	if in.GoogleKubernetesEngineConfig != nil {
-		in, out := &in.GoogleKubernetesEngineConfig, &out.GoogleKubernetesEngineConfig
-		*out = (*in).DeepCopy()
-	}
-	if in.AzureKubernetesServiceConfig != nil {
-		in, out := &in.AzureKubernetesServiceConfig, &out.AzureKubernetesServiceConfig
-		*out = (*in).DeepCopy()
-	}
-	if in.AmazonElasticContainerServiceConfig != nil {
-		in, out := &in.AmazonElasticContainerServiceConfig, &out.AmazonElasticContainerServiceConfig
-		*out = (*in).DeepCopy()
-	}
-	if in.GenericEngineConfig != nil {
-		in, out := &in.GenericEngineConfig, &out.GenericEngineConfig
-		*out = (*in).DeepCopy()
-	}
-	if in.AKSConfig != nil {
-		in, out := &in.AKSConfig, &out.AKSConfig
-		*out = new(v1.AKSClusterConfigSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.EKSConfig != nil {
-		in, out := &in.EKSConfig, &out.EKSConfig
-		*out = new(ekscattleiov1.EKSClusterConfigSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.GKEConfig != nil {
-		in, out := &in.GKEConfig, &out.GKEConfig
-		*out = new(gkecattleiov1.GKEClusterConfigSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	in.ClusterTemplateAnswers.DeepCopyInto(&out.ClusterTemplateAnswers)
-	if in.ClusterTemplateQuestions != nil {
-		in, out := &in.ClusterTemplateQuestions, &out.ClusterTemplateQuestions
-		*out = make([]Question, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
-func (in *ClusterSpec) DeepCopy() *ClusterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterLoggingSpec.
+func (in *ClusterLoggingSpec) DeepCopy() *ClusterLoggingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterSpec)
+	out := new(ClusterLoggingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterSpecBase) DeepCopyInto(out *ClusterSpecBase) {
+func (in *ClusterLoggingStatus) DeepCopyInto(out *ClusterLoggingStatus) {
 	*out = *in
-	if in.AgentEnvVars != nil {
-		in, out := &in.AgentEnvVars, &out.AgentEnvVars
-		*out = make([]corev1.EnvVar, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.RancherKubernetesEngineConfig != nil {
-		in, out := &in.RancherKubernetesEngineConfig, &out.RancherKubernetesEngineConfig
-		*out = new(types.RancherKubernetesEngineConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.EnableNetworkPolicy != nil {
-		in, out := &in.EnableNetworkPolicy, &out.EnableNetworkPolicy
-		*out = new(bool)
-		**out = **in
-	}
-	out.LocalClusterAuthEndpoint = in.LocalClusterAuthEndpoint
-	out.ClusterSecrets = in.ClusterSecrets
-	if in.ClusterAgentDeploymentCustomization != nil {
-		in, out := &in.ClusterAgentDeploymentCustomization, &out.ClusterAgentDeploymentCustomization
-		*out = new(AgentDeploymentCustomization)
-		(*in).DeepCopyInto(*out)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]LoggingCondition, len(*in))
+		copy(*out, *in)
 	}
-	if in.FleetAgentDeploymentCustomization != nil {
-		in, out := &in.FleetAgentDeploymentCustomization, &out.FleetAgentDeploymentCustomization
-		*out = new(AgentDeploymentCustomization)
+	in.AppliedSpec.DeepCopyInto(&out.AppliedSpec)
+	if in.FailedSpec != nil {
+		in, out := &in.FailedSpec, &out.FailedSpec
+		*out = new(ClusterLoggingSpec)
 		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpecBase.
-func (in *ClusterSpecBase) DeepCopy() *ClusterSpecBase {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterLoggingStatus.
+func (in *ClusterLoggingStatus) DeepCopy() *ClusterLoggingStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterSpecBase)
+	out := new(ClusterLoggingStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
+func (in *ClusterMetricNamesInput) DeepCopyInto(out *ClusterMetricNamesInput) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]ClusterCondition, len(*in))
-		copy(*out, *in)
-	}
-	if in.AppliedAgentEnvVars != nil {
-		in, out := &in.AppliedAgentEnvVars, &out.AppliedAgentEnvVars
-		*out = make([]corev1.EnvVar, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.AgentFeatures != nil {
-		in, out := &in.AgentFeatures, &out.AgentFeatures
-		*out = make(map[string]bool, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.ComponentStatuses != nil {
-		in, out := &in.ComponentStatuses, &out.ComponentStatuses
-		*out = make([]ClusterComponentStatus, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Capacity != nil {
-		in, out := &in.Capacity, &out.Capacity
-		*out = make(corev1.ResourceList, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val.DeepCopy()
-		}
-	}
-	if in.Allocatable != nil {
-		in, out := &in.Allocatable, &out.Allocatable
-		*out = make(corev1.ResourceList, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val.DeepCopy()
-		}
-	}
-	in.AppliedSpec.DeepCopyInto(&out.AppliedSpec)
-	if in.FailedSpec != nil {
-		in, out := &in.FailedSpec, &out.FailedSpec
-		*out = new(ClusterSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Requested != nil {
-		in, out := &in.Requested, &out.Requested
-		*out = make(corev1.ResourceList, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val.DeepCopy()
-		}
-	}
-	if in.Limits != nil {
-		in, out := &in.Limits, &out.Limits
-		*out = make(corev1.ResourceList, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val.DeepCopy()
-		}
-	}
-	if in.Version != nil {
-		in, out := &in.Version, &out.Version
-		*out = new(version.Info)
-		**out = **in
-	}
-	in.Capabilities.DeepCopyInto(&out.Capabilities)
-	if in.MonitoringStatus != nil {
-		in, out := &in.MonitoringStatus, &out.MonitoringStatus
-		*out = new(MonitoringStatus)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.CertificatesExpiration != nil {
-		in, out := &in.CertificatesExpiration, &out.CertificatesExpiration
-		*out = make(map[string]CertExpiration, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	in.AKSStatus.DeepCopyInto(&out.AKSStatus)
-	in.EKSStatus.DeepCopyInto(&out.EKSStatus)
-	in.GKEStatus.DeepCopyInto(&out.GKEStatus)
-	if in.AppliedClusterAgentDeploymentCustomization != nil {
-		in, out := &in.AppliedClusterAgentDeploymentCustomization, &out.AppliedClusterAgentDeploymentCustomization
-		*out = new(AgentDeploymentCustomization)
-		(*in).DeepCopyInto(*out)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
-func (in *ClusterStatus) DeepCopy() *ClusterStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMetricNamesInput.
+func (in *ClusterMetricNamesInput) DeepCopy() *ClusterMetricNamesInput {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterStatus)
+	out := new(ClusterMetricNamesInput)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterTemplate) DeepCopyInto(out *ClusterTemplate) {
+func (in *ClusterMigration) DeepCopyInto(out *ClusterMigration) {
 	*out = *in
-	out.Namespaced = in.Namespaced
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplate.
-func (in *ClusterTemplate) DeepCopy() *ClusterTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMigration.
+func (in *ClusterMigration) DeepCopy() *ClusterMigration {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterTemplate)
+	out := new(ClusterMigration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterTemplate) DeepCopyObject() runtime.Object {
+func (in *ClusterMigration) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2368,13 +2422,13 @@ func (in *ClusterTemplate) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterTemplateList) DeepCopyInto(out *ClusterTemplateList) {
+func (in *ClusterMigrationList) DeepCopyInto(out *ClusterMigrationList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterTemplate, len(*in))
+		*out = make([]ClusterMigration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -2382,18 +2436,18 @@ func (in *ClusterTemplateList) DeepCopyInto(out *ClusterTemplateList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateList.
-func (in *ClusterTemplateList) DeepCopy() *ClusterTemplateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMigrationList.
+func (in *ClusterMigrationList) DeepCopy() *ClusterMigrationList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterTemplateList)
+	out := new(ClusterMigrationList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterTemplateList) DeepCopyObject() runtime.Object {
+func (in *ClusterMigrationList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2401,345 +2455,272 @@ func (in *ClusterTemplateList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterTemplateQuestionsOutput) DeepCopyInto(out *ClusterTemplateQuestionsOutput) {
+func (in *ClusterMigrationNode) DeepCopyInto(out *ClusterMigrationNode) {
 	*out = *in
-	if in.Questions != nil {
-		in, out := &in.Questions, &out.Questions
-		*out = make([]Question, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateQuestionsOutput.
-func (in *ClusterTemplateQuestionsOutput) DeepCopy() *ClusterTemplateQuestionsOutput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMigrationNode.
+func (in *ClusterMigrationNode) DeepCopy() *ClusterMigrationNode {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterTemplateQuestionsOutput)
+	out := new(ClusterMigrationNode)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterTemplateRevision) DeepCopyInto(out *ClusterTemplateRevision) {
+func (in *ClusterMigrationProject) DeepCopyInto(out *ClusterMigrationProject) {
 	*out = *in
-	out.Namespaced = in.Namespaced
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateRevision.
-func (in *ClusterTemplateRevision) DeepCopy() *ClusterTemplateRevision {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMigrationProject.
+func (in *ClusterMigrationProject) DeepCopy() *ClusterMigrationProject {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterTemplateRevision)
+	out := new(ClusterMigrationProject)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterTemplateRevision) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterTemplateRevisionCondition) DeepCopyInto(out *ClusterTemplateRevisionCondition) {
+func (in *ClusterMigrationSpec) DeepCopyInto(out *ClusterMigrationSpec) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateRevisionCondition.
-func (in *ClusterTemplateRevisionCondition) DeepCopy() *ClusterTemplateRevisionCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMigrationSpec.
+func (in *ClusterMigrationSpec) DeepCopy() *ClusterMigrationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterTemplateRevisionCondition)
+	out := new(ClusterMigrationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterTemplateRevisionList) DeepCopyInto(out *ClusterTemplateRevisionList) {
+func (in *ClusterMigrationStatus) DeepCopyInto(out *ClusterMigrationStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ClusterTemplateRevision, len(*in))
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]ClusterMigrationStep, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateRevisionList.
-func (in *ClusterTemplateRevisionList) DeepCopy() *ClusterTemplateRevisionList {
-	if in == nil {
-		return nil
-	}
-	out := new(ClusterTemplateRevisionList)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterTemplateRevisionList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterTemplateRevisionSpec) DeepCopyInto(out *ClusterTemplateRevisionSpec) {
-	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Questions != nil {
-		in, out := &in.Questions, &out.Questions
-		*out = make([]Question, len(*in))
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]ClusterMigrationNode, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.ClusterConfig != nil {
-		in, out := &in.ClusterConfig, &out.ClusterConfig
-		*out = new(ClusterSpecBase)
-		(*in).DeepCopyInto(*out)
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]ClusterMigrationProject, len(*in))
+		copy(*out, *in)
+	}
+	if in.Apps != nil {
+		in, out := &in.Apps, &out.Apps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateRevisionSpec.
-func (in *ClusterTemplateRevisionSpec) DeepCopy() *ClusterTemplateRevisionSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMigrationStatus.
+func (in *ClusterMigrationStatus) DeepCopy() *ClusterMigrationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterTemplateRevisionSpec)
+	out := new(ClusterMigrationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterTemplateRevisionStatus) DeepCopyInto(out *ClusterTemplateRevisionStatus) {
+func (in *ClusterMigrationStep) DeepCopyInto(out *ClusterMigrationStep) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]ClusterTemplateRevisionCondition, len(*in))
-		copy(*out, *in)
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateRevisionStatus.
-func (in *ClusterTemplateRevisionStatus) DeepCopy() *ClusterTemplateRevisionStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMigrationStep.
+func (in *ClusterMigrationStep) DeepCopy() *ClusterMigrationStep {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterTemplateRevisionStatus)
+	out := new(ClusterMigrationStep)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterTemplateSpec) DeepCopyInto(out *ClusterTemplateSpec) {
+func (in *ClusterMonitorGraph) DeepCopyInto(out *ClusterMonitorGraph) {
 	*out = *in
-	if in.Members != nil {
-		in, out := &in.Members, &out.Members
-		*out = make([]Member, len(*in))
-		copy(*out, *in)
-	}
+	out.Namespaced = in.Namespaced
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateSpec.
-func (in *ClusterTemplateSpec) DeepCopy() *ClusterTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMonitorGraph.
+func (in *ClusterMonitorGraph) DeepCopy() *ClusterMonitorGraph {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterTemplateSpec)
+	out := new(ClusterMonitorGraph)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterMonitorGraph) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterTestInput) DeepCopyInto(out *ClusterTestInput) {
+func (in *ClusterMonitorGraphList) DeepCopyInto(out *ClusterMonitorGraphList) {
 	*out = *in
-	in.LoggingTargets.DeepCopyInto(&out.LoggingTargets)
-	if in.OutputTags != nil {
-		in, out := &in.OutputTags, &out.OutputTags
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterMonitorGraph, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTestInput.
-func (in *ClusterTestInput) DeepCopy() *ClusterTestInput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMonitorGraphList.
+func (in *ClusterMonitorGraphList) DeepCopy() *ClusterMonitorGraphList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterTestInput)
+	out := new(ClusterMonitorGraphList)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterUpgradeStrategy) DeepCopyInto(out *ClusterUpgradeStrategy) {
-	*out = *in
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeStrategy.
-func (in *ClusterUpgradeStrategy) DeepCopy() *ClusterUpgradeStrategy {
-	if in == nil {
-		return nil
-	}
-	out := new(ClusterUpgradeStrategy)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CommonGroupField) DeepCopyInto(out *CommonGroupField) {
-	*out = *in
-	out.TimingField = in.TimingField
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonGroupField.
-func (in *CommonGroupField) DeepCopy() *CommonGroupField {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterMonitorGraphList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(CommonGroupField)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CommonMonitorGraphSpec) DeepCopyInto(out *CommonMonitorGraphSpec) {
+func (in *ClusterMonitorGraphSpec) DeepCopyInto(out *ClusterMonitorGraphSpec) {
 	*out = *in
-	if in.MetricsSelector != nil {
-		in, out := &in.MetricsSelector, &out.MetricsSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.DetailsMetricsSelector != nil {
-		in, out := &in.DetailsMetricsSelector, &out.DetailsMetricsSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	out.YAxis = in.YAxis
+	in.CommonMonitorGraphSpec.DeepCopyInto(&out.CommonMonitorGraphSpec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonMonitorGraphSpec.
-func (in *CommonMonitorGraphSpec) DeepCopy() *CommonMonitorGraphSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMonitorGraphSpec.
+func (in *ClusterMonitorGraphSpec) DeepCopy() *ClusterMonitorGraphSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CommonMonitorGraphSpec)
+	out := new(ClusterMonitorGraphSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CommonQueryMetricInput) DeepCopyInto(out *CommonQueryMetricInput) {
+func (in *ClusterPolicyBundleStatus) DeepCopyInto(out *ClusterPolicyBundleStatus) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonQueryMetricInput.
-func (in *CommonQueryMetricInput) DeepCopy() *CommonQueryMetricInput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPolicyBundleStatus.
+func (in *ClusterPolicyBundleStatus) DeepCopy() *ClusterPolicyBundleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CommonQueryMetricInput)
+	out := new(ClusterPolicyBundleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CommonRuleField) DeepCopyInto(out *CommonRuleField) {
+func (in *ClusterRegistrationCheck) DeepCopyInto(out *ClusterRegistrationCheck) {
 	*out = *in
-	if in.Inherited != nil {
-		in, out := &in.Inherited, &out.Inherited
-		*out = new(bool)
-		**out = **in
-	}
-	out.TimingField = in.TimingField
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonRuleField.
-func (in *CommonRuleField) DeepCopy() *CommonRuleField {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRegistrationCheck.
+func (in *ClusterRegistrationCheck) DeepCopy() *ClusterRegistrationCheck {
 	if in == nil {
 		return nil
 	}
-	out := new(CommonRuleField)
+	out := new(ClusterRegistrationCheck)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComposeCondition) DeepCopyInto(out *ComposeCondition) {
+func (in *ClusterRegistrationProxy) DeepCopyInto(out *ClusterRegistrationProxy) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposeCondition.
-func (in *ComposeCondition) DeepCopy() *ComposeCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRegistrationProxy.
+func (in *ClusterRegistrationProxy) DeepCopy() *ClusterRegistrationProxy {
 	if in == nil {
 		return nil
 	}
-	out := new(ComposeCondition)
+	out := new(ClusterRegistrationProxy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComposeConfig) DeepCopyInto(out *ComposeConfig) {
+func (in *ClusterRegistrationToken) DeepCopyInto(out *ClusterRegistrationToken) {
 	*out = *in
+	out.Namespaced = in.Namespaced
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposeConfig.
-func (in *ComposeConfig) DeepCopy() *ComposeConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRegistrationToken.
+func (in *ClusterRegistrationToken) DeepCopy() *ClusterRegistrationToken {
 	if in == nil {
 		return nil
 	}
-	out := new(ComposeConfig)
+	out := new(ClusterRegistrationToken)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ComposeConfig) DeepCopyObject() runtime.Object {
+func (in *ClusterRegistrationToken) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2747,13 +2728,13 @@ func (in *ComposeConfig) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComposeConfigList) DeepCopyInto(out *ComposeConfigList) {
+func (in *ClusterRegistrationTokenList) DeepCopyInto(out *ClusterRegistrationTokenList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ComposeConfig, len(*in))
+		*out = make([]ClusterRegistrationToken, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -2761,18 +2742,18 @@ func (in *ComposeConfigList) DeepCopyInto(out *ComposeConfigList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposeConfigList.
-func (in *ComposeConfigList) DeepCopy() *ComposeConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRegistrationTokenList.
+func (in *ClusterRegistrationTokenList) DeepCopy() *ClusterRegistrationTokenList {
 	if in == nil {
 		return nil
 	}
-	out := new(ComposeConfigList)
+	out := new(ClusterRegistrationTokenList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ComposeConfigList) DeepCopyObject() runtime.Object {
+func (in *ClusterRegistrationTokenList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2780,201 +2761,216 @@ func (in *ComposeConfigList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComposeSpec) DeepCopyInto(out *ComposeSpec) {
+func (in *ClusterRegistrationTokenSpec) DeepCopyInto(out *ClusterRegistrationTokenSpec) {
 	*out = *in
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ClusterRegistrationProxy)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposeSpec.
-func (in *ComposeSpec) DeepCopy() *ComposeSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRegistrationTokenSpec.
+func (in *ClusterRegistrationTokenSpec) DeepCopy() *ClusterRegistrationTokenSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ComposeSpec)
+	out := new(ClusterRegistrationTokenSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComposeStatus) DeepCopyInto(out *ComposeStatus) {
+func (in *ClusterRegistrationTokenStatus) DeepCopyInto(out *ClusterRegistrationTokenStatus) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]ComposeCondition, len(*in))
-		copy(*out, *in)
+	if in.Validations != nil {
+		in, out := &in.Validations, &out.Validations
+		*out = make([]ClusterRegistrationValidation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposeStatus.
-func (in *ComposeStatus) DeepCopy() *ComposeStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRegistrationTokenStatus.
+func (in *ClusterRegistrationTokenStatus) DeepCopy() *ClusterRegistrationTokenStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ComposeStatus)
+	out := new(ClusterRegistrationTokenStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Condition) DeepCopyInto(out *Condition) {
+func (in *ClusterRegistrationValidation) DeepCopyInto(out *ClusterRegistrationValidation) {
 	*out = *in
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]ClusterRegistrationCheck, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
-func (in *Condition) DeepCopy() *Condition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRegistrationValidation.
+func (in *ClusterRegistrationValidation) DeepCopy() *ClusterRegistrationValidation {
 	if in == nil {
 		return nil
 	}
-	out := new(Condition)
+	out := new(ClusterRegistrationValidation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ContainerResourceLimit) DeepCopyInto(out *ContainerResourceLimit) {
+func (in *ClusterRoleTemplateBinding) DeepCopyInto(out *ClusterRoleTemplateBinding) {
 	*out = *in
+	out.Namespaced = in.Namespaced
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerResourceLimit.
-func (in *ContainerResourceLimit) DeepCopy() *ContainerResourceLimit {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRoleTemplateBinding.
+func (in *ClusterRoleTemplateBinding) DeepCopy() *ClusterRoleTemplateBinding {
 	if in == nil {
 		return nil
 	}
-	out := new(ContainerResourceLimit)
+	out := new(ClusterRoleTemplateBinding)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterRoleTemplateBinding) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CustomConfig) DeepCopyInto(out *CustomConfig) {
+func (in *ClusterRoleTemplateBindingList) DeepCopyInto(out *ClusterRoleTemplateBindingList) {
 	*out = *in
-	if in.Label != nil {
-		in, out := &in.Label, &out.Label
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterRoleTemplateBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Taints != nil {
-		in, out := &in.Taints, &out.Taints
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomConfig.
-func (in *CustomConfig) DeepCopy() *CustomConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRoleTemplateBindingList.
+func (in *ClusterRoleTemplateBindingList) DeepCopy() *ClusterRoleTemplateBindingList {
 	if in == nil {
 		return nil
 	}
-	out := new(CustomConfig)
+	out := new(ClusterRoleTemplateBindingList)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CustomTargetConfig) DeepCopyInto(out *CustomTargetConfig) {
-	*out = *in
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomTargetConfig.
-func (in *CustomTargetConfig) DeepCopy() *CustomTargetConfig {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterRoleTemplateBindingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(CustomTargetConfig)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DingtalkConfig) DeepCopyInto(out *DingtalkConfig) {
+func (in *ClusterScanRule) DeepCopyInto(out *ClusterScanRule) {
 	*out = *in
-	if in.HTTPClientConfig != nil {
-		in, out := &in.HTTPClientConfig, &out.HTTPClientConfig
-		*out = new(HTTPClientConfig)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DingtalkConfig.
-func (in *DingtalkConfig) DeepCopy() *DingtalkConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScanRule.
+func (in *ClusterScanRule) DeepCopy() *ClusterScanRule {
 	if in == nil {
 		return nil
 	}
-	out := new(DingtalkConfig)
+	out := new(ClusterScanRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DockerInfo) DeepCopyInto(out *DockerInfo) {
+func (in *ClusterSecrets) DeepCopyInto(out *ClusterSecrets) {
 	*out = *in
-	if in.SecurityOptions != nil {
-		in, out := &in.SecurityOptions, &out.SecurityOptions
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerInfo.
-func (in *DockerInfo) DeepCopy() *DockerInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSecrets.
+func (in *ClusterSecrets) DeepCopy() *ClusterSecrets {
 	if in == nil {
 		return nil
 	}
-	out := new(DockerInfo)
+	out := new(ClusterSecrets)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DynamicSchema) DeepCopyInto(out *DynamicSchema) {
+func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicSchema.
-func (in *DynamicSchema) DeepCopy() *DynamicSchema {
-	if in == nil {
-		return nil
+	in.ClusterSpecBase.DeepCopyInto(&out.ClusterSpecBase)
+	if in.K3sConfig != nil {
+		in, out := &in.K3sConfig, &out.K3sConfig
+		*out = new(K3sConfig)
+		**out = **in
 	}
-	out := new(DynamicSchema)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DynamicSchema) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+	if in.Rke2Config != nil {
+		in, out := &in.Rke2Config, &out.Rke2Config
+		*out = new(Rke2Config)
+		**out = **in
 	}
-	return nil
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DynamicSchemaList) DeepCopyInto(out *DynamicSchemaList) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]DynamicSchema, len(*in))
+	if in.ImportedConfig != nil {
+		in, out := &in.ImportedConfig, &out.ImportedConfig
+		*out = new(ImportedConfig)
+		**out = **in
+	}
+	if in.GoogleKubernetesEngineConfig != nil {
+		in, out := &in.GoogleKubernetesEngineConfig, &out.GoogleKubernetesEngineConfig
+		*out = (*in).DeepCopy()
+	}
+	if in.AzureKubernetesServiceConfig != nil {
+		in, out := &in.AzureKubernetesServiceConfig, &out.AzureKubernetesServiceConfig
+		*out = (*in).DeepCopy()
+	}
+	if in.AmazonElasticContainerServiceConfig != nil {
+		in, out := &in.AmazonElasticContainerServiceConfig, &out.AmazonElasticContainerServiceConfig
+		*out = (*in).DeepCopy()
+	}
+	if in.GenericEngineConfig != nil {
+		in, out := &in.GenericEngineConfig, &out.GenericEngineConfig
+		*out = (*in).DeepCopy()
+	}
+	if in.AKSConfig != nil {
+		in, out := &in.AKSConfig, &out.AKSConfig
+		*out = new(v1.AKSClusterConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EKSConfig != nil {
+		in, out := &in.EKSConfig, &out.EKSConfig
+		*out = new(ekscattleiov1.EKSClusterConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GKEConfig != nil {
+		in, out := &in.GKEConfig, &out.GKEConfig
+		*out = new(gkecattleiov1.GKEClusterConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.ClusterTemplateAnswers.DeepCopyInto(&out.ClusterTemplateAnswers)
+	if in.ClusterTemplateQuestions != nil {
+		in, out := &in.ClusterTemplateQuestions, &out.ClusterTemplateQuestions
+		*out = make([]Question, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -2982,131 +2978,431 @@ func (in *DynamicSchemaList) DeepCopyInto(out *DynamicSchemaList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicSchemaList.
-func (in *DynamicSchemaList) DeepCopy() *DynamicSchemaList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
+func (in *ClusterSpec) DeepCopy() *ClusterSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DynamicSchemaList)
+	out := new(ClusterSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DynamicSchemaList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSpecBase) DeepCopyInto(out *ClusterSpecBase) {
+	*out = *in
+	if in.AgentEnvVars != nil {
+		in, out := &in.AgentEnvVars, &out.AgentEnvVars
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	return nil
+	if in.RancherKubernetesEngineConfig != nil {
+		in, out := &in.RancherKubernetesEngineConfig, &out.RancherKubernetesEngineConfig
+		*out = new(types.RancherKubernetesEngineConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnableNetworkPolicy != nil {
+		in, out := &in.EnableNetworkPolicy, &out.EnableNetworkPolicy
+		*out = new(bool)
+		**out = **in
+	}
+	out.LocalClusterAuthEndpoint = in.LocalClusterAuthEndpoint
+	out.ClusterSecrets = in.ClusterSecrets
+	if in.ClusterAgentDeploymentCustomization != nil {
+		in, out := &in.ClusterAgentDeploymentCustomization, &out.ClusterAgentDeploymentCustomization
+		*out = new(AgentDeploymentCustomization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FleetAgentDeploymentCustomization != nil {
+		in, out := &in.FleetAgentDeploymentCustomization, &out.FleetAgentDeploymentCustomization
+		*out = new(AgentDeploymentCustomization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WorkloadDefaults != nil {
+		in, out := &in.WorkloadDefaults, &out.WorkloadDefaults
+		*out = new(WorkloadDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WebhookOverrides != nil {
+		in, out := &in.WebhookOverrides, &out.WebhookOverrides
+		*out = new(WebhookOverrides)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpecBase.
+func (in *ClusterSpecBase) DeepCopy() *ClusterSpecBase {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSpecBase)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DynamicSchemaSpec) DeepCopyInto(out *DynamicSchemaSpec) {
+func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 	*out = *in
-	if in.ResourceMethods != nil {
-		in, out := &in.ResourceMethods, &out.ResourceMethods
-		*out = make([]string, len(*in))
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ClusterCondition, len(*in))
 		copy(*out, *in)
 	}
-	if in.ResourceFields != nil {
-		in, out := &in.ResourceFields, &out.ResourceFields
-		*out = make(map[string]Field, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
+	if in.AppliedAgentEnvVars != nil {
+		in, out := &in.AppliedAgentEnvVars, &out.AppliedAgentEnvVars
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.ResourceActions != nil {
-		in, out := &in.ResourceActions, &out.ResourceActions
-		*out = make(map[string]Action, len(*in))
+	if in.AgentFeatures != nil {
+		in, out := &in.AgentFeatures, &out.AgentFeatures
+		*out = make(map[string]bool, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.CollectionMethods != nil {
-		in, out := &in.CollectionMethods, &out.CollectionMethods
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.ComponentStatuses != nil {
+		in, out := &in.ComponentStatuses, &out.ComponentStatuses
+		*out = make([]ClusterComponentStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.CollectionFields != nil {
-		in, out := &in.CollectionFields, &out.CollectionFields
-		*out = make(map[string]Field, len(*in))
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = make(corev1.ResourceList, len(*in))
 		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
+			(*out)[key] = val.DeepCopy()
 		}
 	}
-	if in.CollectionActions != nil {
-		in, out := &in.CollectionActions, &out.CollectionActions
-		*out = make(map[string]Action, len(*in))
+	if in.Allocatable != nil {
+		in, out := &in.Allocatable, &out.Allocatable
+		*out = make(corev1.ResourceList, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = val.DeepCopy()
 		}
 	}
-	if in.CollectionFilters != nil {
-		in, out := &in.CollectionFilters, &out.CollectionFilters
-		*out = make(map[string]Filter, len(*in))
+	in.AppliedSpec.DeepCopyInto(&out.AppliedSpec)
+	if in.FailedSpec != nil {
+		in, out := &in.FailedSpec, &out.FailedSpec
+		*out = new(ClusterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Requested != nil {
+		in, out := &in.Requested, &out.Requested
+		*out = make(corev1.ResourceList, len(*in))
 		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
+			(*out)[key] = val.DeepCopy()
 		}
 	}
-	if in.IncludeableLinks != nil {
-		in, out := &in.IncludeableLinks, &out.IncludeableLinks
-		*out = make([]string, len(*in))
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(version.Info)
+		**out = **in
+	}
+	in.Capabilities.DeepCopyInto(&out.Capabilities)
+	if in.MonitoringStatus != nil {
+		in, out := &in.MonitoringStatus, &out.MonitoringStatus
+		*out = new(MonitoringStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertificatesExpiration != nil {
+		in, out := &in.CertificatesExpiration, &out.CertificatesExpiration
+		*out = make(map[string]CertExpiration, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.AKSStatus.DeepCopyInto(&out.AKSStatus)
+	in.EKSStatus.DeepCopyInto(&out.EKSStatus)
+	in.GKEStatus.DeepCopyInto(&out.GKEStatus)
+	if in.AppliedClusterAgentDeploymentCustomization != nil {
+		in, out := &in.AppliedClusterAgentDeploymentCustomization, &out.AppliedClusterAgentDeploymentCustomization
+		*out = new(AgentDeploymentCustomization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControlPlaneUsage != nil {
+		in, out := &in.ControlPlaneUsage, &out.ControlPlaneUsage
+		*out = new(ControlPlaneUsage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PolicyBundles != nil {
+		in, out := &in.PolicyBundles, &out.PolicyBundles
+		*out = make([]ClusterPolicyBundleStatus, len(*in))
 		copy(*out, *in)
 	}
+	if in.CircuitBreaker != nil {
+		in, out := &in.CircuitBreaker, &out.CircuitBreaker
+		*out = new(ClusterCircuitBreaker)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicSchemaSpec.
-func (in *DynamicSchemaSpec) DeepCopy() *DynamicSchemaSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
+func (in *ClusterStatus) DeepCopy() *ClusterStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DynamicSchemaSpec)
+	out := new(ClusterStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DynamicSchemaStatus) DeepCopyInto(out *DynamicSchemaStatus) {
+func (in *ClusterTemplate) DeepCopyInto(out *ClusterTemplate) {
 	*out = *in
+	out.Namespaced = in.Namespaced
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicSchemaStatus.
-func (in *DynamicSchemaStatus) DeepCopy() *DynamicSchemaStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplate.
+func (in *ClusterTemplate) DeepCopy() *ClusterTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(DynamicSchemaStatus)
+	out := new(ClusterTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EKSStatus) DeepCopyInto(out *EKSStatus) {
+func (in *ClusterTemplateList) DeepCopyInto(out *ClusterTemplateList) {
 	*out = *in
-	if in.UpstreamSpec != nil {
-		in, out := &in.UpstreamSpec, &out.UpstreamSpec
-		*out = new(ekscattleiov1.EKSClusterConfigSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Subnets != nil {
-		in, out := &in.Subnets, &out.Subnets
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.SecurityGroups != nil {
-		in, out := &in.SecurityGroups, &out.SecurityGroups
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.PrivateRequiresTunnel != nil {
-		in, out := &in.PrivateRequiresTunnel, &out.PrivateRequiresTunnel
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateList.
+func (in *ClusterTemplateList) DeepCopy() *ClusterTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTemplateQuestionsOutput) DeepCopyInto(out *ClusterTemplateQuestionsOutput) {
+	*out = *in
+	if in.Questions != nil {
+		in, out := &in.Questions, &out.Questions
+		*out = make([]Question, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateQuestionsOutput.
+func (in *ClusterTemplateQuestionsOutput) DeepCopy() *ClusterTemplateQuestionsOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplateQuestionsOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTemplateRevision) DeepCopyInto(out *ClusterTemplateRevision) {
+	*out = *in
+	out.Namespaced = in.Namespaced
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateRevision.
+func (in *ClusterTemplateRevision) DeepCopy() *ClusterTemplateRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplateRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterTemplateRevision) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTemplateRevisionCondition) DeepCopyInto(out *ClusterTemplateRevisionCondition) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateRevisionCondition.
+func (in *ClusterTemplateRevisionCondition) DeepCopy() *ClusterTemplateRevisionCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplateRevisionCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTemplateRevisionList) DeepCopyInto(out *ClusterTemplateRevisionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterTemplateRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateRevisionList.
+func (in *ClusterTemplateRevisionList) DeepCopy() *ClusterTemplateRevisionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplateRevisionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterTemplateRevisionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTemplateRevisionSpec) DeepCopyInto(out *ClusterTemplateRevisionSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
 		**out = **in
 	}
-	if in.ManagedLaunchTemplateVersions != nil {
-		in, out := &in.ManagedLaunchTemplateVersions, &out.ManagedLaunchTemplateVersions
+	if in.Questions != nil {
+		in, out := &in.Questions, &out.Questions
+		*out = make([]Question, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClusterConfig != nil {
+		in, out := &in.ClusterConfig, &out.ClusterConfig
+		*out = new(ClusterSpecBase)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateRevisionSpec.
+func (in *ClusterTemplateRevisionSpec) DeepCopy() *ClusterTemplateRevisionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplateRevisionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTemplateRevisionStatus) DeepCopyInto(out *ClusterTemplateRevisionStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ClusterTemplateRevisionCondition, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateRevisionStatus.
+func (in *ClusterTemplateRevisionStatus) DeepCopy() *ClusterTemplateRevisionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplateRevisionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTemplateSpec) DeepCopyInto(out *ClusterTemplateSpec) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]Member, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateSpec.
+func (in *ClusterTemplateSpec) DeepCopy() *ClusterTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTestInput) DeepCopyInto(out *ClusterTestInput) {
+	*out = *in
+	in.LoggingTargets.DeepCopyInto(&out.LoggingTargets)
+	if in.OutputTags != nil {
+		in, out := &in.OutputTags, &out.OutputTags
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
@@ -3115,55 +3411,1739 @@ func (in *EKSStatus) DeepCopyInto(out *EKSStatus) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EKSStatus.
-func (in *EKSStatus) DeepCopy() *EKSStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTestInput.
+func (in *ClusterTestInput) DeepCopy() *ClusterTestInput {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTestInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeStrategy) DeepCopyInto(out *ClusterUpgradeStrategy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeStrategy.
+func (in *ClusterUpgradeStrategy) DeepCopy() *ClusterUpgradeStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradeStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommonGroupField) DeepCopyInto(out *CommonGroupField) {
+	*out = *in
+	out.TimingField = in.TimingField
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonGroupField.
+func (in *CommonGroupField) DeepCopy() *CommonGroupField {
+	if in == nil {
+		return nil
+	}
+	out := new(CommonGroupField)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommonMonitorGraphSpec) DeepCopyInto(out *CommonMonitorGraphSpec) {
+	*out = *in
+	if in.MetricsSelector != nil {
+		in, out := &in.MetricsSelector, &out.MetricsSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DetailsMetricsSelector != nil {
+		in, out := &in.DetailsMetricsSelector, &out.DetailsMetricsSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	out.YAxis = in.YAxis
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonMonitorGraphSpec.
+func (in *CommonMonitorGraphSpec) DeepCopy() *CommonMonitorGraphSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CommonMonitorGraphSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommonQueryMetricInput) DeepCopyInto(out *CommonQueryMetricInput) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonQueryMetricInput.
+func (in *CommonQueryMetricInput) DeepCopy() *CommonQueryMetricInput {
+	if in == nil {
+		return nil
+	}
+	out := new(CommonQueryMetricInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommonRuleField) DeepCopyInto(out *CommonRuleField) {
+	*out = *in
+	if in.Inherited != nil {
+		in, out := &in.Inherited, &out.Inherited
+		*out = new(bool)
+		**out = **in
+	}
+	out.TimingField = in.TimingField
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonRuleField.
+func (in *CommonRuleField) DeepCopy() *CommonRuleField {
+	if in == nil {
+		return nil
+	}
+	out := new(CommonRuleField)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComposeCondition) DeepCopyInto(out *ComposeCondition) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposeCondition.
+func (in *ComposeCondition) DeepCopy() *ComposeCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ComposeCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComposeConfig) DeepCopyInto(out *ComposeConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposeConfig.
+func (in *ComposeConfig) DeepCopy() *ComposeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ComposeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ComposeConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComposeConfigList) DeepCopyInto(out *ComposeConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ComposeConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposeConfigList.
+func (in *ComposeConfigList) DeepCopy() *ComposeConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(ComposeConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ComposeConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComposeSpec) DeepCopyInto(out *ComposeSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposeSpec.
+func (in *ComposeSpec) DeepCopy() *ComposeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComposeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComposeStatus) DeepCopyInto(out *ComposeStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ComposeCondition, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposeStatus.
+func (in *ComposeStatus) DeepCopy() *ComposeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComposeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Condition) DeepCopyInto(out *Condition) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
+	}
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerResourceLimit) DeepCopyInto(out *ContainerResourceLimit) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerResourceLimit.
+func (in *ContainerResourceLimit) DeepCopy() *ContainerResourceLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerResourceLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneNodeUsage) DeepCopyInto(out *ControlPlaneNodeUsage) {
+	*out = *in
+	if in.Allocatable != nil {
+		in, out := &in.Allocatable, &out.Allocatable
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.APIServer != nil {
+		in, out := &in.APIServer, &out.APIServer
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Etcd != nil {
+		in, out := &in.Etcd, &out.Etcd
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneNodeUsage.
+func (in *ControlPlaneNodeUsage) DeepCopy() *ControlPlaneNodeUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneNodeUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneUsage) DeepCopyInto(out *ControlPlaneUsage) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]ControlPlaneNodeUsage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneUsage.
+func (in *ControlPlaneUsage) DeepCopy() *ControlPlaneUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomConfig) DeepCopyInto(out *CustomConfig) {
+	*out = *in
+	if in.Label != nil {
+		in, out := &in.Label, &out.Label
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomConfig.
+func (in *CustomConfig) DeepCopy() *CustomConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomTargetConfig) DeepCopyInto(out *CustomTargetConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomTargetConfig.
+func (in *CustomTargetConfig) DeepCopy() *CustomTargetConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomTargetConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultRuntimeClass) DeepCopyInto(out *DefaultRuntimeClass) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultRuntimeClass.
+func (in *DefaultRuntimeClass) DeepCopy() *DefaultRuntimeClass {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultRuntimeClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DingtalkConfig) DeepCopyInto(out *DingtalkConfig) {
+	*out = *in
+	if in.HTTPClientConfig != nil {
+		in, out := &in.HTTPClientConfig, &out.HTTPClientConfig
+		*out = new(HTTPClientConfig)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DingtalkConfig.
+func (in *DingtalkConfig) DeepCopy() *DingtalkConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DingtalkConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DockerInfo) DeepCopyInto(out *DockerInfo) {
+	*out = *in
+	if in.SecurityOptions != nil {
+		in, out := &in.SecurityOptions, &out.SecurityOptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerInfo.
+func (in *DockerInfo) DeepCopy() *DockerInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(DockerInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynamicSchema) DeepCopyInto(out *DynamicSchema) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicSchema.
+func (in *DynamicSchema) DeepCopy() *DynamicSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(DynamicSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DynamicSchema) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynamicSchemaList) DeepCopyInto(out *DynamicSchemaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DynamicSchema, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicSchemaList.
+func (in *DynamicSchemaList) DeepCopy() *DynamicSchemaList {
+	if in == nil {
+		return nil
+	}
+	out := new(DynamicSchemaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DynamicSchemaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynamicSchemaSpec) DeepCopyInto(out *DynamicSchemaSpec) {
+	*out = *in
+	if in.ResourceMethods != nil {
+		in, out := &in.ResourceMethods, &out.ResourceMethods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceFields != nil {
+		in, out := &in.ResourceFields, &out.ResourceFields
+		*out = make(map[string]Field, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.ResourceActions != nil {
+		in, out := &in.ResourceActions, &out.ResourceActions
+		*out = make(map[string]Action, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CollectionMethods != nil {
+		in, out := &in.CollectionMethods, &out.CollectionMethods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CollectionFields != nil {
+		in, out := &in.CollectionFields, &out.CollectionFields
+		*out = make(map[string]Field, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.CollectionActions != nil {
+		in, out := &in.CollectionActions, &out.CollectionActions
+		*out = make(map[string]Action, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CollectionFilters != nil {
+		in, out := &in.CollectionFilters, &out.CollectionFilters
+		*out = make(map[string]Filter, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.IncludeableLinks != nil {
+		in, out := &in.IncludeableLinks, &out.IncludeableLinks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicSchemaSpec.
+func (in *DynamicSchemaSpec) DeepCopy() *DynamicSchemaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DynamicSchemaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynamicSchemaStatus) DeepCopyInto(out *DynamicSchemaStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicSchemaStatus.
+func (in *DynamicSchemaStatus) DeepCopy() *DynamicSchemaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DynamicSchemaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EKSStatus) DeepCopyInto(out *EKSStatus) {
+	*out = *in
+	if in.UpstreamSpec != nil {
+		in, out := &in.UpstreamSpec, &out.UpstreamSpec
+		*out = new(ekscattleiov1.EKSClusterConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecurityGroups != nil {
+		in, out := &in.SecurityGroups, &out.SecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrivateRequiresTunnel != nil {
+		in, out := &in.PrivateRequiresTunnel, &out.PrivateRequiresTunnel
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ManagedLaunchTemplateVersions != nil {
+		in, out := &in.ManagedLaunchTemplateVersions, &out.ManagedLaunchTemplateVersions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EKSStatus.
+func (in *EKSStatus) DeepCopy() *EKSStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EKSStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchConfig) DeepCopyInto(out *ElasticsearchConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchConfig.
+func (in *ElasticsearchConfig) DeepCopy() *ElasticsearchConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdBackup) DeepCopyInto(out *EtcdBackup) {
+	*out = *in
+	out.Namespaced = in.Namespaced
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackup.
+func (in *EtcdBackup) DeepCopy() *EtcdBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EtcdBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdBackupList) DeepCopyInto(out *EtcdBackupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EtcdBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupList.
+func (in *EtcdBackupList) DeepCopy() *EtcdBackupList {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EtcdBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventRule) DeepCopyInto(out *EventRule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventRule.
+func (in *EventRule) DeepCopy() *EventRule {
+	if in == nil {
+		return nil
+	}
+	out := new(EventRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventSubscription) DeepCopyInto(out *EventSubscription) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventSubscription.
+func (in *EventSubscription) DeepCopy() *EventSubscription {
+	if in == nil {
+		return nil
+	}
+	out := new(EventSubscription)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EventSubscription) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventSubscriptionList) DeepCopyInto(out *EventSubscriptionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EventSubscription, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventSubscriptionList.
+func (in *EventSubscriptionList) DeepCopy() *EventSubscriptionList {
+	if in == nil {
+		return nil
+	}
+	out := new(EventSubscriptionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EventSubscriptionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventSubscriptionSpec) DeepCopyInto(out *EventSubscriptionSpec) {
+	*out = *in
+	if in.EventTypes != nil {
+		in, out := &in.EventTypes, &out.EventTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterNames != nil {
+		in, out := &in.ClusterNames, &out.ClusterNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventSubscriptionSpec.
+func (in *EventSubscriptionSpec) DeepCopy() *EventSubscriptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EventSubscriptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventSubscriptionStatus) DeepCopyInto(out *EventSubscriptionStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventSubscriptionStatus.
+func (in *EventSubscriptionStatus) DeepCopy() *EventSubscriptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EventSubscriptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExportOutput) DeepCopyInto(out *ExportOutput) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExportOutput.
+func (in *ExportOutput) DeepCopy() *ExportOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(ExportOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Feature) DeepCopyInto(out *Feature) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Feature.
+func (in *Feature) DeepCopy() *Feature {
+	if in == nil {
+		return nil
+	}
+	out := new(Feature)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Feature) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeatureList) DeepCopyInto(out *FeatureList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Feature, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureList.
+func (in *FeatureList) DeepCopy() *FeatureList {
+	if in == nil {
+		return nil
+	}
+	out := new(FeatureList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FeatureList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeatureSpec) DeepCopyInto(out *FeatureSpec) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureSpec.
+func (in *FeatureSpec) DeepCopy() *FeatureSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FeatureSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeatureStatus) DeepCopyInto(out *FeatureStatus) {
+	*out = *in
+	if in.LockedValue != nil {
+		in, out := &in.LockedValue, &out.LockedValue
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureStatus.
+func (in *FeatureStatus) DeepCopy() *FeatureStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FeatureStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Field) DeepCopyInto(out *Field) {
+	*out = *in
+	in.Default.DeepCopyInto(&out.Default)
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Field.
+func (in *Field) DeepCopy() *Field {
+	if in == nil {
+		return nil
+	}
+	out := new(Field)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *File) DeepCopyInto(out *File) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new File.
+func (in *File) DeepCopy() *File {
+	if in == nil {
+		return nil
+	}
+	out := new(File)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Filter) DeepCopyInto(out *Filter) {
+	*out = *in
+	if in.Modifiers != nil {
+		in, out := &in.Modifiers, &out.Modifiers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Filter.
+func (in *Filter) DeepCopy() *Filter {
+	if in == nil {
+		return nil
+	}
+	out := new(Filter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetWorkspace) DeepCopyInto(out *FleetWorkspace) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetWorkspace.
+func (in *FleetWorkspace) DeepCopy() *FleetWorkspace {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetWorkspace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FleetWorkspace) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetWorkspaceList) DeepCopyInto(out *FleetWorkspaceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FleetWorkspace, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetWorkspaceList.
+func (in *FleetWorkspaceList) DeepCopy() *FleetWorkspaceList {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetWorkspaceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FleetWorkspaceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetWorkspaceStatus) DeepCopyInto(out *FleetWorkspaceStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetWorkspaceStatus.
+func (in *FleetWorkspaceStatus) DeepCopy() *FleetWorkspaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetWorkspaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluentForwarderConfig) DeepCopyInto(out *FluentForwarderConfig) {
+	*out = *in
+	if in.Compress != nil {
+		in, out := &in.Compress, &out.Compress
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FluentServers != nil {
+		in, out := &in.FluentServers, &out.FluentServers
+		*out = make([]FluentServer, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentForwarderConfig.
+func (in *FluentForwarderConfig) DeepCopy() *FluentForwarderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FluentForwarderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FluentServer) DeepCopyInto(out *FluentServer) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentServer.
+func (in *FluentServer) DeepCopy() *FluentServer {
+	if in == nil {
+		return nil
+	}
+	out := new(FluentServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreeIpaConfig) DeepCopyInto(out *FreeIpaConfig) {
+	*out = *in
+	in.LdapConfig.DeepCopyInto(&out.LdapConfig)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreeIpaConfig.
+func (in *FreeIpaConfig) DeepCopy() *FreeIpaConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FreeIpaConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreeIpaProvider) DeepCopyInto(out *FreeIpaProvider) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.AuthProvider.DeepCopyInto(&out.AuthProvider)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreeIpaProvider.
+func (in *FreeIpaProvider) DeepCopy() *FreeIpaProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(FreeIpaProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FreeIpaProvider) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreeIpaProviderList) DeepCopyInto(out *FreeIpaProviderList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FreeIpaProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreeIpaProviderList.
+func (in *FreeIpaProviderList) DeepCopy() *FreeIpaProviderList {
+	if in == nil {
+		return nil
+	}
+	out := new(FreeIpaProviderList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FreeIpaProviderList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreeIpaTestAndApplyInput) DeepCopyInto(out *FreeIpaTestAndApplyInput) {
+	*out = *in
+	in.LdapTestAndApplyInput.DeepCopyInto(&out.LdapTestAndApplyInput)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreeIpaTestAndApplyInput.
+func (in *FreeIpaTestAndApplyInput) DeepCopy() *FreeIpaTestAndApplyInput {
+	if in == nil {
+		return nil
+	}
+	out := new(FreeIpaTestAndApplyInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GKEStatus) DeepCopyInto(out *GKEStatus) {
+	*out = *in
+	if in.UpstreamSpec != nil {
+		in, out := &in.UpstreamSpec, &out.UpstreamSpec
+		*out = new(gkecattleiov1.GKEClusterConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrivateRequiresTunnel != nil {
+		in, out := &in.PrivateRequiresTunnel, &out.PrivateRequiresTunnel
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKEStatus.
+func (in *GKEStatus) DeepCopy() *GKEStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GKEStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenerateKubeConfigOutput) DeepCopyInto(out *GenerateKubeConfigOutput) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenerateKubeConfigOutput.
+func (in *GenerateKubeConfigOutput) DeepCopy() *GenerateKubeConfigOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(GenerateKubeConfigOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenericLogin) DeepCopyInto(out *GenericLogin) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericLogin.
+func (in *GenericLogin) DeepCopy() *GenericLogin {
+	if in == nil {
+		return nil
+	}
+	out := new(GenericLogin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GithubConfig) DeepCopyInto(out *GithubConfig) {
+	*out = *in
+	in.AuthConfig.DeepCopyInto(&out.AuthConfig)
+	if in.AdditionalClientIDs != nil {
+		in, out := &in.AdditionalClientIDs, &out.AdditionalClientIDs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.HostnameToClientID != nil {
+		in, out := &in.HostnameToClientID, &out.HostnameToClientID
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GithubConfig.
+func (in *GithubConfig) DeepCopy() *GithubConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GithubConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GithubConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GithubConfigApplyInput) DeepCopyInto(out *GithubConfigApplyInput) {
+	*out = *in
+	in.GithubConfig.DeepCopyInto(&out.GithubConfig)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GithubConfigApplyInput.
+func (in *GithubConfigApplyInput) DeepCopy() *GithubConfigApplyInput {
+	if in == nil {
+		return nil
+	}
+	out := new(GithubConfigApplyInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GithubConfigList) DeepCopyInto(out *GithubConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GithubConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GithubConfigList.
+func (in *GithubConfigList) DeepCopy() *GithubConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(GithubConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GithubConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GithubConfigTestOutput) DeepCopyInto(out *GithubConfigTestOutput) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GithubConfigTestOutput.
+func (in *GithubConfigTestOutput) DeepCopy() *GithubConfigTestOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(GithubConfigTestOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GithubLogin) DeepCopyInto(out *GithubLogin) {
+	*out = *in
+	out.GenericLogin = in.GenericLogin
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GithubLogin.
+func (in *GithubLogin) DeepCopy() *GithubLogin {
+	if in == nil {
+		return nil
+	}
+	out := new(GithubLogin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GithubProvider) DeepCopyInto(out *GithubProvider) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.AuthProvider.DeepCopyInto(&out.AuthProvider)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GithubProvider.
+func (in *GithubProvider) DeepCopy() *GithubProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(GithubProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GithubProvider) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GithubProviderList) DeepCopyInto(out *GithubProviderList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GithubProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GithubProviderList.
+func (in *GithubProviderList) DeepCopy() *GithubProviderList {
+	if in == nil {
+		return nil
+	}
+	out := new(GithubProviderList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GithubProviderList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalDNSProviderSpec) DeepCopyInto(out *GlobalDNSProviderSpec) {
+	*out = *in
+	if in.Route53ProviderConfig != nil {
+		in, out := &in.Route53ProviderConfig, &out.Route53ProviderConfig
+		*out = new(Route53ProviderConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloudflareProviderConfig != nil {
+		in, out := &in.CloudflareProviderConfig, &out.CloudflareProviderConfig
+		*out = new(CloudflareProviderConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AlidnsProviderConfig != nil {
+		in, out := &in.AlidnsProviderConfig, &out.AlidnsProviderConfig
+		*out = new(AlidnsProviderConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]Member, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalDNSProviderSpec.
+func (in *GlobalDNSProviderSpec) DeepCopy() *GlobalDNSProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalDNSProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalDNSSpec) DeepCopyInto(out *GlobalDNSSpec) {
+	*out = *in
+	if in.ProjectNames != nil {
+		in, out := &in.ProjectNames, &out.ProjectNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]Member, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalDNSSpec.
+func (in *GlobalDNSSpec) DeepCopy() *GlobalDNSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalDNSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalDNSStatus) DeepCopyInto(out *GlobalDNSStatus) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterEndpoints != nil {
+		in, out := &in.ClusterEndpoints, &out.ClusterEndpoints
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalDNSStatus.
+func (in *GlobalDNSStatus) DeepCopy() *GlobalDNSStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalDNSStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalDns) DeepCopyInto(out *GlobalDns) {
+	*out = *in
+	out.Namespaced = in.Namespaced
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalDns.
+func (in *GlobalDns) DeepCopy() *GlobalDns {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalDns)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GlobalDns) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalDnsList) DeepCopyInto(out *GlobalDnsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GlobalDns, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalDnsList.
+func (in *GlobalDnsList) DeepCopy() *GlobalDnsList {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalDnsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GlobalDnsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalDnsProvider) DeepCopyInto(out *GlobalDnsProvider) {
+	*out = *in
+	out.Namespaced = in.Namespaced
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalDnsProvider.
+func (in *GlobalDnsProvider) DeepCopy() *GlobalDnsProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalDnsProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GlobalDnsProvider) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalDnsProviderList) DeepCopyInto(out *GlobalDnsProviderList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GlobalDnsProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalDnsProviderList.
+func (in *GlobalDnsProviderList) DeepCopy() *GlobalDnsProviderList {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalDnsProviderList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GlobalDnsProviderList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalRole) DeepCopyInto(out *GlobalRole) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]rbacv1.PolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalRole.
+func (in *GlobalRole) DeepCopy() *GlobalRole {
 	if in == nil {
 		return nil
 	}
-	out := new(EKSStatus)
+	out := new(GlobalRole)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchConfig) DeepCopyInto(out *ElasticsearchConfig) {
-	*out = *in
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchConfig.
-func (in *ElasticsearchConfig) DeepCopy() *ElasticsearchConfig {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GlobalRole) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(ElasticsearchConfig)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EtcdBackup) DeepCopyInto(out *EtcdBackup) {
+func (in *GlobalRoleBinding) DeepCopyInto(out *GlobalRoleBinding) {
 	*out = *in
-	out.Namespaced = in.Namespaced
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackup.
-func (in *EtcdBackup) DeepCopy() *EtcdBackup {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalRoleBinding.
+func (in *GlobalRoleBinding) DeepCopy() *GlobalRoleBinding {
 	if in == nil {
 		return nil
 	}
-	out := new(EtcdBackup)
+	out := new(GlobalRoleBinding)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *EtcdBackup) DeepCopyObject() runtime.Object {
+func (in *GlobalRoleBinding) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -3171,13 +5151,13 @@ func (in *EtcdBackup) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EtcdBackupList) DeepCopyInto(out *EtcdBackupList) {
+func (in *GlobalRoleBindingList) DeepCopyInto(out *GlobalRoleBindingList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]EtcdBackup, len(*in))
+		*out = make([]GlobalRoleBinding, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -3185,18 +5165,18 @@ func (in *EtcdBackupList) DeepCopyInto(out *EtcdBackupList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdBackupList.
-func (in *EtcdBackupList) DeepCopy() *EtcdBackupList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalRoleBindingList.
+func (in *GlobalRoleBindingList) DeepCopy() *GlobalRoleBindingList {
 	if in == nil {
 		return nil
 	}
-	out := new(EtcdBackupList)
+	out := new(GlobalRoleBindingList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *EtcdBackupList) DeepCopyObject() runtime.Object {
+func (in *GlobalRoleBindingList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -3204,59 +5184,59 @@ func (in *EtcdBackupList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EventRule) DeepCopyInto(out *EventRule) {
+func (in *GlobalRoleList) DeepCopyInto(out *GlobalRoleList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GlobalRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventRule.
-func (in *EventRule) DeepCopy() *EventRule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalRoleList.
+func (in *GlobalRoleList) DeepCopy() *GlobalRoleList {
 	if in == nil {
 		return nil
 	}
-	out := new(EventRule)
+	out := new(GlobalRoleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExportOutput) DeepCopyInto(out *ExportOutput) {
-	*out = *in
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExportOutput.
-func (in *ExportOutput) DeepCopy() *ExportOutput {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GlobalRoleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(ExportOutput)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Feature) DeepCopyInto(out *Feature) {
+func (in *GoogleOAuthProvider) DeepCopyInto(out *GoogleOAuthProvider) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.AuthProvider.DeepCopyInto(&out.AuthProvider)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Feature.
-func (in *Feature) DeepCopy() *Feature {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoogleOAuthProvider.
+func (in *GoogleOAuthProvider) DeepCopy() *GoogleOAuthProvider {
 	if in == nil {
 		return nil
 	}
-	out := new(Feature)
+	out := new(GoogleOAuthProvider)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Feature) DeepCopyObject() runtime.Object {
+func (in *GoogleOAuthProvider) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -3264,13 +5244,13 @@ func (in *Feature) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FeatureList) DeepCopyInto(out *FeatureList) {
+func (in *GoogleOAuthProviderList) DeepCopyInto(out *GoogleOAuthProviderList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Feature, len(*in))
+		*out = make([]GoogleOAuthProvider, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -3278,18 +5258,18 @@ func (in *FeatureList) DeepCopyInto(out *FeatureList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureList.
-func (in *FeatureList) DeepCopy() *FeatureList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoogleOAuthProviderList.
+func (in *GoogleOAuthProviderList) DeepCopy() *GoogleOAuthProviderList {
 	if in == nil {
 		return nil
 	}
-	out := new(FeatureList)
+	out := new(GoogleOAuthProviderList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *FeatureList) DeepCopyObject() runtime.Object {
+func (in *GoogleOAuthProviderList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -3297,127 +5277,133 @@ func (in *FeatureList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FeatureSpec) DeepCopyInto(out *FeatureSpec) {
+func (in *GoogleOauthConfig) DeepCopyInto(out *GoogleOauthConfig) {
 	*out = *in
-	if in.Value != nil {
-		in, out := &in.Value, &out.Value
-		*out = new(bool)
-		**out = **in
-	}
+	in.AuthConfig.DeepCopyInto(&out.AuthConfig)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureSpec.
-func (in *FeatureSpec) DeepCopy() *FeatureSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoogleOauthConfig.
+func (in *GoogleOauthConfig) DeepCopy() *GoogleOauthConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(FeatureSpec)
+	out := new(GoogleOauthConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GoogleOauthConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FeatureStatus) DeepCopyInto(out *FeatureStatus) {
+func (in *GoogleOauthConfigApplyInput) DeepCopyInto(out *GoogleOauthConfigApplyInput) {
 	*out = *in
-	if in.LockedValue != nil {
-		in, out := &in.LockedValue, &out.LockedValue
-		*out = new(bool)
-		**out = **in
-	}
+	in.GoogleOauthConfig.DeepCopyInto(&out.GoogleOauthConfig)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureStatus.
-func (in *FeatureStatus) DeepCopy() *FeatureStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoogleOauthConfigApplyInput.
+func (in *GoogleOauthConfigApplyInput) DeepCopy() *GoogleOauthConfigApplyInput {
 	if in == nil {
 		return nil
 	}
-	out := new(FeatureStatus)
+	out := new(GoogleOauthConfigApplyInput)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Field) DeepCopyInto(out *Field) {
+func (in *GoogleOauthConfigList) DeepCopyInto(out *GoogleOauthConfigList) {
 	*out = *in
-	in.Default.DeepCopyInto(&out.Default)
-	if in.Options != nil {
-		in, out := &in.Options, &out.Options
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GoogleOauthConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Field.
-func (in *Field) DeepCopy() *Field {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoogleOauthConfigList.
+func (in *GoogleOauthConfigList) DeepCopy() *GoogleOauthConfigList {
 	if in == nil {
 		return nil
 	}
-	out := new(Field)
+	out := new(GoogleOauthConfigList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GoogleOauthConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *File) DeepCopyInto(out *File) {
+func (in *GoogleOauthConfigTestOutput) DeepCopyInto(out *GoogleOauthConfigTestOutput) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new File.
-func (in *File) DeepCopy() *File {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoogleOauthConfigTestOutput.
+func (in *GoogleOauthConfigTestOutput) DeepCopy() *GoogleOauthConfigTestOutput {
 	if in == nil {
 		return nil
 	}
-	out := new(File)
+	out := new(GoogleOauthConfigTestOutput)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Filter) DeepCopyInto(out *Filter) {
+func (in *GoogleOauthLogin) DeepCopyInto(out *GoogleOauthLogin) {
 	*out = *in
-	if in.Modifiers != nil {
-		in, out := &in.Modifiers, &out.Modifiers
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	out.GenericLogin = in.GenericLogin
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Filter.
-func (in *Filter) DeepCopy() *Filter {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoogleOauthLogin.
+func (in *GoogleOauthLogin) DeepCopy() *GoogleOauthLogin {
 	if in == nil {
 		return nil
 	}
-	out := new(Filter)
+	out := new(GoogleOauthLogin)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FleetWorkspace) DeepCopyInto(out *FleetWorkspace) {
+func (in *Group) DeepCopyInto(out *Group) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Status = in.Status
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetWorkspace.
-func (in *FleetWorkspace) DeepCopy() *FleetWorkspace {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Group.
+func (in *Group) DeepCopy() *Group {
 	if in == nil {
 		return nil
 	}
-	out := new(FleetWorkspace)
+	out := new(Group)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *FleetWorkspace) DeepCopyObject() runtime.Object {
+func (in *Group) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -3425,13 +5411,13 @@ func (in *FleetWorkspace) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FleetWorkspaceList) DeepCopyInto(out *FleetWorkspaceList) {
+func (in *GroupList) DeepCopyInto(out *GroupList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]FleetWorkspace, len(*in))
+		*out = make([]Group, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -3439,18 +5425,18 @@ func (in *FleetWorkspaceList) DeepCopyInto(out *FleetWorkspaceList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetWorkspaceList.
-func (in *FleetWorkspaceList) DeepCopy() *FleetWorkspaceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupList.
+func (in *GroupList) DeepCopy() *GroupList {
 	if in == nil {
 		return nil
 	}
-	out := new(FleetWorkspaceList)
+	out := new(GroupList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *FleetWorkspaceList) DeepCopyObject() runtime.Object {
+func (in *GroupList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -3458,279 +5444,245 @@ func (in *FleetWorkspaceList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FleetWorkspaceStatus) DeepCopyInto(out *FleetWorkspaceStatus) {
+func (in *GroupMember) DeepCopyInto(out *GroupMember) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetWorkspaceStatus.
-func (in *FleetWorkspaceStatus) DeepCopy() *FleetWorkspaceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupMember.
+func (in *GroupMember) DeepCopy() *GroupMember {
 	if in == nil {
 		return nil
 	}
-	out := new(FleetWorkspaceStatus)
+	out := new(GroupMember)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GroupMember) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FluentForwarderConfig) DeepCopyInto(out *FluentForwarderConfig) {
+func (in *GroupMemberList) DeepCopyInto(out *GroupMemberList) {
 	*out = *in
-	if in.Compress != nil {
-		in, out := &in.Compress, &out.Compress
-		*out = new(bool)
-		**out = **in
-	}
-	if in.FluentServers != nil {
-		in, out := &in.FluentServers, &out.FluentServers
-		*out = make([]FluentServer, len(*in))
-		copy(*out, *in)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GroupMember, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentForwarderConfig.
-func (in *FluentForwarderConfig) DeepCopy() *FluentForwarderConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupMemberList.
+func (in *GroupMemberList) DeepCopy() *GroupMemberList {
 	if in == nil {
 		return nil
 	}
-	out := new(FluentForwarderConfig)
+	out := new(GroupMemberList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GroupMemberList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FluentServer) DeepCopyInto(out *FluentServer) {
+func (in *HTTPClientConfig) DeepCopyInto(out *HTTPClientConfig) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluentServer.
-func (in *FluentServer) DeepCopy() *FluentServer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPClientConfig.
+func (in *HTTPClientConfig) DeepCopy() *HTTPClientConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(FluentServer)
+	out := new(HTTPClientConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FreeIpaConfig) DeepCopyInto(out *FreeIpaConfig) {
+func (in *ImagePullSecretSource) DeepCopyInto(out *ImagePullSecretSource) {
 	*out = *in
-	in.LdapConfig.DeepCopyInto(&out.LdapConfig)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreeIpaConfig.
-func (in *FreeIpaConfig) DeepCopy() *FreeIpaConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullSecretSource.
+func (in *ImagePullSecretSource) DeepCopy() *ImagePullSecretSource {
 	if in == nil {
 		return nil
 	}
-	out := new(FreeIpaConfig)
+	out := new(ImagePullSecretSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FreeIpaProvider) DeepCopyInto(out *FreeIpaProvider) {
+func (in *ImportClusterYamlInput) DeepCopyInto(out *ImportClusterYamlInput) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.AuthProvider.DeepCopyInto(&out.AuthProvider)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreeIpaProvider.
-func (in *FreeIpaProvider) DeepCopy() *FreeIpaProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportClusterYamlInput.
+func (in *ImportClusterYamlInput) DeepCopy() *ImportClusterYamlInput {
 	if in == nil {
 		return nil
 	}
-	out := new(FreeIpaProvider)
+	out := new(ImportClusterYamlInput)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *FreeIpaProvider) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FreeIpaProviderList) DeepCopyInto(out *FreeIpaProviderList) {
+func (in *ImportYamlOutput) DeepCopyInto(out *ImportYamlOutput) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]FreeIpaProvider, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreeIpaProviderList.
-func (in *FreeIpaProviderList) DeepCopy() *FreeIpaProviderList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportYamlOutput.
+func (in *ImportYamlOutput) DeepCopy() *ImportYamlOutput {
 	if in == nil {
 		return nil
 	}
-	out := new(FreeIpaProviderList)
+	out := new(ImportYamlOutput)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *FreeIpaProviderList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FreeIpaTestAndApplyInput) DeepCopyInto(out *FreeIpaTestAndApplyInput) {
+func (in *ImportedConfig) DeepCopyInto(out *ImportedConfig) {
 	*out = *in
-	in.LdapTestAndApplyInput.DeepCopyInto(&out.LdapTestAndApplyInput)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreeIpaTestAndApplyInput.
-func (in *FreeIpaTestAndApplyInput) DeepCopy() *FreeIpaTestAndApplyInput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportedConfig.
+func (in *ImportedConfig) DeepCopy() *ImportedConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(FreeIpaTestAndApplyInput)
+	out := new(ImportedConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GKEStatus) DeepCopyInto(out *GKEStatus) {
+func (in *IngressCapabilities) DeepCopyInto(out *IngressCapabilities) {
 	*out = *in
-	if in.UpstreamSpec != nil {
-		in, out := &in.UpstreamSpec, &out.UpstreamSpec
-		*out = new(gkecattleiov1.GKEClusterConfigSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.PrivateRequiresTunnel != nil {
-		in, out := &in.PrivateRequiresTunnel, &out.PrivateRequiresTunnel
+	if in.CustomDefaultBackend != nil {
+		in, out := &in.CustomDefaultBackend, &out.CustomDefaultBackend
 		*out = new(bool)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKEStatus.
-func (in *GKEStatus) DeepCopy() *GKEStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressCapabilities.
+func (in *IngressCapabilities) DeepCopy() *IngressCapabilities {
 	if in == nil {
 		return nil
 	}
-	out := new(GKEStatus)
+	out := new(IngressCapabilities)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GenerateKubeConfigOutput) DeepCopyInto(out *GenerateKubeConfigOutput) {
+func (in *K3sConfig) DeepCopyInto(out *K3sConfig) {
 	*out = *in
+	out.ClusterUpgradeStrategy = in.ClusterUpgradeStrategy
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenerateKubeConfigOutput.
-func (in *GenerateKubeConfigOutput) DeepCopy() *GenerateKubeConfigOutput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K3sConfig.
+func (in *K3sConfig) DeepCopy() *K3sConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(GenerateKubeConfigOutput)
+	out := new(K3sConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GenericLogin) DeepCopyInto(out *GenericLogin) {
+func (in *KafkaConfig) DeepCopyInto(out *KafkaConfig) {
 	*out = *in
+	if in.BrokerEndpoints != nil {
+		in, out := &in.BrokerEndpoints, &out.BrokerEndpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericLogin.
-func (in *GenericLogin) DeepCopy() *GenericLogin {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaConfig.
+func (in *KafkaConfig) DeepCopy() *KafkaConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(GenericLogin)
+	out := new(KafkaConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GithubConfig) DeepCopyInto(out *GithubConfig) {
+func (in *KeyCloakConfig) DeepCopyInto(out *KeyCloakConfig) {
 	*out = *in
-	in.AuthConfig.DeepCopyInto(&out.AuthConfig)
-	if in.AdditionalClientIDs != nil {
-		in, out := &in.AdditionalClientIDs, &out.AdditionalClientIDs
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.HostnameToClientID != nil {
-		in, out := &in.HostnameToClientID, &out.HostnameToClientID
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
+	in.SamlConfig.DeepCopyInto(&out.SamlConfig)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GithubConfig.
-func (in *GithubConfig) DeepCopy() *GithubConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyCloakConfig.
+func (in *KeyCloakConfig) DeepCopy() *KeyCloakConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(GithubConfig)
+	out := new(KeyCloakConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GithubConfig) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GithubConfigApplyInput) DeepCopyInto(out *GithubConfigApplyInput) {
+func (in *KeyCloakOIDCConfig) DeepCopyInto(out *KeyCloakOIDCConfig) {
 	*out = *in
-	in.GithubConfig.DeepCopyInto(&out.GithubConfig)
+	in.OIDCConfig.DeepCopyInto(&out.OIDCConfig)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GithubConfigApplyInput.
-func (in *GithubConfigApplyInput) DeepCopy() *GithubConfigApplyInput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyCloakOIDCConfig.
+func (in *KeyCloakOIDCConfig) DeepCopy() *KeyCloakOIDCConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(GithubConfigApplyInput)
+	out := new(KeyCloakOIDCConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GithubConfigList) DeepCopyInto(out *GithubConfigList) {
+func (in *KeyCloakOIDCConfigList) DeepCopyInto(out *KeyCloakOIDCConfigList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]GithubConfig, len(*in))
+		*out = make([]KeyCloakOIDCConfig, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -3738,18 +5690,18 @@ func (in *GithubConfigList) DeepCopyInto(out *GithubConfigList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GithubConfigList.
-func (in *GithubConfigList) DeepCopy() *GithubConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyCloakOIDCConfigList.
+func (in *KeyCloakOIDCConfigList) DeepCopy() *KeyCloakOIDCConfigList {
 	if in == nil {
 		return nil
 	}
-	out := new(GithubConfigList)
+	out := new(KeyCloakOIDCConfigList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GithubConfigList) DeepCopyObject() runtime.Object {
+func (in *KeyCloakOIDCConfigList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -3757,59 +5709,61 @@ func (in *GithubConfigList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GithubConfigTestOutput) DeepCopyInto(out *GithubConfigTestOutput) {
+func (in *KeyCloakOIDCProvider) DeepCopyInto(out *KeyCloakOIDCProvider) {
 	*out = *in
+	in.OIDCProvider.DeepCopyInto(&out.OIDCProvider)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GithubConfigTestOutput.
-func (in *GithubConfigTestOutput) DeepCopy() *GithubConfigTestOutput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyCloakOIDCProvider.
+func (in *KeyCloakOIDCProvider) DeepCopy() *KeyCloakOIDCProvider {
 	if in == nil {
 		return nil
 	}
-	out := new(GithubConfigTestOutput)
+	out := new(KeyCloakOIDCProvider)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GithubLogin) DeepCopyInto(out *GithubLogin) {
+func (in *KeyCloakProvider) DeepCopyInto(out *KeyCloakProvider) {
 	*out = *in
-	out.GenericLogin = in.GenericLogin
+	in.SamlProvider.DeepCopyInto(&out.SamlProvider)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GithubLogin.
-func (in *GithubLogin) DeepCopy() *GithubLogin {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyCloakProvider.
+func (in *KeyCloakProvider) DeepCopy() *KeyCloakProvider {
 	if in == nil {
 		return nil
 	}
-	out := new(GithubLogin)
+	out := new(KeyCloakProvider)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GithubProvider) DeepCopyInto(out *GithubProvider) {
+func (in *KontainerDriver) DeepCopyInto(out *KontainerDriver) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.AuthProvider.DeepCopyInto(&out.AuthProvider)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GithubProvider.
-func (in *GithubProvider) DeepCopy() *GithubProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KontainerDriver.
+func (in *KontainerDriver) DeepCopy() *KontainerDriver {
 	if in == nil {
 		return nil
 	}
-	out := new(GithubProvider)
+	out := new(KontainerDriver)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GithubProvider) DeepCopyObject() runtime.Object {
+func (in *KontainerDriver) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -3817,13 +5771,13 @@ func (in *GithubProvider) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GithubProviderList) DeepCopyInto(out *GithubProviderList) {
+func (in *KontainerDriverList) DeepCopyInto(out *KontainerDriverList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]GithubProvider, len(*in))
+		*out = make([]KontainerDriver, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -3831,18 +5785,18 @@ func (in *GithubProviderList) DeepCopyInto(out *GithubProviderList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GithubProviderList.
-func (in *GithubProviderList) DeepCopy() *GithubProviderList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KontainerDriverList.
+func (in *KontainerDriverList) DeepCopy() *KontainerDriverList {
 	if in == nil {
 		return nil
 	}
-	out := new(GithubProviderList)
+	out := new(KontainerDriverList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GithubProviderList) DeepCopyObject() runtime.Object {
+func (in *KontainerDriverList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -3850,126 +5804,68 @@ func (in *GithubProviderList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GlobalDNSProviderSpec) DeepCopyInto(out *GlobalDNSProviderSpec) {
-	*out = *in
-	if in.Route53ProviderConfig != nil {
-		in, out := &in.Route53ProviderConfig, &out.Route53ProviderConfig
-		*out = new(Route53ProviderConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.CloudflareProviderConfig != nil {
-		in, out := &in.CloudflareProviderConfig, &out.CloudflareProviderConfig
-		*out = new(CloudflareProviderConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.AlidnsProviderConfig != nil {
-		in, out := &in.AlidnsProviderConfig, &out.AlidnsProviderConfig
-		*out = new(AlidnsProviderConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Members != nil {
-		in, out := &in.Members, &out.Members
-		*out = make([]Member, len(*in))
-		copy(*out, *in)
-	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalDNSProviderSpec.
-func (in *GlobalDNSProviderSpec) DeepCopy() *GlobalDNSProviderSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(GlobalDNSProviderSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GlobalDNSSpec) DeepCopyInto(out *GlobalDNSSpec) {
+func (in *KontainerDriverSpec) DeepCopyInto(out *KontainerDriverSpec) {
 	*out = *in
-	if in.ProjectNames != nil {
-		in, out := &in.ProjectNames, &out.ProjectNames
+	if in.WhitelistDomains != nil {
+		in, out := &in.WhitelistDomains, &out.WhitelistDomains
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Members != nil {
-		in, out := &in.Members, &out.Members
-		*out = make([]Member, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalDNSSpec.
-func (in *GlobalDNSSpec) DeepCopy() *GlobalDNSSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KontainerDriverSpec.
+func (in *KontainerDriverSpec) DeepCopy() *KontainerDriverSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GlobalDNSSpec)
+	out := new(KontainerDriverSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GlobalDNSStatus) DeepCopyInto(out *GlobalDNSStatus) {
+func (in *KontainerDriverStatus) DeepCopyInto(out *KontainerDriverStatus) {
 	*out = *in
-	if in.Endpoints != nil {
-		in, out := &in.Endpoints, &out.Endpoints
-		*out = make([]string, len(*in))
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
 		copy(*out, *in)
 	}
-	if in.ClusterEndpoints != nil {
-		in, out := &in.ClusterEndpoints, &out.ClusterEndpoints
-		*out = make(map[string][]string, len(*in))
-		for key, val := range *in {
-			var outVal []string
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				in, out := &val, &outVal
-				*out = make([]string, len(*in))
-				copy(*out, *in)
-			}
-			(*out)[key] = outVal
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalDNSStatus.
-func (in *GlobalDNSStatus) DeepCopy() *GlobalDNSStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KontainerDriverStatus.
+func (in *KontainerDriverStatus) DeepCopy() *KontainerDriverStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GlobalDNSStatus)
+	out := new(KontainerDriverStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GlobalDns) DeepCopyInto(out *GlobalDns) {
+func (in *LandingPreference) DeepCopyInto(out *LandingPreference) {
 	*out = *in
-	out.Namespaced = in.Namespaced
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalDns.
-func (in *GlobalDns) DeepCopy() *GlobalDns {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LandingPreference.
+func (in *LandingPreference) DeepCopy() *LandingPreference {
 	if in == nil {
 		return nil
 	}
-	out := new(GlobalDns)
+	out := new(LandingPreference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GlobalDns) DeepCopyObject() runtime.Object {
+func (in *LandingPreference) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -3977,13 +5873,13 @@ func (in *GlobalDns) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GlobalDnsList) DeepCopyInto(out *GlobalDnsList) {
+func (in *LandingPreferenceList) DeepCopyInto(out *LandingPreferenceList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]GlobalDns, len(*in))
+		*out = make([]LandingPreference, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -3991,18 +5887,18 @@ func (in *GlobalDnsList) DeepCopyInto(out *GlobalDnsList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalDnsList.
-func (in *GlobalDnsList) DeepCopy() *GlobalDnsList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LandingPreferenceList.
+func (in *LandingPreferenceList) DeepCopy() *LandingPreferenceList {
 	if in == nil {
 		return nil
 	}
-	out := new(GlobalDnsList)
+	out := new(LandingPreferenceList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GlobalDnsList) DeepCopyObject() runtime.Object {
+func (in *LandingPreferenceList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -4010,27 +5906,51 @@ func (in *GlobalDnsList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GlobalDnsProvider) DeepCopyInto(out *GlobalDnsProvider) {
+func (in *LandingPreferenceSpec) DeepCopyInto(out *LandingPreferenceSpec) {
 	*out = *in
-	out.Namespaced = in.Namespaced
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	if in.FavoriteProjects != nil {
+		in, out := &in.FavoriteProjects, &out.FavoriteProjects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PinnedNamespaces != nil {
+		in, out := &in.PinnedNamespaces, &out.PinnedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalDnsProvider.
-func (in *GlobalDnsProvider) DeepCopy() *GlobalDnsProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LandingPreferenceSpec.
+func (in *LandingPreferenceSpec) DeepCopy() *LandingPreferenceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GlobalDnsProvider)
+	out := new(LandingPreferenceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LdapConfig) DeepCopyInto(out *LdapConfig) {
+	*out = *in
+	in.AuthConfig.DeepCopyInto(&out.AuthConfig)
+	in.LdapFields.DeepCopyInto(&out.LdapFields)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapConfig.
+func (in *LdapConfig) DeepCopy() *LdapConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LdapConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GlobalDnsProvider) DeepCopyObject() runtime.Object {
+func (in *LdapConfig) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -4038,13 +5958,13 @@ func (in *GlobalDnsProvider) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GlobalDnsProviderList) DeepCopyInto(out *GlobalDnsProviderList) {
+func (in *LdapConfigList) DeepCopyInto(out *LdapConfigList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]GlobalDnsProvider, len(*in))
+		*out = make([]LdapConfig, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -4052,18 +5972,18 @@ func (in *GlobalDnsProviderList) DeepCopyInto(out *GlobalDnsProviderList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalDnsProviderList.
-func (in *GlobalDnsProviderList) DeepCopy() *GlobalDnsProviderList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapConfigList.
+func (in *LdapConfigList) DeepCopy() *LdapConfigList {
 	if in == nil {
 		return nil
 	}
-	out := new(GlobalDnsProviderList)
+	out := new(LdapConfigList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GlobalDnsProviderList) DeepCopyObject() runtime.Object {
+func (in *LdapConfigList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -4071,91 +5991,148 @@ func (in *GlobalDnsProviderList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GlobalRole) DeepCopyInto(out *GlobalRole) {
+func (in *LdapFields) DeepCopyInto(out *LdapFields) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	if in.Rules != nil {
-		in, out := &in.Rules, &out.Rules
-		*out = make([]rbacv1.PolicyRule, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalRole.
-func (in *GlobalRole) DeepCopy() *GlobalRole {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapFields.
+func (in *LdapFields) DeepCopy() *LdapFields {
 	if in == nil {
 		return nil
 	}
-	out := new(GlobalRole)
+	out := new(LdapFields)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GlobalRole) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LdapTestAndApplyInput) DeepCopyInto(out *LdapTestAndApplyInput) {
+	*out = *in
+	in.LdapConfig.DeepCopyInto(&out.LdapConfig)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapTestAndApplyInput.
+func (in *LdapTestAndApplyInput) DeepCopy() *LdapTestAndApplyInput {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(LdapTestAndApplyInput)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GlobalRoleBinding) DeepCopyInto(out *GlobalRoleBinding) {
+func (in *LifecycleEventRoute) DeepCopyInto(out *LifecycleEventRoute) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.EventTypes != nil {
+		in, out := &in.EventTypes, &out.EventTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalRoleBinding.
-func (in *GlobalRoleBinding) DeepCopy() *GlobalRoleBinding {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleEventRoute.
+func (in *LifecycleEventRoute) DeepCopy() *LifecycleEventRoute {
 	if in == nil {
 		return nil
 	}
-	out := new(GlobalRoleBinding)
+	out := new(LifecycleEventRoute)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GlobalRoleBinding) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListOpts) DeepCopyInto(out *ListOpts) {
+	*out = *in
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	return nil
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListOpts.
+func (in *ListOpts) DeepCopy() *ListOpts {
+	if in == nil {
+		return nil
+	}
+	out := new(ListOpts)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GlobalRoleBindingList) DeepCopyInto(out *GlobalRoleBindingList) {
+func (in *LoadBalancerCapabilities) DeepCopyInto(out *LoadBalancerCapabilities) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]GlobalRoleBinding, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ProtocolsSupported != nil {
+		in, out := &in.ProtocolsSupported, &out.ProtocolsSupported
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalRoleBindingList.
-func (in *GlobalRoleBindingList) DeepCopy() *GlobalRoleBindingList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerCapabilities.
+func (in *LoadBalancerCapabilities) DeepCopy() *LoadBalancerCapabilities {
 	if in == nil {
 		return nil
 	}
-	out := new(GlobalRoleBindingList)
+	out := new(LoadBalancerCapabilities)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalClusterAuthEndpoint) DeepCopyInto(out *LocalClusterAuthEndpoint) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalClusterAuthEndpoint.
+func (in *LocalClusterAuthEndpoint) DeepCopy() *LocalClusterAuthEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalClusterAuthEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalConfig) DeepCopyInto(out *LocalConfig) {
+	*out = *in
+	in.AuthConfig.DeepCopyInto(&out.AuthConfig)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalConfig.
+func (in *LocalConfig) DeepCopy() *LocalConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GlobalRoleBindingList) DeepCopyObject() runtime.Object {
+func (in *LocalConfig) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -4163,13 +6140,13 @@ func (in *GlobalRoleBindingList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GlobalRoleList) DeepCopyInto(out *GlobalRoleList) {
+func (in *LocalConfigList) DeepCopyInto(out *LocalConfigList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]GlobalRole, len(*in))
+		*out = make([]LocalConfig, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -4177,18 +6154,18 @@ func (in *GlobalRoleList) DeepCopyInto(out *GlobalRoleList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalRoleList.
-func (in *GlobalRoleList) DeepCopy() *GlobalRoleList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalConfigList.
+func (in *LocalConfigList) DeepCopy() *LocalConfigList {
 	if in == nil {
 		return nil
 	}
-	out := new(GlobalRoleList)
+	out := new(LocalConfigList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GlobalRoleList) DeepCopyObject() runtime.Object {
+func (in *LocalConfigList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -4196,7 +6173,7 @@ func (in *GlobalRoleList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GoogleOAuthProvider) DeepCopyInto(out *GoogleOAuthProvider) {
+func (in *LocalProvider) DeepCopyInto(out *LocalProvider) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -4204,18 +6181,18 @@ func (in *GoogleOAuthProvider) DeepCopyInto(out *GoogleOAuthProvider) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoogleOAuthProvider.
-func (in *GoogleOAuthProvider) DeepCopy() *GoogleOAuthProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalProvider.
+func (in *LocalProvider) DeepCopy() *LocalProvider {
 	if in == nil {
 		return nil
 	}
-	out := new(GoogleOAuthProvider)
+	out := new(LocalProvider)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GoogleOAuthProvider) DeepCopyObject() runtime.Object {
+func (in *LocalProvider) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -4223,13 +6200,13 @@ func (in *GoogleOAuthProvider) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GoogleOAuthProviderList) DeepCopyInto(out *GoogleOAuthProviderList) {
+func (in *LocalProviderList) DeepCopyInto(out *LocalProviderList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]GoogleOAuthProvider, len(*in))
+		*out = make([]LocalProvider, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -4237,18 +6214,18 @@ func (in *GoogleOAuthProviderList) DeepCopyInto(out *GoogleOAuthProviderList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoogleOAuthProviderList.
-func (in *GoogleOAuthProviderList) DeepCopy() *GoogleOAuthProviderList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalProviderList.
+func (in *LocalProviderList) DeepCopy() *LocalProviderList {
 	if in == nil {
 		return nil
 	}
-	out := new(GoogleOAuthProviderList)
+	out := new(LocalProviderList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GoogleOAuthProviderList) DeepCopyObject() runtime.Object {
+func (in *LocalProviderList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -4256,55 +6233,147 @@ func (in *GoogleOAuthProviderList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GoogleOauthConfig) DeepCopyInto(out *GoogleOauthConfig) {
+func (in *LoggingCommonField) DeepCopyInto(out *LoggingCommonField) {
 	*out = *in
-	in.AuthConfig.DeepCopyInto(&out.AuthConfig)
+	if in.OutputTags != nil {
+		in, out := &in.OutputTags, &out.OutputTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoogleOauthConfig.
-func (in *GoogleOauthConfig) DeepCopy() *GoogleOauthConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggingCommonField.
+func (in *LoggingCommonField) DeepCopy() *LoggingCommonField {
 	if in == nil {
 		return nil
 	}
-	out := new(GoogleOauthConfig)
+	out := new(LoggingCommonField)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GoogleOauthConfig) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoggingCondition) DeepCopyInto(out *LoggingCondition) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggingCondition.
+func (in *LoggingCondition) DeepCopy() *LoggingCondition {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(LoggingCondition)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GoogleOauthConfigApplyInput) DeepCopyInto(out *GoogleOauthConfigApplyInput) {
+func (in *LoggingTargets) DeepCopyInto(out *LoggingTargets) {
 	*out = *in
-	in.GoogleOauthConfig.DeepCopyInto(&out.GoogleOauthConfig)
+	if in.ElasticsearchConfig != nil {
+		in, out := &in.ElasticsearchConfig, &out.ElasticsearchConfig
+		*out = new(ElasticsearchConfig)
+		**out = **in
+	}
+	if in.SplunkConfig != nil {
+		in, out := &in.SplunkConfig, &out.SplunkConfig
+		*out = new(SplunkConfig)
+		**out = **in
+	}
+	if in.KafkaConfig != nil {
+		in, out := &in.KafkaConfig, &out.KafkaConfig
+		*out = new(KafkaConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SyslogConfig != nil {
+		in, out := &in.SyslogConfig, &out.SyslogConfig
+		*out = new(SyslogConfig)
+		**out = **in
+	}
+	if in.FluentForwarderConfig != nil {
+		in, out := &in.FluentForwarderConfig, &out.FluentForwarderConfig
+		*out = new(FluentForwarderConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CustomTargetConfig != nil {
+		in, out := &in.CustomTargetConfig, &out.CustomTargetConfig
+		*out = new(CustomTargetConfig)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoogleOauthConfigApplyInput.
-func (in *GoogleOauthConfigApplyInput) DeepCopy() *GoogleOauthConfigApplyInput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggingTargets.
+func (in *LoggingTargets) DeepCopy() *LoggingTargets {
 	if in == nil {
 		return nil
 	}
-	out := new(GoogleOauthConfigApplyInput)
+	out := new(LoggingTargets)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GoogleOauthConfigList) DeepCopyInto(out *GoogleOauthConfigList) {
+func (in *MSTeamsConfig) DeepCopyInto(out *MSTeamsConfig) {
+	*out = *in
+	if in.HTTPClientConfig != nil {
+		in, out := &in.HTTPClientConfig, &out.HTTPClientConfig
+		*out = new(HTTPClientConfig)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MSTeamsConfig.
+func (in *MSTeamsConfig) DeepCopy() *MSTeamsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MSTeamsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedChart) DeepCopyInto(out *ManagedChart) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedChart.
+func (in *ManagedChart) DeepCopy() *ManagedChart {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedChart)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ManagedChart) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedChartList) DeepCopyInto(out *ManagedChartList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]GoogleOauthConfig, len(*in))
+		*out = make([]ManagedChart, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -4312,18 +6381,18 @@ func (in *GoogleOauthConfigList) DeepCopyInto(out *GoogleOauthConfigList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoogleOauthConfigList.
-func (in *GoogleOauthConfigList) DeepCopy() *GoogleOauthConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedChartList.
+func (in *ManagedChartList) DeepCopy() *ManagedChartList {
 	if in == nil {
 		return nil
 	}
-	out := new(GoogleOauthConfigList)
+	out := new(ManagedChartList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GoogleOauthConfigList) DeepCopyObject() runtime.Object {
+func (in *ManagedChartList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -4331,58 +6400,80 @@ func (in *GoogleOauthConfigList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GoogleOauthConfigTestOutput) DeepCopyInto(out *GoogleOauthConfigTestOutput) {
+func (in *ManagedChartSpec) DeepCopyInto(out *ManagedChartSpec) {
 	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = (*in).DeepCopy()
+	}
+	if in.Diff != nil {
+		in, out := &in.Diff, &out.Diff
+		*out = new(v1alpha1.DiffOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RolloutStrategy != nil {
+		in, out := &in.RolloutStrategy, &out.RolloutStrategy
+		*out = new(v1alpha1.RolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]v1alpha1.BundleTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoogleOauthConfigTestOutput.
-func (in *GoogleOauthConfigTestOutput) DeepCopy() *GoogleOauthConfigTestOutput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedChartSpec.
+func (in *ManagedChartSpec) DeepCopy() *ManagedChartSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GoogleOauthConfigTestOutput)
+	out := new(ManagedChartSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GoogleOauthLogin) DeepCopyInto(out *GoogleOauthLogin) {
+func (in *ManagedChartStatus) DeepCopyInto(out *ManagedChartStatus) {
 	*out = *in
-	out.GenericLogin = in.GenericLogin
+	in.BundleStatus.DeepCopyInto(&out.BundleStatus)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GoogleOauthLogin.
-func (in *GoogleOauthLogin) DeepCopy() *GoogleOauthLogin {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedChartStatus.
+func (in *ManagedChartStatus) DeepCopy() *ManagedChartStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GoogleOauthLogin)
+	out := new(ManagedChartStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Group) DeepCopyInto(out *Group) {
+func (in *ManagementEvent) DeepCopyInto(out *ManagementEvent) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Group.
-func (in *Group) DeepCopy() *Group {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementEvent.
+func (in *ManagementEvent) DeepCopy() *ManagementEvent {
 	if in == nil {
 		return nil
 	}
-	out := new(Group)
+	out := new(ManagementEvent)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Group) DeepCopyObject() runtime.Object {
+func (in *ManagementEvent) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -4390,13 +6481,13 @@ func (in *Group) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GroupList) DeepCopyInto(out *GroupList) {
+func (in *ManagementEventList) DeepCopyInto(out *ManagementEventList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Group, len(*in))
+		*out = make([]ManagementEvent, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -4404,18 +6495,18 @@ func (in *GroupList) DeepCopyInto(out *GroupList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupList.
-func (in *GroupList) DeepCopy() *GroupList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementEventList.
+func (in *ManagementEventList) DeepCopy() *ManagementEventList {
 	if in == nil {
 		return nil
 	}
-	out := new(GroupList)
+	out := new(ManagementEventList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GroupList) DeepCopyObject() runtime.Object {
+func (in *ManagementEventList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -4423,229 +6514,293 @@ func (in *GroupList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GroupMember) DeepCopyInto(out *GroupMember) {
+func (in *ManagementEventSpec) DeepCopyInto(out *ManagementEventSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupMember.
-func (in *GroupMember) DeepCopy() *GroupMember {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementEventSpec.
+func (in *ManagementEventSpec) DeepCopy() *ManagementEventSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GroupMember)
+	out := new(ManagementEventSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GroupMember) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MapDelta) DeepCopyInto(out *MapDelta) {
+	*out = *in
+	if in.Add != nil {
+		in, out := &in.Add, &out.Add
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Delete != nil {
+		in, out := &in.Delete, &out.Delete
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MapDelta.
+func (in *MapDelta) DeepCopy() *MapDelta {
+	if in == nil {
+		return nil
+	}
+	out := new(MapDelta)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in MapStringInterface) DeepCopyInto(out *MapStringInterface) {
+	{
+		in := &in
+		clone := in.DeepCopy()
+		*out = *clone
+		return
 	}
-	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GroupMemberList) DeepCopyInto(out *GroupMemberList) {
+func (in *Member) DeepCopyInto(out *Member) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]GroupMember, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupMemberList.
-func (in *GroupMemberList) DeepCopy() *GroupMemberList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Member.
+func (in *Member) DeepCopy() *Member {
 	if in == nil {
 		return nil
 	}
-	out := new(GroupMemberList)
+	out := new(Member)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GroupMemberList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HTTPClientConfig) DeepCopyInto(out *HTTPClientConfig) {
+func (in *MetadataUpdate) DeepCopyInto(out *MetadataUpdate) {
 	*out = *in
+	in.Labels.DeepCopyInto(&out.Labels)
+	in.Annotations.DeepCopyInto(&out.Annotations)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPClientConfig.
-func (in *HTTPClientConfig) DeepCopy() *HTTPClientConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataUpdate.
+func (in *MetadataUpdate) DeepCopy() *MetadataUpdate {
 	if in == nil {
 		return nil
 	}
-	out := new(HTTPClientConfig)
+	out := new(MetadataUpdate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImportClusterYamlInput) DeepCopyInto(out *ImportClusterYamlInput) {
+func (in *MeteringClusterUsage) DeepCopyInto(out *MeteringClusterUsage) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportClusterYamlInput.
-func (in *ImportClusterYamlInput) DeepCopy() *ImportClusterYamlInput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeteringClusterUsage.
+func (in *MeteringClusterUsage) DeepCopy() *MeteringClusterUsage {
 	if in == nil {
 		return nil
 	}
-	out := new(ImportClusterYamlInput)
+	out := new(MeteringClusterUsage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImportYamlOutput) DeepCopyInto(out *ImportYamlOutput) {
+func (in *MeteringProjectUsage) DeepCopyInto(out *MeteringProjectUsage) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportYamlOutput.
-func (in *ImportYamlOutput) DeepCopy() *ImportYamlOutput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeteringProjectUsage.
+func (in *MeteringProjectUsage) DeepCopy() *MeteringProjectUsage {
 	if in == nil {
 		return nil
 	}
-	out := new(ImportYamlOutput)
+	out := new(MeteringProjectUsage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImportedConfig) DeepCopyInto(out *ImportedConfig) {
+func (in *MeteringRecord) DeepCopyInto(out *MeteringRecord) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportedConfig.
-func (in *ImportedConfig) DeepCopy() *ImportedConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeteringRecord.
+func (in *MeteringRecord) DeepCopy() *MeteringRecord {
 	if in == nil {
 		return nil
 	}
-	out := new(ImportedConfig)
+	out := new(MeteringRecord)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MeteringRecord) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IngressCapabilities) DeepCopyInto(out *IngressCapabilities) {
+func (in *MeteringRecordList) DeepCopyInto(out *MeteringRecordList) {
 	*out = *in
-	if in.CustomDefaultBackend != nil {
-		in, out := &in.CustomDefaultBackend, &out.CustomDefaultBackend
-		*out = new(bool)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MeteringRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressCapabilities.
-func (in *IngressCapabilities) DeepCopy() *IngressCapabilities {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeteringRecordList.
+func (in *MeteringRecordList) DeepCopy() *MeteringRecordList {
 	if in == nil {
 		return nil
 	}
-	out := new(IngressCapabilities)
+	out := new(MeteringRecordList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MeteringRecordList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *K3sConfig) DeepCopyInto(out *K3sConfig) {
+func (in *MeteringRecordSpec) DeepCopyInto(out *MeteringRecordSpec) {
 	*out = *in
-	out.ClusterUpgradeStrategy = in.ClusterUpgradeStrategy
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]MeteringClusterUsage, len(*in))
+		copy(*out, *in)
+	}
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]MeteringProjectUsage, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K3sConfig.
-func (in *K3sConfig) DeepCopy() *K3sConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeteringRecordSpec.
+func (in *MeteringRecordSpec) DeepCopy() *MeteringRecordSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(K3sConfig)
+	out := new(MeteringRecordSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KafkaConfig) DeepCopyInto(out *KafkaConfig) {
+func (in *MetricNamesOutput) DeepCopyInto(out *MetricNamesOutput) {
 	*out = *in
-	if in.BrokerEndpoints != nil {
-		in, out := &in.BrokerEndpoints, &out.BrokerEndpoints
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KafkaConfig.
-func (in *KafkaConfig) DeepCopy() *KafkaConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricNamesOutput.
+func (in *MetricNamesOutput) DeepCopy() *MetricNamesOutput {
 	if in == nil {
 		return nil
 	}
-	out := new(KafkaConfig)
+	out := new(MetricNamesOutput)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeyCloakConfig) DeepCopyInto(out *KeyCloakConfig) {
+func (in *MetricRule) DeepCopyInto(out *MetricRule) {
 	*out = *in
-	in.SamlConfig.DeepCopyInto(&out.SamlConfig)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyCloakConfig.
-func (in *KeyCloakConfig) DeepCopy() *KeyCloakConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricRule.
+func (in *MetricRule) DeepCopy() *MetricRule {
 	if in == nil {
 		return nil
 	}
-	out := new(KeyCloakConfig)
+	out := new(MetricRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeyCloakOIDCConfig) DeepCopyInto(out *KeyCloakOIDCConfig) {
+func (in *MonitorMetric) DeepCopyInto(out *MonitorMetric) {
 	*out = *in
-	in.OIDCConfig.DeepCopyInto(&out.OIDCConfig)
+	out.Namespaced = in.Namespaced
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyCloakOIDCConfig.
-func (in *KeyCloakOIDCConfig) DeepCopy() *KeyCloakOIDCConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitorMetric.
+func (in *MonitorMetric) DeepCopy() *MonitorMetric {
 	if in == nil {
 		return nil
 	}
-	out := new(KeyCloakOIDCConfig)
+	out := new(MonitorMetric)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MonitorMetric) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeyCloakOIDCConfigList) DeepCopyInto(out *KeyCloakOIDCConfigList) {
+func (in *MonitorMetricList) DeepCopyInto(out *MonitorMetricList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]KeyCloakOIDCConfig, len(*in))
+		*out = make([]MonitorMetric, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -4653,18 +6808,18 @@ func (in *KeyCloakOIDCConfigList) DeepCopyInto(out *KeyCloakOIDCConfigList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyCloakOIDCConfigList.
-func (in *KeyCloakOIDCConfigList) DeepCopy() *KeyCloakOIDCConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitorMetricList.
+func (in *MonitorMetricList) DeepCopy() *MonitorMetricList {
 	if in == nil {
 		return nil
 	}
-	out := new(KeyCloakOIDCConfigList)
+	out := new(MonitorMetricList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KeyCloakOIDCConfigList) DeepCopyObject() runtime.Object {
+func (in *MonitorMetricList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -4672,162 +6827,208 @@ func (in *KeyCloakOIDCConfigList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeyCloakOIDCProvider) DeepCopyInto(out *KeyCloakOIDCProvider) {
+func (in *MonitorMetricSpec) DeepCopyInto(out *MonitorMetricSpec) {
 	*out = *in
-	in.OIDCProvider.DeepCopyInto(&out.OIDCProvider)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyCloakOIDCProvider.
-func (in *KeyCloakOIDCProvider) DeepCopy() *KeyCloakOIDCProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitorMetricSpec.
+func (in *MonitorMetricSpec) DeepCopy() *MonitorMetricSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KeyCloakOIDCProvider)
+	out := new(MonitorMetricSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeyCloakProvider) DeepCopyInto(out *KeyCloakProvider) {
+func (in *MonitoringCondition) DeepCopyInto(out *MonitoringCondition) {
 	*out = *in
-	in.SamlProvider.DeepCopyInto(&out.SamlProvider)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyCloakProvider.
-func (in *KeyCloakProvider) DeepCopy() *KeyCloakProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringCondition.
+func (in *MonitoringCondition) DeepCopy() *MonitoringCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(KeyCloakProvider)
+	out := new(MonitoringCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KontainerDriver) DeepCopyInto(out *KontainerDriver) {
+func (in *MonitoringInput) DeepCopyInto(out *MonitoringInput) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Answers != nil {
+		in, out := &in.Answers, &out.Answers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AnswersSetString != nil {
+		in, out := &in.AnswersSetString, &out.AnswersSetString
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KontainerDriver.
-func (in *KontainerDriver) DeepCopy() *KontainerDriver {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringInput.
+func (in *MonitoringInput) DeepCopy() *MonitoringInput {
 	if in == nil {
 		return nil
 	}
-	out := new(KontainerDriver)
+	out := new(MonitoringInput)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KontainerDriver) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KontainerDriverList) DeepCopyInto(out *KontainerDriverList) {
+func (in *MonitoringOutput) DeepCopyInto(out *MonitoringOutput) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]KontainerDriver, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.Answers != nil {
+		in, out := &in.Answers, &out.Answers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AnswersSetString != nil {
+		in, out := &in.AnswersSetString, &out.AnswersSetString
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KontainerDriverList.
-func (in *KontainerDriverList) DeepCopy() *KontainerDriverList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringOutput.
+func (in *MonitoringOutput) DeepCopy() *MonitoringOutput {
 	if in == nil {
 		return nil
 	}
-	out := new(KontainerDriverList)
+	out := new(MonitoringOutput)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KontainerDriverList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringStatus) DeepCopyInto(out *MonitoringStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]MonitoringCondition, len(*in))
+		copy(*out, *in)
 	}
-	return nil
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringStatus.
+func (in *MonitoringStatus) DeepCopy() *MonitoringStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KontainerDriverSpec) DeepCopyInto(out *KontainerDriverSpec) {
+func (in *MultiClusterApp) DeepCopyInto(out *MultiClusterApp) {
 	*out = *in
-	if in.WhitelistDomains != nil {
-		in, out := &in.WhitelistDomains, &out.WhitelistDomains
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	out.Namespaced = in.Namespaced
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KontainerDriverSpec.
-func (in *KontainerDriverSpec) DeepCopy() *KontainerDriverSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterApp.
+func (in *MultiClusterApp) DeepCopy() *MultiClusterApp {
 	if in == nil {
 		return nil
 	}
-	out := new(KontainerDriverSpec)
+	out := new(MultiClusterApp)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MultiClusterApp) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KontainerDriverStatus) DeepCopyInto(out *KontainerDriverStatus) {
+func (in *MultiClusterAppList) DeepCopyInto(out *MultiClusterAppList) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]Condition, len(*in))
-		copy(*out, *in)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MultiClusterApp, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KontainerDriverStatus.
-func (in *KontainerDriverStatus) DeepCopy() *KontainerDriverStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterAppList.
+func (in *MultiClusterAppList) DeepCopy() *MultiClusterAppList {
 	if in == nil {
 		return nil
 	}
-	out := new(KontainerDriverStatus)
+	out := new(MultiClusterAppList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MultiClusterAppList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LdapConfig) DeepCopyInto(out *LdapConfig) {
+func (in *MultiClusterAppRevision) DeepCopyInto(out *MultiClusterAppRevision) {
 	*out = *in
-	in.AuthConfig.DeepCopyInto(&out.AuthConfig)
-	in.LdapFields.DeepCopyInto(&out.LdapFields)
+	out.Namespaced = in.Namespaced
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Answers != nil {
+		in, out := &in.Answers, &out.Answers
+		*out = make([]Answer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapConfig.
-func (in *LdapConfig) DeepCopy() *LdapConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterAppRevision.
+func (in *MultiClusterAppRevision) DeepCopy() *MultiClusterAppRevision {
 	if in == nil {
 		return nil
 	}
-	out := new(LdapConfig)
+	out := new(MultiClusterAppRevision)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LdapConfig) DeepCopyObject() runtime.Object {
+func (in *MultiClusterAppRevision) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -4835,13 +7036,13 @@ func (in *LdapConfig) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LdapConfigList) DeepCopyInto(out *LdapConfigList) {
+func (in *MultiClusterAppRevisionList) DeepCopyInto(out *MultiClusterAppRevisionList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]LdapConfig, len(*in))
+		*out = make([]MultiClusterAppRevision, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -4849,18 +7050,18 @@ func (in *LdapConfigList) DeepCopyInto(out *LdapConfigList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapConfigList.
-func (in *LdapConfigList) DeepCopy() *LdapConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterAppRevisionList.
+func (in *MultiClusterAppRevisionList) DeepCopy() *MultiClusterAppRevisionList {
 	if in == nil {
 		return nil
 	}
-	out := new(LdapConfigList)
+	out := new(MultiClusterAppRevisionList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LdapConfigList) DeepCopyObject() runtime.Object {
+func (in *MultiClusterAppRevisionList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -4868,127 +7069,159 @@ func (in *LdapConfigList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LdapFields) DeepCopyInto(out *LdapFields) {
+func (in *MultiClusterAppRollbackInput) DeepCopyInto(out *MultiClusterAppRollbackInput) {
 	*out = *in
-	if in.Servers != nil {
-		in, out := &in.Servers, &out.Servers
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapFields.
-func (in *LdapFields) DeepCopy() *LdapFields {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterAppRollbackInput.
+func (in *MultiClusterAppRollbackInput) DeepCopy() *MultiClusterAppRollbackInput {
 	if in == nil {
 		return nil
 	}
-	out := new(LdapFields)
+	out := new(MultiClusterAppRollbackInput)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LdapTestAndApplyInput) DeepCopyInto(out *LdapTestAndApplyInput) {
+func (in *MultiClusterAppSpec) DeepCopyInto(out *MultiClusterAppSpec) {
 	*out = *in
-	in.LdapConfig.DeepCopyInto(&out.LdapConfig)
+	if in.Answers != nil {
+		in, out := &in.Answers, &out.Answers
+		*out = make([]Answer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]Target, len(*in))
+		copy(*out, *in)
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]Member, len(*in))
+		copy(*out, *in)
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.UpgradeStrategy.DeepCopyInto(&out.UpgradeStrategy)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LdapTestAndApplyInput.
-func (in *LdapTestAndApplyInput) DeepCopy() *LdapTestAndApplyInput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterAppSpec.
+func (in *MultiClusterAppSpec) DeepCopy() *MultiClusterAppSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(LdapTestAndApplyInput)
+	out := new(MultiClusterAppSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ListOpts) DeepCopyInto(out *ListOpts) {
+func (in *MultiClusterAppStatus) DeepCopyInto(out *MultiClusterAppStatus) {
 	*out = *in
-	if in.Filters != nil {
-		in, out := &in.Filters, &out.Filters
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]projectcattleiov3.AppCondition, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListOpts.
-func (in *ListOpts) DeepCopy() *ListOpts {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterAppStatus.
+func (in *MultiClusterAppStatus) DeepCopy() *MultiClusterAppStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ListOpts)
+	out := new(MultiClusterAppStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LoadBalancerCapabilities) DeepCopyInto(out *LoadBalancerCapabilities) {
+func (in *NamespacePolicy) DeepCopyInto(out *NamespacePolicy) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ResourceQuota != nil {
+		in, out := &in.ResourceQuota, &out.ResourceQuota
+		*out = new(ResourceQuotaLimit)
 		**out = **in
 	}
-	if in.ProtocolsSupported != nil {
-		in, out := &in.ProtocolsSupported, &out.ProtocolsSupported
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.ContainerResourceLimit != nil {
+		in, out := &in.ContainerResourceLimit, &out.ContainerResourceLimit
+		*out = new(ContainerResourceLimit)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerCapabilities.
-func (in *LoadBalancerCapabilities) DeepCopy() *LoadBalancerCapabilities {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacePolicy.
+func (in *NamespacePolicy) DeepCopy() *NamespacePolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(LoadBalancerCapabilities)
+	out := new(NamespacePolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocalClusterAuthEndpoint) DeepCopyInto(out *LocalClusterAuthEndpoint) {
+func (in *NamespaceResourceQuota) DeepCopyInto(out *NamespaceResourceQuota) {
 	*out = *in
+	out.Limit = in.Limit
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalClusterAuthEndpoint.
-func (in *LocalClusterAuthEndpoint) DeepCopy() *LocalClusterAuthEndpoint {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceResourceQuota.
+func (in *NamespaceResourceQuota) DeepCopy() *NamespaceResourceQuota {
 	if in == nil {
 		return nil
 	}
-	out := new(LocalClusterAuthEndpoint)
+	out := new(NamespaceResourceQuota)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocalConfig) DeepCopyInto(out *LocalConfig) {
+func (in *NamespaceTemplate) DeepCopyInto(out *NamespaceTemplate) {
 	*out = *in
-	in.AuthConfig.DeepCopyInto(&out.AuthConfig)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalConfig.
-func (in *LocalConfig) DeepCopy() *LocalConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceTemplate.
+func (in *NamespaceTemplate) DeepCopy() *NamespaceTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(LocalConfig)
+	out := new(NamespaceTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LocalConfig) DeepCopyObject() runtime.Object {
+func (in *NamespaceTemplate) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -4996,13 +7229,13 @@ func (in *LocalConfig) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocalConfigList) DeepCopyInto(out *LocalConfigList) {
+func (in *NamespaceTemplateList) DeepCopyInto(out *NamespaceTemplateList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]LocalConfig, len(*in))
+		*out = make([]NamespaceTemplate, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -5010,18 +7243,18 @@ func (in *LocalConfigList) DeepCopyInto(out *LocalConfigList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalConfigList.
-func (in *LocalConfigList) DeepCopy() *LocalConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceTemplateList.
+func (in *NamespaceTemplateList) DeepCopy() *NamespaceTemplateList {
 	if in == nil {
 		return nil
 	}
-	out := new(LocalConfigList)
+	out := new(NamespaceTemplateList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LocalConfigList) DeepCopyObject() runtime.Object {
+func (in *NamespaceTemplateList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5029,173 +7262,220 @@ func (in *LocalConfigList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocalProvider) DeepCopyInto(out *LocalProvider) {
+func (in *NamespaceTemplateNetworkPolicy) DeepCopyInto(out *NamespaceTemplateNetworkPolicy) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.AuthProvider.DeepCopyInto(&out.AuthProvider)
+	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalProvider.
-func (in *LocalProvider) DeepCopy() *LocalProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceTemplateNetworkPolicy.
+func (in *NamespaceTemplateNetworkPolicy) DeepCopy() *NamespaceTemplateNetworkPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(LocalProvider)
+	out := new(NamespaceTemplateNetworkPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LocalProvider) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocalProviderList) DeepCopyInto(out *LocalProviderList) {
+func (in *NamespaceTemplateRoleBinding) DeepCopyInto(out *NamespaceTemplateRoleBinding) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]LocalProvider, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]rbacv1.Subject, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalProviderList.
-func (in *LocalProviderList) DeepCopy() *LocalProviderList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceTemplateRoleBinding.
+func (in *NamespaceTemplateRoleBinding) DeepCopy() *NamespaceTemplateRoleBinding {
 	if in == nil {
 		return nil
 	}
-	out := new(LocalProviderList)
+	out := new(NamespaceTemplateRoleBinding)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LocalProviderList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LoggingCommonField) DeepCopyInto(out *LoggingCommonField) {
+func (in *NamespaceTemplateSpec) DeepCopyInto(out *NamespaceTemplateSpec) {
 	*out = *in
-	if in.OutputTags != nil {
-		in, out := &in.OutputTags, &out.OutputTags
+	if in.ProjectNames != nil {
+		in, out := &in.ProjectNames, &out.ProjectNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProjectSelector != nil {
+		in, out := &in.ProjectSelector, &out.ProjectSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
+	if in.ResourceQuota != nil {
+		in, out := &in.ResourceQuota, &out.ResourceQuota
+		*out = new(NamespaceResourceQuota)
+		**out = **in
+	}
+	if in.ContainerDefaultResourceLimit != nil {
+		in, out := &in.ContainerDefaultResourceLimit, &out.ContainerDefaultResourceLimit
+		*out = new(ContainerResourceLimit)
+		**out = **in
+	}
+	if in.NetworkPolicies != nil {
+		in, out := &in.NetworkPolicies, &out.NetworkPolicies
+		*out = make([]NamespaceTemplateNetworkPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RoleBindings != nil {
+		in, out := &in.RoleBindings, &out.RoleBindings
+		*out = make([]NamespaceTemplateRoleBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggingCommonField.
-func (in *LoggingCommonField) DeepCopy() *LoggingCommonField {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceTemplateSpec.
+func (in *NamespaceTemplateSpec) DeepCopy() *NamespaceTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(LoggingCommonField)
+	out := new(NamespaceTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LoggingCondition) DeepCopyInto(out *LoggingCondition) {
+func (in *Node) DeepCopyInto(out *Node) {
 	*out = *in
+	out.Namespaced = in.Namespaced
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggingCondition.
-func (in *LoggingCondition) DeepCopy() *LoggingCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Node.
+func (in *Node) DeepCopy() *Node {
 	if in == nil {
 		return nil
 	}
-	out := new(LoggingCondition)
+	out := new(Node)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Node) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LoggingTargets) DeepCopyInto(out *LoggingTargets) {
+func (in *NodeCommonParams) DeepCopyInto(out *NodeCommonParams) {
 	*out = *in
-	if in.ElasticsearchConfig != nil {
-		in, out := &in.ElasticsearchConfig, &out.ElasticsearchConfig
-		*out = new(ElasticsearchConfig)
-		**out = **in
+	if in.EngineOpt != nil {
+		in, out := &in.EngineOpt, &out.EngineOpt
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.SplunkConfig != nil {
-		in, out := &in.SplunkConfig, &out.SplunkConfig
-		*out = new(SplunkConfig)
-		**out = **in
+	if in.StorageOpt != nil {
+		in, out := &in.StorageOpt, &out.StorageOpt
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.KafkaConfig != nil {
-		in, out := &in.KafkaConfig, &out.KafkaConfig
-		*out = new(KafkaConfig)
-		(*in).DeepCopyInto(*out)
+	if in.LogOpt != nil {
+		in, out := &in.LogOpt, &out.LogOpt
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.SyslogConfig != nil {
-		in, out := &in.SyslogConfig, &out.SyslogConfig
-		*out = new(SyslogConfig)
-		**out = **in
+	if in.EngineInsecureRegistry != nil {
+		in, out := &in.EngineInsecureRegistry, &out.EngineInsecureRegistry
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.FluentForwarderConfig != nil {
-		in, out := &in.FluentForwarderConfig, &out.FluentForwarderConfig
-		*out = new(FluentForwarderConfig)
-		(*in).DeepCopyInto(*out)
+	if in.EngineRegistryMirror != nil {
+		in, out := &in.EngineRegistryMirror, &out.EngineRegistryMirror
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.CustomTargetConfig != nil {
-		in, out := &in.CustomTargetConfig, &out.CustomTargetConfig
-		*out = new(CustomTargetConfig)
+	if in.EngineLabel != nil {
+		in, out := &in.EngineLabel, &out.EngineLabel
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EngineEnv != nil {
+		in, out := &in.EngineEnv, &out.EngineEnv
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.UseInternalIPAddress != nil {
+		in, out := &in.UseInternalIPAddress, &out.UseInternalIPAddress
+		*out = new(bool)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggingTargets.
-func (in *LoggingTargets) DeepCopy() *LoggingTargets {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeCommonParams.
+func (in *NodeCommonParams) DeepCopy() *NodeCommonParams {
 	if in == nil {
 		return nil
 	}
-	out := new(LoggingTargets)
+	out := new(NodeCommonParams)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MSTeamsConfig) DeepCopyInto(out *MSTeamsConfig) {
+func (in *NodeCondition) DeepCopyInto(out *NodeCondition) {
 	*out = *in
-	if in.HTTPClientConfig != nil {
-		in, out := &in.HTTPClientConfig, &out.HTTPClientConfig
-		*out = new(HTTPClientConfig)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MSTeamsConfig.
-func (in *MSTeamsConfig) DeepCopy() *MSTeamsConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeCondition.
+func (in *NodeCondition) DeepCopy() *NodeCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(MSTeamsConfig)
+	out := new(NodeCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagedChart) DeepCopyInto(out *ManagedChart) {
+func (in *NodeDriver) DeepCopyInto(out *NodeDriver) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -5204,18 +7484,18 @@ func (in *ManagedChart) DeepCopyInto(out *ManagedChart) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedChart.
-func (in *ManagedChart) DeepCopy() *ManagedChart {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDriver.
+func (in *NodeDriver) DeepCopy() *NodeDriver {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagedChart)
+	out := new(NodeDriver)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ManagedChart) DeepCopyObject() runtime.Object {
+func (in *NodeDriver) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5223,13 +7503,13 @@ func (in *ManagedChart) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagedChartList) DeepCopyInto(out *ManagedChartList) {
+func (in *NodeDriverList) DeepCopyInto(out *NodeDriverList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ManagedChart, len(*in))
+		*out = make([]NodeDriver, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -5237,18 +7517,18 @@ func (in *ManagedChartList) DeepCopyInto(out *ManagedChartList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedChartList.
-func (in *ManagedChartList) DeepCopy() *ManagedChartList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDriverList.
+func (in *NodeDriverList) DeepCopy() *NodeDriverList {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagedChartList)
+	out := new(NodeDriverList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ManagedChartList) DeepCopyObject() runtime.Object {
+func (in *NodeDriverList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5256,192 +7536,243 @@ func (in *ManagedChartList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagedChartSpec) DeepCopyInto(out *ManagedChartSpec) {
+func (in *NodeDriverSpec) DeepCopyInto(out *NodeDriverSpec) {
 	*out = *in
-	if in.Values != nil {
-		in, out := &in.Values, &out.Values
-		*out = (*in).DeepCopy()
-	}
-	if in.Diff != nil {
-		in, out := &in.Diff, &out.Diff
-		*out = new(v1alpha1.DiffOptions)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.RolloutStrategy != nil {
-		in, out := &in.RolloutStrategy, &out.RolloutStrategy
-		*out = new(v1alpha1.RolloutStrategy)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Targets != nil {
-		in, out := &in.Targets, &out.Targets
-		*out = make([]v1alpha1.BundleTarget, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.WhitelistDomains != nil {
+		in, out := &in.WhitelistDomains, &out.WhitelistDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedChartSpec.
-func (in *ManagedChartSpec) DeepCopy() *ManagedChartSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDriverSpec.
+func (in *NodeDriverSpec) DeepCopy() *NodeDriverSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagedChartSpec)
+	out := new(NodeDriverSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagedChartStatus) DeepCopyInto(out *ManagedChartStatus) {
+func (in *NodeDriverStatus) DeepCopyInto(out *NodeDriverStatus) {
 	*out = *in
-	in.BundleStatus.DeepCopyInto(&out.BundleStatus)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedChartStatus.
-func (in *ManagedChartStatus) DeepCopy() *ManagedChartStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDriverStatus.
+func (in *NodeDriverStatus) DeepCopy() *NodeDriverStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagedChartStatus)
+	out := new(NodeDriverStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MapDelta) DeepCopyInto(out *MapDelta) {
+func (in *NodeList) DeepCopyInto(out *NodeList) {
 	*out = *in
-	if in.Add != nil {
-		in, out := &in.Add, &out.Add
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Delete != nil {
-		in, out := &in.Delete, &out.Delete
-		*out = make(map[string]bool, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Node, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MapDelta.
-func (in *MapDelta) DeepCopy() *MapDelta {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeList.
+func (in *NodeList) DeepCopy() *NodeList {
 	if in == nil {
 		return nil
 	}
-	out := new(MapDelta)
+	out := new(NodeList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in MapStringInterface) DeepCopyInto(out *MapStringInterface) {
-	{
-		in := &in
-		clone := in.DeepCopy()
-		*out = *clone
-		return
+func (in *NodePlan) DeepCopyInto(out *NodePlan) {
+	*out = *in
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(types.RKEConfigNodePlan)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePlan.
+func (in *NodePlan) DeepCopy() *NodePlan {
+	if in == nil {
+		return nil
 	}
+	out := new(NodePlan)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Member) DeepCopyInto(out *Member) {
+func (in *NodePool) DeepCopyInto(out *NodePool) {
 	*out = *in
+	out.Namespaced = in.Namespaced
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Member.
-func (in *Member) DeepCopy() *Member {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePool.
+func (in *NodePool) DeepCopy() *NodePool {
 	if in == nil {
 		return nil
 	}
-	out := new(Member)
+	out := new(NodePool)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodePool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MetadataUpdate) DeepCopyInto(out *MetadataUpdate) {
+func (in *NodePoolList) DeepCopyInto(out *NodePoolList) {
 	*out = *in
-	in.Labels.DeepCopyInto(&out.Labels)
-	in.Annotations.DeepCopyInto(&out.Annotations)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataUpdate.
-func (in *MetadataUpdate) DeepCopy() *MetadataUpdate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolList.
+func (in *NodePoolList) DeepCopy() *NodePoolList {
 	if in == nil {
 		return nil
 	}
-	out := new(MetadataUpdate)
+	out := new(NodePoolList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodePoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MetricNamesOutput) DeepCopyInto(out *MetricNamesOutput) {
+func (in *NodePoolSpec) DeepCopyInto(out *NodePoolSpec) {
 	*out = *in
-	if in.Names != nil {
-		in, out := &in.Names, &out.Names
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.NodeLabels != nil {
+		in, out := &in.NodeLabels, &out.NodeLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeAnnotations != nil {
+		in, out := &in.NodeAnnotations, &out.NodeAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeTaints != nil {
+		in, out := &in.NodeTaints, &out.NodeTaints
+		*out = make([]corev1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricNamesOutput.
-func (in *MetricNamesOutput) DeepCopy() *MetricNamesOutput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolSpec.
+func (in *NodePoolSpec) DeepCopy() *NodePoolSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MetricNamesOutput)
+	out := new(NodePoolSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MetricRule) DeepCopyInto(out *MetricRule) {
+func (in *NodePoolStatus) DeepCopyInto(out *NodePoolStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricRule.
-func (in *MetricRule) DeepCopy() *MetricRule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolStatus.
+func (in *NodePoolStatus) DeepCopy() *NodePoolStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(MetricRule)
+	out := new(NodePoolStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MonitorMetric) DeepCopyInto(out *MonitorMetric) {
+func (in *NodeRegistrationBatch) DeepCopyInto(out *NodeRegistrationBatch) {
 	*out = *in
-	out.Namespaced = in.Namespaced
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitorMetric.
-func (in *MonitorMetric) DeepCopy() *MonitorMetric {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeRegistrationBatch.
+func (in *NodeRegistrationBatch) DeepCopy() *NodeRegistrationBatch {
 	if in == nil {
 		return nil
 	}
-	out := new(MonitorMetric)
+	out := new(NodeRegistrationBatch)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MonitorMetric) DeepCopyObject() runtime.Object {
+func (in *NodeRegistrationBatch) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5449,13 +7780,13 @@ func (in *MonitorMetric) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MonitorMetricList) DeepCopyInto(out *MonitorMetricList) {
+func (in *NodeRegistrationBatchList) DeepCopyInto(out *NodeRegistrationBatchList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]MonitorMetric, len(*in))
+		*out = make([]NodeRegistrationBatch, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -5463,18 +7794,18 @@ func (in *MonitorMetricList) DeepCopyInto(out *MonitorMetricList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitorMetricList.
-func (in *MonitorMetricList) DeepCopy() *MonitorMetricList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeRegistrationBatchList.
+func (in *NodeRegistrationBatchList) DeepCopy() *NodeRegistrationBatchList {
 	if in == nil {
 		return nil
 	}
-	out := new(MonitorMetricList)
+	out := new(NodeRegistrationBatchList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MonitorMetricList) DeepCopyObject() runtime.Object {
+func (in *NodeRegistrationBatchList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5482,49 +7813,66 @@ func (in *MonitorMetricList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MonitorMetricSpec) DeepCopyInto(out *MonitorMetricSpec) {
+func (in *NodeRegistrationBatchSpec) DeepCopyInto(out *NodeRegistrationBatchSpec) {
 	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NodeRegistrationEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitorMetricSpec.
-func (in *MonitorMetricSpec) DeepCopy() *MonitorMetricSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeRegistrationBatchSpec.
+func (in *NodeRegistrationBatchSpec) DeepCopy() *NodeRegistrationBatchSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MonitorMetricSpec)
+	out := new(NodeRegistrationBatchSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MonitoringCondition) DeepCopyInto(out *MonitoringCondition) {
+func (in *NodeRegistrationBatchStatus) DeepCopyInto(out *NodeRegistrationBatchStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NodeRegistrationNodeStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringCondition.
-func (in *MonitoringCondition) DeepCopy() *MonitoringCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeRegistrationBatchStatus.
+func (in *NodeRegistrationBatchStatus) DeepCopy() *NodeRegistrationBatchStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(MonitoringCondition)
+	out := new(NodeRegistrationBatchStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MonitoringInput) DeepCopyInto(out *MonitoringInput) {
+func (in *NodeRegistrationEntry) DeepCopyInto(out *NodeRegistrationEntry) {
 	*out = *in
-	if in.Answers != nil {
-		in, out := &in.Answers, &out.Answers
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.AnswersSetString != nil {
-		in, out := &in.AnswersSetString, &out.AnswersSetString
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
@@ -5533,69 +7881,174 @@ func (in *MonitoringInput) DeepCopyInto(out *MonitoringInput) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringInput.
-func (in *MonitoringInput) DeepCopy() *MonitoringInput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeRegistrationEntry.
+func (in *NodeRegistrationEntry) DeepCopy() *NodeRegistrationEntry {
 	if in == nil {
 		return nil
 	}
-	out := new(MonitoringInput)
+	out := new(NodeRegistrationEntry)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MonitoringOutput) DeepCopyInto(out *MonitoringOutput) {
+func (in *NodeRegistrationNodeStatus) DeepCopyInto(out *NodeRegistrationNodeStatus) {
 	*out = *in
-	if in.Answers != nil {
-		in, out := &in.Answers, &out.Answers
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeRegistrationNodeStatus.
+func (in *NodeRegistrationNodeStatus) DeepCopy() *NodeRegistrationNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeRegistrationNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeRule) DeepCopyInto(out *NodeRule) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.AnswersSetString != nil {
-		in, out := &in.AnswersSetString, &out.AnswersSetString
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeRule.
+func (in *NodeRule) DeepCopy() *NodeRule {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSpec) DeepCopyInto(out *NodeSpec) {
+	*out = *in
+	if in.CustomConfig != nil {
+		in, out := &in.CustomConfig, &out.CustomConfig
+		*out = new(CustomConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	in.InternalNodeSpec.DeepCopyInto(&out.InternalNodeSpec)
+	if in.DesiredNodeTaints != nil {
+		in, out := &in.DesiredNodeTaints, &out.DesiredNodeTaints
+		*out = make([]corev1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.UpdateTaintsFromAPI != nil {
+		in, out := &in.UpdateTaintsFromAPI, &out.UpdateTaintsFromAPI
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NodeDrainInput != nil {
+		in, out := &in.NodeDrainInput, &out.NodeDrainInput
+		*out = new(types.NodeDrainInput)
+		(*in).DeepCopyInto(*out)
+	}
+	in.MetadataUpdate.DeepCopyInto(&out.MetadataUpdate)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringOutput.
-func (in *MonitoringOutput) DeepCopy() *MonitoringOutput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSpec.
+func (in *NodeSpec) DeepCopy() *NodeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MonitoringOutput)
+	out := new(NodeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MonitoringStatus) DeepCopyInto(out *MonitoringStatus) {
+func (in *NodeStatus) DeepCopyInto(out *NodeStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]MonitoringCondition, len(*in))
+		*out = make([]NodeCondition, len(*in))
 		copy(*out, *in)
 	}
+	in.InternalNodeStatus.DeepCopyInto(&out.InternalNodeStatus)
+	if in.Requested != nil {
+		in, out := &in.Requested, &out.Requested
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.NodeTemplateSpec != nil {
+		in, out := &in.NodeTemplateSpec, &out.NodeTemplateSpec
+		*out = new(NodeTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeConfig != nil {
+		in, out := &in.NodeConfig, &out.NodeConfig
+		*out = new(types.RKEConfigNode)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeAnnotations != nil {
+		in, out := &in.NodeAnnotations, &out.NodeAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeLabels != nil {
+		in, out := &in.NodeLabels, &out.NodeLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeTaints != nil {
+		in, out := &in.NodeTaints, &out.NodeTaints
+		*out = make([]corev1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DockerInfo != nil {
+		in, out := &in.DockerInfo, &out.DockerInfo
+		*out = new(DockerInfo)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodePlan != nil {
+		in, out := &in.NodePlan, &out.NodePlan
+		*out = new(NodePlan)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringStatus.
-func (in *MonitoringStatus) DeepCopy() *MonitoringStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeStatus.
+func (in *NodeStatus) DeepCopy() *NodeStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(MonitoringStatus)
+	out := new(NodeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MultiClusterApp) DeepCopyInto(out *MultiClusterApp) {
+func (in *NodeTemplate) DeepCopyInto(out *NodeTemplate) {
 	*out = *in
 	out.Namespaced = in.Namespaced
 	out.TypeMeta = in.TypeMeta
@@ -5605,51 +8058,18 @@ func (in *MultiClusterApp) DeepCopyInto(out *MultiClusterApp) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterApp.
-func (in *MultiClusterApp) DeepCopy() *MultiClusterApp {
-	if in == nil {
-		return nil
-	}
-	out := new(MultiClusterApp)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MultiClusterApp) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MultiClusterAppList) DeepCopyInto(out *MultiClusterAppList) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]MultiClusterApp, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterAppList.
-func (in *MultiClusterAppList) DeepCopy() *MultiClusterAppList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTemplate.
+func (in *NodeTemplate) DeepCopy() *NodeTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(MultiClusterAppList)
+	out := new(NodeTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MultiClusterAppList) DeepCopyObject() runtime.Object {
+func (in *NodeTemplate) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5657,47 +8077,29 @@ func (in *MultiClusterAppList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MultiClusterAppRevision) DeepCopyInto(out *MultiClusterAppRevision) {
+func (in *NodeTemplateCondition) DeepCopyInto(out *NodeTemplateCondition) {
 	*out = *in
-	out.Namespaced = in.Namespaced
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	if in.Answers != nil {
-		in, out := &in.Answers, &out.Answers
-		*out = make([]Answer, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterAppRevision.
-func (in *MultiClusterAppRevision) DeepCopy() *MultiClusterAppRevision {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTemplateCondition.
+func (in *NodeTemplateCondition) DeepCopy() *NodeTemplateCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(MultiClusterAppRevision)
+	out := new(NodeTemplateCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MultiClusterAppRevision) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MultiClusterAppRevisionList) DeepCopyInto(out *MultiClusterAppRevisionList) {
+func (in *NodeTemplateList) DeepCopyInto(out *NodeTemplateList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]MultiClusterAppRevision, len(*in))
+		*out = make([]NodeTemplate, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -5705,18 +8107,18 @@ func (in *MultiClusterAppRevisionList) DeepCopyInto(out *MultiClusterAppRevision
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterAppRevisionList.
-func (in *MultiClusterAppRevisionList) DeepCopy() *MultiClusterAppRevisionList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTemplateList.
+func (in *NodeTemplateList) DeepCopy() *NodeTemplateList {
 	if in == nil {
 		return nil
 	}
-	out := new(MultiClusterAppRevisionList)
+	out := new(NodeTemplateList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MultiClusterAppRevisionList) DeepCopyObject() runtime.Object {
+func (in *NodeTemplateList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5724,231 +8126,124 @@ func (in *MultiClusterAppRevisionList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MultiClusterAppRollbackInput) DeepCopyInto(out *MultiClusterAppRollbackInput) {
-	*out = *in
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterAppRollbackInput.
-func (in *MultiClusterAppRollbackInput) DeepCopy() *MultiClusterAppRollbackInput {
-	if in == nil {
-		return nil
-	}
-	out := new(MultiClusterAppRollbackInput)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MultiClusterAppSpec) DeepCopyInto(out *MultiClusterAppSpec) {
+func (in *NodeTemplateSpec) DeepCopyInto(out *NodeTemplateSpec) {
 	*out = *in
-	if in.Answers != nil {
-		in, out := &in.Answers, &out.Answers
-		*out = make([]Answer, len(*in))
+	if in.NodeTaints != nil {
+		in, out := &in.NodeTaints, &out.NodeTaints
+		*out = make([]corev1.Taint, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Targets != nil {
-		in, out := &in.Targets, &out.Targets
-		*out = make([]Target, len(*in))
-		copy(*out, *in)
-	}
-	if in.Members != nil {
-		in, out := &in.Members, &out.Members
-		*out = make([]Member, len(*in))
-		copy(*out, *in)
-	}
-	if in.Roles != nil {
-		in, out := &in.Roles, &out.Roles
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	in.UpgradeStrategy.DeepCopyInto(&out.UpgradeStrategy)
+	in.NodeCommonParams.DeepCopyInto(&out.NodeCommonParams)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterAppSpec.
-func (in *MultiClusterAppSpec) DeepCopy() *MultiClusterAppSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTemplateSpec.
+func (in *NodeTemplateSpec) DeepCopy() *NodeTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MultiClusterAppSpec)
+	out := new(NodeTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MultiClusterAppStatus) DeepCopyInto(out *MultiClusterAppStatus) {
+func (in *NodeTemplateStatus) DeepCopyInto(out *NodeTemplateStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]projectcattleiov3.AppCondition, len(*in))
+		*out = make([]NodeTemplateCondition, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterAppStatus.
-func (in *MultiClusterAppStatus) DeepCopy() *MultiClusterAppStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(MultiClusterAppStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NamespaceResourceQuota) DeepCopyInto(out *NamespaceResourceQuota) {
-	*out = *in
-	out.Limit = in.Limit
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceResourceQuota.
-func (in *NamespaceResourceQuota) DeepCopy() *NamespaceResourceQuota {
-	if in == nil {
-		return nil
-	}
-	out := new(NamespaceResourceQuota)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Node) DeepCopyInto(out *Node) {
-	*out = *in
-	out.Namespaced = in.Namespaced
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Node.
-func (in *Node) DeepCopy() *Node {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTemplateStatus.
+func (in *NodeTemplateStatus) DeepCopy() *NodeTemplateStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(Node)
+	out := new(NodeTemplateStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Node) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeCommonParams) DeepCopyInto(out *NodeCommonParams) {
+func (in *Notification) DeepCopyInto(out *Notification) {
 	*out = *in
-	if in.EngineOpt != nil {
-		in, out := &in.EngineOpt, &out.EngineOpt
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.StorageOpt != nil {
-		in, out := &in.StorageOpt, &out.StorageOpt
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.LogOpt != nil {
-		in, out := &in.LogOpt, &out.LogOpt
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.SMTPConfig != nil {
+		in, out := &in.SMTPConfig, &out.SMTPConfig
+		*out = new(SMTPConfig)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.EngineInsecureRegistry != nil {
-		in, out := &in.EngineInsecureRegistry, &out.EngineInsecureRegistry
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.SlackConfig != nil {
+		in, out := &in.SlackConfig, &out.SlackConfig
+		*out = new(SlackConfig)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.EngineRegistryMirror != nil {
-		in, out := &in.EngineRegistryMirror, &out.EngineRegistryMirror
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.PagerdutyConfig != nil {
+		in, out := &in.PagerdutyConfig, &out.PagerdutyConfig
+		*out = new(PagerdutyConfig)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.EngineLabel != nil {
-		in, out := &in.EngineLabel, &out.EngineLabel
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.WebhookConfig != nil {
+		in, out := &in.WebhookConfig, &out.WebhookConfig
+		*out = new(WebhookConfig)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.EngineEnv != nil {
-		in, out := &in.EngineEnv, &out.EngineEnv
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.WechatConfig != nil {
+		in, out := &in.WechatConfig, &out.WechatConfig
+		*out = new(WechatConfig)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.UseInternalIPAddress != nil {
-		in, out := &in.UseInternalIPAddress, &out.UseInternalIPAddress
-		*out = new(bool)
-		**out = **in
+	if in.DingtalkConfig != nil {
+		in, out := &in.DingtalkConfig, &out.DingtalkConfig
+		*out = new(DingtalkConfig)
+		(*in).DeepCopyInto(*out)
 	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeCommonParams.
-func (in *NodeCommonParams) DeepCopy() *NodeCommonParams {
-	if in == nil {
-		return nil
+	if in.MSTeamsConfig != nil {
+		in, out := &in.MSTeamsConfig, &out.MSTeamsConfig
+		*out = new(MSTeamsConfig)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(NodeCommonParams)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeCondition) DeepCopyInto(out *NodeCondition) {
-	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeCondition.
-func (in *NodeCondition) DeepCopy() *NodeCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Notification.
+func (in *Notification) DeepCopy() *Notification {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeCondition)
+	out := new(Notification)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeDriver) DeepCopyInto(out *NodeDriver) {
+func (in *Notifier) DeepCopyInto(out *Notifier) {
 	*out = *in
+	out.Namespaced = in.Namespaced
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	out.Status = in.Status
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDriver.
-func (in *NodeDriver) DeepCopy() *NodeDriver {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Notifier.
+func (in *Notifier) DeepCopy() *Notifier {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeDriver)
+	out := new(Notifier)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NodeDriver) DeepCopyObject() runtime.Object {
+func (in *Notifier) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5956,13 +8251,13 @@ func (in *NodeDriver) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeDriverList) DeepCopyInto(out *NodeDriverList) {
+func (in *NotifierList) DeepCopyInto(out *NotifierList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]NodeDriver, len(*in))
+		*out = make([]Notifier, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -5970,18 +8265,18 @@ func (in *NodeDriverList) DeepCopyInto(out *NodeDriverList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDriverList.
-func (in *NodeDriverList) DeepCopy() *NodeDriverList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotifierList.
+func (in *NotifierList) DeepCopy() *NotifierList {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeDriverList)
+	out := new(NotifierList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NodeDriverList) DeepCopyObject() runtime.Object {
+func (in *NotifierList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -5989,55 +8284,134 @@ func (in *NodeDriverList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeDriverSpec) DeepCopyInto(out *NodeDriverSpec) {
+func (in *NotifierSpec) DeepCopyInto(out *NotifierSpec) {
 	*out = *in
-	if in.WhitelistDomains != nil {
-		in, out := &in.WhitelistDomains, &out.WhitelistDomains
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.SMTPConfig != nil {
+		in, out := &in.SMTPConfig, &out.SMTPConfig
+		*out = new(SMTPConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SlackConfig != nil {
+		in, out := &in.SlackConfig, &out.SlackConfig
+		*out = new(SlackConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PagerdutyConfig != nil {
+		in, out := &in.PagerdutyConfig, &out.PagerdutyConfig
+		*out = new(PagerdutyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WebhookConfig != nil {
+		in, out := &in.WebhookConfig, &out.WebhookConfig
+		*out = new(WebhookConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WechatConfig != nil {
+		in, out := &in.WechatConfig, &out.WechatConfig
+		*out = new(WechatConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DingtalkConfig != nil {
+		in, out := &in.DingtalkConfig, &out.DingtalkConfig
+		*out = new(DingtalkConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MSTeamsConfig != nil {
+		in, out := &in.MSTeamsConfig, &out.MSTeamsConfig
+		*out = new(MSTeamsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LifecycleEvents != nil {
+		in, out := &in.LifecycleEvents, &out.LifecycleEvents
+		*out = make([]LifecycleEventRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDriverSpec.
-func (in *NodeDriverSpec) DeepCopy() *NodeDriverSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotifierSpec.
+func (in *NotifierSpec) DeepCopy() *NotifierSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeDriverSpec)
+	out := new(NotifierSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeDriverStatus) DeepCopyInto(out *NodeDriverStatus) {
+func (in *NotifierStatus) DeepCopyInto(out *NotifierStatus) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]Condition, len(*in))
-		copy(*out, *in)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotifierStatus.
+func (in *NotifierStatus) DeepCopy() *NotifierStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NotifierStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCApplyInput) DeepCopyInto(out *OIDCApplyInput) {
+	*out = *in
+	in.OIDCConfig.DeepCopyInto(&out.OIDCConfig)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCApplyInput.
+func (in *OIDCApplyInput) DeepCopy() *OIDCApplyInput {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCApplyInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCConfig) DeepCopyInto(out *OIDCConfig) {
+	*out = *in
+	in.AuthConfig.DeepCopyInto(&out.AuthConfig)
+	if in.GroupSearchEnabled != nil {
+		in, out := &in.GroupSearchEnabled, &out.GroupSearchEnabled
+		*out = new(bool)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDriverStatus.
-func (in *NodeDriverStatus) DeepCopy() *NodeDriverStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCConfig.
+func (in *OIDCConfig) DeepCopy() *OIDCConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeDriverStatus)
+	out := new(OIDCConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OIDCConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeList) DeepCopyInto(out *NodeList) {
+func (in *OIDCConfigList) DeepCopyInto(out *OIDCConfigList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Node, len(*in))
+		*out = make([]OIDCConfig, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -6045,18 +8419,18 @@ func (in *NodeList) DeepCopyInto(out *NodeList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeList.
-func (in *NodeList) DeepCopy() *NodeList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCConfigList.
+func (in *OIDCConfigList) DeepCopy() *OIDCConfigList {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeList)
+	out := new(OIDCConfigList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NodeList) DeepCopyObject() runtime.Object {
+func (in *OIDCConfigList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -6064,49 +8438,43 @@ func (in *NodeList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodePlan) DeepCopyInto(out *NodePlan) {
+func (in *OIDCLogin) DeepCopyInto(out *OIDCLogin) {
 	*out = *in
-	if in.Plan != nil {
-		in, out := &in.Plan, &out.Plan
-		*out = new(types.RKEConfigNodePlan)
-		(*in).DeepCopyInto(*out)
-	}
+	out.GenericLogin = in.GenericLogin
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePlan.
-func (in *NodePlan) DeepCopy() *NodePlan {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCLogin.
+func (in *OIDCLogin) DeepCopy() *OIDCLogin {
 	if in == nil {
 		return nil
 	}
-	out := new(NodePlan)
+	out := new(OIDCLogin)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodePool) DeepCopyInto(out *NodePool) {
+func (in *OIDCProvider) DeepCopyInto(out *OIDCProvider) {
 	*out = *in
-	out.Namespaced = in.Namespaced
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.AuthProvider.DeepCopyInto(&out.AuthProvider)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePool.
-func (in *NodePool) DeepCopy() *NodePool {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCProvider.
+func (in *OIDCProvider) DeepCopy() *OIDCProvider {
 	if in == nil {
 		return nil
 	}
-	out := new(NodePool)
+	out := new(OIDCProvider)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NodePool) DeepCopyObject() runtime.Object {
+func (in *OIDCProvider) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -6114,13 +8482,13 @@ func (in *NodePool) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodePoolList) DeepCopyInto(out *NodePoolList) {
+func (in *OIDCProviderList) DeepCopyInto(out *OIDCProviderList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]NodePool, len(*in))
+		*out = make([]OIDCProvider, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -6128,245 +8496,113 @@ func (in *NodePoolList) DeepCopyInto(out *NodePoolList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolList.
-func (in *NodePoolList) DeepCopy() *NodePoolList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCProviderList.
+func (in *OIDCProviderList) DeepCopy() *OIDCProviderList {
 	if in == nil {
 		return nil
 	}
-	out := new(NodePoolList)
+	out := new(OIDCProviderList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NodePoolList) DeepCopyObject() runtime.Object {
+func (in *OIDCProviderList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodePoolSpec) DeepCopyInto(out *NodePoolSpec) {
-	*out = *in
-	if in.NodeLabels != nil {
-		in, out := &in.NodeLabels, &out.NodeLabels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.NodeAnnotations != nil {
-		in, out := &in.NodeAnnotations, &out.NodeAnnotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.NodeTaints != nil {
-		in, out := &in.NodeTaints, &out.NodeTaints
-		*out = make([]corev1.Taint, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolSpec.
-func (in *NodePoolSpec) DeepCopy() *NodePoolSpec {
-	if in == nil {
-		return nil
+		return c
 	}
-	out := new(NodePoolSpec)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodePoolStatus) DeepCopyInto(out *NodePoolStatus) {
+func (in *OIDCTestOutput) DeepCopyInto(out *OIDCTestOutput) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]Condition, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolStatus.
-func (in *NodePoolStatus) DeepCopy() *NodePoolStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCTestOutput.
+func (in *OIDCTestOutput) DeepCopy() *OIDCTestOutput {
 	if in == nil {
 		return nil
 	}
-	out := new(NodePoolStatus)
+	out := new(OIDCTestOutput)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeRule) DeepCopyInto(out *NodeRule) {
+func (in *OKTAConfig) DeepCopyInto(out *OKTAConfig) {
 	*out = *in
-	if in.Selector != nil {
-		in, out := &in.Selector, &out.Selector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
+	in.SamlConfig.DeepCopyInto(&out.SamlConfig)
+	in.OpenLdapConfig.DeepCopyInto(&out.OpenLdapConfig)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeRule.
-func (in *NodeRule) DeepCopy() *NodeRule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OKTAConfig.
+func (in *OKTAConfig) DeepCopy() *OKTAConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeRule)
+	out := new(OKTAConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeSpec) DeepCopyInto(out *NodeSpec) {
+func (in *OKTAProvider) DeepCopyInto(out *OKTAProvider) {
 	*out = *in
-	if in.CustomConfig != nil {
-		in, out := &in.CustomConfig, &out.CustomConfig
-		*out = new(CustomConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	in.InternalNodeSpec.DeepCopyInto(&out.InternalNodeSpec)
-	if in.DesiredNodeTaints != nil {
-		in, out := &in.DesiredNodeTaints, &out.DesiredNodeTaints
-		*out = make([]corev1.Taint, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.UpdateTaintsFromAPI != nil {
-		in, out := &in.UpdateTaintsFromAPI, &out.UpdateTaintsFromAPI
-		*out = new(bool)
-		**out = **in
-	}
-	if in.NodeDrainInput != nil {
-		in, out := &in.NodeDrainInput, &out.NodeDrainInput
-		*out = new(types.NodeDrainInput)
-		(*in).DeepCopyInto(*out)
-	}
-	in.MetadataUpdate.DeepCopyInto(&out.MetadataUpdate)
+	in.SamlProvider.DeepCopyInto(&out.SamlProvider)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSpec.
-func (in *NodeSpec) DeepCopy() *NodeSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OKTAProvider.
+func (in *OKTAProvider) DeepCopy() *OKTAProvider {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeSpec)
+	out := new(OKTAProvider)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeStatus) DeepCopyInto(out *NodeStatus) {
+func (in *OpenLdapConfig) DeepCopyInto(out *OpenLdapConfig) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]NodeCondition, len(*in))
-		copy(*out, *in)
-	}
-	in.InternalNodeStatus.DeepCopyInto(&out.InternalNodeStatus)
-	if in.Requested != nil {
-		in, out := &in.Requested, &out.Requested
-		*out = make(corev1.ResourceList, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val.DeepCopy()
-		}
-	}
-	if in.Limits != nil {
-		in, out := &in.Limits, &out.Limits
-		*out = make(corev1.ResourceList, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val.DeepCopy()
-		}
-	}
-	if in.NodeTemplateSpec != nil {
-		in, out := &in.NodeTemplateSpec, &out.NodeTemplateSpec
-		*out = new(NodeTemplateSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.NodeConfig != nil {
-		in, out := &in.NodeConfig, &out.NodeConfig
-		*out = new(types.RKEConfigNode)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.NodeAnnotations != nil {
-		in, out := &in.NodeAnnotations, &out.NodeAnnotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.NodeLabels != nil {
-		in, out := &in.NodeLabels, &out.NodeLabels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.NodeTaints != nil {
-		in, out := &in.NodeTaints, &out.NodeTaints
-		*out = make([]corev1.Taint, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.DockerInfo != nil {
-		in, out := &in.DockerInfo, &out.DockerInfo
-		*out = new(DockerInfo)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.NodePlan != nil {
-		in, out := &in.NodePlan, &out.NodePlan
-		*out = new(NodePlan)
-		(*in).DeepCopyInto(*out)
-	}
+	in.LdapConfig.DeepCopyInto(&out.LdapConfig)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeStatus.
-func (in *NodeStatus) DeepCopy() *NodeStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenLdapConfig.
+func (in *OpenLdapConfig) DeepCopy() *OpenLdapConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeStatus)
+	out := new(OpenLdapConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeTemplate) DeepCopyInto(out *NodeTemplate) {
+func (in *OpenLdapProvider) DeepCopyInto(out *OpenLdapProvider) {
 	*out = *in
-	out.Namespaced = in.Namespaced
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.AuthProvider.DeepCopyInto(&out.AuthProvider)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTemplate.
-func (in *NodeTemplate) DeepCopy() *NodeTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenLdapProvider.
+func (in *OpenLdapProvider) DeepCopy() *OpenLdapProvider {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeTemplate)
+	out := new(OpenLdapProvider)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NodeTemplate) DeepCopyObject() runtime.Object {
+func (in *OpenLdapProvider) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -6374,29 +8610,13 @@ func (in *NodeTemplate) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeTemplateCondition) DeepCopyInto(out *NodeTemplateCondition) {
-	*out = *in
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTemplateCondition.
-func (in *NodeTemplateCondition) DeepCopy() *NodeTemplateCondition {
-	if in == nil {
-		return nil
-	}
-	out := new(NodeTemplateCondition)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeTemplateList) DeepCopyInto(out *NodeTemplateList) {
+func (in *OpenLdapProviderList) DeepCopyInto(out *OpenLdapProviderList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]NodeTemplate, len(*in))
+		*out = make([]OpenLdapProvider, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -6404,18 +8624,18 @@ func (in *NodeTemplateList) DeepCopyInto(out *NodeTemplateList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTemplateList.
-func (in *NodeTemplateList) DeepCopy() *NodeTemplateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenLdapProviderList.
+func (in *OpenLdapProviderList) DeepCopy() *OpenLdapProviderList {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeTemplateList)
+	out := new(OpenLdapProviderList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NodeTemplateList) DeepCopyObject() runtime.Object {
+func (in *OpenLdapProviderList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -6423,124 +8643,114 @@ func (in *NodeTemplateList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeTemplateSpec) DeepCopyInto(out *NodeTemplateSpec) {
+func (in *OpenLdapTestAndApplyInput) DeepCopyInto(out *OpenLdapTestAndApplyInput) {
 	*out = *in
-	if in.NodeTaints != nil {
-		in, out := &in.NodeTaints, &out.NodeTaints
-		*out = make([]corev1.Taint, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	in.NodeCommonParams.DeepCopyInto(&out.NodeCommonParams)
+	in.LdapTestAndApplyInput.DeepCopyInto(&out.LdapTestAndApplyInput)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTemplateSpec.
-func (in *NodeTemplateSpec) DeepCopy() *NodeTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenLdapTestAndApplyInput.
+func (in *OpenLdapTestAndApplyInput) DeepCopy() *OpenLdapTestAndApplyInput {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeTemplateSpec)
+	out := new(OpenLdapTestAndApplyInput)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NodeTemplateStatus) DeepCopyInto(out *NodeTemplateStatus) {
+func (in *PagerdutyConfig) DeepCopyInto(out *PagerdutyConfig) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]NodeTemplateCondition, len(*in))
-		copy(*out, *in)
+	if in.HTTPClientConfig != nil {
+		in, out := &in.HTTPClientConfig, &out.HTTPClientConfig
+		*out = new(HTTPClientConfig)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTemplateStatus.
-func (in *NodeTemplateStatus) DeepCopy() *NodeTemplateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PagerdutyConfig.
+func (in *PagerdutyConfig) DeepCopy() *PagerdutyConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(NodeTemplateStatus)
+	out := new(PagerdutyConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Notification) DeepCopyInto(out *Notification) {
+func (in *PingConfig) DeepCopyInto(out *PingConfig) {
 	*out = *in
-	if in.SMTPConfig != nil {
-		in, out := &in.SMTPConfig, &out.SMTPConfig
-		*out = new(SMTPConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.SlackConfig != nil {
-		in, out := &in.SlackConfig, &out.SlackConfig
-		*out = new(SlackConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.PagerdutyConfig != nil {
-		in, out := &in.PagerdutyConfig, &out.PagerdutyConfig
-		*out = new(PagerdutyConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.WebhookConfig != nil {
-		in, out := &in.WebhookConfig, &out.WebhookConfig
-		*out = new(WebhookConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.WechatConfig != nil {
-		in, out := &in.WechatConfig, &out.WechatConfig
-		*out = new(WechatConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.DingtalkConfig != nil {
-		in, out := &in.DingtalkConfig, &out.DingtalkConfig
-		*out = new(DingtalkConfig)
-		(*in).DeepCopyInto(*out)
+	in.SamlConfig.DeepCopyInto(&out.SamlConfig)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingConfig.
+func (in *PingConfig) DeepCopy() *PingConfig {
+	if in == nil {
+		return nil
 	}
-	if in.MSTeamsConfig != nil {
-		in, out := &in.MSTeamsConfig, &out.MSTeamsConfig
-		*out = new(MSTeamsConfig)
-		(*in).DeepCopyInto(*out)
+	out := new(PingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingProvider) DeepCopyInto(out *PingProvider) {
+	*out = *in
+	in.SamlProvider.DeepCopyInto(&out.SamlProvider)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingProvider.
+func (in *PingProvider) DeepCopy() *PingProvider {
+	if in == nil {
+		return nil
 	}
+	out := new(PingProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodRule) DeepCopyInto(out *PodRule) {
+	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Notification.
-func (in *Notification) DeepCopy() *Notification {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodRule.
+func (in *PodRule) DeepCopy() *PodRule {
 	if in == nil {
 		return nil
 	}
-	out := new(Notification)
+	out := new(PodRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Notifier) DeepCopyInto(out *Notifier) {
+func (in *PodSecurityAdmissionConfigurationTemplate) DeepCopyInto(out *PodSecurityAdmissionConfigurationTemplate) {
 	*out = *in
-	out.Namespaced = in.Namespaced
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Configuration.DeepCopyInto(&out.Configuration)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Notifier.
-func (in *Notifier) DeepCopy() *Notifier {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityAdmissionConfigurationTemplate.
+func (in *PodSecurityAdmissionConfigurationTemplate) DeepCopy() *PodSecurityAdmissionConfigurationTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(Notifier)
+	out := new(PodSecurityAdmissionConfigurationTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Notifier) DeepCopyObject() runtime.Object {
+func (in *PodSecurityAdmissionConfigurationTemplate) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -6548,13 +8758,60 @@ func (in *Notifier) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NotifierList) DeepCopyInto(out *NotifierList) {
+func (in *PodSecurityAdmissionConfigurationTemplateDefaults) DeepCopyInto(out *PodSecurityAdmissionConfigurationTemplateDefaults) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityAdmissionConfigurationTemplateDefaults.
+func (in *PodSecurityAdmissionConfigurationTemplateDefaults) DeepCopy() *PodSecurityAdmissionConfigurationTemplateDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSecurityAdmissionConfigurationTemplateDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSecurityAdmissionConfigurationTemplateExemptions) DeepCopyInto(out *PodSecurityAdmissionConfigurationTemplateExemptions) {
+	*out = *in
+	if in.Usernames != nil {
+		in, out := &in.Usernames, &out.Usernames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RuntimeClasses != nil {
+		in, out := &in.RuntimeClasses, &out.RuntimeClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityAdmissionConfigurationTemplateExemptions.
+func (in *PodSecurityAdmissionConfigurationTemplateExemptions) DeepCopy() *PodSecurityAdmissionConfigurationTemplateExemptions {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSecurityAdmissionConfigurationTemplateExemptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSecurityAdmissionConfigurationTemplateList) DeepCopyInto(out *PodSecurityAdmissionConfigurationTemplateList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Notifier, len(*in))
+		*out = make([]PodSecurityAdmissionConfigurationTemplate, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -6562,18 +8819,18 @@ func (in *NotifierList) DeepCopyInto(out *NotifierList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotifierList.
-func (in *NotifierList) DeepCopy() *NotifierList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityAdmissionConfigurationTemplateList.
+func (in *PodSecurityAdmissionConfigurationTemplateList) DeepCopy() *PodSecurityAdmissionConfigurationTemplateList {
 	if in == nil {
 		return nil
 	}
-	out := new(NotifierList)
+	out := new(PodSecurityAdmissionConfigurationTemplateList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NotifierList) DeepCopyObject() runtime.Object {
+func (in *PodSecurityAdmissionConfigurationTemplateList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -6581,113 +8838,104 @@ func (in *NotifierList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NotifierSpec) DeepCopyInto(out *NotifierSpec) {
+func (in *PodSecurityAdmissionConfigurationTemplateSpec) DeepCopyInto(out *PodSecurityAdmissionConfigurationTemplateSpec) {
 	*out = *in
-	if in.SMTPConfig != nil {
-		in, out := &in.SMTPConfig, &out.SMTPConfig
-		*out = new(SMTPConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.SlackConfig != nil {
-		in, out := &in.SlackConfig, &out.SlackConfig
-		*out = new(SlackConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.PagerdutyConfig != nil {
-		in, out := &in.PagerdutyConfig, &out.PagerdutyConfig
-		*out = new(PagerdutyConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.WebhookConfig != nil {
-		in, out := &in.WebhookConfig, &out.WebhookConfig
-		*out = new(WebhookConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.WechatConfig != nil {
-		in, out := &in.WechatConfig, &out.WechatConfig
-		*out = new(WechatConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.DingtalkConfig != nil {
-		in, out := &in.DingtalkConfig, &out.DingtalkConfig
-		*out = new(DingtalkConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.MSTeamsConfig != nil {
-		in, out := &in.MSTeamsConfig, &out.MSTeamsConfig
-		*out = new(MSTeamsConfig)
-		(*in).DeepCopyInto(*out)
-	}
+	out.Defaults = in.Defaults
+	in.Exemptions.DeepCopyInto(&out.Exemptions)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotifierSpec.
-func (in *NotifierSpec) DeepCopy() *NotifierSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityAdmissionConfigurationTemplateSpec.
+func (in *PodSecurityAdmissionConfigurationTemplateSpec) DeepCopy() *PodSecurityAdmissionConfigurationTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NotifierSpec)
+	out := new(PodSecurityAdmissionConfigurationTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NotifierStatus) DeepCopyInto(out *NotifierStatus) {
+func (in *PodSecurityPolicyTemplate) DeepCopyInto(out *PodSecurityPolicyTemplate) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotifierStatus.
-func (in *NotifierStatus) DeepCopy() *NotifierStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityPolicyTemplate.
+func (in *PodSecurityPolicyTemplate) DeepCopy() *PodSecurityPolicyTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(NotifierStatus)
+	out := new(PodSecurityPolicyTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PodSecurityPolicyTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OIDCApplyInput) DeepCopyInto(out *OIDCApplyInput) {
+func (in *PodSecurityPolicyTemplateList) DeepCopyInto(out *PodSecurityPolicyTemplateList) {
 	*out = *in
-	in.OIDCConfig.DeepCopyInto(&out.OIDCConfig)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PodSecurityPolicyTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCApplyInput.
-func (in *OIDCApplyInput) DeepCopy() *OIDCApplyInput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityPolicyTemplateList.
+func (in *PodSecurityPolicyTemplateList) DeepCopy() *PodSecurityPolicyTemplateList {
 	if in == nil {
 		return nil
 	}
-	out := new(OIDCApplyInput)
+	out := new(PodSecurityPolicyTemplateList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PodSecurityPolicyTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OIDCConfig) DeepCopyInto(out *OIDCConfig) {
+func (in *PodSecurityPolicyTemplateProjectBinding) DeepCopyInto(out *PodSecurityPolicyTemplateProjectBinding) {
 	*out = *in
-	in.AuthConfig.DeepCopyInto(&out.AuthConfig)
-	if in.GroupSearchEnabled != nil {
-		in, out := &in.GroupSearchEnabled, &out.GroupSearchEnabled
-		*out = new(bool)
-		**out = **in
-	}
+	out.Namespaced = in.Namespaced
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCConfig.
-func (in *OIDCConfig) DeepCopy() *OIDCConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityPolicyTemplateProjectBinding.
+func (in *PodSecurityPolicyTemplateProjectBinding) DeepCopy() *PodSecurityPolicyTemplateProjectBinding {
 	if in == nil {
 		return nil
 	}
-	out := new(OIDCConfig)
+	out := new(PodSecurityPolicyTemplateProjectBinding)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OIDCConfig) DeepCopyObject() runtime.Object {
+func (in *PodSecurityPolicyTemplateProjectBinding) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -6695,13 +8943,13 @@ func (in *OIDCConfig) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OIDCConfigList) DeepCopyInto(out *OIDCConfigList) {
+func (in *PodSecurityPolicyTemplateProjectBindingList) DeepCopyInto(out *PodSecurityPolicyTemplateProjectBindingList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]OIDCConfig, len(*in))
+		*out = make([]PodSecurityPolicyTemplateProjectBinding, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -6709,18 +8957,18 @@ func (in *OIDCConfigList) DeepCopyInto(out *OIDCConfigList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCConfigList.
-func (in *OIDCConfigList) DeepCopy() *OIDCConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityPolicyTemplateProjectBindingList.
+func (in *PodSecurityPolicyTemplateProjectBindingList) DeepCopy() *PodSecurityPolicyTemplateProjectBindingList {
 	if in == nil {
 		return nil
 	}
-	out := new(OIDCConfigList)
+	out := new(PodSecurityPolicyTemplateProjectBindingList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OIDCConfigList) DeepCopyObject() runtime.Object {
+func (in *PodSecurityPolicyTemplateProjectBindingList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -6728,57 +8976,57 @@ func (in *OIDCConfigList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OIDCLogin) DeepCopyInto(out *OIDCLogin) {
+func (in *PolicyBundle) DeepCopyInto(out *PolicyBundle) {
 	*out = *in
-	out.GenericLogin = in.GenericLogin
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCLogin.
-func (in *OIDCLogin) DeepCopy() *OIDCLogin {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyBundle.
+func (in *PolicyBundle) DeepCopy() *PolicyBundle {
 	if in == nil {
 		return nil
 	}
-	out := new(OIDCLogin)
+	out := new(PolicyBundle)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PolicyBundle) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OIDCProvider) DeepCopyInto(out *OIDCProvider) {
+func (in *PolicyBundleClusterStatus) DeepCopyInto(out *PolicyBundleClusterStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.AuthProvider.DeepCopyInto(&out.AuthProvider)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCProvider.
-func (in *OIDCProvider) DeepCopy() *OIDCProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyBundleClusterStatus.
+func (in *PolicyBundleClusterStatus) DeepCopy() *PolicyBundleClusterStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(OIDCProvider)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OIDCProvider) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
+	out := new(PolicyBundleClusterStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OIDCProviderList) DeepCopyInto(out *OIDCProviderList) {
+func (in *PolicyBundleList) DeepCopyInto(out *PolicyBundleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]OIDCProvider, len(*in))
+		*out = make([]PolicyBundle, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -6786,18 +9034,18 @@ func (in *OIDCProviderList) DeepCopyInto(out *OIDCProviderList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCProviderList.
-func (in *OIDCProviderList) DeepCopy() *OIDCProviderList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyBundleList.
+func (in *PolicyBundleList) DeepCopy() *PolicyBundleList {
 	if in == nil {
 		return nil
 	}
-	out := new(OIDCProviderList)
+	out := new(PolicyBundleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OIDCProviderList) DeepCopyObject() runtime.Object {
+func (in *PolicyBundleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -6805,94 +9053,103 @@ func (in *OIDCProviderList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OIDCTestOutput) DeepCopyInto(out *OIDCTestOutput) {
+func (in *PolicyBundlePolicy) DeepCopyInto(out *PolicyBundlePolicy) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCTestOutput.
-func (in *OIDCTestOutput) DeepCopy() *OIDCTestOutput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyBundlePolicy.
+func (in *PolicyBundlePolicy) DeepCopy() *PolicyBundlePolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(OIDCTestOutput)
+	out := new(PolicyBundlePolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OKTAConfig) DeepCopyInto(out *OKTAConfig) {
+func (in *PolicyBundleSpec) DeepCopyInto(out *PolicyBundleSpec) {
 	*out = *in
-	in.SamlConfig.DeepCopyInto(&out.SamlConfig)
-	in.OpenLdapConfig.DeepCopyInto(&out.OpenLdapConfig)
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OKTAConfig.
-func (in *OKTAConfig) DeepCopy() *OKTAConfig {
-	if in == nil {
-		return nil
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]PolicyBundlePolicy, len(*in))
+		copy(*out, *in)
+	}
+	if in.RolloutStrategy != nil {
+		in, out := &in.RolloutStrategy, &out.RolloutStrategy
+		*out = new(v1alpha1.RolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]v1alpha1.BundleTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	out := new(OKTAConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OKTAProvider) DeepCopyInto(out *OKTAProvider) {
-	*out = *in
-	in.SamlProvider.DeepCopyInto(&out.SamlProvider)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OKTAProvider.
-func (in *OKTAProvider) DeepCopy() *OKTAProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyBundleSpec.
+func (in *PolicyBundleSpec) DeepCopy() *PolicyBundleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OKTAProvider)
+	out := new(PolicyBundleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenLdapConfig) DeepCopyInto(out *OpenLdapConfig) {
+func (in *PolicyBundleStatus) DeepCopyInto(out *PolicyBundleStatus) {
 	*out = *in
-	in.LdapConfig.DeepCopyInto(&out.LdapConfig)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]PolicyBundleClusterStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenLdapConfig.
-func (in *OpenLdapConfig) DeepCopy() *OpenLdapConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyBundleStatus.
+func (in *PolicyBundleStatus) DeepCopy() *PolicyBundleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenLdapConfig)
+	out := new(PolicyBundleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenLdapProvider) DeepCopyInto(out *OpenLdapProvider) {
+func (in *Preference) DeepCopyInto(out *Preference) {
 	*out = *in
+	out.Namespaced = in.Namespaced
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.AuthProvider.DeepCopyInto(&out.AuthProvider)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenLdapProvider.
-func (in *OpenLdapProvider) DeepCopy() *OpenLdapProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Preference.
+func (in *Preference) DeepCopy() *Preference {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenLdapProvider)
+	out := new(Preference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OpenLdapProvider) DeepCopyObject() runtime.Object {
+func (in *Preference) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -6900,13 +9157,13 @@ func (in *OpenLdapProvider) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenLdapProviderList) DeepCopyInto(out *OpenLdapProviderList) {
+func (in *PreferenceList) DeepCopyInto(out *PreferenceList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]OpenLdapProvider, len(*in))
+		*out = make([]Preference, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -6914,18 +9171,18 @@ func (in *OpenLdapProviderList) DeepCopyInto(out *OpenLdapProviderList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenLdapProviderList.
-func (in *OpenLdapProviderList) DeepCopy() *OpenLdapProviderList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreferenceList.
+func (in *PreferenceList) DeepCopy() *PreferenceList {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenLdapProviderList)
+	out := new(PreferenceList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OpenLdapProviderList) DeepCopyObject() runtime.Object {
+func (in *PreferenceList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -6933,114 +9190,117 @@ func (in *OpenLdapProviderList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenLdapTestAndApplyInput) DeepCopyInto(out *OpenLdapTestAndApplyInput) {
+func (in *Principal) DeepCopyInto(out *Principal) {
 	*out = *in
-	in.LdapTestAndApplyInput.DeepCopyInto(&out.LdapTestAndApplyInput)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.ExtraInfo != nil {
+		in, out := &in.ExtraInfo, &out.ExtraInfo
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenLdapTestAndApplyInput.
-func (in *OpenLdapTestAndApplyInput) DeepCopy() *OpenLdapTestAndApplyInput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Principal.
+func (in *Principal) DeepCopy() *Principal {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenLdapTestAndApplyInput)
+	out := new(Principal)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PagerdutyConfig) DeepCopyInto(out *PagerdutyConfig) {
-	*out = *in
-	if in.HTTPClientConfig != nil {
-		in, out := &in.HTTPClientConfig, &out.HTTPClientConfig
-		*out = new(HTTPClientConfig)
-		**out = **in
-	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PagerdutyConfig.
-func (in *PagerdutyConfig) DeepCopy() *PagerdutyConfig {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Principal) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(PagerdutyConfig)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PingConfig) DeepCopyInto(out *PingConfig) {
+func (in *PrincipalList) DeepCopyInto(out *PrincipalList) {
 	*out = *in
-	in.SamlConfig.DeepCopyInto(&out.SamlConfig)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Principal, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingConfig.
-func (in *PingConfig) DeepCopy() *PingConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrincipalList.
+func (in *PrincipalList) DeepCopy() *PrincipalList {
 	if in == nil {
 		return nil
 	}
-	out := new(PingConfig)
+	out := new(PrincipalList)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PingProvider) DeepCopyInto(out *PingProvider) {
-	*out = *in
-	in.SamlProvider.DeepCopyInto(&out.SamlProvider)
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingProvider.
-func (in *PingProvider) DeepCopy() *PingProvider {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PrincipalList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(PingProvider)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodRule) DeepCopyInto(out *PodRule) {
+func (in *Principals) DeepCopyInto(out *Principals) {
 	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Principal, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodRule.
-func (in *PodRule) DeepCopy() *PodRule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Principals.
+func (in *Principals) DeepCopy() *Principals {
 	if in == nil {
 		return nil
 	}
-	out := new(PodRule)
+	out := new(Principals)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodSecurityAdmissionConfigurationTemplate) DeepCopyInto(out *PodSecurityAdmissionConfigurationTemplate) {
+func (in *Project) DeepCopyInto(out *Project) {
 	*out = *in
+	out.Namespaced = in.Namespaced
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Configuration.DeepCopyInto(&out.Configuration)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityAdmissionConfigurationTemplate.
-func (in *PodSecurityAdmissionConfigurationTemplate) DeepCopy() *PodSecurityAdmissionConfigurationTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Project.
+func (in *Project) DeepCopy() *Project {
 	if in == nil {
 		return nil
 	}
-	out := new(PodSecurityAdmissionConfigurationTemplate)
+	out := new(Project)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PodSecurityAdmissionConfigurationTemplate) DeepCopyObject() runtime.Object {
+func (in *Project) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -7048,79 +9308,28 @@ func (in *PodSecurityAdmissionConfigurationTemplate) DeepCopyObject() runtime.Ob
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodSecurityAdmissionConfigurationTemplateDefaults) DeepCopyInto(out *PodSecurityAdmissionConfigurationTemplateDefaults) {
-	*out = *in
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityAdmissionConfigurationTemplateDefaults.
-func (in *PodSecurityAdmissionConfigurationTemplateDefaults) DeepCopy() *PodSecurityAdmissionConfigurationTemplateDefaults {
-	if in == nil {
-		return nil
-	}
-	out := new(PodSecurityAdmissionConfigurationTemplateDefaults)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodSecurityAdmissionConfigurationTemplateExemptions) DeepCopyInto(out *PodSecurityAdmissionConfigurationTemplateExemptions) {
-	*out = *in
-	if in.Usernames != nil {
-		in, out := &in.Usernames, &out.Usernames
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.RuntimeClasses != nil {
-		in, out := &in.RuntimeClasses, &out.RuntimeClasses
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Namespaces != nil {
-		in, out := &in.Namespaces, &out.Namespaces
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityAdmissionConfigurationTemplateExemptions.
-func (in *PodSecurityAdmissionConfigurationTemplateExemptions) DeepCopy() *PodSecurityAdmissionConfigurationTemplateExemptions {
-	if in == nil {
-		return nil
-	}
-	out := new(PodSecurityAdmissionConfigurationTemplateExemptions)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodSecurityAdmissionConfigurationTemplateList) DeepCopyInto(out *PodSecurityAdmissionConfigurationTemplateList) {
+func (in *ProjectAlert) DeepCopyInto(out *ProjectAlert) {
 	*out = *in
+	out.Namespaced = in.Namespaced
 	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]PodSecurityAdmissionConfigurationTemplate, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
 	return
 }
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityAdmissionConfigurationTemplateList.
-func (in *PodSecurityAdmissionConfigurationTemplateList) DeepCopy() *PodSecurityAdmissionConfigurationTemplateList {
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectAlert.
+func (in *ProjectAlert) DeepCopy() *ProjectAlert {
 	if in == nil {
 		return nil
 	}
-	out := new(PodSecurityAdmissionConfigurationTemplateList)
+	out := new(ProjectAlert)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PodSecurityAdmissionConfigurationTemplateList) DeepCopyObject() runtime.Object {
+func (in *ProjectAlert) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -7128,44 +9337,61 @@ func (in *PodSecurityAdmissionConfigurationTemplateList) DeepCopyObject() runtim
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodSecurityAdmissionConfigurationTemplateSpec) DeepCopyInto(out *PodSecurityAdmissionConfigurationTemplateSpec) {
+func (in *ProjectAlertGroup) DeepCopyInto(out *ProjectAlertGroup) {
 	*out = *in
-	out.Defaults = in.Defaults
-	in.Exemptions.DeepCopyInto(&out.Exemptions)
+	out.Namespaced = in.Namespaced
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityAdmissionConfigurationTemplateSpec.
-func (in *PodSecurityAdmissionConfigurationTemplateSpec) DeepCopy() *PodSecurityAdmissionConfigurationTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectAlertGroup.
+func (in *ProjectAlertGroup) DeepCopy() *ProjectAlertGroup {
 	if in == nil {
 		return nil
 	}
-	out := new(PodSecurityAdmissionConfigurationTemplateSpec)
+	out := new(ProjectAlertGroup)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProjectAlertGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodSecurityPolicyTemplate) DeepCopyInto(out *PodSecurityPolicyTemplate) {
+func (in *ProjectAlertGroupList) DeepCopyInto(out *ProjectAlertGroupList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ProjectAlertGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityPolicyTemplate.
-func (in *PodSecurityPolicyTemplate) DeepCopy() *PodSecurityPolicyTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectAlertGroupList.
+func (in *ProjectAlertGroupList) DeepCopy() *ProjectAlertGroupList {
 	if in == nil {
 		return nil
 	}
-	out := new(PodSecurityPolicyTemplate)
+	out := new(ProjectAlertGroupList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PodSecurityPolicyTemplate) DeepCopyObject() runtime.Object {
+func (in *ProjectAlertGroupList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -7173,13 +9399,13 @@ func (in *PodSecurityPolicyTemplate) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodSecurityPolicyTemplateList) DeepCopyInto(out *PodSecurityPolicyTemplateList) {
+func (in *ProjectAlertList) DeepCopyInto(out *ProjectAlertList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]PodSecurityPolicyTemplate, len(*in))
+		*out = make([]ProjectAlert, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -7187,18 +9413,18 @@ func (in *PodSecurityPolicyTemplateList) DeepCopyInto(out *PodSecurityPolicyTemp
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityPolicyTemplateList.
-func (in *PodSecurityPolicyTemplateList) DeepCopy() *PodSecurityPolicyTemplateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectAlertList.
+func (in *ProjectAlertList) DeepCopy() *ProjectAlertList {
 	if in == nil {
 		return nil
 	}
-	out := new(PodSecurityPolicyTemplateList)
+	out := new(ProjectAlertList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PodSecurityPolicyTemplateList) DeepCopyObject() runtime.Object {
+func (in *ProjectAlertList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -7206,26 +9432,28 @@ func (in *PodSecurityPolicyTemplateList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodSecurityPolicyTemplateProjectBinding) DeepCopyInto(out *PodSecurityPolicyTemplateProjectBinding) {
+func (in *ProjectAlertRule) DeepCopyInto(out *ProjectAlertRule) {
 	*out = *in
 	out.Namespaced = in.Namespaced
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityPolicyTemplateProjectBinding.
-func (in *PodSecurityPolicyTemplateProjectBinding) DeepCopy() *PodSecurityPolicyTemplateProjectBinding {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectAlertRule.
+func (in *ProjectAlertRule) DeepCopy() *ProjectAlertRule {
 	if in == nil {
 		return nil
 	}
-	out := new(PodSecurityPolicyTemplateProjectBinding)
+	out := new(ProjectAlertRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PodSecurityPolicyTemplateProjectBinding) DeepCopyObject() runtime.Object {
+func (in *ProjectAlertRule) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -7233,13 +9461,13 @@ func (in *PodSecurityPolicyTemplateProjectBinding) DeepCopyObject() runtime.Obje
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodSecurityPolicyTemplateProjectBindingList) DeepCopyInto(out *PodSecurityPolicyTemplateProjectBindingList) {
+func (in *ProjectAlertRuleList) DeepCopyInto(out *ProjectAlertRuleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]PodSecurityPolicyTemplateProjectBinding, len(*in))
+		*out = make([]ProjectAlertRule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -7247,18 +9475,18 @@ func (in *PodSecurityPolicyTemplateProjectBindingList) DeepCopyInto(out *PodSecu
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityPolicyTemplateProjectBindingList.
-func (in *PodSecurityPolicyTemplateProjectBindingList) DeepCopy() *PodSecurityPolicyTemplateProjectBindingList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectAlertRuleList.
+func (in *ProjectAlertRuleList) DeepCopy() *ProjectAlertRuleList {
 	if in == nil {
 		return nil
 	}
-	out := new(PodSecurityPolicyTemplateProjectBindingList)
+	out := new(ProjectAlertRuleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PodSecurityPolicyTemplateProjectBindingList) DeepCopyObject() runtime.Object {
+func (in *ProjectAlertRuleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -7266,92 +9494,84 @@ func (in *PodSecurityPolicyTemplateProjectBindingList) DeepCopyObject() runtime.
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Preference) DeepCopyInto(out *Preference) {
+func (in *ProjectAlertRuleSpec) DeepCopyInto(out *ProjectAlertRuleSpec) {
 	*out = *in
-	out.Namespaced = in.Namespaced
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.CommonRuleField.DeepCopyInto(&out.CommonRuleField)
+	if in.PodRule != nil {
+		in, out := &in.PodRule, &out.PodRule
+		*out = new(PodRule)
+		**out = **in
+	}
+	if in.WorkloadRule != nil {
+		in, out := &in.WorkloadRule, &out.WorkloadRule
+		*out = new(WorkloadRule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MetricRule != nil {
+		in, out := &in.MetricRule, &out.MetricRule
+		*out = new(MetricRule)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Preference.
-func (in *Preference) DeepCopy() *Preference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectAlertRuleSpec.
+func (in *ProjectAlertRuleSpec) DeepCopy() *ProjectAlertRuleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Preference)
+	out := new(ProjectAlertRuleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Preference) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PreferenceList) DeepCopyInto(out *PreferenceList) {
+func (in *ProjectAlertSpec) DeepCopyInto(out *ProjectAlertSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Preference, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	in.AlertCommonSpec.DeepCopyInto(&out.AlertCommonSpec)
+	if in.TargetWorkload != nil {
+		in, out := &in.TargetWorkload, &out.TargetWorkload
+		*out = new(TargetWorkload)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TargetPod != nil {
+		in, out := &in.TargetPod, &out.TargetPod
+		*out = new(TargetPod)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreferenceList.
-func (in *PreferenceList) DeepCopy() *PreferenceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectAlertSpec.
+func (in *ProjectAlertSpec) DeepCopy() *ProjectAlertSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PreferenceList)
+	out := new(ProjectAlertSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PreferenceList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Principal) DeepCopyInto(out *Principal) {
+func (in *ProjectCatalog) DeepCopyInto(out *ProjectCatalog) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	if in.ExtraInfo != nil {
-		in, out := &in.ExtraInfo, &out.ExtraInfo
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
+	out.Namespaced = in.Namespaced
+	in.Catalog.DeepCopyInto(&out.Catalog)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Principal.
-func (in *Principal) DeepCopy() *Principal {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectCatalog.
+func (in *ProjectCatalog) DeepCopy() *ProjectCatalog {
 	if in == nil {
 		return nil
 	}
-	out := new(Principal)
+	out := new(ProjectCatalog)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Principal) DeepCopyObject() runtime.Object {
+func (in *ProjectCatalog) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -7359,13 +9579,13 @@ func (in *Principal) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PrincipalList) DeepCopyInto(out *PrincipalList) {
+func (in *ProjectCatalogList) DeepCopyInto(out *ProjectCatalogList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Principal, len(*in))
+		*out = make([]ProjectCatalog, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -7373,18 +9593,18 @@ func (in *PrincipalList) DeepCopyInto(out *PrincipalList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrincipalList.
-func (in *PrincipalList) DeepCopy() *PrincipalList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectCatalogList.
+func (in *ProjectCatalogList) DeepCopy() *ProjectCatalogList {
 	if in == nil {
 		return nil
 	}
-	out := new(PrincipalList)
+	out := new(ProjectCatalogList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PrincipalList) DeepCopyObject() runtime.Object {
+func (in *ProjectCatalogList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -7392,80 +9612,70 @@ func (in *PrincipalList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Principals) DeepCopyInto(out *Principals) {
+func (in *ProjectCondition) DeepCopyInto(out *ProjectCondition) {
 	*out = *in
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Principal, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Principals.
-func (in *Principals) DeepCopy() *Principals {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectCondition.
+func (in *ProjectCondition) DeepCopy() *ProjectCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(Principals)
+	out := new(ProjectCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Project) DeepCopyInto(out *Project) {
+func (in *ProjectGroupSpec) DeepCopyInto(out *ProjectGroupSpec) {
 	*out = *in
-	out.Namespaced = in.Namespaced
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Recipients != nil {
+		in, out := &in.Recipients, &out.Recipients
+		*out = make([]Recipient, len(*in))
+		copy(*out, *in)
+	}
+	out.CommonGroupField = in.CommonGroupField
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Project.
-func (in *Project) DeepCopy() *Project {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectGroupSpec.
+func (in *ProjectGroupSpec) DeepCopy() *ProjectGroupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Project)
+	out := new(ProjectGroupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Project) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectAlert) DeepCopyInto(out *ProjectAlert) {
+func (in *ProjectList) DeepCopyInto(out *ProjectList) {
 	*out = *in
-	out.Namespaced = in.Namespaced
 	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Project, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectAlert.
-func (in *ProjectAlert) DeepCopy() *ProjectAlert {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectList.
+func (in *ProjectList) DeepCopy() *ProjectList {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectAlert)
+	out := new(ProjectList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProjectAlert) DeepCopyObject() runtime.Object {
+func (in *ProjectList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -7473,28 +9683,28 @@ func (in *ProjectAlert) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectAlertGroup) DeepCopyInto(out *ProjectAlertGroup) {
+func (in *ProjectLogging) DeepCopyInto(out *ProjectLogging) {
 	*out = *in
 	out.Namespaced = in.Namespaced
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectAlertGroup.
-func (in *ProjectAlertGroup) DeepCopy() *ProjectAlertGroup {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectLogging.
+func (in *ProjectLogging) DeepCopy() *ProjectLogging {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectAlertGroup)
+	out := new(ProjectLogging)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProjectAlertGroup) DeepCopyObject() runtime.Object {
+func (in *ProjectLogging) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -7502,13 +9712,13 @@ func (in *ProjectAlertGroup) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectAlertGroupList) DeepCopyInto(out *ProjectAlertGroupList) {
+func (in *ProjectLoggingList) DeepCopyInto(out *ProjectLoggingList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ProjectAlertGroup, len(*in))
+		*out = make([]ProjectLogging, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -7516,18 +9726,18 @@ func (in *ProjectAlertGroupList) DeepCopyInto(out *ProjectAlertGroupList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectAlertGroupList.
-func (in *ProjectAlertGroupList) DeepCopy() *ProjectAlertGroupList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectLoggingList.
+func (in *ProjectLoggingList) DeepCopy() *ProjectLoggingList {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectAlertGroupList)
+	out := new(ProjectLoggingList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProjectAlertGroupList) DeepCopyObject() runtime.Object {
+func (in *ProjectLoggingList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -7535,61 +9745,83 @@ func (in *ProjectAlertGroupList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectAlertList) DeepCopyInto(out *ProjectAlertList) {
+func (in *ProjectLoggingSpec) DeepCopyInto(out *ProjectLoggingSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ProjectAlert, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	in.LoggingTargets.DeepCopyInto(&out.LoggingTargets)
+	in.LoggingCommonField.DeepCopyInto(&out.LoggingCommonField)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectLoggingSpec.
+func (in *ProjectLoggingSpec) DeepCopy() *ProjectLoggingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectLoggingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectLoggingStatus) DeepCopyInto(out *ProjectLoggingStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]LoggingCondition, len(*in))
+		copy(*out, *in)
 	}
+	in.AppliedSpec.DeepCopyInto(&out.AppliedSpec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectAlertList.
-func (in *ProjectAlertList) DeepCopy() *ProjectAlertList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectLoggingStatus.
+func (in *ProjectLoggingStatus) DeepCopy() *ProjectLoggingStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectAlertList)
+	out := new(ProjectLoggingStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProjectAlertList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectMetricNamesInput) DeepCopyInto(out *ProjectMetricNamesInput) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectMetricNamesInput.
+func (in *ProjectMetricNamesInput) DeepCopy() *ProjectMetricNamesInput {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(ProjectMetricNamesInput)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectAlertRule) DeepCopyInto(out *ProjectAlertRule) {
+func (in *ProjectMonitorGraph) DeepCopyInto(out *ProjectMonitorGraph) {
 	*out = *in
 	out.Namespaced = in.Namespaced
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectAlertRule.
-func (in *ProjectAlertRule) DeepCopy() *ProjectAlertRule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectMonitorGraph.
+func (in *ProjectMonitorGraph) DeepCopy() *ProjectMonitorGraph {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectAlertRule)
+	out := new(ProjectMonitorGraph)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProjectAlertRule) DeepCopyObject() runtime.Object {
+func (in *ProjectMonitorGraph) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -7597,13 +9829,13 @@ func (in *ProjectAlertRule) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectAlertRuleList) DeepCopyInto(out *ProjectAlertRuleList) {
+func (in *ProjectMonitorGraphList) DeepCopyInto(out *ProjectMonitorGraphList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ProjectAlertRule, len(*in))
+		*out = make([]ProjectMonitorGraph, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -7611,18 +9843,18 @@ func (in *ProjectAlertRuleList) DeepCopyInto(out *ProjectAlertRuleList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectAlertRuleList.
-func (in *ProjectAlertRuleList) DeepCopy() *ProjectAlertRuleList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectMonitorGraphList.
+func (in *ProjectMonitorGraphList) DeepCopy() *ProjectMonitorGraphList {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectAlertRuleList)
+	out := new(ProjectMonitorGraphList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProjectAlertRuleList) DeepCopyObject() runtime.Object {
+func (in *ProjectMonitorGraphList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -7630,84 +9862,49 @@ func (in *ProjectAlertRuleList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectAlertRuleSpec) DeepCopyInto(out *ProjectAlertRuleSpec) {
+func (in *ProjectMonitorGraphSpec) DeepCopyInto(out *ProjectMonitorGraphSpec) {
 	*out = *in
-	in.CommonRuleField.DeepCopyInto(&out.CommonRuleField)
-	if in.PodRule != nil {
-		in, out := &in.PodRule, &out.PodRule
-		*out = new(PodRule)
-		**out = **in
-	}
-	if in.WorkloadRule != nil {
-		in, out := &in.WorkloadRule, &out.WorkloadRule
-		*out = new(WorkloadRule)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.MetricRule != nil {
-		in, out := &in.MetricRule, &out.MetricRule
-		*out = new(MetricRule)
-		**out = **in
-	}
+	in.CommonMonitorGraphSpec.DeepCopyInto(&out.CommonMonitorGraphSpec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectAlertRuleSpec.
-func (in *ProjectAlertRuleSpec) DeepCopy() *ProjectAlertRuleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectMonitorGraphSpec.
+func (in *ProjectMonitorGraphSpec) DeepCopy() *ProjectMonitorGraphSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectAlertRuleSpec)
+	out := new(ProjectMonitorGraphSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectAlertSpec) DeepCopyInto(out *ProjectAlertSpec) {
+func (in *ProjectNetworkPolicy) DeepCopyInto(out *ProjectNetworkPolicy) {
 	*out = *in
-	in.AlertCommonSpec.DeepCopyInto(&out.AlertCommonSpec)
-	if in.TargetWorkload != nil {
-		in, out := &in.TargetWorkload, &out.TargetWorkload
-		*out = new(TargetWorkload)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.TargetPod != nil {
-		in, out := &in.TargetPod, &out.TargetPod
-		*out = new(TargetPod)
+	out.Namespaced = in.Namespaced
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(ProjectNetworkPolicyStatus)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectAlertSpec.
-func (in *ProjectAlertSpec) DeepCopy() *ProjectAlertSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(ProjectAlertSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectCatalog) DeepCopyInto(out *ProjectCatalog) {
-	*out = *in
-	out.Namespaced = in.Namespaced
-	in.Catalog.DeepCopyInto(&out.Catalog)
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectCatalog.
-func (in *ProjectCatalog) DeepCopy() *ProjectCatalog {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectNetworkPolicy.
+func (in *ProjectNetworkPolicy) DeepCopy() *ProjectNetworkPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectCatalog)
+	out := new(ProjectNetworkPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProjectCatalog) DeepCopyObject() runtime.Object {
+func (in *ProjectNetworkPolicy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -7715,13 +9912,13 @@ func (in *ProjectCatalog) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectCatalogList) DeepCopyInto(out *ProjectCatalogList) {
+func (in *ProjectNetworkPolicyList) DeepCopyInto(out *ProjectNetworkPolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ProjectCatalog, len(*in))
+		*out = make([]ProjectNetworkPolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -7729,18 +9926,18 @@ func (in *ProjectCatalogList) DeepCopyInto(out *ProjectCatalogList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectCatalogList.
-func (in *ProjectCatalogList) DeepCopy() *ProjectCatalogList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectNetworkPolicyList.
+func (in *ProjectNetworkPolicyList) DeepCopy() *ProjectNetworkPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectCatalogList)
+	out := new(ProjectNetworkPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProjectCatalogList) DeepCopyObject() runtime.Object {
+func (in *ProjectNetworkPolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -7748,99 +9945,109 @@ func (in *ProjectCatalogList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectCondition) DeepCopyInto(out *ProjectCondition) {
+func (in *ProjectNetworkPolicyPeer) DeepCopyInto(out *ProjectNetworkPolicyPeer) {
 	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectCondition.
-func (in *ProjectCondition) DeepCopy() *ProjectCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectNetworkPolicyPeer.
+func (in *ProjectNetworkPolicyPeer) DeepCopy() *ProjectNetworkPolicyPeer {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectCondition)
+	out := new(ProjectNetworkPolicyPeer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectGroupSpec) DeepCopyInto(out *ProjectGroupSpec) {
+func (in *ProjectNetworkPolicySpec) DeepCopyInto(out *ProjectNetworkPolicySpec) {
 	*out = *in
-	if in.Recipients != nil {
-		in, out := &in.Recipients, &out.Recipients
-		*out = make([]Recipient, len(*in))
+	if in.AllowedIngress != nil {
+		in, out := &in.AllowedIngress, &out.AllowedIngress
+		*out = make([]ProjectNetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExemptNamespaces != nil {
+		in, out := &in.ExemptNamespaces, &out.ExemptNamespaces
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	out.CommonGroupField = in.CommonGroupField
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectGroupSpec.
-func (in *ProjectGroupSpec) DeepCopy() *ProjectGroupSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectNetworkPolicySpec.
+func (in *ProjectNetworkPolicySpec) DeepCopy() *ProjectNetworkPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectGroupSpec)
+	out := new(ProjectNetworkPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectList) DeepCopyInto(out *ProjectList) {
+func (in *ProjectNetworkPolicyStatus) DeepCopyInto(out *ProjectNetworkPolicyStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Project, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectList.
-func (in *ProjectList) DeepCopy() *ProjectList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectNetworkPolicyStatus.
+func (in *ProjectNetworkPolicyStatus) DeepCopy() *ProjectNetworkPolicyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectList)
+	out := new(ProjectNetworkPolicyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProjectList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectResourceQuota) DeepCopyInto(out *ProjectResourceQuota) {
+	*out = *in
+	out.Limit = in.Limit
+	out.UsedLimit = in.UsedLimit
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectResourceQuota.
+func (in *ProjectResourceQuota) DeepCopy() *ProjectResourceQuota {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(ProjectResourceQuota)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectLogging) DeepCopyInto(out *ProjectLogging) {
+func (in *ProjectRoleTemplateBinding) DeepCopyInto(out *ProjectRoleTemplateBinding) {
 	*out = *in
 	out.Namespaced = in.Namespaced
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectLogging.
-func (in *ProjectLogging) DeepCopy() *ProjectLogging {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectRoleTemplateBinding.
+func (in *ProjectRoleTemplateBinding) DeepCopy() *ProjectRoleTemplateBinding {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectLogging)
+	out := new(ProjectRoleTemplateBinding)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProjectLogging) DeepCopyObject() runtime.Object {
+func (in *ProjectRoleTemplateBinding) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -7848,13 +10055,13 @@ func (in *ProjectLogging) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectLoggingList) DeepCopyInto(out *ProjectLoggingList) {
+func (in *ProjectRoleTemplateBindingList) DeepCopyInto(out *ProjectRoleTemplateBindingList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ProjectLogging, len(*in))
+		*out = make([]ProjectRoleTemplateBinding, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -7862,18 +10069,18 @@ func (in *ProjectLoggingList) DeepCopyInto(out *ProjectLoggingList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectLoggingList.
-func (in *ProjectLoggingList) DeepCopy() *ProjectLoggingList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectRoleTemplateBindingList.
+func (in *ProjectRoleTemplateBindingList) DeepCopy() *ProjectRoleTemplateBindingList {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectLoggingList)
+	out := new(ProjectRoleTemplateBindingList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProjectLoggingList) DeepCopyObject() runtime.Object {
+func (in *ProjectRoleTemplateBindingList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -7881,83 +10088,112 @@ func (in *ProjectLoggingList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectLoggingSpec) DeepCopyInto(out *ProjectLoggingSpec) {
+func (in *ProjectSpec) DeepCopyInto(out *ProjectSpec) {
 	*out = *in
-	in.LoggingTargets.DeepCopyInto(&out.LoggingTargets)
-	in.LoggingCommonField.DeepCopyInto(&out.LoggingCommonField)
+	if in.ResourceQuota != nil {
+		in, out := &in.ResourceQuota, &out.ResourceQuota
+		*out = new(ProjectResourceQuota)
+		**out = **in
+	}
+	if in.NamespaceDefaultResourceQuota != nil {
+		in, out := &in.NamespaceDefaultResourceQuota, &out.NamespaceDefaultResourceQuota
+		*out = new(NamespaceResourceQuota)
+		**out = **in
+	}
+	if in.ContainerDefaultResourceLimit != nil {
+		in, out := &in.ContainerDefaultResourceLimit, &out.ContainerDefaultResourceLimit
+		*out = new(ContainerResourceLimit)
+		**out = **in
+	}
+	if in.NamespacePolicy != nil {
+		in, out := &in.NamespacePolicy, &out.NamespacePolicy
+		*out = new(NamespacePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectLoggingSpec.
-func (in *ProjectLoggingSpec) DeepCopy() *ProjectLoggingSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectSpec.
+func (in *ProjectSpec) DeepCopy() *ProjectSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectLoggingSpec)
+	out := new(ProjectSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectLoggingStatus) DeepCopyInto(out *ProjectLoggingStatus) {
+func (in *ProjectStatus) DeepCopyInto(out *ProjectStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]LoggingCondition, len(*in))
+		*out = make([]ProjectCondition, len(*in))
 		copy(*out, *in)
 	}
-	in.AppliedSpec.DeepCopyInto(&out.AppliedSpec)
+	if in.MonitoringStatus != nil {
+		in, out := &in.MonitoringStatus, &out.MonitoringStatus
+		*out = new(MonitoringStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectLoggingStatus.
-func (in *ProjectLoggingStatus) DeepCopy() *ProjectLoggingStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectStatus.
+func (in *ProjectStatus) DeepCopy() *ProjectStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectLoggingStatus)
+	out := new(ProjectStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectMetricNamesInput) DeepCopyInto(out *ProjectMetricNamesInput) {
+func (in *ProjectTestInput) DeepCopyInto(out *ProjectTestInput) {
 	*out = *in
+	in.LoggingTargets.DeepCopyInto(&out.LoggingTargets)
+	if in.OutputTags != nil {
+		in, out := &in.OutputTags, &out.OutputTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectMetricNamesInput.
-func (in *ProjectMetricNamesInput) DeepCopy() *ProjectMetricNamesInput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectTestInput.
+func (in *ProjectTestInput) DeepCopy() *ProjectTestInput {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectMetricNamesInput)
+	out := new(ProjectTestInput)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectMonitorGraph) DeepCopyInto(out *ProjectMonitorGraph) {
+func (in *ProvisioningQuota) DeepCopyInto(out *ProvisioningQuota) {
 	*out = *in
-	out.Namespaced = in.Namespaced
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectMonitorGraph.
-func (in *ProjectMonitorGraph) DeepCopy() *ProjectMonitorGraph {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisioningQuota.
+func (in *ProvisioningQuota) DeepCopy() *ProvisioningQuota {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectMonitorGraph)
+	out := new(ProvisioningQuota)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProjectMonitorGraph) DeepCopyObject() runtime.Object {
+func (in *ProvisioningQuota) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -7965,13 +10201,13 @@ func (in *ProjectMonitorGraph) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectMonitorGraphList) DeepCopyInto(out *ProjectMonitorGraphList) {
+func (in *ProvisioningQuotaList) DeepCopyInto(out *ProvisioningQuotaList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ProjectMonitorGraph, len(*in))
+		*out = make([]ProvisioningQuota, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -7979,18 +10215,18 @@ func (in *ProjectMonitorGraphList) DeepCopyInto(out *ProjectMonitorGraphList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectMonitorGraphList.
-func (in *ProjectMonitorGraphList) DeepCopy() *ProjectMonitorGraphList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisioningQuotaList.
+func (in *ProvisioningQuotaList) DeepCopy() *ProvisioningQuotaList {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectMonitorGraphList)
+	out := new(ProvisioningQuotaList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProjectMonitorGraphList) DeepCopyObject() runtime.Object {
+func (in *ProvisioningQuotaList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -7998,173 +10234,234 @@ func (in *ProjectMonitorGraphList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectMonitorGraphSpec) DeepCopyInto(out *ProjectMonitorGraphSpec) {
+func (in *ProvisioningQuotaSpec) DeepCopyInto(out *ProvisioningQuotaSpec) {
 	*out = *in
-	in.CommonMonitorGraphSpec.DeepCopyInto(&out.CommonMonitorGraphSpec)
+	if in.UserNames != nil {
+		in, out := &in.UserNames, &out.UserNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GroupPrincipalNames != nil {
+		in, out := &in.GroupPrincipalNames, &out.GroupPrincipalNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterGroupNames != nil {
+		in, out := &in.ClusterGroupNames, &out.ClusterGroupNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxClusters != nil {
+		in, out := &in.MaxClusters, &out.MaxClusters
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxNodes != nil {
+		in, out := &in.MaxNodes, &out.MaxNodes
+		*out = new(int)
+		**out = **in
+	}
+	if in.AllowedInstanceTypes != nil {
+		in, out := &in.AllowedInstanceTypes, &out.AllowedInstanceTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedRegions != nil {
+		in, out := &in.AllowedRegions, &out.AllowedRegions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectMonitorGraphSpec.
-func (in *ProjectMonitorGraphSpec) DeepCopy() *ProjectMonitorGraphSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisioningQuotaSpec.
+func (in *ProvisioningQuotaSpec) DeepCopy() *ProvisioningQuotaSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectMonitorGraphSpec)
+	out := new(ProvisioningQuotaSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectNetworkPolicy) DeepCopyInto(out *ProjectNetworkPolicy) {
+func (in *PublicEndpoint) DeepCopyInto(out *PublicEndpoint) {
 	*out = *in
-	out.Namespaced = in.Namespaced
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	if in.Status != nil {
-		in, out := &in.Status, &out.Status
-		*out = new(ProjectNetworkPolicyStatus)
-		**out = **in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectNetworkPolicy.
-func (in *ProjectNetworkPolicy) DeepCopy() *ProjectNetworkPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicEndpoint.
+func (in *PublicEndpoint) DeepCopy() *PublicEndpoint {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectNetworkPolicy)
+	out := new(PublicEndpoint)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProjectNetworkPolicy) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectNetworkPolicyList) DeepCopyInto(out *ProjectNetworkPolicyList) {
+func (in *QueryClusterGraph) DeepCopyInto(out *QueryClusterGraph) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ProjectNetworkPolicy, len(*in))
+	if in.Series != nil {
+		in, out := &in.Series, &out.Series
+		*out = make([]*TimeSeries, len(*in))
 		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(TimeSeries)
+				(*in).DeepCopyInto(*out)
+			}
 		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectNetworkPolicyList.
-func (in *ProjectNetworkPolicyList) DeepCopy() *ProjectNetworkPolicyList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryClusterGraph.
+func (in *QueryClusterGraph) DeepCopy() *QueryClusterGraph {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectNetworkPolicyList)
+	out := new(QueryClusterGraph)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProjectNetworkPolicyList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectNetworkPolicySpec) DeepCopyInto(out *ProjectNetworkPolicySpec) {
+func (in *QueryClusterGraphOutput) DeepCopyInto(out *QueryClusterGraphOutput) {
 	*out = *in
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make([]QueryClusterGraph, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectNetworkPolicySpec.
-func (in *ProjectNetworkPolicySpec) DeepCopy() *ProjectNetworkPolicySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryClusterGraphOutput.
+func (in *QueryClusterGraphOutput) DeepCopy() *QueryClusterGraphOutput {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectNetworkPolicySpec)
+	out := new(QueryClusterGraphOutput)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectNetworkPolicyStatus) DeepCopyInto(out *ProjectNetworkPolicyStatus) {
+func (in *QueryClusterMetricInput) DeepCopyInto(out *QueryClusterMetricInput) {
 	*out = *in
+	out.CommonQueryMetricInput = in.CommonQueryMetricInput
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectNetworkPolicyStatus.
-func (in *ProjectNetworkPolicyStatus) DeepCopy() *ProjectNetworkPolicyStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryClusterMetricInput.
+func (in *QueryClusterMetricInput) DeepCopy() *QueryClusterMetricInput {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectNetworkPolicyStatus)
+	out := new(QueryClusterMetricInput)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectResourceQuota) DeepCopyInto(out *ProjectResourceQuota) {
+func (in *QueryGraphInput) DeepCopyInto(out *QueryGraphInput) {
 	*out = *in
-	out.Limit = in.Limit
-	out.UsedLimit = in.UsedLimit
+	if in.MetricParams != nil {
+		in, out := &in.MetricParams, &out.MetricParams
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectResourceQuota.
-func (in *ProjectResourceQuota) DeepCopy() *ProjectResourceQuota {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryGraphInput.
+func (in *QueryGraphInput) DeepCopy() *QueryGraphInput {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectResourceQuota)
+	out := new(QueryGraphInput)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectRoleTemplateBinding) DeepCopyInto(out *ProjectRoleTemplateBinding) {
+func (in *QueryMetricOutput) DeepCopyInto(out *QueryMetricOutput) {
 	*out = *in
-	out.Namespaced = in.Namespaced
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Series != nil {
+		in, out := &in.Series, &out.Series
+		*out = make([]*TimeSeries, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(TimeSeries)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectRoleTemplateBinding.
-func (in *ProjectRoleTemplateBinding) DeepCopy() *ProjectRoleTemplateBinding {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryMetricOutput.
+func (in *QueryMetricOutput) DeepCopy() *QueryMetricOutput {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectRoleTemplateBinding)
+	out := new(QueryMetricOutput)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProjectRoleTemplateBinding) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryProjectGraph) DeepCopyInto(out *QueryProjectGraph) {
+	*out = *in
+	if in.Series != nil {
+		in, out := &in.Series, &out.Series
+		*out = make([]*TimeSeries, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(TimeSeries)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryProjectGraph.
+func (in *QueryProjectGraph) DeepCopy() *QueryProjectGraph {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(QueryProjectGraph)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectRoleTemplateBindingList) DeepCopyInto(out *ProjectRoleTemplateBindingList) {
+func (in *QueryProjectGraphOutput) DeepCopyInto(out *QueryProjectGraphOutput) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ProjectRoleTemplateBinding, len(*in))
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make([]QueryProjectGraph, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -8172,159 +10469,140 @@ func (in *ProjectRoleTemplateBindingList) DeepCopyInto(out *ProjectRoleTemplateB
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectRoleTemplateBindingList.
-func (in *ProjectRoleTemplateBindingList) DeepCopy() *ProjectRoleTemplateBindingList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryProjectGraphOutput.
+func (in *QueryProjectGraphOutput) DeepCopy() *QueryProjectGraphOutput {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectRoleTemplateBindingList)
+	out := new(QueryProjectGraphOutput)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProjectRoleTemplateBindingList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectSpec) DeepCopyInto(out *ProjectSpec) {
+func (in *QueryProjectMetricInput) DeepCopyInto(out *QueryProjectMetricInput) {
 	*out = *in
-	if in.ResourceQuota != nil {
-		in, out := &in.ResourceQuota, &out.ResourceQuota
-		*out = new(ProjectResourceQuota)
-		**out = **in
-	}
-	if in.NamespaceDefaultResourceQuota != nil {
-		in, out := &in.NamespaceDefaultResourceQuota, &out.NamespaceDefaultResourceQuota
-		*out = new(NamespaceResourceQuota)
-		**out = **in
-	}
-	if in.ContainerDefaultResourceLimit != nil {
-		in, out := &in.ContainerDefaultResourceLimit, &out.ContainerDefaultResourceLimit
-		*out = new(ContainerResourceLimit)
-		**out = **in
-	}
+	out.CommonQueryMetricInput = in.CommonQueryMetricInput
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectSpec.
-func (in *ProjectSpec) DeepCopy() *ProjectSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryProjectMetricInput.
+func (in *QueryProjectMetricInput) DeepCopy() *QueryProjectMetricInput {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectSpec)
+	out := new(QueryProjectMetricInput)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectStatus) DeepCopyInto(out *ProjectStatus) {
+func (in *Question) DeepCopyInto(out *Question) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]ProjectCondition, len(*in))
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.MonitoringStatus != nil {
-		in, out := &in.MonitoringStatus, &out.MonitoringStatus
-		*out = new(MonitoringStatus)
-		(*in).DeepCopyInto(*out)
+	if in.Subquestions != nil {
+		in, out := &in.Subquestions, &out.Subquestions
+		*out = make([]SubQuestion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectStatus.
-func (in *ProjectStatus) DeepCopy() *ProjectStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Question.
+func (in *Question) DeepCopy() *Question {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectStatus)
+	out := new(Question)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProjectTestInput) DeepCopyInto(out *ProjectTestInput) {
+func (in *RancherConfig) DeepCopyInto(out *RancherConfig) {
 	*out = *in
-	in.LoggingTargets.DeepCopyInto(&out.LoggingTargets)
-	if in.OutputTags != nil {
-		in, out := &in.OutputTags, &out.OutputTags
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectTestInput.
-func (in *ProjectTestInput) DeepCopy() *ProjectTestInput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RancherConfig.
+func (in *RancherConfig) DeepCopy() *RancherConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ProjectTestInput)
+	out := new(RancherConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RancherConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PublicEndpoint) DeepCopyInto(out *PublicEndpoint) {
+func (in *RancherConfigAuthConfig) DeepCopyInto(out *RancherConfigAuthConfig) {
 	*out = *in
-	if in.Addresses != nil {
-		in, out := &in.Addresses, &out.Addresses
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = (*in).DeepCopy()
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicEndpoint.
-func (in *PublicEndpoint) DeepCopy() *PublicEndpoint {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RancherConfigAuthConfig.
+func (in *RancherConfigAuthConfig) DeepCopy() *RancherConfigAuthConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(PublicEndpoint)
+	out := new(RancherConfigAuthConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QueryClusterGraph) DeepCopyInto(out *QueryClusterGraph) {
+func (in *RancherConfigGlobalRole) DeepCopyInto(out *RancherConfigGlobalRole) {
 	*out = *in
-	if in.Series != nil {
-		in, out := &in.Series, &out.Series
-		*out = make([]*TimeSeries, len(*in))
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]rbacv1.PolicyRule, len(*in))
 		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(TimeSeries)
-				(*in).DeepCopyInto(*out)
-			}
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryClusterGraph.
-func (in *QueryClusterGraph) DeepCopy() *QueryClusterGraph {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RancherConfigGlobalRole.
+func (in *RancherConfigGlobalRole) DeepCopy() *RancherConfigGlobalRole {
 	if in == nil {
 		return nil
 	}
-	out := new(QueryClusterGraph)
+	out := new(RancherConfigGlobalRole)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QueryClusterGraphOutput) DeepCopyInto(out *QueryClusterGraphOutput) {
+func (in *RancherConfigList) DeepCopyInto(out *RancherConfigList) {
 	*out = *in
-	if in.Data != nil {
-		in, out := &in.Data, &out.Data
-		*out = make([]QueryClusterGraph, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RancherConfig, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -8332,123 +10610,144 @@ func (in *QueryClusterGraphOutput) DeepCopyInto(out *QueryClusterGraphOutput) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryClusterGraphOutput.
-func (in *QueryClusterGraphOutput) DeepCopy() *QueryClusterGraphOutput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RancherConfigList.
+func (in *RancherConfigList) DeepCopy() *RancherConfigList {
 	if in == nil {
 		return nil
 	}
-	out := new(QueryClusterGraphOutput)
+	out := new(RancherConfigList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RancherConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QueryClusterMetricInput) DeepCopyInto(out *QueryClusterMetricInput) {
+func (in *RancherConfigResourceStatus) DeepCopyInto(out *RancherConfigResourceStatus) {
 	*out = *in
-	out.CommonQueryMetricInput = in.CommonQueryMetricInput
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryClusterMetricInput.
-func (in *QueryClusterMetricInput) DeepCopy() *QueryClusterMetricInput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RancherConfigResourceStatus.
+func (in *RancherConfigResourceStatus) DeepCopy() *RancherConfigResourceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(QueryClusterMetricInput)
+	out := new(RancherConfigResourceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QueryGraphInput) DeepCopyInto(out *QueryGraphInput) {
+func (in *RancherConfigSpec) DeepCopyInto(out *RancherConfigSpec) {
 	*out = *in
-	if in.MetricParams != nil {
-		in, out := &in.MetricParams, &out.MetricParams
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.Filters != nil {
-		in, out := &in.Filters, &out.Filters
-		*out = make(map[string]string, len(*in))
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make(map[string]bool, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
+	if in.AuthConfigs != nil {
+		in, out := &in.AuthConfigs, &out.AuthConfigs
+		*out = make([]RancherConfigAuthConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GlobalRoles != nil {
+		in, out := &in.GlobalRoles, &out.GlobalRoles
+		*out = make([]RancherConfigGlobalRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryGraphInput.
-func (in *QueryGraphInput) DeepCopy() *QueryGraphInput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RancherConfigSpec.
+func (in *RancherConfigSpec) DeepCopy() *RancherConfigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(QueryGraphInput)
+	out := new(RancherConfigSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QueryMetricOutput) DeepCopyInto(out *QueryMetricOutput) {
+func (in *RancherConfigStatus) DeepCopyInto(out *RancherConfigStatus) {
 	*out = *in
-	if in.Series != nil {
-		in, out := &in.Series, &out.Series
-		*out = make([]*TimeSeries, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(TimeSeries)
-				(*in).DeepCopyInto(*out)
-			}
-		}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]RancherConfigResourceStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryMetricOutput.
-func (in *QueryMetricOutput) DeepCopy() *QueryMetricOutput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RancherConfigStatus.
+func (in *RancherConfigStatus) DeepCopy() *RancherConfigStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(QueryMetricOutput)
+	out := new(RancherConfigStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QueryProjectGraph) DeepCopyInto(out *QueryProjectGraph) {
+func (in *RancherUserNotification) DeepCopyInto(out *RancherUserNotification) {
 	*out = *in
-	if in.Series != nil {
-		in, out := &in.Series, &out.Series
-		*out = make([]*TimeSeries, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(TimeSeries)
-				(*in).DeepCopyInto(*out)
-			}
-		}
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryProjectGraph.
-func (in *QueryProjectGraph) DeepCopy() *QueryProjectGraph {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RancherUserNotification.
+func (in *RancherUserNotification) DeepCopy() *RancherUserNotification {
 	if in == nil {
 		return nil
 	}
-	out := new(QueryProjectGraph)
+	out := new(RancherUserNotification)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RancherUserNotification) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QueryProjectGraphOutput) DeepCopyInto(out *QueryProjectGraphOutput) {
+func (in *RancherUserNotificationList) DeepCopyInto(out *RancherUserNotificationList) {
 	*out = *in
-	if in.Data != nil {
-		in, out := &in.Data, &out.Data
-		*out = make([]QueryProjectGraph, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RancherUserNotification, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -8456,81 +10755,132 @@ func (in *QueryProjectGraphOutput) DeepCopyInto(out *QueryProjectGraphOutput) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryProjectGraphOutput.
-func (in *QueryProjectGraphOutput) DeepCopy() *QueryProjectGraphOutput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RancherUserNotificationList.
+func (in *RancherUserNotificationList) DeepCopy() *RancherUserNotificationList {
+	if in == nil {
+		return nil
+	}
+	out := new(RancherUserNotificationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RancherUserNotificationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Recipient) DeepCopyInto(out *Recipient) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Recipient.
+func (in *Recipient) DeepCopy() *Recipient {
+	if in == nil {
+		return nil
+	}
+	out := new(Recipient)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Report) DeepCopyInto(out *Report) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Report.
+func (in *Report) DeepCopy() *Report {
 	if in == nil {
 		return nil
 	}
-	out := new(QueryProjectGraphOutput)
+	out := new(Report)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Report) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QueryProjectMetricInput) DeepCopyInto(out *QueryProjectMetricInput) {
+func (in *ReportArtifact) DeepCopyInto(out *ReportArtifact) {
 	*out = *in
-	out.CommonQueryMetricInput = in.CommonQueryMetricInput
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryProjectMetricInput.
-func (in *QueryProjectMetricInput) DeepCopy() *QueryProjectMetricInput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportArtifact.
+func (in *ReportArtifact) DeepCopy() *ReportArtifact {
 	if in == nil {
 		return nil
 	}
-	out := new(QueryProjectMetricInput)
+	out := new(ReportArtifact)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Question) DeepCopyInto(out *Question) {
+func (in *ReportEmail) DeepCopyInto(out *ReportEmail) {
 	*out = *in
-	if in.Options != nil {
-		in, out := &in.Options, &out.Options
+	if in.Recipients != nil {
+		in, out := &in.Recipients, &out.Recipients
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Subquestions != nil {
-		in, out := &in.Subquestions, &out.Subquestions
-		*out = make([]SubQuestion, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Question.
-func (in *Question) DeepCopy() *Question {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportEmail.
+func (in *ReportEmail) DeepCopy() *ReportEmail {
 	if in == nil {
 		return nil
 	}
-	out := new(Question)
+	out := new(ReportEmail)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RancherUserNotification) DeepCopyInto(out *RancherUserNotification) {
+func (in *ReportList) DeepCopyInto(out *ReportList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Report, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RancherUserNotification.
-func (in *RancherUserNotification) DeepCopy() *RancherUserNotification {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportList.
+func (in *ReportList) DeepCopy() *ReportList {
 	if in == nil {
 		return nil
 	}
-	out := new(RancherUserNotification)
+	out := new(ReportList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RancherUserNotification) DeepCopyObject() runtime.Object {
+func (in *ReportList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -8538,50 +10888,58 @@ func (in *RancherUserNotification) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RancherUserNotificationList) DeepCopyInto(out *RancherUserNotificationList) {
+func (in *ReportSpec) DeepCopyInto(out *ReportSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]RancherUserNotification, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Sections != nil {
+		in, out := &in.Sections, &out.Sections
+		*out = make([]ReportSection, len(*in))
+		copy(*out, *in)
+	}
+	if in.Formats != nil {
+		in, out := &in.Formats, &out.Formats
+		*out = make([]ReportFormat, len(*in))
+		copy(*out, *in)
+	}
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = new(ReportEmail)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RancherUserNotificationList.
-func (in *RancherUserNotificationList) DeepCopy() *RancherUserNotificationList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportSpec.
+func (in *ReportSpec) DeepCopy() *ReportSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RancherUserNotificationList)
+	out := new(ReportSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RancherUserNotificationList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Recipient) DeepCopyInto(out *Recipient) {
+func (in *ReportStatus) DeepCopyInto(out *ReportStatus) {
 	*out = *in
+	if in.Artifacts != nil {
+		in, out := &in.Artifacts, &out.Artifacts
+		*out = make([]ReportArtifact, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Recipient.
-func (in *Recipient) DeepCopy() *Recipient {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportStatus.
+func (in *ReportStatus) DeepCopy() *ReportStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(Recipient)
+	out := new(ReportStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -8834,6 +11192,11 @@ func (in *RoleTemplate) DeepCopyInto(out *RoleTemplate) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AggregationRule != nil {
+		in, out := &in.AggregationRule, &out.AggregationRule
+		*out = new(RoleTemplateAggregationRule)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -8855,6 +11218,29 @@ func (in *RoleTemplate) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleTemplateAggregationRule) DeepCopyInto(out *RoleTemplateAggregationRule) {
+	*out = *in
+	if in.RoleTemplateSelectors != nil {
+		in, out := &in.RoleTemplateSelectors, &out.RoleTemplateSelectors
+		*out = make([]metav1.LabelSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleTemplateAggregationRule.
+func (in *RoleTemplateAggregationRule) DeepCopy() *RoleTemplateAggregationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleTemplateAggregationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoleTemplateList) DeepCopyInto(out *RoleTemplateList) {
 	*out = *in
@@ -9153,46 +11539,182 @@ func (in *SamlProvider) DeepCopy() *SamlProvider {
 	if in == nil {
 		return nil
 	}
-	out := new(SamlProvider)
+	out := new(SamlProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SamlProvider) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SamlProviderList) DeepCopyInto(out *SamlProviderList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SamlProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SamlProviderList.
+func (in *SamlProviderList) DeepCopy() *SamlProviderList {
+	if in == nil {
+		return nil
+	}
+	out := new(SamlProviderList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SamlProviderList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SamlToken) DeepCopyInto(out *SamlToken) {
+	*out = *in
+	out.Namespaced = in.Namespaced
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SamlToken.
+func (in *SamlToken) DeepCopy() *SamlToken {
+	if in == nil {
+		return nil
+	}
+	out := new(SamlToken)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SamlToken) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SamlTokenList) DeepCopyInto(out *SamlTokenList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SamlToken, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SamlTokenList.
+func (in *SamlTokenList) DeepCopy() *SamlTokenList {
+	if in == nil {
+		return nil
+	}
+	out := new(SamlTokenList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SamlTokenList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SaveAsTemplateInput) DeepCopyInto(out *SaveAsTemplateInput) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SaveAsTemplateInput.
+func (in *SaveAsTemplateInput) DeepCopy() *SaveAsTemplateInput {
+	if in == nil {
+		return nil
+	}
+	out := new(SaveAsTemplateInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SaveAsTemplateOutput) DeepCopyInto(out *SaveAsTemplateOutput) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SaveAsTemplateOutput.
+func (in *SaveAsTemplateOutput) DeepCopy() *SaveAsTemplateOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(SaveAsTemplateOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SearchPrincipalsInput) DeepCopyInto(out *SearchPrincipalsInput) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SearchPrincipalsInput.
+func (in *SearchPrincipalsInput) DeepCopy() *SearchPrincipalsInput {
+	if in == nil {
+		return nil
+	}
+	out := new(SearchPrincipalsInput)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SamlProvider) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SamlProviderList) DeepCopyInto(out *SamlProviderList) {
+func (in *SecretDistribution) DeepCopyInto(out *SecretDistribution) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]SamlProvider, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SamlProviderList.
-func (in *SamlProviderList) DeepCopy() *SamlProviderList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretDistribution.
+func (in *SecretDistribution) DeepCopy() *SecretDistribution {
 	if in == nil {
 		return nil
 	}
-	out := new(SamlProviderList)
+	out := new(SecretDistribution)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SamlProviderList) DeepCopyObject() runtime.Object {
+func (in *SecretDistribution) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -9200,40 +11722,30 @@ func (in *SamlProviderList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SamlToken) DeepCopyInto(out *SamlToken) {
+func (in *SecretDistributionClusterStatus) DeepCopyInto(out *SecretDistributionClusterStatus) {
 	*out = *in
-	out.Namespaced = in.Namespaced
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.LastSyncTime.DeepCopyInto(&out.LastSyncTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SamlToken.
-func (in *SamlToken) DeepCopy() *SamlToken {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretDistributionClusterStatus.
+func (in *SecretDistributionClusterStatus) DeepCopy() *SecretDistributionClusterStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SamlToken)
+	out := new(SecretDistributionClusterStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SamlToken) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SamlTokenList) DeepCopyInto(out *SamlTokenList) {
+func (in *SecretDistributionList) DeepCopyInto(out *SecretDistributionList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]SamlToken, len(*in))
+		*out = make([]SecretDistribution, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -9241,18 +11753,18 @@ func (in *SamlTokenList) DeepCopyInto(out *SamlTokenList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SamlTokenList.
-func (in *SamlTokenList) DeepCopy() *SamlTokenList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretDistributionList.
+func (in *SecretDistributionList) DeepCopy() *SecretDistributionList {
 	if in == nil {
 		return nil
 	}
-	out := new(SamlTokenList)
+	out := new(SecretDistributionList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SamlTokenList) DeepCopyObject() runtime.Object {
+func (in *SecretDistributionList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -9260,49 +11772,88 @@ func (in *SamlTokenList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SaveAsTemplateInput) DeepCopyInto(out *SaveAsTemplateInput) {
+func (in *SecretDistributionSource) DeepCopyInto(out *SecretDistributionSource) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SaveAsTemplateInput.
-func (in *SaveAsTemplateInput) DeepCopy() *SaveAsTemplateInput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretDistributionSource.
+func (in *SecretDistributionSource) DeepCopy() *SecretDistributionSource {
 	if in == nil {
 		return nil
 	}
-	out := new(SaveAsTemplateInput)
+	out := new(SecretDistributionSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SaveAsTemplateOutput) DeepCopyInto(out *SaveAsTemplateOutput) {
+func (in *SecretDistributionSpec) DeepCopyInto(out *SecretDistributionSpec) {
 	*out = *in
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]SecretDistributionSource, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterNames != nil {
+		in, out := &in.ClusterNames, &out.ClusterNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SaveAsTemplateOutput.
-func (in *SaveAsTemplateOutput) DeepCopy() *SaveAsTemplateOutput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretDistributionSpec.
+func (in *SecretDistributionSpec) DeepCopy() *SecretDistributionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SaveAsTemplateOutput)
+	out := new(SecretDistributionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SearchPrincipalsInput) DeepCopyInto(out *SearchPrincipalsInput) {
+func (in *SecretDistributionStatus) DeepCopyInto(out *SecretDistributionStatus) {
 	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]SecretDistributionClusterStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SearchPrincipalsInput.
-func (in *SearchPrincipalsInput) DeepCopy() *SearchPrincipalsInput {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretDistributionStatus.
+func (in *SecretDistributionStatus) DeepCopy() *SecretDistributionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SearchPrincipalsInput)
+	out := new(SecretDistributionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -10367,6 +12918,22 @@ func (in *VersionCommits) DeepCopy() *VersionCommits {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebAuthnAssertion) DeepCopyInto(out *WebAuthnAssertion) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebAuthnAssertion.
+func (in *WebAuthnAssertion) DeepCopy() *WebAuthnAssertion {
+	if in == nil {
+		return nil
+	}
+	out := new(WebAuthnAssertion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebhookConfig) DeepCopyInto(out *WebhookConfig) {
 	*out = *in
@@ -10388,6 +12955,48 @@ func (in *WebhookConfig) DeepCopy() *WebhookConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookOverride) DeepCopyInto(out *WebhookOverride) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookOverride.
+func (in *WebhookOverride) DeepCopy() *WebhookOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookOverrides) DeepCopyInto(out *WebhookOverrides) {
+	*out = *in
+	if in.Webhooks != nil {
+		in, out := &in.Webhooks, &out.Webhooks
+		*out = make([]WebhookOverride, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExemptNamespaces != nil {
+		in, out := &in.ExemptNamespaces, &out.ExemptNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookOverrides.
+func (in *WebhookOverrides) DeepCopy() *WebhookOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WechatConfig) DeepCopyInto(out *WechatConfig) {
 	*out = *in
@@ -10425,6 +13034,183 @@ func (in *WindowsSystemImages) DeepCopy() *WindowsSystemImages {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadDefaults) DeepCopyInto(out *WorkloadDefaults) {
+	*out = *in
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]ImagePullSecretSource, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultRuntimeClass != nil {
+		in, out := &in.DefaultRuntimeClass, &out.DefaultRuntimeClass
+		*out = new(DefaultRuntimeClass)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExcludedNamespaces != nil {
+		in, out := &in.ExcludedNamespaces, &out.ExcludedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadDefaults.
+func (in *WorkloadDefaults) DeepCopy() *WorkloadDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadPlacement) DeepCopyInto(out *WorkloadPlacement) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadPlacement.
+func (in *WorkloadPlacement) DeepCopy() *WorkloadPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadPlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkloadPlacement) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadPlacementList) DeepCopyInto(out *WorkloadPlacementList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WorkloadPlacement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadPlacementList.
+func (in *WorkloadPlacementList) DeepCopy() *WorkloadPlacementList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadPlacementList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkloadPlacementList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadPlacementOverride) DeepCopyInto(out *WorkloadPlacementOverride) {
+	*out = *in
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadPlacementOverride.
+func (in *WorkloadPlacementOverride) DeepCopy() *WorkloadPlacementOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadPlacementOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadPlacementSpec) DeepCopyInto(out *WorkloadPlacementSpec) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = (*in).DeepCopy()
+	}
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]WorkloadPlacementOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RolloutStrategy != nil {
+		in, out := &in.RolloutStrategy, &out.RolloutStrategy
+		*out = new(v1alpha1.RolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadPlacementSpec.
+func (in *WorkloadPlacementSpec) DeepCopy() *WorkloadPlacementSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadPlacementSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadPlacementStatus) DeepCopyInto(out *WorkloadPlacementStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadPlacementStatus.
+func (in *WorkloadPlacementStatus) DeepCopy() *WorkloadPlacementStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadPlacementStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadRule) DeepCopyInto(out *WorkloadRule) {
 	*out = *in
@@ -264,6 +264,40 @@ func NewClusterAlertRule(namespace, name string, obj ClusterAlertRule) *ClusterA
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// ClusterGroupList is a list of ClusterGroup resources
+type ClusterGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ClusterGroup `json:"items"`
+}
+
+func NewClusterGroup(namespace, name string, obj ClusterGroup) *ClusterGroup {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("ClusterGroup").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterGroupRoleTemplateBindingList is a list of ClusterGroupRoleTemplateBinding resources
+type ClusterGroupRoleTemplateBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ClusterGroupRoleTemplateBinding `json:"items"`
+}
+
+func NewClusterGroupRoleTemplateBinding(namespace, name string, obj ClusterGroupRoleTemplateBinding) *ClusterGroupRoleTemplateBinding {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("ClusterGroupRoleTemplateBinding").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // ClusterLoggingList is a list of ClusterLogging resources
 type ClusterLoggingList struct {
 	metav1.TypeMeta `json:",inline"`
@@ -281,6 +315,23 @@ func NewClusterLogging(namespace, name string, obj ClusterLogging) *ClusterLoggi
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// ClusterMigrationList is a list of ClusterMigration resources
+type ClusterMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ClusterMigration `json:"items"`
+}
+
+func NewClusterMigration(namespace, name string, obj ClusterMigration) *ClusterMigration {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("ClusterMigration").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // ClusterMonitorGraphList is a list of ClusterMonitorGraph resources
 type ClusterMonitorGraphList struct {
 	metav1.TypeMeta `json:",inline"`
@@ -417,6 +468,23 @@ func NewEtcdBackup(namespace, name string, obj EtcdBackup) *EtcdBackup {
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// EventSubscriptionList is a list of EventSubscription resources
+type EventSubscriptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []EventSubscription `json:"items"`
+}
+
+func NewEventSubscription(namespace, name string, obj EventSubscription) *EventSubscription {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("EventSubscription").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // FeatureList is a list of Feature resources
 type FeatureList struct {
 	metav1.TypeMeta `json:",inline"`
@@ -621,6 +689,23 @@ func NewKontainerDriver(namespace, name string, obj KontainerDriver) *KontainerD
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// LandingPreferenceList is a list of LandingPreference resources
+type LandingPreferenceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []LandingPreference `json:"items"`
+}
+
+func NewLandingPreference(namespace, name string, obj LandingPreference) *LandingPreference {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("LandingPreference").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // LocalProviderList is a list of LocalProvider resources
 type LocalProviderList struct {
 	metav1.TypeMeta `json:",inline"`
@@ -655,6 +740,40 @@ func NewManagedChart(namespace, name string, obj ManagedChart) *ManagedChart {
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// ManagementEventList is a list of ManagementEvent resources
+type ManagementEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ManagementEvent `json:"items"`
+}
+
+func NewManagementEvent(namespace, name string, obj ManagementEvent) *ManagementEvent {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("ManagementEvent").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MeteringRecordList is a list of MeteringRecord resources
+type MeteringRecordList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []MeteringRecord `json:"items"`
+}
+
+func NewMeteringRecord(namespace, name string, obj MeteringRecord) *MeteringRecord {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("MeteringRecord").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // MonitorMetricList is a list of MonitorMetric resources
 type MonitorMetricList struct {
 	metav1.TypeMeta `json:",inline"`
@@ -706,6 +825,23 @@ func NewMultiClusterAppRevision(namespace, name string, obj MultiClusterAppRevis
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// NamespaceTemplateList is a list of NamespaceTemplate resources
+type NamespaceTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []NamespaceTemplate `json:"items"`
+}
+
+func NewNamespaceTemplate(namespace, name string, obj NamespaceTemplate) *NamespaceTemplate {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("NamespaceTemplate").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // NodeList is a list of Node resources
 type NodeList struct {
 	metav1.TypeMeta `json:",inline"`
@@ -757,6 +893,23 @@ func NewNodePool(namespace, name string, obj NodePool) *NodePool {
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// NodeRegistrationBatchList is a list of NodeRegistrationBatch resources
+type NodeRegistrationBatchList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []NodeRegistrationBatch `json:"items"`
+}
+
+func NewNodeRegistrationBatch(namespace, name string, obj NodeRegistrationBatch) *NodeRegistrationBatch {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("NodeRegistrationBatch").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // NodeTemplateList is a list of NodeTemplate resources
 type NodeTemplateList struct {
 	metav1.TypeMeta `json:",inline"`
@@ -876,6 +1029,23 @@ func NewPodSecurityPolicyTemplateProjectBinding(namespace, name string, obj PodS
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// PolicyBundleList is a list of PolicyBundle resources
+type PolicyBundleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []PolicyBundle `json:"items"`
+}
+
+func NewPolicyBundle(namespace, name string, obj PolicyBundle) *PolicyBundle {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("PolicyBundle").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // PreferenceList is a list of Preference resources
 type PreferenceList struct {
 	metav1.TypeMeta `json:",inline"`
@@ -1046,6 +1216,40 @@ func NewProjectRoleTemplateBinding(namespace, name string, obj ProjectRoleTempla
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// ProvisioningQuotaList is a list of ProvisioningQuota resources
+type ProvisioningQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ProvisioningQuota `json:"items"`
+}
+
+func NewProvisioningQuota(namespace, name string, obj ProvisioningQuota) *ProvisioningQuota {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("ProvisioningQuota").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RancherConfigList is a list of RancherConfig resources
+type RancherConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []RancherConfig `json:"items"`
+}
+
+func NewRancherConfig(namespace, name string, obj RancherConfig) *RancherConfig {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("RancherConfig").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // RancherUserNotificationList is a list of RancherUserNotification resources
 type RancherUserNotificationList struct {
 	metav1.TypeMeta `json:",inline"`
@@ -1063,6 +1267,23 @@ func NewRancherUserNotification(namespace, name string, obj RancherUserNotificat
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// ReportList is a list of Report resources
+type ReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Report `json:"items"`
+}
+
+func NewReport(namespace, name string, obj Report) *Report {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("Report").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // RkeAddonList is a list of RkeAddon resources
 type RkeAddonList struct {
 	metav1.TypeMeta `json:",inline"`
@@ -1165,6 +1386,23 @@ func NewSamlToken(namespace, name string, obj SamlToken) *SamlToken {
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// SecretDistributionList is a list of SecretDistribution resources
+type SecretDistributionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []SecretDistribution `json:"items"`
+}
+
+func NewSecretDistribution(namespace, name string, obj SecretDistribution) *SecretDistribution {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("SecretDistribution").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // SettingList is a list of Setting resources
 type SettingList struct {
 	metav1.TypeMeta `json:",inline"`
@@ -1284,6 +1522,23 @@ func NewUserAttribute(namespace, name string, obj UserAttribute) *UserAttribute
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// WorkloadPlacementList is a list of WorkloadPlacement resources
+type WorkloadPlacementList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []WorkloadPlacement `json:"items"`
+}
+
+func NewWorkloadPlacement(namespace, name string, obj WorkloadPlacement) *WorkloadPlacement {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("WorkloadPlacement").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // ProjectCatalogList is a list of ProjectCatalog resources
 type ProjectCatalogList struct {
 	metav1.TypeMeta `json:",inline"`
@@ -1301,6 +1556,40 @@ func NewProjectCatalog(namespace, name string, obj ProjectCatalog) *ProjectCatal
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// ClusterArchiveList is a list of ClusterArchive resources
+type ClusterArchiveList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ClusterArchive `json:"items"`
+}
+
+func NewClusterArchive(namespace, name string, obj ClusterArchive) *ClusterArchive {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("ClusterArchive").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterBundleList is a list of ClusterBundle resources
+type ClusterBundleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ClusterBundle `json:"items"`
+}
+
+func NewClusterBundle(namespace, name string, obj ClusterBundle) *ClusterBundle {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("ClusterBundle").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // ClusterCatalogList is a list of ClusterCatalog resources
 type ClusterCatalogList struct {
 	metav1.TypeMeta `json:",inline"`
@@ -42,8 +42,13 @@
 	ClusterAlertResourceName                              = "clusteralerts"
 	ClusterAlertGroupResourceName                         = "clusteralertgroups"
 	ClusterAlertRuleResourceName                          = "clusteralertrules"
+	ClusterArchiveResourceName                            = "clusterarchives"
+	ClusterBundleResourceName                             = "clusterbundles"
 	ClusterCatalogResourceName                            = "clustercatalogs"
+	ClusterGroupResourceName                              = "clustergroups"
+	ClusterGroupRoleTemplateBindingResourceName           = "clustergrouproletemplatebindings"
 	ClusterLoggingResourceName                            = "clusterloggings"
+	ClusterMigrationResourceName                          = "clustermigrations"
 	ClusterMonitorGraphResourceName                       = "clustermonitorgraphs"
 	ClusterRegistrationTokenResourceName                  = "clusterregistrationtokens"
 	ClusterRoleTemplateBindingResourceName                = "clusterroletemplatebindings"
@@ -52,6 +57,7 @@
 	ComposeConfigResourceName                             = "composeconfigs"
 	DynamicSchemaResourceName                             = "dynamicschemas"
 	EtcdBackupResourceName                                = "etcdbackups"
+	EventSubscriptionResourceName                         = "eventsubscriptions"
 	FeatureResourceName                                   = "features"
 	FleetWorkspaceResourceName                            = "fleetworkspaces"
 	FreeIpaProviderResourceName                           = "freeipaproviders"
@@ -64,14 +70,19 @@
 	GroupResourceName                                     = "groups"
 	GroupMemberResourceName                               = "groupmembers"
 	KontainerDriverResourceName                           = "kontainerdrivers"
+	LandingPreferenceResourceName                         = "landingpreferences"
 	LocalProviderResourceName                             = "localproviders"
 	ManagedChartResourceName                              = "managedcharts"
+	ManagementEventResourceName                           = "managementevents"
+	MeteringRecordResourceName                            = "meteringrecords"
 	MonitorMetricResourceName                             = "monitormetrics"
 	MultiClusterAppResourceName                           = "multiclusterapps"
 	MultiClusterAppRevisionResourceName                   = "multiclusterapprevisions"
+	NamespaceTemplateResourceName                         = "namespacetemplates"
 	NodeResourceName                                      = "nodes"
 	NodeDriverResourceName                                = "nodedrivers"
 	NodePoolResourceName                                  = "nodepools"
+	NodeRegistrationBatchResourceName                     = "noderegistrationbatches"
 	NodeTemplateResourceName                              = "nodetemplates"
 	NotifierResourceName                                  = "notifiers"
 	OIDCProviderResourceName                              = "oidcproviders"
@@ -79,6 +90,7 @@
 	PodSecurityAdmissionConfigurationTemplateResourceName = "podsecurityadmissionconfigurationtemplates"
 	PodSecurityPolicyTemplateResourceName                 = "podsecuritypolicytemplates"
 	PodSecurityPolicyTemplateProjectBindingResourceName   = "podsecuritypolicytemplateprojectbindings"
+	PolicyBundleResourceName                              = "policybundles"
 	PreferenceResourceName                                = "preferences"
 	PrincipalResourceName                                 = "principals"
 	ProjectResourceName                                   = "projects"
@@ -90,13 +102,17 @@
 	ProjectMonitorGraphResourceName                       = "projectmonitorgraphs"
 	ProjectNetworkPolicyResourceName                      = "projectnetworkpolicies"
 	ProjectRoleTemplateBindingResourceName                = "projectroletemplatebindings"
+	ProvisioningQuotaResourceName                         = "provisioningquotas"
+	RancherConfigResourceName                             = "rancherconfigs"
 	RancherUserNotificationResourceName                   = "rancherusernotifications"
+	ReportResourceName                                    = "reports"
 	RkeAddonResourceName                                  = "rkeaddons"
 	RkeK8sServiceOptionResourceName                       = "rkek8sserviceoptions"
 	RkeK8sSystemImageResourceName                         = "rkek8ssystemimages"
 	RoleTemplateResourceName                              = "roletemplates"
 	SamlProviderResourceName                              = "samlproviders"
 	SamlTokenResourceName                                 = "samltokens"
+	SecretDistributionResourceName                        = "secretdistributions"
 	SettingResourceName                                   = "settings"
 	TemplateResourceName                                  = "templates"
 	TemplateContentResourceName                           = "templatecontents"
@@ -104,6 +120,7 @@
 	TokenResourceName                                     = "tokens"
 	UserResourceName                                      = "users"
 	UserAttributeResourceName                             = "userattributes"
+	WorkloadPlacementResourceName                         = "workloadplacements"
 )
 
 // SchemeGroupVersion is group version used to register these objects
@@ -155,10 +172,20 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ClusterAlertGroupList{},
 		&ClusterAlertRule{},
 		&ClusterAlertRuleList{},
+		&ClusterArchive{},
+		&ClusterArchiveList{},
+		&ClusterBundle{},
+		&ClusterBundleList{},
 		&ClusterCatalog{},
 		&ClusterCatalogList{},
+		&ClusterGroup{},
+		&ClusterGroupList{},
+		&ClusterGroupRoleTemplateBinding{},
+		&ClusterGroupRoleTemplateBindingList{},
 		&ClusterLogging{},
 		&ClusterLoggingList{},
+		&ClusterMigration{},
+		&ClusterMigrationList{},
 		&ClusterMonitorGraph{},
 		&ClusterMonitorGraphList{},
 		&ClusterRegistrationToken{},
@@ -175,6 +202,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&DynamicSchemaList{},
 		&EtcdBackup{},
 		&EtcdBackupList{},
+		&EventSubscription{},
+		&EventSubscriptionList{},
 		&Feature{},
 		&FeatureList{},
 		&FleetWorkspace{},
@@ -199,22 +228,32 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&GroupMemberList{},
 		&KontainerDriver{},
 		&KontainerDriverList{},
+		&LandingPreference{},
+		&LandingPreferenceList{},
 		&LocalProvider{},
 		&LocalProviderList{},
 		&ManagedChart{},
 		&ManagedChartList{},
+		&ManagementEvent{},
+		&ManagementEventList{},
+		&MeteringRecord{},
+		&MeteringRecordList{},
 		&MonitorMetric{},
 		&MonitorMetricList{},
 		&MultiClusterApp{},
 		&MultiClusterAppList{},
 		&MultiClusterAppRevision{},
 		&MultiClusterAppRevisionList{},
+		&NamespaceTemplate{},
+		&NamespaceTemplateList{},
 		&Node{},
 		&NodeList{},
 		&NodeDriver{},
 		&NodeDriverList{},
 		&NodePool{},
 		&NodePoolList{},
+		&NodeRegistrationBatch{},
+		&NodeRegistrationBatchList{},
 		&NodeTemplate{},
 		&NodeTemplateList{},
 		&Notifier{},
@@ -229,6 +268,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&PodSecurityPolicyTemplateList{},
 		&PodSecurityPolicyTemplateProjectBinding{},
 		&PodSecurityPolicyTemplateProjectBindingList{},
+		&PolicyBundle{},
+		&PolicyBundleList{},
 		&Preference{},
 		&PreferenceList{},
 		&Principal{},
@@ -251,9 +292,15 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ProjectNetworkPolicyList{},
 		&ProjectRoleTemplateBinding{},
 		&ProjectRoleTemplateBindingList{},
+		&ProvisioningQuota{},
+		&ProvisioningQuotaList{},
+		&RancherConfig{},
+		&RancherConfigList{},
 		&RancherUserNotification{},
 		&RancherUserNotificationList{},
 		&RkeAddon{},
+		&Report{},
+		&ReportList{},
 		&RkeAddonList{},
 		&RkeK8sServiceOption{},
 		&RkeK8sServiceOptionList{},
@@ -265,6 +312,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&SamlProviderList{},
 		&SamlToken{},
 		&SamlTokenList{},
+		&SecretDistribution{},
+		&SecretDistributionList{},
 		&Setting{},
 		&SettingList{},
 		&Template{},
@@ -279,6 +328,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&UserList{},
 		&UserAttribute{},
 		&UserAttributeList{},
+		&WorkloadPlacement{},
+		&WorkloadPlacementList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
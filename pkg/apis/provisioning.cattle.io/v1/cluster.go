@@ -34,6 +34,31 @@ type ClusterSpec struct {
 	FleetAgentDeploymentCustomization                    *AgentDeploymentCustomization `json:"fleetAgentDeploymentCustomization,omitempty"`
 
 	RedeploySystemAgentGeneration int64 `json:"redeploySystemAgentGeneration,omitempty"`
+
+	KubeconfigDistribution *KubeconfigDistribution `json:"kubeconfigDistribution,omitempty"`
+	MetadataPropagation    *MetadataPropagation    `json:"metadataPropagation,omitempty"`
+}
+
+// KubeconfigDistribution opts a cluster in to having its kubeconfig copied as a secret to each of the namespaces, such
+// as fleet workspaces, for the consumption of continuous delivery tools or custom controllers. Copies are updated when
+// the token or CA of the kubeconfig change, and deleted from namespaces removed from the list.
+type KubeconfigDistribution struct {
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Format of the copies, either kubeconfig for a secret holding the kubeconfig under the value key, or argocd for an
+	// Argo CD cluster secret. Defaults to kubeconfig.
+	Format string `json:"format,omitempty" norman:"type=enum,options=kubeconfig|argocd"`
+}
+
+// MetadataPropagation copies the chosen labels and annotations of a cluster to the nodes and to some namespaces of the
+// cluster, so that schedulers and policies of the cluster can select them by the metadata of the cluster. A key ending
+// with * matches the keys starting with what precedes it. Keys of the kubernetes.io and k8s.io domains are never
+// copied. The copies are kept in sync with the cluster, and removed once the keys or namespaces are removed from the
+// lists or the keys are removed from the cluster.
+type MetadataPropagation struct {
+	Labels      []string `json:"labels,omitempty"`
+	Annotations []string `json:"annotations,omitempty"`
+	// Namespaces are the namespaces of the cluster the metadata is copied to, in addition to its nodes.
+	Namespaces []string `json:"namespaces,omitempty"`
 }
 
 type AgentDeploymentCustomization struct {
@@ -49,6 +74,261 @@ type ClusterStatus struct {
 	AgentDeployed      bool                                `json:"agentDeployed,omitempty"`
 	ObservedGeneration int64                               `json:"observedGeneration"`
 	Conditions         []genericcondition.GenericCondition `json:"conditions,omitempty"`
+	// MachinePoolOSImages is the state of the machine pools provisioned from an OS image.
+	MachinePoolOSImages []MachinePoolOSImageStatus `json:"machinePoolOSImages,omitempty"`
+	// MachinePoolCloudCredentials is the health of the cloud credentials of the machine pools provisioned by a node
+	// driver.
+	MachinePoolCloudCredentials []MachinePoolCloudCredentialStatus `json:"machinePoolCloudCredentials,omitempty"`
+	// OrphanedCloudResources are the cloud resources tagged with a machine of the cluster that no longer exists, as
+	// found by the last scan for the resources left behind by failed machine provisioning.
+	OrphanedCloudResources []OrphanedCloudResource `json:"orphanedCloudResources,omitempty"`
+	// MachinePoolImages is the state of the rollout of the pinned images of the machine pools.
+	MachinePoolImages []MachinePoolImageStatus `json:"machinePoolImages,omitempty"`
+	// MachineReplacements is the progress of the replacements of machines requested through the API.
+	MachineReplacements []MachineReplacementStatus `json:"machineReplacements,omitempty"`
+	// MachinePoolMigrations is the progress of the migrations of the machines of renamed machine pools.
+	MachinePoolMigrations []MachinePoolMigrationStatus `json:"machinePoolMigrations,omitempty"`
+	// MachineAdoptions is the progress of the adoptions of existing cloud instances into machine pools requested through
+	// the API.
+	MachineAdoptions []MachineAdoptionStatus `json:"machineAdoptions,omitempty"`
+	// UpgradeScan is the scan of the cluster for the use of the APIs removed in the Kubernetes version it's upgraded to.
+	UpgradeScan *UpgradeScanStatus `json:"upgradeScan,omitempty"`
+	// UpgradeRollback is the progress of the last rollback of a Kubernetes upgrade requested through the API.
+	UpgradeRollback *UpgradeRollbackStatus `json:"upgradeRollback,omitempty"`
+	// AdmissionRejections are the objects generated for the cluster that admission webhooks or policies currently
+	// reject, by the controller generating them.
+	AdmissionRejections []AdmissionRejectionStatus `json:"admissionRejections,omitempty"`
+}
+
+type AdmissionRejectionStatus struct {
+	// Controller is the name of the controller whose objects were rejected.
+	Controller string `json:"controller"`
+	// Webhook is the name of the admission webhook or policy that denied the request, when it's known.
+	Webhook string `json:"webhook,omitempty"`
+	// Message is the rejection, as returned by the API server.
+	Message string `json:"message"`
+	// Attempts is the number of consecutive rejections for the generation of the cluster. The objects are no longer
+	// applied once the admission-rejection-retry-limit setting is reached, until the cluster changes.
+	Attempts int `json:"attempts"`
+	// ObservedGeneration is the generation of the cluster the objects were last rejected for.
+	ObservedGeneration int64 `json:"observedGeneration"`
+	// LastRejectionTime is when the objects were last rejected.
+	LastRejectionTime metav1.Time `json:"lastRejectionTime,omitempty"`
+}
+
+const (
+	MachineImagePending    = "Pending"
+	MachineImageRollingOut = "RollingOut"
+	MachineImageFailed     = "Failed"
+	MachineImageComplete   = "Complete"
+)
+
+type MachinePoolImageStatus struct {
+	// Name of the machine pool.
+	Name string `json:"name"`
+	// Version is the image rolled out to the machines of the pool, it lags the image of the pool until the pool and
+	// the control plane are healthy.
+	Version string `json:"version,omitempty"`
+	// Phase of the rollout of the image: Pending while waiting for the pool and the control plane to be healthy,
+	// RollingOut while machines of the pool run another image, Failed if machines running the image fail, or Complete.
+	Phase string `json:"phase,omitempty"`
+	// Message describes why the rollout is pending or failed.
+	Message string `json:"message,omitempty"`
+	// Machines are the images the machines of the pool run.
+	Machines []MachineImageStatus `json:"machines,omitempty"`
+}
+
+type MachineImageStatus struct {
+	// MachineName is the name of the CAPI machine.
+	MachineName string `json:"machineName"`
+	// NodeName is the name of the node of the machine, once it joined the cluster.
+	NodeName string `json:"nodeName,omitempty"`
+	// Version is the image the machine runs, empty if it was provisioned before the image of the pool was pinned.
+	Version string `json:"version,omitempty"`
+}
+
+const (
+	MachinePoolMigrationMoving    = "Moving"
+	MachinePoolMigrationCompleted = "Completed"
+	MachinePoolMigrationFailed    = "Failed"
+)
+
+type MachinePoolMigrationStatus struct {
+	// PoolName is the name of the machine pool the machines are moved to.
+	PoolName string `json:"poolName"`
+	// From is the name of the machine pool the machine pool was renamed from.
+	From string `json:"from"`
+	// TemplateName is the name the machine templates of the machine pool are named after, kept from the machine pool
+	// it was renamed from so that renaming a machine pool doesn't replace its machines.
+	TemplateName string `json:"templateName,omitempty"`
+	// Phase of the migration: Moving while the machine sets and machines of the machine pool it was renamed from are
+	// moved to the machine pool, Completed once they're moved, or Failed if they couldn't be moved, in which case the
+	// machines are replaced.
+	Phase string `json:"phase,omitempty"`
+	// Message describes why the migration failed.
+	Message string `json:"message,omitempty"`
+}
+
+const (
+	MachineReplacementProvisioning = "Provisioning"
+	MachineReplacementMigrating    = "Migrating"
+	MachineReplacementDeleting     = "Deleting"
+	MachineReplacementFailed       = "Failed"
+)
+
+type MachineReplacementStatus struct {
+	// MachineName is the name of the CAPI machine being replaced.
+	MachineName string `json:"machineName"`
+	// PoolName is the name of the machine pool of the machine, which is scaled up by one while the substitute is
+	// provisioned and the data of the machine is moved to it.
+	PoolName string `json:"poolName,omitempty"`
+	// ID is the ID of the replacement request.
+	ID string `json:"id,omitempty"`
+	// Phase of the replacement: Provisioning until the substitute is ready, Migrating while the node of the machine is
+	// drained and its data volumes are moved to the substitute, Deleting once the machine is deleted, or Failed.
+	Phase string `json:"phase,omitempty"`
+	// SubstituteName is the name of the CAPI machine replacing the machine.
+	SubstituteName string `json:"substituteName,omitempty"`
+	Message        string `json:"message,omitempty"`
+}
+
+const (
+	MachineAdoptionPending    = "Pending"
+	MachineAdoptionInstalling = "Installing"
+	MachineAdoptionAdopted    = "Adopted"
+	MachineAdoptionFailed     = "Failed"
+)
+
+type MachineAdoptionStatus struct {
+	// ID is the ID of the adoption request.
+	ID string `json:"id"`
+	// PoolName is the name of the machine pool the instance is adopted into.
+	PoolName string `json:"poolName,omitempty"`
+	// InstanceID is the ID of the instance in its cloud.
+	InstanceID string `json:"instanceId,omitempty"`
+	// ProviderID is the provider ID of the node of the instance.
+	ProviderID string `json:"providerId,omitempty"`
+	// MachineName is the name of the CAPI machine of the instance, once a machine of the pool claimed it.
+	MachineName string `json:"machineName,omitempty"`
+	// NodeName is the name of the node of the instance, once it joined the cluster.
+	NodeName string `json:"nodeName,omitempty"`
+	// Phase of the adoption: Pending until a machine of the pool claims the instance, Installing while the system agent
+	// is installed on the instance and its node joins the cluster, Adopted once the node of the machine is the instance,
+	// or Failed.
+	Phase   string `json:"phase,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// RemovedAPIUpgradeGateAnnotation on a cluster overrides the removed-api-upgrade-gate setting for the cluster, true to
+// hold Kubernetes minor upgrades of the cluster while objects use APIs removed in the new version and false not to.
+const RemovedAPIUpgradeGateAnnotation = "provisioning.cattle.io/removed-api-upgrade-gate"
+
+type UpgradeScanStatus struct {
+	// CurrentVersion and TargetVersion are the Kubernetes versions the cluster is upgraded from and to, the same for a
+	// cluster not being upgraded.
+	CurrentVersion string `json:"currentVersion"`
+	TargetVersion  string `json:"targetVersion"`
+	// ScannedAt is the RFC3339 time of the scan, empty if the upgrade doesn't remove any API.
+	ScannedAt string `json:"scannedAt,omitempty"`
+	// Passed is true if no object uses an API removed in the target version.
+	Passed bool `json:"passed"`
+	// Message describes why the scan failed or is incomplete.
+	Message string `json:"message,omitempty"`
+	// Blocking are the objects last written through an API removed in the target version.
+	Blocking []RemovedAPIUsage `json:"blocking,omitempty"`
+	// Requested are the APIs removed in the target version that clients requested since the API server started.
+	Requested []RemovedAPIRequest `json:"requested,omitempty"`
+}
+
+type RemovedAPIUsage struct {
+	// APIVersion and Kind are the API the object was written through.
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	// Managers are the field managers which wrote the object through the API.
+	Managers []string `json:"managers,omitempty"`
+	// RemovedIn is the Kubernetes minor version the API is removed in.
+	RemovedIn string `json:"removedIn"`
+	// Replacement is the API version to migrate the object to, empty if the API is removed without replacement.
+	Replacement string `json:"replacement,omitempty"`
+}
+
+type RemovedAPIRequest struct {
+	APIVersion string `json:"apiVersion"`
+	Resource   string `json:"resource"`
+	RemovedIn  string `json:"removedIn"`
+}
+
+const (
+	UpgradeRollbackRestoring   = "Restoring"
+	UpgradeRollbackReconciling = "Reconciling"
+	UpgradeRollbackComplete    = "Complete"
+	UpgradeRollbackFailed      = "Failed"
+)
+
+type UpgradeRollbackStatus struct {
+	// ID is the ID of the rollback request.
+	ID string `json:"id,omitempty"`
+	// SnapshotName is the etcd snapshot taken before the upgrade that is restored.
+	SnapshotName string `json:"snapshotName,omitempty"`
+	// FromVersion and ToVersion are the Kubernetes versions the cluster is rolled back from and to.
+	FromVersion string `json:"fromVersion,omitempty"`
+	ToVersion   string `json:"toVersion,omitempty"`
+	// Phase of the rollback: Restoring while the etcd snapshot is restored, Reconciling until the control plane is
+	// reconciled and the machines of all the pools run the version rolled back to, Complete, or Failed.
+	Phase   string `json:"phase,omitempty"`
+	Message string `json:"message,omitempty"`
+	// PendingMachines are the machines not running the version rolled back to yet.
+	PendingMachines []string `json:"pendingMachines,omitempty"`
+}
+
+type OrphanedCloudResource struct {
+	// Type of the resource, such as instance, volume or networkInterface.
+	Type string `json:"type"`
+	// ID of the resource in its cloud.
+	ID string `json:"id"`
+	// Region of the resource.
+	Region string `json:"region,omitempty"`
+	// MachineName is the name of the infrastructure machine the resource is tagged with.
+	MachineName string `json:"machineName"`
+	// Deleted is true if rancher deleted the resource.
+	Deleted bool `json:"deleted,omitempty"`
+	// Message describes why the resource couldn't be deleted.
+	Message string `json:"message,omitempty"`
+}
+
+type MachinePoolCloudCredentialStatus struct {
+	// Name of the machine pool.
+	Name string `json:"name"`
+	// CloudCredentialSecretName is the cloud credential of the pool, or of the cluster if the pool doesn't set one.
+	CloudCredentialSecretName string `json:"cloudCredentialSecretName"`
+	// Ready is true if the cloud credential exists and is a credential of the node driver of the pool.
+	Ready bool `json:"ready"`
+	// Message describes why the cloud credential isn't ready. The machines of the pool are switched to the cloud
+	// credential once it is.
+	Message string `json:"message,omitempty"`
+}
+
+type MachinePoolOSImageStatus struct {
+	// Name of the machine pool.
+	Name string `json:"name"`
+	// Version is the OS version rolled out to the machines of the pool, it lags the version of the pool while Kubernetes
+	// upgrades of the cluster are in progress.
+	Version string `json:"version,omitempty"`
+	// RegistrationImageURL is the URL the registration image of the pool can be downloaded from, once built.
+	RegistrationImageURL string `json:"registrationImageURL,omitempty"`
+	// Inventory is the hardware inventory of the machines registered to the pool.
+	Inventory []MachineInventoryStatus `json:"inventory,omitempty"`
+}
+
+type MachineInventoryStatus struct {
+	// Name of the Elemental MachineInventory of the machine.
+	Name string `json:"name"`
+	// MachineName is the name of the CAPI machine provisioned on the machine, empty while it is unused.
+	MachineName string `json:"machineName,omitempty"`
+	// Hardware is the hardware information the machine reported when it registered.
+	Hardware map[string]string `json:"hardware,omitempty"`
 }
 
 type ImportedConfig struct {
@@ -30,6 +30,46 @@ type RKEMachinePool struct {
 	MachineOS                    string                       `json:"machineOS,omitempty"`
 	DynamicSchemaSpec            string                       `json:"dynamicSchemaSpec,omitempty"`
 	HostnameLengthLimit          int                          `json:"hostnameLengthLimit,omitempty"`
+	// OSImage provisions the machines of the pool from an OS image, with Elemental. The machine config of the pool must
+	// be an Elemental MachineInventorySelectorTemplate.
+	OSImage *RKEMachinePoolOSImage `json:"osImage,omitempty"`
+	// GarbageCollection tunes the image and container log garbage collection and the eviction thresholds of the
+	// kubelet of the machines of the pool. It takes precedence over the matching kubelet-arg of the machines.
+	GarbageCollection *rkev1.GarbageCollection `json:"garbageCollection,omitempty"`
+	// MachineImage pins the image the machines of the pool are provisioned from, overriding the image of the machine
+	// config of the pool. A new image is rolled out through the pool, honoring its rolling update, once the pool and the
+	// control plane are healthy.
+	MachineImage *RKEMachinePoolMachineImage `json:"machineImage,omitempty"`
+	// RenamedFrom is the previous name of the machine pool. The machines of the machine pool of that name are moved
+	// to this machine pool instead of being deleted and recreated, and are only replaced, following the rolling update
+	// of the machine pool, if other fields of the machine pool changed with its name. This allows changing fields that
+	// can't be changed in place by renaming the machine pool.
+	RenamedFrom string `json:"renamedFrom,omitempty"`
+}
+
+type RKEMachinePoolMachineImage struct {
+	// Field is the field of the machine config of the pool holding the image, such as ami. Defaults to the image field
+	// of the node driver of the machine config.
+	Field string `json:"field,omitempty"`
+	// Version is the image the machines of the pool run, such as the ID of an AMI.
+	Version string `json:"version"`
+}
+
+// RKEMachinePoolOSImage references the OS versions of a machine pool, published by an Elemental ManagedOSVersionChannel
+// in the namespace of the cluster.
+type RKEMachinePoolOSImage struct {
+	// Channel is the name of the ManagedOSVersionChannel publishing the OS versions of the pool.
+	Channel string `json:"channel"`
+	// Version is the name of the ManagedOSVersion of the channel the machines of the pool run. A new version is rolled
+	// out to the machines of the pool once Kubernetes upgrades of the cluster are complete.
+	Version string `json:"version,omitempty"`
+	// RegistrationImageVersion is the name of the ManagedOSVersion, of type iso, the registration image of the pool is
+	// built from. Machines booted from the registration image register to the pool. No registration image is built if
+	// empty.
+	RegistrationImageVersion string `json:"registrationImageVersion,omitempty"`
+	// RegistrationConfig is the Elemental config of the machines registering to the pool, such as the device the OS is
+	// installed to.
+	RegistrationConfig rkev1.GenericMap `json:"registrationConfig,omitempty" wrangler:"nullable"`
 }
 
 type RKEMachinePoolRollingUpdate struct {
@@ -63,8 +103,22 @@ type RKEMachinePoolRollingUpdate struct {
 	// at any time during the update is at most 130% of desired machines.
 	// +optional
 	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+
+	// The order in which the machines of the pool are replaced during the
+	// update, OldestFirst or NewestFirst. The machines of the oldest or the
+	// newest machine sets are deleted first, respectively.
+	// Defaults to OldestFirst.
+	// +optional
+	RolloutOrder RKEMachinePoolRolloutOrder `json:"rolloutOrder,omitempty"`
 }
 
+type RKEMachinePoolRolloutOrder string
+
+const (
+	RKEMachinePoolRolloutOrderOldestFirst RKEMachinePoolRolloutOrder = "OldestFirst"
+	RKEMachinePoolRolloutOrderNewestFirst RKEMachinePoolRolloutOrder = "NewestFirst"
+)
+
 // Note: if you add new fields to the RKEConfig, please ensure that you check
 // `pkg/controllers/provisioningv2/rke2/provisioningcluster/template.go` file and
 // drop the fields when saving a copy of the cluster spec on etcd snapshots, otherwise,
@@ -81,6 +135,82 @@ type RKEConfig struct {
 	MachinePools        []RKEMachinePool        `json:"machinePools,omitempty"`
 	MachinePoolDefaults RKEMachinePoolDefaults  `json:"machinePoolDefaults,omitempty"`
 	InfrastructureRef   *corev1.ObjectReference `json:"infrastructureRef,omitempty"`
+
+	// NodeAttestation requires custom nodes to prove their identity before they are allowed to join the cluster, so
+	// that the registration command alone isn't enough to join.
+	NodeAttestation *NodeAttestation `json:"nodeAttestation,omitempty"`
+
+	// ControlPlaneAutoResize resizes the machines of the control plane machine pools provisioned by a node driver, as
+	// recommended by the control plane advisor, during maintenance windows.
+	ControlPlaneAutoResize *ControlPlaneAutoResize `json:"controlPlaneAutoResize,omitempty"`
+
+	// CustomNodeAdoption adopts the custom nodes registering with a pre-approved fingerprint into adoption pools, which
+	// set their roles, labels and taints instead of the roles passed to their registration command.
+	CustomNodeAdoption *CustomNodeAdoption `json:"customNodeAdoption,omitempty"`
+}
+
+type ControlPlaneAutoResize struct {
+	// MaintenanceWindow is the cron schedule of the start of the maintenance windows control plane machine pools can
+	// be resized in.
+	MaintenanceWindow string `json:"maintenanceWindow"`
+	// MaintenanceWindowDuration is how long maintenance windows last, defaults to 1 hour.
+	MaintenanceWindowDuration *metav1.Duration `json:"maintenanceWindowDuration,omitempty"`
+	// ScaleDown allows resizing machine pools to smaller machines, machine pools are only resized to larger machines
+	// otherwise.
+	ScaleDown bool `json:"scaleDown,omitempty"`
+}
+
+type NodeAttestationType string
+
+const (
+	// NodeAttestationAWSInstanceIdentity attests nodes with their signed AWS instance identity document.
+	NodeAttestationAWSInstanceIdentity NodeAttestationType = "aws-instance-identity"
+	// NodeAttestationTPM attests nodes with a signature made by a key resident in their TPM.
+	NodeAttestationTPM NodeAttestationType = "tpm"
+)
+
+type NodeAttestation struct {
+	// Type of the attestation custom nodes must present.
+	Type NodeAttestationType `json:"type"`
+
+	// AWSCertificate is the PEM encoded AWS public certificate instance identity documents are signed with, for the
+	// region of the nodes.
+	AWSCertificate string `json:"awsCertificate,omitempty"`
+	// AWSAccountIDs are the AWS accounts nodes are allowed to run in, any account if empty.
+	AWSAccountIDs []string `json:"awsAccountIds,omitempty"`
+	// AWSRegions are the AWS regions nodes are allowed to run in, any region if empty.
+	AWSRegions []string `json:"awsRegions,omitempty"`
+
+	// TPMKeyHashes are the hex encoded SHA-256 hashes of the DER encoded public keys of the TPM resident keys of the
+	// nodes allowed to join.
+	TPMKeyHashes []string `json:"tpmKeyHashes,omitempty"`
+}
+
+type CustomNodeAdoption struct {
+	// Fingerprints are the pre-approved fingerprints of the custom nodes adopted into the pools: the identity the nodes
+	// attested if the cluster requires NodeAttestation, the hex encoded SHA-256 hash of their machine ID otherwise.
+	Fingerprints []string `json:"fingerprints,omitempty"`
+	// Pools are the pools the nodes are adopted into, a node is adopted into the first pool it matches.
+	Pools []CustomNodeAdoptionPool `json:"pools,omitempty"`
+	// RejectUnmatched refuses to register the custom nodes that aren't pre-approved or match no pool, they register with
+	// the roles passed to their registration command otherwise.
+	RejectUnmatched bool `json:"rejectUnmatched,omitempty"`
+}
+
+type CustomNodeAdoptionPool struct {
+	// Name of the pool, the machines adopted into the pool are labeled with it.
+	Name string `json:"name"`
+	// NodeSelector are the labels, passed to the registration command of the nodes, the nodes of the pool must have.
+	// Any pre-approved node matches the pool if empty.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	EtcdRole         bool `json:"etcdRole,omitempty"`
+	ControlPlaneRole bool `json:"controlPlaneRole,omitempty"`
+	WorkerRole       bool `json:"workerRole,omitempty"`
+
+	// Labels and Taints are added to the nodes adopted into the pool.
+	Labels map[string]string `json:"labels,omitempty"`
+	Taints []corev1.Taint    `json:"taints,omitempty"`
 }
 
 type RKEMachinePoolDefaults struct {
@@ -30,6 +30,23 @@
 	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionRejectionStatus) DeepCopyInto(out *AdmissionRejectionStatus) {
+	*out = *in
+	in.LastRejectionTime.DeepCopyInto(&out.LastRejectionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionRejectionStatus.
+func (in *AdmissionRejectionStatus) DeepCopy() *AdmissionRejectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionRejectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentDeploymentCustomization) DeepCopyInto(out *AgentDeploymentCustomization) {
 	*out = *in
@@ -174,6 +191,16 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(AgentDeploymentCustomization)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.KubeconfigDistribution != nil {
+		in, out := &in.KubeconfigDistribution, &out.KubeconfigDistribution
+		*out = new(KubeconfigDistribution)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MetadataPropagation != nil {
+		in, out := &in.MetadataPropagation, &out.MetadataPropagation
+		*out = new(MetadataPropagation)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -195,6 +222,62 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 		*out = make([]genericcondition.GenericCondition, len(*in))
 		copy(*out, *in)
 	}
+	if in.MachinePoolOSImages != nil {
+		in, out := &in.MachinePoolOSImages, &out.MachinePoolOSImages
+		*out = make([]MachinePoolOSImageStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MachinePoolCloudCredentials != nil {
+		in, out := &in.MachinePoolCloudCredentials, &out.MachinePoolCloudCredentials
+		*out = make([]MachinePoolCloudCredentialStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.OrphanedCloudResources != nil {
+		in, out := &in.OrphanedCloudResources, &out.OrphanedCloudResources
+		*out = make([]OrphanedCloudResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.MachinePoolImages != nil {
+		in, out := &in.MachinePoolImages, &out.MachinePoolImages
+		*out = make([]MachinePoolImageStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MachineReplacements != nil {
+		in, out := &in.MachineReplacements, &out.MachineReplacements
+		*out = make([]MachineReplacementStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.MachinePoolMigrations != nil {
+		in, out := &in.MachinePoolMigrations, &out.MachinePoolMigrations
+		*out = make([]MachinePoolMigrationStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.MachineAdoptions != nil {
+		in, out := &in.MachineAdoptions, &out.MachineAdoptions
+		*out = make([]MachineAdoptionStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.UpgradeScan != nil {
+		in, out := &in.UpgradeScan, &out.UpgradeScan
+		*out = new(UpgradeScanStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradeRollback != nil {
+		in, out := &in.UpgradeRollback, &out.UpgradeRollback
+		*out = new(UpgradeRollbackStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdmissionRejections != nil {
+		in, out := &in.AdmissionRejections, &out.AdmissionRejections
+		*out = make([]AdmissionRejectionStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -208,6 +291,92 @@ func (in *ClusterStatus) DeepCopy() *ClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneAutoResize) DeepCopyInto(out *ControlPlaneAutoResize) {
+	*out = *in
+	if in.MaintenanceWindowDuration != nil {
+		in, out := &in.MaintenanceWindowDuration, &out.MaintenanceWindowDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneAutoResize.
+func (in *ControlPlaneAutoResize) DeepCopy() *ControlPlaneAutoResize {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneAutoResize)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomNodeAdoption) DeepCopyInto(out *CustomNodeAdoption) {
+	*out = *in
+	if in.Fingerprints != nil {
+		in, out := &in.Fingerprints, &out.Fingerprints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Pools != nil {
+		in, out := &in.Pools, &out.Pools
+		*out = make([]CustomNodeAdoptionPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomNodeAdoption.
+func (in *CustomNodeAdoption) DeepCopy() *CustomNodeAdoption {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomNodeAdoption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomNodeAdoptionPool) DeepCopyInto(out *CustomNodeAdoptionPool) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]corev1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomNodeAdoptionPool.
+func (in *CustomNodeAdoptionPool) DeepCopy() *CustomNodeAdoptionPool {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomNodeAdoptionPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImportedConfig) DeepCopyInto(out *ImportedConfig) {
 	*out = *in
@@ -224,6 +393,252 @@ func (in *ImportedConfig) DeepCopy() *ImportedConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigDistribution) DeepCopyInto(out *KubeconfigDistribution) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigDistribution.
+func (in *KubeconfigDistribution) DeepCopy() *KubeconfigDistribution {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigDistribution)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineAdoptionStatus) DeepCopyInto(out *MachineAdoptionStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineAdoptionStatus.
+func (in *MachineAdoptionStatus) DeepCopy() *MachineAdoptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineAdoptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineImageStatus) DeepCopyInto(out *MachineImageStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineImageStatus.
+func (in *MachineImageStatus) DeepCopy() *MachineImageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineImageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineInventoryStatus) DeepCopyInto(out *MachineInventoryStatus) {
+	*out = *in
+	if in.Hardware != nil {
+		in, out := &in.Hardware, &out.Hardware
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineInventoryStatus.
+func (in *MachineInventoryStatus) DeepCopy() *MachineInventoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineInventoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolCloudCredentialStatus) DeepCopyInto(out *MachinePoolCloudCredentialStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolCloudCredentialStatus.
+func (in *MachinePoolCloudCredentialStatus) DeepCopy() *MachinePoolCloudCredentialStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolCloudCredentialStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolImageStatus) DeepCopyInto(out *MachinePoolImageStatus) {
+	*out = *in
+	if in.Machines != nil {
+		in, out := &in.Machines, &out.Machines
+		*out = make([]MachineImageStatus, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolImageStatus.
+func (in *MachinePoolImageStatus) DeepCopy() *MachinePoolImageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolImageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolMigrationStatus) DeepCopyInto(out *MachinePoolMigrationStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolMigrationStatus.
+func (in *MachinePoolMigrationStatus) DeepCopy() *MachinePoolMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolOSImageStatus) DeepCopyInto(out *MachinePoolOSImageStatus) {
+	*out = *in
+	if in.Inventory != nil {
+		in, out := &in.Inventory, &out.Inventory
+		*out = make([]MachineInventoryStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolOSImageStatus.
+func (in *MachinePoolOSImageStatus) DeepCopy() *MachinePoolOSImageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolOSImageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineReplacementStatus) DeepCopyInto(out *MachineReplacementStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineReplacementStatus.
+func (in *MachineReplacementStatus) DeepCopy() *MachineReplacementStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineReplacementStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetadataPropagation) DeepCopyInto(out *MetadataPropagation) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataPropagation.
+func (in *MetadataPropagation) DeepCopy() *MetadataPropagation {
+	if in == nil {
+		return nil
+	}
+	out := new(MetadataPropagation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeAttestation) DeepCopyInto(out *NodeAttestation) {
+	*out = *in
+	if in.AWSAccountIDs != nil {
+		in, out := &in.AWSAccountIDs, &out.AWSAccountIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AWSRegions != nil {
+		in, out := &in.AWSRegions, &out.AWSRegions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TPMKeyHashes != nil {
+		in, out := &in.TPMKeyHashes, &out.TPMKeyHashes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAttestation.
+func (in *NodeAttestation) DeepCopy() *NodeAttestation {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeAttestation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanedCloudResource) DeepCopyInto(out *OrphanedCloudResource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanedCloudResource.
+func (in *OrphanedCloudResource) DeepCopy() *OrphanedCloudResource {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanedCloudResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RKEConfig) DeepCopyInto(out *RKEConfig) {
 	*out = *in
@@ -236,7 +651,7 @@ func (in *RKEConfig) DeepCopyInto(out *RKEConfig) {
 	if in.ETCDSnapshotRestore != nil {
 		in, out := &in.ETCDSnapshotRestore, &out.ETCDSnapshotRestore
 		*out = new(rkecattleiov1.ETCDSnapshotRestore)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.RotateCertificates != nil {
 		in, out := &in.RotateCertificates, &out.RotateCertificates
@@ -261,6 +676,21 @@ func (in *RKEConfig) DeepCopyInto(out *RKEConfig) {
 		*out = new(corev1.ObjectReference)
 		**out = **in
 	}
+	if in.NodeAttestation != nil {
+		in, out := &in.NodeAttestation, &out.NodeAttestation
+		*out = new(NodeAttestation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControlPlaneAutoResize != nil {
+		in, out := &in.ControlPlaneAutoResize, &out.ControlPlaneAutoResize
+		*out = new(ControlPlaneAutoResize)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CustomNodeAdoption != nil {
+		in, out := &in.CustomNodeAdoption, &out.CustomNodeAdoption
+		*out = new(CustomNodeAdoption)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -332,6 +762,21 @@ func (in *RKEMachinePool) DeepCopyInto(out *RKEMachinePool) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.OSImage != nil {
+		in, out := &in.OSImage, &out.OSImage
+		*out = new(RKEMachinePoolOSImage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GarbageCollection != nil {
+		in, out := &in.GarbageCollection, &out.GarbageCollection
+		*out = new(rkecattleiov1.GarbageCollection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachineImage != nil {
+		in, out := &in.MachineImage, &out.MachineImage
+		*out = new(RKEMachinePoolMachineImage)
+		**out = **in
+	}
 	return
 }
 
@@ -361,6 +806,39 @@ func (in *RKEMachinePoolDefaults) DeepCopy() *RKEMachinePoolDefaults {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RKEMachinePoolMachineImage) DeepCopyInto(out *RKEMachinePoolMachineImage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RKEMachinePoolMachineImage.
+func (in *RKEMachinePoolMachineImage) DeepCopy() *RKEMachinePoolMachineImage {
+	if in == nil {
+		return nil
+	}
+	out := new(RKEMachinePoolMachineImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RKEMachinePoolOSImage) DeepCopyInto(out *RKEMachinePoolOSImage) {
+	*out = *in
+	in.RegistrationConfig.DeepCopyInto(&out.RegistrationConfig)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RKEMachinePoolOSImage.
+func (in *RKEMachinePoolOSImage) DeepCopy() *RKEMachinePoolOSImage {
+	if in == nil {
+		return nil
+	}
+	out := new(RKEMachinePoolOSImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RKEMachinePoolRollingUpdate) DeepCopyInto(out *RKEMachinePoolRollingUpdate) {
 	*out = *in
@@ -386,3 +864,89 @@ func (in *RKEMachinePoolRollingUpdate) DeepCopy() *RKEMachinePoolRollingUpdate {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemovedAPIRequest) DeepCopyInto(out *RemovedAPIRequest) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemovedAPIRequest.
+func (in *RemovedAPIRequest) DeepCopy() *RemovedAPIRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(RemovedAPIRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemovedAPIUsage) DeepCopyInto(out *RemovedAPIUsage) {
+	*out = *in
+	if in.Managers != nil {
+		in, out := &in.Managers, &out.Managers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemovedAPIUsage.
+func (in *RemovedAPIUsage) DeepCopy() *RemovedAPIUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(RemovedAPIUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeRollbackStatus) DeepCopyInto(out *UpgradeRollbackStatus) {
+	*out = *in
+	if in.PendingMachines != nil {
+		in, out := &in.PendingMachines, &out.PendingMachines
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeRollbackStatus.
+func (in *UpgradeRollbackStatus) DeepCopy() *UpgradeRollbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeRollbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeScanStatus) DeepCopyInto(out *UpgradeScanStatus) {
+	*out = *in
+	if in.Blocking != nil {
+		in, out := &in.Blocking, &out.Blocking
+		*out = make([]RemovedAPIUsage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Requested != nil {
+		in, out := &in.Requested, &out.Requested
+		*out = make([]RemovedAPIRequest, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeScanStatus.
+func (in *UpgradeScanStatus) DeepCopy() *UpgradeScanStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeScanStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,20 @@
+package v1
+
+// AdmissionConfiguration configures the admission plugins of the kube-apiservers of the cluster. The planner renders
+// the admission control configuration file of the plugins on the control plane nodes and enables them, restarting the
+// kube-apiservers with the rest of the control plane configuration when it changes.
+type AdmissionConfiguration struct {
+	Plugins []AdmissionPlugin `json:"plugins,omitempty"`
+}
+
+// AdmissionPlugin is an admission plugin of the kube-apiserver, such as ImagePolicyWebhook or EventRateLimit.
+type AdmissionPlugin struct {
+	Name string `json:"name"`
+	// Configuration is the configuration of the plugin, in the format expected by the plugin, such as the
+	// ImagePolicyWebhook configuration with its imagePolicy field or the EventRateLimit Configuration.
+	Configuration GenericMap `json:"configuration,omitempty" wrangler:"nullable"`
+	// KubeconfigSecretName is the name of a secret in the namespace of the cluster, authorized for the cluster, with
+	// the kubeconfig of the webhook backend of the plugin in its kubeconfig key. It is rendered next to the admission
+	// control configuration file and set as the kubeConfigFile of the ImagePolicyWebhook plugin.
+	KubeconfigSecretName string `json:"kubeconfigSecretName,omitempty"`
+}
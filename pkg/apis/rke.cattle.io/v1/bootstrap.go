@@ -25,6 +25,23 @@ type RKEBootstrapStatus struct {
 	// DataSecretName is the name of the secret that stores the bootstrap data script.
 	// +optional
 	DataSecretName *string `json:"dataSecretName,omitempty"`
+
+	// Phases are the times the machine reached each of its provisioning phases.
+	// +optional
+	Phases RKEBootstrapPhases `json:"phases,omitempty"`
+}
+
+// RKEBootstrapPhases are the times the machine of a bootstrap first reached each of its provisioning phases, unset
+// for phases it didn't reach yet.
+type RKEBootstrapPhases struct {
+	// InfrastructureReady is when the infrastructure of the machine was ready, such as its instance running.
+	InfrastructureReady *metav1.Time `json:"infrastructureReady,omitempty"`
+	// BootstrapDelivered is when the bootstrap data of the machine was ready to be consumed.
+	BootstrapDelivered *metav1.Time `json:"bootstrapDelivered,omitempty"`
+	// FirstPlanApplied is when the machine applied its first plan.
+	FirstPlanApplied *metav1.Time `json:"firstPlanApplied,omitempty"`
+	// NodeReady is when the node of the machine was ready.
+	NodeReady *metav1.Time `json:"nodeReady,omitempty"`
 }
 
 // +genclient
@@ -29,9 +29,42 @@ type RKEClusterSpecCommon struct {
 	MachineSelectorFiles  []RKEProvisioningFiles `json:"machineSelectorFiles,omitempty"`
 	AdditionalManifest    string                 `json:"additionalManifest,omitempty"`
 	Registries            *Registry              `json:"registries,omitempty"`
+	EmbeddedRegistry      *EmbeddedRegistry      `json:"embeddedRegistry,omitempty"`
+	NodeLocalDNS          *NodeLocalDNS          `json:"nodeLocalDNS,omitempty"`
 	ETCD                  *ETCD                  `json:"etcd,omitempty"`
+	InitNodeSelection     *InitNodeSelection     `json:"initNodeSelection,omitempty"`
+	MachineDeletionHooks  *MachineDeletionHooks  `json:"machineDeletionHooks,omitempty"`
+	// NodeDNS and NodeTime configure the DNS resolver and the time synchronization of the nodes, restoring the
+	// configuration when it drifts.
+	NodeDNS  *NodeDNS  `json:"nodeDNS,omitempty"`
+	NodeTime *NodeTime `json:"nodeTime,omitempty"`
+	// AdmissionConfiguration configures the admission plugins of the kube-apiservers, without machineSelectorFiles
+	// and kube-apiserver args.
+	AdmissionConfiguration *AdmissionConfiguration `json:"admissionConfiguration,omitempty"`
+	// MachineSelectorConfigDropIns are additional config files written to the config.yaml.d directory of the
+	// machines, next to the config rendered by rancher.
+	MachineSelectorConfigDropIns []RKEConfigDropIn `json:"machineSelectorConfigDropIns,omitempty"`
+	// MachineSelectorProbes are health probes of the machines, on top of the probes of the Kubernetes components.
+	MachineSelectorProbes []RKEProbe `json:"machineSelectorProbes,omitempty"`
 	// Increment to force all nodes to re-provision
 	ProvisionGeneration int `json:"provisionGeneration,omitempty"`
+	// EncryptPlanSecrets encrypts the machine plans, which contain tokens and certificates, with a key specific to the
+	// cluster before they are stored. Plans are only decrypted when served to the agent of their machine. The cluster
+	// isn't reconciled while the plan encryption key provider isn't configured.
+	EncryptPlanSecrets bool `json:"encryptPlanSecrets,omitempty"`
+	// OneTimeBootstrapKeys embeds a key unique to each machine in its bootstrap data, rather than a token valid for the
+	// lifetime of the machine. The key is exchanged once for the credentials of the machine, and is invalid afterwards.
+	OneTimeBootstrapKeys bool `json:"oneTimeBootstrapKeys,omitempty"`
+	// SystemChartValuesUpdate controls how changes to the default values of the system charts are rolled out, as soon
+	// as they change if unset.
+	SystemChartValuesUpdate *SystemChartValuesUpdate `json:"systemChartValuesUpdate,omitempty"`
+	// LockPools are the names of the lock pools the cluster shares with the clusters running on the same
+	// infrastructure, such as the hosts of a Harvester or vSphere cluster. The operations restarting etcd on every
+	// etcd node, certificate and encryption key rotation, run on a single cluster of a lock pool at a time.
+	LockPools []string `json:"lockPools,omitempty"`
+	// PlannerBehaviors enables or disables the planner behaviors of the cluster by name, overriding the
+	// planner-behavior-defaults setting, so that newer behaviors can be rolled out to test clusters first.
+	PlannerBehaviors map[string]bool `json:"plannerBehaviors,omitempty"`
 }
 
 type LocalClusterAuthEndpoint struct {
@@ -40,6 +73,30 @@ type LocalClusterAuthEndpoint struct {
 	CACerts string `json:"caCerts,omitempty"`
 }
 
+// NodeLocalDNS deploys NodeLocal DNSCache with the coredns chart of RKE2, running a DNS cache on every node of the
+// cluster. It isn't supported on K3s.
+type NodeLocalDNS struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// IPAddress is the link-local address the cache listens on, defaults to 169.254.20.10.
+	IPAddress string `json:"ipAddress,omitempty"`
+}
+
+// NodeDNS configures the DNS servers and search domains of the nodes, with systemd-resolved and NetworkManager, those
+// running on the nodes.
+type NodeDNS struct {
+	// Servers are the addresses of the DNS servers.
+	Servers []string `json:"servers,omitempty"`
+	// SearchDomains are the domains searched for names that aren't fully qualified.
+	SearchDomains []string `json:"searchDomains,omitempty"`
+}
+
+// NodeTime configures the NTP servers the nodes synchronize their time with, with chrony. The time of a node is stepped
+// whenever it's off by more than a second, as a skewed time fails the certificates and the joins of nodes.
+type NodeTime struct {
+	// NTPServers are the hostnames or addresses of the NTP servers.
+	NTPServers []string `json:"ntpServers,omitempty"`
+}
+
 type RKESystemConfig struct {
 	MachineLabelSelector *metav1.LabelSelector `json:"machineLabelSelector,omitempty"`
 	Config               GenericMap            `json:"config,omitempty" wrangler:"nullable"`
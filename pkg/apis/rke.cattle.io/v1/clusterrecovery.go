@@ -0,0 +1,63 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ClusterRecoveryPhase string
+
+const (
+	// ClusterRecoveryPhaseResetting is the phase in which etcd is reset on a single member, from its own data or a
+	// snapshot, and the cluster is restarted.
+	ClusterRecoveryPhaseResetting ClusterRecoveryPhase = "Resetting"
+	// ClusterRecoveryPhaseRejoining is the phase in which the other etcd machines rejoin the reset member, or are
+	// replaced.
+	ClusterRecoveryPhaseRejoining ClusterRecoveryPhase = "Rejoining"
+	// ClusterRecoveryPhaseVerifying is the phase in which the recovery waits for the cluster to be ready.
+	ClusterRecoveryPhaseVerifying ClusterRecoveryPhase = "Verifying"
+	ClusterRecoveryPhaseComplete  ClusterRecoveryPhase = "Complete"
+	ClusterRecoveryPhaseFailed    ClusterRecoveryPhase = "Failed"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterRecovery recovers a provisioned cluster that lost etcd quorum. etcd is reset on a single member, either a
+// surviving etcd machine keeping its data or the machine a snapshot is restored on, the other etcd machines rejoin it
+// or are replaced, and the recovery completes once the cluster is ready again.
+type ClusterRecovery struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              ClusterRecoverySpec   `json:"spec"`
+	Status            ClusterRecoveryStatus `json:"status,omitempty"`
+}
+
+type ClusterRecoverySpec struct {
+	// ClusterName is the name of the provisioning cluster to recover, in the namespace of the recovery.
+	ClusterName string `json:"clusterName"`
+	// MachineName is the name of a surviving etcd machine of the cluster that etcd is reset on, keeping its data.
+	// Exactly one of MachineName and SnapshotName must be set.
+	MachineName string `json:"machineName,omitempty"`
+	// SnapshotName is the name of the etcdsnapshot of the cluster that etcd is restored from.
+	SnapshotName string `json:"snapshotName,omitempty"`
+	// ReplaceUnhealthyMachines deletes the etcd machines of machine pools that didn't rejoin etcd within the
+	// RejoinTimeout, so that their pools replace them. Other etcd machines are only reported.
+	ReplaceUnhealthyMachines bool `json:"replaceUnhealthyMachines,omitempty"`
+	// RejoinTimeout is how long the etcd machines are given to rejoin once etcd was reset, 15m by default.
+	RejoinTimeout *metav1.Duration `json:"rejoinTimeout,omitempty"`
+}
+
+type ClusterRecoveryStatus struct {
+	Phase   ClusterRecoveryPhase `json:"phase,omitempty"`
+	Message string               `json:"message,omitempty"`
+	// ETCDSnapshotRestore is the etcd snapshot restore the recovery set on the cluster.
+	ETCDSnapshotRestore *ETCDSnapshotRestore `json:"etcdSnapshotRestore,omitempty"`
+	StartTime           *metav1.Time         `json:"startTime,omitempty"`
+	// ResetTime is when etcd was reset and the cluster started restarting, from which the RejoinTimeout elapses.
+	ResetTime      *metav1.Time `json:"resetTime,omitempty"`
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// UnhealthyMachines are the etcd machines that haven't rejoined etcd.
+	UnhealthyMachines []string `json:"unhealthyMachines,omitempty"`
+	// ReplacedMachines are the etcd machines deleted to be replaced by their machine pools.
+	ReplacedMachines []string `json:"replacedMachines,omitempty"`
+}
@@ -0,0 +1,20 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RKEConfigDropIn is an additional config file written to the config.yaml.d directory of the machines matching its
+// selector. K3s and RKE2 merge the files of the directory in the lexical order of their names, later files overriding
+// the values of earlier ones: the file is named <order>-dropin-<name>.yaml, so that drop-ins ordered before 50 are
+// overridden by the config rendered by rancher, 50-rancher.yaml, and drop-ins ordered after it override it. Changing
+// or removing a drop-in restarts the machines it applies to.
+type RKEConfigDropIn struct {
+	MachineLabelSelector *metav1.LabelSelector `json:"machineLabelSelector,omitempty"`
+	// Name of the drop-in, a lowercase DNS label unique among the drop-ins of the same order.
+	Name string `json:"name"`
+	// Order of the drop-in among the config files, from 0 to 99 except 50, the order of the config rendered by
+	// rancher.
+	Order  int        `json:"order"`
+	Config GenericMap `json:"config,omitempty" wrangler:"nullable"`
+}
@@ -51,4 +51,18 @@ type RKEControlPlaneStatus struct {
 	ConfigGeneration              int64                               `json:"configGeneration,omitempty"`
 	Initialized                   bool                                `json:"initialized,omitempty"`
 	AgentConnected                bool                                `json:"agentConnected,omitempty"`
+	EmbeddedRegistryNodes         []EmbeddedRegistryNode              `json:"embeddedRegistryNodes,omitempty"`
+	// InitNode is the name of the machine currently elected init node.
+	InitNode string `json:"initNode,omitempty"`
+	// InitNodeUnavailableSince is when the init node stopped being available, from which the re-election backoff
+	// elapses.
+	InitNodeUnavailableSince *metav1.Time `json:"initNodeUnavailableSince,omitempty"`
+	// InitNodeHistory are the latest elections of the init node, the most recent last.
+	InitNodeHistory []InitNodeElection `json:"initNodeHistory,omitempty"`
+	// SystemChartValues is the state of the default values of the system charts, and of their pending update.
+	SystemChartValues *SystemChartValuesStatus `json:"systemChartValues,omitempty"`
+	// LockPools are the lock pools held by the cluster while it restarts etcd.
+	LockPools []string `json:"lockPools,omitempty"`
+	// PlannerBehaviors are the planner behaviors of the cluster and whether they're active.
+	PlannerBehaviors []PlannerBehaviorStatus `json:"plannerBehaviors,omitempty"`
 }
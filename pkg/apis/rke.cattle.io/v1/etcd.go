@@ -12,6 +12,7 @@
 	ETCDSnapshotPhaseInitialRestartCluster  ETCDSnapshotPhase = "InitialRestartCluster"
 	ETCDSnapshotPhasePostRestoreNodeCleanup ETCDSnapshotPhase = "PostRestoreNodeCleanup"
 	ETCDSnapshotPhaseRestartCluster         ETCDSnapshotPhase = "RestartCluster"
+	ETCDSnapshotPhaseNamespaceRestore       ETCDSnapshotPhase = "NamespaceRestore"
 	ETCDSnapshotPhaseFinished               ETCDSnapshotPhase = "Finished"
 	ETCDSnapshotPhaseFailed                 ETCDSnapshotPhase = "Failed"
 )
@@ -39,8 +40,28 @@ type ETCDSnapshotRestore struct {
 	Generation int `json:"generation,omitempty"`
 	// Set to either none (or empty string), all, or kubernetesVersion
 	RestoreRKEConfig string `json:"restoreRKEConfig,omitempty"`
+
+	// MachineName refers to the name of a surviving etcd machine that etcd is reset on, keeping its data, instead of
+	// restoring a snapshot. It is used to recover a cluster that lost etcd quorum, and is mutually exclusive with Name.
+	MachineName string `json:"machineName,omitempty"`
+
+	// Namespaces restricts the restore of the snapshot to the resources of these namespaces. The snapshot is restored
+	// into a temporary control plane on a control plane machine, from which the resources of the namespaces are
+	// applied to the running cluster, which is neither stopped nor rolled back. It requires Name, and the RKE config
+	// isn't restored.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// ConflictStrategy is what a namespace restore does with the resources that exist in the cluster: skip (the
+	// default) keeps them, overwrite replaces them with those of the snapshot, and fail aborts the restore before any
+	// resource is restored.
+	ConflictStrategy string `json:"conflictStrategy,omitempty"`
 }
 
+const (
+	NamespaceRestoreConflictSkip      = "skip"
+	NamespaceRestoreConflictOverwrite = "overwrite"
+	NamespaceRestoreConflictFail      = "fail"
+)
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -0,0 +1,27 @@
+package v1
+
+// GarbageCollection tunes the garbage collection of images and container logs of the kubelet of a node, and the
+// thresholds the kubelet evicts pods at, preventing nodes from running out of disk. Unset fields keep the defaults of
+// the kubelet, or the kubelet-arg set for the node.
+type GarbageCollection struct {
+	// ImageGCHighThresholdPercent is the percent of disk usage after which image garbage collection always runs.
+	ImageGCHighThresholdPercent *int32 `json:"imageGCHighThresholdPercent,omitempty"`
+	// ImageGCLowThresholdPercent is the percent of disk usage image garbage collection frees disk down to. It must be
+	// lower than ImageGCHighThresholdPercent.
+	ImageGCLowThresholdPercent *int32 `json:"imageGCLowThresholdPercent,omitempty"`
+	// ContainerLogMaxSize is the maximum size of a container log file before it is rotated, as a quantity such as 10Mi.
+	ContainerLogMaxSize string `json:"containerLogMaxSize,omitempty"`
+	// ContainerLogMaxFiles is the maximum number of log files kept for a container, at least 2.
+	ContainerLogMaxFiles *int32 `json:"containerLogMaxFiles,omitempty"`
+	// EvictionHard maps eviction signals, such as nodefs.available, to the quantity or percentage of the resource
+	// below which pods are evicted immediately.
+	EvictionHard map[string]string `json:"evictionHard,omitempty"`
+	// EvictionSoft maps eviction signals to the quantity or percentage of the resource below which pods are evicted
+	// once the grace period of the signal elapsed.
+	EvictionSoft map[string]string `json:"evictionSoft,omitempty"`
+	// EvictionSoftGracePeriod maps the eviction signals of EvictionSoft to their grace period, such as 1m30s.
+	EvictionSoftGracePeriod map[string]string `json:"evictionSoftGracePeriod,omitempty"`
+	// EvictionMinimumReclaim maps eviction signals to the quantity or percentage of the resource the kubelet reclaims
+	// beyond the threshold when it evicts pods.
+	EvictionMinimumReclaim map[string]string `json:"evictionMinimumReclaim,omitempty"`
+}
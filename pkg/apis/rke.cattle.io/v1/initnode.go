@@ -0,0 +1,38 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type InitNodeSelectionStrategy string
+
+const (
+	// InitNodeSelectionStrategyLowestIndex elects the etcd machine with the lowest name, which orders the machines of a
+	// machine pool by their index.
+	InitNodeSelectionStrategyLowestIndex InitNodeSelectionStrategy = "LowestIndex"
+	// InitNodeSelectionStrategyOldestMachine elects the oldest etcd machine.
+	InitNodeSelectionStrategyOldestMachine InitNodeSelectionStrategy = "OldestMachine"
+)
+
+// InitNodeSelection configures how the init node, the etcd machine the other machines of the cluster join, is elected,
+// and how long an unavailable init node is kept before another one is elected. Machines annotated with
+// rke.cattle.io/init-node-pin=true are elected before any other machine.
+type InitNodeSelection struct {
+	// Strategy orders the etcd machines the init node is elected from, LowestIndex by default.
+	Strategy InitNodeSelectionStrategy `json:"strategy,omitempty"`
+	// PreferredZones are zones, from the topology.kubernetes.io/zone label of the machines, whose machines are elected
+	// before the machines of other zones, in order.
+	PreferredZones []string `json:"preferredZones,omitempty"`
+	// ReelectionBackoff is how long an init node that became unavailable is kept before another one is elected, so that
+	// a short outage of the init node doesn't change the join URL of the cluster. An init node that is deleted or failed
+	// is replaced immediately.
+	ReelectionBackoff *metav1.Duration `json:"reelectionBackoff,omitempty"`
+}
+
+// InitNodeElection records that a machine was elected init node.
+type InitNodeElection struct {
+	MachineName string      `json:"machineName,omitempty"`
+	ElectedAt   metav1.Time `json:"electedAt,omitempty"`
+	// Reason is why the machine was elected.
+	Reason string `json:"reason,omitempty"`
+}
@@ -23,6 +23,9 @@ type RKEMachineStatus struct {
 	FailureReason             string                              `json:"failureReason,omitempty"`
 	FailureMessage            string                              `json:"failureMessage,omitempty"`
 	Addresses                 []capi.MachineAddress               `json:"addresses,omitempty"`
+	// QueuePosition is the position of the machine in the provisioning queue while its creation waits for other
+	// machines to be created, starting at 1.
+	QueuePosition int `json:"queuePosition,omitempty"`
 }
 
 // +genclient
@@ -0,0 +1,31 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MachineAdoption is the adoption of an existing cloud instance into a machine pool requested through the API, recorded
+// in the rke.cattle.io/machine-adoptions annotation of the cluster. The machine pool is scaled up by one for each
+// adoption, and the new machine claiming it installs the system agent on the instance over SSH instead of creating an
+// instance.
+type MachineAdoption struct {
+	// ID identifies the request.
+	ID string `json:"id,omitempty"`
+	// PoolName is the name of the machine pool the instance is adopted into.
+	PoolName string `json:"poolName,omitempty"`
+	// InstanceID is the ID of the instance in its cloud.
+	InstanceID string `json:"instanceId,omitempty"`
+	// ProviderID is the provider ID of the node of the instance, set on the kubelet of the instance so that the node is
+	// matched to its machine and to the instance by the cloud provider.
+	ProviderID string `json:"providerId,omitempty"`
+	// Address is the address the system agent is installed through.
+	Address string `json:"address,omitempty"`
+	// SSHUser is the user the system agent is installed as.
+	SSHUser string `json:"sshUser,omitempty"`
+	// SSHKeySecretName is the secret of the namespace of the cluster holding the private SSH key of the instance.
+	SSHKeySecretName string `json:"sshKeySecretName,omitempty"`
+	// RequestedAt is when the adoption was requested.
+	RequestedAt metav1.Time `json:"requestedAt,omitempty"`
+	// MachineName is the name of the CAPI machine of the pool that claimed the instance, empty until a machine claims it.
+	MachineName string `json:"machineName,omitempty"`
+}
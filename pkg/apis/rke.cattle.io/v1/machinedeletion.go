@@ -0,0 +1,74 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type MachineDeletionHookFailurePolicy string
+
+const (
+	// MachineDeletionHookFailurePolicyFail keeps the machine from being terminated until the hook succeeds.
+	MachineDeletionHookFailurePolicyFail MachineDeletionHookFailurePolicy = "Fail"
+	// MachineDeletionHookFailurePolicyIgnore moves on to the next hook once the hook failed or timed out.
+	MachineDeletionHookFailurePolicyIgnore MachineDeletionHookFailurePolicy = "Ignore"
+)
+
+type MachineDeletionHookState string
+
+const (
+	MachineDeletionHookStateRunning   MachineDeletionHookState = "Running"
+	MachineDeletionHookStateSucceeded MachineDeletionHookState = "Succeeded"
+	MachineDeletionHookStateSkipped   MachineDeletionHookState = "Skipped"
+	MachineDeletionHookStateFailed    MachineDeletionHookState = "Failed"
+	MachineDeletionHookStateTimedOut  MachineDeletionHookState = "TimedOut"
+)
+
+// MachineDeletionHooks configures the hooks run, in order, when a machine is deleted and before its infrastructure is
+// terminated. The built-in hooks are drain, etcd-member-removal and volume-detach, followed by the webhooks.
+type MachineDeletionHooks struct {
+	// Policies override the timeout and failure policy of the built-in hooks, by name.
+	Policies []MachineDeletionHookPolicy `json:"policies,omitempty"`
+	// Webhooks are called after the built-in hooks, in order, to deregister the machine from external systems.
+	Webhooks []MachineDeletionWebhook `json:"webhooks,omitempty"`
+}
+
+type MachineDeletionHookPolicy struct {
+	Name string `json:"name,omitempty"`
+	// Timeout is how long the hook is run before it is timed out, zero never times out the hook.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// FailurePolicy is what happens once the hook timed out.
+	FailurePolicy MachineDeletionHookFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// MachineDeletionWebhook is called with a POST request, whose body is a MachineDeletionWebhookRequest, until it responds
+// with a 2xx status code.
+type MachineDeletionWebhook struct {
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
+	// CACerts are the PEM encoded certificates trusted to serve the URL, in addition to the system ones.
+	CACerts string `json:"caCerts,omitempty"`
+	// Timeout is how long the webhook is called before it is timed out, 5 minutes by default.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// FailurePolicy is what happens once the webhook timed out, Ignore by default.
+	FailurePolicy MachineDeletionHookFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// MachineDeletionWebhookRequest is the body of the requests to the machine deletion webhooks.
+type MachineDeletionWebhookRequest struct {
+	ClusterNamespace string   `json:"clusterNamespace,omitempty"`
+	ClusterName      string   `json:"clusterName,omitempty"`
+	MachineName      string   `json:"machineName,omitempty"`
+	NodeName         string   `json:"nodeName,omitempty"`
+	ProviderID       string   `json:"providerID,omitempty"`
+	Addresses        []string `json:"addresses,omitempty"`
+}
+
+// MachineDeletionHookStatus is the status of a hook run for a deleting machine, recorded in the
+// rke.cattle.io/deletion-hook-status annotation of the machine.
+type MachineDeletionHookStatus struct {
+	Name           string                   `json:"name,omitempty"`
+	State          MachineDeletionHookState `json:"state,omitempty"`
+	Message        string                   `json:"message,omitempty"`
+	StartTime      *metav1.Time             `json:"startTime,omitempty"`
+	CompletionTime *metav1.Time             `json:"completionTime,omitempty"`
+}
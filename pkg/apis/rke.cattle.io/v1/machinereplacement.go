@@ -0,0 +1,17 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MachineReplacement is the replacement of a machine by a substitute requested through the API, recorded in the
+// rke.cattle.io/machine-replacement annotation of the machine.
+type MachineReplacement struct {
+	// ID identifies the request.
+	ID string `json:"id,omitempty"`
+	// Force replaces the machine even if the data volumes of its node can't be moved to the substitute, losing their
+	// data.
+	Force bool `json:"force,omitempty"`
+	// RequestedAt is when the replacement was first requested, the substitute is a machine of the pool created after.
+	RequestedAt metav1.Time `json:"requestedAt,omitempty"`
+}
@@ -0,0 +1,48 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type NodeMaintenanceAction string
+
+const (
+	NodeMaintenanceActionCordon   NodeMaintenanceAction = "cordon"
+	NodeMaintenanceActionDrain    NodeMaintenanceAction = "drain"
+	NodeMaintenanceActionUncordon NodeMaintenanceAction = "uncordon"
+)
+
+type NodeMaintenanceState string
+
+const (
+	NodeMaintenanceStateInProgress NodeMaintenanceState = "InProgress"
+	NodeMaintenanceStateSucceeded  NodeMaintenanceState = "Succeeded"
+	NodeMaintenanceStateFailed     NodeMaintenanceState = "Failed"
+)
+
+// NodeMaintenance is a cordon, drain or uncordon of the node of a machine requested through the API, recorded in the
+// rke.cattle.io/node-maintenance annotation of the machine.
+type NodeMaintenance struct {
+	// ID identifies the request, a new request replaces the previous one.
+	ID     string                `json:"id,omitempty"`
+	Action NodeMaintenanceAction `json:"action,omitempty"`
+	// DrainOptions are the options of a drain, the node is cordoned before it is drained.
+	DrainOptions *DrainOptions `json:"drainOptions,omitempty"`
+	RequestedAt  metav1.Time   `json:"requestedAt,omitempty"`
+}
+
+// NodeMaintenanceStatus is the progress of the node maintenance of a machine, recorded in the
+// rke.cattle.io/node-maintenance-status annotation of the machine.
+type NodeMaintenanceStatus struct {
+	// ID is the ID of the request the status is for.
+	ID      string                `json:"id,omitempty"`
+	Action  NodeMaintenanceAction `json:"action,omitempty"`
+	State   NodeMaintenanceState  `json:"state,omitempty"`
+	Message string                `json:"message,omitempty"`
+	// PodsToEvict is the number of pods that were to be evicted from the node when the drain started.
+	PodsToEvict int `json:"podsToEvict,omitempty"`
+	// PodsEvicted is the number of pods that were evicted or deleted from the node so far.
+	PodsEvicted    int          `json:"podsEvicted,omitempty"`
+	StartTime      *metav1.Time `json:"startTime,omitempty"`
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
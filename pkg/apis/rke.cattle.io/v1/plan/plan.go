@@ -38,6 +38,8 @@ type PeriodicInstructionOutput struct {
 	Stderr                []byte `json:"stderr"`                // Stderr is a byte array of the gzip+base64 stderr output
 	ExitCode              int    `json:"exitCode"`              // ExitCode is an int representing the exit code of the last run instruction
 	LastSuccessfulRunTime string `json:"lastSuccessfulRunTime"` // LastSuccessfulRunTime is a time.UnixDate formatted string of the last time the instruction was run
+	TimedOut              bool   `json:"timedOut,omitempty"`    // TimedOut is true if the last run of the instruction was killed after exceeding its timeout
+	Truncated             bool   `json:"truncated,omitempty"`   // Truncated is true if the output of the last run of the instruction was truncated to its max output size
 }
 
 type Secret struct {
@@ -52,6 +54,12 @@ type OneTimeInstruction struct {
 	Args       []string `json:"args,omitempty"`
 	Command    string   `json:"command,omitempty"`
 	SaveOutput bool     `json:"saveOutput,omitempty"`
+	// TimeoutSeconds is how long the agent lets the instruction run before killing it, failing the plan. Killed
+	// instructions are recorded in the periodic output. No timeout is enforced if unset.
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+	// MaxOutputBytes is the size the agent truncates the saved output of the instruction to. Truncated outputs are
+	// recorded in the periodic output. The output is not truncated if unset.
+	MaxOutputBytes int `json:"maxOutputBytes,omitempty"`
 }
 
 type PeriodicInstruction struct {
@@ -61,6 +69,12 @@ type PeriodicInstruction struct {
 	Args          []string `json:"args,omitempty"`
 	Command       string   `json:"command,omitempty"`
 	PeriodSeconds int      `json:"periodSeconds,omitempty"` // default 600, i.e. 10 minutes
+	// TimeoutSeconds is how long the agent lets a run of the instruction go before killing it, which is recorded in
+	// the periodic output of the instruction. No timeout is enforced if unset.
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+	// MaxOutputBytes is the size the agent truncates the stdout and stderr of the instruction to, which is recorded
+	// in the periodic output of the instruction. The output is not truncated if unset.
+	MaxOutputBytes int `json:"maxOutputBytes,omitempty"`
 }
 
 type File struct {
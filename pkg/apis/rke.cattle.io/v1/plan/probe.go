@@ -8,6 +8,16 @@ type HTTPGetAction struct {
 	CACert     string `json:"caCert,omitempty"`
 }
 
+// TCPSocketAction succeeds if a TCP connection to the address, host:port, can be opened.
+type TCPSocketAction struct {
+	Address string `json:"address,omitempty"`
+}
+
+// ExecAction succeeds if the command exits with 0.
+type ExecAction struct {
+	Command []string `json:"command,omitempty"`
+}
+
 type Probe struct {
 	Name                string        `json:"name,omitempty"`
 	InitialDelaySeconds int           `json:"initialDelaySeconds,omitempty"` // default 0
@@ -15,4 +25,7 @@ type Probe struct {
 	SuccessThreshold    int           `json:"successThreshold,omitempty"`    // default 1
 	FailureThreshold    int           `json:"failureThreshold,omitempty"`    // default 3
 	HTTPGetAction       HTTPGetAction `json:"httpGet,omitempty"`
+	// TCPSocketAction and ExecAction are set instead of HTTPGetAction by the custom probes of clusters.
+	TCPSocketAction *TCPSocketAction `json:"tcpSocket,omitempty"`
+	ExecAction      *ExecAction      `json:"exec,omitempty"`
 }
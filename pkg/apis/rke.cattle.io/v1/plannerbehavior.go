@@ -0,0 +1,21 @@
+package v1
+
+// PlannerBehaviorSource is where the state of a planner behavior of a cluster comes from.
+type PlannerBehaviorSource string
+
+const (
+	// PlannerBehaviorSourceDefault is the default state of the behavior in this version of rancher.
+	PlannerBehaviorSourceDefault PlannerBehaviorSource = "default"
+	// PlannerBehaviorSourceSetting is the planner-behavior-defaults setting.
+	PlannerBehaviorSourceSetting PlannerBehaviorSource = "setting"
+	// PlannerBehaviorSourceCluster is the plannerBehaviors of the cluster.
+	PlannerBehaviorSourceCluster PlannerBehaviorSource = "cluster"
+)
+
+// PlannerBehaviorStatus is whether a planner behavior is active for a cluster.
+type PlannerBehaviorStatus struct {
+	Name        string                `json:"name"`
+	Description string                `json:"description,omitempty"`
+	Enabled     bool                  `json:"enabled"`
+	Source      PlannerBehaviorSource `json:"source,omitempty"`
+}
@@ -0,0 +1,47 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RKEProbe is a health probe of the machines matching its selector, evaluated by the system agent alongside the probes
+// of the Kubernetes components. Machines are only ready once their probes are healthy, so that provisioning and
+// upgrades wait on them.
+type RKEProbe struct {
+	MachineLabelSelector *metav1.LabelSelector `json:"machineLabelSelector,omitempty"`
+	// Name of the probe, a lowercase DNS label other than the names of the probes of the Kubernetes components.
+	Name string `json:"name"`
+	// InitialDelaySeconds is how long to wait before the probe is first run. Defaults to 0.
+	InitialDelaySeconds int `json:"initialDelaySeconds,omitempty"`
+	// TimeoutSeconds is how long the probe can run before it fails. Defaults to 1.
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+	// SuccessThreshold is the number of consecutive successes after which the probe is healthy. Defaults to 1.
+	SuccessThreshold int `json:"successThreshold,omitempty"`
+	// FailureThreshold is the number of consecutive failures after which the probe is unhealthy. Defaults to 3.
+	FailureThreshold int `json:"failureThreshold,omitempty"`
+	// HTTPGet, TCPSocket and Exec are the check of the probe, exactly one of which must be set.
+	HTTPGet   *RKEProbeHTTPGetAction   `json:"httpGet,omitempty"`
+	TCPSocket *RKEProbeTCPSocketAction `json:"tcpSocket,omitempty"`
+	Exec      *RKEProbeExecAction      `json:"exec,omitempty"`
+}
+
+// RKEProbeHTTPGetAction succeeds if a GET request to the URL returns a 2xx status.
+type RKEProbeHTTPGetAction struct {
+	URL      string `json:"url"`
+	Insecure bool   `json:"insecure,omitempty"`
+	// CACert, ClientCert and ClientKey are the paths of the certificates on the machine.
+	CACert     string `json:"caCert,omitempty"`
+	ClientCert string `json:"clientCert,omitempty"`
+	ClientKey  string `json:"clientKey,omitempty"`
+}
+
+// RKEProbeTCPSocketAction succeeds if a TCP connection to the address can be opened.
+type RKEProbeTCPSocketAction struct {
+	// Address is the host and port to connect to, such as 127.0.0.1:9099.
+	Address string `json:"address"`
+}
+
+// RKEProbeExecAction succeeds if the command exits with 0.
+type RKEProbeExecAction struct {
+	Command []string `json:"command"`
+}
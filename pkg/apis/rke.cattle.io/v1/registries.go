@@ -57,3 +57,22 @@ type RegistryConfig struct {
 
 	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
 }
+
+// EmbeddedRegistry configures the embedded distributed registry mirror (Spegel) of K3s and RKE2, which lets the nodes of
+// a cluster pull the images already pulled by their peers instead of the upstream registry.
+type EmbeddedRegistry struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// Registries are the registries mirrored between the nodes, in addition to the mirrors of the cluster registries.
+	// All registries are mirrored when none is set.
+	Registries []string `json:"registries,omitempty"`
+	// DisableDefaultEndpoint prevents nodes from falling back to the upstream endpoint of the mirrored registries.
+	DisableDefaultEndpoint bool `json:"disableDefaultEndpoint,omitempty"`
+}
+
+// EmbeddedRegistryNode is the participation of a machine in the embedded registry mirror of its cluster.
+type EmbeddedRegistryNode struct {
+	MachineName string `json:"machineName"`
+	NodeName    string `json:"nodeName,omitempty"`
+	// Participating is true when the plan enabling the embedded registry is applied on the machine.
+	Participating bool `json:"participating"`
+}
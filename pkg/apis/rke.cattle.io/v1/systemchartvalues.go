@@ -0,0 +1,48 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type SystemChartValuesUpdateStrategy string
+
+const (
+	// SystemChartValuesUpdateAuto rolls out the default values of the system charts as soon as they change.
+	SystemChartValuesUpdateAuto SystemChartValuesUpdateStrategy = "Auto"
+	// SystemChartValuesUpdateManual rolls out the default values of the system charts once their update is approved.
+	SystemChartValuesUpdateManual SystemChartValuesUpdateStrategy = "Manual"
+	// SystemChartValuesUpdateScheduled rolls out the default values of the system charts in the next maintenance
+	// window.
+	SystemChartValuesUpdateScheduled SystemChartValuesUpdateStrategy = "Scheduled"
+)
+
+// SystemChartValuesUpdate controls how changes to the default values rancher renders for the system charts, such as
+// the CNI and the ingress controller, are rolled out to the cluster. The defaults change with rancher and KDM updates,
+// and are held back until the update is approved or a maintenance window starts so that upgrading rancher doesn't
+// roll out the system charts of every cluster at once. Changes to the chart values of the cluster are rolled out
+// immediately, along with the pending defaults.
+type SystemChartValuesUpdate struct {
+	// Strategy is Auto, Manual or Scheduled, Auto by default.
+	Strategy SystemChartValuesUpdateStrategy `json:"strategy,omitempty"`
+	// ApprovedHash approves the pending update of the Manual strategy whose hash it is.
+	ApprovedHash string `json:"approvedHash,omitempty"`
+	// MaintenanceWindow is the cron schedule of the start of the maintenance windows of the Scheduled strategy.
+	MaintenanceWindow string `json:"maintenanceWindow,omitempty"`
+	// MaintenanceWindowDuration is how long maintenance windows last, defaults to 1 hour.
+	MaintenanceWindowDuration *metav1.Duration `json:"maintenanceWindowDuration,omitempty"`
+}
+
+// SystemChartValuesStatus is the state of the default values of the system charts of the cluster.
+type SystemChartValuesStatus struct {
+	// AppliedHash is the hash of the default values rolled out to the cluster, and AppliedCharts the hash of the
+	// defaults of each system chart.
+	AppliedHash   string            `json:"appliedHash,omitempty"`
+	AppliedCharts map[string]string `json:"appliedCharts,omitempty"`
+	// ChartValuesHash is the hash of the chart values of the cluster when the defaults were last rolled out.
+	ChartValuesHash string `json:"chartValuesHash,omitempty"`
+	// PendingHash is the hash of the default values held back, which the ApprovedHash of the Manual strategy must
+	// match, and PendingCharts the system charts whose defaults change.
+	PendingHash   string       `json:"pendingHash,omitempty"`
+	PendingCharts []string     `json:"pendingCharts,omitempty"`
+	PendingSince  *metav1.Time `json:"pendingSince,omitempty"`
+}
@@ -0,0 +1,19 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// UpgradeRollback is the rollback of the Kubernetes upgrade of a cluster requested through the API, recorded in the
+// rke.cattle.io/upgrade-rollback annotation of the cluster.
+type UpgradeRollback struct {
+	// ID identifies the request.
+	ID string `json:"id,omitempty"`
+	// SnapshotName is the etcd snapshot taken before the upgrade, restored with the Kubernetes version it was taken at.
+	SnapshotName string `json:"snapshotName,omitempty"`
+	// FromVersion and ToVersion are the Kubernetes versions the cluster is rolled back from and to.
+	FromVersion string `json:"fromVersion,omitempty"`
+	ToVersion   string `json:"toVersion,omitempty"`
+	// RequestedAt is when the rollback was requested.
+	RequestedAt metav1.Time `json:"requestedAt,omitempty"`
+}
@@ -29,6 +29,46 @@
 	v1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionConfiguration) DeepCopyInto(out *AdmissionConfiguration) {
+	*out = *in
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]AdmissionPlugin, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionConfiguration.
+func (in *AdmissionConfiguration) DeepCopy() *AdmissionConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionPlugin) DeepCopyInto(out *AdmissionPlugin) {
+	*out = *in
+	in.Configuration.DeepCopyInto(&out.Configuration)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionPlugin.
+func (in *AdmissionPlugin) DeepCopy() *AdmissionPlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionPlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthConfig) DeepCopyInto(out *AuthConfig) {
 	*out = *in
@@ -45,6 +85,131 @@ func (in *AuthConfig) DeepCopy() *AuthConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRecovery) DeepCopyInto(out *ClusterRecovery) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRecovery.
+func (in *ClusterRecovery) DeepCopy() *ClusterRecovery {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRecovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterRecovery) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRecoveryList) DeepCopyInto(out *ClusterRecoveryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterRecovery, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRecoveryList.
+func (in *ClusterRecoveryList) DeepCopy() *ClusterRecoveryList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRecoveryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterRecoveryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRecoverySpec) DeepCopyInto(out *ClusterRecoverySpec) {
+	*out = *in
+	if in.RejoinTimeout != nil {
+		in, out := &in.RejoinTimeout, &out.RejoinTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRecoverySpec.
+func (in *ClusterRecoverySpec) DeepCopy() *ClusterRecoverySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRecoverySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRecoveryStatus) DeepCopyInto(out *ClusterRecoveryStatus) {
+	*out = *in
+	if in.ETCDSnapshotRestore != nil {
+		in, out := &in.ETCDSnapshotRestore, &out.ETCDSnapshotRestore
+		*out = new(ETCDSnapshotRestore)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ResetTime != nil {
+		in, out := &in.ResetTime, &out.ResetTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.UnhealthyMachines != nil {
+		in, out := &in.UnhealthyMachines, &out.UnhealthyMachines
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReplacedMachines != nil {
+		in, out := &in.ReplacedMachines, &out.ReplacedMachines
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRecoveryStatus.
+func (in *ClusterRecoveryStatus) DeepCopy() *ClusterRecoveryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRecoveryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterUpgradeStrategy) DeepCopyInto(out *ClusterUpgradeStrategy) {
 	*out = *in
@@ -340,6 +505,11 @@ func (in *ETCDSnapshotList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ETCDSnapshotRestore) DeepCopyInto(out *ETCDSnapshotRestore) {
 	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -402,108 +572,523 @@ func (in *ETCDSnapshotStatus) DeepCopy() *ETCDSnapshotStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EnvVar) DeepCopyInto(out *EnvVar) {
+func (in *EmbeddedRegistry) DeepCopyInto(out *EmbeddedRegistry) {
+	*out = *in
+	if in.Registries != nil {
+		in, out := &in.Registries, &out.Registries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedRegistry.
+func (in *EmbeddedRegistry) DeepCopy() *EmbeddedRegistry {
+	if in == nil {
+		return nil
+	}
+	out := new(EmbeddedRegistry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmbeddedRegistryNode) DeepCopyInto(out *EmbeddedRegistryNode) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedRegistryNode.
+func (in *EmbeddedRegistryNode) DeepCopy() *EmbeddedRegistryNode {
+	if in == nil {
+		return nil
+	}
+	out := new(EmbeddedRegistryNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvVar) DeepCopyInto(out *EnvVar) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvVar.
+func (in *EnvVar) DeepCopy() *EnvVar {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvVar)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericMap.
+func (in *GenericMap) DeepCopy() *GenericMap {
+	if in == nil {
+		return nil
+	}
+	out := new(GenericMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GarbageCollection) DeepCopyInto(out *GarbageCollection) {
+	*out = *in
+	if in.ImageGCHighThresholdPercent != nil {
+		in, out := &in.ImageGCHighThresholdPercent, &out.ImageGCHighThresholdPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ImageGCLowThresholdPercent != nil {
+		in, out := &in.ImageGCLowThresholdPercent, &out.ImageGCLowThresholdPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ContainerLogMaxFiles != nil {
+		in, out := &in.ContainerLogMaxFiles, &out.ContainerLogMaxFiles
+		*out = new(int32)
+		**out = **in
+	}
+	if in.EvictionHard != nil {
+		in, out := &in.EvictionHard, &out.EvictionHard
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EvictionSoft != nil {
+		in, out := &in.EvictionSoft, &out.EvictionSoft
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EvictionSoftGracePeriod != nil {
+		in, out := &in.EvictionSoftGracePeriod, &out.EvictionSoftGracePeriod
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EvictionMinimumReclaim != nil {
+		in, out := &in.EvictionMinimumReclaim, &out.EvictionMinimumReclaim
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GarbageCollection.
+func (in *GarbageCollection) DeepCopy() *GarbageCollection {
+	if in == nil {
+		return nil
+	}
+	out := new(GarbageCollection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InitNodeElection) DeepCopyInto(out *InitNodeElection) {
+	*out = *in
+	in.ElectedAt.DeepCopyInto(&out.ElectedAt)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InitNodeElection.
+func (in *InitNodeElection) DeepCopy() *InitNodeElection {
+	if in == nil {
+		return nil
+	}
+	out := new(InitNodeElection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InitNodeSelection) DeepCopyInto(out *InitNodeSelection) {
+	*out = *in
+	if in.PreferredZones != nil {
+		in, out := &in.PreferredZones, &out.PreferredZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReelectionBackoff != nil {
+		in, out := &in.ReelectionBackoff, &out.ReelectionBackoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InitNodeSelection.
+func (in *InitNodeSelection) DeepCopy() *InitNodeSelection {
+	if in == nil {
+		return nil
+	}
+	out := new(InitNodeSelection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *K8sObjectFileSource) DeepCopyInto(out *K8sObjectFileSource) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeyToPath, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K8sObjectFileSource.
+func (in *K8sObjectFileSource) DeepCopy() *K8sObjectFileSource {
+	if in == nil {
+		return nil
+	}
+	out := new(K8sObjectFileSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyToPath) DeepCopyInto(out *KeyToPath) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyToPath.
+func (in *KeyToPath) DeepCopy() *KeyToPath {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyToPath)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalClusterAuthEndpoint) DeepCopyInto(out *LocalClusterAuthEndpoint) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalClusterAuthEndpoint.
+func (in *LocalClusterAuthEndpoint) DeepCopy() *LocalClusterAuthEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalClusterAuthEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineAdoption) DeepCopyInto(out *MachineAdoption) {
+	*out = *in
+	in.RequestedAt.DeepCopyInto(&out.RequestedAt)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineAdoption.
+func (in *MachineAdoption) DeepCopy() *MachineAdoption {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineAdoption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDeletionHookPolicy) DeepCopyInto(out *MachineDeletionHookPolicy) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeletionHookPolicy.
+func (in *MachineDeletionHookPolicy) DeepCopy() *MachineDeletionHookPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDeletionHookPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDeletionHookStatus) DeepCopyInto(out *MachineDeletionHookStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeletionHookStatus.
+func (in *MachineDeletionHookStatus) DeepCopy() *MachineDeletionHookStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDeletionHookStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDeletionHooks) DeepCopyInto(out *MachineDeletionHooks) {
+	*out = *in
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]MachineDeletionHookPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Webhooks != nil {
+		in, out := &in.Webhooks, &out.Webhooks
+		*out = make([]MachineDeletionWebhook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeletionHooks.
+func (in *MachineDeletionHooks) DeepCopy() *MachineDeletionHooks {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDeletionHooks)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDeletionWebhook) DeepCopyInto(out *MachineDeletionWebhook) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeletionWebhook.
+func (in *MachineDeletionWebhook) DeepCopy() *MachineDeletionWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDeletionWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDeletionWebhookRequest) DeepCopyInto(out *MachineDeletionWebhookRequest) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeletionWebhookRequest.
+func (in *MachineDeletionWebhookRequest) DeepCopy() *MachineDeletionWebhookRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDeletionWebhookRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineReplacement) DeepCopyInto(out *MachineReplacement) {
+	*out = *in
+	in.RequestedAt.DeepCopyInto(&out.RequestedAt)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineReplacement.
+func (in *MachineReplacement) DeepCopy() *MachineReplacement {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineReplacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Mirror) DeepCopyInto(out *Mirror) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rewrites != nil {
+		in, out := &in.Rewrites, &out.Rewrites
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Mirror.
+func (in *Mirror) DeepCopy() *Mirror {
+	if in == nil {
+		return nil
+	}
+	out := new(Mirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeDNS) DeepCopyInto(out *NodeDNS) {
 	*out = *in
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SearchDomains != nil {
+		in, out := &in.SearchDomains, &out.SearchDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvVar.
-func (in *EnvVar) DeepCopy() *EnvVar {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDNS.
+func (in *NodeDNS) DeepCopy() *NodeDNS {
 	if in == nil {
 		return nil
 	}
-	out := new(EnvVar)
+	out := new(NodeDNS)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericMap.
-func (in *GenericMap) DeepCopy() *GenericMap {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeLocalDNS) DeepCopyInto(out *NodeLocalDNS) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeLocalDNS.
+func (in *NodeLocalDNS) DeepCopy() *NodeLocalDNS {
 	if in == nil {
 		return nil
 	}
-	out := new(GenericMap)
+	out := new(NodeLocalDNS)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *K8sObjectFileSource) DeepCopyInto(out *K8sObjectFileSource) {
+func (in *NodeMaintenance) DeepCopyInto(out *NodeMaintenance) {
 	*out = *in
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]KeyToPath, len(*in))
-		copy(*out, *in)
+	if in.DrainOptions != nil {
+		in, out := &in.DrainOptions, &out.DrainOptions
+		*out = new(DrainOptions)
+		(*in).DeepCopyInto(*out)
 	}
+	in.RequestedAt.DeepCopyInto(&out.RequestedAt)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new K8sObjectFileSource.
-func (in *K8sObjectFileSource) DeepCopy() *K8sObjectFileSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMaintenance.
+func (in *NodeMaintenance) DeepCopy() *NodeMaintenance {
 	if in == nil {
 		return nil
 	}
-	out := new(K8sObjectFileSource)
+	out := new(NodeMaintenance)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KeyToPath) DeepCopyInto(out *KeyToPath) {
+func (in *NodeMaintenanceStatus) DeepCopyInto(out *NodeMaintenanceStatus) {
 	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyToPath.
-func (in *KeyToPath) DeepCopy() *KeyToPath {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeMaintenanceStatus.
+func (in *NodeMaintenanceStatus) DeepCopy() *NodeMaintenanceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KeyToPath)
+	out := new(NodeMaintenanceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocalClusterAuthEndpoint) DeepCopyInto(out *LocalClusterAuthEndpoint) {
+func (in *NodeTime) DeepCopyInto(out *NodeTime) {
 	*out = *in
+	if in.NTPServers != nil {
+		in, out := &in.NTPServers, &out.NTPServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalClusterAuthEndpoint.
-func (in *LocalClusterAuthEndpoint) DeepCopy() *LocalClusterAuthEndpoint {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTime.
+func (in *NodeTime) DeepCopy() *NodeTime {
 	if in == nil {
 		return nil
 	}
-	out := new(LocalClusterAuthEndpoint)
+	out := new(NodeTime)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Mirror) DeepCopyInto(out *Mirror) {
+func (in *PlannerBehaviorStatus) DeepCopyInto(out *PlannerBehaviorStatus) {
 	*out = *in
-	if in.Endpoints != nil {
-		in, out := &in.Endpoints, &out.Endpoints
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Rewrites != nil {
-		in, out := &in.Rewrites, &out.Rewrites
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Mirror.
-func (in *Mirror) DeepCopy() *Mirror {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannerBehaviorStatus.
+func (in *PlannerBehaviorStatus) DeepCopy() *PlannerBehaviorStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(Mirror)
+	out := new(PlannerBehaviorStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -587,6 +1172,38 @@ func (in *RKEBootstrapList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RKEBootstrapPhases) DeepCopyInto(out *RKEBootstrapPhases) {
+	*out = *in
+	if in.InfrastructureReady != nil {
+		in, out := &in.InfrastructureReady, &out.InfrastructureReady
+		*out = (*in).DeepCopy()
+	}
+	if in.BootstrapDelivered != nil {
+		in, out := &in.BootstrapDelivered, &out.BootstrapDelivered
+		*out = (*in).DeepCopy()
+	}
+	if in.FirstPlanApplied != nil {
+		in, out := &in.FirstPlanApplied, &out.FirstPlanApplied
+		*out = (*in).DeepCopy()
+	}
+	if in.NodeReady != nil {
+		in, out := &in.NodeReady, &out.NodeReady
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RKEBootstrapPhases.
+func (in *RKEBootstrapPhases) DeepCopy() *RKEBootstrapPhases {
+	if in == nil {
+		return nil
+	}
+	out := new(RKEBootstrapPhases)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RKEBootstrapSpec) DeepCopyInto(out *RKEBootstrapSpec) {
 	*out = *in
@@ -611,6 +1228,7 @@ func (in *RKEBootstrapStatus) DeepCopyInto(out *RKEBootstrapStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	in.Phases.DeepCopyInto(&out.Phases)
 	return
 }
 
@@ -808,11 +1426,77 @@ func (in *RKEClusterSpecCommon) DeepCopyInto(out *RKEClusterSpecCommon) {
 		*out = new(Registry)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.EmbeddedRegistry != nil {
+		in, out := &in.EmbeddedRegistry, &out.EmbeddedRegistry
+		*out = new(EmbeddedRegistry)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeLocalDNS != nil {
+		in, out := &in.NodeLocalDNS, &out.NodeLocalDNS
+		*out = new(NodeLocalDNS)
+		**out = **in
+	}
+	if in.NodeDNS != nil {
+		in, out := &in.NodeDNS, &out.NodeDNS
+		*out = new(NodeDNS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeTime != nil {
+		in, out := &in.NodeTime, &out.NodeTime
+		*out = new(NodeTime)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ETCD != nil {
 		in, out := &in.ETCD, &out.ETCD
 		*out = new(ETCD)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.InitNodeSelection != nil {
+		in, out := &in.InitNodeSelection, &out.InitNodeSelection
+		*out = new(InitNodeSelection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachineDeletionHooks != nil {
+		in, out := &in.MachineDeletionHooks, &out.MachineDeletionHooks
+		*out = new(MachineDeletionHooks)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdmissionConfiguration != nil {
+		in, out := &in.AdmissionConfiguration, &out.AdmissionConfiguration
+		*out = new(AdmissionConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachineSelectorConfigDropIns != nil {
+		in, out := &in.MachineSelectorConfigDropIns, &out.MachineSelectorConfigDropIns
+		*out = make([]RKEConfigDropIn, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MachineSelectorProbes != nil {
+		in, out := &in.MachineSelectorProbes, &out.MachineSelectorProbes
+		*out = make([]RKEProbe, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SystemChartValuesUpdate != nil {
+		in, out := &in.SystemChartValuesUpdate, &out.SystemChartValuesUpdate
+		*out = new(SystemChartValuesUpdate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LockPools != nil {
+		in, out := &in.LockPools, &out.LockPools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PlannerBehaviors != nil {
+		in, out := &in.PlannerBehaviors, &out.PlannerBehaviors
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -877,6 +1561,28 @@ func (in *RKECommonNodeConfig) DeepCopy() *RKECommonNodeConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RKEConfigDropIn) DeepCopyInto(out *RKEConfigDropIn) {
+	*out = *in
+	if in.MachineLabelSelector != nil {
+		in, out := &in.MachineLabelSelector, &out.MachineLabelSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Config.DeepCopyInto(&out.Config)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RKEConfigDropIn.
+func (in *RKEConfigDropIn) DeepCopy() *RKEConfigDropIn {
+	if in == nil {
+		return nil
+	}
+	out := new(RKEConfigDropIn)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RKEControlPlane) DeepCopyInto(out *RKEControlPlane) {
 	*out = *in
@@ -956,7 +1662,7 @@ func (in *RKEControlPlaneSpec) DeepCopyInto(out *RKEControlPlaneSpec) {
 	if in.ETCDSnapshotRestore != nil {
 		in, out := &in.ETCDSnapshotRestore, &out.ETCDSnapshotRestore
 		*out = new(ETCDSnapshotRestore)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.RotateCertificates != nil {
 		in, out := &in.RotateCertificates, &out.RotateCertificates
@@ -1002,13 +1708,44 @@ func (in *RKEControlPlaneStatus) DeepCopyInto(out *RKEControlPlaneStatus) {
 	if in.ETCDSnapshotRestore != nil {
 		in, out := &in.ETCDSnapshotRestore, &out.ETCDSnapshotRestore
 		*out = new(ETCDSnapshotRestore)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.ETCDSnapshotCreate != nil {
 		in, out := &in.ETCDSnapshotCreate, &out.ETCDSnapshotCreate
 		*out = new(ETCDSnapshotCreate)
 		**out = **in
 	}
+	if in.EmbeddedRegistryNodes != nil {
+		in, out := &in.EmbeddedRegistryNodes, &out.EmbeddedRegistryNodes
+		*out = make([]EmbeddedRegistryNode, len(*in))
+		copy(*out, *in)
+	}
+	if in.InitNodeUnavailableSince != nil {
+		in, out := &in.InitNodeUnavailableSince, &out.InitNodeUnavailableSince
+		*out = (*in).DeepCopy()
+	}
+	if in.InitNodeHistory != nil {
+		in, out := &in.InitNodeHistory, &out.InitNodeHistory
+		*out = make([]InitNodeElection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SystemChartValues != nil {
+		in, out := &in.SystemChartValues, &out.SystemChartValues
+		*out = new(SystemChartValuesStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LockPools != nil {
+		in, out := &in.LockPools, &out.LockPools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PlannerBehaviors != nil {
+		in, out := &in.PlannerBehaviors, &out.PlannerBehaviors
+		*out = make([]PlannerBehaviorStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1048,6 +1785,95 @@ func (in *RKEMachineStatus) DeepCopy() *RKEMachineStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RKEProbe) DeepCopyInto(out *RKEProbe) {
+	*out = *in
+	if in.MachineLabelSelector != nil {
+		in, out := &in.MachineLabelSelector, &out.MachineLabelSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTPGet != nil {
+		in, out := &in.HTTPGet, &out.HTTPGet
+		*out = new(RKEProbeHTTPGetAction)
+		**out = **in
+	}
+	if in.TCPSocket != nil {
+		in, out := &in.TCPSocket, &out.TCPSocket
+		*out = new(RKEProbeTCPSocketAction)
+		**out = **in
+	}
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(RKEProbeExecAction)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RKEProbe.
+func (in *RKEProbe) DeepCopy() *RKEProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(RKEProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RKEProbeExecAction) DeepCopyInto(out *RKEProbeExecAction) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RKEProbeExecAction.
+func (in *RKEProbeExecAction) DeepCopy() *RKEProbeExecAction {
+	if in == nil {
+		return nil
+	}
+	out := new(RKEProbeExecAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RKEProbeHTTPGetAction) DeepCopyInto(out *RKEProbeHTTPGetAction) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RKEProbeHTTPGetAction.
+func (in *RKEProbeHTTPGetAction) DeepCopy() *RKEProbeHTTPGetAction {
+	if in == nil {
+		return nil
+	}
+	out := new(RKEProbeHTTPGetAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RKEProbeTCPSocketAction) DeepCopyInto(out *RKEProbeTCPSocketAction) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RKEProbeTCPSocketAction.
+func (in *RKEProbeTCPSocketAction) DeepCopy() *RKEProbeTCPSocketAction {
+	if in == nil {
+		return nil
+	}
+	out := new(RKEProbeTCPSocketAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RKEProvisioningFiles) DeepCopyInto(out *RKEProvisioningFiles) {
 	*out = *in
@@ -1185,3 +2011,73 @@ func (in *RotateEncryptionKeys) DeepCopy() *RotateEncryptionKeys {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SystemChartValuesStatus) DeepCopyInto(out *SystemChartValuesStatus) {
+	*out = *in
+	if in.AppliedCharts != nil {
+		in, out := &in.AppliedCharts, &out.AppliedCharts
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PendingCharts != nil {
+		in, out := &in.PendingCharts, &out.PendingCharts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingSince != nil {
+		in, out := &in.PendingSince, &out.PendingSince
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemChartValuesStatus.
+func (in *SystemChartValuesStatus) DeepCopy() *SystemChartValuesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SystemChartValuesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SystemChartValuesUpdate) DeepCopyInto(out *SystemChartValuesUpdate) {
+	*out = *in
+	if in.MaintenanceWindowDuration != nil {
+		in, out := &in.MaintenanceWindowDuration, &out.MaintenanceWindowDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemChartValuesUpdate.
+func (in *SystemChartValuesUpdate) DeepCopy() *SystemChartValuesUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(SystemChartValuesUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeRollback) DeepCopyInto(out *UpgradeRollback) {
+	*out = *in
+	in.RequestedAt.DeepCopyInto(&out.RequestedAt)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeRollback.
+func (in *UpgradeRollback) DeepCopy() *UpgradeRollback {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeRollback)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -26,6 +26,23 @@
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// ClusterRecoveryList is a list of ClusterRecovery resources
+type ClusterRecoveryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ClusterRecovery `json:"items"`
+}
+
+func NewClusterRecovery(namespace, name string, obj ClusterRecovery) *ClusterRecovery {
+	obj.APIVersion, obj.Kind = SchemeGroupVersion.WithKind("ClusterRecovery").ToAPIVersionAndKind()
+	obj.Name = name
+	obj.Namespace = namespace
+	return &obj
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // CustomMachineList is a list of CustomMachine resources
 type CustomMachineList struct {
 	metav1.TypeMeta `json:",inline"`
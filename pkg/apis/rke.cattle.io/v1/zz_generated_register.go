@@ -28,6 +28,7 @@
 )
 
 var (
+	ClusterRecoveryResourceName      = "clusterrecoveries"
 	CustomMachineResourceName        = "custommachines"
 	ETCDSnapshotResourceName         = "etcdsnapshots"
 	RKEBootstrapResourceName         = "rkebootstraps"
@@ -57,6 +58,8 @@ func Resource(resource string) schema.GroupResource {
 // Adds the list of known types to Scheme.
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
+		&ClusterRecovery{},
+		&ClusterRecoveryList{},
 		&CustomMachine{},
 		&CustomMachineList{},
 		&ETCDSnapshot{},
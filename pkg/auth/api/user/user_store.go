@@ -11,14 +11,16 @@
 	"github.com/rancher/norman/types"
 	client "github.com/rancher/rancher/pkg/client/generated/management/v3"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/settings"
 	"github.com/rancher/rancher/pkg/types/config"
 	"github.com/rancher/rancher/pkg/user"
-	"github.com/sirupsen/logrus"
 	"golang.org/x/crypto/bcrypt"
 	"k8s.io/client-go/tools/cache"
 )
 
+var logger = logging.Logger(logging.Auth)
+
 const userByUsernameIndex = "auth.management.cattle.io/user-by-username"
 
 type userStore struct {
@@ -138,7 +140,7 @@ func (s *userStore) Create(apiContext *types.APIContext, schema *types.Schema, d
 
 			created, err = s.ByID(apiContext, schema, id)
 			if err != nil {
-				logrus.Warnf("error while getting user: %v", err)
+				logger.Warnf("error while getting user: %v", err)
 				continue
 			}
 
@@ -162,7 +164,7 @@ func (s *userStore) Create(apiContext *types.APIContext, schema *types.Schema, d
 					continue
 				}
 
-				logrus.Warnf("error while updating user: %v", err)
+				logger.Warnf("error while updating user: %v", err)
 				break
 			}
 			break
@@ -17,7 +17,7 @@
 
 	"github.com/pborman/uuid"
 	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apiserver/pkg/endpoints/request"
 )
@@ -103,7 +103,7 @@ func getUserNameForBasicLogin(body []byte) string {
 	input := &v32.BasicLogin{}
 	err := json.Unmarshal(body, input)
 	if err != nil {
-		logrus.Debugf("error unmarshalling input, cannot add login info to audit log: %v", err)
+		logger.Debugf("error unmarshalling input, cannot add login info to audit log: %v", err)
 		return ""
 	}
 	return input.Username
@@ -162,7 +162,7 @@ func (a *auditLog) write(userInfo *User, reqHeaders, resHeaders http.Header, res
 			a.log.User.Extra = make(map[string][]string)
 		}
 		a.log.User.Extra["username"] = []string{a.log.UserLoginName}
-		logrus.Debugf("Added username for login request to audit log %v", a.log.UserLoginName)
+		logger.Debugf("Added username for login request to audit log %v", a.log.UserLoginName)
 	}
 
 	var buffer bytes.Buffer
@@ -15,9 +15,11 @@
 
 	"github.com/rancher/rancher/pkg/auth/util"
 	"github.com/rancher/rancher/pkg/data/management"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 )
 
+var logger = logging.Logger(logging.Auth)
+
 var errorDebounceTime = time.Second * 30
 
 func NewAuditLogMiddleware(auditWriter *LogWriter) (func(http.Handler) http.Handler, error) {
@@ -93,7 +95,7 @@ func (h auditHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	// This is to prevent the rancher logs from being flooded with error messages
 	// when the log path is invalid or any other error that will always cause a write to fail.
 	if lastSeen, ok := h.errMap[err.Error()]; !ok || time.Since(lastSeen) > errorDebounceTime {
-		logrus.Warnf("Failed to write audit log: %s", err)
+		logger.Warnf("Failed to write audit log: %s", err)
 		h.errMap[err.Error()] = time.Now()
 	}
 }
@@ -126,7 +128,7 @@ func (aw *wrapWriter) CloseNotify() <-chan bool {
 	if cn, ok := aw.ResponseWriter.(http.CloseNotifier); ok {
 		return cn.CloseNotify()
 	}
-	logrus.Errorf("Upstream ResponseWriter of type %v does not implement http.CloseNotifier", reflect.TypeOf(aw.ResponseWriter))
+	logger.Errorf("Upstream ResponseWriter of type %v does not implement http.CloseNotifier", reflect.TypeOf(aw.ResponseWriter))
 	return make(<-chan bool)
 }
 
@@ -135,5 +137,5 @@ func (aw *wrapWriter) Flush() {
 		f.Flush()
 		return
 	}
-	logrus.Errorf("Upstream ResponseWriter of type %v does not implement http.Flusher", reflect.TypeOf(aw.ResponseWriter))
+	logger.Errorf("Upstream ResponseWriter of type %v does not implement http.Flusher", reflect.TypeOf(aw.ResponseWriter))
 }
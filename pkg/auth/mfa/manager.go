@@ -0,0 +1,506 @@
+// Package mfa implements the second factors of local users: TOTP codes with recovery codes, and WebAuthn security
+// keys.
+package mfa
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rancher/norman/httperror"
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	v1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/logging"
+	"github.com/rancher/rancher/pkg/namespace"
+	"github.com/rancher/rancher/pkg/settings"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+var logger = logging.Logger(logging.Auth)
+
+const (
+	// SecretNamespace is the namespace of the secrets storing the second factors of users.
+	SecretNamespace = namespace.System
+	// VerifiedExtraInfoKey is set in the extra info of the user principal of tokens issued after a second factor was
+	// verified, derived tokens inherit it with the principal.
+	VerifiedExtraInfoKey = "mfaVerified"
+
+	secretNameSuffix = "-mfa"
+	stateKey         = "state"
+	localProvider    = "local"
+	issuer           = "Rancher"
+
+	grbByUserIndex = "auth.management.cattle.io/mfa-grb-by-user"
+)
+
+var (
+	// ErrorCodeRequired is returned by logins of users with a second factor that didn't provide it.
+	ErrorCodeRequired = httperror.ErrorCode{Code: "MFARequired", Status: http.StatusUnauthorized}
+
+	// ErrAuthenticationFailed is returned by logins with a wrong second factor.
+	ErrAuthenticationFailed = httperror.NewAPIError(httperror.Unauthorized, "authentication failed")
+)
+
+// Manager stores the second factors of local users and applies the multi-factor authentication policy. A single
+// manager is built with each scaled context, see config.ScaledContext.MFA.
+type Manager struct {
+	secrets      v1.SecretInterface
+	secretLister v1.SecretLister
+	grbIndexer   cache.Indexer
+	now          func() time.Time
+}
+
+func NewManager(core v1.Interface, management v3.Interface) (*Manager, error) {
+	grbInformer := management.GlobalRoleBindings("").Controller().Informer()
+	if err := grbInformer.AddIndexers(map[string]cache.IndexFunc{grbByUserIndex: grbByUser}); err != nil {
+		return nil, err
+	}
+	return &Manager{
+		secrets:      core.Secrets(SecretNamespace),
+		secretLister: core.Secrets("").Controller().Lister(),
+		grbIndexer:   grbInformer.GetIndexer(),
+		now:          time.Now,
+	}, nil
+}
+
+func grbByUser(obj interface{}) ([]string, error) {
+	binding, ok := obj.(*v32.GlobalRoleBinding)
+	if !ok || binding.UserName == "" {
+		return nil, nil
+	}
+	return []string{binding.UserName}, nil
+}
+
+// IsVerified returns true if the principal was issued after a second factor was verified.
+func IsVerified(principal v32.Principal) bool {
+	return principal.ExtraInfo[VerifiedExtraInfoKey] == "true"
+}
+
+// MarkVerified marks the principal as issued after a second factor was verified.
+func MarkVerified(principal *v32.Principal) {
+	if principal.ExtraInfo == nil {
+		principal.ExtraInfo = map[string]string{}
+	}
+	principal.ExtraInfo[VerifiedExtraInfoKey] = "true"
+}
+
+// GetState returns the second factors of the user.
+func (m *Manager) GetState(userID string) (*State, error) {
+	secret, err := m.secretLister.Get(SecretNamespace, userID+secretNameSuffix)
+	if apierrors.IsNotFound(err) {
+		return &State{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	state := &State{}
+	if err := json.Unmarshal(secret.Data[stateKey], state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// SaveState stores the second factors of the user.
+func (m *Manager) SaveState(userID string, state *State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	secret, err := m.secretLister.Get(SecretNamespace, userID+secretNameSuffix)
+	if apierrors.IsNotFound(err) {
+		_, err = m.secrets.Create(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      userID + secretNameSuffix,
+				Namespace: SecretNamespace,
+			},
+			Data: map[string][]byte{stateKey: data},
+		})
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	secret = secret.DeepCopy()
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[stateKey] = data
+	_, err = m.secrets.Update(secret)
+	return err
+}
+
+// Required returns true if the policy requires the user to authenticate with a second factor, either for every local
+// user or for the users of one of the global roles of the mfa-required-global-roles setting.
+func (m *Manager) Required(userID string) (bool, error) {
+	if settings.MFARequired.Get() == "true" {
+		return true, nil
+	}
+	requiredRoles := settings.MFARequiredGlobalRoles.Get()
+	if strings.TrimSpace(requiredRoles) == "" {
+		return false, nil
+	}
+
+	bindings, err := m.grbIndexer.ByIndex(grbByUserIndex, userID)
+	if err != nil {
+		return false, err
+	}
+	var globalRoles []string
+	for _, obj := range bindings {
+		if binding, ok := obj.(*v32.GlobalRoleBinding); ok {
+			globalRoles = append(globalRoles, binding.GlobalRoleName)
+		}
+	}
+	return requiredForRoles(requiredRoles, globalRoles), nil
+}
+
+func requiredForRoles(requiredRoles string, globalRoles []string) bool {
+	for _, required := range strings.Split(requiredRoles, ",") {
+		for _, role := range globalRoles {
+			if strings.TrimSpace(required) == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Pending returns true if the token is a local user token that wasn't issued after a second factor was verified while
+// the policy requires one. Such tokens can only be used to enroll a second factor.
+func (m *Manager) Pending(token *v3.Token) (bool, error) {
+	if token.AuthProvider != localProvider || IsVerified(token.UserPrincipal) {
+		return false, nil
+	}
+	return m.Required(token.UserID)
+}
+
+// AllowedWhilePending returns true for the requests that can be authenticated by a pending token: enrolling a second
+// factor and logging out.
+func AllowedWhilePending(req *http.Request) bool {
+	if strings.HasPrefix(req.URL.Path, "/v1/mfa") {
+		return true
+	}
+	return req.URL.Path == "/v3/tokens" && req.URL.Query().Get("action") == "logout"
+}
+
+// CheckTokenIssuance returns an error if tokens can't be derived from the token, because it is a local user token that
+// wasn't issued after a second factor was verified while the user has or requires one.
+func (m *Manager) CheckTokenIssuance(token *v3.Token) error {
+	if token.AuthProvider != localProvider || IsVerified(token.UserPrincipal) {
+		return nil
+	}
+	required, err := m.Required(token.UserID)
+	if err != nil {
+		return err
+	}
+	if !required {
+		state, err := m.GetState(token.UserID)
+		if err != nil {
+			return err
+		}
+		required = state.Enrolled()
+	}
+	if required {
+		return httperror.NewAPIError(httperror.PermissionDenied, "tokens can only be created from a session authenticated with a second factor")
+	}
+	return nil
+}
+
+// Authenticate verifies the second factor of a login of the user once their password is verified. It returns false
+// without error if the user has no second factor.
+func (m *Manager) Authenticate(userID string, login *v32.BasicLogin) (bool, error) {
+	state, err := m.GetState(userID)
+	if err != nil {
+		return false, err
+	}
+	if !state.Enrolled() {
+		return false, nil
+	}
+
+	now := m.now()
+	switch {
+	case login.MFACode != "":
+		if !state.verifyCode(login.MFACode, now) {
+			logger.Debugf("Invalid second factor code for User [%s]", userID)
+			return false, ErrAuthenticationFailed
+		}
+	case login.WebAuthn != nil:
+		rp, err := relyingParty()
+		if err != nil {
+			return false, err
+		}
+		if err := state.verifyAssertion(rp, login.WebAuthn, now); err != nil {
+			logger.Debugf("Invalid security key response for User [%s]: %v", userID, err)
+			// the challenge is consumed even if the response is invalid
+			if err := m.SaveState(userID, state); err != nil {
+				return false, err
+			}
+			return false, ErrAuthenticationFailed
+		}
+	default:
+		return false, httperror.NewAPIError(ErrorCodeRequired, "multi-factor authentication required")
+	}
+
+	if err := m.SaveState(userID, state); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// AssertionOptions are the options of a security key authentication, passed to navigator.credentials.get.
+type AssertionOptions struct {
+	Challenge        string   `json:"challenge"`
+	RPID             string   `json:"rpId"`
+	AllowCredentials []string `json:"allowCredentials"`
+	Timeout          int64    `json:"timeout"`
+}
+
+// NewAssertion issues a challenge for the security keys of the user.
+func (m *Manager) NewAssertion(userID string) (*AssertionOptions, error) {
+	state, err := m.GetState(userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(state.WebAuthnCredentials) == 0 {
+		return nil, httperror.NewAPIError(httperror.InvalidState, "no security key is registered")
+	}
+	rp, err := relyingParty()
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := state.newChallenge(m.now())
+	if err != nil {
+		return nil, err
+	}
+	if err := m.SaveState(userID, state); err != nil {
+		return nil, err
+	}
+
+	options := &AssertionOptions{
+		Challenge: challenge,
+		RPID:      rp.ID,
+		Timeout:   ChallengeTTL.Milliseconds(),
+	}
+	for _, credential := range state.WebAuthnCredentials {
+		options.AllowCredentials = append(options.AllowCredentials, credential.ID)
+	}
+	return options, nil
+}
+
+// TOTPEnrollment is a TOTP secret to confirm with a code.
+type TOTPEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// BeginTOTP generates a TOTP secret for the user, which replaces their TOTP secret once confirmed.
+func (m *Manager) BeginTOTP(userID, username string) (*TOTPEnrollment, error) {
+	state, err := m.GetState(userID)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := NewTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	state.PendingTOTPSecret = secret
+	if err := m.SaveState(userID, state); err != nil {
+		return nil, err
+	}
+	return &TOTPEnrollment{Secret: secret, URI: TOTPURI(issuer, username, secret)}, nil
+}
+
+// ConfirmTOTP confirms the pending TOTP secret of the user with a code. It returns recovery codes if the user had
+// none.
+func (m *Manager) ConfirmTOTP(userID, code string) ([]string, error) {
+	state, err := m.GetState(userID)
+	if err != nil {
+		return nil, err
+	}
+	if state.PendingTOTPSecret == "" {
+		return nil, httperror.NewAPIError(httperror.InvalidState, "no pending TOTP enrollment")
+	}
+	step, ok := ValidateTOTP(state.PendingTOTPSecret, code, m.now())
+	if !ok {
+		return nil, httperror.NewAPIError(httperror.InvalidBodyContent, "invalid code")
+	}
+
+	state.TOTPSecret = state.PendingTOTPSecret
+	state.PendingTOTPSecret = ""
+	state.TOTPLastStep = step
+	return m.saveWithRecoveryCodes(userID, state)
+}
+
+// RemoveTOTP removes the TOTP secret of the user.
+func (m *Manager) RemoveTOTP(userID string) error {
+	state, err := m.GetState(userID)
+	if err != nil {
+		return err
+	}
+	state.TOTPSecret = ""
+	state.PendingTOTPSecret = ""
+	state.removeFactors()
+	return m.SaveState(userID, state)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the user.
+func (m *Manager) RegenerateRecoveryCodes(userID string) ([]string, error) {
+	state, err := m.GetState(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !state.Enrolled() {
+		return nil, httperror.NewAPIError(httperror.InvalidState, "no second factor is enrolled")
+	}
+	state.RecoveryCodes = nil
+	return m.saveWithRecoveryCodes(userID, state)
+}
+
+func (m *Manager) saveWithRecoveryCodes(userID string, state *State) ([]string, error) {
+	var codes []string
+	if len(state.RecoveryCodes) == 0 {
+		var hashes []string
+		var err error
+		codes, hashes, err = NewRecoveryCodes()
+		if err != nil {
+			return nil, err
+		}
+		state.RecoveryCodes = hashes
+	}
+	return codes, m.SaveState(userID, state)
+}
+
+// CreationOptions are the options of a security key registration, passed to navigator.credentials.create.
+type CreationOptions struct {
+	Challenge          string                `json:"challenge"`
+	RP                 CreationEntity        `json:"rp"`
+	User               CreationEntity        `json:"user"`
+	PubKeyCredParams   []CredentialParameter `json:"pubKeyCredParams"`
+	ExcludeCredentials []string              `json:"excludeCredentials,omitempty"`
+	Attestation        string                `json:"attestation"`
+	Timeout            int64                 `json:"timeout"`
+}
+
+type CreationEntity struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+type CredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int64  `json:"alg"`
+}
+
+// BeginWebAuthn issues a challenge to register a security key for the user.
+func (m *Manager) BeginWebAuthn(userID, username, displayName string) (*CreationOptions, error) {
+	state, err := m.GetState(userID)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := relyingParty()
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := state.newChallenge(m.now())
+	if err != nil {
+		return nil, err
+	}
+	if err := m.SaveState(userID, state); err != nil {
+		return nil, err
+	}
+
+	options := &CreationOptions{
+		Challenge: challenge,
+		RP:        CreationEntity{ID: rp.ID, Name: issuer},
+		User: CreationEntity{
+			ID:          base64.RawURLEncoding.EncodeToString([]byte(userID)),
+			Name:        username,
+			DisplayName: displayName,
+		},
+		PubKeyCredParams: []CredentialParameter{
+			{Type: "public-key", Alg: AlgorithmES256},
+			{Type: "public-key", Alg: AlgorithmRS256},
+		},
+		Attestation: "none",
+		Timeout:     ChallengeTTL.Milliseconds(),
+	}
+	for _, credential := range state.WebAuthnCredentials {
+		options.ExcludeCredentials = append(options.ExcludeCredentials, credential.ID)
+	}
+	return options, nil
+}
+
+// FinishWebAuthn registers the security key that answered the registration challenge of the user. It returns recovery
+// codes if the user had none.
+func (m *Manager) FinishWebAuthn(userID, name, clientDataJSON, attestationObject string) (*WebAuthnCredential, []string, error) {
+	state, err := m.GetState(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	rp, err := relyingParty()
+	if err != nil {
+		return nil, nil, err
+	}
+	challenge, err := state.takeChallenge(m.now())
+	if err != nil {
+		return nil, nil, httperror.NewAPIError(httperror.InvalidState, err.Error())
+	}
+
+	rawClientData, err := DecodeBase64URL(clientDataJSON)
+	if err != nil {
+		return nil, nil, httperror.NewAPIError(httperror.InvalidBodyContent, "invalid clientDataJSON")
+	}
+	rawAttestation, err := DecodeBase64URL(attestationObject)
+	if err != nil {
+		return nil, nil, httperror.NewAPIError(httperror.InvalidBodyContent, "invalid attestationObject")
+	}
+	credential, err := rp.VerifyRegistration(challenge, rawClientData, rawAttestation)
+	if err != nil {
+		return nil, nil, httperror.NewAPIError(httperror.InvalidBodyContent, err.Error())
+	}
+	for _, existing := range state.WebAuthnCredentials {
+		if existing.ID == credential.ID {
+			return nil, nil, httperror.NewAPIError(httperror.Conflict, "security key is already registered")
+		}
+	}
+
+	credential.Name = name
+	state.WebAuthnCredentials = append(state.WebAuthnCredentials, *credential)
+	codes, err := m.saveWithRecoveryCodes(userID, state)
+	if err != nil {
+		return nil, nil, err
+	}
+	return credential, codes, nil
+}
+
+// RemoveWebAuthn removes a security key of the user.
+func (m *Manager) RemoveWebAuthn(userID, credentialID string) error {
+	state, err := m.GetState(userID)
+	if err != nil {
+		return err
+	}
+	for i, credential := range state.WebAuthnCredentials {
+		if credential.ID == credentialID {
+			state.WebAuthnCredentials = append(state.WebAuthnCredentials[:i], state.WebAuthnCredentials[i+1:]...)
+			state.removeFactors()
+			return m.SaveState(userID, state)
+		}
+	}
+	return httperror.NewAPIError(httperror.NotFound, "security key not found")
+}
+
+func relyingParty() (RelyingParty, error) {
+	rp, err := RelyingPartyFromServerURL(settings.ServerURL.Get())
+	if err != nil {
+		return RelyingParty{}, httperror.WrapAPIError(err, httperror.InvalidState, err.Error())
+	}
+	return rp, nil
+}
@@ -0,0 +1,73 @@
+package mfa
+
+import (
+	"testing"
+	"time"
+
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateVerifyCode(t *testing.T) {
+	codes, hashes, err := NewRecoveryCodes()
+	require.NoError(t, err)
+	state := &State{TOTPSecret: rfcSecret, RecoveryCodes: hashes}
+	at := time.Unix(59, 0)
+
+	assert.True(t, state.verifyCode("287082", at))
+	assert.Equal(t, int64(1), state.TOTPLastStep)
+	assert.False(t, state.verifyCode("287082", at), "a TOTP code can only be used once")
+
+	assert.True(t, state.verifyCode(codes[0], at))
+	assert.Len(t, state.RecoveryCodes, recoveryCodeCount-1)
+	assert.False(t, state.verifyCode(codes[0], at))
+}
+
+func TestStateChallenge(t *testing.T) {
+	now := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	state := &State{}
+
+	challenge, err := state.newChallenge(now)
+	require.NoError(t, err)
+	taken, err := state.takeChallenge(now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, challenge, taken)
+	_, err = state.takeChallenge(now.Add(time.Minute))
+	assert.Error(t, err, "a challenge can only be answered once")
+
+	_, err = state.newChallenge(now)
+	require.NoError(t, err)
+	_, err = state.takeChallenge(now.Add(ChallengeTTL + time.Second))
+	assert.Error(t, err, "expired challenge")
+}
+
+func TestStateRemoveFactors(t *testing.T) {
+	state := &State{
+		TOTPSecret:          rfcSecret,
+		RecoveryCodes:       []string{"hash"},
+		WebAuthnCredentials: []WebAuthnCredential{{ID: "key"}},
+	}
+	state.TOTPSecret = ""
+	state.removeFactors()
+	assert.True(t, state.Enrolled())
+	assert.NotEmpty(t, state.RecoveryCodes)
+
+	state.WebAuthnCredentials = nil
+	state.removeFactors()
+	assert.False(t, state.Enrolled())
+	assert.Empty(t, state.RecoveryCodes)
+}
+
+func TestRequiredForRoles(t *testing.T) {
+	assert.True(t, requiredForRoles("admin, restricted-admin", []string{"user", "restricted-admin"}))
+	assert.False(t, requiredForRoles("admin", []string{"user"}))
+	assert.False(t, requiredForRoles("admin", nil))
+}
+
+func TestVerifiedPrincipal(t *testing.T) {
+	principal := v32.Principal{}
+	assert.False(t, IsVerified(principal))
+	MarkVerified(&principal)
+	assert.True(t, IsVerified(principal))
+}
@@ -0,0 +1,106 @@
+package mfa
+
+import (
+	"errors"
+	"time"
+
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+)
+
+// ChallengeTTL is how long a security key challenge can be answered.
+const ChallengeTTL = 5 * time.Minute
+
+// State is the multi-factor authentication enrollment of a local user.
+type State struct {
+	TOTPSecret string `json:"totpSecret,omitempty"`
+	// PendingTOTPSecret is a TOTP secret that becomes the TOTP secret once a code generated from it is confirmed.
+	PendingTOTPSecret string `json:"pendingTotpSecret,omitempty"`
+	// TOTPLastStep is the time step of the last accepted TOTP code, codes can't be used twice.
+	TOTPLastStep        int64                `json:"totpLastStep,omitempty"`
+	RecoveryCodes       []string             `json:"recoveryCodes,omitempty"`
+	WebAuthnCredentials []WebAuthnCredential `json:"webauthnCredentials,omitempty"`
+	Challenge           string               `json:"challenge,omitempty"`
+	ChallengeExpiresAt  time.Time            `json:"challengeExpiresAt,omitempty"`
+}
+
+// Enrolled returns true if the user has a second factor.
+func (s *State) Enrolled() bool {
+	return s.TOTPSecret != "" || len(s.WebAuthnCredentials) > 0
+}
+
+// newChallenge sets a new challenge for a security key ceremony.
+func (s *State) newChallenge(now time.Time) (string, error) {
+	challenge, err := NewChallenge()
+	if err != nil {
+		return "", err
+	}
+	s.Challenge = challenge
+	s.ChallengeExpiresAt = now.Add(ChallengeTTL)
+	return challenge, nil
+}
+
+// takeChallenge returns the current challenge and clears it, so that a challenge is only answered once.
+func (s *State) takeChallenge(now time.Time) (string, error) {
+	challenge := s.Challenge
+	s.Challenge = ""
+	if challenge == "" || now.After(s.ChallengeExpiresAt) {
+		return "", errors.New("no pending security key challenge")
+	}
+	return challenge, nil
+}
+
+// verifyCode checks a TOTP code or a recovery code, recovery codes are consumed.
+func (s *State) verifyCode(code string, now time.Time) bool {
+	if s.TOTPSecret != "" {
+		if step, ok := ValidateTOTP(s.TOTPSecret, code, now); ok && step > s.TOTPLastStep {
+			s.TOTPLastStep = step
+			return true
+		}
+	}
+	if remaining, ok := useRecoveryCode(s.RecoveryCodes, code); ok {
+		s.RecoveryCodes = remaining
+		return true
+	}
+	return false
+}
+
+// verifyAssertion checks the response of one of the security keys of the user to the current challenge.
+func (s *State) verifyAssertion(rp RelyingParty, assertion *v32.WebAuthnAssertion, now time.Time) error {
+	challenge, err := s.takeChallenge(now)
+	if err != nil {
+		return err
+	}
+
+	for i, credential := range s.WebAuthnCredentials {
+		if credential.ID != trimPadding(assertion.CredentialID) {
+			continue
+		}
+		clientDataJSON, err := DecodeBase64URL(assertion.ClientDataJSON)
+		if err != nil {
+			return err
+		}
+		authData, err := DecodeBase64URL(assertion.AuthenticatorData)
+		if err != nil {
+			return err
+		}
+		signature, err := DecodeBase64URL(assertion.Signature)
+		if err != nil {
+			return err
+		}
+		signCount, err := rp.VerifyAssertion(challenge, credential, clientDataJSON, authData, signature)
+		if err != nil {
+			return err
+		}
+		s.WebAuthnCredentials[i].SignCount = signCount
+		return nil
+	}
+	return errors.New("unknown security key")
+}
+
+// removeFactors clears the recovery codes once the user has no second factor left, they are only a fallback.
+func (s *State) removeFactors() {
+	if !s.Enrolled() {
+		s.RecoveryCodes = nil
+		s.TOTPLastStep = 0
+	}
+}
@@ -0,0 +1,73 @@
+package mfa
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA1 secret of the test vectors of RFC 6238.
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestValidateTOTP(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		at       time.Time
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "rfc vector", code: "287082", at: time.Unix(59, 0), wantStep: 1, wantOK: true},
+		{name: "rfc vector later", code: "081804", at: time.Unix(1111111109, 0), wantStep: 37037036, wantOK: true},
+		{name: "previous period is accepted", code: "287082", at: time.Unix(89, 0), wantStep: 1, wantOK: true},
+		{name: "older period is rejected", code: "287082", at: time.Unix(120, 0)},
+		{name: "spaces are ignored", code: "287 082", at: time.Unix(59, 0), wantStep: 1, wantOK: true},
+		{name: "wrong code", code: "123456", at: time.Unix(59, 0)},
+		{name: "wrong length", code: "94287082", at: time.Unix(59, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := ValidateTOTP(rfcSecret, tt.code, tt.at)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantStep, step)
+		})
+	}
+}
+
+func TestNewTOTPSecret(t *testing.T) {
+	secret, err := NewTOTPSecret()
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	now := time.Now()
+	key, err := totpEncoding.DecodeString(secret)
+	require.NoError(t, err)
+	_, ok := ValidateTOTP(secret, totpCode(key, now.Unix()/totpPeriod), now)
+	assert.True(t, ok)
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri := TOTPURI("Rancher", "admin", "ABCDEF")
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Rancher:admin?"), uri)
+	assert.Contains(t, uri, "secret=ABCDEF")
+	assert.Contains(t, uri, "issuer=Rancher")
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, hashes, err := NewRecoveryCodes()
+	require.NoError(t, err)
+	require.Len(t, codes, recoveryCodeCount)
+	require.Len(t, hashes, recoveryCodeCount)
+
+	remaining, ok := useRecoveryCode(hashes, strings.ToUpper(codes[3]))
+	assert.True(t, ok)
+	assert.Len(t, remaining, recoveryCodeCount-1)
+	assert.Len(t, hashes, recoveryCodeCount, "hashes must not be modified")
+
+	_, ok = useRecoveryCode(remaining, codes[3])
+	assert.False(t, ok, "recovery codes can only be used once")
+}
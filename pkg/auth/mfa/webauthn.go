@@ -0,0 +1,285 @@
+package mfa
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+)
+
+const (
+	webAuthnCreate = "webauthn.create"
+	webAuthnGet    = "webauthn.get"
+
+	flagUserPresent            = 0x01
+	flagAttestedCredentialData = 0x40
+
+	coseKeyType    = int64(1)
+	coseAlgorithm  = int64(3)
+	coseEC2Curve   = int64(-1)
+	coseEC2X       = int64(-2)
+	coseEC2Y       = int64(-3)
+	coseRSAModulus = int64(-1)
+	coseRSAExp     = int64(-2)
+
+	coseKeyTypeEC2 = 2
+	coseKeyTypeRSA = 3
+	coseCurveP256  = 1
+
+	// AlgorithmES256 and AlgorithmRS256 are the COSE algorithms of the supported credentials, offered to
+	// authenticators in this order of preference.
+	AlgorithmES256 = -7
+	AlgorithmRS256 = -257
+)
+
+// WebAuthnCredential is a security key registered by a user.
+type WebAuthnCredential struct {
+	// ID is the base64url encoded credential ID chosen by the authenticator.
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+	// PublicKey is the base64 encoded PKIX public key of the credential.
+	PublicKey string `json:"publicKey"`
+	Algorithm int64  `json:"algorithm"`
+	SignCount uint32 `json:"signCount"`
+}
+
+// RelyingParty identifies rancher to authenticators. Credentials are scoped to the ID, the host name of rancher, and
+// ceremonies are only accepted from the origin of rancher.
+type RelyingParty struct {
+	ID     string
+	Origin string
+}
+
+// RelyingPartyFromServerURL returns the relying party of the server URL of rancher.
+func RelyingPartyFromServerURL(serverURL string) (RelyingParty, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return RelyingParty{}, err
+	}
+	if u.Hostname() == "" {
+		return RelyingParty{}, errors.New("server-url must be set to use security keys")
+	}
+	return RelyingParty{ID: u.Hostname(), Origin: u.Scheme + "://" + u.Host}, nil
+}
+
+// NewChallenge returns a random base64url encoded WebAuthn challenge.
+func NewChallenge() (string, error) {
+	challenge := make([]byte, 32)
+	if _, err := rand.Read(challenge); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(challenge), nil
+}
+
+// DecodeBase64URL decodes the base64url encoded binary fields of WebAuthn responses, with or without padding.
+func DecodeBase64URL(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(trimPadding(s))
+}
+
+func trimPadding(s string) string {
+	return strings.TrimRight(s, "=")
+}
+
+// VerifyRegistration verifies the response of an authenticator to a credential creation for the challenge and returns
+// the new credential. Attestation statements are not verified since registrations request no attestation.
+func (rp RelyingParty) VerifyRegistration(challenge string, clientDataJSON, attestationObject []byte) (*WebAuthnCredential, error) {
+	if err := rp.verifyClientData(clientDataJSON, webAuthnCreate, challenge); err != nil {
+		return nil, err
+	}
+
+	decoded, _, err := decodeCBOR(attestationObject)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attestation object: %w", err)
+	}
+	attestation, ok := decoded.(map[interface{}]interface{})
+	if !ok {
+		return nil, errors.New("invalid attestation object")
+	}
+	rawAuthData, ok := attestation["authData"].([]byte)
+	if !ok {
+		return nil, errors.New("attestation object has no authenticator data")
+	}
+
+	authData, err := rp.parseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	if authData.flags&flagAttestedCredentialData == 0 || authData.publicKey == nil {
+		return nil, errors.New("authenticator data has no credential")
+	}
+
+	publicKey, algorithm, err := parseCOSEKey(authData.publicKey)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebAuthnCredential{
+		ID:        base64.RawURLEncoding.EncodeToString(authData.credentialID),
+		PublicKey: base64.StdEncoding.EncodeToString(der),
+		Algorithm: algorithm,
+		SignCount: authData.signCount,
+	}, nil
+}
+
+// VerifyAssertion verifies the response of an authenticator to an authentication with the credential for the challenge,
+// and returns the new signature counter of the credential.
+func (rp RelyingParty) VerifyAssertion(challenge string, credential WebAuthnCredential, clientDataJSON, rawAuthData, signature []byte) (uint32, error) {
+	if err := rp.verifyClientData(clientDataJSON, webAuthnGet, challenge); err != nil {
+		return 0, err
+	}
+	authData, err := rp.parseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return 0, err
+	}
+	// a counter that doesn't increase reveals a cloned authenticator, authenticators without counter always send 0
+	if (authData.signCount != 0 || credential.SignCount != 0) && authData.signCount <= credential.SignCount {
+		return 0, errors.New("signature counter did not increase")
+	}
+
+	der, err := base64.StdEncoding.DecodeString(credential.PublicKey)
+	if err != nil {
+		return 0, err
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return 0, err
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	digest := sha256.Sum256(append(append([]byte{}, rawAuthData...), clientDataHash[:]...))
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		if credential.Algorithm != AlgorithmES256 || !ecdsa.VerifyASN1(key, digest[:], signature) {
+			return 0, errors.New("invalid signature")
+		}
+	case *rsa.PublicKey:
+		if credential.Algorithm != AlgorithmRS256 || rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) != nil {
+			return 0, errors.New("invalid signature")
+		}
+	default:
+		return 0, fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+	return authData.signCount, nil
+}
+
+type clientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+func (rp RelyingParty) verifyClientData(raw []byte, ceremony, challenge string) error {
+	var data clientData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return fmt.Errorf("invalid client data: %w", err)
+	}
+	if data.Type != ceremony {
+		return fmt.Errorf("invalid client data type %s", data.Type)
+	}
+	if challenge == "" || subtle.ConstantTimeCompare([]byte(trimPadding(data.Challenge)), []byte(challenge)) != 1 {
+		return errors.New("invalid challenge")
+	}
+	if data.Origin != rp.Origin {
+		return fmt.Errorf("invalid origin %s", data.Origin)
+	}
+	return nil
+}
+
+type authenticatorData struct {
+	flags        byte
+	signCount    uint32
+	credentialID []byte
+	publicKey    map[interface{}]interface{}
+}
+
+// parseAuthenticatorData parses authenticator data as defined by the WebAuthn specification, and checks that it is
+// scoped to the relying party and that the user was present.
+func (rp RelyingParty) parseAuthenticatorData(data []byte) (*authenticatorData, error) {
+	if len(data) < 37 {
+		return nil, errors.New("authenticator data is too short")
+	}
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if !bytes.Equal(data[:32], rpIDHash[:]) {
+		return nil, errors.New("authenticator data is not scoped to this server")
+	}
+
+	authData := &authenticatorData{
+		flags:     data[32],
+		signCount: binary.BigEndian.Uint32(data[33:37]),
+	}
+	if authData.flags&flagUserPresent == 0 {
+		return nil, errors.New("user was not present")
+	}
+	if authData.flags&flagAttestedCredentialData == 0 {
+		return authData, nil
+	}
+
+	// attested credential data: 16 bytes AAGUID, 2 bytes credential ID length, credential ID, COSE public key
+	rest := data[37:]
+	if len(rest) < 18 {
+		return nil, errors.New("attested credential data is too short")
+	}
+	idLength := int(binary.BigEndian.Uint16(rest[16:18]))
+	rest = rest[18:]
+	if len(rest) < idLength {
+		return nil, errors.New("attested credential data is too short")
+	}
+	authData.credentialID = append([]byte{}, rest[:idLength]...)
+
+	key, _, err := decodeCBOR(rest[idLength:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid credential public key: %w", err)
+	}
+	publicKey, ok := key.(map[interface{}]interface{})
+	if !ok {
+		return nil, errors.New("invalid credential public key")
+	}
+	authData.publicKey = publicKey
+	return authData, nil
+}
+
+// parseCOSEKey converts a COSE key, as defined by RFC 8152, to a public key. Only ES256 and RS256 keys are supported.
+func parseCOSEKey(key map[interface{}]interface{}) (crypto.PublicKey, int64, error) {
+	keyType, _ := key[coseKeyType].(int64)
+	algorithm, _ := key[coseAlgorithm].(int64)
+
+	switch {
+	case keyType == coseKeyTypeEC2 && algorithm == AlgorithmES256:
+		curve, _ := key[coseEC2Curve].(int64)
+		x, _ := key[coseEC2X].([]byte)
+		y, _ := key[coseEC2Y].([]byte)
+		if curve != coseCurveP256 || len(x) != 32 || len(y) != 32 {
+			return nil, 0, errors.New("invalid ES256 public key")
+		}
+		publicKey := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !publicKey.Curve.IsOnCurve(publicKey.X, publicKey.Y) {
+			return nil, 0, errors.New("invalid ES256 public key")
+		}
+		return publicKey, algorithm, nil
+	case keyType == coseKeyTypeRSA && algorithm == AlgorithmRS256:
+		n, _ := key[coseRSAModulus].([]byte)
+		e, _ := key[coseRSAExp].([]byte)
+		if len(n) < 256 || len(e) == 0 || len(e) > 4 {
+			return nil, 0, errors.New("invalid RS256 public key")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, algorithm, nil
+	}
+	return nil, 0, fmt.Errorf("unsupported credential algorithm %d", algorithm)
+}
@@ -0,0 +1,203 @@
+package mfa
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testRP = RelyingParty{ID: "rancher.example.com", Origin: "https://rancher.example.com"}
+
+// encodeCBOR encodes the few item types used by attestation objects and COSE keys.
+func encodeCBOR(v interface{}) []byte {
+	head := func(major byte, n uint64) []byte {
+		switch {
+		case n < 24:
+			return []byte{major<<5 | byte(n)}
+		case n < 1<<8:
+			return []byte{major<<5 | 24, byte(n)}
+		default:
+			b := []byte{major<<5 | 25, 0, 0}
+			binary.BigEndian.PutUint16(b[1:], uint16(n))
+			return b
+		}
+	}
+	switch v := v.(type) {
+	case int:
+		if v < 0 {
+			return head(1, uint64(-1-v))
+		}
+		return head(0, uint64(v))
+	case []byte:
+		return append(head(2, uint64(len(v))), v...)
+	case string:
+		return append(head(3, uint64(len(v))), v...)
+	case map[interface{}]interface{}:
+		var keys []string
+		encoded := map[string][]byte{}
+		for k, value := range v {
+			key := string(encodeCBOR(k))
+			keys = append(keys, key)
+			encoded[key] = encodeCBOR(value)
+		}
+		sort.Strings(keys)
+		out := head(5, uint64(len(v)))
+		for _, key := range keys {
+			out = append(append(out, key...), encoded[key]...)
+		}
+		return out
+	}
+	panic("unsupported type")
+}
+
+type testAuthenticator struct {
+	key       *ecdsa.PrivateKey
+	id        []byte
+	signCount uint32
+}
+
+func newTestAuthenticator(t *testing.T) *testAuthenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return &testAuthenticator{key: key, id: []byte("credential-1")}
+}
+
+func (a *testAuthenticator) authData(rpID string, attested bool) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	data := append([]byte{}, rpIDHash[:]...)
+	flags := byte(flagUserPresent)
+	if attested {
+		flags |= flagAttestedCredentialData
+	}
+	data = append(data, flags)
+	data = binary.BigEndian.AppendUint32(data, a.signCount)
+	if attested {
+		data = append(data, make([]byte, 16)...)
+		data = binary.BigEndian.AppendUint16(data, uint16(len(a.id)))
+		data = append(data, a.id...)
+		data = append(data, encodeCBOR(map[interface{}]interface{}{
+			1:  coseKeyTypeEC2,
+			3:  AlgorithmES256,
+			-1: coseCurveP256,
+			-2: a.key.X.FillBytes(make([]byte, 32)),
+			-3: a.key.Y.FillBytes(make([]byte, 32)),
+		})...)
+	}
+	return data
+}
+
+func clientDataJSON(t *testing.T, ceremony, challenge, origin string) []byte {
+	data, err := json.Marshal(clientData{Type: ceremony, Challenge: challenge, Origin: origin})
+	require.NoError(t, err)
+	return data
+}
+
+func (a *testAuthenticator) register(t *testing.T, challenge string) ([]byte, []byte) {
+	attestation := encodeCBOR(map[interface{}]interface{}{
+		"fmt":      "none",
+		"attStmt":  map[interface{}]interface{}{},
+		"authData": a.authData(testRP.ID, true),
+	})
+	return clientDataJSON(t, webAuthnCreate, challenge, testRP.Origin), attestation
+}
+
+func (a *testAuthenticator) sign(t *testing.T, challenge, rpID string) ([]byte, []byte, []byte) {
+	a.signCount++
+	clientData := clientDataJSON(t, webAuthnGet, challenge, testRP.Origin)
+	authData := a.authData(rpID, false)
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	require.NoError(t, err)
+	return clientData, authData, signature
+}
+
+func TestWebAuthnRegistrationAndAssertion(t *testing.T) {
+	authenticator := newTestAuthenticator(t)
+
+	challenge, err := NewChallenge()
+	require.NoError(t, err)
+	clientData, attestation := authenticator.register(t, challenge)
+	credential, err := testRP.VerifyRegistration(challenge, clientData, attestation)
+	require.NoError(t, err)
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(authenticator.id), credential.ID)
+	assert.Equal(t, int64(AlgorithmES256), credential.Algorithm)
+
+	challenge, err = NewChallenge()
+	require.NoError(t, err)
+	clientData, authData, signature := authenticator.sign(t, challenge, testRP.ID)
+	signCount, err := testRP.VerifyAssertion(challenge, *credential, clientData, authData, signature)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), signCount)
+
+	credential.SignCount = signCount
+	_, err = testRP.VerifyAssertion(challenge, *credential, clientData, authData, signature)
+	assert.Error(t, err, "a replayed assertion must not increase the signature counter")
+}
+
+func TestWebAuthnRejections(t *testing.T) {
+	authenticator := newTestAuthenticator(t)
+	challenge, err := NewChallenge()
+	require.NoError(t, err)
+	clientData, attestation := authenticator.register(t, challenge)
+	credential, err := testRP.VerifyRegistration(challenge, clientData, attestation)
+	require.NoError(t, err)
+
+	other, err := NewChallenge()
+	require.NoError(t, err)
+	_, err = testRP.VerifyRegistration(other, clientData, attestation)
+	assert.Error(t, err, "wrong challenge")
+
+	_, err = RelyingParty{ID: testRP.ID, Origin: "https://evil.example.com"}.VerifyRegistration(challenge, clientData, attestation)
+	assert.Error(t, err, "wrong origin")
+
+	clientData, authData, signature := authenticator.sign(t, challenge, "evil.example.com")
+	_, err = testRP.VerifyAssertion(challenge, *credential, clientData, authData, signature)
+	assert.Error(t, err, "wrong relying party")
+
+	clientData, authData, signature = authenticator.sign(t, challenge, testRP.ID)
+	signature[len(signature)-1] ^= 0xff
+	_, err = testRP.VerifyAssertion(challenge, *credential, clientData, authData, signature)
+	assert.Error(t, err, "invalid signature")
+
+	_, err = testRP.VerifyAssertion(challenge, *credential, clientDataJSON(t, webAuthnCreate, challenge, testRP.Origin), authData, signature)
+	assert.Error(t, err, "wrong ceremony")
+}
+
+func TestDecodeCBOR(t *testing.T) {
+	value, n, err := decodeCBOR([]byte{0xa2, 0x01, 0x02, 0x20, 0x43, 0x01, 0x02, 0x03, 0xff})
+	require.NoError(t, err)
+	assert.Equal(t, 8, n)
+	assert.Equal(t, map[interface{}]interface{}{int64(1): int64(2), int64(-1): []byte{1, 2, 3}}, value)
+
+	_, _, err = decodeCBOR([]byte{0x5a, 0xff, 0xff, 0xff, 0xff})
+	assert.Error(t, err, "truncated byte string")
+
+	_, _, err = decodeCBOR([]byte{0x9f})
+	assert.Error(t, err, "indefinite length array")
+
+	nested := make([]byte, maxCBORDepth+2)
+	for i := range nested {
+		nested[i] = 0x81
+	}
+	_, _, err = decodeCBOR(nested)
+	assert.Error(t, err, "nesting too deep")
+}
+
+func TestRelyingPartyFromServerURL(t *testing.T) {
+	rp, err := RelyingPartyFromServerURL("https://rancher.example.com:8443/")
+	require.NoError(t, err)
+	assert.Equal(t, RelyingParty{ID: "rancher.example.com", Origin: "https://rancher.example.com:8443"}, rp)
+
+	_, err = RelyingPartyFromServerURL("")
+	assert.Error(t, err)
+}
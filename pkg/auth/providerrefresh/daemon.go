@@ -9,9 +9,9 @@
 	"github.com/rancher/rancher/pkg/auth/settings"
 	"github.com/rancher/rancher/pkg/auth/tokens"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/types/config"
 	"github.com/robfig/cron"
-	"github.com/sirupsen/logrus"
 )
 
 var (
@@ -43,7 +43,7 @@ func UpdateRefreshCronTime(refreshCronTime string) {
 
 	parsed, err := ParseCron(refreshCronTime)
 	if err != nil {
-		logrus.Errorf("%v", err)
+		logger.Errorf("%v", err)
 		return
 	}
 
@@ -70,7 +70,7 @@ func RefreshAllForCron() {
 		return
 	}
 
-	logrus.Debug("Triggering auth refresh cron")
+	logger.Debug("Triggering auth refresh cron")
 	ref.refreshAll(false)
 }
 
@@ -79,12 +79,12 @@ func RefreshAttributes(attribs *v3.UserAttribute) (*v3.UserAttribute, error) {
 		return nil, errors.Errorf("refresh daemon not yet initialized")
 	}
 
-	logrus.Debugf("Starting refresh process for %v", attribs.Name)
+	logger.Debugf("Starting refresh process for %v", attribs.Name)
 	modified, err := ref.refreshAttributes(attribs)
 	if err != nil {
 		return nil, err
 	}
-	logrus.Debugf("Finished refresh process for %v", attribs.Name)
+	logger.Debugf("Finished refresh process for %v", attribs.Name)
 	modified.LastRefresh = time.Now().UTC().Format(time.RFC3339)
 	modified.NeedsRefresh = false
 	return modified, nil
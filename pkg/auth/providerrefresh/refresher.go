@@ -12,14 +12,16 @@
 	"github.com/rancher/rancher/pkg/auth/settings"
 	"github.com/rancher/rancher/pkg/auth/tokens"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/types/config"
-	"github.com/sirupsen/logrus"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/utils/pointer"
 )
 
+var logger = logging.Logger(logging.Auth)
+
 type UserAuthRefresher interface {
 	TriggerAllUserRefresh()
 	TriggerUserRefresh(string, bool)
@@ -56,7 +58,7 @@ func (r *refresher) ensureMaxAgeUpToDate(maxAge string) {
 
 	parsed, err := ParseMaxAge(maxAge)
 	if err != nil {
-		logrus.Errorf("Error parsing max age %v", err)
+		logger.Errorf("Error parsing max age %v", err)
 		return
 	}
 	r.unparsedMaxAge = maxAge
@@ -65,15 +67,15 @@ func (r *refresher) ensureMaxAgeUpToDate(maxAge string) {
 
 func (r *refresher) TriggerUserRefresh(userName string, force bool) {
 	if force {
-		logrus.Debugf("Triggering auth refresh manually on %v", userName)
+		logger.Debugf("Triggering auth refresh manually on %v", userName)
 	} else {
-		logrus.Debugf("Triggering auth refresh on %v", userName)
+		logger.Debugf("Triggering auth refresh on %v", userName)
 	}
 	r.Lock()
 	r.ensureMaxAgeUpToDate(settings.AuthUserInfoMaxAgeSeconds.Get())
 	r.Unlock()
 	if !force && (r.maxAge <= 0) {
-		logrus.Debugf("Skipping refresh trigger on user %v because max age setting is <= 0", userName)
+		logger.Debugf("Skipping refresh trigger on user %v because max age setting is <= 0", userName)
 		return
 	}
 
@@ -81,14 +83,14 @@ func (r *refresher) TriggerUserRefresh(userName string, force bool) {
 }
 
 func (r *refresher) TriggerAllUserRefresh() {
-	logrus.Debug("Triggering auth refresh manually on all users")
+	logger.Debug("Triggering auth refresh manually on all users")
 	r.refreshAll(true)
 }
 
 func (r *refresher) refreshAll(force bool) {
 	users, err := r.userLister.List("", labels.Everything())
 	if err != nil {
-		logrus.Errorf("Error listing Users during auth provider refresh: %v", err)
+		logger.Errorf("Error listing Users during auth provider refresh: %v", err)
 	}
 	for _, user := range users {
 		r.triggerUserRefresh(user.Name, force)
@@ -98,7 +100,7 @@ func (r *refresher) refreshAll(force bool) {
 func (r *refresher) triggerUserRefresh(userName string, force bool) {
 	attribs, needCreate, err := r.tokenMGR.EnsureAndGetUserAttribute(userName)
 	if err != nil {
-		logrus.Errorf("Error fetching user attribute to trigger refresh: %v", err)
+		logger.Errorf("Error fetching user attribute to trigger refresh: %v", err)
 		return
 	}
 	now := time.Now().UTC()
@@ -106,19 +108,19 @@ func (r *refresher) triggerUserRefresh(userName string, force bool) {
 	lastRefresh, _ := time.Parse(time.RFC3339, attribs.LastRefresh)
 	earliestRefresh := lastRefresh.Add(r.maxAge)
 	if !force && now.Before(earliestRefresh) {
-		logrus.Debugf("Skipping refresh for %v due to max-age", userName)
+		logger.Debugf("Skipping refresh for %v due to max-age", userName)
 		return
 	}
 
 	user, err := r.userLister.Get("", userName)
 	if err != nil {
-		logrus.Errorf("Error finding user before triggering refresh %v", err)
+		logger.Errorf("Error finding user before triggering refresh %v", err)
 		return
 	}
 
 	for _, principalID := range user.PrincipalIDs {
 		if strings.HasPrefix(principalID, "system://") {
-			logrus.Debugf("Skipping refresh for system-user %v ", userName)
+			logger.Debugf("Skipping refresh for system-user %v ", userName)
 			return
 		}
 	}
@@ -127,16 +129,16 @@ func (r *refresher) triggerUserRefresh(userName string, force bool) {
 	if needCreate {
 		_, err := r.userAttributes.Create(attribs)
 		if err != nil {
-			logrus.Errorf("Error creating user attribute to trigger refresh: %v", err)
+			logger.Errorf("Error creating user attribute to trigger refresh: %v", err)
 		}
 	} else {
 		_, err = r.userAttributes.Update(attribs)
 		if err != nil {
 			if apierrors.IsConflict(err) {
 				// User attribute has just been updated, triggering the refresh.
-				logrus.Debugf("Error updating user attribute to trigger refresh: %v", err)
+				logger.Debugf("Error updating user attribute to trigger refresh: %v", err)
 			} else {
-				logrus.Errorf("Error updating user attribute to trigger refresh: %v", err)
+				logger.Errorf("Error updating user attribute to trigger refresh: %v", err)
 			}
 		}
 	}
@@ -193,7 +195,7 @@ func (r *refresher) refreshAttributes(attribs *v3.UserAttribute) (*v3.UserAttrib
 
 		providerDisabled, err := providers.IsDisabledProvider(providerName)
 		if err != nil {
-			logrus.Warnf("Unable to determine if provider %s was disabled, will assume that it isn't with error: %v", providerName, err)
+			logger.Warnf("Unable to determine if provider %s was disabled, will assume that it isn't with error: %v", providerName, err)
 			// this is set as false by the return, but it's re-set here to be explicit/safe about the behavior
 			providerDisabled = false
 		}
@@ -229,7 +231,7 @@ func (r *refresher) refreshAttributes(attribs *v3.UserAttribute) (*v3.UserAttrib
 					// we no longer want to disable derived tokens, or remove their login tokens for this provider
 					if err.Error() != "no access" {
 						errorConfirmingLogins = true
-						logrus.Errorf("Error refreshing token principals, skipping: %v", err)
+						logger.Errorf("Error refreshing token principals, skipping: %v", err)
 						existingPrincipals := attribs.GroupPrincipals[providerName].Items
 						if existingPrincipals != nil {
 							newGroupPrincipals = existingPrincipals
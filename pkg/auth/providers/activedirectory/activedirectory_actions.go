@@ -13,8 +13,8 @@
 	"github.com/rancher/rancher/pkg/auth/providers/common"
 	client "github.com/rancher/rancher/pkg/client/generated/management/v3"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/logging"
 	managementschema "github.com/rancher/rancher/pkg/schemas/management.cattle.io/v3"
-	"github.com/sirupsen/logrus"
 )
 
 func (p *adProvider) formatter(apiContext *types.APIContext, resource *types.RawResource) {
@@ -114,7 +114,7 @@ func (p *adProvider) saveActiveDirectoryConfig(config *v32.ActiveDirectoryConfig
 
 	config.ServiceAccountPassword = common.GetFullSecretName(config.Type, field)
 
-	logrus.Debugf("updating activeDirectoryConfig")
+	logger.Debugf("updating activeDirectoryConfig")
 	_, err = p.authConfigs.ObjectClient().Update(config.ObjectMeta.Name, config)
 	if err != nil {
 		return err
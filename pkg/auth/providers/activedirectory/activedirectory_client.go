@@ -18,12 +18,14 @@
 	"github.com/rancher/norman/types/slice"
 	"github.com/rancher/rancher/pkg/auth/providers/common/ldap"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+var logger = logging.Logger(logging.Auth)
+
 func (p *adProvider) loginUser(adCredential *v32.BasicLogin, config *v32.ActiveDirectoryConfig, caPool *x509.CertPool, testServiceAccountBind bool) (v3.Principal, []v3.Principal, error) {
-	logrus.Debug("Now generating Ldap token")
+	logger.Debug("Now generating Ldap token")
 
 	username := adCredential.Username
 	password := adCredential.Password
@@ -47,7 +49,7 @@ func (p *adProvider) loginUser(adCredential *v32.BasicLogin, config *v32.ActiveD
 		}
 	}
 
-	logrus.Debug("Binding username password")
+	logger.Debug("Binding username password")
 	err = lConn.Bind(externalID, password)
 	if err != nil {
 		if ldapv3.IsErrorWithCode(err, ldapv3.LDAPResultInvalidCredentials) {
@@ -61,7 +63,7 @@ func (p *adProvider) loginUser(adCredential *v32.BasicLogin, config *v32.ActiveD
 		samName = strings.SplitN(username, `\`, 2)[1]
 	}
 	query := fmt.Sprintf("(%v=%v)", config.UserLoginAttribute, ldapv3.EscapeFilter(samName))
-	logrus.Debugf("LDAP Search query: {%s}", query)
+	logger.Debugf("LDAP Search query: {%s}", query)
 	search := ldapv3.NewSearchRequest(config.UserSearchBase,
 		ldapv3.ScopeWholeSubtree, ldapv3.NeverDerefAliases, 0, 0, false,
 		query,
@@ -120,7 +122,7 @@ func (p *adProvider) RefetchGroupPrincipals(principalID string, secret string) (
 	}
 
 	escapedGUID := common.EscapeUUID(externalID)
-	logrus.Debugf("LDAP Refetch principals GUID : {%s}", externalID)
+	logger.Debugf("LDAP Refetch principals GUID : {%s}", externalID)
 
 	filter := fmt.Sprintf("(&(%v=%v)(%v=%v))", ObjectClass, config.UserObjectClass, common.AttributeObjectGUID, escapedGUID)
 	search := ldapv3.NewSearchRequest(
@@ -172,8 +174,8 @@ func (p *adProvider) getPrincipalsFromSearchResult(result *ldapv3.SearchResult,
 
 	memberOf := entry.GetAttributeValues(MemberOfAttribute)
 
-	logrus.Debugf("ADConstants userMemberAttribute() {%v}", MemberOfAttribute)
-	logrus.Debugf("SearchResult memberOf attribute {%s}", memberOf)
+	logger.Debugf("ADConstants userMemberAttribute() {%v}", MemberOfAttribute)
+	logger.Debugf("SearchResult memberOf attribute {%s}", memberOf)
 
 	isType := false
 	objectClass := entry.GetAttributeValues(ObjectClass)
@@ -205,7 +207,7 @@ func (p *adProvider) getPrincipalsFromSearchResult(result *ldapv3.SearchResult,
 			query += ")"
 			query = fmt.Sprintf("(&%v%v)", filter, query)
 			// Pulling user's groups
-			logrus.Debugf("AD: Query for pulling user's groups: %v", query)
+			logger.Debugf("AD: Query for pulling user's groups: %v", query)
 			searchDomain := config.UserSearchBase
 			if config.GroupSearchBase != "" {
 				searchDomain = config.GroupSearchBase
@@ -301,7 +303,7 @@ func (p *adProvider) getGroupPrincipalsFromSearch(searchBase string, filter stri
 	for _, e := range result.Entries {
 		principal, err := ldap.AttributesToPrincipal(e.Attributes, e.DN, GroupScope, Name, config.UserObjectClass, config.UserNameAttribute, config.UserLoginAttribute, config.GroupObjectClass, config.GroupNameAttribute, "")
 		if err != nil {
-			logrus.Errorf("AD: Error in getting principal for group entry %v: %v", e, err)
+			logger.Errorf("AD: Error in getting principal for group entry %v: %v", e, err)
 			continue
 		}
 		if !reflect.DeepEqual(principal, nilPrincipal) {
@@ -337,7 +339,7 @@ func (p *adProvider) getPrincipal(distinguishedName string, scope string, config
 		filter = fmt.Sprintf("(%v=%v)", ObjectClass, config.GroupObjectClass)
 	}
 
-	logrus.Debugf("Query for getPrincipal(%s): %s", userSearchString, filter)
+	logger.Debugf("Query for getPrincipal(%s): %s", userSearchString, filter)
 	lConn, err := p.ldapConnection(config, caPool)
 	if err != nil {
 		return nil, err
@@ -441,14 +443,14 @@ func (p *adProvider) searchUser(name string, config *v32.ActiveDirectoryConfig,
 	}
 	// UserSearchFilter should be follow AD search filter syntax, enclosed by parentheses
 	query += srchAttrs + ")" + config.UserSearchFilter + ")"
-	logrus.Debugf("LDAPProvider searchUser query: %s", query)
+	logger.Debugf("LDAPProvider searchUser query: %s", query)
 	return p.searchLdap(query, UserScope, config, lConn)
 }
 
 func (p *adProvider) searchGroup(name string, config *v32.ActiveDirectoryConfig, lConn *ldapv3.Conn) ([]v3.Principal, error) {
 	// GroupSearchFilter should be follow AD search filter syntax, enclosed by parentheses
 	query := "(&(" + ObjectClass + "=" + config.GroupObjectClass + ")(" + config.GroupSearchAttribute + "=" + name + "*)" + config.GroupSearchFilter + ")"
-	logrus.Debugf("LDAPProvider searchGroup query: %s", query)
+	logger.Debugf("LDAPProvider searchGroup query: %s", query)
 	return p.searchLdap(query, GroupScope, config, lConn)
 }
 
@@ -493,7 +495,7 @@ func (p *adProvider) searchLdap(query string, scope string, config *v32.ActiveDi
 
 		principal, err := ldap.AttributesToPrincipal(entry.Attributes, results.Entries[i].DN, scope, Name, config.UserObjectClass, config.UserNameAttribute, config.UserLoginAttribute, config.GroupObjectClass, config.GroupNameAttribute, objectGUID)
 		if err != nil {
-			logrus.Errorf("Error translating search result: %v", err)
+			logger.Errorf("Error translating search result: %v", err)
 			continue
 		}
 		principals = append(principals, *principal)
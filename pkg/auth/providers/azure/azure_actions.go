@@ -14,8 +14,8 @@
 	"github.com/rancher/rancher/pkg/auth/providers/azure/clients"
 	"github.com/rancher/rancher/pkg/auth/providers/common"
 	client "github.com/rancher/rancher/pkg/client/generated/management/v3"
+	"github.com/rancher/rancher/pkg/logging"
 	managementschema "github.com/rancher/rancher/pkg/schemas/management.cattle.io/v3"
-	"github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -70,7 +70,7 @@ func (ap *azureProvider) testAndApply(actionName string, action *types.Action, r
 	defer func() {
 		if err != nil {
 			if err = ap.secrets.DeleteNamespaced(common.SecretsNamespace, clients.AccessTokenSecretName, &metav1.DeleteOptions{}); err != nil {
-				logrus.Errorf("Failed to delete the Azure AD access token secret from Kubernetes")
+				logger.Errorf("Failed to delete the Azure AD access token secret from Kubernetes")
 			}
 		}
 	}()
@@ -85,7 +85,7 @@ func (ap *azureProvider) testAndApply(actionName string, action *types.Action, r
 
 	currentConfig, err := ap.getAzureConfigK8s()
 	if err != nil {
-		logrus.Errorf("Failed to fetch Azure AD Config from Kubernetes: %v", err)
+		logger.Errorf("Failed to fetch Azure AD Config from Kubernetes: %v", err)
 		return httperror.NewAPIError(httperror.ServerError, "failed to fetch Azure AD Config from Kubernetes")
 	}
 	migrateNewFlowAnnotation(currentConfig, azureADConfig)
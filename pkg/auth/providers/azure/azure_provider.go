@@ -20,15 +20,17 @@
 	publicclient "github.com/rancher/rancher/pkg/client/generated/management/v3public"
 	corev1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/settings"
 	"github.com/rancher/rancher/pkg/types/config"
 	"github.com/rancher/rancher/pkg/user"
-	"github.com/sirupsen/logrus"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+var logger = logging.Logger(logging.Auth)
+
 const (
 	Name = clients.Name
 )
@@ -45,7 +47,7 @@ func Configure(ctx context.Context, mgmtCtx *config.ScaledContext, userMGR user.
 	var err error
 	clients.GroupCache, err = lru.New(settings.AzureGroupCacheSize.GetInt())
 	if err != nil {
-		logrus.Warnf("initial azure-group-cache-size was invalid value, setting to 10000 error:%v", err)
+		logger.Warnf("initial azure-group-cache-size was invalid value, setting to 10000 error:%v", err)
 		clients.GroupCache, _ = lru.New(10000)
 	}
 
@@ -85,7 +87,7 @@ func (ap *azureProvider) RefetchGroupPrincipals(principalID, secret string) ([]v
 		return nil, err
 	}
 
-	logrus.Debug("[AZURE_PROVIDER] Started getting user info from AzureAD")
+	logger.Debug("[AZURE_PROVIDER] Started getting user info from AzureAD")
 
 	parsed, err := clients.ParsePrincipalID(principalID)
 	if err != nil {
@@ -96,7 +98,7 @@ func (ap *azureProvider) RefetchGroupPrincipals(principalID, secret string) ([]v
 		return nil, err
 	}
 
-	logrus.Debug("[AZURE_PROVIDER] Completed getting user info from AzureAD")
+	logger.Debug("[AZURE_PROVIDER] Completed getting user info from AzureAD")
 
 	userGroups, err := azureClient.ListGroupMemberships(clients.GetPrincipalID(userPrincipal))
 	if err != nil {
@@ -302,7 +304,7 @@ func (ap *azureProvider) saveAzureConfigK8s(config *v32.AzureADConfig) error {
 
 	config.ApplicationSecret = common.GetFullSecretName(config.Type, field)
 
-	logrus.Debugf("updating AzureADConfig")
+	logger.Debugf("updating AzureADConfig")
 	_, err = ap.authConfigs.ObjectClient().Update(config.ObjectMeta.Name, config)
 	if err != nil {
 		return err
@@ -403,7 +405,7 @@ func formAzureRedirectURL(config map[string]interface{}) string {
 			)
 		}
 	} else {
-		logrus.Warnf("failed to determine if Graph endpoint is deprecated when generating redirect URL: %v", err)
+		logger.Warnf("failed to determine if Graph endpoint is deprecated when generating redirect URL: %v", err)
 	}
 	// Return the redirect URL for the deprecated Azure AD Graph.
 	return fmt.Sprintf(
@@ -424,7 +426,7 @@ func extractAnnotationsFromAuthConfig(config map[string]interface{}) map[string]
 	if metadata, ok := config["metadata"].(map[string]interface{}); ok {
 		return parseAnnotations(metadata)
 	}
-	logrus.Info("Failed to decode the 'metadata' field of the AuthConfig. Attempting to decode 'annotations' at the top level.")
+	logger.Info("Failed to decode the 'metadata' field of the AuthConfig. Attempting to decode 'annotations' at the top level.")
 	return parseAnnotations(config)
 }
 
@@ -432,14 +434,14 @@ func parseAnnotations(metadata map[string]interface{}) map[string]string {
 	annotations := make(map[string]string)
 	rawAnnotations, ok := metadata["annotations"].(map[string]interface{})
 	if !ok {
-		logrus.Info("Failed to decode the 'annotations' field of the AuthConfig.")
+		logger.Info("Failed to decode the 'annotations' field of the AuthConfig.")
 		return annotations
 	}
 	for k, v := range rawAnnotations {
 		if stringValue, ok := v.(string); ok {
 			annotations[k] = stringValue
 		} else {
-			logrus.Infof("Failed to decode the annotation value of the key %q as a string (%v of type %T) on the AuthConfig.", k, v, v)
+			logger.Infof("Failed to decode the annotation value of the key %q as a string (%v of type %T) on the AuthConfig.", k, v, v)
 		}
 	}
 	return annotations
@@ -448,7 +450,7 @@ func parseAnnotations(metadata map[string]interface{}) map[string]string {
 func (ap *azureProvider) CanAccessWithGroupProviders(userPrincipalID string, groupPrincipals []v3.Principal) (bool, error) {
 	cfg, err := ap.getAzureConfigK8s()
 	if err != nil {
-		logrus.Errorf("Error fetching azure config: %v", err)
+		logger.Errorf("Error fetching azure config: %v", err)
 		return false, err
 	}
 	allowed, err := ap.userMGR.CheckAccess(cfg.AccessMode, cfg.AllowedPrincipalIDs, userPrincipalID, groupPrincipals)
@@ -469,11 +471,11 @@ func samePrincipal(me v3.Principal, other v3.Principal) bool {
 func UpdateGroupCacheSize(size string) {
 	i, err := strconv.Atoi(size)
 	if err != nil {
-		logrus.Errorf("error parsing azure-group-cache-size, skipping update %v", err)
+		logger.Errorf("error parsing azure-group-cache-size, skipping update %v", err)
 		return
 	}
 	if i < 0 {
-		logrus.Error("azure-group-cache-size must be >= 0, skipping update")
+		logger.Error("azure-group-cache-size must be >= 0, skipping update")
 		return
 	}
 	clients.GroupCache.Resize(i)
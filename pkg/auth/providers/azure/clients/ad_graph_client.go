@@ -9,7 +9,7 @@
 	"github.com/Azure/go-autorest/autorest/adal"
 	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -93,13 +93,13 @@ func (c *azureADGraphClient) LoginUser(_ *v32.AzureADConfig, _ *v32.AzureADLogin
 		return v3.Principal{}, nil, "", err
 	}
 
-	logrus.Debug("[AZURE_PROVIDER] Started getting user info from AzureAD")
+	logger.Debug("[AZURE_PROVIDER] Started getting user info from AzureAD")
 	userPrincipal, err := c.GetUser(oid.(string))
 	if err != nil {
 		return v3.Principal{}, nil, "", err
 	}
 	userPrincipal.Me = true
-	logrus.Debug("[AZURE_PROVIDER] Completed getting user info from AzureAD")
+	logger.Debug("[AZURE_PROVIDER] Completed getting user info from AzureAD")
 
 	userGroups, err := c.ListGroupMemberships(GetPrincipalID(userPrincipal))
 	if err != nil {
@@ -122,7 +122,7 @@ func (c *azureADGraphClient) LoginUser(_ *v32.AzureADConfig, _ *v32.AzureADLogin
 // NewADGraphClientFromCredential configures the SPT, user, and group clients using a credential.
 func NewADGraphClientFromCredential(config *v32.AzureADConfig, credential *v32.AzureADLogin) (AzureClient, error) {
 	var c azureADGraphClient
-	logrus.Debug("[AZURE_PROVIDER] Started token swap with AzureAD")
+	logger.Debug("[AZURE_PROVIDER] Started token swap with AzureAD")
 	oauthConfig, err := adal.NewOAuthConfig(config.Endpoint, config.TenantID)
 	if err != nil {
 		return nil, err
@@ -150,7 +150,7 @@ func NewADGraphClientFromCredential(config *v32.AzureADConfig, credential *v32.A
 	if err := spt.Refresh(); err != nil {
 		return nil, err
 	}
-	logrus.Debug("[AZURE_PROVIDER] Completed token swap with AzureAD")
+	logger.Debug("[AZURE_PROVIDER] Completed token swap with AzureAD")
 
 	c.setInternalFields(config, *spt)
 	return &c, err
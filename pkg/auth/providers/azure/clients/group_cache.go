@@ -5,7 +5,7 @@
 
 	lru "github.com/hashicorp/golang-lru"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -19,7 +19,7 @@ func UserGroupsToPrincipals(azureClient AzureClient, groupNames []string) ([]v3.
 	groupPrincipals := make([]v3.Principal, len(groupNames))
 
 	start := time.Now()
-	logrus.Debug("[AZURE_PROVIDER] Started gathering users groups")
+	logger.Debug("[AZURE_PROVIDER] Started gathering users groups")
 
 	for i, id := range groupNames {
 		if id == "" {
@@ -32,7 +32,7 @@ func UserGroupsToPrincipals(azureClient AzureClient, groupNames []string) ([]v3.
 		if principal, ok := GroupCache.Get(groupID); ok {
 			p, ok := principal.(v3.Principal)
 			if !ok {
-				logrus.Errorf("failed to convert a cached group to principal")
+				logger.Errorf("failed to convert a cached group to principal")
 				continue
 			}
 			groupPrincipals[j] = p
@@ -47,7 +47,7 @@ func UserGroupsToPrincipals(azureClient AzureClient, groupNames []string) ([]v3.
 			// So Microsoft Graph groups are effectively fetched twice. But this happens only once - before the groups are added to the cache.
 			groupObj, err := azureClient.GetGroup(groupID)
 			if err != nil {
-				logrus.Errorf("[AZURE_PROVIDER] Error getting group: %v", err)
+				logger.Errorf("[AZURE_PROVIDER] Error getting group: %v", err)
 				return err
 			}
 			groupObj.MemberOf = true
@@ -60,6 +60,6 @@ func UserGroupsToPrincipals(azureClient AzureClient, groupNames []string) ([]v3.
 	if err := tasksManager.Wait(); err != nil {
 		return nil, err
 	}
-	logrus.Debugf("[AZURE_PROVIDER] Completed gathering users groups, took %v, keys in cache:%v", time.Since(start), GroupCache.Len())
+	logger.Debugf("[AZURE_PROVIDER] Completed gathering users groups, took %v, keys in cache:%v", time.Since(start), GroupCache.Len())
 	return groupPrincipals, nil
 }
@@ -15,11 +15,13 @@
 	"github.com/rancher/rancher/pkg/auth/providers/common"
 	corev1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 	"golang.org/x/oauth2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+var logger = logging.Logger(logging.Auth)
+
 const (
 	// AccessTokenSecretName is the name of the secret that contains an access token for the Microsoft Graph API.
 	AccessTokenSecretName = "azuread-access-token"
@@ -110,22 +112,22 @@ func (c azureMSGraphClient) ListGroupMemberships(id string) ([]string, error) {
 // LoginUser verifies the user and fetches the user principal, user's group principals. It deliberately does not return
 // the provider access token because the client itself handles its caching and does not need to return it.
 func (c azureMSGraphClient) LoginUser(config *v32.AzureADConfig, credential *v32.AzureADLogin) (v3.Principal, []v3.Principal, string, error) {
-	logrus.Debugf("[%s] Started token swap with AzureAD", providerLogPrefix)
+	logger.Debugf("[%s] Started token swap with AzureAD", providerLogPrefix)
 
 	// Acquire the OID just to verify the user.
 	oid, err := oidFromAuthCode(credential.Code, config)
 	if err != nil {
 		return v3.Principal{}, nil, "", err
 	}
-	logrus.Debugf("[%s] Completed token swap with AzureAD", providerLogPrefix)
+	logger.Debugf("[%s] Completed token swap with AzureAD", providerLogPrefix)
 
-	logrus.Debugf("[%s] Started getting user info from AzureAD", providerLogPrefix)
+	logger.Debugf("[%s] Started getting user info from AzureAD", providerLogPrefix)
 	userPrincipal, err := c.GetUser(oid)
 	if err != nil {
 		return v3.Principal{}, nil, "", err
 	}
 	userPrincipal.Me = true
-	logrus.Debugf("[%s] Completed getting user info from AzureAD", providerLogPrefix)
+	logger.Debugf("[%s] Completed getting user info from AzureAD", providerLogPrefix)
 
 	userGroups, err := c.ListGroupMemberships(GetPrincipalID(userPrincipal))
 	if err != nil {
@@ -206,13 +208,13 @@ func (c AccessTokenCache) Replace(cache cache.Unmarshaler, key string) {
 	secretName := fmt.Sprintf("%s:%s", common.SecretsNamespace, AccessTokenSecretName)
 	secret, err := common.ReadFromSecret(c.Secrets, secretName, "access-token")
 	if err != nil {
-		logrus.Errorf("[%s] failed to read the access token from Kubernetes: %v", cacheLogPrefix, err)
+		logger.Errorf("[%s] failed to read the access token from Kubernetes: %v", cacheLogPrefix, err)
 		return
 	}
 
 	err = cache.Unmarshal([]byte(secret))
 	if err != nil {
-		logrus.Errorf("[%s] failed to unmarshal the access token: %v", cacheLogPrefix, err)
+		logger.Errorf("[%s] failed to unmarshal the access token: %v", cacheLogPrefix, err)
 	}
 }
 
@@ -220,13 +222,13 @@ func (c AccessTokenCache) Replace(cache cache.Unmarshaler, key string) {
 func (c AccessTokenCache) Export(cache cache.Marshaler, key string) {
 	marshalled, err := cache.Marshal()
 	if err != nil {
-		logrus.Errorf("[%s] failed to marshal the access token before saving in Kubernetes: %v", cacheLogPrefix, err)
+		logger.Errorf("[%s] failed to marshal the access token before saving in Kubernetes: %v", cacheLogPrefix, err)
 		return
 	}
 
 	err = common.CreateOrUpdateSecrets(c.Secrets, string(marshalled), "access-token", "azuread")
 	if err != nil {
-		logrus.Errorf("[%s] failed to save the access token in Kubernetes: %v", cacheLogPrefix, err)
+		logger.Errorf("[%s] failed to save the access token in Kubernetes: %v", cacheLogPrefix, err)
 	}
 }
 
@@ -252,8 +254,8 @@ func NewMSGraphClient(config *v32.AzureADConfig, secrets corev1.SecretInterface)
 	var ar confidential.AuthResult
 	ar, err = confidentialClientApp.AcquireTokenSilent(context.Background(), []string{scope})
 	if err != nil {
-		logrus.Infof("failed to get the access token from cache: %v", err)
-		logrus.Infoln("attempting to acquire the access token by credential")
+		logger.Infof("failed to get the access token from cache: %v", err)
+		logger.Infoln("attempting to acquire the access token by credential")
 		ar, err = confidentialClientApp.AcquireTokenByCredential(context.Background(), []string{scope})
 		if err != nil {
 			return nil, err
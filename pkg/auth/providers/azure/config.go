@@ -5,7 +5,7 @@
 	"strings"
 
 	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 )
 
 const (
@@ -53,7 +53,7 @@ func isConfigForChina(c *v32.AzureADConfig) bool {
 
 func updateEndpointsForGlobal(c *v32.AzureADConfig) {
 	if c.GraphEndpoint != globalAzureADGraphEndpoint {
-		logrus.Infof("Refusing to upgrade because the Graph Endpoint %s is not deprecated.", c.GraphEndpoint)
+		logger.Infof("Refusing to upgrade because the Graph Endpoint %s is not deprecated.", c.GraphEndpoint)
 		return
 	}
 	// Update the Graph Endpoint.
@@ -65,7 +65,7 @@ func updateEndpointsForGlobal(c *v32.AzureADConfig) {
 
 func updateEndpointsForChina(c *v32.AzureADConfig) {
 	if c.GraphEndpoint != chinaAzureADGraphEndpoint {
-		logrus.Infof("Refusing to upgrade because the Graph Endpoint %s is not deprecated.", c.GraphEndpoint)
+		logger.Infof("Refusing to upgrade because the Graph Endpoint %s is not deprecated.", c.GraphEndpoint)
 		return
 	}
 	// Update the Graph Endpoint.
@@ -5,7 +5,7 @@
 	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
 	"github.com/rancher/rancher/pkg/auth/api/secrets"
 	"github.com/rancher/rancher/pkg/auth/providers/azure/clients"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 )
 
 // migrateToMicrosoftGraph performs a migration of the registered Azure AD auth provider
@@ -59,6 +59,6 @@ func (ap *azureProvider) applyUpdatedConfig(cfg *v32.AzureADConfig) error {
 
 func (ap *azureProvider) deleteUserAccessTokens() {
 	if err := secrets.CleanupOAuthTokens(ap.secrets, ap.GetName()); err != nil {
-		logrus.Errorf("error during OAuth secrets clean up on Azure AD endpoint update: %v", err)
+		logger.Errorf("error during OAuth secrets clean up on Azure AD endpoint update: %v", err)
 	}
 }
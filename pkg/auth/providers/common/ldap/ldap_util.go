@@ -13,10 +13,12 @@
 	"github.com/rancher/norman/httperror"
 	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+var logger = logging.Logger(logging.Auth)
+
 type ConfigAttributes struct {
 	GroupMemberMappingAttribute string
 	GroupNameAttribute          string
@@ -34,7 +36,7 @@ func Connect(config *v3.LdapConfig, caPool *x509.CertPool) (*ldapv3.Conn, error)
 }
 
 func NewLDAPConn(servers []string, TLS, startTLS bool, port int64, connectionTimeout int64, caPool *x509.CertPool) (*ldapv3.Conn, error) {
-	logrus.Debug("Now creating Ldap connection")
+	logger.Debug("Now creating Ldap connection")
 	var lConn *ldapv3.Conn
 	var err error
 	var tlsConfig *tls.Config
@@ -92,7 +94,7 @@ func HasPermission(attributes []*ldapv3.EntryAttribute, userObjectClass string,
 				if len(attr.Values) > 0 && attr.Values[0] != "" {
 					intAttr, err := strconv.ParseInt(attr.Values[0], 10, 64)
 					if err != nil {
-						logrus.Errorf("Failed to get USER_ENABLED_ATTRIBUTE, error: %v", err)
+						logger.Errorf("Failed to get USER_ENABLED_ATTRIBUTE, error: %v", err)
 						return false
 					}
 					permission = intAttr
@@ -111,13 +113,13 @@ func IsType(search []*ldapv3.EntryAttribute, varType string) bool {
 		if strings.EqualFold(attrib.Name, "objectClass") {
 			for _, val := range attrib.Values {
 				if strings.EqualFold(val, varType) {
-					logrus.Debugf("ldap IsType found object of type %s", varType)
+					logger.Debugf("ldap IsType found object of type %s", varType)
 					return true
 				}
 			}
 		}
 	}
-	logrus.Debugf("ldap IsType failed to determine if object is type: %s", varType)
+	logger.Debugf("ldap IsType failed to determine if object is type: %s", varType)
 	return false
 }
 
@@ -172,7 +174,7 @@ func GetGroupSearchAttributesForLDAP(ObjectClass string, config *v3.LdapConfig)
 }
 
 func AuthenticateServiceAccountUser(serviceAccountPassword string, serviceAccountUsername string, defaultLoginDomain string, lConn *ldapv3.Conn) error {
-	logrus.Debug("Binding service account username password")
+	logger.Debug("Binding service account username password")
 	if serviceAccountPassword == "" {
 		return httperror.NewAPIError(httperror.MissingRequired, "service account password not provided")
 	}
@@ -277,7 +279,7 @@ func GatherParentGroups(groupPrincipal v3.Principal, searchDomain string, groupS
 		entry := resultGroups.Entries[i]
 		principal, err := AttributesToPrincipal(entry.Attributes, entry.DN, groupScope, config.ProviderName, config.UserObjectClass, config.UserNameAttribute, config.UserLoginAttribute, config.GroupObjectClass, config.GroupNameAttribute, "")
 		if err != nil {
-			logrus.Errorf("Error translating group result: %v", err)
+			logger.Errorf("Error translating group result: %v", err)
 			continue
 		}
 		principals = append(principals, *principal)
@@ -342,6 +344,6 @@ func ValidateLdapConfig(ldapConfig *v3.LdapConfig, certpool *x509.CertPool) (boo
 	}
 	defer lConn.Close()
 
-	logrus.Debugf("validated ldap configuration: %s", strings.Join(ldapConfig.Servers, ","))
+	logger.Debugf("validated ldap configuration: %s", strings.Join(ldapConfig.Servers, ","))
 	return true, nil
 }
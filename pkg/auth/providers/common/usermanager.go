@@ -18,11 +18,11 @@
 	tokenUtil "github.com/rancher/rancher/pkg/auth/tokens"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
 	rbacv1 "github.com/rancher/rancher/pkg/generated/norman/rbac.authorization.k8s.io/v1"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/settings"
 	"github.com/rancher/rancher/pkg/types/config"
 	"github.com/rancher/rancher/pkg/user"
 	"github.com/rancher/wrangler/pkg/randomtoken"
-	"github.com/sirupsen/logrus"
 	k8srbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -32,6 +32,8 @@
 	"k8s.io/client-go/tools/cache"
 )
 
+var logger = logging.Logger(logging.Auth)
+
 const (
 	userAuthHeader               = "Impersonate-User"
 	userByPrincipalIndex         = "auth.management.cattle.io/userByPrincipal"
@@ -152,7 +154,7 @@ func (m *userManager) SetPrincipalOnCurrentUserByUserID(userID string, principal
 	if conflict, err := m.GetUserByPrincipalID(principal.Name); err != nil {
 		return nil, err
 	} else if conflict != nil && conflict.UID != user.UID {
-		logrus.Errorf("refusing to set principal [%s] on user [%s], principal already in use on user [%s]", principal.Name, user.DisplayName, conflict.DisplayName)
+		logger.Errorf("refusing to set principal [%s] on user [%s], principal already in use on user [%s]", principal.Name, user.DisplayName, conflict.DisplayName)
 		return user, errors.New("refusing to set principal on user that is already bound to another user")
 	}
 
@@ -168,7 +170,7 @@ func (m *userManager) SetPrincipalOnCurrentUserByUserID(userID string, principal
 
 	if !slice.ContainsString(user.PrincipalIDs, principal.Name) {
 		user.PrincipalIDs = append(user.PrincipalIDs, principal.Name)
-		logrus.Infof("Updating user %v. Adding principal", user.Name)
+		logger.Infof("Updating user %v. Adding principal", user.Name)
 		return m.users.Update(user)
 	}
 	return user, nil
@@ -287,7 +289,7 @@ func (m *userManager) EnsureClusterToken(clusterName string, input user.TokenInp
 		return "", err
 	}
 
-	logrus.Infof("Creating token for user %v", input.UserName)
+	logger.Infof("Creating token for user %v", input.UserName)
 	err = wait.ExponentialBackoff(backoff, func() (bool, error) {
 		// Backoff was added here because it is possible the token is the process of deleting.
 		// This should cause the create to retry until the delete is finished.
@@ -323,7 +325,7 @@ func (m *userManager) newTokenForKubeconfig(clusterName, tokenName, description,
 	}
 
 	ttlMilli := tokenTTL.Milliseconds()
-	logrus.Infof("Creating token for user %v", userName)
+	logger.Infof("Creating token for user %v", userName)
 	input := user.TokenInput{
 		TokenName:     tokenName,
 		Description:   description,
@@ -380,7 +382,7 @@ func (m *userManager) GetKubeconfigToken(clusterName, tokenName, description, ki
 
 				token, err = m.tokens.Update(tokenCopy)
 				if err != nil {
-					logrus.Debugf("getToken: updating token [%s] failed [%v]", randomizedTokenName, err)
+					logger.Debugf("getToken: updating token [%s] failed [%v]", randomizedTokenName, err)
 					if apierrors.IsConflict(err) {
 						return false, nil
 					}
@@ -395,7 +397,7 @@ func (m *userManager) GetKubeconfigToken(clusterName, tokenName, description, ki
 		}
 	}
 
-	logrus.Debugf("getToken: token %s expiresAt %s", token.Name, token.ExpiresAt)
+	logger.Debugf("getToken: token %s expiresAt %s", token.Name, token.ExpiresAt)
 	return token, createdTokenValue, nil
 }
 
@@ -433,7 +435,7 @@ func (m *userManager) EnsureUser(principalName, displayName string) (*v3.User, e
 		}
 	} else {
 		// User doesn't exist, create user
-		logrus.Infof("Creating user for principal %v", principalName)
+		logger.Infof("Creating user for principal %v", principalName)
 
 		// Create a hash of the principalName to use as the name for the user,
 		// this lets k8s tell us if there are duplicate users with the same name
@@ -468,7 +470,7 @@ func (m *userManager) EnsureUser(principalName, displayName string) (*v3.User, e
 		}
 	}
 
-	logrus.Infof("Creating globalRoleBindings for %v", user.Name)
+	logger.Infof("Creating globalRoleBindings for %v", user.Name)
 	err = m.createUsersBindings(user)
 	if err != nil {
 		return nil, err
@@ -11,10 +11,12 @@
 	"strings"
 
 	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/tomnomnom/linkheader"
 )
 
+var logger = logging.Logger(logging.Auth)
+
 const (
 	gheAPI                = "/api/v3"
 	githubAPI             = "https://api.github.com"
@@ -37,7 +39,7 @@ func (g *GClient) getAccessToken(code string, config *v32.GithubConfig) (string,
 
 	b, err := g.postToGithub(url, form)
 	if err != nil {
-		logrus.Errorf("Github getAccessToken: GET url %v received error from github, err: %v", url, err)
+		logger.Errorf("Github getAccessToken: GET url %v received error from github, err: %v", url, err)
 		return "", err
 	}
 
@@ -45,13 +47,13 @@ func (g *GClient) getAccessToken(code string, config *v32.GithubConfig) (string,
 	var respMap map[string]interface{}
 
 	if err := json.Unmarshal(b, &respMap); err != nil {
-		logrus.Errorf("Github getAccessToken: received error unmarshalling response body, err: %v", err)
+		logger.Errorf("Github getAccessToken: received error unmarshalling response body, err: %v", err)
 		return "", err
 	}
 
 	if respMap["error"] != nil {
 		desc := respMap["error_description"]
-		logrus.Errorf("Received Error from github %v, description from github %v", respMap["error"], desc)
+		logger.Errorf("Received Error from github %v, description from github %v", respMap["error"], desc)
 		return "", fmt.Errorf("Received Error from github %v, description from github %v", respMap["error"], desc)
 	}
 
@@ -67,13 +69,13 @@ func (g *GClient) getUser(githubAccessToken string, config *v32.GithubConfig) (A
 	url := g.getURL("USER_INFO", config)
 	b, _, err := g.getFromGithub(githubAccessToken, url)
 	if err != nil {
-		logrus.Errorf("Github getGithubUser: GET url %v received error from github, err: %v", url, err)
+		logger.Errorf("Github getGithubUser: GET url %v received error from github, err: %v", url, err)
 		return Account{}, err
 	}
 	var githubAcct Account
 
 	if err := json.Unmarshal(b, &githubAcct); err != nil {
-		logrus.Errorf("Github getGithubUser: error unmarshalling response, err: %v", err)
+		logger.Errorf("Github getGithubUser: error unmarshalling response, err: %v", err)
 		return Account{}, err
 	}
 
@@ -86,14 +88,14 @@ func (g *GClient) getOrgs(githubAccessToken string, config *v32.GithubConfig) ([
 	url := g.getURL("ORG_INFO", config)
 	responses, err := g.paginateGithub(githubAccessToken, url)
 	if err != nil {
-		logrus.Errorf("Github getGithubOrgs: GET url %v received error from github, err: %v", url, err)
+		logger.Errorf("Github getGithubOrgs: GET url %v received error from github, err: %v", url, err)
 		return orgs, err
 	}
 
 	for _, b := range responses {
 		var orgObjs []Account
 		if err := json.Unmarshal(b, &orgObjs); err != nil {
-			logrus.Errorf("Github getGithubOrgs: received error unmarshalling org array, err: %v", err)
+			logger.Errorf("Github getGithubOrgs: received error unmarshalling org array, err: %v", err)
 			return nil, err
 		}
 		orgs = append(orgs, orgObjs...)
@@ -108,14 +110,14 @@ func (g *GClient) getTeams(githubAccessToken string, config *v32.GithubConfig) (
 	url := g.getURL("TEAMS", config)
 	responses, err := g.paginateGithub(githubAccessToken, url)
 	if err != nil {
-		logrus.Errorf("Github getGithubTeams: GET url %v received error from github, err: %v", url, err)
+		logger.Errorf("Github getGithubTeams: GET url %v received error from github, err: %v", url, err)
 		return teams, err
 	}
 	for _, response := range responses {
 		teamObjs, err := g.getTeamInfo(response, config)
 
 		if err != nil {
-			logrus.Errorf("Github getGithubTeams: received error unmarshalling teams array, err: %v", err)
+			logger.Errorf("Github getGithubTeams: received error unmarshalling teams array, err: %v", err)
 			return teams, err
 		}
 		for _, teamObj := range teamObjs {
@@ -130,7 +132,7 @@ func (g *GClient) getTeamInfo(b []byte, config *v32.GithubConfig) ([]Account, er
 	var teams []Account
 	var teamObjs []Team
 	if err := json.Unmarshal(b, &teamObjs); err != nil {
-		logrus.Errorf("Github getTeamInfo: received error unmarshalling team array, err: %v", err)
+		logger.Errorf("Github getTeamInfo: received error unmarshalling team array, err: %v", err)
 		return teams, err
 	}
 
@@ -150,12 +152,12 @@ func (g *GClient) getTeamByID(id string, githubAccessToken string, config *v32.G
 	url := g.getURL("TEAM", config) + id
 	b, _, err := g.getFromGithub(githubAccessToken, url)
 	if err != nil {
-		logrus.Errorf("Github getTeamByID: GET url %v received error from github, err: %v", url, err)
+		logger.Errorf("Github getTeamByID: GET url %v received error from github, err: %v", url, err)
 		return teamAcct, err
 	}
 	var teamObj Team
 	if err := json.Unmarshal(b, &teamObj); err != nil {
-		logrus.Errorf("Github getTeamByID: received error unmarshalling team array, err: %v", err)
+		logger.Errorf("Github getTeamByID: received error unmarshalling team array, err: %v", err)
 		return teamAcct, err
 	}
 	url = g.getURL("TEAM_PROFILE", config)
@@ -227,12 +229,12 @@ func (g *GClient) getOrgByName(org string, githubAccessToken string, config *v32
 
 	b, _, err := g.getFromGithub(githubAccessToken, url)
 	if err != nil {
-		logrus.Debugf("Github getGithubOrgByName: GET url %v received error from github, err: %v", url, err)
+		logger.Debugf("Github getGithubOrgByName: GET url %v received error from github, err: %v", url, err)
 		return Account{}, err
 	}
 	var githubAcct Account
 	if err := json.Unmarshal(b, &githubAcct); err != nil {
-		logrus.Errorf("Github getGithubOrgByName: error unmarshalling response, err: %v", err)
+		logger.Errorf("Github getGithubOrgByName: error unmarshalling response, err: %v", err)
 		return Account{}, err
 	}
 
@@ -244,13 +246,13 @@ func (g *GClient) getUserOrgByID(id string, githubAccessToken string, config *v3
 
 	b, _, err := g.getFromGithub(githubAccessToken, url)
 	if err != nil {
-		logrus.Errorf("Github getUserOrgById: GET url %v received error from github, err: %v", url, err)
+		logger.Errorf("Github getUserOrgById: GET url %v received error from github, err: %v", url, err)
 		return Account{}, err
 	}
 	var githubAcct Account
 
 	if err := json.Unmarshal(b, &githubAcct); err != nil {
-		logrus.Errorf("Github getUserOrgById: error unmarshalling response, err: %v", err)
+		logger.Errorf("Github getUserOrgById: error unmarshalling response, err: %v", err)
 		return Account{}, err
 	}
 
@@ -261,7 +263,7 @@ func (g *GClient) getUserOrgByID(id string, githubAccessToken string, config *v3
 func URLEncoded(str string) string {
 	u, err := url.Parse(str)
 	if err != nil {
-		logrus.Errorf("Error encoding the url: %s, error: %v", str, err)
+		logger.Errorf("Error encoding the url: %s, error: %v", str, err)
 		return str
 	}
 	return u.String()
@@ -270,14 +272,14 @@ func URLEncoded(str string) string {
 func (g *GClient) postToGithub(url string, form url.Values) ([]byte, error) {
 	req, err := http.NewRequest("POST", url, strings.NewReader(form.Encode()))
 	if err != nil {
-		logrus.Error(err)
+		logger.Error(err)
 	}
 	req.PostForm = form
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Add("Accept", "application/json")
 	resp, err := g.httpClient.Do(req)
 	if err != nil {
-		logrus.Errorf("Received error from github: %v", err)
+		logger.Errorf("Received error from github: %v", err)
 		return nil, err
 	}
 
@@ -305,7 +307,7 @@ func (g *GClient) getFromGithub(githubAccessToken string, url string) ([]byte, s
 	req.Header.Add("user-agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_10_5) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/51.0.2704.103 Safari/537.36)")
 	resp, err := g.httpClient.Do(req)
 	if err != nil {
-		logrus.Errorf("Received error from github: %v", err)
+		logger.Errorf("Received error from github: %v", err)
 		return nil, "", err
 	}
 	defer resp.Body.Close()
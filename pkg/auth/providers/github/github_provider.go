@@ -20,9 +20,9 @@
 	publicclient "github.com/rancher/rancher/pkg/client/generated/management/v3public"
 	corev1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/types/config"
 	"github.com/rancher/rancher/pkg/user"
-	"github.com/sirupsen/logrus"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -187,7 +187,7 @@ func (g *ghProvider) LoginUser(host string, githubCredential *v32.GithubLogin, c
 
 	accessToken, err := g.githubClient.getAccessToken(securityCode, config)
 	if err != nil {
-		logrus.Infof("Error generating accessToken from github %v", err)
+		logger.Infof("Error generating accessToken from github %v", err)
 		return v3.Principal{}, nil, "", err
 	}
 
@@ -288,7 +288,7 @@ func (g *ghProvider) SearchPrincipals(searchKey, principalType string, token v3.
 
 	accts, err := g.githubClient.searchUsers(searchKey, principalType, accessToken, config)
 	if err != nil {
-		logrus.Errorf("problem searching github: %v", err)
+		logger.Errorf("problem searching github: %v", err)
 	}
 
 	for _, acct := range accts {
@@ -398,7 +398,7 @@ func (g *ghProvider) isThisUserMe(me v3.Principal, other v3.Principal) bool {
 func (g *ghProvider) CanAccessWithGroupProviders(userPrincipalID string, groupPrincipals []v3.Principal) (bool, error) {
 	config, err := g.getGithubConfigCR()
 	if err != nil {
-		logrus.Errorf("Error fetching github config: %v", err)
+		logger.Errorf("Error fetching github config: %v", err)
 		return false, err
 	}
 	allowed, err := g.userMGR.CheckAccess(config.AccessMode, config.AllowedPrincipalIDs, userPrincipalID, groupPrincipals)
@@ -9,7 +9,7 @@
 	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
 
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	admin "google.golang.org/api/admin/directory/v1"
@@ -31,7 +31,7 @@ func (g *googleOauthProvider) getUserInfoAndGroups(adminSvc *admin.Service, gOAu
 	}
 	userPrincipal = g.toPrincipal(userType, *user, nil)
 	userPrincipal.Me = true
-	logrus.Debugf("[Google OAuth] loginuser: Obtained userinfo using oauth access token")
+	logger.Debugf("[Google OAuth] loginuser: Obtained userinfo using oauth access token")
 
 	groupPrincipals, err = g.getGroupsUserBelongsTo(adminSvc, user.SubjectUniqueID, user.HostedDomain, config)
 	if err != nil {
@@ -55,7 +55,7 @@ func (g *googleOauthProvider) getUserInfoAndGroups(adminSvc *admin.Service, gOAu
 		}
 	}
 
-	logrus.Debugf("[Google OAuth] loginuser: Retrieved user's groups using admin directory")
+	logger.Debugf("[Google OAuth] loginuser: Retrieved user's groups using admin directory")
 	return userPrincipal, groupPrincipals, nil
 }
 
@@ -19,9 +19,9 @@
 	publicclient "github.com/rancher/rancher/pkg/client/generated/management/v3public"
 	corev1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/types/config"
 	"github.com/rancher/rancher/pkg/user"
-	"github.com/sirupsen/logrus"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	admin "google.golang.org/api/admin/directory/v1"
@@ -32,6 +32,8 @@
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+var logger = logging.Logger(logging.Auth)
+
 const (
 	Name                 = "googleoauth"
 	userType             = "user"
@@ -88,7 +90,7 @@ func (g *googleOauthProvider) loginUser(c context.Context, googleOAuthCredential
 		}
 	}
 
-	logrus.Debugf("[Google OAuth] loginuser: Using code to get oauth token")
+	logger.Debugf("[Google OAuth] loginuser: Using code to get oauth token")
 	securityCode := googleOAuthCredential.Code
 	oauth2Config, err := google.ConfigFromJSON([]byte(config.OauthCredential), scopes...)
 	if err != nil {
@@ -99,7 +101,7 @@ func (g *googleOauthProvider) loginUser(c context.Context, googleOAuthCredential
 	if err != nil {
 		return userPrincipal, groupPrincipals, "", err
 	}
-	logrus.Debugf("[Google OAuth] loginuser: Exchanged code for oauth token")
+	logger.Debugf("[Google OAuth] loginuser: Exchanged code for oauth token")
 
 	// init the admin directory service
 	adminSvc, err := g.getDirectoryService(c, config.AdminEmail, []byte(config.ServiceAccountCredential), oauth2Config.TokenSource(c, gOAuthToken))
@@ -111,7 +113,7 @@ func (g *googleOauthProvider) loginUser(c context.Context, googleOAuthCredential
 		return userPrincipal, groupPrincipals, "", err
 	}
 
-	logrus.Debugf("[Google OAuth] loginuser: Checking user's access to Rancher")
+	logger.Debugf("[Google OAuth] loginuser: Checking user's access to Rancher")
 	allowed, err := g.userMGR.CheckAccess(config.AccessMode, config.AllowedPrincipalIDs, userPrincipal.Name, groupPrincipals)
 	if err != nil {
 		return userPrincipal, groupPrincipals, "", err
@@ -127,7 +129,7 @@ func (g *googleOauthProvider) loginUser(c context.Context, googleOAuthCredential
 		return userPrincipal, groupPrincipals, "", err
 	}
 
-	logrus.Debugf("[Google OAuth] loginuser: Returning principals and marshaled oauth token")
+	logger.Debugf("[Google OAuth] loginuser: Returning principals and marshaled oauth token")
 	return userPrincipal, groupPrincipals, string(oauthToken), nil
 }
 
@@ -144,13 +146,13 @@ func (g *googleOauthProvider) SearchPrincipals(searchKey, principalType string,
 	if err != nil && !apierrors.IsNotFound(err) {
 		return nil, err
 	}
-	logrus.Debugf("[Google OAuth] SearchPrincipals: Retrieved stored oauth token")
+	logger.Debugf("[Google OAuth] SearchPrincipals: Retrieved stored oauth token")
 	adminSvc, err := g.getdirectoryServiceFromStoredToken(storedOauthToken, config)
 	if err != nil {
 		return principals, err
 	}
 
-	logrus.Debugf("[Google OAuth] SearchPrincipals: Initialized dir svc with stored oauth token")
+	logger.Debugf("[Google OAuth] SearchPrincipals: Initialized dir svc with stored oauth token")
 	accounts, err := g.searchPrincipals(adminSvc, searchKey, principalType, config)
 	if err != nil {
 		return principals, err
@@ -158,7 +160,7 @@ func (g *googleOauthProvider) SearchPrincipals(searchKey, principalType string,
 	for _, acc := range accounts {
 		principals = append(principals, g.toPrincipal(acc.Type, acc, &token))
 	}
-	logrus.Debugf("[Google OAuth] SearchPrincipals: Returning principals")
+	logger.Debugf("[Google OAuth] SearchPrincipals: Returning principals")
 	return principals, nil
 }
 
@@ -174,18 +176,18 @@ func (g *googleOauthProvider) GetPrincipal(principalID string, token v3.Token) (
 			return principal, err
 		}
 	}
-	logrus.Debugf("[Google OAuth] GetPrincipal: Retrieved stored oauth token")
+	logger.Debugf("[Google OAuth] GetPrincipal: Retrieved stored oauth token")
 	adminSvc, err := g.getdirectoryServiceFromStoredToken(storedOauthToken, config)
 	if err != nil {
 		return principal, err
 	}
 
-	logrus.Debugf("[Google OAuth] GetPrincipal: Initialized dir svc with stored oauth token")
+	logger.Debugf("[Google OAuth] GetPrincipal: Initialized dir svc with stored oauth token")
 	externalID, principalType, err := getUIDFromPrincipalID(principalID)
 	if err != nil {
 		return principal, err
 	}
-	logrus.Debugf("[Google OAuth] GetPrincipal: Parsed principalID")
+	logger.Debugf("[Google OAuth] GetPrincipal: Parsed principalID")
 	switch principalType {
 	case userType:
 		user, err := adminSvc.Users.Get(externalID).Do()
@@ -263,12 +265,12 @@ func (g *googleOauthProvider) RefetchGroupPrincipals(principalID string, secret
 	if err != nil {
 		return principals, err
 	}
-	logrus.Debugf("[Google OAuth] RefetchGroupPrincipals: Initialized dir svc with stored oauth token")
+	logger.Debugf("[Google OAuth] RefetchGroupPrincipals: Initialized dir svc with stored oauth token")
 	externalID, _, err := getUIDFromPrincipalID(principalID)
 	if err != nil {
 		return principals, err
 	}
-	logrus.Debugf("[Google OAuth] GetPrincipal: Parsed principalID")
+	logger.Debugf("[Google OAuth] GetPrincipal: Parsed principalID")
 	groupPrincipals, err := g.getGroupsUserBelongsTo(adminSvc, externalID, config.Hostname, config)
 	if err != nil {
 		return principals, err
@@ -282,7 +284,7 @@ func (g *googleOauthProvider) RefetchGroupPrincipals(principalID string, secret
 func (g *googleOauthProvider) CanAccessWithGroupProviders(userPrincipalID string, groupPrincipals []v3.Principal) (bool, error) {
 	config, err := g.getGoogleOAuthConfigCR()
 	if err != nil {
-		logrus.Errorf("Error fetching google OAuth config: %v", err)
+		logger.Errorf("Error fetching google OAuth config: %v", err)
 		return false, err
 	}
 	allowed, err := g.userMGR.CheckAccess(config.AccessMode, config.AllowedPrincipalIDs, userPrincipalID, groupPrincipals)
@@ -407,13 +409,13 @@ func (g *googleOauthProvider) getDirectoryService(ctx context.Context, userEmail
 		// using JWTConfigFromJSON method
 		config, err := google.JWTConfigFromJSON(jsonCredentials, admin.AdminDirectoryUserReadonlyScope, admin.AdminDirectoryGroupReadonlyScope)
 		if err != nil {
-			logrus.Errorf("[Google OAuth] error unmarshaling service account creds: %v", err)
+			logger.Errorf("[Google OAuth] error unmarshaling service account creds: %v", err)
 			return nil, fmt.Errorf("invalid Service Account Credentials provided")
 		}
 		config.Subject = userEmail
 		srv, err := admin.NewService(ctx, option.WithTokenSource(config.TokenSource(ctx)))
 		if err != nil {
-			logrus.Errorf("[Google OAuth] error generating tokenSource for service account creds: %v", err)
+			logger.Errorf("[Google OAuth] error generating tokenSource for service account creds: %v", err)
 			return nil, fmt.Errorf("invalid Service Account Credentials provided")
 		}
 		return srv, nil
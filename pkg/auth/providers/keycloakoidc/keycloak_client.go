@@ -11,9 +11,11 @@
 	"github.com/pkg/errors"
 	"github.com/rancher/norman/httperror"
 	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 )
 
+var logger = logging.Logger(logging.Auth)
+
 // account defines properties an account in keycloak has
 type account struct {
 	ID            string `json:"id,omitempty"`
@@ -51,11 +53,11 @@ func (k *KeyCloakClient) searchPrincipals(searchTerm, principalType string, conf
 
 		b, err := k.getFromKeyCloak(search)
 		if err != nil {
-			logrus.Errorf("[keycloak oidc] searchPrincipals: GET request failed. url: %s, err: %s", search, err)
+			logger.Errorf("[keycloak oidc] searchPrincipals: GET request failed. url: %s, err: %s", search, err)
 			return accounts, err
 		}
 		if err := json.Unmarshal(b, &userAccounts); err != nil {
-			logrus.Errorf("[keycloak oidc] searchPrincipals: received error unmarshalling search results, err: %v", err)
+			logger.Errorf("[keycloak oidc] searchPrincipals: received error unmarshalling search results, err: %v", err)
 			return accounts, err
 		}
 		for _, u := range userAccounts {
@@ -85,11 +87,11 @@ func (k *KeyCloakClient) groupSearch(searchTerm string, sURL string) ([]account,
 
 	b, err := k.getFromKeyCloak(search)
 	if err != nil {
-		logrus.Errorf("[keycloak oidc] groupSearch: GET request failed. url: %s, err: %s", search, err)
+		logger.Errorf("[keycloak oidc] groupSearch: GET request failed. url: %s, err: %s", search, err)
 		return accounts, err
 	}
 	if err = json.Unmarshal(b, &groups); err != nil {
-		logrus.Errorf("[keycloak oidc] groupSearch: received error unmarshalling search results, err: %v", err)
+		logger.Errorf("[keycloak oidc] groupSearch: received error unmarshalling search results, err: %v", err)
 		return accounts, err
 	}
 	for _, g := range groups {
@@ -142,11 +144,11 @@ func (k *KeyCloakClient) getFromKeyCloakByID(principalID, principalType string,
 		search := URLEncoded(searchURL)
 		b, err := k.getFromKeyCloak(search)
 		if err != nil {
-			logrus.Errorf("[keycloak oidc] getFromKeyCloakByID: GET request failed. url: %s, err: %s", search, err)
+			logger.Errorf("[keycloak oidc] getFromKeyCloakByID: GET request failed. url: %s, err: %s", search, err)
 			return searchResult, err
 		}
 		if err := json.Unmarshal(b, &searchResult); err != nil {
-			logrus.Errorf("[keycloak oidc] getFromKeyCloakByID: received error unmarshalling search results, err: %v", err)
+			logger.Errorf("[keycloak oidc] getFromKeyCloakByID: received error unmarshalling search results, err: %v", err)
 			return searchResult, err
 		}
 	} else {
@@ -180,7 +182,7 @@ func getSearchURL(issuer string) (string, error) {
 func URLEncoded(str string) string {
 	u, err := url.Parse(str)
 	if err != nil {
-		logrus.Errorf("[keycloak oidc] URLEncoded: Error encoding the url: %s, error: %v", str, err)
+		logger.Errorf("[keycloak oidc] URLEncoded: Error encoding the url: %s, error: %v", str, err)
 		return str
 	}
 	return u.String()
@@ -194,7 +196,7 @@ func (k *KeyCloakClient) getFromKeyCloak(url string) ([]byte, error) {
 	req.Header.Add("Accept", "application/json")
 	resp, err := k.httpClient.Do(req)
 	if err != nil {
-		logrus.Errorf("[keycloak oidc] getFromKeyCloak: received error from keycloak: %v", err)
+		logger.Errorf("[keycloak oidc] getFromKeyCloak: received error from keycloak: %v", err)
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -13,14 +13,16 @@
 	"github.com/rancher/norman/httperror"
 	"github.com/rancher/rancher/pkg/auth/providers/common/ldap"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+var logger = logging.Logger(logging.Auth)
+
 var operationalAttrList = []string{"1.1", "+", "*"}
 
 func (p *ldapProvider) loginUser(credential *v32.BasicLogin, config *v3.LdapConfig, caPool *x509.CertPool) (v3.Principal, []v3.Principal, error) {
-	logrus.Debug("Now generating Ldap token")
+	logger.Debug("Now generating Ldap token")
 
 	username := credential.Username
 	password := credential.Password
@@ -42,7 +44,7 @@ func (p *ldapProvider) loginUser(credential *v32.BasicLogin, config *v3.LdapConf
 		return v3.Principal{}, nil, err
 	}
 
-	logrus.Debug("Binding username password")
+	logger.Debug("Binding username password")
 
 	searchRequest := ldapv3.NewSearchRequest(config.UserSearchBase,
 		ldapv3.ScopeWholeSubtree, ldapv3.NeverDerefAliases, 0, 0, false,
@@ -113,17 +115,17 @@ func (p *ldapProvider) getPrincipalsFromSearchResult(result *ldapv3.SearchResult
 		return v3.Principal{}, nil, fmt.Errorf("Permission denied")
 	}
 
-	logrus.Debugf("getPrincipals: user attributes: %v ", userAttributes)
+	logger.Debugf("getPrincipals: user attributes: %v ", userAttributes)
 
 	userMemberAttribute := entry.GetAttributeValues(config.UserMemberAttribute)
 	if len(userMemberAttribute) == 0 {
 		userMemberAttribute = opResult.Entries[0].GetAttributeValues(config.UserMemberAttribute)
 	}
 
-	logrus.Debugf("SearchResult memberOf attribute {%s}", userMemberAttribute)
+	logger.Debugf("SearchResult memberOf attribute {%s}", userMemberAttribute)
 
 	if !ldap.IsType(userAttributes, config.UserObjectClass) {
-		logrus.Debugf("The objectClass %s was not found in the user attributes", config.UserObjectClass)
+		logger.Debugf("The objectClass %s was not found in the user attributes", config.UserObjectClass)
 		return v3.Principal{}, nil, nil
 	}
 
@@ -149,7 +151,7 @@ func (p *ldapProvider) getPrincipalsFromSearchResult(result *ldapv3.SearchResult
 			query += ")"
 			query = fmt.Sprintf("(&%v%v)", filter, query)
 			// Pulling user's groups
-			logrus.Debugf("Ldap: Query for pulling user's groups: %v", query)
+			logger.Debugf("Ldap: Query for pulling user's groups: %v", query)
 			userMemberGroupPrincipals, err := p.searchLdap(query, groupScope, config, lConn)
 			groupPrincipals = append(groupPrincipals, userMemberGroupPrincipals...)
 			if err != nil {
@@ -186,14 +188,14 @@ func (p *ldapProvider) getPrincipalsFromSearchResult(result *ldapv3.SearchResult
 		// So we run a separate query with the filer: (&(member=uid of user logging in)(objectclass=groupofnames))
 		// This returns all details of a user's groups that we need to create principals, but doesn't return nested membership,
 		// so we derive nested membership using the logic we have for openldap
-		logrus.Debugf("EntryDN attribute not returned, retrieving group membership using the member attribute")
+		logger.Debugf("EntryDN attribute not returned, retrieving group membership using the member attribute")
 		// didn't get the entrydn as expected, so use query with member attribute and manually gather nested group
 		query := fmt.Sprintf("(&(%v=%v)(%v=%v))", config.GroupMemberMappingAttribute, ldapv3.EscapeFilter(userDN), ObjectClass, config.GroupObjectClass)
 		groupPrincipals, err = p.searchLdap(query, groupScope, config, lConn)
 		if err != nil {
 			return userPrincipal, groupPrincipals, err
 		}
-		logrus.Debugf("Retrieved following groups using member attribute: %v", groupPrincipals)
+		logger.Debugf("Retrieved following groups using member attribute: %v", groupPrincipals)
 		freeipaNonEntrydnApproach = true
 	}
 	// Handle nestedgroups for openldap, filter operationalAttrList already handles nestedgroups for freeipa
@@ -254,7 +256,7 @@ func (p *ldapProvider) getPrincipal(distinguishedName string, scope string, conf
 	}
 
 	if !ldap.IsType(attribs, scope) && !p.permissionCheck(attribs, config) {
-		logrus.Errorf("Failed to get object %v", distinguishedName)
+		logger.Errorf("Failed to get object %v", distinguishedName)
 		return nil, nil
 	}
 
@@ -265,7 +267,7 @@ func (p *ldapProvider) getPrincipal(distinguishedName string, scope string, conf
 		filter = fmt.Sprintf("(%v=%v)", ObjectClass, config.GroupObjectClass)
 	}
 
-	logrus.Debugf("Query for getPrincipal(%v): %v", distinguishedName, filter)
+	logger.Debugf("Query for getPrincipal(%v): %v", distinguishedName, filter)
 
 	lConn, err := ldap.Connect(config, caPool)
 	if err != nil {
@@ -375,7 +377,7 @@ func (p *ldapProvider) searchUser(name string, config *v3.LdapConfig, lConn *lda
 	// The user search filter will be added as another and clause
 	// and is expected to follow ldap syntax and enclosed in parenthesis
 	query += srchAttrs + ")" + config.UserSearchFilter + ")"
-	logrus.Debugf("%s searchUser query: %s", p.providerName, query)
+	logger.Debugf("%s searchUser query: %s", p.providerName, query)
 	return p.searchLdap(query, p.userScope, config, lConn)
 }
 
@@ -387,7 +389,7 @@ func (p *ldapProvider) searchGroup(name string, config *v3.LdapConfig, lConn *ld
 	}
 
 	query := "(&(" + ObjectClass + "=" + config.GroupObjectClass + ")(" + fmt.Sprintf(searchFmt, name) + ")" + config.GroupSearchFilter + ")"
-	logrus.Debugf("%s searchGroup query: %s scope: %s", p.providerName, query, p.groupScope)
+	logger.Debugf("%s searchGroup query: %s scope: %s", p.providerName, query, p.groupScope)
 	return p.searchLdap(query, p.groupScope, config, lConn)
 }
 
@@ -17,9 +17,9 @@
 	client "github.com/rancher/rancher/pkg/client/generated/management/v3"
 	corev1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/types/config"
 	"github.com/rancher/rancher/pkg/user"
-	"github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -141,13 +141,13 @@ func (p *ldapProvider) SearchPrincipals(searchKey, principalType string, myToken
 		if IsNotConfigured(err) {
 			return principals, err
 		}
-		logrus.Warnf("ldap search principals failed to get ldap config: %s\n", err)
+		logger.Warnf("ldap search principals failed to get ldap config: %s\n", err)
 		return principals, nil
 	}
 
 	lConn, err := ldap.Connect(config, caPool)
 	if err != nil {
-		logrus.Warnf("ldap search principals failed to connect to ldap: %s\n", err)
+		logger.Warnf("ldap search principals failed to connect to ldap: %s\n", err)
 		return principals, nil
 	}
 	defer lConn.Close()
@@ -278,7 +278,7 @@ func (p *ldapProvider) getLDAPConfig() (*v3.LdapConfig, *x509.CertPool, error) {
 func (p *ldapProvider) CanAccessWithGroupProviders(userPrincipalID string, groupPrincipals []v3.Principal) (bool, error) {
 	config, _, err := p.getLDAPConfig()
 	if err != nil {
-		logrus.Errorf("Error fetching ldap config: %v", err)
+		logger.Errorf("Error fetching ldap config: %v", err)
 		return false, err
 	}
 	allowed, err := p.userMGR.CheckAccess(config.AccessMode, config.AllowedPrincipalIDs, userPrincipalID, groupPrincipals)
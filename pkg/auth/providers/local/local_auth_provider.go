@@ -3,6 +3,7 @@
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 
 	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
@@ -10,17 +11,21 @@
 	"github.com/pkg/errors"
 	"github.com/rancher/norman/httperror"
 	"github.com/rancher/norman/types"
+	"github.com/rancher/rancher/pkg/auth/mfa"
 	"github.com/rancher/rancher/pkg/auth/providers/common"
 	"github.com/rancher/rancher/pkg/auth/tokens"
+	"github.com/rancher/rancher/pkg/auth/util"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/types/config"
-	"github.com/sirupsen/logrus"
 	"golang.org/x/crypto/bcrypt"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/cache"
 )
 
+var logger = logging.Logger(logging.Auth)
+
 const (
 	Name                  = "local"
 	userNameIndex         = "authn.management.cattle.io/user-username-index"
@@ -37,6 +42,8 @@ type Provider struct {
 	gmIndexer    cache.Indexer
 	groupIndexer cache.Indexer
 	tokenMGR     *tokens.Manager
+	mfa          *mfa.Manager
+	throttle     *loginThrottle
 	invalidHash  []byte
 }
 
@@ -62,6 +69,8 @@ func Configure(ctx context.Context, mgmtCtx *config.ScaledContext, tokenMGR *tok
 		groupIndexer: gInformer.GetIndexer(),
 		userLister:   mgmtCtx.Management.Users("").Controller().Lister(),
 		tokenMGR:     tokenMGR,
+		mfa:          mgmtCtx.MFA,
+		throttle:     newLoginThrottle(),
 		invalidHash:  invalidHash,
 	}
 	return l
@@ -106,27 +115,31 @@ func (l *Provider) AuthenticateUser(ctx context.Context, input interface{}) (v3.
 		return v3.Principal{}, nil, "", httperror.NewAPIError(httperror.ServerError, "Unexpected input type")
 	}
 
-	username := localInput.Username
-	pwd := localInput.Password
-
-	authFailedError := httperror.NewAPIError(httperror.Unauthorized, "authentication failed")
-	user, err := l.getUser(username)
+	var source string
+	if req, ok := ctx.Value(util.RequestKey).(*http.Request); ok {
+		source = util.SourceIP(req)
+	}
+	user, err := l.verifyPassword(localInput.Username, localInput.Password, source)
 	if err != nil {
-		// If the user don't exist the password is evaluated
-		// to avoid user enumeration via timing attack (time based side-channel).
-		bcrypt.CompareHashAndPassword(l.invalidHash, []byte(pwd))
-		logrus.Debugf("Get User [%s] failed during Authentication: %v", username, err)
-		return v3.Principal{}, nil, "", authFailedError
+		return v3.Principal{}, nil, "", err
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(pwd)); err != nil {
-		logrus.Debugf("Authentication failed for User [%s]: %v", username, err)
-		return v3.Principal{}, nil, "", authFailedError
+	verified, err := l.mfa.Authenticate(user.Name, localInput)
+	if err == mfa.ErrAuthenticationFailed {
+		// wrong second factors count towards the lockout as wrong passwords do, so that codes can't be guessed either
+		l.throttle.failed(localInput.Username, source)
+		return v3.Principal{}, nil, "", err
+	} else if err != nil {
+		return v3.Principal{}, nil, "", err
 	}
+	l.throttle.succeeded(localInput.Username, source)
 
 	principalID := getLocalPrincipalID(user)
 	userPrincipal := l.toPrincipal("user", user.DisplayName, user.Username, principalID, nil)
 	userPrincipal.Me = true
+	if verified {
+		mfa.MarkVerified(&userPrincipal)
+	}
 
 	groupPrincipals, err := l.getGroupPrincipals(user)
 	if err != nil {
@@ -136,6 +149,43 @@ func (l *Provider) AuthenticateUser(ctx context.Context, input interface{}) (v3.
 	return userPrincipal, groupPrincipals, "", nil
 }
 
+// verifyPassword returns the user with the username if the password is theirs. Usernames whose password or second
+// factor was wrong too many times from the source are locked out from it for a while, whether they exist or not. The
+// failures are only forgotten once the second factor of the user is verified as well.
+func (l *Provider) verifyPassword(username, pwd, source string) (*v3.User, error) {
+	if err := l.throttle.allowed(username, source); err != nil {
+		return nil, err
+	}
+
+	authFailedError := httperror.NewAPIError(httperror.Unauthorized, "authentication failed")
+	user, err := l.getUser(username)
+	if err != nil {
+		// If the user don't exist the password is evaluated
+		// to avoid user enumeration via timing attack (time based side-channel).
+		bcrypt.CompareHashAndPassword(l.invalidHash, []byte(pwd))
+		logger.Debugf("Get User [%s] failed during Authentication: %v", username, err)
+		l.throttle.failed(username, source)
+		return nil, authFailedError
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(pwd)); err != nil {
+		logger.Debugf("Authentication failed for User [%s]: %v", username, err)
+		l.throttle.failed(username, source)
+		return nil, authFailedError
+	}
+	return user, nil
+}
+
+// MFAChallenge issues a challenge for the security keys of the user, once their password is verified, to log in with
+// one of them. The source is the IP of the client, see util.SourceIP.
+func (l *Provider) MFAChallenge(username, pwd, source string) (*mfa.AssertionOptions, error) {
+	user, err := l.verifyPassword(username, pwd, source)
+	if err != nil {
+		return nil, err
+	}
+	return l.mfa.NewAssertion(user.Name)
+}
+
 func getLocalPrincipalID(user *v3.User) string {
 	// TODO error condition handling: no principal, more than one that would match
 	var principalID string
@@ -168,7 +218,7 @@ func (l *Provider) getGroupPrincipals(user *v3.User) ([]v3.Principal, error) {
 			//find group for this member mapping
 			localGroup, err := l.groupLister.Get("", gm.GroupName)
 			if err != nil {
-				logrus.Errorf("Failed to get Group resource %v: %v", gm.GroupName, err)
+				logger.Errorf("Failed to get Group resource %v: %v", gm.GroupName, err)
 				continue
 			}
 
@@ -214,7 +264,7 @@ func (l *Provider) SearchPrincipalsDedupe(searchKey, principalType string, token
 	}
 
 	if err != nil {
-		logrus.Infof("Failed to search User/Group resources for %v: %v", searchKey, err)
+		logger.Infof("Failed to search User/Group resources for %v: %v", searchKey, err)
 		return principals, err
 	}
 
@@ -296,7 +346,7 @@ func (l *Provider) listAllUsersAndGroups(searchKey string) ([]*v3.User, []*v3.Gr
 
 	allUsers, err := l.userLister.List("", labels.NewSelector())
 	if err != nil {
-		logrus.Infof("Failed to search User resources for %v: %v", searchKey, err)
+		logger.Infof("Failed to search User resources for %v: %v", searchKey, err)
 		return localUsers, localGroups, err
 	}
 	for _, user := range allUsers {
@@ -308,7 +358,7 @@ func (l *Provider) listAllUsersAndGroups(searchKey string) ([]*v3.User, []*v3.Gr
 
 	allGroups, err := l.groupLister.List("", labels.NewSelector())
 	if err != nil {
-		logrus.Infof("Failed to search group resources for %v: %v", searchKey, err)
+		logger.Infof("Failed to search group resources for %v: %v", searchKey, err)
 		return localUsers, localGroups, err
 	}
 	for _, group := range allGroups {
@@ -327,14 +377,14 @@ func (l *Provider) listUsersAndGroupsByIndex(searchKey string) ([]*v3.User, []*v
 
 	objs, err := l.userIndexer.ByIndex(userSearchIndex, searchKey)
 	if err != nil {
-		logrus.Infof("Failed to search User resources for %v: %v", searchKey, err)
+		logger.Infof("Failed to search User resources for %v: %v", searchKey, err)
 		return localUsers, localGroups, err
 	}
 
 	for _, obj := range objs {
 		user, ok := obj.(*v3.User)
 		if !ok {
-			logrus.Errorf("User isnt a user %v", obj)
+			logger.Errorf("User isnt a user %v", obj)
 			return localUsers, localGroups, err
 		}
 		localUsers = append(localUsers, user)
@@ -342,14 +392,14 @@ func (l *Provider) listUsersAndGroupsByIndex(searchKey string) ([]*v3.User, []*v
 
 	groupObjs, err := l.groupIndexer.ByIndex(groupSearchIndex, searchKey)
 	if err != nil {
-		logrus.Infof("Failed to search Group resources for %v: %v", searchKey, err)
+		logger.Infof("Failed to search Group resources for %v: %v", searchKey, err)
 		return localUsers, localGroups, err
 	}
 
 	for _, obj := range groupObjs {
 		group, ok := obj.(*v3.Group)
 		if !ok {
-			logrus.Errorf("Object isnt a group %v", obj)
+			logger.Errorf("Object isnt a group %v", obj)
 			return localUsers, localGroups, err
 		}
 		localGroups = append(localGroups, group)
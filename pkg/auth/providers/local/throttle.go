@@ -0,0 +1,102 @@
+package local
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rancher/norman/httperror"
+)
+
+const (
+	// maxFailedLogins is how many times the password or second factor of a user can be wrong from a source within
+	// failedLoginWindow before their logins from it are no longer verified until the lockout ends.
+	maxFailedLogins = 5
+	// failedLoginWindow is how long a wrong password or second factor counts towards the lockout of a user.
+	failedLoginWindow = 5 * time.Minute
+	// loginLockout is how long the logins of a user from a source aren't verified once they failed too many times.
+	loginLockout = 5 * time.Minute
+)
+
+var errTooManyFailedLogins = httperror.NewAPIError(httperror.ErrorCode{Code: "TooManyRequests", Status: http.StatusTooManyRequests},
+	"too many failed logins, try again later")
+
+// loginThrottle locks out the usernames whose password or second factor was wrong too many times, so that neither the
+// login action nor the security key challenge of the local provider can be used to guess passwords or codes. Usernames
+// are only locked out from the sources of the failures, so that a user can't be locked out by others. The failures are
+// counted per rancher replica.
+type loginThrottle struct {
+	lock     sync.Mutex
+	failures map[string]*loginFailures
+	now      func() time.Time
+}
+
+type loginFailures struct {
+	count       int
+	first       time.Time
+	lockedUntil time.Time
+}
+
+func newLoginThrottle() *loginThrottle {
+	return &loginThrottle{
+		failures: map[string]*loginFailures{},
+		now:      time.Now,
+	}
+}
+
+// allowed returns an error if the username is locked out from the source.
+func (t *loginThrottle) allowed(username, source string) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	failures, ok := t.failures[throttleKey(username, source)]
+	if ok && t.now().Before(failures.lockedUntil) {
+		return errTooManyFailedLogins
+	}
+	return nil
+}
+
+// failed records a wrong password or second factor for the username from the source, locking it out from the source
+// once they were wrong too many times.
+func (t *loginThrottle) failed(username, source string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+	t.prune(now)
+
+	key := throttleKey(username, source)
+	failures, ok := t.failures[key]
+	if !ok {
+		failures = &loginFailures{first: now}
+		t.failures[key] = failures
+	}
+	failures.count++
+	if failures.count >= maxFailedLogins {
+		logger.Infof("Locking out logins of user [%s] from [%s] for %s after %d failed logins", username, source, loginLockout, failures.count)
+		failures.lockedUntil = now.Add(loginLockout)
+		failures.count = 0
+		failures.first = now
+	}
+}
+
+// succeeded forgets the failures of the username from the source.
+func (t *loginThrottle) succeeded(username, source string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.failures, throttleKey(username, source))
+}
+
+// prune forgets the failures outside of the window of usernames that aren't locked out.
+func (t *loginThrottle) prune(now time.Time) {
+	for key, failures := range t.failures {
+		if now.Sub(failures.first) > failedLoginWindow && !now.Before(failures.lockedUntil) {
+			delete(t.failures, key)
+		}
+	}
+}
+
+func throttleKey(username, source string) string {
+	return strings.ToLower(strings.TrimSpace(username)) + "/" + source
+}
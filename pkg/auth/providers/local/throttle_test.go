@@ -0,0 +1,60 @@
+package local
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginThrottle(t *testing.T) {
+	const source = "10.0.0.1"
+	now := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	throttle := newLoginThrottle()
+	throttle.now = func() time.Time { return now }
+
+	for i := 0; i < maxFailedLogins-1; i++ {
+		throttle.failed("admin", source)
+		assert.NoError(t, throttle.allowed("admin", source))
+	}
+	throttle.failed("Admin", source)
+	assert.Equal(t, errTooManyFailedLogins, throttle.allowed("admin", source), "usernames are locked out regardless of case")
+	assert.NoError(t, throttle.allowed("other", source))
+
+	now = now.Add(loginLockout)
+	assert.NoError(t, throttle.allowed("admin", source))
+}
+
+func TestLoginThrottleWindow(t *testing.T) {
+	const source = "10.0.0.1"
+	now := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+	throttle := newLoginThrottle()
+	throttle.now = func() time.Time { return now }
+
+	for i := 0; i < maxFailedLogins-1; i++ {
+		throttle.failed("admin", source)
+	}
+	now = now.Add(failedLoginWindow + time.Second)
+	throttle.failed("admin", source)
+	assert.NoError(t, throttle.allowed("admin", source), "failures outside of the window are forgotten")
+
+	for i := 0; i < maxFailedLogins-2; i++ {
+		throttle.failed("admin", source)
+	}
+	throttle.succeeded("admin", source)
+	throttle.failed("admin", source)
+	assert.NoError(t, throttle.allowed("admin", source), "a successful login forgets the failures")
+}
+
+func TestLoginThrottleSources(t *testing.T) {
+	throttle := newLoginThrottle()
+
+	for i := 0; i < maxFailedLogins; i++ {
+		throttle.failed("admin", "10.0.0.1")
+	}
+	assert.Equal(t, errTooManyFailedLogins, throttle.allowed("admin", "10.0.0.1"))
+	assert.NoError(t, throttle.allowed("admin", "10.0.0.2"), "usernames are only locked out from the sources of the failures")
+
+	throttle.succeeded("admin", "10.0.0.2")
+	assert.Equal(t, errTooManyFailedLogins, throttle.allowed("admin", "10.0.0.1"))
+}
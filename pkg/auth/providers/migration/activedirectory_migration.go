@@ -10,8 +10,8 @@
 	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
 	"github.com/rancher/rancher/pkg/auth/providers"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/types/config"
-	"github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -20,6 +20,8 @@
 	"k8s.io/client-go/tools/cache"
 )
 
+var logger = logging.Logger(logging.Auth)
+
 const (
 	crtbsByPrincipalAndUserIndex = "auth.management.cattle.io/crtbByPrincipalAndUser"
 	prtbsByPrincipalAndUserIndex = "auth.management.cattle.io/prtbByPrincipalAndUser"
@@ -57,7 +59,7 @@ func MigrateActiveDirectoryDNToGUID(ctx context.Context, management *config.Mana
 	go func(context.Context, context.CancelFunc) {
 		defer migrateCancel()
 		err := wait.PollImmediate(time.Hour*24, 0, func() (bool, error) {
-			logrus.Debugf("Starting active directory principalID migration with exponentialBackoff")
+			logger.Debugf("Starting active directory principalID migration with exponentialBackoff")
 			steps := 5
 			backOffDuration := time.Minute * 10
 			var err error
@@ -73,14 +75,14 @@ func MigrateActiveDirectoryDNToGUID(ctx context.Context, management *config.Mana
 			}
 			if err != nil {
 				// returning false & nil because PollImmediate terminates on error
-				logrus.Errorf("problem in migrating active directory user principalIds %v", err)
+				logger.Errorf("problem in migrating active directory user principalIds %v", err)
 				return false, nil
 			}
 			// no error returned, user cleanup done, calling the child context's cancelfunc to terminate child context
 			return true, nil
 		})
 		if err != nil {
-			logrus.Errorf("problem in migrating active directory user principalIds %v", err)
+			logger.Errorf("problem in migrating active directory user principalIds %v", err)
 			return
 		}
 	}(migrateCtx, migrateCancel)
@@ -157,7 +159,7 @@ func (m *adMigration) migrateTokens(userName string, newPrincipalID string) erro
 		token.UserPrincipal.Name = newPrincipalID
 		_, e := m.tokens.Update(token)
 		if e != nil {
-			logrus.Errorf("unable to update token %v for principalId %v", token.Name, newPrincipalID)
+			logger.Errorf("unable to update token %v for principalId %v", token.Name, newPrincipalID)
 		}
 	}
 	return nil
@@ -13,6 +13,7 @@
 	"github.com/rancher/norman/httperror"
 	"github.com/rancher/norman/types"
 	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/auth/mfa"
 	"github.com/rancher/rancher/pkg/auth/providers"
 	"github.com/rancher/rancher/pkg/auth/providers/activedirectory"
 	"github.com/rancher/rancher/pkg/auth/providers/azure"
@@ -31,15 +32,18 @@
 	"github.com/rancher/rancher/pkg/controllers/managementuser/clusterauthtoken/common"
 	v1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/logging"
 	schema "github.com/rancher/rancher/pkg/schemas/management.cattle.io/v3public"
+	"github.com/rancher/rancher/pkg/securityevents"
 	"github.com/rancher/rancher/pkg/types/config"
 	"github.com/rancher/rancher/pkg/user"
-	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 )
 
+var logger = logging.Logger(logging.Auth)
+
 const (
 	CookieName = "R_SESS"
 )
@@ -107,18 +111,18 @@ func (h *loginHandler) createLoginToken(request *types.APIContext) (v3.Token, st
 	var userPrincipal v3.Principal
 	var groupPrincipals []v3.Principal
 	var providerToken string
-	logrus.Debugf("Create Token Invoked")
+	logger.Debugf("Create Token Invoked")
 
 	bytes, err := ioutil.ReadAll(request.Request.Body)
 	if err != nil {
-		logrus.Errorf("login failed with error: %v", err)
+		logger.Errorf("login failed with error: %v", err)
 		return v3.Token{}, "", "", httperror.NewAPIError(httperror.InvalidBodyContent, "")
 	}
 
 	generic := &v32.GenericLogin{}
 	err = json.Unmarshal(bytes, generic)
 	if err != nil {
-		logrus.Errorf("unmarshal failed with error: %v", err)
+		logger.Errorf("unmarshal failed with error: %v", err)
 		return v3.Token{}, "", "", httperror.NewAPIError(httperror.InvalidBodyContent, "")
 	}
 	responseType := generic.ResponseType
@@ -181,7 +185,7 @@ func (h *loginHandler) createLoginToken(request *types.APIContext) (v3.Token, st
 
 	err = json.Unmarshal(bytes, input)
 	if err != nil {
-		logrus.Errorf("unmarshal failed with error: %v", err)
+		logger.Errorf("unmarshal failed with error: %v", err)
 		return v3.Token{}, "", "", httperror.NewAPIError(httperror.InvalidBodyContent, "")
 	}
 
@@ -198,6 +202,7 @@ func (h *loginHandler) createLoginToken(request *types.APIContext) (v3.Token, st
 	ctx := context.WithValue(request.Request.Context(), util.RequestKey, request.Request)
 	userPrincipal, groupPrincipals, providerToken, err = providers.AuthenticateUser(ctx, input, providerName)
 	if err != nil {
+		emitLoginFailed(request.Request, providerName, input, err)
 		return v3.Token{}, "", "", err
 	}
 
@@ -220,6 +225,14 @@ func (h *loginHandler) createLoginToken(request *types.APIContext) (v3.Token, st
 			return v3.Token{}, "", "", err
 		}
 
+		event := securityevents.NewEvent(securityevents.KubeconfigDownload, securityevents.OutcomeSuccess, request.Request)
+		event.User = currUser.Name
+		event.Resource = "tokens/" + token.Name
+		if token.ClusterName != "" {
+			event.Details = map[string]string{"cluster": token.ClusterName}
+		}
+		securityevents.Emit(event)
+
 		if err := h.createClusterAuthTokenIfNeeded(token, tokenValue); err != nil {
 			return v3.Token{}, "", "", httperror.NewAPIError(httperror.ServerError,
 				fmt.Sprintf("Failed to create cluster auth token for cluster [%s], cluster auth endpoint may fail: %v", token.ClusterName, err))
@@ -233,6 +246,22 @@ func (h *loginHandler) createLoginToken(request *types.APIContext) (v3.Token, st
 	return rToken, unhashedTokenKey, responseType, err
 }
 
+// emitLoginFailed emits a security event for a failed login. Logins of users with a second factor that didn't provide
+// it aren't failures, the second factor is requested.
+func emitLoginFailed(req *http.Request, providerName string, input interface{}, err error) {
+	if apiErr, ok := err.(*httperror.APIError); ok && apiErr.Code == mfa.ErrorCodeRequired {
+		return
+	}
+
+	event := securityevents.NewEvent(securityevents.LoginFailed, securityevents.OutcomeFailure, req)
+	if basicLogin, ok := input.(*v32.BasicLogin); ok {
+		event.User = basicLogin.Username
+	}
+	event.Resource = "authProviders/" + providerName
+	event.Message = err.Error()
+	securityevents.Emit(event)
+}
+
 // createClusterAuthTokenIfNeeded checks if local cluster auth endpoint is enabled. If it is, a cluster auth token
 // is created.
 func (h *loginHandler) createClusterAuthTokenIfNeeded(token *v3.Token, tokenValue string) error {
@@ -9,7 +9,7 @@
 	"github.com/rancher/norman/types"
 	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
 	"github.com/rancher/rancher/pkg/auth/providers/common"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 )
 
 func (s *Provider) formatter(apiContext *types.APIContext, resource *types.RawResource) {
@@ -47,7 +47,7 @@ func (s *Provider) testAndEnable(actionName string, action *types.Action, reques
 		return err
 	}
 
-	logrus.Debug("SAML [testAndEnable]: Initializing SAML service provider")
+	logger.Debug("SAML [testAndEnable]: Initializing SAML service provider")
 	err = InitializeSamlServiceProvider(samlConfig, s.name)
 	if err != nil {
 		return err
@@ -58,7 +58,7 @@ func (s *Provider) testAndEnable(actionName string, action *types.Action, reques
 		return fmt.Errorf("SAML [testAndEnable]: Provider %v not configured", s.name)
 	}
 
-	logrus.Debugf("SAML [testAndEnable]: Setting clientState for SAML service provider %v", s.name)
+	logger.Debugf("SAML [testAndEnable]: Setting clientState for SAML service provider %v", s.name)
 	finalRedirectURL := samlLogin.FinalRedirectURL
 	provider.clientState.SetState(request.Response, request.Request, "Rancher_UserID", provider.userMGR.GetUser(request))
 	provider.clientState.SetState(request.Response, request.Request, "Rancher_FinalRedirectURL", finalRedirectURL)
@@ -67,7 +67,7 @@ func (s *Provider) testAndEnable(actionName string, action *types.Action, reques
 	if err != nil {
 		return err
 	}
-	logrus.Debugf("SAML [testAndEnable]: Redirecting to the identity provider login page at %v", idpRedirectURL)
+	logger.Debugf("SAML [testAndEnable]: Redirecting to the identity provider login page at %v", idpRedirectURL)
 	data := map[string]interface{}{
 		"idpRedirectUrl": idpRedirectURL,
 		"type":           "samlConfigTestOutput",
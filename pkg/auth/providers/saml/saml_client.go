@@ -24,12 +24,14 @@
 	"github.com/rancher/rancher/pkg/auth/settings"
 	"github.com/rancher/rancher/pkg/auth/tokens"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/namespace"
-	log "github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+var logger = logging.Logger(logging.Auth)
+
 type IDPMetadata struct {
 	XMLName           xml.Name                `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
 	ValidUntil        time.Time               `xml:"validUntil,attr"`
@@ -58,7 +60,7 @@ func InitializeSamlServiceProvider(configToSet *v32.SamlConfig, name string) err
 	var err error
 	var ok bool
 
-	log.Debugf("SAML [InitializeSamlServiceProvider]: Validating input for provider %v", name)
+	logger.Debugf("SAML [InitializeSamlServiceProvider]: Validating input for provider %v", name)
 
 	if configToSet.IDPMetadataContent == "" {
 		return fmt.Errorf("SAML: Cannot initialize saml SP properly, missing IDP URL/metadata in the config %v", configToSet)
@@ -121,7 +123,7 @@ func InitializeSamlServiceProvider(configToSet *v32.SamlConfig, name string) err
 		return fmt.Errorf("SAML [InitializeSamlServiceProvider]: Provider %v not configured", name)
 	}
 
-	log.Debugf("SAML [InitializeSamlServiceProvider]: Initializing provider %v", name)
+	logger.Debugf("SAML [InitializeSamlServiceProvider]: Initializing provider %v", name)
 
 	rancherAPIHost := strings.TrimRight(configToSet.RancherAPIHost, "/")
 	samlURL := rancherAPIHost + "/v1-saml/"
@@ -297,14 +299,14 @@ func (s *Provider) HandleSamlAssertion(w http.ResponseWriter, r *http.Request, a
 
 	config, err := s.getSamlConfig()
 	if err != nil {
-		log.Errorf("SAML: Error getting saml config %v", err)
+		logger.Errorf("SAML: Error getting saml config %v", err)
 		http.Redirect(w, r, redirectURL+"errorCode=500", http.StatusFound)
 		return
 	}
 
 	userPrincipal, groupPrincipals, err = s.getSamlPrincipals(config, samlData)
 	if err != nil {
-		log.Error(err)
+		logger.Error(err)
 		// UI uses this translation key to get the error message
 		http.Redirect(w, r, redirectURL+"errorCode=422&errorMsg="+UITranslationKeyForErrorMessage, http.StatusFound)
 		return
@@ -313,12 +315,12 @@ func (s *Provider) HandleSamlAssertion(w http.ResponseWriter, r *http.Request, a
 
 	allowed, err := s.userMGR.CheckAccess(config.AccessMode, allowedPrincipals, userPrincipal.Name, groupPrincipals)
 	if err != nil {
-		log.Errorf("SAML: Error during login while checking access %v", err)
+		logger.Errorf("SAML: Error during login while checking access %v", err)
 		http.Redirect(w, r, redirectURL+"errorCode=500", http.StatusFound)
 		return
 	}
 	if !allowed {
-		log.Errorf("SAML: User [%s] is not an authorized user or is not a member of an authorized group", userPrincipal.Name)
+		logger.Errorf("SAML: User [%s] is not an authorized user or is not a member of an authorized group", userPrincipal.Name)
 		http.Redirect(w, r, redirectURL+"errorCode=403", http.StatusFound)
 		return
 	}
@@ -327,7 +329,7 @@ func (s *Provider) HandleSamlAssertion(w http.ResponseWriter, r *http.Request, a
 	if userID != "" && rancherAction == testAndEnableAction {
 		user, err := s.userMGR.SetPrincipalOnCurrentUserByUserID(userID, userPrincipal)
 		if err != nil && user == nil {
-			log.Errorf("SAML: Error setting principal on current user %v", err)
+			logger.Errorf("SAML: Error setting principal on current user %v", err)
 			http.Redirect(w, r, redirectURL+"errorCode=500", http.StatusFound)
 			return
 		} else if err != nil && user != nil {
@@ -338,7 +340,7 @@ func (s *Provider) HandleSamlAssertion(w http.ResponseWriter, r *http.Request, a
 		config.Enabled = true
 		err = s.saveSamlConfig(config)
 		if err != nil {
-			log.Errorf("SAML: Error saving saml config %v", err)
+			logger.Errorf("SAML: Error saving saml config %v", err)
 			http.Redirect(w, r, redirectURL+"errorCode=500", http.StatusFound)
 			return
 		}
@@ -349,7 +351,7 @@ func (s *Provider) HandleSamlAssertion(w http.ResponseWriter, r *http.Request, a
 		}
 		err = s.setRancherToken(w, r, s.tokenMGR, user.Name, userPrincipal, groupPrincipals, isSecure)
 		if err != nil {
-			log.Errorf("SAML: Failed creating token with error: %v", err)
+			logger.Errorf("SAML: Failed creating token with error: %v", err)
 			http.Redirect(w, r, redirectURL+"errorCode=500", http.StatusFound)
 		}
 		// delete the cookies
@@ -371,20 +373,20 @@ func (s *Provider) HandleSamlAssertion(w http.ResponseWriter, r *http.Request, a
 	}
 	user, err := s.userMGR.EnsureUser(userPrincipal.Name, displayName)
 	if err != nil {
-		log.Errorf("SAML: Failed getting user with error: %v", err)
+		logger.Errorf("SAML: Failed getting user with error: %v", err)
 		http.Redirect(w, r, redirectURL+"errorCode=500", http.StatusFound)
 		return
 	}
 
 	if user.Enabled != nil && !*user.Enabled {
-		log.Errorf("SAML: User %v permission denied", user.Name)
+		logger.Errorf("SAML: User %v permission denied", user.Name)
 		http.Redirect(w, r, redirectURL+"errorCode=403", http.StatusFound)
 		return
 	}
 
 	err = s.setRancherToken(w, r, s.tokenMGR, user.Name, userPrincipal, groupPrincipals, true)
 	if err != nil {
-		log.Errorf("SAML: Failed creating token with error: %v", err)
+		logger.Errorf("SAML: Failed creating token with error: %v", err)
 		http.Redirect(w, r, redirectURL+"errorCode=500", http.StatusFound)
 	}
 	redirectURL = s.clientState.GetState(r, "Rancher_FinalRedirectURL")
@@ -394,7 +396,7 @@ func (s *Provider) HandleSamlAssertion(w http.ResponseWriter, r *http.Request, a
 		s.clientState.DeleteState(w, r, "Rancher_FinalRedirectURL")
 
 		requestID := s.clientState.GetState(r, "Rancher_RequestID")
-		log.Debugf("SAML: requestID: %s", requestID)
+		logger.Debugf("SAML: requestID: %s", requestID)
 		if requestID != "" {
 			// generate kubeconfig saml token
 			responseType := s.clientState.GetState(r, "Rancher_ResponseType")
@@ -402,21 +404,21 @@ func (s *Provider) HandleSamlAssertion(w http.ResponseWriter, r *http.Request, a
 
 			token, tokenValue, err := tokens.GetKubeConfigToken(user.Name, responseType, s.userMGR, userPrincipal)
 			if err != nil {
-				log.Errorf("SAML: getToken error %v", err)
+				logger.Errorf("SAML: getToken error %v", err)
 				http.Redirect(w, r, redirectURL+"errorCode=500", http.StatusFound)
 				return
 			}
 
 			keyBytes, err := base64.StdEncoding.DecodeString(publicKey)
 			if err != nil {
-				log.Errorf("SAML: base64 DecodeString error %v", err)
+				logger.Errorf("SAML: base64 DecodeString error %v", err)
 				http.Redirect(w, r, redirectURL+"errorCode=500", http.StatusFound)
 				return
 			}
 			pubKey := &rsa.PublicKey{}
 			err = json.Unmarshal(keyBytes, pubKey)
 			if err != nil {
-				log.Errorf("SAML: getPublicKey error %v", err)
+				logger.Errorf("SAML: getPublicKey error %v", err)
 				http.Redirect(w, r, redirectURL+"errorCode=500", http.StatusFound)
 				return
 			}
@@ -427,7 +429,7 @@ func (s *Provider) HandleSamlAssertion(w http.ResponseWriter, r *http.Request, a
 				[]byte(fmt.Sprintf("%s:%s", token.ObjectMeta.Name, tokenValue)),
 				nil)
 			if err != nil {
-				log.Errorf("SAML: getEncryptedToken error %v", err)
+				logger.Errorf("SAML: getEncryptedToken error %v", err)
 				http.Redirect(w, r, redirectURL+"errorCode=500", http.StatusFound)
 				return
 			}
@@ -444,7 +446,7 @@ func (s *Provider) HandleSamlAssertion(w http.ResponseWriter, r *http.Request, a
 
 			_, err = s.samlTokens.Create(samlToken)
 			if err != nil {
-				log.Errorf("SAML: createToken err %v", err)
+				logger.Errorf("SAML: createToken err %v", err)
 				http.Redirect(w, r, redirectURL+"errorCode=500", http.StatusFound)
 			}
 
@@ -10,7 +10,7 @@
 
 	"github.com/crewjam/saml"
 	"github.com/golang-jwt/jwt"
-	log "github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 )
 
 // ServeHTTP is the handler for /saml/metadata and /saml/acs endpoints
@@ -28,7 +28,7 @@ func (s *Provider) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		assertion, err := serviceProvider.ParseResponse(r, s.getPossibleRequestIDs(r))
 		if err != nil {
 			if parseErr, ok := err.(*saml.InvalidResponseError); ok {
-				log.Debugf("RESPONSE: ===\n%s\n===\nNOW: %s\nERROR: %s",
+				logger.Debugf("RESPONSE: ===\n%s\n===\nNOW: %s\nERROR: %s",
 					parseErr.Response, parseErr.Now, parseErr.PrivateErr)
 			}
 			redirectURL := r.URL.Host + "/login?errorCode=403"
@@ -58,7 +58,7 @@ func (s *Provider) getPossibleRequestIDs(r *http.Request) []string {
 			return secretBlock, nil
 		})
 		if err != nil || !token.Valid {
-			log.Debugf("... invalid token %s", err)
+			logger.Debugf("... invalid token %s", err)
 			continue
 		}
 		claims := token.Claims.(jwt.MapClaims)
@@ -73,7 +73,7 @@ func (s *Provider) HandleSamlLogin(w http.ResponseWriter, r *http.Request) (stri
 	if r.URL.Path == serviceProvider.AcsURL.Path {
 		return "", fmt.Errorf("don't wrap Middleware with RequireAccount")
 	}
-	log.Debugf("SAML [HandleSamlLogin]: Creating authentication request for %v", s.name)
+	logger.Debugf("SAML [HandleSamlLogin]: Creating authentication request for %v", s.name)
 	binding := saml.HTTPRedirectBinding
 	bindingLocation := serviceProvider.GetSSOBindingLocation(binding)
 
@@ -19,9 +19,9 @@
 	publicclient "github.com/rancher/rancher/pkg/client/generated/management/v3public"
 	corev1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/types/config"
 	"github.com/rancher/rancher/pkg/user"
-	"github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -114,14 +114,14 @@ func PerformSamlLogin(name string, apiContext *types.APIContext, input interface
 
 	if provider, ok := SamlProviders[name]; ok {
 		if provider == nil {
-			logrus.Errorf("SAML: Provider %v not initialized", name)
+			logger.Errorf("SAML: Provider %v not initialized", name)
 			return fmt.Errorf("SAML: Provider %v not initialized", name)
 		}
 		if provider.clientState == nil {
-			logrus.Errorf("SAML: Provider %v clientState not set", name)
+			logger.Errorf("SAML: Provider %v clientState not set", name)
 			return fmt.Errorf("SAML: Provider %v clientState not set", name)
 		}
-		logrus.Debugf("SAML [PerformSamlLogin]: Setting clientState for SAML service provider %v", name)
+		logger.Debugf("SAML [PerformSamlLogin]: Setting clientState for SAML service provider %v", name)
 		provider.clientState.SetState(apiContext.Response, apiContext.Request, "Rancher_FinalRedirectURL", finalRedirectURL)
 		provider.clientState.SetState(apiContext.Response, apiContext.Request, "Rancher_Action", loginAction)
 		provider.clientState.SetState(apiContext.Response, apiContext.Request, "Rancher_PublicKey", login.PublicKey)
@@ -132,7 +132,7 @@ func PerformSamlLogin(name string, apiContext *types.APIContext, input interface
 		if err != nil {
 			return err
 		}
-		logrus.Debugf("SAML [PerformSamlLogin]: Redirecting to the identity provider login page at %v", idpRedirectURL)
+		logger.Debugf("SAML [PerformSamlLogin]: Redirecting to the identity provider login page at %v", idpRedirectURL)
 		data := map[string]interface{}{
 			"idpRedirectUrl": idpRedirectURL,
 			"type":           "samlLoginOutput",
@@ -320,7 +320,7 @@ func (s *Provider) isThisUserMe(me v3.Principal, other v3.Principal) bool {
 func (s *Provider) CanAccessWithGroupProviders(userPrincipalID string, groupPrincipals []v3.Principal) (bool, error) {
 	config, err := s.getSamlConfig()
 	if err != nil {
-		logrus.Errorf("Error fetching saml config: %v", err)
+		logger.Errorf("Error fetching saml config: %v", err)
 		return false, err
 	}
 	allowed, err := s.userMGR.CheckAccess(config.AccessMode, config.AllowedPrincipalIDs, userPrincipalID, groupPrincipals)
@@ -364,7 +364,7 @@ func (s *Provider) combineSamlAndLdapConfig(config *v32.SamlConfig) runtime.Obje
 
 	// can be misconfigured but still want it saved
 	if err != nil {
-		logrus.Warnf("error pulling %s ldap configs: %s\n", s.name, err)
+		logger.Warnf("error pulling %s ldap configs: %s\n", s.name, err)
 
 		// if the the config subkey not in the crd
 		if ldapConfig == nil {
@@ -7,20 +7,23 @@
 
 	"github.com/pkg/errors"
 	"github.com/rancher/norman/httperror"
+	"github.com/rancher/rancher/pkg/auth/mfa"
 	"github.com/rancher/rancher/pkg/auth/providerrefresh"
 	"github.com/rancher/rancher/pkg/auth/providers"
 	"github.com/rancher/rancher/pkg/auth/providers/common"
 	"github.com/rancher/rancher/pkg/auth/tokens"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/types/config"
 	"github.com/rancher/steve/pkg/auth"
-	"github.com/sirupsen/logrus"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apiserver/pkg/authentication/user"
 	"k8s.io/client-go/tools/cache"
 )
 
+var logger = logging.Logger(logging.Auth)
+
 var (
 	ErrMustAuthenticate = httperror.NewAPIError(httperror.Unauthorized, "must authenticate")
 )
@@ -59,16 +62,19 @@ func ToAuthMiddleware(a Authenticator) auth.Middleware {
 func NewAuthenticator(ctx context.Context, clusterRouter ClusterRouter, mgmtCtx *config.ScaledContext) Authenticator {
 	tokenInformer := mgmtCtx.Management.Tokens("").Controller().Informer()
 	tokenInformer.AddIndexers(map[string]cache.IndexFunc{tokenKeyIndex: tokenKeyIndexer})
+	tokenClient := mgmtCtx.Management.Tokens("")
 
 	return &tokenAuthenticator{
 		ctx:                 ctx,
 		tokenIndexer:        tokenInformer.GetIndexer(),
-		tokenClient:         mgmtCtx.Management.Tokens(""),
+		tokenClient:         tokenClient,
 		userAttributeLister: mgmtCtx.Management.UserAttributes("").Controller().Lister(),
 		userAttributes:      mgmtCtx.Management.UserAttributes(""),
 		userLister:          mgmtCtx.Management.Users("").Controller().Lister(),
 		clusterRouter:       clusterRouter,
 		userAuthRefresher:   providerrefresh.NewUserAuthRefresher(ctx, mgmtCtx),
+		usageTracker:        tokens.NewUsageTracker(ctx, tokenClient, tokenClient.Controller().Lister()),
+		mfa:                 mgmtCtx.MFA,
 	}
 }
 
@@ -81,6 +87,8 @@ type tokenAuthenticator struct {
 	userLister          v3.UserLister
 	clusterRouter       ClusterRouter
 	userAuthRefresher   providerrefresh.UserAuthRefresher
+	usageTracker        *tokens.UsageTracker
+	mfa                 *mfa.Manager
 }
 
 const (
@@ -112,6 +120,14 @@ func (a *tokenAuthenticator) Authenticate(req *http.Request) (*AuthenticatorResp
 		return nil, errors.Wrapf(ErrMustAuthenticate, "clusterID does not match")
 	}
 
+	pending, err := a.mfa.Pending(token)
+	if err != nil {
+		return nil, err
+	}
+	if pending && !mfa.AllowedWhilePending(req) {
+		return nil, errors.Wrapf(ErrMustAuthenticate, "multi-factor authentication enrollment required")
+	}
+
 	attribs, err := a.userAttributeLister.Get("", token.UserID)
 	if err != nil && !apierrors.IsNotFound(err) {
 		return nil, err
@@ -152,13 +168,14 @@ func (a *tokenAuthenticator) Authenticate(req *http.Request) (*AuthenticatorResp
 	if !strings.HasPrefix(token.UserID, "system:") {
 		go a.userAuthRefresher.TriggerUserRefresh(token.UserID, false)
 	}
+	a.usageTracker.Record(token, req)
 
 	authResp.IsAuthed = true
 	authResp.User = token.UserID
 	authResp.UserPrincipal = token.UserPrincipal.Name
 	authResp.Groups = groups
 	authResp.Extras = getUserExtraInfo(token, u, attribs)
-	logrus.Debugf("Extras returned %v", authResp.Extras)
+	logger.Debugf("Extras returned %v", authResp.Extras)
 
 	return authResp, nil
 }
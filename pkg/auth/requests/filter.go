@@ -7,7 +7,7 @@
 	"github.com/rancher/rancher/pkg/auth/audit"
 	"github.com/rancher/rancher/pkg/auth/providers"
 	"github.com/rancher/rancher/pkg/auth/util"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 	"k8s.io/apiserver/pkg/endpoints/request"
 )
 
@@ -53,7 +53,7 @@ func (h authHeaderHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request)
 		}
 	}
 
-	logrus.Tracef("Rancher Auth Filter ##headers %v: ", req.Header)
+	logger.Tracef("Rancher Auth Filter ##headers %v: ", req.Header)
 
 	auditUser, ok := audit.FromContext(req.Context())
 	if ok {
@@ -4,12 +4,14 @@
 	"context"
 	"net/http"
 
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 	authV1 "k8s.io/api/authorization/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
 )
 
+var logger = logging.Logger(logging.Auth)
+
 // SubjectAccessReview checks if a user can impersonate as another user or group
 type SubjectAccessReview interface {
 	// UserCanImpersonateUser checks if user can impersonate as impUser
@@ -64,7 +66,7 @@ func (sar subjectAccessReview) checkUserCanImpersonateUser(sarClient v1.SubjectA
 	if err != nil {
 		return false, err
 	}
-	logrus.Debugf("Impersonate check result: %v", result)
+	logger.Debugf("Impersonate check result: %v", result)
 	return result.Status.Allowed, nil
 }
 
@@ -85,7 +87,7 @@ func (sar subjectAccessReview) checkUserCanImpersonateGroup(sarClient v1.Subject
 		if err != nil {
 			return false, err
 		}
-		logrus.Debugf("Impersonate check result: %v", result)
+		logger.Debugf("Impersonate check result: %v", result)
 		if !result.Status.Allowed {
 			return false, nil
 		}
@@ -18,13 +18,15 @@
 	"github.com/rancher/rancher/pkg/auth/tokens"
 	"github.com/rancher/rancher/pkg/clusterrouter"
 	"github.com/rancher/rancher/pkg/features"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/types/config"
 	steveauth "github.com/rancher/steve/pkg/auth"
-	"github.com/sirupsen/logrus"
 	"k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/client-go/rest"
 )
 
+var logger = logging.Logger(logging.Auth)
+
 type Server struct {
 	Authenticator steveauth.Middleware
 	Management    func(http.Handler) http.Handler
@@ -143,7 +145,7 @@ func (s *Server) OnLeader(ctx context.Context) error {
 
 	tokens.StartPurgeDaemon(ctx, management)
 	providerrefresh.StartRefreshDaemon(ctx, s.scaledContext, management)
-	logrus.Infof("Steve auth startup complete")
+	logger.Infof("Steve auth startup complete")
 	return nil
 }
 
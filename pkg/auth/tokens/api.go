@@ -8,9 +8,9 @@
 	"github.com/rancher/norman/httperror"
 	"github.com/rancher/norman/types"
 	client "github.com/rancher/rancher/pkg/client/generated/management/v3"
+	"github.com/rancher/rancher/pkg/logging"
 	managementSchema "github.com/rancher/rancher/pkg/schemas/management.cattle.io/v3"
 	"github.com/rancher/rancher/pkg/types/config"
-	"github.com/sirupsen/logrus"
 )
 
 const (
@@ -60,7 +60,7 @@ type tokenAPI struct {
 }
 
 func (t *tokenAPI) tokenActionHandler(actionName string, action *types.Action, request *types.APIContext) error {
-	logrus.Debugf("TokenActionHandler called for action %v", actionName)
+	logger.Debugf("TokenActionHandler called for action %v", actionName)
 	if actionName == "logout" {
 		return t.mgr.logout(actionName, action, request)
 	}
@@ -68,12 +68,12 @@ func (t *tokenAPI) tokenActionHandler(actionName string, action *types.Action, r
 }
 
 func (t *tokenAPI) tokenCreateHandler(request *types.APIContext, _ types.RequestHandler) error {
-	logrus.Debugf("TokenCreateHandler called")
+	logger.Debugf("TokenCreateHandler called")
 	return t.mgr.deriveToken(request)
 }
 
 func (t *tokenAPI) tokenListHandler(request *types.APIContext, _ types.RequestHandler) error {
-	logrus.Debugf("TokenListHandler called")
+	logger.Debugf("TokenListHandler called")
 	if request.ID != "" {
 		return t.mgr.getTokenFromRequest(request)
 	}
@@ -81,6 +81,6 @@ func (t *tokenAPI) tokenListHandler(request *types.APIContext, _ types.RequestHa
 }
 
 func (t *tokenAPI) tokenDeleteHandler(request *types.APIContext, _ types.RequestHandler) error {
-	logrus.Debugf("TokenDeleteHandler called")
+	logger.Debugf("TokenDeleteHandler called")
 	return t.mgr.removeToken(request)
 }
@@ -15,15 +15,17 @@
 	"github.com/rancher/norman/types"
 	"github.com/rancher/norman/types/convert"
 	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/auth/mfa"
 	"github.com/rancher/rancher/pkg/auth/util"
 	"github.com/rancher/rancher/pkg/catalog/utils"
 	clientv3 "github.com/rancher/rancher/pkg/client/generated/management/v3"
 	v1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/logging"
+	"github.com/rancher/rancher/pkg/securityevents"
 	"github.com/rancher/rancher/pkg/settings"
 	"github.com/rancher/rancher/pkg/types/config"
 	"github.com/rancher/wrangler/pkg/randomtoken"
-	"github.com/sirupsen/logrus"
 	apicorev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -32,6 +34,8 @@
 	"k8s.io/client-go/tools/cache"
 )
 
+var logger = logging.Logger(logging.Auth)
+
 // TODO Cleanup error logging. If error is being returned, use errors.wrap to return and dont log here
 
 const (
@@ -68,6 +72,7 @@ func NewManager(ctx context.Context, apiContext *config.ScaledContext) *Manager
 		userLister:          apiContext.Management.Users("").Controller().Lister(),
 		secrets:             apiContext.Core.Secrets(""),
 		secretLister:        apiContext.Core.Secrets("").Controller().Lister(),
+		mfa:                 apiContext.MFA,
 	}
 }
 
@@ -81,6 +86,7 @@ type Manager struct {
 	userLister          v3.UserLister
 	secrets             v1.SecretInterface
 	secretLister        v1.SecretLister
+	mfa                 *mfa.Manager
 }
 
 func userPrincipalIndexer(obj interface{}) ([]string, error) {
@@ -94,13 +100,17 @@ func userPrincipalIndexer(obj interface{}) ([]string, error) {
 
 // createDerivedToken will create a jwt token for the authenticated user
 func (m *Manager) createDerivedToken(jsonInput clientv3.Token, tokenAuthValue string) (v3.Token, string, int, error) {
-	logrus.Debug("Create Derived Token Invoked")
+	logger.Debug("Create Derived Token Invoked")
 
 	token, _, err := m.getToken(tokenAuthValue)
 	if err != nil {
 		return v3.Token{}, "", 401, err
 	}
 
+	if err := m.mfa.CheckTokenIssuance(token); err != nil {
+		return v3.Token{}, "", 403, err
+	}
+
 	tokenTTL, err := ClampToMaxTTL(time.Duration(int64(jsonInput.TTLMillis)) * time.Millisecond)
 	if err != nil {
 		return v3.Token{}, "", 500, fmt.Errorf("error validating max-ttl %v", err)
@@ -127,7 +137,7 @@ func (m *Manager) createDerivedToken(jsonInput clientv3.Token, tokenAuthValue st
 func (m *Manager) createToken(k8sToken *v3.Token) (v3.Token, string, error) {
 	key, err := randomtoken.Generate()
 	if err != nil {
-		logrus.Errorf("Failed to generate token key: %v", err)
+		logger.Errorf("Failed to generate token key: %v", err)
 		return v3.Token{}, "", errors.New("failed to generate token key")
 	}
 
@@ -191,7 +201,7 @@ func (m *Manager) getToken(tokenAuthValue string) (*v3.Token, int, error) {
 
 // GetTokens will list all(login and derived, and even expired) tokens of the authenticated user
 func (m *Manager) getTokens(tokenAuthValue string) ([]v3.Token, int, error) {
-	logrus.Debug("LIST Tokens Invoked")
+	logger.Debug("LIST Tokens Invoked")
 	tokens := make([]v3.Token, 0)
 
 	storedToken, _, err := m.getToken(tokenAuthValue)
@@ -216,7 +226,7 @@ func (m *Manager) getTokens(tokenAuthValue string) ([]v3.Token, int, error) {
 }
 
 func (m *Manager) deleteToken(tokenAuthValue string) (int, error) {
-	logrus.Debug("DELETE Token Invoked")
+	logger.Debug("DELETE Token Invoked")
 
 	storedToken, status, err := m.getToken(tokenAuthValue)
 	if err != nil {
@@ -238,13 +248,13 @@ func (m *Manager) deleteTokenByName(tokenName string) (int, error) {
 		}
 		return 500, fmt.Errorf("failed to delete token")
 	}
-	logrus.Debug("Deleted Token")
+	logger.Debug("Deleted Token")
 	return 0, nil
 }
 
 // getToken will get the token by ID
 func (m *Manager) getTokenByID(tokenAuthValue string, tokenID string) (v3.Token, int, error) {
-	logrus.Debug("GET Token Invoked")
+	logger.Debug("GET Token Invoked")
 	token := &v3.Token{}
 
 	storedToken, _, err := m.getToken(tokenAuthValue)
@@ -292,13 +302,22 @@ func (m *Manager) deriveToken(request *types.APIContext) error {
 	// create derived token
 	token, unhashedTokenKey, status, err := m.createDerivedToken(jsonInput, tokenAuthValue)
 	if err != nil {
-		logrus.Errorf("deriveToken failed with error: %v", err)
+		logger.Errorf("deriveToken failed with error: %v", err)
 		if status == 0 {
 			status = http.StatusInternalServerError
 		}
 		return httperror.NewAPIErrorLong(status, util.GetHTTPErrorCode(status), fmt.Sprintf("%v", err))
 	}
 
+	event := securityevents.NewEvent(securityevents.TokenCreated, securityevents.OutcomeSuccess, r)
+	event.User = token.UserID
+	event.Resource = "tokens/" + token.Name
+	event.Details = map[string]string{"ttl": fmt.Sprint(token.TTLMillis)}
+	if token.ClusterName != "" {
+		event.Details["cluster"] = token.ClusterName
+	}
+	securityevents.Emit(event)
+
 	tokenData, err := ConvertTokenResource(request.Schema, token)
 	if err != nil {
 		return err
@@ -322,7 +341,7 @@ func (m *Manager) listTokens(request *types.APIContext) error {
 	//getToken
 	tokens, status, err := m.getTokens(tokenAuthValue)
 	if err != nil {
-		logrus.Errorf("GetToken failed with error: %v", err)
+		logger.Errorf("GetToken failed with error: %v", err)
 		if status == 0 {
 			status = http.StatusInternalServerError
 		}
@@ -381,7 +400,7 @@ func (m *Manager) logout(actionName string, action *types.Action, request *types
 	//getToken
 	status, err := m.deleteToken(tokenAuthValue)
 	if err != nil {
-		logrus.Errorf("DeleteToken failed with error: %v", err)
+		logger.Errorf("DeleteToken failed with error: %v", err)
 		if status == 0 {
 			status = http.StatusInternalServerError
 		}
@@ -410,7 +429,7 @@ func (m *Manager) getTokenFromRequest(request *types.APIContext) error {
 	//getToken
 	token, status, err := m.getTokenByID(tokenAuthValue, tokenID)
 	if err != nil {
-		logrus.Errorf("GetToken failed with error: %v", err)
+		logger.Errorf("GetToken failed with error: %v", err)
 		if status == 0 {
 			status = http.StatusInternalServerError
 		} else if status == 410 {
@@ -442,7 +461,7 @@ func (m *Manager) removeToken(request *types.APIContext) error {
 	t, status, err := m.getTokenByID(tokenAuthValue, tokenID)
 	if err != nil {
 		if status != 410 {
-			logrus.Errorf("DeleteToken Failed to fetch the token to delete with error: %v", err)
+			logger.Errorf("DeleteToken Failed to fetch the token to delete with error: %v", err)
 			if status == 0 {
 				status = http.StatusInternalServerError
 			}
@@ -662,7 +681,7 @@ func (m *Manager) NewLoginToken(userID string, userPrincipal v3.Principal, group
 	err := wait.ExponentialBackoff(uaBackoff, func() (bool, error) {
 		err := m.UserAttributeCreateOrUpdate(userID, provider, groupPrincipals, userExtraInfo)
 		if err != nil {
-			logrus.Warnf("Problem creating or updating userAttribute for %v: %v", userID, err)
+			logger.Warnf("Problem creating or updating userAttribute for %v: %v", userID, err)
 		}
 		return err == nil, nil
 	})
@@ -696,7 +715,7 @@ func (m *Manager) GetGroupsForTokenAuthProvider(token *v3.Token) []v3.Principal
 
 	attribs, err := m.userAttributeLister.Get("", token.UserID)
 	if err != nil && !apierrors.IsNotFound(err) {
-		logrus.Warnf("Problem getting userAttribute while getting groups for %v: %v", token.UserID, err)
+		logger.Warnf("Problem getting userAttribute while getting groups for %v: %v", token.UserID, err)
 		// if err is not nil, then attribs will be. So, below code will handle it
 	}
 
@@ -725,7 +744,7 @@ func (m *Manager) GetGroupsForTokenAuthProvider(token *v3.Token) []v3.Principal
 func (m *Manager) IsMemberOf(token v3.Token, group v3.Principal) bool {
 	attribs, err := m.userAttributeLister.Get("", token.UserID)
 	if err != nil && !apierrors.IsNotFound(err) {
-		logrus.Warnf("Problem getting userAttribute while determining group membership for %v in %v (%v): %v", token.UserID,
+		logger.Warnf("Problem getting userAttribute while determining group membership for %v in %v (%v): %v", token.UserID,
 			group.Name, group.DisplayName, err)
 		// if err not nil, then attribs will be nil. So, below code will handle it
 	}
@@ -754,7 +773,7 @@ func (m *Manager) IsMemberOf(token v3.Token, group v3.Principal) bool {
 func (m *Manager) CreateTokenAndSetCookie(userID string, userPrincipal v3.Principal, groupPrincipals []v3.Principal, providerToken string, ttl int, description string, request *types.APIContext, userExtraInfo map[string][]string) error {
 	token, unhashedTokenKey, err := m.NewLoginToken(userID, userPrincipal, groupPrincipals, providerToken, 0, description, userExtraInfo)
 	if err != nil {
-		logrus.Errorf("Failed creating token with error: %v", err)
+		logger.Errorf("Failed creating token with error: %v", err)
 		return httperror.NewAPIErrorLong(500, "", fmt.Sprintf("Failed creating token with error: %v", err))
 	}
 
@@ -782,7 +801,7 @@ func (m *Manager) TokenStreamTransformer(
 	schema *types.Schema,
 	data chan map[string]interface{},
 	opt *types.QueryOptions) (chan map[string]interface{}, error) {
-	logrus.Debug("TokenStreamTransformer called")
+	logger.Debug("TokenStreamTransformer called")
 	tokenAuthValue := GetTokenAuthFromRequest(apiContext.Request)
 	if tokenAuthValue == "" {
 		// no cookie or auth header, cannot authenticate
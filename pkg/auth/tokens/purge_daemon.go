@@ -2,13 +2,15 @@
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/rancher/norman/clientbase"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/namespace"
+	"github.com/rancher/rancher/pkg/settings"
 	"github.com/rancher/rancher/pkg/types/config"
-	"github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -36,7 +38,7 @@ type purger struct {
 func (p *purger) purge() {
 	allTokens, err := p.tokenLister.List("", labels.Everything())
 	if err != nil {
-		logrus.Errorf("Error listing tokens during purge: %v", err)
+		logger.Errorf("Error listing tokens during purge: %v", err)
 	}
 
 	var count int
@@ -44,16 +46,18 @@ func (p *purger) purge() {
 		if IsExpired(*token) {
 			err = p.tokens.Delete(token.ObjectMeta.Name, &metav1.DeleteOptions{})
 			if err != nil && !clientbase.IsNotFound(err) {
-				logrus.Errorf("Error: while deleting expired token %v: %v", err, token.ObjectMeta.Name)
+				logger.Errorf("Error: while deleting expired token %v: %v", err, token.ObjectMeta.Name)
 				continue
 			}
 			count++
 		}
 	}
 	if count > 0 {
-		logrus.Infof("Purged %v expired tokens", count)
+		logger.Infof("Purged %v expired tokens", count)
 	}
 
+	p.disableInactiveTokens(allTokens)
+
 	// saml tokens store encrypted token for login request from rancher cli
 	samlTokens, err := p.samlTokensLister.List(namespace.GlobalNamespace, labels.Everything())
 	if err != nil {
@@ -66,13 +70,45 @@ func (p *purger) purge() {
 		if token.CreationTimestamp.Add(15 * time.Minute).Before(time.Now()) {
 			err = p.samlTokens.Delete(token.ObjectMeta.Name, &metav1.DeleteOptions{})
 			if err != nil && !clientbase.IsNotFound(err) {
-				logrus.Errorf("Error: while deleting expired token %v: %v", err, token.Name)
+				logger.Errorf("Error: while deleting expired token %v: %v", err, token.Name)
 				continue
 			}
 			count++
 		}
 	}
 	if count > 0 {
-		logrus.Infof("Purged %v saml tokens", count)
+		logger.Infof("Purged %v saml tokens", count)
+	}
+}
+
+// disableInactiveTokens disables the enabled tokens of users that didn't authenticate any request for the number of days
+// of the disable-inactive-tokens-after-days setting. Tokens of system users are never disabled.
+func (p *purger) disableInactiveTokens(tokens []*v3.Token) {
+	days := settings.DisableInactiveTokensAfterDays.GetInt()
+	if days <= 0 {
+		return
+	}
+
+	now := time.Now()
+	var count int
+	for _, token := range tokens {
+		if token.Enabled != nil && !*token.Enabled || IsExpired(*token) || strings.HasPrefix(token.UserID, "system:") {
+			continue
+		}
+		if !IsInactive(token, now, days) {
+			continue
+		}
+
+		token = token.DeepCopy()
+		enabled := false
+		token.Enabled = &enabled
+		if _, err := p.tokens.Update(token); err != nil && !clientbase.IsNotFound(err) {
+			logger.Errorf("Error: while disabling inactive token %v: %v", token.ObjectMeta.Name, err)
+			continue
+		}
+		count++
+	}
+	if count > 0 {
+		logger.Infof("Disabled %v tokens inactive for %v days", count, days)
 	}
 }
@@ -12,8 +12,8 @@
 	"github.com/rancher/norman/types/convert"
 	"github.com/rancher/rancher/pkg/features"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/user"
-	"github.com/sirupsen/logrus"
 )
 
 func getAuthProviderName(principalID string) string {
@@ -74,7 +74,7 @@ func GetTokenAuthFromRequest(req *http.Request) string {
 				base64Value := strings.TrimSpace(parts[1])
 				data, err := base64.URLEncoding.DecodeString(base64Value)
 				if err != nil {
-					logrus.Errorf("Error %v parsing %v header", err, AuthHeaderName)
+					logger.Errorf("Error %v parsing %v header", err, AuthHeaderName)
 				} else {
 					tokenAuthValue = string(data)
 				}
@@ -107,7 +107,7 @@ func GetKubeConfigToken(userName, responseType string, userMGR user.Manager, use
 	// create kubeconfig expiring tokens if responseType=kubeconfig in login action vs login tokens for responseType=json
 	clusterID := extractClusterIDFromResponseType(responseType)
 
-	logrus.Debugf("getKubeConfigToken: responseType %s", responseType)
+	logger.Debugf("getKubeConfigToken: responseType %s", responseType)
 	name := "kubeconfig-" + userName
 	if clusterID != "" {
 		name = fmt.Sprintf("kubeconfig-%s.%s", userName, clusterID)
@@ -137,7 +137,7 @@ func VerifyToken(storedToken *v3.Token, tokenName, tokenKey string) (int, error)
 	}
 	if storedToken.Annotations != nil && storedToken.Annotations[TokenHashed] == "true" {
 		if err := VerifySHA256Hash(storedToken.Token, tokenKey); err != nil {
-			logrus.Errorf("VerifySHA256Hash failed with error: %v", err)
+			logger.Errorf("VerifySHA256Hash failed with error: %v", err)
 			return 422, invalidAuthTokenErr
 		}
 	} else {
@@ -159,7 +159,7 @@ func ConvertTokenKeyToHash(token *v3.Token) error {
 	if token != nil && len(token.Token) > 0 {
 		hashedToken, err := CreateSHA256Hash(token.Token)
 		if err != nil {
-			logrus.Errorf("Failed to generate hash from token: %v", err)
+			logger.Errorf("Failed to generate hash from token: %v", err)
 			return errors.New("failed to generate hash from token")
 		}
 		token.Token = hashedToken
@@ -0,0 +1,112 @@
+package tokens
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/auth/util"
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/logging"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// usageGranularity is the minimum time between two updates of the usage of a token from the same source IP.
+	usageGranularity = time.Minute
+	usageFlushPeriod = 30 * time.Second
+)
+
+type usage struct {
+	at time.Time
+	ip string
+}
+
+// UsageTracker records the last use of tokens. Usages are batched in memory and written to the tokens periodically, so
+// that authenticating a request never waits for a token update.
+type UsageTracker struct {
+	tokens      v3.TokenInterface
+	tokenLister v3.TokenLister
+
+	lock    sync.Mutex
+	pending map[string]usage
+}
+
+// NewUsageTracker creates a tracker writing usages until the context is done.
+func NewUsageTracker(ctx context.Context, tokens v3.TokenInterface, tokenLister v3.TokenLister) *UsageTracker {
+	t := &UsageTracker{
+		tokens:      tokens,
+		tokenLister: tokenLister,
+		pending:     map[string]usage{},
+	}
+	go wait.Until(t.flush, usageFlushPeriod, ctx.Done())
+	return t
+}
+
+// Record records the use of the token from the source IP of the request.
+func (t *UsageTracker) Record(token *v32.Token, req *http.Request) {
+	u := usage{at: time.Now().UTC(), ip: util.SourceIP(req)}
+	if !usageOutdated(token, u) {
+		return
+	}
+
+	t.lock.Lock()
+	t.pending[token.Name] = u
+	t.lock.Unlock()
+}
+
+func (t *UsageTracker) flush() {
+	t.lock.Lock()
+	pending := t.pending
+	t.pending = map[string]usage{}
+	t.lock.Unlock()
+
+	for name, u := range pending {
+		token, err := t.tokenLister.Get("", name)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				logger.Errorf("Error getting token %s to record its usage: %v", name, err)
+			}
+			continue
+		}
+		if !usageOutdated(token, u) {
+			continue
+		}
+
+		token = token.DeepCopy()
+		token.LastUsedAt = u.at.Format(time.RFC3339)
+		token.LastUsedFrom = u.ip
+		if _, err := t.tokens.Update(token); err != nil && !apierrors.IsConflict(err) && !apierrors.IsNotFound(err) {
+			logger.Errorf("Error recording usage of token %s: %v", name, err)
+		}
+	}
+}
+
+// usageOutdated returns true if the recorded usage of the token is older than the usage granularity, or from another
+// source IP.
+func usageOutdated(token *v32.Token, u usage) bool {
+	if token.LastUsedFrom != u.ip {
+		return true
+	}
+	lastUsed, err := time.Parse(time.RFC3339, token.LastUsedAt)
+	if err != nil {
+		return true
+	}
+	return u.at.Sub(lastUsed) >= usageGranularity
+}
+
+// IsInactive returns true if the token didn't authenticate any request for the given number of days, counted from its
+// creation if it was never used.
+func IsInactive(token *v32.Token, now time.Time, days int) bool {
+	if days <= 0 {
+		return false
+	}
+	lastActive := token.CreationTimestamp.Time
+	if lastUsed, err := time.Parse(time.RFC3339, token.LastUsedAt); err == nil && lastUsed.After(lastActive) {
+		lastActive = lastUsed
+	}
+	return now.Sub(lastActive) >= time.Duration(days)*24*time.Hour
+}
@@ -0,0 +1,44 @@
+package tokens
+
+import (
+	"testing"
+	"time"
+
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUsageOutdated(t *testing.T) {
+	now := time.Date(2023, 3, 1, 12, 0, 30, 0, time.UTC)
+	token := &v32.Token{LastUsedAt: "2023-03-01T12:00:00Z", LastUsedFrom: "10.0.0.1"}
+
+	assert.False(t, usageOutdated(token, usage{at: now, ip: "10.0.0.1"}))
+	assert.True(t, usageOutdated(token, usage{at: now, ip: "10.0.0.2"}))
+	assert.True(t, usageOutdated(token, usage{at: now.Add(time.Minute), ip: "10.0.0.1"}))
+	assert.True(t, usageOutdated(&v32.Token{}, usage{at: now}))
+}
+
+func TestIsInactive(t *testing.T) {
+	now := time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC)
+	created := metav1.NewTime(time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name     string
+		lastUsed string
+		days     int
+		want     bool
+	}{
+		{name: "policy disabled", days: 0},
+		{name: "never used since creation", days: 30, want: true},
+		{name: "never used recently created", days: 31},
+		{name: "used recently", lastUsed: "2023-03-20T00:00:00Z", days: 30},
+		{name: "used long ago", lastUsed: "2023-03-20T00:00:00Z", days: 10, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := &v32.Token{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created}, LastUsedAt: tt.lastUsed}
+			assert.Equal(t, tt.want, IsInactive(token, now, tt.days))
+		})
+	}
+}
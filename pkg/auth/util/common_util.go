@@ -2,12 +2,22 @@
 
 import (
 	"encoding/json"
+	"net"
 	"net/http"
 	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/sirupsen/logrus"
 )
 
 var (
 	RequestKey = struct{}{}
+
+	trustedProxiesLock    sync.Mutex
+	trustedProxiesSetting string
+	trustedProxies        []*net.IPNet
 )
 
 // ReturnHTTPError handles sending out Error response
@@ -53,6 +63,90 @@ func GetHost(req *http.Request) string {
 	return host
 }
 
+// SourceIP returns the IP of the client of the request. The X-Forwarded-For header is only used if the request comes
+// from one of the trusted-proxies, in which case the source IP is the last address of the header that isn't a trusted
+// proxy. Otherwise it is the remote address of the request, as any client can set the header.
+func SourceIP(req *http.Request) string {
+	return sourceIP(req, getTrustedProxies())
+}
+
+func sourceIP(req *http.Request, trusted []*net.IPNet) string {
+	remote, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		remote = req.RemoteAddr
+	}
+	forwarded := req.Header.Values("X-Forwarded-For")
+	if len(forwarded) == 0 || !isTrustedProxy(remote, trusted) {
+		return remote
+	}
+
+	var addresses []string
+	for _, header := range forwarded {
+		for _, address := range strings.Split(header, ",") {
+			if address = strings.TrimSpace(address); address != "" {
+				addresses = append(addresses, address)
+			}
+		}
+	}
+	for i := len(addresses) - 1; i >= 0; i-- {
+		if !isTrustedProxy(addresses[i], trusted) || i == 0 {
+			return addresses[i]
+		}
+	}
+	return remote
+}
+
+func isTrustedProxy(address string, trusted []*net.IPNet) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, network := range trusted {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// getTrustedProxies returns the networks of the trusted-proxies setting, parsed again only when it changes.
+func getTrustedProxies() []*net.IPNet {
+	value := settings.TrustedProxies.Get()
+
+	trustedProxiesLock.Lock()
+	defer trustedProxiesLock.Unlock()
+	if value != trustedProxiesSetting {
+		trustedProxiesSetting = value
+		trustedProxies = parseTrustedProxies(value)
+	}
+	return trustedProxies
+}
+
+// parseTrustedProxies parses a comma separated list of IPs and CIDRs, logging and skipping invalid ones.
+func parseTrustedProxies(value string) []*net.IPNet {
+	var result []*net.IPNet
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			if ip := net.ParseIP(entry); ip != nil && ip.To4() != nil {
+				entry += "/32"
+			} else {
+				entry += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			logrus.Errorf("Ignoring invalid entry %q of the %s setting: %v", entry, settings.TrustedProxies.Name, err)
+			continue
+		}
+		result = append(result, network)
+	}
+	return result
+}
+
 // AuthError structure contains the error resource definition
 type AuthError struct {
 	Type    string `json:"type"`
@@ -0,0 +1,76 @@
+package util
+
+import (
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourceIP(t *testing.T) {
+	req := &http.Request{RemoteAddr: "10.0.0.1:43210", Header: http.Header{}}
+	assert.Equal(t, "10.0.0.1", SourceIP(req))
+
+	req.Header.Set("X-Forwarded-For", "192.168.1.5, 10.0.0.3")
+	assert.Equal(t, "10.0.0.1", SourceIP(req), "the header is ignored unless the request comes from a trusted proxy")
+}
+
+func TestSourceIPTrustedProxies(t *testing.T) {
+	trusted := parseTrustedProxies("10.0.0.0/24, 172.16.0.1,invalid")
+	assert.Len(t, trusted, 2)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		expected   string
+	}{
+		{
+			name:       "untrusted remote address",
+			remoteAddr: "192.168.1.9:43210",
+			forwarded:  []string{"1.2.3.4"},
+			expected:   "192.168.1.9",
+		},
+		{
+			name:       "trusted proxy without header",
+			remoteAddr: "10.0.0.1:43210",
+			expected:   "10.0.0.1",
+		},
+		{
+			name:       "client spoofing the header through a trusted proxy",
+			remoteAddr: "10.0.0.1:43210",
+			forwarded:  []string{"1.2.3.4, 192.168.1.5"},
+			expected:   "192.168.1.5",
+		},
+		{
+			name:       "chain of trusted proxies",
+			remoteAddr: "10.0.0.1:43210",
+			forwarded:  []string{"192.168.1.5, 172.16.0.1", "10.0.0.7"},
+			expected:   "192.168.1.5",
+		},
+		{
+			name:       "only trusted proxies",
+			remoteAddr: "10.0.0.1:43210",
+			forwarded:  []string{"10.0.0.8, 172.16.0.1"},
+			expected:   "10.0.0.8",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &http.Request{RemoteAddr: tt.remoteAddr, Header: http.Header{}}
+			for _, value := range tt.forwarded {
+				req.Header.Add("X-Forwarded-For", value)
+			}
+			assert.Equal(t, tt.expected, sourceIP(req, trusted))
+		})
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	_, ipv4, _ := net.ParseCIDR("172.16.0.1/32")
+	_, ipv6, _ := net.ParseCIDR("fd00::1/128")
+	assert.Equal(t, []*net.IPNet{ipv4, ipv6}, parseTrustedProxies("172.16.0.1, fd00::1"))
+	assert.Nil(t, parseTrustedProxies(""))
+}
@@ -5,12 +5,14 @@
 	"net/http"
 
 	"github.com/rancher/rancher/pkg/auth/requests"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 	v1 "k8s.io/api/authentication/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apiserver/pkg/endpoints/request"
 )
 
+var logger = logging.Logger(logging.Auth)
+
 type TokenReviewer struct {
 	ExternalIDs bool
 }
@@ -65,7 +67,7 @@ func writeResp(rw http.ResponseWriter, tr *v1.TokenReview) {
 	enc := json.NewEncoder(rw)
 	err := enc.Encode(tr)
 	if err != nil {
-		logrus.Infof("Failed to encode token review response")
+		logger.Infof("Failed to encode token review response")
 	}
 }
 
@@ -0,0 +1,213 @@
+// Package breakglass builds the break glass kubeconfigs escrowed for provisioned clusters. A break glass kubeconfig
+// authenticates with a client certificate issued by the cluster itself and connects to its control plane nodes
+// directly, so that it keeps working when rancher is down.
+package breakglass
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rancher/wrangler/pkg/name"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// SecretType is the type of the secrets escrowing break glass kubeconfigs.
+	SecretType = "rke.cattle.io/break-glass-kubeconfig"
+
+	// KubeconfigField is the field of the escrow secret holding the encrypted kubeconfig.
+	KubeconfigField = "kubeconfig"
+	// UserField is the field of the escrow secret holding the user of the client certificate.
+	UserField = "user"
+	// IssuedAtField is the field of the escrow secret holding when the client certificate was issued, in RFC3339.
+	IssuedAtField = "issuedAt"
+	// NotAfterField is the field of the escrow secret holding when the client certificate expires, in RFC3339.
+	NotAfterField = "notAfter"
+	// RotationGenerationField is the field of the escrow secret holding the certificate rotation generation of the
+	// control plane when the kubeconfig was issued.
+	RotationGenerationField = "certificateRotationGeneration"
+	// HashField is the field of the escrow secret holding the hash of the CA and servers of the kubeconfig.
+	HashField = "hash"
+	// VaultSyncedField is the field of the escrow secret holding whether the kubeconfig was written to vault.
+	VaultSyncedField = "vaultSynced"
+
+	// LastRetrievedByAnnotation and LastRetrievedAtAnnotation record the last retrieval of an escrowed kubeconfig.
+	LastRetrievedByAnnotation = "rke.cattle.io/break-glass-last-retrieved-by"
+	LastRetrievedAtAnnotation = "rke.cattle.io/break-glass-last-retrieved-at"
+
+	// UserPrefix prefixes the user names of break glass client certificates, which end with the id of the issue.
+	UserPrefix = "rancher-break-glass:"
+	// ClusterRoleBindingName is the name of the binding granting the break glass user cluster-admin downstream.
+	ClusterRoleBindingName = "rancher-break-glass"
+
+	// apiServerPort is the port of the kube-apiserver of RKE2 and K3s.
+	apiServerPort = 6443
+)
+
+// SecretName returns the name of the secret escrowing the break glass kubeconfig of a cluster.
+func SecretName(clusterName string) string {
+	return name.SafeConcatName(clusterName, "break", "glass", "kubeconfig")
+}
+
+// Server is a control plane node of a cluster.
+type Server struct {
+	Name    string
+	Address string
+}
+
+// Hash returns the hash of the CA and servers of a kubeconfig, which changes when the kubeconfig must be rebuilt.
+func Hash(caCert []byte, servers []Server) string {
+	h := sha256.New()
+	h.Write(caCert)
+	for _, server := range sortedServers(servers) {
+		fmt.Fprintf(h, "\x00%s=%s", server.Name, server.Address)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func sortedServers(servers []Server) []Server {
+	result := append([]Server(nil), servers...)
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// NewCertificateRequest returns a private key and a certificate signing request for the client certificate of a
+// break glass user.
+func NewCertificateRequest(user string) (keyPEM, csrPEM []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: user},
+	}, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr}), nil
+}
+
+// NotAfter returns when a PEM encoded certificate expires.
+func NotAfter(certPEM []byte) (time.Time, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return time.Time{}, fmt.Errorf("invalid certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cert.NotAfter, nil
+}
+
+// Kubeconfig returns a kubeconfig with a context for every server of a cluster, authenticating with a client
+// certificate.
+func Kubeconfig(clusterName string, servers []Server, caCert, clientCert, clientKey []byte) ([]byte, error) {
+	if len(servers) == 0 {
+		return nil, fmt.Errorf("cluster %s has no control plane node with an address", clusterName)
+	}
+
+	user := clusterName + "-break-glass"
+	config := clientcmdapi.Config{
+		Clusters: map[string]*clientcmdapi.Cluster{},
+		AuthInfos: map[string]*clientcmdapi.AuthInfo{
+			user: {
+				ClientCertificateData: clientCert,
+				ClientKeyData:         clientKey,
+			},
+		},
+		Contexts: map[string]*clientcmdapi.Context{},
+	}
+	for _, server := range sortedServers(servers) {
+		contextName := clusterName + "-" + server.Name
+		config.Clusters[contextName] = &clientcmdapi.Cluster{
+			Server:                   "https://" + hostPort(server.Address),
+			CertificateAuthorityData: caCert,
+		}
+		config.Contexts[contextName] = &clientcmdapi.Context{
+			Cluster:  contextName,
+			AuthInfo: user,
+		}
+		if config.CurrentContext == "" {
+			config.CurrentContext = contextName
+		}
+	}
+	return clientcmd.Write(config)
+}
+
+func hostPort(address string) string {
+	if strings.Contains(address, ":") {
+		// IPv6 address.
+		return "[" + address + "]:" + strconv.Itoa(apiServerPort)
+	}
+	return address + ":" + strconv.Itoa(apiServerPort)
+}
+
+// Escrow is the state of an escrowed kubeconfig recorded in its secret.
+type Escrow struct {
+	// User is the user of the client certificate, bound to cluster-admin.
+	User               string
+	IssuedAt           time.Time
+	NotAfter           time.Time
+	RotationGeneration int64
+	Hash               string
+	// VaultSynced is whether the kubeconfig was written to vault.
+	VaultSynced bool
+}
+
+// ParseEscrow returns the state of an escrowed kubeconfig from the data of its secret.
+func ParseEscrow(data map[string][]byte) Escrow {
+	issuedAt, _ := time.Parse(time.RFC3339, string(data[IssuedAtField]))
+	notAfter, _ := time.Parse(time.RFC3339, string(data[NotAfterField]))
+	generation, _ := strconv.ParseInt(string(data[RotationGenerationField]), 10, 64)
+	return Escrow{
+		User:               string(data[UserField]),
+		IssuedAt:           issuedAt,
+		NotAfter:           notAfter,
+		RotationGeneration: generation,
+		Hash:               string(data[HashField]),
+		VaultSynced:        string(data[VaultSyncedField]) == "true",
+	}
+}
+
+// Data returns the data of the secret escrowing a kubeconfig, already encrypted.
+func (e Escrow) Data(kubeconfig []byte) map[string][]byte {
+	return map[string][]byte{
+		KubeconfigField:         kubeconfig,
+		UserField:               []byte(e.User),
+		IssuedAtField:           []byte(e.IssuedAt.UTC().Format(time.RFC3339)),
+		NotAfterField:           []byte(e.NotAfter.UTC().Format(time.RFC3339)),
+		RotationGenerationField: []byte(strconv.FormatInt(e.RotationGeneration, 10)),
+		HashField:               []byte(e.Hash),
+		VaultSyncedField:        []byte(strconv.FormatBool(e.VaultSynced)),
+	}
+}
+
+// RenewAt returns when an escrowed kubeconfig must be reissued, once two thirds of the validity of its certificate
+// elapsed, or the zero time if it must be reissued now because it was never issued, the certificates of the cluster
+// were rotated or its CA or servers changed since.
+func (e Escrow) RenewAt(current Escrow) time.Time {
+	if e.NotAfter.IsZero() || e.RotationGeneration != current.RotationGeneration || e.Hash != current.Hash {
+		return time.Time{}
+	}
+	return e.NotAfter.Add(-e.NotAfter.Sub(e.IssuedAt) / 3)
+}
@@ -0,0 +1,147 @@
+package breakglass
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func TestKubeconfig(t *testing.T) {
+	servers := []Server{
+		{Name: "node-b", Address: "fd00::2"},
+		{Name: "node-a", Address: "10.0.0.1"},
+	}
+	data, err := Kubeconfig("c-1", servers, []byte("ca"), []byte("cert"), []byte("key"))
+	require.NoError(t, err)
+
+	config, err := clientcmd.Load(data)
+	require.NoError(t, err)
+	assert.Equal(t, "c-1-node-a", config.CurrentContext)
+	require.Len(t, config.Clusters, 2)
+	assert.Equal(t, "https://10.0.0.1:6443", config.Clusters["c-1-node-a"].Server)
+	assert.Equal(t, "https://[fd00::2]:6443", config.Clusters["c-1-node-b"].Server)
+	assert.Equal(t, []byte("ca"), config.Clusters["c-1-node-b"].CertificateAuthorityData)
+	assert.Equal(t, "c-1-break-glass", config.Contexts["c-1-node-b"].AuthInfo)
+	assert.Equal(t, []byte("cert"), config.AuthInfos["c-1-break-glass"].ClientCertificateData)
+	assert.Equal(t, []byte("key"), config.AuthInfos["c-1-break-glass"].ClientKeyData)
+
+	_, err = Kubeconfig("c-1", nil, []byte("ca"), []byte("cert"), []byte("key"))
+	assert.Error(t, err)
+}
+
+func TestHash(t *testing.T) {
+	servers := []Server{{Name: "a", Address: "10.0.0.1"}, {Name: "b", Address: "10.0.0.2"}}
+	reordered := []Server{servers[1], servers[0]}
+
+	assert.Equal(t, Hash([]byte("ca"), servers), Hash([]byte("ca"), reordered))
+	assert.NotEqual(t, Hash([]byte("ca"), servers), Hash([]byte("other"), servers))
+	assert.NotEqual(t, Hash([]byte("ca"), servers), Hash([]byte("ca"), servers[:1]))
+	assert.NotEqual(t, Hash([]byte("ca"), servers), Hash([]byte("ca"), []Server{{Name: "a", Address: "10.0.0.3"}, servers[1]}))
+}
+
+func TestEscrowRoundTrip(t *testing.T) {
+	escrow := Escrow{
+		User:               UserPrefix + "abc",
+		IssuedAt:           time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:           time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		RotationGeneration: 2,
+		Hash:               "hash",
+		VaultSynced:        true,
+	}
+	data := escrow.Data([]byte("encrypted"))
+	assert.Equal(t, []byte("encrypted"), data[KubeconfigField])
+	assert.Equal(t, escrow, ParseEscrow(data))
+	assert.Equal(t, Escrow{}, ParseEscrow(nil))
+}
+
+func TestRenewAt(t *testing.T) {
+	issuedAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	escrow := Escrow{
+		IssuedAt:           issuedAt,
+		NotAfter:           issuedAt.Add(300 * time.Hour),
+		RotationGeneration: 1,
+		Hash:               "hash",
+	}
+
+	tests := []struct {
+		name    string
+		escrow  Escrow
+		current Escrow
+		want    time.Time
+	}{
+		{
+			name:    "never issued",
+			current: Escrow{RotationGeneration: 1, Hash: "hash"},
+		},
+		{
+			name:    "up to date",
+			escrow:  escrow,
+			current: Escrow{RotationGeneration: 1, Hash: "hash"},
+			want:    issuedAt.Add(200 * time.Hour),
+		},
+		{
+			name:    "certificates rotated",
+			escrow:  escrow,
+			current: Escrow{RotationGeneration: 2, Hash: "hash"},
+		},
+		{
+			name:    "servers changed",
+			escrow:  escrow,
+			current: Escrow{RotationGeneration: 1, Hash: "other"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.escrow.RenewAt(tt.current))
+		})
+	}
+}
+
+func TestNewCertificateRequest(t *testing.T) {
+	keyPEM, csrPEM, err := NewCertificateRequest(UserPrefix + "abc")
+	require.NoError(t, err)
+
+	keyBlock, _ := pem.Decode(keyPEM)
+	require.NotNil(t, keyBlock)
+	key, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+	require.NoError(t, err)
+
+	csrBlock, _ := pem.Decode(csrPEM)
+	require.NotNil(t, csrBlock)
+	csr, err := x509.ParseCertificateRequest(csrBlock.Bytes)
+	require.NoError(t, err)
+	assert.NoError(t, csr.CheckSignature())
+	assert.Equal(t, UserPrefix+"abc", csr.Subject.CommonName)
+	assert.True(t, key.PublicKey.Equal(csr.PublicKey))
+}
+
+func TestNotAfter(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	notAfter := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    notAfter.Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	got, err := NotAfter(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	require.NoError(t, err)
+	assert.Equal(t, notAfter, got.UTC())
+
+	_, err = NotAfter([]byte("invalid"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,70 @@
+package breakglass
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// VaultClient writes escrowed kubeconfigs to a KV version 2 secrets engine of HashiCorp Vault, so that they can be
+// retrieved when rancher is down.
+type VaultClient struct {
+	// Address is the address of vault, such as https://vault.example.com:8200.
+	Address string
+	// Mount is the path the KV secrets engine is mounted at.
+	Mount string
+	// Path is the path of the secrets under the mount, the secret of a cluster is at <path>/<namespace>/<cluster>.
+	Path  string
+	Token string
+
+	HTTPClient *http.Client
+}
+
+// Write writes the kubeconfig of a cluster to vault.
+func (v *VaultClient) Write(ctx context.Context, namespace, clusterName string, kubeconfig []byte, notAfter time.Time) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"data": map[string]string{
+			"kubeconfig": string(kubeconfig),
+			"notAfter":   notAfter.UTC().Format(time.RFC3339),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.secretURL(namespace, clusterName), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", v.Token)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := v.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("vault returned %s writing the break glass kubeconfig of cluster %s/%s: %s", resp.Status, namespace, clusterName, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+func (v *VaultClient) secretURL(namespace, clusterName string) string {
+	parts := []string{strings.TrimSuffix(v.Address, "/"), "v1", strings.Trim(v.Mount, "/"), "data"}
+	if path := strings.Trim(v.Path, "/"); path != "" {
+		parts = append(parts, path)
+	}
+	return strings.Join(append(parts, namespace, clusterName), "/")
+}
@@ -0,0 +1,61 @@
+package breakglass
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultClientWrite(t *testing.T) {
+	var (
+		gotPath, gotToken string
+		gotBody           map[string]map[string]string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotToken = r.Header.Get("X-Vault-Token")
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := VaultClient{
+		Address: server.URL + "/",
+		Mount:   "/secret/",
+		Path:    "rancher/break-glass/",
+		Token:   "token",
+	}
+	notAfter := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, client.Write(context.Background(), "fleet-default", "c-1", []byte("kubeconfig"), notAfter))
+
+	assert.Equal(t, "/v1/secret/data/rancher/break-glass/fleet-default/c-1", gotPath)
+	assert.Equal(t, "token", gotToken)
+	assert.Equal(t, map[string]string{"kubeconfig": "kubeconfig", "notAfter": "2024-01-01T00:00:00Z"}, gotBody["data"])
+}
+
+func TestVaultClientWriteError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+	}))
+	defer server.Close()
+
+	client := VaultClient{Address: server.URL, Mount: "secret", Token: "token"}
+	err := client.Write(context.Background(), "fleet-default", "c-1", []byte("kubeconfig"), time.Now())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "permission denied")
+}
+
+func TestVaultClientSecretURL(t *testing.T) {
+	client := VaultClient{Address: "https://vault.example.com:8200", Mount: "kv"}
+	assert.Equal(t, "https://vault.example.com:8200/v1/kv/data/fleet-default/c-1", client.secretURL("fleet-default", "c-1"))
+}
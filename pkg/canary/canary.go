@@ -0,0 +1,134 @@
+// Package canary rolls the fleet-wide changes driven by rancher out to the canary clusters first, holding the rest of
+// the clusters until the canaries have been healthy with the change for a soak period, or halting them if a canary
+// fails its health checks.
+package canary
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// Label marks a cluster as a canary when set to "true".
+	Label = "management.cattle.io/canary"
+	// RolloutsAnnotation is the annotation of canary clusters recording the revisions of the operations applied to
+	// them, as a JSON object of rollouts by operation.
+	RolloutsAnnotation = "management.cattle.io/canary-rollouts"
+
+	// AgentOperation is the rollout of the cluster agent image.
+	AgentOperation = "agent"
+	// SystemUpgradeControllerOperation is the rollout of the system-upgrade-controller chart version.
+	SystemUpgradeControllerOperation = "system-upgrade-controller"
+
+	// DefaultSoakPeriod is the soak period used when the canary soak period setting is invalid.
+	DefaultSoakPeriod = time.Hour
+	// waitInterval is how often clusters waiting on canaries are checked again.
+	waitInterval = time.Minute
+)
+
+// Rollout is the revision of an operation applied to a canary cluster.
+type Rollout struct {
+	Revision  string      `json:"revision"`
+	AppliedAt metav1.Time `json:"appliedAt"`
+	// Failed is set if the canary failed its health checks during the soak period of the revision.
+	Failed  bool   `json:"failed,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// PSACTOperation returns the rollout of the default pod security admission configuration template of a cluster group.
+func PSACTOperation(clusterGroupName string) string {
+	return "psact/" + clusterGroupName
+}
+
+// IsCanary returns whether a cluster is a canary.
+func IsCanary(cluster *v3.Cluster) bool {
+	return cluster.Labels[Label] == "true"
+}
+
+// SoakPeriod parses the canary soak period setting, the default soak period is returned if it's invalid.
+func SoakPeriod(setting string) time.Duration {
+	soak, err := time.ParseDuration(setting)
+	if err != nil || soak < 0 {
+		return DefaultSoakPeriod
+	}
+	return soak
+}
+
+// Rollouts returns the rollouts recorded on a canary cluster by operation.
+func Rollouts(cluster *v3.Cluster) (map[string]Rollout, error) {
+	rollouts := map[string]Rollout{}
+	data := cluster.Annotations[RolloutsAnnotation]
+	if data == "" {
+		return rollouts, nil
+	}
+	if err := json.Unmarshal([]byte(data), &rollouts); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation of cluster %s: %w", RolloutsAnnotation, cluster.Name, err)
+	}
+	return rollouts, nil
+}
+
+func setRollouts(cluster *v3.Cluster, rollouts map[string]Rollout) error {
+	data, err := json.Marshal(rollouts)
+	if err != nil {
+		return err
+	}
+	if cluster.Annotations == nil {
+		cluster.Annotations = map[string]string{}
+	}
+	cluster.Annotations[RolloutsAnnotation] = string(data)
+	return nil
+}
+
+// SetApplied records that the revision of an operation is applied to a canary cluster, returning whether the cluster
+// was changed. The time a revision was first applied at is kept, so that it can be recorded every time it's observed.
+func SetApplied(cluster *v3.Cluster, operation, revision string, now time.Time) (bool, error) {
+	if !IsCanary(cluster) {
+		return false, nil
+	}
+	rollouts, err := Rollouts(cluster)
+	if err != nil {
+		// a corrupted annotation is replaced
+		rollouts = map[string]Rollout{}
+	} else if rollout, ok := rollouts[operation]; ok && rollout.Revision == revision {
+		return false, nil
+	}
+	rollouts[operation] = Rollout{
+		Revision:  revision,
+		AppliedAt: metav1.NewTime(now),
+	}
+	return true, setRollouts(cluster, rollouts)
+}
+
+// CheckHealth marks the rollouts of a canary cluster in their soak period as failed if the cluster isn't ready,
+// returning whether the cluster was changed.
+func CheckHealth(cluster *v3.Cluster, soak time.Duration, now time.Time) (bool, error) {
+	if !IsCanary(cluster) || cluster.Annotations[RolloutsAnnotation] == "" || healthy(cluster) {
+		return false, nil
+	}
+	rollouts, err := Rollouts(cluster)
+	if err != nil {
+		return false, err
+	}
+	changed := false
+	for operation, rollout := range rollouts {
+		if rollout.Failed || !now.Before(rollout.AppliedAt.Add(soak)) {
+			continue
+		}
+		rollout.Failed = true
+		rollout.Message = fmt.Sprintf("cluster was not ready %s after revision %s was applied", now.Sub(rollout.AppliedAt.Time).Round(time.Second), rollout.Revision)
+		rollouts[operation] = rollout
+		changed = true
+	}
+	if !changed {
+		return false, nil
+	}
+	return true, setRollouts(cluster, rollouts)
+}
+
+func healthy(cluster *v3.Cluster) bool {
+	return v3.ClusterConditionReady.IsTrue(cluster)
+}
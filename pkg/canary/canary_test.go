@@ -0,0 +1,103 @@
+package canary
+
+import (
+	"testing"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var now = time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func newCluster(name string, canary, ready bool) *v3.Cluster {
+	cluster := &v3.Cluster{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if canary {
+		cluster.Labels = map[string]string{Label: "true"}
+	}
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	cluster.Status.Conditions = []v3.ClusterCondition{{Type: "Ready", Status: status}}
+	return cluster
+}
+
+func withRollout(t *testing.T, cluster *v3.Cluster, operation string, rollout Rollout) *v3.Cluster {
+	rollouts, err := Rollouts(cluster)
+	require.NoError(t, err)
+	rollouts[operation] = rollout
+	require.NoError(t, setRollouts(cluster, rollouts))
+	return cluster
+}
+
+func TestSoakPeriod(t *testing.T) {
+	assert.Equal(t, 30*time.Minute, SoakPeriod("30m"))
+	assert.Equal(t, time.Duration(0), SoakPeriod("0s"))
+	assert.Equal(t, DefaultSoakPeriod, SoakPeriod("-1h"))
+	assert.Equal(t, DefaultSoakPeriod, SoakPeriod("an hour"))
+}
+
+func TestSetApplied(t *testing.T) {
+	cluster := newCluster("c-canary", false, true)
+	changed, err := SetApplied(cluster, AgentOperation, "agent:v2", now)
+	require.NoError(t, err)
+	assert.False(t, changed, "only canaries record rollouts")
+	assert.Empty(t, cluster.Annotations[RolloutsAnnotation])
+
+	cluster = newCluster("c-canary", true, true)
+	changed, err = SetApplied(cluster, AgentOperation, "agent:v2", now)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	changed, err = SetApplied(cluster, AgentOperation, "agent:v2", now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.False(t, changed, "the time a revision was first applied at is kept")
+
+	changed, err = SetApplied(cluster, PSACTOperation("prod"), "restricted", now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	rollouts, err := Rollouts(cluster)
+	require.NoError(t, err)
+	assert.Len(t, rollouts, 2)
+	assert.Equal(t, "agent:v2", rollouts[AgentOperation].Revision)
+	assert.True(t, now.Equal(rollouts[AgentOperation].AppliedAt.Time))
+	assert.Equal(t, "restricted", rollouts[PSACTOperation("prod")].Revision)
+	assert.True(t, now.Add(time.Hour).Equal(rollouts[PSACTOperation("prod")].AppliedAt.Time))
+
+	cluster.Annotations[RolloutsAnnotation] = "{"
+	changed, err = SetApplied(cluster, AgentOperation, "agent:v3", now)
+	require.NoError(t, err)
+	assert.True(t, changed, "a corrupted annotation is replaced")
+}
+
+func TestCheckHealth(t *testing.T) {
+	soaking := Rollout{Revision: "agent:v2", AppliedAt: metav1.NewTime(now.Add(-10 * time.Minute))}
+	soaked := Rollout{Revision: "chart:v2", AppliedAt: metav1.NewTime(now.Add(-2 * time.Hour))}
+
+	cluster := withRollout(t, newCluster("c-canary", true, true), AgentOperation, soaking)
+	changed, err := CheckHealth(cluster, time.Hour, now)
+	require.NoError(t, err)
+	assert.False(t, changed, "ready canaries pass their health checks")
+
+	cluster = newCluster("c-canary", true, false)
+	withRollout(t, cluster, AgentOperation, soaking)
+	withRollout(t, cluster, SystemUpgradeControllerOperation, soaked)
+	changed, err = CheckHealth(cluster, time.Hour, now)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	rollouts, err := Rollouts(cluster)
+	require.NoError(t, err)
+	assert.True(t, rollouts[AgentOperation].Failed)
+	assert.Equal(t, "cluster was not ready 10m0s after revision agent:v2 was applied", rollouts[AgentOperation].Message)
+	assert.False(t, rollouts[SystemUpgradeControllerOperation].Failed, "rollouts past their soak period aren't checked")
+
+	changed, err = CheckHealth(cluster, time.Hour, now)
+	require.NoError(t, err)
+	assert.False(t, changed, "failed rollouts are kept failed")
+}
@@ -0,0 +1,88 @@
+package canary
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+)
+
+// Decision is whether the revision of an operation may be applied to a cluster.
+type Decision struct {
+	// Proceed is true if the revision may be applied to the cluster.
+	Proceed bool
+	// Halted is true if the rollout of the revision is halted by a canary that failed its health checks.
+	Halted bool
+	// Message explains why the cluster is held.
+	Message string
+	// RetryAfter is when the cluster should be checked again if it's held.
+	RetryAfter time.Duration
+}
+
+// Gate returns whether the revision of an operation may be applied to a cluster, given the clusters the operation
+// applies to. Canaries, and clusters for which the operation has no canaries, proceed right away. Other clusters
+// proceed once every canary has been ready with the revision for the soak period, and are halted as soon as a canary
+// fails its health checks during its soak period.
+func Gate(cluster *v3.Cluster, clusters []*v3.Cluster, operation, revision string, soak time.Duration, now time.Time) Decision {
+	if IsCanary(cluster) {
+		return Decision{Proceed: true}
+	}
+
+	var canaries []*v3.Cluster
+	for _, c := range clusters {
+		if c.Name != cluster.Name && c.DeletionTimestamp == nil && IsCanary(c) {
+			canaries = append(canaries, c)
+		}
+	}
+	sort.Slice(canaries, func(i, j int) bool {
+		return canaries[i].Name < canaries[j].Name
+	})
+
+	var (
+		waiting    []string
+		retryAfter time.Duration
+	)
+	for _, c := range canaries {
+		rollouts, err := Rollouts(c)
+		if err != nil {
+			return Decision{Halted: true, Message: err.Error(), RetryAfter: waitInterval}
+		}
+		rollout, ok := rollouts[operation]
+		if !ok || rollout.Revision != revision {
+			waiting = append(waiting, c.Name)
+			retryAfter = waitInterval
+			continue
+		}
+		if rollout.Failed {
+			return Decision{
+				Halted:     true,
+				Message:    fmt.Sprintf("rollout of %s %s halted, canary cluster %s failed: %s", operation, revision, c.Name, rollout.Message),
+				RetryAfter: waitInterval,
+			}
+		}
+		remaining := rollout.AppliedAt.Add(soak).Sub(now)
+		if remaining <= 0 {
+			continue
+		}
+		if !healthy(c) {
+			return Decision{
+				Halted:     true,
+				Message:    fmt.Sprintf("rollout of %s %s halted, canary cluster %s is not ready", operation, revision, c.Name),
+				RetryAfter: waitInterval,
+			}
+		}
+		waiting = append(waiting, c.Name)
+		if retryAfter == 0 || remaining < retryAfter {
+			retryAfter = remaining
+		}
+	}
+
+	if len(waiting) > 0 {
+		return Decision{
+			Message:    fmt.Sprintf("waiting for %s %s to soak on canary clusters %v", operation, revision, waiting),
+			RetryAfter: retryAfter,
+		}
+	}
+	return Decision{Proceed: true}
+}
@@ -0,0 +1,118 @@
+package canary
+
+import (
+	"testing"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGate(t *testing.T) {
+	const revision = "agent:v2"
+	soak := time.Hour
+	applied := func(ago time.Duration) Rollout {
+		return Rollout{Revision: revision, AppliedAt: metav1.NewTime(now.Add(-ago))}
+	}
+
+	tests := []struct {
+		name       string
+		cluster    *v3.Cluster
+		clusters   func(t *testing.T) []*v3.Cluster
+		proceed    bool
+		halted     bool
+		retryAfter time.Duration
+	}{
+		{
+			name:    "canary",
+			cluster: newCluster("c-canary", true, true),
+			clusters: func(t *testing.T) []*v3.Cluster {
+				return []*v3.Cluster{newCluster("c-other", true, false)}
+			},
+			proceed: true,
+		},
+		{
+			name:    "no canaries",
+			cluster: newCluster("c-1", false, true),
+			clusters: func(t *testing.T) []*v3.Cluster {
+				return []*v3.Cluster{newCluster("c-1", false, true), newCluster("c-2", false, true)}
+			},
+			proceed: true,
+		},
+		{
+			name:    "canary not applied",
+			cluster: newCluster("c-1", false, true),
+			clusters: func(t *testing.T) []*v3.Cluster {
+				return []*v3.Cluster{
+					withRollout(t, newCluster("c-canary", true, true), AgentOperation, Rollout{Revision: "agent:v1", AppliedAt: metav1.NewTime(now.Add(-48 * time.Hour))}),
+				}
+			},
+			retryAfter: waitInterval,
+		},
+		{
+			name:    "soaking",
+			cluster: newCluster("c-1", false, true),
+			clusters: func(t *testing.T) []*v3.Cluster {
+				return []*v3.Cluster{
+					withRollout(t, newCluster("c-canary-1", true, true), AgentOperation, applied(20*time.Minute)),
+					withRollout(t, newCluster("c-canary-2", true, true), AgentOperation, applied(50*time.Minute)),
+				}
+			},
+			retryAfter: 10 * time.Minute,
+		},
+		{
+			name:    "soaked",
+			cluster: newCluster("c-1", false, true),
+			clusters: func(t *testing.T) []*v3.Cluster {
+				return []*v3.Cluster{
+					withRollout(t, newCluster("c-canary-1", true, true), AgentOperation, applied(2*time.Hour)),
+					withRollout(t, newCluster("c-canary-2", true, false), AgentOperation, applied(time.Hour)),
+				}
+			},
+			proceed: true,
+		},
+		{
+			name:    "canary not ready during soak",
+			cluster: newCluster("c-1", false, true),
+			clusters: func(t *testing.T) []*v3.Cluster {
+				return []*v3.Cluster{
+					withRollout(t, newCluster("c-canary", true, false), AgentOperation, applied(5*time.Minute)),
+				}
+			},
+			halted:     true,
+			retryAfter: waitInterval,
+		},
+		{
+			name:    "canary failed",
+			cluster: newCluster("c-1", false, true),
+			clusters: func(t *testing.T) []*v3.Cluster {
+				rollout := applied(2 * time.Hour)
+				rollout.Failed = true
+				return []*v3.Cluster{
+					withRollout(t, newCluster("c-canary", true, true), AgentOperation, rollout),
+				}
+			},
+			halted:     true,
+			retryAfter: waitInterval,
+		},
+		{
+			name:    "deleting canary",
+			cluster: newCluster("c-1", false, true),
+			clusters: func(t *testing.T) []*v3.Cluster {
+				canary := newCluster("c-canary", true, true)
+				canary.DeletionTimestamp = &metav1.Time{Time: now}
+				return []*v3.Cluster{canary}
+			},
+			proceed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := Gate(tt.cluster, tt.clusters(t), AgentOperation, revision, soak, now)
+			assert.Equal(t, tt.proceed, decision.Proceed, decision.Message)
+			assert.Equal(t, tt.halted, decision.Halted, decision.Message)
+			assert.Equal(t, tt.retryAfter, decision.RetryAfter)
+		})
+	}
+}
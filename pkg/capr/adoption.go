@@ -0,0 +1,76 @@
+package capr
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/kv"
+)
+
+// maxFingerprintLength is the length attested identities are truncated to, to fit a label value.
+const maxFingerprintLength = 63
+
+// CustomNodeFingerprint returns the fingerprint of a custom node pre-approved for adoption: the identity it attested, or
+// the hex encoded SHA-256 hash of its machine ID if it didn't attest its identity.
+func CustomNodeFingerprint(machineID, attestedIdentity string) string {
+	if attestedIdentity != "" {
+		return attestedIdentity
+	}
+	hash := sha256.Sum256([]byte(machineID))
+	return hex.EncodeToString(hash[:])
+}
+
+// ParseNodeLabels parses the comma separated key=value labels passed to the registration command of a custom node.
+func ParseNodeLabels(labels string) map[string]string {
+	result := map[string]string{}
+	for _, str := range strings.Split(labels, ",") {
+		k, v := kv.Split(str, "=")
+		if k == "" {
+			continue
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// AdoptionPool returns the pool a custom node of the fingerprint and labels is adopted into, the first pool it matches,
+// or nil if the node isn't pre-approved or matches no pool.
+func AdoptionPool(adoption *provv1.CustomNodeAdoption, fingerprint string, nodeLabels map[string]string) *provv1.CustomNodeAdoptionPool {
+	if adoption == nil || !approvedFingerprint(adoption.Fingerprints, fingerprint) {
+		return nil
+	}
+	for i, pool := range adoption.Pools {
+		if matchesSelector(pool.NodeSelector, nodeLabels) {
+			return &adoption.Pools[i]
+		}
+	}
+	return nil
+}
+
+// approvedFingerprint compares the fingerprints ignoring case, and only up to the length attested identities are
+// truncated to, so that the full hash of a TPM key can be pre-approved.
+func approvedFingerprint(approved []string, fingerprint string) bool {
+	if fingerprint == "" {
+		return false
+	}
+	for _, f := range approved {
+		if len(f) > maxFingerprintLength && len(fingerprint) == maxFingerprintLength {
+			f = f[:maxFingerprintLength]
+		}
+		if strings.EqualFold(f, fingerprint) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesSelector(selector, nodeLabels map[string]string) bool {
+	for k, v := range selector {
+		if value, ok := nodeLabels[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
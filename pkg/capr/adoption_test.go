@@ -0,0 +1,65 @@
+package capr
+
+import (
+	"strings"
+	"testing"
+
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomNodeFingerprint(t *testing.T) {
+	assert.Equal(t, "i-0123456789", CustomNodeFingerprint("machine-1", "i-0123456789"))
+	assert.Equal(t, "f7a7266df8b420793d51b92561955db28792ce00570593d47d44d954189b3685", CustomNodeFingerprint("machine-1", ""))
+}
+
+func TestParseNodeLabels(t *testing.T) {
+	assert.Equal(t, map[string]string{"rack": "r1", "gpu": "", "zone": "a=b"}, ParseNodeLabels("rack=r1,gpu,,zone=a=b"))
+	assert.Equal(t, map[string]string{}, ParseNodeLabels(""))
+}
+
+func TestAdoptionPool(t *testing.T) {
+	tpmKeyHash := strings.Repeat("ab", 32)
+	adoption := &provv1.CustomNodeAdoption{
+		Fingerprints: []string{"i-0123456789", strings.ToUpper(tpmKeyHash)},
+		Pools: []provv1.CustomNodeAdoptionPool{
+			{Name: "servers", NodeSelector: map[string]string{"node-role": "server"}, EtcdRole: true, ControlPlaneRole: true},
+			{Name: "gpu", NodeSelector: map[string]string{"node-role": "worker", "gpu": "true"}, WorkerRole: true},
+			{Name: "workers", WorkerRole: true},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		adoption    *provv1.CustomNodeAdoption
+		fingerprint string
+		labels      map[string]string
+		expected    string
+	}{
+		{name: "no adoption", fingerprint: "i-0123456789"},
+		{name: "not approved", adoption: adoption, fingerprint: "i-9876543210", labels: map[string]string{"node-role": "server"}},
+		{name: "no fingerprint", adoption: adoption, labels: map[string]string{"node-role": "server"}},
+		{name: "servers", adoption: adoption, fingerprint: "i-0123456789", labels: map[string]string{"node-role": "server"}, expected: "servers"},
+		{name: "selector partially matching", adoption: adoption, fingerprint: "i-0123456789", labels: map[string]string{"node-role": "worker"}, expected: "workers"},
+		{name: "selector matching", adoption: adoption, fingerprint: "i-0123456789", labels: map[string]string{"node-role": "worker", "gpu": "true"}, expected: "gpu"},
+		{name: "truncated tpm key hash", adoption: adoption, fingerprint: tpmKeyHash[:63], expected: "workers"},
+		{
+			name:        "no matching pool",
+			adoption:    &provv1.CustomNodeAdoption{Fingerprints: adoption.Fingerprints, Pools: adoption.Pools[:2]},
+			fingerprint: "i-0123456789",
+			labels:      map[string]string{"node-role": "worker"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := AdoptionPool(tt.adoption, tt.fingerprint, tt.labels)
+			if tt.expected == "" {
+				assert.Nil(t, pool)
+				return
+			}
+			if assert.NotNil(t, pool) {
+				assert.Equal(t, tt.expected, pool.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Package bootstrapkey manages the one-time bootstrap keys of machines. When a cluster has oneTimeBootstrapKeys
+// enabled, the bootstrap data of each of its machines embeds a key unique to the machine, rather than a token that stays
+// valid for the lifetime of the machine. The key is exchanged once for the credentials of the plan of the machine, after
+// which it's invalid, so that leaked bootstrap data can't be used to retrieve them.
+package bootstrapkey
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"time"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/rancher/wrangler/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// UsedAnnotation is when a bootstrap key was exchanged, after which it's invalid.
+	UsedAnnotation = "rke.cattle.io/bootstrap-key-used"
+
+	keyField = "key"
+	keySize  = 32
+)
+
+// SecretName returns the name of the secret of the bootstrap key of a bootstrap.
+func SecretName(bootstrapName string) string {
+	return name.SafeConcatName(bootstrapName, "bootstrap", "key")
+}
+
+// Ensure returns the bootstrap key of a bootstrap, creating it if the bootstrap doesn't have one yet. The key of a
+// bootstrap never changes, so that its bootstrap data is stable, even once the key was used.
+func Ensure(secrets corecontrollers.SecretClient, secretsCache corecontrollers.SecretCache, bootstrap *rkev1.RKEBootstrap, machineName string) (string, error) {
+	secret, err := get(secrets, secretsCache, bootstrap.Namespace, SecretName(bootstrap.Name))
+	if err == nil {
+		return string(secret.Data[keyField]), nil
+	} else if !apierrors.IsNotFound(err) {
+		return "", err
+	}
+
+	key, err := generate()
+	if err != nil {
+		return "", err
+	}
+	_, err = secrets.Create(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SecretName(bootstrap.Name),
+			Namespace: bootstrap.Namespace,
+			Labels: map[string]string{
+				capr.MachineNameLabel: machineName,
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: rkev1.SchemeGroupVersion.String(),
+				Kind:       "RKEBootstrap",
+				Name:       bootstrap.Name,
+				UID:        bootstrap.UID,
+			}},
+		},
+		Type: capr.SecretTypeBootstrapKey,
+		Data: map[string][]byte{
+			keyField: []byte(key),
+		},
+	})
+	if apierrors.IsAlreadyExists(err) {
+		// The bootstrap was reconciled concurrently, use the key it created.
+		secret, err = get(secrets, secretsCache, bootstrap.Namespace, SecretName(bootstrap.Name))
+		if err != nil {
+			return "", err
+		}
+		return string(secret.Data[keyField]), nil
+	} else if err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// Index returns the key of a bootstrap key secret if it wasn't used yet, for the secrets to be looked up by the keys
+// presented by machines.
+func Index(secret *corev1.Secret) []string {
+	if secret.Type != capr.SecretTypeBootstrapKey || Used(secret) || len(secret.Data[keyField]) == 0 {
+		return nil
+	}
+	return []string{string(secret.Data[keyField])}
+}
+
+// Used returns true if the bootstrap key was already exchanged.
+func Used(secret *corev1.Secret) bool {
+	return secret.Annotations[UsedAnnotation] != ""
+}
+
+// Use records that a bootstrap key was exchanged, invalidating it. The update fails with a conflict if the key was
+// exchanged concurrently, so that it's only ever exchanged once.
+func Use(secrets corecontrollers.SecretClient, secret *corev1.Secret, now time.Time) error {
+	secret = secret.DeepCopy()
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[UsedAnnotation] = now.UTC().Format(time.RFC3339)
+	_, err := secrets.Update(secret)
+	return err
+}
+
+func get(secrets corecontrollers.SecretClient, secretsCache corecontrollers.SecretCache, namespace, name string) (*corev1.Secret, error) {
+	secret, err := secretsCache.Get(namespace, name)
+	if apierrors.IsNotFound(err) {
+		// The cache may not have seen a key created just now.
+		return secrets.Get(namespace, name, metav1.GetOptions{})
+	}
+	return secret, err
+}
+
+func generate() (string, error) {
+	key := make([]byte, keySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(key), nil
+}
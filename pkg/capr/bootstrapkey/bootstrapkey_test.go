@@ -0,0 +1,124 @@
+package bootstrapkey
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/rancher/pkg/capr/mock/mockcorecontrollers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var secretsResource = schema.GroupResource{Resource: "secrets"}
+
+func testBootstrap() *rkev1.RKEBootstrap {
+	return &rkev1.RKEBootstrap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pool-abcde",
+			Namespace: "fleet-default",
+			UID:       "bootstrap-uid",
+		},
+	}
+}
+
+func keySecret(key string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SecretName("pool-abcde"),
+			Namespace: "fleet-default",
+		},
+		Type: capr.SecretTypeBootstrapKey,
+		Data: map[string][]byte{keyField: []byte(key)},
+	}
+}
+
+func TestEnsureCreates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	secrets := mockcorecontrollers.NewMockSecretClient(ctrl)
+	secretsCache := mockcorecontrollers.NewMockSecretCache(ctrl)
+	notFound := apierrors.NewNotFound(secretsResource, SecretName("pool-abcde"))
+
+	secretsCache.EXPECT().Get("fleet-default", SecretName("pool-abcde")).Return(nil, notFound)
+	secrets.EXPECT().Get("fleet-default", SecretName("pool-abcde"), metav1.GetOptions{}).Return(nil, notFound)
+	var created *corev1.Secret
+	secrets.EXPECT().Create(gomock.Any()).DoAndReturn(func(secret *corev1.Secret) (*corev1.Secret, error) {
+		created = secret
+		return secret, nil
+	})
+
+	key, err := Ensure(secrets, secretsCache, testBootstrap(), "pool-abcde")
+	require.NoError(t, err)
+	assert.Len(t, key, 43)
+	assert.Equal(t, key, string(created.Data[keyField]))
+	assert.Equal(t, capr.SecretTypeBootstrapKey, string(created.Type))
+	assert.Equal(t, "pool-abcde", created.Labels[capr.MachineNameLabel])
+	require.Len(t, created.OwnerReferences, 1)
+	assert.Equal(t, "RKEBootstrap", created.OwnerReferences[0].Kind)
+}
+
+func TestEnsureExisting(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	secrets := mockcorecontrollers.NewMockSecretClient(ctrl)
+	secretsCache := mockcorecontrollers.NewMockSecretCache(ctrl)
+
+	used := keySecret("existing")
+	used.Annotations = map[string]string{UsedAnnotation: "2023-05-01T12:00:00Z"}
+	secretsCache.EXPECT().Get("fleet-default", SecretName("pool-abcde")).Return(used, nil)
+
+	key, err := Ensure(secrets, secretsCache, testBootstrap(), "pool-abcde")
+	require.NoError(t, err)
+	assert.Equal(t, "existing", key, "the key of a bootstrap never changes, even once used")
+}
+
+func TestEnsureConcurrent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	secrets := mockcorecontrollers.NewMockSecretClient(ctrl)
+	secretsCache := mockcorecontrollers.NewMockSecretCache(ctrl)
+	notFound := apierrors.NewNotFound(secretsResource, SecretName("pool-abcde"))
+
+	gomock.InOrder(
+		secretsCache.EXPECT().Get("fleet-default", SecretName("pool-abcde")).Return(nil, notFound),
+		secrets.EXPECT().Get("fleet-default", SecretName("pool-abcde"), metav1.GetOptions{}).Return(nil, notFound),
+		secrets.EXPECT().Create(gomock.Any()).Return(nil, apierrors.NewAlreadyExists(secretsResource, SecretName("pool-abcde"))),
+		secretsCache.EXPECT().Get("fleet-default", SecretName("pool-abcde")).Return(nil, notFound),
+		secrets.EXPECT().Get("fleet-default", SecretName("pool-abcde"), metav1.GetOptions{}).Return(keySecret("concurrent"), nil),
+	)
+
+	key, err := Ensure(secrets, secretsCache, testBootstrap(), "pool-abcde")
+	require.NoError(t, err)
+	assert.Equal(t, "concurrent", key)
+}
+
+func TestIndex(t *testing.T) {
+	secret := keySecret("key")
+	assert.Equal(t, []string{"key"}, Index(secret))
+
+	secret.Annotations = map[string]string{UsedAnnotation: "2023-05-01T12:00:00Z"}
+	assert.Empty(t, Index(secret), "used keys are invalid")
+
+	other := keySecret("key")
+	other.Type = corev1.SecretTypeOpaque
+	assert.Empty(t, Index(other))
+}
+
+func TestUse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	secrets := mockcorecontrollers.NewMockSecretClient(ctrl)
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	secret := keySecret("key")
+	secrets.EXPECT().Update(gomock.Any()).DoAndReturn(func(secret *corev1.Secret) (*corev1.Secret, error) {
+		assert.Equal(t, "2023-05-01T12:00:00Z", secret.Annotations[UsedAnnotation])
+		assert.True(t, Used(secret))
+		return secret, nil
+	})
+	require.NoError(t, Use(secrets, secret, now))
+	assert.False(t, Used(secret), "the secret is copied before it's updated")
+}
@@ -41,42 +41,69 @@
 
 const (
 	AddressAnnotation = "rke.cattle.io/address"
-	ClusterNameLabel  = "rke.cattle.io/cluster-name"
+	// AdoptedPoolLabel is the adoption pool a custom machine was adopted into, see provisioning.cattle.io/v1 CustomNodeAdoption.
+	AdoptedPoolLabel = "rke.cattle.io/adopted-pool"
+	// AttestedIdentityLabel is the identity a custom machine attested when it registered, see provisioning.cattle.io/v1 NodeAttestation.
+	AttestedIdentityLabel = "rke.cattle.io/attested-identity"
+	ClusterNameLabel      = "rke.cattle.io/cluster-name"
 	// ClusterSpecAnnotation is used to define the cluster spec used to generate the rkecontrolplane object as an annotation on the object
 	ClusterSpecAnnotation         = "rke.cattle.io/cluster-spec"
 	ControlPlaneRoleLabel         = "rke.cattle.io/control-plane-role"
+	DeletionHookStatusAnnotation  = "rke.cattle.io/deletion-hook-status"
 	DrainAnnotation               = "rke.cattle.io/drain-options"
 	DrainDoneAnnotation           = "rke.cattle.io/drain-done"
 	DrainErrorAnnotation          = "rke.cattle.io/drain-error"
 	EtcdRoleLabel                 = "rke.cattle.io/etcd-role"
 	ForceRemoveEtcdAnnotation     = "rke.cattle.io/etcd-force-remove"
+	GarbageCollectionAnnotation   = "rke.cattle.io/garbage-collection"
 	HostnameLengthLimitAnnotation = "rke.cattle.io/hostname-length-limit"
 	InitNodeLabel                 = "rke.cattle.io/init-node"
 	InitNodeMachineIDLabel        = "rke.cattle.io/init-node-machine-id"
+	InitNodePinAnnotation         = "rke.cattle.io/init-node-pin"
 	InternalAddressAnnotation     = "rke.cattle.io/internal-address"
 	JoinURLAutosetDisabled        = "rke.cattle.io/join-url-autoset-disabled"
 	JoinURLAnnotation             = "rke.cattle.io/join-url"
 	JoinedToAnnotation            = "rke.cattle.io/joined-to"
 	LabelsAnnotation              = "rke.cattle.io/labels"
-	MachineIDLabel                = "rke.cattle.io/machine-id"
-	MachineNameLabel              = "rke.cattle.io/machine-name"
-	MachineTemplateHashLabel      = "rke.cattle.io/machine-template-hash"
-	RKEMachinePoolNameLabel       = "rke.cattle.io/rke-machine-pool-name"
-	MachineNamespaceLabel         = "rke.cattle.io/machine-namespace"
-	MachineRequestType            = "rke.cattle.io/machine-request"
-	MachineUIDLabel               = "rke.cattle.io/machine"
-	NodeNameLabel                 = "rke.cattle.io/node-name"
-	PlanSecret                    = "rke.cattle.io/plan-secret-name"
-	PostDrainAnnotation           = "rke.cattle.io/post-drain"
-	PreDrainAnnotation            = "rke.cattle.io/pre-drain"
-	RoleLabel                     = "rke.cattle.io/service-account-role"
-	TaintsAnnotation              = "rke.cattle.io/taints"
-	UnCordonAnnotation            = "rke.cattle.io/uncordon"
-	WorkerRoleLabel               = "rke.cattle.io/worker-role"
-	AuthorizedObjectAnnotation    = "rke.cattle.io/object-authorized-for-clusters"
-
-	SecretTypeMachinePlan  = "rke.cattle.io/machine-plan"
-	SecretTypeClusterState = "rke.cattle.io/cluster-state"
+	// LiveMigrationAnnotation is when the live migration of the VM of a machine started, while it's being migrated.
+	LiveMigrationAnnotation = "rke.cattle.io/live-migration"
+	// LiveMigrationCordonedAnnotation marks the machines whose node was cordoned during the live migration of their VM.
+	LiveMigrationCordonedAnnotation = "rke.cattle.io/live-migration-cordoned"
+	MachineIDLabel                  = "rke.cattle.io/machine-id"
+	MachineNameLabel                = "rke.cattle.io/machine-name"
+	MachineTemplateHashLabel        = "rke.cattle.io/machine-template-hash"
+	RKEMachinePoolNameLabel         = "rke.cattle.io/rke-machine-pool-name"
+	MachineNamespaceLabel           = "rke.cattle.io/machine-namespace"
+	MachineRequestType              = "rke.cattle.io/machine-request"
+	MachineReplacementAnnotation    = "rke.cattle.io/machine-replacement"
+	MachineUIDLabel                 = "rke.cattle.io/machine"
+	MaintenanceAnnotation           = "rke.cattle.io/node-maintenance"
+	MaintenanceStatusAnnotation     = "rke.cattle.io/node-maintenance-status"
+	NodeNameLabel                   = "rke.cattle.io/node-name"
+	PlanSecret                      = "rke.cattle.io/plan-secret-name"
+	PostDrainAnnotation             = "rke.cattle.io/post-drain"
+	PreDrainAnnotation              = "rke.cattle.io/pre-drain"
+	PreserveDataLabel               = "rke.cattle.io/preserve-data"
+	ReplacementErrorAnnotation      = "rke.cattle.io/machine-replacement-error"
+	RoleLabel                       = "rke.cattle.io/service-account-role"
+	SkipDeletionHooksAnnotation     = "rke.cattle.io/skip-deletion-hooks"
+	TaintsAnnotation                = "rke.cattle.io/taints"
+	UnCordonAnnotation              = "rke.cattle.io/uncordon"
+	WorkerRoleLabel                 = "rke.cattle.io/worker-role"
+	AuthorizedObjectAnnotation      = "rke.cattle.io/object-authorized-for-clusters"
+
+	// AppliedPlanChecksumAnnotation is the checksum of the plan last applied to a machine by its system-agent.
+	AppliedPlanChecksumAnnotation = "rke.cattle.io/applied-plan-checksum"
+	// PlanAppliedTimeAnnotation is when the plan of the AppliedPlanChecksumAnnotation of a machine was applied.
+	PlanAppliedTimeAnnotation = "rke.cattle.io/plan-applied-time"
+	// PlanPendingReapplicationsAnnotation is how many times applying the desired plan of a machine failed and is retried
+	// by its system-agent, 0 once the plan is applied.
+	PlanPendingReapplicationsAnnotation = "rke.cattle.io/plan-pending-reapplications"
+
+	SecretTypeMachinePlan       = "rke.cattle.io/machine-plan"
+	SecretTypeClusterState      = "rke.cattle.io/cluster-state"
+	SecretTypePlanEncryptionKey = "rke.cattle.io/plan-encryption-key"
+	SecretTypeBootstrapKey      = "rke.cattle.io/bootstrap-key"
 
 	MachineTemplateClonedFromGroupVersionAnn = "rke.cattle.io/cloned-from-group-version"
 	MachineTemplateClonedFromKindAnn         = "rke.cattle.io/cloned-from-kind"
@@ -102,6 +129,13 @@
 	InfrastructureReady          = condition.Cond(capi.InfrastructureReadyCondition)
 	SystemUpgradeControllerReady = condition.Cond("SystemUpgradeControllerReady")
 	Bootstrapped                 = condition.Cond("Bootstrapped")
+	AdmissionRejected            = condition.Cond("AdmissionRejected")
+	// TLSSANsPending is true while SANs added to the config of control plane nodes aren't served yet, as they're only
+	// added to the serving certificates once the runtime of the nodes is next restarted.
+	TLSSANsPending = condition.Cond("TLSSANsPending")
+	// PlannerBehaviorsDisabled is true while fields of the spec of the cluster are ignored, as the planner behaviors
+	// using them are disabled.
+	PlannerBehaviorsDisabled = condition.Cond("PlannerBehaviorsDisabled")
 
 	RuntimeK3S  = "k3s"
 	RuntimeRKE2 = "rke2"
@@ -221,10 +255,10 @@ func GetRuntimeSupervisorPort(kubernetesVersion string) int {
 	return 6443
 }
 
-func IsOwnedByMachine(bootstrapCache rkecontroller.RKEBootstrapCache, machineName string, sa *corev1.ServiceAccount) (bool, error) {
-	for _, owner := range sa.OwnerReferences {
+func IsOwnedByMachine(bootstrapCache rkecontroller.RKEBootstrapCache, machineName string, obj metav1.Object) (bool, error) {
+	for _, owner := range obj.GetOwnerReferences() {
 		if owner.Kind == "RKEBootstrap" {
-			bootstrap, err := bootstrapCache.Get(sa.Namespace, owner.Name)
+			bootstrap, err := bootstrapCache.Get(obj.GetNamespace(), owner.Name)
 			if err != nil {
 				return false, err
 			}
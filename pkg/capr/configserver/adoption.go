@@ -0,0 +1,47 @@
+package configserver
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/rancher/pkg/controllers/dashboard/clusterindex"
+	"github.com/sirupsen/logrus"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const labelsHeader = headerPrefix + "Labels"
+
+// checkAdoption refuses the registration of a node with a cluster registration token if the cluster of the token only
+// adopts pre-approved custom nodes, and the node isn't pre-approved or matches no adoption pool. The pool the node is
+// adopted into is picked when its machine is created.
+func (r *RKE2ConfigServer) checkAdoption(req *http.Request, token *v3.ClusterRegistrationToken, machineID, identity string) error {
+	clusters, err := r.provisioningClusterCache.GetByIndex(clusterindex.ClusterV1ByClusterV3Reference, token.Namespace)
+	if err != nil || len(clusters) == 0 {
+		return err
+	}
+	cluster := clusters[0]
+	if cluster.Spec.RKEConfig == nil || cluster.Spec.RKEConfig.CustomNodeAdoption == nil ||
+		!cluster.Spec.RKEConfig.CustomNodeAdoption.RejectUnmatched {
+		return nil
+	}
+
+	// Existing machines registering again were already adopted, or registered before adoption was required.
+	machines, err := r.machineCache.List(cluster.Namespace, labels.SelectorFromSet(map[string]string{
+		capr.MachineIDLabel: machineID,
+	}))
+	if err != nil || len(machines) > 0 {
+		return err
+	}
+
+	fingerprint := capr.CustomNodeFingerprint(machineID, identity)
+	nodeLabels := capr.ParseNodeLabels(strings.Join(req.Header.Values(labelsHeader), ","))
+	if capr.AdoptionPool(cluster.Spec.RKEConfig.CustomNodeAdoption, fingerprint, nodeLabels) == nil {
+		logrus.Warnf("[rke2configserver] machine ID %s with fingerprint %s isn't pre-approved for any adoption pool of cluster %s/%s", machineID, fingerprint, cluster.Namespace, cluster.Name)
+		return apierror.NewForbidden(attestationResource, cluster.Name, fmt.Errorf("node isn't pre-approved for any adoption pool"))
+	}
+	return nil
+}
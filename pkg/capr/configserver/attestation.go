@@ -0,0 +1,204 @@
+package configserver
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/rancher/pkg/controllers/dashboard/clusterindex"
+	"github.com/sirupsen/logrus"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// attestationHeaderPrefix prefixes the headers holding the attestation of a node, they are not part of the data of
+	// machine requests.
+	attestationHeaderPrefix = headerPrefix + "Attestation-"
+
+	// awsDocumentHeader and awsSignatureHeader hold the base64 encoded instance identity document of a node and its
+	// base64 encoded signature, as served by the instance metadata service.
+	awsDocumentHeader  = attestationHeaderPrefix + "Aws-Document"
+	awsSignatureHeader = attestationHeaderPrefix + "Aws-Signature"
+
+	// tpmKeyHeader holds the base64 encoded DER public key of the TPM resident key of a node, tpmTimestampHeader the
+	// RFC 3339 time the attestation was made at, and tpmSignatureHeader the base64 encoded signature of the machine ID
+	// and the timestamp made with the key.
+	tpmKeyHeader       = attestationHeaderPrefix + "Tpm-Key"
+	tpmTimestampHeader = attestationHeaderPrefix + "Tpm-Timestamp"
+	tpmSignatureHeader = attestationHeaderPrefix + "Tpm-Signature"
+
+	// maxAttestationAge is how long a TPM attestation can be used for, and how far clocks can drift.
+	maxAttestationAge = 5 * time.Minute
+)
+
+var attestationResource = schema.GroupResource{Group: "provisioning.cattle.io", Resource: "clusters"}
+
+// attest verifies the attestation of a node registering with a cluster registration token, if the cluster of the token
+// requires custom nodes to attest their identity. It returns the attested identity of the node, empty if the cluster
+// doesn't require attestation. A forbidden error is returned if the node fails attestation, or if its identity is
+// already used by another machine of the cluster.
+func (r *RKE2ConfigServer) attest(req *http.Request, token *v3.ClusterRegistrationToken, machineID string) (string, error) {
+	clusters, err := r.provisioningClusterCache.GetByIndex(clusterindex.ClusterV1ByClusterV3Reference, token.Namespace)
+	if err != nil || len(clusters) == 0 {
+		return "", err
+	}
+	cluster := clusters[0]
+	if cluster.Spec.RKEConfig == nil || cluster.Spec.RKEConfig.NodeAttestation == nil {
+		return "", nil
+	}
+
+	identity, err := verifyAttestation(req, cluster.Spec.RKEConfig.NodeAttestation, machineID, time.Now())
+	if err != nil {
+		logrus.Warnf("[rke2configserver] machine ID %s failed node attestation for cluster %s/%s: %v", machineID, cluster.Namespace, cluster.Name, err)
+		return "", apierror.NewForbidden(attestationResource, cluster.Name, fmt.Errorf("node attestation failed: %w", err))
+	}
+
+	// An attestation can be replayed by anyone holding it, an identity is only allowed for a single machine.
+	machines, err := r.machineCache.List(cluster.Namespace, labels.SelectorFromSet(map[string]string{
+		capr.AttestedIdentityLabel: identity,
+	}))
+	if err != nil {
+		return "", err
+	}
+	for _, machine := range machines {
+		if machine.Labels[capr.MachineIDLabel] != machineID {
+			logrus.Warnf("[rke2configserver] machine ID %s presented the attested identity of machine %s/%s", machineID, machine.Namespace, machine.Name)
+			return "", apierror.NewForbidden(attestationResource, cluster.Name, fmt.Errorf("node attestation failed: identity is used by another machine"))
+		}
+	}
+
+	return identity, nil
+}
+
+// verifyAttestation verifies the attestation presented by a node, and returns the identity it attests, a value suitable
+// as a label value.
+func verifyAttestation(req *http.Request, attestation *rancherv1.NodeAttestation, machineID string, now time.Time) (string, error) {
+	switch attestation.Type {
+	case rancherv1.NodeAttestationAWSInstanceIdentity:
+		document, err := base64.StdEncoding.DecodeString(req.Header.Get(awsDocumentHeader))
+		if err != nil || len(document) == 0 {
+			return "", fmt.Errorf("missing or invalid instance identity document")
+		}
+		signature, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(req.Header.Get(awsSignatureHeader), "\n", ""))
+		if err != nil || len(signature) == 0 {
+			return "", fmt.Errorf("missing or invalid instance identity signature")
+		}
+		return verifyInstanceIdentity(attestation, document, signature)
+	case rancherv1.NodeAttestationTPM:
+		key, err := base64.StdEncoding.DecodeString(req.Header.Get(tpmKeyHeader))
+		if err != nil || len(key) == 0 {
+			return "", fmt.Errorf("missing or invalid TPM key")
+		}
+		signature, err := base64.StdEncoding.DecodeString(req.Header.Get(tpmSignatureHeader))
+		if err != nil || len(signature) == 0 {
+			return "", fmt.Errorf("missing or invalid TPM signature")
+		}
+		return verifyTPMKey(attestation, key, signature, machineID, req.Header.Get(tpmTimestampHeader), now)
+	default:
+		return "", fmt.Errorf("unsupported attestation type %q", attestation.Type)
+	}
+}
+
+type instanceIdentityDocument struct {
+	AccountID  string `json:"accountId"`
+	InstanceID string `json:"instanceId"`
+	Region     string `json:"region"`
+}
+
+// verifyInstanceIdentity verifies an AWS instance identity document against its SHA256 RSA signature, and returns the
+// instance ID it attests.
+func verifyInstanceIdentity(attestation *rancherv1.NodeAttestation, document, signature []byte) (string, error) {
+	block, _ := pem.Decode([]byte(attestation.AWSCertificate))
+	if block == nil {
+		return "", fmt.Errorf("cluster has no valid AWS certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("cluster has no valid AWS certificate: %w", err)
+	}
+	publicKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return "", fmt.Errorf("AWS certificate doesn't hold an RSA public key")
+	}
+	hash := sha256.Sum256(document)
+	if err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hash[:], signature); err != nil {
+		return "", fmt.Errorf("invalid instance identity signature")
+	}
+
+	var identity instanceIdentityDocument
+	if err := json.Unmarshal(document, &identity); err != nil {
+		return "", fmt.Errorf("invalid instance identity document: %w", err)
+	}
+	if identity.InstanceID == "" {
+		return "", fmt.Errorf("instance identity document has no instance ID")
+	}
+	if len(attestation.AWSAccountIDs) > 0 && !contains(attestation.AWSAccountIDs, identity.AccountID) {
+		return "", fmt.Errorf("AWS account %s is not allowed", identity.AccountID)
+	}
+	if len(attestation.AWSRegions) > 0 && !contains(attestation.AWSRegions, identity.Region) {
+		return "", fmt.Errorf("AWS region %s is not allowed", identity.Region)
+	}
+	return identity.InstanceID, nil
+}
+
+// verifyTPMKey verifies that a node holds an allowed TPM resident key, from its signature of the machine ID and of a
+// recent timestamp. It returns the hash of the key, truncated to fit a label value.
+func verifyTPMKey(attestation *rancherv1.NodeAttestation, key, signature []byte, machineID, timestamp string, now time.Time) (string, error) {
+	keyHash := sha256.Sum256(key)
+	keyHashHex := hex.EncodeToString(keyHash[:])
+	if !contains(attestation.TPMKeyHashes, keyHashHex) {
+		return "", fmt.Errorf("TPM key %s is not allowed", keyHashHex)
+	}
+
+	signedAt, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return "", fmt.Errorf("invalid TPM attestation timestamp")
+	}
+	if age := now.Sub(signedAt); age > maxAttestationAge || age < -maxAttestationAge {
+		return "", fmt.Errorf("TPM attestation is expired")
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(key)
+	if err != nil {
+		return "", fmt.Errorf("invalid TPM key: %w", err)
+	}
+	hash := sha256.Sum256([]byte(machineID + "\n" + timestamp))
+	switch publicKey := publicKey.(type) {
+	case *rsa.PublicKey:
+		err = rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hash[:], signature)
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(publicKey, hash[:], signature) {
+			err = fmt.Errorf("invalid signature")
+		}
+	default:
+		err = fmt.Errorf("unsupported key type %T", publicKey)
+	}
+	if err != nil {
+		return "", fmt.Errorf("invalid TPM signature: %w", err)
+	}
+	return keyHashHex[:63], nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
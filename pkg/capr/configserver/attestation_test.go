@@ -0,0 +1,164 @@
+package configserver
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyInstanceIdentity(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "aws"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	certificate := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+
+	document := []byte(`{"accountId":"123456789012","instanceId":"i-0123456789abcdef0","region":"us-west-2"}`)
+	hash := sha256.Sum256(document)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	require.NoError(t, err)
+
+	request := func(document, signature []byte) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, ConnectAgent, nil)
+		req.Header.Set(awsDocumentHeader, base64.StdEncoding.EncodeToString(document))
+		req.Header.Set(awsSignatureHeader, base64.StdEncoding.EncodeToString(signature))
+		return req
+	}
+
+	tests := []struct {
+		name        string
+		attestation rancherv1.NodeAttestation
+		req         *http.Request
+		identity    string
+		wantErr     bool
+	}{
+		{
+			name:        "valid",
+			attestation: rancherv1.NodeAttestation{AWSCertificate: certificate},
+			req:         request(document, signature),
+			identity:    "i-0123456789abcdef0",
+		},
+		{
+			name:        "allowed account and region",
+			attestation: rancherv1.NodeAttestation{AWSCertificate: certificate, AWSAccountIDs: []string{"123456789012"}, AWSRegions: []string{"us-east-1", "us-west-2"}},
+			req:         request(document, signature),
+			identity:    "i-0123456789abcdef0",
+		},
+		{
+			name:        "other account",
+			attestation: rancherv1.NodeAttestation{AWSCertificate: certificate, AWSAccountIDs: []string{"210987654321"}},
+			req:         request(document, signature),
+			wantErr:     true,
+		},
+		{
+			name:        "other region",
+			attestation: rancherv1.NodeAttestation{AWSCertificate: certificate, AWSRegions: []string{"eu-west-1"}},
+			req:         request(document, signature),
+			wantErr:     true,
+		},
+		{
+			name:        "tampered document",
+			attestation: rancherv1.NodeAttestation{AWSCertificate: certificate},
+			req:         request([]byte(`{"accountId":"123456789012","instanceId":"i-1","region":"us-west-2"}`), signature),
+			wantErr:     true,
+		},
+		{
+			name:        "no attestation",
+			attestation: rancherv1.NodeAttestation{AWSCertificate: certificate},
+			req:         httptest.NewRequest(http.MethodGet, ConnectAgent, nil),
+			wantErr:     true,
+		},
+		{
+			name:        "no certificate",
+			attestation: rancherv1.NodeAttestation{},
+			req:         request(document, signature),
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.attestation.Type = rancherv1.NodeAttestationAWSInstanceIdentity
+			identity, err := verifyAttestation(tt.req, &tt.attestation, "machine-id", time.Now())
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.identity, identity)
+		})
+	}
+}
+
+func TestVerifyTPMKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	keyHash := sha256.Sum256(publicKey)
+	attestation := &rancherv1.NodeAttestation{
+		Type:         rancherv1.NodeAttestationTPM,
+		TPMKeyHashes: []string{hex.EncodeToString(keyHash[:])},
+	}
+
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	request := func(machineID string, signedAt time.Time) *http.Request {
+		timestamp := signedAt.Format(time.RFC3339)
+		hash := sha256.Sum256([]byte(machineID + "\n" + timestamp))
+		signature, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodGet, ConnectAgent, nil)
+		req.Header.Set(tpmKeyHeader, base64.StdEncoding.EncodeToString(publicKey))
+		req.Header.Set(tpmTimestampHeader, timestamp)
+		req.Header.Set(tpmSignatureHeader, base64.StdEncoding.EncodeToString(signature))
+		return req
+	}
+
+	identity, err := verifyAttestation(request("machine-id", now.Add(-time.Minute)), attestation, "machine-id", now)
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(keyHash[:])[:63], identity)
+
+	_, err = verifyAttestation(request("other-machine-id", now), attestation, "machine-id", now)
+	assert.Error(t, err, "signature of another machine ID")
+
+	_, err = verifyAttestation(request("machine-id", now.Add(-10*time.Minute)), attestation, "machine-id", now)
+	assert.Error(t, err, "expired attestation")
+
+	_, err = verifyAttestation(request("machine-id", now), &rancherv1.NodeAttestation{
+		Type:         rancherv1.NodeAttestationTPM,
+		TPMKeyHashes: []string{"0000"},
+	}, "machine-id", now)
+	assert.Error(t, err, "key not allowed")
+}
+
+func TestDataFromHeadersSkipsAttestation(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, ConnectAgent, nil)
+	req.Header.Set("X-Cattle-Role-Worker", "true")
+	req.Header.Set(awsDocumentHeader, "document")
+	data := dataFromHeaders(req)
+	assert.Contains(t, data, "role-worker")
+	assert.Len(t, data, 1)
+}
@@ -43,6 +43,18 @@ func (r *RKE2ConfigServer) findMachineByClusterToken(req *http.Request) (string,
 		return "", "", nil
 	}
 
+	identity, err := r.attest(req, tokens[0], machineID)
+	if err != nil {
+		return "", "", err
+	}
+	if identity != "" {
+		data["attested-identity"] = identity
+	}
+
+	if err := r.checkAdoption(req, tokens[0], machineID, identity); err != nil {
+		return "", "", err
+	}
+
 	secretName := machineRequestSecretName(machineID)
 	secret, err := r.secretsCache.Get(tokens[0].Namespace, secretName)
 	if apierror.IsNotFound(err) {
@@ -132,7 +144,7 @@ func machineRequestSecretName(name string) string {
 func dataFromHeaders(req *http.Request) map[string]interface{} {
 	data := make(map[string]interface{})
 	for k, v := range req.Header {
-		if strings.HasPrefix(k, headerPrefix) {
+		if strings.HasPrefix(k, headerPrefix) && !strings.HasPrefix(k, attestationHeaderPrefix) {
 			data[strings.ToLower(strings.TrimPrefix(k, headerPrefix))] = v
 		}
 	}
@@ -3,15 +3,18 @@
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/rancher/pkg/capr/bootstrapkey"
+	"github.com/sirupsen/logrus"
 	api "k8s.io/api/core/v1"
 )
 
 func (r *RKE2ConfigServer) findMachineByProvisioningSA(req *http.Request) (string, string, error) {
 	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
 	secrets, err := r.secretsCache.GetByIndex(tokenIndex, token)
-	if err != nil || len(secrets) == 0 {
+	if err != nil || len(secrets) == 0 || secrets[0].Type != api.SecretTypeServiceAccountToken {
 		return "", "", err
 	}
 
@@ -30,3 +33,49 @@ func (r *RKE2ConfigServer) findMachineByProvisioningSA(req *http.Request) (strin
 
 	return sa.Namespace, sa.Labels[capr.MachineNameLabel], nil
 }
+
+// findMachineByBootstrapKey returns the machine of the one-time bootstrap key of the request, if it wasn't used yet.
+func (r *RKE2ConfigServer) findMachineByBootstrapKey(req *http.Request) (string, string, error) {
+	secret, err := r.bootstrapKey(req)
+	if err != nil || secret == nil {
+		return "", "", err
+	}
+
+	machineName := secret.Labels[capr.MachineNameLabel]
+	if foundParent, err := capr.IsOwnedByMachine(r.bootstrapCache, machineName, secret); err != nil || !foundParent {
+		return "", "", err
+	}
+
+	return secret.Namespace, machineName, nil
+}
+
+// useBootstrapKey invalidates the one-time bootstrap key of the request, if it authenticated with one.
+func (r *RKE2ConfigServer) useBootstrapKey(req *http.Request) error {
+	secret, err := r.bootstrapKey(req)
+	if err != nil || secret == nil {
+		return err
+	}
+
+	if err := bootstrapkey.Use(r.secrets, secret, time.Now()); err != nil {
+		return err
+	}
+	logrus.Infof("[rke2configserver] %s/%s bootstrap key of machine %s was exchanged and is now invalid", secret.Namespace, secret.Name, secret.Labels[capr.MachineNameLabel])
+	return nil
+}
+
+func (r *RKE2ConfigServer) bootstrapKey(req *http.Request) (*api.Secret, error) {
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		return nil, nil
+	}
+	secrets, err := r.secretsCache.GetByIndex(tokenIndex, token)
+	if err != nil {
+		return nil, err
+	}
+	for _, secret := range secrets {
+		if secret.Type == capr.SecretTypeBootstrapKey && !bootstrapkey.Used(secret) {
+			return secret, nil
+		}
+	}
+	return nil, nil
+}
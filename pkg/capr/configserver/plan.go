@@ -0,0 +1,155 @@
+package configserver
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/rancher/pkg/capr/planencryption"
+	"github.com/rancher/rancher/pkg/capr/planredaction"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// rancherPlanFields are the fields of plan secrets written by rancher, which agents can't update.
+var rancherPlanFields = append([]string{"max-failures", "failure-threshold"}, planencryption.PlanFields...)
+
+// connectPlan serves the plan secret of a machine to its agent, with its plans decrypted, and takes the updates of the
+// agent to the fields it owns. Agents authenticate with the token of their plan service account, which only lets them
+// watch the plan secret through the Kubernetes API, so this is the only way they read and update it.
+func (r *RKE2ConfigServer) connectPlan(rw http.ResponseWriter, req *http.Request) {
+	planSecret, err := r.findPlanSecret(req)
+	if apierrors.IsNotFound(err) {
+		rw.WriteHeader(http.StatusUnauthorized)
+		return
+	} else if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		planSecret, err = r.updatePlanSecret(planSecret, req)
+		if apierrors.IsConflict(err) {
+			http.Error(rw, err.Error(), http.StatusConflict)
+			return
+		} else if apierrors.IsBadRequest(err) {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		} else if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	default:
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Agents are never served plans that couldn't be decrypted.
+	planSecret, err = r.encryptor.DecryptSecret(planSecret)
+	if err != nil {
+		logrus.Errorf("[rke2configserver] %v", err)
+		http.Error(rw, "failed to decrypt plan", http.StatusInternalServerError)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(rw).Encode(planSecret)
+}
+
+// findPlanSecret returns the plan secret assigned to the plan service account whose token the request is authenticated
+// with.
+func (r *RKE2ConfigServer) findPlanSecret(req *http.Request) (*corev1.Secret, error) {
+	notFound := apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "")
+
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if token == "" || token == req.Header.Get("Authorization") {
+		return nil, notFound
+	}
+	hash := sha256.Sum256([]byte(token))
+	secrets, err := r.secretsCache.GetByIndex(tokenIndex, base64.URLEncoding.EncodeToString(hash[:]))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, secret := range secrets {
+		if secret.Type != corev1.SecretTypeServiceAccountToken ||
+			subtle.ConstantTimeCompare(secret.Data[corev1.ServiceAccountTokenKey], []byte(token)) != 1 {
+			continue
+		}
+		sa, err := r.serviceAccountsCache.Get(secret.Namespace, secret.Annotations[corev1.ServiceAccountNameKey])
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if sa.Labels[capr.RoleLabel] != capr.RolePlan || string(sa.UID) != secret.Annotations[corev1.ServiceAccountUIDKey] {
+			continue
+		}
+		planSecretName, err := capr.GetPlanSecretName(sa)
+		if err != nil || planSecretName == "" {
+			continue
+		}
+		planSecret, err := r.secretsCache.Get(sa.Namespace, planSecretName)
+		if err != nil {
+			return nil, err
+		}
+		if planSecret.Type != capr.SecretTypeMachinePlan {
+			return nil, notFound
+		}
+		return planSecret, nil
+	}
+
+	return nil, notFound
+}
+
+// updatePlanSecret updates the fields of a plan secret owned by its agent with those of the request, redacting the
+// output of the instructions. The request must be for the resource version of the plan secret it was served.
+func (r *RKE2ConfigServer) updatePlanSecret(planSecret *corev1.Secret, req *http.Request) (*corev1.Secret, error) {
+	var update corev1.Secret
+	if err := json.NewDecoder(req.Body).Decode(&update); err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+
+	planSecret = planSecret.DeepCopy()
+	planSecret.ResourceVersion = update.ResourceVersion
+	planSecret.Data = mergeAgentData(planSecret.Data, update.Data)
+	if _, err := planredaction.Current().RedactData(planSecret.Data); err != nil {
+		logrus.Warnf("[rke2configserver] failed to redact plan secret %s/%s: %v", planSecret.Namespace, planSecret.Name, err)
+	}
+	return r.secrets.Update(planSecret)
+}
+
+// mergeAgentData returns the data of a plan secret with the fields owned by the agent replaced by those it sent, the
+// fields written by rancher are left as stored.
+func mergeAgentData(stored, update map[string][]byte) map[string][]byte {
+	result := map[string][]byte{}
+	for _, field := range rancherPlanFields {
+		if value, ok := stored[field]; ok {
+			result[field] = value
+		}
+	}
+	for field, value := range update {
+		if isRancherPlanField(field) {
+			continue
+		}
+		result[field] = value
+	}
+	return result
+}
+
+func isRancherPlanField(field string) bool {
+	for _, f := range rancherPlanFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
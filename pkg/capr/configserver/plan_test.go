@@ -0,0 +1,32 @@
+package configserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeAgentData(t *testing.T) {
+	stored := map[string][]byte{
+		"plan":             []byte("plan"),
+		"appliedPlan":      []byte("applied"),
+		"max-failures":     []byte("3"),
+		"applied-checksum": []byte("old"),
+		"probe-statuses":   []byte("{}"),
+	}
+	update := map[string][]byte{
+		"plan":              []byte("tampered"),
+		"appliedPlan":       []byte("tampered"),
+		"failure-threshold": []byte("1"),
+		"applied-checksum":  []byte("new"),
+		"applied-output":    []byte("output"),
+	}
+
+	assert.Equal(t, map[string][]byte{
+		"plan":             []byte("plan"),
+		"appliedPlan":      []byte("applied"),
+		"max-failures":     []byte("3"),
+		"applied-checksum": []byte("new"),
+		"applied-output":   []byte("output"),
+	}, mergeAgentData(stored, update))
+}
@@ -11,6 +11,8 @@
 
 	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
 	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/rancher/pkg/capr/bootstrapkey"
+	"github.com/rancher/rancher/pkg/capr/planencryption"
 	"github.com/rancher/rancher/pkg/capr/planner"
 	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
 	mgmtcontroller "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
@@ -38,6 +40,7 @@
 	ConnectClusterInfo    = "/v3/connect/cluster-info"
 	ConnectConfigYamlPath = "/v3/connect/config-yaml"
 	ConnectAgent          = "/v3/connect/agent"
+	ConnectPlan           = "/v3/connect/plan"
 )
 
 var (
@@ -57,6 +60,7 @@ type RKE2ConfigServer struct {
 	bootstrapCache           rkecontroller.RKEBootstrapCache
 	provisioningClusterCache provisioningcontrollers.ClusterCache
 	k8s                      kubernetes.Interface
+	encryptor                *planencryption.Encryptor
 }
 
 func New(clients *wrangler.Context) *RKE2ConfigServer {
@@ -65,7 +69,7 @@ func New(clients *wrangler.Context) *RKE2ConfigServer {
 			hash := sha256.Sum256(obj.Data["token"])
 			return []string{base64.URLEncoding.EncodeToString(hash[:])}, nil
 		}
-		return nil, nil
+		return bootstrapkey.Index(obj), nil
 	})
 
 	clients.Mgmt.ClusterRegistrationToken().Cache().AddIndexer(tokenIndex,
@@ -85,6 +89,7 @@ func(obj *v3.ClusterRegistrationToken) ([]string, error) {
 		bootstrapCache:           clients.RKE.RKEBootstrap().Cache(),
 		provisioningClusterCache: clients.Provisioning.Cluster().Cache(),
 		k8s:                      clients.K8s,
+		encryptor:                planencryption.NewEncryptor(clients),
 	}
 }
 
@@ -99,10 +104,17 @@ func (r *RKE2ConfigServer) ServeHTTP(rw http.ResponseWriter, req *http.Request)
 		rw.WriteHeader(http.StatusUnauthorized)
 		return
 	}
+	if req.URL.Path == ConnectPlan {
+		r.connectPlan(rw, req)
+		return
+	}
 	planSecret, secret, err := r.findSA(req)
 	if apierrors.IsNotFound(err) {
 		rw.WriteHeader(http.StatusUnauthorized)
 		return
+	} else if apierrors.IsForbidden(err) {
+		http.Error(rw, err.Error(), http.StatusForbidden)
+		return
 	} else if err != nil {
 		http.Error(rw, err.Error(), http.StatusInternalServerError)
 		return
@@ -121,6 +133,10 @@ func (r *RKE2ConfigServer) ServeHTTP(rw http.ResponseWriter, req *http.Request)
 	}
 }
 
+// connectAgent serves the connection info of the agent of a machine: the kubeconfig of its plan service account, with
+// which it watches its plan secret, and the URL of the plan endpoint it reads its plan from and reports to, see
+// connectPlan. The service account can't get or update the plan secret, so that the agent never reads encrypted plans
+// and can't write the fields of rancher.
 func (r *RKE2ConfigServer) connectAgent(planSecret string, secret *v1.Secret, rw http.ResponseWriter, req *http.Request) {
 	var ca []byte
 	url, pem := settings.ServerURL.Get(), settings.CACerts.Get()
@@ -166,6 +182,15 @@ func (r *RKE2ConfigServer) connectAgent(planSecret string, secret *v1.Secret, rw
 		return
 	}
 
+	// The credentials are only delivered once the bootstrap key they're exchanged for, if any, is invalidated.
+	if err := r.useBootstrapKey(req); apierrors.IsConflict(err) {
+		rw.WriteHeader(http.StatusUnauthorized)
+		return
+	} else if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	rw.Header().Set("Content-Type", "application/json")
 	enc := json.NewEncoder(rw)
 	enc.SetIndent("", "  ")
@@ -173,6 +198,7 @@ func (r *RKE2ConfigServer) connectAgent(planSecret string, secret *v1.Secret, rw
 		"namespace":  secret.Namespace,
 		"secretName": planSecret,
 		"kubeConfig": string(kubeConfig),
+		"planURL":    url + ConnectPlan,
 	})
 }
 
@@ -183,6 +209,12 @@ func (r *RKE2ConfigServer) connectConfigYaml(name, ns string, rw http.ResponseWr
 		return
 	}
 
+	mpSecret, err = r.encryptor.DecryptSecret(mpSecret)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	config := make(map[string]interface{})
 	if err := json.Unmarshal(mpSecret.Data[capr.RolePlan], &config); err != nil {
 		http.Error(rw, err.Error(), http.StatusInternalServerError)
@@ -303,6 +335,13 @@ func (r *RKE2ConfigServer) findSA(req *http.Request) (string, *corev1.Secret, er
 		return "", nil, err
 	}
 	logrus.Debugf("[rke2configserver] Got %s/%s machine from provisioning SA", machineNamespace, machineName)
+	if machineName == "" {
+		machineNamespace, machineName, err = r.findMachineByBootstrapKey(req)
+		if err != nil {
+			return "", nil, err
+		}
+		logrus.Debugf("[rke2configserver] Got %s/%s machine from bootstrap key", machineNamespace, machineName)
+	}
 	if machineName == "" {
 		machineNamespace, machineName, err = r.findMachineByClusterToken(req)
 		if err != nil {
@@ -0,0 +1,134 @@
+package capr
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// defaultImageGCHighThresholdPercent and defaultImageGCLowThresholdPercent are the image garbage collection
+	// thresholds of the kubelet.
+	defaultImageGCHighThresholdPercent = 85
+	defaultImageGCLowThresholdPercent  = 80
+	// minContainerLogMaxFiles is the lowest number of log files per container accepted by the kubelet.
+	minContainerLogMaxFiles = 2
+)
+
+// evictionSignals are the eviction signals of the kubelet.
+var evictionSignals = map[string]bool{
+	"memory.available":       true,
+	"nodefs.available":       true,
+	"nodefs.inodesFree":      true,
+	"imagefs.available":      true,
+	"imagefs.inodesFree":     true,
+	"containerfs.available":  true,
+	"containerfs.inodesFree": true,
+	"pid.available":          true,
+}
+
+// ValidateGarbageCollection returns an error if the kubelet would refuse the garbage collection tuning.
+func ValidateGarbageCollection(gc *rkev1.GarbageCollection) error {
+	if gc == nil {
+		return nil
+	}
+
+	high, low := int32(defaultImageGCHighThresholdPercent), int32(defaultImageGCLowThresholdPercent)
+	if gc.ImageGCHighThresholdPercent != nil {
+		high = *gc.ImageGCHighThresholdPercent
+	}
+	if gc.ImageGCLowThresholdPercent != nil {
+		low = *gc.ImageGCLowThresholdPercent
+	}
+	if high < 0 || high > 100 {
+		return fmt.Errorf("imageGCHighThresholdPercent %d must be between 0 and 100", high)
+	}
+	if low < 0 || low > 100 {
+		return fmt.Errorf("imageGCLowThresholdPercent %d must be between 0 and 100", low)
+	}
+	if low >= high {
+		return fmt.Errorf("imageGCLowThresholdPercent %d must be lower than imageGCHighThresholdPercent %d", low, high)
+	}
+
+	if gc.ContainerLogMaxSize != "" {
+		size, err := resource.ParseQuantity(gc.ContainerLogMaxSize)
+		if err != nil {
+			return fmt.Errorf("invalid containerLogMaxSize %s: %w", gc.ContainerLogMaxSize, err)
+		}
+		if size.Sign() <= 0 {
+			return fmt.Errorf("containerLogMaxSize %s must be positive", gc.ContainerLogMaxSize)
+		}
+	}
+	if gc.ContainerLogMaxFiles != nil && *gc.ContainerLogMaxFiles < minContainerLogMaxFiles {
+		return fmt.Errorf("containerLogMaxFiles %d must be at least %d", *gc.ContainerLogMaxFiles, minContainerLogMaxFiles)
+	}
+
+	for field, thresholds := range map[string]map[string]string{
+		"evictionHard":           gc.EvictionHard,
+		"evictionSoft":           gc.EvictionSoft,
+		"evictionMinimumReclaim": gc.EvictionMinimumReclaim,
+	} {
+		for signal, threshold := range thresholds {
+			if err := validateEvictionThreshold(signal, threshold); err != nil {
+				return fmt.Errorf("invalid %s: %w", field, err)
+			}
+		}
+	}
+
+	for signal, gracePeriod := range gc.EvictionSoftGracePeriod {
+		if _, ok := gc.EvictionSoft[signal]; !ok {
+			return fmt.Errorf("evictionSoftGracePeriod of signal %s has no evictionSoft threshold", signal)
+		}
+		duration, err := time.ParseDuration(gracePeriod)
+		if err != nil {
+			return fmt.Errorf("invalid evictionSoftGracePeriod of signal %s: %w", signal, err)
+		}
+		if duration < 0 {
+			return fmt.Errorf("evictionSoftGracePeriod of signal %s must not be negative", signal)
+		}
+	}
+	for signal := range gc.EvictionSoft {
+		if _, ok := gc.EvictionSoftGracePeriod[signal]; !ok {
+			return fmt.Errorf("evictionSoft threshold of signal %s has no evictionSoftGracePeriod", signal)
+		}
+	}
+
+	return nil
+}
+
+// validateEvictionThreshold returns an error if the signal isn't an eviction signal of the kubelet, or the threshold
+// isn't a percentage or a non-negative quantity.
+func validateEvictionThreshold(signal, threshold string) error {
+	if !evictionSignals[signal] {
+		return fmt.Errorf("unknown eviction signal %s, must be one of %s", signal, strings.Join(sortedEvictionSignals(), ", "))
+	}
+	if strings.HasSuffix(threshold, "%") {
+		value, err := strconv.ParseFloat(strings.TrimSuffix(threshold, "%"), 64)
+		if err != nil || value < 0 || value > 100 {
+			return fmt.Errorf("threshold %s of signal %s must be a percentage between 0%% and 100%%", threshold, signal)
+		}
+		return nil
+	}
+	quantity, err := resource.ParseQuantity(threshold)
+	if err != nil {
+		return fmt.Errorf("threshold %s of signal %s must be a percentage or a quantity: %w", threshold, signal, err)
+	}
+	if quantity.Sign() < 0 {
+		return fmt.Errorf("threshold %s of signal %s must not be negative", threshold, signal)
+	}
+	return nil
+}
+
+func sortedEvictionSignals() []string {
+	var result []string
+	for signal := range evictionSignals {
+		result = append(result, signal)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,105 @@
+package capr
+
+import (
+	"testing"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func TestValidateGarbageCollection(t *testing.T) {
+	tests := []struct {
+		name        string
+		gc          *rkev1.GarbageCollection
+		expectedErr string
+	}{
+		{
+			name: "unset",
+		},
+		{
+			name: "valid",
+			gc: &rkev1.GarbageCollection{
+				ImageGCHighThresholdPercent: int32Ptr(75),
+				ImageGCLowThresholdPercent:  int32Ptr(60),
+				ContainerLogMaxSize:         "50Mi",
+				ContainerLogMaxFiles:        int32Ptr(4),
+				EvictionHard:                map[string]string{"memory.available": "100Mi", "nodefs.available": "10%"},
+				EvictionSoft:                map[string]string{"imagefs.available": "15%"},
+				EvictionSoftGracePeriod:     map[string]string{"imagefs.available": "2m"},
+				EvictionMinimumReclaim:      map[string]string{"imagefs.available": "2Gi"},
+			},
+		},
+		{
+			name:        "high threshold below the default low threshold",
+			gc:          &rkev1.GarbageCollection{ImageGCHighThresholdPercent: int32Ptr(80)},
+			expectedErr: "imageGCLowThresholdPercent 80 must be lower than imageGCHighThresholdPercent 80",
+		},
+		{
+			name:        "threshold above 100",
+			gc:          &rkev1.GarbageCollection{ImageGCHighThresholdPercent: int32Ptr(101)},
+			expectedErr: "imageGCHighThresholdPercent 101 must be between 0 and 100",
+		},
+		{
+			name:        "invalid log size",
+			gc:          &rkev1.GarbageCollection{ContainerLogMaxSize: "ten megs"},
+			expectedErr: "invalid containerLogMaxSize ten megs",
+		},
+		{
+			name:        "zero log size",
+			gc:          &rkev1.GarbageCollection{ContainerLogMaxSize: "0"},
+			expectedErr: "containerLogMaxSize 0 must be positive",
+		},
+		{
+			name:        "single log file",
+			gc:          &rkev1.GarbageCollection{ContainerLogMaxFiles: int32Ptr(1)},
+			expectedErr: "containerLogMaxFiles 1 must be at least 2",
+		},
+		{
+			name:        "unknown signal",
+			gc:          &rkev1.GarbageCollection{EvictionHard: map[string]string{"disk.available": "10%"}},
+			expectedErr: "invalid evictionHard: unknown eviction signal disk.available",
+		},
+		{
+			name:        "invalid percentage",
+			gc:          &rkev1.GarbageCollection{EvictionHard: map[string]string{"nodefs.available": "110%"}},
+			expectedErr: "invalid evictionHard: threshold 110% of signal nodefs.available must be a percentage between 0% and 100%",
+		},
+		{
+			name:        "negative quantity",
+			gc:          &rkev1.GarbageCollection{EvictionMinimumReclaim: map[string]string{"nodefs.available": "-1Gi"}},
+			expectedErr: "invalid evictionMinimumReclaim: threshold -1Gi of signal nodefs.available must not be negative",
+		},
+		{
+			name:        "soft threshold without grace period",
+			gc:          &rkev1.GarbageCollection{EvictionSoft: map[string]string{"nodefs.available": "15%"}},
+			expectedErr: "evictionSoft threshold of signal nodefs.available has no evictionSoftGracePeriod",
+		},
+		{
+			name:        "grace period without soft threshold",
+			gc:          &rkev1.GarbageCollection{EvictionSoftGracePeriod: map[string]string{"nodefs.available": "1m"}},
+			expectedErr: "evictionSoftGracePeriod of signal nodefs.available has no evictionSoft threshold",
+		},
+		{
+			name: "invalid grace period",
+			gc: &rkev1.GarbageCollection{
+				EvictionSoft:            map[string]string{"nodefs.available": "15%"},
+				EvictionSoftGracePeriod: map[string]string{"nodefs.available": "a minute"},
+			},
+			expectedErr: "invalid evictionSoftGracePeriod of signal nodefs.available",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateGarbageCollection(tt.gc)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -90,6 +90,10 @@ func LinuxInstallScript(ctx context.Context, token string, envVars []corev1.EnvV
 	server := ""
 	if settings.ServerURL.Get() != "" {
 		server = fmt.Sprintf("CATTLE_SERVER=%s", settings.ServerURL.Get())
+		// The system agent fails over to the additional servers, in order, when the server is unreachable.
+		if additional := systemtemplate.AdditionalServerURLs(); len(additional) > 0 {
+			server += fmt.Sprintf("\nCATTLE_ADDITIONAL_SERVERS=\"%s\"", strings.Join(additional, ","))
+		}
 	}
 	return []byte(fmt.Sprintf(`#!/usr/bin/env sh
 %s
@@ -150,6 +154,9 @@ func WindowsInstallScript(ctx context.Context, token string, envVars []corev1.En
 	server := ""
 	if settings.ServerURL.Get() != "" {
 		server = fmt.Sprintf("$env:CATTLE_SERVER=\"%s\"", settings.ServerURL.Get())
+		if additional := systemtemplate.AdditionalServerURLs(); len(additional) > 0 {
+			server += fmt.Sprintf("\n$env:CATTLE_ADDITIONAL_SERVERS=\"%s\"", strings.Join(additional, ","))
+		}
 	}
 
 	return []byte(fmt.Sprintf(`%s
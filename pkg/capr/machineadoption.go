@@ -0,0 +1,106 @@
+package capr
+
+import (
+	"encoding/json"
+	"fmt"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+)
+
+const (
+	// MachineAdoptionsAnnotation is the adoptions of existing cloud instances into the machine pools of a cluster
+	// requested through the API, set on the cluster.
+	MachineAdoptionsAnnotation = "rke.cattle.io/machine-adoptions"
+	// MachineAdoptionAnnotation is the adoption claimed by an infrastructure machine, whose system agent is installed on
+	// the adopted instance instead of creating one.
+	MachineAdoptionAnnotation = "rke.cattle.io/machine-adoption"
+	// ProviderIDAnnotation is the provider ID of the node of a machine, set on the kubelet of the node by the planner.
+	ProviderIDAnnotation = "rke.cattle.io/provider-id"
+)
+
+// ParseMachineAdoptions returns the adoptions recorded in the machine adoptions annotation of a cluster.
+func ParseMachineAdoptions(annotations map[string]string) ([]rkev1.MachineAdoption, error) {
+	data := annotations[MachineAdoptionsAnnotation]
+	if data == "" {
+		return nil, nil
+	}
+	var adoptions []rkev1.MachineAdoption
+	if err := json.Unmarshal([]byte(data), &adoptions); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", MachineAdoptionsAnnotation, err)
+	}
+	return adoptions, nil
+}
+
+// SetMachineAdoptions records the adoptions in the machine adoptions annotation of a cluster, removing it if there are
+// none.
+func SetMachineAdoptions(annotations map[string]string, adoptions []rkev1.MachineAdoption) error {
+	if len(adoptions) == 0 {
+		delete(annotations, MachineAdoptionsAnnotation)
+		return nil
+	}
+	data, err := json.Marshal(adoptions)
+	if err != nil {
+		return err
+	}
+	annotations[MachineAdoptionsAnnotation] = string(data)
+	return nil
+}
+
+// ParseMachineAdoption returns the adoption claimed by an infrastructure machine, nil if it didn't claim any.
+func ParseMachineAdoption(annotations map[string]string) (*rkev1.MachineAdoption, error) {
+	data := annotations[MachineAdoptionAnnotation]
+	if data == "" {
+		return nil, nil
+	}
+	adoption := &rkev1.MachineAdoption{}
+	if err := json.Unmarshal([]byte(data), adoption); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", MachineAdoptionAnnotation, err)
+	}
+	return adoption, nil
+}
+
+// ClaimMachineAdoption returns the index of the adoption claimed by the machine of the pool: the adoption it already
+// claimed, or else the oldest adoption of the pool not claimed by any machine. It returns -1 if there is none.
+func ClaimMachineAdoption(adoptions []rkev1.MachineAdoption, poolName, machineName string) int {
+	claim := -1
+	for i, adoption := range adoptions {
+		if adoption.PoolName != poolName {
+			continue
+		}
+		if adoption.MachineName == machineName {
+			return i
+		}
+		if adoption.MachineName == "" && (claim == -1 || adoption.RequestedAt.Before(&adoptions[claim].RequestedAt)) {
+			claim = i
+		}
+	}
+	return claim
+}
+
+// MergeMachineAdoptions returns the adoptions with the requested adoptions added. Adoptions of the same instances that
+// are no longer active, as their machine was removed, are replaced, and an error is returned if any of the instances is
+// being or was adopted by an existing machine.
+func MergeMachineAdoptions(adoptions, requested []rkev1.MachineAdoption, active func(rkev1.MachineAdoption) bool) ([]rkev1.MachineAdoption, error) {
+	instances := map[string]bool{}
+	for _, adoption := range requested {
+		if instances[adoption.InstanceID] {
+			return nil, fmt.Errorf("instance %s is requested more than once", adoption.InstanceID)
+		}
+		instances[adoption.InstanceID] = true
+	}
+
+	var result []rkev1.MachineAdoption
+	for _, adoption := range adoptions {
+		if !instances[adoption.InstanceID] {
+			result = append(result, adoption)
+			continue
+		}
+		if active(adoption) {
+			if adoption.MachineName == "" {
+				return nil, fmt.Errorf("instance %s is already being adopted into machine pool %s", adoption.InstanceID, adoption.PoolName)
+			}
+			return nil, fmt.Errorf("instance %s is already adopted by machine %s", adoption.InstanceID, adoption.MachineName)
+		}
+	}
+	return append(result, requested...), nil
+}
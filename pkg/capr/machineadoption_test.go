@@ -0,0 +1,127 @@
+package capr
+
+import (
+	"testing"
+	"time"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testAdoption(id, pool, instance, machine string, requestedAt time.Time) rkev1.MachineAdoption {
+	return rkev1.MachineAdoption{
+		ID:          id,
+		PoolName:    pool,
+		InstanceID:  instance,
+		RequestedAt: metav1.NewTime(requestedAt),
+		MachineName: machine,
+	}
+}
+
+func TestParseAndSetMachineAdoptions(t *testing.T) {
+	adoptions, err := ParseMachineAdoptions(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, adoptions)
+
+	expected := []rkev1.MachineAdoption{{ID: "a1", PoolName: "pool1", InstanceID: "i-1", ProviderID: "aws:///us-east-1a/i-1"}}
+	annotations := map[string]string{}
+	require.NoError(t, SetMachineAdoptions(annotations, expected))
+	adoptions, err = ParseMachineAdoptions(annotations)
+	require.NoError(t, err)
+	assert.Equal(t, expected, adoptions)
+
+	require.NoError(t, SetMachineAdoptions(annotations, nil))
+	assert.NotContains(t, annotations, MachineAdoptionsAnnotation)
+
+	_, err = ParseMachineAdoptions(map[string]string{MachineAdoptionsAnnotation: "{"})
+	assert.Error(t, err)
+}
+
+func TestParseMachineAdoption(t *testing.T) {
+	adoption, err := ParseMachineAdoption(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, adoption)
+
+	adoption, err = ParseMachineAdoption(map[string]string{
+		MachineAdoptionAnnotation: `{"id":"a1","poolName":"pool1","instanceId":"i-1","address":"10.0.0.1","sshUser":"ubuntu"}`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &rkev1.MachineAdoption{ID: "a1", PoolName: "pool1", InstanceID: "i-1", Address: "10.0.0.1", SSHUser: "ubuntu"}, adoption)
+
+	_, err = ParseMachineAdoption(map[string]string{MachineAdoptionAnnotation: "{"})
+	assert.Error(t, err)
+}
+
+func TestClaimMachineAdoption(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	adoptions := []rkev1.MachineAdoption{
+		testAdoption("a1", "pool1", "i-1", "pool1-a", now.Add(-time.Hour)),
+		testAdoption("a2", "pool2", "i-2", "", now.Add(-time.Hour)),
+		testAdoption("a3", "pool1", "i-3", "", now),
+		testAdoption("a4", "pool1", "i-4", "", now.Add(-time.Minute)),
+	}
+
+	assert.Equal(t, 0, ClaimMachineAdoption(adoptions, "pool1", "pool1-a"))
+	assert.Equal(t, 3, ClaimMachineAdoption(adoptions, "pool1", "pool1-b"))
+	assert.Equal(t, 1, ClaimMachineAdoption(adoptions, "pool2", "pool2-a"))
+	assert.Equal(t, -1, ClaimMachineAdoption(adoptions, "pool3", "pool3-a"))
+}
+
+func TestMergeMachineAdoptions(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	adoptions := []rkev1.MachineAdoption{
+		testAdoption("a1", "pool1", "i-1", "pool1-a", now),
+		testAdoption("a2", "pool1", "i-2", "", now),
+		testAdoption("a3", "pool1", "i-3", "pool1-removed", now),
+	}
+	active := func(adoption rkev1.MachineAdoption) bool {
+		return adoption.MachineName != "pool1-removed"
+	}
+
+	tests := []struct {
+		name      string
+		requested []rkev1.MachineAdoption
+		expected  []rkev1.MachineAdoption
+		err       string
+	}{
+		{
+			name:      "new instance",
+			requested: []rkev1.MachineAdoption{testAdoption("b1", "pool1", "i-4", "", now)},
+			expected:  append(append([]rkev1.MachineAdoption{}, adoptions...), testAdoption("b1", "pool1", "i-4", "", now)),
+		},
+		{
+			name:      "instance of a removed machine",
+			requested: []rkev1.MachineAdoption{testAdoption("b1", "pool2", "i-3", "", now)},
+			expected:  []rkev1.MachineAdoption{adoptions[0], adoptions[1], testAdoption("b1", "pool2", "i-3", "", now)},
+		},
+		{
+			name:      "adopted instance",
+			requested: []rkev1.MachineAdoption{testAdoption("b1", "pool1", "i-1", "", now)},
+			err:       "instance i-1 is already adopted by machine pool1-a",
+		},
+		{
+			name:      "instance being adopted",
+			requested: []rkev1.MachineAdoption{testAdoption("b1", "pool1", "i-2", "", now)},
+			err:       "instance i-2 is already being adopted into machine pool pool1",
+		},
+		{
+			name:      "duplicate instance",
+			requested: []rkev1.MachineAdoption{testAdoption("b1", "pool1", "i-4", "", now), testAdoption("b2", "pool1", "i-4", "", now)},
+			err:       "instance i-4 is requested more than once",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := MergeMachineAdoptions(adoptions, tt.requested, active)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
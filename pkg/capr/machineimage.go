@@ -0,0 +1,38 @@
+package capr
+
+import (
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+)
+
+// MachineImageAnnotation is the image a machine was provisioned from, set on the machines of the machine pools pinning
+// their image.
+const MachineImageAnnotation = "rke.cattle.io/machine-image"
+
+// machineImageFields are the fields of the machine configs of node drivers holding the image of machines, by machine
+// config kind.
+var machineImageFields = map[string]string{
+	"Amazonec2Config":    "ami",
+	"AzureConfig":        "image",
+	"DigitaloceanConfig": "image",
+	"GoogleConfig":       "machineImage",
+	"HarvesterConfig":    "imageName",
+	"LinodeConfig":       "image",
+	"OpenstackConfig":    "imageName",
+}
+
+// MachineImageField returns the field of the machine config of a machine pool the pinned image of the pool is set to,
+// empty if the node driver of the machine config isn't known and the pool doesn't set the field.
+func MachineImageField(nodeConfigKind string, image *provv1.RKEMachinePoolMachineImage) string {
+	if image != nil && image.Field != "" {
+		return image.Field
+	}
+	return machineImageFields[nodeConfigKind]
+}
+
+// ControlPlaneSettled returns true once the planner has reconciled the control plane and no Kubernetes upgrade is in
+// progress, so that machines are replaced and Kubernetes is upgraded one after the other.
+func ControlPlaneSettled(controlPlane *rkev1.RKEControlPlane) bool {
+	return controlPlane != nil && Reconciled.IsTrue(controlPlane) && controlPlane.Status.AppliedSpec != nil &&
+		controlPlane.Status.AppliedSpec.KubernetesVersion == controlPlane.Spec.KubernetesVersion
+}
@@ -0,0 +1,267 @@
+// Package planencryption envelope-encrypts the plans stored in machine plan secrets. Every cluster has its own data key,
+// stored wrapped by a KeyProvider next to the plan secrets of the cluster, so that plans are never written to the
+// management etcd in the clear and are only decrypted by rancher, to plan and to serve them to the agent of their
+// machine through the config server.
+package planencryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"sync"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/wrangler"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/rancher/wrangler/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// encryptedPrefix prefixes encrypted plans, plans without it are stored in the clear.
+	encryptedPrefix = "rancher:plan-enc:v1:"
+
+	// dataKeySize is the size of data keys, which are AES-256 keys.
+	dataKeySize = 32
+	nonceSize   = 12
+
+	keyField      = "key"
+	providerField = "provider"
+)
+
+// PlanFields are the fields of plan secrets holding plans.
+var PlanFields = []string{"plan", "appliedPlan"}
+
+type Encryptor struct {
+	secrets      corecontrollers.SecretClient
+	secretsCache corecontrollers.SecretCache
+	providers    map[string]KeyProvider
+
+	lock sync.Mutex
+	// keys are the unwrapped data keys, by the UID of their secret.
+	keys map[types.UID][]byte
+}
+
+func NewEncryptor(clients *wrangler.Context) *Encryptor {
+	return &Encryptor{
+		secrets:      clients.Core.Secret(),
+		secretsCache: clients.Core.Secret().Cache(),
+		providers: map[string]KeyProvider{
+			KMSProvider: &kmsProvider{},
+		},
+		keys: map[types.UID][]byte{},
+	}
+}
+
+// IsEncrypted returns whether a plan is encrypted.
+func IsEncrypted(value []byte) bool {
+	return bytes.HasPrefix(value, []byte(encryptedPrefix))
+}
+
+// ValidateKeyProvider returns an error if new data keys can't be wrapped by the provider set by the
+// plan-encryption-key-provider setting, in which case plan secrets must not be encrypted.
+func (e *Encryptor) ValidateKeyProvider() error {
+	_, err := e.keyProvider()
+	return err
+}
+
+func (e *Encryptor) keyProvider() (KeyProvider, error) {
+	provider, ok := e.providers[settings.PlanEncryptionKeyProvider.Get()]
+	if !ok {
+		return nil, fmt.Errorf("unknown plan encryption key provider %q set by setting %s", settings.PlanEncryptionKeyProvider.Get(), settings.PlanEncryptionKeyProvider.Name)
+	}
+	if err := provider.Validate(); err != nil {
+		return nil, err
+	}
+	return provider, nil
+}
+
+// KeySecretName returns the name of the secret holding the wrapped data key of a cluster.
+func KeySecretName(clusterName string) string {
+	return name.SafeConcatName(clusterName, "plan", "encryption", "key")
+}
+
+// Encrypt encrypts a plan with the data key of the cluster of a control plane, creating the data key if needed.
+func (e *Encryptor) Encrypt(controlPlane *rkev1.RKEControlPlane, plan []byte) ([]byte, error) {
+	if len(plan) == 0 || IsEncrypted(plan) {
+		return plan, nil
+	}
+	key, err := e.dataKey(controlPlane)
+	if err != nil {
+		return nil, err
+	}
+	return seal(key, plan)
+}
+
+// Decrypt decrypts a plan of a cluster, plans that aren't encrypted are returned as is.
+func (e *Encryptor) Decrypt(namespace, clusterName string, value []byte) ([]byte, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	key, err := e.existingDataKey(namespace, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	return open(key, value)
+}
+
+// DecryptSecret returns a copy of a plan secret with its plans decrypted, or the secret itself if its plans aren't
+// encrypted.
+func (e *Encryptor) DecryptSecret(secret *corev1.Secret) (*corev1.Secret, error) {
+	if secret == nil || !secretEncrypted(secret) {
+		return secret, nil
+	}
+	secret = secret.DeepCopy()
+	for _, field := range PlanFields {
+		if !IsEncrypted(secret.Data[field]) {
+			continue
+		}
+		value, err := e.Decrypt(secret.Namespace, secret.Labels[capr.ClusterNameLabel], secret.Data[field])
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt %s of plan secret %s/%s: %w", field, secret.Namespace, secret.Name, err)
+		}
+		secret.Data[field] = value
+	}
+	return secret, nil
+}
+
+func secretEncrypted(secret *corev1.Secret) bool {
+	for _, field := range PlanFields {
+		if IsEncrypted(secret.Data[field]) {
+			return true
+		}
+	}
+	return false
+}
+
+// dataKey returns the data key of the cluster of a control plane, the data key is generated and wrapped by the
+// provider set by the plan-encryption-key-provider setting if the cluster doesn't have one yet.
+func (e *Encryptor) dataKey(controlPlane *rkev1.RKEControlPlane) ([]byte, error) {
+	key, err := e.existingDataKey(controlPlane.Namespace, controlPlane.Name)
+	if !apierrors.IsNotFound(err) {
+		return key, err
+	}
+
+	provider, err := e.keyProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	key = make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	wrapped, err := provider.Wrap(key)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = e.secrets.Create(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      KeySecretName(controlPlane.Name),
+			Namespace: controlPlane.Namespace,
+			Labels: map[string]string{
+				capr.ClusterNameLabel: controlPlane.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: rkev1.SchemeGroupVersion.String(),
+				Kind:       "RKEControlPlane",
+				Name:       controlPlane.Name,
+				UID:        controlPlane.UID,
+			}},
+		},
+		Type: capr.SecretTypePlanEncryptionKey,
+		Data: map[string][]byte{
+			keyField:      wrapped,
+			providerField: []byte(provider.Name()),
+		},
+	})
+	if apierrors.IsAlreadyExists(err) {
+		// Another plan was encrypted concurrently, use the data key it created.
+		return e.existingDataKey(controlPlane.Namespace, controlPlane.Name)
+	} else if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// existingDataKey returns the data key of a cluster, unwrapped by the provider that wrapped it so that changing the
+// provider doesn't prevent decrypting existing plans.
+func (e *Encryptor) existingDataKey(namespace, clusterName string) ([]byte, error) {
+	secret, err := e.secretsCache.Get(namespace, KeySecretName(clusterName))
+	if apierrors.IsNotFound(err) {
+		// The cache may not have seen a data key created just now.
+		secret, err = e.secrets.Get(namespace, KeySecretName(clusterName), metav1.GetOptions{})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if key, ok := e.keys[secret.UID]; ok {
+		return key, nil
+	}
+
+	provider, ok := e.providers[string(secret.Data[providerField])]
+	if !ok {
+		return nil, fmt.Errorf("unknown plan encryption key provider %s for data key %s/%s", secret.Data[providerField], secret.Namespace, secret.Name)
+	}
+	key, err := provider.Unwrap(secret.Data[keyField])
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+	if len(key) != dataKeySize {
+		return nil, fmt.Errorf("data key %s/%s is invalid", secret.Namespace, secret.Name)
+	}
+	e.keys[secret.UID] = key
+	return key, nil
+}
+
+// seal encrypts a plan with AES-GCM and a random nonce, so that the same plan never encrypts to the same value. Plans
+// must be decrypted to be compared.
+func seal(key, plaintext []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, nonceSize)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	result := append([]byte(encryptedPrefix), nonce...)
+	return aead.Seal(result, nonce, plaintext, nil), nil
+}
+
+func open(key, value []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	value = bytes.TrimPrefix(value, []byte(encryptedPrefix))
+	if len(value) < nonceSize {
+		return nil, fmt.Errorf("encrypted plan is too short")
+	}
+	return aead.Open(nil, value[:nonceSize], value[nonceSize:], nil)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != dataKeySize {
+		return nil, fmt.Errorf("invalid data key size %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCMWithNonceSize(block, nonceSize)
+}
@@ -0,0 +1,60 @@
+package planencryption
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, dataKeySize)
+}
+
+func TestSealOpen(t *testing.T) {
+	plan := []byte(`{"files":[{"content":"c2VjcmV0","path":"/etc/rancher/rke2/config.yaml.d/50-rancher.yaml"}]}`)
+
+	sealed, err := seal(testKey(1), plan)
+	require.NoError(t, err)
+	assert.True(t, IsEncrypted(sealed))
+	assert.False(t, bytes.Contains(sealed, []byte("c2VjcmV0")))
+
+	opened, err := open(testKey(1), sealed)
+	require.NoError(t, err)
+	assert.Equal(t, plan, opened)
+
+	_, err = open(testKey(2), sealed)
+	assert.Error(t, err)
+
+	sealed[len(sealed)-1] ^= 1
+	_, err = open(testKey(1), sealed)
+	assert.Error(t, err)
+}
+
+func TestSealRandomNonce(t *testing.T) {
+	first, err := seal(testKey(1), []byte("plan"))
+	require.NoError(t, err)
+	second, err := seal(testKey(1), []byte("plan"))
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second, "the same plan never encrypts to the same value")
+
+	for _, sealed := range [][]byte{first, second} {
+		opened, err := open(testKey(1), sealed)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("plan"), opened)
+	}
+}
+
+func TestInvalidKey(t *testing.T) {
+	_, err := seal([]byte("short"), []byte("plan"))
+	assert.Error(t, err)
+	_, err = open(testKey(1), []byte(encryptedPrefix+"short"))
+	assert.Error(t, err)
+}
+
+func TestIsEncrypted(t *testing.T) {
+	assert.False(t, IsEncrypted(nil))
+	assert.False(t, IsEncrypted([]byte(`{"files":[]}`)))
+	assert.True(t, IsEncrypted([]byte(encryptedPrefix)))
+}
@@ -0,0 +1,84 @@
+package planencryption
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/rancher/rancher/pkg/settings"
+)
+
+const (
+	// KMSProvider wraps data keys with an AWS KMS key.
+	KMSProvider = "kms"
+)
+
+// KeyProvider wraps the data keys plan secrets are encrypted with, so that data keys are never stored in the clear.
+// The key wrapping data keys must be held outside of the local cluster: a key stored next to the data keys it wraps
+// doesn't protect them from anyone able to read the secrets of the local cluster.
+type KeyProvider interface {
+	Name() string
+	// Validate returns an error if the provider isn't configured to wrap data keys.
+	Validate() error
+	Wrap(key []byte) ([]byte, error)
+	Unwrap(wrapped []byte) ([]byte, error)
+}
+
+// kmsProvider wraps data keys with the AWS KMS key set by the plan-encryption-kms-key-id setting. AWS credentials and
+// region are read from the environment of rancher.
+type kmsProvider struct{}
+
+func (k *kmsProvider) Name() string {
+	return KMSProvider
+}
+
+func (k *kmsProvider) Validate() error {
+	if settings.PlanEncryptionKMSKeyID.Get() == "" {
+		return fmt.Errorf("setting %s must be set to use the %s plan encryption key provider", settings.PlanEncryptionKMSKeyID.Name, KMSProvider)
+	}
+	return nil
+}
+
+func (k *kmsProvider) Wrap(key []byte) ([]byte, error) {
+	if err := k.Validate(); err != nil {
+		return nil, err
+	}
+	keyID := settings.PlanEncryptionKMSKeyID.Get()
+	client, err := k.client()
+	if err != nil {
+		return nil, err
+	}
+	output, err := client.Encrypt(&kms.EncryptInput{
+		KeyId:     aws.String(keyID),
+		Plaintext: key,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data key with KMS key %s: %w", keyID, err)
+	}
+	return output.CiphertextBlob, nil
+}
+
+func (k *kmsProvider) Unwrap(wrapped []byte) ([]byte, error) {
+	client, err := k.client()
+	if err != nil {
+		return nil, err
+	}
+	output, err := client.Decrypt(&kms.DecryptInput{
+		CiphertextBlob: wrapped,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key with KMS: %w", err)
+	}
+	return output.Plaintext, nil
+}
+
+func (k *kmsProvider) client() (*kms.KMS, error) {
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS session: %w", err)
+	}
+	return kms.New(sess), nil
+}
@@ -0,0 +1,240 @@
+package planner
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/wrangler/pkg/data"
+	"github.com/rancher/wrangler/pkg/data/convert"
+	"github.com/rancher/wrangler/pkg/slice"
+)
+
+const (
+	kubeAPIServerArg              = "kube-apiserver-arg"
+	kubeAPIServerExtraMount       = "kube-apiserver-extra-mount"
+	admissionControlConfigFileArg = "admission-control-config-file"
+	enableAdmissionPluginsArg     = "enable-admission-plugins"
+
+	imagePolicyWebhookPlugin = "ImagePolicyWebhook"
+	eventRateLimitPlugin     = "EventRateLimit"
+	// nodeRestrictionPlugin is enabled by K3s and RKE2 unless enable-admission-plugins is set, so it is kept enabled
+	// when the plugins of the admission configuration are enabled.
+	nodeRestrictionPlugin = "NodeRestriction"
+
+	admissionConfigDir      = "/var/lib/rancher/%s/etc/config-files/admission"
+	admissionConfigFileName = "admission-config.yaml"
+	admissionKubeconfigKey  = "kubeconfig"
+)
+
+var eventRateLimitTypes = []string{"Server", "Namespace", "User", "SourceAndObject"}
+
+// validateAdmissionConfiguration returns an error if the admission configuration of the control plane is invalid.
+func validateAdmissionConfiguration(ac *rkev1.AdmissionConfiguration) error {
+	if ac == nil {
+		return nil
+	}
+	seen := map[string]bool{}
+	for i, plugin := range ac.Plugins {
+		if plugin.Name == "" {
+			return fmt.Errorf("admission plugin %d has no name", i)
+		}
+		if seen[plugin.Name] {
+			return fmt.Errorf("admission plugin %s is configured more than once", plugin.Name)
+		}
+		seen[plugin.Name] = true
+
+		if plugin.KubeconfigSecretName != "" && plugin.Name != imagePolicyWebhookPlugin {
+			return fmt.Errorf("admission plugin %s doesn't support a kubeconfig secret, only %s does", plugin.Name, imagePolicyWebhookPlugin)
+		}
+
+		var err error
+		switch plugin.Name {
+		case imagePolicyWebhookPlugin:
+			err = validateImagePolicyWebhook(plugin)
+		case eventRateLimitPlugin:
+			err = validateEventRateLimit(plugin)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid configuration of admission plugin %s: %w", plugin.Name, err)
+		}
+	}
+	return nil
+}
+
+func validateImagePolicyWebhook(plugin rkev1.AdmissionPlugin) error {
+	imagePolicy := data.Object(plugin.Configuration.Data).Map("imagePolicy")
+	if imagePolicy == nil {
+		return fmt.Errorf("imagePolicy is required")
+	}
+	if imagePolicy.String("kubeConfigFile") == "" && plugin.KubeconfigSecretName == "" {
+		return fmt.Errorf("imagePolicy.kubeConfigFile or kubeconfigSecretName is required")
+	}
+	for _, field := range []string{"allowTTL", "denyTTL", "retryBackoff"} {
+		value, ok := imagePolicy[field]
+		if !ok {
+			continue
+		}
+		if n, err := convert.ToNumber(value); err != nil || n < 0 {
+			return fmt.Errorf("imagePolicy.%s must be a non-negative number", field)
+		}
+	}
+	if value, ok := imagePolicy["defaultAllow"]; ok {
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("imagePolicy.defaultAllow must be a boolean")
+		}
+	}
+	return nil
+}
+
+func validateEventRateLimit(plugin rkev1.AdmissionPlugin) error {
+	limits := data.Object(plugin.Configuration.Data).Slice("limits")
+	if len(limits) == 0 {
+		return fmt.Errorf("at least one limit is required")
+	}
+	for i, limit := range limits {
+		if t := limit.String("type"); !slice.ContainsString(eventRateLimitTypes, t) {
+			return fmt.Errorf("limit %d has unknown type %q, must be one of %s", i, t, strings.Join(eventRateLimitTypes, ", "))
+		}
+		for _, field := range []string{"qps", "burst"} {
+			if n, err := convert.ToNumber(limit[field]); err != nil || n <= 0 {
+				return fmt.Errorf("limit %d must have a positive %s", i, field)
+			}
+		}
+	}
+	return nil
+}
+
+// addAdmissionConfiguration renders the admission control configuration file of the admission plugins of the cluster,
+// with the kubeconfigs of their webhooks, on the control plane nodes and configures the kube-apiserver to use it and
+// enable the plugins. The files aren't dynamic, so changing the configuration restarts the kube-apiservers, following
+// the upgrade strategy of the control plane.
+func (p *Planner) addAdmissionConfiguration(config map[string]interface{}, controlPlane *rkev1.RKEControlPlane, entry *planEntry) ([]plan.File, error) {
+	ac := controlPlane.Spec.AdmissionConfiguration
+	if ac == nil || len(ac.Plugins) == 0 || !isControlPlane(entry) {
+		return nil, nil
+	}
+	if err := validateAdmissionConfiguration(ac); err != nil {
+		return nil, err
+	}
+
+	args := convert.ToStringSlice(config[kubeAPIServerArg])
+	if getArgValue(args, admissionControlConfigFileArg, "=") != "" {
+		return nil, fmt.Errorf("kube-apiserver arg %s cannot be set when the admission configuration of the cluster is defined", admissionControlConfigFileArg)
+	}
+
+	runtime := capr.GetRuntime(controlPlane.Spec.KubernetesVersion)
+	dir := fmt.Sprintf(admissionConfigDir, runtime)
+
+	var files []plan.File
+	kubeconfigs := map[string]string{}
+	for _, plugin := range ac.Plugins {
+		if plugin.KubeconfigSecretName == "" {
+			continue
+		}
+		secret, err := p.secretCache.Get(controlPlane.Namespace, plugin.KubeconfigSecretName)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving kubeconfig secret %s/%s of admission plugin %s: %w", controlPlane.Namespace, plugin.KubeconfigSecretName, plugin.Name, err)
+		}
+		if authorized, found := clusterObjectAuthorized(secret, capr.AuthorizedObjectAnnotation, controlPlane.Name); !authorized || !found {
+			return nil, fmt.Errorf("cluster %s/%s was not authorized to access kubeconfig secret %s/%s of admission plugin %s", controlPlane.Namespace, controlPlane.Name, controlPlane.Namespace, plugin.KubeconfigSecretName, plugin.Name)
+		}
+		kubeconfig, ok := secret.Data[admissionKubeconfigKey]
+		if !ok {
+			return nil, fmt.Errorf("kubeconfig secret %s/%s of admission plugin %s has no %s key", controlPlane.Namespace, plugin.KubeconfigSecretName, plugin.Name, admissionKubeconfigKey)
+		}
+
+		path := fmt.Sprintf("%s/%s-kubeconfig.yaml", dir, strings.ToLower(plugin.Name))
+		kubeconfigs[plugin.Name] = path
+		files = append(files, plan.File{
+			Content:     base64.StdEncoding.EncodeToString(kubeconfig),
+			Path:        path,
+			Permissions: "0600",
+		})
+	}
+
+	content, err := renderAdmissionConfiguration(ac, kubeconfigs)
+	if err != nil {
+		return nil, err
+	}
+	configPath := fmt.Sprintf("%s/%s", dir, admissionConfigFileName)
+	files = append(files, plan.File{
+		Content:     base64.StdEncoding.EncodeToString(content),
+		Path:        configPath,
+		Permissions: "0600",
+	})
+
+	config[kubeAPIServerArg] = append(enableAdmissionPlugins(args, ac.Plugins),
+		fmt.Sprintf("%s=%s", admissionControlConfigFileArg, configPath))
+	if runtime == capr.RuntimeRKE2 {
+		// the kube-apiserver of RKE2 runs in a static pod, which needs the admission files mounted
+		config[kubeAPIServerExtraMount] = append(convert.ToStringSlice(config[kubeAPIServerExtraMount]),
+			fmt.Sprintf("%s:%s:ro", dir, dir))
+	}
+	return files, nil
+}
+
+// renderAdmissionConfiguration returns the admission control configuration file of the plugins, with the passed in
+// kubeconfig files of the plugins set as the kubeConfigFile of their configuration.
+func renderAdmissionConfiguration(ac *rkev1.AdmissionConfiguration, kubeconfigs map[string]string) ([]byte, error) {
+	var plugins []map[string]interface{}
+	for _, plugin := range ac.Plugins {
+		var configuration rkev1.GenericMap
+		plugin.Configuration.DeepCopyInto(&configuration)
+		if configuration.Data == nil {
+			configuration.Data = map[string]interface{}{}
+		}
+
+		if path := kubeconfigs[plugin.Name]; path != "" {
+			data.PutValue(configuration.Data, path, "imagePolicy", "kubeConfigFile")
+		}
+		if plugin.Name == eventRateLimitPlugin {
+			if _, ok := configuration.Data["apiVersion"]; !ok {
+				configuration.Data["apiVersion"] = "eventratelimit.admission.k8s.io/v1alpha1"
+			}
+			if _, ok := configuration.Data["kind"]; !ok {
+				configuration.Data["kind"] = "Configuration"
+			}
+		}
+
+		rendered := map[string]interface{}{
+			"name": plugin.Name,
+		}
+		if len(configuration.Data) > 0 {
+			rendered["configuration"] = configuration.Data
+		}
+		plugins = append(plugins, rendered)
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"apiVersion": "apiserver.config.k8s.io/v1",
+		"kind":       "AdmissionConfiguration",
+		"plugins":    plugins,
+	}, "", "  ")
+}
+
+// enableAdmissionPlugins returns the kube-apiserver args with the plugins added to the enabled admission plugins,
+// keeping the plugins enabled by the args or NodeRestriction when none is.
+func enableAdmissionPlugins(args []string, plugins []rkev1.AdmissionPlugin) []string {
+	names := []string{nodeRestrictionPlugin}
+	if enabled := getArgValue(args, enableAdmissionPluginsArg, "="); enabled != "" {
+		names = strings.Split(enabled, ",")
+	}
+	for _, plugin := range plugins {
+		if !slice.ContainsString(names, plugin.Name) {
+			names = append(names, plugin.Name)
+		}
+	}
+
+	result := make([]string, 0, len(args)+1)
+	for _, arg := range args {
+		if key, _ := splitArgKeyVal(arg, "="); key != enableAdmissionPluginsArg {
+			result = append(result, arg)
+		}
+	}
+	return append(result, fmt.Sprintf("%s=%s", enableAdmissionPluginsArg, strings.Join(names, ",")))
+}
@@ -0,0 +1,215 @@
+package planner
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func eventRateLimit() rkev1.AdmissionPlugin {
+	return rkev1.AdmissionPlugin{
+		Name: "EventRateLimit",
+		Configuration: rkev1.GenericMap{Data: map[string]interface{}{
+			"limits": []interface{}{
+				map[string]interface{}{"type": "Server", "qps": 50, "burst": 100},
+			},
+		}},
+	}
+}
+
+func Test_validateAdmissionConfiguration(t *testing.T) {
+	imagePolicy := func(policy map[string]interface{}) rkev1.AdmissionPlugin {
+		return rkev1.AdmissionPlugin{
+			Name:          "ImagePolicyWebhook",
+			Configuration: rkev1.GenericMap{Data: map[string]interface{}{"imagePolicy": policy}},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		plugins []rkev1.AdmissionPlugin
+		err     string
+	}{
+		{
+			name:    "valid",
+			plugins: []rkev1.AdmissionPlugin{eventRateLimit(), imagePolicy(map[string]interface{}{"kubeConfigFile": "/etc/webhook.yaml", "allowTTL": 50, "defaultAllow": false}), {Name: "AlwaysPullImages"}},
+		},
+		{
+			name:    "no name",
+			plugins: []rkev1.AdmissionPlugin{{}},
+			err:     "admission plugin 0 has no name",
+		},
+		{
+			name:    "duplicate",
+			plugins: []rkev1.AdmissionPlugin{eventRateLimit(), eventRateLimit()},
+			err:     "admission plugin EventRateLimit is configured more than once",
+		},
+		{
+			name:    "no limits",
+			plugins: []rkev1.AdmissionPlugin{{Name: "EventRateLimit"}},
+			err:     "invalid configuration of admission plugin EventRateLimit: at least one limit is required",
+		},
+		{
+			name: "unknown limit type",
+			plugins: []rkev1.AdmissionPlugin{{Name: "EventRateLimit", Configuration: rkev1.GenericMap{Data: map[string]interface{}{
+				"limits": []interface{}{map[string]interface{}{"type": "Pod", "qps": 1, "burst": 1}},
+			}}}},
+			err: `invalid configuration of admission plugin EventRateLimit: limit 0 has unknown type "Pod", must be one of Server, Namespace, User, SourceAndObject`,
+		},
+		{
+			name: "no burst",
+			plugins: []rkev1.AdmissionPlugin{{Name: "EventRateLimit", Configuration: rkev1.GenericMap{Data: map[string]interface{}{
+				"limits": []interface{}{map[string]interface{}{"type": "User", "qps": 1}},
+			}}}},
+			err: "invalid configuration of admission plugin EventRateLimit: limit 0 must have a positive burst",
+		},
+		{
+			name:    "no kubeconfig",
+			plugins: []rkev1.AdmissionPlugin{imagePolicy(map[string]interface{}{"defaultAllow": true})},
+			err:     "invalid configuration of admission plugin ImagePolicyWebhook: imagePolicy.kubeConfigFile or kubeconfigSecretName is required",
+		},
+		{
+			name:    "negative ttl",
+			plugins: []rkev1.AdmissionPlugin{imagePolicy(map[string]interface{}{"kubeConfigFile": "/etc/webhook.yaml", "denyTTL": -1})},
+			err:     "invalid configuration of admission plugin ImagePolicyWebhook: imagePolicy.denyTTL must be a non-negative number",
+		},
+		{
+			name:    "kubeconfig secret of other plugin",
+			plugins: []rkev1.AdmissionPlugin{{Name: "AlwaysPullImages", KubeconfigSecretName: "webhook"}},
+			err:     "admission plugin AlwaysPullImages doesn't support a kubeconfig secret, only ImagePolicyWebhook does",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAdmissionConfiguration(&rkev1.AdmissionConfiguration{Plugins: tt.plugins})
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+	assert.NoError(t, validateAdmissionConfiguration(nil))
+}
+
+func Test_renderAdmissionConfiguration(t *testing.T) {
+	ac := &rkev1.AdmissionConfiguration{
+		Plugins: []rkev1.AdmissionPlugin{
+			eventRateLimit(),
+			{
+				Name: "ImagePolicyWebhook",
+				Configuration: rkev1.GenericMap{Data: map[string]interface{}{
+					"imagePolicy": map[string]interface{}{"defaultAllow": false},
+				}},
+				KubeconfigSecretName: "webhook",
+			},
+			{Name: "AlwaysPullImages"},
+		},
+	}
+
+	content, err := renderAdmissionConfiguration(ac, map[string]string{"ImagePolicyWebhook": "/admission/imagepolicywebhook-kubeconfig.yaml"})
+	require.NoError(t, err)
+
+	var rendered map[string]interface{}
+	require.NoError(t, json.Unmarshal(content, &rendered))
+	assert.Equal(t, map[string]interface{}{
+		"apiVersion": "apiserver.config.k8s.io/v1",
+		"kind":       "AdmissionConfiguration",
+		"plugins": []interface{}{
+			map[string]interface{}{
+				"name": "EventRateLimit",
+				"configuration": map[string]interface{}{
+					"apiVersion": "eventratelimit.admission.k8s.io/v1alpha1",
+					"kind":       "Configuration",
+					"limits": []interface{}{
+						map[string]interface{}{"type": "Server", "qps": float64(50), "burst": float64(100)},
+					},
+				},
+			},
+			map[string]interface{}{
+				"name": "ImagePolicyWebhook",
+				"configuration": map[string]interface{}{
+					"imagePolicy": map[string]interface{}{
+						"defaultAllow":   false,
+						"kubeConfigFile": "/admission/imagepolicywebhook-kubeconfig.yaml",
+					},
+				},
+			},
+			map[string]interface{}{
+				"name": "AlwaysPullImages",
+			},
+		},
+	}, rendered)
+
+	// the configuration of the cluster isn't modified
+	assert.Nil(t, ac.Plugins[0].Configuration.Data["kind"])
+	assert.Nil(t, ac.Plugins[1].Configuration.Data["imagePolicy"].(map[string]interface{})["kubeConfigFile"])
+}
+
+func Test_enableAdmissionPlugins(t *testing.T) {
+	plugins := []rkev1.AdmissionPlugin{{Name: "EventRateLimit"}, {Name: "PodSecurity"}}
+
+	assert.Equal(t, []string{"audit-log-maxage=30", "enable-admission-plugins=NodeRestriction,EventRateLimit,PodSecurity"},
+		enableAdmissionPlugins([]string{"audit-log-maxage=30"}, plugins))
+	assert.Equal(t, []string{"audit-log-maxage=30", "enable-admission-plugins=PodSecurity,AlwaysPullImages,EventRateLimit"},
+		enableAdmissionPlugins([]string{"enable-admission-plugins=PodSecurity,AlwaysPullImages", "audit-log-maxage=30"}, plugins))
+}
+
+func Test_addAdmissionConfiguration(t *testing.T) {
+	controlPlane := &rkev1.RKEControlPlane{}
+	controlPlane.Spec.KubernetesVersion = "v1.25.7+rke2r1"
+	controlPlane.Spec.AdmissionConfiguration = &rkev1.AdmissionConfiguration{
+		Plugins: []rkev1.AdmissionPlugin{eventRateLimit()},
+	}
+	p := &Planner{}
+
+	// worker nodes don't run a kube-apiserver
+	config := map[string]interface{}{}
+	files, err := p.addAdmissionConfiguration(config, controlPlane, createTestPlanEntry("linux"))
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+	assert.Empty(t, config)
+
+	entry := createTestPlanEntry("linux")
+	entry.Metadata.Labels[capr.ControlPlaneRoleLabel] = "true"
+
+	config = map[string]interface{}{
+		"kube-apiserver-arg": []interface{}{"audit-log-maxage=30"},
+	}
+	files, err = p.addAdmissionConfiguration(config, controlPlane, entry)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "/var/lib/rancher/rke2/etc/config-files/admission/admission-config.yaml", files[0].Path)
+	content, err := base64.StdEncoding.DecodeString(files[0].Content)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"name": "EventRateLimit"`)
+	assert.Equal(t, []string{
+		"audit-log-maxage=30",
+		"enable-admission-plugins=NodeRestriction,EventRateLimit",
+		"admission-control-config-file=/var/lib/rancher/rke2/etc/config-files/admission/admission-config.yaml",
+	}, config["kube-apiserver-arg"])
+	assert.Equal(t, []string{
+		"/var/lib/rancher/rke2/etc/config-files/admission:/var/lib/rancher/rke2/etc/config-files/admission:ro",
+	}, config["kube-apiserver-extra-mount"])
+
+	// changing the configuration changes the files of the plan, restarting the kube-apiserver
+	controlPlane.Spec.AdmissionConfiguration.Plugins[0].Configuration.Data["limits"] = []interface{}{
+		map[string]interface{}{"type": "Namespace", "qps": 5, "burst": 10},
+	}
+	changed, err := p.addAdmissionConfiguration(map[string]interface{}{}, controlPlane, entry)
+	require.NoError(t, err)
+	assert.NotEqual(t, restartStamp(plan.NodePlan{Files: files}, controlPlane, "image"),
+		restartStamp(plan.NodePlan{Files: changed}, controlPlane, "image"))
+
+	// the admission control config file can't be set with the kube-apiserver args too
+	_, err = p.addAdmissionConfiguration(map[string]interface{}{
+		"kube-apiserver-arg": []string{"admission-control-config-file=/etc/admission.yaml"},
+	}, controlPlane, entry)
+	assert.EqualError(t, err, "kube-apiserver arg admission-control-config-file cannot be set when the admission configuration of the cluster is defined")
+}
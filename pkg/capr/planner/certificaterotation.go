@@ -9,7 +9,7 @@
 	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
 	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
 	"github.com/rancher/rancher/pkg/capr"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 )
 
 // rotateCertificates checks if there is a need to rotate any certificates and updates the plan accordingly.
@@ -20,14 +20,18 @@ func (p *Planner) rotateCertificates(controlPlane *rkev1.RKEControlPlane, status
 
 	found, joinServer, _, err := p.findInitNode(controlPlane, clusterPlan)
 	if err != nil {
-		logrus.Errorf("[planner] rkecluster %s/%s: error encountered while searching for init node during certificate rotation: %v", controlPlane.Namespace, controlPlane.Name, err)
+		logger.Errorf("[planner] rkecluster %s/%s: error encountered while searching for init node during certificate rotation: %v", controlPlane.Namespace, controlPlane.Name, err)
 		return status, err
 	}
 	if !found || joinServer == "" {
-		logrus.Warnf("[planner] rkecluster %s/%s: skipping certificate creation as cluster does not have an init node", controlPlane.Namespace, controlPlane.Name)
+		logger.Warnf("[planner] rkecluster %s/%s: skipping certificate creation as cluster does not have an init node", controlPlane.Namespace, controlPlane.Name)
 		return status, nil
 	}
 
+	if status, err = p.acquireLockPools(controlPlane, status, "certificate rotation"); err != nil {
+		return status, err
+	}
+
 	for _, node := range collect(clusterPlan, anyRole) {
 		if !shouldRotateEntry(controlPlane.Spec.RotateCertificates, node) {
 			continue
@@ -38,7 +42,7 @@ func (p *Planner) rotateCertificates(controlPlane *rkev1.RKEControlPlane, status
 			return status, err
 		}
 
-		err = assignAndCheckPlan(p.store, fmt.Sprintf("[%s] certificate rotation", node.Machine.Name), node, rotatePlan, joinedServer, 0, 0)
+		err = assignAndCheckPlan(p.store, fmt.Sprintf("[%s] certificate rotation", node.Machine.Name), capr.WaitingForCertificateRotation, node, rotatePlan, joinedServer, 0, 0)
 		if err != nil {
 			// Ensure the CAPI cluster is paused if we have assigned and are checking a plan.
 			if pauseErr := p.pauseCAPICluster(controlPlane, true); pauseErr != nil {
@@ -49,11 +53,11 @@ func (p *Planner) rotateCertificates(controlPlane *rkev1.RKEControlPlane, status
 	}
 
 	if err := p.pauseCAPICluster(controlPlane, false); err != nil {
-		return status, errWaiting("unpausing CAPI cluster")
+		return status, newErrWaiting(capr.WaitingForCertificateRotation, "unpausing CAPI cluster")
 	}
 
 	status.CertificateRotationGeneration = controlPlane.Spec.RotateCertificates.Generation
-	return status, errWaiting("certificate rotation done")
+	return status, newErrWaiting(capr.WaitingForCertificateRotation, "certificate rotation done")
 }
 
 // shouldRotate `true` if the cluster is ready and the generation is stale
@@ -65,7 +69,7 @@ func shouldRotate(cp *rkev1.RKEControlPlane) bool {
 
 	// The controlplane must be initialized before we rotate anything
 	if cp.Status.Initialized != true {
-		logrus.Warnf("[planner] rkecluster %s/%s: skipping certificate rotation as cluster was not initialized", cp.Namespace, cp.Name)
+		logger.Warnf("[planner] rkecluster %s/%s: skipping certificate rotation as cluster was not initialized", cp.Namespace, cp.Name)
 		return false
 	}
 
@@ -143,9 +147,10 @@ func (p *Planner) rotateCertificatesPlan(controlPlane *rkev1.RKEControlPlane, to
 		Path:    rotateScriptPath,
 	})
 	rotatePlan.Instructions = append(rotatePlan.Instructions, plan.OneTimeInstruction{
-		Name:    "rotate certificates",
-		Command: "sh",
-		Args:    args,
+		Name:           "rotate certificates",
+		Command:        "sh",
+		Args:           args,
+		TimeoutSeconds: instructionTimeout(controlPlane, rotateCertificatesTimeoutSeconds),
 	})
 	if isControlPlane(entry) {
 		// The following kube-scheduler and kube-controller-manager certificates are self-signed by the respective services and are used by CAPR for secure healthz probes against the service.
@@ -15,6 +15,7 @@
 	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
 	"github.com/rancher/rancher/pkg/capr"
 	"github.com/rancher/rancher/pkg/controllers/management/secretmigrator"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/nodeconfig"
 	"github.com/rancher/rancher/pkg/provisioningv2/image"
 	"github.com/rancher/wrangler/pkg/data"
@@ -22,7 +23,6 @@
 	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
 	"github.com/rancher/wrangler/pkg/kv"
 	"github.com/rancher/wrangler/pkg/yaml"
-	"github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -128,14 +128,14 @@ func addRoleConfig(config map[string]interface{}, controlPlane *rkev1.RKEControl
 	// If this is a control-plane node, then we need to set arguments/(and for RKE2, volume mounts) to allow probes
 	// to run.
 	if isControlPlane(entry) {
-		logrus.Debug("addRoleConfig rendering arguments and mounts for kube-controller-manager")
+		logger.Debug("addRoleConfig rendering arguments and mounts for kube-controller-manager")
 		certDirArg, certDirMount := renderArgAndMount(config[KubeControllerManagerArg], config[KubeControllerManagerExtraMount], runtime, DefaultKubeControllerManagerDefaultSecurePort, DefaultKubeControllerManagerCertDir)
 		config[KubeControllerManagerArg] = certDirArg
 		if runtime == capr.RuntimeRKE2 {
 			config[KubeControllerManagerExtraMount] = certDirMount
 		}
 
-		logrus.Debug("addRoleConfig rendering arguments and mounts for kube-scheduler")
+		logger.Debug("addRoleConfig rendering arguments and mounts for kube-scheduler")
 		certDirArg, certDirMount = renderArgAndMount(config[KubeSchedulerArg], config[KubeSchedulerExtraMount], runtime, DefaultKubeSchedulerDefaultSecurePort, DefaultKubeSchedulerCertDir)
 		config[KubeSchedulerArg] = certDirArg
 		if runtime == capr.RuntimeRKE2 {
@@ -222,25 +222,47 @@ func (h *helmChartConfig) DeepCopyObject() runtime.Object {
 	panic("unsupported")
 }
 
+// systemChartValues returns the values of the system charts rendered for the entry: the chart values of the control
+// plane, with the defaults rendered by rancher.
+func systemChartValues(controlPlane *rkev1.RKEControlPlane, entry *planEntry) (map[string]interface{}, error) {
+	chartValues, err := addVSphereCharts(controlPlane, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	if nodeLocalDNSEnabled(controlPlane) {
+		config := map[string]interface{}{}
+		if err := addUserConfig(config, controlPlane, entry); err != nil {
+			return nil, err
+		}
+		chartValues = addNodeLocalDNSChartValues(chartValues, controlPlane, kubeProxyIPVS(config))
+	}
+
+	result := map[string]interface{}{}
+	for chart, values := range chartValues {
+		valuesMap := convert.ToMapInterface(values)
+		if valuesMap == nil {
+			valuesMap = map[string]interface{}{}
+		}
+		data.PutValue(valuesMap, controlPlane.Spec.ManagementClusterName, "global", "cattle", "clusterId")
+		result[chart] = valuesMap
+	}
+	return result, nil
+}
+
 func (p *Planner) addChartConfigs(nodePlan plan.NodePlan, controlPlane *rkev1.RKEControlPlane, entry *planEntry) (plan.NodePlan, error) {
 	if isOnlyWorker(entry) {
 		return nodePlan, nil
 	}
 
-	chartValues, err := addVSphereCharts(controlPlane, entry)
+	chartValues, err := systemChartValues(controlPlane, entry)
 	if err != nil {
 		return nodePlan, err
 	}
 
 	var chartConfigs []runtime.Object
 	for _, chart := range capr.SortedKeys(chartValues) {
-		valuesMap := convert.ToMapInterface(chartValues[chart])
-		if valuesMap == nil {
-			valuesMap = map[string]interface{}{}
-		}
-		data.PutValue(valuesMap, controlPlane.Spec.ManagementClusterName, "global", "cattle", "clusterId")
-
-		data, err := json.Marshal(valuesMap)
+		data, err := json.Marshal(chartValues[chart])
 		if err != nil {
 			return plan.NodePlan{}, err
 		}
@@ -264,11 +286,15 @@ func (p *Planner) addChartConfigs(nodePlan plan.NodePlan, controlPlane *rkev1.RK
 		return plan.NodePlan{}, err
 	}
 
-	nodePlan.Files = append(nodePlan.Files, plan.File{
+	file := plan.File{
 		Content: base64.StdEncoding.EncodeToString(contents),
 		Path:    fmt.Sprintf("/var/lib/rancher/%s/server/manifests/rancher/managed-chart-config.yaml", capr.GetRuntime(controlPlane.Spec.KubernetesVersion)),
 		Dynamic: true,
-	})
+	}
+	if systemChartValuesHeld(&controlPlane.Status) {
+		file = previousFile(entry, file)
+	}
+	nodePlan.Files = append(nodePlan.Files, file)
 
 	return nodePlan, nil
 }
@@ -332,6 +358,7 @@ func addAddresses(secrets corecontrollers.SecretCache, config map[string]interfa
 		}
 	}
 
+	ipAddress, internalIPAddress = unbracketAddress(ipAddress), unbracketAddress(internalIPAddress)
 	setNodeExternalIP := ipAddress != "" && internalIPAddress != "" && ipAddress != internalIPAddress
 
 	if setNodeExternalIP && !isOnlyWorker(entry) {
@@ -549,6 +576,11 @@ func (p *Planner) addConfigFile(nodePlan plan.NodePlan, controlPlane *rkev1.RKEC
 	if err := addUserConfig(config, controlPlane, entry); err != nil {
 		return nodePlan, config, "", err
 	}
+	addNodeLocalDNSConfig(config, controlPlane)
+	if err := addGarbageCollectionConfig(config, entry); err != nil {
+		return nodePlan, config, "", err
+	}
+	addProviderIDConfig(config, entry)
 
 	files, err := p.addETCD(config, controlPlane, entry, renderS3)
 	if err != nil {
@@ -558,6 +590,12 @@ func (p *Planner) addConfigFile(nodePlan plan.NodePlan, controlPlane *rkev1.RKEC
 
 	joinedServer := addRoleConfig(config, controlPlane, entry, joinServer)
 	addLocalClusterAuthenticationEndpointConfig(config, controlPlane, entry)
+	addEmbeddedRegistryConfig(config, controlPlane, entry)
+	files, err = p.addAdmissionConfiguration(config, controlPlane, entry)
+	if err != nil {
+		return nodePlan, config, joinedServer, err
+	}
+	nodePlan.Files = append(nodePlan.Files, files...)
 	addToken(config, entry, tokensSecret)
 
 	if err := addAddresses(p.secretCache, config, entry); err != nil {
@@ -0,0 +1,161 @@
+package planner
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/rancher/rancher/pkg/capr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// configDropInFileName is the path of a config drop-in, by runtime, order and name.
+	configDropInFileName = "/etc/rancher/%s/config.yaml.d/%02d-dropin-%s.yaml"
+	// rancherConfigOrder is the order of the config rendered by rancher, ConfigYamlFileName.
+	rancherConfigOrder = 50
+
+	removeStaleConfigDropInsInstructionName = "remove stale config drop-ins"
+)
+
+// configDropInProtectedArgs are the args rancher relies on to join nodes to the cluster, which drop-ins can't set.
+var configDropInProtectedArgs = []string{"server", "token", "agent-token", "cluster-init"}
+
+// validateConfigDropIn returns an error if a config drop-in can't be written, or would conflict with the config
+// rendered by rancher.
+func validateConfigDropIn(dropIn rkev1.RKEConfigDropIn) error {
+	if errs := validation.IsDNS1123Label(dropIn.Name); len(errs) > 0 {
+		return fmt.Errorf("invalid config drop-in name %q: %s", dropIn.Name, strings.Join(errs, ", "))
+	}
+	if dropIn.Order < 0 || dropIn.Order > 99 || dropIn.Order == rancherConfigOrder {
+		return fmt.Errorf("invalid order %d of config drop-in %s: must be from 0 to 99, except %d", dropIn.Order, dropIn.Name, rancherConfigOrder)
+	}
+	for _, arg := range configDropInProtectedArgs {
+		if _, ok := dropIn.Config.Data[arg]; ok {
+			return fmt.Errorf("config drop-in %s cannot set %s, which is managed by rancher", dropIn.Name, arg)
+		}
+	}
+	return nil
+}
+
+// addConfigDropIns adds the config drop-ins matching the machine of the entry to the plan, in the order they're merged
+// in. As the restart stamp of the plan covers its files, the machine is restarted when they change. Drop-ins that were
+// removed, or all of them if the config drop-ins planner behavior is disabled, are deleted from Linux machines before
+// the runtime is restarted.
+func addConfigDropIns(nodePlan plan.NodePlan, controlPlane *rkev1.RKEControlPlane, entry *planEntry) (plan.NodePlan, error) {
+	runtime := capr.GetRuntime(controlPlane.Spec.KubernetesVersion)
+
+	dropIns := controlPlane.Spec.MachineSelectorConfigDropIns
+	if !plannerBehaviorEnabled(controlPlane, capr.PlannerBehaviorConfigDropIns) {
+		dropIns = nil
+	}
+
+	var files []plan.File
+	seen := map[string]bool{}
+	for _, dropIn := range dropIns {
+		if err := validateConfigDropIn(dropIn); err != nil {
+			return nodePlan, err
+		}
+		filePath := fmt.Sprintf(configDropInFileName, runtime, dropIn.Order, dropIn.Name)
+		if seen[filePath] {
+			return nodePlan, fmt.Errorf("duplicate config drop-in %s with order %d", dropIn.Name, dropIn.Order)
+		}
+		seen[filePath] = true
+
+		sel, err := metav1.LabelSelectorAsSelector(dropIn.MachineLabelSelector)
+		if err != nil {
+			return nodePlan, err
+		}
+		if dropIn.MachineLabelSelector != nil && !sel.Matches(labels.Set(entry.Machine.Labels)) {
+			continue
+		}
+
+		config := map[string]interface{}{}
+		for k, v := range dropIn.Config.Data {
+			config[k] = v
+		}
+		filterConfigData(config, controlPlane, entry)
+		content, err := json.MarshalIndent(config, "", "  ")
+		if err != nil {
+			return nodePlan, err
+		}
+		files = append(files, plan.File{
+			Content: base64.StdEncoding.EncodeToString(content),
+			Path:    filePath,
+		})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+	nodePlan.Files = append(nodePlan.Files, files...)
+
+	if entry.Metadata.Labels[capr.CattleOSLabel] == capr.WindowsMachineOS || !configDropInsManaged(controlPlane, entry) {
+		return nodePlan, nil
+	}
+	nodePlan.Instructions = append(nodePlan.Instructions, removeStaleConfigDropInsInstruction(runtime, files))
+	return nodePlan, nil
+}
+
+// configDropInsManaged returns whether the config drop-ins of the machine of the entry are managed, either because the
+// cluster has drop-ins or because the plan of the machine had some. Clusters that never had drop-ins leave the
+// config.yaml.d directory of their machines alone.
+func configDropInsManaged(controlPlane *rkev1.RKEControlPlane, entry *planEntry) bool {
+	if len(controlPlane.Spec.MachineSelectorConfigDropIns) > 0 {
+		return true
+	}
+	if entry.Plan == nil {
+		return false
+	}
+	for _, nodePlan := range []*plan.NodePlan{&entry.Plan.Plan, entry.Plan.AppliedPlan} {
+		if nodePlan == nil {
+			continue
+		}
+		for _, instruction := range nodePlan.Instructions {
+			if instruction.Name == removeStaleConfigDropInsInstructionName {
+				return true
+			}
+		}
+		for _, file := range nodePlan.Files {
+			if isConfigDropIn(file.Path) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isConfigDropIn(filePath string) bool {
+	dir, file := path.Split(filePath)
+	return strings.HasSuffix(dir, "/config.yaml.d/") && strings.Contains(file, "-dropin-") && strings.HasSuffix(file, ".yaml")
+}
+
+// removeStaleConfigDropInsInstruction returns the instruction deleting the drop-ins of the config.yaml.d directory
+// that aren't among the given files.
+func removeStaleConfigDropInsInstruction(runtime string, files []plan.File) plan.OneTimeInstruction {
+	keep := make([]string, 0, len(files))
+	for _, file := range files {
+		keep = append(keep, path.Base(file.Path))
+	}
+	return plan.OneTimeInstruction{
+		Name:    removeStaleConfigDropInsInstructionName,
+		Command: "sh",
+		Args: []string{
+			"-c",
+			fmt.Sprintf(`cd /etc/rancher/%s/config.yaml.d 2>/dev/null || exit 0
+for f in [0-9][0-9]-dropin-*.yaml; do
+  [ -e "$f" ] || continue
+  case " %s " in
+    *" $f "*) ;;
+    *) rm -f "$f" ;;
+  esac
+done`, runtime, strings.Join(keep, " ")),
+		},
+	}
+}
@@ -0,0 +1,82 @@
+package planner
+
+import (
+	"encoding/base64"
+	"testing"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateConfigDropIn(t *testing.T) {
+	tests := []struct {
+		name    string
+		dropIn  rkev1.RKEConfigDropIn
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			dropIn: rkev1.RKEConfigDropIn{Name: "kubelet-tuning", Order: 60, Config: rkev1.GenericMap{Data: map[string]interface{}{"kubelet-arg": []interface{}{"max-pods=200"}}}},
+		},
+		{
+			name:    "invalid name",
+			dropIn:  rkev1.RKEConfigDropIn{Name: "Kubelet_Tuning", Order: 60},
+			wantErr: true,
+		},
+		{
+			name:    "order of the rancher config",
+			dropIn:  rkev1.RKEConfigDropIn{Name: "tuning", Order: 50},
+			wantErr: true,
+		},
+		{
+			name:    "order out of range",
+			dropIn:  rkev1.RKEConfigDropIn{Name: "tuning", Order: 100},
+			wantErr: true,
+		},
+		{
+			name:    "protected arg",
+			dropIn:  rkev1.RKEConfigDropIn{Name: "tuning", Order: 90, Config: rkev1.GenericMap{Data: map[string]interface{}{"server": "https://elsewhere:9345"}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateConfigDropIn(tt.dropIn)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfigDropInsManaged(t *testing.T) {
+	withDropIns := &rkev1.RKEControlPlane{}
+	withDropIns.Spec.MachineSelectorConfigDropIns = []rkev1.RKEConfigDropIn{{Name: "tuning", Order: 60}}
+	withoutDropIns := &rkev1.RKEControlPlane{}
+
+	dropInFile := plan.File{Path: "/etc/rancher/rke2/config.yaml.d/60-dropin-tuning.yaml"}
+	rancherFile := plan.File{Path: "/etc/rancher/rke2/config.yaml.d/50-rancher.yaml"}
+
+	assert.True(t, configDropInsManaged(withDropIns, &planEntry{}))
+	assert.False(t, configDropInsManaged(withoutDropIns, &planEntry{}))
+	assert.False(t, configDropInsManaged(withoutDropIns, &planEntry{Plan: &plan.Node{Plan: plan.NodePlan{Files: []plan.File{rancherFile}}}}))
+	assert.True(t, configDropInsManaged(withoutDropIns, &planEntry{Plan: &plan.Node{AppliedPlan: &plan.NodePlan{Files: []plan.File{rancherFile, dropInFile}}}}),
+		"drop-ins of the applied plan must be removed")
+	assert.True(t, configDropInsManaged(withoutDropIns, &planEntry{Plan: &plan.Node{Plan: plan.NodePlan{Instructions: []plan.OneTimeInstruction{{Name: removeStaleConfigDropInsInstructionName}}}}}),
+		"the removal instruction is kept once drop-ins were removed")
+}
+
+func TestRemoveStaleConfigDropInsInstruction(t *testing.T) {
+	instruction := removeStaleConfigDropInsInstruction("k3s", []plan.File{
+		{Path: "/etc/rancher/k3s/config.yaml.d/10-dropin-defaults.yaml", Content: base64.StdEncoding.EncodeToString([]byte("{}"))},
+		{Path: "/etc/rancher/k3s/config.yaml.d/60-dropin-tuning.yaml", Content: base64.StdEncoding.EncodeToString([]byte("{}"))},
+	})
+	assert.Equal(t, removeStaleConfigDropInsInstructionName, instruction.Name)
+	assert.Equal(t, "sh", instruction.Command)
+	assert.Len(t, instruction.Args, 2)
+	assert.Contains(t, instruction.Args[1], "cd /etc/rancher/k3s/config.yaml.d")
+	assert.Contains(t, instruction.Args[1], `case " 10-dropin-defaults.yaml 60-dropin-tuning.yaml " in`)
+}
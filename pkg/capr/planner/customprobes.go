@@ -0,0 +1,100 @@
+package planner
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// validateCustomProbe returns an error if a custom probe can't be evaluated by the system agent, or would replace a
+// probe of the Kubernetes components.
+func validateCustomProbe(probe rkev1.RKEProbe) error {
+	if errs := validation.IsDNS1123Label(probe.Name); len(errs) > 0 {
+		return fmt.Errorf("invalid probe name %q: %s", probe.Name, strings.Join(errs, ", "))
+	}
+	if _, ok := allProbes[probe.Name]; ok {
+		return fmt.Errorf("probe %s cannot replace the probe of the Kubernetes component", probe.Name)
+	}
+	if probe.InitialDelaySeconds < 0 || probe.TimeoutSeconds < 0 || probe.SuccessThreshold < 0 || probe.FailureThreshold < 0 {
+		return fmt.Errorf("invalid probe %s: delays and thresholds cannot be negative", probe.Name)
+	}
+
+	var checks int
+	if probe.HTTPGet != nil {
+		checks++
+		if !strings.HasPrefix(probe.HTTPGet.URL, "http://") && !strings.HasPrefix(probe.HTTPGet.URL, "https://") {
+			return fmt.Errorf("invalid URL %q of probe %s: must be http or https", probe.HTTPGet.URL, probe.Name)
+		}
+	}
+	if probe.TCPSocket != nil {
+		checks++
+		if _, _, err := net.SplitHostPort(probe.TCPSocket.Address); err != nil {
+			return fmt.Errorf("invalid address %q of probe %s: %w", probe.TCPSocket.Address, probe.Name, err)
+		}
+	}
+	if probe.Exec != nil {
+		checks++
+		if len(probe.Exec.Command) == 0 {
+			return fmt.Errorf("probe %s has no command", probe.Name)
+		}
+	}
+	if checks != 1 {
+		return fmt.Errorf("probe %s must have exactly one of httpGet, tcpSocket and exec", probe.Name)
+	}
+	return nil
+}
+
+// addCustomProbes adds the custom probes of the cluster matching the machine of the entry to its probes.
+func addCustomProbes(probes map[string]plan.Probe, controlPlane *rkev1.RKEControlPlane, entry *planEntry) (map[string]plan.Probe, error) {
+	seen := map[string]bool{}
+	for _, probe := range controlPlane.Spec.MachineSelectorProbes {
+		if err := validateCustomProbe(probe); err != nil {
+			return probes, err
+		}
+		if seen[probe.Name] {
+			return probes, fmt.Errorf("duplicate probe %s", probe.Name)
+		}
+		seen[probe.Name] = true
+
+		sel, err := metav1.LabelSelectorAsSelector(probe.MachineLabelSelector)
+		if err != nil {
+			return probes, err
+		}
+		if probe.MachineLabelSelector != nil && !sel.Matches(labels.Set(entry.Machine.Labels)) {
+			continue
+		}
+
+		planProbe := plan.Probe{
+			InitialDelaySeconds: probe.InitialDelaySeconds,
+			TimeoutSeconds:      probe.TimeoutSeconds,
+			SuccessThreshold:    probe.SuccessThreshold,
+			FailureThreshold:    probe.FailureThreshold,
+		}
+		switch {
+		case probe.HTTPGet != nil:
+			planProbe.HTTPGetAction = plan.HTTPGetAction{
+				URL:        probe.HTTPGet.URL,
+				Insecure:   probe.HTTPGet.Insecure,
+				CACert:     probe.HTTPGet.CACert,
+				ClientCert: probe.HTTPGet.ClientCert,
+				ClientKey:  probe.HTTPGet.ClientKey,
+			}
+		case probe.TCPSocket != nil:
+			planProbe.TCPSocketAction = &plan.TCPSocketAction{
+				Address: probe.TCPSocket.Address,
+			}
+		case probe.Exec != nil:
+			planProbe.ExecAction = &plan.ExecAction{
+				Command: append([]string(nil), probe.Exec.Command...),
+			}
+		}
+		probes[probe.Name] = planProbe
+	}
+	return probes, nil
+}
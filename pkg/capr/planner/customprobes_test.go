@@ -0,0 +1,135 @@
+package planner
+
+import (
+	"testing"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestValidateCustomProbe(t *testing.T) {
+	tests := []struct {
+		name    string
+		probe   rkev1.RKEProbe
+		wantErr bool
+	}{
+		{
+			name:  "http",
+			probe: rkev1.RKEProbe{Name: "ingress", HTTPGet: &rkev1.RKEProbeHTTPGetAction{URL: "http://127.0.0.1:10254/healthz"}},
+		},
+		{
+			name:  "tcp",
+			probe: rkev1.RKEProbe{Name: "proxy", TCPSocket: &rkev1.RKEProbeTCPSocketAction{Address: "127.0.0.1:3128"}},
+		},
+		{
+			name:  "exec",
+			probe: rkev1.RKEProbe{Name: "csi-socket", Exec: &rkev1.RKEProbeExecAction{Command: []string{"test", "-S", "/var/lib/kubelet/plugins/csi.sock"}}},
+		},
+		{
+			name:    "invalid name",
+			probe:   rkev1.RKEProbe{Name: "CSI_Socket", Exec: &rkev1.RKEProbeExecAction{Command: []string{"true"}}},
+			wantErr: true,
+		},
+		{
+			name:    "built-in name",
+			probe:   rkev1.RKEProbe{Name: "kubelet", TCPSocket: &rkev1.RKEProbeTCPSocketAction{Address: "127.0.0.1:10250"}},
+			wantErr: true,
+		},
+		{
+			name:    "no check",
+			probe:   rkev1.RKEProbe{Name: "nothing"},
+			wantErr: true,
+		},
+		{
+			name: "several checks",
+			probe: rkev1.RKEProbe{
+				Name:      "both",
+				TCPSocket: &rkev1.RKEProbeTCPSocketAction{Address: "127.0.0.1:3128"},
+				Exec:      &rkev1.RKEProbeExecAction{Command: []string{"true"}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "address without port",
+			probe:   rkev1.RKEProbe{Name: "proxy", TCPSocket: &rkev1.RKEProbeTCPSocketAction{Address: "127.0.0.1"}},
+			wantErr: true,
+		},
+		{
+			name:    "url without scheme",
+			probe:   rkev1.RKEProbe{Name: "ingress", HTTPGet: &rkev1.RKEProbeHTTPGetAction{URL: "127.0.0.1:10254/healthz"}},
+			wantErr: true,
+		},
+		{
+			name:    "empty command",
+			probe:   rkev1.RKEProbe{Name: "csi-socket", Exec: &rkev1.RKEProbeExecAction{}},
+			wantErr: true,
+		},
+		{
+			name:    "negative threshold",
+			probe:   rkev1.RKEProbe{Name: "proxy", FailureThreshold: -1, TCPSocket: &rkev1.RKEProbeTCPSocketAction{Address: "127.0.0.1:3128"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCustomProbe(tt.probe)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAddCustomProbes(t *testing.T) {
+	controlPlane := &rkev1.RKEControlPlane{}
+	controlPlane.Spec.MachineSelectorProbes = []rkev1.RKEProbe{
+		{
+			Name:             "csi-socket",
+			FailureThreshold: 5,
+			Exec:             &rkev1.RKEProbeExecAction{Command: []string{"test", "-S", "/var/lib/kubelet/plugins/csi.sock"}},
+		},
+		{
+			MachineLabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"proxy": "true"}},
+			Name:                 "proxy",
+			TCPSocket:            &rkev1.RKEProbeTCPSocketAction{Address: "127.0.0.1:3128"},
+		},
+	}
+	entry := &planEntry{
+		Machine: &capi.Machine{},
+	}
+	builtIn := map[string]plan.Probe{"kubelet": allProbes["kubelet"]}
+
+	probes, err := addCustomProbes(copyProbes(builtIn), controlPlane, entry)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]plan.Probe{
+		"kubelet": allProbes["kubelet"],
+		"csi-socket": {
+			FailureThreshold: 5,
+			ExecAction:       &plan.ExecAction{Command: []string{"test", "-S", "/var/lib/kubelet/plugins/csi.sock"}},
+		},
+	}, probes)
+
+	entry.Machine.Labels = map[string]string{"proxy": "true"}
+	probes, err = addCustomProbes(copyProbes(builtIn), controlPlane, entry)
+	require.NoError(t, err)
+	assert.Len(t, probes, 3)
+	assert.Equal(t, &plan.TCPSocketAction{Address: "127.0.0.1:3128"}, probes["proxy"].TCPSocketAction)
+
+	controlPlane.Spec.MachineSelectorProbes = append(controlPlane.Spec.MachineSelectorProbes, controlPlane.Spec.MachineSelectorProbes[1])
+	_, err = addCustomProbes(copyProbes(builtIn), controlPlane, entry)
+	assert.Error(t, err, "duplicate probes")
+}
+
+func copyProbes(probes map[string]plan.Probe) map[string]plan.Probe {
+	result := map[string]plan.Probe{}
+	for k, v := range probes {
+		result[k] = v
+	}
+	return result
+}
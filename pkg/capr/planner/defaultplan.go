@@ -11,12 +11,13 @@
 // commonNodePlan returns a "default" node plan with the corresponding registry configuration.
 // It will append to the node plan passed in through options.
 func (p *Planner) commonNodePlan(controlPlane *rkev1.RKEControlPlane, np plan.NodePlan) (plan.NodePlan, registries, error) {
-	if controlPlane.Spec.Registries == nil {
+	registry := registriesWithEmbeddedMirrors(controlPlane)
+	if registry == nil {
 		return np, registries{}, nil
 	}
 
 	reg, err := p.renderRegistries(capr.GetRuntime(controlPlane.Spec.KubernetesVersion),
-		controlPlane.Namespace, controlPlane.Spec.Registries)
+		controlPlane.Namespace, registry)
 	if err != nil {
 		return plan.NodePlan{}, registries{}, err
 	}
@@ -0,0 +1,89 @@
+package planner
+
+import (
+	"sort"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+)
+
+const (
+	embeddedRegistryArg               = "embedded-registry"
+	disableDefaultRegistryEndpointArg = "disable-default-registry-endpoint"
+
+	// allRegistries is the wildcard mirror of the registries.yaml file, matching every registry.
+	allRegistries = "*"
+)
+
+func embeddedRegistryEnabled(controlPlane *rkev1.RKEControlPlane) bool {
+	return controlPlane.Spec.EmbeddedRegistry != nil && controlPlane.Spec.EmbeddedRegistry.Enabled
+}
+
+// registriesWithEmbeddedMirrors returns the registries of the control plane, with a mirror added for each registry
+// mirrored by the embedded registry that isn't already mirrored. The embedded registry only serves the images of
+// registries with a mirror in the registries.yaml file of the node.
+func registriesWithEmbeddedMirrors(controlPlane *rkev1.RKEControlPlane) *rkev1.Registry {
+	if !embeddedRegistryEnabled(controlPlane) {
+		return controlPlane.Spec.Registries
+	}
+
+	registry := &rkev1.Registry{}
+	if controlPlane.Spec.Registries != nil {
+		registry = controlPlane.Spec.Registries.DeepCopy()
+	}
+	if registry.Mirrors == nil {
+		registry.Mirrors = map[string]rkev1.Mirror{}
+	}
+
+	mirrored := controlPlane.Spec.EmbeddedRegistry.Registries
+	if len(mirrored) == 0 {
+		mirrored = []string{allRegistries}
+	}
+	for _, name := range mirrored {
+		if _, ok := registry.Mirrors[name]; !ok {
+			registry.Mirrors[name] = rkev1.Mirror{}
+		}
+	}
+
+	return registry
+}
+
+// addEmbeddedRegistryConfig enables the embedded registry on the servers of the cluster, which enable it for their
+// agents, and disables the default endpoint of mirrored registries on every node when requested.
+func addEmbeddedRegistryConfig(config map[string]interface{}, controlPlane *rkev1.RKEControlPlane, entry *planEntry) {
+	if !embeddedRegistryEnabled(controlPlane) {
+		return
+	}
+
+	if !isOnlyWorker(entry) {
+		config[embeddedRegistryArg] = true
+	}
+	if controlPlane.Spec.EmbeddedRegistry.DisableDefaultEndpoint {
+		config[disableDefaultRegistryEndpointArg] = true
+	}
+}
+
+// embeddedRegistryNodes returns the participation of the machines of the cluster in its embedded registry. A machine
+// participates once the plan enabling the embedded registry is applied, which isn't supported on Windows.
+func embeddedRegistryNodes(controlPlane *rkev1.RKEControlPlane, clusterPlan *plan.Plan) []rkev1.EmbeddedRegistryNode {
+	if !embeddedRegistryEnabled(controlPlane) {
+		return nil
+	}
+
+	var result []rkev1.EmbeddedRegistryNode
+	for _, entry := range collect(clusterPlan, isNotDeleting) {
+		node := rkev1.EmbeddedRegistryNode{
+			MachineName:   entry.Machine.Name,
+			Participating: entry.Plan != nil && entry.Plan.InSync && !entry.Plan.Failed && !windows(entry),
+		}
+		if entry.Machine.Status.NodeRef != nil {
+			node.NodeName = entry.Machine.Status.NodeRef.Name
+		}
+		result = append(result, node)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].MachineName < result[j].MachineName
+	})
+	return result
+}
@@ -0,0 +1,137 @@
+package planner
+
+import (
+	"testing"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func controlPlaneWithEmbeddedRegistry(embedded *rkev1.EmbeddedRegistry, registry *rkev1.Registry) *rkev1.RKEControlPlane {
+	cp := &rkev1.RKEControlPlane{}
+	cp.Spec.EmbeddedRegistry = embedded
+	cp.Spec.Registries = registry
+	return cp
+}
+
+func Test_registriesWithEmbeddedMirrors(t *testing.T) {
+	registry := &rkev1.Registry{
+		Mirrors: map[string]rkev1.Mirror{
+			"docker.io": {Endpoints: []string{"https://mirror.example.com"}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		embedded *rkev1.EmbeddedRegistry
+		registry *rkev1.Registry
+		expected *rkev1.Registry
+	}{
+		{
+			name:     "disabled",
+			embedded: &rkev1.EmbeddedRegistry{Registries: []string{"quay.io"}},
+			registry: registry,
+			expected: registry,
+		},
+		{
+			name:     "disabled without registries",
+			expected: nil,
+		},
+		{
+			name:     "all registries",
+			embedded: &rkev1.EmbeddedRegistry{Enabled: true},
+			expected: &rkev1.Registry{
+				Mirrors: map[string]rkev1.Mirror{
+					"*": {},
+				},
+			},
+		},
+		{
+			name:     "keeps existing mirrors",
+			embedded: &rkev1.EmbeddedRegistry{Enabled: true, Registries: []string{"docker.io", "quay.io"}},
+			registry: registry,
+			expected: &rkev1.Registry{
+				Mirrors: map[string]rkev1.Mirror{
+					"docker.io": {Endpoints: []string{"https://mirror.example.com"}},
+					"quay.io":   {},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, registriesWithEmbeddedMirrors(controlPlaneWithEmbeddedRegistry(tt.embedded, tt.registry)))
+		})
+	}
+
+	assert.Len(t, registry.Mirrors, 1, "registries of the control plane must not be modified")
+}
+
+func Test_addEmbeddedRegistryConfig(t *testing.T) {
+	server := &planEntry{Metadata: &plan.Metadata{Labels: map[string]string{capr.ControlPlaneRoleLabel: "true"}}}
+	worker := &planEntry{Metadata: &plan.Metadata{Labels: map[string]string{capr.WorkerRoleLabel: "true"}}}
+
+	config := map[string]interface{}{}
+	addEmbeddedRegistryConfig(config, controlPlaneWithEmbeddedRegistry(nil, nil), server)
+	assert.Empty(t, config)
+
+	cp := controlPlaneWithEmbeddedRegistry(&rkev1.EmbeddedRegistry{Enabled: true, DisableDefaultEndpoint: true}, nil)
+	addEmbeddedRegistryConfig(config, cp, server)
+	assert.Equal(t, map[string]interface{}{
+		embeddedRegistryArg:               true,
+		disableDefaultRegistryEndpointArg: true,
+	}, config)
+
+	config = map[string]interface{}{}
+	addEmbeddedRegistryConfig(config, cp, worker)
+	assert.Equal(t, map[string]interface{}{
+		disableDefaultRegistryEndpointArg: true,
+	}, config)
+}
+
+func Test_embeddedRegistryNodes(t *testing.T) {
+	now := metav1.Now()
+	clusterPlan := &plan.Plan{
+		Machines: map[string]*capi.Machine{
+			"m-b": {
+				ObjectMeta: metav1.ObjectMeta{Name: "m-b"},
+				Status:     capi.MachineStatus{NodeRef: &corev1.ObjectReference{Name: "node-b"}},
+			},
+			"m-a": {
+				ObjectMeta: metav1.ObjectMeta{Name: "m-a"},
+				Status:     capi.MachineStatus{NodeRef: &corev1.ObjectReference{Name: "node-a"}},
+			},
+			"m-windows": {
+				ObjectMeta: metav1.ObjectMeta{Name: "m-windows"},
+			},
+			"m-deleting": {
+				ObjectMeta: metav1.ObjectMeta{Name: "m-deleting", DeletionTimestamp: &now},
+			},
+		},
+		Nodes: map[string]*plan.Node{
+			"m-a":        {InSync: true},
+			"m-b":        {InSync: false},
+			"m-windows":  {InSync: true},
+			"m-deleting": {InSync: true},
+		},
+		Metadata: map[string]*plan.Metadata{
+			"m-a":        {},
+			"m-b":        {},
+			"m-windows":  {Labels: map[string]string{capr.CattleOSLabel: capr.WindowsMachineOS}},
+			"m-deleting": {},
+		},
+	}
+
+	assert.Nil(t, embeddedRegistryNodes(controlPlaneWithEmbeddedRegistry(nil, nil), clusterPlan))
+	assert.Equal(t, []rkev1.EmbeddedRegistryNode{
+		{MachineName: "m-a", NodeName: "node-a", Participating: true},
+		{MachineName: "m-b", NodeName: "node-b"},
+		{MachineName: "m-windows"},
+	}, embeddedRegistryNodes(controlPlaneWithEmbeddedRegistry(&rkev1.EmbeddedRegistry{Enabled: true}, nil), clusterPlan))
+}
@@ -12,7 +12,7 @@
 	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
 	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
 	"github.com/rancher/rancher/pkg/capr"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 	"k8s.io/apimachinery/pkg/api/equality"
 )
 
@@ -129,7 +129,7 @@ func (p *Planner) setEncryptionKeyRotateState(status rkev1.RKEControlPlaneStatus
 	}
 	status.RotateEncryptionKeys = rotate
 	status.RotateEncryptionKeysPhase = phase
-	return status, errWaiting("refreshing encryption key rotation state")
+	return status, newErrWaiting(capr.WaitingForEncryptionKeyRotation, "refreshing encryption key rotation state")
 }
 
 func (p *Planner) resetEncryptionKeyRotateState(status rkev1.RKEControlPlaneStatus) (rkev1.RKEControlPlaneStatus, error) {
@@ -153,7 +153,7 @@ func (p *Planner) rotateEncryptionKeys(cp *rkev1.RKEControlPlane, status rkev1.R
 	if supported, err := encryptionKeyRotationSupported(releaseData); err != nil {
 		return status, err
 	} else if !supported {
-		logrus.Debugf("rkecluster %s/%s: marking encryption key rotation phase as failed as it was not supported by version: %s", cp.Namespace, cp.Name, cp.Spec.KubernetesVersion)
+		logger.Debugf("rkecluster %s/%s: marking encryption key rotation phase as failed as it was not supported by version: %s", cp.Namespace, cp.Name, cp.Spec.KubernetesVersion)
 		return p.setEncryptionKeyRotateState(status, cp.Spec.RotateEncryptionKeys, rkev1.RotateEncryptionKeysPhaseFailed)
 	}
 
@@ -163,22 +163,27 @@ func (p *Planner) rotateEncryptionKeys(cp *rkev1.RKEControlPlane, status rkev1.R
 
 	if !status.Initialized {
 		// cluster is not yet initialized, so return nil for now.
-		logrus.Warnf("[planner] rkecluster %s/%s: skipping encryption key rotation as cluster was not initialized", cp.Namespace, cp.Name)
+		logger.Warnf("[planner] rkecluster %s/%s: skipping encryption key rotation as cluster was not initialized", cp.Namespace, cp.Name)
 		return status, nil
 	}
 
 	found, joinServer, initNode, err := p.findInitNode(cp, clusterPlan)
 	if err != nil {
-		logrus.Errorf("[planner] rkecluster %s/%s: error encountered while searching for init node during encryption key rotation: %v", cp.Namespace, cp.Name, err)
+		logger.Errorf("[planner] rkecluster %s/%s: error encountered while searching for init node during encryption key rotation: %v", cp.Namespace, cp.Name, err)
 		return status, err
 	}
 	if !found || joinServer == "" {
-		logrus.Warnf("[planner] rkecluster %s/%s: skipping encryption key rotation as cluster does not have an init node", cp.Namespace, cp.Name)
+		logger.Warnf("[planner] rkecluster %s/%s: skipping encryption key rotation as cluster does not have an init node", cp.Namespace, cp.Name)
 		return status, nil
 	}
 
 	if shouldRestartEncryptionKeyRotation(cp) {
-		logrus.Debugf("[planner] rkecluster %s/%s: starting/restarting encryption key rotation", cp.Namespace, cp.Name)
+		// The lock pools are held from the start of the rotation until it's done or failed, as it can't be
+		// interrupted.
+		if status, err = p.acquireLockPools(cp, status, "encryption key rotation"); err != nil {
+			return status, err
+		}
+		logger.Debugf("[planner] rkecluster %s/%s: starting/restarting encryption key rotation", cp.Namespace, cp.Name)
 		return p.setEncryptionKeyRotateState(status, cp.Spec.RotateEncryptionKeys, rkev1.RotateEncryptionKeysPhasePrepare)
 	}
 
@@ -189,15 +194,15 @@ func (p *Planner) rotateEncryptionKeys(cp *rkev1.RKEControlPlane, status rkev1.R
 
 	if status.RotateEncryptionKeysLeader != leader.Machine.Name {
 		status.RotateEncryptionKeysLeader = leader.Machine.Name
-		return status, errWaitingf("elected %s as control plane leader for encryption key rotation", leader.Machine.Name)
+		return status, errWaitingf(capr.WaitingForEncryptionKeyRotation, "elected %s as control plane leader for encryption key rotation", leader.Machine.Name)
 	}
 
-	logrus.Debugf("[planner] rkecluster %s/%s: current encryption key rotation phase: [%s]", cp.Namespace, cp.Spec.ClusterName, cp.Status.RotateEncryptionKeysPhase)
+	logger.Debugf("[planner] rkecluster %s/%s: current encryption key rotation phase: [%s]", cp.Namespace, cp.Spec.ClusterName, cp.Status.RotateEncryptionKeysPhase)
 
 	switch cp.Status.RotateEncryptionKeysPhase {
 	case rkev1.RotateEncryptionKeysPhasePrepare:
 		if err := p.pauseCAPICluster(cp, true); err != nil {
-			return status, errWaiting("pausing CAPI cluster")
+			return status, newErrWaiting(capr.WaitingForEncryptionKeyRotation, "pausing CAPI cluster")
 		}
 		status, err = p.encryptionKeyRotationLeaderPhaseReconcile(cp, status, tokensSecret, joinServer, leader)
 		if err != nil {
@@ -234,7 +239,7 @@ func (p *Planner) rotateEncryptionKeys(cp *rkev1.RKEControlPlane, status rkev1.R
 			return status, err
 		}
 		if err = p.pauseCAPICluster(cp, false); err != nil {
-			return status, errWaiting("unpausing CAPI cluster")
+			return status, newErrWaiting(capr.WaitingForEncryptionKeyRotation, "unpausing CAPI cluster")
 		}
 		status.RotateEncryptionKeysLeader = ""
 		return p.setEncryptionKeyRotateState(status, cp.Spec.RotateEncryptionKeys, rkev1.RotateEncryptionKeysPhaseDone)
@@ -379,13 +384,13 @@ func encryptionKeyRotationIsEtcdAndNotControlPlaneAndNotLeaderAndInit(cp *rkev1.
 func (p *Planner) encryptionKeyRotationRestartNodes(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPlaneStatus, tokensSecret plan.Secret, clusterPlan *plan.Plan, leader *planEntry, initNode *planEntry, joinServer string) (rkev1.RKEControlPlaneStatus, error) {
 	// in certain cases with multi-node setups, we must restart the init node before we can proceed to restarting the leader.
 	if !isInitNode(leader) {
-		logrus.Debugf("[planner] rkecluster %s/%s: leader %s was not the init node, finding and restarting etcd nodes", cp.Namespace, cp.Name, leader.Machine.Name)
+		logger.Debugf("[planner] rkecluster %s/%s: leader %s was not the init node, finding and restarting etcd nodes", cp.Namespace, cp.Name, leader.Machine.Name)
 
 		_, status, err := p.encryptionKeyRotationRestartService(cp, status, tokensSecret, joinServer, initNode, false, "")
 		if err != nil {
 			return status, err
 		}
-		logrus.Debugf("[planner] rkecluster %s/%s: collecting etcd and not control plane", cp.Namespace, cp.Name)
+		logger.Debugf("[planner] rkecluster %s/%s: collecting etcd and not control plane", cp.Namespace, cp.Name)
 		for _, entry := range collect(clusterPlan, encryptionKeyRotationIsEtcdAndNotControlPlaneAndNotLeaderAndInit(cp)) {
 			_, status, err = p.encryptionKeyRotationRestartService(cp, status, tokensSecret, joinServer, entry, false, "")
 			if err != nil {
@@ -399,7 +404,7 @@ func (p *Planner) encryptionKeyRotationRestartNodes(cp *rkev1.RKEControlPlane, s
 		return status, err
 	}
 
-	logrus.Debugf("[planner] rkecluster %s/%s: collecting control plane and not leader and init nodes", cp.Namespace, cp.Name)
+	logger.Debugf("[planner] rkecluster %s/%s: collecting control plane and not leader and init nodes", cp.Namespace, cp.Name)
 	for _, entry := range collect(clusterPlan, encryptionKeyRotationIsControlPlaneAndNotLeaderAndInit(cp)) {
 		var stage string
 		stage, status, err = p.encryptionKeyRotationRestartService(cp, status, tokensSecret, joinServer, entry, true, leaderStage)
@@ -472,11 +477,11 @@ func (p *Planner) encryptionKeyRotationRestartService(cp *rkev1.RKEControlPlane,
 	// - the plan failing with the k3s/rke2-server services crashing the first, and resuming subsequent times
 	// It's not necessarily ideal if encryption key rotation can never complete, especially since we don't have access to
 	// the downstream k3s/rke2-server service logs, but it has to be done in order for encryption key rotation to succeed
-	err = assignAndCheckPlan(p.store, fmt.Sprintf("encryption key rotation [%s] for machine [%s]", cp.Status.RotateEncryptionKeysPhase, entry.Machine.Name), entry, nodePlan, joinedServer, 5, 5)
+	err = assignAndCheckPlan(p.store, fmt.Sprintf("encryption key rotation [%s] for machine [%s]", cp.Status.RotateEncryptionKeysPhase, entry.Machine.Name), capr.WaitingForEncryptionKeyRotation, entry, nodePlan, joinedServer, 5, 5)
 	if err != nil {
 		if IsErrWaiting(err) {
 			if planAppliedButWaitingForProbes(entry) {
-				return "", status, errWaitingf("%s: %s", err.Error(), probesMessage(entry.Plan))
+				return "", status, errWaitingf(capr.WaitingForProbe, "%s: %s", err.Error(), probesMessage(entry.Plan))
 			}
 			return "", status, err
 		}
@@ -532,11 +537,11 @@ func (p *Planner) encryptionKeyRotationLeaderPhaseReconcile(cp *rkev1.RKEControl
 	nodePlan.PeriodicInstructions = []plan.PeriodicInstruction{
 		encryptionKeyRotationSecretsEncryptStatusPeriodicInstruction(cp),
 	}
-	err = assignAndCheckPlan(p.store, fmt.Sprintf("encryption key rotation [%s] for machine [%s]", cp.Status.RotateEncryptionKeysPhase, leader.Machine.Name), leader, nodePlan, joinedServer, 1, 1)
+	err = assignAndCheckPlan(p.store, fmt.Sprintf("encryption key rotation [%s] for machine [%s]", cp.Status.RotateEncryptionKeysPhase, leader.Machine.Name), capr.WaitingForEncryptionKeyRotation, leader, nodePlan, joinedServer, 1, 1)
 	if err != nil {
 		if IsErrWaiting(err) {
 			if strings.HasPrefix(err.Error(), "starting") {
-				logrus.Infof("[planner] rkecluster %s/%s: applying encryption key rotation stage command: [%s]", cp.Namespace, cp.Spec.ClusterName, apply.Args[1])
+				logger.Infof("[planner] rkecluster %s/%s: applying encryption key rotation stage command: [%s]", cp.Namespace, cp.Spec.ClusterName, apply.Args[1])
 			}
 			return status, err
 		}
@@ -556,11 +561,11 @@ func (p *Planner) encryptionKeyRotationLeaderPhaseReconcile(cp *rkev1.RKEControl
 	}
 	if scrapedStageFromOneTimeInstructions == encryptionKeyRotationStageReencryptRequest || scrapedStageFromOneTimeInstructions == encryptionKeyRotationStageReencryptActive {
 		if periodic != encryptionKeyRotationStageReencryptFinished {
-			return status, errWaitingf("waiting for encryption key rotation stage to be finished")
+			return status, errWaitingf(capr.WaitingForEncryptionKeyRotation, "waiting for encryption key rotation stage to be finished")
 		}
 	}
 	// successful restart, complete same phases for rotate & reencrypt
-	logrus.Infof("[planner] rkecluster %s/%s: successfully applied encryption key rotation stage command: [%s]", cp.Namespace, cp.Spec.ClusterName, leader.Plan.Plan.Instructions[0].Args[1])
+	logger.Infof("[planner] rkecluster %s/%s: successfully applied encryption key rotation stage command: [%s]", cp.Namespace, cp.Spec.ClusterName, leader.Plan.Plan.Instructions[0].Args[1])
 	return status, nil
 }
 
@@ -571,7 +576,7 @@ func encryptionKeyRotationSecretsEncryptStageFromPeriodic(plan *planEntry) (stri
 	if !ok {
 		for _, pi := range plan.Plan.Plan.PeriodicInstructions {
 			if pi.Name == encryptionKeyRotationSecretsEncryptStatusCommand {
-				return "", errWaitingf("could not extract current status from plan for [%s]: no output for status", plan.Machine.Name)
+				return "", errWaitingf(capr.WaitingForEncryptionKeyRotation, "could not extract current status from plan for [%s]: no output for status", plan.Machine.Name)
 			}
 		}
 		return "", fmt.Errorf("could not extract current status from plan for [%s]: status command not present in plan", plan.Machine.Name)
@@ -585,7 +590,7 @@ func encryptionKeyRotationSecretsEncryptStageFromPeriodic(plan *planEntry) (stri
 func encryptionKeyRotationSecretsEncryptStageFromOneTimeStatus(plan *planEntry) (string, error) {
 	output, ok := plan.Plan.Output[encryptionKeyRotationSecretsEncryptStatusCommand]
 	if !ok {
-		return "", errWaitingf("could not extract current status from plan for [%s]: no output for status", plan.Machine.Name)
+		return "", errWaitingf(capr.WaitingForEncryptionKeyRotation, "could not extract current status from plan for [%s]: no output for status", plan.Machine.Name)
 	}
 	status, err := encryptionKeyRotationStageFromOutput(plan, string(output))
 	return status, err
@@ -595,7 +600,7 @@ func encryptionKeyRotationSecretsEncryptStageFromOneTimeStatus(plan *planEntry)
 func encryptionKeyRotationStageFromOutput(plan *planEntry, output string) (string, error) {
 	a := strings.Split(output, "\n")
 	if len(a) < 2 {
-		return "", errWaitingf("could not extract current stage from plan for [%s]: status output is incomplete", plan.Machine.Name)
+		return "", errWaitingf(capr.WaitingForEncryptionKeyRotation, "could not extract current stage from plan for [%s]: status output is incomplete", plan.Machine.Name)
 	}
 	for _, v := range a {
 		a = strings.Split(v, ": ")
@@ -605,7 +610,7 @@ func encryptionKeyRotationStageFromOutput(plan *planEntry, output string) (strin
 		status := a[1]
 		return status, nil
 	}
-	return "", errWaitingf("unable to parse rotation stage from output")
+	return "", errWaitingf(capr.WaitingForEncryptionKeyRotation, "unable to parse rotation stage from output")
 }
 
 // encryptionKeyRotationSecretsEncryptInstruction generates a secrets-encrypt command to run on the leader node given
@@ -3,18 +3,29 @@
 import (
 	"errors"
 	"fmt"
+
+	"github.com/rancher/rancher/pkg/capr"
 )
 
-// errWaiting will not cause a re-enqueue of the object being processed and should be used when waiting for other objects/controllers
-type errWaiting string
+// errWaiting will not cause a re-enqueue of the object being processed and should be used when waiting for other objects/controllers.
+// Its reason is set as the reason of the conditions of the control plane, its message as their message.
+type errWaiting struct {
+	reason  string
+	message string
+}
 
 func (e errWaiting) Error() string {
-	return string(e)
+	return e.message
+}
+
+// newErrWaiting returns an error of type errWaiting with the given reason and message.
+func newErrWaiting(reason, message string) errWaiting {
+	return errWaiting{reason: reason, message: message}
 }
 
 // errWaitingf renders an error of type errWaiting that will not cause a re-enqueue of the object being processed and should be used when waiting for other objects/controllers
-func errWaitingf(format string, a ...interface{}) errWaiting {
-	return errWaiting(fmt.Sprintf(format, a...))
+func errWaitingf(reason, format string, a ...interface{}) errWaiting {
+	return newErrWaiting(reason, fmt.Sprintf(format, a...))
 }
 
 func IsErrWaiting(err error) bool {
@@ -22,6 +33,15 @@ func IsErrWaiting(err error) bool {
 	return errors.As(err, &errWaiting)
 }
 
+// WaitingReason returns the reason of an error of type errWaiting, the generic waiting reason if it has none.
+func WaitingReason(err error) string {
+	var errWaiting errWaiting
+	if errors.As(err, &errWaiting) && errWaiting.reason != "" {
+		return errWaiting.reason
+	}
+	return capr.WaitingReason
+}
+
 // errIgnore is specifically used during plan processing to ignore internal processing errors
 type errIgnore string
 
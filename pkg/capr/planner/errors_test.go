@@ -0,0 +1,22 @@
+package planner
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitingReason(t *testing.T) {
+	err := errWaitingf(capr.WaitingForDrain, "draining %d node(s)", 2)
+	assert.True(t, IsErrWaiting(err))
+	assert.Equal(t, "draining 2 node(s)", err.Error())
+	assert.Equal(t, capr.WaitingForDrain, WaitingReason(err))
+	assert.Equal(t, capr.WaitingForDrain, WaitingReason(fmt.Errorf("wrapped: %w", err)))
+
+	assert.Equal(t, capr.WaitingReason, WaitingReason(newErrWaiting("", "waiting")))
+	assert.False(t, IsErrWaiting(errors.New("failed")))
+	assert.Equal(t, capr.WaitingReason, WaitingReason(errors.New("failed")))
+}
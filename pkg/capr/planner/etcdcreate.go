@@ -9,8 +9,8 @@
 	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
 	"github.com/rancher/rancher/pkg/capr"
 	"github.com/rancher/rancher/pkg/controllers/capr/managesystemagent"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/wrangler/pkg/merr"
-	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/api/equality"
 )
 
@@ -18,7 +18,7 @@ func (p *Planner) setEtcdSnapshotCreateState(status rkev1.RKEControlPlaneStatus,
 	if status.ETCDSnapshotCreatePhase != phase || !equality.Semantic.DeepEqual(status.ETCDSnapshotCreate, create) {
 		status.ETCDSnapshotCreatePhase = phase
 		status.ETCDSnapshotCreate = create
-		return status, errWaiting("refreshing etcd create state")
+		return status, newErrWaiting(capr.WaitingForEtcdSnapshot, "refreshing etcd create state")
 	}
 	return status, nil
 }
@@ -54,7 +54,7 @@ func (p *Planner) runEtcdSnapshotCreate(controlPlane *rkev1.RKEControlPlane, tok
 		if server.Machine.Status.NodeRef != nil && server.Machine.Status.NodeRef.Name != "" {
 			msg = fmt.Sprintf("etcd snapshot on node %s", server.Machine.Status.NodeRef.Name)
 		}
-		if err = assignAndCheckPlan(p.store, msg, server, createPlan, joinedServer, 3, 3); err != nil {
+		if err = assignAndCheckPlan(p.store, msg, capr.WaitingForEtcdSnapshot, server, createPlan, joinedServer, 3, 3); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -88,7 +88,7 @@ func (p *Planner) runEtcdSnapshotManagementServiceStart(controlPlane *rkev1.RKEC
 		if err != nil {
 			return err
 		}
-		if err = assignAndCheckPlan(p.store, fmt.Sprintf("%s management plane restart", operation), entry, plan, joinedServer, 1, -1); err != nil {
+		if err = assignAndCheckPlan(p.store, fmt.Sprintf("%s management plane restart", operation), capr.WaitingForEtcdSnapshot, entry, plan, joinedServer, 1, -1); err != nil {
 			return err
 		}
 	}
@@ -135,11 +135,11 @@ func (p *Planner) createEtcdSnapshot(controlPlane *rkev1.RKEControlPlane, status
 
 	found, joinServer, _, err := p.findInitNode(controlPlane, clusterPlan)
 	if err != nil {
-		logrus.Errorf("[planner] rkecluster %s/%s: error encountered while searching for init node during etcd snapshot creation: %v", controlPlane.Namespace, controlPlane.Name, err)
+		logger.Errorf("[planner] rkecluster %s/%s: error encountered while searching for init node during etcd snapshot creation: %v", controlPlane.Namespace, controlPlane.Name, err)
 		return status, err
 	}
 	if !found || joinServer == "" {
-		logrus.Warnf("[planner] rkecluster %s/%s: skipping etcd snapshot creation as cluster does not have an init node", controlPlane.Namespace, controlPlane.Name)
+		logger.Warnf("[planner] rkecluster %s/%s: skipping etcd snapshot creation as cluster does not have an init node", controlPlane.Namespace, controlPlane.Name)
 		return status, nil
 	}
 
@@ -171,7 +171,7 @@ func (p *Planner) createEtcdSnapshot(controlPlane *rkev1.RKEControlPlane, status
 					}
 				}
 			}
-			return status, errWaiting(merr.NewErrors(finErrs...).Error())
+			return status, newErrWaiting(capr.WaitingForEtcdSnapshot, merr.NewErrors(finErrs...).Error())
 		}
 		if status, err = p.setEtcdSnapshotCreateState(status, snapshot, rkev1.ETCDSnapshotPhaseRestartCluster); err != nil {
 			return status, err
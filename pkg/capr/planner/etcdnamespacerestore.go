@@ -0,0 +1,347 @@
+package planner
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/wrangler/pkg/name"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	etcdNamespaceRestoreMessage = "etcd namespace restore"
+
+	// The temporary control plane of a namespace restore listens on these ports, so that it doesn't conflict with the
+	// control plane of the node it runs on.
+	etcdNamespaceRestoreAPIPort     = 16443
+	etcdNamespaceRestoreClientPort  = 12379
+	etcdNamespaceRestorePeerPort    = 12380
+	etcdNamespaceRestoreMetricsPort = 12381
+
+	etcdNamespaceRestoreTimeoutSeconds = 3600
+	etcdNamespaceRestoreMaxOutputBytes = 64 * 1024
+
+	etcdNamespaceRestorePath   = "restore_namespaces.sh"
+	etcdNamespaceRestoreScript = `
+#!/bin/sh
+
+# Restores the resources of namespaces from an etcd snapshot into the running cluster. The snapshot is restored into a
+# temporary control plane with its own data directory and ports, from which the resources of the namespaces are
+# exported and then applied to the cluster according to the conflict strategy. The arguments are those of the server
+# of the temporary control plane.
+
+for VAR in RUNTIME KUBECTL KUBECONFIG STAGING_DIR STAGING_KUBECONFIG STAGING_SERVER SNAPSHOT NAMESPACES CONFLICT_STRATEGY; do
+        eval "VALUE=\${$VAR}"
+        if [ -z "$VALUE" ]; then
+                echo "Must define $VAR environment variable"
+                exit 1
+        fi
+done
+
+STAGING_PID=""
+
+cleanup() {
+        if [ -n "$STAGING_PID" ]; then
+                kill "$STAGING_PID" 2>/dev/null
+                wait "$STAGING_PID" 2>/dev/null
+        fi
+        rm -rf "$STAGING_DIR"
+}
+trap cleanup EXIT
+
+rm -rf "$STAGING_DIR"
+mkdir -p "$STAGING_DIR/export"
+
+# The data of the cluster is left untouched, the snapshot is restored into the data directory of the temporary control
+# plane.
+echo "Restoring snapshot $SNAPSHOT into temporary control plane"
+if ! "$RUNTIME" server --cluster-reset --cluster-reset-restore-path="$SNAPSHOT" "$@" > "$STAGING_DIR/restore.log" 2>&1; then
+        echo "Error restoring snapshot $SNAPSHOT"
+        tail -n 20 "$STAGING_DIR/restore.log"
+        exit 1
+fi
+
+"$RUNTIME" server "$@" > "$STAGING_DIR/server.log" 2>&1 &
+STAGING_PID=$!
+
+staging() {
+        ${KUBECTL} --kubeconfig "$STAGING_KUBECONFIG" --server "$STAGING_SERVER" "$@"
+}
+
+i=0
+until staging get --raw=/readyz >/dev/null 2>&1; do
+        i=$((i + 1))
+        if [ $i -ge 60 ]; then
+                echo "Temporary control plane did not become ready"
+                tail -n 20 "$STAGING_DIR/server.log"
+                exit 1
+        fi
+        sleep 5
+done
+
+# The resources that are recreated by controllers, or that only make sense in the cluster they were created in, aren't
+# restored.
+RESOURCES=$(staging api-resources --namespaced=true --verbs=list,create -o name 2>/dev/null | grep -v -x \
+        -e events -e events.events.k8s.io -e pods -e replicasets.apps -e controllerrevisions.apps -e endpoints \
+        -e endpointslices.discovery.k8s.io -e leases.coordination.k8s.io)
+
+# The metadata the cluster sets is removed, as are owner references, whose owners have other UIDs once restored.
+STRIP='{"metadata":{"uid":null,"resourceVersion":null,"creationTimestamp":null,"generation":null,"managedFields":null,"ownerReferences":null,"selfLink":null},"status":null}'
+
+for NS in $NAMESPACES; do
+        if ! staging get namespace "$NS" -o json > "$STAGING_DIR/export/namespace-$NS.json"; then
+                echo "Namespace $NS not found in snapshot $SNAPSHOT"
+                exit 1
+        fi
+        for RESOURCE in $RESOURCES; do
+                if [ -z "$(staging get "$RESOURCE" -n "$NS" -o name)" ]; then
+                        continue
+                fi
+                FILE="$STAGING_DIR/export/resource-$NS-$RESOURCE.json"
+                if ! staging get "$RESOURCE" -n "$NS" -o json > "$FILE.raw"; then
+                        echo "Error exporting $RESOURCE of namespace $NS"
+                        exit 1
+                fi
+                if ! ${KUBECTL} patch --local -f "$FILE.raw" --type=merge -p "$STRIP" -o json > "$FILE"; then
+                        echo "Error preparing $RESOURCE of namespace $NS"
+                        exit 1
+                fi
+                rm "$FILE.raw"
+                if [ "$RESOURCE" = "services" ]; then
+                        # Cluster IPs are allocated again, as they may have been allocated to other services since.
+                        ${KUBECTL} patch --local -f "$FILE" --type=merge -p '{"spec":{"clusterIP":null,"clusterIPs":null}}' -o json > "$FILE.services" && mv "$FILE.services" "$FILE"
+                fi
+        done
+done
+
+if [ "$CONFLICT_STRATEGY" = "fail" ]; then
+        for FILE in "$STAGING_DIR"/export/resource-*.json; do
+                [ -e "$FILE" ] || continue
+                EXISTING=$(${KUBECTL} get -f "$FILE" --ignore-not-found -o name 2>/dev/null)
+                if [ -n "$EXISTING" ]; then
+                        echo "Not restoring any resource, as resources exist in the cluster:"
+                        echo "$EXISTING"
+                        exit 1
+                fi
+        done
+fi
+
+FAILED=false
+for NS in $NAMESPACES; do
+        if ! ${KUBECTL} get namespace "$NS" >/dev/null 2>&1; then
+                echo "Creating namespace $NS"
+                ${KUBECTL} patch --local -f "$STAGING_DIR/export/namespace-$NS.json" --type=merge -p "$STRIP" -o json | ${KUBECTL} create -f - || FAILED=true
+        fi
+        for FILE in "$STAGING_DIR"/export/resource-"$NS"-*.json; do
+                [ -e "$FILE" ] || continue
+                if [ "$CONFLICT_STRATEGY" = "overwrite" ]; then
+                        ${KUBECTL} apply --server-side --force-conflicts --field-manager=rancher-namespace-restore -f "$FILE" || FAILED=true
+                        continue
+                fi
+                # The resources that exist in the cluster are kept.
+                OUTPUT=$(${KUBECTL} create -f "$FILE" 2>&1)
+                echo "$OUTPUT" | grep -v "(AlreadyExists)"
+                if echo "$OUTPUT" | grep "^Error" | grep -q -v "(AlreadyExists)"; then
+                        FAILED=true
+                fi
+        done
+done
+
+if $FAILED; then
+        echo "Error restoring resources"
+        exit 1
+fi
+echo "Restored namespaces $NAMESPACES"
+`
+)
+
+// validateNamespaceRestore returns an error if the etcd snapshot restore restores namespaces but can't be run.
+func validateNamespaceRestore(restore *rkev1.ETCDSnapshotRestore) error {
+	if restore == nil || len(restore.Namespaces) == 0 {
+		return nil
+	}
+	if restore.Name == "" {
+		return fmt.Errorf("namespaces can only be restored from a snapshot")
+	}
+	if restore.RestoreRKEConfig != "" && restore.RestoreRKEConfig != "none" {
+		return fmt.Errorf("the RKE config can't be restored along with namespaces")
+	}
+	switch restore.ConflictStrategy {
+	case "", rkev1.NamespaceRestoreConflictSkip, rkev1.NamespaceRestoreConflictOverwrite, rkev1.NamespaceRestoreConflictFail:
+	default:
+		return fmt.Errorf("conflict strategy %s must be %s, %s or %s", restore.ConflictStrategy,
+			rkev1.NamespaceRestoreConflictSkip, rkev1.NamespaceRestoreConflictOverwrite, rkev1.NamespaceRestoreConflictFail)
+	}
+	for _, namespace := range restore.Namespaces {
+		if len(validation.IsDNS1123Label(namespace)) > 0 {
+			return fmt.Errorf("namespace %q is not a valid namespace name", namespace)
+		}
+	}
+	return nil
+}
+
+// restoreEtcdSnapshotNamespaces restores the namespaces of an etcd snapshot restore into the running cluster, which
+// unlike a full restore is neither shut down nor restarted. The phases are in order:
+// Started -> When the phase is started, it gets set to NamespaceRestore
+// NamespaceRestore -> When the phase is NamespaceRestore, the namespaces are restored by a control plane machine
+// Finished -> When the phase is finished, the restore returns nil.
+func (p *Planner) restoreEtcdSnapshotNamespaces(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPlaneStatus, snapshot *rkev1.ETCDSnapshot, tokensSecret plan.Secret, clusterPlan *plan.Plan) (rkev1.RKEControlPlaneStatus, error) {
+	switch cp.Status.ETCDSnapshotRestorePhase {
+	case rkev1.ETCDSnapshotPhaseStarted:
+		status, _ = p.setEtcdSnapshotRestoreState(status, cp.Spec.ETCDSnapshotRestore, rkev1.ETCDSnapshotPhaseNamespaceRestore)
+		return status, errWaitingf(capr.WaitingForEtcdRestore, "restoring namespaces %s", strings.Join(cp.Spec.ETCDSnapshotRestore.Namespaces, ", "))
+	case rkev1.ETCDSnapshotPhaseNamespaceRestore:
+		if snapshot == nil {
+			return status, fmt.Errorf("unable to restore namespaces as etcd snapshot %s/%s was not found", cp.Namespace, cp.Spec.ETCDSnapshotRestore.Name)
+		}
+		if err := p.runEtcdNamespaceRestorePlan(cp, snapshot, tokensSecret, clusterPlan); err != nil {
+			return status, err
+		}
+		return p.setEtcdSnapshotRestoreState(status, cp.Spec.ETCDSnapshotRestore, rkev1.ETCDSnapshotPhaseFinished)
+	case rkev1.ETCDSnapshotPhaseFinished:
+		return status, nil
+	default:
+		return p.setEtcdSnapshotRestoreState(status, cp.Spec.ETCDSnapshotRestore, rkev1.ETCDSnapshotPhaseStarted)
+	}
+}
+
+// runEtcdNamespaceRestorePlan delivers the desired plan of the machine restoring the namespaces, along with the
+// instruction restoring them, and returns nil once it has been applied.
+func (p *Planner) runEtcdNamespaceRestorePlan(controlPlane *rkev1.RKEControlPlane, snapshot *rkev1.ETCDSnapshot, tokensSecret plan.Secret, clusterPlan *plan.Plan) error {
+	entry, err := etcdNamespaceRestoreEntry(controlPlane, snapshot, clusterPlan)
+	if err != nil {
+		return err
+	}
+
+	var joinServer string
+	if !isInitNode(entry) {
+		_, joinServer, _, err = p.findInitNode(controlPlane, clusterPlan)
+		if err != nil {
+			return err
+		}
+		if joinServer == "" {
+			return newErrWaiting(capr.WaitingForEtcdRestore, "waiting for join server")
+		}
+	}
+
+	nodePlan, joinedServer, err := p.desiredPlan(controlPlane, tokensSecret, entry, joinServer)
+	if err != nil {
+		return err
+	}
+	files, instruction, err := p.generateEtcdNamespaceRestoreFilesAndInstruction(controlPlane, snapshot, tokensSecret)
+	if err != nil {
+		return err
+	}
+	nodePlan.Files = append(nodePlan.Files, files...)
+	nodePlan.Instructions = append(nodePlan.Instructions, instruction)
+	return assignAndCheckPlan(p.store, etcdNamespaceRestoreMessage, capr.WaitingForEtcdRestore, entry, nodePlan, joinedServer, 1, 1)
+}
+
+// etcdNamespaceRestoreEntry returns the entry of the machine the namespaces are restored by, which must be a control
+// plane machine to reach the cluster. Local snapshots are restored by the machine they were taken on, S3 snapshots by
+// the first control plane machine.
+func etcdNamespaceRestoreEntry(controlPlane *rkev1.RKEControlPlane, snapshot *rkev1.ETCDSnapshot, clusterPlan *plan.Plan) (*planEntry, error) {
+	entries := collect(clusterPlan, roleAnd(isControlPlane, roleNot(isDeleting)))
+	if snapshot.SnapshotFile.S3 != nil {
+		if len(entries) == 0 {
+			return nil, fmt.Errorf("unable to restore namespaces as no control plane machine was found")
+		}
+		return entries[0], nil
+	}
+
+	id := snapshot.Labels[capr.MachineIDLabel]
+	if id == "" {
+		return nil, fmt.Errorf("unable to restore namespaces as label %s on snapshot %s/%s did not exist", capr.MachineIDLabel, snapshot.Namespace, snapshot.Name)
+	}
+	for _, entry := range entries {
+		if entry.Machine.Labels[capr.MachineIDLabel] == id {
+			return entry, nil
+		}
+	}
+	return nil, fmt.Errorf("unable to restore namespaces from local snapshot %s/%s as the machine it was taken on is not a control plane machine", snapshot.Namespace, snapshot.Name)
+}
+
+// generateEtcdNamespaceRestoreFilesAndInstruction generates the script restoring the namespaces and the instruction
+// running it. The temporary control plane is started from its own config and data directory, which is named after the
+// restore so that the instruction runs again when the restore is requested again.
+func (p *Planner) generateEtcdNamespaceRestoreFilesAndInstruction(controlPlane *rkev1.RKEControlPlane, snapshot *rkev1.ETCDSnapshot, tokensSecret plan.Secret) ([]plan.File, plan.OneTimeInstruction, error) {
+	restore := controlPlane.Spec.ETCDSnapshotRestore
+	runtime := capr.GetRuntimeCommand(controlPlane.Spec.KubernetesVersion)
+	kubectl, kubeconfig := capr.GetKubectlAndKubeconfigPaths(controlPlane.Spec.KubernetesVersion)
+	if kubectl == "" || kubeconfig == "" {
+		return nil, plan.OneTimeInstruction{}, fmt.Errorf("unable to restore namespaces of rkecontrolplane %s/%s as its runtime is unknown", controlPlane.Namespace, controlPlane.Name)
+	}
+
+	conflictStrategy := restore.ConflictStrategy
+	if conflictStrategy == "" {
+		conflictStrategy = rkev1.NamespaceRestoreConflictSkip
+	}
+	identifier := name.Hex(restore.Name+strings.Join(restore.Namespaces, ",")+conflictStrategy+strconv.Itoa(restore.Generation), 10)
+	stagingDir := etcdRestoreScriptPath(controlPlane, "namespace-restore-"+identifier)
+	loopback := loopbackAddress(controlPlane)
+
+	env := []string{
+		fmt.Sprintf("RUNTIME=%s", runtime),
+		fmt.Sprintf("KUBECTL=%s", kubectl),
+		fmt.Sprintf("KUBECONFIG=%s", kubeconfig),
+		fmt.Sprintf("STAGING_DIR=%s", stagingDir),
+		fmt.Sprintf("STAGING_KUBECONFIG=%s/data/server/cred/admin.kubeconfig", stagingDir),
+		fmt.Sprintf("STAGING_SERVER=https://%s:%d", loopback, etcdNamespaceRestoreAPIPort),
+		fmt.Sprintf("NAMESPACES=%s", strings.Join(restore.Namespaces, " ")),
+		fmt.Sprintf("CONFLICT_STRATEGY=%s", conflictStrategy),
+		// The temporary control plane decrypts the bootstrap data of the snapshot with the token of the cluster.
+		fmt.Sprintf("%s_TOKEN=%s", strings.ToUpper(runtime), tokensSecret.ServerToken),
+	}
+
+	args := []string{
+		etcdRestoreScriptPath(controlPlane, etcdNamespaceRestorePath),
+		"--config=/dev/null",
+		fmt.Sprintf("--data-dir=%s/data", stagingDir),
+		fmt.Sprintf("--https-listen-port=%d", etcdNamespaceRestoreAPIPort),
+		"--disable-agent",
+		fmt.Sprintf("--etcd-arg=listen-client-urls=https://%s:%d", loopback, etcdNamespaceRestoreClientPort),
+		fmt.Sprintf("--etcd-arg=advertise-client-urls=https://%s:%d", loopback, etcdNamespaceRestoreClientPort),
+		fmt.Sprintf("--etcd-arg=listen-peer-urls=https://%s:%d", loopback, etcdNamespaceRestorePeerPort),
+		fmt.Sprintf("--etcd-arg=initial-advertise-peer-urls=https://%s:%d", loopback, etcdNamespaceRestorePeerPort),
+		fmt.Sprintf("--etcd-arg=listen-metrics-urls=http://%s:%d", loopback, etcdNamespaceRestoreMetricsPort),
+		"--etcd-disable-snapshots",
+	}
+
+	files := []plan.File{
+		{
+			Content: base64.StdEncoding.EncodeToString([]byte(etcdNamespaceRestoreScript)),
+			Path:    etcdRestoreScriptPath(controlPlane, etcdNamespaceRestorePath),
+			Dynamic: true,
+		},
+	}
+
+	if snapshot.SnapshotFile.S3 == nil {
+		// The snapshot is read from the data directory of the node, as the temporary control plane has its own.
+		env = append(env, fmt.Sprintf("SNAPSHOT=/var/lib/rancher/%s/server/db/snapshots/%s", runtime, snapshot.SnapshotFile.Name))
+		args = append(args, "--etcd-s3=false")
+	} else {
+		env = append(env, fmt.Sprintf("SNAPSHOT=%s", snapshot.SnapshotFile.Name))
+		s3, s3Env, s3Files, err := p.etcdS3Args.ToArgs(snapshot.SnapshotFile.S3, controlPlane, "etcd-", true)
+		if err != nil {
+			return nil, plan.OneTimeInstruction{}, err
+		}
+		args = append(args, s3...)
+		env = append(env, s3Env...)
+		files = append(files, s3Files...)
+	}
+
+	return files, plan.OneTimeInstruction{
+		Name:           "restore-namespaces",
+		Command:        "/bin/sh",
+		Args:           args,
+		Env:            env,
+		SaveOutput:     true,
+		TimeoutSeconds: etcdNamespaceRestoreTimeoutSeconds,
+		MaxOutputBytes: etcdNamespaceRestoreMaxOutputBytes,
+	}, nil
+}
@@ -0,0 +1,130 @@
+package planner
+
+import (
+	"testing"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func Test_validateNamespaceRestore(t *testing.T) {
+	tests := []struct {
+		name     string
+		restore  *rkev1.ETCDSnapshotRestore
+		expected string
+	}{
+		{
+			name:    "full restore",
+			restore: &rkev1.ETCDSnapshotRestore{Name: "snapshot", RestoreRKEConfig: "all"},
+		},
+		{
+			name:    "namespace restore",
+			restore: &rkev1.ETCDSnapshotRestore{Name: "snapshot", Namespaces: []string{"app"}, ConflictStrategy: rkev1.NamespaceRestoreConflictOverwrite},
+		},
+		{
+			name:     "without snapshot",
+			restore:  &rkev1.ETCDSnapshotRestore{MachineName: "etcd-1", Namespaces: []string{"app"}},
+			expected: "namespaces can only be restored from a snapshot",
+		},
+		{
+			name:     "with RKE config",
+			restore:  &rkev1.ETCDSnapshotRestore{Name: "snapshot", Namespaces: []string{"app"}, RestoreRKEConfig: "kubernetesVersion"},
+			expected: "the RKE config can't be restored along with namespaces",
+		},
+		{
+			name:     "unknown conflict strategy",
+			restore:  &rkev1.ETCDSnapshotRestore{Name: "snapshot", Namespaces: []string{"app"}, ConflictStrategy: "merge"},
+			expected: "conflict strategy merge must be skip, overwrite or fail",
+		},
+		{
+			name:     "invalid namespace",
+			restore:  &rkev1.ETCDSnapshotRestore{Name: "snapshot", Namespaces: []string{"App"}},
+			expected: `namespace "App" is not a valid namespace name`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateNamespaceRestore(tt.restore)
+			if tt.expected != "" {
+				assert.EqualError(t, err, tt.expected)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_etcdNamespaceRestoreEntry(t *testing.T) {
+	clusterPlan := &plan.Plan{
+		Nodes:    map[string]*plan.Node{},
+		Machines: map[string]*capi.Machine{},
+		Metadata: map[string]*plan.Metadata{},
+	}
+	for name, labels := range map[string]map[string]string{
+		"cp-etcd-1": {capr.ControlPlaneRoleLabel: "true", capr.EtcdRoleLabel: "true"},
+		"cp-etcd-2": {capr.ControlPlaneRoleLabel: "true", capr.EtcdRoleLabel: "true"},
+		"etcd":      {capr.EtcdRoleLabel: "true"},
+	} {
+		clusterPlan.Machines[name] = &capi.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "fleet-default",
+				Name:      name,
+				Labels:    map[string]string{capr.MachineIDLabel: name + "-id"},
+			},
+		}
+		clusterPlan.Metadata[name] = &plan.Metadata{Labels: labels}
+	}
+
+	tests := []struct {
+		name     string
+		snapshot *rkev1.ETCDSnapshot
+		expected string
+		err      string
+	}{
+		{
+			name: "S3 snapshot",
+			snapshot: &rkev1.ETCDSnapshot{
+				SnapshotFile: rkev1.ETCDSnapshotFile{S3: &rkev1.ETCDSnapshotS3{Bucket: "snapshots"}},
+			},
+			expected: "cp-etcd-1",
+		},
+		{
+			name: "local snapshot",
+			snapshot: &rkev1.ETCDSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{capr.MachineIDLabel: "cp-etcd-2-id"}},
+			},
+			expected: "cp-etcd-2",
+		},
+		{
+			name: "local snapshot of etcd-only machine",
+			snapshot: &rkev1.ETCDSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "fleet-default", Name: "snapshot", Labels: map[string]string{capr.MachineIDLabel: "etcd-id"}},
+			},
+			err: "unable to restore namespaces from local snapshot fleet-default/snapshot as the machine it was taken on is not a control plane machine",
+		},
+		{
+			name: "local snapshot without machine",
+			snapshot: &rkev1.ETCDSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "fleet-default", Name: "snapshot"},
+			},
+			err: "unable to restore namespaces as label rke.cattle.io/machine-id on snapshot fleet-default/snapshot did not exist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := etcdNamespaceRestoreEntry(&rkev1.RKEControlPlane{}, tt.snapshot, clusterPlan)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, entry.Machine.Name)
+		})
+	}
+}
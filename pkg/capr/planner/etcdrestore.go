@@ -11,8 +11,8 @@
 	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
 	"github.com/rancher/rancher/pkg/capr"
 	"github.com/rancher/rancher/pkg/controllers/capr/managesystemagent"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/wrangler/pkg/name"
-	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	capi "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -173,7 +173,7 @@ func (p *Planner) setEtcdSnapshotRestoreState(status rkev1.RKEControlPlaneStatus
 	if !equality.Semantic.DeepEqual(status.ETCDSnapshotRestore, restore) || status.ETCDSnapshotRestorePhase != phase {
 		status.ETCDSnapshotRestore = restore
 		status.ETCDSnapshotRestorePhase = phase
-		return status, errWaiting("refreshing etcd restore state")
+		return status, newErrWaiting(capr.WaitingForEtcdRestore, "refreshing etcd restore state")
 	}
 	return status, nil
 }
@@ -219,7 +219,7 @@ func (p *Planner) runEtcdSnapshotRestorePlan(controlPlane *rkev1.RKEControlPlane
 	if err != nil {
 		return err
 	}
-	return assignAndCheckPlan(p.store, ETCDRestoreMessage, servers[0], restorePlan, joinedServer, 1, 1)
+	return assignAndCheckPlan(p.store, ETCDRestoreMessage, capr.WaitingForEtcdRestore, servers[0], restorePlan, joinedServer, 1, 1)
 }
 
 func (p *Planner) runEtcdSnapshotPostRestorePodCleanupPlan(controlPlane *rkev1.RKEControlPlane, tokensSecret plan.Secret, clusterPlan *plan.Plan) error {
@@ -239,16 +239,16 @@ func (p *Planner) runEtcdSnapshotPostRestorePodCleanupPlan(controlPlane *rkev1.R
 		cleanupScriptFiles, cleanupInstructions := p.generateEtcdRestorePodCleanupFilesAndInstruction(controlPlane, []string{string(initNode.Machine.UID)})
 		initNodePlan.Files = append(initNodePlan.Files, cleanupScriptFiles...)
 		initNodePlan.Instructions = append(initNodePlan.Instructions, cleanupInstructions...)
-		return assignAndCheckPlan(p.store, ETCDRestoreMessage, initNode, initNodePlan, "", 5, 5)
+		return assignAndCheckPlan(p.store, ETCDRestoreMessage, capr.WaitingForEtcdRestore, initNode, initNodePlan, "", 5, 5)
 	}
 
-	if err := assignAndCheckPlan(p.store, ETCDRestoreMessage, initNode, initNodePlan, "", 5, 5); err != nil {
+	if err := assignAndCheckPlan(p.store, ETCDRestoreMessage, capr.WaitingForEtcdRestore, initNode, initNodePlan, "", 5, 5); err != nil {
 		return err
 	}
 
 	_, joinServer, _, err := p.findInitNode(controlPlane, clusterPlan)
 	if joinServer == "" {
-		return errWaitingf("waiting for join server")
+		return newErrWaiting(capr.WaitingForEtcdRestore, "waiting for join server")
 	}
 	if err != nil {
 		return err
@@ -269,7 +269,7 @@ func (p *Planner) runEtcdSnapshotPostRestorePodCleanupPlan(controlPlane *rkev1.R
 	cleanupScriptFiles, cleanupInstructions := p.generateEtcdRestorePodCleanupFilesAndInstruction(controlPlane, []string{string(initNode.Machine.UID), string(controlPlaneEntry.Machine.UID)})
 	firstControlPlanePlan.Files = append(firstControlPlanePlan.Files, cleanupScriptFiles...)
 	firstControlPlanePlan.Instructions = append(firstControlPlanePlan.Instructions, cleanupInstructions...)
-	return assignAndCheckPlan(p.store, ETCDRestoreMessage, controlPlaneEntry, firstControlPlanePlan, joinedServer, 5, 5)
+	return assignAndCheckPlan(p.store, ETCDRestoreMessage, capr.WaitingForEtcdRestore, controlPlaneEntry, firstControlPlanePlan, joinedServer, 5, 5)
 }
 
 func (p *Planner) runEtcdSnapshotPostRestoreNodeCleanupPlan(controlPlane *rkev1.RKEControlPlane, tokensSecret plan.Secret, clusterPlan *plan.Plan) error {
@@ -297,7 +297,7 @@ func (p *Planner) runEtcdSnapshotPostRestoreNodeCleanupPlan(controlPlane *rkev1.
 	cleanupScriptFiles, cleanupInstructions := p.generateEtcdRestoreNodeCleanupFilesAndInstruction(controlPlane, allMachineUIDs, allNodeNames)
 	initNodePlan.Files = append(initNodePlan.Files, cleanupScriptFiles...)
 	initNodePlan.Instructions = append(initNodePlan.Instructions, cleanupInstructions...)
-	return assignAndCheckPlan(p.store, ETCDRestoreMessage, initNode, initNodePlan, "", 5, 5)
+	return assignAndCheckPlan(p.store, ETCDRestoreMessage, capr.WaitingForEtcdRestore, initNode, initNodePlan, "", 5, 5)
 }
 
 // generateEtcdSnapshotRestorePlan returns a node plan that contains instructions to stop etcd, remove the tombstone file (if one exists), then restore etcd in that order.
@@ -315,12 +315,16 @@ func (p *Planner) generateEtcdSnapshotRestorePlan(controlPlane *rkev1.RKEControl
 	args := []string{
 		"server",
 		"--cluster-reset",
-		"--etcd-arg=advertise-client-urls=https://127.0.0.1:2379", // this is a workaround for: https://github.com/rancher/rke2/issues/4052 and can likely remain indefinitely (unless IPv6-only becomes a requirement)
+		fmt.Sprintf("--etcd-arg=advertise-client-urls=https://%s:2379", loopbackAddress(controlPlane)), // this is a workaround for: https://github.com/rancher/rke2/issues/4052 and can likely remain indefinitely
 	}
 
 	var env []string
 
-	if snapshot == nil {
+	resetMachineName := controlPlane.Spec.ETCDSnapshotRestore.MachineName
+	if resetMachineName != "" {
+		// When resetting etcd on a surviving member, its data is kept and no snapshot is restored.
+		args = append(args, "--etcd-s3=false")
+	} else if snapshot == nil {
 		// If the snapshot is nil, then we will assume the passed in snapshot name is a local snapshot.
 		args = append(args, fmt.Sprintf("--cluster-reset-restore-path=db/snapshots/%s", snapshotName), "--etcd-s3=false")
 	} else if snapshot.SnapshotFile.S3 == nil {
@@ -345,14 +349,17 @@ func (p *Planner) generateEtcdSnapshotRestorePlan(controlPlane *rkev1.RKEControl
 	// make sure to install the desired version before performing restore
 	stopPlan.Instructions = append(stopPlan.Instructions, p.generateInstallInstructionWithSkipStart(controlPlane, entry))
 
-	planInstructions := append(stopPlan.Instructions,
-		plan.OneTimeInstruction{
-			Name:    "remove-etcd-db-dir",
-			Command: "rm",
-			Args: []string{
-				"-rf",
-				fmt.Sprintf("/var/lib/rancher/%s/server/db/etcd", capr.GetRuntimeCommand(controlPlane.Spec.KubernetesVersion)),
-			}})
+	planInstructions := stopPlan.Instructions
+	if resetMachineName == "" {
+		planInstructions = append(planInstructions,
+			plan.OneTimeInstruction{
+				Name:    "remove-etcd-db-dir",
+				Command: "rm",
+				Args: []string{
+					"-rf",
+					fmt.Sprintf("/var/lib/rancher/%s/server/db/etcd", capr.GetRuntimeCommand(controlPlane.Spec.KubernetesVersion)),
+				}})
+	}
 
 	nodePlan.Instructions = append(planInstructions, plan.OneTimeInstruction{
 		Name:    "restore",
@@ -591,23 +598,33 @@ func generateRemoveTLSAndCredDirInstructions(controlPlane *rkev1.RKEControlPlane
 
 // runEtcdRestoreInitNodeElection runs an election for an init node. Notably, it accepts a nil snapshot, and will
 func (p *Planner) runEtcdRestoreInitNodeElection(controlPlane *rkev1.RKEControlPlane, snapshot *rkev1.ETCDSnapshot, clusterPlan *plan.Plan) (string, error) {
+	if controlPlane.Spec.ETCDSnapshotRestore != nil && controlPlane.Spec.ETCDSnapshotRestore.MachineName != "" {
+		// If etcd is reset on a surviving member, designate the machine of that member as the init node.
+		entry, err := etcdResetEntry(controlPlane, clusterPlan)
+		if err != nil {
+			return "", err
+		}
+		id := entry.Machine.Labels[capr.MachineIDLabel]
+		logger.Infof("[planner] rkecluster %s/%s: designating init node with machine ID: %s for etcd reset on machine %s", controlPlane.Namespace, controlPlane.Name, id, entry.Machine.Name)
+		return p.designateInitNodeByMachineID(controlPlane, clusterPlan, id)
+	}
 	if snapshot != nil { // If the snapshot CR is not nil, then find an init node.
 		if snapshot.SnapshotFile.S3 == nil {
 			// If the snapshot is not an S3 snapshot, then designate the init node by machine ID defined.
 			if id, ok := snapshot.Labels[capr.MachineIDLabel]; ok {
-				logrus.Infof("[planner] rkecluster %s/%s: designating init node with machine ID: %s for local snapshot %s/%s restoration", controlPlane.Namespace, controlPlane.Name, id, snapshot.Namespace, snapshot.Name)
+				logger.Infof("[planner] rkecluster %s/%s: designating init node with machine ID: %s for local snapshot %s/%s restoration", controlPlane.Namespace, controlPlane.Name, id, snapshot.Namespace, snapshot.Name)
 				return p.designateInitNodeByMachineID(controlPlane, clusterPlan, id)
 			}
 			return "", fmt.Errorf("unable to designate machine as label %s on snapshot %s/%s did not exist", capr.MachineIDLabel, snapshot.Namespace, snapshot.Name)
 		}
-		logrus.Infof("[planner] rkecluster %s/%s: electing init node for S3 snapshot %s/%s restoration", controlPlane.Namespace, controlPlane.Name, snapshot.Namespace, snapshot.Name)
+		logger.Infof("[planner] rkecluster %s/%s: electing init node for S3 snapshot %s/%s restoration", controlPlane.Namespace, controlPlane.Name, snapshot.Namespace, snapshot.Name)
 		return p.electInitNode(controlPlane, clusterPlan)
 	}
 	// make sure that we only have one suitable init node, and elect it.
 	if len(collect(clusterPlan, canBeInitNode)) != 1 {
 		return "", fmt.Errorf("more than one init node existed and no corresponding etcd snapshot CR found, no assumption can be made for the machine that contains the snapshot")
 	}
-	logrus.Infof("[planner] rkecluster %s/%s: electing init node for local snapshot with no associated CR", controlPlane.Namespace, controlPlane.Name)
+	logger.Infof("[planner] rkecluster %s/%s: electing init node for local snapshot with no associated CR", controlPlane.Namespace, controlPlane.Name)
 	return p.electInitNode(controlPlane, clusterPlan)
 }
 
@@ -626,7 +643,7 @@ func (p *Planner) runEtcdRestoreServiceStop(controlPlane *rkev1.RKEControlPlane,
 	if err != nil {
 		return err
 	} else if deletingEtcdNodes != 0 {
-		return errWaitingf("waiting for %d etcd machines to delete", deletingEtcdNodes)
+		return errWaitingf(capr.WaitingForEtcdRestore, "waiting for %d etcd machines to delete", deletingEtcdNodes)
 	}
 
 	servers := collect(clusterPlan, anyRoleWithoutWindows)
@@ -639,7 +656,7 @@ func (p *Planner) runEtcdRestoreServiceStop(controlPlane *rkev1.RKEControlPlane,
 		if err != nil {
 			return err
 		}
-		if isEtcd(server) {
+		if isEtcd(server) && !isEtcdResetMachine(controlPlane, server) {
 			stopPlan.Instructions = append(stopPlan.Instructions, generateCreateEtcdTombstoneInstruction(controlPlane))
 		}
 		if roleOr(isEtcd, isControlPlane)(server) {
@@ -655,7 +672,7 @@ func (p *Planner) runEtcdRestoreServiceStop(controlPlane *rkev1.RKEControlPlane,
 
 	// If any of the node plans were updated, return an errWaiting message for shutting down control plane and etcd
 	if updated {
-		return errWaiting("stopping " + capr.GetRuntime(controlPlane.Spec.KubernetesVersion) + " services on control plane and etcd machines/nodes")
+		return newErrWaiting(capr.WaitingForEtcdRestore, "stopping "+capr.GetRuntime(controlPlane.Spec.KubernetesVersion)+" services on control plane and etcd machines/nodes")
 	}
 
 	for _, server := range servers {
@@ -664,14 +681,14 @@ func (p *Planner) runEtcdRestoreServiceStop(controlPlane *rkev1.RKEControlPlane,
 		}
 		if !server.Plan.InSync {
 			if server.Machine.Status.NodeRef == nil {
-				return errWaiting(fmt.Sprintf("waiting to stop %s services on machine [%s]", capr.GetRuntime(controlPlane.Spec.KubernetesVersion), server.Machine.Name))
+				return errWaitingf(capr.WaitingForEtcdRestore, "waiting to stop %s services on machine [%s]", capr.GetRuntime(controlPlane.Spec.KubernetesVersion), server.Machine.Name)
 			}
-			return errWaiting(fmt.Sprintf("waiting to stop %s services on node [%s]", capr.GetRuntime(controlPlane.Spec.KubernetesVersion), server.Machine.Status.NodeRef.Name))
+			return errWaitingf(capr.WaitingForEtcdRestore, "waiting to stop %s services on node [%s]", capr.GetRuntime(controlPlane.Spec.KubernetesVersion), server.Machine.Status.NodeRef.Name)
 		}
 	}
 
 	if len(collect(clusterPlan, roleAnd(isEtcd, roleNot(isDeleting)))) == 0 {
-		return errWaiting("waiting for suitable etcd nodes for etcd restore continuation")
+		return newErrWaiting(capr.WaitingForEtcdRestore, "waiting for suitable etcd nodes for etcd restore continuation")
 	}
 	return nil
 }
@@ -687,6 +704,10 @@ func (p *Planner) retrieveEtcdSnapshot(controlPlane *rkev1.RKEControlPlane) (*rk
 	if controlPlane.Spec.ClusterName == "" {
 		return nil, fmt.Errorf("cluster name on rkecontrolplane %s/%s was blank", controlPlane.Namespace, controlPlane.Name)
 	}
+	if controlPlane.Spec.ETCDSnapshotRestore.Name == "" {
+		// etcd is reset on a surviving member, no snapshot is restored.
+		return nil, nil
+	}
 	snapshot, err := p.etcdSnapshotCache.Get(controlPlane.Namespace, controlPlane.Spec.ETCDSnapshotRestore.Name)
 	if apierrors.IsNotFound(err) {
 		return nil, nil
@@ -700,18 +721,18 @@ func (p *Planner) forceDeleteAllDeletingEtcdMachines(cp *rkev1.RKEControlPlane,
 	etcdDeleting := collect(plan, roleAnd(isEtcd, isDeleting))
 	for _, deletingEtcdNode := range etcdDeleting {
 		if deletingEtcdNode.Machine == nil {
-			logrus.Warnf("[planner] rkecluster %s/%s: did not find CAPI machine for entry when deleting etcd nodes", cp.Namespace, cp.Name)
+			logger.Warnf("[planner] rkecluster %s/%s: did not find CAPI machine for entry when deleting etcd nodes", cp.Namespace, cp.Name)
 			continue
 		}
 		if deletingEtcdNode.Machine.Spec.Bootstrap.ConfigRef == nil {
-			logrus.Warnf("[planner] rkecluster %s/%s: did not find a corresponding CAPI machine for %s/%s", cp.Namespace, cp.Name, deletingEtcdNode.Machine.Namespace, deletingEtcdNode.Machine.Name)
+			logger.Warnf("[planner] rkecluster %s/%s: did not find a corresponding CAPI machine for %s/%s", cp.Namespace, cp.Name, deletingEtcdNode.Machine.Namespace, deletingEtcdNode.Machine.Name)
 			continue
 		}
 		if !strings.Contains(deletingEtcdNode.Machine.Spec.Bootstrap.ConfigRef.APIVersion, "rke.cattle.io") {
-			logrus.Warnf("[planner] rkecluster %s/%s: CAPI machine %s/%s had a bootstrap ref with an unexpected API version: %s", cp.Namespace, cp.Name, deletingEtcdNode.Machine.Namespace, deletingEtcdNode.Machine.Name, deletingEtcdNode.Machine.Spec.Bootstrap.ConfigRef.APIVersion)
+			logger.Warnf("[planner] rkecluster %s/%s: CAPI machine %s/%s had a bootstrap ref with an unexpected API version: %s", cp.Namespace, cp.Name, deletingEtcdNode.Machine.Namespace, deletingEtcdNode.Machine.Name, deletingEtcdNode.Machine.Spec.Bootstrap.ConfigRef.APIVersion)
 			continue
 		}
-		logrus.Infof("[planner] rkecluster %s/%s: force deleting etcd machine %s/%s as cluster was not sane and machine was deleting", cp.Namespace, cp.Name, deletingEtcdNode.Machine.Namespace, deletingEtcdNode.Machine.Name)
+		logger.Infof("[planner] rkecluster %s/%s: force deleting etcd machine %s/%s as cluster was not sane and machine was deleting", cp.Namespace, cp.Name, deletingEtcdNode.Machine.Namespace, deletingEtcdNode.Machine.Name)
 		// Update the CAPI machine annotation for exclude node draining and set it to true to get the CAPI controllers to not try to drain this node.
 		deletingEtcdNode.Machine.Annotations[capi.ExcludeNodeDrainingAnnotation] = "true"
 		var err error
@@ -736,6 +757,32 @@ func (p *Planner) forceDeleteAllDeletingEtcdMachines(cp *rkev1.RKEControlPlane,
 	return len(etcdDeleting), nil
 }
 
+// etcdResetEntry returns the entry of the surviving etcd machine that etcd is reset on.
+func etcdResetEntry(controlPlane *rkev1.RKEControlPlane, clusterPlan *plan.Plan) (*planEntry, error) {
+	machineName := controlPlane.Spec.ETCDSnapshotRestore.MachineName
+	entries := collect(clusterPlan, func(entry *planEntry) bool {
+		return entry.Machine.Name == machineName
+	})
+	if len(entries) != 1 {
+		return nil, fmt.Errorf("unable to reset etcd as machine %s/%s was not found", controlPlane.Namespace, machineName)
+	}
+	entry := entries[0]
+	if !canBeInitNode(entry) {
+		return nil, fmt.Errorf("unable to reset etcd on machine %s/%s as it is not a healthy etcd machine", controlPlane.Namespace, machineName)
+	}
+	if entry.Machine.Labels[capr.MachineIDLabel] == "" {
+		return nil, fmt.Errorf("unable to reset etcd on machine %s/%s as label %s did not exist", controlPlane.Namespace, machineName, capr.MachineIDLabel)
+	}
+	return entry, nil
+}
+
+// isEtcdResetMachine returns true if etcd is reset on the machine of the entry, in which case its etcd data is kept.
+func isEtcdResetMachine(controlPlane *rkev1.RKEControlPlane, entry *planEntry) bool {
+	return controlPlane.Spec.ETCDSnapshotRestore != nil &&
+		controlPlane.Spec.ETCDSnapshotRestore.MachineName != "" &&
+		entry.Machine.Name == controlPlane.Spec.ETCDSnapshotRestore.MachineName
+}
+
 // restoreEtcdSnapshot is called multiple times during an etcd snapshot restoration.
 // restoreEtcdSnapshot utilizes the status of the corresponding control plane object of the cluster to track state
 // The phases are in order:
@@ -743,10 +790,17 @@ func (p *Planner) forceDeleteAllDeletingEtcdMachines(cp *rkev1.RKEControlPlane,
 // Shutdown -> When the phase is shutdown, it attempts to shut down etcd on all nodes (stop etcd)
 // Restore ->  When the phase is restore, it attempts to restore etcd
 // Finished -> When the phase is finished, Restore returns nil.
+// Restores of namespaces go through phases of their own, see restoreEtcdSnapshotNamespaces.
 func (p *Planner) restoreEtcdSnapshot(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPlaneStatus, tokensSecret plan.Secret, clusterPlan *plan.Plan, currentVersion *semver.Version) (rkev1.RKEControlPlaneStatus, error) {
-	if cp.Spec.ETCDSnapshotRestore == nil || cp.Spec.ETCDSnapshotRestore.Name == "" {
+	if cp.Spec.ETCDSnapshotRestore == nil || (cp.Spec.ETCDSnapshotRestore.Name == "" && cp.Spec.ETCDSnapshotRestore.MachineName == "") {
 		return p.resetEtcdSnapshotRestoreState(status)
 	}
+	if cp.Spec.ETCDSnapshotRestore.Name != "" && cp.Spec.ETCDSnapshotRestore.MachineName != "" {
+		return status, fmt.Errorf("etcd snapshot restore of rkecontrolplane %s/%s cannot set both a snapshot and a machine to reset etcd on", cp.Namespace, cp.Name)
+	}
+	if err := validateNamespaceRestore(cp.Spec.ETCDSnapshotRestore); err != nil {
+		return status, fmt.Errorf("etcd snapshot restore of rkecontrolplane %s/%s is invalid: %w", cp.Namespace, cp.Name, err)
+	}
 
 	if status, err := p.startOrRestartEtcdSnapshotRestore(status, cp.Spec.ETCDSnapshotRestore); err != nil {
 		return status, err
@@ -761,7 +815,7 @@ func (p *Planner) restoreEtcdSnapshot(cp *rkev1.RKEControlPlane, status rkev1.RK
 	if snapshot != nil {
 		clusterSpec, err := capr.ParseSnapshotClusterSpecOrError(snapshot)
 		if err != nil || clusterSpec == nil {
-			logrus.Errorf("[planner] rkecluster %s/%s: error parsing snapshot cluster spec for snapshot %s/%s during etcd restoration: %v", cp.Namespace, cp.Name, snapshot.Namespace, snapshot.Name, err)
+			logger.Errorf("[planner] rkecluster %s/%s: error parsing snapshot cluster spec for snapshot %s/%s during etcd restoration: %v", cp.Namespace, cp.Name, snapshot.Namespace, snapshot.Name, err)
 		} else {
 			snapshotK8sVersion, err := semver.NewVersion(clusterSpec.KubernetesVersion)
 			if err != nil {
@@ -773,15 +827,19 @@ func (p *Planner) restoreEtcdSnapshot(cp *rkev1.RKEControlPlane, status rkev1.RK
 		}
 	}
 
+	if len(cp.Spec.ETCDSnapshotRestore.Namespaces) > 0 {
+		return p.restoreEtcdSnapshotNamespaces(cp, status, snapshot, tokensSecret, clusterPlan)
+	}
+
 	switch cp.Status.ETCDSnapshotRestorePhase {
 	case rkev1.ETCDSnapshotPhaseStarted:
 		if status.Initialized || status.Ready {
 			status.Initialized = false
 			status.Ready = false
-			logrus.Debugf("[planner] rkecluster %s/%s: setting controlplane ready/initialized to false during etcd restore", cp.Namespace, cp.Name)
+			logger.Debugf("[planner] rkecluster %s/%s: setting controlplane ready/initialized to false during etcd restore", cp.Namespace, cp.Name)
 		}
 		status, _ = p.setEtcdSnapshotRestoreState(status, cp.Spec.ETCDSnapshotRestore, rkev1.ETCDSnapshotPhaseShutdown)
-		return status, errWaitingf("shutting down cluster")
+		return status, newErrWaiting(capr.WaitingForEtcdRestore, "shutting down cluster")
 	case rkev1.ETCDSnapshotPhaseShutdown:
 		if err = p.runEtcdRestoreServiceStop(cp, snapshot, tokensSecret, clusterPlan); err != nil {
 			return status, err
@@ -790,7 +848,7 @@ func (p *Planner) restoreEtcdSnapshot(cp *rkev1.RKEControlPlane, status rkev1.RK
 		// the error returned from setEtcdSnapshotRestoreState is set based on etcd snapshot restore fields, but we are
 		// manipulating other fields so we should unconditionally return a waiting error.
 		status, _ = p.setEtcdSnapshotRestoreState(status, cp.Spec.ETCDSnapshotRestore, rkev1.ETCDSnapshotPhaseRestore)
-		return status, errWaiting("cluster shutdown complete, running etcd restore")
+		return status, newErrWaiting(capr.WaitingForEtcdRestore, "cluster shutdown complete, running etcd restore")
 	case rkev1.ETCDSnapshotPhaseRestore:
 		if err = p.runEtcdSnapshotRestorePlan(cp, snapshot, cp.Spec.ETCDSnapshotRestore.Name, tokensSecret, clusterPlan); err != nil {
 			return status, err
@@ -806,7 +864,7 @@ func (p *Planner) restoreEtcdSnapshot(cp *rkev1.RKEControlPlane, status rkev1.RK
 		if err := p.pauseCAPICluster(cp, false); err != nil {
 			return status, err
 		}
-		logrus.Infof("[planner] rkecluster %s/%s: running full reconcile during etcd restore to initially restart cluster", cp.Namespace, cp.Name)
+		logger.Infof("[planner] rkecluster %s/%s: running full reconcile during etcd restore to initially restart cluster", cp.Namespace, cp.Name)
 		// Run a full reconcile of the cluster at this point, ignoring drain and concurrency.
 		if status, err := p.fullReconcile(cp, status, tokensSecret, clusterPlan, true); err != nil {
 			return status, err
@@ -821,7 +879,7 @@ func (p *Planner) restoreEtcdSnapshot(cp *rkev1.RKEControlPlane, status rkev1.RK
 		if err := p.pauseCAPICluster(cp, false); err != nil {
 			return status, err
 		}
-		logrus.Infof("[planner] rkecluster %s/%s: running full reconcile during etcd restore to restart cluster", cp.Namespace, cp.Name)
+		logger.Infof("[planner] rkecluster %s/%s: running full reconcile during etcd restore to restart cluster", cp.Namespace, cp.Name)
 		// Run a full reconcile of the cluster at this point, ignoring drain and concurrency.
 		if status, err := p.fullReconcile(cp, status, tokensSecret, clusterPlan, true); err != nil {
 			return status, err
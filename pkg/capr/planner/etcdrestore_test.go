@@ -0,0 +1,119 @@
+package planner
+
+import (
+	"testing"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func createTestEtcdResetPlan() *plan.Plan {
+	clusterPlan := &plan.Plan{
+		Nodes:    map[string]*plan.Node{},
+		Machines: map[string]*capi.Machine{},
+		Metadata: map[string]*plan.Metadata{},
+	}
+	for name, etcd := range map[string]string{"etcd-1": "true", "etcd-2": "true", "worker": "false"} {
+		clusterPlan.Machines[name] = &capi.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "fleet-default",
+				Name:      name,
+				Labels: map[string]string{
+					capr.MachineIDLabel: name + "-id",
+				},
+			},
+			Status: capi.MachineStatus{
+				Conditions: capi.Conditions{{Type: capi.InfrastructureReadyCondition, Status: v1.ConditionTrue}},
+			},
+		}
+		clusterPlan.Metadata[name] = &plan.Metadata{
+			Labels: map[string]string{
+				capr.EtcdRoleLabel: etcd,
+			},
+		}
+	}
+	return clusterPlan
+}
+
+func Test_etcdResetEntry(t *testing.T) {
+	tests := []struct {
+		name        string
+		machineName string
+		modify      func(clusterPlan *plan.Plan)
+		expected    string
+	}{
+		{
+			name:        "surviving etcd machine",
+			machineName: "etcd-2",
+		},
+		{
+			name:        "missing machine",
+			machineName: "etcd-3",
+			expected:    "unable to reset etcd as machine fleet-default/etcd-3 was not found",
+		},
+		{
+			name:        "worker machine",
+			machineName: "worker",
+			expected:    "unable to reset etcd on machine fleet-default/worker as it is not a healthy etcd machine",
+		},
+		{
+			name:        "failed machine",
+			machineName: "etcd-1",
+			modify: func(clusterPlan *plan.Plan) {
+				clusterPlan.Machines["etcd-1"].Status.Phase = string(capi.MachinePhaseFailed)
+			},
+			expected: "unable to reset etcd on machine fleet-default/etcd-1 as it is not a healthy etcd machine",
+		},
+		{
+			name:        "machine without id",
+			machineName: "etcd-1",
+			modify: func(clusterPlan *plan.Plan) {
+				delete(clusterPlan.Machines["etcd-1"].Labels, capr.MachineIDLabel)
+			},
+			expected: "unable to reset etcd on machine fleet-default/etcd-1 as label rke.cattle.io/machine-id did not exist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusterPlan := createTestEtcdResetPlan()
+			if tt.modify != nil {
+				tt.modify(clusterPlan)
+			}
+			controlPlane := &rkev1.RKEControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "fleet-default", Name: "c"},
+				Spec: rkev1.RKEControlPlaneSpec{
+					ETCDSnapshotRestore: &rkev1.ETCDSnapshotRestore{MachineName: tt.machineName, Generation: 1},
+				},
+			}
+
+			entry, err := etcdResetEntry(controlPlane, clusterPlan)
+			if tt.expected != "" {
+				assert.EqualError(t, err, tt.expected)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.machineName, entry.Machine.Name)
+		})
+	}
+}
+
+func Test_isEtcdResetMachine(t *testing.T) {
+	clusterPlan := createTestEtcdResetPlan()
+	entries := collect(clusterPlan, isEtcd)
+	controlPlane := &rkev1.RKEControlPlane{}
+
+	assert.False(t, isEtcdResetMachine(controlPlane, entries[0]))
+
+	controlPlane.Spec.ETCDSnapshotRestore = &rkev1.ETCDSnapshotRestore{Name: "snapshot"}
+	assert.False(t, isEtcdResetMachine(controlPlane, entries[0]))
+
+	controlPlane.Spec.ETCDSnapshotRestore = &rkev1.ETCDSnapshotRestore{MachineName: "etcd-2"}
+	assert.False(t, isEtcdResetMachine(controlPlane, entries[0]))
+	assert.True(t, isEtcdResetMachine(controlPlane, entries[1]))
+}
@@ -0,0 +1,93 @@
+package planner
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/wrangler/pkg/data/convert"
+)
+
+// addGarbageCollectionConfig renders the garbage collection tuning of the machine pool of the entry into kubelet
+// arguments. The tuning of the pool replaces the matching kubelet-arg of the machine.
+func addGarbageCollectionConfig(config map[string]interface{}, entry *planEntry) error {
+	data := entry.Metadata.Annotations[capr.GarbageCollectionAnnotation]
+	if data == "" {
+		return nil
+	}
+
+	gc := &rkev1.GarbageCollection{}
+	if err := json.Unmarshal([]byte(data), gc); err != nil {
+		return fmt.Errorf("invalid garbage collection tuning of machine %s/%s: %w", entry.Machine.Namespace, entry.Machine.Name, err)
+	}
+	if err := capr.ValidateGarbageCollection(gc); err != nil {
+		return fmt.Errorf("invalid garbage collection tuning of machine %s/%s: %w", entry.Machine.Namespace, entry.Machine.Name, err)
+	}
+
+	args := convert.ToStringSlice(config[kubeletArg])
+	for _, arg := range garbageCollectionKubeletArgs(gc) {
+		args = replaceArg(args, arg)
+	}
+	if len(args) > 0 {
+		config[kubeletArg] = args
+	}
+	return nil
+}
+
+// garbageCollectionKubeletArgs returns the kubelet arguments of the garbage collection tuning, in the key=value form
+// of kubelet-arg.
+func garbageCollectionKubeletArgs(gc *rkev1.GarbageCollection) []string {
+	var args []string
+	if gc.ImageGCHighThresholdPercent != nil {
+		args = append(args, fmt.Sprintf("image-gc-high-threshold=%d", *gc.ImageGCHighThresholdPercent))
+	}
+	if gc.ImageGCLowThresholdPercent != nil {
+		args = append(args, fmt.Sprintf("image-gc-low-threshold=%d", *gc.ImageGCLowThresholdPercent))
+	}
+	if gc.ContainerLogMaxSize != "" {
+		args = append(args, "container-log-max-size="+gc.ContainerLogMaxSize)
+	}
+	if gc.ContainerLogMaxFiles != nil {
+		args = append(args, fmt.Sprintf("container-log-max-files=%d", *gc.ContainerLogMaxFiles))
+	}
+	if len(gc.EvictionHard) > 0 {
+		args = append(args, "eviction-hard="+joinSignals(gc.EvictionHard, "<"))
+	}
+	if len(gc.EvictionSoft) > 0 {
+		args = append(args, "eviction-soft="+joinSignals(gc.EvictionSoft, "<"))
+	}
+	if len(gc.EvictionSoftGracePeriod) > 0 {
+		args = append(args, "eviction-soft-grace-period="+joinSignals(gc.EvictionSoftGracePeriod, "="))
+	}
+	if len(gc.EvictionMinimumReclaim) > 0 {
+		args = append(args, "eviction-minimum-reclaim="+joinSignals(gc.EvictionMinimumReclaim, "="))
+	}
+	return args
+}
+
+// joinSignals returns the values of eviction signals in the comma separated form of the kubelet flags, sorted by
+// signal so that the rendered plan is stable.
+func joinSignals(values map[string]string, operator string) string {
+	var result []string
+	for signal, value := range values {
+		result = append(result, signal+operator+value)
+	}
+	sort.Strings(result)
+	return strings.Join(result, ",")
+}
+
+// replaceArg returns the args with every argument of the key of arg removed, and arg appended.
+func replaceArg(args []string, arg string) []string {
+	key, _ := splitArgKeyVal(arg, "=")
+	result := make([]string, 0, len(args)+1)
+	for _, existing := range args {
+		if existingKey, _ := splitArgKeyVal(existing, "="); existingKey == key {
+			continue
+		}
+		result = append(result, existing)
+	}
+	return append(result, arg)
+}
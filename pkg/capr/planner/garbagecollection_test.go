@@ -0,0 +1,97 @@
+package planner
+
+import (
+	"testing"
+
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/stretchr/testify/assert"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func garbageCollectionEntry(annotation string) *planEntry {
+	entry := &planEntry{
+		Machine:  &capi.Machine{},
+		Metadata: &plan.Metadata{Annotations: map[string]string{}},
+	}
+	entry.Machine.Namespace = "fleet-default"
+	entry.Machine.Name = "machine"
+	if annotation != "" {
+		entry.Metadata.Annotations[capr.GarbageCollectionAnnotation] = annotation
+	}
+	return entry
+}
+
+func Test_addGarbageCollectionConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotation  string
+		config      map[string]interface{}
+		expected    interface{}
+		expectedErr bool
+	}{
+		{
+			name:     "no tuning",
+			config:   map[string]interface{}{kubeletArg: []interface{}{"max-pods=200"}},
+			expected: []interface{}{"max-pods=200"},
+		},
+		{
+			name:       "image and log tuning",
+			annotation: `{"imageGCHighThresholdPercent":70,"imageGCLowThresholdPercent":60,"containerLogMaxSize":"20Mi","containerLogMaxFiles":3}`,
+			config:     map[string]interface{}{kubeletArg: []interface{}{"max-pods=200"}},
+			expected: []string{
+				"max-pods=200",
+				"image-gc-high-threshold=70",
+				"image-gc-low-threshold=60",
+				"container-log-max-size=20Mi",
+				"container-log-max-files=3",
+			},
+		},
+		{
+			name:       "eviction tuning",
+			annotation: `{"evictionHard":{"nodefs.available":"10%","memory.available":"100Mi"},"evictionSoft":{"nodefs.available":"15%"},"evictionSoftGracePeriod":{"nodefs.available":"1m30s"},"evictionMinimumReclaim":{"nodefs.available":"1Gi"}}`,
+			config:     map[string]interface{}{},
+			expected: []string{
+				"eviction-hard=memory.available<100Mi,nodefs.available<10%",
+				"eviction-soft=nodefs.available<15%",
+				"eviction-soft-grace-period=nodefs.available=1m30s",
+				"eviction-minimum-reclaim=nodefs.available=1Gi",
+			},
+		},
+		{
+			name:       "replaces kubelet-arg set by the user",
+			annotation: `{"imageGCHighThresholdPercent":90,"containerLogMaxFiles":10}`,
+			config:     map[string]interface{}{kubeletArg: []interface{}{"image-gc-high-threshold=95", "max-pods=200", "container-log-max-files=5"}},
+			expected:   []string{"max-pods=200", "image-gc-high-threshold=90", "container-log-max-files=10"},
+		},
+		{
+			name:        "invalid tuning",
+			annotation:  `{"imageGCHighThresholdPercent":50}`,
+			config:      map[string]interface{}{},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid annotation",
+			annotation:  `{`,
+			config:      map[string]interface{}{},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := addGarbageCollectionConfig(tt.config, garbageCollectionEntry(tt.annotation))
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, tt.config[kubeletArg])
+		})
+	}
+}
+
+func Test_replaceArg(t *testing.T) {
+	assert.Equal(t, []string{"max-pods=200", "eviction-hard=memory.available<100Mi"},
+		replaceArg([]string{"eviction-hard=nodefs.available<5%", "max-pods=200"}, "eviction-hard=memory.available<100Mi"))
+	assert.Equal(t, []string{"eviction-hard=memory.available<100Mi"}, replaceArg(nil, "eviction-hard=memory.available<100Mi"))
+}
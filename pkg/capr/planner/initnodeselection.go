@@ -0,0 +1,174 @@
+package planner
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/rancher/rancher/pkg/capr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// maxInitNodeHistory is the number of init node elections kept in the status of the control plane.
+	maxInitNodeHistory = 10
+	// liveMigrationRecheck is how often an init node whose VM is live migrated is checked to be available again.
+	liveMigrationRecheck = 30 * time.Second
+)
+
+// validateInitNodeSelection returns an error if the init node selection of the control plane is invalid.
+func validateInitNodeSelection(selection *rkev1.InitNodeSelection) error {
+	if selection == nil {
+		return nil
+	}
+	switch selection.Strategy {
+	case "", rkev1.InitNodeSelectionStrategyLowestIndex, rkev1.InitNodeSelectionStrategyOldestMachine:
+	default:
+		return fmt.Errorf("unknown init node selection strategy %s, must be %s or %s", selection.Strategy,
+			rkev1.InitNodeSelectionStrategyLowestIndex, rkev1.InitNodeSelectionStrategyOldestMachine)
+	}
+	if selection.ReelectionBackoff != nil && selection.ReelectionBackoff.Duration < 0 {
+		return fmt.Errorf("init node re-election backoff %s must not be negative", selection.ReelectionBackoff.Duration)
+	}
+	return nil
+}
+
+// sortInitNodeCandidates orders the entries by preference to be elected init node. Pinned machines come first, then
+// the machines of the preferred zones, then the machines ordered by the strategy of the selection.
+func sortInitNodeCandidates(selection *rkev1.InitNodeSelection, entries []*planEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if pinnedA, pinnedB := isPinnedInitNode(a), isPinnedInitNode(b); pinnedA != pinnedB {
+			return pinnedA
+		}
+		if selection == nil {
+			return a.Machine.Name < b.Machine.Name
+		}
+		if rankA, rankB := zoneRank(selection, a), zoneRank(selection, b); rankA != rankB {
+			return rankA < rankB
+		}
+		if selection.Strategy == rkev1.InitNodeSelectionStrategyOldestMachine && !a.Machine.CreationTimestamp.Equal(&b.Machine.CreationTimestamp) {
+			return a.Machine.CreationTimestamp.Before(&b.Machine.CreationTimestamp)
+		}
+		return a.Machine.Name < b.Machine.Name
+	})
+}
+
+// isPinnedInitNode returns true if the machine of the entry is annotated to be elected init node before any other.
+func isPinnedInitNode(entry *planEntry) bool {
+	return entry.Machine.Annotations[capr.InitNodePinAnnotation] == "true"
+}
+
+// zoneRank returns the position of the zone of the machine of the entry in the preferred zones, or the number of
+// preferred zones if it isn't preferred.
+func zoneRank(selection *rkev1.InitNodeSelection, entry *planEntry) int {
+	zone := entry.Machine.Labels[corev1.LabelTopologyZone]
+	if zone == "" && entry.Metadata != nil {
+		zone = entry.Metadata.Labels[corev1.LabelTopologyZone]
+	}
+	for i, preferred := range selection.PreferredZones {
+		if zone != "" && zone == preferred {
+			return i
+		}
+	}
+	return len(selection.PreferredZones)
+}
+
+// isLiveMigrating returns true if the VM of the machine of the entry is being live migrated.
+func isLiveMigrating(entry *planEntry) bool {
+	return entry.Machine.Annotations[capr.LiveMigrationAnnotation] != ""
+}
+
+// initNodeAvailable returns true if the entry can keep being the init node without an election.
+func initNodeAvailable(entry *planEntry) bool {
+	return canBeInitNode(entry) && entry.Metadata.Annotations[capr.JoinURLAnnotation] != ""
+}
+
+// currentInitNode returns the entry of the init node of the cluster, or nil if there isn't a single one.
+func currentInitNode(clusterPlan *plan.Plan) *planEntry {
+	initNodes := collect(clusterPlan, isInitNode)
+	if len(initNodes) != 1 {
+		return nil
+	}
+	return initNodes[0]
+}
+
+// trackInitNodeAvailability records when the init node stopped being available, from which the re-election backoff
+// elapses.
+func trackInitNodeAvailability(status rkev1.RKEControlPlaneStatus, clusterPlan *plan.Plan, now time.Time) rkev1.RKEControlPlaneStatus {
+	initNode := currentInitNode(clusterPlan)
+	if initNode == nil || initNodeAvailable(initNode) {
+		status.InitNodeUnavailableSince = nil
+	} else if status.InitNodeUnavailableSince == nil {
+		since := metav1.NewTime(now)
+		status.InitNodeUnavailableSince = &since
+	}
+	return status
+}
+
+// initNodeReelectionWait returns how long the election of another init node is held back, as the current init node
+// only became unavailable within the re-election backoff or its VM is being live migrated. Init nodes that are deleted
+// or failed are never waited for.
+func initNodeReelectionWait(controlPlane *rkev1.RKEControlPlane, status rkev1.RKEControlPlaneStatus, clusterPlan *plan.Plan, now time.Time) time.Duration {
+	if controlPlane.Labels[capr.InitNodeMachineIDLabel] != "" {
+		return 0
+	}
+	initNode := currentInitNode(clusterPlan)
+	if initNode == nil || initNodeAvailable(initNode) || isDeleting(initNode) || isFailed(initNode) {
+		return 0
+	}
+	// The init node comes back once the migration of its VM completes, another one isn't elected in the meantime.
+	if isLiveMigrating(initNode) {
+		return liveMigrationRecheck
+	}
+	selection := controlPlane.Spec.InitNodeSelection
+	if selection == nil || selection.ReelectionBackoff == nil || selection.ReelectionBackoff.Duration <= 0 {
+		return 0
+	}
+	if status.InitNodeUnavailableSince == nil {
+		return selection.ReelectionBackoff.Duration
+	}
+	if wait := selection.ReelectionBackoff.Duration - now.Sub(status.InitNodeUnavailableSince.Time); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// recordInitNodeElection records the init node of the cluster in the status of the control plane, and the election
+// in its history if the init node changed.
+func recordInitNodeElection(status rkev1.RKEControlPlaneStatus, clusterPlan *plan.Plan, now time.Time) rkev1.RKEControlPlaneStatus {
+	initNode := currentInitNode(clusterPlan)
+	if initNode == nil || initNode.Machine.Name == status.InitNode {
+		return status
+	}
+
+	var reason string
+	previous := clusterPlan.Machines[status.InitNode]
+	switch {
+	case status.InitNode == "":
+		reason = "initial election"
+	case isPinnedInitNode(initNode):
+		reason = fmt.Sprintf("machine is pinned, replacing %s", status.InitNode)
+	case previous == nil:
+		reason = fmt.Sprintf("previous init node %s was removed", status.InitNode)
+	case previous.DeletionTimestamp != nil:
+		reason = fmt.Sprintf("previous init node %s was deleted", status.InitNode)
+	default:
+		reason = fmt.Sprintf("previous init node %s was unavailable", status.InitNode)
+	}
+
+	status.InitNode = initNode.Machine.Name
+	status.InitNodeUnavailableSince = nil
+	status.InitNodeHistory = append(status.InitNodeHistory, rkev1.InitNodeElection{
+		MachineName: initNode.Machine.Name,
+		ElectedAt:   metav1.NewTime(now),
+		Reason:      reason,
+	})
+	if len(status.InitNodeHistory) > maxInitNodeHistory {
+		status.InitNodeHistory = status.InitNodeHistory[len(status.InitNodeHistory)-maxInitNodeHistory:]
+	}
+	return status
+}
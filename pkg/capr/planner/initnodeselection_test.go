@@ -0,0 +1,192 @@
+package planner
+
+import (
+	"testing"
+	"time"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func createTestInitNodeEntry(name, zone string, created time.Time) *planEntry {
+	return &planEntry{
+		Machine: &capi.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(created),
+				Labels: map[string]string{
+					v1.LabelTopologyZone: zone,
+				},
+				Annotations: map[string]string{},
+			},
+			Status: capi.MachineStatus{
+				Conditions: capi.Conditions{{Type: capi.InfrastructureReadyCondition, Status: v1.ConditionTrue}},
+			},
+		},
+		Metadata: &plan.Metadata{
+			Labels: map[string]string{
+				capr.EtcdRoleLabel: "true",
+			},
+			Annotations: map[string]string{
+				capr.JoinURLAnnotation: "https://" + name + ":9345",
+			},
+		},
+	}
+}
+
+func createTestInitNodePlan(entries ...*planEntry) *plan.Plan {
+	clusterPlan := &plan.Plan{
+		Nodes:    map[string]*plan.Node{},
+		Machines: map[string]*capi.Machine{},
+		Metadata: map[string]*plan.Metadata{},
+	}
+	for _, entry := range entries {
+		clusterPlan.Machines[entry.Machine.Name] = entry.Machine
+		clusterPlan.Metadata[entry.Machine.Name] = entry.Metadata
+	}
+	return clusterPlan
+}
+
+func Test_validateInitNodeSelection(t *testing.T) {
+	assert.NoError(t, validateInitNodeSelection(nil))
+	assert.NoError(t, validateInitNodeSelection(&rkev1.InitNodeSelection{Strategy: rkev1.InitNodeSelectionStrategyOldestMachine}))
+	assert.EqualError(t, validateInitNodeSelection(&rkev1.InitNodeSelection{Strategy: "Random"}),
+		"unknown init node selection strategy Random, must be LowestIndex or OldestMachine")
+	assert.EqualError(t, validateInitNodeSelection(&rkev1.InitNodeSelection{ReelectionBackoff: &metav1.Duration{Duration: -time.Second}}),
+		"init node re-election backoff -1s must not be negative")
+}
+
+func Test_sortInitNodeCandidates(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		selection *rkev1.InitNodeSelection
+		pinned    string
+		expected  []string
+	}{
+		{
+			name:     "lowest index by default",
+			expected: []string{"etcd-1", "etcd-2", "etcd-3"},
+		},
+		{
+			name:      "oldest machine",
+			selection: &rkev1.InitNodeSelection{Strategy: rkev1.InitNodeSelectionStrategyOldestMachine},
+			expected:  []string{"etcd-3", "etcd-2", "etcd-1"},
+		},
+		{
+			name:      "preferred zones",
+			selection: &rkev1.InitNodeSelection{PreferredZones: []string{"zone-b", "zone-a"}},
+			expected:  []string{"etcd-2", "etcd-1", "etcd-3"},
+		},
+		{
+			name:      "pinned machine",
+			selection: &rkev1.InitNodeSelection{Strategy: rkev1.InitNodeSelectionStrategyOldestMachine},
+			pinned:    "etcd-1",
+			expected:  []string{"etcd-1", "etcd-3", "etcd-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := []*planEntry{
+				createTestInitNodeEntry("etcd-2", "zone-b", now.Add(-time.Hour)),
+				createTestInitNodeEntry("etcd-3", "zone-c", now.Add(-2*time.Hour)),
+				createTestInitNodeEntry("etcd-1", "zone-a", now),
+			}
+			for _, entry := range entries {
+				if entry.Machine.Name == tt.pinned {
+					entry.Machine.Annotations[capr.InitNodePinAnnotation] = "true"
+				}
+			}
+			sortInitNodeCandidates(tt.selection, entries)
+			var names []string
+			for _, entry := range entries {
+				names = append(names, entry.Machine.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func Test_initNodeReelectionWait(t *testing.T) {
+	now := time.Now()
+	controlPlane := &rkev1.RKEControlPlane{
+		Spec: rkev1.RKEControlPlaneSpec{
+			RKEClusterSpecCommon: rkev1.RKEClusterSpecCommon{
+				InitNodeSelection: &rkev1.InitNodeSelection{ReelectionBackoff: &metav1.Duration{Duration: 5 * time.Minute}},
+			},
+		},
+	}
+	initNode := createTestInitNodeEntry("etcd-1", "", now)
+	initNode.Metadata.Labels[capr.InitNodeLabel] = "true"
+	clusterPlan := createTestInitNodePlan(initNode, createTestInitNodeEntry("etcd-2", "", now))
+
+	status := trackInitNodeAvailability(rkev1.RKEControlPlaneStatus{}, clusterPlan, now)
+	assert.Nil(t, status.InitNodeUnavailableSince)
+	assert.Zero(t, initNodeReelectionWait(controlPlane, status, clusterPlan, now))
+
+	// the init node lost its join URL, another one is only elected once the backoff elapsed
+	delete(initNode.Metadata.Annotations, capr.JoinURLAnnotation)
+	status = trackInitNodeAvailability(status, clusterPlan, now)
+	assert.Equal(t, now.Unix(), status.InitNodeUnavailableSince.Unix())
+	assert.Equal(t, 5*time.Minute, initNodeReelectionWait(controlPlane, status, clusterPlan, now))
+	assert.Equal(t, 2*time.Minute, initNodeReelectionWait(controlPlane, status, clusterPlan, now.Add(3*time.Minute)))
+	assert.Zero(t, initNodeReelectionWait(controlPlane, status, clusterPlan, now.Add(6*time.Minute)))
+
+	// the time it became unavailable is kept
+	assert.Equal(t, status.InitNodeUnavailableSince, trackInitNodeAvailability(status, clusterPlan, now.Add(time.Minute)).InitNodeUnavailableSince)
+
+	// a deleting init node is replaced immediately
+	deleted := metav1.NewTime(now)
+	initNode.Machine.DeletionTimestamp = &deleted
+	assert.Zero(t, initNodeReelectionWait(controlPlane, status, clusterPlan, now))
+
+	// without a backoff, there is no wait
+	initNode.Machine.DeletionTimestamp = nil
+	controlPlane.Spec.InitNodeSelection = nil
+	assert.Zero(t, initNodeReelectionWait(controlPlane, status, clusterPlan, now))
+
+	// an init node being live migrated is waited for, even without a backoff
+	initNode.Machine.Annotations[capr.LiveMigrationAnnotation] = now.Format(time.RFC3339)
+	assert.Equal(t, liveMigrationRecheck, initNodeReelectionWait(controlPlane, status, clusterPlan, now.Add(time.Hour)))
+}
+
+func Test_recordInitNodeElection(t *testing.T) {
+	now := time.Now()
+	first := createTestInitNodeEntry("etcd-1", "", now)
+	second := createTestInitNodeEntry("etcd-2", "", now)
+	clusterPlan := createTestInitNodePlan(first, second)
+
+	status := recordInitNodeElection(rkev1.RKEControlPlaneStatus{}, clusterPlan, now)
+	assert.Empty(t, status.InitNode)
+
+	first.Metadata.Labels[capr.InitNodeLabel] = "true"
+	status = recordInitNodeElection(status, clusterPlan, now)
+	assert.Equal(t, "etcd-1", status.InitNode)
+	assert.Equal(t, []rkev1.InitNodeElection{{MachineName: "etcd-1", ElectedAt: metav1.NewTime(now), Reason: "initial election"}}, status.InitNodeHistory)
+
+	// the same init node isn't recorded again
+	assert.Equal(t, status, recordInitNodeElection(status, clusterPlan, now.Add(time.Minute)))
+
+	delete(first.Metadata.Labels, capr.InitNodeLabel)
+	second.Metadata.Labels[capr.InitNodeLabel] = "true"
+	status = recordInitNodeElection(status, clusterPlan, now)
+	assert.Equal(t, "etcd-2", status.InitNode)
+	assert.Equal(t, "previous init node etcd-1 was unavailable", status.InitNodeHistory[1].Reason)
+
+	delete(clusterPlan.Machines, "etcd-2")
+	delete(clusterPlan.Metadata, "etcd-2")
+	first.Metadata.Labels[capr.InitNodeLabel] = "true"
+	for i := 0; i < maxInitNodeHistory; i++ {
+		status.InitNode = "etcd-2"
+		status = recordInitNodeElection(status, clusterPlan, now)
+	}
+	assert.Len(t, status.InitNodeHistory, maxInitNodeHistory)
+	assert.Equal(t, "previous init node etcd-2 was removed", status.InitNodeHistory[maxInitNodeHistory-1].Reason)
+}
@@ -12,6 +12,13 @@
 const (
 	captureAddressInstructionName = "capture-address"
 	etcdNameInstructionName       = "etcd-name"
+
+	// periodicInstructionTimeoutSeconds is the timeout of the periodic instructions run by the planner, shorter than
+	// their period so that a hung run doesn't delay the next one.
+	periodicInstructionTimeoutSeconds = 540
+	// rotateCertificatesTimeoutSeconds is the timeout of the certificate rotation instruction, killed and retried
+	// with the plan if the rotation hangs.
+	rotateCertificatesTimeoutSeconds = 600
 )
 
 // generateInstallInstruction generates the instruction necessary to install the desired tool.
@@ -64,10 +71,17 @@ func (p *Planner) generateInstallInstruction(controlPlane *rkev1.RKEControlPlane
 
 // addInstallInstructionWithRestartStamp will generate an instruction and append it to the node plan that executes the `run.sh` or `run.ps1`
 // from the installer image based on the control plane configuration. It will generate a restart stamp based on the
-// passed in configuration to determine whether it needs to start/restart the service being managed.
+// passed in configuration to determine whether it needs to start/restart the service being managed. If the only change
+// to the plan is SANs added to the config, the previous restart stamp is kept and the SANs are added to the serving
+// certificate when the service is next restarted.
 func (p *Planner) addInstallInstructionWithRestartStamp(nodePlan plan.NodePlan, controlPlane *rkev1.RKEControlPlane, entry *planEntry) (plan.NodePlan, error) {
 	var restartStampEnv string
-	stamp := restartStamp(nodePlan, controlPlane, p.getInstallerImage(controlPlane))
+	image := p.getInstallerImage(controlPlane)
+	stamp := restartStamp(nodePlan, controlPlane, image)
+	previousStamp, tlsSANBase, tlsSANDeferred := tlsSANRestartStamp(nodePlan, controlPlane, entry, image)
+	if tlsSANDeferred {
+		stamp = previousStamp
+	}
 	switch entry.Metadata.Labels[capr.CattleOSLabel] {
 	case capr.WindowsMachineOS:
 		restartStampEnv = "$env:RESTART_STAMP=\"" + stamp + "\""
@@ -75,6 +89,9 @@ func (p *Planner) addInstallInstructionWithRestartStamp(nodePlan plan.NodePlan,
 		restartStampEnv = "RESTART_STAMP=" + stamp
 	}
 	instEnv := []string{restartStampEnv}
+	if tlsSANDeferred {
+		instEnv = append(instEnv, tlsSANBaseRestartStampEnv+"="+tlsSANBase)
+	}
 	nodePlan.Instructions = append(nodePlan.Instructions, p.generateInstallInstruction(controlPlane, entry, instEnv))
 	return nodePlan, nil
 }
@@ -107,7 +124,8 @@ func (p *Planner) addInitNodePeriodicInstruction(nodePlan plan.NodePlan, control
 					capr.GetRuntime(controlPlane.Spec.KubernetesVersion),
 					capr.GetRuntimeSupervisorPort(controlPlane.Spec.KubernetesVersion)),
 			},
-			PeriodSeconds: 600,
+			PeriodSeconds:  600,
+			TimeoutSeconds: instructionTimeout(controlPlane, periodicInstructionTimeoutSeconds),
 		},
 		{
 			Name:    etcdNameInstructionName,
@@ -116,7 +134,8 @@ func (p *Planner) addInitNodePeriodicInstruction(nodePlan plan.NodePlan, control
 				"-c",
 				fmt.Sprintf("cat /var/lib/rancher/%s/server/db/etcd/name", capr.GetRuntime(controlPlane.Spec.KubernetesVersion)),
 			},
-			PeriodSeconds: 600,
+			PeriodSeconds:  600,
+			TimeoutSeconds: instructionTimeout(controlPlane, periodicInstructionTimeoutSeconds),
 		},
 	}...)
 	return nodePlan, nil
@@ -132,7 +151,8 @@ func (p *Planner) addEtcdSnapshotListLocalPeriodicInstruction(nodePlan plan.Node
 			fmt.Sprintf("%s etcd-snapshot list --etcd-s3=false 2>/dev/null",
 				capr.GetRuntime(controlPlane.Spec.KubernetesVersion)),
 		},
-		PeriodSeconds: 600,
+		PeriodSeconds:  600,
+		TimeoutSeconds: instructionTimeout(controlPlane, periodicInstructionTimeoutSeconds),
 	})
 	return nodePlan, nil
 }
@@ -147,7 +167,8 @@ func (p *Planner) addEtcdSnapshotListS3PeriodicInstruction(nodePlan plan.NodePla
 			fmt.Sprintf("%s etcd-snapshot list --etcd-s3 2>/dev/null",
 				capr.GetRuntime(controlPlane.Spec.KubernetesVersion)),
 		},
-		PeriodSeconds: 600,
+		PeriodSeconds:  600,
+		TimeoutSeconds: instructionTimeout(controlPlane, periodicInstructionTimeoutSeconds),
 	})
 	return nodePlan, nil
 }
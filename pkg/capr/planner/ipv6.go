@@ -0,0 +1,83 @@
+package planner
+
+import (
+	"net"
+	"strings"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/rancher/wrangler/pkg/data/convert"
+)
+
+const (
+	loopbackIPv4 = "127.0.0.1"
+	// loopbackIPv6 is bracketed, as the loopback address is only ever rendered into URLs.
+	loopbackIPv6 = "[::1]"
+)
+
+// isIPv6Only returns true if the cluster and service CIDRs of the cluster are all IPv6. The components of IPv6-only
+// clusters, such as etcd and the kubelet, serve their health endpoints on the IPv6 loopback address rather than the
+// IPv4 one. The CIDRs are read from the config of the cluster rather than of the nodes, as they're server args which
+// the config of worker nodes doesn't have.
+func isIPv6Only(controlPlane *rkev1.RKEControlPlane) bool {
+	config := map[string]interface{}{}
+	for k, v := range controlPlane.Spec.MachineGlobalConfig.Data {
+		config[k] = v
+	}
+	for _, opts := range controlPlane.Spec.MachineSelectorConfig {
+		if opts.MachineLabelSelector == nil {
+			for k, v := range opts.Config.Data {
+				config[k] = v
+			}
+		}
+	}
+
+	var cidrs []string
+	for _, arg := range []string{"cluster-cidr", "service-cidr"} {
+		for _, value := range convert.ToStringSlice(config[arg]) {
+			cidrs = append(cidrs, strings.Split(value, ",")...)
+		}
+	}
+	if len(cidrs) == 0 {
+		return false
+	}
+	for _, cidr := range cidrs {
+		ip, _, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil || ip.To4() != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// loopbackAddress returns the loopback address to reach the components of the nodes of the cluster at, as rendered
+// in URLs.
+func loopbackAddress(controlPlane *rkev1.RKEControlPlane) string {
+	if isIPv6Only(controlPlane) {
+		return loopbackIPv6
+	}
+	return loopbackIPv4
+}
+
+// replaceLoopbackForProbes replaces the IPv4 loopback address of the URLs of the probes with the loopback address of
+// the cluster.
+func replaceLoopbackForProbes(probes map[string]plan.Probe, loopback string) map[string]plan.Probe {
+	if loopback == loopbackIPv4 {
+		return probes
+	}
+	result := map[string]plan.Probe{}
+	for k, v := range probes {
+		v.HTTPGetAction.URL = strings.Replace(v.HTTPGetAction.URL, "//"+loopbackIPv4+":", "//"+loopback+":", 1)
+		result[k] = v
+	}
+	return result
+}
+
+// unbracketAddress returns an address without the brackets IPv6 addresses are enclosed in in URLs, as the addresses
+// of node args such as tls-san and node-ip must be bare.
+func unbracketAddress(address string) string {
+	if strings.HasPrefix(address, "[") && strings.HasSuffix(address, "]") {
+		return address[1 : len(address)-1]
+	}
+	return address
+}
@@ -0,0 +1,103 @@
+package planner
+
+import (
+	"testing"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func controlPlaneWithCIDRs(global map[string]interface{}, selectorConfigs ...rkev1.RKESystemConfig) *rkev1.RKEControlPlane {
+	cp := &rkev1.RKEControlPlane{}
+	cp.Spec.MachineGlobalConfig = rkev1.GenericMap{Data: global}
+	cp.Spec.MachineSelectorConfig = selectorConfigs
+	return cp
+}
+
+func Test_isIPv6Only(t *testing.T) {
+	tests := []struct {
+		name         string
+		controlPlane *rkev1.RKEControlPlane
+		expected     bool
+	}{
+		{
+			name:         "default CIDRs",
+			controlPlane: controlPlaneWithCIDRs(nil),
+		},
+		{
+			name: "IPv4",
+			controlPlane: controlPlaneWithCIDRs(map[string]interface{}{
+				"cluster-cidr": "10.42.0.0/16",
+				"service-cidr": "10.43.0.0/16",
+			}),
+		},
+		{
+			name: "dual-stack",
+			controlPlane: controlPlaneWithCIDRs(map[string]interface{}{
+				"cluster-cidr": "10.42.0.0/16,fd00:42::/56",
+				"service-cidr": "10.43.0.0/16,fd00:43::/112",
+			}),
+		},
+		{
+			name: "IPv6-only",
+			controlPlane: controlPlaneWithCIDRs(map[string]interface{}{
+				"cluster-cidr": "fd00:42::/56",
+				"service-cidr": "fd00:43::/112",
+			}),
+			expected: true,
+		},
+		{
+			name: "IPv6-only in config of all machines",
+			controlPlane: controlPlaneWithCIDRs(nil, rkev1.RKESystemConfig{
+				Config: rkev1.GenericMap{Data: map[string]interface{}{
+					"cluster-cidr": "fd00:42::/56",
+					"service-cidr": "fd00:43::/112",
+				}},
+			}),
+			expected: true,
+		},
+		{
+			name: "IPv6-only in config of selected machines",
+			controlPlane: controlPlaneWithCIDRs(nil, rkev1.RKESystemConfig{
+				MachineLabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "a"}},
+				Config: rkev1.GenericMap{Data: map[string]interface{}{
+					"cluster-cidr": "fd00:42::/56",
+				}},
+			}),
+		},
+		{
+			name: "invalid CIDR",
+			controlPlane: controlPlaneWithCIDRs(map[string]interface{}{
+				"cluster-cidr": "fd00:42::",
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isIPv6Only(tt.controlPlane))
+		})
+	}
+}
+
+func Test_replaceLoopbackForProbes(t *testing.T) {
+	probes := map[string]plan.Probe{
+		"kubelet": {HTTPGetAction: plan.HTTPGetAction{URL: "http://127.0.0.1:10248/healthz"}},
+		"custom":  {HTTPGetAction: plan.HTTPGetAction{URL: "https://10.0.0.1:8443/healthz"}},
+	}
+
+	assert.Equal(t, probes, replaceLoopbackForProbes(probes, loopbackIPv4))
+
+	replaced := replaceLoopbackForProbes(probes, loopbackIPv6)
+	assert.Equal(t, "http://[::1]:10248/healthz", replaced["kubelet"].HTTPGetAction.URL)
+	assert.Equal(t, "https://10.0.0.1:8443/healthz", replaced["custom"].HTTPGetAction.URL)
+	assert.Equal(t, "http://127.0.0.1:10248/healthz", probes["kubelet"].HTTPGetAction.URL, "the probes are copied")
+}
+
+func Test_unbracketAddress(t *testing.T) {
+	assert.Equal(t, "fd00::1", unbracketAddress("[fd00::1]"))
+	assert.Equal(t, "fd00::1", unbracketAddress("fd00::1"))
+	assert.Equal(t, "10.0.0.1", unbracketAddress("10.0.0.1"))
+	assert.Equal(t, "node.example.com", unbracketAddress("node.example.com"))
+}
@@ -0,0 +1,160 @@
+package planner
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/wrangler/pkg/slice"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// lockPoolRetryInterval is how often a cluster waiting for a lock pool checks whether it was released, should the
+	// release not enqueue it.
+	lockPoolRetryInterval = 30 * time.Second
+	// lockPoolReleaseDelay delays enqueuing the clusters waiting for a released lock pool so that the status of the
+	// releasing cluster is updated before they check the pool.
+	lockPoolReleaseDelay = 5 * time.Second
+)
+
+// lockPools serializes the operations restarting etcd across the clusters of the same lock pools. The pools a cluster
+// holds are recorded in the status of its control plane, which is the source of truth across restarts of rancher, and
+// in the grants of the planner, which cover the status updates not yet observed by the cache.
+type lockPools struct {
+	lock sync.Mutex
+	// grants are the control planes, by namespace/name, the planner granted each lock pool to.
+	grants map[string]string
+}
+
+// acquireLockPools acquires the lock pools of the control plane for the operation, recording them in its status. It
+// returns a waiting error if another cluster holds any of the pools, and enqueues the control plane to retry. No pools
+// are acquired if the lock pools planner behavior is disabled.
+func (p *Planner) acquireLockPools(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPlaneStatus, operation string) (rkev1.RKEControlPlaneStatus, error) {
+	if !plannerBehaviorEnabled(cp, capr.PlannerBehaviorLockPools) {
+		return status, nil
+	}
+	// The pools held are kept until released, should the pools of the cluster change during the operation.
+	pools := normalizeLockPools(append(append([]string{}, cp.Spec.LockPools...), status.LockPools...))
+	if len(pools) == 0 {
+		return status, nil
+	}
+
+	p.lockPools.lock.Lock()
+	defer p.lockPools.lock.Unlock()
+
+	controlPlanes, err := p.rkeControlPlanes.Cache().List("", labels.Everything())
+	if err != nil {
+		return status, err
+	}
+
+	key := cp.Namespace + "/" + cp.Name
+	holders := lockPoolHolders(controlPlanes, p.lockPools.grants)
+	for _, pool := range pools {
+		if holder := holders[pool]; holder != "" && holder != key {
+			p.rkeControlPlanes.EnqueueAfter(cp.Namespace, cp.Name, lockPoolRetryInterval)
+			return status, errWaitingf(capr.WaitingForLockPool, "waiting for lock pool %s held by %s before %s", pool, holder, operation)
+		}
+	}
+
+	if p.lockPools.grants == nil {
+		p.lockPools.grants = map[string]string{}
+	}
+	for _, pool := range pools {
+		p.lockPools.grants[pool] = key
+	}
+	if !slice.StringsEqual(status.LockPools, pools) {
+		logger.Infof("[planner] rkecluster %s/%s: acquired lock pools %s for %s", cp.Namespace, cp.Name, strings.Join(pools, ", "), operation)
+		status.LockPools = pools
+	}
+	return status, nil
+}
+
+// releaseLockPools releases the lock pools held by the control plane, and enqueues the clusters waiting for them.
+func (p *Planner) releaseLockPools(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPlaneStatus) rkev1.RKEControlPlaneStatus {
+	p.lockPools.lock.Lock()
+	defer p.lockPools.lock.Unlock()
+
+	key := cp.Namespace + "/" + cp.Name
+	for pool, holder := range p.lockPools.grants {
+		if holder == key {
+			delete(p.lockPools.grants, pool)
+		}
+	}
+
+	if len(status.LockPools) == 0 {
+		return status
+	}
+	released := status.LockPools
+	status.LockPools = nil
+	logger.Infof("[planner] rkecluster %s/%s: released lock pools %s", cp.Namespace, cp.Name, strings.Join(released, ", "))
+
+	controlPlanes, err := p.rkeControlPlanes.Cache().List("", labels.Everything())
+	if err != nil {
+		logger.Errorf("[planner] rkecluster %s/%s: error listing the clusters waiting for lock pools %s: %v", cp.Namespace, cp.Name, strings.Join(released, ", "), err)
+		return status
+	}
+	for _, waiting := range lockPoolMembers(controlPlanes, released) {
+		if waiting.Namespace == cp.Namespace && waiting.Name == cp.Name {
+			continue
+		}
+		p.rkeControlPlanes.EnqueueAfter(waiting.Namespace, waiting.Name, lockPoolReleaseDelay)
+	}
+	return status
+}
+
+// lockPoolHolders returns the control plane holding each lock pool, by namespace/name, from the status of the control
+// planes and the grants of the planner. The grants of control planes which no longer exist or are deleting are
+// dropped.
+func lockPoolHolders(controlPlanes []*rkev1.RKEControlPlane, grants map[string]string) map[string]string {
+	holders := map[string]string{}
+	existing := map[string]bool{}
+	for _, cp := range controlPlanes {
+		if !cp.DeletionTimestamp.IsZero() {
+			continue
+		}
+		key := cp.Namespace + "/" + cp.Name
+		existing[key] = true
+		for _, pool := range cp.Status.LockPools {
+			holders[pool] = key
+		}
+	}
+	for pool, holder := range grants {
+		if !existing[holder] {
+			delete(grants, pool)
+			continue
+		}
+		holders[pool] = holder
+	}
+	return holders
+}
+
+// lockPoolMembers returns the control planes in any of the lock pools.
+func lockPoolMembers(controlPlanes []*rkev1.RKEControlPlane, pools []string) []*rkev1.RKEControlPlane {
+	var members []*rkev1.RKEControlPlane
+	for _, cp := range controlPlanes {
+		for _, pool := range normalizeLockPools(cp.Spec.LockPools) {
+			if slice.ContainsString(pools, pool) {
+				members = append(members, cp)
+				break
+			}
+		}
+	}
+	return members
+}
+
+// normalizeLockPools returns the sorted, unique and non-empty lock pools.
+func normalizeLockPools(pools []string) []string {
+	var result []string
+	for _, pool := range pools {
+		pool = strings.TrimSpace(pool)
+		if pool != "" && !slice.ContainsString(result, pool) {
+			result = append(result, pool)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,108 @@
+package planner
+
+import (
+	"testing"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newLockPoolControlPlane(name string, pools, held []string) *rkev1.RKEControlPlane {
+	cp := &rkev1.RKEControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "fleet-default",
+			Name:      name,
+		},
+	}
+	cp.Spec.LockPools = pools
+	cp.Status.LockPools = held
+	return cp
+}
+
+func TestNormalizeLockPools(t *testing.T) {
+	assert.Nil(t, normalizeLockPools(nil))
+	assert.Nil(t, normalizeLockPools([]string{"", " "}))
+	assert.Equal(t, []string{"harvester-a", "vsphere-b"}, normalizeLockPools([]string{"vsphere-b", " harvester-a", "vsphere-b", ""}))
+}
+
+func TestLockPoolHolders(t *testing.T) {
+	deleting := newLockPoolControlPlane("deleting", []string{"vsphere"}, []string{"vsphere"})
+	now := metav1.Now()
+	deleting.DeletionTimestamp = &now
+
+	tests := []struct {
+		name           string
+		controlPlanes  []*rkev1.RKEControlPlane
+		grants         map[string]string
+		expected       map[string]string
+		expectedGrants map[string]string
+	}{
+		{
+			name: "no holders",
+			controlPlanes: []*rkev1.RKEControlPlane{
+				newLockPoolControlPlane("a", []string{"harvester"}, nil),
+			},
+			grants:         map[string]string{},
+			expected:       map[string]string{},
+			expectedGrants: map[string]string{},
+		},
+		{
+			name: "held in status",
+			controlPlanes: []*rkev1.RKEControlPlane{
+				newLockPoolControlPlane("a", []string{"harvester"}, []string{"harvester"}),
+				newLockPoolControlPlane("b", []string{"harvester"}, nil),
+			},
+			grants:         map[string]string{},
+			expected:       map[string]string{"harvester": "fleet-default/a"},
+			expectedGrants: map[string]string{},
+		},
+		{
+			name: "granted but not yet in status",
+			controlPlanes: []*rkev1.RKEControlPlane{
+				newLockPoolControlPlane("a", []string{"harvester"}, nil),
+				newLockPoolControlPlane("b", []string{"harvester"}, nil),
+			},
+			grants:         map[string]string{"harvester": "fleet-default/b"},
+			expected:       map[string]string{"harvester": "fleet-default/b"},
+			expectedGrants: map[string]string{"harvester": "fleet-default/b"},
+		},
+		{
+			name: "grants of removed clusters are dropped",
+			controlPlanes: []*rkev1.RKEControlPlane{
+				newLockPoolControlPlane("a", []string{"harvester"}, nil),
+			},
+			grants:         map[string]string{"harvester": "fleet-default/removed"},
+			expected:       map[string]string{},
+			expectedGrants: map[string]string{},
+		},
+		{
+			name: "deleting clusters don't hold pools",
+			controlPlanes: []*rkev1.RKEControlPlane{
+				deleting,
+				newLockPoolControlPlane("a", []string{"vsphere"}, nil),
+			},
+			grants:         map[string]string{"vsphere": "fleet-default/deleting"},
+			expected:       map[string]string{},
+			expectedGrants: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, lockPoolHolders(tt.controlPlanes, tt.grants))
+			assert.Equal(t, tt.expectedGrants, tt.grants)
+		})
+	}
+}
+
+func TestLockPoolMembers(t *testing.T) {
+	a := newLockPoolControlPlane("a", []string{"harvester", "vsphere"}, nil)
+	b := newLockPoolControlPlane("b", []string{"vsphere"}, nil)
+	c := newLockPoolControlPlane("c", nil, nil)
+	controlPlanes := []*rkev1.RKEControlPlane{a, b, c}
+
+	assert.Equal(t, []*rkev1.RKEControlPlane{a, b}, lockPoolMembers(controlPlanes, []string{"vsphere"}))
+	assert.Equal(t, []*rkev1.RKEControlPlane{a}, lockPoolMembers(controlPlanes, []string{"harvester"}))
+	assert.Nil(t, lockPoolMembers(controlPlanes, []string{"other"}))
+}
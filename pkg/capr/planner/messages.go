@@ -28,6 +28,14 @@ func detailedMessage(machines []string, messages map[string][]string) string {
 	return ""
 }
 
+// machineWaitingReason returns the reason a machine is waiting for, the given reason if the machine has none.
+func machineWaitingReason(machineName, reason string, reasons map[string]string) string {
+	if machineReason := reasons[machineName]; machineReason != "" {
+		return machineReason
+	}
+	return reason
+}
+
 // removeReconciledCondition removes the condition "Reconciled" from a CAPI machine object so that messages are not
 // duplicated during summarization.
 func removeReconciledCondition(machine *capi.Machine) *capi.Machine {
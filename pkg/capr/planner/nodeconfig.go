@@ -0,0 +1,134 @@
+package planner
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+)
+
+const (
+	nodeConfigInstructionName = "node-config"
+	// nodeConfigPeriodSeconds is how often the DNS and time configuration of the nodes is restored when it drifts.
+	nodeConfigPeriodSeconds = 300
+
+	resolvedConfigPath       = "/etc/systemd/resolved.conf.d/50-rancher.conf"
+	networkManagerConfigPath = "/etc/NetworkManager/conf.d/50-rancher-dns.conf"
+	chronyConfigPath         = "/etc/rancher/agent/chrony.conf"
+)
+
+// nodeConfigValue matches the hostnames, IPv4 and IPv6 addresses and domains rendered into the node config script.
+var nodeConfigValue = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9.:_-]*$`)
+
+// nodeConfigScriptHeader writes a file only if its content changed, returning false if it didn't.
+const nodeConfigScriptHeader = `set -e
+write_file() {
+	if [ -f "$1" ] && [ "$(cat "$1")" = "$2" ]; then
+		return 1
+	fi
+	mkdir -p "$(dirname "$1")"
+	printf '%s\n' "$2" > "$1"
+}
+`
+
+// addNodeConfigPeriodicInstruction adds the periodic instruction configuring the DNS resolver and the time
+// synchronization of the node, which rewrites the configuration and restarts the services only when it drifted.
+func addNodeConfigPeriodicInstruction(nodePlan plan.NodePlan, controlPlane *rkev1.RKEControlPlane, entry *planEntry) (plan.NodePlan, error) {
+	if windows(entry) || (controlPlane.Spec.NodeDNS == nil && controlPlane.Spec.NodeTime == nil) {
+		return nodePlan, nil
+	}
+	script, err := nodeConfigScript(controlPlane.Spec.NodeDNS, controlPlane.Spec.NodeTime)
+	if err != nil {
+		return nodePlan, err
+	}
+	if script == "" {
+		return nodePlan, nil
+	}
+	nodePlan.PeriodicInstructions = append(nodePlan.PeriodicInstructions, plan.PeriodicInstruction{
+		Name:          nodeConfigInstructionName,
+		Command:       "sh",
+		Args:          []string{"-c", script},
+		PeriodSeconds: nodeConfigPeriodSeconds,
+	})
+	return nodePlan, nil
+}
+
+// nodeConfigScript returns the script configuring the DNS resolver and the time synchronization of a node, empty if
+// there is nothing to configure.
+func nodeConfigScript(dns *rkev1.NodeDNS, time *rkev1.NodeTime) (string, error) {
+	var script strings.Builder
+	if dns != nil && (len(dns.Servers) > 0 || len(dns.SearchDomains) > 0) {
+		if err := validateNodeConfigValues("DNS server", dns.Servers); err != nil {
+			return "", err
+		}
+		if err := validateNodeConfigValues("DNS search domain", dns.SearchDomains); err != nil {
+			return "", err
+		}
+		resolved := "[Resolve]"
+		networkManager := "[global-dns]"
+		if len(dns.SearchDomains) > 0 {
+			resolved += "\nDomains=" + strings.Join(dns.SearchDomains, " ")
+			networkManager += "\nsearches=" + strings.Join(dns.SearchDomains, ",")
+		}
+		if len(dns.Servers) > 0 {
+			resolved += "\nDNS=" + strings.Join(dns.Servers, " ")
+			networkManager += "\n[global-dns-domain-*]\nservers=" + strings.Join(dns.Servers, ",")
+		}
+		fmt.Fprintf(&script, `if systemctl is-active --quiet systemd-resolved && write_file %s '%s'; then
+	systemctl restart systemd-resolved
+fi
+if systemctl is-active --quiet NetworkManager && write_file %s '%s'; then
+	systemctl reload NetworkManager
+fi
+`, resolvedConfigPath, resolved, networkManagerConfigPath, networkManager)
+	}
+
+	if time != nil && len(time.NTPServers) > 0 {
+		if err := validateNodeConfigValues("NTP server", time.NTPServers); err != nil {
+			return "", err
+		}
+		chrony := "makestep 1 -1"
+		for _, server := range time.NTPServers {
+			chrony += "\nserver " + server + " iburst"
+		}
+		fmt.Fprintf(&script, `chrony_conf=""
+for conf in /etc/chrony/chrony.conf /etc/chrony.conf; do
+	if [ -f "$conf" ]; then
+		chrony_conf="$conf"
+		break
+	fi
+done
+if [ -z "$chrony_conf" ]; then
+	echo "chrony is not installed" >&2
+	exit 1
+fi
+restart_chrony=""
+if write_file %[1]s '%[2]s'; then
+	restart_chrony=true
+fi
+if ! grep -qx 'include %[1]s' "$chrony_conf"; then
+	printf '\ninclude %[1]s\n' >> "$chrony_conf"
+	restart_chrony=true
+fi
+if [ -n "$restart_chrony" ]; then
+	systemctl restart chronyd 2>/dev/null || systemctl restart chrony
+fi
+`, chronyConfigPath, chrony)
+	}
+
+	if script.Len() == 0 {
+		return "", nil
+	}
+	return nodeConfigScriptHeader + script.String(), nil
+}
+
+func validateNodeConfigValues(kind string, values []string) error {
+	for _, value := range values {
+		if !nodeConfigValue.MatchString(value) {
+			return fmt.Errorf("invalid %s [%s]", kind, value)
+		}
+	}
+	return nil
+}
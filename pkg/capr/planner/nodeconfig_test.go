@@ -0,0 +1,79 @@
+package planner
+
+import (
+	"testing"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_nodeConfigScript(t *testing.T) {
+	tests := []struct {
+		name        string
+		dns         *rkev1.NodeDNS
+		time        *rkev1.NodeTime
+		contains    []string
+		notContains []string
+		empty       bool
+		expectErr   bool
+	}{
+		{
+			name:  "nothing to configure",
+			dns:   &rkev1.NodeDNS{},
+			time:  &rkev1.NodeTime{},
+			empty: true,
+		},
+		{
+			name: "dns servers and search domains",
+			dns: &rkev1.NodeDNS{
+				Servers:       []string{"10.0.0.2", "fd00::2"},
+				SearchDomains: []string{"corp.example.com", "example.com"},
+			},
+			contains: []string{
+				"write_file " + resolvedConfigPath + " '[Resolve]\nDomains=corp.example.com example.com\nDNS=10.0.0.2 fd00::2'",
+				"searches=corp.example.com,example.com\n[global-dns-domain-*]\nservers=10.0.0.2,fd00::2'",
+				"systemctl restart systemd-resolved",
+			},
+			notContains: []string{"chrony"},
+		},
+		{
+			name: "ntp servers",
+			time: &rkev1.NodeTime{NTPServers: []string{"ntp1.example.com", "10.0.0.3"}},
+			contains: []string{
+				"write_file " + chronyConfigPath + " 'makestep 1 -1\nserver ntp1.example.com iburst\nserver 10.0.0.3 iburst'",
+				"include " + chronyConfigPath,
+			},
+			notContains: []string{"resolved"},
+		},
+		{
+			name:      "invalid dns server",
+			dns:       &rkev1.NodeDNS{Servers: []string{"10.0.0.2; reboot"}},
+			expectErr: true,
+		},
+		{
+			name:      "invalid ntp server",
+			time:      &rkev1.NodeTime{NTPServers: []string{"'ntp'"}},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script, err := nodeConfigScript(tt.dns, tt.time)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if tt.empty {
+				assert.Empty(t, script)
+				return
+			}
+			for _, s := range tt.contains {
+				assert.Contains(t, script, s)
+			}
+			for _, s := range tt.notContains {
+				assert.NotContains(t, script, s)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package planner
+
+import (
+	"fmt"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/wrangler/pkg/data"
+	"github.com/rancher/wrangler/pkg/data/convert"
+)
+
+const (
+	kubeletArg   = "kubelet-arg"
+	kubeProxyArg = "kube-proxy-arg"
+
+	coreDNSChart               = "rke2-coredns"
+	defaultNodeLocalDNSAddress = "169.254.20.10"
+)
+
+func nodeLocalDNSEnabled(controlPlane *rkev1.RKEControlPlane) bool {
+	return controlPlane.Spec.NodeLocalDNS != nil && controlPlane.Spec.NodeLocalDNS.Enabled &&
+		capr.GetRuntime(controlPlane.Spec.KubernetesVersion) == capr.RuntimeRKE2
+}
+
+func nodeLocalDNSAddress(controlPlane *rkev1.RKEControlPlane) string {
+	if controlPlane.Spec.NodeLocalDNS.IPAddress != "" {
+		return controlPlane.Spec.NodeLocalDNS.IPAddress
+	}
+	return defaultNodeLocalDNSAddress
+}
+
+// kubeProxyIPVS returns true if kube-proxy runs in ipvs mode with the rendered config.
+func kubeProxyIPVS(config map[string]interface{}) bool {
+	return getArgValue(config[kubeProxyArg], "proxy-mode", "=") == "ipvs"
+}
+
+// addNodeLocalDNSConfig points the kubelet to the NodeLocal DNSCache address when kube-proxy runs in ipvs mode. In
+// iptables mode, the cache intercepts the traffic to the cluster DNS service so the kubelet is left untouched. A
+// cluster-dns set by the user is never overridden.
+func addNodeLocalDNSConfig(config map[string]interface{}, controlPlane *rkev1.RKEControlPlane) {
+	if !nodeLocalDNSEnabled(controlPlane) || !kubeProxyIPVS(config) {
+		return
+	}
+	if getArgValue(config[kubeletArg], "cluster-dns", "=") != "" {
+		return
+	}
+	config[kubeletArg] = append(convert.ToStringSlice(config[kubeletArg]), fmt.Sprintf("cluster-dns=%s", nodeLocalDNSAddress(controlPlane)))
+}
+
+// addNodeLocalDNSChartValues returns the chart values with the coredns chart configured to deploy NodeLocal DNSCache
+// in the kube-proxy mode of the node. The passed in chart values are not modified.
+func addNodeLocalDNSChartValues(chartValues map[string]interface{}, controlPlane *rkev1.RKEControlPlane, ipvs bool) map[string]interface{} {
+	if !nodeLocalDNSEnabled(controlPlane) {
+		return chartValues
+	}
+
+	return data.MergeMaps(chartValues, map[string]interface{}{
+		coreDNSChart: map[string]interface{}{
+			"nodelocal": map[string]interface{}{
+				"enabled":    true,
+				"ipvs":       ipvs,
+				"ip_address": nodeLocalDNSAddress(controlPlane),
+			},
+		},
+	})
+}
@@ -0,0 +1,104 @@
+package planner
+
+import (
+	"testing"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func controlPlaneWithNodeLocalDNS(version string, nodeLocalDNS *rkev1.NodeLocalDNS) *rkev1.RKEControlPlane {
+	cp := &rkev1.RKEControlPlane{}
+	cp.Spec.KubernetesVersion = version
+	cp.Spec.NodeLocalDNS = nodeLocalDNS
+	return cp
+}
+
+func Test_addNodeLocalDNSConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		version      string
+		nodeLocalDNS *rkev1.NodeLocalDNS
+		config       map[string]interface{}
+		expected     interface{}
+	}{
+		{
+			name:         "iptables mode",
+			version:      "v1.25.9+rke2r1",
+			nodeLocalDNS: &rkev1.NodeLocalDNS{Enabled: true},
+			config:       map[string]interface{}{kubeletArg: []interface{}{"max-pods=200"}},
+			expected:     []interface{}{"max-pods=200"},
+		},
+		{
+			name:         "ipvs mode",
+			version:      "v1.25.9+rke2r1",
+			nodeLocalDNS: &rkev1.NodeLocalDNS{Enabled: true},
+			config: map[string]interface{}{
+				kubeProxyArg: []interface{}{"proxy-mode=ipvs"},
+				kubeletArg:   []interface{}{"max-pods=200"},
+			},
+			expected: []string{"max-pods=200", "cluster-dns=169.254.20.10"},
+		},
+		{
+			name:         "ipvs mode with custom address",
+			version:      "v1.25.9+rke2r1",
+			nodeLocalDNS: &rkev1.NodeLocalDNS{Enabled: true, IPAddress: "169.254.0.53"},
+			config:       map[string]interface{}{kubeProxyArg: "proxy-mode=ipvs"},
+			expected:     []string{"cluster-dns=169.254.0.53"},
+		},
+		{
+			name:         "ipvs mode with cluster-dns set by the user",
+			version:      "v1.25.9+rke2r1",
+			nodeLocalDNS: &rkev1.NodeLocalDNS{Enabled: true},
+			config: map[string]interface{}{
+				kubeProxyArg: []interface{}{"proxy-mode=ipvs"},
+				kubeletArg:   []interface{}{"cluster-dns=10.43.0.10"},
+			},
+			expected: []interface{}{"cluster-dns=10.43.0.10"},
+		},
+		{
+			name:    "disabled",
+			version: "v1.25.9+rke2r1",
+			config:  map[string]interface{}{kubeProxyArg: []interface{}{"proxy-mode=ipvs"}},
+		},
+		{
+			name:         "k3s",
+			version:      "v1.25.9+k3s1",
+			nodeLocalDNS: &rkev1.NodeLocalDNS{Enabled: true},
+			config:       map[string]interface{}{kubeProxyArg: []interface{}{"proxy-mode=ipvs"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addNodeLocalDNSConfig(tt.config, controlPlaneWithNodeLocalDNS(tt.version, tt.nodeLocalDNS))
+			assert.Equal(t, tt.expected, tt.config[kubeletArg])
+		})
+	}
+}
+
+func Test_addNodeLocalDNSChartValues(t *testing.T) {
+	chartValues := map[string]interface{}{
+		"rke2-coredns": map[string]interface{}{
+			"resources": map[string]interface{}{"limits": map[string]interface{}{"cpu": "100m"}},
+		},
+		"rke2-ingress-nginx": map[string]interface{}{},
+	}
+
+	result := addNodeLocalDNSChartValues(chartValues, controlPlaneWithNodeLocalDNS("v1.25.9+rke2r1", &rkev1.NodeLocalDNS{Enabled: true}), true)
+	assert.Equal(t, map[string]interface{}{
+		"rke2-coredns": map[string]interface{}{
+			"resources": map[string]interface{}{"limits": map[string]interface{}{"cpu": "100m"}},
+			"nodelocal": map[string]interface{}{
+				"enabled":    true,
+				"ipvs":       true,
+				"ip_address": "169.254.20.10",
+			},
+		},
+		"rke2-ingress-nginx": map[string]interface{}{},
+	}, result)
+	assert.NotContains(t, chartValues["rke2-coredns"], "nodelocal", "chart values of the control plane must not be modified")
+
+	disabled := controlPlaneWithNodeLocalDNS("v1.25.9+rke2r1", nil)
+	assert.Equal(t, chartValues, addNodeLocalDNSChartValues(chartValues, disabled, false))
+}
@@ -11,6 +11,7 @@
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/moby/locker"
@@ -19,17 +20,18 @@
 	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
 	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
 	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/rancher/pkg/capr/planencryption"
 	"github.com/rancher/rancher/pkg/controllers/capr/managesystemagent"
 	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
 	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
 	ranchercontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
 	rkecontrollers "github.com/rancher/rancher/pkg/generated/controllers/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/wrangler"
 	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
 	"github.com/rancher/wrangler/pkg/name"
 	"github.com/rancher/wrangler/pkg/randomtoken"
 	"github.com/rancher/wrangler/pkg/summary"
-	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierror "k8s.io/apimachinery/pkg/api/errors"
@@ -41,6 +43,8 @@
 	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
+var logger = logging.Logger(logging.Planner)
+
 const (
 	clusterRegToken = "clusterRegToken"
 
@@ -123,6 +127,7 @@ type Planner struct {
 	managementClusters            mgmtcontrollers.ClusterCache
 	rancherClusterCache           ranchercontrollers.ClusterCache
 	locker                        locker.Locker
+	lockPools                     lockPools
 	etcdS3Args                    s3Args
 	retrievalFunctions            InfoFunctions
 }
@@ -136,37 +141,72 @@ type InfoFunctions struct {
 	SystemPodLabelSelectors func(plane *rkev1.RKEControlPlane) []string
 }
 
+// Clients are the clients and caches the planner reads and updates the objects of the clusters it provisions with.
+type Clients struct {
+	Secrets                       corecontrollers.SecretController
+	ConfigMapCache                corecontrollers.ConfigMapCache
+	Machines                      capicontrollers.MachineController
+	CAPIClusters                  capicontrollers.ClusterController
+	RKEBootstrap                  rkecontrollers.RKEBootstrapController
+	RKEControlPlanes              rkecontrollers.RKEControlPlaneController
+	ETCDSnapshotCache             rkecontrollers.ETCDSnapshotCache
+	ClusterRegistrationTokenCache mgmtcontrollers.ClusterRegistrationTokenCache
+	ManagementClusterCache        mgmtcontrollers.ClusterCache
+	ProvisioningClusterCache      ranchercontrollers.ClusterCache
+}
+
 func New(ctx context.Context, clients *wrangler.Context, functions InfoFunctions) *Planner {
-	clients.Mgmt.ClusterRegistrationToken().Cache().AddIndexer(clusterRegToken, func(obj *v3.ClusterRegistrationToken) ([]string, error) {
+	return NewForClients(ctx, Clients{
+		Secrets:                       clients.Core.Secret(),
+		ConfigMapCache:                clients.Core.ConfigMap().Cache(),
+		Machines:                      clients.CAPI.Machine(),
+		CAPIClusters:                  clients.CAPI.Cluster(),
+		RKEBootstrap:                  clients.RKE.RKEBootstrap(),
+		RKEControlPlanes:              clients.RKE.RKEControlPlane(),
+		ETCDSnapshotCache:             clients.RKE.ETCDSnapshot().Cache(),
+		ClusterRegistrationTokenCache: clients.Mgmt.ClusterRegistrationToken().Cache(),
+		ManagementClusterCache:        clients.Mgmt.Cluster().Cache(),
+		ProvisioningClusterCache:      clients.Provisioning.Cluster().Cache(),
+	}, planencryption.NewEncryptor(clients), functions)
+}
+
+// NewForClients returns a planner using the given clients rather than those of the management cluster, such as the
+// in-memory ones of the planner simulator. The encryptor may only be nil if no control plane encrypts its plan secrets.
+func NewForClients(ctx context.Context, clients Clients, encryptor *planencryption.Encryptor, functions InfoFunctions) *Planner {
+	clients.ClusterRegistrationTokenCache.AddIndexer(clusterRegToken, func(obj *v3.ClusterRegistrationToken) ([]string, error) {
 		return []string{obj.Spec.ClusterName}, nil
 	})
-	store := NewStore(clients.Core.Secret(),
-		clients.CAPI.Machine().Cache())
+	store := NewStore(clients.Secrets,
+		clients.Machines.Cache(),
+		clients.RKEControlPlanes.Cache(),
+		encryptor)
 	return &Planner{
 		ctx:                           ctx,
 		store:                         store,
-		machines:                      clients.CAPI.Machine(),
-		machinesCache:                 clients.CAPI.Machine().Cache(),
-		secretClient:                  clients.Core.Secret(),
-		secretCache:                   clients.Core.Secret().Cache(),
-		configMapCache:                clients.Core.ConfigMap().Cache(),
-		clusterRegistrationTokenCache: clients.Mgmt.ClusterRegistrationToken().Cache(),
-		capiClient:                    clients.CAPI.Cluster(),
-		capiClusters:                  clients.CAPI.Cluster().Cache(),
-		managementClusters:            clients.Mgmt.Cluster().Cache(),
-		rancherClusterCache:           clients.Provisioning.Cluster().Cache(),
-		rkeControlPlanes:              clients.RKE.RKEControlPlane(),
-		rkeBootstrap:                  clients.RKE.RKEBootstrap(),
-		rkeBootstrapCache:             clients.RKE.RKEBootstrap().Cache(),
-		etcdSnapshotCache:             clients.RKE.ETCDSnapshot().Cache(),
+		machines:                      clients.Machines,
+		machinesCache:                 clients.Machines.Cache(),
+		secretClient:                  clients.Secrets,
+		secretCache:                   clients.Secrets.Cache(),
+		configMapCache:                clients.ConfigMapCache,
+		clusterRegistrationTokenCache: clients.ClusterRegistrationTokenCache,
+		capiClient:                    clients.CAPIClusters,
+		capiClusters:                  clients.CAPIClusters.Cache(),
+		managementClusters:            clients.ManagementClusterCache,
+		rancherClusterCache:           clients.ProvisioningClusterCache,
+		rkeControlPlanes:              clients.RKEControlPlanes,
+		rkeBootstrap:                  clients.RKEBootstrap,
+		rkeBootstrapCache:             clients.RKEBootstrap.Cache(),
+		etcdSnapshotCache:             clients.ETCDSnapshotCache,
 		etcdS3Args: s3Args{
-			secretCache: clients.Core.Secret().Cache(),
+			secretCache: clients.Secrets.Cache(),
 		},
 		retrievalFunctions: functions,
 	}
 }
 
-func (p *Planner) setMachineConditionStatus(clusterPlan *plan.Plan, machineNames []string, messagePrefix string, messages map[string][]string) error {
+// setMachineConditionStatus sets the Reconciled condition of the machines, to Unknown with their waiting reason and their
+// messages if they have messages, to True otherwise. The reason of a machine defaults to the given reason.
+func (p *Planner) setMachineConditionStatus(clusterPlan *plan.Plan, machineNames []string, reason, messagePrefix string, messages map[string][]string, reasons map[string]string) error {
 	var waiting bool
 	for _, machineName := range machineNames {
 		machine := clusterPlan.Machines[machineName]
@@ -182,11 +222,12 @@ func (p *Planner) setMachineConditionStatus(clusterPlan *plan.Plan, machineNames
 		machine = machine.DeepCopy()
 		if message := messages[machineName]; len(message) > 0 {
 			msg := strings.Join(message, ", ")
+			machineReason := machineWaitingReason(machineName, reason, reasons)
 			waiting = true
-			if capr.Reconciled.GetMessage(machine) == msg {
+			if capr.Reconciled.GetMessage(machine) == msg && capr.Reconciled.GetReason(machine) == machineReason {
 				continue
 			}
-			conditions.MarkUnknown(machine, capi.ConditionType(capr.Reconciled), "Waiting", msg)
+			conditions.MarkUnknown(machine, capi.ConditionType(capr.Reconciled), machineReason, msg)
 		} else if !capr.Reconciled.IsTrue(machine) {
 			// Since there is no status message, then the condition should be set to true.
 			conditions.MarkTrue(machine, capi.ConditionType(capr.Reconciled))
@@ -204,19 +245,34 @@ func (p *Planner) setMachineConditionStatus(clusterPlan *plan.Plan, machineNames
 	}
 
 	if waiting {
-		return errWaiting(messagePrefix + atMostThree(machineNames) + detailedMessage(machineNames, messages))
+		if len(machineNames) == 1 {
+			reason = machineWaitingReason(machineNames[0], reason, reasons)
+		}
+		return newErrWaiting(reason, messagePrefix+atMostThree(machineNames)+detailedMessage(machineNames, messages))
 	}
 	return nil
 }
 
 func (p *Planner) Process(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPlaneStatus) (rkev1.RKEControlPlaneStatus, error) {
-	logrus.Debugf("[planner] rkecluster %s/%s: attempting to lock %s for processing", cp.Namespace, cp.Name, string(cp.UID))
+	logger.Debugf("[planner] rkecluster %s/%s: attempting to lock %s for processing", cp.Namespace, cp.Name, string(cp.UID))
 	p.locker.Lock(string(cp.UID))
 	defer func(namespace, name, uid string) {
-		logrus.Debugf("[planner] rkecluster %s/%s: unlocking %s", namespace, name, uid)
+		logger.Debugf("[planner] rkecluster %s/%s: unlocking %s", namespace, name, uid)
 		_ = p.locker.Unlock(uid)
 	}(cp.Namespace, cp.Name, string(cp.UID))
 
+	status.PlannerBehaviors = plannerBehaviors(cp)
+	// The plans read the planner behaviors resolved for this run from the status of the control plane.
+	if !equality.Semantic.DeepEqual(cp.Status.PlannerBehaviors, status.PlannerBehaviors) {
+		cp = cp.DeepCopy()
+		cp.Status.PlannerBehaviors = status.PlannerBehaviors
+	}
+	status = setPlannerBehaviorsDisabled(status, cp)
+
+	if err := p.store.validateEncryption(cp); err != nil {
+		return status, fmt.Errorf("rkecluster %s/%s: %w", cp.Namespace, cp.Name, err)
+	}
+
 	currentVersion, err := semver.NewVersion(cp.Spec.KubernetesVersion)
 	if err != nil {
 		return status, fmt.Errorf("rkecluster %s/%s: error semver parsing kubernetes version %s: %v", cp.Namespace, cp.Name, cp.Spec.KubernetesVersion, err)
@@ -224,19 +280,19 @@ func (p *Planner) Process(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPlan
 
 	releaseData := p.retrievalFunctions.ReleaseData(p.ctx, cp)
 	if releaseData == nil {
-		return status, errWaitingf("%s/%s: releaseData nil for version %s", cp.Namespace, cp.Name, cp.Spec.KubernetesVersion)
+		return status, errWaitingf(capr.WaitingReason, "%s/%s: releaseData nil for version %s", cp.Namespace, cp.Name, cp.Spec.KubernetesVersion)
 	}
 
 	capiCluster, err := capr.GetOwnerCAPICluster(cp, p.capiClusters)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return status, errWaiting("CAPI cluster does not exist")
+			return status, newErrWaiting(capr.WaitingForInfrastructure, "CAPI cluster does not exist")
 		}
 		return status, err
 	}
 
 	if capiCluster == nil {
-		return status, errWaiting("CAPI cluster does not exist")
+		return status, newErrWaiting(capr.WaitingForInfrastructure, "CAPI cluster does not exist")
 	}
 
 	if !capiCluster.DeletionTimestamp.IsZero() {
@@ -245,15 +301,15 @@ func (p *Planner) Process(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPlan
 		if capiannotations.IsPaused(capiCluster, cp) {
 			err = p.pauseCAPICluster(cp, false)
 			if err != nil {
-				logrus.Errorf("error unpausing CAPI cluster during deletion: %s", err)
+				logger.Errorf("error unpausing CAPI cluster during deletion: %s", err)
 			}
 		}
-		logrus.Infof("[planner] %s/%s: reconciliation stopped: CAPI cluster is deleting", cp.Namespace, cp.Name)
+		logger.Infof("[planner] %s/%s: reconciliation stopped: CAPI cluster is deleting", cp.Namespace, cp.Name)
 		return status, nil
 	}
 
 	if !capiCluster.Status.InfrastructureReady {
-		return status, errWaiting("waiting for infrastructure ready")
+		return status, newErrWaiting(capr.WaitingForInfrastructure, "waiting for infrastructure ready")
 	}
 
 	plan, anyPlansDelivered, err := p.store.Load(capiCluster, cp)
@@ -261,6 +317,9 @@ func (p *Planner) Process(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPlan
 		return status, err
 	}
 
+	status.EmbeddedRegistryNodes = embeddedRegistryNodes(cp, plan)
+	status = setTLSSANsPending(status, plan)
+
 	// Check for cluster sanity to ensure we can properly deliver plans to this cluster.
 	if !clusterIsSane(plan) {
 		// Set the Stable condition on the controlplane to False. This will be used to indicate that the Ready condition
@@ -274,13 +333,13 @@ func (p *Planner) Process(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPlan
 		if status.Initialized || status.Ready {
 			status.Initialized = false
 			status.Ready = false
-			logrus.Debugf("[planner] rkecluster %s/%s: setting controlplane ready/initialized to false as cluster was not sane", cp.Namespace, cp.Name)
-			return status, errWaitingf("uninitializing rkecontrolplane %s/%s", cp.Namespace, cp.Name)
+			logger.Debugf("[planner] rkecluster %s/%s: setting controlplane ready/initialized to false as cluster was not sane", cp.Namespace, cp.Name)
+			return status, errWaitingf(capr.WaitingForMachineRegistration, "uninitializing rkecontrolplane %s/%s", cp.Namespace, cp.Name)
 		}
 
 		// Uninitialize the CAPI ClusterControlPlaneInitialized condition so that CAPI controllers don't get hung up and will take ownership of new RKEBootstraps (amongst other objects)
 		if err := p.ensureCAPIClusterControlPlaneInitializedFalse(cp); err != nil {
-			return status, errWaitingf("uninitializing CAPI cluster: %v", err)
+			return status, errWaitingf(capr.WaitingForMachineRegistration, "uninitializing CAPI cluster: %v", err)
 		}
 
 		// Collect all nodes that are etcd and deleting. At this point, if we have any etcd nodes left in the cluster,
@@ -289,9 +348,9 @@ func (p *Planner) Process(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPlan
 		if err != nil {
 			return status, err
 		} else if etcdDeleting != 0 {
-			return status, errWaiting("waiting for all etcd machines to be deleted")
+			return status, newErrWaiting(capr.WaitingForMachineDeletion, "waiting for all etcd machines to be deleted")
 		}
-		return status, errWaiting("waiting for at least one control plane, etcd, and worker node to be registered")
+		return status, newErrWaiting(capr.WaitingForMachineRegistration, "waiting for at least one control plane, etcd, and worker node to be registered")
 	}
 
 	capr.Provisioned.True(&status)
@@ -313,7 +372,7 @@ func (p *Planner) Process(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPlan
 
 	lowestKubelet := getLowestMachineKubeletVersion(plan)
 	if lowestKubelet != nil {
-		logrus.Debugf("rkecluster %s/%s: lowest detected Kubelet version for cluster was: %s", cp.Namespace, cp.Name, lowestKubelet.String())
+		logger.Debugf("rkecluster %s/%s: lowest detected Kubelet version for cluster was: %s", cp.Namespace, cp.Name, lowestKubelet.String())
 		if err := blockProgressForSUCPSPIf125(fmt.Sprintf("[planner] rkecluster %s/%s:", cp.Namespace, cp.Name), status, currentVersion, lowestKubelet); err != nil {
 			return status, err
 		}
@@ -327,10 +386,13 @@ func (p *Planner) Process(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPlan
 		return status, err
 	}
 
+	// Neither rotation is in progress, release the lock pools held for them.
+	status = p.releaseLockPools(cp, status)
+
 	// pausing the control plane only affects machine reconciliation: etcd snapshot/restore, encryption key & cert
 	// rotation are not interruptable processes, and therefore must always be completed when requested
 	if capiannotations.IsPaused(capiCluster, cp) {
-		return status, errWaitingf("CAPI cluster or RKEControlPlane is paused")
+		return status, newErrWaiting(capr.WaitingForPause, "CAPI cluster or RKEControlPlane is paused")
 	}
 
 	// In the case where the cluster has been bootstrapped and no plans have been
@@ -339,18 +401,45 @@ func (p *Planner) Process(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPlan
 	if (capr.Bootstrapped.IsTrue(&status) || len(collect(plan, roleOr(hasJoinURL, hasJoinedTo))) != 0) && len(collect(plan, roleAnd(isEtcd, anyPlanDataExists))) == 0 {
 		// deliver an etcd snapshot list command to the etcd nodes.
 		capr.Stable.False(&status) // Set the Stable condition on the controlplane to False. This will be used to hide the v3.Cluster Ready condition from the UI.
-		return status, errWaiting("rkecontrolplane was already initialized but no etcd machines exist that have plans, indicating the etcd plane has been entirely replaced. Restoration from etcd snapshot is required.")
+		return status, newErrWaiting(capr.WaitingForEtcdRestore, "rkecontrolplane was already initialized but no etcd machines exist that have plans, indicating the etcd plane has been entirely replaced. Restoration from etcd snapshot is required.")
 	}
 
 	return p.fullReconcile(cp, status, clusterSecretTokens, plan, false)
 }
 
 func (p *Planner) fullReconcile(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPlaneStatus, clusterSecretTokens plan.Secret, plan *plan.Plan, ignoreDrainAndConcurrency bool) (rkev1.RKEControlPlaneStatus, error) {
+	if err := validateInitNodeSelection(cp.Spec.InitNodeSelection); err != nil {
+		return status, err
+	}
+	if err := validateAdmissionConfiguration(cp.Spec.AdmissionConfiguration); err != nil {
+		return status, err
+	}
+	now := time.Now()
+	status, systemChartValuesWait, err := updateSystemChartValues(cp, status, plan, now)
+	if err != nil {
+		return status, err
+	}
+	if systemChartValuesWait > 0 {
+		p.rkeControlPlanes.EnqueueAfter(cp.Namespace, cp.Name, systemChartValuesWait)
+	}
+	// The plans hold back the default values of the system charts as the status of the control plane does.
+	if systemChartValuesHeld(&cp.Status) != systemChartValuesHeld(&status) {
+		cp = cp.DeepCopy()
+		cp.Status.SystemChartValues = status.SystemChartValues.DeepCopy()
+	}
+	status = trackInitNodeAvailability(status, plan, now)
+	// The init node isn't held back while etcd is restored, as the restore designates its own init node.
+	if wait := initNodeReelectionWait(cp, status, plan, now); wait > 0 && !ignoreDrainAndConcurrency {
+		p.rkeControlPlanes.EnqueueAfter(cp.Namespace, cp.Name, wait)
+		return status, errWaitingf(capr.WaitingForInitNode, "waiting %s for init node %s to become available before electing another init node", wait.Round(time.Second), status.InitNode)
+	}
+
 	// on the first run through, electInitNode will return a `generic.ErrSkip` as it is attempting to wait for the cache to catch up.
 	joinServer, err := p.electInitNode(cp, plan)
 	if err != nil {
 		return status, err
 	}
+	status = recordInitNodeElection(status, plan, now)
 
 	var (
 		firstIgnoreError                             error
@@ -380,9 +469,9 @@ func (p *Planner) fullReconcile(cp *rkev1.RKEControlPlane, status rkev1.RKEContr
 		if err != nil {
 			return status, err
 		} else if joinServer == "" && firstIgnoreError != nil {
-			return status, errWaiting(firstIgnoreError.Error() + " and join url to be available on bootstrap node")
+			return status, newErrWaiting(capr.WaitingForJoinURL, firstIgnoreError.Error()+" and join url to be available on bootstrap node")
 		} else if joinServer == "" {
-			return status, errWaiting("waiting for join url to be available on bootstrap node")
+			return status, newErrWaiting(capr.WaitingForJoinURL, "waiting for join url to be available on bootstrap node")
 		}
 	}
 
@@ -406,13 +495,13 @@ func (p *Planner) fullReconcile(cp *rkev1.RKEControlPlane, status rkev1.RKEContr
 
 	// If there are any suitable controlplane nodes with join URL annotations
 	if len(collect(plan, roleAnd(isControlPlane, roleAnd(hasJoinURL, roleNot(isDeleting))))) == 0 {
-		return status, errWaiting("waiting for control plane to be available")
+		return status, newErrWaiting(capr.WaitingForControlPlane, "waiting for control plane to be available")
 	}
 
 	if status.Initialized != true || status.Ready != true {
 		status.Initialized = true
 		status.Ready = true
-		return status, errWaiting("marking control plane as initialized and ready")
+		return status, newErrWaiting(capr.WaitingForControlPlane, "marking control plane as initialized and ready")
 	}
 
 	// Process all nodes that are ONLY worker nodes.
@@ -425,7 +514,7 @@ func (p *Planner) fullReconcile(cp *rkev1.RKEControlPlane, status rkev1.RKEContr
 	}
 
 	if firstIgnoreError != nil {
-		return status, errWaiting(firstIgnoreError.Error())
+		return status, newErrWaiting(capr.WaitingForMachines, firstIgnoreError.Error())
 	}
 	return status, nil
 }
@@ -437,7 +526,7 @@ func getLowestMachineKubeletVersion(plan *plan.Plan) *semver.Version {
 		if machine.Status.NodeInfo != nil {
 			ver, err := semver.NewVersion(machine.Status.NodeInfo.KubeletVersion)
 			if err != nil {
-				logrus.Errorf("error while parsing node kubelet version (%s): %v", machine.Status.NodeInfo.KubeletVersion, err)
+				logger.Errorf("error while parsing node kubelet version (%s): %v", machine.Status.NodeInfo.KubeletVersion, err)
 				continue
 			}
 			if lowestVersion == nil {
@@ -462,19 +551,19 @@ func blockProgressForSUCPSPIf125(msgPrefix string, status rkev1.RKEControlPlaneS
 		return fmt.Errorf("%s currentVersion or lowestKubelet was nil", msgPrefix)
 	}
 	if lowestKubelet != nil && !currentVersion.LessThan(managesystemagent.Kubernetes125) && lowestKubelet.LessThan(managesystemagent.Kubernetes125) {
-		logrus.Tracef("%s checking for SystemUpgradeController readiness", msgPrefix)
+		logger.Tracef("%s checking for SystemUpgradeController readiness", msgPrefix)
 		if capr.SystemUpgradeControllerReady.GetStatus(&status) == "" || capr.SystemUpgradeControllerReady.IsFalse(&status) || capr.SystemUpgradeControllerReady.IsUnknown(&status) {
 			if capr.SystemUpgradeControllerReady.GetReason(&status) != "" {
-				return errWaitingf("waiting for system-upgrade-controller helm chart reconciliation: %s", capr.SystemUpgradeControllerReady.GetReason(&status))
+				return errWaitingf(capr.WaitingForSystemUpgradeChart, "waiting for system-upgrade-controller helm chart reconciliation: %s", capr.SystemUpgradeControllerReady.GetReason(&status))
 			}
-			return errWaiting("waiting for system-upgrade-controller helm chart reconciliation")
+			return newErrWaiting(capr.WaitingForSystemUpgradeChart, "waiting for system-upgrade-controller helm chart reconciliation")
 		}
 		if disabled, err := systemUpgradeControllerPSPsDisabled(capr.SystemUpgradeControllerReady.GetMessage(&status)); err == nil {
 			if !disabled {
-				return errWaiting("system-upgrade-controller helm chart has podsecuritypolicy enabled, waiting for helm chart reconciliation")
+				return newErrWaiting(capr.WaitingForSystemUpgradeChart, "system-upgrade-controller helm chart has podsecuritypolicy enabled, waiting for helm chart reconciliation")
 			}
 		} else {
-			return errWaitingf("error occurred while determining whether SUC PSPs were disabled: %v", err)
+			return errWaitingf(capr.WaitingForSystemUpgradeChart, "error occurred while determining whether SUC PSPs were disabled: %v", err)
 		}
 	}
 	return nil
@@ -522,7 +611,7 @@ func calculateJoinURL(cp *rkev1.RKEControlPlane, entry *planEntry, plan *plan.Pl
 	}
 
 	scaled := int(ck) * len(entries) / math.MaxUint32
-	logrus.Debugf("[planner] %s/%s: For machine %s/%s, determined join URL: %s (calculation of index: (%v * %v) / %v = [%v])", cp.Namespace, cp.Name, entry.Machine.Namespace, entry.Machine.Name, entries[scaled].Metadata.Annotations[capr.JoinURLAnnotation], ck, uint32(len(entries)), math.MaxUint32, scaled)
+	logger.Debugf("[planner] %s/%s: For machine %s/%s, determined join URL: %s (calculation of index: (%v * %v) / %v = [%v])", cp.Namespace, cp.Name, entry.Machine.Namespace, entry.Machine.Name, entries[scaled].Metadata.Annotations[capr.JoinURLAnnotation], ck, uint32(len(entries)), math.MaxUint32, scaled)
 	return entries[scaled].Metadata.Annotations[capr.JoinURLAnnotation]
 }
 
@@ -550,7 +639,7 @@ func determineJoinURL(cp *rkev1.RKEControlPlane, entry *planEntry, plan *plan.Pl
 			if entry.Plan != nil {
 				joinedTo = entry.Plan.JoinedTo
 			}
-			logrus.Infof("[planner] rkecluster %s/%s - machine %s/%s - previous join server (%s) was not valid, using new join server (%s)", cp.Namespace, cp.Name, entry.Machine.Namespace, entry.Machine.Name, joinedTo, joinURL)
+			logger.Infof("[planner] rkecluster %s/%s - machine %s/%s - previous join server (%s) was not valid, using new join server (%s)", cp.Namespace, cp.Name, entry.Machine.Namespace, entry.Machine.Name, joinedTo, joinURL)
 			if joinURL == "" {
 				return "", fmt.Errorf("no suitable join URL found to join machine %s/%s in rkecluster %s/%s to", entry.Machine.Namespace, entry.Machine.Name, cp.Namespace, cp.Name)
 			}
@@ -694,13 +783,13 @@ func splitArgKeyVal(val string, delim string) (string, string) {
 // getArgValue will search the passed in interface (arg) for a key that matches the searchArg. If a match is found, it
 // returns the value of the argument, otherwise it returns an empty string.
 func getArgValue(arg interface{}, searchArg string, delim string) string {
-	logrus.Tracef("getArgValue (searchArg: %s, delim: %s) type of %v is %T", searchArg, delim, arg, arg)
+	logger.Tracef("getArgValue (searchArg: %s, delim: %s) type of %v is %T", searchArg, delim, arg, arg)
 	switch arg := arg.(type) {
 	case []interface{}:
-		logrus.Tracef("getArgValue (searchArg: %s, delim: %s) encountered interface slice %v", searchArg, delim, arg)
+		logger.Tracef("getArgValue (searchArg: %s, delim: %s) encountered interface slice %v", searchArg, delim, arg)
 		return getArgValue(convertInterfaceSliceToStringSlice(arg), searchArg, delim)
 	case []string:
-		logrus.Tracef("getArgValue (searchArg: %s, delim: %s) found string array: %v", searchArg, delim, arg)
+		logger.Tracef("getArgValue (searchArg: %s, delim: %s) found string array: %v", searchArg, delim, arg)
 		for _, v := range arg {
 			argKey, argVal := splitArgKeyVal(v, delim)
 			if argKey == searchArg {
@@ -708,13 +797,13 @@ func getArgValue(arg interface{}, searchArg string, delim string) string {
 			}
 		}
 	case string:
-		logrus.Tracef("getArgValue (searchArg: %s, delim: %s) found string: %v", searchArg, delim, arg)
+		logger.Tracef("getArgValue (searchArg: %s, delim: %s) found string: %v", searchArg, delim, arg)
 		argKey, argVal := splitArgKeyVal(arg, delim)
 		if argKey == searchArg {
 			return argVal
 		}
 	}
-	logrus.Tracef("getArgValue (searchArg: %s, delim: %s) did not find searchArg in: %v", searchArg, delim, arg)
+	logger.Tracef("getArgValue (searchArg: %s, delim: %s) did not find searchArg in: %v", searchArg, delim, arg)
 	return ""
 }
 
@@ -799,16 +888,16 @@ func renderArgAndMount(existingArg interface{}, existingMount interface{}, runti
 		}
 	}
 	if certDirArg != "" {
-		logrus.Debugf("renderArgAndMount adding %s to component arguments", certDirArg)
+		logger.Debugf("renderArgAndMount adding %s to component arguments", certDirArg)
 		retArg = appendToInterface(existingArg, certDirArg)
 	}
 	if securePortArg != "" {
-		logrus.Debugf("renderArgAndMount adding %s to component arguments", securePortArg)
+		logger.Debugf("renderArgAndMount adding %s to component arguments", securePortArg)
 		retArg = appendToInterface(retArg, securePortArg)
 	}
 	if runtime == capr.RuntimeRKE2 {
 		// todo: make sure the certDirMount is not already set by the user to some custom value before we set it for the static pod extraMount
-		logrus.Debugf("renderArgAndMount adding %s to component mounts", certDirMount)
+		logger.Debugf("renderArgAndMount adding %s to component mounts", certDirMount)
 		retMount = appendToInterface(existingMount, certDirMount)
 	}
 	return retArg, retMount
@@ -845,23 +934,8 @@ func getTaints(entry *planEntry, cp *rkev1.RKEControlPlane) (result []corev1.Tai
 		}
 	}
 
-	if !isWorker(entry) {
-		// k3s charts do not have correct tolerations when the master node is both controlplane and etcd
-		if isEtcd(entry) && (capr.GetRuntime(cp.Spec.KubernetesVersion) != capr.RuntimeK3S || !isControlPlane(entry)) {
-			result = append(result, corev1.Taint{
-				Key:    "node-role.kubernetes.io/etcd",
-				Effect: corev1.TaintEffectNoExecute,
-			})
-		}
-		if isControlPlane(entry) {
-			result = append(result, corev1.Taint{
-				Key:    "node-role.kubernetes.io/control-plane",
-				Effect: corev1.TaintEffectNoSchedule,
-			})
-		}
-	}
-
-	return
+	roleTaints := capr.RoleTaints(capr.GetRuntime(cp.Spec.KubernetesVersion), isEtcd(entry), isControlPlane(entry), isWorker(entry))
+	return capr.MergeRoleTaints(result, roleTaints), nil
 }
 
 func (p *Planner) reconcile(controlPlane *rkev1.RKEControlPlane, tokensSecret plan.Secret, clusterPlan *plan.Plan, required bool,
@@ -869,6 +943,7 @@ func (p *Planner) reconcile(controlPlane *rkev1.RKEControlPlane, tokensSecret pl
 	var (
 		ready, outOfSync, reconciling, nonReady, errMachines, draining, uncordoned []string
 		messages                                                                   = map[string][]string{}
+		reasons                                                                    = map[string]string{}
 	)
 
 	entries := collect(clusterPlan, include)
@@ -879,10 +954,10 @@ func (p *Planner) reconcile(controlPlane *rkev1.RKEControlPlane, tokensSecret pl
 	}
 
 	for _, entry := range entries {
-		logrus.Tracef("[planner] rkecluster %s/%s reconcile tier %s - processing machine entry: %s/%s", controlPlane.Namespace, controlPlane.Name, tierName, entry.Machine.Namespace, entry.Machine.Name)
+		logger.Tracef("[planner] rkecluster %s/%s reconcile tier %s - processing machine entry: %s/%s", controlPlane.Namespace, controlPlane.Name, tierName, entry.Machine.Namespace, entry.Machine.Name)
 		// we exclude here and not in collect to ensure that include matched at least one node
 		if exclude(entry) {
-			logrus.Tracef("[planner] rkecluster %s/%s reconcile tier %s - excluding machine entry: %s/%s", controlPlane.Namespace, controlPlane.Name, tierName, entry.Machine.Namespace, entry.Machine.Name)
+			logger.Tracef("[planner] rkecluster %s/%s reconcile tier %s - excluding machine entry: %s/%s", controlPlane.Namespace, controlPlane.Name, tierName, entry.Machine.Namespace, entry.Machine.Name)
 			continue
 		}
 
@@ -900,34 +975,35 @@ func (p *Planner) reconcile(controlPlane *rkev1.RKEControlPlane, tokensSecret pl
 			summary.Message = append(summary.Message, planStatusMessage)
 		}
 		messages[entry.Machine.Name] = summary.Message
+		reasons[entry.Machine.Name] = getPlanStatusReason(entry)
 
 		joinURL, err := determineJoinURL(controlPlane, entry, clusterPlan, forcedJoinURL)
 		if err != nil {
 			return err
 		}
 
-		logrus.Debugf("[planner] rkecluster %s/%s reconcile tier %s - rendering desired plan for machine %s/%s with join URL: (%s)", controlPlane.Namespace, controlPlane.Name, tierName, entry.Machine.Namespace, entry.Machine.Name, joinURL)
+		logger.Debugf("[planner] rkecluster %s/%s reconcile tier %s - rendering desired plan for machine %s/%s with join URL: (%s)", controlPlane.Namespace, controlPlane.Name, tierName, entry.Machine.Namespace, entry.Machine.Name, joinURL)
 		plan, joinedURL, err := p.desiredPlan(controlPlane, tokensSecret, entry, joinURL)
 		if err != nil {
 			return err
 		}
 
 		if entry.Plan == nil {
-			logrus.Debugf("[planner] rkecluster %s/%s reconcile tier %s - setting initial plan for machine %s/%s", controlPlane.Namespace, controlPlane.Name, tierName, entry.Machine.Namespace, entry.Machine.Name)
-			logrus.Tracef("[planner] rkecluster %s/%s reconcile tier %s - initial plan for machine %s/%s new: %+v", controlPlane.Namespace, controlPlane.Name, tierName, entry.Machine.Namespace, entry.Machine.Name, plan)
+			logger.Debugf("[planner] rkecluster %s/%s reconcile tier %s - setting initial plan for machine %s/%s", controlPlane.Namespace, controlPlane.Name, tierName, entry.Machine.Namespace, entry.Machine.Name)
+			logger.Tracef("[planner] rkecluster %s/%s reconcile tier %s - initial plan for machine %s/%s new: %+v", controlPlane.Namespace, controlPlane.Name, tierName, entry.Machine.Namespace, entry.Machine.Name, plan)
 			outOfSync = append(outOfSync, entry.Machine.Name)
 			if err := p.store.UpdatePlan(entry, plan, joinedURL, -1, 1); err != nil {
 				return err
 			}
 		} else if minorPlanChangeDetected(entry.Plan.Plan, plan) {
-			logrus.Debugf("[planner] rkecluster %s/%s reconcile tier %s - minor plan change detected for machine %s/%s, updating plan immediately", controlPlane.Namespace, controlPlane.Name, tierName, entry.Machine.Namespace, entry.Machine.Name)
-			logrus.Tracef("[planner] rkecluster %s/%s reconcile tier %s - minor plan change for machine %s/%s old: %+v, new: %+v", controlPlane.Namespace, controlPlane.Name, tierName, entry.Machine.Namespace, entry.Machine.Name, entry.Plan.Plan, plan)
+			logger.Debugf("[planner] rkecluster %s/%s reconcile tier %s - minor plan change detected for machine %s/%s, updating plan immediately", controlPlane.Namespace, controlPlane.Name, tierName, entry.Machine.Namespace, entry.Machine.Name)
+			logger.Tracef("[planner] rkecluster %s/%s reconcile tier %s - minor plan change for machine %s/%s old: %+v, new: %+v", controlPlane.Namespace, controlPlane.Name, tierName, entry.Machine.Namespace, entry.Machine.Name, entry.Plan.Plan, plan)
 			outOfSync = append(outOfSync, entry.Machine.Name)
 			if err := p.store.UpdatePlan(entry, plan, joinedURL, -1, 1); err != nil {
 				return err
 			}
 		} else if !equality.Semantic.DeepEqual(entry.Plan.Plan, plan) {
-			logrus.Debugf("[planner] rkecluster %s/%s reconcile tier %s - plan for machine %s/%s did not match, appending to outOfSync", controlPlane.Namespace, controlPlane.Name, tierName, entry.Machine.Namespace, entry.Machine.Name)
+			logger.Debugf("[planner] rkecluster %s/%s reconcile tier %s - plan for machine %s/%s did not match, appending to outOfSync", controlPlane.Namespace, controlPlane.Name, tierName, entry.Machine.Namespace, entry.Machine.Name)
 			outOfSync = append(outOfSync, entry.Machine.Name)
 			// Conditions
 			// 1. If the node is already draining then the plan is out of sync.  There is no harm in updating it if
@@ -935,7 +1011,7 @@ func (p *Planner) reconcile(controlPlane *rkev1.RKEControlPlane, tokensSecret pl
 			// 2. If the plan has failed to apply. Note that the `Failed` will only be `true` if the max failure count has passed, or (if max-failures is not set) the plan has failed to apply at least once.
 			// 3. concurrency == 0 which means infinite concurrency.
 			// 4. unavailable < concurrency meaning we have capacity to make something unavailable
-			logrus.Debugf("[planner] rkecluster %s/%s reconcile tier %s - concurrency: %d, unavailable: %d", controlPlane.Namespace, controlPlane.Name, tierName, concurrency, unavailable)
+			logger.Debugf("[planner] rkecluster %s/%s reconcile tier %s - concurrency: %d, unavailable: %d", controlPlane.Namespace, controlPlane.Name, tierName, concurrency, unavailable)
 			if isInDrain(entry) || entry.Plan.Failed || concurrency == 0 || unavailable < concurrency {
 				reconciling = append(reconciling, entry.Machine.Name)
 				if !isUnavailable(entry) {
@@ -945,20 +1021,23 @@ func (p *Planner) reconcile(controlPlane *rkev1.RKEControlPlane, tokensSecret pl
 					return err
 				} else if ok && err == nil {
 					// Drain is done (or didn't need to be done) and there are no errors, so the plan should be updated to enact the reason the node was drained.
-					logrus.Debugf("[planner] rkecluster %s/%s reconcile tier %s - major plan change for machine %s/%s", controlPlane.Namespace, controlPlane.Name, tierName, entry.Machine.Namespace, entry.Machine.Name)
-					logrus.Tracef("[planner] rkecluster %s/%s reconcile tier %s - major plan change for machine %s/%s old: %+v, new: %+v", controlPlane.Namespace, controlPlane.Name, tierName, entry.Machine.Namespace, entry.Machine.Name, entry.Plan.Plan, plan)
+					logger.Debugf("[planner] rkecluster %s/%s reconcile tier %s - major plan change for machine %s/%s", controlPlane.Namespace, controlPlane.Name, tierName, entry.Machine.Namespace, entry.Machine.Name)
+					logger.Tracef("[planner] rkecluster %s/%s reconcile tier %s - major plan change for machine %s/%s old: %+v, new: %+v", controlPlane.Namespace, controlPlane.Name, tierName, entry.Machine.Namespace, entry.Machine.Name, entry.Plan.Plan, plan)
 					if err = p.store.UpdatePlan(entry, plan, joinedURL, -1, 1); err != nil {
 						return err
 					} else if entry.Metadata.Annotations[capr.DrainDoneAnnotation] != "" {
 						messages[entry.Machine.Name] = append(messages[entry.Machine.Name], "drain completed")
+						reasons[entry.Machine.Name] = capr.WaitingForPlan
 					} else if planStatusMessage == "" {
 						messages[entry.Machine.Name] = append(messages[entry.Machine.Name], WaitingPlanStatusMessage)
+						reasons[entry.Machine.Name] = capr.WaitingForPlan
 					}
 				} else {
 					// In this case, it is true that ((ok == true && err != nil) || (ok == false && err == nil))
 					// The first case indicates that there is an error trying to drain the node.
 					// The second case indicates that the node is draining.
 					draining = append(draining, entry.Machine.Name)
+					reasons[entry.Machine.Name] = capr.WaitingForDrain
 					if err != nil {
 						messages[entry.Machine.Name] = append(messages[entry.Machine.Name], err.Error())
 					} else {
@@ -974,6 +1053,7 @@ func (p *Planner) reconcile(controlPlane *rkev1.RKEControlPlane, tokensSecret pl
 			// The uncordoning is happening or there was an error.
 			// Either way, the planner should wait for the result and display the message on the machine.
 			uncordoned = append(uncordoned, entry.Machine.Name)
+			reasons[entry.Machine.Name] = capr.WaitingForUncordon
 			if err != nil {
 				messages[entry.Machine.Name] = append(messages[entry.Machine.Name], err.Error())
 			} else {
@@ -982,41 +1062,43 @@ func (p *Planner) reconcile(controlPlane *rkev1.RKEControlPlane, tokensSecret pl
 		} else if !kubeletVersionUpToDate(controlPlane, entry.Machine) {
 			outOfSync = append(outOfSync, entry.Machine.Name)
 			messages[entry.Machine.Name] = append(messages[entry.Machine.Name], "waiting for kubelet to update")
+			reasons[entry.Machine.Name] = capr.WaitingForKubelet
 		} else if isControlPlane(entry) && !controlPlane.Status.AgentConnected {
 			// If the control plane nodes are currently being provisioned/updated, then it should be ensured that cluster-agent is connected.
 			// Without the agent connected, the controllers running in Rancher, including CAPI, can't communicate with the downstream cluster.
 			outOfSync = append(outOfSync, entry.Machine.Name)
 			messages[entry.Machine.Name] = append(messages[entry.Machine.Name], "waiting for cluster agent to connect")
+			reasons[entry.Machine.Name] = capr.WaitingForClusterAgent
 		} else {
 			ready = append(ready, entry.Machine.Name)
 		}
 	}
 
 	if required && len(entries) == 0 {
-		return errWaiting("waiting for at least one " + tierName + " node")
+		return newErrWaiting(capr.WaitingForMachineRegistration, "waiting for at least one "+tierName+" node")
 	}
 
 	// If multiple machines are changing status, then all of their statuses should be updated to avoid having stale conditions.
 	// However, only the first one will be returned so that status goes on the control plane and cluster objects.
 	var firstError error
-	if err := p.setMachineConditionStatus(clusterPlan, uncordoned, fmt.Sprintf("uncordoning %s node(s) ", tierName), messages); err != nil && firstError == nil {
+	if err := p.setMachineConditionStatus(clusterPlan, uncordoned, capr.WaitingForUncordon, fmt.Sprintf("uncordoning %s node(s) ", tierName), messages, reasons); err != nil && firstError == nil {
 		firstError = err
 	}
 
-	if err := p.setMachineConditionStatus(clusterPlan, draining, fmt.Sprintf("draining %s node(s) ", tierName), messages); err != nil && firstError == nil {
+	if err := p.setMachineConditionStatus(clusterPlan, draining, capr.WaitingForDrain, fmt.Sprintf("draining %s node(s) ", tierName), messages, reasons); err != nil && firstError == nil {
 		firstError = err
 	}
 
-	if err := p.setMachineConditionStatus(clusterPlan, reconciling, fmt.Sprintf("configuring %s node(s) ", tierName), messages); err != nil && firstError == nil {
+	if err := p.setMachineConditionStatus(clusterPlan, reconciling, capr.WaitingForPlan, fmt.Sprintf("configuring %s node(s) ", tierName), messages, reasons); err != nil && firstError == nil {
 		firstError = err
 	}
 
-	if err := p.setMachineConditionStatus(clusterPlan, outOfSync, fmt.Sprintf("configuring %s node(s) ", tierName), messages); err != nil && firstError == nil {
+	if err := p.setMachineConditionStatus(clusterPlan, outOfSync, capr.WaitingForPlan, fmt.Sprintf("configuring %s node(s) ", tierName), messages, reasons); err != nil && firstError == nil {
 		firstError = err
 	}
 
 	// Ensure that the conditions that we control are updated.
-	if err := p.setMachineConditionStatus(clusterPlan, ready, "", nil); err != nil && firstError == nil {
+	if err := p.setMachineConditionStatus(clusterPlan, ready, "", "", nil, nil); err != nil && firstError == nil {
 		firstError = err
 	}
 
@@ -1071,6 +1153,11 @@ func (p *Planner) generatePlanWithConfigFiles(controlPlane *rkev1.RKEControlPlan
 		}
 
 		nodePlan, err = addOtherFiles(nodePlan, controlPlane, entry)
+		if err != nil {
+			return nodePlan, config, joinedServer, err
+		}
+
+		nodePlan, err = addConfigDropIns(nodePlan, controlPlane, entry)
 		return nodePlan, config, joinedServer, err
 	}
 	return plan.NodePlan{}, map[string]interface{}{}, "", nil
@@ -1094,6 +1181,11 @@ func (p *Planner) desiredPlan(controlPlane *rkev1.RKEControlPlane, tokensSecret
 		return nodePlan, joinedTo, err
 	}
 
+	nodePlan, err = addNodeConfigPeriodicInstruction(nodePlan, controlPlane, entry)
+	if err != nil {
+		return nodePlan, joinedTo, err
+	}
+
 	if isInitNode(entry) && IsOnlyEtcd(entry) {
 		// If the annotation to disable autosetting the join URL is enabled, don't deliver a plan to add the periodic instruction to scrape init node.
 		if _, autosetDisabled := entry.Metadata.Annotations[capr.JoinURLAutosetDisabled]; !autosetDisabled {
@@ -0,0 +1,56 @@
+package planner
+
+import (
+	"strings"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/rancher/pkg/settings"
+)
+
+// plannerBehaviors returns whether each planner behavior is active for the control plane. An invalid
+// planner-behavior-defaults setting is logged and ignored.
+func plannerBehaviors(controlPlane *rkev1.RKEControlPlane) []rkev1.PlannerBehaviorStatus {
+	defaults, err := capr.ParsePlannerBehaviorDefaults(settings.PlannerBehaviorDefaults.Get())
+	if err != nil {
+		logger.Errorf("[planner] rkecluster %s/%s: ignoring the %s setting: %v", controlPlane.Namespace, controlPlane.Name, settings.PlannerBehaviorDefaults.Name, err)
+	}
+	return capr.ResolvePlannerBehaviors(controlPlane.Spec.PlannerBehaviors, defaults)
+}
+
+// plannerBehaviorEnabled returns true if the planner behavior is active for the control plane, as resolved into its
+// status once per run of the planner.
+func plannerBehaviorEnabled(controlPlane *rkev1.RKEControlPlane, name string) bool {
+	if controlPlane == nil {
+		return false
+	}
+	for _, behavior := range controlPlane.Status.PlannerBehaviors {
+		if behavior.Name == name {
+			return behavior.Enabled
+		}
+	}
+	return false
+}
+
+// setPlannerBehaviorsDisabled sets the PlannerBehaviorsDisabled condition of the status of the control plane, true with
+// the fields of its spec ignored as the planner behaviors using them are disabled.
+func setPlannerBehaviorsDisabled(status rkev1.RKEControlPlaneStatus, controlPlane *rkev1.RKEControlPlane) rkev1.RKEControlPlaneStatus {
+	ignored := capr.IgnoredPlannerBehaviorFields(&controlPlane.Spec.RKEClusterSpecCommon, status.PlannerBehaviors)
+	if len(ignored) == 0 {
+		capr.PlannerBehaviorsDisabled.False(&status)
+		capr.PlannerBehaviorsDisabled.Message(&status, "")
+		return status
+	}
+	capr.PlannerBehaviorsDisabled.True(&status)
+	capr.PlannerBehaviorsDisabled.Message(&status, strings.Join(ignored, "; "))
+	return status
+}
+
+// instructionTimeout returns the timeout of an instruction of the control plane, none if instruction timeouts are
+// disabled.
+func instructionTimeout(controlPlane *rkev1.RKEControlPlane, seconds int) int {
+	if !plannerBehaviorEnabled(controlPlane, capr.PlannerBehaviorInstructionTimeouts) {
+		return 0
+	}
+	return seconds
+}
@@ -0,0 +1,53 @@
+package planner
+
+import (
+	"testing"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstructionTimeout(t *testing.T) {
+	assert.Equal(t, 0, instructionTimeout(nil, periodicInstructionTimeoutSeconds))
+
+	controlPlane := &rkev1.RKEControlPlane{}
+	assert.Equal(t, 0, instructionTimeout(controlPlane, periodicInstructionTimeoutSeconds))
+
+	controlPlane.Status.PlannerBehaviors = []rkev1.PlannerBehaviorStatus{{Name: capr.PlannerBehaviorInstructionTimeouts, Enabled: true}}
+	assert.Equal(t, periodicInstructionTimeoutSeconds, instructionTimeout(controlPlane, periodicInstructionTimeoutSeconds))
+
+	controlPlane.Spec.PlannerBehaviors = map[string]bool{capr.PlannerBehaviorInstructionTimeouts: false}
+	assert.Equal(t, periodicInstructionTimeoutSeconds, instructionTimeout(controlPlane, periodicInstructionTimeoutSeconds),
+		"the behaviors are read as resolved into the status")
+}
+
+func TestPlannerBehaviors(t *testing.T) {
+	controlPlane := &rkev1.RKEControlPlane{}
+	controlPlane.Spec.PlannerBehaviors = map[string]bool{capr.PlannerBehaviorCustomProbes: true}
+
+	for _, status := range plannerBehaviors(controlPlane) {
+		if status.Name == capr.PlannerBehaviorCustomProbes {
+			assert.True(t, status.Enabled)
+			assert.Equal(t, rkev1.PlannerBehaviorSourceCluster, status.Source)
+		} else {
+			assert.False(t, status.Enabled)
+			assert.Equal(t, rkev1.PlannerBehaviorSourceDefault, status.Source)
+		}
+	}
+}
+
+func TestSetPlannerBehaviorsDisabled(t *testing.T) {
+	controlPlane := &rkev1.RKEControlPlane{}
+	controlPlane.Spec.LockPools = []string{"harvester"}
+	status := rkev1.RKEControlPlaneStatus{PlannerBehaviors: capr.ResolvePlannerBehaviors(nil, nil)}
+
+	status = setPlannerBehaviorsDisabled(status, controlPlane)
+	assert.True(t, capr.PlannerBehaviorsDisabled.IsTrue(&status))
+	assert.Equal(t, "lockPools is ignored while the lock-pools planner behavior is disabled", capr.PlannerBehaviorsDisabled.GetMessage(&status))
+
+	status.PlannerBehaviors = capr.ResolvePlannerBehaviors(map[string]bool{capr.PlannerBehaviorLockPools: true}, nil)
+	status = setPlannerBehaviorsDisabled(status, controlPlane)
+	assert.True(t, capr.PlannerBehaviorsDisabled.IsFalse(&status))
+	assert.Empty(t, capr.PlannerBehaviorsDisabled.GetMessage(&status))
+}
@@ -106,8 +106,8 @@ func renderSecureProbe(arg interface{}, rawProbe plan.Probe, runtime string, def
 	return replaceCACertAndPortForProbes(rawProbe, TLSCert, securePort)
 }
 
-// generateProbes generates probes for the machine (based on type of machine) to the nodePlan and returns the probes and an error
-// if one occurred.
+// generateProbes generates probes for the machine (based on type of machine) to the nodePlan, along with the custom probes
+// of the cluster matching the machine, and returns the probes and an error if one occurred.
 func (p *Planner) generateProbes(controlPlane *rkev1.RKEControlPlane, entry *planEntry, config map[string]interface{}) (map[string]plan.Probe, error) {
 	var (
 		runtime    = capr.GetRuntime(controlPlane.Spec.KubernetesVersion)
@@ -136,6 +136,7 @@ func (p *Planner) generateProbes(controlPlane *rkev1.RKEControlPlane, entry *pla
 	}
 
 	probes = replaceRuntimeForProbes(probes, runtime)
+	probes = replaceLoopbackForProbes(probes, loopbackAddress(controlPlane))
 
 	if isControlPlane(entry) {
 		kcmProbe, err := renderSecureProbe(config[KubeControllerManagerArg], probes["kube-controller-manager"], runtime, DefaultKubeControllerManagerDefaultSecurePort, DefaultKubeControllerManagerCertDir, DefaultKubeControllerManagerCert)
@@ -150,7 +151,10 @@ func (p *Planner) generateProbes(controlPlane *rkev1.RKEControlPlane, entry *pla
 		}
 		probes["kube-scheduler"] = ksProbe
 	}
-	return probes, nil
+	if !plannerBehaviorEnabled(controlPlane, capr.PlannerBehaviorCustomProbes) {
+		return probes, nil
+	}
+	return addCustomProbes(probes, controlPlane, entry)
 }
 
 // replaceCACertAndPortForProbes adds/replaces the CACert and URL with rendered values based on the values provided.
@@ -0,0 +1,17 @@
+package planner
+
+import (
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/wrangler/pkg/data/convert"
+)
+
+// addProviderIDConfig sets the provider ID of the node of an adopted instance on its kubelet, so that the node is
+// matched to its machine and the cloud provider manages the existing instance. The provider ID replaces the matching
+// kubelet-arg of the machine.
+func addProviderIDConfig(config map[string]interface{}, entry *planEntry) {
+	providerID := entry.Machine.Annotations[capr.ProviderIDAnnotation]
+	if providerID == "" {
+		return
+	}
+	config[kubeletArg] = replaceArg(convert.ToStringSlice(config[kubeletArg]), "provider-id="+providerID)
+}
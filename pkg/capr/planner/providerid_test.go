@@ -0,0 +1,45 @@
+package planner
+
+import (
+	"testing"
+
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/stretchr/testify/assert"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func Test_addProviderIDConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		config      map[string]interface{}
+		expected    interface{}
+	}{
+		{
+			name:     "not adopted",
+			config:   map[string]interface{}{kubeletArg: []interface{}{"max-pods=200"}},
+			expected: []interface{}{"max-pods=200"},
+		},
+		{
+			name:        "adopted",
+			annotations: map[string]string{capr.ProviderIDAnnotation: "aws:///us-east-1a/i-1"},
+			config:      map[string]interface{}{kubeletArg: []interface{}{"max-pods=200", "provider-id=rke2://node"}},
+			expected:    []string{"max-pods=200", "provider-id=aws:///us-east-1a/i-1"},
+		},
+		{
+			name:        "adopted without kubelet args",
+			annotations: map[string]string{capr.ProviderIDAnnotation: "aws:///us-east-1a/i-1"},
+			config:      map[string]interface{}{},
+			expected:    []string{"provider-id=aws:///us-east-1a/i-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := &planEntry{Machine: &capi.Machine{}}
+			entry.Machine.Annotations = tt.annotations
+			addProviderIDConfig(tt.config, entry)
+			assert.Equal(t, tt.expected, tt.config[kubeletArg])
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package simulator
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/rancher/rancher/pkg/capr/planner"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// The periodic instructions the planner reads the join URL of an etcd-only init node from.
+	captureAddressInstructionName = "capture-address"
+	etcdNameInstructionName       = "etcd-name"
+)
+
+// agent is a simulated system-agent, which applies the plans of its machine without running their instructions, and
+// reports them applied with healthy probes. The machine gets its node once the agent applied its first plan.
+type agent struct {
+	sim        *Simulator
+	machine    string
+	secret     string
+	address    string
+	changed    chan struct{}
+	planHash   string
+	noticed    time.Time
+	registered bool
+}
+
+func newAgent(sim *Simulator, machine, secret, address string) *agent {
+	return &agent{
+		sim:     sim,
+		machine: machine,
+		secret:  secret,
+		address: address,
+		changed: make(chan struct{}, 1),
+	}
+}
+
+func (a *agent) notify() {
+	select {
+	case a.changed <- struct{}{}:
+	default:
+	}
+}
+
+func (a *agent) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-a.changed:
+		}
+		if err := a.apply(ctx); err != nil && ctx.Err() == nil {
+			logrus.Errorf("[planner-simulator] agent of machine %s: %v", a.machine, err)
+		}
+	}
+}
+
+// apply applies the plan of the machine if it hasn't been applied yet, and retries it if it fails.
+func (a *agent) apply(ctx context.Context) error {
+	obj, err := a.sim.objects.cached(secretKind, namespace, a.secret)
+	if err != nil {
+		return err
+	}
+	secret := obj.(*corev1.Secret)
+	planData := secret.Data["plan"]
+	if len(planData) == 0 {
+		return nil
+	}
+	if _, reported := secret.Data["probe-statuses"]; reported && bytes.Equal(planData, secret.Data["appliedPlan"]) {
+		return nil
+	}
+
+	hash := planner.PlanHash(planData)
+	if hash != a.planHash {
+		a.planHash = hash
+		a.noticed = time.Now()
+	}
+	var nodePlan plan.NodePlan
+	if err := json.Unmarshal(planData, &nodePlan); err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(a.sim.applyLatency()):
+	}
+
+	failed := a.sim.fails()
+	applied, err := a.report(planData, hash, &nodePlan, failed)
+	if err != nil || !applied {
+		// The plan was replaced while it was applied, the agent is notified of the new one.
+		return err
+	}
+	a.sim.recordApply(time.Since(a.noticed), failed)
+	if failed {
+		a.notify()
+		return nil
+	}
+	return a.register()
+}
+
+// report records the outcome of the application of the plan in the plan secret, like the system-agent does. It
+// returns false if the plan was replaced in the meantime.
+func (a *agent) report(planData []byte, hash string, nodePlan *plan.NodePlan, failed bool) (bool, error) {
+	var (
+		probes, periodicOutput []byte
+		err                    error
+	)
+	if !failed {
+		if probes, err = probeStatuses(nodePlan); err != nil {
+			return false, err
+		}
+		if periodicOutput, err = a.periodicOutput(nodePlan); err != nil {
+			return false, err
+		}
+	}
+
+	var reported bool
+	err = a.sim.objects.modify(secretKind, namespace, a.secret, func(obj runtime.Object) bool {
+		secret := obj.(*corev1.Secret)
+		if !bytes.Equal(secret.Data["plan"], planData) {
+			return false
+		}
+		reported = true
+		if failed {
+			var count int
+			if string(secret.Data["failed-checksum"]) == hash {
+				count, _ = strconv.Atoi(string(secret.Data["failure-count"]))
+			}
+			secret.Data["failed-checksum"] = []byte(hash)
+			secret.Data["failure-count"] = []byte(strconv.Itoa(count + 1))
+			return true
+		}
+		delete(secret.Data, "failed-checksum")
+		delete(secret.Data, "failure-count")
+		secret.Data["appliedPlan"] = planData
+		secret.Data["applied-checksum"] = []byte(hash)
+		secret.Data["probe-statuses"] = probes
+		if periodicOutput != nil {
+			secret.Data["applied-periodic-output"] = periodicOutput
+		}
+		return true
+	})
+	return reported, err
+}
+
+// register adds the node of the machine to its status once it has applied its first plan.
+func (a *agent) register() error {
+	if a.registered {
+		return nil
+	}
+	err := a.sim.objects.modify(machineKind, namespace, a.machine, func(obj runtime.Object) bool {
+		machine := obj.(*capi.Machine)
+		machine.Status.NodeRef = &corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Node",
+			Name:       a.machine,
+		}
+		machine.Status.NodeInfo = &corev1.NodeSystemInfo{
+			KubeletVersion:  a.sim.config.KubernetesVersion,
+			OperatingSystem: "linux",
+			Architecture:    "amd64",
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	a.registered = true
+	return nil
+}
+
+func probeStatuses(nodePlan *plan.NodePlan) ([]byte, error) {
+	statuses := map[string]plan.ProbeStatus{}
+	for name := range nodePlan.Probes {
+		statuses[name] = plan.ProbeStatus{
+			Healthy:      true,
+			SuccessCount: 1,
+		}
+	}
+	return json.Marshal(statuses)
+}
+
+// periodicOutput returns the gzipped output of the successful runs of the periodic instructions of the plan, nil if it
+// has none.
+func (a *agent) periodicOutput(nodePlan *plan.NodePlan) ([]byte, error) {
+	if len(nodePlan.PeriodicInstructions) == 0 {
+		return nil, nil
+	}
+
+	now := time.Now().Format(time.UnixDate)
+	outputs := map[string]plan.PeriodicInstructionOutput{}
+	for _, instruction := range nodePlan.PeriodicInstructions {
+		output := plan.PeriodicInstructionOutput{
+			Name:                  instruction.Name,
+			LastSuccessfulRunTime: now,
+		}
+		switch instruction.Name {
+		case captureAddressInstructionName:
+			output.Stdout = []byte(fmt.Sprintf(`{"members":[{"name":%q,"clientURLs":["https://%s:2379"]}]}`, a.machine, a.address))
+		case etcdNameInstructionName:
+			output.Stdout = []byte(a.machine)
+		}
+		outputs[instruction.Name] = output
+	}
+
+	data, err := json.Marshal(outputs)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(data); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
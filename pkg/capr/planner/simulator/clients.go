@@ -0,0 +1,333 @@
+package simulator
+
+import (
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr/planner"
+	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	ranchercontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	rkecontrollers "github.com/rancher/rancher/pkg/generated/controllers/rke.cattle.io/v1"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// The clients and caches of the simulator embed the interfaces they implement and only implement the methods the planner
+// calls, the others panic.
+
+func newClients(o *objects, enqueueAfter func(time.Duration)) planner.Clients {
+	return planner.Clients{
+		Secrets:                       &secrets{objects: o},
+		ConfigMapCache:                &configMapCache{objects: o},
+		Machines:                      &machines{objects: o},
+		CAPIClusters:                  &capiClusters{objects: o},
+		RKEBootstrap:                  &rkeBootstraps{objects: o},
+		RKEControlPlanes:              &rkeControlPlanes{objects: o, enqueueAfter: enqueueAfter},
+		ETCDSnapshotCache:             &etcdSnapshotCache{objects: o},
+		ClusterRegistrationTokenCache: &clusterRegistrationTokenCache{objects: o},
+		ManagementClusterCache:        &managementClusterCache{objects: o},
+		ProvisioningClusterCache:      &provisioningClusterCache{objects: o},
+	}
+}
+
+type secrets struct {
+	corecontrollers.SecretController
+	objects *objects
+}
+
+func (s *secrets) Cache() corecontrollers.SecretCache {
+	return &secretCache{objects: s.objects}
+}
+
+func (s *secrets) Get(namespace, name string, _ metav1.GetOptions) (*corev1.Secret, error) {
+	obj, err := s.objects.get(secretKind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.Secret), nil
+}
+
+func (s *secrets) Create(secret *corev1.Secret) (*corev1.Secret, error) {
+	obj, err := s.objects.create(secretKind, secret)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.Secret), nil
+}
+
+func (s *secrets) Update(secret *corev1.Secret) (*corev1.Secret, error) {
+	obj, err := s.objects.update(secretKind, secret)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.Secret), nil
+}
+
+type secretCache struct {
+	corecontrollers.SecretCache
+	objects *objects
+}
+
+func (s *secretCache) Get(namespace, name string) (*corev1.Secret, error) {
+	obj, err := s.objects.cached(secretKind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.Secret), nil
+}
+
+func (s *secretCache) List(namespace string, selector labels.Selector) ([]*corev1.Secret, error) {
+	objs, err := s.objects.list(secretKind, namespace, selector)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*corev1.Secret, 0, len(objs))
+	for _, obj := range objs {
+		result = append(result, obj.(*corev1.Secret))
+	}
+	return result, nil
+}
+
+type configMapCache struct {
+	corecontrollers.ConfigMapCache
+	objects *objects
+}
+
+func (c *configMapCache) Get(namespace, name string) (*corev1.ConfigMap, error) {
+	obj, err := c.objects.cached(configMapKind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*corev1.ConfigMap), nil
+}
+
+type machines struct {
+	capicontrollers.MachineController
+	objects *objects
+}
+
+func (m *machines) Cache() capicontrollers.MachineCache {
+	return &machineCache{objects: m.objects}
+}
+
+func (m *machines) Get(namespace, name string, _ metav1.GetOptions) (*capi.Machine, error) {
+	obj, err := m.objects.get(machineKind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*capi.Machine), nil
+}
+
+func (m *machines) Update(machine *capi.Machine) (*capi.Machine, error) {
+	obj, err := m.objects.update(machineKind, machine)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*capi.Machine), nil
+}
+
+func (m *machines) UpdateStatus(machine *capi.Machine) (*capi.Machine, error) {
+	return m.Update(machine)
+}
+
+type machineCache struct {
+	capicontrollers.MachineCache
+	objects *objects
+}
+
+func (m *machineCache) Get(namespace, name string) (*capi.Machine, error) {
+	obj, err := m.objects.cached(machineKind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*capi.Machine), nil
+}
+
+func (m *machineCache) List(namespace string, selector labels.Selector) ([]*capi.Machine, error) {
+	objs, err := m.objects.list(machineKind, namespace, selector)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*capi.Machine, 0, len(objs))
+	for _, obj := range objs {
+		result = append(result, obj.(*capi.Machine))
+	}
+	return result, nil
+}
+
+type capiClusters struct {
+	capicontrollers.ClusterController
+	objects *objects
+}
+
+func (c *capiClusters) Cache() capicontrollers.ClusterCache {
+	return &capiClusterCache{objects: c.objects}
+}
+
+func (c *capiClusters) Update(cluster *capi.Cluster) (*capi.Cluster, error) {
+	obj, err := c.objects.update(capiClusterKind, cluster)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*capi.Cluster), nil
+}
+
+func (c *capiClusters) UpdateStatus(cluster *capi.Cluster) (*capi.Cluster, error) {
+	return c.Update(cluster)
+}
+
+type capiClusterCache struct {
+	capicontrollers.ClusterCache
+	objects *objects
+}
+
+func (c *capiClusterCache) Get(namespace, name string) (*capi.Cluster, error) {
+	obj, err := c.objects.cached(capiClusterKind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*capi.Cluster), nil
+}
+
+type rkeBootstraps struct {
+	rkecontrollers.RKEBootstrapController
+	objects *objects
+}
+
+func (r *rkeBootstraps) Cache() rkecontrollers.RKEBootstrapCache {
+	return &rkeBootstrapCache{objects: r.objects}
+}
+
+func (r *rkeBootstraps) Update(bootstrap *rkev1.RKEBootstrap) (*rkev1.RKEBootstrap, error) {
+	obj, err := r.objects.update(rkeBootstrapKind, bootstrap)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*rkev1.RKEBootstrap), nil
+}
+
+type rkeBootstrapCache struct {
+	rkecontrollers.RKEBootstrapCache
+	objects *objects
+}
+
+func (r *rkeBootstrapCache) Get(namespace, name string) (*rkev1.RKEBootstrap, error) {
+	obj, err := r.objects.cached(rkeBootstrapKind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*rkev1.RKEBootstrap), nil
+}
+
+// rkeControlPlanes has the simulator process its control plane again when the planner enqueues it, which is the only
+// control plane it knows of.
+type rkeControlPlanes struct {
+	rkecontrollers.RKEControlPlaneController
+	objects      *objects
+	enqueueAfter func(time.Duration)
+}
+
+func (r *rkeControlPlanes) Cache() rkecontrollers.RKEControlPlaneCache {
+	return &rkeControlPlaneCache{objects: r.objects}
+}
+
+func (r *rkeControlPlanes) EnqueueAfter(_, _ string, duration time.Duration) {
+	r.enqueueAfter(duration)
+}
+
+type rkeControlPlaneCache struct {
+	rkecontrollers.RKEControlPlaneCache
+	objects *objects
+}
+
+func (r *rkeControlPlaneCache) Get(namespace, name string) (*rkev1.RKEControlPlane, error) {
+	obj, err := r.objects.cached(rkeControlPlaneKind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*rkev1.RKEControlPlane), nil
+}
+
+type etcdSnapshotCache struct {
+	rkecontrollers.ETCDSnapshotCache
+	objects *objects
+}
+
+func (e *etcdSnapshotCache) Get(namespace, name string) (*rkev1.ETCDSnapshot, error) {
+	obj, err := e.objects.cached(etcdSnapshotKind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*rkev1.ETCDSnapshot), nil
+}
+
+type clusterRegistrationTokenCache struct {
+	mgmtcontrollers.ClusterRegistrationTokenCache
+	objects  *objects
+	indexers map[string]mgmtcontrollers.ClusterRegistrationTokenIndexer
+}
+
+func (c *clusterRegistrationTokenCache) AddIndexer(indexName string, indexer mgmtcontrollers.ClusterRegistrationTokenIndexer) {
+	if c.indexers == nil {
+		c.indexers = map[string]mgmtcontrollers.ClusterRegistrationTokenIndexer{}
+	}
+	c.indexers[indexName] = indexer
+}
+
+func (c *clusterRegistrationTokenCache) GetByIndex(indexName, key string) ([]*v3.ClusterRegistrationToken, error) {
+	indexer := c.indexers[indexName]
+	if indexer == nil {
+		return nil, nil
+	}
+	objs, err := c.objects.list(clusterRegistrationTokenKind, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	var result []*v3.ClusterRegistrationToken
+	for _, obj := range objs {
+		token := obj.(*v3.ClusterRegistrationToken)
+		keys, err := indexer(token)
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range keys {
+			if k == key {
+				result = append(result, token)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+type managementClusterCache struct {
+	mgmtcontrollers.ClusterCache
+	objects *objects
+}
+
+func (m *managementClusterCache) Get(name string) (*v3.Cluster, error) {
+	obj, err := m.objects.cached(managementClusterKind, "", name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*v3.Cluster), nil
+}
+
+type provisioningClusterCache struct {
+	ranchercontrollers.ClusterCache
+	objects *objects
+}
+
+func (p *provisioningClusterCache) Get(namespace, name string) (*provv1.Cluster, error) {
+	obj, err := p.objects.cached(provisioningClusterKind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*provv1.Cluster), nil
+}
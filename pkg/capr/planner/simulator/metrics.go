@@ -0,0 +1,87 @@
+package simulator
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Durations are durations measured during a simulation.
+type Durations []time.Duration
+
+// Total returns the sum of the durations.
+func (d Durations) Total() time.Duration {
+	var total time.Duration
+	for _, duration := range d {
+		total += duration
+	}
+	return total
+}
+
+// Mean returns the mean of the durations, 0 if there are none.
+func (d Durations) Mean() time.Duration {
+	if len(d) == 0 {
+		return 0
+	}
+	return d.Total() / time.Duration(len(d))
+}
+
+// Max returns the longest of the durations, 0 if there are none.
+func (d Durations) Max() time.Duration {
+	var longest time.Duration
+	for _, duration := range d {
+		if duration > longest {
+			longest = duration
+		}
+	}
+	return longest
+}
+
+// Percentile returns the shortest of the durations that the given percentage of the durations don't exceed, 0 if there
+// are none.
+func (d Durations) Percentile(percent float64) time.Duration {
+	if len(d) == 0 {
+		return 0
+	}
+	sorted := make(Durations, len(d))
+	copy(sorted, d)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	i := int(math.Ceil(percent/100*float64(len(sorted)))) - 1
+	if i < 0 {
+		i = 0
+	} else if i >= len(sorted) {
+		i = len(sorted) - 1
+	}
+	return sorted[i]
+}
+
+// Metrics are the measurements of a simulation.
+type Metrics struct {
+	// Nodes is the number of nodes of the simulated cluster.
+	Nodes int
+	// Converged is whether the planner finished reconciling the cluster before the simulation timed out.
+	Converged bool
+	// Duration is how long the planner took to reconcile the cluster, or how long it ran if it didn't converge.
+	Duration time.Duration
+	// Reconciles are the durations of the runs of the planner.
+	Reconciles Durations
+	// Errors is the number of runs of the planner that failed rather than waited or skipped, such as those that
+	// conflicted with an agent. The error of the last one is LastError.
+	Errors    int
+	LastError error
+	// Applies are the durations agents took to apply plans, from when they noticed them to when they reported them
+	// applied, including the failed attempts.
+	Applies Durations
+	// Failures is the number of attempts of the agents to apply a plan that failed.
+	Failures int
+}
+
+func (m Metrics) String() string {
+	return fmt.Sprintf("nodes=%d converged=%t duration=%s reconciles=%d reconcile(mean=%s p99=%s max=%s total=%s) errors=%d applies=%d apply(mean=%s p99=%s) failures=%d",
+		m.Nodes, m.Converged, m.Duration, len(m.Reconciles),
+		m.Reconciles.Mean(), m.Reconciles.Percentile(99), m.Reconciles.Max(), m.Reconciles.Total(),
+		m.Errors, len(m.Applies), m.Applies.Mean(), m.Applies.Percentile(99), m.Failures)
+}
@@ -0,0 +1,169 @@
+package simulator
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+const (
+	secretKind                   = "secrets"
+	configMapKind                = "configmaps"
+	machineKind                  = "machines"
+	capiClusterKind              = "clusters.cluster.x-k8s.io"
+	rkeBootstrapKind             = "rkebootstraps"
+	rkeControlPlaneKind          = "rkecontrolplanes"
+	etcdSnapshotKind             = "etcdsnapshots"
+	clusterRegistrationTokenKind = "clusterregistrationtokens"
+	managementClusterKind        = "clusters.management.cattle.io"
+	provisioningClusterKind      = "clusters.provisioning.cattle.io"
+)
+
+type objectKey struct {
+	kind, namespace, name string
+}
+
+// objects is the in-memory API server of the simulator. Objects are copied in and out of it by clients, and their
+// resource versions are checked when they're updated, so that the planner conflicts with the agents like it does with
+// the system-agents of a real cluster. Like informer caches, caches share the stored objects, which are replaced rather
+// than modified when they're written. onChange is called with the key of every object that is written.
+type objects struct {
+	lock            sync.Mutex
+	resourceVersion int
+	objects         map[objectKey]runtime.Object
+	onChange        func(objectKey)
+}
+
+func newObjects(onChange func(objectKey)) *objects {
+	return &objects{
+		objects:  map[objectKey]runtime.Object{},
+		onChange: onChange,
+	}
+}
+
+func (o *objects) get(kind, namespace, name string) (runtime.Object, error) {
+	obj, err := o.cached(kind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.DeepCopyObject(), nil
+}
+
+func (o *objects) cached(kind, namespace, name string) (runtime.Object, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	obj, ok := o.objects[objectKey{kind: kind, namespace: namespace, name: name}]
+	if !ok {
+		return nil, errors.NewNotFound(schema.GroupResource{Resource: kind}, name)
+	}
+	return obj, nil
+}
+
+func (o *objects) list(kind, namespace string, selector labels.Selector) ([]runtime.Object, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	var result []runtime.Object
+	for key, obj := range o.objects {
+		if key.kind != kind || (namespace != "" && key.namespace != namespace) {
+			continue
+		}
+		m, err := meta.Accessor(obj)
+		if err != nil {
+			return nil, err
+		}
+		if selector != nil && !selector.Matches(labels.Set(m.GetLabels())) {
+			continue
+		}
+		result = append(result, obj)
+	}
+	return result, nil
+}
+
+func (o *objects) create(kind string, obj runtime.Object) (runtime.Object, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	m, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	key := objectKey{kind: kind, namespace: m.GetNamespace(), name: m.GetName()}
+	if _, ok := o.objects[key]; ok {
+		return nil, errors.NewAlreadyExists(schema.GroupResource{Resource: kind}, key.name)
+	}
+
+	obj = obj.DeepCopyObject()
+	m, _ = meta.Accessor(obj)
+	if m.GetUID() == "" {
+		m.SetUID(types.UID(uuid.NewUUID()))
+	}
+	return o.store(key, obj, m), nil
+}
+
+func (o *objects) update(kind string, obj runtime.Object) (runtime.Object, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	m, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	key := objectKey{kind: kind, namespace: m.GetNamespace(), name: m.GetName()}
+	current, ok := o.objects[key]
+	if !ok {
+		return nil, errors.NewNotFound(schema.GroupResource{Resource: kind}, key.name)
+	}
+	currentMeta, _ := meta.Accessor(current)
+	if m.GetResourceVersion() != "" && m.GetResourceVersion() != currentMeta.GetResourceVersion() {
+		return nil, errors.NewConflict(schema.GroupResource{Resource: kind}, key.name,
+			fmt.Errorf("the object has been modified; please apply your changes to the latest version and try again"))
+	}
+
+	obj = obj.DeepCopyObject()
+	m, _ = meta.Accessor(obj)
+	return o.store(key, obj, m), nil
+}
+
+// modify updates the object with the given function without the risk of a conflict, as the object can't be updated by
+// anything else in between. The object isn't updated if the function returns false.
+func (o *objects) modify(kind, namespace, name string, modify func(runtime.Object) bool) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	key := objectKey{kind: kind, namespace: namespace, name: name}
+	current, ok := o.objects[key]
+	if !ok {
+		return errors.NewNotFound(schema.GroupResource{Resource: kind}, name)
+	}
+	obj := current.DeepCopyObject()
+	if !modify(obj) {
+		return nil
+	}
+	m, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	o.store(key, obj, m)
+	return nil
+}
+
+func (o *objects) store(key objectKey, obj runtime.Object, m metav1.Object) runtime.Object {
+	o.resourceVersion++
+	m.SetResourceVersion(strconv.Itoa(o.resourceVersion))
+	o.objects[key] = obj
+	if o.onChange != nil {
+		o.onChange(key)
+	}
+	return obj.DeepCopyObject()
+}
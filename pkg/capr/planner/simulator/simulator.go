@@ -0,0 +1,474 @@
+// Package simulator provisions a simulated cluster with a real planner, whose plans are applied in memory by simulated
+// system-agents, to measure how the planner performs as the number of nodes of a cluster grows. Everything but the
+// planner is simulated: the objects are kept in memory, the agents apply plans without running their instructions, and
+// the planner is run again whenever an object changes, like the planner controller does.
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/rancher/channelserver/pkg/model"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/rancher/pkg/capr/planner"
+	"github.com/rancher/wrangler/pkg/generic"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	namespace             = "fleet-default"
+	clusterName           = "simulated"
+	managementClusterName = "c-simulated"
+
+	// DefaultKubernetesVersion is the Kubernetes version of the simulated cluster if the config has none.
+	DefaultKubernetesVersion = "v1.25.9+rke2r1"
+	// DefaultTimeout is how long a simulation runs at most if the config has no timeout.
+	DefaultTimeout = 5 * time.Minute
+
+	// retryDelay is how long the simulator waits before running the planner again after it failed. The planner
+	// controller waits 5 seconds after the planner skipped a run, and backs off after errors, the simulator doesn't
+	// slow a simulation down as much.
+	retryDelay = 10 * time.Millisecond
+)
+
+// Pool is a pool of simulated machines with the same roles.
+type Pool struct {
+	Name         string
+	Quantity     int
+	Etcd         bool
+	ControlPlane bool
+	Worker       bool
+}
+
+// Config is the config of a simulation.
+type Config struct {
+	// Pools are the machine pools of the simulated cluster, which must have at least one etcd, control plane and worker
+	// node.
+	Pools []Pool
+	// KubernetesVersion is the Kubernetes version of the simulated cluster, DefaultKubernetesVersion if empty.
+	KubernetesVersion string
+	// ApplyLatency is how long agents take to apply a plan, to which a random duration up to ApplyJitter is added.
+	ApplyLatency time.Duration
+	ApplyJitter  time.Duration
+	// FailureRate is the probability that an attempt of an agent to apply a plan fails, between 0 and 1. Agents retry
+	// plans that failed, like the system-agent does.
+	FailureRate float64
+	// Timeout is how long a simulation runs at most, DefaultTimeout if 0.
+	Timeout time.Duration
+	// Seed is the seed of the random latencies and failures of the agents.
+	Seed int64
+}
+
+// Nodes returns the number of nodes of the simulated cluster.
+func (c Config) Nodes() int {
+	var nodes int
+	for _, pool := range c.Pools {
+		nodes += pool.Quantity
+	}
+	return nodes
+}
+
+// Simulator provisions a simulated cluster with a planner.
+type Simulator struct {
+	config  Config
+	objects *objects
+	planner *planner.Planner
+	agents  map[string]*agent
+	changed chan struct{}
+
+	lock    sync.Mutex
+	random  *rand.Rand
+	metrics Metrics
+}
+
+// New returns a simulator of a cluster with the given config, whose machines are ready to be provisioned.
+func New(ctx context.Context, config Config) (*Simulator, error) {
+	if config.KubernetesVersion == "" {
+		config.KubernetesVersion = DefaultKubernetesVersion
+	}
+	if config.Timeout == 0 {
+		config.Timeout = DefaultTimeout
+	}
+	if config.FailureRate < 0 || config.FailureRate >= 1 {
+		return nil, fmt.Errorf("failure rate %v must be at least 0 and less than 1", config.FailureRate)
+	}
+
+	s := &Simulator{
+		config:  config,
+		agents:  map[string]*agent{},
+		changed: make(chan struct{}, 1),
+		random:  rand.New(rand.NewSource(config.Seed)),
+		metrics: Metrics{
+			Nodes: config.Nodes(),
+		},
+	}
+	s.objects = newObjects(s.onChange)
+	if err := s.createCluster(); err != nil {
+		return nil, err
+	}
+
+	s.planner = planner.NewForClients(ctx, newClients(s.objects, s.enqueueAfter), nil, planner.InfoFunctions{
+		ImageResolver: func(image string, _ *rkev1.RKEControlPlane) string {
+			return image
+		},
+		ReleaseData: func(_ context.Context, cp *rkev1.RKEControlPlane) *model.Release {
+			return &model.Release{Version: cp.Spec.KubernetesVersion}
+		},
+		SystemAgentImage: func() string {
+			return "rancher/system-agent-installer-"
+		},
+	})
+	return s, nil
+}
+
+// Run provisions the simulated cluster, until the planner has reconciled it or the simulation times out, and returns
+// the metrics of the simulation. It only returns an error if the simulation itself fails.
+func (s *Simulator) Run(ctx context.Context) (Metrics, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, a := range s.agents {
+		wg.Add(1)
+		go func(a *agent) {
+			defer wg.Done()
+			a.run(ctx)
+		}(a)
+	}
+
+	start := time.Now()
+	err := s.provision(ctx)
+	duration := time.Since(start)
+	cancel()
+	wg.Wait()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.metrics.Duration = duration
+	return s.metrics, err
+}
+
+// provision runs the planner whenever the cluster changes, until it has reconciled the cluster or the context is done.
+func (s *Simulator) provision(ctx context.Context) error {
+	for {
+		// Changes made from now on require the planner to run again.
+		select {
+		case <-s.changed:
+		default:
+		}
+
+		converged, err := s.process()
+		if err != nil {
+			return err
+		}
+		if converged {
+			s.lock.Lock()
+			s.metrics.Converged = true
+			s.lock.Unlock()
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.changed:
+		}
+	}
+}
+
+// process runs the planner once, and records the status of the control plane like the planner controller does. It
+// returns whether the control plane is reconciled.
+func (s *Simulator) process() (bool, error) {
+	obj, err := s.objects.get(rkeControlPlaneKind, namespace, clusterName)
+	if err != nil {
+		return false, err
+	}
+	cp := obj.(*rkev1.RKEControlPlane)
+
+	start := time.Now()
+	status, processErr := s.planner.Process(cp, cp.Status)
+	duration := time.Since(start)
+
+	s.lock.Lock()
+	s.metrics.Reconciles = append(s.metrics.Reconciles, duration)
+	if processFailed(processErr) {
+		s.metrics.Errors++
+		s.metrics.LastError = processErr
+	}
+	s.lock.Unlock()
+
+	if processErr == nil {
+		status.AppliedSpec = &cp.Spec
+	}
+	err = s.objects.modify(rkeControlPlaneKind, namespace, clusterName, func(obj runtime.Object) bool {
+		cp := obj.(*rkev1.RKEControlPlane)
+		if equality.Semantic.DeepEqual(cp.Status, status) {
+			return false
+		}
+		cp.Status = status
+		return true
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if processErr != nil && !planner.IsErrWaiting(processErr) {
+		logrus.Debugf("[planner-simulator] planner failed: %v", processErr)
+		time.AfterFunc(retryDelay, s.notify)
+	}
+	return processErr == nil, nil
+}
+
+// processFailed returns whether the planner failed, rather than waited for the cluster or skipped the run to let caches
+// catch up.
+func processFailed(err error) bool {
+	return err != nil && !planner.IsErrWaiting(err) && !errors.Is(err, generic.ErrSkip)
+}
+
+// onChange notifies the agent of a plan secret that changed, and has the planner run again, as the planner controller
+// does when any object of the cluster changes, including the status of the control plane.
+func (s *Simulator) onChange(key objectKey) {
+	if key.kind == secretKind {
+		if a := s.agents[key.name]; a != nil {
+			a.notify()
+		}
+	}
+	s.notify()
+}
+
+func (s *Simulator) notify() {
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Simulator) enqueueAfter(duration time.Duration) {
+	time.AfterFunc(duration, s.notify)
+}
+
+// applyLatency returns how long an agent takes to apply a plan.
+func (s *Simulator) applyLatency() time.Duration {
+	if s.config.ApplyJitter <= 0 {
+		return s.config.ApplyLatency
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.config.ApplyLatency + time.Duration(s.random.Int63n(int64(s.config.ApplyJitter)))
+}
+
+// fails returns whether an attempt of an agent to apply a plan fails.
+func (s *Simulator) fails() bool {
+	if s.config.FailureRate == 0 {
+		return false
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.random.Float64() < s.config.FailureRate
+}
+
+func (s *Simulator) recordApply(duration time.Duration, failed bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if failed {
+		s.metrics.Failures++
+		return
+	}
+	s.metrics.Applies = append(s.metrics.Applies, duration)
+}
+
+// createCluster creates the objects of the simulated cluster as they are once its machines are provisioned, and the
+// agents of the machines.
+func (s *Simulator) createCluster() error {
+	var etcd, controlPlane, worker bool
+	for _, pool := range s.config.Pools {
+		if pool.Quantity < 0 {
+			return fmt.Errorf("quantity %d of pool %s must not be negative", pool.Quantity, pool.Name)
+		}
+		etcd = etcd || (pool.Etcd && pool.Quantity > 0)
+		controlPlane = controlPlane || (pool.ControlPlane && pool.Quantity > 0)
+		worker = worker || (pool.Worker && pool.Quantity > 0)
+	}
+	if !etcd || !controlPlane || !worker {
+		return errors.New("pools must have at least one etcd, control plane and worker node")
+	}
+
+	capiCluster := &capi.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterName,
+			Namespace: namespace,
+			UID:       types.UID("simulated-capi-cluster"),
+		},
+		Spec: capi.ClusterSpec{
+			ControlPlaneRef: &corev1.ObjectReference{
+				APIVersion: capr.RKEAPIVersion,
+				Kind:       "RKEControlPlane",
+				Name:       clusterName,
+				Namespace:  namespace,
+			},
+		},
+		Status: capi.ClusterStatus{
+			InfrastructureReady: true,
+		},
+	}
+	controlPlaneObj := &rkev1.RKEControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterName,
+			Namespace: namespace,
+			UID:       types.UID("simulated-control-plane"),
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: capi.GroupVersion.String(),
+				Kind:       "Cluster",
+				Name:       clusterName,
+				UID:        capiCluster.UID,
+			}},
+		},
+		Spec: rkev1.RKEControlPlaneSpec{
+			KubernetesVersion:     s.config.KubernetesVersion,
+			ClusterName:           clusterName,
+			ManagementClusterName: managementClusterName,
+		},
+	}
+	managementCluster := &v3.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: managementClusterName,
+		},
+	}
+	token := &v3.ClusterRegistrationToken{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "default-token",
+			Namespace: managementClusterName,
+		},
+		Spec: v3.ClusterRegistrationTokenSpec{
+			ClusterName: managementClusterName,
+		},
+		Status: v3.ClusterRegistrationTokenStatus{
+			Token: "simulated",
+		},
+	}
+
+	for kind, obj := range map[string]runtime.Object{
+		capiClusterKind:              capiCluster,
+		rkeControlPlaneKind:          controlPlaneObj,
+		managementClusterKind:        managementCluster,
+		clusterRegistrationTokenKind: token,
+	} {
+		if _, err := s.objects.create(kind, obj); err != nil {
+			return err
+		}
+	}
+
+	var i int
+	for _, pool := range s.config.Pools {
+		for n := 0; n < pool.Quantity; n++ {
+			i++
+			if err := s.createMachine(pool, fmt.Sprintf("%s-%s-%d", clusterName, pool.Name, n), i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// createMachine creates the i-th machine of the cluster, with its bootstrap, its plan secret and its agent.
+func (s *Simulator) createMachine(pool Pool, name string, i int) error {
+	address := fmt.Sprintf("10.%d.%d.%d", i>>16&255, i>>8&255, i&255)
+	now := metav1.Now()
+	machine := &capi.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				capi.ClusterLabelName: clusterName,
+			},
+		},
+		Spec: capi.MachineSpec{
+			ClusterName: clusterName,
+			Bootstrap: capi.Bootstrap{
+				ConfigRef: &corev1.ObjectReference{
+					APIVersion: capr.RKEAPIVersion,
+					Kind:       "RKEBootstrap",
+					Name:       name,
+					Namespace:  namespace,
+				},
+			},
+		},
+		Status: capi.MachineStatus{
+			Addresses: capi.MachineAddresses{{
+				Type:    capi.MachineInternalIP,
+				Address: address,
+			}},
+			Phase:               string(capi.MachinePhaseRunning),
+			BootstrapReady:      true,
+			InfrastructureReady: true,
+			Conditions: capi.Conditions{
+				{Type: capi.ReadyCondition, Status: corev1.ConditionTrue, LastTransitionTime: now},
+				{Type: capi.BootstrapReadyCondition, Status: corev1.ConditionTrue, LastTransitionTime: now},
+				{Type: capi.InfrastructureReadyCondition, Status: corev1.ConditionTrue, LastTransitionTime: now},
+			},
+		},
+	}
+
+	roles := map[string]string{}
+	if pool.Etcd {
+		roles[capr.EtcdRoleLabel] = "true"
+	}
+	if pool.ControlPlane {
+		roles[capr.ControlPlaneRoleLabel] = "true"
+	}
+	if pool.Worker {
+		roles[capr.WorkerRoleLabel] = "true"
+	}
+	bootstrap := &rkev1.RKEBootstrap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Labels:      roles,
+			Annotations: map[string]string{},
+		},
+		Spec: rkev1.RKEBootstrapSpec{
+			ClusterName: clusterName,
+		},
+	}
+
+	secretLabels := map[string]string{
+		capr.MachineNameLabel: name,
+		capr.ClusterNameLabel: clusterName,
+	}
+	for k, v := range roles {
+		secretLabels[k] = v
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        capr.PlanSecretFromBootstrapName(name),
+			Namespace:   namespace,
+			Labels:      secretLabels,
+			Annotations: map[string]string{},
+		},
+		Type: capr.SecretTypeMachinePlan,
+	}
+
+	for kind, obj := range map[string]runtime.Object{
+		machineKind:      machine,
+		rkeBootstrapKind: bootstrap,
+		secretKind:       secret,
+	} {
+		if _, err := s.objects.create(kind, obj); err != nil {
+			return err
+		}
+	}
+	s.agents[secret.Name] = newAgent(s, name, secret.Name, address)
+	return nil
+}
@@ -0,0 +1,135 @@
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDurations(t *testing.T) {
+	durations := Durations{4 * time.Second, time.Second, 3 * time.Second, 2 * time.Second}
+
+	assert.Equal(t, 10*time.Second, durations.Total())
+	assert.Equal(t, 2500*time.Millisecond, durations.Mean())
+	assert.Equal(t, 4*time.Second, durations.Max())
+	assert.Equal(t, time.Second, durations.Percentile(0))
+	assert.Equal(t, 2*time.Second, durations.Percentile(50))
+	assert.Equal(t, 3*time.Second, durations.Percentile(75))
+	assert.Equal(t, 4*time.Second, durations.Percentile(99))
+	assert.Equal(t, 4*time.Second, durations.Percentile(100))
+	// The durations aren't sorted in place.
+	assert.Equal(t, 4*time.Second, durations[0])
+
+	var empty Durations
+	assert.Zero(t, empty.Total())
+	assert.Zero(t, empty.Mean())
+	assert.Zero(t, empty.Max())
+	assert.Zero(t, empty.Percentile(99))
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{
+			name: "no etcd node",
+			config: Config{
+				Pools: []Pool{{Name: "cp", Quantity: 1, ControlPlane: true}, {Name: "worker", Quantity: 1, Worker: true}},
+			},
+		},
+		{
+			name: "empty worker pool",
+			config: Config{
+				Pools: []Pool{{Name: "all", Quantity: 1, Etcd: true, ControlPlane: true}, {Name: "worker", Worker: true}},
+			},
+		},
+		{
+			name: "negative quantity",
+			config: Config{
+				Pools: []Pool{{Name: "all", Quantity: 1, Etcd: true, ControlPlane: true, Worker: true}, {Name: "worker", Quantity: -1, Worker: true}},
+			},
+		},
+		{
+			name: "agents always fail",
+			config: Config{
+				Pools:       []Pool{{Name: "all", Quantity: 1, Etcd: true, ControlPlane: true, Worker: true}},
+				FailureRate: 1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(context.Background(), tt.config)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	config := Config{
+		Pools: []Pool{
+			{Name: "etcd-cp", Quantity: 3, Etcd: true, ControlPlane: true},
+			{Name: "worker", Quantity: 5, Worker: true},
+		},
+		ApplyLatency: time.Millisecond,
+		ApplyJitter:  5 * time.Millisecond,
+		FailureRate:  0.1,
+		Timeout:      time.Minute,
+		Seed:         1,
+	}
+
+	sim, err := New(context.Background(), config)
+	require.NoError(t, err)
+	metrics, err := sim.Run(context.Background())
+	require.NoError(t, err)
+	t.Log(metrics)
+
+	assert.True(t, metrics.Converged, "planner did not converge, last error: %v", metrics.LastError)
+	assert.Equal(t, 8, metrics.Nodes)
+	assert.NotEmpty(t, metrics.Reconciles)
+	// Every node applies at least one plan.
+	assert.GreaterOrEqual(t, len(metrics.Applies), metrics.Nodes)
+}
+
+// BenchmarkRun measures how the planner scales with the number of nodes of a cluster. Each iteration provisions a whole
+// cluster, the time per iteration is dominated by the latency of the agents, the reconcile metrics are those of the
+// planner.
+func BenchmarkRun(b *testing.B) {
+	for _, workers := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("nodes=%d", workers+3), func(b *testing.B) {
+			if workers >= 1000 && testing.Short() {
+				b.Skip("skipping large cluster in short mode")
+			}
+			config := Config{
+				Pools: []Pool{
+					{Name: "etcd", Quantity: 1, Etcd: true},
+					{Name: "cp", Quantity: 2, ControlPlane: true},
+					{Name: "worker", Quantity: workers, Worker: true},
+				},
+				ApplyLatency: time.Millisecond,
+				ApplyJitter:  10 * time.Millisecond,
+			}
+
+			var reconciles Durations
+			for i := 0; i < b.N; i++ {
+				config.Seed = int64(i)
+				sim, err := New(context.Background(), config)
+				require.NoError(b, err)
+				metrics, err := sim.Run(context.Background())
+				require.NoError(b, err)
+				require.True(b, metrics.Converged, "planner did not converge, last error: %v", metrics.LastError)
+				reconciles = append(reconciles, metrics.Reconciles...)
+			}
+
+			b.ReportMetric(float64(len(reconciles))/float64(b.N), "reconciles/op")
+			b.ReportMetric(float64(reconciles.Mean().Microseconds()), "reconcile-mean-µs")
+			b.ReportMetric(float64(reconciles.Percentile(99).Microseconds()), "reconcile-p99-µs")
+		})
+	}
+}
@@ -19,7 +19,9 @@
 	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
 	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
 	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/rancher/pkg/capr/planencryption"
 	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
+	rkecontrollers "github.com/rancher/rancher/pkg/generated/controllers/rke.cattle.io/v1"
 	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
 	"github.com/rancher/wrangler/pkg/generic"
 	corev1 "k8s.io/api/core/v1"
@@ -37,16 +39,20 @@
 )
 
 type PlanStore struct {
-	secrets      corecontrollers.SecretClient
-	secretsCache corecontrollers.SecretCache
-	machineCache capicontrollers.MachineCache
+	secrets              corecontrollers.SecretClient
+	secretsCache         corecontrollers.SecretCache
+	machineCache         capicontrollers.MachineCache
+	rkeControlPlaneCache rkecontrollers.RKEControlPlaneCache
+	encryptor            *planencryption.Encryptor
 }
 
-func NewStore(secrets corecontrollers.SecretController, machineCache capicontrollers.MachineCache) *PlanStore {
+func NewStore(secrets corecontrollers.SecretController, machineCache capicontrollers.MachineCache, rkeControlPlaneCache rkecontrollers.RKEControlPlaneCache, encryptor *planencryption.Encryptor) *PlanStore {
 	return &PlanStore{
-		secrets:      secrets,
-		secretsCache: secrets.Cache(),
-		machineCache: machineCache,
+		secrets:              secrets,
+		secretsCache:         secrets.Cache(),
+		machineCache:         machineCache,
+		rkeControlPlaneCache: rkeControlPlaneCache,
+		encryptor:            encryptor,
 	}
 }
 
@@ -103,7 +109,7 @@ func (p *PlanStore) Load(cluster *capi.Cluster, rkeControlPlane *rkev1.RKEContro
 			Labels:      secret.Labels,
 			Annotations: secret.Annotations,
 		}
-		node, err := SecretToNode(secret)
+		node, err := p.secretToNode(secret)
 		if err != nil {
 			return nil, anyPlanDelivered, err
 		}
@@ -160,12 +166,61 @@ func getPlanStatusReasonMessage(entry *planEntry) string {
 	case entry.Plan.InSync:
 		return ""
 	case entry.Plan.Failed:
+		if killed := timedOutInstructions(entry.Plan); len(killed) > 0 {
+			return fmt.Sprintf("%s: instructions timed out: %s", FailedPlanStatusMessage, strings.Join(killed, ", "))
+		}
 		return FailedPlanStatusMessage
 	default:
 		return WaitingPlanStatusMessage
 	}
 }
 
+// timedOutInstructions returns the sorted names of the instructions of the plan that the agent killed after they
+// exceeded their timeout, as recorded in the periodic output.
+func timedOutInstructions(node *plan.Node) []string {
+	var names []string
+	for name, output := range node.PeriodicOutput {
+		if output.TimedOut {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// getPlanStatusReason returns the waiting reason matching the message of getPlanStatusReasonMessage, empty if the plan
+// of the entry is in sync.
+func getPlanStatusReason(entry *planEntry) string {
+	switch {
+	case entry.Plan == nil || len(entry.Plan.Plan.Instructions) == 0:
+		return noPlanReason(entry)
+	case entry.Plan.AppliedPlan == nil:
+		if isEtcd(entry) {
+			return capr.WaitingForEtcdJoin
+		}
+		return capr.WaitingForAgent
+	case entry.Plan.Plan.Error != "":
+		return capr.PlanFailed
+	case !entry.Plan.Healthy:
+		return capr.WaitingForProbe
+	case entry.Plan.InSync:
+		return ""
+	case entry.Plan.Failed:
+		return capr.PlanFailed
+	default:
+		return capr.WaitingForPlan
+	}
+}
+
+func noPlanReason(entry *planEntry) string {
+	if isEtcd(entry) {
+		return capr.WaitingForEtcdJoin
+	} else if isControlPlane(entry) {
+		return capr.WaitingForEtcd
+	}
+	return capr.WaitingForControlPlane
+}
+
 // SecretToNode consumes a secret of type rke.cattle.io/machine-plan and returns a node object and an error if one exists
 func SecretToNode(secret *corev1.Secret) (*plan.Node, error) {
 	if secret == nil {
@@ -301,6 +356,42 @@ func PlanHash(plan []byte) string {
 	return hex.EncodeToString(result[:])
 }
 
+// secretToNode decrypts the plans of a plan secret before converting it to a node.
+func (p *PlanStore) secretToNode(secret *corev1.Secret) (*plan.Node, error) {
+	secret, err := p.encryptor.DecryptSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+	return SecretToNode(secret)
+}
+
+// encryptPlan encrypts the plan of a machine if its cluster encrypts plan secrets.
+func (p *PlanStore) encryptPlan(machine *capi.Machine, data []byte) ([]byte, error) {
+	controlPlane, err := p.rkeControlPlaneCache.Get(machine.Namespace, machine.Spec.ClusterName)
+	if err != nil {
+		return nil, err
+	}
+	if !controlPlane.Spec.EncryptPlanSecrets {
+		return data, nil
+	}
+	return p.encryptor.Encrypt(controlPlane, data)
+}
+
+// validateEncryption returns an error if the plan secrets of a control plane must be encrypted but can't be, so that
+// its plans are never stored in the clear.
+func (p *PlanStore) validateEncryption(controlPlane *rkev1.RKEControlPlane) error {
+	if !controlPlane.Spec.EncryptPlanSecrets {
+		return nil
+	}
+	if p.encryptor == nil {
+		return fmt.Errorf("plan secrets can't be encrypted")
+	}
+	if err := p.encryptor.ValidateKeyProvider(); err != nil {
+		return fmt.Errorf("plan secrets can't be encrypted: %w", err)
+	}
+	return nil
+}
+
 // getPlanSecrets retrieves the plan secrets for the given list of machines
 func (p *PlanStore) getPlanSecrets(machines []*capi.Machine) (map[string]*corev1.Secret, error) {
 	result := map[string]*corev1.Secret{}
@@ -398,6 +489,11 @@ func (p *PlanStore) UpdatePlan(entry *planEntry, newNodePlan plan.NodePlan, join
 	// If the plan is being updated, then delete the probe-statuses so their healthy status will be reported as healthy only when they pass.
 	delete(secret.Data, "probe-statuses")
 
+	data, err = p.encryptPlan(entry.Machine, data)
+	if err != nil {
+		return err
+	}
+
 	secret.Data["plan"] = data
 	if maxFailures > 0 || maxFailures == -1 {
 		secret.Data["max-failures"] = []byte(strconv.Itoa(maxFailures))
@@ -417,7 +513,7 @@ func (p *PlanStore) UpdatePlan(entry *planEntry, newNodePlan plan.NodePlan, join
 	}
 
 	// Update the node immediately so that future plan processing occurs
-	newNode, err := SecretToNode(updatedSecret)
+	newNode, err := p.secretToNode(updatedSecret)
 	if err != nil {
 		return err
 	}
@@ -438,7 +534,7 @@ func (p *PlanStore) updatePlanSecretLabelsAndAnnotations(entry *planEntry) error
 	if err != nil {
 		return err
 	}
-	newNode, err := SecretToNode(updatedSecret)
+	newNode, err := p.secretToNode(updatedSecret)
 	if err != nil {
 		return err
 	}
@@ -463,7 +559,7 @@ func (p *PlanStore) removePlanSecretLabel(entry *planEntry, key string) error {
 	if err != nil {
 		return err
 	}
-	newNode, err := SecretToNode(updatedSecret)
+	newNode, err := p.secretToNode(updatedSecret)
 	if err != nil {
 		return err
 	}
@@ -473,18 +569,18 @@ func (p *PlanStore) removePlanSecretLabel(entry *planEntry, key string) error {
 }
 
 // assignAndCheckPlan assigns the given newPlan to the designated server in the planEntry, and will return nil if the plan is assigned and in sync.
-func assignAndCheckPlan(store *PlanStore, msg string, entry *planEntry, newPlan plan.NodePlan, joinedTo string, failureThreshold, maxRetries int) error {
+func assignAndCheckPlan(store *PlanStore, msg, reason string, entry *planEntry, newPlan plan.NodePlan, joinedTo string, failureThreshold, maxRetries int) error {
 	if entry.Plan == nil || !equality.Semantic.DeepEqual(entry.Plan.Plan, newPlan) {
 		if err := store.UpdatePlan(entry, newPlan, joinedTo, failureThreshold, maxRetries); err != nil {
 			return err
 		}
-		return errWaiting(fmt.Sprintf("starting %s", msg))
+		return errWaitingf(reason, "starting %s", msg)
 	}
 	if entry.Plan.Failed {
 		return fmt.Errorf("operation %s failed", msg)
 	}
 	if !entry.Plan.InSync {
-		return errWaiting(fmt.Sprintf("waiting for %s", msg))
+		return errWaitingf(reason, "waiting for %s", msg)
 	}
 	return nil
 }
@@ -3,6 +3,8 @@
 import (
 	"testing"
 
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/rancher/rancher/pkg/capr"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -39,3 +41,84 @@ func TestJoinURLFromAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPlanStatusReason(t *testing.T) {
+	instructions := plan.NodePlan{Instructions: []plan.OneTimeInstruction{{}}}
+	etcd := &plan.Metadata{Labels: map[string]string{capr.EtcdRoleLabel: "true"}}
+	controlPlane := &plan.Metadata{Labels: map[string]string{capr.ControlPlaneRoleLabel: "true"}}
+	worker := &plan.Metadata{Labels: map[string]string{capr.WorkerRoleLabel: "true"}}
+
+	tests := []struct {
+		name     string
+		entry    *planEntry
+		expected string
+	}{
+		{
+			name:     "etcd without plan",
+			entry:    &planEntry{Metadata: etcd},
+			expected: capr.WaitingForEtcdJoin,
+		},
+		{
+			name:     "control plane without plan",
+			entry:    &planEntry{Metadata: controlPlane, Plan: &plan.Node{}},
+			expected: capr.WaitingForEtcd,
+		},
+		{
+			name:     "worker without plan",
+			entry:    &planEntry{Metadata: worker},
+			expected: capr.WaitingForControlPlane,
+		},
+		{
+			name:     "etcd plan not applied",
+			entry:    &planEntry{Metadata: etcd, Plan: &plan.Node{Plan: instructions}},
+			expected: capr.WaitingForEtcdJoin,
+		},
+		{
+			name:     "worker plan not applied",
+			entry:    &planEntry{Metadata: worker, Plan: &plan.Node{Plan: instructions}},
+			expected: capr.WaitingForAgent,
+		},
+		{
+			name:     "unhealthy",
+			entry:    &planEntry{Metadata: worker, Plan: &plan.Node{Plan: instructions, AppliedPlan: &instructions, InSync: true}},
+			expected: capr.WaitingForProbe,
+		},
+		{
+			name:     "in sync",
+			entry:    &planEntry{Metadata: worker, Plan: &plan.Node{Plan: instructions, AppliedPlan: &instructions, InSync: true, Healthy: true}},
+			expected: "",
+		},
+		{
+			name:     "failed",
+			entry:    &planEntry{Metadata: worker, Plan: &plan.Node{Plan: instructions, AppliedPlan: &instructions, Healthy: true, Failed: true}},
+			expected: capr.PlanFailed,
+		},
+		{
+			name:     "applying",
+			entry:    &planEntry{Metadata: worker, Plan: &plan.Node{Plan: instructions, AppliedPlan: &instructions, Healthy: true}},
+			expected: capr.WaitingForPlan,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getPlanStatusReason(tt.entry))
+		})
+	}
+}
+
+func TestTimedOutInstructions(t *testing.T) {
+	node := &plan.Node{
+		PeriodicOutput: map[string]plan.PeriodicInstructionOutput{
+			"rotate certificates": {Name: "rotate certificates", ExitCode: -1, TimedOut: true},
+			"capture-address":     {Name: "capture-address", Truncated: true},
+			"etcd-name":           {Name: "etcd-name", ExitCode: -1, TimedOut: true},
+		},
+	}
+	assert.Equal(t, []string{"etcd-name", "rotate certificates"}, timedOutInstructions(node))
+	assert.Empty(t, timedOutInstructions(&plan.Node{}))
+
+	instructions := plan.NodePlan{Instructions: []plan.OneTimeInstruction{{}}}
+	node.Plan, node.AppliedPlan, node.Healthy, node.Failed = instructions, &instructions, true, true
+	assert.Equal(t, FailedPlanStatusMessage+": instructions timed out: etcd-name, rotate certificates", getPlanStatusReasonMessage(&planEntry{Plan: node}))
+}
@@ -0,0 +1,187 @@
+package planner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/robfig/cron"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const defaultSystemChartValuesWindowDuration = time.Hour
+
+// updateSystemChartValues updates the state of the default values of the system charts in the status, holding back the
+// changes to the defaults as configured by the systemChartValuesUpdate of the control plane. The returned duration is
+// how long until a held back update is rolled out by the Scheduled strategy.
+func updateSystemChartValues(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPlaneStatus, clusterPlan *plan.Plan, now time.Time) (rkev1.RKEControlPlaneStatus, time.Duration, error) {
+	charts, err := systemChartDefaults(cp, clusterPlan)
+	if err != nil {
+		return status, 0, err
+	}
+	chartValuesHash, err := hashJSON(cp.Spec.ChartValues.Data)
+	if err != nil {
+		return status, 0, err
+	}
+	result, wait, err := systemChartValuesStatus(cp.Spec.SystemChartValuesUpdate, status.SystemChartValues, charts, chartValuesHash, now)
+	if err != nil {
+		return status, 0, err
+	}
+	if result.PendingHash != "" && (status.SystemChartValues == nil || status.SystemChartValues.PendingHash != result.PendingHash) {
+		logger.Infof("[planner] rkecluster %s/%s: holding back the update %s of the default values of the system charts %s",
+			cp.Namespace, cp.Name, result.PendingHash, strings.Join(result.PendingCharts, ", "))
+	}
+	status.SystemChartValues = result
+	return status, wait, nil
+}
+
+// systemChartDefaults returns the hash of the default values rancher renders for each system chart, over the machines
+// the system charts are configured on.
+func systemChartDefaults(cp *rkev1.RKEControlPlane, clusterPlan *plan.Plan) (map[string]string, error) {
+	defaults := cp.DeepCopy()
+	defaults.Spec.ChartValues.Data = nil
+
+	values := map[string][]string{}
+	for _, entry := range collect(clusterPlan, roleAnd(roleNot(isOnlyWorker), isNotDeleting)) {
+		chartValues, err := systemChartValues(defaults, entry)
+		if err != nil {
+			return nil, err
+		}
+		for chart, v := range chartValues {
+			data, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			values[chart] = append(values[chart], string(data))
+		}
+	}
+
+	result := map[string]string{}
+	for chart, v := range values {
+		sort.Strings(v)
+		result[chart] = hashString(strings.Join(v, "\n"))
+	}
+	return result, nil
+}
+
+// systemChartValuesStatus returns the state of the default values of the system charts, whose hashes are charts. The
+// defaults are rolled out when they're first seen, when the chart values of the cluster change, and as the strategy of
+// the update allows. Otherwise, they're pending, and the returned duration is how long until the next maintenance
+// window of the Scheduled strategy starts.
+func systemChartValuesStatus(update *rkev1.SystemChartValuesUpdate, current *rkev1.SystemChartValuesStatus, charts map[string]string,
+	chartValuesHash string, now time.Time) (*rkev1.SystemChartValuesStatus, time.Duration, error) {
+	hash, err := hashJSON(charts)
+	if err != nil {
+		return current, 0, err
+	}
+	applied := &rkev1.SystemChartValuesStatus{
+		AppliedHash:     hash,
+		AppliedCharts:   charts,
+		ChartValuesHash: chartValuesHash,
+	}
+	if current == nil || current.AppliedHash == "" || current.AppliedHash == hash || current.ChartValuesHash != chartValuesHash {
+		return applied, 0, nil
+	}
+
+	var wait time.Duration
+	strategy := rkev1.SystemChartValuesUpdateAuto
+	if update != nil && update.Strategy != "" {
+		strategy = update.Strategy
+	}
+	switch strategy {
+	case rkev1.SystemChartValuesUpdateAuto:
+		return applied, 0, nil
+	case rkev1.SystemChartValuesUpdateManual:
+		if update.ApprovedHash == hash {
+			return applied, 0, nil
+		}
+	case rkev1.SystemChartValuesUpdateScheduled:
+		inWindow, next, err := systemChartValuesWindow(update, now)
+		if err != nil {
+			return current, 0, err
+		}
+		if inWindow {
+			return applied, 0, nil
+		}
+		wait = next.Sub(now)
+	default:
+		return current, 0, fmt.Errorf("unknown system chart values update strategy %s", strategy)
+	}
+
+	result := current.DeepCopy()
+	if result.PendingHash != hash {
+		result.PendingHash = hash
+		result.PendingCharts = changedCharts(current.AppliedCharts, charts)
+		result.PendingSince = &metav1.Time{Time: now}
+	}
+	return result, wait, nil
+}
+
+// systemChartValuesWindow returns whether now is in a maintenance window of the Scheduled strategy, and when the next
+// maintenance window starts if it isn't.
+func systemChartValuesWindow(update *rkev1.SystemChartValuesUpdate, now time.Time) (bool, time.Time, error) {
+	schedule, err := cron.ParseStandard(update.MaintenanceWindow)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("invalid system chart values maintenance window %q: %w", update.MaintenanceWindow, err)
+	}
+	duration := defaultSystemChartValuesWindowDuration
+	if update.MaintenanceWindowDuration != nil && update.MaintenanceWindowDuration.Duration > 0 {
+		duration = update.MaintenanceWindowDuration.Duration
+	}
+	start := schedule.Next(now.Add(-duration))
+	return !start.After(now), start, nil
+}
+
+// changedCharts returns the sorted charts whose hash was added, changed or removed.
+func changedCharts(previous, current map[string]string) []string {
+	var result []string
+	for chart, hash := range current {
+		if previous[chart] != hash {
+			result = append(result, chart)
+		}
+	}
+	for chart := range previous {
+		if _, ok := current[chart]; !ok {
+			result = append(result, chart)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// systemChartValuesHeld returns whether the default values of the system charts are held back.
+func systemChartValuesHeld(status *rkev1.RKEControlPlaneStatus) bool {
+	return status.SystemChartValues != nil && status.SystemChartValues.PendingHash != ""
+}
+
+// previousFile returns the file of the path in the current plan of the entry, or the passed file if the plan has none.
+func previousFile(entry *planEntry, file plan.File) plan.File {
+	if entry.Plan == nil {
+		return file
+	}
+	for _, f := range entry.Plan.Plan.Files {
+		if f.Path == file.Path {
+			return f
+		}
+	}
+	return file
+}
+
+func hashJSON(obj interface{}) (string, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	return hashString(string(data)), nil
+}
+
+func hashString(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
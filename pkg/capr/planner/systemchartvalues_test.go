@@ -0,0 +1,138 @@
+package planner
+
+import (
+	"testing"
+	"time"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSystemChartValuesStatus(t *testing.T) {
+	now := time.Date(2023, 5, 3, 1, 30, 0, 0, time.UTC)
+	previous := map[string]string{"rke2-calico": "a", "rancher-vsphere-cpi": "b"}
+	charts := map[string]string{"rke2-calico": "a", "rancher-vsphere-cpi": "c"}
+	previousHash, err := hashJSON(previous)
+	require.NoError(t, err)
+	hash, err := hashJSON(charts)
+	require.NoError(t, err)
+	current := &rkev1.SystemChartValuesStatus{
+		AppliedHash:     previousHash,
+		AppliedCharts:   previous,
+		ChartValuesHash: "values",
+	}
+	applied := &rkev1.SystemChartValuesStatus{
+		AppliedHash:     hash,
+		AppliedCharts:   charts,
+		ChartValuesHash: "values",
+	}
+	pending := &rkev1.SystemChartValuesStatus{
+		AppliedHash:     previousHash,
+		AppliedCharts:   previous,
+		ChartValuesHash: "values",
+		PendingHash:     hash,
+		PendingCharts:   []string{"rancher-vsphere-cpi"},
+		PendingSince:    &metav1.Time{Time: now},
+	}
+
+	tests := []struct {
+		name            string
+		update          *rkev1.SystemChartValuesUpdate
+		current         *rkev1.SystemChartValuesStatus
+		chartValuesHash string
+		expected        *rkev1.SystemChartValuesStatus
+		expectedWait    time.Duration
+		expectedErr     bool
+	}{
+		{
+			name:            "first seen",
+			update:          &rkev1.SystemChartValuesUpdate{Strategy: rkev1.SystemChartValuesUpdateManual},
+			chartValuesHash: "values",
+			expected:        applied,
+		},
+		{
+			name:            "auto by default",
+			current:         current,
+			chartValuesHash: "values",
+			expected:        applied,
+		},
+		{
+			name:            "manual pending",
+			update:          &rkev1.SystemChartValuesUpdate{Strategy: rkev1.SystemChartValuesUpdateManual, ApprovedHash: previousHash},
+			current:         current,
+			chartValuesHash: "values",
+			expected:        pending,
+		},
+		{
+			name:            "manual still pending",
+			update:          &rkev1.SystemChartValuesUpdate{Strategy: rkev1.SystemChartValuesUpdateManual},
+			current:         &rkev1.SystemChartValuesStatus{AppliedHash: previousHash, AppliedCharts: previous, ChartValuesHash: "values", PendingHash: hash, PendingCharts: []string{"rancher-vsphere-cpi"}, PendingSince: &metav1.Time{Time: now.Add(-time.Hour)}},
+			chartValuesHash: "values",
+			expected:        &rkev1.SystemChartValuesStatus{AppliedHash: previousHash, AppliedCharts: previous, ChartValuesHash: "values", PendingHash: hash, PendingCharts: []string{"rancher-vsphere-cpi"}, PendingSince: &metav1.Time{Time: now.Add(-time.Hour)}},
+		},
+		{
+			name:            "manual approved",
+			update:          &rkev1.SystemChartValuesUpdate{Strategy: rkev1.SystemChartValuesUpdateManual, ApprovedHash: hash},
+			current:         current,
+			chartValuesHash: "values",
+			expected:        applied,
+		},
+		{
+			name:            "chart values changed",
+			update:          &rkev1.SystemChartValuesUpdate{Strategy: rkev1.SystemChartValuesUpdateManual},
+			current:         current,
+			chartValuesHash: "other",
+			expected:        &rkev1.SystemChartValuesStatus{AppliedHash: hash, AppliedCharts: charts, ChartValuesHash: "other"},
+		},
+		{
+			name:            "scheduled in window",
+			update:          &rkev1.SystemChartValuesUpdate{Strategy: rkev1.SystemChartValuesUpdateScheduled, MaintenanceWindow: "0 1 * * *"},
+			current:         current,
+			chartValuesHash: "values",
+			expected:        applied,
+		},
+		{
+			name:            "scheduled outside window",
+			update:          &rkev1.SystemChartValuesUpdate{Strategy: rkev1.SystemChartValuesUpdateScheduled, MaintenanceWindow: "0 1 * * *", MaintenanceWindowDuration: &metav1.Duration{Duration: 15 * time.Minute}},
+			current:         current,
+			chartValuesHash: "values",
+			expected:        pending,
+			expectedWait:    23*time.Hour + 30*time.Minute,
+		},
+		{
+			name:            "scheduled invalid window",
+			update:          &rkev1.SystemChartValuesUpdate{Strategy: rkev1.SystemChartValuesUpdateScheduled, MaintenanceWindow: "never"},
+			current:         current,
+			chartValuesHash: "values",
+			expectedErr:     true,
+		},
+		{
+			name:            "unknown strategy",
+			update:          &rkev1.SystemChartValuesUpdate{Strategy: "Never"},
+			current:         current,
+			chartValuesHash: "values",
+			expectedErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, wait, err := systemChartValuesStatus(tt.update, tt.current, charts, tt.chartValuesHash, now)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.expectedWait, wait)
+		})
+	}
+}
+
+func TestChangedCharts(t *testing.T) {
+	assert.Equal(t, []string{"rancher-vsphere-cpi", "rancher-vsphere-csi", "rke2-coredns"}, changedCharts(
+		map[string]string{"rke2-calico": "a", "rancher-vsphere-cpi": "b", "rancher-vsphere-csi": "c"},
+		map[string]string{"rke2-calico": "a", "rancher-vsphere-cpi": "d", "rke2-coredns": "e"}))
+	assert.Empty(t, changedCharts(map[string]string{"rke2-calico": "a"}, map[string]string{"rke2-calico": "a"}))
+}
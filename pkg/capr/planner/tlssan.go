@@ -0,0 +1,145 @@
+package planner
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/wrangler/pkg/data/convert"
+	"github.com/rancher/wrangler/pkg/kv"
+)
+
+const (
+	tlsSANArg = "tls-san"
+
+	// tlsSANBaseRestartStampEnv is set on the install instruction of nodes whose restart stamp was kept when SANs were
+	// added. It holds the restart stamp of the plan without its SANs, so that the stamp keeps being reused as long as
+	// nothing but SANs is added.
+	tlsSANBaseRestartStampEnv = "TLS_SAN_BASE_RESTART_STAMP"
+)
+
+// tlsSANRestartStamp returns the restart stamp of the previous plan of the entry when the only change of the new plan
+// is SANs added to its config file, so that adding SANs doesn't restart the node. The SANs are instead added to the
+// serving certificates by the runtime at the next restart, which a change to anything else triggers. It also returns
+// the restart stamp of the new plan without its SANs, which is set on the install instruction so that later plans can
+// tell that the SANs were deferred, and that the TLSSANsPending condition of the control plane reports them.
+func tlsSANRestartStamp(nodePlan plan.NodePlan, controlPlane *rkev1.RKEControlPlane, entry *planEntry, image string) (string, string, bool) {
+	if entry.Plan == nil || isOnlyWorker(entry) || windows(entry) {
+		return "", "", false
+	}
+
+	previousPlan := entry.Plan.Plan
+	previousStamp := getRestartStamp(&previousPlan)
+	if previousStamp == "" {
+		return "", "", false
+	}
+
+	previousSANs, previousBase, err := restartStampWithoutTLSSAN(previousPlan, controlPlane, image)
+	if err != nil {
+		return "", "", false
+	}
+	sans, base, err := restartStampWithoutTLSSAN(nodePlan, controlPlane, image)
+	if err != nil || base != previousBase || !containsAll(sans, previousSANs) {
+		return "", "", false
+	}
+
+	if deferredBase := getInstructionEnv(&previousPlan, tlsSANBaseRestartStampEnv); deferredBase != "" {
+		// SANs were already deferred, the stamp is kept as long as nothing but SANs changed since.
+		if deferredBase != base {
+			return "", "", false
+		}
+		return previousStamp, base, true
+	}
+
+	// The previous plan restarted the node with its config, the stamp is kept if SANs were added since and the
+	// control plane didn't otherwise change the restart stamp, such as with a new provision generation.
+	if len(sans) == len(previousSANs) || restartStamp(previousPlan, controlPlane, image) != previousStamp {
+		return "", "", false
+	}
+	return previousStamp, base, true
+}
+
+// setTLSSANsPending sets the TLSSANsPending condition of the control plane to whether the plan of any machine added SANs
+// without restarting its runtime, so that users know which SANs aren't served until the next restart.
+func setTLSSANsPending(status rkev1.RKEControlPlaneStatus, clusterPlan *plan.Plan) rkev1.RKEControlPlaneStatus {
+	var machines []string
+	for _, entry := range collect(clusterPlan, hasPendingTLSSANs) {
+		machines = append(machines, entry.Machine.Name)
+	}
+	if len(machines) == 0 {
+		capr.TLSSANsPending.False(&status)
+		capr.TLSSANsPending.Message(&status, "")
+		return status
+	}
+	sort.Strings(machines)
+	capr.TLSSANsPending.True(&status)
+	capr.TLSSANsPending.Message(&status, fmt.Sprintf("TLS SANs added to machine(s) %s are served once their runtime is next restarted",
+		strings.Join(machines, ", ")))
+	return status
+}
+
+// hasPendingTLSSANs returns whether the plan of the entry added SANs without restarting the runtime.
+func hasPendingTLSSANs(entry *planEntry) bool {
+	return entry.Plan != nil && getInstructionEnv(&entry.Plan.Plan, tlsSANBaseRestartStampEnv) != ""
+}
+
+// restartStampWithoutTLSSAN returns the SANs of the config file of the node plan, and the restart stamp of the node plan
+// with the SANs removed from its config file.
+func restartStampWithoutTLSSAN(nodePlan plan.NodePlan, controlPlane *rkev1.RKEControlPlane, image string) ([]string, string, error) {
+	configPath := fmt.Sprintf(ConfigYamlFileName, capr.GetRuntime(controlPlane.Spec.KubernetesVersion))
+
+	var sans []string
+	files := make([]plan.File, 0, len(nodePlan.Files))
+	for _, file := range nodePlan.Files {
+		if file.Path == configPath {
+			content, err := base64.StdEncoding.DecodeString(file.Content)
+			if err != nil {
+				return nil, "", err
+			}
+			config := map[string]interface{}{}
+			if err := json.Unmarshal(content, &config); err != nil {
+				return nil, "", err
+			}
+			sans = convert.ToStringSlice(config[tlsSANArg])
+			delete(config, tlsSANArg)
+			content, err = json.MarshalIndent(config, "", "  ")
+			if err != nil {
+				return nil, "", err
+			}
+			file.Content = base64.StdEncoding.EncodeToString(content)
+		}
+		files = append(files, file)
+	}
+
+	return sans, restartStamp(plan.NodePlan{Files: files}, controlPlane, image), nil
+}
+
+// getInstructionEnv returns the value of the environment variable of the first instruction of the node plan setting it.
+func getInstructionEnv(nodePlan *plan.NodePlan, name string) string {
+	for _, instruction := range nodePlan.Instructions {
+		for _, env := range instruction.Env {
+			if k, v := kv.Split(env, "="); k == name {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+func containsAll(values, subset []string) bool {
+	present := map[string]bool{}
+	for _, value := range values {
+		present[value] = true
+	}
+	for _, value := range subset {
+		if !present[value] {
+			return false
+		}
+	}
+	return true
+}
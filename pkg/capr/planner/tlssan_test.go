@@ -0,0 +1,151 @@
+package planner
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/rancher/pkg/provisioningv2/image"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func tlsSANNodePlan(t *testing.T, runtime string, config map[string]interface{}) plan.NodePlan {
+	data, err := json.MarshalIndent(config, "", "  ")
+	require.NoError(t, err)
+	return plan.NodePlan{
+		Files: []plan.File{
+			{
+				Content: base64.StdEncoding.EncodeToString(data),
+				Path:    fmt.Sprintf(ConfigYamlFileName, runtime),
+			},
+		},
+	}
+}
+
+func tlsSANPlanEntry(controlPlaneRole bool, previous *plan.NodePlan) *planEntry {
+	entry := createTestPlanEntry("linux")
+	if controlPlaneRole {
+		entry.Metadata.Labels[capr.ControlPlaneRoleLabel] = "true"
+		entry.Metadata.Labels[capr.WorkerRoleLabel] = "false"
+	}
+	if previous != nil {
+		entry.Plan = &plan.Node{Plan: *previous}
+	}
+	return entry
+}
+
+func TestPlanner_addInstallInstructionWithRestartStamp_TLSSAN(t *testing.T) {
+	var planner Planner
+	planner.retrievalFunctions.SystemAgentImage = func() string { return "rancher/system-agent-installer-" }
+	planner.retrievalFunctions.ImageResolver = image.ResolveWithControlPlane
+
+	rke2 := createTestControlPlane("v1.25.9+rke2r1")
+	generate := func(config map[string]interface{}, controlPlaneRole bool, previous *plan.NodePlan) plan.NodePlan {
+		nodePlan, err := planner.addInstallInstructionWithRestartStamp(tlsSANNodePlan(t, capr.RuntimeRKE2, config), rke2, tlsSANPlanEntry(controlPlaneRole, previous))
+		require.NoError(t, err)
+		return nodePlan
+	}
+
+	initial := generate(map[string]interface{}{"tls-san": []string{"a.example.com"}}, true, nil)
+	initialStamp := getRestartStamp(&initial)
+	require.NotEmpty(t, initialStamp)
+	assert.Empty(t, getInstructionEnv(&initial, tlsSANBaseRestartStampEnv))
+	assert.Len(t, initial.Instructions, 1)
+
+	// adding a SAN keeps the restart stamp, the SANs are deferred to the next restart
+	added := generate(map[string]interface{}{"tls-san": []string{"a.example.com", "10.0.0.10"}}, true, &initial)
+	assert.Equal(t, initialStamp, getRestartStamp(&added))
+	assert.NotEmpty(t, getInstructionEnv(&added, tlsSANBaseRestartStampEnv))
+	assert.Len(t, added.Instructions, 1)
+	assert.Len(t, added.Files, 1)
+
+	// the plan is stable while nothing else changes
+	again := generate(map[string]interface{}{"tls-san": []string{"a.example.com", "10.0.0.10"}}, true, &added)
+	assert.Equal(t, added, again)
+
+	// another SAN is still deferred
+	more := generate(map[string]interface{}{"tls-san": []string{"a.example.com", "10.0.0.10", "b.example.com"}}, true, &again)
+	assert.Equal(t, initialStamp, getRestartStamp(&more))
+	assert.Equal(t, getInstructionEnv(&added, tlsSANBaseRestartStampEnv), getInstructionEnv(&more, tlsSANBaseRestartStampEnv))
+
+	// any other change restarts the node with the SANs
+	changed := generate(map[string]interface{}{"tls-san": []string{"a.example.com", "10.0.0.10", "b.example.com"}, "kubelet-arg": []string{"max-pods=200"}}, true, &more)
+	assert.NotEqual(t, initialStamp, getRestartStamp(&changed))
+	assert.Empty(t, getInstructionEnv(&changed, tlsSANBaseRestartStampEnv))
+	assert.Len(t, changed.Instructions, 1)
+
+	// removing a SAN restarts the node
+	removed := generate(map[string]interface{}{"tls-san": []string{"a.example.com", "10.0.0.10"}, "kubelet-arg": []string{"max-pods=200"}}, true, &changed)
+	assert.NotEqual(t, getRestartStamp(&changed), getRestartStamp(&removed))
+	assert.Len(t, removed.Instructions, 1)
+
+	// a new provision generation restarts the node
+	rke2.Spec.ProvisionGeneration = 1
+	regenerated := generate(map[string]interface{}{"tls-san": []string{"a.example.com", "10.0.0.10"}}, true, &initial)
+	assert.NotEqual(t, initialStamp, getRestartStamp(&regenerated))
+	rke2.Spec.ProvisionGeneration = 0
+
+	// etcd only nodes pick up the SANs at their next restart
+	etcd := tlsSANPlanEntry(false, &initial)
+	etcd.Metadata.Labels[capr.EtcdRoleLabel] = "true"
+	etcd.Metadata.Labels[capr.WorkerRoleLabel] = "false"
+	etcdPlan, err := planner.addInstallInstructionWithRestartStamp(tlsSANNodePlan(t, capr.RuntimeRKE2, map[string]interface{}{"tls-san": []string{"a.example.com", "10.0.0.10"}}), rke2, etcd)
+	require.NoError(t, err)
+	assert.Equal(t, initialStamp, getRestartStamp(&etcdPlan))
+	assert.Len(t, etcdPlan.Instructions, 1)
+
+	// worker nodes are unaffected
+	workerInitial := generate(map[string]interface{}{"tls-san": []string{"a.example.com"}}, false, nil)
+	worker := generate(map[string]interface{}{"tls-san": []string{"a.example.com", "10.0.0.10"}}, false, &workerInitial)
+	assert.NotEqual(t, getRestartStamp(&workerInitial), getRestartStamp(&worker))
+}
+
+func TestPlanner_addInstallInstructionWithRestartStamp_TLSSANK3s(t *testing.T) {
+	var planner Planner
+	planner.retrievalFunctions.SystemAgentImage = func() string { return "rancher/system-agent-installer-" }
+	planner.retrievalFunctions.ImageResolver = image.ResolveWithControlPlane
+
+	k3s := createTestControlPlane("v1.25.9+k3s1")
+	initial, err := planner.addInstallInstructionWithRestartStamp(tlsSANNodePlan(t, capr.RuntimeK3S, map[string]interface{}{"tls-san": []string{"a.example.com"}}), k3s, tlsSANPlanEntry(true, nil))
+	require.NoError(t, err)
+
+	// the SANs are deferred to the next restart
+	added, err := planner.addInstallInstructionWithRestartStamp(tlsSANNodePlan(t, capr.RuntimeK3S, map[string]interface{}{"tls-san": []string{"a.example.com", "10.0.0.10"}}), k3s, tlsSANPlanEntry(true, &initial))
+	require.NoError(t, err)
+	assert.Equal(t, getRestartStamp(&initial), getRestartStamp(&added))
+	assert.Len(t, added.Instructions, 1)
+	assert.NotEmpty(t, getInstructionEnv(&added, tlsSANBaseRestartStampEnv))
+}
+
+func TestSetTLSSANsPending(t *testing.T) {
+	clusterPlan := &plan.Plan{
+		Machines: map[string]*capi.Machine{},
+		Nodes:    map[string]*plan.Node{},
+		Metadata: map[string]*plan.Metadata{},
+	}
+	addMachine := func(name string, env ...string) {
+		clusterPlan.Machines[name] = &capi.Machine{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		clusterPlan.Nodes[name] = &plan.Node{Plan: plan.NodePlan{Instructions: []plan.OneTimeInstruction{{Env: env}}}}
+	}
+	addMachine("restarted", "RESTART_STAMP=b")
+	addMachine("pending-b", "RESTART_STAMP=a", tlsSANBaseRestartStampEnv+"=c")
+	addMachine("pending-a", "RESTART_STAMP=a", tlsSANBaseRestartStampEnv+"=c")
+	clusterPlan.Machines["new"] = &capi.Machine{ObjectMeta: metav1.ObjectMeta{Name: "new"}}
+
+	status := setTLSSANsPending(rkev1.RKEControlPlaneStatus{}, clusterPlan)
+	assert.True(t, capr.TLSSANsPending.IsTrue(&status))
+	assert.Equal(t, "TLS SANs added to machine(s) pending-a, pending-b are served once their runtime is next restarted", capr.TLSSANsPending.GetMessage(&status))
+
+	delete(clusterPlan.Machines, "pending-a")
+	delete(clusterPlan.Machines, "pending-b")
+	status = setTLSSANsPending(status, clusterPlan)
+	assert.True(t, capr.TLSSANsPending.IsFalse(&status))
+	assert.Empty(t, capr.TLSSANsPending.GetMessage(&status))
+}
@@ -0,0 +1,157 @@
+package capr
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+)
+
+const (
+	// PlannerBehaviorInstructionTimeouts kills and retries the periodic and certificate rotation instructions of the
+	// system agent that hang, instead of waiting for them indefinitely.
+	PlannerBehaviorInstructionTimeouts = "instruction-timeouts"
+	// PlannerBehaviorCustomProbes adds the custom health probes of the cluster to the plans of its machines.
+	PlannerBehaviorCustomProbes = "custom-probes"
+	// PlannerBehaviorConfigDropIns delivers the config drop-ins of the cluster to its machines. The drop-ins delivered
+	// before the behavior was disabled are removed.
+	PlannerBehaviorConfigDropIns = "config-drop-ins"
+	// PlannerBehaviorLockPools serializes the operations restarting etcd across the clusters of the same lock pools.
+	PlannerBehaviorLockPools = "lock-pools"
+)
+
+// PlannerBehavior is a behavior of the planner which can be enabled or disabled per cluster.
+type PlannerBehavior struct {
+	Name        string
+	Description string
+	Default     bool
+}
+
+// PlannerBehaviors are the planner behaviors which can be enabled or disabled per cluster, and whether they're enabled
+// by default. New behaviors are disabled by default, so that clusters opt in through their plannerBehaviors or the
+// planner-behavior-defaults setting, until they're proven on test clusters.
+var PlannerBehaviors = []PlannerBehavior{
+	{
+		Name:        PlannerBehaviorConfigDropIns,
+		Description: "Deliver the config drop-ins of the cluster to its machines",
+		Default:     false,
+	},
+	{
+		Name:        PlannerBehaviorCustomProbes,
+		Description: "Add the custom health probes of the cluster to the plans of its machines",
+		Default:     false,
+	},
+	{
+		Name:        PlannerBehaviorInstructionTimeouts,
+		Description: "Kill and retry the periodic and certificate rotation instructions that hang",
+		Default:     false,
+	},
+	{
+		Name:        PlannerBehaviorLockPools,
+		Description: "Serialize the operations restarting etcd across the clusters of the same lock pools",
+		Default:     false,
+	},
+}
+
+// ValidatePlannerBehaviors returns an error for each planner behavior of a cluster which doesn't exist.
+func ValidatePlannerBehaviors(behaviors map[string]bool) []string {
+	var violations []string
+	for name := range behaviors {
+		if getPlannerBehavior(name) == nil {
+			violations = append(violations, fmt.Sprintf("unknown planner behavior %s, must be one of %s", name, strings.Join(plannerBehaviorNames(), ", ")))
+		}
+	}
+	sort.Strings(violations)
+	return violations
+}
+
+// ParsePlannerBehaviorDefaults parses the planner-behavior-defaults setting, a comma separated list of name=true or
+// name=false overriding the default of the planner behaviors.
+func ParsePlannerBehaviorDefaults(setting string) (map[string]bool, error) {
+	defaults := map[string]bool{}
+	for _, item := range strings.Split(setting, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid planner behavior default %s, must be name=true or name=false", item)
+		}
+		name = strings.TrimSpace(name)
+		if getPlannerBehavior(name) == nil {
+			return nil, fmt.Errorf("unknown planner behavior %s, must be one of %s", name, strings.Join(plannerBehaviorNames(), ", "))
+		}
+		enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid planner behavior default %s: %w", item, err)
+		}
+		defaults[name] = enabled
+	}
+	return defaults, nil
+}
+
+// ResolvePlannerBehaviors returns whether each planner behavior is active for a cluster, according to the behaviors of
+// the cluster, or else the defaults of the planner-behavior-defaults setting, or else the default of the behavior.
+func ResolvePlannerBehaviors(behaviors, defaults map[string]bool) []rkev1.PlannerBehaviorStatus {
+	result := make([]rkev1.PlannerBehaviorStatus, 0, len(PlannerBehaviors))
+	for _, behavior := range PlannerBehaviors {
+		status := rkev1.PlannerBehaviorStatus{
+			Name:        behavior.Name,
+			Description: behavior.Description,
+			Enabled:     behavior.Default,
+			Source:      rkev1.PlannerBehaviorSourceDefault,
+		}
+		if enabled, ok := behaviors[behavior.Name]; ok {
+			status.Enabled = enabled
+			status.Source = rkev1.PlannerBehaviorSourceCluster
+		} else if enabled, ok := defaults[behavior.Name]; ok {
+			status.Enabled = enabled
+			status.Source = rkev1.PlannerBehaviorSourceSetting
+		}
+		result = append(result, status)
+	}
+	return result
+}
+
+// IgnoredPlannerBehaviorFields returns a message for each field set in the spec of a cluster which is ignored, as the
+// planner behavior using it is disabled according to the statuses. Instruction timeouts have no fields to ignore.
+func IgnoredPlannerBehaviorFields(spec *rkev1.RKEClusterSpecCommon, statuses []rkev1.PlannerBehaviorStatus) []string {
+	configured := map[string]bool{
+		PlannerBehaviorConfigDropIns: len(spec.MachineSelectorConfigDropIns) > 0,
+		PlannerBehaviorCustomProbes:  len(spec.MachineSelectorProbes) > 0,
+		PlannerBehaviorLockPools:     len(spec.LockPools) > 0,
+	}
+	fields := map[string]string{
+		PlannerBehaviorConfigDropIns: "machineSelectorConfigDropIns",
+		PlannerBehaviorCustomProbes:  "machineSelectorProbes",
+		PlannerBehaviorLockPools:     "lockPools",
+	}
+
+	var result []string
+	for _, status := range statuses {
+		if configured[status.Name] && !status.Enabled {
+			result = append(result, fmt.Sprintf("%s is ignored while the %s planner behavior is disabled", fields[status.Name], status.Name))
+		}
+	}
+	return result
+}
+
+func getPlannerBehavior(name string) *PlannerBehavior {
+	for i := range PlannerBehaviors {
+		if PlannerBehaviors[i].Name == name {
+			return &PlannerBehaviors[i]
+		}
+	}
+	return nil
+}
+
+func plannerBehaviorNames() []string {
+	names := make([]string, 0, len(PlannerBehaviors))
+	for _, behavior := range PlannerBehaviors {
+		names = append(names, behavior.Name)
+	}
+	return names
+}
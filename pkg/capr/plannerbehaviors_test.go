@@ -0,0 +1,124 @@
+package capr
+
+import (
+	"testing"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePlannerBehaviors(t *testing.T) {
+	assert.Empty(t, ValidatePlannerBehaviors(nil))
+	assert.Empty(t, ValidatePlannerBehaviors(map[string]bool{
+		PlannerBehaviorCustomProbes: false,
+		PlannerBehaviorLockPools:    true,
+	}))
+	assert.Equal(t, []string{
+		"unknown planner behavior new-drain, must be one of config-drop-ins, custom-probes, instruction-timeouts, lock-pools",
+	}, ValidatePlannerBehaviors(map[string]bool{
+		PlannerBehaviorCustomProbes: false,
+		"new-drain":                 true,
+	}))
+}
+
+func TestParsePlannerBehaviorDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		setting  string
+		expected map[string]bool
+		wantErr  bool
+	}{
+		{
+			name:     "empty",
+			setting:  "",
+			expected: map[string]bool{},
+		},
+		{
+			name:    "defaults",
+			setting: " custom-probes=false, lock-pools = true,",
+			expected: map[string]bool{
+				PlannerBehaviorCustomProbes: false,
+				PlannerBehaviorLockPools:    true,
+			},
+		},
+		{
+			name:    "missing value",
+			setting: "custom-probes",
+			wantErr: true,
+		},
+		{
+			name:    "invalid value",
+			setting: "custom-probes=maybe",
+			wantErr: true,
+		},
+		{
+			name:    "unknown behavior",
+			setting: "new-drain=true",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defaults, err := ParsePlannerBehaviorDefaults(tt.setting)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, defaults)
+		})
+	}
+}
+
+func TestResolvePlannerBehaviors(t *testing.T) {
+	statuses := ResolvePlannerBehaviors(map[string]bool{
+		PlannerBehaviorCustomProbes: true,
+		PlannerBehaviorLockPools:    false,
+	}, map[string]bool{
+		PlannerBehaviorCustomProbes:  false,
+		PlannerBehaviorConfigDropIns: false,
+	})
+
+	enabled := map[string]bool{}
+	sources := map[string]rkev1.PlannerBehaviorSource{}
+	for _, status := range statuses {
+		enabled[status.Name] = status.Enabled
+		sources[status.Name] = status.Source
+	}
+	assert.Len(t, statuses, len(PlannerBehaviors))
+	assert.Equal(t, map[string]bool{
+		PlannerBehaviorConfigDropIns:       false,
+		PlannerBehaviorCustomProbes:        true,
+		PlannerBehaviorInstructionTimeouts: false,
+		PlannerBehaviorLockPools:           false,
+	}, enabled)
+	assert.Equal(t, map[string]rkev1.PlannerBehaviorSource{
+		PlannerBehaviorConfigDropIns:       rkev1.PlannerBehaviorSourceSetting,
+		PlannerBehaviorCustomProbes:        rkev1.PlannerBehaviorSourceCluster,
+		PlannerBehaviorInstructionTimeouts: rkev1.PlannerBehaviorSourceDefault,
+		PlannerBehaviorLockPools:           rkev1.PlannerBehaviorSourceCluster,
+	}, sources)
+}
+
+func TestIgnoredPlannerBehaviorFields(t *testing.T) {
+	spec := &rkev1.RKEClusterSpecCommon{
+		MachineSelectorConfigDropIns: []rkev1.RKEConfigDropIn{{}},
+		LockPools:                    []string{"harvester"},
+	}
+	statuses := ResolvePlannerBehaviors(map[string]bool{PlannerBehaviorLockPools: true}, nil)
+
+	assert.Equal(t, []string{
+		"machineSelectorConfigDropIns is ignored while the config-drop-ins planner behavior is disabled",
+	}, IgnoredPlannerBehaviorFields(spec, statuses))
+
+	spec.MachineSelectorProbes = []rkev1.RKEProbe{{}}
+	statuses = ResolvePlannerBehaviors(nil, nil)
+	assert.Equal(t, []string{
+		"machineSelectorConfigDropIns is ignored while the config-drop-ins planner behavior is disabled",
+		"machineSelectorProbes is ignored while the custom-probes planner behavior is disabled",
+		"lockPools is ignored while the lock-pools planner behavior is disabled",
+	}, IgnoredPlannerBehaviorFields(spec, statuses))
+
+	assert.Empty(t, IgnoredPlannerBehaviorFields(&rkev1.RKEClusterSpecCommon{}, statuses))
+}
@@ -0,0 +1,203 @@
+// Package planredaction scrubs tokens, private keys and passwords from the output of plan instructions, so that they
+// are neither stored in machine plan secrets nor shown through the API.
+package planredaction
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sync"
+
+	"github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1/plan"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// Redacted replaces the sensitive values found in the output of instructions.
+	Redacted = "[REDACTED]"
+
+	// OutputField and PeriodicOutputField are the fields of plan secrets the agent stores the output of one-time and
+	// periodic instructions in, as gzipped JSON.
+	OutputField         = "applied-output"
+	PeriodicOutputField = "applied-periodic-output"
+)
+
+type rule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// defaultRules are always applied. Rules matching a key and its value keep the key, so that the output stays readable.
+var defaultRules = []rule{
+	{
+		pattern:     regexp.MustCompile(`-----BEGIN [A-Z0-9 ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z0-9 ]*PRIVATE KEY-----`),
+		replacement: Redacted,
+	},
+	{
+		// K3s and RKE2 tokens.
+		pattern:     regexp.MustCompile(`K10[0-9a-f]+::[^\s:"']+:[^\s"']+`),
+		replacement: Redacted,
+	},
+	{
+		// JSON web tokens, such as service account tokens.
+		pattern:     regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`),
+		replacement: Redacted,
+	},
+	{
+		pattern:     regexp.MustCompile(`(?i)(\bbearer\s+)[A-Za-z0-9._~+/-]+=*`),
+		replacement: "${1}" + Redacted,
+	},
+	{
+		pattern:     regexp.MustCompile(`(?i)(\b[a-z0-9_-]*(?:token|password|passwd|secret|access[_-]?key)["']?\s*[:=]\s*["']?)[^\s"',]+`),
+		replacement: "${1}" + Redacted,
+	},
+}
+
+// Redactor redacts the default rules, and additional patterns whose whole matches are redacted.
+type Redactor struct {
+	rules []rule
+}
+
+// New returns a redactor redacting the default rules and the given patterns.
+func New(patterns []string) (*Redactor, error) {
+	r := &Redactor{rules: defaultRules}
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", pattern, err)
+		}
+		r.rules = append(r.rules, rule{pattern: re, replacement: Redacted})
+	}
+	return r, nil
+}
+
+var (
+	currentLock     sync.Mutex
+	currentPatterns string
+	current         *Redactor
+)
+
+// Current returns the redactor for the patterns of the plan-output-redaction-patterns setting. If the setting is
+// invalid, only the default rules are redacted.
+func Current() *Redactor {
+	patterns := settings.PlanOutputRedactionPatterns.Get()
+
+	currentLock.Lock()
+	defer currentLock.Unlock()
+	if current != nil && currentPatterns == patterns {
+		return current
+	}
+
+	r, err := parse(patterns)
+	if err != nil {
+		logrus.Errorf("[planredaction] ignoring setting %s: %v", settings.PlanOutputRedactionPatterns.Name, err)
+		r = &Redactor{rules: defaultRules}
+	}
+	current, currentPatterns = r, patterns
+	return current
+}
+
+// parse returns the redactor for a JSON list of patterns.
+func parse(patterns string) (*Redactor, error) {
+	var list []string
+	if patterns != "" {
+		if err := json.Unmarshal([]byte(patterns), &list); err != nil {
+			return nil, fmt.Errorf("patterns must be a JSON list of regular expressions: %w", err)
+		}
+	}
+	return New(list)
+}
+
+// Redact returns the output with its sensitive values redacted.
+func (r *Redactor) Redact(output []byte) []byte {
+	for _, rule := range r.rules {
+		output = rule.pattern.ReplaceAll(output, []byte(rule.replacement))
+	}
+	return output
+}
+
+// RedactData redacts the output of the instructions stored in the data of a plan secret, in place, and returns whether
+// anything was redacted. Output that can't be read is left as is.
+func (r *Redactor) RedactData(data map[string][]byte) (bool, error) {
+	var changed bool
+
+	if len(data[OutputField]) > 0 {
+		output := map[string][]byte{}
+		if err := unmarshal(data[OutputField], &output); err != nil {
+			return false, fmt.Errorf("reading %s: %w", OutputField, err)
+		}
+		var redacted bool
+		for name, value := range output {
+			if v := r.Redact(value); !bytes.Equal(v, value) {
+				output[name] = v
+				redacted = true
+			}
+		}
+		if redacted {
+			value, err := marshal(output)
+			if err != nil {
+				return false, err
+			}
+			data[OutputField] = value
+			changed = true
+		}
+	}
+
+	if len(data[PeriodicOutputField]) > 0 {
+		output := map[string]plan.PeriodicInstructionOutput{}
+		if err := unmarshal(data[PeriodicOutputField], &output); err != nil {
+			return changed, fmt.Errorf("reading %s: %w", PeriodicOutputField, err)
+		}
+		var redacted bool
+		for name, value := range output {
+			stdout, stderr := r.Redact(value.Stdout), r.Redact(value.Stderr)
+			if !bytes.Equal(stdout, value.Stdout) || !bytes.Equal(stderr, value.Stderr) {
+				value.Stdout, value.Stderr = stdout, stderr
+				output[name] = value
+				redacted = true
+			}
+		}
+		if redacted {
+			value, err := marshal(output)
+			if err != nil {
+				return changed, err
+			}
+			data[PeriodicOutputField] = value
+			changed = true
+		}
+	}
+
+	return changed, nil
+}
+
+func unmarshal(data []byte, obj interface{}) error {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	content, err := io.ReadAll(gz)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(content, obj)
+}
+
+func marshal(obj interface{}) ([]byte, error) {
+	content, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(content); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,79 @@
+package capr
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	EtcdTaintKey               = "node-role.kubernetes.io/etcd"
+	ControlPlaneTaintKey       = "node-role.kubernetes.io/control-plane"
+	CriticalAddonsOnlyTaintKey = "CriticalAddonsOnly"
+)
+
+// RoleTaints returns the taints of the nodes of the roles, which keep the workloads of the users off the etcd and
+// control plane nodes unless they're also workers.
+func RoleTaints(runtime string, etcd, controlPlane, worker bool) []corev1.Taint {
+	if worker {
+		return nil
+	}
+
+	var result []corev1.Taint
+	// k3s charts do not have correct tolerations when the master node is both controlplane and etcd
+	if etcd && (runtime != RuntimeK3S || !controlPlane) {
+		result = append(result, corev1.Taint{
+			Key:    EtcdTaintKey,
+			Effect: corev1.TaintEffectNoExecute,
+		})
+	}
+	if controlPlane {
+		result = append(result, corev1.Taint{
+			Key:    ControlPlaneTaintKey,
+			Effect: corev1.TaintEffectNoSchedule,
+		})
+		// k3s servers run an agent, the dedicated control plane servers are left to the packaged components, which
+		// tolerate the CriticalAddonsOnly taint, instead of disabling their agent
+		if runtime == RuntimeK3S {
+			result = append(result, corev1.Taint{
+				Key:    CriticalAddonsOnlyTaintKey,
+				Value:  "true",
+				Effect: corev1.TaintEffectNoExecute,
+			})
+		}
+	}
+	return result
+}
+
+// MergeRoleTaints returns the taints of a node, the role taints after the taints given to the node, dropping the
+// given taints of the same key and effect as a role taint as nodes refuse duplicate taints.
+func MergeRoleTaints(taints, roleTaints []corev1.Taint) []corev1.Taint {
+	var result []corev1.Taint
+	for _, taint := range taints {
+		if !hasTaint(roleTaints, taint) {
+			result = append(result, taint)
+		}
+	}
+	return append(result, roleTaints...)
+}
+
+// ValidateMachinePoolTaints returns an error if a taint of a machine pool has the key and effect of one of the role
+// taints of the pool, such as a custom etcd taint letting the workloads tolerating it onto the etcd-only nodes.
+func ValidateMachinePoolTaints(runtime string, etcd, controlPlane, worker bool, taints []corev1.Taint) error {
+	roleTaints := RoleTaints(runtime, etcd, controlPlane, worker)
+	for _, taint := range taints {
+		if hasTaint(roleTaints, taint) {
+			return fmt.Errorf("taint %s:%s is set for the roles of the pool", taint.Key, taint.Effect)
+		}
+	}
+	return nil
+}
+
+func hasTaint(taints []corev1.Taint, taint corev1.Taint) bool {
+	for _, t := range taints {
+		if t.Key == taint.Key && t.Effect == taint.Effect {
+			return true
+		}
+	}
+	return false
+}
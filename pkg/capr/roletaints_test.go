@@ -0,0 +1,62 @@
+package capr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var (
+	etcdTaint         = corev1.Taint{Key: EtcdTaintKey, Effect: corev1.TaintEffectNoExecute}
+	controlPlaneTaint = corev1.Taint{Key: ControlPlaneTaintKey, Effect: corev1.TaintEffectNoSchedule}
+	criticalTaint     = corev1.Taint{Key: CriticalAddonsOnlyTaintKey, Value: "true", Effect: corev1.TaintEffectNoExecute}
+)
+
+func TestRoleTaints(t *testing.T) {
+	tests := []struct {
+		name                       string
+		runtime                    string
+		etcd, controlPlane, worker bool
+		expected                   []corev1.Taint
+	}{
+		{name: "rke2 worker", runtime: RuntimeRKE2, worker: true},
+		{name: "rke2 all roles", runtime: RuntimeRKE2, etcd: true, controlPlane: true, worker: true},
+		{name: "rke2 etcd only", runtime: RuntimeRKE2, etcd: true, expected: []corev1.Taint{etcdTaint}},
+		{name: "rke2 control plane only", runtime: RuntimeRKE2, controlPlane: true, expected: []corev1.Taint{controlPlaneTaint}},
+		{name: "rke2 etcd and control plane", runtime: RuntimeRKE2, etcd: true, controlPlane: true, expected: []corev1.Taint{etcdTaint, controlPlaneTaint}},
+		{name: "k3s etcd only", runtime: RuntimeK3S, etcd: true, expected: []corev1.Taint{etcdTaint}},
+		{name: "k3s control plane only", runtime: RuntimeK3S, controlPlane: true, expected: []corev1.Taint{controlPlaneTaint, criticalTaint}},
+		{name: "k3s etcd and control plane", runtime: RuntimeK3S, etcd: true, controlPlane: true, expected: []corev1.Taint{controlPlaneTaint, criticalTaint}},
+		{name: "k3s control plane and worker", runtime: RuntimeK3S, controlPlane: true, worker: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, RoleTaints(tt.runtime, tt.etcd, tt.controlPlane, tt.worker))
+		})
+	}
+}
+
+func TestMergeRoleTaints(t *testing.T) {
+	custom := corev1.Taint{Key: "dedicated", Value: "infra", Effect: corev1.TaintEffectNoSchedule}
+	customEtcd := corev1.Taint{Key: EtcdTaintKey, Value: "true", Effect: corev1.TaintEffectNoExecute}
+	etcdNoSchedule := corev1.Taint{Key: EtcdTaintKey, Effect: corev1.TaintEffectNoSchedule}
+
+	assert.Equal(t, []corev1.Taint{custom, etcdNoSchedule, etcdTaint},
+		MergeRoleTaints([]corev1.Taint{custom, customEtcd, etcdNoSchedule}, []corev1.Taint{etcdTaint}))
+	assert.Equal(t, []corev1.Taint{custom}, MergeRoleTaints([]corev1.Taint{custom}, nil))
+	assert.Nil(t, MergeRoleTaints(nil, nil))
+}
+
+func TestValidateMachinePoolTaints(t *testing.T) {
+	custom := corev1.Taint{Key: "dedicated", Value: "infra", Effect: corev1.TaintEffectNoSchedule}
+	customEtcd := corev1.Taint{Key: EtcdTaintKey, Value: "true", Effect: corev1.TaintEffectNoExecute}
+
+	assert.NoError(t, ValidateMachinePoolTaints(RuntimeRKE2, true, false, false, []corev1.Taint{custom}))
+	assert.EqualError(t, ValidateMachinePoolTaints(RuntimeRKE2, true, false, false, []corev1.Taint{custom, customEtcd}),
+		"taint node-role.kubernetes.io/etcd:NoExecute is set for the roles of the pool")
+	// workers aren't tainted for their roles
+	assert.NoError(t, ValidateMachinePoolTaints(RuntimeRKE2, true, false, true, []corev1.Taint{customEtcd}))
+	assert.Error(t, ValidateMachinePoolTaints(RuntimeK3S, false, true, false, []corev1.Taint{criticalTaint}))
+	assert.NoError(t, ValidateMachinePoolTaints(RuntimeRKE2, false, true, false, []corev1.Taint{criticalTaint}))
+}
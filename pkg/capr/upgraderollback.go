@@ -0,0 +1,59 @@
+package capr
+
+import (
+	"encoding/json"
+	"fmt"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+)
+
+const (
+	// UpgradeRollbackAnnotation is the rollback of the Kubernetes upgrade of a cluster requested through the API, set
+	// on the cluster.
+	UpgradeRollbackAnnotation = "rke.cattle.io/upgrade-rollback"
+	// RestoreRKEConfigKubernetesVersion restores the Kubernetes version of a cluster along with an etcd snapshot.
+	RestoreRKEConfigKubernetesVersion = "kubernetesVersion"
+
+	snapshotFailed = "failed"
+)
+
+// PreUpgradeSnapshot returns the newest etcd snapshot taken before the cluster was upgraded to the Kubernetes version,
+// and the version the snapshot was taken at. Snapshots that failed, are missing, or don't record the spec of the
+// cluster are skipped.
+func PreUpgradeSnapshot(snapshots []*rkev1.ETCDSnapshot, kubernetesVersion string) (*rkev1.ETCDSnapshot, string, error) {
+	var (
+		result  *rkev1.ETCDSnapshot
+		version string
+	)
+	for _, snapshot := range snapshots {
+		if snapshot.Status.Missing || snapshot.SnapshotFile.Status == snapshotFailed || snapshot.SnapshotFile.CreatedAt == nil {
+			continue
+		}
+		if result != nil && !result.SnapshotFile.CreatedAt.Before(snapshot.SnapshotFile.CreatedAt) {
+			continue
+		}
+		spec, err := ParseSnapshotClusterSpecOrError(snapshot)
+		if err != nil || spec.KubernetesVersion == "" || spec.KubernetesVersion == kubernetesVersion {
+			continue
+		}
+		result, version = snapshot, spec.KubernetesVersion
+	}
+	if result == nil {
+		return nil, "", fmt.Errorf("no etcd snapshot taken before the upgrade to kubernetes version %s", kubernetesVersion)
+	}
+	return result, version, nil
+}
+
+// ParseUpgradeRollback returns the rollback of the upgrade of a cluster recorded in its upgrade rollback annotation,
+// nil if no rollback was requested.
+func ParseUpgradeRollback(annotations map[string]string) (*rkev1.UpgradeRollback, error) {
+	data := annotations[UpgradeRollbackAnnotation]
+	if data == "" {
+		return nil, nil
+	}
+	rollback := &rkev1.UpgradeRollback{}
+	if err := json.Unmarshal([]byte(data), rollback); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", UpgradeRollbackAnnotation, err)
+	}
+	return rollback, nil
+}
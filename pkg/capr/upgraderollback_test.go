@@ -0,0 +1,71 @@
+package capr
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testSnapshot(t *testing.T, name, kubernetesVersion string, createdAt time.Time) *rkev1.ETCDSnapshot {
+	snapshot := &rkev1.ETCDSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}
+	created := metav1.NewTime(createdAt)
+	snapshot.SnapshotFile.CreatedAt = &created
+	snapshot.SnapshotFile.Status = "successful"
+	if kubernetesVersion != "" {
+		spec, err := CompressInterface(provv1.ClusterSpec{KubernetesVersion: kubernetesVersion})
+		require.NoError(t, err)
+		metadata, err := json.Marshal(map[string]string{"provisioning-cluster-spec": spec})
+		require.NoError(t, err)
+		snapshot.SnapshotFile.Metadata = base64.StdEncoding.EncodeToString(metadata)
+	}
+	return snapshot
+}
+
+func TestPreUpgradeSnapshot(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	failed := testSnapshot(t, "failed", "v1.25.9+rke2r1", now.Add(-time.Hour))
+	failed.SnapshotFile.Status = "failed"
+	missing := testSnapshot(t, "missing", "v1.25.9+rke2r1", now.Add(-2*time.Hour))
+	missing.Status.Missing = true
+	snapshots := []*rkev1.ETCDSnapshot{
+		testSnapshot(t, "older", "v1.25.9+rke2r1", now.Add(-48*time.Hour)),
+		testSnapshot(t, "during-upgrade", "v1.26.4+rke2r1", now),
+		failed,
+		missing,
+		testSnapshot(t, "without-spec", "", now.Add(-3*time.Hour)),
+		testSnapshot(t, "pre-upgrade", "v1.25.9+rke2r1", now.Add(-4*time.Hour)),
+		testSnapshot(t, "before-previous-upgrade", "v1.24.13+rke2r1", now.Add(-72*time.Hour)),
+	}
+
+	snapshot, version, err := PreUpgradeSnapshot(snapshots, "v1.26.4+rke2r1")
+	require.NoError(t, err)
+	assert.Equal(t, "pre-upgrade", snapshot.Name)
+	assert.Equal(t, "v1.25.9+rke2r1", version)
+
+	_, _, err = PreUpgradeSnapshot(snapshots[1:2], "v1.26.4+rke2r1")
+	assert.Error(t, err)
+}
+
+func TestParseUpgradeRollback(t *testing.T) {
+	rollback, err := ParseUpgradeRollback(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, rollback)
+
+	rollback, err = ParseUpgradeRollback(map[string]string{
+		UpgradeRollbackAnnotation: `{"id":"r1","snapshotName":"pre-upgrade","fromVersion":"v1.26.4+rke2r1","toVersion":"v1.25.9+rke2r1"}`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &rkev1.UpgradeRollback{ID: "r1", SnapshotName: "pre-upgrade", FromVersion: "v1.26.4+rke2r1", ToVersion: "v1.25.9+rke2r1"}, rollback)
+
+	_, err = ParseUpgradeRollback(map[string]string{UpgradeRollbackAnnotation: "{"})
+	assert.Error(t, err)
+}
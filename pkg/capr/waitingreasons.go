@@ -0,0 +1,35 @@
+package capr
+
+// Reasons of the Ready and Reconciled conditions of RKE control planes, and of the Reconciled condition of machines, set
+// by the planner while it's waiting. The message of the conditions details what is waited on.
+const (
+	// WaitingReason is the reason set when the planner is waiting on nothing more specific.
+	WaitingReason = "Waiting"
+
+	WaitingForInfrastructure        = "WaitingForInfrastructure"
+	WaitingForMachineRegistration   = "WaitingForMachineRegistration"
+	WaitingForMachines              = "WaitingForMachines"
+	WaitingForMachineDeletion       = "WaitingForMachineDeletion"
+	WaitingForPause                 = "WaitingForPause"
+	WaitingForInitNode              = "WaitingForInitNode"
+	WaitingForJoinURL               = "WaitingForJoinURL"
+	WaitingForEtcd                  = "WaitingForEtcd"
+	WaitingForEtcdJoin              = "WaitingForEtcdJoin"
+	WaitingForControlPlane          = "WaitingForControlPlane"
+	WaitingForAgent                 = "WaitingForAgent"
+	WaitingForClusterAgent          = "WaitingForClusterAgent"
+	WaitingForPlan                  = "WaitingForPlan"
+	WaitingForProbe                 = "WaitingForProbe"
+	WaitingForDrain                 = "WaitingForDrain"
+	WaitingForUncordon              = "WaitingForUncordon"
+	WaitingForKubelet               = "WaitingForKubelet"
+	WaitingForSystemUpgradeChart    = "WaitingForSystemUpgradeChart"
+	WaitingForEtcdSnapshot          = "WaitingForEtcdSnapshot"
+	WaitingForEtcdRestore           = "WaitingForEtcdRestore"
+	WaitingForCertificateRotation   = "WaitingForCertificateRotation"
+	WaitingForEncryptionKeyRotation = "WaitingForEncryptionKeyRotation"
+	WaitingForLockPool              = "WaitingForLockPool"
+
+	// PlanFailed is the reason set on machines whose plan failed to apply, the planner waiting for it to be retried.
+	PlanFailed = "PlanFailed"
+)
@@ -9,7 +9,7 @@
 
 	client "github.com/rancher/rancher/pkg/client/generated/management/v3"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -28,7 +28,7 @@ func isUpToDate(commit string, catalog *v3.Catalog) bool {
 }
 
 func setRefreshed(catalog *v3.Catalog) bool {
-	logrus.Debugf("Catalog %s is already up to date", catalog.Name)
+	logger.Debugf("Catalog %s is already up to date", catalog.Name)
 	if !v32.CatalogConditionRefreshed.IsTrue(catalog) {
 		v32.CatalogConditionRefreshed.True(catalog)
 		v32.CatalogConditionRefreshed.Reason(catalog, "")
@@ -66,13 +66,13 @@ func (m *Manager) deleteTemplates(key string, namespace string) error {
 		for {
 			for k := range templates {
 				if err := m.templateClient.DeleteNamespaced(namespace, k, &metav1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
-					logrus.Warnf("Deleting template %v doesn't succeed. Continue loop", k)
+					logger.Warnf("Deleting template %v doesn't succeed. Continue loop", k)
 					continue
 				}
 			}
 			for k := range tvToDelete {
 				if err := m.templateVersionClient.DeleteNamespaced(namespace, k, &metav1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
-					logrus.Warnf("Deleting templateVersion %v doesn't succeed. Continue loop", k)
+					logger.Warnf("Deleting templateVersion %v doesn't succeed. Continue loop", k)
 					continue
 				}
 			}
@@ -6,9 +6,9 @@
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
 	helmlib "github.com/rancher/rancher/pkg/helm"
 	"github.com/rancher/rancher/pkg/image"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/namespace"
 	"github.com/rancher/rancher/pkg/settings"
-	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -46,7 +46,7 @@ func (m *Manager) Sync(key string, obj *v3.Catalog) (runtime.Object, error) {
 	if err != nil {
 		return m.updateCatalogError(catalog, err)
 	}
-	logrus.Debugf("Chart hash comparison for global catalog %v: new -- %v --- current -- %v", catalog.Name, commit, catalog.Status.Commit)
+	logger.Debugf("Chart hash comparison for global catalog %v: new -- %v --- current -- %v", catalog.Name, commit, catalog.Status.Commit)
 
 	if isUpToDate(commit, catalog) {
 		if setRefreshed(catalog) {
@@ -59,7 +59,7 @@ func (m *Manager) Sync(key string, obj *v3.Catalog) (runtime.Object, error) {
 		catalog: catalog,
 	}
 
-	logrus.Infof("Updating global catalog %s", catalog.Name)
+	logger.Infof("Updating global catalog %s", catalog.Name)
 	err = m.traverseAndUpdate(helm, commit, cmt)
 	if err != nil {
 		return nil, err
@@ -93,7 +93,7 @@ func CreateOrUpdateSystemCatalogImageCache(systemCatalog *v3.Catalog, configMapI
 
 	// if the cache does not exist generate it
 	if err != nil && errors.IsNotFound(err) {
-		logrus.Debug("system catalog image cache configmap not found")
+		logger.Debug("system catalog image cache configmap not found")
 
 		systemCatalogImageCache = &v1.ConfigMap{}
 		systemCatalogImageCache.Name = systemCatalogImageCacheName
@@ -109,7 +109,7 @@ func CreateOrUpdateSystemCatalogImageCache(systemCatalog *v3.Catalog, configMapI
 			return
 		}
 		systemCatalogImageCacheCreated = true
-		logrus.Debug("system catalog image cache configmap created")
+		logger.Debug("system catalog image cache configmap created")
 
 	} else if err != nil {
 		return
@@ -129,7 +129,7 @@ func CreateOrUpdateSystemCatalogImageCache(systemCatalog *v3.Catalog, configMapI
 		if err != nil {
 			return
 		}
-		logrus.Debug("system catalog image cache updated")
+		logger.Debug("system catalog image cache updated")
 	}
 
 	return
@@ -3,7 +3,7 @@
 import (
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
 	helmlib "github.com/rancher/rancher/pkg/helm"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/cache"
@@ -35,7 +35,7 @@ func (m *Manager) ClusterCatalogSync(key string, obj *v3.ClusterCatalog) (runtim
 	if err != nil {
 		return m.updateClusterCatalogError(clusterCatalog, err)
 	}
-	logrus.Debugf("Chart hash comparison for cluster catalog %v: new -- %v --- current -- %v", clusterCatalog.Name, commit, &clusterCatalog.Catalog.Status.Commit)
+	logger.Debugf("Chart hash comparison for cluster catalog %v: new -- %v --- current -- %v", clusterCatalog.Name, commit, &clusterCatalog.Catalog.Status.Commit)
 
 	if isUpToDate(commit, &clusterCatalog.Catalog) {
 		if setRefreshed(&clusterCatalog.Catalog) {
@@ -49,6 +49,6 @@ func (m *Manager) ClusterCatalogSync(key string, obj *v3.ClusterCatalog) (runtim
 		clusterCatalog: clusterCatalog,
 	}
 
-	logrus.Infof("Updating cluster catalog %s", clusterCatalog.Name)
+	logger.Infof("Updating cluster catalog %s", clusterCatalog.Name)
 	return nil, m.traverseAndUpdate(helm, commit, cmt)
 }
@@ -9,7 +9,7 @@
 	"github.com/pkg/errors"
 	"github.com/rancher/rancher/pkg/catalog/utils"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -32,7 +32,7 @@ func (m *Manager) createTemplate(template v3.CatalogTemplate, catalog *v3.Catalo
 		template.Spec.Versions[i].Readme = ""
 		template.Spec.Versions[i].AppReadme = ""
 	}
-	logrus.Debugf("Creating template %s", template.Name)
+	logger.Debugf("Creating template %s", template.Name)
 	createdTemplate, err := m.templateClient.Create(&template)
 	if err != nil {
 		return errors.Wrapf(err, "failed to create template %s", template.Name)
@@ -75,7 +75,7 @@ func (m *Manager) updateTemplate(template *v3.CatalogTemplate, toUpdate v3.Catal
 		templateVersion.Spec = toUpdateVer
 		if tv, ok := tvByVersion[toUpdateVer.Version]; ok {
 			if !reflect.DeepEqual(tv.Spec, toUpdateVer) {
-				logrus.Debugf("Updating templateVersion %v", tv.Name)
+				logger.Debugf("Updating templateVersion %v", tv.Name)
 				newObject := tv.DeepCopy()
 				newObject.Spec = templateVersion.Spec
 				if _, err := m.templateVersionClient.Update(newObject); err != nil {
@@ -91,7 +91,7 @@ func (m *Manager) updateTemplate(template *v3.CatalogTemplate, toUpdate v3.Catal
 			}
 			toCreate.Spec = templateVersion.Spec
 			toCreate.Status = v32.TemplateVersionStatus{HelmVersion: template.Status.HelmVersion}
-			logrus.Debugf("Creating templateVersion %v", toCreate.Name)
+			logger.Debugf("Creating templateVersion %v", toCreate.Name)
 			if _, err := m.templateVersionClient.Create(toCreate); err != nil {
 				return err
 			}
@@ -105,7 +105,7 @@ func (m *Manager) updateTemplate(template *v3.CatalogTemplate, toUpdate v3.Catal
 	}
 	for v, tv := range tvByVersion {
 		if _, ok := toUpdateTvs[v]; !ok {
-			logrus.Infof("Deleting templateVersion %s", tv.Name)
+			logger.Infof("Deleting templateVersion %s", tv.Name)
 			if err := m.templateVersionClient.DeleteNamespaced(template.Namespace, tv.Name, &metav1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
 				return err
 			}
@@ -185,7 +185,7 @@ func (m *Manager) createTemplateVersions(catalogName string, versionsSpec []v32.
 		}}
 		templateVersion.OwnerReferences = ownerRef
 
-		logrus.Debugf("Creating templateVersion %s", templateVersion.Name)
+		logger.Debugf("Creating templateVersion %s", templateVersion.Name)
 		if _, err := m.templateVersionClient.Create(templateVersion); err != nil && !kerrors.IsAlreadyExists(err) {
 			return err
 		}
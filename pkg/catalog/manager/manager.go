@@ -15,13 +15,15 @@
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
 	projectv3 "github.com/rancher/rancher/pkg/generated/norman/project.cattle.io/v3"
 	helmlib "github.com/rancher/rancher/pkg/helm"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/namespace"
 	"github.com/rancher/rancher/pkg/settings"
-	"github.com/sirupsen/logrus"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+var logger = logging.Logger(logging.Catalog)
+
 // IncompatibleTemplateVersionErr is returned when there's no valid template version for the current Kubernetes cluster
 type IncompatibleTemplateVersionErr error
 
@@ -103,7 +105,7 @@ func getKey(namespace, name string) string {
 func (m *Manager) DeleteOldTemplateContent() bool {
 	// Template content is not used, remove old contents
 	if err := m.templateContentClient.DeleteCollection(&metav1.DeleteOptions{}, metav1.ListOptions{}); err != nil {
-		logrus.Warnf("Catalog-manager error deleting old template content: %s", err)
+		logger.Warnf("Catalog-manager error deleting old template content: %s", err)
 		return false
 	}
 	return true
@@ -116,7 +118,7 @@ func (m *Manager) DeleteBadCatalogTemplates() bool {
 		return true
 	}
 	for _, err := range errs {
-		logrus.Errorf("Catalog-manager error deleting bad catalog templates: %s", err)
+		logger.Errorf("Catalog-manager error deleting bad catalog templates: %s", err)
 	}
 	return false
 }
@@ -188,7 +190,7 @@ func (m *Manager) deleteBadCatalogTemplates() []error {
 	for _, templateVersion := range templateVersions.Items {
 		ns, name, _, _, _, err := common.SplitExternalID(templateVersion.Spec.ExternalID)
 		if err != nil {
-			logrus.Errorf("Catalog-manager error extracting namespace/name from template version: %s", err)
+			logger.Errorf("Catalog-manager error extracting namespace/name from template version: %s", err)
 			continue
 		}
 		if ns == "" {
@@ -203,7 +205,7 @@ func (m *Manager) deleteBadCatalogTemplates() []error {
 	}
 
 	if deleteCount > 0 {
-		logrus.Infof("Catalog-manager deleted %d orphaned catalog template entries", deleteCount)
+		logger.Infof("Catalog-manager deleted %d orphaned catalog template entries", deleteCount)
 	}
 
 	return errs
@@ -222,7 +224,7 @@ func (m *Manager) ValidateKubeVersion(template *v3.CatalogTemplateVersion, clust
 	}
 	constraint, err := semver.ParseRange(template.Spec.KubeVersion)
 	if err != nil {
-		logrus.Errorf("failed to parse constraint for kubeversion %s: %v", template.Spec.KubeVersion, err)
+		logger.Errorf("failed to parse constraint for kubeversion %s: %v", template.Spec.KubeVersion, err)
 		return nil
 	}
 
@@ -274,7 +276,7 @@ func (m *Manager) ValidateRancherVersion(template *v3.CatalogTemplateVersion, cu
 	}
 	constraint, err := semver.ParseRange(versionRange)
 	if err != nil {
-		logrus.Errorf("failed to parse constraint for rancher version %s: %v", versionRange, err)
+		logger.Errorf("failed to parse constraint for rancher version %s: %v", versionRange, err)
 		return nil
 	}
 
@@ -3,7 +3,7 @@
 import (
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
 	helmlib "github.com/rancher/rancher/pkg/helm"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/cache"
@@ -35,7 +35,7 @@ func (m *Manager) ProjectCatalogSync(key string, obj *v3.ProjectCatalog) (runtim
 	if err != nil {
 		return m.updateProjectCatalogError(projectCatalog, err)
 	}
-	logrus.Debugf("Chart hash comparison for project catalog %v: new -- %v --- current -- %v", projectCatalog.Name, commit, &projectCatalog.Catalog.Status.Commit)
+	logger.Debugf("Chart hash comparison for project catalog %v: new -- %v --- current -- %v", projectCatalog.Name, commit, &projectCatalog.Catalog.Status.Commit)
 
 	if isUpToDate(commit, &projectCatalog.Catalog) {
 		if setRefreshed(&projectCatalog.Catalog) {
@@ -49,6 +49,6 @@ func (m *Manager) ProjectCatalogSync(key string, obj *v3.ProjectCatalog) (runtim
 		projectCatalog: projectCatalog,
 	}
 
-	logrus.Infof("Updating project catalog %s", projectCatalog.Name)
+	logger.Infof("Updating project catalog %s", projectCatalog.Name)
 	return nil, m.traverseAndUpdate(helm, commit, cmt)
 }
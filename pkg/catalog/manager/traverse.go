@@ -12,8 +12,8 @@
 	client "github.com/rancher/rancher/pkg/client/generated/management/v3"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
 	helmlib "github.com/rancher/rancher/pkg/helm"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/namespace"
-	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v2"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -203,10 +203,10 @@ func (m *Manager) traverseAndUpdate(helm *helmlib.Helm, commit string, cmt *Cata
 			return errors.New("Catalog is no longer available")
 		}
 		if isUpToDate(commit, catalog) {
-			logrus.Debugf("Stopping catalog [%s] update, catalog already up to date", catalog.Name)
+			logger.Debugf("Stopping catalog [%s] update, catalog already up to date", catalog.Name)
 			return nil
 		}
-		logrus.Debugf("Catalog [%s] found chart template for [%s]", catalog.Name, chart)
+		logger.Debugf("Catalog [%s] found chart template for [%s]", catalog.Name, chart)
 
 		// look for template by name, if not found then create it, otherwise do update
 		existing, err := m.templateLister.Get(template.Namespace, template.Name)
@@ -228,7 +228,7 @@ func (m *Manager) traverseAndUpdate(helm *helmlib.Helm, commit string, cmt *Cata
 			}
 		}
 	}
-	logrus.Debugf("skipped generating templates for charts that have not been changed: %v", skippedCharts)
+	logger.Debugf("skipped generating templates for charts that have not been changed: %v", skippedCharts)
 
 	var toDeleteChart []string
 	for templateName := range catalogTemplateMap {
@@ -242,14 +242,14 @@ func (m *Manager) traverseAndUpdate(helm *helmlib.Helm, commit string, cmt *Cata
 	}
 	// delete non-existing templates
 	for _, toDelete := range toDeleteChart {
-		logrus.Debugf("Deleting template %s and its associated templateVersion in namespace %s", toDelete, templateNamespace)
+		logger.Debugf("Deleting template %s and its associated templateVersion in namespace %s", toDelete, templateNamespace)
 		if err := m.deleteChart(toDelete, templateNamespace); err != nil {
 			return err
 		}
 		deletedTemplates++
 	}
 	failedTemplates = failedTemplates + len(entriesWithErrors)
-	logrus.Infof("Catalog sync done. %v templates created, %v templates updated, %v templates deleted, %v templates failed", createdTemplates, updatedTemplates, deletedTemplates, failedTemplates)
+	logger.Infof("Catalog sync done. %v templates created, %v templates updated, %v templates deleted, %v templates failed", createdTemplates, updatedTemplates, deletedTemplates, failedTemplates)
 
 	if projectCatalog != nil {
 		projectCatalog.Catalog = *catalog
@@ -273,7 +273,7 @@ func (m *Manager) traverseAndUpdate(helm *helmlib.Helm, commit string, cmt *Cata
 		if _, err := m.updateCatalogInfo(cmt, catalogType, "", false, true); err != nil {
 			return err
 		}
-		logrus.Error(fmt.Sprintf("failed to sync templates. Multiple error(s) occurred: %v", invalidChartErrors))
+		logger.Error(fmt.Sprintf("failed to sync templates. Multiple error(s) occurred: %v", invalidChartErrors))
 		return &controller.ForgetError{Err: errors.Errorf("failed to sync templates. Multiple error(s) occurred: %v", invalidChartErrors)}
 	}
 	if len(errstrings) > 0 {
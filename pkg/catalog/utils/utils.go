@@ -4,13 +4,15 @@
 	"fmt"
 	"path/filepath"
 
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 	"gopkg.in/yaml.v2"
 
 	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
 	helmlib "github.com/rancher/rancher/pkg/helm"
 )
 
+var logger = logging.Logger(logging.Catalog)
+
 const (
 	CatalogExternalIDFormat = "catalog://?catalog=%s&template=%s&version=%s"
 	SystemLibraryName       = "system-library"
@@ -65,7 +67,7 @@ func Convert(src, target interface{}) error {
 
 	err = yaml.Unmarshal(newBytes, target)
 	if err != nil {
-		logrus.Errorf("Failed to unmarshall: %v\n%s", err, string(newBytes))
+		logger.Errorf("Failed to unmarshall: %v\n%s", err, string(newBytes))
 	}
 	return err
 }
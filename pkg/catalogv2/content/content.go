@@ -19,16 +19,18 @@
 	"github.com/rancher/rancher/pkg/catalogv2/helm"
 	helmhttp "github.com/rancher/rancher/pkg/catalogv2/http"
 	catalogcontrollers "github.com/rancher/rancher/pkg/generated/controllers/catalog.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/settings"
 	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
 	"github.com/rancher/wrangler/pkg/schemas/validation"
-	"github.com/sirupsen/logrus"
 	"helm.sh/helm/v3/pkg/repo"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/discovery"
 )
 
+var logger = logging.Logger(logging.Catalog)
+
 type Manager struct {
 	configMaps   corecontrollers.ConfigMapCache
 	secrets      corecontrollers.SecretCache
@@ -203,12 +205,12 @@ func (c *Manager) filterReleases(index *repo.IndexFile, k8sVersion *semver.Versi
 
 	rancherVersion, err := semver.NewVersion(settings.ServerVersion.Get())
 	if err != nil {
-		logrus.Errorf("failed to parse server version %s: %v", settings.ServerVersion.Get(), err)
+		logger.Errorf("failed to parse server version %s: %v", settings.ServerVersion.Get(), err)
 		return index
 	}
 	rancherVersionWithoutPrerelease, err := rancherVersion.SetPrerelease("")
 	if err != nil {
-		logrus.Errorf("failed to remove prerelease from %s: %v", settings.ServerVersion.Get(), err)
+		logger.Errorf("failed to remove prerelease from %s: %v", settings.ServerVersion.Get(), err)
 		return index
 	}
 
@@ -234,7 +236,7 @@ func (c *Manager) filterReleases(index *repo.IndexFile, k8sVersion *semver.Versi
 						continue
 					}
 				} else {
-					logrus.Errorf("failed to parse constraint version %s: %v", constraintStr, err)
+					logger.Errorf("failed to parse constraint version %s: %v", constraintStr, err)
 				}
 			}
 			if constraintStr, ok := version.Annotations["catalog.cattle.io/kube-version"]; ok {
@@ -243,7 +245,7 @@ func (c *Manager) filterReleases(index *repo.IndexFile, k8sVersion *semver.Versi
 						continue
 					}
 				} else {
-					logrus.Errorf("failed to parse constraint kube-version %s from annotation: %v", constraintStr, err)
+					logger.Errorf("failed to parse constraint kube-version %s from annotation: %v", constraintStr, err)
 				}
 			}
 			if version.KubeVersion != "" {
@@ -252,7 +254,7 @@ func (c *Manager) filterReleases(index *repo.IndexFile, k8sVersion *semver.Versi
 						continue
 					}
 				} else {
-					logrus.Errorf("failed to parse constraint for kubeversion %s: %v", version.KubeVersion, err)
+					logger.Errorf("failed to parse constraint for kubeversion %s: %v", version.KubeVersion, err)
 				}
 
 			}
@@ -11,12 +11,14 @@
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	v1 "github.com/rancher/rancher/pkg/apis/catalog.cattle.io/v1"
-	"github.com/sirupsen/logrus"
+	"github.com/rancher/rancher/pkg/logging"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	rspb "k8s.io/helm/pkg/proto/hapi/release"
 	"sigs.k8s.io/yaml"
 )
 
+var logger = logging.Logger(logging.Catalog)
+
 var (
 	readmes = map[string]bool{
 		"readme":     true,
@@ -129,7 +131,7 @@ func toMap(namespace, name string, manifest string) map[string]interface{} {
 	}
 
 	if err := yaml.Unmarshal([]byte(manifest), &values); err != nil {
-		logrus.Errorf("failed to unmarshal yaml for %s/%s", namespace, name)
+		logger.Errorf("failed to unmarshal yaml for %s/%s", namespace, name)
 	}
 
 	return values
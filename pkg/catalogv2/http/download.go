@@ -14,12 +14,14 @@
 
 	"sigs.k8s.io/yaml"
 
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/settings"
-	"github.com/sirupsen/logrus"
 	"helm.sh/helm/v3/pkg/repo"
 	corev1 "k8s.io/api/core/v1"
 )
 
+var logger = logging.Logger(logging.Catalog)
+
 func Icon(secret *corev1.Secret, repoURL string, caBundle []byte, insecureSkipTLSVerify bool, disableSameOriginCheck bool, chart *repo.ChartVersion) (io.ReadCloser, string, error) {
 	if len(chart.URLs) == 0 {
 		return nil, "", fmt.Errorf("failed to find chartName %s version %s: %w", chart.Name, chart.Version, validation.NotFound)
@@ -120,7 +122,7 @@ func DownloadIndex(secret *corev1.Secret, repoURL string, caBundle []byte, insec
 	parsedURL.Path = path.Join(parsedURL.Path, "index.yaml")
 
 	url := parsedURL.String()
-	logrus.Infof("Downloading repo index from %s", url)
+	logger.Infof("Downloading repo index from %s", url)
 
 	req, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
@@ -143,7 +145,7 @@ func DownloadIndex(secret *corev1.Secret, repoURL string, caBundle []byte, insec
 	// become a "fetch any file" service.
 	index := &repo.IndexFile{}
 	if err := yaml.Unmarshal(bytes, index); err != nil {
-		logrus.Errorf("failed to unmarshal %s: %v", url, err)
+		logger.Errorf("failed to unmarshal %s: %v", url, err)
 		return nil, fmt.Errorf("failed to parse response from %s", url)
 	}
 
@@ -19,10 +19,10 @@
 	catalogcontrollers "github.com/rancher/rancher/pkg/generated/controllers/catalog.cattle.io/v1"
 	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
 	corev1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/settings"
 	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
 	"github.com/rancher/wrangler/pkg/merr"
-	"github.com/sirupsen/logrus"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/release"
 	"helm.sh/helm/v3/pkg/repo"
@@ -34,6 +34,8 @@
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
+var logger = logging.Logger(logging.Catalog)
+
 var (
 	installUser = &user.DefaultInfo{
 		Name: "helm-installer",
@@ -172,11 +174,11 @@ func (m *Manager) installCharts(charts map[desiredKey]map[string]interface{}, fo
 	for key, values := range charts {
 		for {
 			if err := m.install(key.namespace, key.name, key.minVersion, key.exactVersion, values, forceAdopt, key.installImageOverride); err == repo.ErrNoChartName || apierrors.IsNotFound(err) {
-				logrus.Errorf("Failed to find system chart %s will try again in 5 seconds: %v", key.name, err)
+				logger.Errorf("Failed to find system chart %s will try again in 5 seconds: %v", key.name, err)
 				time.Sleep(5 * time.Second)
 				continue
 			} else if err != nil {
-				logrus.Errorf("Failed to install system chart %s: %v", key.name, err)
+				logger.Errorf("Failed to install system chart %s: %v", key.name, err)
 				errs = append(errs, err)
 			}
 			break
@@ -361,7 +363,7 @@ func podDone(chart string, newPod *corev1.Pod) (bool, error) {
 
 func (m *Manager) isInstalled(namespace, name, minVersion, desiredVersion string, isExact bool, desiredValue map[string]interface{}) (bool, string, map[string]interface{}, error) {
 	helmcfg := &action.Configuration{}
-	if err := helmcfg.Init(m.restClientGetter, namespace, "", logrus.Infof); err != nil {
+	if err := helmcfg.Init(m.restClientGetter, namespace, "", logger.Infof); err != nil {
 		return false, "", nil, err
 	}
 
@@ -435,7 +437,7 @@ func desiredVersionAndValues(releases []*release.Release, minVersion, desiredVer
 				return false, "", nil, err
 			}
 			if desired.LessThan(min) {
-				logrus.Errorf("available chart version (%s) for %s is less than the min version (%s) ", desired, r.Chart.Name(), min)
+				logger.Errorf("available chart version (%s) for %s is less than the min version (%s) ", desired, r.Chart.Name(), min)
 				return false, "", nil, repo.ErrNoChartName
 			}
 			if min.LessThan(current) || min.Equal(current) {
@@ -443,7 +445,7 @@ func desiredVersionAndValues(releases []*release.Release, minVersion, desiredVer
 				if !bytes.Equal(patchedJSON, actualValueJSON) {
 					return false, r.Chart.Metadata.Version, desiredValues, nil
 				}
-				logrus.Debugf("Skipping installing/upgrading desired version %s for release %s, since current version %s is greater or equal to minimal required version %s", desired.String(), r.Name, current.String(), minVersion)
+				logger.Debugf("Skipping installing/upgrading desired version %s for release %s, since current version %s is greater or equal to minimal required version %s", desired.String(), r.Name, current.String(), minVersion)
 				return true, "", nil, nil
 			}
 		}
@@ -457,7 +459,7 @@ func desiredVersionAndValues(releases []*release.Release, minVersion, desiredVer
 
 func (m *Manager) hasStatus(namespace, name string, stateMask action.ListStates) (bool, error) {
 	helmcfg := &action.Configuration{}
-	if err := helmcfg.Init(m.restClientGetter, namespace, "", logrus.Infof); err != nil {
+	if err := helmcfg.Init(m.restClientGetter, namespace, "", logger.Infof); err != nil {
 		return false, err
 	}
 
@@ -58,6 +58,7 @@ type DynamicInterval struct {
 
 func (d *DynamicInterval) Wait(ctx context.Context) bool {
 	start := time.Now()
+	bundleModified := bundleModTime()
 	for {
 		select {
 		case <-time.After(time.Second):
@@ -65,6 +66,10 @@ func (d *DynamicInterval) Wait(ctx context.Context) bool {
 			if start.Add(duration).Before(time.Now()) {
 				return true
 			}
+			if modified := bundleModTime(); !modified.Equal(bundleModified) {
+				logrus.Infof("getReleaseConfig: reloading config for %s, the bundle was modified", d.subKey)
+				return true
+			}
 			continue
 		case msg := <-action:
 			if msg == d.subKey {
@@ -85,7 +90,13 @@ func Refresh() {
 
 type DynamicSource struct{}
 
+// URL returns the URL of the KDM data. Data loaded from a bundle or verified against a public key is read from where
+// it was stored once verified.
 func (d *DynamicSource) URL() string {
+	config, err := GetMetadataConfig()
+	if err == nil && config.Verified() {
+		return verifiedDataURL(config)
+	}
 	url, _ := GetURLAndInterval()
 	return url
 }
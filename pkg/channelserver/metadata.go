@@ -0,0 +1,201 @@
+package channelserver
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// maxMetadataSize is the maximum size of KDM data and of its signature.
+	maxMetadataSize = 64 << 20
+	// verifiedDataFile is where KDM data loaded from a bundle or verified is stored, for the channel server to read it.
+	verifiedDataFile = "verified-data.json"
+)
+
+var (
+	metadataClient = &http.Client{
+		Timeout: 30 * time.Second,
+	}
+	verifiedDataPath = filepath.Join("./management-state", "driver-metadata")
+)
+
+// MetadataConfig is the value of the rke-metadata-config setting.
+type MetadataConfig struct {
+	// URL is the URL of the KDM data, such as the URL of an internal mirror.
+	URL string `json:"url,omitempty"`
+	// Bundle is the path of KDM data supplied by the admin, such as a file mounted from a secret, loaded instead of
+	// URL. Changes to the file are loaded right away.
+	Bundle string `json:"bundle,omitempty"`
+	// PublicKey is the PEM encoded public key the KDM data must be signed with, ECDSA, Ed25519 or RSA. No signature
+	// is required if empty.
+	PublicKey string `json:"public-key,omitempty"`
+	// SignatureURL is the URL or path of the base64 encoded signature of the SHA256 of the KDM data. Defaults to the
+	// URL or bundle with the .sig suffix.
+	SignatureURL string `json:"signature-url,omitempty"`
+}
+
+// GetMetadataConfig returns the value of the rke-metadata-config setting.
+func GetMetadataConfig() (MetadataConfig, error) {
+	config := MetadataConfig{}
+	if err := json.Unmarshal([]byte(settings.RkeMetadataConfig.Get()), &config); err != nil {
+		return config, fmt.Errorf("failed to parse %s value: %w", settings.RkeMetadataConfig.Name, err)
+	}
+	return config, nil
+}
+
+// Verified returns whether KDM data is loaded from a bundle or must be signed, rather than read as is from its URL.
+func (c MetadataConfig) Verified() bool {
+	return c.Bundle != "" || c.PublicKey != ""
+}
+
+// LoadMetadata returns the KDM data of the config, verified against its public key if it has one.
+func LoadMetadata(config MetadataConfig) ([]byte, error) {
+	source := config.URL
+	if config.Bundle != "" {
+		source = config.Bundle
+	}
+	if source == "" {
+		return nil, fmt.Errorf("neither url nor bundle are set in %s", settings.RkeMetadataConfig.Name)
+	}
+	content, err := read(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read KDM data from %s: %w", source, err)
+	}
+	if config.PublicKey == "" {
+		return content, nil
+	}
+
+	signatureURL := config.SignatureURL
+	if signatureURL == "" {
+		signatureURL = source + ".sig"
+	}
+	signature, err := read(signatureURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signature of KDM data from %s: %w", signatureURL, err)
+	}
+	if err := VerifySignature(content, signature, config.PublicKey); err != nil {
+		return nil, fmt.Errorf("failed to verify KDM data from %s: %w", source, err)
+	}
+	return content, nil
+}
+
+// VerifySignature verifies the base64 encoded signature of the SHA256 of content, against a PEM encoded public key.
+func VerifySignature(content, signature []byte, publicKeyPEM string) error {
+	block, _ := pem.Decode([]byte(publicKeyPEM))
+	if block == nil {
+		return fmt.Errorf("invalid public key: no PEM data found")
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+
+	hash := sha256.Sum256(content)
+	switch publicKey := publicKey.(type) {
+	case *rsa.PublicKey:
+		err = rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hash[:], decoded)
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(publicKey, hash[:], decoded) {
+			err = fmt.Errorf("invalid signature")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(publicKey, hash[:], decoded) {
+			err = fmt.Errorf("invalid signature")
+		}
+	default:
+		err = fmt.Errorf("unsupported key type %T", publicKey)
+	}
+	return err
+}
+
+// read returns the content of a file, or of a URL if it isn't a file.
+func read(source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		f, err := os.Open(source)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return readAll(f)
+	}
+
+	resp, err := metadataClient.Get(source)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %v", resp.Status)
+	}
+	return readAll(resp.Body)
+}
+
+func readAll(r io.Reader) ([]byte, error) {
+	content, err := io.ReadAll(io.LimitReader(r, maxMetadataSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > maxMetadataSize {
+		return nil, fmt.Errorf("larger than %d bytes", maxMetadataSize)
+	}
+	return content, nil
+}
+
+// verifiedDataURL loads the KDM data of the config and stores it for the channel server, returning its path. It
+// returns an empty path if the data can't be loaded or verified, for the channel server to fall back to the data
+// embedded in rancher.
+func verifiedDataURL(config MetadataConfig) string {
+	content, err := LoadMetadata(config)
+	if err != nil {
+		logrus.Errorf("[channelserver] %v", err)
+		return ""
+	}
+	if err := os.MkdirAll(verifiedDataPath, 0700); err != nil {
+		logrus.Errorf("[channelserver] failed to store KDM data: %v", err)
+		return ""
+	}
+	path := filepath.Join(verifiedDataPath, verifiedDataFile)
+	if err := os.WriteFile(path+".tmp", content, 0600); err != nil {
+		logrus.Errorf("[channelserver] failed to store KDM data: %v", err)
+		return ""
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		logrus.Errorf("[channelserver] failed to store KDM data: %v", err)
+		return ""
+	}
+	return path
+}
+
+// bundleModTime returns when the bundle of the rke-metadata-config setting was last modified, zero if there is none.
+func bundleModTime() time.Time {
+	config, err := GetMetadataConfig()
+	if err != nil || config.Bundle == "" {
+		return time.Time{}
+	}
+	info, err := os.Stat(config.Bundle)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
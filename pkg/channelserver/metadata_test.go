@@ -0,0 +1,144 @@
+package channelserver
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func publicKeyPEM(t *testing.T, key crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func TestVerifySignature(t *testing.T) {
+	content := []byte(`{"k3s": {}, "rke2": {}}`)
+	hash := sha256.Sum256(content)
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecdsaSignature, err := ecdsa.SignASN1(rand.Reader, ecdsaKey, hash[:])
+	require.NoError(t, err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaSignature, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, hash[:])
+	require.NoError(t, err)
+
+	ed25519Public, ed25519Private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ed25519Signature := ed25519.Sign(ed25519Private, hash[:])
+
+	tests := []struct {
+		name      string
+		content   []byte
+		signature []byte
+		publicKey string
+		wantErr   bool
+	}{
+		{
+			name:      "ecdsa",
+			content:   content,
+			signature: []byte(base64.StdEncoding.EncodeToString(ecdsaSignature) + "\n"),
+			publicKey: publicKeyPEM(t, &ecdsaKey.PublicKey),
+		},
+		{
+			name:      "rsa",
+			content:   content,
+			signature: []byte(base64.StdEncoding.EncodeToString(rsaSignature)),
+			publicKey: publicKeyPEM(t, &rsaKey.PublicKey),
+		},
+		{
+			name:      "ed25519",
+			content:   content,
+			signature: []byte(base64.StdEncoding.EncodeToString(ed25519Signature)),
+			publicKey: publicKeyPEM(t, ed25519Public),
+		},
+		{
+			name:      "tampered content",
+			content:   []byte(`{"k3s": {}}`),
+			signature: []byte(base64.StdEncoding.EncodeToString(ecdsaSignature)),
+			publicKey: publicKeyPEM(t, &ecdsaKey.PublicKey),
+			wantErr:   true,
+		},
+		{
+			name:      "other key",
+			content:   content,
+			signature: []byte(base64.StdEncoding.EncodeToString(ecdsaSignature)),
+			publicKey: publicKeyPEM(t, &rsaKey.PublicKey),
+			wantErr:   true,
+		},
+		{
+			name:      "signature not base64",
+			content:   content,
+			signature: ecdsaSignature,
+			publicKey: publicKeyPEM(t, &ecdsaKey.PublicKey),
+			wantErr:   true,
+		},
+		{
+			name:      "invalid public key",
+			content:   content,
+			signature: []byte(base64.StdEncoding.EncodeToString(ecdsaSignature)),
+			publicKey: "not a key",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifySignature(tt.content, tt.signature, tt.publicKey)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLoadMetadata(t *testing.T) {
+	dir := t.TempDir()
+	content := []byte(`{"k3s": {}, "rke2": {}}`)
+	hash := sha256.Sum256(content)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	require.NoError(t, err)
+
+	bundle := filepath.Join(dir, "data.json")
+	require.NoError(t, os.WriteFile(bundle, content, 0600))
+
+	loaded, err := LoadMetadata(MetadataConfig{Bundle: bundle})
+	require.NoError(t, err)
+	assert.Equal(t, content, loaded)
+
+	config := MetadataConfig{Bundle: bundle, PublicKey: publicKeyPEM(t, &key.PublicKey)}
+	_, err = LoadMetadata(config)
+	assert.Error(t, err, "the signature is missing")
+
+	require.NoError(t, os.WriteFile(bundle+".sig", []byte(base64.StdEncoding.EncodeToString(signature)), 0600))
+	loaded, err = LoadMetadata(config)
+	require.NoError(t, err)
+	assert.Equal(t, content, loaded)
+
+	require.NoError(t, os.WriteFile(bundle, []byte(`{"k3s": {}}`), 0600))
+	_, err = LoadMetadata(config)
+	assert.Error(t, err, "the bundle was modified")
+
+	_, err = LoadMetadata(MetadataConfig{})
+	assert.Error(t, err)
+}
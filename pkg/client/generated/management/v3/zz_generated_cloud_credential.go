@@ -50,6 +50,10 @@ type CloudCredentialOperations interface {
 	Replace(existing *CloudCredential) (*CloudCredential, error)
 	ByID(id string) (*CloudCredential, error)
 	Delete(container *CloudCredential) error
+
+	ActionDependents(resource *CloudCredential) (*CloudCredentialDependentsOutput, error)
+
+	ActionRotate(resource *CloudCredential, input *CloudCredentialRotateInput) (*CloudCredentialRotateOutput, error)
 }
 
 func newCloudCredentialClient(apiClient *Client) *CloudCredentialClient {
@@ -120,3 +124,15 @@ func (c *CloudCredentialClient) ByID(id string) (*CloudCredential, error) {
 func (c *CloudCredentialClient) Delete(container *CloudCredential) error {
 	return c.apiClient.Ops.DoResourceDelete(CloudCredentialType, &container.Resource)
 }
+
+func (c *CloudCredentialClient) ActionDependents(resource *CloudCredential) (*CloudCredentialDependentsOutput, error) {
+	resp := &CloudCredentialDependentsOutput{}
+	err := c.apiClient.Ops.DoAction(CloudCredentialType, "dependents", &resource.Resource, nil, resp)
+	return resp, err
+}
+
+func (c *CloudCredentialClient) ActionRotate(resource *CloudCredential, input *CloudCredentialRotateInput) (*CloudCredentialRotateOutput, error) {
+	resp := &CloudCredentialRotateOutput{}
+	err := c.apiClient.Ops.DoAction(CloudCredentialType, "rotate", &resource.Resource, input, resp)
+	return resp, err
+}
@@ -0,0 +1,16 @@
+package client
+
+const (
+	CloudCredentialDependentType             = "cloudCredentialDependent"
+	CloudCredentialDependentFieldMachinePool = "machinePool"
+	CloudCredentialDependentFieldName        = "name"
+	CloudCredentialDependentFieldNamespace   = "namespace"
+	CloudCredentialDependentFieldType        = "type"
+)
+
+type CloudCredentialDependent struct {
+	MachinePool string `json:"machinePool,omitempty" yaml:"machinePool,omitempty"`
+	Name        string `json:"name,omitempty" yaml:"name,omitempty"`
+	Namespace   string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Type        string `json:"type,omitempty" yaml:"type,omitempty"`
+}
@@ -0,0 +1,10 @@
+package client
+
+const (
+	CloudCredentialDependentsOutputType            = "cloudCredentialDependentsOutput"
+	CloudCredentialDependentsOutputFieldDependents = "dependents"
+)
+
+type CloudCredentialDependentsOutput struct {
+	Dependents []CloudCredentialDependent `json:"dependents,omitempty" yaml:"dependents,omitempty"`
+}
@@ -0,0 +1,12 @@
+package client
+
+const (
+	CloudCredentialRotateInputType                   = "cloudCredentialRotateInput"
+	CloudCredentialRotateInputFieldCloudCredentialID = "cloudCredentialId"
+	CloudCredentialRotateInputFieldDryRun            = "dryRun"
+)
+
+type CloudCredentialRotateInput struct {
+	CloudCredentialID string `json:"cloudCredentialId,omitempty" yaml:"cloudCredentialId,omitempty"`
+	DryRun            bool   `json:"dryRun,omitempty" yaml:"dryRun,omitempty"`
+}
@@ -0,0 +1,12 @@
+package client
+
+const (
+	CloudCredentialRotateOutputType            = "cloudCredentialRotateOutput"
+	CloudCredentialRotateOutputFieldDependents = "dependents"
+	CloudCredentialRotateOutputFieldMessage    = "message"
+)
+
+type CloudCredentialRotateOutput struct {
+	Dependents []CloudCredentialDependent `json:"dependents,omitempty" yaml:"dependents,omitempty"`
+	Message    string                     `json:"message,omitempty" yaml:"message,omitempty"`
+}
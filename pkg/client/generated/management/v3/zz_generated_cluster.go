@@ -86,8 +86,10 @@
 	ClusterFieldVirtualCenterSecret                                  = "virtualCenterSecret"
 	ClusterFieldVsphereSecret                                        = "vsphereSecret"
 	ClusterFieldWeavePasswordSecret                                  = "weavePasswordSecret"
+	ClusterFieldWebhookOverrides                                     = "webhookOverrides"
 	ClusterFieldWindowsPreferedCluster                               = "windowsPreferedCluster"
 	ClusterFieldWindowsWorkerCount                                   = "windowsWorkerCount"
+	ClusterFieldWorkloadDefaults                                     = "workloadDefaults"
 )
 
 type Cluster struct {
@@ -172,8 +174,10 @@ type Cluster struct {
 	VirtualCenterSecret                                  string                         `json:"virtualCenterSecret,omitempty" yaml:"virtualCenterSecret,omitempty"`
 	VsphereSecret                                        string                         `json:"vsphereSecret,omitempty" yaml:"vsphereSecret,omitempty"`
 	WeavePasswordSecret                                  string                         `json:"weavePasswordSecret,omitempty" yaml:"weavePasswordSecret,omitempty"`
+	WebhookOverrides                                     *WebhookOverrides              `json:"webhookOverrides,omitempty" yaml:"webhookOverrides,omitempty"`
 	WindowsPreferedCluster                               bool                           `json:"windowsPreferedCluster,omitempty" yaml:"windowsPreferedCluster,omitempty"`
 	WindowsWorkerCount                                   int64                          `json:"windowsWorkerCount,omitempty" yaml:"windowsWorkerCount,omitempty"`
+	WorkloadDefaults                                     *WorkloadDefaults              `json:"workloadDefaults,omitempty" yaml:"workloadDefaults,omitempty"`
 }
 
 type ClusterCollection struct {
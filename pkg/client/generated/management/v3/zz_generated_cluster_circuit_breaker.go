@@ -0,0 +1,18 @@
+package client
+
+const (
+	ClusterCircuitBreakerType                     = "clusterCircuitBreaker"
+	ClusterCircuitBreakerFieldConsecutiveFailures = "consecutiveFailures"
+	ClusterCircuitBreakerFieldLastError           = "lastError"
+	ClusterCircuitBreakerFieldNextProbeTime       = "nextProbeTime"
+	ClusterCircuitBreakerFieldOpenedAt            = "openedAt"
+	ClusterCircuitBreakerFieldState               = "state"
+)
+
+type ClusterCircuitBreaker struct {
+	ConsecutiveFailures int64  `json:"consecutiveFailures,omitempty" yaml:"consecutiveFailures,omitempty"`
+	LastError           string `json:"lastError,omitempty" yaml:"lastError,omitempty"`
+	NextProbeTime       string `json:"nextProbeTime,omitempty" yaml:"nextProbeTime,omitempty"`
+	OpenedAt            string `json:"openedAt,omitempty" yaml:"openedAt,omitempty"`
+	State               string `json:"state,omitempty" yaml:"state,omitempty"`
+}
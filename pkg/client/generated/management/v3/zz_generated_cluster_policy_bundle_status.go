@@ -0,0 +1,18 @@
+package client
+
+const (
+	ClusterPolicyBundleStatusType         = "clusterPolicyBundleStatus"
+	ClusterPolicyBundleStatusFieldEngine  = "engine"
+	ClusterPolicyBundleStatusFieldMessage = "message"
+	ClusterPolicyBundleStatusFieldName    = "name"
+	ClusterPolicyBundleStatusFieldState   = "state"
+	ClusterPolicyBundleStatusFieldVersion = "version"
+)
+
+type ClusterPolicyBundleStatus struct {
+	Engine  string `json:"engine,omitempty" yaml:"engine,omitempty"`
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+	Name    string `json:"name,omitempty" yaml:"name,omitempty"`
+	State   string `json:"state,omitempty" yaml:"state,omitempty"`
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+}
@@ -0,0 +1,14 @@
+package client
+
+const (
+	ClusterRegistrationCheckType         = "clusterRegistrationCheck"
+	ClusterRegistrationCheckFieldMessage = "message"
+	ClusterRegistrationCheckFieldName    = "name"
+	ClusterRegistrationCheckFieldPassed  = "passed"
+)
+
+type ClusterRegistrationCheck struct {
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+	Name    string `json:"name,omitempty" yaml:"name,omitempty"`
+	Passed  bool   `json:"passed,omitempty" yaml:"passed,omitempty"`
+}
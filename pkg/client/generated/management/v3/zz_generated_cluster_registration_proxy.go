@@ -0,0 +1,14 @@
+package client
+
+const (
+	ClusterRegistrationProxyType            = "clusterRegistrationProxy"
+	ClusterRegistrationProxyFieldHTTPProxy  = "httpProxy"
+	ClusterRegistrationProxyFieldHTTPSProxy = "httpsProxy"
+	ClusterRegistrationProxyFieldNoProxy    = "noProxy"
+)
+
+type ClusterRegistrationProxy struct {
+	HTTPProxy  string `json:"httpProxy,omitempty" yaml:"httpProxy,omitempty"`
+	HTTPSProxy string `json:"httpsProxy,omitempty" yaml:"httpsProxy,omitempty"`
+	NoProxy    string `json:"noProxy,omitempty" yaml:"noProxy,omitempty"`
+}
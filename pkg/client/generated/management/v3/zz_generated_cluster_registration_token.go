@@ -6,6 +6,7 @@
 
 const (
 	ClusterRegistrationTokenType                            = "clusterRegistrationToken"
+	ClusterRegistrationTokenFieldAdditionalCA               = "additionalCA"
 	ClusterRegistrationTokenFieldAnnotations                = "annotations"
 	ClusterRegistrationTokenFieldClusterID                  = "clusterId"
 	ClusterRegistrationTokenFieldCommand                    = "command"
@@ -13,6 +14,7 @@
 	ClusterRegistrationTokenFieldCreatorID                  = "creatorId"
 	ClusterRegistrationTokenFieldInsecureCommand            = "insecureCommand"
 	ClusterRegistrationTokenFieldInsecureNodeCommand        = "insecureNodeCommand"
+	ClusterRegistrationTokenFieldInsecureValidateCommand    = "insecureValidateCommand"
 	ClusterRegistrationTokenFieldInsecureWindowsNodeCommand = "insecureWindowsNodeCommand"
 	ClusterRegistrationTokenFieldLabels                     = "labels"
 	ClusterRegistrationTokenFieldManifestURL                = "manifestUrl"
@@ -20,38 +22,46 @@
 	ClusterRegistrationTokenFieldNamespaceId                = "namespaceId"
 	ClusterRegistrationTokenFieldNodeCommand                = "nodeCommand"
 	ClusterRegistrationTokenFieldOwnerReferences            = "ownerReferences"
+	ClusterRegistrationTokenFieldProxy                      = "proxy"
 	ClusterRegistrationTokenFieldRemoved                    = "removed"
 	ClusterRegistrationTokenFieldState                      = "state"
 	ClusterRegistrationTokenFieldToken                      = "token"
 	ClusterRegistrationTokenFieldTransitioning              = "transitioning"
 	ClusterRegistrationTokenFieldTransitioningMessage       = "transitioningMessage"
 	ClusterRegistrationTokenFieldUUID                       = "uuid"
+	ClusterRegistrationTokenFieldValidateCommand            = "validateCommand"
+	ClusterRegistrationTokenFieldValidations                = "validations"
 	ClusterRegistrationTokenFieldWindowsNodeCommand         = "windowsNodeCommand"
 )
 
 type ClusterRegistrationToken struct {
 	types.Resource
-	Annotations                map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
-	ClusterID                  string            `json:"clusterId,omitempty" yaml:"clusterId,omitempty"`
-	Command                    string            `json:"command,omitempty" yaml:"command,omitempty"`
-	Created                    string            `json:"created,omitempty" yaml:"created,omitempty"`
-	CreatorID                  string            `json:"creatorId,omitempty" yaml:"creatorId,omitempty"`
-	InsecureCommand            string            `json:"insecureCommand,omitempty" yaml:"insecureCommand,omitempty"`
-	InsecureNodeCommand        string            `json:"insecureNodeCommand,omitempty" yaml:"insecureNodeCommand,omitempty"`
-	InsecureWindowsNodeCommand string            `json:"insecureWindowsNodeCommand,omitempty" yaml:"insecureWindowsNodeCommand,omitempty"`
-	Labels                     map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
-	ManifestURL                string            `json:"manifestUrl,omitempty" yaml:"manifestUrl,omitempty"`
-	Name                       string            `json:"name,omitempty" yaml:"name,omitempty"`
-	NamespaceId                string            `json:"namespaceId,omitempty" yaml:"namespaceId,omitempty"`
-	NodeCommand                string            `json:"nodeCommand,omitempty" yaml:"nodeCommand,omitempty"`
-	OwnerReferences            []OwnerReference  `json:"ownerReferences,omitempty" yaml:"ownerReferences,omitempty"`
-	Removed                    string            `json:"removed,omitempty" yaml:"removed,omitempty"`
-	State                      string            `json:"state,omitempty" yaml:"state,omitempty"`
-	Token                      string            `json:"token,omitempty" yaml:"token,omitempty"`
-	Transitioning              string            `json:"transitioning,omitempty" yaml:"transitioning,omitempty"`
-	TransitioningMessage       string            `json:"transitioningMessage,omitempty" yaml:"transitioningMessage,omitempty"`
-	UUID                       string            `json:"uuid,omitempty" yaml:"uuid,omitempty"`
-	WindowsNodeCommand         string            `json:"windowsNodeCommand,omitempty" yaml:"windowsNodeCommand,omitempty"`
+	AdditionalCA               string                          `json:"additionalCA,omitempty" yaml:"additionalCA,omitempty"`
+	Annotations                map[string]string               `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	ClusterID                  string                          `json:"clusterId,omitempty" yaml:"clusterId,omitempty"`
+	Command                    string                          `json:"command,omitempty" yaml:"command,omitempty"`
+	Created                    string                          `json:"created,omitempty" yaml:"created,omitempty"`
+	CreatorID                  string                          `json:"creatorId,omitempty" yaml:"creatorId,omitempty"`
+	InsecureCommand            string                          `json:"insecureCommand,omitempty" yaml:"insecureCommand,omitempty"`
+	InsecureNodeCommand        string                          `json:"insecureNodeCommand,omitempty" yaml:"insecureNodeCommand,omitempty"`
+	InsecureValidateCommand    string                          `json:"insecureValidateCommand,omitempty" yaml:"insecureValidateCommand,omitempty"`
+	InsecureWindowsNodeCommand string                          `json:"insecureWindowsNodeCommand,omitempty" yaml:"insecureWindowsNodeCommand,omitempty"`
+	Labels                     map[string]string               `json:"labels,omitempty" yaml:"labels,omitempty"`
+	ManifestURL                string                          `json:"manifestUrl,omitempty" yaml:"manifestUrl,omitempty"`
+	Name                       string                          `json:"name,omitempty" yaml:"name,omitempty"`
+	NamespaceId                string                          `json:"namespaceId,omitempty" yaml:"namespaceId,omitempty"`
+	NodeCommand                string                          `json:"nodeCommand,omitempty" yaml:"nodeCommand,omitempty"`
+	OwnerReferences            []OwnerReference                `json:"ownerReferences,omitempty" yaml:"ownerReferences,omitempty"`
+	Proxy                      *ClusterRegistrationProxy       `json:"proxy,omitempty" yaml:"proxy,omitempty"`
+	Removed                    string                          `json:"removed,omitempty" yaml:"removed,omitempty"`
+	State                      string                          `json:"state,omitempty" yaml:"state,omitempty"`
+	Token                      string                          `json:"token,omitempty" yaml:"token,omitempty"`
+	Transitioning              string                          `json:"transitioning,omitempty" yaml:"transitioning,omitempty"`
+	TransitioningMessage       string                          `json:"transitioningMessage,omitempty" yaml:"transitioningMessage,omitempty"`
+	UUID                       string                          `json:"uuid,omitempty" yaml:"uuid,omitempty"`
+	ValidateCommand            string                          `json:"validateCommand,omitempty" yaml:"validateCommand,omitempty"`
+	Validations                []ClusterRegistrationValidation `json:"validations,omitempty" yaml:"validations,omitempty"`
+	WindowsNodeCommand         string                          `json:"windowsNodeCommand,omitempty" yaml:"windowsNodeCommand,omitempty"`
 }
 
 type ClusterRegistrationTokenCollection struct {
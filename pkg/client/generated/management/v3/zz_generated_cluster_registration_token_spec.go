@@ -1,10 +1,14 @@
 package client
 
 const (
-	ClusterRegistrationTokenSpecType           = "clusterRegistrationTokenSpec"
-	ClusterRegistrationTokenSpecFieldClusterID = "clusterId"
+	ClusterRegistrationTokenSpecType              = "clusterRegistrationTokenSpec"
+	ClusterRegistrationTokenSpecFieldAdditionalCA = "additionalCA"
+	ClusterRegistrationTokenSpecFieldClusterID    = "clusterId"
+	ClusterRegistrationTokenSpecFieldProxy        = "proxy"
 )
 
 type ClusterRegistrationTokenSpec struct {
-	ClusterID string `json:"clusterId,omitempty" yaml:"clusterId,omitempty"`
+	AdditionalCA string                    `json:"additionalCA,omitempty" yaml:"additionalCA,omitempty"`
+	ClusterID    string                    `json:"clusterId,omitempty" yaml:"clusterId,omitempty"`
+	Proxy        *ClusterRegistrationProxy `json:"proxy,omitempty" yaml:"proxy,omitempty"`
 }
@@ -0,0 +1,16 @@
+package client
+
+const (
+	ClusterRegistrationValidationType          = "clusterRegistrationValidation"
+	ClusterRegistrationValidationFieldChecks   = "checks"
+	ClusterRegistrationValidationFieldNodeName = "nodeName"
+	ClusterRegistrationValidationFieldPassed   = "passed"
+	ClusterRegistrationValidationFieldTime     = "time"
+)
+
+type ClusterRegistrationValidation struct {
+	Checks   []ClusterRegistrationCheck `json:"checks,omitempty" yaml:"checks,omitempty"`
+	NodeName string                     `json:"nodeName,omitempty" yaml:"nodeName,omitempty"`
+	Passed   bool                       `json:"passed,omitempty" yaml:"passed,omitempty"`
+	Time     string                     `json:"time,omitempty" yaml:"time,omitempty"`
+}
@@ -36,7 +36,9 @@
 	ClusterSpecFieldLocalClusterAuthEndpoint                             = "localClusterAuthEndpoint"
 	ClusterSpecFieldRancherKubernetesEngineConfig                        = "rancherKubernetesEngineConfig"
 	ClusterSpecFieldRke2Config                                           = "rke2Config"
+	ClusterSpecFieldWebhookOverrides                                     = "webhookOverrides"
 	ClusterSpecFieldWindowsPreferedCluster                               = "windowsPreferedCluster"
+	ClusterSpecFieldWorkloadDefaults                                     = "workloadDefaults"
 )
 
 type ClusterSpec struct {
@@ -74,5 +76,7 @@ type ClusterSpec struct {
 	LocalClusterAuthEndpoint                             *LocalClusterAuthEndpoint      `json:"localClusterAuthEndpoint,omitempty" yaml:"localClusterAuthEndpoint,omitempty"`
 	RancherKubernetesEngineConfig                        *RancherKubernetesEngineConfig `json:"rancherKubernetesEngineConfig,omitempty" yaml:"rancherKubernetesEngineConfig,omitempty"`
 	Rke2Config                                           *Rke2Config                    `json:"rke2Config,omitempty" yaml:"rke2Config,omitempty"`
+	WebhookOverrides                                     *WebhookOverrides              `json:"webhookOverrides,omitempty" yaml:"webhookOverrides,omitempty"`
 	WindowsPreferedCluster                               bool                           `json:"windowsPreferedCluster,omitempty" yaml:"windowsPreferedCluster,omitempty"`
+	WorkloadDefaults                                     *WorkloadDefaults              `json:"workloadDefaults,omitempty" yaml:"workloadDefaults,omitempty"`
 }
@@ -18,7 +18,9 @@
 	ClusterSpecBaseFieldFleetAgentDeploymentCustomization                    = "fleetAgentDeploymentCustomization"
 	ClusterSpecBaseFieldLocalClusterAuthEndpoint                             = "localClusterAuthEndpoint"
 	ClusterSpecBaseFieldRancherKubernetesEngineConfig                        = "rancherKubernetesEngineConfig"
+	ClusterSpecBaseFieldWebhookOverrides                                     = "webhookOverrides"
 	ClusterSpecBaseFieldWindowsPreferedCluster                               = "windowsPreferedCluster"
+	ClusterSpecBaseFieldWorkloadDefaults                                     = "workloadDefaults"
 )
 
 type ClusterSpecBase struct {
@@ -38,5 +40,7 @@ type ClusterSpecBase struct {
 	FleetAgentDeploymentCustomization                    *AgentDeploymentCustomization  `json:"fleetAgentDeploymentCustomization,omitempty" yaml:"fleetAgentDeploymentCustomization,omitempty"`
 	LocalClusterAuthEndpoint                             *LocalClusterAuthEndpoint      `json:"localClusterAuthEndpoint,omitempty" yaml:"localClusterAuthEndpoint,omitempty"`
 	RancherKubernetesEngineConfig                        *RancherKubernetesEngineConfig `json:"rancherKubernetesEngineConfig,omitempty" yaml:"rancherKubernetesEngineConfig,omitempty"`
+	WebhookOverrides                                     *WebhookOverrides              `json:"webhookOverrides,omitempty" yaml:"webhookOverrides,omitempty"`
 	WindowsPreferedCluster                               bool                           `json:"windowsPreferedCluster,omitempty" yaml:"windowsPreferedCluster,omitempty"`
+	WorkloadDefaults                                     *WorkloadDefaults              `json:"workloadDefaults,omitempty" yaml:"workloadDefaults,omitempty"`
 }
@@ -19,8 +19,10 @@
 	ClusterStatusFieldCapabilities                               = "capabilities"
 	ClusterStatusFieldCapacity                                   = "capacity"
 	ClusterStatusFieldCertificatesExpiration                     = "certificatesExpiration"
+	ClusterStatusFieldCircuitBreaker                             = "circuitBreaker"
 	ClusterStatusFieldComponentStatuses                          = "componentStatuses"
 	ClusterStatusFieldConditions                                 = "conditions"
+	ClusterStatusFieldControlPlaneUsage                          = "controlPlaneUsage"
 	ClusterStatusFieldCurrentCisRunName                          = "currentCisRunName"
 	ClusterStatusFieldDriver                                     = "driver"
 	ClusterStatusFieldEKSStatus                                  = "eksStatus"
@@ -33,6 +35,7 @@
 	ClusterStatusFieldNodeCount                                  = "nodeCount"
 	ClusterStatusFieldNodeVersion                                = "nodeVersion"
 	ClusterStatusFieldOpenStackSecret                            = "openStackSecret"
+	ClusterStatusFieldPolicyBundles                              = "policyBundles"
 	ClusterStatusFieldPrivateRegistrySecret                      = "privateRegistrySecret"
 	ClusterStatusFieldProvider                                   = "provider"
 	ClusterStatusFieldRequested                                  = "requested"
@@ -63,8 +66,10 @@ type ClusterStatus struct {
 	Capabilities                               *Capabilities                 `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
 	Capacity                                   map[string]string             `json:"capacity,omitempty" yaml:"capacity,omitempty"`
 	CertificatesExpiration                     map[string]CertExpiration     `json:"certificatesExpiration,omitempty" yaml:"certificatesExpiration,omitempty"`
+	CircuitBreaker                             *ClusterCircuitBreaker        `json:"circuitBreaker,omitempty" yaml:"circuitBreaker,omitempty"`
 	ComponentStatuses                          []ClusterComponentStatus      `json:"componentStatuses,omitempty" yaml:"componentStatuses,omitempty"`
 	Conditions                                 []ClusterCondition            `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+	ControlPlaneUsage                          *ControlPlaneUsage            `json:"controlPlaneUsage,omitempty" yaml:"controlPlaneUsage,omitempty"`
 	CurrentCisRunName                          string                        `json:"currentCisRunName,omitempty" yaml:"currentCisRunName,omitempty"`
 	Driver                                     string                        `json:"driver,omitempty" yaml:"driver,omitempty"`
 	EKSStatus                                  *EKSStatus                    `json:"eksStatus,omitempty" yaml:"eksStatus,omitempty"`
@@ -77,6 +82,7 @@ type ClusterStatus struct {
 	NodeCount                                  int64                         `json:"nodeCount,omitempty" yaml:"nodeCount,omitempty"`
 	NodeVersion                                int64                         `json:"nodeVersion,omitempty" yaml:"nodeVersion,omitempty"`
 	OpenStackSecret                            string                        `json:"openStackSecret,omitempty" yaml:"openStackSecret,omitempty"`
+	PolicyBundles                              []ClusterPolicyBundleStatus   `json:"policyBundles,omitempty" yaml:"policyBundles,omitempty"`
 	PrivateRegistrySecret                      string                        `json:"privateRegistrySecret,omitempty" yaml:"privateRegistrySecret,omitempty"`
 	Provider                                   string                        `json:"provider,omitempty" yaml:"provider,omitempty"`
 	Requested                                  map[string]string             `json:"requested,omitempty" yaml:"requested,omitempty"`
@@ -0,0 +1,16 @@
+package client
+
+const (
+	ControlPlaneNodeUsageType             = "controlPlaneNodeUsage"
+	ControlPlaneNodeUsageFieldAPIServer   = "apiServer"
+	ControlPlaneNodeUsageFieldAllocatable = "allocatable"
+	ControlPlaneNodeUsageFieldEtcd        = "etcd"
+	ControlPlaneNodeUsageFieldNodeName    = "nodeName"
+)
+
+type ControlPlaneNodeUsage struct {
+	APIServer   map[string]string `json:"apiServer,omitempty" yaml:"apiServer,omitempty"`
+	Allocatable map[string]string `json:"allocatable,omitempty" yaml:"allocatable,omitempty"`
+	Etcd        map[string]string `json:"etcd,omitempty" yaml:"etcd,omitempty"`
+	NodeName    string            `json:"nodeName,omitempty" yaml:"nodeName,omitempty"`
+}
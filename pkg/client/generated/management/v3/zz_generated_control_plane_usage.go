@@ -0,0 +1,18 @@
+package client
+
+const (
+	ControlPlaneUsageType                   = "controlPlaneUsage"
+	ControlPlaneUsageFieldCollectedAt       = "collectedAt"
+	ControlPlaneUsageFieldEtcdDBSizeBytes   = "etcdDbSizeBytes"
+	ControlPlaneUsageFieldNodes             = "nodes"
+	ControlPlaneUsageFieldRequestCount      = "requestCount"
+	ControlPlaneUsageFieldRequestsPerSecond = "requestsPerSecond"
+)
+
+type ControlPlaneUsage struct {
+	CollectedAt       string                  `json:"collectedAt,omitempty" yaml:"collectedAt,omitempty"`
+	EtcdDBSizeBytes   int64                   `json:"etcdDbSizeBytes,omitempty" yaml:"etcdDbSizeBytes,omitempty"`
+	Nodes             []ControlPlaneNodeUsage `json:"nodes,omitempty" yaml:"nodes,omitempty"`
+	RequestCount      int64                   `json:"requestCount,omitempty" yaml:"requestCount,omitempty"`
+	RequestsPerSecond int64                   `json:"requestsPerSecond,omitempty" yaml:"requestsPerSecond,omitempty"`
+}
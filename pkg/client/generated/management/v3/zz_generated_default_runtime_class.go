@@ -0,0 +1,16 @@
+package client
+
+const (
+	DefaultRuntimeClassType              = "defaultRuntimeClass"
+	DefaultRuntimeClassFieldHandler      = "handler"
+	DefaultRuntimeClassFieldName         = "name"
+	DefaultRuntimeClassFieldNodeSelector = "nodeSelector"
+	DefaultRuntimeClassFieldTolerations  = "tolerations"
+)
+
+type DefaultRuntimeClass struct {
+	Handler      string            `json:"handler,omitempty" yaml:"handler,omitempty"`
+	Name         string            `json:"name,omitempty" yaml:"name,omitempty"`
+	NodeSelector map[string]string `json:"nodeSelector,omitempty" yaml:"nodeSelector,omitempty"`
+	Tolerations  []Toleration      `json:"tolerations,omitempty" yaml:"tolerations,omitempty"`
+}
@@ -0,0 +1,12 @@
+package client
+
+const (
+	ImagePullSecretSourceType           = "imagePullSecretSource"
+	ImagePullSecretSourceFieldName      = "name"
+	ImagePullSecretSourceFieldNamespace = "namespace"
+)
+
+type ImagePullSecretSource struct {
+	Name      string `json:"name,omitempty" yaml:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+}
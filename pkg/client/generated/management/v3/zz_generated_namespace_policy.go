@@ -0,0 +1,22 @@
+package client
+
+const (
+	NamespacePolicyType                                     = "namespacePolicy"
+	NamespacePolicyFieldAnnotations                         = "annotations"
+	NamespacePolicyFieldContainerResourceLimit              = "containerResourceLimit"
+	NamespacePolicyFieldDeleteOrphanedNamespaces            = "deleteOrphanedNamespaces"
+	NamespacePolicyFieldLabels                              = "labels"
+	NamespacePolicyFieldNamePattern                         = "namePattern"
+	NamespacePolicyFieldOrphanedNamespaceGracePeriodSeconds = "orphanedNamespaceGracePeriodSeconds"
+	NamespacePolicyFieldResourceQuota                       = "resourceQuota"
+)
+
+type NamespacePolicy struct {
+	Annotations                         map[string]string       `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	ContainerResourceLimit              *ContainerResourceLimit `json:"containerResourceLimit,omitempty" yaml:"containerResourceLimit,omitempty"`
+	DeleteOrphanedNamespaces            bool                    `json:"deleteOrphanedNamespaces,omitempty" yaml:"deleteOrphanedNamespaces,omitempty"`
+	Labels                              map[string]string       `json:"labels,omitempty" yaml:"labels,omitempty"`
+	NamePattern                         string                  `json:"namePattern,omitempty" yaml:"namePattern,omitempty"`
+	OrphanedNamespaceGracePeriodSeconds int64                   `json:"orphanedNamespaceGracePeriodSeconds,omitempty" yaml:"orphanedNamespaceGracePeriodSeconds,omitempty"`
+	ResourceQuota                       *ResourceQuotaLimit     `json:"resourceQuota,omitempty" yaml:"resourceQuota,omitempty"`
+}
@@ -6,6 +6,7 @@
 	NotifierSpecFieldDescription     = "description"
 	NotifierSpecFieldDingtalkConfig  = "dingtalkConfig"
 	NotifierSpecFieldDisplayName     = "displayName"
+	NotifierSpecFieldLifecycleEvents = "lifecycleEvents"
 	NotifierSpecFieldMSTeamsConfig   = "msteamsConfig"
 	NotifierSpecFieldPagerdutyConfig = "pagerdutyConfig"
 	NotifierSpecFieldSMTPConfig      = "smtpConfig"
@@ -16,15 +17,16 @@
 )
 
 type NotifierSpec struct {
-	ClusterID       string           `json:"clusterId,omitempty" yaml:"clusterId,omitempty"`
-	Description     string           `json:"description,omitempty" yaml:"description,omitempty"`
-	DingtalkConfig  *DingtalkConfig  `json:"dingtalkConfig,omitempty" yaml:"dingtalkConfig,omitempty"`
-	DisplayName     string           `json:"displayName,omitempty" yaml:"displayName,omitempty"`
-	MSTeamsConfig   *MSTeamsConfig   `json:"msteamsConfig,omitempty" yaml:"msteamsConfig,omitempty"`
-	PagerdutyConfig *PagerdutyConfig `json:"pagerdutyConfig,omitempty" yaml:"pagerdutyConfig,omitempty"`
-	SMTPConfig      *SMTPConfig      `json:"smtpConfig,omitempty" yaml:"smtpConfig,omitempty"`
-	SendResolved    bool             `json:"sendResolved,omitempty" yaml:"sendResolved,omitempty"`
-	SlackConfig     *SlackConfig     `json:"slackConfig,omitempty" yaml:"slackConfig,omitempty"`
-	WebhookConfig   *WebhookConfig   `json:"webhookConfig,omitempty" yaml:"webhookConfig,omitempty"`
-	WechatConfig    *WechatConfig    `json:"wechatConfig,omitempty" yaml:"wechatConfig,omitempty"`
+	ClusterID       string                `json:"clusterId,omitempty" yaml:"clusterId,omitempty"`
+	Description     string                `json:"description,omitempty" yaml:"description,omitempty"`
+	DingtalkConfig  *DingtalkConfig       `json:"dingtalkConfig,omitempty" yaml:"dingtalkConfig,omitempty"`
+	DisplayName     string                `json:"displayName,omitempty" yaml:"displayName,omitempty"`
+	LifecycleEvents []LifecycleEventRoute `json:"lifecycleEvents,omitempty" yaml:"lifecycleEvents,omitempty"`
+	MSTeamsConfig   *MSTeamsConfig        `json:"msteamsConfig,omitempty" yaml:"msteamsConfig,omitempty"`
+	PagerdutyConfig *PagerdutyConfig      `json:"pagerdutyConfig,omitempty" yaml:"pagerdutyConfig,omitempty"`
+	SMTPConfig      *SMTPConfig           `json:"smtpConfig,omitempty" yaml:"smtpConfig,omitempty"`
+	SendResolved    bool                  `json:"sendResolved,omitempty" yaml:"sendResolved,omitempty"`
+	SlackConfig     *SlackConfig          `json:"slackConfig,omitempty" yaml:"slackConfig,omitempty"`
+	WebhookConfig   *WebhookConfig        `json:"webhookConfig,omitempty" yaml:"webhookConfig,omitempty"`
+	WechatConfig    *WechatConfig         `json:"wechatConfig,omitempty" yaml:"wechatConfig,omitempty"`
 }
@@ -6,10 +6,12 @@
 
 const (
 	ProjectNetworkPolicyType                      = "projectNetworkPolicy"
+	ProjectNetworkPolicyFieldAllowedIngress       = "allowedIngress"
 	ProjectNetworkPolicyFieldAnnotations          = "annotations"
 	ProjectNetworkPolicyFieldCreated              = "created"
 	ProjectNetworkPolicyFieldCreatorID            = "creatorId"
 	ProjectNetworkPolicyFieldDescription          = "description"
+	ProjectNetworkPolicyFieldExemptNamespaces     = "exemptNamespaces"
 	ProjectNetworkPolicyFieldLabels               = "labels"
 	ProjectNetworkPolicyFieldName                 = "name"
 	ProjectNetworkPolicyFieldNamespaceId          = "namespaceId"
@@ -25,10 +27,12 @@
 
 type ProjectNetworkPolicy struct {
 	types.Resource
+	AllowedIngress       []ProjectNetworkPolicyPeer  `json:"allowedIngress,omitempty" yaml:"allowedIngress,omitempty"`
 	Annotations          map[string]string           `json:"annotations,omitempty" yaml:"annotations,omitempty"`
 	Created              string                      `json:"created,omitempty" yaml:"created,omitempty"`
 	CreatorID            string                      `json:"creatorId,omitempty" yaml:"creatorId,omitempty"`
 	Description          string                      `json:"description,omitempty" yaml:"description,omitempty"`
+	ExemptNamespaces     []string                    `json:"exemptNamespaces,omitempty" yaml:"exemptNamespaces,omitempty"`
 	Labels               map[string]string           `json:"labels,omitempty" yaml:"labels,omitempty"`
 	Name                 string                      `json:"name,omitempty" yaml:"name,omitempty"`
 	NamespaceId          string                      `json:"namespaceId,omitempty" yaml:"namespaceId,omitempty"`
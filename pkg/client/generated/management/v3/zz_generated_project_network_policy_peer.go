@@ -0,0 +1,14 @@
+package client
+
+const (
+	ProjectNetworkPolicyPeerType                   = "projectNetworkPolicyPeer"
+	ProjectNetworkPolicyPeerFieldCIDR              = "cidr"
+	ProjectNetworkPolicyPeerFieldNamespaceSelector = "namespaceSelector"
+	ProjectNetworkPolicyPeerFieldProjectID         = "projectId"
+)
+
+type ProjectNetworkPolicyPeer struct {
+	CIDR              string         `json:"cidr,omitempty" yaml:"cidr,omitempty"`
+	NamespaceSelector *LabelSelector `json:"namespaceSelector,omitempty" yaml:"namespaceSelector,omitempty"`
+	ProjectID         string         `json:"projectId,omitempty" yaml:"projectId,omitempty"`
+}
@@ -1,12 +1,16 @@
 package client
 
 const (
-	ProjectNetworkPolicySpecType             = "projectNetworkPolicySpec"
-	ProjectNetworkPolicySpecFieldDescription = "description"
-	ProjectNetworkPolicySpecFieldProjectID   = "projectId"
+	ProjectNetworkPolicySpecType                  = "projectNetworkPolicySpec"
+	ProjectNetworkPolicySpecFieldAllowedIngress   = "allowedIngress"
+	ProjectNetworkPolicySpecFieldDescription      = "description"
+	ProjectNetworkPolicySpecFieldExemptNamespaces = "exemptNamespaces"
+	ProjectNetworkPolicySpecFieldProjectID        = "projectId"
 )
 
 type ProjectNetworkPolicySpec struct {
-	Description string `json:"description,omitempty" yaml:"description,omitempty"`
-	ProjectID   string `json:"projectId,omitempty" yaml:"projectId,omitempty"`
+	AllowedIngress   []ProjectNetworkPolicyPeer `json:"allowedIngress,omitempty" yaml:"allowedIngress,omitempty"`
+	Description      string                     `json:"description,omitempty" yaml:"description,omitempty"`
+	ExemptNamespaces []string                   `json:"exemptNamespaces,omitempty" yaml:"exemptNamespaces,omitempty"`
+	ProjectID        string                     `json:"projectId,omitempty" yaml:"projectId,omitempty"`
 }
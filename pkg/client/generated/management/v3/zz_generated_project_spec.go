@@ -8,6 +8,7 @@
 	ProjectSpecFieldDisplayName                   = "displayName"
 	ProjectSpecFieldEnableProjectMonitoring       = "enableProjectMonitoring"
 	ProjectSpecFieldNamespaceDefaultResourceQuota = "namespaceDefaultResourceQuota"
+	ProjectSpecFieldNamespacePolicy               = "namespacePolicy"
 	ProjectSpecFieldResourceQuota                 = "resourceQuota"
 )
 
@@ -18,5 +19,6 @@ type ProjectSpec struct {
 	DisplayName                   string                  `json:"displayName,omitempty" yaml:"displayName,omitempty"`
 	EnableProjectMonitoring       bool                    `json:"enableProjectMonitoring,omitempty" yaml:"enableProjectMonitoring,omitempty"`
 	NamespaceDefaultResourceQuota *NamespaceResourceQuota `json:"namespaceDefaultResourceQuota,omitempty" yaml:"namespaceDefaultResourceQuota,omitempty"`
+	NamespacePolicy               *NamespacePolicy        `json:"namespacePolicy,omitempty" yaml:"namespacePolicy,omitempty"`
 	ResourceQuota                 *ProjectResourceQuota   `json:"resourceQuota,omitempty" yaml:"resourceQuota,omitempty"`
 }
@@ -0,0 +1,10 @@
+package client
+
+const (
+	RoleTemplateAggregationRuleType                       = "roleTemplateAggregationRule"
+	RoleTemplateAggregationRuleFieldRoleTemplateSelectors = "roleTemplateSelectors"
+)
+
+type RoleTemplateAggregationRule struct {
+	RoleTemplateSelectors []LabelSelector `json:"roleTemplateSelectors,omitempty" yaml:"roleTemplateSelectors,omitempty"`
+}
@@ -0,0 +1,14 @@
+package client
+
+const (
+	WebhookOverrideType               = "webhookOverride"
+	WebhookOverrideFieldDisabled      = "disabled"
+	WebhookOverrideFieldFailurePolicy = "failurePolicy"
+	WebhookOverrideFieldName          = "name"
+)
+
+type WebhookOverride struct {
+	Disabled      bool   `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+	FailurePolicy string `json:"failurePolicy,omitempty" yaml:"failurePolicy,omitempty"`
+	Name          string `json:"name,omitempty" yaml:"name,omitempty"`
+}
@@ -0,0 +1,12 @@
+package client
+
+const (
+	WebhookOverridesType                  = "webhookOverrides"
+	WebhookOverridesFieldExemptNamespaces = "exemptNamespaces"
+	WebhookOverridesFieldWebhooks         = "webhooks"
+)
+
+type WebhookOverrides struct {
+	ExemptNamespaces []string          `json:"exemptNamespaces,omitempty" yaml:"exemptNamespaces,omitempty"`
+	Webhooks         []WebhookOverride `json:"webhooks,omitempty" yaml:"webhooks,omitempty"`
+}
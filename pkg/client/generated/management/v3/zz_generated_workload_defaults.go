@@ -0,0 +1,14 @@
+package client
+
+const (
+	WorkloadDefaultsType                     = "workloadDefaults"
+	WorkloadDefaultsFieldDefaultRuntimeClass = "defaultRuntimeClass"
+	WorkloadDefaultsFieldExcludedNamespaces  = "excludedNamespaces"
+	WorkloadDefaultsFieldImagePullSecrets    = "imagePullSecrets"
+)
+
+type WorkloadDefaults struct {
+	DefaultRuntimeClass *DefaultRuntimeClass    `json:"defaultRuntimeClass,omitempty" yaml:"defaultRuntimeClass,omitempty"`
+	ExcludedNamespaces  []string                `json:"excludedNamespaces,omitempty" yaml:"excludedNamespaces,omitempty"`
+	ImagePullSecrets    []ImagePullSecretSource `json:"imagePullSecrets,omitempty" yaml:"imagePullSecrets,omitempty"`
+}
@@ -3,16 +3,20 @@
 const (
 	BasicLoginType              = "basicLogin"
 	BasicLoginFieldDescription  = "description"
+	BasicLoginFieldMFACode      = "mfaCode"
 	BasicLoginFieldPassword     = "password"
 	BasicLoginFieldResponseType = "responseType"
 	BasicLoginFieldTTLMillis    = "ttl"
 	BasicLoginFieldUsername     = "username"
+	BasicLoginFieldWebAuthn     = "webauthn"
 )
 
 type BasicLogin struct {
-	Description  string `json:"description,omitempty" yaml:"description,omitempty"`
-	Password     string `json:"password,omitempty" yaml:"password,omitempty"`
-	ResponseType string `json:"responseType,omitempty" yaml:"responseType,omitempty"`
-	TTLMillis    int64  `json:"ttl,omitempty" yaml:"ttl,omitempty"`
-	Username     string `json:"username,omitempty" yaml:"username,omitempty"`
+	Description  string             `json:"description,omitempty" yaml:"description,omitempty"`
+	MFACode      string             `json:"mfaCode,omitempty" yaml:"mfaCode,omitempty"`
+	Password     string             `json:"password,omitempty" yaml:"password,omitempty"`
+	ResponseType string             `json:"responseType,omitempty" yaml:"responseType,omitempty"`
+	TTLMillis    int64              `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+	Username     string             `json:"username,omitempty" yaml:"username,omitempty"`
+	WebAuthn     *WebAuthnAssertion `json:"webauthn,omitempty" yaml:"webauthn,omitempty"`
 }
@@ -0,0 +1,16 @@
+package client
+
+const (
+	WebAuthnAssertionType                   = "webAuthnAssertion"
+	WebAuthnAssertionFieldAuthenticatorData = "authenticatorData"
+	WebAuthnAssertionFieldClientDataJSON    = "clientDataJSON"
+	WebAuthnAssertionFieldCredentialID      = "credentialId"
+	WebAuthnAssertionFieldSignature         = "signature"
+)
+
+type WebAuthnAssertion struct {
+	AuthenticatorData string `json:"authenticatorData,omitempty" yaml:"authenticatorData,omitempty"`
+	ClientDataJSON    string `json:"clientDataJSON,omitempty" yaml:"clientDataJSON,omitempty"`
+	CredentialID      string `json:"credentialId,omitempty" yaml:"credentialId,omitempty"`
+	Signature         string `json:"signature,omitempty" yaml:"signature,omitempty"`
+}
@@ -0,0 +1,159 @@
+// Package clusterarchive builds the archives of removed clusters, and the immutable configmaps the archives are kept in
+// to be restored from.
+package clusterarchive
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/namespace"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ConfigMapLabel is the label of the configmaps archives are kept in, set to the name of their archive.
+	ConfigMapLabel = "management.cattle.io/cluster-archive"
+
+	configMapPrefix = "cluster-archive-"
+	specKey         = "spec"
+	creatorIDAnn    = "field.cattle.io/creatorId"
+	// maxLogLength is the length of the end of the provisioning log of a cluster kept in its archive.
+	maxLogLength = 10000
+)
+
+// Enabled returns true if the cluster is archived when it's removed, according to its archive annotation or else the
+// cluster archive enabled setting.
+func Enabled(cluster *v3.Cluster, setting string) bool {
+	if value, ok := cluster.Annotations[v3.ClusterArchiveAnnotation]; ok {
+		return value == "true"
+	}
+	return setting == "true"
+}
+
+// Name returns the name of the archive of a cluster, unique to the cluster even if another cluster of the same name is
+// created after it's removed.
+func Name(cluster *v3.Cluster) string {
+	uid := strings.ReplaceAll(string(cluster.UID), "-", "")
+	if len(uid) > 8 {
+		uid = uid[:8]
+	}
+	return cluster.Name + "-" + strings.ToLower(uid)
+}
+
+// ConfigMapName returns the name of the configmap an archive is kept in.
+func ConfigMapName(archiveName string) string {
+	return configMapPrefix + archiveName
+}
+
+// Build returns the archive of a cluster being removed, with its members and projects, and the provisioning log of the
+// cluster.
+func Build(cluster *v3.Cluster, bindings []*v3.ClusterRoleTemplateBinding, projects []*v3.Project, provisioningLog string, now time.Time) (*v3.ClusterArchive, error) {
+	clusterSpec, err := json.Marshal(cluster.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal spec of cluster %s: %w", cluster.Name, err)
+	}
+	removedAt := now
+	if cluster.DeletionTimestamp != nil {
+		removedAt = cluster.DeletionTimestamp.Time
+	}
+	if len(provisioningLog) > maxLogLength {
+		provisioningLog = provisioningLog[len(provisioningLog)-maxLogLength:]
+	}
+
+	spec := v3.ClusterArchiveSpec{
+		ClusterName:     cluster.Name,
+		ClusterUID:      string(cluster.UID),
+		DisplayName:     cluster.Spec.DisplayName,
+		Description:     cluster.Spec.Description,
+		Driver:          cluster.Status.Driver,
+		Provider:        cluster.Status.Provider,
+		NodeCount:       cluster.Status.NodeCount,
+		CreatorID:       cluster.Annotations[creatorIDAnn],
+		CreatedAt:       cluster.CreationTimestamp.UTC().Format(time.RFC3339),
+		RemovedAt:       removedAt.UTC().Format(time.RFC3339),
+		Labels:          cluster.Labels,
+		ClusterSpec:     string(clusterSpec),
+		Conditions:      cluster.Status.Conditions,
+		ProvisioningLog: provisioningLog,
+	}
+	if cluster.Status.Version != nil {
+		spec.KubernetesVersion = cluster.Status.Version.GitVersion
+	}
+	for _, binding := range bindings {
+		spec.Members = append(spec.Members, v3.ClusterArchiveMember{
+			Name:               binding.Name,
+			RoleTemplateName:   binding.RoleTemplateName,
+			UserName:           binding.UserName,
+			UserPrincipalName:  binding.UserPrincipalName,
+			GroupName:          binding.GroupName,
+			GroupPrincipalName: binding.GroupPrincipalName,
+		})
+	}
+	sort.Slice(spec.Members, func(i, j int) bool {
+		return spec.Members[i].Name < spec.Members[j].Name
+	})
+	for _, project := range projects {
+		spec.Projects = append(spec.Projects, v3.ClusterArchiveProject{
+			Name:        project.Name,
+			DisplayName: project.Spec.DisplayName,
+			CreatorID:   project.Annotations[creatorIDAnn],
+		})
+	}
+	sort.Slice(spec.Projects, func(i, j int) bool {
+		return spec.Projects[i].Name < spec.Projects[j].Name
+	})
+
+	return v3.NewClusterArchive("", Name(cluster), v3.ClusterArchive{Spec: *spec.DeepCopy()}), nil
+}
+
+// ConfigMap returns the immutable configmap an archive is kept in.
+func ConfigMap(archive *v3.ClusterArchive) (*corev1.ConfigMap, error) {
+	spec, err := json.Marshal(archive.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal spec of cluster archive %s: %w", archive.Name, err)
+	}
+	immutable := true
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigMapName(archive.Name),
+			Namespace: namespace.GlobalNamespace,
+			Labels: map[string]string{
+				ConfigMapLabel: archive.Name,
+			},
+		},
+		Immutable: &immutable,
+		Data: map[string]string{
+			specKey: string(spec),
+		},
+	}, nil
+}
+
+// FromConfigMap returns the archive kept in a configmap.
+func FromConfigMap(configMap *corev1.ConfigMap) (*v3.ClusterArchive, error) {
+	name := configMap.Labels[ConfigMapLabel]
+	if name == "" {
+		return nil, fmt.Errorf("configmap %s/%s doesn't keep a cluster archive", configMap.Namespace, configMap.Name)
+	}
+	var spec v3.ClusterArchiveSpec
+	if err := json.Unmarshal([]byte(configMap.Data[specKey]), &spec); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cluster archive %s: %w", name, err)
+	}
+	return v3.NewClusterArchive("", name, v3.ClusterArchive{Spec: spec}), nil
+}
+
+// Expired returns true if the archive is past the retention, never if the retention is 0.
+func Expired(spec v3.ClusterArchiveSpec, now time.Time, retentionDays int) bool {
+	if retentionDays <= 0 {
+		return false
+	}
+	removedAt, err := time.Parse(time.RFC3339, spec.RemovedAt)
+	if err != nil {
+		return false
+	}
+	return now.Sub(removedAt) > time.Duration(retentionDays)*24*time.Hour
+}
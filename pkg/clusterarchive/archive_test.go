@@ -0,0 +1,125 @@
+package clusterarchive
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/namespace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+func testCluster() *v3.Cluster {
+	removedAt := metav1.NewTime(time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC))
+	return &v3.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "c-abcde",
+			UID:               "0f1e2d3c-4b5a-6978-8796-a5b4c3d2e1f0",
+			CreationTimestamp: metav1.NewTime(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)),
+			DeletionTimestamp: &removedAt,
+			Labels:            map[string]string{"team": "payments"},
+			Annotations:       map[string]string{creatorIDAnn: "u-admin"},
+		},
+		Spec: v3.ClusterSpec{
+			DisplayName: "payments-prod",
+			Description: "production",
+		},
+		Status: v3.ClusterStatus{
+			Driver:    "rancherKubernetesEngine",
+			Provider:  "rke",
+			NodeCount: 3,
+			Version:   &version.Info{GitVersion: "v1.24.8"},
+		},
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	cluster := testCluster()
+	assert.False(t, Enabled(cluster, "false"))
+	assert.True(t, Enabled(cluster, "true"))
+
+	cluster.Annotations[v3.ClusterArchiveAnnotation] = "true"
+	assert.True(t, Enabled(cluster, "false"))
+	cluster.Annotations[v3.ClusterArchiveAnnotation] = "false"
+	assert.False(t, Enabled(cluster, "true"))
+}
+
+func TestName(t *testing.T) {
+	assert.Equal(t, "c-abcde-0f1e2d3c", Name(testCluster()))
+	assert.Equal(t, "cluster-archive-c-abcde-0f1e2d3c", ConfigMapName("c-abcde-0f1e2d3c"))
+}
+
+func TestBuild(t *testing.T) {
+	cluster := testCluster()
+	bindings := []*v3.ClusterRoleTemplateBinding{
+		{ObjectMeta: metav1.ObjectMeta{Name: "crtb-b"}, RoleTemplateName: "cluster-member", GroupPrincipalName: "okta_group://ops"},
+		{ObjectMeta: metav1.ObjectMeta{Name: "crtb-a"}, RoleTemplateName: "cluster-owner", UserName: "u-admin"},
+	}
+	projects := []*v3.Project{
+		{ObjectMeta: metav1.ObjectMeta{Name: "p-2"}, Spec: v3.ProjectSpec{DisplayName: "System"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "p-1", Annotations: map[string]string{creatorIDAnn: "u-admin"}}, Spec: v3.ProjectSpec{DisplayName: "Default"}},
+	}
+	log := strings.Repeat("a", maxLogLength) + "end"
+
+	archive, err := Build(cluster, bindings, projects, log, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, "c-abcde-0f1e2d3c", archive.Name)
+	assert.Equal(t, "c-abcde", archive.Spec.ClusterName)
+	assert.Equal(t, "0f1e2d3c-4b5a-6978-8796-a5b4c3d2e1f0", archive.Spec.ClusterUID)
+	assert.Equal(t, "payments-prod", archive.Spec.DisplayName)
+	assert.Equal(t, "v1.24.8", archive.Spec.KubernetesVersion)
+	assert.Equal(t, "u-admin", archive.Spec.CreatorID)
+	assert.Equal(t, "2025-01-02T03:04:05Z", archive.Spec.CreatedAt)
+	assert.Equal(t, "2026-10-16T12:00:00Z", archive.Spec.RemovedAt)
+	assert.Contains(t, archive.Spec.ClusterSpec, `"displayName":"payments-prod"`)
+	assert.Len(t, archive.Spec.ProvisioningLog, maxLogLength)
+	assert.True(t, strings.HasSuffix(archive.Spec.ProvisioningLog, "end"))
+	assert.Equal(t, []v3.ClusterArchiveMember{
+		{Name: "crtb-a", RoleTemplateName: "cluster-owner", UserName: "u-admin"},
+		{Name: "crtb-b", RoleTemplateName: "cluster-member", GroupPrincipalName: "okta_group://ops"},
+	}, archive.Spec.Members)
+	assert.Equal(t, []v3.ClusterArchiveProject{
+		{Name: "p-1", DisplayName: "Default", CreatorID: "u-admin"},
+		{Name: "p-2", DisplayName: "System"},
+	}, archive.Spec.Projects)
+
+	// the archive doesn't share the labels of the cluster
+	archive.Spec.Labels["team"] = "search"
+	assert.Equal(t, "payments", cluster.Labels["team"])
+}
+
+func TestConfigMap(t *testing.T) {
+	archive, err := Build(testCluster(), nil, nil, "", time.Now())
+	require.NoError(t, err)
+
+	configMap, err := ConfigMap(archive)
+	require.NoError(t, err)
+	assert.Equal(t, "cluster-archive-c-abcde-0f1e2d3c", configMap.Name)
+	assert.Equal(t, namespace.GlobalNamespace, configMap.Namespace)
+	assert.Equal(t, archive.Name, configMap.Labels[ConfigMapLabel])
+	require.NotNil(t, configMap.Immutable)
+	assert.True(t, *configMap.Immutable)
+
+	kept, err := FromConfigMap(configMap)
+	require.NoError(t, err)
+	assert.Equal(t, archive.Name, kept.Name)
+	assert.Equal(t, archive.Spec, kept.Spec)
+
+	delete(configMap.Labels, ConfigMapLabel)
+	_, err = FromConfigMap(configMap)
+	assert.Error(t, err)
+}
+
+func TestExpired(t *testing.T) {
+	spec := v3.ClusterArchiveSpec{RemovedAt: "2026-10-01T00:00:00Z"}
+	now := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	assert.False(t, Expired(spec, now, 0))
+	assert.False(t, Expired(spec, now, 30))
+	assert.True(t, Expired(spec, now, 7))
+	assert.False(t, Expired(v3.ClusterArchiveSpec{RemovedAt: "unknown"}, now, 7))
+}
@@ -0,0 +1,61 @@
+package clusterarchive
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+)
+
+// Query selects cluster archives, its empty fields selecting all archives.
+type Query struct {
+	ClusterName string
+	// DisplayName selects the archives whose display name contains it, ignoring case.
+	DisplayName string
+	CreatorID   string
+	// RemovedAfter and RemovedBefore select the archives of the clusters removed in the period.
+	RemovedAfter  time.Time
+	RemovedBefore time.Time
+}
+
+// Matches returns true if the query selects the archive.
+func (q Query) Matches(spec v3.ClusterArchiveSpec) bool {
+	if q.ClusterName != "" && spec.ClusterName != q.ClusterName {
+		return false
+	}
+	if q.DisplayName != "" && !strings.Contains(strings.ToLower(spec.DisplayName), strings.ToLower(q.DisplayName)) {
+		return false
+	}
+	if q.CreatorID != "" && spec.CreatorID != q.CreatorID {
+		return false
+	}
+	if q.RemovedAfter.IsZero() && q.RemovedBefore.IsZero() {
+		return true
+	}
+	removedAt, err := time.Parse(time.RFC3339, spec.RemovedAt)
+	if err != nil {
+		return false
+	}
+	if !q.RemovedAfter.IsZero() && removedAt.Before(q.RemovedAfter) {
+		return false
+	}
+	return q.RemovedBefore.IsZero() || removedAt.Before(q.RemovedBefore)
+}
+
+// Filter returns the archives selected by the query, the most recently removed clusters first.
+func Filter(archives []*v3.ClusterArchive, q Query) []*v3.ClusterArchive {
+	var result []*v3.ClusterArchive
+	for _, archive := range archives {
+		if q.Matches(archive.Spec) {
+			result = append(result, archive)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Spec.RemovedAt != result[j].Spec.RemovedAt {
+			return result[i].Spec.RemovedAt > result[j].Spec.RemovedAt
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
@@ -0,0 +1,82 @@
+package clusterarchive
+
+import (
+	"testing"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testArchives() []*v3.ClusterArchive {
+	return []*v3.ClusterArchive{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "c-1-aaaa"},
+			Spec:       v3.ClusterArchiveSpec{ClusterName: "c-1", DisplayName: "Payments-Prod", CreatorID: "u-a", RemovedAt: "2026-09-01T00:00:00Z"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "c-2-bbbb"},
+			Spec:       v3.ClusterArchiveSpec{ClusterName: "c-2", DisplayName: "search", CreatorID: "u-b", RemovedAt: "2026-10-01T00:00:00Z"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "c-1-cccc"},
+			Spec:       v3.ClusterArchiveSpec{ClusterName: "c-1", DisplayName: "payments-staging", CreatorID: "u-a", RemovedAt: "2026-10-01T00:00:00Z"},
+		},
+	}
+}
+
+func names(archives []*v3.ClusterArchive) []string {
+	var result []string
+	for _, archive := range archives {
+		result = append(result, archive.Name)
+	}
+	return result
+}
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		query Query
+		want  []string
+	}{
+		{
+			name: "all archives, most recently removed first",
+			want: []string{"c-1-cccc", "c-2-bbbb", "c-1-aaaa"},
+		},
+		{
+			name:  "cluster name",
+			query: Query{ClusterName: "c-1"},
+			want:  []string{"c-1-cccc", "c-1-aaaa"},
+		},
+		{
+			name:  "display name ignoring case",
+			query: Query{DisplayName: "PAYMENTS"},
+			want:  []string{"c-1-cccc", "c-1-aaaa"},
+		},
+		{
+			name:  "creator",
+			query: Query{CreatorID: "u-b"},
+			want:  []string{"c-2-bbbb"},
+		},
+		{
+			name:  "removed after",
+			query: Query{RemovedAfter: time.Date(2026, 9, 15, 0, 0, 0, 0, time.UTC)},
+			want:  []string{"c-1-cccc", "c-2-bbbb"},
+		},
+		{
+			name:  "removed before",
+			query: Query{RemovedBefore: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)},
+			want:  []string{"c-1-aaaa"},
+		},
+		{
+			name:  "no match",
+			query: Query{ClusterName: "c-2", CreatorID: "u-a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, names(Filter(testArchives(), tt.query)))
+		})
+	}
+}
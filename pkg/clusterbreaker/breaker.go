@@ -0,0 +1,179 @@
+// Package clusterbreaker is a circuit breaker around the controllers and health checks of downstream clusters. A
+// cluster failing to be reached too many times in a row is only probed at an interval doubling with each failed probe,
+// instead of its controllers being restarted and its health being checked continuously, so that permanently
+// unreachable clusters, such as clusters deleted out-of-band, don't consume workers and fill the logs.
+package clusterbreaker
+
+import (
+	"sync"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/sirupsen/logrus"
+)
+
+// probeInterval is the interval a cluster is first probed at once its circuit breaker opens.
+const probeInterval = 30 * time.Second
+
+// Default is the circuit breaker used by Allow, Success, Failure, Open, Remove and SetStatus.
+var Default = New()
+
+// Allow returns true if the cluster can be reached in the default circuit breaker.
+func Allow(cluster string) bool {
+	return Default.Allow(cluster)
+}
+
+// Success records the cluster being reached in the default circuit breaker.
+func Success(cluster string) {
+	Default.Success(cluster)
+}
+
+// Failure records the cluster failing to be reached in the default circuit breaker, returning true if it is open.
+func Failure(cluster string, err error) bool {
+	return Default.Failure(cluster, err)
+}
+
+// Open returns true if the breaker of the cluster is open in the default circuit breaker.
+func Open(cluster string) bool {
+	return Default.Status(cluster) != nil
+}
+
+// Remove drops the state of the cluster in the default circuit breaker.
+func Remove(cluster string) {
+	Default.Remove(cluster)
+}
+
+// SetStatus sets the state of the default circuit breaker in the status of the cluster.
+func SetStatus(cluster *v3.Cluster) {
+	cluster.Status.CircuitBreaker = Default.Status(cluster.Name)
+}
+
+// Breaker is a circuit breaker per cluster. The breaker of a cluster is closed while the cluster is reached, opens
+// once it fails to be reached the failure threshold times in a row, and lets a single probe through at the probe
+// interval while open.
+type Breaker struct {
+	lock     sync.Mutex
+	clusters map[string]*state
+	now      func() time.Time
+}
+
+type state struct {
+	failures  int
+	lastError string
+	openedAt  time.Time
+	nextProbe time.Time
+}
+
+func New() *Breaker {
+	return &Breaker{
+		clusters: map[string]*state{},
+		now:      time.Now,
+	}
+}
+
+// Allow returns true if the cluster can be reached: while its breaker is closed, or for a single probe once the probe
+// interval passed while it's open.
+func (b *Breaker) Allow(cluster string) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	s, ok := b.clusters[cluster]
+	if !ok || s.openedAt.IsZero() {
+		return true
+	}
+	now := b.now()
+	if now.Before(s.nextProbe) {
+		return false
+	}
+	// hold back other probes until this one fails, or times out without being recorded
+	s.nextProbe = now.Add(probeInterval)
+	return true
+}
+
+// Success records the cluster being reached, closing its breaker.
+func (b *Breaker) Success(cluster string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if s, ok := b.clusters[cluster]; ok && !s.openedAt.IsZero() {
+		logrus.Infof("[clusterbreaker] cluster [%s] is reachable again, closing its circuit breaker", cluster)
+	}
+	delete(b.clusters, cluster)
+}
+
+// Failure records the cluster failing to be reached, returning true if its breaker is open.
+func (b *Breaker) Failure(cluster string, err error) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	s, ok := b.clusters[cluster]
+	if !ok {
+		s = &state{}
+		b.clusters[cluster] = s
+	}
+	s.failures++
+	if err != nil {
+		s.lastError = err.Error()
+	}
+
+	threshold := settings.ClusterCircuitBreakerFailureThreshold.GetInt()
+	if threshold < 1 {
+		threshold = 1
+	}
+	if s.failures < threshold {
+		return false
+	}
+
+	now := b.now()
+	if s.openedAt.IsZero() {
+		s.openedAt = now
+		logrus.Warnf("[clusterbreaker] cluster [%s] failed to be reached %d times in a row, opening its circuit breaker: %s", cluster, s.failures, s.lastError)
+	}
+	interval := nextInterval(s.failures-threshold, time.Duration(settings.ClusterCircuitBreakerMaxProbeIntervalSeconds.GetInt())*time.Second)
+	s.nextProbe = now.Add(interval)
+	logrus.Debugf("[clusterbreaker] probing cluster [%s] again in %v", cluster, interval)
+	return true
+}
+
+// nextInterval returns the probe interval after the number of failed probes, doubling from the probe interval up to
+// max.
+func nextInterval(probes int, max time.Duration) time.Duration {
+	if max < probeInterval {
+		max = probeInterval
+	}
+	interval := probeInterval
+	for i := 0; i < probes && interval < max; i++ {
+		interval *= 2
+	}
+	if interval > max {
+		return max
+	}
+	return interval
+}
+
+// Remove drops the state of the cluster.
+func (b *Breaker) Remove(cluster string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	delete(b.clusters, cluster)
+}
+
+// Status returns the state of the breaker of the cluster for its status, nil if the breaker is closed.
+func (b *Breaker) Status(cluster string) *v3.ClusterCircuitBreaker {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	s, ok := b.clusters[cluster]
+	if !ok || s.openedAt.IsZero() {
+		return nil
+	}
+	return &v3.ClusterCircuitBreaker{
+		State:               v3.ClusterCircuitBreakerOpen,
+		ConsecutiveFailures: s.failures,
+		LastError:           s.lastError,
+		OpenedAt:            s.openedAt.UTC().Format(time.RFC3339),
+		NextProbeTime:       s.nextProbe.UTC().Format(time.RFC3339),
+	}
+}
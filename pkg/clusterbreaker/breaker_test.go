@@ -0,0 +1,66 @@
+package clusterbreaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreaker(t *testing.T) {
+	require.NoError(t, settings.ClusterCircuitBreakerFailureThreshold.Set("3"))
+	defer settings.ClusterCircuitBreakerFailureThreshold.Set(settings.ClusterCircuitBreakerFailureThreshold.Default)
+	require.NoError(t, settings.ClusterCircuitBreakerMaxProbeIntervalSeconds.Set("100"))
+	defer settings.ClusterCircuitBreakerMaxProbeIntervalSeconds.Set(settings.ClusterCircuitBreakerMaxProbeIntervalSeconds.Default)
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	b := New()
+	b.now = func() time.Time {
+		return now
+	}
+	unreachable := errors.New("dial tcp: i/o timeout")
+
+	assert.False(t, b.Failure("c-1", unreachable))
+	assert.False(t, b.Failure("c-1", unreachable))
+	assert.True(t, b.Allow("c-1"))
+	assert.Nil(t, b.Status("c-1"))
+
+	assert.True(t, b.Failure("c-1", unreachable))
+	assert.False(t, b.Allow("c-1"))
+	assert.True(t, b.Allow("c-2"))
+	assert.Equal(t, &v3.ClusterCircuitBreaker{
+		State:               v3.ClusterCircuitBreakerOpen,
+		ConsecutiveFailures: 3,
+		LastError:           "dial tcp: i/o timeout",
+		OpenedAt:            "2026-10-16T12:00:00Z",
+		NextProbeTime:       "2026-10-16T12:00:30Z",
+	}, b.Status("c-1"))
+
+	// a single probe is let through once the probe interval passed
+	now = now.Add(30 * time.Second)
+	assert.True(t, b.Allow("c-1"))
+	assert.False(t, b.Allow("c-1"))
+
+	// the probe interval doubles with each failed probe
+	assert.True(t, b.Failure("c-1", unreachable))
+	now = now.Add(59 * time.Second)
+	assert.False(t, b.Allow("c-1"))
+	now = now.Add(time.Second)
+	assert.True(t, b.Allow("c-1"))
+
+	b.Success("c-1")
+	assert.Nil(t, b.Status("c-1"))
+	assert.True(t, b.Allow("c-1"))
+	assert.False(t, b.Failure("c-1", unreachable))
+}
+
+func TestNextInterval(t *testing.T) {
+	assert.Equal(t, 30*time.Second, nextInterval(0, 30*time.Minute))
+	assert.Equal(t, 2*time.Minute, nextInterval(2, 30*time.Minute))
+	assert.Equal(t, 30*time.Minute, nextInterval(20, 30*time.Minute))
+	assert.Equal(t, 30*time.Second, nextInterval(2, time.Second))
+}
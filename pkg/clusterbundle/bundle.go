@@ -0,0 +1,133 @@
+package clusterbundle
+
+import (
+	"encoding/json"
+	"fmt"
+
+	fleet "github.com/rancher/fleet/pkg/apis/fleet.cattle.io/v1alpha1"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/wrangler/pkg/data"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// NameLabel is the label of the objects instantiated from a cluster bundle with the name of the cluster bundle.
+const NameLabel = "clusterbundle.cattle.io/name"
+
+// Bundle is the cluster definition packaged in the bundle layer of a cluster bundle.
+type Bundle struct {
+	// Cluster is the spec of the provisioning cluster.
+	Cluster provv1.ClusterSpec `json:"cluster"`
+	// MachineConfigs are the machine configs the machine pools of the cluster reference by name.
+	MachineConfigs []map[string]interface{} `json:"machineConfigs,omitempty"`
+	// ChartValues are the values of the charts installed on the cluster by chart name, merged over the chart values
+	// of the cluster spec.
+	ChartValues map[string]interface{} `json:"chartValues,omitempty"`
+	// Policies are policy bundles deployed to the cluster.
+	Policies []Policy `json:"policies,omitempty"`
+}
+
+type Policy struct {
+	Name string              `json:"name"`
+	Spec v3.PolicyBundleSpec `json:"spec"`
+}
+
+// Parse parses the bundle layer of a cluster bundle.
+func Parse(content []byte) (*Bundle, error) {
+	var bundle Bundle
+	if err := json.Unmarshal(content, &bundle); err != nil {
+		return nil, fmt.Errorf("failed to parse bundle: %w", err)
+	}
+	return &bundle, nil
+}
+
+// Objects returns the objects instantiating the bundle as the cluster of the name in the namespace: the machine
+// configs, named <cluster>-<machine config> and referenced as such by the machine pools, the provisioning cluster
+// and the policy bundles, named <cluster>-<policy> and targeting the cluster.
+func (b *Bundle) Objects(namespace, clusterName, bundleName, cloudCredentialSecretName string) ([]runtime.Object, error) {
+	var result []runtime.Object
+
+	machineConfigNames := map[string]string{}
+	for _, obj := range b.MachineConfigs {
+		machineConfig := &unstructured.Unstructured{Object: runtime.DeepCopyJSON(obj)}
+		if machineConfig.GetAPIVersion() != capr.DefaultMachineConfigAPIVersion {
+			return nil, fmt.Errorf("machine config %s has apiVersion %q, not %s", machineConfig.GetName(),
+				machineConfig.GetAPIVersion(), capr.DefaultMachineConfigAPIVersion)
+		}
+		if machineConfig.GetKind() == "" || machineConfig.GetName() == "" {
+			return nil, fmt.Errorf("machine configs must have a kind and a name")
+		}
+		key := machineConfig.GetKind() + "/" + machineConfig.GetName()
+		if _, ok := machineConfigNames[key]; ok {
+			return nil, fmt.Errorf("duplicate machine config %s", key)
+		}
+		name := clusterName + "-" + machineConfig.GetName()
+		machineConfigNames[key] = name
+
+		annotations := machineConfig.GetAnnotations()
+		delete(machineConfig.Object, "metadata")
+		delete(machineConfig.Object, "status")
+		machineConfig.SetName(name)
+		machineConfig.SetNamespace(namespace)
+		machineConfig.SetLabels(map[string]string{NameLabel: bundleName})
+		machineConfig.SetAnnotations(annotations)
+		result = append(result, machineConfig)
+	}
+
+	spec := b.Cluster.DeepCopy()
+	if cloudCredentialSecretName != "" {
+		spec.CloudCredentialSecretName = cloudCredentialSecretName
+	}
+	if spec.RKEConfig != nil {
+		for i, pool := range spec.RKEConfig.MachinePools {
+			if pool.NodeConfig == nil {
+				continue
+			}
+			name, ok := machineConfigNames[pool.NodeConfig.Kind+"/"+pool.NodeConfig.Name]
+			if !ok {
+				return nil, fmt.Errorf("machine pool %s references machine config %s/%s, which isn't in the bundle",
+					pool.Name, pool.NodeConfig.Kind, pool.NodeConfig.Name)
+			}
+			spec.RKEConfig.MachinePools[i].NodeConfig.Name = name
+		}
+	}
+	if len(b.ChartValues) > 0 {
+		if spec.RKEConfig == nil {
+			return nil, fmt.Errorf("chart values can only be set for RKE2 and K3s clusters")
+		}
+		spec.RKEConfig.ChartValues.Data = data.MergeMaps(spec.RKEConfig.ChartValues.Data, runtime.DeepCopyJSON(b.ChartValues))
+	}
+	result = append(result, &provv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterName,
+			Namespace: namespace,
+			Labels:    map[string]string{NameLabel: bundleName},
+		},
+		Spec: *spec,
+	})
+
+	for _, policy := range b.Policies {
+		if policy.Name == "" {
+			return nil, fmt.Errorf("policies must have a name")
+		}
+		policySpec := policy.Spec.DeepCopy()
+		policySpec.Targets = []fleet.BundleTarget{
+			{
+				Name:        "cluster",
+				ClusterName: clusterName,
+			},
+		}
+		result = append(result, &v3.PolicyBundle{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clusterName + "-" + policy.Name,
+				Namespace: namespace,
+				Labels:    map[string]string{NameLabel: bundleName},
+			},
+			Spec: *policySpec,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,82 @@
+package clusterbundle
+
+import (
+	"testing"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const bundleJSON = `{
+	"cluster": {
+		"kubernetesVersion": "v1.25.9+rke2r1",
+		"cloudCredentialSecretName": "cattle-global-data:cc-abc",
+		"rkeConfig": {
+			"chartValues": {"rke2-calico": {"installation": {"calicoNetwork": {"mtu": 1450}}}},
+			"machinePools": [{"name": "pool1", "machineConfigRef": {"kind": "Amazonec2Config", "name": "workers"}}]
+		}
+	},
+	"machineConfigs": [{
+		"apiVersion": "rke-machine-config.cattle.io/v1",
+		"kind": "Amazonec2Config",
+		"metadata": {"name": "workers", "namespace": "fleet-other", "uid": "abc", "annotations": {"field.cattle.io/creatorId": "u-1"}},
+		"instanceType": "t3.large"
+	}],
+	"chartValues": {"rke2-calico": {"installation": {"calicoNetwork": {"bgp": "Disabled"}}}},
+	"policies": [{"name": "baseline", "spec": {"engine": "kyverno", "version": "1"}}]
+}`
+
+func TestObjects(t *testing.T) {
+	bundle, err := Parse([]byte(bundleJSON))
+	require.NoError(t, err)
+
+	objs, err := bundle.Objects("fleet-default", "edge-1", "edge", "cattle-global-data:cc-def")
+	require.NoError(t, err)
+	require.Len(t, objs, 3)
+
+	machineConfig := objs[0].(*unstructured.Unstructured)
+	assert.Equal(t, "edge-1-workers", machineConfig.GetName())
+	assert.Equal(t, "fleet-default", machineConfig.GetNamespace())
+	assert.Empty(t, machineConfig.GetUID())
+	assert.Equal(t, map[string]string{NameLabel: "edge"}, machineConfig.GetLabels())
+	assert.Equal(t, map[string]string{"field.cattle.io/creatorId": "u-1"}, machineConfig.GetAnnotations())
+	assert.Equal(t, "t3.large", machineConfig.Object["instanceType"])
+
+	cluster := objs[1].(*provv1.Cluster)
+	assert.Equal(t, "edge-1", cluster.Name)
+	assert.Equal(t, "fleet-default", cluster.Namespace)
+	assert.Equal(t, "cattle-global-data:cc-def", cluster.Spec.CloudCredentialSecretName)
+	assert.Equal(t, "edge-1-workers", cluster.Spec.RKEConfig.MachinePools[0].NodeConfig.Name)
+	assert.Equal(t, map[string]interface{}{
+		"rke2-calico": map[string]interface{}{
+			"installation": map[string]interface{}{
+				"calicoNetwork": map[string]interface{}{"mtu": float64(1450), "bgp": "Disabled"},
+			},
+		},
+	}, cluster.Spec.RKEConfig.ChartValues.Data)
+	assert.Equal(t, "workers", bundle.Cluster.RKEConfig.MachinePools[0].NodeConfig.Name)
+
+	policy := objs[2].(*v3.PolicyBundle)
+	assert.Equal(t, "edge-1-baseline", policy.Name)
+	assert.Equal(t, "kyverno", policy.Spec.Engine)
+	if assert.Len(t, policy.Spec.Targets, 1) {
+		assert.Equal(t, "edge-1", policy.Spec.Targets[0].ClusterName)
+	}
+}
+
+func TestObjectsInvalid(t *testing.T) {
+	bundle, err := Parse([]byte(bundleJSON))
+	require.NoError(t, err)
+	bundle.MachineConfigs = nil
+	_, err = bundle.Objects("fleet-default", "edge-1", "edge", "")
+	assert.Error(t, err)
+
+	bundle, err = Parse([]byte(bundleJSON))
+	require.NoError(t, err)
+	bundle.MachineConfigs[0]["apiVersion"] = "v1"
+	_, err = bundle.Objects("fleet-default", "edge-1", "edge", "")
+	assert.Error(t, err)
+}
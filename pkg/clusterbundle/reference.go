@@ -0,0 +1,90 @@
+package clusterbundle
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	dockerHubRegistry    = "docker.io"
+	dockerHubAPIRegistry = "registry-1.docker.io"
+	defaultTag           = "latest"
+)
+
+var (
+	repositoryRegexp = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*$`)
+	tagRegexp        = regexp.MustCompile(`^\w[\w.-]{0,127}$`)
+	digestRegexp     = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+)
+
+// Reference is an artifact in an OCI registry.
+type Reference struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// ParseReference parses a reference as [oci://][<registry>/]<repository>[:<tag>][@<digest>]. The registry defaults to
+// Docker Hub and the tag to latest.
+func ParseReference(value string) (Reference, error) {
+	var ref Reference
+	rest := strings.TrimPrefix(value, "oci://")
+	if i := strings.Index(rest, "@"); i >= 0 {
+		rest, ref.Digest = rest[:i], rest[i+1:]
+		if !digestRegexp.MatchString(ref.Digest) {
+			return ref, fmt.Errorf("invalid digest %q in reference %q", ref.Digest, value)
+		}
+	}
+	if i := strings.LastIndex(rest, ":"); i > strings.LastIndex(rest, "/") {
+		rest, ref.Tag = rest[:i], rest[i+1:]
+		if !tagRegexp.MatchString(ref.Tag) {
+			return ref, fmt.Errorf("invalid tag %q in reference %q", ref.Tag, value)
+		}
+	}
+	if i := strings.Index(rest, "/"); i >= 0 && (strings.ContainsAny(rest[:i], ".:") || rest[:i] == "localhost") {
+		ref.Registry, rest = rest[:i], rest[i+1:]
+	} else {
+		ref.Registry = dockerHubRegistry
+		if !strings.Contains(rest, "/") {
+			rest = "library/" + rest
+		}
+	}
+	if !repositoryRegexp.MatchString(rest) {
+		return ref, fmt.Errorf("invalid repository %q in reference %q", rest, value)
+	}
+	ref.Repository = rest
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = defaultTag
+	}
+	return ref, nil
+}
+
+// String returns the reference as <registry>/<repository>[:<tag>][@<digest>].
+func (r Reference) String() string {
+	result := r.Registry + "/" + r.Repository
+	if r.Tag != "" {
+		result += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		result += "@" + r.Digest
+	}
+	return result
+}
+
+// apiRegistry is the host the registry API is served from.
+func (r Reference) apiRegistry() string {
+	if r.Registry == dockerHubRegistry {
+		return dockerHubAPIRegistry
+	}
+	return r.Registry
+}
+
+// manifestReference is the digest of the manifest if the reference has one, its tag otherwise.
+func (r Reference) manifestReference() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+	return r.Tag
+}
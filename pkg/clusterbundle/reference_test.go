@@ -0,0 +1,64 @@
+package clusterbundle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReference(t *testing.T) {
+	digest := "sha256:" + "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	tests := []struct {
+		name     string
+		value    string
+		expected Reference
+		err      bool
+	}{
+		{
+			name:     "registry and tag",
+			value:    "oci://registry.example.com:5000/blueprints/edge:1.2.0",
+			expected: Reference{Registry: "registry.example.com:5000", Repository: "blueprints/edge", Tag: "1.2.0"},
+		},
+		{
+			name:     "digest",
+			value:    "registry.example.com/blueprints/edge@" + digest,
+			expected: Reference{Registry: "registry.example.com", Repository: "blueprints/edge", Digest: digest},
+		},
+		{
+			name:     "docker hub",
+			value:    "rancher/edge",
+			expected: Reference{Registry: "docker.io", Repository: "rancher/edge", Tag: "latest"},
+		},
+		{
+			name:     "docker hub library",
+			value:    "edge:v1",
+			expected: Reference{Registry: "docker.io", Repository: "library/edge", Tag: "v1"},
+		},
+		{
+			name:     "localhost",
+			value:    "localhost/edge",
+			expected: Reference{Registry: "localhost", Repository: "edge", Tag: "latest"},
+		},
+		{
+			name:  "invalid digest",
+			value: "registry.example.com/edge@sha256:abc",
+			err:   true,
+		},
+		{
+			name:  "invalid repository",
+			value: "registry.example.com/Edge",
+			err:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, err := ParseReference(tt.value)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, ref)
+		})
+	}
+}
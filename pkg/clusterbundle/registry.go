@@ -0,0 +1,319 @@
+package clusterbundle
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	ManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+	// ConfigMediaType is the media type of the config of the manifests of cluster bundles, their artifact type.
+	ConfigMediaType = "application/vnd.cattle.io.cluster-bundle.config.v1+json"
+	// LayerMediaType is the media type of the layer holding the JSON of the bundle.
+	LayerMediaType = "application/vnd.cattle.io.cluster-bundle.v1+json"
+	// SignatureAnnotation is the annotation of the bundle layer holding the base64 signature of the SHA-256 digest of
+	// the layer.
+	SignatureAnnotation = "io.cattle.cluster-bundle.signature"
+	// VersionAnnotation is the annotation of the manifest holding the version of the bundle.
+	VersionAnnotation = "org.opencontainers.image.version"
+
+	maxManifestSize = 4 << 20
+	maxBundleSize   = 16 << 20
+)
+
+// Credentials are the credentials of a registry.
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// Artifact is a cluster bundle pulled from a registry.
+type Artifact struct {
+	// Digest is the digest of the manifest of the artifact.
+	Digest  string
+	Version string
+	// Content is the bundle layer and Signature the signature of its SHA-256 digest.
+	Content   []byte
+	Signature []byte
+}
+
+type descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type manifest struct {
+	MediaType   string            `json:"mediaType,omitempty"`
+	Config      descriptor        `json:"config"`
+	Layers      []descriptor      `json:"layers"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Pull pulls the cluster bundle of the reference from its registry, checking the digests of the manifest and of the
+// bundle layer. The signature isn't verified.
+func Pull(ctx context.Context, client *http.Client, ref Reference, credentials *Credentials) (*Artifact, error) {
+	p := &puller{
+		client:      client,
+		ref:         ref,
+		credentials: credentials,
+	}
+
+	data, err := p.get(ctx, "manifests/"+ref.manifestReference(), ManifestMediaType, maxManifestSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manifest of %s: %w", ref, err)
+	}
+	digest := sha256Digest(data)
+	if ref.Digest != "" && ref.Digest != digest {
+		return nil, fmt.Errorf("digest %s of the manifest of %s doesn't match", digest, ref)
+	}
+
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest of %s: %w", ref, err)
+	}
+	if m.Config.MediaType != ConfigMediaType {
+		return nil, fmt.Errorf("%s is not a cluster bundle, its config media type is %q", ref, m.Config.MediaType)
+	}
+	var layer *descriptor
+	for i := range m.Layers {
+		if m.Layers[i].MediaType != LayerMediaType {
+			continue
+		}
+		if layer != nil {
+			return nil, fmt.Errorf("%s has several %s layers", ref, LayerMediaType)
+		}
+		layer = &m.Layers[i]
+	}
+	if layer == nil {
+		return nil, fmt.Errorf("%s has no %s layer", ref, LayerMediaType)
+	}
+	if layer.Size > maxBundleSize {
+		return nil, fmt.Errorf("bundle layer of %s is larger than %d bytes", ref, maxBundleSize)
+	}
+	signature, err := base64.StdEncoding.DecodeString(layer.Annotations[SignatureAnnotation])
+	if err != nil || len(signature) == 0 {
+		return nil, fmt.Errorf("bundle layer of %s has no valid %s annotation", ref, SignatureAnnotation)
+	}
+
+	content, err := p.get(ctx, "blobs/"+layer.Digest, "", maxBundleSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bundle layer of %s: %w", ref, err)
+	}
+	if sha256Digest(content) != layer.Digest {
+		return nil, fmt.Errorf("digest of the bundle layer of %s doesn't match %s", ref, layer.Digest)
+	}
+
+	return &Artifact{
+		Digest:    digest,
+		Version:   m.Annotations[VersionAnnotation],
+		Content:   content,
+		Signature: signature,
+	}, nil
+}
+
+// CredentialsFromSecret returns the credentials of the registry of a kubernetes.io/basic-auth or
+// kubernetes.io/dockerconfigjson secret.
+func CredentialsFromSecret(secret *corev1.Secret, registry string) (*Credentials, error) {
+	switch secret.Type {
+	case corev1.SecretTypeBasicAuth:
+		return &Credentials{
+			Username: string(secret.Data[corev1.BasicAuthUsernameKey]),
+			Password: string(secret.Data[corev1.BasicAuthPasswordKey]),
+		}, nil
+	case corev1.SecretTypeDockerConfigJson:
+		var config struct {
+			Auths map[string]struct {
+				Username string `json:"username"`
+				Password string `json:"password"`
+				Auth     string `json:"auth"`
+			} `json:"auths"`
+		}
+		if err := json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &config); err != nil {
+			return nil, fmt.Errorf("failed to parse %s of secret %s/%s: %w", corev1.DockerConfigJsonKey, secret.Namespace, secret.Name, err)
+		}
+		for server, auth := range config.Auths {
+			if registryHost(server) != registry {
+				continue
+			}
+			if auth.Auth == "" {
+				return &Credentials{Username: auth.Username, Password: auth.Password}, nil
+			}
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("invalid auth of %s in secret %s/%s: %w", server, secret.Namespace, secret.Name, err)
+			}
+			username, password, _ := strings.Cut(string(decoded), ":")
+			return &Credentials{Username: username, Password: password}, nil
+		}
+		return nil, fmt.Errorf("secret %s/%s has no credentials for registry %s", secret.Namespace, secret.Name, registry)
+	}
+	return nil, fmt.Errorf("secret %s/%s is of type %s, not %s or %s", secret.Namespace, secret.Name, secret.Type,
+		corev1.SecretTypeBasicAuth, corev1.SecretTypeDockerConfigJson)
+}
+
+// registryHost returns the host of a server of a docker config, which may be a URL.
+func registryHost(server string) string {
+	if u, err := url.Parse(server); err == nil && u.Host != "" {
+		server = u.Host
+	}
+	if server == "index.docker.io" || server == dockerHubAPIRegistry {
+		return dockerHubRegistry
+	}
+	return server
+}
+
+func sha256Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+type puller struct {
+	client      *http.Client
+	ref         Reference
+	credentials *Credentials
+	// authorization is the authorization header negotiated with the registry.
+	authorization string
+}
+
+// get gets a manifest or a blob of the repository, authenticating as challenged by the registry.
+func (p *puller) get(ctx context.Context, path, accept string, limit int64) ([]byte, error) {
+	u := fmt.Sprintf("https://%s/v2/%s/%s", p.ref.apiRegistry(), p.ref.Repository, path)
+	resp, err := p.do(ctx, u, accept)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && p.authorization == "" {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		if p.authorization, err = p.authorize(ctx, challenge); err != nil {
+			return nil, err
+		}
+		if resp, err = p.do(ctx, u, accept); err != nil {
+			return nil, err
+		}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("larger than %d bytes", limit)
+	}
+	return data, nil
+}
+
+func (p *puller) do(ctx context.Context, u, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if p.authorization != "" {
+		req.Header.Set("Authorization", p.authorization)
+	}
+	return p.client.Do(req)
+}
+
+// authorize returns the authorization header answering the WWW-Authenticate challenge of the registry: the
+// credentials for basic authentication, or a token from the realm for bearer authentication.
+func (p *puller) authorize(ctx context.Context, challenge string) (string, error) {
+	scheme, params := parseChallenge(challenge)
+	switch scheme {
+	case "basic":
+		if p.credentials == nil {
+			return "", fmt.Errorf("registry %s requires credentials", p.ref.Registry)
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(p.credentials.Username+":"+p.credentials.Password)), nil
+	case "bearer":
+	default:
+		return "", fmt.Errorf("unsupported authentication challenge %q of registry %s", challenge, p.ref.Registry)
+	}
+
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Host == "" {
+		return "", fmt.Errorf("invalid realm %q of registry %s", params["realm"], p.ref.Registry)
+	}
+	query := realm.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", p.ref.Repository)
+	}
+	query.Set("scope", scope)
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if p.credentials != nil {
+		req.SetBasicAuth(p.credentials.Username, p.credentials.Password)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get token from %s: unexpected status code %d", realm.Host, resp.StatusCode)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to parse token from %s: %w", realm.Host, err)
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	if token.Token == "" {
+		return "", fmt.Errorf("no token from %s", realm.Host)
+	}
+	return "Bearer " + token.Token, nil
+}
+
+// parseChallenge parses a WWW-Authenticate header such as Bearer realm="https://auth.docker.io/token",service="x"
+// into its lowercase scheme and its parameters.
+func parseChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := map[string]string{}
+	for rest = strings.TrimSpace(rest); rest != ""; rest = strings.TrimLeft(rest, ", ") {
+		key, value, ok := strings.Cut(rest, "=")
+		if !ok {
+			break
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				params[key] = value[1:]
+				break
+			}
+			params[key], rest = value[1:end+1], value[end+2:]
+		} else {
+			params[key], rest, _ = strings.Cut(value, ",")
+		}
+	}
+	return strings.ToLower(scheme), params
+}
@@ -0,0 +1,142 @@
+package clusterbundle
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPull(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	content := []byte(`{"cluster": {"kubernetesVersion": "v1.25.9+rke2r1"}}`)
+	sum := sha256.Sum256(content)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, sum[:])
+	require.NoError(t, err)
+
+	manifestData, err := json.Marshal(manifest{
+		MediaType: ManifestMediaType,
+		Config:    descriptor{MediaType: ConfigMediaType, Digest: sha256Digest([]byte("{}")), Size: 2},
+		Layers: []descriptor{
+			{
+				MediaType:   LayerMediaType,
+				Digest:      sha256Digest(content),
+				Size:        int64(len(content)),
+				Annotations: map[string]string{SignatureAnnotation: base64.StdEncoding.EncodeToString(signature)},
+			},
+		},
+		Annotations: map[string]string{VersionAnnotation: "1.2.0"},
+	})
+	require.NoError(t, err)
+
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/token" {
+			if user, password, ok := req.BasicAuth(); !ok || user != "user" || password != "password" ||
+				req.URL.Query().Get("scope") != "repository:blueprints/edge:pull" {
+				rw.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = rw.Write([]byte(`{"token": "token"}`))
+			return
+		}
+		if req.Header.Get("Authorization") != "Bearer token" {
+			rw.Header().Set("WWW-Authenticate", `Bearer realm="`+server.URL+`/token",service="registry"`)
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch req.URL.Path {
+		case "/v2/blueprints/edge/manifests/1.2.0", "/v2/blueprints/edge/manifests/" + sha256Digest(manifestData):
+			_, _ = rw.Write(manifestData)
+		case "/v2/blueprints/edge/blobs/" + sha256Digest(content):
+			_, _ = rw.Write(content)
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	registry := strings.TrimPrefix(server.URL, "https://")
+	credentials := &Credentials{Username: "user", Password: "password"}
+	ref := Reference{Registry: registry, Repository: "blueprints/edge", Tag: "1.2.0"}
+
+	artifact, err := Pull(context.Background(), server.Client(), ref, credentials)
+	require.NoError(t, err)
+	assert.Equal(t, sha256Digest(manifestData), artifact.Digest)
+	assert.Equal(t, "1.2.0", artifact.Version)
+	assert.Equal(t, content, artifact.Content)
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	keys, err := ParsePublicKeys(string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})))
+	require.NoError(t, err)
+	assert.NoError(t, artifact.Verify(keys))
+	otherKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	assert.Error(t, artifact.Verify([]crypto.PublicKey{otherKey}))
+	assert.Error(t, artifact.Verify(nil))
+
+	ref.Tag, ref.Digest = "", sha256Digest(manifestData)
+	_, err = Pull(context.Background(), server.Client(), ref, credentials)
+	assert.NoError(t, err)
+	ref.Digest = sha256Digest([]byte("other"))
+	_, err = Pull(context.Background(), server.Client(), ref, credentials)
+	assert.Error(t, err)
+
+	ref.Tag, ref.Digest = "1.2.0", ""
+	_, err = Pull(context.Background(), server.Client(), ref, nil)
+	assert.Error(t, err)
+}
+
+func TestCredentialsFromSecret(t *testing.T) {
+	secret := &corev1.Secret{
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: []byte(`{"auths": {
+				"https://index.docker.io/v1/": {"auth": "` + base64.StdEncoding.EncodeToString([]byte("hub:secret")) + `"},
+				"registry.example.com": {"username": "user", "password": "password"}
+			}}`),
+		},
+	}
+	credentials, err := CredentialsFromSecret(secret, "docker.io")
+	require.NoError(t, err)
+	assert.Equal(t, &Credentials{Username: "hub", Password: "secret"}, credentials)
+	credentials, err = CredentialsFromSecret(secret, "registry.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, &Credentials{Username: "user", Password: "password"}, credentials)
+	_, err = CredentialsFromSecret(secret, "other.example.com")
+	assert.Error(t, err)
+
+	_, err = CredentialsFromSecret(&corev1.Secret{Type: corev1.SecretTypeOpaque}, "docker.io")
+	assert.Error(t, err)
+}
+
+func TestParseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(`Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/edge:pull"`)
+	assert.Equal(t, "bearer", scheme)
+	assert.Equal(t, map[string]string{
+		"realm":   "https://auth.docker.io/token",
+		"service": "registry.docker.io",
+		"scope":   "repository:library/edge:pull",
+	}, params)
+
+	scheme, params = parseChallenge(`Basic realm="registry"`)
+	assert.Equal(t, "basic", scheme)
+	assert.Equal(t, map[string]string{"realm": "registry"}, params)
+}
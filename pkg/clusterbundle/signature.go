@@ -0,0 +1,65 @@
+package clusterbundle
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+)
+
+// ParsePublicKeys parses PEM encoded ECDSA, Ed25519 and RSA public keys.
+func ParsePublicKeys(data string) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "PUBLIC KEY" {
+			continue
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key: %w", err)
+		}
+		switch key.(type) {
+		case *ecdsa.PublicKey, ed25519.PublicKey, *rsa.PublicKey:
+			keys = append(keys, key)
+		default:
+			return nil, fmt.Errorf("unsupported public key type %T", key)
+		}
+	}
+	return keys, nil
+}
+
+// Verify verifies the signature of the artifact is a signature of the SHA-256 digest of its content by one of the
+// keys: an ASN.1 ECDSA signature, an Ed25519 signature or an RSA PKCS #1 v1.5 signature.
+func (a *Artifact) Verify(keys []crypto.PublicKey) error {
+	if len(keys) == 0 {
+		return fmt.Errorf("no keys to verify the signature of the bundle with")
+	}
+	digest := sha256.Sum256(a.Content)
+	for _, key := range keys {
+		switch key := key.(type) {
+		case *ecdsa.PublicKey:
+			if ecdsa.VerifyASN1(key, digest[:], a.Signature) {
+				return nil
+			}
+		case ed25519.PublicKey:
+			if ed25519.Verify(key, digest[:], a.Signature) {
+				return nil
+			}
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], a.Signature) == nil {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("the bundle isn't signed by any of the trusted keys")
+}
@@ -9,6 +9,7 @@
 	"net"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strings"
 	"sync"
 	"time"
@@ -17,6 +18,7 @@
 	"github.com/rancher/norman/httperror"
 	"github.com/rancher/norman/types"
 	apimgmtv3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/clusterbreaker"
 	"github.com/rancher/rancher/pkg/clusterrouter"
 	"github.com/rancher/rancher/pkg/controllers/management/secretmigrator"
 	clusterController "github.com/rancher/rancher/pkg/controllers/managementuser"
@@ -96,6 +98,10 @@ func (m *Manager) Start(ctx context.Context, cluster *apimgmtv3.Cluster, cluster
 	if err != nil {
 		return err
 	}
+	// the controllers of an unreachable cluster are only started to probe it once its circuit breaker allows it
+	if !clusterbreaker.Allow(cluster.Name) {
+		return nil
+	}
 	_, err = m.start(ctx, cluster, true, clusterOwner)
 	return err
 }
@@ -112,7 +118,9 @@ func (m *Manager) RESTConfig(cluster *apimgmtv3.Cluster) (rest.Config, error) {
 
 func (m *Manager) markUnavailable(clusterName string) {
 	if cluster, err := m.clusters.Get(clusterName, metav1.GetOptions{}); err == nil {
-		if !apimgmtv3.ClusterConditionReady.IsFalse(cluster) {
+		circuitBreaker := cluster.Status.CircuitBreaker
+		clusterbreaker.SetStatus(cluster)
+		if !apimgmtv3.ClusterConditionReady.IsFalse(cluster) || !reflect.DeepEqual(circuitBreaker, cluster.Status.CircuitBreaker) {
 			apimgmtv3.ClusterConditionReady.False(cluster)
 			m.clusters.Update(cluster)
 		}
@@ -120,6 +128,18 @@ func (m *Manager) markUnavailable(clusterName string) {
 	}
 }
 
+// clearCircuitBreaker removes the state of the circuit breaker from the status of a cluster reached again.
+func (m *Manager) clearCircuitBreaker(clusterName string) {
+	cluster, err := m.clusters.Get(clusterName, metav1.GetOptions{})
+	if err != nil || cluster.Status.CircuitBreaker == nil {
+		return
+	}
+	cluster.Status.CircuitBreaker = nil
+	if _, err := m.clusters.Update(cluster); err != nil {
+		logrus.Warnf("failed to clear the circuit breaker of cluster %s: %v", clusterName, err)
+	}
+}
+
 func (m *Manager) start(ctx context.Context, cluster *apimgmtv3.Cluster, controllers, clusterOwner bool) (*record, error) {
 	if cluster.DeletionTimestamp != nil {
 		return nil, nil
@@ -160,7 +180,11 @@ func (m *Manager) startController(r *record, controllers, clusterOwner bool) err
 	if !r.started {
 		go func() {
 			if err := m.doStart(r, clusterOwner); err != nil {
-				logrus.Errorf("failed to start cluster controllers %s: %v", r.cluster.ClusterName, err)
+				if clusterbreaker.Open(r.clusterRec.Name) {
+					logrus.Debugf("failed to start cluster controllers %s: %v", r.cluster.ClusterName, err)
+				} else {
+					logrus.Errorf("failed to start cluster controllers %s: %v", r.cluster.ClusterName, err)
+				}
 				m.markUnavailable(r.clusterRec.Name)
 				m.Stop(r.clusterRec)
 			}
@@ -200,7 +224,10 @@ func (m *Manager) doStart(rec *record, clusterOwner bool) (exit error) {
 		// To work around this, now we try to get a namespace from the API, even if not found, it means the API is up.
 		if _, err := rec.cluster.K8sClient.CoreV1().Namespaces().Get(rec.ctx, "kube-system", metav1.GetOptions{}); err != nil && !apierrors.IsNotFound(err) {
 			if i == 2 {
-				m.markUnavailable(rec.cluster.ClusterName)
+				// the caller marks the cluster unavailable and stops its controllers, which are started again once
+				// its circuit breaker allows it
+				clusterbreaker.Failure(rec.clusterRec.Name, err)
+				return fmt.Errorf("failed to reach cluster: %w", err)
 			}
 			select {
 			case <-rec.ctx.Done():
@@ -212,6 +239,11 @@ func (m *Manager) doStart(rec *record, clusterOwner bool) (exit error) {
 
 		break
 	}
+	open := clusterbreaker.Open(rec.clusterRec.Name)
+	clusterbreaker.Success(rec.clusterRec.Name)
+	if open || rec.clusterRec.Status.CircuitBreaker != nil {
+		m.clearCircuitBreaker(rec.clusterRec.Name)
+	}
 
 	if err := m.startSem.Acquire(rec.ctx, 1); err != nil {
 		return err
@@ -220,7 +252,7 @@ func (m *Manager) doStart(rec *record, clusterOwner bool) (exit error) {
 
 	transaction := controller.NewHandlerTransaction(rec.ctx)
 	if clusterOwner {
-		if err := clusterController.Register(transaction, m.ScaledContext, rec.cluster, rec.clusterRec, m); err != nil {
+		if err := clusterController.Register(transaction, m.ScaledContext, rec.cluster, rec.clusterRec, m, m); err != nil {
 			transaction.Rollback()
 			return err
 		}
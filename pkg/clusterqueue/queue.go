@@ -0,0 +1,150 @@
+// Package clusterqueue queues the non-urgent actions on downstream clusters whose agent is disconnected, and runs them
+// once the agent reconnects instead of failing them while rancher and the cluster can't reach each other.
+package clusterqueue
+
+import (
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// maxActions is the number of actions queued for a cluster, the oldest action is dropped when it is exceeded.
+const maxActions = 500
+
+// Action is an action on a downstream cluster. An action returning an error is queued again.
+type Action func() error
+
+// Default is the queue used by Add, Run and Remove.
+var Default = New()
+
+// Add queues an action for a cluster in the default queue.
+func Add(cluster, key string, action Action) {
+	Default.Add(cluster, key, action)
+}
+
+// Run runs the actions queued for a cluster in the default queue.
+func Run(cluster string) {
+	Default.Run(cluster)
+}
+
+// Remove drops the actions queued for a cluster in the default queue.
+func Remove(cluster string) {
+	Default.Remove(cluster)
+}
+
+// Queue is a queue of actions per cluster. Actions are identified by a key, and an action replaces the queued action
+// with the same key, so that only the latest action on an object runs.
+type Queue struct {
+	lock     sync.Mutex
+	clusters map[string]*actions
+}
+
+type actions struct {
+	keys    []string
+	byKey   map[string]Action
+	running bool
+}
+
+func New() *Queue {
+	return &Queue{
+		clusters: map[string]*actions{},
+	}
+}
+
+// Add queues an action for a cluster. An action with the same key queued for the cluster is replaced, and keeps its
+// position in the queue.
+func (q *Queue) Add(cluster, key string, action Action) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	a, ok := q.clusters[cluster]
+	if !ok {
+		a = &actions{byKey: map[string]Action{}}
+		q.clusters[cluster] = a
+	}
+	if _, ok := a.byKey[key]; !ok {
+		if len(a.keys) == maxActions {
+			logrus.Warnf("[clusterqueue] dropping action %s queued for cluster [%s], too many actions are queued", a.keys[0], cluster)
+			delete(a.byKey, a.keys[0])
+			a.keys = a.keys[1:]
+		}
+		a.keys = append(a.keys, key)
+	}
+	a.byKey[key] = action
+}
+
+// Len returns the number of actions queued for a cluster.
+func (q *Queue) Len(cluster string) int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if a, ok := q.clusters[cluster]; ok {
+		return len(a.keys)
+	}
+	return 0
+}
+
+// Run runs the actions queued for a cluster in order. Run stops at the first action returning an error, which is queued
+// again unless it was replaced meanwhile, as the cluster is likely disconnected again. Run returns immediately if the
+// actions of the cluster are already running.
+func (q *Queue) Run(cluster string) {
+	q.lock.Lock()
+	a, ok := q.clusters[cluster]
+	if !ok || a.running {
+		q.lock.Unlock()
+		return
+	}
+	a.running = true
+	q.lock.Unlock()
+
+	for {
+		key, action, ok := q.pop(a)
+		if !ok {
+			break
+		}
+		if err := action(); err != nil {
+			logrus.Warnf("[clusterqueue] failed to run action %s queued for cluster [%s], retrying on reconnect: %v", key, cluster, err)
+			q.requeue(a, key, action)
+			break
+		}
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	a.running = false
+	if len(a.keys) == 0 && q.clusters[cluster] == a {
+		delete(q.clusters, cluster)
+	}
+}
+
+// Remove drops the actions queued for a cluster.
+func (q *Queue) Remove(cluster string) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	delete(q.clusters, cluster)
+}
+
+func (q *Queue) pop(a *actions) (string, Action, bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if len(a.keys) == 0 {
+		return "", nil, false
+	}
+	key := a.keys[0]
+	action := a.byKey[key]
+	a.keys = a.keys[1:]
+	delete(a.byKey, key)
+	return key, action, true
+}
+
+func (q *Queue) requeue(a *actions, key string, action Action) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if _, ok := a.byKey[key]; ok {
+		return
+	}
+	a.keys = append([]string{key}, a.keys...)
+	a.byKey[key] = action
+}
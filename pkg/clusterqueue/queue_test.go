@@ -0,0 +1,85 @@
+package clusterqueue
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	q := New()
+	var ran []string
+	record := func(name string) Action {
+		return func() error {
+			ran = append(ran, name)
+			return nil
+		}
+	}
+
+	q.Add("c-1", "a", record("a1"))
+	q.Add("c-1", "b", record("b"))
+	q.Add("c-1", "a", record("a2"))
+	q.Add("c-2", "a", record("c-2"))
+	assert.Equal(t, 2, q.Len("c-1"))
+
+	q.Run("c-1")
+	assert.Equal(t, []string{"a2", "b"}, ran)
+	assert.Equal(t, 0, q.Len("c-1"))
+	assert.Equal(t, 1, q.Len("c-2"))
+
+	q.Run("c-3")
+	assert.Len(t, ran, 2)
+}
+
+func TestRunRequeuesFailedAction(t *testing.T) {
+	q := New()
+	fail := true
+	var ran []string
+
+	q.Add("c-1", "a", func() error {
+		ran = append(ran, "a")
+		if fail {
+			return errors.New("cluster unavailable")
+		}
+		return nil
+	})
+	q.Add("c-1", "b", func() error {
+		ran = append(ran, "b")
+		return nil
+	})
+
+	q.Run("c-1")
+	assert.Equal(t, []string{"a"}, ran)
+	assert.Equal(t, 2, q.Len("c-1"))
+
+	fail = false
+	q.Run("c-1")
+	assert.Equal(t, []string{"a", "a", "b"}, ran)
+	assert.Equal(t, 0, q.Len("c-1"))
+}
+
+func TestAddDropsOldestAction(t *testing.T) {
+	q := New()
+	var ran []string
+	for i := 0; i <= maxActions; i++ {
+		name := fmt.Sprint(i)
+		q.Add("c-1", name, func() error {
+			ran = append(ran, name)
+			return nil
+		})
+	}
+	assert.Equal(t, maxActions, q.Len("c-1"))
+
+	q.Run("c-1")
+	assert.Len(t, ran, maxActions)
+	assert.Equal(t, "1", ran[0])
+}
+
+func TestRemove(t *testing.T) {
+	q := New()
+	q.Add("c-1", "a", func() error { return nil })
+	q.Remove("c-1")
+	assert.Equal(t, 0, q.Len("c-1"))
+}
@@ -16,6 +16,8 @@
 	dialer2 "github.com/rancher/rancher/pkg/dialer"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
 	"github.com/rancher/rancher/pkg/impersonation"
+	"github.com/rancher/rancher/pkg/logging"
+	"github.com/rancher/rancher/pkg/tracing"
 	"github.com/rancher/rancher/pkg/types/config"
 	"github.com/rancher/rancher/pkg/types/config/dialer"
 	"github.com/rancher/wrangler/pkg/schemas/validation"
@@ -241,12 +243,29 @@ func (r *RemoteService) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 		req.Header.Set("Authorization", "Bearer "+token)
 	}
 
+	req, span := tracing.StartProxySpan(req, r.cluster.Name)
+	defer span.End()
+
 	if httpstream.IsUpgradeRequest(req) {
+		if s, ok := streamRequest(u.Path); ok {
+			s.Cluster = r.cluster.Name
+			if userInfo, ok := request.UserFrom(req.Context()); ok {
+				s.User = userInfo.GetName()
+			}
+			sess, err := Sessions.start(s)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusTooManyRequests)
+				return
+			}
+			defer Sessions.end(sess)
+			rw = sess.responseWriter(rw)
+		}
 		upgradeProxy := NewUpgradeProxy(&u, transport)
 		upgradeProxy.ServeHTTP(rw, req)
 		return
 	}
 
+	logging.FromContext(req.Context(), logging.Proxy).Debugf("clusterrouter: proxying %s %s to cluster %s", req.Method, u.Path, r.cluster.Name)
 	httpProxy := proxy.NewUpgradeAwareHandler(&u, transport, true, false, er)
 	httpProxy.ServeHTTP(rw, req)
 }
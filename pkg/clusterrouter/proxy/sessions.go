@@ -0,0 +1,325 @@
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rancher/rancher/pkg/logging"
+	"github.com/rancher/rancher/pkg/settings"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/util/rand"
+)
+
+var logger = logging.Logger(logging.Proxy)
+
+const (
+	reapInterval = 30 * time.Second
+	// minBurst is the smallest burst of the rate limiters of sessions, so that low bandwidth caps don't split reads and
+	// writes into tiny chunks.
+	minBurst = 32 * 1024
+)
+
+var (
+	// Sessions tracks the exec, attach and port-forward sessions proxied by this rancher replica.
+	Sessions = NewSessionTracker()
+
+	errTooManyUserSessions    = fmt.Errorf("too many exec, attach and port-forward sessions for user")
+	errTooManyClusterSessions = fmt.Errorf("too many exec, attach and port-forward sessions for cluster")
+)
+
+// Session is an exec, attach or port-forward session proxied to a cluster.
+type Session struct {
+	ID           string    `json:"id"`
+	User         string    `json:"user"`
+	Cluster      string    `json:"cluster"`
+	Kind         string    `json:"kind"`
+	Namespace    string    `json:"namespace"`
+	Pod          string    `json:"pod"`
+	StartedAt    time.Time `json:"startedAt"`
+	LastActivity time.Time `json:"lastActivity"`
+	BytesIn      int64     `json:"bytesIn"`
+	BytesOut     int64     `json:"bytesOut"`
+}
+
+// streamRequest returns the session for a request to the exec, attach or portforward subresources of a pod, and
+// false for any other request. path is the path of the request in the cluster.
+func streamRequest(path string) (Session, bool) {
+	// /api/v1/namespaces/{namespace}/pods/{pod}/{kind}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) != 7 || parts[0] != "api" || parts[1] != "v1" || parts[2] != "namespaces" || parts[4] != "pods" {
+		return Session{}, false
+	}
+	switch parts[6] {
+	case "exec", "attach", "portforward":
+		return Session{
+			Kind:      parts[6],
+			Namespace: parts[3],
+			Pod:       parts[5],
+		}, true
+	}
+	return Session{}, false
+}
+
+// SessionTracker enforces the limits on the number, idle time and bandwidth of the exec, attach and port-forward
+// sessions of users and clusters, and allows them to be listed and terminated.
+type SessionTracker struct {
+	sync.Mutex
+	sessions map[string]*session
+	reaper   sync.Once
+}
+
+func NewSessionTracker() *SessionTracker {
+	return &SessionTracker{
+		sessions: map[string]*session{},
+	}
+}
+
+type session struct {
+	Session
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	lastActivity      atomic.Int64
+	bytesIn, bytesOut atomic.Int64
+	inLimiter         *rate.Limiter
+	outLimiter        *rate.Limiter
+
+	lock   sync.Mutex
+	conn   net.Conn
+	closed bool
+}
+
+// start registers a session, unless the user or the cluster already reached their limit of sessions. The session
+// must be ended with end.
+func (t *SessionTracker) start(s Session) (*session, error) {
+	t.reaper.Do(func() {
+		go t.reap()
+	})
+
+	t.Lock()
+	defer t.Unlock()
+
+	perUser, perCluster := settings.ProxyStreamSessionsPerUser.GetInt(), settings.ProxyStreamSessionsPerCluster.GetInt()
+	var userSessions, clusterSessions int
+	for _, existing := range t.sessions {
+		if existing.User == s.User {
+			userSessions++
+		}
+		if existing.Cluster == s.Cluster {
+			clusterSessions++
+		}
+	}
+	if perUser > 0 && userSessions >= perUser {
+		return nil, errTooManyUserSessions
+	}
+	if perCluster > 0 && clusterSessions >= perCluster {
+		return nil, errTooManyClusterSessions
+	}
+
+	s.ID = rand.String(10)
+	s.StartedAt = time.Now()
+	sess := &session{
+		Session: s,
+	}
+	sess.ctx, sess.cancel = context.WithCancel(context.Background())
+	sess.lastActivity.Store(s.StartedAt.UnixNano())
+	if bytesPerSecond := settings.ProxyStreamBandwidthBytesPerSecond.GetInt(); bytesPerSecond > 0 {
+		burst := bytesPerSecond
+		if burst < minBurst {
+			burst = minBurst
+		}
+		sess.inLimiter = rate.NewLimiter(rate.Limit(bytesPerSecond), burst)
+		sess.outLimiter = rate.NewLimiter(rate.Limit(bytesPerSecond), burst)
+	}
+	t.sessions[sess.ID] = sess
+	return sess, nil
+}
+
+// end unregisters a session and closes its connection.
+func (t *SessionTracker) end(s *session) {
+	t.Lock()
+	delete(t.sessions, s.ID)
+	t.Unlock()
+	s.close()
+}
+
+// List returns the sessions to a cluster, or to all clusters if cluster is empty, oldest first.
+func (t *SessionTracker) List(cluster string) []Session {
+	t.Lock()
+	defer t.Unlock()
+
+	result := []Session{}
+	for _, s := range t.sessions {
+		if cluster != "" && s.Cluster != cluster {
+			continue
+		}
+		result = append(result, s.snapshot())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].StartedAt.Before(result[j].StartedAt)
+	})
+	return result
+}
+
+// Terminate closes a session, and returns false if there's no session with the id.
+func (t *SessionTracker) Terminate(id string) bool {
+	t.Lock()
+	s, ok := t.sessions[id]
+	t.Unlock()
+	if !ok {
+		return false
+	}
+	logger.Infof("[cluster-proxy] terminating %s session %s of user %s to pod %s/%s of cluster %s", s.Kind, s.ID, s.User, s.Namespace, s.Pod, s.Cluster)
+	s.close()
+	return true
+}
+
+func (t *SessionTracker) reap() {
+	for range time.Tick(reapInterval) {
+		t.closeIdle(time.Now(), time.Duration(settings.ProxyStreamIdleTimeoutSeconds.GetInt())*time.Second)
+	}
+}
+
+// closeIdle closes the sessions with no traffic for longer than timeout.
+func (t *SessionTracker) closeIdle(now time.Time, timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+	t.Lock()
+	var idle []*session
+	for _, s := range t.sessions {
+		if now.Sub(time.Unix(0, s.lastActivity.Load())) > timeout {
+			idle = append(idle, s)
+		}
+	}
+	t.Unlock()
+
+	for _, s := range idle {
+		logger.Debugf("[cluster-proxy] closing idle %s session %s of user %s to pod %s/%s of cluster %s", s.Kind, s.ID, s.User, s.Namespace, s.Pod, s.Cluster)
+		s.close()
+	}
+}
+
+func (s *session) snapshot() Session {
+	result := s.Session
+	result.LastActivity = time.Unix(0, s.lastActivity.Load())
+	result.BytesIn = s.bytesIn.Load()
+	result.BytesOut = s.bytesOut.Load()
+	return result
+}
+
+// attach wraps the hijacked connection of the session, closing it right away if the session was already closed.
+func (s *session) attach(conn net.Conn) net.Conn {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.conn = conn
+	if s.closed {
+		conn.Close()
+	}
+	return &sessionConn{
+		Conn:    conn,
+		session: s,
+	}
+}
+
+func (s *session) close() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	s.cancel()
+	if s.conn != nil {
+		s.conn.Close()
+	}
+}
+
+// responseWriter wraps a response writer so that the connection hijacked to proxy the session is tracked.
+func (s *session) responseWriter(rw http.ResponseWriter) http.ResponseWriter {
+	return &sessionResponseWriter{
+		ResponseWriter: rw,
+		session:        s,
+	}
+}
+
+type sessionResponseWriter struct {
+	http.ResponseWriter
+	session *session
+}
+
+func (w *sessionResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+	return w.session.attach(conn), rw, nil
+}
+
+func (w *sessionResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// sessionConn counts the traffic of a session and caps its bandwidth. Reads are the traffic from the user, writes the
+// traffic to the user.
+type sessionConn struct {
+	net.Conn
+	session *session
+}
+
+func (c *sessionConn) Read(p []byte) (int, error) {
+	if limiter := c.session.inLimiter; limiter != nil && len(p) > limiter.Burst() {
+		p = p[:limiter.Burst()]
+	}
+	n, err := c.Conn.Read(p)
+	if n > 0 {
+		c.session.lastActivity.Store(time.Now().UnixNano())
+		c.session.bytesIn.Add(int64(n))
+		if limiter := c.session.inLimiter; limiter != nil {
+			if waitErr := limiter.WaitN(c.session.ctx, n); waitErr != nil && err == nil {
+				err = waitErr
+			}
+		}
+	}
+	return n, err
+}
+
+func (c *sessionConn) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		chunk := p
+		if limiter := c.session.outLimiter; limiter != nil {
+			if len(chunk) > limiter.Burst() {
+				chunk = chunk[:limiter.Burst()]
+			}
+			if err := limiter.WaitN(c.session.ctx, len(chunk)); err != nil {
+				return written, err
+			}
+		}
+		n, err := c.Conn.Write(chunk)
+		written += n
+		if n > 0 {
+			c.session.lastActivity.Store(time.Now().UnixNano())
+			c.session.bytesOut.Add(int64(n))
+		}
+		if err != nil {
+			return written, err
+		}
+		p = p[n:]
+	}
+	return written, nil
+}
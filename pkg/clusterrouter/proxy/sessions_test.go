@@ -0,0 +1,123 @@
+package proxy
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamRequest(t *testing.T) {
+	s, ok := streamRequest("/api/v1/namespaces/default/pods/nginx/exec")
+	require.True(t, ok)
+	assert.Equal(t, Session{Kind: "exec", Namespace: "default", Pod: "nginx"}, s)
+
+	s, ok = streamRequest("/api/v1/namespaces/default/pods/nginx/portforward")
+	require.True(t, ok)
+	assert.Equal(t, "portforward", s.Kind)
+
+	_, ok = streamRequest("/api/v1/namespaces/default/pods/nginx/log")
+	assert.False(t, ok)
+	_, ok = streamRequest("/api/v1/namespaces/default/pods")
+	assert.False(t, ok)
+	_, ok = streamRequest("/apis/apps/v1/namespaces/default/deployments/nginx/scale")
+	assert.False(t, ok)
+}
+
+func TestSessionLimits(t *testing.T) {
+	require.NoError(t, settings.ProxyStreamSessionsPerUser.Set("2"))
+	defer settings.ProxyStreamSessionsPerUser.Set(settings.ProxyStreamSessionsPerUser.Default)
+	require.NoError(t, settings.ProxyStreamSessionsPerCluster.Set("3"))
+	defer settings.ProxyStreamSessionsPerCluster.Set(settings.ProxyStreamSessionsPerCluster.Default)
+
+	tracker := NewSessionTracker()
+	first, err := tracker.start(Session{User: "u-1", Cluster: "c-1"})
+	require.NoError(t, err)
+	_, err = tracker.start(Session{User: "u-1", Cluster: "c-1"})
+	require.NoError(t, err)
+	_, err = tracker.start(Session{User: "u-1", Cluster: "c-2"})
+	assert.Equal(t, errTooManyUserSessions, err)
+
+	_, err = tracker.start(Session{User: "u-2", Cluster: "c-1"})
+	require.NoError(t, err)
+	_, err = tracker.start(Session{User: "u-3", Cluster: "c-1"})
+	assert.Equal(t, errTooManyClusterSessions, err)
+	_, err = tracker.start(Session{User: "u-3", Cluster: "c-2"})
+	require.NoError(t, err)
+
+	assert.Len(t, tracker.List(""), 4)
+	assert.Len(t, tracker.List("c-1"), 3)
+
+	tracker.end(first)
+	_, err = tracker.start(Session{User: "u-1", Cluster: "c-2"})
+	assert.NoError(t, err, "ended sessions don't count towards the limits")
+}
+
+func TestSessionTerminate(t *testing.T) {
+	tracker := NewSessionTracker()
+	s, err := tracker.start(Session{User: "u-1", Cluster: "c-1"})
+	require.NoError(t, err)
+
+	user, backend := net.Pipe()
+	defer backend.Close()
+	conn := s.attach(user)
+
+	assert.False(t, tracker.Terminate("unknown"))
+	assert.True(t, tracker.Terminate(s.ID))
+	_, err = conn.Read(make([]byte, 1))
+	assert.Error(t, err, "terminated sessions are closed")
+}
+
+func TestSessionCloseIdle(t *testing.T) {
+	tracker := NewSessionTracker()
+	s, err := tracker.start(Session{User: "u-1", Cluster: "c-1"})
+	require.NoError(t, err)
+
+	user, backend := net.Pipe()
+	defer backend.Close()
+	conn := s.attach(user)
+
+	go backend.Write([]byte("ok"))
+	buf := make([]byte, 2)
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), tracker.List("")[0].BytesIn)
+
+	tracker.closeIdle(time.Now(), time.Minute)
+	tracker.closeIdle(time.Now().Add(30*time.Minute), 0)
+	go backend.Write([]byte("ok"))
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err, "active sessions and disabled idle timeouts don't close sessions")
+
+	tracker.closeIdle(time.Now().Add(2*time.Minute), time.Minute)
+	_, err = conn.Read(buf)
+	assert.Error(t, err, "idle sessions are closed")
+}
+
+func TestSessionBandwidth(t *testing.T) {
+	require.NoError(t, settings.ProxyStreamBandwidthBytesPerSecond.Set("1024"))
+	defer settings.ProxyStreamBandwidthBytesPerSecond.Set(settings.ProxyStreamBandwidthBytesPerSecond.Default)
+
+	tracker := NewSessionTracker()
+	s, err := tracker.start(Session{User: "u-1", Cluster: "c-1"})
+	require.NoError(t, err)
+	defer tracker.end(s)
+
+	user, backend := net.Pipe()
+	defer backend.Close()
+	conn := s.attach(user)
+
+	// The first burst isn't delayed, the next 1KiB takes a second.
+	payload := make([]byte, minBurst+1024)
+	go io.Copy(io.Discard, backend)
+	start := time.Now()
+	n, err := conn.Write(payload)
+	require.NoError(t, err)
+	assert.Equal(t, len(payload), n)
+	assert.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+	assert.Equal(t, int64(len(payload)), tracker.List("")[0].BytesOut)
+}
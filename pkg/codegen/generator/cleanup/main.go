@@ -20,6 +20,11 @@ func run() error {
 	if err := os.RemoveAll("./pkg/generated"); err != nil {
 		return err
 	}
+	for _, dir := range []string{"clientset", "informers", "listers"} {
+		if err := os.RemoveAll(filepath.Join("./pkg/provisioningclient", dir)); err != nil {
+			return err
+		}
+	}
 	return filepath.Walk("./pkg/apis", func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
@@ -91,12 +91,12 @@ func main() {
 				Types: []interface{}{
 					"./pkg/apis/provisioning.cattle.io/v1",
 				},
-				GenerateTypes:   true,
-				GenerateClients: true,
+				GenerateTypes: true,
 			},
 			"fleet.cattle.io": {
 				Types: []interface{}{
 					fleet.Bundle{},
+					fleet.BundleDeployment{},
 					fleet.Cluster{},
 				},
 			},
@@ -104,8 +104,7 @@ func main() {
 				Types: []interface{}{
 					"./pkg/apis/rke.cattle.io/v1",
 				},
-				GenerateTypes:   true,
-				GenerateClients: true,
+				GenerateTypes: true,
 			},
 			"cluster.x-k8s.io": {
 				Types: []interface{}{
@@ -118,6 +117,35 @@ func main() {
 		},
 	})
 
+	// The clients, listers and informers of the provisioning and rke APIs are generated into their own module, so that
+	// they can be used without depending on all of rancher.
+	controllergen.Run(args.Options{
+		OutputPackage: "github.com/rancher/rancher/pkg/provisioningclient",
+		Boilerplate:   "scripts/boilerplate.go.txt",
+		Groups: map[string]args.Group{
+			"provisioning.cattle.io": {
+				Types: []interface{}{
+					"./pkg/apis/provisioning.cattle.io/v1",
+				},
+				GenerateClients:   true,
+				GenerateListers:   true,
+				GenerateInformers: true,
+			},
+			"rke.cattle.io": {
+				Types: []interface{}{
+					"./pkg/apis/rke.cattle.io/v1",
+				},
+				GenerateClients:   true,
+				GenerateListers:   true,
+				GenerateInformers: true,
+			},
+		},
+	})
+	// controller-gen always generates wrangler controllers, which would pull lasso and wrangler into the module.
+	if err := os.RemoveAll("./pkg/provisioningclient/controllers"); err != nil {
+		panic(err)
+	}
+
 	clusterAPIVersion := &types.APIVersion{Group: capi.GroupVersion.Group, Version: capi.GroupVersion.Version, Path: "/v1"}
 	generator.GenerateClient(factory.Schemas(clusterAPIVersion).Init(func(schemas *types.Schemas) *types.Schemas {
 		return schemas.MustImportAndCustomize(clusterAPIVersion, capi.Machine{}, func(schema *types.Schema) {
@@ -0,0 +1,115 @@
+package compatibility
+
+import (
+	"context"
+	"reflect"
+	"sort"
+
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/rancher/pkg/channelserver"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/wrangler"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type Checker struct {
+	nodeCache mgmtcontrollers.NodeCache
+}
+
+func NewChecker(clients *wrangler.Context) *Checker {
+	return &Checker{
+		nodeCache: clients.Mgmt.Node().Cache(),
+	}
+}
+
+// Check returns the reasons the Kubernetes version of an RKE2 or K3s cluster is incompatible with the cluster, on
+// creation, or on update if oldCluster is set. Updates are only checked when they change the Kubernetes version, the
+// CNI or the OS of the machine pools, the nodes of the cluster being checked as well.
+func (c *Checker) Check(ctx context.Context, cluster, oldCluster *provv1.Cluster) ([]string, error) {
+	if cluster.Spec.RKEConfig == nil || cluster.Spec.KubernetesVersion == "" {
+		return nil, nil
+	}
+	if oldCluster != nil && oldCluster.Spec.RKEConfig != nil && !relevantChange(cluster, oldCluster) {
+		return nil, nil
+	}
+
+	runtime := capr.GetRuntime(cluster.Spec.KubernetesVersion)
+	target := Cluster{
+		KubernetesVersion: cluster.Spec.KubernetesVersion,
+		Runtime:           runtime,
+		CNIs:              cnis(cluster),
+	}
+	for _, pool := range cluster.Spec.RKEConfig.MachinePools {
+		target.Machines = append(target.Machines, Machine{
+			Name: "pool " + pool.Name,
+			OS:   machineOS(pool.MachineOS),
+		})
+	}
+	if oldCluster != nil && cluster.Status.ClusterName != "" {
+		nodes, err := c.nodeCache.List(cluster.Status.ClusterName, labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range nodes {
+			name := node.Status.NodeName
+			if name == "" {
+				name = node.Name
+			}
+			target.Machines = append(target.Machines, Machine{
+				Name:                    "node " + name,
+				OS:                      machineOS(node.Status.NodeLabels[corev1.LabelOSStable]),
+				OSImage:                 node.Status.InternalNodeStatus.NodeInfo.OSImage,
+				ContainerRuntimeVersion: node.Status.InternalNodeStatus.NodeInfo.ContainerRuntimeVersion,
+			})
+		}
+	}
+
+	release := channelserver.GetReleaseConfigByRuntimeAndVersion(ctx, runtime, cluster.Spec.KubernetesVersion)
+	return Current().Check(target, &release), nil
+}
+
+// relevantChange returns whether an update changes what the compatibility of a cluster is checked on.
+func relevantChange(cluster, oldCluster *provv1.Cluster) bool {
+	return cluster.Spec.KubernetesVersion != oldCluster.Spec.KubernetesVersion ||
+		!reflect.DeepEqual(cnis(cluster), cnis(oldCluster)) ||
+		!reflect.DeepEqual(poolOS(cluster), poolOS(oldCluster))
+}
+
+// cnis returns the CNIs of the global machine config of a cluster, which can be a single CNI or a list.
+func cnis(cluster *provv1.Cluster) []string {
+	var result []string
+	switch cni := cluster.Spec.RKEConfig.MachineGlobalConfig.Data["cni"].(type) {
+	case string:
+		if cni != "" {
+			result = append(result, cni)
+		}
+	case []interface{}:
+		for _, v := range cni {
+			if s, ok := v.(string); ok && s != "" {
+				result = append(result, s)
+			}
+		}
+	case []string:
+		result = append(result, cni...)
+	}
+	return result
+}
+
+// poolOS returns the sorted OS of the machine pools of a cluster.
+func poolOS(cluster *provv1.Cluster) []string {
+	var result []string
+	for _, pool := range cluster.Spec.RKEConfig.MachinePools {
+		result = append(result, pool.Name+"="+machineOS(pool.MachineOS))
+	}
+	sort.Strings(result)
+	return result
+}
+
+func machineOS(os string) string {
+	if os == "" {
+		return capr.DefaultMachineOS
+	}
+	return os
+}
@@ -0,0 +1,261 @@
+// Package compatibility validates the Kubernetes versions of RKE2 and K3s clusters against the CNIs of the clusters and
+// the OS, OS images and containerd versions of their machines, so that known bad combinations are rejected when the
+// cluster is created or upgraded rather than failing on the nodes.
+package compatibility
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/blang/semver"
+	"github.com/rancher/channelserver/pkg/model"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/sirupsen/logrus"
+)
+
+// Rule is a known bad combination. A rule matches when all of its conditions set match, and conditions on machines
+// must all match the same machine.
+type Rule struct {
+	// KubernetesVersions is the semver range of the Kubernetes versions the rule applies to, such as ">=1.26.0-0".
+	KubernetesVersions string `json:"kubernetesVersions,omitempty"`
+	// Runtimes are the runtimes the rule applies to, rke2 or k3s.
+	Runtimes []string `json:"runtimes,omitempty"`
+	// CNIs are the CNIs the rule applies to.
+	CNIs []string `json:"cnis,omitempty"`
+	// MachineOS are the OS of the machines the rule applies to, linux or windows.
+	MachineOS []string `json:"machineOS,omitempty"`
+	// OSImage is a regular expression matching the OS images of the nodes the rule applies to, such as
+	// "^CentOS Linux 7".
+	OSImage string `json:"osImage,omitempty"`
+	// ContainerdVersions is the semver range of the containerd versions of the nodes the rule applies to.
+	ContainerdVersions string `json:"containerdVersions,omitempty"`
+	// Message explains why the combination is rejected and how to fix it.
+	Message string `json:"message"`
+}
+
+// Cluster is the cluster being validated.
+type Cluster struct {
+	KubernetesVersion string
+	// Runtime is rke2 or k3s.
+	Runtime  string
+	CNIs     []string
+	Machines []Machine
+}
+
+// Machine is a machine pool, whose machines are yet to be provisioned, or the node of an existing machine.
+type Machine struct {
+	// Name describes the machine, for example "pool pool1" or "node node1".
+	Name string
+	OS   string
+	// OSImage and ContainerRuntimeVersion are only known for the nodes of existing machines, for example
+	// "Ubuntu 22.04.2 LTS" and "containerd://1.6.8-k3s1".
+	OSImage                 string
+	ContainerRuntimeVersion string
+}
+
+// defaultRules are always checked, on top of the rules of the kubernetes-compatibility-rules setting.
+var defaultRules = []Rule{
+	{
+		KubernetesVersions: ">=1.26.0-0",
+		ContainerdVersions: "<1.6.0-0",
+		Message:            "Kubernetes 1.26 and later require containerd 1.6 or later, upgrade containerd on the nodes or use the containerd embedded in the runtime",
+	},
+	{
+		Runtimes:  []string{"k3s"},
+		MachineOS: []string{"windows"},
+		Message:   "K3s does not support Windows machines, use RKE2",
+	},
+	{
+		Runtimes:  []string{"rke2"},
+		CNIs:      []string{"canal", "cilium"},
+		MachineOS: []string{"windows"},
+		Message:   "the canal and cilium CNIs do not support Windows machines, use calico or flannel",
+	},
+}
+
+type rule struct {
+	Rule
+	kubernetesVersions semver.Range
+	osImage            *regexp.Regexp
+	containerdVersions semver.Range
+}
+
+// Matrix checks clusters against the released KDM data of their Kubernetes version and against rules.
+type Matrix struct {
+	rules []rule
+}
+
+// New returns the matrix of the default rules and the given rules.
+func New(rules []Rule) (*Matrix, error) {
+	m := &Matrix{}
+	for _, r := range append(append([]Rule(nil), defaultRules...), rules...) {
+		compiled, err := compile(r)
+		if err != nil {
+			return nil, err
+		}
+		m.rules = append(m.rules, compiled)
+	}
+	return m, nil
+}
+
+func compile(r Rule) (rule, error) {
+	result := rule{Rule: r}
+	if r.Message == "" {
+		return result, fmt.Errorf("compatibility rule has no message")
+	}
+	var err error
+	if r.KubernetesVersions != "" {
+		if result.kubernetesVersions, err = semver.ParseRange(r.KubernetesVersions); err != nil {
+			return result, fmt.Errorf("invalid Kubernetes versions %q of compatibility rule: %w", r.KubernetesVersions, err)
+		}
+	}
+	if r.OSImage != "" {
+		if result.osImage, err = regexp.Compile(r.OSImage); err != nil {
+			return result, fmt.Errorf("invalid OS image %q of compatibility rule: %w", r.OSImage, err)
+		}
+	}
+	if r.ContainerdVersions != "" {
+		if result.containerdVersions, err = semver.ParseRange(r.ContainerdVersions); err != nil {
+			return result, fmt.Errorf("invalid containerd versions %q of compatibility rule: %w", r.ContainerdVersions, err)
+		}
+	}
+	return result, nil
+}
+
+var (
+	currentLock  sync.Mutex
+	currentRules string
+	current      *Matrix
+)
+
+// Current returns the matrix for the rules of the kubernetes-compatibility-rules setting. If the setting is invalid,
+// only the default rules are checked.
+func Current() *Matrix {
+	rules := settings.KubernetesCompatibilityRules.Get()
+
+	currentLock.Lock()
+	defer currentLock.Unlock()
+	if current != nil && currentRules == rules {
+		return current
+	}
+
+	m, err := parse(rules)
+	if err != nil {
+		logrus.Errorf("[compatibility] ignoring setting %s: %v", settings.KubernetesCompatibilityRules.Name, err)
+		m, _ = New(nil)
+	}
+	current, currentRules = m, rules
+	return current
+}
+
+// parse returns the matrix for a JSON list of rules.
+func parse(rules string) (*Matrix, error) {
+	var list []Rule
+	if rules != "" {
+		if err := json.Unmarshal([]byte(rules), &list); err != nil {
+			return nil, fmt.Errorf("rules must be a JSON list of compatibility rules: %w", err)
+		}
+	}
+	return New(list)
+}
+
+// Check returns the reasons the cluster is incompatible with its Kubernetes version. The CNIs of the cluster are
+// checked against the CNIs the release of the version supports, if the release is known.
+func (m *Matrix) Check(cluster Cluster, release *model.Release) []string {
+	var result []string
+	if release != nil && release.Version == cluster.KubernetesVersion {
+		if supported := release.ServerArgs["cni"].Options; len(supported) > 0 {
+			for _, cni := range cluster.CNIs {
+				if !contains(supported, cni) {
+					result = append(result, fmt.Sprintf("CNI %s is not supported by Kubernetes version %s, use one of: %s",
+						cni, cluster.KubernetesVersion, strings.Join(supported, ", ")))
+				}
+			}
+		}
+	}
+
+	version, err := semver.ParseTolerant(cluster.KubernetesVersion)
+	if err != nil {
+		return append(result, fmt.Sprintf("invalid Kubernetes version %s: %v", cluster.KubernetesVersion, err))
+	}
+
+	for _, r := range m.rules {
+		if r.kubernetesVersions != nil && !r.kubernetesVersions(version) {
+			continue
+		}
+		if len(r.Runtimes) > 0 && !contains(r.Runtimes, cluster.Runtime) {
+			continue
+		}
+		if len(r.CNIs) > 0 && !containsAny(r.CNIs, cluster.CNIs) {
+			continue
+		}
+		if !r.machineConditions() {
+			result = append(result, fmt.Sprintf("Kubernetes version %s: %s", cluster.KubernetesVersion, r.Message))
+			continue
+		}
+		var machines []string
+		for _, machine := range cluster.Machines {
+			if r.matches(machine) {
+				machines = append(machines, machine.Name)
+			}
+		}
+		if len(machines) > 0 {
+			sort.Strings(machines)
+			result = append(result, fmt.Sprintf("Kubernetes version %s: %s (%s)", cluster.KubernetesVersion, r.Message, strings.Join(machines, ", ")))
+		}
+	}
+	return result
+}
+
+func (r rule) machineConditions() bool {
+	return len(r.MachineOS) > 0 || r.osImage != nil || r.containerdVersions != nil
+}
+
+func (r rule) matches(machine Machine) bool {
+	if len(r.MachineOS) > 0 && !contains(r.MachineOS, machine.OS) {
+		return false
+	}
+	if r.osImage != nil && (machine.OSImage == "" || !r.osImage.MatchString(machine.OSImage)) {
+		return false
+	}
+	if r.containerdVersions != nil {
+		version, ok := containerdVersion(machine.ContainerRuntimeVersion)
+		if !ok || !r.containerdVersions(version) {
+			return false
+		}
+	}
+	return true
+}
+
+// containerdVersion returns the version of a container runtime version reported by the kubelet, such as
+// "containerd://1.6.8-k3s1", if the runtime is containerd.
+func containerdVersion(runtimeVersion string) (semver.Version, bool) {
+	v := strings.TrimPrefix(runtimeVersion, "containerd://")
+	if v == runtimeVersion {
+		return semver.Version{}, false
+	}
+	version, err := semver.ParseTolerant(v)
+	return version, err == nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(list, items []string) bool {
+	for _, item := range items {
+		if contains(list, item) {
+			return true
+		}
+	}
+	return false
+}
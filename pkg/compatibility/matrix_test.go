@@ -0,0 +1,168 @@
+package compatibility
+
+import (
+	"testing"
+
+	"github.com/rancher/channelserver/pkg/model"
+	"github.com/rancher/wrangler/pkg/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	release := &model.Release{
+		Version: "v1.26.4+rke2r1",
+		ServerArgs: map[string]schemas.Field{
+			"cni": {Options: []string{"canal", "calico", "cilium"}},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		rules   []Rule
+		cluster Cluster
+		release *model.Release
+		want    []string
+	}{
+		{
+			name: "compatible",
+			cluster: Cluster{
+				KubernetesVersion: "v1.26.4+rke2r1",
+				Runtime:           "rke2",
+				CNIs:              []string{"calico"},
+				Machines: []Machine{
+					{Name: "pool windows", OS: "windows"},
+					{Name: "node node1", OS: "linux", OSImage: "Ubuntu 22.04.2 LTS", ContainerRuntimeVersion: "containerd://1.6.19-k3s1"},
+				},
+			},
+			release: release,
+		},
+		{
+			name: "unsupported cni",
+			cluster: Cluster{
+				KubernetesVersion: "v1.26.4+rke2r1",
+				Runtime:           "rke2",
+				CNIs:              []string{"flannel"},
+			},
+			release: release,
+			want:    []string{"CNI flannel is not supported by Kubernetes version v1.26.4+rke2r1, use one of: canal, calico, cilium"},
+		},
+		{
+			name: "cni of unknown release",
+			cluster: Cluster{
+				KubernetesVersion: "v1.27.1+rke2r1",
+				Runtime:           "rke2",
+				CNIs:              []string{"flannel"},
+			},
+			release: release,
+		},
+		{
+			name: "old containerd",
+			cluster: Cluster{
+				KubernetesVersion: "v1.26.4+k3s1",
+				Runtime:           "k3s",
+				Machines: []Machine{
+					{Name: "node node2", OS: "linux", ContainerRuntimeVersion: "containerd://1.5.13"},
+					{Name: "node node1", OS: "linux", ContainerRuntimeVersion: "containerd://1.5.9-k3s1"},
+					{Name: "node node3", OS: "linux", ContainerRuntimeVersion: "containerd://1.6.0-k3s1"},
+					{Name: "node node4", OS: "linux", ContainerRuntimeVersion: "docker://20.10.21"},
+				},
+			},
+			want: []string{"Kubernetes version v1.26.4+k3s1: Kubernetes 1.26 and later require containerd 1.6 or later, upgrade containerd on the nodes or use the containerd embedded in the runtime (node node1, node node2)"},
+		},
+		{
+			name: "old containerd before 1.26",
+			cluster: Cluster{
+				KubernetesVersion: "v1.25.9+k3s1",
+				Runtime:           "k3s",
+				Machines: []Machine{
+					{Name: "node node1", OS: "linux", ContainerRuntimeVersion: "containerd://1.5.9-k3s1"},
+				},
+			},
+		},
+		{
+			name: "windows cni",
+			cluster: Cluster{
+				KubernetesVersion: "v1.26.4+rke2r1",
+				Runtime:           "rke2",
+				CNIs:              []string{"cilium"},
+				Machines: []Machine{
+					{Name: "pool linux", OS: "linux"},
+					{Name: "pool windows", OS: "windows"},
+				},
+			},
+			release: release,
+			want:    []string{"Kubernetes version v1.26.4+rke2r1: the canal and cilium CNIs do not support Windows machines, use calico or flannel (pool windows)"},
+		},
+		{
+			name: "os image rule",
+			rules: []Rule{
+				{
+					KubernetesVersions: ">=1.25.0-0",
+					OSImage:            "^CentOS Linux 7",
+					Message:            "CentOS 7 is not supported",
+				},
+			},
+			cluster: Cluster{
+				KubernetesVersion: "v1.25.9+rke2r1",
+				Runtime:           "rke2",
+				Machines: []Machine{
+					{Name: "pool pool1", OS: "linux"},
+					{Name: "node node1", OS: "linux", OSImage: "CentOS Linux 7 (Core)"},
+					{Name: "node node2", OS: "linux", OSImage: "Rocky Linux 8.7 (Green Obsidian)"},
+				},
+			},
+			want: []string{"Kubernetes version v1.25.9+rke2r1: CentOS 7 is not supported (node node1)"},
+		},
+		{
+			name: "cluster rule",
+			rules: []Rule{
+				{
+					KubernetesVersions: ">=1.24.8-0 <1.24.9-0",
+					Runtimes:           []string{"k3s"},
+					Message:            "v1.24.8+k3s1 has a known regression, use v1.24.9+k3s1",
+				},
+			},
+			cluster: Cluster{
+				KubernetesVersion: "v1.24.8+k3s1",
+				Runtime:           "k3s",
+			},
+			want: []string{"Kubernetes version v1.24.8+k3s1: v1.24.8+k3s1 has a known regression, use v1.24.9+k3s1"},
+		},
+		{
+			name: "invalid version",
+			cluster: Cluster{
+				KubernetesVersion: "latest",
+				Runtime:           "rke2",
+			},
+			want: []string{`invalid Kubernetes version latest: Invalid character(s) found in major number "latest"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := New(tt.rules)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, m.Check(tt.cluster, tt.release))
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	m, err := parse(`[{"osImage": "^Ubuntu 18\\.04", "message": "Ubuntu 18.04 is not supported"}]`)
+	require.NoError(t, err)
+	assert.Len(t, m.rules, len(defaultRules)+1)
+
+	m, err = parse("")
+	require.NoError(t, err)
+	assert.Len(t, m.rules, len(defaultRules))
+
+	_, err = parse(`{}`)
+	assert.Error(t, err)
+	_, err = parse(`[{"kubernetesVersions": "not a range", "message": "bad"}]`)
+	assert.Error(t, err)
+	_, err = parse(`[{"osImage": "("}]`)
+	assert.Error(t, err)
+	_, err = parse(`[{"osImage": "(", "message": "bad"}]`)
+	assert.Error(t, err)
+}
@@ -10,6 +10,7 @@
 
 	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
 	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/rancher/pkg/capr/bootstrapkey"
 	"github.com/rancher/rancher/pkg/capr/installer"
 	"github.com/rancher/rancher/pkg/controllers/capr/etcdmgmt"
 	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
@@ -37,9 +38,8 @@
 )
 
 const (
-	rkeBootstrapName                       = "rke.cattle.io/rkebootstrap-name"
-	capiMachinePreTerminateAnnotation      = "pre-terminate.delete.hook.machine.cluster.x-k8s.io/rke-bootstrap-cleanup"
-	capiMachinePreTerminateAnnotationOwner = "rke-bootstrap-controller"
+	rkeBootstrapName                  = "rke.cattle.io/rkebootstrap-name"
+	capiMachinePreTerminateAnnotation = "pre-terminate.delete.hook.machine.cluster.x-k8s.io/rke-bootstrap-cleanup"
 )
 
 type handler struct {
@@ -105,8 +105,33 @@ func Register(ctx context.Context, clients *wrangler.Context) {
 				}}, nil
 			}
 		}
+		if secret, ok := obj.(*corev1.Secret); ok {
+			return h.firstPlanApplied(secret), nil
+		}
 		return nil, nil
-	}, clients.RKE.RKEBootstrap(), clients.Core.ServiceAccount(), clients.CAPI.Machine())
+	}, clients.RKE.RKEBootstrap(), clients.Core.ServiceAccount(), clients.CAPI.Machine(), clients.Core.Secret())
+}
+
+// firstPlanApplied returns the bootstrap of a plan secret if its machine applied its first plan, and it wasn't
+// recorded yet.
+func (h *handler) firstPlanApplied(secret *corev1.Secret) []relatedresource.Key {
+	if secret.Type != capr.SecretTypeMachinePlan || len(secret.Data["appliedPlan"]) == 0 {
+		return nil
+	}
+	for _, owner := range secret.OwnerReferences {
+		if owner.Kind != "RKEBootstrap" {
+			continue
+		}
+		bootstrap, err := h.rkeBootstrap.Cache().Get(secret.Namespace, owner.Name)
+		if err != nil || bootstrap.Status.Phases.BootstrapDelivered == nil || bootstrap.Status.Phases.FirstPlanApplied != nil {
+			return nil
+		}
+		return []relatedresource.Key{{
+			Namespace: bootstrap.Namespace,
+			Name:      bootstrap.Name,
+		}}
+	}
+	return nil
 }
 
 func (h *handler) getBootstrapSecret(namespace, name string, envVars []corev1.EnvVar, machine *capi.Machine) (*corev1.Secret, error) {
@@ -124,6 +149,22 @@ func (h *handler) getBootstrapSecret(namespace, name string, envVars []corev1.En
 	}
 	hash := sha256.Sum256(secret.Data["token"])
 
+	return h.bootstrapSecret(namespace, name, base64.URLEncoding.EncodeToString(hash[:]), envVars, machine)
+}
+
+// getBootstrapKeySecret returns the bootstrap data of a machine authenticating with the one-time bootstrap key of its
+// bootstrap.
+func (h *handler) getBootstrapKeySecret(bootstrap *rkev1.RKEBootstrap, name string, envVars []corev1.EnvVar, machine *capi.Machine) (*corev1.Secret, error) {
+	key, err := bootstrapkey.Ensure(h.secretClient, h.secretCache, bootstrap, machine.Name)
+	if err != nil {
+		return nil, err
+	}
+	return h.bootstrapSecret(bootstrap.Namespace, name, key, envVars, machine)
+}
+
+// bootstrapSecret returns the bootstrap data of a machine, the install script of the system agent authenticating with
+// the token.
+func (h *handler) bootstrapSecret(namespace, name, token string, envVars []corev1.EnvVar, machine *capi.Machine) (*corev1.Secret, error) {
 	hasHostPort, err := h.rancherDeploymentHasHostPort()
 	if err != nil {
 		return nil, err
@@ -133,7 +174,7 @@ func (h *handler) getBootstrapSecret(namespace, name string, envVars []corev1.En
 	if os := machine.GetLabels()[capr.CattleOSLabel]; os == capr.WindowsMachineOS {
 		is = installer.WindowsInstallScript
 	}
-	data, err := is(context.WithValue(context.Background(), tls.InternalAPI, hasHostPort), base64.URLEncoding.EncodeToString(hash[:]), envVars, "")
+	data, err := is(context.WithValue(context.Background(), tls.InternalAPI, hasHostPort), token, envVars, "")
 	if err != nil {
 		return nil, err
 	}
@@ -175,6 +216,9 @@ func (h *handler) assignPlanSecret(machine *capi.Machine, bootstrap *rkev1.RKEBo
 		},
 		Type: capr.SecretTypeMachinePlan,
 	}
+	// The agent only watches its plan secret for changes through the Kubernetes API. It reads its plan and writes the
+	// fields it owns through the plan endpoint of the config server, which decrypts the plans and keeps the agent from
+	// writing the fields of rancher.
 	role := &rbacv1.Role{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      secretName,
@@ -182,7 +226,7 @@ func (h *handler) assignPlanSecret(machine *capi.Machine, bootstrap *rkev1.RKEBo
 		},
 		Rules: []rbacv1.PolicyRule{
 			{
-				Verbs:         []string{"watch", "get", "update", "list"},
+				Verbs:         []string{"watch", "list"},
 				APIGroups:     []string{""},
 				Resources:     []string{"secrets"},
 				ResourceNames: []string{secretName},
@@ -211,14 +255,17 @@ func (h *handler) assignPlanSecret(machine *capi.Machine, bootstrap *rkev1.RKEBo
 	return []runtime.Object{sa, secret, role, roleBinding}
 }
 
-func (h *handler) getEnvVar(bootstrap *rkev1.RKEBootstrap, capiCluster *capi.Cluster) ([]corev1.EnvVar, error) {
+// getControlPlane returns the control plane of the cluster of a bootstrap, or nil if it isn't an RKEControlPlane.
+func (h *handler) getControlPlane(bootstrap *rkev1.RKEBootstrap, capiCluster *capi.Cluster) (*rkev1.RKEControlPlane, error) {
 	if capiCluster.Spec.ControlPlaneRef == nil || capiCluster.Spec.ControlPlaneRef.Kind != "RKEControlPlane" {
 		return nil, nil
 	}
+	return h.rkeControlPlanes.Get(bootstrap.Namespace, capiCluster.Spec.ControlPlaneRef.Name)
+}
 
-	cp, err := h.rkeControlPlanes.Get(bootstrap.Namespace, capiCluster.Spec.ControlPlaneRef.Name)
-	if err != nil {
-		return nil, err
+func getEnvVar(cp *rkev1.RKEControlPlane) []corev1.EnvVar {
+	if cp == nil {
+		return nil
 	}
 
 	var result []corev1.EnvVar
@@ -229,7 +276,7 @@ func (h *handler) getEnvVar(bootstrap *rkev1.RKEBootstrap, capiCluster *capi.Clu
 		})
 	}
 
-	return result, nil
+	return result
 }
 
 func (h *handler) assignBootStrapSecret(machine *capi.Machine, bootstrap *rkev1.RKEBootstrap, capiCluster *capi.Cluster) (*corev1.Secret, []runtime.Object, error) {
@@ -240,10 +287,11 @@ func (h *handler) assignBootStrapSecret(machine *capi.Machine, bootstrap *rkev1.
 		return nil, nil, nil
 	}
 
-	envVars, err := h.getEnvVar(bootstrap, capiCluster)
+	cp, err := h.getControlPlane(bootstrap, capiCluster)
 	if err != nil {
 		return nil, nil, err
 	}
+	envVars := getEnvVar(cp)
 
 	secretName := name.SafeConcatName(bootstrap.Name, "machine", "bootstrap")
 
@@ -259,7 +307,12 @@ func (h *handler) assignBootStrapSecret(machine *capi.Machine, bootstrap *rkev1.
 		},
 	}
 
-	bootstrapSecret, err := h.getBootstrapSecret(sa.Namespace, sa.Name, envVars, machine)
+	var bootstrapSecret *corev1.Secret
+	if cp != nil && cp.Spec.OneTimeBootstrapKeys {
+		bootstrapSecret, err = h.getBootstrapKeySecret(bootstrap, secretName, envVars, machine)
+	} else {
+		bootstrapSecret, err = h.getBootstrapSecret(sa.Namespace, sa.Name, envVars, machine)
+	}
 	if err != nil {
 		return nil, nil, err
 	}
@@ -287,6 +340,8 @@ func (h *handler) OnChange(_ string, bootstrap *rkev1.RKEBootstrap) (*rkev1.RKEB
 func (h *handler) GeneratingHandler(bootstrap *rkev1.RKEBootstrap, status rkev1.RKEBootstrapStatus) ([]runtime.Object, rkev1.RKEBootstrapStatus, error) {
 	var (
 		result []runtime.Object
+		// the phases of machines bootstrapped before phases were recorded are unknown
+		recordingPhases = !status.Ready || status.Phases.BootstrapDelivered != nil
 	)
 
 	machine, err := capr.GetOwnerCAPIMachine(bootstrap, h.machineCache)
@@ -339,6 +394,22 @@ func (h *handler) GeneratingHandler(bootstrap *rkev1.RKEBootstrap, status rkev1.
 		result = append(result, bootstrapSecret)
 	}
 
+	if recordingPhases {
+		planSecret, err := h.secretCache.Get(bootstrap.Namespace, capr.PlanSecretFromBootstrapName(bootstrap.Name))
+		if apierrors.IsNotFound(err) {
+			planSecret = nil
+		} else if err != nil {
+			return nil, status, err
+		}
+		cp, err := h.getControlPlane(bootstrap, capiCluster)
+		if apierrors.IsNotFound(err) {
+			cp = nil
+		} else if err != nil {
+			return nil, status, err
+		}
+		recordPhases(bootstrap, cp, &status, machine, planSecret, time.Now())
+	}
+
 	result = append(result, objs...)
 	return result, status, nil
 }
@@ -386,12 +457,11 @@ func (h *handler) OnRemove(_ string, bootstrap *rkev1.RKEBootstrap) (*rkev1.RKEB
 	return h.reconcileMachinePreTerminateAnnotation(bootstrap)
 }
 
-// reconcileMachinePreTerminateAnnotation reconciles the machine object that owns the bootstrap. It only reconciles the machine if it is an
-// etcd machine. Its primary purpose is to manage the pre-terminate.delete.hook.machine.x-k8s.io annotation on the machine
-// object, which is used to prevent premature tear down of infrastructure before it is ready to be teared down, i.e.
-// allowing removal of an etcd member without causing quorum loss.
-// The pre-terminate hook will be set on the machine object if the machine and bootstrap are not deleting, the corresponding
-// CAPI cluster and RKEControlPlane are not deleting, and the force remove annotation is not set on the bootstrap.
+// reconcileMachinePreTerminateAnnotation reconciles the machine object that owns the bootstrap. The pre-terminate hook of
+// the machines is held by the machine deletion hooks controller, which removes the etcd member of deleting etcd machines
+// before their infrastructure is torn down. This only finishes the etcd member removal of the machines that were
+// annotated with the former pre-terminate.delete.hook.machine.x-k8s.io/rke-bootstrap-cleanup annotation, and removes the
+// annotation from the machines that are not deleting.
 // The annotation will be removed from the machine to allow infrastructure cleanup in the following cases:
 // * The machine is deleting and the "safe remove" logic has fired and removed the etcd member from the etcd cluster
 // * The bootstrap is missing the CAPI cluster label || the CAPI cluster controlPlaneRef is nil || the machine noderef is nil
@@ -406,6 +476,10 @@ func (h *handler) reconcileMachinePreTerminateAnnotation(bootstrap *rkev1.RKEBoo
 		return bootstrap, err
 	}
 
+	if _, ok := machine.GetAnnotations()[capiMachinePreTerminateAnnotation]; !ok {
+		return bootstrap, nil
+	}
+
 	_, isEtcd := machine.Labels[capr.EtcdRoleLabel]
 
 	forceRemove, ok := bootstrap.Annotations[capr.ForceRemoveEtcdAnnotation]
@@ -415,19 +489,8 @@ func (h *handler) reconcileMachinePreTerminateAnnotation(bootstrap *rkev1.RKEBoo
 	}
 
 	if machine.DeletionTimestamp.IsZero() && bootstrap.DeletionTimestamp.IsZero() {
-		// annotate the CAPI machine with the pre-terminate.delete.hook.machine.cluster.x-k8s.io annotation if it is an etcd machine
-		if val, ok := machine.GetAnnotations()[capiMachinePreTerminateAnnotation]; !ok || val != capiMachinePreTerminateAnnotationOwner {
-			machine = machine.DeepCopy()
-			if machine.Annotations == nil {
-				machine.Annotations = make(map[string]string)
-			}
-			machine.Annotations[capiMachinePreTerminateAnnotation] = capiMachinePreTerminateAnnotationOwner
-			machine, err = h.machineClient.Update(machine)
-			if err != nil {
-				return bootstrap, err
-			}
-		}
-		return bootstrap, nil
+		// The machine deletion hooks controller removes the etcd member once the machine is deleted.
+		return h.ensureMachinePreTerminateAnnotationRemoved(bootstrap, machine)
 	}
 
 	clusterName := bootstrap.Labels[capi.ClusterLabelName]
@@ -0,0 +1,69 @@
+package bootstrap
+
+import (
+	"context"
+	"time"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/metrics"
+	"github.com/rancher/rancher/pkg/tracing"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// Provisioning phases of machines, as labeled in metrics.
+const (
+	phaseInfrastructureReady = "infrastructure_ready"
+	phaseBootstrapDelivered  = "bootstrap_delivered"
+	phaseFirstPlanApplied    = "first_plan_applied"
+	phaseNodeReady           = "node_ready"
+)
+
+// recordPhases records the times of the provisioning phases the machine of a bootstrap reached since they were last
+// recorded, and observes how long the machine took to reach them since it was created, both as a metric and as a span
+// in the trace of the cluster, if its control plane is known. The time a phase was reached is taken from the matching
+// condition of the machine if it has one, or is now.
+func recordPhases(bootstrap *rkev1.RKEBootstrap, controlPlane *rkev1.RKEControlPlane, status *rkev1.RKEBootstrapStatus, machine *capi.Machine, planSecret *corev1.Secret, now time.Time) {
+	var traced metav1.Object
+	if controlPlane != nil {
+		traced = controlPlane
+	}
+	for _, phase := range reachedPhases(status, machine, planSecret, now) {
+		if seconds := phase.time.Sub(machine.CreationTimestamp.Time).Seconds(); seconds >= 0 {
+			metrics.ObserveMachineProvisioningPhase(bootstrap.Namespace, bootstrap.Spec.ClusterName, phase.name, seconds)
+			tracing.RecordClusterSpan(context.Background(), traced, "machine."+phase.name,
+				machine.CreationTimestamp.Time, phase.time, tracing.MachineNameKey.String(machine.Name))
+		}
+	}
+}
+
+type reachedPhase struct {
+	name string
+	time time.Time
+}
+
+// reachedPhases sets the times of the phases reached since they were last recorded in the status, and returns them.
+func reachedPhases(status *rkev1.RKEBootstrapStatus, machine *capi.Machine, planSecret *corev1.Secret, now time.Time) []reachedPhase {
+	var result []reachedPhase
+	record := func(name string, phase **metav1.Time, reached bool, conditionType capi.ConditionType) {
+		if *phase != nil || !reached {
+			return
+		}
+		t := metav1.NewTime(now)
+		if conditionType != "" {
+			if transition := conditions.GetLastTransitionTime(machine, conditionType); transition != nil && !transition.IsZero() {
+				t = *transition
+			}
+		}
+		*phase = &t
+		result = append(result, reachedPhase{name: name, time: t.Time})
+	}
+
+	record(phaseInfrastructureReady, &status.Phases.InfrastructureReady, machine.Status.InfrastructureReady, capi.InfrastructureReadyCondition)
+	record(phaseBootstrapDelivered, &status.Phases.BootstrapDelivered, status.Ready && status.DataSecretName != nil, "")
+	record(phaseFirstPlanApplied, &status.Phases.FirstPlanApplied, planSecret != nil && len(planSecret.Data["appliedPlan"]) > 0, "")
+	record(phaseNodeReady, &status.Phases.NodeReady, machine.Status.NodeRef != nil && conditions.IsTrue(machine, capi.MachineNodeHealthyCondition), capi.MachineNodeHealthyCondition)
+	return result
+}
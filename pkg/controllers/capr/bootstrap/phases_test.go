@@ -0,0 +1,122 @@
+package bootstrap
+
+import (
+	"testing"
+	"time"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestReachedPhases(t *testing.T) {
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	infrastructureReady := metav1.NewTime(now.Add(-3 * time.Minute))
+	nodeHealthy := metav1.NewTime(now.Add(-time.Minute))
+	recorded := metav1.NewTime(now.Add(-10 * time.Minute))
+	dataSecretName := "bootstrap"
+
+	machine := &capi.Machine{
+		Status: capi.MachineStatus{
+			InfrastructureReady: true,
+			NodeRef:             &corev1.ObjectReference{Name: "node1"},
+			Conditions: capi.Conditions{
+				{Type: capi.InfrastructureReadyCondition, Status: corev1.ConditionTrue, LastTransitionTime: infrastructureReady},
+				{Type: capi.MachineNodeHealthyCondition, Status: corev1.ConditionTrue, LastTransitionTime: nodeHealthy},
+			},
+		},
+	}
+	planSecret := &corev1.Secret{
+		Data: map[string][]byte{
+			"appliedPlan": []byte("{}"),
+		},
+	}
+
+	tests := []struct {
+		name       string
+		status     rkev1.RKEBootstrapStatus
+		machine    *capi.Machine
+		planSecret *corev1.Secret
+		want       []reachedPhase
+		wantPhases rkev1.RKEBootstrapPhases
+	}{
+		{
+			name:    "nothing reached",
+			status:  rkev1.RKEBootstrapStatus{},
+			machine: &capi.Machine{},
+		},
+		{
+			name: "all reached",
+			status: rkev1.RKEBootstrapStatus{
+				Ready:          true,
+				DataSecretName: &dataSecretName,
+			},
+			machine:    machine,
+			planSecret: planSecret,
+			want: []reachedPhase{
+				{name: phaseInfrastructureReady, time: infrastructureReady.Time},
+				{name: phaseBootstrapDelivered, time: now},
+				{name: phaseFirstPlanApplied, time: now},
+				{name: phaseNodeReady, time: nodeHealthy.Time},
+			},
+			wantPhases: rkev1.RKEBootstrapPhases{
+				InfrastructureReady: &infrastructureReady,
+				BootstrapDelivered:  &metav1.Time{Time: now},
+				FirstPlanApplied:    &metav1.Time{Time: now},
+				NodeReady:           &nodeHealthy,
+			},
+		},
+		{
+			name: "already recorded",
+			status: rkev1.RKEBootstrapStatus{
+				Ready:          true,
+				DataSecretName: &dataSecretName,
+				Phases: rkev1.RKEBootstrapPhases{
+					InfrastructureReady: &recorded,
+					BootstrapDelivered:  &recorded,
+				},
+			},
+			machine: machine,
+			want: []reachedPhase{
+				{name: phaseNodeReady, time: nodeHealthy.Time},
+			},
+			wantPhases: rkev1.RKEBootstrapPhases{
+				InfrastructureReady: &recorded,
+				BootstrapDelivered:  &recorded,
+				NodeReady:           &nodeHealthy,
+			},
+		},
+		{
+			name: "plan not applied and node not healthy",
+			status: rkev1.RKEBootstrapStatus{
+				Ready:          true,
+				DataSecretName: &dataSecretName,
+			},
+			machine: &capi.Machine{
+				Status: capi.MachineStatus{
+					NodeRef: &corev1.ObjectReference{Name: "node1"},
+					Conditions: capi.Conditions{
+						{Type: capi.MachineNodeHealthyCondition, Status: corev1.ConditionFalse, LastTransitionTime: nodeHealthy},
+					},
+				},
+			},
+			planSecret: &corev1.Secret{},
+			want: []reachedPhase{
+				{name: phaseBootstrapDelivered, time: now},
+			},
+			wantPhases: rkev1.RKEBootstrapPhases{
+				BootstrapDelivered: &metav1.Time{Time: now},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := tt.status.DeepCopy()
+			assert.Equal(t, tt.want, reachedPhases(status, tt.machine, tt.planSecret, now))
+			assert.Equal(t, tt.wantPhases, status.Phases)
+		})
+	}
+}
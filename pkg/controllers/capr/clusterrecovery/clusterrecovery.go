@@ -0,0 +1,542 @@
+package clusterrecovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	provcontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	rkecontrollers "github.com/rancher/rancher/pkg/generated/controllers/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/wrangler"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	// defaultRejoinTimeout is how long the etcd machines are given to rejoin the reset member when the recovery doesn't
+	// set a timeout.
+	defaultRejoinTimeout = 15 * time.Minute
+	requeueInterval      = 15 * time.Second
+	restoreRKEConfigNone = "none"
+)
+
+type handler struct {
+	recoveries        rkecontrollers.ClusterRecoveryController
+	recoveryCache     rkecontrollers.ClusterRecoveryCache
+	provClusters      provcontrollers.ClusterClient
+	provClusterCache  provcontrollers.ClusterCache
+	mgmtClusterCache  mgmtcontrollers.ClusterCache
+	controlPlaneCache rkecontrollers.RKEControlPlaneCache
+	snapshotCache     rkecontrollers.ETCDSnapshotCache
+	machines          capicontrollers.MachineClient
+	machineCache      capicontrollers.MachineCache
+	rkeBootstrap      rkecontrollers.RKEBootstrapClient
+	rkeBootstrapCache rkecontrollers.RKEBootstrapCache
+}
+
+func Register(ctx context.Context, clients *wrangler.Context) {
+	h := &handler{
+		recoveries:        clients.RKE.ClusterRecovery(),
+		recoveryCache:     clients.RKE.ClusterRecovery().Cache(),
+		provClusters:      clients.Provisioning.Cluster(),
+		provClusterCache:  clients.Provisioning.Cluster().Cache(),
+		mgmtClusterCache:  clients.Mgmt.Cluster().Cache(),
+		controlPlaneCache: clients.RKE.RKEControlPlane().Cache(),
+		snapshotCache:     clients.RKE.ETCDSnapshot().Cache(),
+		machines:          clients.CAPI.Machine(),
+		machineCache:      clients.CAPI.Machine().Cache(),
+		rkeBootstrap:      clients.RKE.RKEBootstrap(),
+		rkeBootstrapCache: clients.RKE.RKEBootstrap().Cache(),
+	}
+
+	rkecontrollers.RegisterClusterRecoveryStatusHandler(ctx, clients.RKE.ClusterRecovery(),
+		"", "cluster-recovery", h.OnChange)
+	relatedresource.Watch(ctx, "cluster-recovery-trigger", h.resolve, clients.RKE.ClusterRecovery(),
+		clients.RKE.RKEControlPlane(), clients.CAPI.Machine())
+}
+
+// resolve enqueues the recoveries in progress of the cluster of control planes and machines.
+func (h *handler) resolve(namespace, _ string, obj runtime.Object) ([]relatedresource.Key, error) {
+	var clusterName string
+	switch o := obj.(type) {
+	case *rkev1.RKEControlPlane:
+		clusterName = o.Spec.ClusterName
+	case *capi.Machine:
+		clusterName = o.Labels[capi.ClusterLabelName]
+	}
+	if clusterName == "" {
+		return nil, nil
+	}
+
+	recoveries, err := h.recoveryCache.List(namespace, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var keys []relatedresource.Key
+	for _, recovery := range recoveries {
+		if recovery.Spec.ClusterName == clusterName && inProgress(recovery) {
+			keys = append(keys, relatedresource.Key{Namespace: recovery.Namespace, Name: recovery.Name})
+		}
+	}
+	return keys, nil
+}
+
+// OnChange moves the recovery through its phases. The recovery sets an etcd snapshot restore on the cluster, resetting
+// etcd on the surviving member or restoring the snapshot, waits for the planner to reset etcd and restart the cluster,
+// for the other etcd machines to rejoin or be replaced, then for the cluster to be ready.
+func (h *handler) OnChange(recovery *rkev1.ClusterRecovery, status rkev1.ClusterRecoveryStatus) (rkev1.ClusterRecoveryStatus, error) {
+	if recovery == nil || recovery.DeletionTimestamp != nil {
+		return status, nil
+	}
+
+	var err error
+	switch status.Phase {
+	case "":
+		status, err = h.start(recovery, status)
+	case rkev1.ClusterRecoveryPhaseResetting:
+		status, err = h.reset(recovery, status)
+	case rkev1.ClusterRecoveryPhaseRejoining:
+		status, err = h.rejoin(recovery, status)
+	case rkev1.ClusterRecoveryPhaseVerifying:
+		status, err = h.verify(recovery, status)
+	default:
+		return status, nil
+	}
+	if err == nil && status.Phase != rkev1.ClusterRecoveryPhaseComplete && status.Phase != rkev1.ClusterRecoveryPhaseFailed {
+		h.recoveries.EnqueueAfter(recovery.Namespace, recovery.Name, requeueInterval)
+	}
+	return status, err
+}
+
+// start validates the recovery and records the etcd snapshot restore that resets etcd. The restore is only set on the
+// cluster once it is recorded, so that it is set once.
+func (h *handler) start(recovery *rkev1.ClusterRecovery, status rkev1.ClusterRecoveryStatus) (rkev1.ClusterRecoveryStatus, error) {
+	cluster, err := h.provClusterCache.Get(recovery.Namespace, recovery.Spec.ClusterName)
+	if apierrors.IsNotFound(err) {
+		return failed(status, fmt.Errorf("cluster %s/%s not found", recovery.Namespace, recovery.Spec.ClusterName)), nil
+	} else if err != nil {
+		return status, err
+	}
+
+	if err := validateRecovery(recovery, cluster); err != nil {
+		return failed(status, err), nil
+	}
+	if err := h.validateSource(recovery); err != nil {
+		return failed(status, err), nil
+	}
+
+	recoveries, err := h.recoveryCache.List(recovery.Namespace, labels.Everything())
+	if err != nil {
+		return status, err
+	}
+	for _, other := range recoveries {
+		if other.Name != recovery.Name && other.Spec.ClusterName == recovery.Spec.ClusterName && other.Status.Phase != "" && inProgress(other) {
+			return failed(status, fmt.Errorf("cluster %s/%s is already being recovered by %s", recovery.Namespace, recovery.Spec.ClusterName, other.Name)), nil
+		}
+	}
+
+	logrus.Infof("[clusterrecovery] %s/%s: recovering cluster %s from %s", recovery.Namespace, recovery.Name, recovery.Spec.ClusterName, source(recovery))
+	now := metav1.Now()
+	status.Phase = rkev1.ClusterRecoveryPhaseResetting
+	status.Message = "resetting etcd on " + source(recovery)
+	status.ETCDSnapshotRestore = nextETCDSnapshotRestore(recovery, cluster)
+	status.StartTime = &now
+	return status, nil
+}
+
+// reset sets the etcd snapshot restore of the recovery on the cluster and follows its progress. The restore restarts
+// the whole cluster, which can only finish once every etcd machine rejoined, so unhealthy etcd machines are handled
+// from the moment the reset member is restarted.
+func (h *handler) reset(recovery *rkev1.ClusterRecovery, status rkev1.ClusterRecoveryStatus) (rkev1.ClusterRecoveryStatus, error) {
+	cluster, err := h.provClusterCache.Get(recovery.Namespace, recovery.Spec.ClusterName)
+	if apierrors.IsNotFound(err) {
+		return failed(status, fmt.Errorf("cluster %s/%s not found", recovery.Namespace, recovery.Spec.ClusterName)), nil
+	} else if err != nil {
+		return status, err
+	}
+	if cluster.Spec.RKEConfig == nil {
+		return failed(status, fmt.Errorf("cluster %s/%s is not provisioned by rancher", cluster.Namespace, cluster.Name)), nil
+	}
+
+	current := cluster.Spec.RKEConfig.ETCDSnapshotRestore
+	if !equality.Semantic.DeepEqual(current, status.ETCDSnapshotRestore) {
+		if current != nil && current.Generation >= status.ETCDSnapshotRestore.Generation {
+			return failed(status, errors.New("another etcd snapshot restore of the cluster superseded the recovery")), nil
+		}
+		cluster = cluster.DeepCopy()
+		cluster.Spec.RKEConfig.ETCDSnapshotRestore = status.ETCDSnapshotRestore.DeepCopy()
+		if _, err := h.provClusters.Update(cluster); err != nil {
+			return status, err
+		}
+		return status, nil
+	}
+
+	cp, err := h.controlPlaneCache.Get(cluster.Namespace, cluster.Name)
+	if apierrors.IsNotFound(err) {
+		status.Message = "waiting for the control plane of the cluster"
+		return status, nil
+	} else if err != nil {
+		return status, err
+	}
+	if !equality.Semantic.DeepEqual(cp.Status.ETCDSnapshotRestore, status.ETCDSnapshotRestore) {
+		status.Message = "waiting for the etcd reset to start"
+		return status, nil
+	}
+
+	phase := cp.Status.ETCDSnapshotRestorePhase
+	switch {
+	case phase == rkev1.ETCDSnapshotPhaseFailed:
+		return failed(status, errors.New("etcd reset failed")), nil
+	case phase == rkev1.ETCDSnapshotPhaseFinished:
+		if status.ResetTime == nil {
+			now := metav1.Now()
+			status.ResetTime = &now
+		}
+		status.Phase = rkev1.ClusterRecoveryPhaseRejoining
+		status.Message = "waiting for the etcd machines to rejoin"
+		return status, nil
+	case restarting(phase):
+		if status.ResetTime == nil {
+			now := metav1.Now()
+			status.ResetTime = &now
+		}
+		return h.handleUnhealthyMachines(recovery, cluster, status, fmt.Sprintf("etcd was reset, restarting the cluster (%s)", phase))
+	default:
+		status.Message = fmt.Sprintf("resetting etcd on %s (%s)", source(recovery), phase)
+		return status, nil
+	}
+}
+
+// rejoin waits for every etcd machine to rejoin the reset member.
+func (h *handler) rejoin(recovery *rkev1.ClusterRecovery, status rkev1.ClusterRecoveryStatus) (rkev1.ClusterRecoveryStatus, error) {
+	cluster, err := h.provClusterCache.Get(recovery.Namespace, recovery.Spec.ClusterName)
+	if apierrors.IsNotFound(err) {
+		return failed(status, fmt.Errorf("cluster %s/%s not found", recovery.Namespace, recovery.Spec.ClusterName)), nil
+	} else if err != nil {
+		return status, err
+	}
+
+	status, err = h.handleUnhealthyMachines(recovery, cluster, status, "waiting for the etcd machines to rejoin")
+	if err != nil || len(status.UnhealthyMachines) > 0 {
+		return status, err
+	}
+	status.Phase = rkev1.ClusterRecoveryPhaseVerifying
+	status.Message = "waiting for the cluster to be ready"
+	return status, nil
+}
+
+// verify waits for the control plane and the cluster to be ready.
+func (h *handler) verify(recovery *rkev1.ClusterRecovery, status rkev1.ClusterRecoveryStatus) (rkev1.ClusterRecoveryStatus, error) {
+	cluster, err := h.provClusterCache.Get(recovery.Namespace, recovery.Spec.ClusterName)
+	if apierrors.IsNotFound(err) {
+		return failed(status, fmt.Errorf("cluster %s/%s not found", recovery.Namespace, recovery.Spec.ClusterName)), nil
+	} else if err != nil {
+		return status, err
+	}
+
+	machines, err := h.etcdMachines(cluster)
+	if err != nil {
+		return status, err
+	}
+	if unhealthy := unhealthyMachines(machines); len(unhealthy) > 0 {
+		// An etcd machine became unhealthy again, it is handled like after the reset.
+		status.Phase = rkev1.ClusterRecoveryPhaseRejoining
+		status.Message = "waiting for the etcd machines to rejoin"
+		return status, nil
+	}
+
+	cp, err := h.controlPlaneCache.Get(cluster.Namespace, cluster.Name)
+	if err != nil {
+		return status, err
+	}
+	if !cp.Status.Ready {
+		status.Message = "waiting for the control plane to be ready"
+		return status, nil
+	}
+	if cluster.Status.ClusterName != "" {
+		mgmtCluster, err := h.mgmtClusterCache.Get(cluster.Status.ClusterName)
+		if err != nil {
+			return status, err
+		}
+		if !v3.ClusterConditionReady.IsTrue(mgmtCluster) {
+			status.Message = "waiting for the cluster to be ready"
+			return status, nil
+		}
+	}
+
+	logrus.Infof("[clusterrecovery] %s/%s: recovered cluster %s", recovery.Namespace, recovery.Name, recovery.Spec.ClusterName)
+	now := metav1.Now()
+	status.Phase = rkev1.ClusterRecoveryPhaseComplete
+	status.Message = "cluster recovered"
+	status.CompletionTime = &now
+	return status, nil
+}
+
+// handleUnhealthyMachines records the etcd machines that haven't rejoined etcd. Once the rejoin timeout elapsed, the
+// etcd machines of machine pools are deleted to be replaced if the recovery replaces unhealthy machines, and the other
+// machines are reported.
+func (h *handler) handleUnhealthyMachines(recovery *rkev1.ClusterRecovery, cluster *provv1.Cluster, status rkev1.ClusterRecoveryStatus, message string) (rkev1.ClusterRecoveryStatus, error) {
+	machines, err := h.etcdMachines(cluster)
+	if err != nil {
+		return status, err
+	}
+
+	unhealthy := unhealthyMachines(machines)
+	status.UnhealthyMachines = machineNames(unhealthy)
+	status.Message = message
+	if len(unhealthy) == 0 {
+		return status, nil
+	}
+
+	timeout := rejoinTimeout(recovery)
+	if status.ResetTime == nil || time.Since(status.ResetTime.Time) < timeout {
+		status.Message = fmt.Sprintf("%s, waiting for etcd machines %s to rejoin", message, strings.Join(status.UnhealthyMachines, ", "))
+		return status, nil
+	}
+
+	var remaining []string
+	for _, machine := range unhealthy {
+		if !recovery.Spec.ReplaceUnhealthyMachines || !replaceable(machine) {
+			remaining = append(remaining, machine.Name)
+			continue
+		}
+		if err := h.replace(recovery, machine); err != nil {
+			return status, err
+		}
+		if !contains(status.ReplacedMachines, machine.Name) {
+			status.ReplacedMachines = append(status.ReplacedMachines, machine.Name)
+		}
+	}
+
+	if len(remaining) > 0 {
+		status.Message = fmt.Sprintf("%s, etcd machines %s did not rejoin within %s and must be repaired or deleted", message, strings.Join(remaining, ", "), timeout)
+	} else {
+		status.Message = fmt.Sprintf("%s, replacing etcd machines %s", message, strings.Join(status.UnhealthyMachines, ", "))
+	}
+	return status, nil
+}
+
+// replace deletes the etcd machine so that its machine pool replaces it. As etcd can't safely remove the member of
+// the machine while the cluster is recovering, the removal is forced and the node isn't drained.
+func (h *handler) replace(recovery *rkev1.ClusterRecovery, machine *capi.Machine) error {
+	if machine.DeletionTimestamp != nil {
+		return nil
+	}
+	logrus.Infof("[clusterrecovery] %s/%s: deleting etcd machine %s that did not rejoin to be replaced", recovery.Namespace, recovery.Name, machine.Name)
+
+	if machine.Annotations[capi.ExcludeNodeDrainingAnnotation] != "true" {
+		machine = machine.DeepCopy()
+		if machine.Annotations == nil {
+			machine.Annotations = map[string]string{}
+		}
+		machine.Annotations[capi.ExcludeNodeDrainingAnnotation] = "true"
+		var err error
+		if machine, err = h.machines.Update(machine); err != nil {
+			return err
+		}
+	}
+
+	if ref := machine.Spec.Bootstrap.ConfigRef; ref != nil && strings.Contains(ref.APIVersion, "rke.cattle.io") {
+		rb, err := h.rkeBootstrapCache.Get(ref.Namespace, ref.Name)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		if err == nil && rb.Annotations[capr.ForceRemoveEtcdAnnotation] != "true" {
+			rb = rb.DeepCopy()
+			if rb.Annotations == nil {
+				rb.Annotations = map[string]string{}
+			}
+			rb.Annotations[capr.ForceRemoveEtcdAnnotation] = "true"
+			if _, err := h.rkeBootstrap.Update(rb); err != nil {
+				return err
+			}
+		}
+	}
+
+	err := h.machines.Delete(machine.Namespace, machine.Name, &metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// validateSource returns an error if the surviving member or the snapshot of the recovery isn't one of the cluster.
+func (h *handler) validateSource(recovery *rkev1.ClusterRecovery) error {
+	if recovery.Spec.MachineName != "" {
+		machine, err := h.machineCache.Get(recovery.Namespace, recovery.Spec.MachineName)
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("machine %s/%s not found", recovery.Namespace, recovery.Spec.MachineName)
+		} else if err != nil {
+			return err
+		}
+		return validateMachine(recovery, machine)
+	}
+
+	snapshot, err := h.snapshotCache.Get(recovery.Namespace, recovery.Spec.SnapshotName)
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("etcd snapshot %s/%s not found", recovery.Namespace, recovery.Spec.SnapshotName)
+	} else if err != nil {
+		return err
+	}
+	if snapshot.Spec.ClusterName != recovery.Spec.ClusterName {
+		return fmt.Errorf("etcd snapshot %s/%s is not a snapshot of cluster %s", snapshot.Namespace, snapshot.Name, recovery.Spec.ClusterName)
+	}
+	return nil
+}
+
+// etcdMachines returns the etcd machines of the cluster that aren't deleting.
+func (h *handler) etcdMachines(cluster *provv1.Cluster) ([]*capi.Machine, error) {
+	machines, err := h.machineCache.List(cluster.Namespace, labels.SelectorFromSet(labels.Set{
+		capi.ClusterLabelName: cluster.Name,
+		capr.EtcdRoleLabel:    "true",
+	}))
+	if err != nil {
+		return nil, err
+	}
+	var result []*capi.Machine
+	for _, machine := range machines {
+		if machine.DeletionTimestamp == nil {
+			result = append(result, machine)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// validateRecovery returns an error if the recovery doesn't set exactly one of a surviving member and a snapshot, or
+// the cluster isn't provisioned by rancher.
+func validateRecovery(recovery *rkev1.ClusterRecovery, cluster *provv1.Cluster) error {
+	if recovery.Spec.MachineName == "" && recovery.Spec.SnapshotName == "" {
+		return errors.New("either a machine to reset etcd on or a snapshot to restore must be set")
+	}
+	if recovery.Spec.MachineName != "" && recovery.Spec.SnapshotName != "" {
+		return errors.New("only one of a machine to reset etcd on and a snapshot to restore can be set")
+	}
+	if recovery.Spec.RejoinTimeout != nil && recovery.Spec.RejoinTimeout.Duration <= 0 {
+		return fmt.Errorf("rejoin timeout %s must be positive", recovery.Spec.RejoinTimeout.Duration)
+	}
+	if cluster.Spec.RKEConfig == nil {
+		return fmt.Errorf("cluster %s/%s is not provisioned by rancher", cluster.Namespace, cluster.Name)
+	}
+	return nil
+}
+
+// validateMachine returns an error if the machine isn't a healthy etcd machine of the cluster of the recovery, which
+// etcd can be reset on.
+func validateMachine(recovery *rkev1.ClusterRecovery, machine *capi.Machine) error {
+	if machine.Labels[capi.ClusterLabelName] != recovery.Spec.ClusterName {
+		return fmt.Errorf("machine %s/%s is not a machine of cluster %s", machine.Namespace, machine.Name, recovery.Spec.ClusterName)
+	}
+	if machine.Labels[capr.EtcdRoleLabel] != "true" {
+		return fmt.Errorf("machine %s/%s is not an etcd machine", machine.Namespace, machine.Name)
+	}
+	if machine.DeletionTimestamp != nil {
+		return fmt.Errorf("machine %s/%s is being deleted", machine.Namespace, machine.Name)
+	}
+	if machine.Labels[capr.MachineIDLabel] == "" || !capr.InfrastructureReady.IsTrue(machine) {
+		return fmt.Errorf("machine %s/%s is not ready", machine.Namespace, machine.Name)
+	}
+	return nil
+}
+
+// nextETCDSnapshotRestore returns the etcd snapshot restore of the cluster resetting etcd as set by the recovery. The
+// configuration of the cluster is kept.
+func nextETCDSnapshotRestore(recovery *rkev1.ClusterRecovery, cluster *provv1.Cluster) *rkev1.ETCDSnapshotRestore {
+	generation := 1
+	if cluster.Spec.RKEConfig != nil && cluster.Spec.RKEConfig.ETCDSnapshotRestore != nil {
+		generation = cluster.Spec.RKEConfig.ETCDSnapshotRestore.Generation + 1
+	}
+	return &rkev1.ETCDSnapshotRestore{
+		Name:             recovery.Spec.SnapshotName,
+		MachineName:      recovery.Spec.MachineName,
+		Generation:       generation,
+		RestoreRKEConfig: restoreRKEConfigNone,
+	}
+}
+
+// restarting returns true if etcd was reset and the restore is restarting the cluster.
+func restarting(phase rkev1.ETCDSnapshotPhase) bool {
+	switch phase {
+	case rkev1.ETCDSnapshotPhaseInitialRestartCluster, rkev1.ETCDSnapshotPhasePostRestoreNodeCleanup, rkev1.ETCDSnapshotPhaseRestartCluster:
+		return true
+	}
+	return false
+}
+
+// unhealthyMachines returns the machines whose node isn't healthy.
+func unhealthyMachines(machines []*capi.Machine) []*capi.Machine {
+	var result []*capi.Machine
+	for _, machine := range machines {
+		if machine.Status.NodeRef == nil || !conditions.IsTrue(machine, capi.MachineNodeHealthyCondition) {
+			result = append(result, machine)
+		}
+	}
+	return result
+}
+
+// replaceable returns true if the machine belongs to a machine pool, which replaces it when it is deleted.
+func replaceable(machine *capi.Machine) bool {
+	owner := metav1.GetControllerOf(machine)
+	return owner != nil && owner.Kind == "MachineSet"
+}
+
+func rejoinTimeout(recovery *rkev1.ClusterRecovery) time.Duration {
+	if recovery.Spec.RejoinTimeout != nil && recovery.Spec.RejoinTimeout.Duration > 0 {
+		return recovery.Spec.RejoinTimeout.Duration
+	}
+	return defaultRejoinTimeout
+}
+
+func inProgress(recovery *rkev1.ClusterRecovery) bool {
+	return recovery.Status.Phase != rkev1.ClusterRecoveryPhaseComplete && recovery.Status.Phase != rkev1.ClusterRecoveryPhaseFailed
+}
+
+func source(recovery *rkev1.ClusterRecovery) string {
+	if recovery.Spec.MachineName != "" {
+		return "machine " + recovery.Spec.MachineName
+	}
+	return "snapshot " + recovery.Spec.SnapshotName
+}
+
+func failed(status rkev1.ClusterRecoveryStatus, err error) rkev1.ClusterRecoveryStatus {
+	now := metav1.Now()
+	status.Phase = rkev1.ClusterRecoveryPhaseFailed
+	status.Message = err.Error()
+	status.CompletionTime = &now
+	return status
+}
+
+func machineNames(machines []*capi.Machine) []string {
+	var result []string
+	for _, machine := range machines {
+		result = append(result, machine.Name)
+	}
+	return result
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,192 @@
+package clusterrecovery
+
+import (
+	"testing"
+	"time"
+
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestValidateRecovery(t *testing.T) {
+	rkeCluster := &provv1.Cluster{Spec: provv1.ClusterSpec{RKEConfig: &provv1.RKEConfig{}}}
+
+	tests := []struct {
+		name     string
+		spec     rkev1.ClusterRecoverySpec
+		cluster  *provv1.Cluster
+		expected string
+	}{
+		{
+			name:    "machine",
+			spec:    rkev1.ClusterRecoverySpec{ClusterName: "c", MachineName: "m"},
+			cluster: rkeCluster,
+		},
+		{
+			name:    "snapshot",
+			spec:    rkev1.ClusterRecoverySpec{ClusterName: "c", SnapshotName: "s", RejoinTimeout: &metav1.Duration{Duration: time.Minute}},
+			cluster: rkeCluster,
+		},
+		{
+			name:     "no source",
+			spec:     rkev1.ClusterRecoverySpec{ClusterName: "c"},
+			cluster:  rkeCluster,
+			expected: "either a machine to reset etcd on or a snapshot to restore must be set",
+		},
+		{
+			name:     "both sources",
+			spec:     rkev1.ClusterRecoverySpec{ClusterName: "c", MachineName: "m", SnapshotName: "s"},
+			cluster:  rkeCluster,
+			expected: "only one of a machine to reset etcd on and a snapshot to restore can be set",
+		},
+		{
+			name:     "negative timeout",
+			spec:     rkev1.ClusterRecoverySpec{ClusterName: "c", MachineName: "m", RejoinTimeout: &metav1.Duration{Duration: -time.Minute}},
+			cluster:  rkeCluster,
+			expected: "rejoin timeout -1m0s must be positive",
+		},
+		{
+			name:     "imported cluster",
+			spec:     rkev1.ClusterRecoverySpec{ClusterName: "c", MachineName: "m"},
+			cluster:  &provv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "fleet-default", Name: "c"}},
+			expected: "cluster fleet-default/c is not provisioned by rancher",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRecovery(&rkev1.ClusterRecovery{Spec: tt.spec}, tt.cluster)
+			if tt.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateMachine(t *testing.T) {
+	recovery := &rkev1.ClusterRecovery{Spec: rkev1.ClusterRecoverySpec{ClusterName: "c", MachineName: "m"}}
+	now := metav1.Now()
+
+	tests := []struct {
+		name     string
+		modify   func(machine *capi.Machine)
+		expected string
+	}{
+		{
+			name:   "healthy etcd machine",
+			modify: func(machine *capi.Machine) {},
+		},
+		{
+			name: "other cluster",
+			modify: func(machine *capi.Machine) {
+				machine.Labels[capi.ClusterLabelName] = "other"
+			},
+			expected: "machine fleet-default/m is not a machine of cluster c",
+		},
+		{
+			name: "not etcd",
+			modify: func(machine *capi.Machine) {
+				delete(machine.Labels, capr.EtcdRoleLabel)
+			},
+			expected: "machine fleet-default/m is not an etcd machine",
+		},
+		{
+			name: "deleting",
+			modify: func(machine *capi.Machine) {
+				machine.DeletionTimestamp = &now
+			},
+			expected: "machine fleet-default/m is being deleted",
+		},
+		{
+			name: "infrastructure not ready",
+			modify: func(machine *capi.Machine) {
+				machine.Status.Conditions = nil
+			},
+			expected: "machine fleet-default/m is not ready",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			machine := &capi.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "fleet-default",
+					Name:      "m",
+					Labels: map[string]string{
+						capi.ClusterLabelName: "c",
+						capr.EtcdRoleLabel:    "true",
+						capr.MachineIDLabel:   "id",
+					},
+				},
+				Status: capi.MachineStatus{
+					Conditions: capi.Conditions{{Type: capi.InfrastructureReadyCondition, Status: corev1.ConditionTrue}},
+				},
+			}
+			tt.modify(machine)
+			err := validateMachine(recovery, machine)
+			if tt.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNextETCDSnapshotRestore(t *testing.T) {
+	recovery := &rkev1.ClusterRecovery{Spec: rkev1.ClusterRecoverySpec{ClusterName: "c", MachineName: "m"}}
+
+	cluster := &provv1.Cluster{Spec: provv1.ClusterSpec{RKEConfig: &provv1.RKEConfig{}}}
+	assert.Equal(t, &rkev1.ETCDSnapshotRestore{MachineName: "m", Generation: 1, RestoreRKEConfig: "none"}, nextETCDSnapshotRestore(recovery, cluster))
+
+	cluster.Spec.RKEConfig.ETCDSnapshotRestore = &rkev1.ETCDSnapshotRestore{Name: "s", Generation: 3, RestoreRKEConfig: "all"}
+	assert.Equal(t, &rkev1.ETCDSnapshotRestore{MachineName: "m", Generation: 4, RestoreRKEConfig: "none"}, nextETCDSnapshotRestore(recovery, cluster))
+
+	recovery.Spec = rkev1.ClusterRecoverySpec{ClusterName: "c", SnapshotName: "s"}
+	assert.Equal(t, &rkev1.ETCDSnapshotRestore{Name: "s", Generation: 4, RestoreRKEConfig: "none"}, nextETCDSnapshotRestore(recovery, cluster))
+}
+
+func TestUnhealthyMachines(t *testing.T) {
+	healthy := &capi.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "healthy"},
+		Status: capi.MachineStatus{
+			NodeRef:    &corev1.ObjectReference{Name: "node"},
+			Conditions: capi.Conditions{{Type: capi.MachineNodeHealthyCondition, Status: corev1.ConditionTrue}},
+		},
+	}
+	notReady := &capi.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "not-ready"},
+		Status: capi.MachineStatus{
+			NodeRef:    &corev1.ObjectReference{Name: "node"},
+			Conditions: capi.Conditions{{Type: capi.MachineNodeHealthyCondition, Status: corev1.ConditionFalse}},
+		},
+	}
+	noNode := &capi.Machine{ObjectMeta: metav1.ObjectMeta{Name: "no-node"}}
+
+	assert.Equal(t, []string{"not-ready", "no-node"}, machineNames(unhealthyMachines([]*capi.Machine{healthy, notReady, noNode})))
+	assert.Empty(t, unhealthyMachines([]*capi.Machine{healthy}))
+}
+
+func TestReplaceable(t *testing.T) {
+	controller := true
+	pool := &capi.Machine{ObjectMeta: metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{{Kind: "MachineSet", Name: "pool", Controller: &controller}}}}
+	custom := &capi.Machine{}
+
+	assert.True(t, replaceable(pool))
+	assert.False(t, replaceable(custom))
+}
+
+func TestRejoinTimeout(t *testing.T) {
+	recovery := &rkev1.ClusterRecovery{}
+	assert.Equal(t, defaultRejoinTimeout, rejoinTimeout(recovery))
+
+	recovery.Spec.RejoinTimeout = &metav1.Duration{Duration: time.Hour}
+	assert.Equal(t, time.Hour, rejoinTimeout(recovery))
+}
@@ -6,7 +6,10 @@
 	"github.com/rancher/rancher/pkg/capr"
 	"github.com/rancher/rancher/pkg/capr/planner"
 	"github.com/rancher/rancher/pkg/controllers/capr/bootstrap"
+	"github.com/rancher/rancher/pkg/controllers/capr/clusterrecovery"
 	"github.com/rancher/rancher/pkg/controllers/capr/dynamicschema"
+	"github.com/rancher/rancher/pkg/controllers/capr/harvestermigration"
+	"github.com/rancher/rancher/pkg/controllers/capr/machinedeletion"
 	"github.com/rancher/rancher/pkg/controllers/capr/machinedrain"
 	"github.com/rancher/rancher/pkg/controllers/capr/machinenodelookup"
 	"github.com/rancher/rancher/pkg/controllers/capr/machineprovision"
@@ -34,6 +37,7 @@ func Register(ctx context.Context, clients *wrangler.Context, kubeconfigManager
 	if features.MCM.Enabled() {
 		dynamicschema.Register(ctx, clients)
 		machineprovision.Register(ctx, clients, kubeconfigManager)
+		harvestermigration.Register(ctx, clients)
 	}
 	rkecluster.Register(ctx, clients)
 	bootstrap.Register(ctx, clients)
@@ -44,4 +48,6 @@ func Register(ctx context.Context, clients *wrangler.Context, kubeconfigManager
 	rkecontrolplane.Register(ctx, clients)
 	managesystemagent.Register(ctx, clients)
 	machinedrain.Register(ctx, clients)
+	machinedeletion.Register(ctx, clients)
+	clusterrecovery.Register(ctx, clients)
 }
@@ -0,0 +1,249 @@
+// Package harvestermigration tracks the live migration of the VMs of the machines of Harvester clusters, such as when
+// the Harvester hosts they run on enter maintenance. While a VM is being migrated its node may briefly be unreachable,
+// so its machine is excluded from the remediation of its machine health check and, if the
+// harvester-live-migration-cordon setting is enabled, its node is cordoned, rather than the machine being replaced.
+package harvestermigration
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rancher/lasso/pkg/dynamic"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/rancher/pkg/controllers/capr/machineprovision"
+	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/wrangler"
+	"github.com/rancher/wrangler/pkg/data"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/rancher/wrangler/pkg/name"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8sdynamic "k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	harvesterMachineKind = "HarvesterMachine"
+	// skipRemediationValue is the value of the skip remediation annotation set by this controller, so that the
+	// annotation is only removed once the migration completes if it was set for the migration.
+	skipRemediationValue = "live-migration"
+	// gracePeriod is how long after the migration of its VM completed a machine is still considered migrating, for its
+	// node to report ready again.
+	gracePeriod = 2 * time.Minute
+	// pollInterval is how often the VMs of the machines are checked for live migrations.
+	pollInterval = 30 * time.Second
+)
+
+var vmiGVR = schema.GroupVersionResource{
+	Group:    "kubevirt.io",
+	Version:  "v1",
+	Resource: "virtualmachineinstances",
+}
+
+type handler struct {
+	ctx         context.Context
+	machines    capicontrollers.MachineController
+	dynamic     *dynamic.Controller
+	secretCache corecontrollers.SecretCache
+}
+
+func Register(ctx context.Context, clients *wrangler.Context) {
+	h := &handler{
+		ctx:         ctx,
+		machines:    clients.CAPI.Machine(),
+		dynamic:     clients.Dynamic,
+		secretCache: clients.Core.Secret().Cache(),
+	}
+
+	clients.CAPI.Machine().OnChange(ctx, "harvester-live-migration", h.OnChange)
+}
+
+func (h *handler) OnChange(_ string, machine *capi.Machine) (*capi.Machine, error) {
+	if machine == nil || !machine.DeletionTimestamp.IsZero() || machine.Spec.InfrastructureRef.Kind != harvesterMachineKind {
+		return machine, nil
+	}
+
+	start, err := h.migrationStart(machine)
+	if err != nil {
+		logrus.Debugf("[harvestermigration] %s/%s: failed to check the live migration of the VM: %v", machine.Namespace, machine.Name, err)
+		h.machines.EnqueueAfter(machine.Namespace, machine.Name, pollInterval)
+		return machine, nil
+	}
+
+	if start != "" {
+		machine, err = h.onMigrating(machine, start)
+	} else if machine.Annotations[capr.LiveMigrationAnnotation] != "" {
+		machine, err = h.onMigrated(machine)
+	}
+	if err != nil {
+		return machine, err
+	}
+
+	h.machines.EnqueueAfter(machine.Namespace, machine.Name, pollInterval)
+	return machine, nil
+}
+
+// onMigrating excludes the machine from remediation and cordons its node if enabled, while its VM is being migrated.
+func (h *handler) onMigrating(machine *capi.Machine, start string) (*capi.Machine, error) {
+	if machine.Annotations[capr.LiveMigrationAnnotation] == start && (cordoned(machine) || !cordonEnabled(machine)) {
+		return machine, nil
+	}
+
+	machine = machine.DeepCopy()
+	if machine.Annotations == nil {
+		machine.Annotations = map[string]string{}
+	}
+	if machine.Annotations[capr.LiveMigrationAnnotation] == "" {
+		logrus.Infof("[harvestermigration] %s/%s: VM is being live migrated, the machine is excluded from remediation", machine.Namespace, machine.Name)
+	}
+	machine.Annotations[capr.LiveMigrationAnnotation] = start
+	if _, ok := machine.Annotations[capi.MachineSkipRemediationAnnotation]; !ok {
+		machine.Annotations[capi.MachineSkipRemediationAnnotation] = skipRemediationValue
+	}
+	if cordonEnabled(machine) && !cordoned(machine) {
+		if err := h.cordon(machine, true); err != nil {
+			return machine, err
+		}
+		machine.Annotations[capr.LiveMigrationCordonedAnnotation] = "true"
+	}
+	return h.machines.Update(machine)
+}
+
+// onMigrated uncordons the node of the machine if it was cordoned for the migration of its VM, and includes the
+// machine in remediation again.
+func (h *handler) onMigrated(machine *capi.Machine) (*capi.Machine, error) {
+	machine = machine.DeepCopy()
+	if cordoned(machine) {
+		if err := h.cordon(machine, false); err != nil {
+			return machine, err
+		}
+		delete(machine.Annotations, capr.LiveMigrationCordonedAnnotation)
+	}
+	if machine.Annotations[capi.MachineSkipRemediationAnnotation] == skipRemediationValue {
+		delete(machine.Annotations, capi.MachineSkipRemediationAnnotation)
+	}
+	delete(machine.Annotations, capr.LiveMigrationAnnotation)
+	logrus.Infof("[harvestermigration] %s/%s: live migration of the VM completed, the machine is included in remediation", machine.Namespace, machine.Name)
+	return h.machines.Update(machine)
+}
+
+// migrationStart returns when the live migration of the VM of the machine started, if it's being migrated.
+func (h *handler) migrationStart(machine *capi.Machine) (string, error) {
+	ref := machine.Spec.InfrastructureRef
+	infra, err := h.dynamic.Get(schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind), machine.Namespace, ref.Name)
+	if apierrors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	infraData, err := data.Convert(infra)
+	if err != nil {
+		return "", err
+	}
+
+	vmNamespace := infraData.String("spec", "vmNamespace")
+	credential := infraData.String("spec", "common", "cloudCredentialSecretName")
+	if vmNamespace == "" || credential == "" {
+		return "", nil
+	}
+
+	client, err := h.harvesterClient(machine.Namespace, credential)
+	if err != nil {
+		return "", err
+	}
+	vmi, err := client.Resource(vmiGVR).Namespace(vmNamespace).Get(h.ctx, ref.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return migrationWindow(vmi.Object, time.Now()), nil
+}
+
+// harvesterClient returns a client of the Harvester cluster of a Harvester cloud credential.
+func (h *handler) harvesterClient(namespace, credential string) (k8sdynamic.Interface, error) {
+	secret, err := machineprovision.GetCloudCredentialSecret(h.secretCache, namespace, credential)
+	if err != nil {
+		return nil, err
+	}
+	kubeconfig := secret.Data["harvestercredentialConfig-kubeconfigContent"]
+	if len(kubeconfig) == 0 {
+		return nil, fmt.Errorf("cloud credential %s has no kubeconfig", credential)
+	}
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	return k8sdynamic.NewForConfig(restConfig)
+}
+
+// cordon cordons or uncordons the node of the machine in its cluster.
+func (h *handler) cordon(machine *capi.Machine, unschedulable bool) error {
+	if machine.Status.NodeRef == nil || machine.Status.NodeRef.Name == "" {
+		return nil
+	}
+
+	secret, err := h.secretCache.Get(machine.Namespace, name.SafeConcatName(machine.Spec.ClusterName, "kubeconfig"))
+	if err != nil {
+		return err
+	}
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(secret.Data["value"])
+	if err != nil {
+		return err
+	}
+	k8s, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	node, err := k8s.CoreV1().Nodes().Get(h.ctx, machine.Status.NodeRef.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if node.Spec.Unschedulable == unschedulable {
+		return nil
+	}
+	node = node.DeepCopy()
+	node.Spec.Unschedulable = unschedulable
+	_, err = k8s.CoreV1().Nodes().Update(h.ctx, node, metav1.UpdateOptions{})
+	return err
+}
+
+// cordonEnabled returns true if the node of the machine is to be cordoned while its VM is migrated.
+func cordonEnabled(machine *capi.Machine) bool {
+	return settings.HarvesterLiveMigrationCordon.Get() == "true" && machine.Status.NodeRef != nil
+}
+
+func cordoned(machine *capi.Machine) bool {
+	return machine.Annotations[capr.LiveMigrationCordonedAnnotation] == "true"
+}
+
+// migrationWindow returns when the live migration of a VM instance started if it's in progress, or completed less than
+// the grace period ago, and an empty string otherwise.
+func migrationWindow(vmi data.Object, now time.Time) string {
+	state := vmi.Map("status", "migrationState")
+	start := state.String("startTimestamp")
+	if start == "" {
+		return ""
+	}
+	end := state.String("endTimestamp")
+	if end == "" {
+		if state.Bool("completed") || state.Bool("failed") {
+			return ""
+		}
+		return start
+	}
+	endTime, err := time.Parse(time.RFC3339, end)
+	if err != nil || now.Sub(endTime) >= gracePeriod {
+		return ""
+	}
+	return start
+}
@@ -0,0 +1,71 @@
+package harvestermigration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rancher/wrangler/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrationWindow(t *testing.T) {
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	start := "2023-05-01T11:55:00Z"
+	tests := []struct {
+		name  string
+		state map[string]interface{}
+		want  string
+	}{
+		{
+			name: "not migrated",
+		},
+		{
+			name: "in progress",
+			state: map[string]interface{}{
+				"startTimestamp": start,
+				"targetNode":     "harvester-2",
+			},
+			want: start,
+		},
+		{
+			name: "completed within the grace period",
+			state: map[string]interface{}{
+				"startTimestamp": start,
+				"endTimestamp":   "2023-05-01T11:59:00Z",
+				"completed":      true,
+			},
+			want: start,
+		},
+		{
+			name: "completed before the grace period",
+			state: map[string]interface{}{
+				"startTimestamp": start,
+				"endTimestamp":   "2023-05-01T11:57:00Z",
+				"completed":      true,
+			},
+		},
+		{
+			name: "failed without an end",
+			state: map[string]interface{}{
+				"startTimestamp": start,
+				"failed":         true,
+			},
+		},
+		{
+			name: "invalid end",
+			state: map[string]interface{}{
+				"startTimestamp": start,
+				"endTimestamp":   "soon",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vmi := data.Object{"status": map[string]interface{}{}}
+			if tt.state != nil {
+				vmi = data.Object{"status": map[string]interface{}{"migrationState": tt.state}}
+			}
+			assert.Equal(t, tt.want, migrationWindow(vmi, now))
+		})
+	}
+}
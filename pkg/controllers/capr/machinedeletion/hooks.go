@@ -0,0 +1,205 @@
+package machinedeletion
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	drainHook             = "drain"
+	etcdMemberRemovalHook = "etcd-member-removal"
+	volumeDetachHook      = "volume-detach"
+
+	defaultVolumeDetachTimeout = 10 * time.Minute
+	defaultWebhookTimeout      = 5 * time.Minute
+)
+
+// hookContext is what the hooks of a deleting machine are run with.
+type hookContext struct {
+	machine   *capi.Machine
+	bootstrap *rkev1.RKEBootstrap
+	// controlPlane is nil if the cluster of the machine was not found or is being deleted.
+	controlPlane *rkev1.RKEControlPlane
+	// restConfig is the config of the downstream cluster, nil if its kubeconfig was not found.
+	restConfig *rest.Config
+}
+
+// hook is run when a machine is deleted, before its infrastructure is terminated. run returns the state of the hook,
+// which is Running until the hook is done, and a message describing it.
+type hook struct {
+	name          string
+	timeout       time.Duration
+	failurePolicy rkev1.MachineDeletionHookFailurePolicy
+	run           func(ctx *hookContext) (rkev1.MachineDeletionHookState, string, error)
+}
+
+// deletionHooks returns the hooks of the machines of the cluster in the order they are run: the built-in hooks with the
+// policies of the config applied, followed by the webhooks of the config.
+func deletionHooks(config *rkev1.MachineDeletionHooks, builtin []hook) ([]hook, error) {
+	hooks := make([]hook, len(builtin))
+	copy(hooks, builtin)
+	if config == nil {
+		return hooks, nil
+	}
+
+	names := map[string]bool{}
+	for _, h := range hooks {
+		names[h.name] = true
+	}
+
+	for _, policy := range config.Policies {
+		found := false
+		for i := range hooks {
+			if hooks[i].name != policy.Name {
+				continue
+			}
+			found = true
+			if policy.Timeout != nil {
+				hooks[i].timeout = policy.Timeout.Duration
+			}
+			if policy.FailurePolicy != "" {
+				hooks[i].failurePolicy = policy.FailurePolicy
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("policy for unknown machine deletion hook %s", policy.Name)
+		}
+	}
+
+	for _, webhook := range config.Webhooks {
+		if webhook.Name == "" || webhook.URL == "" {
+			return nil, fmt.Errorf("machine deletion webhooks must have a name and a url")
+		}
+		if names[webhook.Name] {
+			return nil, fmt.Errorf("machine deletion webhook %s conflicts with another hook of the same name", webhook.Name)
+		}
+		names[webhook.Name] = true
+		hooks = append(hooks, newWebhookHook(webhook))
+	}
+
+	for _, h := range hooks {
+		switch h.failurePolicy {
+		case rkev1.MachineDeletionHookFailurePolicyFail, rkev1.MachineDeletionHookFailurePolicyIgnore:
+		default:
+			return nil, fmt.Errorf("unknown failure policy %s of machine deletion hook %s, must be %s or %s", h.failurePolicy, h.name,
+				rkev1.MachineDeletionHookFailurePolicyFail, rkev1.MachineDeletionHookFailurePolicyIgnore)
+		}
+		if h.timeout < 0 {
+			return nil, fmt.Errorf("timeout %s of machine deletion hook %s must not be negative", h.timeout, h.name)
+		}
+	}
+	return hooks, nil
+}
+
+// runHooks runs the hooks in order, starting from the first one that is not finished according to the statuses. It
+// returns the updated statuses and whether all hooks are finished, in which case the infrastructure of the machine can
+// be terminated. A hook is finished once it succeeded, was skipped, or failed or timed out with the Ignore failure
+// policy. Hooks that failed or timed out with the Fail failure policy are run again until they succeed.
+func runHooks(ctx *hookContext, hooks []hook, statuses []rkev1.MachineDeletionHookStatus, now time.Time) ([]rkev1.MachineDeletionHookStatus, bool) {
+	skip := skippedHooks(ctx.machine)
+	var result []rkev1.MachineDeletionHookStatus
+
+	for _, h := range hooks {
+		status := hookStatus(statuses, h.name)
+		if finished(h, status) {
+			result = append(result, status)
+			continue
+		}
+
+		nowTime := metav1.NewTime(now)
+		if status.StartTime == nil {
+			status.StartTime = &nowTime
+		}
+
+		if skip["*"] || skip[h.name] {
+			status.State = rkev1.MachineDeletionHookStateSkipped
+			status.Message = fmt.Sprintf("skipped per annotation %s", capr.SkipDeletionHooksAnnotation)
+			status.CompletionTime = &nowTime
+			result = append(result, status)
+			continue
+		}
+
+		state, message, err := h.run(ctx)
+		if err != nil {
+			state, message = rkev1.MachineDeletionHookStateRunning, err.Error()
+		}
+		status.State, status.Message = state, message
+
+		if state == rkev1.MachineDeletionHookStateRunning && h.timeout > 0 && now.Sub(status.StartTime.Time) >= h.timeout {
+			status.State = rkev1.MachineDeletionHookStateTimedOut
+			status.Message = fmt.Sprintf("timed out after %s: %s", h.timeout, message)
+		}
+
+		if status.State == rkev1.MachineDeletionHookStateRunning {
+			status.CompletionTime = nil
+		} else {
+			status.CompletionTime = &nowTime
+		}
+
+		result = append(result, status)
+		if !finished(h, status) {
+			return result, false
+		}
+	}
+
+	return result, true
+}
+
+// finished returns true if the hook doesn't need to be run anymore.
+func finished(h hook, status rkev1.MachineDeletionHookStatus) bool {
+	switch status.State {
+	case rkev1.MachineDeletionHookStateSucceeded, rkev1.MachineDeletionHookStateSkipped:
+		return true
+	case rkev1.MachineDeletionHookStateFailed, rkev1.MachineDeletionHookStateTimedOut:
+		return h.failurePolicy == rkev1.MachineDeletionHookFailurePolicyIgnore
+	}
+	return false
+}
+
+// hookStatus returns the status of the hook with the given name, or an empty status if it was not run yet.
+func hookStatus(statuses []rkev1.MachineDeletionHookStatus, name string) rkev1.MachineDeletionHookStatus {
+	for _, status := range statuses {
+		if status.Name == name {
+			return status
+		}
+	}
+	return rkev1.MachineDeletionHookStatus{Name: name}
+}
+
+// skippedHooks returns the names of the hooks the machine is annotated to skip.
+func skippedHooks(machine *capi.Machine) map[string]bool {
+	skip := map[string]bool{}
+	for _, name := range strings.Split(machine.Annotations[capr.SkipDeletionHooksAnnotation], ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			skip[name] = true
+		}
+	}
+	return skip
+}
+
+// runDrain waits for CAPI to drain the node of the machine. CAPI only sets the pre-terminate condition once the node is
+// drained, or it gave up draining it because the drain timeout of the machine elapsed.
+func runDrain(ctx *hookContext) (rkev1.MachineDeletionHookState, string, error) {
+	machine := ctx.machine
+	if machine.Status.NodeRef == nil {
+		return rkev1.MachineDeletionHookStateSkipped, "machine has no node", nil
+	}
+	if _, ok := machine.Annotations[capi.ExcludeNodeDrainingAnnotation]; ok {
+		return rkev1.MachineDeletionHookStateSkipped, "machine is excluded from draining", nil
+	}
+	if !conditions.Has(machine, capi.PreTerminateDeleteHookSucceededCondition) {
+		return rkev1.MachineDeletionHookStateRunning, fmt.Sprintf("waiting for node %s to be drained", machine.Status.NodeRef.Name), nil
+	}
+	if conditions.IsFalse(machine, capi.DrainingSucceededCondition) {
+		return rkev1.MachineDeletionHookStateFailed, conditions.GetMessage(machine, capi.DrainingSucceededCondition), nil
+	}
+	return rkev1.MachineDeletionHookStateSucceeded, fmt.Sprintf("node %s was drained", machine.Status.NodeRef.Name), nil
+}
@@ -0,0 +1,245 @@
+package machinedeletion
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func testHook(name string, timeout time.Duration, policy rkev1.MachineDeletionHookFailurePolicy, state rkev1.MachineDeletionHookState, err error, calls *[]string) hook {
+	return hook{
+		name:          name,
+		timeout:       timeout,
+		failurePolicy: policy,
+		run: func(ctx *hookContext) (rkev1.MachineDeletionHookState, string, error) {
+			*calls = append(*calls, name)
+			return state, name + " " + string(state), err
+		},
+	}
+}
+
+func TestDeletionHooks(t *testing.T) {
+	builtin := []hook{
+		{name: "first", failurePolicy: rkev1.MachineDeletionHookFailurePolicyFail},
+		{name: "second", timeout: time.Minute, failurePolicy: rkev1.MachineDeletionHookFailurePolicyIgnore},
+	}
+
+	hooks, err := deletionHooks(nil, builtin)
+	assert.NoError(t, err)
+	assert.Len(t, hooks, 2)
+
+	hooks, err = deletionHooks(&rkev1.MachineDeletionHooks{
+		Policies: []rkev1.MachineDeletionHookPolicy{
+			{Name: "first", Timeout: &metav1.Duration{Duration: time.Hour}, FailurePolicy: rkev1.MachineDeletionHookFailurePolicyIgnore},
+		},
+		Webhooks: []rkev1.MachineDeletionWebhook{
+			{Name: "cmdb", URL: "https://cmdb.example.com"},
+			{Name: "ipam", URL: "https://ipam.example.com", Timeout: &metav1.Duration{Duration: time.Second}, FailurePolicy: rkev1.MachineDeletionHookFailurePolicyFail},
+		},
+	}, builtin)
+	assert.NoError(t, err)
+	var names []string
+	for _, h := range hooks {
+		names = append(names, h.name)
+	}
+	assert.Equal(t, []string{"first", "second", "cmdb", "ipam"}, names)
+	assert.Equal(t, time.Hour, hooks[0].timeout)
+	assert.Equal(t, rkev1.MachineDeletionHookFailurePolicyIgnore, hooks[0].failurePolicy)
+	assert.Equal(t, defaultWebhookTimeout, hooks[2].timeout)
+	assert.Equal(t, rkev1.MachineDeletionHookFailurePolicyIgnore, hooks[2].failurePolicy)
+	assert.Equal(t, time.Second, hooks[3].timeout)
+	assert.Equal(t, rkev1.MachineDeletionHookFailurePolicyFail, hooks[3].failurePolicy)
+	// the built-in hooks are not modified
+	assert.Equal(t, rkev1.MachineDeletionHookFailurePolicyFail, builtin[0].failurePolicy)
+
+	tests := []struct {
+		name     string
+		config   *rkev1.MachineDeletionHooks
+		expected string
+	}{
+		{
+			name:     "unknown policy",
+			config:   &rkev1.MachineDeletionHooks{Policies: []rkev1.MachineDeletionHookPolicy{{Name: "third"}}},
+			expected: "policy for unknown machine deletion hook third",
+		},
+		{
+			name:     "invalid failure policy",
+			config:   &rkev1.MachineDeletionHooks{Policies: []rkev1.MachineDeletionHookPolicy{{Name: "first", FailurePolicy: "Retry"}}},
+			expected: "unknown failure policy Retry of machine deletion hook first, must be Fail or Ignore",
+		},
+		{
+			name:     "negative timeout",
+			config:   &rkev1.MachineDeletionHooks{Policies: []rkev1.MachineDeletionHookPolicy{{Name: "second", Timeout: &metav1.Duration{Duration: -time.Second}}}},
+			expected: "timeout -1s of machine deletion hook second must not be negative",
+		},
+		{
+			name:     "webhook without url",
+			config:   &rkev1.MachineDeletionHooks{Webhooks: []rkev1.MachineDeletionWebhook{{Name: "cmdb"}}},
+			expected: "machine deletion webhooks must have a name and a url",
+		},
+		{
+			name:     "webhook named like a hook",
+			config:   &rkev1.MachineDeletionHooks{Webhooks: []rkev1.MachineDeletionWebhook{{Name: "first", URL: "https://cmdb.example.com"}}},
+			expected: "machine deletion webhook first conflicts with another hook of the same name",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := deletionHooks(tt.config, builtin)
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+func TestRunHooks(t *testing.T) {
+	now := time.Now()
+	ctx := &hookContext{machine: &capi.Machine{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}}}}
+	fail := rkev1.MachineDeletionHookFailurePolicyFail
+	ignore := rkev1.MachineDeletionHookFailurePolicyIgnore
+
+	// hooks are run in order until one isn't done
+	var calls []string
+	hooks := []hook{
+		testHook("first", 0, fail, rkev1.MachineDeletionHookStateSucceeded, nil, &calls),
+		testHook("second", time.Minute, fail, rkev1.MachineDeletionHookStateRunning, nil, &calls),
+		testHook("third", 0, fail, rkev1.MachineDeletionHookStateSucceeded, nil, &calls),
+	}
+	statuses, done := runHooks(ctx, hooks, nil, now)
+	assert.False(t, done)
+	assert.Equal(t, []string{"first", "second"}, calls)
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, rkev1.MachineDeletionHookStateSucceeded, statuses[0].State)
+	assert.Equal(t, now.Unix(), statuses[0].CompletionTime.Unix())
+	assert.Equal(t, rkev1.MachineDeletionHookStateRunning, statuses[1].State)
+	assert.Equal(t, now.Unix(), statuses[1].StartTime.Unix())
+	assert.Nil(t, statuses[1].CompletionTime)
+
+	// finished hooks are not run again, and hooks that fail with the Fail policy block the others once timed out
+	calls = nil
+	statuses, done = runHooks(ctx, hooks, statuses, now.Add(2*time.Minute))
+	assert.False(t, done)
+	assert.Equal(t, []string{"second"}, calls)
+	assert.Equal(t, rkev1.MachineDeletionHookStateTimedOut, statuses[1].State)
+	assert.Equal(t, "timed out after 1m0s: second Running", statuses[1].Message)
+	assert.Equal(t, now.Unix(), statuses[1].StartTime.Unix())
+
+	// until they succeed
+	calls = nil
+	hooks[1] = testHook("second", time.Minute, fail, rkev1.MachineDeletionHookStateSucceeded, nil, &calls)
+	statuses, done = runHooks(ctx, hooks, statuses, now.Add(3*time.Minute))
+	assert.True(t, done)
+	assert.Equal(t, []string{"second", "third"}, calls)
+	assert.Len(t, statuses, 3)
+
+	// hooks that fail or time out with the Ignore policy don't block the others
+	calls = nil
+	hooks = []hook{
+		testHook("first", time.Minute, ignore, rkev1.MachineDeletionHookStateRunning, fmt.Errorf("unreachable"), &calls),
+		testHook("second", 0, ignore, rkev1.MachineDeletionHookStateFailed, nil, &calls),
+		testHook("third", 0, fail, rkev1.MachineDeletionHookStateSkipped, nil, &calls),
+	}
+	statuses, done = runHooks(ctx, hooks, nil, now)
+	assert.False(t, done)
+	assert.Equal(t, "unreachable", statuses[0].Message)
+	statuses, done = runHooks(ctx, hooks, statuses, now.Add(time.Minute))
+	assert.True(t, done)
+	assert.Equal(t, []string{"first", "first", "second", "third"}, calls)
+	assert.Equal(t, rkev1.MachineDeletionHookStateTimedOut, statuses[0].State)
+	assert.Equal(t, rkev1.MachineDeletionHookStateFailed, statuses[1].State)
+	assert.Equal(t, rkev1.MachineDeletionHookStateSkipped, statuses[2].State)
+
+	// hooks can be skipped per annotation
+	calls = nil
+	ctx.machine.Annotations[capr.SkipDeletionHooksAnnotation] = "first, second"
+	statuses, done = runHooks(ctx, hooks, nil, now)
+	assert.True(t, done)
+	assert.Equal(t, []string{"third"}, calls)
+	assert.Equal(t, rkev1.MachineDeletionHookStateSkipped, statuses[0].State)
+	assert.Equal(t, "skipped per annotation rke.cattle.io/skip-deletion-hooks", statuses[0].Message)
+
+	calls = nil
+	ctx.machine.Annotations[capr.SkipDeletionHooksAnnotation] = "*"
+	_, done = runHooks(ctx, hooks, nil, now)
+	assert.True(t, done)
+	assert.Empty(t, calls)
+}
+
+func TestRunDrain(t *testing.T) {
+	tests := []struct {
+		name     string
+		machine  *capi.Machine
+		expected rkev1.MachineDeletionHookState
+		message  string
+	}{
+		{
+			name:     "no node",
+			machine:  &capi.Machine{},
+			expected: rkev1.MachineDeletionHookStateSkipped,
+			message:  "machine has no node",
+		},
+		{
+			name: "excluded from draining",
+			machine: &capi.Machine{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{capi.ExcludeNodeDrainingAnnotation: "true"}},
+				Status:     capi.MachineStatus{NodeRef: &corev1.ObjectReference{Name: "node"}},
+			},
+			expected: rkev1.MachineDeletionHookStateSkipped,
+			message:  "machine is excluded from draining",
+		},
+		{
+			name: "draining",
+			machine: &capi.Machine{
+				Status: capi.MachineStatus{
+					NodeRef:    &corev1.ObjectReference{Name: "node"},
+					Conditions: capi.Conditions{{Type: capi.DrainingSucceededCondition, Status: corev1.ConditionFalse, Message: "evicting pods"}},
+				},
+			},
+			expected: rkev1.MachineDeletionHookStateRunning,
+			message:  "waiting for node node to be drained",
+		},
+		{
+			name: "drain timed out",
+			machine: &capi.Machine{
+				Status: capi.MachineStatus{
+					NodeRef: &corev1.ObjectReference{Name: "node"},
+					Conditions: capi.Conditions{
+						{Type: capi.DrainingSucceededCondition, Status: corev1.ConditionFalse, Message: "evicting pods"},
+						{Type: capi.PreTerminateDeleteHookSucceededCondition, Status: corev1.ConditionFalse},
+					},
+				},
+			},
+			expected: rkev1.MachineDeletionHookStateFailed,
+			message:  "evicting pods",
+		},
+		{
+			name: "drained",
+			machine: &capi.Machine{
+				Status: capi.MachineStatus{
+					NodeRef: &corev1.ObjectReference{Name: "node"},
+					Conditions: capi.Conditions{
+						{Type: capi.DrainingSucceededCondition, Status: corev1.ConditionTrue},
+						{Type: capi.PreTerminateDeleteHookSucceededCondition, Status: corev1.ConditionFalse},
+					},
+				},
+			},
+			expected: rkev1.MachineDeletionHookStateSucceeded,
+			message:  "node node was drained",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, message, err := runDrain(&hookContext{machine: tt.machine})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, state)
+			assert.Equal(t, tt.message, message)
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package machinedeletion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/rancher/pkg/controllers/capr/etcdmgmt"
+	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
+	rkecontroller "github.com/rancher/rancher/pkg/generated/controllers/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/wrangler"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+const (
+	capiMachinePreTerminateAnnotation      = "pre-terminate.delete.hook.machine.cluster.x-k8s.io/rke-machine-deletion"
+	capiMachinePreTerminateAnnotationOwner = "rke-machine-deletion-controller"
+)
+
+type handler struct {
+	machines          capicontrollers.MachineController
+	capiClusterCache  capicontrollers.ClusterCache
+	rkeControlPlanes  rkecontroller.RKEControlPlaneCache
+	rkeBootstrapCache rkecontroller.RKEBootstrapCache
+	secretCache       corecontrollers.SecretCache
+}
+
+// Register registers the controller running the deletion hooks of the machines of the clusters provisioned by rancher.
+// The controller holds a single pre-terminate hook on the machines, which keeps CAPI from terminating the
+// infrastructure of a deleting machine until all deletion hooks are finished.
+func Register(ctx context.Context, clients *wrangler.Context) {
+	h := &handler{
+		machines:          clients.CAPI.Machine(),
+		capiClusterCache:  clients.CAPI.Cluster().Cache(),
+		rkeControlPlanes:  clients.RKE.RKEControlPlane().Cache(),
+		rkeBootstrapCache: clients.RKE.RKEBootstrap().Cache(),
+		secretCache:       clients.Core.Secret().Cache(),
+	}
+
+	clients.CAPI.Machine().OnChange(ctx, "machine-deletion-hooks", h.OnChange)
+}
+
+// builtinHooks returns the built-in hooks, in the order they are run: the node of the machine is drained, then its etcd
+// member is removed, then its volumes are detached.
+func builtinHooks() []hook {
+	return []hook{
+		{
+			name:          drainHook,
+			failurePolicy: rkev1.MachineDeletionHookFailurePolicyIgnore,
+			run:           runDrain,
+		},
+		{
+			name:          etcdMemberRemovalHook,
+			failurePolicy: rkev1.MachineDeletionHookFailurePolicyFail,
+			run:           runEtcdMemberRemoval,
+		},
+		{
+			name:          volumeDetachHook,
+			timeout:       defaultVolumeDetachTimeout,
+			failurePolicy: rkev1.MachineDeletionHookFailurePolicyIgnore,
+			run:           runVolumeDetach,
+		},
+	}
+}
+
+func (h *handler) OnChange(_ string, machine *capi.Machine) (*capi.Machine, error) {
+	if machine == nil || machine.Spec.Bootstrap.ConfigRef == nil || machine.Spec.Bootstrap.ConfigRef.Kind != "RKEBootstrap" {
+		return machine, nil
+	}
+
+	if machine.DeletionTimestamp.IsZero() {
+		if machine.Annotations[capiMachinePreTerminateAnnotation] == capiMachinePreTerminateAnnotationOwner {
+			return machine, nil
+		}
+		machine = machine.DeepCopy()
+		if machine.Annotations == nil {
+			machine.Annotations = map[string]string{}
+		}
+		machine.Annotations[capiMachinePreTerminateAnnotation] = capiMachinePreTerminateAnnotationOwner
+		return h.machines.Update(machine)
+	}
+
+	if _, ok := machine.Annotations[capiMachinePreTerminateAnnotation]; !ok {
+		return machine, nil
+	}
+
+	ctx, err := h.hookContext(machine)
+	if err != nil {
+		return machine, err
+	}
+
+	var config *rkev1.MachineDeletionHooks
+	if ctx.controlPlane != nil {
+		config = ctx.controlPlane.Spec.MachineDeletionHooks
+	}
+	hooks, err := deletionHooks(config, builtinHooks())
+	if err != nil {
+		return machine, err
+	}
+
+	var statuses []rkev1.MachineDeletionHookStatus
+	if data := machine.Annotations[capr.DeletionHookStatusAnnotation]; data != "" {
+		if err := json.Unmarshal([]byte(data), &statuses); err != nil {
+			logrus.Errorf("[machinedeletion] %s/%s: discarding invalid deletion hook status: %v", machine.Namespace, machine.Name, err)
+			statuses = nil
+		}
+	}
+
+	statuses, done := runHooks(ctx, hooks, statuses, time.Now())
+	data, err := json.Marshal(statuses)
+	if err != nil {
+		return machine, err
+	}
+
+	if machine.Annotations[capr.DeletionHookStatusAnnotation] != string(data) || done {
+		machine = machine.DeepCopy()
+		machine.Annotations[capr.DeletionHookStatusAnnotation] = string(data)
+		if done {
+			logrus.Infof("[machinedeletion] %s/%s: deletion hooks finished, allowing infrastructure to be terminated", machine.Namespace, machine.Name)
+			delete(machine.Annotations, capiMachinePreTerminateAnnotation)
+		}
+		if machine, err = h.machines.Update(machine); err != nil {
+			return machine, err
+		}
+	}
+
+	if !done {
+		h.machines.EnqueueAfter(machine.Namespace, machine.Name, 5*time.Second)
+	}
+	return machine, nil
+}
+
+// hookContext retrieves what the hooks of the machine are run with. The control plane and config of the downstream
+// cluster are left unset if the cluster is being deleted or any of them is not found.
+func (h *handler) hookContext(machine *capi.Machine) (*hookContext, error) {
+	ctx := &hookContext{
+		machine: machine,
+	}
+
+	bootstrap, err := h.rkeBootstrapCache.Get(machine.Namespace, machine.Spec.Bootstrap.ConfigRef.Name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	} else if err == nil {
+		ctx.bootstrap = bootstrap
+	}
+
+	capiCluster, err := h.capiClusterCache.Get(machine.Namespace, machine.Spec.ClusterName)
+	if apierrors.IsNotFound(err) {
+		return ctx, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if !capiCluster.DeletionTimestamp.IsZero() || capiCluster.Spec.ControlPlaneRef == nil {
+		return ctx, nil
+	}
+
+	cp, err := h.rkeControlPlanes.Get(capiCluster.Spec.ControlPlaneRef.Namespace, capiCluster.Spec.ControlPlaneRef.Name)
+	if apierrors.IsNotFound(err) {
+		return ctx, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if !cp.DeletionTimestamp.IsZero() {
+		return ctx, nil
+	}
+	ctx.controlPlane = cp
+
+	kcSecret, err := h.secretCache.Get(machine.Namespace, secret.Name(machine.Spec.ClusterName, secret.Kubeconfig))
+	if apierrors.IsNotFound(err) {
+		return ctx, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	ctx.restConfig, err = clientcmd.RESTConfigFromKubeConfig(kcSecret.Data["value"])
+	if err != nil {
+		return nil, err
+	}
+	return ctx, nil
+}
+
+// runEtcdMemberRemoval removes the etcd member of an etcd machine from the etcd cluster, so that the infrastructure
+// isn't torn down before and causes a loss of quorum.
+func runEtcdMemberRemoval(ctx *hookContext) (rkev1.MachineDeletionHookState, string, error) {
+	machine := ctx.machine
+	if _, isEtcd := machine.Labels[capr.EtcdRoleLabel]; !isEtcd {
+		return rkev1.MachineDeletionHookStateSkipped, "machine is not an etcd machine", nil
+	}
+	if ctx.bootstrap != nil && strings.ToLower(ctx.bootstrap.Annotations[capr.ForceRemoveEtcdAnnotation]) == "true" {
+		return rkev1.MachineDeletionHookStateSkipped, fmt.Sprintf("etcd member removal is forced per annotation %s", capr.ForceRemoveEtcdAnnotation), nil
+	}
+	if ctx.controlPlane == nil {
+		return rkev1.MachineDeletionHookStateSkipped, "cluster is being deleted or was not found", nil
+	}
+	if machine.Status.NodeRef == nil {
+		return rkev1.MachineDeletionHookStateSkipped, "machine has no node", nil
+	}
+	if ctx.restConfig == nil {
+		return rkev1.MachineDeletionHookStateSkipped, "kubeconfig of the cluster was not found", nil
+	}
+
+	removed, err := etcdmgmt.SafelyRemoved(ctx.restConfig, capr.GetRuntimeCommand(ctx.controlPlane.Spec.KubernetesVersion), machine.Status.NodeRef.Name)
+	if err != nil {
+		return "", "", err
+	}
+	if !removed {
+		return rkev1.MachineDeletionHookStateRunning, fmt.Sprintf("waiting for the etcd member of node %s to be removed", machine.Status.NodeRef.Name), nil
+	}
+	return rkev1.MachineDeletionHookStateSucceeded, fmt.Sprintf("etcd member of node %s was removed", machine.Status.NodeRef.Name), nil
+}
+
+// runVolumeDetach waits for the volumes attached to the node of the machine to be detached, so that they aren't lost
+// when the infrastructure is torn down.
+func runVolumeDetach(ctx *hookContext) (rkev1.MachineDeletionHookState, string, error) {
+	machine := ctx.machine
+	if machine.Status.NodeRef == nil {
+		return rkev1.MachineDeletionHookStateSkipped, "machine has no node", nil
+	}
+	if ctx.restConfig == nil {
+		return rkev1.MachineDeletionHookStateSkipped, "kubeconfig of the cluster was not found", nil
+	}
+
+	clientset, err := kubernetes.NewForConfig(ctx.restConfig)
+	if err != nil {
+		return "", "", err
+	}
+
+	node, err := clientset.CoreV1().Nodes().Get(context.TODO(), machine.Status.NodeRef.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return rkev1.MachineDeletionHookStateSucceeded, fmt.Sprintf("node %s was removed", machine.Status.NodeRef.Name), nil
+	} else if err != nil {
+		return "", "", err
+	}
+
+	if attached := len(node.Status.VolumesAttached); attached > 0 {
+		return rkev1.MachineDeletionHookStateRunning, fmt.Sprintf("waiting for %d volumes to be detached from node %s", attached, node.Name), nil
+	}
+	return rkev1.MachineDeletionHookStateSucceeded, fmt.Sprintf("volumes were detached from node %s", node.Name), nil
+}
@@ -0,0 +1,96 @@
+package machinedeletion
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+)
+
+const webhookRequestTimeout = 30 * time.Second
+
+// newWebhookHook returns the hook calling the webhook, which deregisters the machine from an external system.
+func newWebhookHook(webhook rkev1.MachineDeletionWebhook) hook {
+	h := hook{
+		name:          webhook.Name,
+		timeout:       defaultWebhookTimeout,
+		failurePolicy: rkev1.MachineDeletionHookFailurePolicyIgnore,
+		run: func(ctx *hookContext) (rkev1.MachineDeletionHookState, string, error) {
+			return callWebhook(webhook, ctx)
+		},
+	}
+	if webhook.Timeout != nil {
+		h.timeout = webhook.Timeout.Duration
+	}
+	if webhook.FailurePolicy != "" {
+		h.failurePolicy = webhook.FailurePolicy
+	}
+	return h
+}
+
+func callWebhook(webhook rkev1.MachineDeletionWebhook, ctx *hookContext) (rkev1.MachineDeletionHookState, string, error) {
+	client, err := webhookClient(webhook)
+	if err != nil {
+		return "", "", err
+	}
+
+	body, err := json.Marshal(webhookRequest(ctx))
+	if err != nil {
+		return "", "", err
+	}
+
+	resp, err := client.Post(webhook.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", "", fmt.Errorf("calling webhook %s: %w", webhook.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", "", fmt.Errorf("webhook %s responded with status %d: %s", webhook.Name, resp.StatusCode, bytes.TrimSpace(respBody))
+	}
+	return rkev1.MachineDeletionHookStateSucceeded, fmt.Sprintf("webhook %s responded with status %d", webhook.Name, resp.StatusCode), nil
+}
+
+func webhookClient(webhook rkev1.MachineDeletionWebhook) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if webhook.CACerts != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(webhook.CACerts)) {
+			return nil, fmt.Errorf("unable to parse the CA certificates of webhook %s", webhook.Name)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	return &http.Client{
+		Timeout:   webhookRequestTimeout,
+		Transport: transport,
+	}, nil
+}
+
+func webhookRequest(ctx *hookContext) rkev1.MachineDeletionWebhookRequest {
+	machine := ctx.machine
+	request := rkev1.MachineDeletionWebhookRequest{
+		ClusterNamespace: machine.Namespace,
+		ClusterName:      machine.Spec.ClusterName,
+		MachineName:      machine.Name,
+	}
+	if machine.Status.NodeRef != nil {
+		request.NodeName = machine.Status.NodeRef.Name
+	}
+	if machine.Spec.ProviderID != nil {
+		request.ProviderID = *machine.Spec.ProviderID
+	}
+	for _, address := range machine.Status.Addresses {
+		request.Addresses = append(request.Addresses, address.Address)
+	}
+	return request
+}
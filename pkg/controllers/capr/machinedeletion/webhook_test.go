@@ -0,0 +1,60 @@
+package machinedeletion
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestCallWebhook(t *testing.T) {
+	providerID := "aws:///us-east-1a/i-1234"
+	ctx := &hookContext{
+		machine: &capi.Machine{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "fleet-default", Name: "pool-1"},
+			Spec:       capi.MachineSpec{ClusterName: "c", ProviderID: &providerID},
+			Status: capi.MachineStatus{
+				NodeRef:   &corev1.ObjectReference{Name: "node-1"},
+				Addresses: capi.MachineAddresses{{Type: capi.MachineInternalIP, Address: "10.0.0.1"}},
+			},
+		},
+	}
+
+	var received rkev1.MachineDeletionWebhookRequest
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&received))
+		rw.WriteHeader(status)
+		_, _ = rw.Write([]byte("not registered\n"))
+	}))
+	defer server.Close()
+
+	webhook := rkev1.MachineDeletionWebhook{Name: "cmdb", URL: server.URL}
+	state, message, err := callWebhook(webhook, ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, rkev1.MachineDeletionHookStateSucceeded, state)
+	assert.Equal(t, "webhook cmdb responded with status 200", message)
+	assert.Equal(t, rkev1.MachineDeletionWebhookRequest{
+		ClusterNamespace: "fleet-default",
+		ClusterName:      "c",
+		MachineName:      "pool-1",
+		NodeName:         "node-1",
+		ProviderID:       providerID,
+		Addresses:        []string{"10.0.0.1"},
+	}, received)
+
+	status = http.StatusNotFound
+	_, _, err = callWebhook(webhook, ctx)
+	assert.EqualError(t, err, "webhook cmdb responded with status 404: not registered")
+
+	webhook.CACerts = "not a certificate"
+	_, _, err = callWebhook(webhook, ctx)
+	assert.EqualError(t, err, "unable to parse the CA certificates of webhook cmdb")
+}
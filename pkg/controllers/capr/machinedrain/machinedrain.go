@@ -25,6 +25,7 @@
 
 type handler struct {
 	ctx          context.Context
+	machines     capicontrollers.MachineClient
 	machineCache capicontrollers.MachineCache
 	secrets      corecontrollers.SecretClient
 	secretCache  corecontrollers.SecretCache
@@ -33,12 +34,14 @@ type handler struct {
 func Register(ctx context.Context, clients *wrangler.Context) {
 	h := &handler{
 		ctx:          ctx,
+		machines:     clients.CAPI.Machine(),
 		machineCache: clients.CAPI.Machine().Cache(),
 		secrets:      clients.Core.Secret(),
 		secretCache:  clients.Core.Secret().Cache(),
 	}
 
 	clients.Core.Secret().OnChange(ctx, "machine-drain", h.OnChange)
+	clients.CAPI.Machine().OnChange(ctx, "machine-node-maintenance", h.OnMachineChange)
 }
 
 func (h *handler) OnChange(_ string, secret *corev1.Secret) (*corev1.Secret, error) {
@@ -0,0 +1,163 @@
+package machinedrain
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/kubectl/pkg/drain"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// OnMachineChange performs the cordon, drain or uncordon of the node of the machine requested through the API, and
+// records its progress in the rke.cattle.io/node-maintenance-status annotation of the machine.
+func (h *handler) OnMachineChange(_ string, machine *capi.Machine) (*capi.Machine, error) {
+	if machine == nil || machine.Annotations[capr.MaintenanceAnnotation] == "" {
+		return machine, nil
+	}
+
+	var request rkev1.NodeMaintenance
+	if err := json.Unmarshal([]byte(machine.Annotations[capr.MaintenanceAnnotation]), &request); err != nil {
+		logrus.Errorf("[machinedrain] %s/%s: invalid node maintenance request: %v", machine.Namespace, machine.Name, err)
+		return machine, nil
+	}
+
+	var status rkev1.NodeMaintenanceStatus
+	if data := machine.Annotations[capr.MaintenanceStatusAnnotation]; data != "" {
+		if err := json.Unmarshal([]byte(data), &status); err != nil {
+			logrus.Errorf("[machinedrain] %s/%s: discarding invalid node maintenance status: %v", machine.Namespace, machine.Name, err)
+		}
+	}
+	// A request that is still in progress was interrupted, for instance by a restart of rancher, and is performed again.
+	if status.ID == request.ID && status.State != rkev1.NodeMaintenanceStateInProgress {
+		return machine, nil
+	}
+
+	now := metav1.Now()
+	status = rkev1.NodeMaintenanceStatus{
+		ID:        request.ID,
+		Action:    request.Action,
+		State:     rkev1.NodeMaintenanceStateInProgress,
+		StartTime: &now,
+	}
+
+	var err error
+	switch {
+	case !machine.DeletionTimestamp.IsZero():
+		err = fmt.Errorf("machine is being deleted")
+	case machine.Status.NodeRef == nil || machine.Status.NodeRef.Name == "":
+		err = fmt.Errorf("machine has no node")
+	default:
+		if err = h.updateMaintenanceStatus(machine, status); err != nil {
+			return machine, err
+		}
+		err = h.performMaintenance(machine, request, &status)
+	}
+
+	completed := metav1.Now()
+	status.CompletionTime = &completed
+	if err != nil {
+		status.State = rkev1.NodeMaintenanceStateFailed
+		status.Message = err.Error()
+	} else {
+		status.State = rkev1.NodeMaintenanceStateSucceeded
+		status.Message = maintenanceDoneMessage(request.Action, machine.Status.NodeRef.Name)
+	}
+	return machine, h.updateMaintenanceStatus(machine, status)
+}
+
+func (h *handler) performMaintenance(machine *capi.Machine, request rkev1.NodeMaintenance, status *rkev1.NodeMaintenanceStatus) error {
+	drainOpts := rkev1.DrainOptions{}
+	if request.DrainOptions != nil {
+		drainOpts = *request.DrainOptions
+	}
+
+	helper, node, err := h.getHelper(machine, drainOpts)
+	if err != nil {
+		return err
+	}
+
+	switch request.Action {
+	case rkev1.NodeMaintenanceActionCordon:
+		return drain.RunCordonOrUncordon(helper, node, true)
+	case rkev1.NodeMaintenanceActionUncordon:
+		return drain.RunCordonOrUncordon(helper, node, false)
+	case rkev1.NodeMaintenanceActionDrain:
+	default:
+		return fmt.Errorf("unknown node maintenance action %s", request.Action)
+	}
+
+	if err := drain.RunCordonOrUncordon(helper, node, true); err != nil {
+		return err
+	}
+
+	pods, errs := helper.GetPodsForDeletion(node.Name)
+	if len(errs) > 0 {
+		return utilerrors.NewAggregate(errs)
+	}
+	status.PodsToEvict = len(pods.Pods())
+	status.Message = fmt.Sprintf("draining node %s", node.Name)
+	if err := h.updateMaintenanceStatus(machine, *status); err != nil {
+		return err
+	}
+
+	// Pods are evicted concurrently, the progress is recorded as each of them is gone.
+	var lock sync.Mutex
+	helper.OnPodDeletedOrEvicted = func(pod *corev1.Pod, _ bool) {
+		lock.Lock()
+		defer lock.Unlock()
+		status.PodsEvicted++
+		if err := h.updateMaintenanceStatus(machine, *status); err != nil {
+			logrus.Debugf("[machinedrain] %s/%s: failed to record node maintenance progress: %v", machine.Namespace, machine.Name, err)
+		}
+	}
+
+	err = drain.RunNodeDrain(helper, node.Name)
+	lock.Lock()
+	defer lock.Unlock()
+	return err
+}
+
+// updateMaintenanceStatus records the status in the annotation of the machine, unless the machine was requested
+// another node maintenance in the meantime.
+func (h *handler) updateMaintenanceStatus(machine *capi.Machine, status rkev1.NodeMaintenanceStatus) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		machine, err := h.machines.Get(machine.Namespace, machine.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		var request rkev1.NodeMaintenance
+		if err := json.Unmarshal([]byte(machine.Annotations[capr.MaintenanceAnnotation]), &request); err != nil || request.ID != status.ID {
+			return nil
+		}
+		if machine.Annotations[capr.MaintenanceStatusAnnotation] == string(data) {
+			return nil
+		}
+		machine = machine.DeepCopy()
+		machine.Annotations[capr.MaintenanceStatusAnnotation] = string(data)
+		_, err = h.machines.Update(machine)
+		return err
+	})
+}
+
+func maintenanceDoneMessage(action rkev1.NodeMaintenanceAction, nodeName string) string {
+	switch action {
+	case rkev1.NodeMaintenanceActionCordon:
+		return fmt.Sprintf("node %s was cordoned", nodeName)
+	case rkev1.NodeMaintenanceActionUncordon:
+		return fmt.Sprintf("node %s was uncordoned", nodeName)
+	}
+	return fmt.Sprintf("node %s was drained", nodeName)
+}
@@ -0,0 +1,166 @@
+package machineprovision
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// genericDriver is the driver of rancher-machine installing the system agent on an existing instance over SSH, used
+// for the machines adopting an instance. Removing a machine of the generic driver leaves its instance running.
+const genericDriver = "generic"
+
+// claimMachineAdoption claims an adoption requested for the machine pool of a machine without a create job, so that the
+// system agent is installed on the adopted instance instead of creating one. The claim is recorded on the cluster, the
+// adoption on the infrastructure machine, and the provider ID of the instance on the CAPI machine for the planner to set
+// it on the kubelet. It returns whether the infrastructure machine claimed an adoption and must be updated.
+func (h *handler) claimMachineAdoption(infra *infraObject, machine *capi.Machine) (bool, error) {
+	poolName := machine.Labels[capr.RKEMachinePoolNameLabel]
+	if poolName == "" || infra.meta.GetAnnotations()[capr.MachineAdoptionAnnotation] != "" || infra.data.String("status", "jobName") != "" {
+		return false, nil
+	}
+	if _, err := h.getJobFromInfraMachine(infra); err == nil {
+		return false, nil
+	} else if !apierrors.IsNotFound(err) {
+		return false, err
+	}
+
+	namespace, clusterName := infra.meta.GetNamespace(), infra.meta.GetLabels()[capi.ClusterLabelName]
+	cluster, err := h.rancherClusterCache.Get(namespace, clusterName)
+	if err != nil {
+		return false, err
+	}
+	adoptions, err := capr.ParseMachineAdoptions(cluster.Annotations)
+	if err != nil || capr.ClaimMachineAdoption(adoptions, poolName, machine.Name) < 0 {
+		return false, err
+	}
+
+	var adoption *rkev1.MachineAdoption
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		adoption = nil
+		cluster, err := h.rancherClusters.Get(namespace, clusterName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		adoptions, err := capr.ParseMachineAdoptions(cluster.Annotations)
+		if err != nil {
+			return err
+		}
+		i := capr.ClaimMachineAdoption(adoptions, poolName, machine.Name)
+		if i < 0 {
+			return nil
+		}
+		adoption = &adoptions[i]
+		if adoption.MachineName == machine.Name {
+			return nil
+		}
+
+		adoption.MachineName = machine.Name
+		cluster = cluster.DeepCopy()
+		if err := capr.SetMachineAdoptions(cluster.Annotations, adoptions); err != nil {
+			return err
+		}
+		_, err = h.rancherClusters.Update(cluster)
+		return err
+	})
+	if err != nil || adoption == nil {
+		return false, err
+	}
+
+	if machine.Annotations[capr.ProviderIDAnnotation] != adoption.ProviderID {
+		machine = machine.DeepCopy()
+		if machine.Annotations == nil {
+			machine.Annotations = map[string]string{}
+		}
+		machine.Annotations[capr.ProviderIDAnnotation] = adoption.ProviderID
+		if _, err := h.machineClient.Update(machine); err != nil {
+			return false, err
+		}
+	}
+
+	data, err := json.Marshal(adoption)
+	if err != nil {
+		return false, err
+	}
+	logrus.Infof("[machineprovision] %s/%s: adopting instance %s into machine pool %s", infra.meta.GetNamespace(), infra.meta.GetName(), adoption.InstanceID, poolName)
+	infra.data.SetNested(string(data), "metadata", "annotations", capr.MachineAdoptionAnnotation)
+	return true, nil
+}
+
+// releaseMachineAdoption releases the adoption claimed by a machine whose system agent failed to be installed, for the
+// machine recreated in its place to claim it again.
+func (h *handler) releaseMachineAdoption(infra *infraObject, machine *capi.Machine, adoption *rkev1.MachineAdoption) error {
+	namespace, clusterName := infra.meta.GetNamespace(), infra.meta.GetLabels()[capi.ClusterLabelName]
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cluster, err := h.rancherClusters.Get(namespace, clusterName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		adoptions, err := capr.ParseMachineAdoptions(cluster.Annotations)
+		if err != nil {
+			return err
+		}
+		released := false
+		for i := range adoptions {
+			if adoptions[i].ID == adoption.ID && adoptions[i].MachineName == machine.Name {
+				adoptions[i].MachineName = ""
+				released = true
+			}
+		}
+		if !released {
+			return nil
+		}
+
+		logrus.Infof("[machineprovision] %s/%s: releasing the adoption of instance %s", infra.meta.GetNamespace(), infra.meta.GetName(), adoption.InstanceID)
+		cluster = cluster.DeepCopy()
+		if err := capr.SetMachineAdoptions(cluster.Annotations, adoptions); err != nil {
+			return err
+		}
+		_, err = h.rancherClusters.Update(cluster)
+		return err
+	})
+}
+
+// adoptionFilesSecret returns the files secret of the job installing the system agent on an adopted instance, holding
+// the private SSH key of the instance.
+func (h *handler) adoptionFilesSecret(namespace string, adoption *rkev1.MachineAdoption) (*corev1.Secret, error) {
+	secret, err := h.secrets.Get(namespace, adoption.SSHKeySecretName)
+	if err != nil {
+		return nil, err
+	}
+	key := secret.Data[corev1.SSHAuthPrivateKey]
+	if len(key) == 0 {
+		return nil, fmt.Errorf("secret %s/%s has no %s", namespace, adoption.SSHKeySecretName, corev1.SSHAuthPrivateKey)
+	}
+	if key[len(key)-1] != '\n' {
+		key = append(append([]byte{}, key...), '\n')
+	}
+	return &corev1.Secret{
+		Data: map[string][]byte{
+			"id_rsa": key,
+		},
+	}, nil
+}
+
+// adoptionArgs returns the arguments of the generic driver installing the system agent on an adopted instance over SSH.
+func adoptionArgs(adoption *rkev1.MachineAdoption) []string {
+	args := []string{
+		fmt.Sprintf("--%s-ip-address=%s", genericDriver, adoption.Address),
+		fmt.Sprintf("--%s-ssh-key=%s", genericDriver, path.Join(pathToMachineFiles, "id_rsa")),
+	}
+	if adoption.SSHUser != "" {
+		args = append(args, fmt.Sprintf("--%s-ssh-user=%s", genericDriver, adoption.SSHUser))
+	}
+	return args
+}
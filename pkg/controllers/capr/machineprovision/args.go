@@ -65,15 +65,19 @@ type driverArgs struct {
 	BackoffLimit        int32
 }
 
-func (h *handler) getArgsEnvAndStatus(infra *infraObject, args map[string]interface{}, driver string, create bool) (driverArgs, error) {
+// getArgsEnvAndStatus returns the arguments of the job creating or removing the infrastructure of a machine with its
+// driver. The system agent of a machine adopting an existing instance is installed with the generic driver instead.
+func (h *handler) getArgsEnvAndStatus(infra *infraObject, args map[string]interface{}, driver string, adoption *rkev1.MachineAdoption, create bool) (driverArgs, error) {
 	var (
 		url, hash, cloudCredentialSecretName string
 		jobBackoffLimit                      int32
 		filesSecret                          *corev1.Secret
 	)
 
-	nd, err := h.nodeDriverCache.Get(driver)
-	if !create && apierror.IsNotFound(err) {
+	if adoption != nil {
+		// The generic driver is built into rancher-machine.
+		driver = genericDriver
+	} else if nd, err := h.nodeDriverCache.Get(driver); !create && apierror.IsNotFound(err) {
 		url = infra.data.String("status", "driverURL")
 		hash = infra.data.String("status", "driverHash")
 	} else if err != nil {
@@ -126,7 +130,13 @@ func (h *handler) getArgsEnvAndStatus(infra *infraObject, args map[string]interf
 
 	// The files secret must be constructed before toArgs is called because
 	// constructFilesSecret replaces file contents and creates a secret to be passed as a volume.
-	filesSecret = constructFilesSecret(driver, args)
+	if adoption == nil {
+		filesSecret = constructFilesSecret(driver, args)
+	} else if create {
+		if filesSecret, err = h.adoptionFilesSecret(infra.meta.GetNamespace(), adoption); err != nil {
+			return driverArgs{}, err
+		}
+	}
 	if create {
 		cmd = append(cmd, "create",
 			fmt.Sprintf("--driver=%s", driver),
@@ -141,7 +151,11 @@ func (h *handler) getArgsEnvAndStatus(infra *infraObject, args map[string]interf
 		if err != nil {
 			return driverArgs{}, err
 		}
-		cmd = append(cmd, toArgs(driver, args, rancherCluster.Status.ClusterName)...)
+		if adoption != nil {
+			cmd = append(cmd, adoptionArgs(adoption)...)
+		} else {
+			cmd = append(cmd, toArgs(driver, args, rancherCluster.Status.ClusterName, infra.meta.GetName())...)
+		}
 	} else {
 		cmd = append(cmd, "rm", "-y")
 		jobBackoffLimit = 3
@@ -244,9 +258,10 @@ func GetCloudCredentialSecret(secrets corecontrollers.SecretCache, namespace, na
 	return secrets.Get(namespace, name)
 }
 
-func toArgs(driverName string, args map[string]interface{}, clusterID string) (cmd []string) {
+func toArgs(driverName string, args map[string]interface{}, clusterID, machineName string) (cmd []string) {
 	if driverName == "amazonec2" {
-		tagValue := fmt.Sprintf("kubernetes.io/cluster/%s,owned", clusterID)
+		// The machine tag lets the resources left behind by failed provisioning be found once the machine is removed.
+		tagValue := fmt.Sprintf("kubernetes.io/cluster/%s,owned,%s,%s", clusterID, InfraMachineName, machineName)
 		if tags, ok := args["tags"]; !ok || convert.ToString(tags) == "" {
 			args["tags"] = tagValue
 		} else {
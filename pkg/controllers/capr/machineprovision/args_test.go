@@ -3,6 +3,7 @@
 import (
 	"testing"
 
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
 	"github.com/rancher/rancher/pkg/capr"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -177,3 +178,27 @@ func TestGetHostname(t *testing.T) {
 		})
 	}
 }
+
+func TestToArgsTags(t *testing.T) {
+	args := map[string]interface{}{"tags": "team,infra"}
+	cmd := toArgs("amazonec2", args, "c-m-abc", "pool1-xyz")
+	assert.Contains(t, cmd, "--amazonec2-tags=team,infra,kubernetes.io/cluster/c-m-abc,owned,rke.cattle.io/infra-machine-name,pool1-xyz")
+
+	cmd = toArgs("digitalocean", map[string]interface{}{"tags": "team"}, "c-m-abc", "pool1-xyz")
+	assert.Contains(t, cmd, "--digitalocean-tags=team")
+}
+
+func TestAdoptionArgs(t *testing.T) {
+	adoption := &rkev1.MachineAdoption{Address: "10.0.0.1", SSHUser: "ubuntu"}
+	assert.Equal(t, []string{
+		"--generic-ip-address=10.0.0.1",
+		"--generic-ssh-key=/path/to/machine/files/id_rsa",
+		"--generic-ssh-user=ubuntu",
+	}, adoptionArgs(adoption))
+
+	adoption.SSHUser = ""
+	assert.Equal(t, []string{
+		"--generic-ip-address=10.0.0.1",
+		"--generic-ssh-key=/path/to/machine/files/id_rsa",
+	}, adoptionArgs(adoption))
+}
@@ -5,6 +5,7 @@
 	"errors"
 	"fmt"
 	"path"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,9 +17,11 @@
 	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
 	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
 	ranchercontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	rkecontrollers "github.com/rancher/rancher/pkg/generated/controllers/rke.cattle.io/v1"
 	"github.com/rancher/rancher/pkg/namespace"
 	"github.com/rancher/rancher/pkg/provisioningv2/kubeconfig"
 	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/tracing"
 	"github.com/rancher/rancher/pkg/wrangler"
 	"github.com/rancher/wrangler/pkg/apply"
 	"github.com/rancher/wrangler/pkg/condition"
@@ -48,6 +51,7 @@
 const (
 	createJobConditionType = "CreateJob"
 	deleteJobConditionType = "DeleteJob"
+	queuedConditionType    = "Queued"
 
 	forceRemoveMachineAnn = "provisioning.cattle.io/force-machine-remove"
 )
@@ -85,21 +89,24 @@ func newInfraObject(obj runtime.Object) (*infraObject, error) {
 }
 
 type handler struct {
-	ctx                 context.Context
-	apply               apply.Apply
-	jobController       batchcontrollers.JobController
-	jobs                batchcontrollers.JobCache
-	pods                corecontrollers.PodCache
-	secrets             corecontrollers.SecretCache
-	capiClusterCache    capicontrollers.ClusterCache
-	machineCache        capicontrollers.MachineCache
-	machineClient       capicontrollers.MachineClient
-	machineSetCache     capicontrollers.MachineSetCache
-	namespaces          corecontrollers.NamespaceCache
-	nodeDriverCache     mgmtcontrollers.NodeDriverCache
-	dynamic             *dynamic.Controller
-	rancherClusterCache ranchercontrollers.ClusterCache
-	kubeconfigManager   *kubeconfig.Manager
+	ctx                  context.Context
+	apply                apply.Apply
+	jobController        batchcontrollers.JobController
+	jobs                 batchcontrollers.JobCache
+	pods                 corecontrollers.PodCache
+	secrets              corecontrollers.SecretCache
+	capiClusterCache     capicontrollers.ClusterCache
+	machineCache         capicontrollers.MachineCache
+	machineClient        capicontrollers.MachineClient
+	machineSetCache      capicontrollers.MachineSetCache
+	namespaces           corecontrollers.NamespaceCache
+	nodeDriverCache      mgmtcontrollers.NodeDriverCache
+	dynamic              *dynamic.Controller
+	rancherClusterCache  ranchercontrollers.ClusterCache
+	rkeControlPlaneCache rkecontrollers.RKEControlPlaneCache
+	rancherClusters      ranchercontrollers.ClusterClient
+	kubeconfigManager    *kubeconfig.Manager
+	queue                *provisionQueue
 }
 
 func Register(ctx context.Context, clients *wrangler.Context, kubeconfigManager *kubeconfig.Manager) {
@@ -110,19 +117,22 @@ func Register(ctx context.Context, clients *wrangler.Context, kubeconfigManager
 			clients.RBAC.RoleBinding(),
 			clients.RBAC.Role(),
 			clients.Batch.Job()),
-		pods:                clients.Core.Pod().Cache(),
-		jobController:       clients.Batch.Job(),
-		jobs:                clients.Batch.Job().Cache(),
-		secrets:             clients.Core.Secret().Cache(),
-		machineCache:        clients.CAPI.Machine().Cache(),
-		machineClient:       clients.CAPI.Machine(),
-		machineSetCache:     clients.CAPI.MachineSet().Cache(),
-		capiClusterCache:    clients.CAPI.Cluster().Cache(),
-		nodeDriverCache:     clients.Mgmt.NodeDriver().Cache(),
-		namespaces:          clients.Core.Namespace().Cache(),
-		dynamic:             clients.Dynamic,
-		rancherClusterCache: clients.Provisioning.Cluster().Cache(),
-		kubeconfigManager:   kubeconfigManager,
+		pods:                 clients.Core.Pod().Cache(),
+		jobController:        clients.Batch.Job(),
+		jobs:                 clients.Batch.Job().Cache(),
+		secrets:              clients.Core.Secret().Cache(),
+		machineCache:         clients.CAPI.Machine().Cache(),
+		machineClient:        clients.CAPI.Machine(),
+		machineSetCache:      clients.CAPI.MachineSet().Cache(),
+		capiClusterCache:     clients.CAPI.Cluster().Cache(),
+		nodeDriverCache:      clients.Mgmt.NodeDriver().Cache(),
+		namespaces:           clients.Core.Namespace().Cache(),
+		dynamic:              clients.Dynamic,
+		rancherClusterCache:  clients.Provisioning.Cluster().Cache(),
+		rkeControlPlaneCache: clients.RKE.RKEControlPlane().Cache(),
+		rancherClusters:      clients.Provisioning.Cluster(),
+		kubeconfigManager:    kubeconfigManager,
+		queue:                newProvisionQueue(),
 	}
 
 	removeHandler := generic.NewRemoveHandler("machine-provision-remove", clients.Dynamic.Update, h.OnRemove)
@@ -313,6 +323,7 @@ func (h *handler) OnRemove(key string, obj runtime.Object) (runtime.Object, erro
 	if err != nil {
 		return obj, err
 	}
+	h.queue.remove(machineKey(infra.meta.GetNamespace(), infra.obj.GetObjectKind().GroupVersionKind().Kind, infra.meta.GetName()))
 
 	// When infra machines are initially created, the machine set controller sets itself as the owner reference
 	// Later, the CAPI machine will adopt the node, setting itself as the owner reference
@@ -498,6 +509,33 @@ func (h *handler) EnqueueAfter(infra *infraObject, duration time.Duration) {
 
 // OnChange is called whenever the infrastructure machine is updated, including when the object is being deleted.
 func (h *handler) OnChange(obj runtime.Object) (runtime.Object, error) {
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		return obj, err
+	}
+
+	_, span := tracing.StartClusterSpan(h.ctx, h.tracedControlPlane(objMeta), "machineprovision.OnChange",
+		tracing.MachineNameKey.String(objMeta.GetName()))
+	result, err := h.onChange(obj)
+	tracing.End(span, err)
+	return result, err
+}
+
+// tracedControlPlane returns the RKE control plane of the cluster of an infrastructure machine, whose trace the spans of
+// the machine are part of, or nil if it's unknown.
+func (h *handler) tracedControlPlane(objMeta metav1.Object) metav1.Object {
+	clusterName := objMeta.GetLabels()[capi.ClusterLabelName]
+	if clusterName == "" {
+		return nil
+	}
+	cp, err := h.rkeControlPlaneCache.Get(objMeta.GetNamespace(), clusterName)
+	if err != nil {
+		return nil
+	}
+	return cp
+}
+
+func (h *handler) onChange(obj runtime.Object) (runtime.Object, error) {
 	infra, err := newInfraObject(obj)
 	if err != nil {
 		return obj, err
@@ -559,6 +597,26 @@ func (h *handler) OnChange(obj runtime.Object) (runtime.Object, error) {
 		return obj, generic.ErrSkip
 	}
 
+	if claimed, err := h.claimMachineAdoption(infra, machine); err != nil {
+		return obj, err
+	} else if claimed {
+		return h.dynamic.Update(&unstructured.Unstructured{
+			Object: infra.data,
+		})
+	}
+
+	if queued, changed, err := h.waitForProvisionSlot(infra); err != nil {
+		return obj, err
+	} else if queued {
+		h.EnqueueAfter(infra, 10*time.Second)
+		if !changed {
+			return obj, nil
+		}
+		return h.dynamic.UpdateStatus(&unstructured.Unstructured{
+			Object: infra.data,
+		})
+	}
+
 	state, failure, err := h.run(infra, true)
 	if err != nil {
 		return obj, err
@@ -566,6 +624,13 @@ func (h *handler) OnChange(obj runtime.Object) (runtime.Object, error) {
 
 	if failure {
 		logrus.Infof("[machineprovision] %s/%s: Failed to create infrastructure for machine %s, deleting and recreating...", infra.meta.GetNamespace(), infra.meta.GetName(), machine.Name)
+		if adoption, err := capr.ParseMachineAdoption(infra.meta.GetAnnotations()); err != nil {
+			return obj, err
+		} else if adoption != nil {
+			if err := h.releaseMachineAdoption(infra, machine, adoption); err != nil {
+				return obj, err
+			}
+		}
 		if err = h.machineClient.Delete(machine.Namespace, machine.Name, &metav1.DeleteOptions{}); err != nil {
 			return obj, err
 		}
@@ -621,13 +686,59 @@ func (h *handler) OnChange(obj runtime.Object) (runtime.Object, error) {
 	})
 }
 
+// waitForProvisionSlot returns whether the creation of a machine without a create job is queued because too many
+// machines are being created, globally or with its cloud credential. The position of the machine in the queue is
+// recorded in its status, and removed once it is admitted. It also returns whether the status changed.
+func (h *handler) waitForProvisionSlot(infra *infraObject) (bool, bool, error) {
+	if _, err := h.getJobFromInfraMachine(infra); err == nil {
+		return false, false, nil
+	} else if !apierrors.IsNotFound(err) {
+		return false, false, err
+	}
+
+	key := machineKey(infra.meta.GetNamespace(), infra.obj.GetObjectKind().GroupVersionKind().Kind, infra.meta.GetName())
+	limit := settings.MachineProvisionConcurrency.GetInt()
+	credentialLimit := settings.MachineProvisionConcurrencyPerCloudCredential.GetInt()
+	admitted, position := true, 0
+	if limit > 0 || credentialLimit > 0 {
+		jobs, err := h.jobs.List("", labels.SelectorFromSet(labels.Set{InfraJobRemove: "false"}))
+		if err != nil {
+			return false, false, err
+		}
+		credential := credentialKey(infra.meta.GetNamespace(), infra.data.String("spec", "common", "cloudCredentialSecretName"))
+		admitted, position = h.queue.admit(key, credential, infra.meta.GetCreationTimestamp().Time, runningCreateJobs(jobs), limit, credentialLimit)
+	} else {
+		h.queue.remove(key)
+	}
+
+	previous := infra.data.String("status", "queuePosition")
+	if admitted {
+		if previous == "" {
+			return false, false, nil
+		}
+		delete(infra.data.Map("status"), "queuePosition")
+		_, err := insertOrUpdateCondition(infra.data, summary.NewCondition(queuedConditionType, "False", "", ""))
+		return false, true, err
+	}
+
+	logrus.Debugf("[machineprovision] %s/%s: waiting: machine is at position %d in the provisioning queue", infra.meta.GetNamespace(), infra.meta.GetName(), position)
+	infra.data.SetNested(int64(position), "status", "queuePosition")
+	changed, err := insertOrUpdateCondition(infra.data, summary.NewCondition(queuedConditionType, "True", "Waiting",
+		fmt.Sprintf("waiting for other machines to be created, position %d in the provisioning queue", position)))
+	return true, changed || previous != strconv.Itoa(position), err
+}
+
 func (h *handler) run(infra *infraObject, create bool) (rkev1.RKEMachineStatus, bool, error) {
 	logrus.Infof("[machineprovision] %s/%s: reconciling machine job", infra.meta.GetNamespace(), infra.meta.GetName())
 
 	args := infra.data.Map("spec")
 	driver := getNodeDriverName(infra.typeMeta)
+	adoption, err := capr.ParseMachineAdoption(infra.meta.GetAnnotations())
+	if err != nil {
+		return rkev1.RKEMachineStatus{}, false, err
+	}
 
-	dArgs, err := h.getArgsEnvAndStatus(infra, args, driver, create)
+	dArgs, err := h.getArgsEnvAndStatus(infra, args, driver, adoption, create)
 	if err != nil {
 		return rkev1.RKEMachineStatus{}, false, err
 	}
@@ -0,0 +1,165 @@
+package machineprovision
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rancher/rancher/pkg/namespace"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// queuedTimeout is how long a queued machine stays in the queue without being reconciled. Queued machines are
+	// reconciled every few seconds, so the machines not reconciled for longer were deleted.
+	queuedTimeout = 2 * time.Minute
+	// startedTimeout is how long a machine admitted by the queue counts as being created before its create job is in
+	// the cache.
+	startedTimeout = time.Minute
+)
+
+type queuedMachine struct {
+	key        string
+	credential string
+	created    time.Time
+	lastSeen   time.Time
+}
+
+type startedMachine struct {
+	credential string
+	started    time.Time
+}
+
+// provisionQueue limits how many machines are created at the same time, globally and per cloud credential, so that
+// creating many clusters at once doesn't exceed the rate limits of cloud APIs. The other machines are queued in the
+// order they were created. The queue is only kept in memory: the queued machines are added back as they are
+// reconciled, and the machines being created are counted from their create jobs.
+type provisionQueue struct {
+	lock    sync.Mutex
+	queued  map[string]queuedMachine
+	started map[string]startedMachine
+	now     func() time.Time
+}
+
+func newProvisionQueue() *provisionQueue {
+	return &provisionQueue{
+		queued:  map[string]queuedMachine{},
+		started: map[string]startedMachine{},
+		now:     time.Now,
+	}
+}
+
+// admit returns whether a machine can start being created and otherwise its position in the queue, starting at 1.
+// Running are the cloud credentials of the machines being created, by machine key. A limit of 0 or less is no limit.
+func (q *provisionQueue) admit(key, credential string, created time.Time, running map[string]string, limit, credentialLimit int) (bool, int) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	now := q.now()
+	for k, machine := range q.started {
+		if _, ok := running[k]; ok || now.Sub(machine.started) > startedTimeout {
+			delete(q.started, k)
+		}
+	}
+	for k, machine := range q.queued {
+		if now.Sub(machine.lastSeen) > queuedTimeout {
+			delete(q.queued, k)
+		}
+	}
+
+	active := len(running) + len(q.started)
+	activeByCredential := map[string]int{}
+	for _, c := range running {
+		activeByCredential[c]++
+	}
+	for _, machine := range q.started {
+		activeByCredential[machine.credential]++
+	}
+
+	q.queued[key] = queuedMachine{
+		key:        key,
+		credential: credential,
+		created:    created,
+		lastSeen:   now,
+	}
+	queued := make([]queuedMachine, 0, len(q.queued))
+	for _, machine := range q.queued {
+		queued = append(queued, machine)
+	}
+	sort.Slice(queued, func(i, j int) bool {
+		if !queued[i].created.Equal(queued[j].created) {
+			return queued[i].created.Before(queued[j].created)
+		}
+		return queued[i].key < queued[j].key
+	})
+
+	// Machines are admitted in order as long as there are slots left for them, machines waiting for a slot of their
+	// cloud credential don't hold back the machines of other credentials.
+	position := 0
+	for _, machine := range queued {
+		if (limit <= 0 || active < limit) && (credentialLimit <= 0 || activeByCredential[machine.credential] < credentialLimit) {
+			active++
+			activeByCredential[machine.credential]++
+			if machine.key == key {
+				delete(q.queued, key)
+				q.started[key] = startedMachine{credential: credential, started: now}
+				return true, 0
+			}
+			continue
+		}
+		position++
+		if machine.key == key {
+			return false, position
+		}
+	}
+	return false, position
+}
+
+// remove removes a machine from the queue.
+func (q *provisionQueue) remove(key string) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	delete(q.queued, key)
+	delete(q.started, key)
+}
+
+func machineKey(ns, kind, name string) string {
+	return ns + "/" + kind + "/" + name
+}
+
+// credentialKey returns the namespace and name of a cloud credential used by a machine in a namespace, cloud
+// credentials are either in the namespace of the machine or in the global namespace.
+func credentialKey(ns, credential string) string {
+	if credential == "" || strings.HasPrefix(credential, namespace.GlobalNamespace+":") {
+		return credential
+	}
+	return ns + ":" + credential
+}
+
+// runningCreateJobs returns the cloud credentials of the machines with a create job that hasn't finished, by machine
+// key.
+func runningCreateJobs(jobs []*batchv1.Job) map[string]string {
+	result := map[string]string{}
+	for _, job := range jobs {
+		if job.Labels[InfraJobRemove] != "false" || jobFinished(job) {
+			continue
+		}
+		key := machineKey(job.Namespace, job.Labels[InfraMachineKind], job.Labels[InfraMachineName])
+		result[key] = credentialKey(job.Namespace, job.Annotations[InfraCloudCredential])
+	}
+	return result
+}
+
+func jobFinished(job *batchv1.Job) bool {
+	if job.Status.CompletionTime != nil {
+		return true
+	}
+	for _, cond := range job.Status.Conditions {
+		if (cond.Type == batchv1.JobComplete || cond.Type == batchv1.JobFailed) && cond.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package machineprovision
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestProvisionQueueAdmit(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	q := newProvisionQueue()
+	q.now = func() time.Time { return now }
+
+	running := map[string]string{"fleet-default/Amazonec2Machine/m0": "cattle-global-data:cc-a"}
+
+	// one slot is left, the oldest machine is admitted
+	admitted, position := q.admit("m2", "cattle-global-data:cc-a", now.Add(2*time.Second), running, 2, 0)
+	assert.True(t, admitted)
+	assert.Equal(t, 0, position)
+
+	// the admitted machine counts as being created until its job is running
+	admitted, position = q.admit("m3", "cattle-global-data:cc-a", now.Add(3*time.Second), running, 2, 0)
+	assert.False(t, admitted)
+	assert.Equal(t, 1, position)
+	admitted, position = q.admit("m1", "cattle-global-data:cc-b", now.Add(time.Second), running, 2, 0)
+	assert.False(t, admitted)
+	assert.Equal(t, 1, position)
+	admitted, position = q.admit("m3", "cattle-global-data:cc-a", now.Add(3*time.Second), running, 2, 0)
+	assert.False(t, admitted)
+	assert.Equal(t, 2, position)
+
+	// the jobs finished, the oldest machine is admitted first
+	running = map[string]string{"m2": "cattle-global-data:cc-a"}
+	admitted, _ = q.admit("m3", "cattle-global-data:cc-a", now.Add(3*time.Second), running, 2, 0)
+	assert.False(t, admitted)
+	admitted, _ = q.admit("m1", "cattle-global-data:cc-b", now.Add(time.Second), running, 2, 0)
+	assert.True(t, admitted)
+}
+
+func TestProvisionQueueAdmitPerCredential(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	q := newProvisionQueue()
+	q.now = func() time.Time { return now }
+
+	running := map[string]string{"m0": "cattle-global-data:cc-a"}
+
+	// machines waiting for a slot of their cloud credential don't hold back the others
+	admitted, position := q.admit("m1", "cattle-global-data:cc-a", now.Add(time.Second), running, 0, 1)
+	assert.False(t, admitted)
+	assert.Equal(t, 1, position)
+	admitted, _ = q.admit("m2", "cattle-global-data:cc-b", now.Add(2*time.Second), running, 0, 1)
+	assert.True(t, admitted)
+
+	// queued machines that aren't reconciled anymore are removed from the queue
+	now = now.Add(queuedTimeout + time.Second)
+	admitted, position = q.admit("m3", "cattle-global-data:cc-a", now, running, 0, 1)
+	assert.False(t, admitted)
+	assert.Equal(t, 1, position)
+
+	q.remove("m3")
+	assert.Empty(t, q.queued)
+}
+
+func TestRunningCreateJobs(t *testing.T) {
+	job := func(name, remove, credential string, completed bool) *batchv1.Job {
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "fleet-default",
+				Name:      name,
+				Labels: map[string]string{
+					InfraMachineKind: "Amazonec2Machine",
+					InfraMachineName: name,
+					InfraJobRemove:   remove,
+				},
+				Annotations: map[string]string{
+					InfraCloudCredential: credential,
+				},
+			},
+		}
+		if completed {
+			job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		}
+		return job
+	}
+
+	assert.Equal(t, map[string]string{
+		"fleet-default/Amazonec2Machine/m1": "cattle-global-data:cc-a",
+		"fleet-default/Amazonec2Machine/m2": "fleet-default:cc-b",
+	}, runningCreateJobs([]*batchv1.Job{
+		job("m1", "false", "cattle-global-data:cc-a", false),
+		job("m2", "false", "cc-b", false),
+		job("m3", "false", "cattle-global-data:cc-a", true),
+		job("m4", "true", "cattle-global-data:cc-a", false),
+	}))
+}
@@ -13,12 +13,13 @@
 )
 
 const (
-	InfraMachineGroup   = "rke.cattle.io/infra-machine-group"
-	InfraMachineVersion = "rke.cattle.io/infra-machine-version"
-	InfraMachineKind    = "rke.cattle.io/infra-machine-kind"
-	InfraMachineName    = "rke.cattle.io/infra-machine-name"
-	InfraJobRemove      = "rke.cattle.io/infra-remove"
-	CapiMachineName     = "rke.cattle.io/capi-machine-name"
+	InfraMachineGroup    = "rke.cattle.io/infra-machine-group"
+	InfraMachineVersion  = "rke.cattle.io/infra-machine-version"
+	InfraMachineKind     = "rke.cattle.io/infra-machine-kind"
+	InfraMachineName     = "rke.cattle.io/infra-machine-name"
+	InfraJobRemove       = "rke.cattle.io/infra-remove"
+	CapiMachineName      = "rke.cattle.io/capi-machine-name"
+	InfraCloudCredential = "rke.cattle.io/infra-cloud-credential"
 
 	pathToMachineFiles = "/path/to/machine/files"
 	sslCertDir         = "/etc/rancher/ssl"
@@ -199,6 +200,9 @@ func objects(ready bool, args driverArgs) []runtime.Object {
 			Name:      saName,
 			Namespace: args.MachineNamespace,
 			Labels:    labels,
+			Annotations: map[string]string{
+				InfraCloudCredential: args.CloudCredentialSecretName,
+			},
 		},
 		Spec: batchv1.JobSpec{
 			BackoffLimit: &args.BackoffLimit,
@@ -44,6 +44,10 @@ type handler struct {
 	clusterRegistrationTokens v3.ClusterRegistrationTokenCache
 	bundles                   fleetcontrollers.BundleClient
 	rkeControlPlane           v1.RKEControlPlaneController
+	clusters                  rocontrollers.ClusterController
+	mgmtClusters              v3.ClusterClient
+	mgmtClusterCache          v3.ClusterCache
+	managedChartCache         v3.ManagedChartCache
 }
 
 func Register(ctx context.Context, clients *wrangler.Context) {
@@ -51,6 +55,10 @@ func Register(ctx context.Context, clients *wrangler.Context) {
 		clusterRegistrationTokens: clients.Mgmt.ClusterRegistrationToken().Cache(),
 		bundles:                   clients.Fleet.Bundle(),
 		rkeControlPlane:           clients.RKE.RKEControlPlane(),
+		clusters:                  clients.Provisioning.Cluster(),
+		mgmtClusters:              clients.Mgmt.Cluster(),
+		mgmtClusterCache:          clients.Mgmt.Cluster().Cache(),
+		managedChartCache:         clients.Mgmt.ManagedChart().Cache(),
 	}
 
 	v1.RegisterRKEControlPlaneStatusHandler(ctx, clients.RKE.RKEControlPlane(),
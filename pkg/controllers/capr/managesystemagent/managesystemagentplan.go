@@ -4,12 +4,14 @@
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/rancher/fleet/pkg/apis/fleet.cattle.io/v1alpha1"
 	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
 	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
 	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/canary"
 	"github.com/rancher/rancher/pkg/capr"
 	namespaces "github.com/rancher/rancher/pkg/namespace"
 	"github.com/rancher/rancher/pkg/provisioningv2/image"
@@ -17,6 +19,7 @@
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -40,16 +43,22 @@ func (h *handler) OnChangeInstallSUC(cluster *rancherv1.Cluster, status rancherv
 	// we must limit the output of name.SafeConcatName to at most 48 characters because
 	// a) the chart release name cannot exceed 53 characters, and
 	// b) upon creation of this resource the prefix 'mcc-' will be added to the release name, hence the limiting to 48 characters
+	mccName := capr.SafeConcatName(48, cluster.Name, "managed", "system-upgrade-controller")
+	version, err := h.systemUpgradeControllerVersion(cluster, mccName)
+	if err != nil {
+		return nil, status, err
+	}
+
 	mcc := &v3.ManagedChart{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: cluster.Namespace,
-			Name:      capr.SafeConcatName(48, cluster.Name, "managed", "system-upgrade-controller"),
+			Name:      mccName,
 		},
 		Spec: v3.ManagedChartSpec{
 			DefaultNamespace: namespaces.System,
 			RepoName:         "rancher-charts",
 			Chart:            "system-upgrade-controller",
-			Version:          settings.SystemUpgradeControllerChartVersion.Get(),
+			Version:          version,
 			Values: &v1alpha1.GenericMap{
 				Data: map[string]interface{}{
 					"global": map[string]interface{}{
@@ -83,6 +92,56 @@ func (h *handler) OnChangeInstallSUC(cluster *rancherv1.Cluster, status rancherv
 	}, status, nil
 }
 
+// systemUpgradeControllerVersion returns the version of the system-upgrade-controller chart of a cluster: the version of
+// the setting, or the version its chart is at until the version of the setting soaked on the canary clusters. Canaries
+// record the version when it's rolled out to them.
+func (h *handler) systemUpgradeControllerVersion(cluster *rancherv1.Cluster, mccName string) (string, error) {
+	version := settings.SystemUpgradeControllerChartVersion.Get()
+	if cluster.Status.ClusterName == "" {
+		return version, nil
+	}
+	mgmtCluster, err := h.mgmtClusterCache.Get(cluster.Status.ClusterName)
+	if errors.IsNotFound(err) {
+		return version, nil
+	} else if err != nil {
+		return "", err
+	}
+
+	if canary.IsCanary(mgmtCluster) {
+		mgmtCluster = mgmtCluster.DeepCopy()
+		if changed, err := canary.SetApplied(mgmtCluster, canary.SystemUpgradeControllerOperation, version, time.Now()); err != nil {
+			return "", err
+		} else if changed {
+			if _, err := h.mgmtClusters.Update(mgmtCluster); err != nil {
+				return "", err
+			}
+		}
+		return version, nil
+	}
+
+	mcc, err := h.managedChartCache.Get(cluster.Namespace, mccName)
+	if errors.IsNotFound(err) {
+		return version, nil
+	} else if err != nil {
+		return "", err
+	}
+	if mcc.Spec.Version == version {
+		return version, nil
+	}
+
+	clusters, err := h.mgmtClusterCache.List(labels.Everything())
+	if err != nil {
+		return "", err
+	}
+	decision := canary.Gate(mgmtCluster, clusters, canary.SystemUpgradeControllerOperation, version, canary.SoakPeriod(settings.CanarySoakPeriod.Get()), time.Now())
+	if decision.Proceed {
+		return version, nil
+	}
+	logrus.Infof("[managesystemagent] rkecluster %s/%s: holding system-upgrade-controller chart version %s: %s", cluster.Namespace, cluster.Name, version, decision.Message)
+	h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, decision.RetryAfter)
+	return mcc.Spec.Version, nil
+}
+
 type SUCMetadata struct {
 	PspEnabled bool
 }
@@ -10,10 +10,13 @@
 	"github.com/rancher/rancher/pkg/capr"
 	caprplanner "github.com/rancher/rancher/pkg/capr/planner"
 	v1 "github.com/rancher/rancher/pkg/generated/controllers/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/tracing"
 	"github.com/rancher/rancher/pkg/wrangler"
 	"github.com/rancher/wrangler/pkg/generic"
 	"github.com/rancher/wrangler/pkg/relatedresource"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -21,12 +24,14 @@
 )
 
 type handler struct {
+	ctx           context.Context
 	planner       *caprplanner.Planner
 	controlPlanes v1.RKEControlPlaneController
 }
 
 func Register(ctx context.Context, clients *wrangler.Context, planner *caprplanner.Planner) {
 	h := handler{
+		ctx:           ctx,
 		planner:       planner,
 		controlPlanes: clients.RKE.RKEControlPlane(),
 	}
@@ -89,23 +94,26 @@ func (h *handler) OnChange(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPla
 	status.ObservedGeneration = cp.Generation
 
 	logrus.Debugf("[planner] rkecluster %s/%s: calling planner process", cp.Namespace, cp.Name)
+	_, span := tracing.StartClusterSpan(h.ctx, cp, "planner.Process")
 	status, err := h.planner.Process(cp, status)
+	endProcessSpan(span, err)
 	if err != nil {
 		// planner.Process can encounter 3 types of errors:
-		// * planner.errWaiting - This is an error that indicates we are waiting for something, and will not re-enqueue the object
+		// * planner.errWaiting - This is an error that indicates we are waiting for something, and will not re-enqueue the object. Its reason is set on the conditions.
 		// * generic.ErrSkip - These will cause the object to be re-enqueued after 5 seconds.
 		// * error - All other errors. This should be an actual error during planner processing.
 		if caprplanner.IsErrWaiting(err) {
 			logrus.Infof("[planner] rkecluster %s/%s: waiting: %v", cp.Namespace, cp.Name, err)
+			reason := caprplanner.WaitingReason(err)
 			capr.Ready.SetStatus(&status, "Unknown")
 			capr.Ready.Message(&status, err.Error())
-			capr.Ready.Reason(&status, "Waiting")
+			capr.Ready.Reason(&status, reason)
 			// Set err to nil so planner doesn't automatically re-enqueue the object, as we're waiting.
 			// If the Reconciled condition is already true and the error was NOT an errIgnore/ErrSkip/ErrWaiting and the status.AppliedSpec (from planner.Process) does not match the controlplane spec, set reconciled to unknown.
 			if !equality.Semantic.DeepEqual(cp.Spec, status.AppliedSpec) {
 				capr.Reconciled.SetStatus(&status, "Unknown")
 				capr.Reconciled.Message(&status, "RKEControlPlane has not been fully reconciled yet")
-				capr.Reconciled.Reason(&status, "Waiting")
+				capr.Reconciled.Reason(&status, reason)
 			}
 			return status, nil
 		} else if errors.Is(err, generic.ErrSkip) {
@@ -134,3 +142,16 @@ func (h *handler) OnChange(cp *rkev1.RKEControlPlane, status rkev1.RKEControlPla
 	capr.Reconciled.Reason(&status, "")
 	return status, nil
 }
+
+// endProcessSpan ends the span of a run of the planner. When the planner is waiting, what it waits for is recorded on
+// the span rather than an error.
+func endProcessSpan(span trace.Span, err error) {
+	if caprplanner.IsErrWaiting(err) {
+		span.SetAttributes(
+			attribute.String("rancher.planner.waiting_reason", caprplanner.WaitingReason(err)),
+			attribute.String("rancher.planner.waiting_message", err.Error()),
+		)
+		err = nil
+	}
+	tracing.End(span, err)
+}
@@ -6,10 +6,13 @@
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	v1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
 	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/rancher/pkg/capr/planencryption"
 	"github.com/rancher/rancher/pkg/capr/planner"
+	"github.com/rancher/rancher/pkg/capr/planredaction"
 	sb "github.com/rancher/rancher/pkg/controllers/managementuser/snapshotbackpopulate"
 	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
 	rkev1controllers "github.com/rancher/rancher/pkg/generated/controllers/rke.cattle.io/v1"
@@ -31,6 +34,7 @@ type handler struct {
 	machinesClient      capicontrollers.MachineClient
 	etcdSnapshotsClient rkev1controllers.ETCDSnapshotClient
 	etcdSnapshotsCache  rkev1controllers.ETCDSnapshotCache
+	encryptor           *planencryption.Encryptor
 }
 
 func Register(ctx context.Context, clients *wrangler.Context) {
@@ -40,6 +44,7 @@ func Register(ctx context.Context, clients *wrangler.Context) {
 		machinesClient:      clients.CAPI.Machine(),
 		etcdSnapshotsClient: clients.RKE.ETCDSnapshot(),
 		etcdSnapshotsCache:  clients.RKE.ETCDSnapshot().Cache(),
+		encryptor:           planencryption.NewEncryptor(clients),
 	}
 	clients.Core.Secret().OnChange(ctx, "plan-secret", h.OnChange)
 }
@@ -51,18 +56,38 @@ func (h *handler) OnChange(key string, secret *corev1.Secret) (*corev1.Secret, e
 
 	logrus.Debugf("[plansecret] reconciling secret %s/%s", secret.Namespace, secret.Name)
 
-	node, err := planner.SecretToNode(secret)
+	// Agents write their output through the config server, which redacts it, output stored before that or matching
+	// patterns added since is redacted here.
+	redacted := secret.DeepCopy()
+	if changed, err := planredaction.Current().RedactData(redacted.Data); err != nil {
+		logrus.Errorf("[plansecret] error redacting instruction output of secret %s/%s: %v", secret.Namespace, secret.Name, err)
+	} else if changed {
+		logrus.Debugf("[plansecret] %s/%s: redacting instruction output", secret.Namespace, secret.Name)
+		return h.secrets.Update(redacted)
+	}
+
+	decrypted, err := h.encryptor.DecryptSecret(secret)
+	if err != nil {
+		return secret, err
+	}
+
+	node, err := planner.SecretToNode(decrypted)
 	if err != nil {
 		return secret, err
 	}
 
-	if v, ok := node.PeriodicOutput["etcd-snapshot-list-local"]; ok && v.ExitCode == 0 && len(v.Stdout) > 0 {
+	// A truncated snapshot list is missing snapshots, which would be removed if it were reconciled.
+	if v, ok := node.PeriodicOutput["etcd-snapshot-list-local"]; ok && v.Truncated {
+		logrus.Warnf("[plansecret] skipping truncated local snapshot list for secret %s/%s", secret.Namespace, secret.Name)
+	} else if ok && v.ExitCode == 0 && len(v.Stdout) > 0 {
 		if err := h.reconcileEtcdSnapshotList(secret, false, v.Stdout); err != nil {
 			logrus.Errorf("[plansecret] error reconciling local snapshot list for secret %s/%s: %v", secret.Namespace, secret.Name, err)
 		}
 	}
 
-	if v, ok := node.PeriodicOutput["etcd-snapshot-list-s3"]; ok && v.ExitCode == 0 && len(v.Stdout) > 0 && secret.Labels[capr.InitNodeLabel] == "true" {
+	if v, ok := node.PeriodicOutput["etcd-snapshot-list-s3"]; ok && v.Truncated {
+		logrus.Warnf("[plansecret] skipping truncated S3 snapshot list for secret %s/%s", secret.Namespace, secret.Name)
+	} else if ok && v.ExitCode == 0 && len(v.Stdout) > 0 && secret.Labels[capr.InitNodeLabel] == "true" {
 		if err := h.reconcileEtcdSnapshotList(secret, true, v.Stdout); err != nil {
 			logrus.Errorf("[plansecret] error reconciling S3 snapshot list for secret %s/%s: %v", secret.Namespace, secret.Name, err)
 		}
@@ -71,8 +96,10 @@ func (h *handler) OnChange(key string, secret *corev1.Secret) (*corev1.Secret, e
 	appliedChecksum := string(secret.Data["applied-checksum"])
 	failedChecksum := string(secret.Data["failed-checksum"])
 	plan := secret.Data["plan"]
+	// The agent computes checksums on the plans it is served, which are decrypted.
+	planHash := planner.PlanHash(decrypted.Data["plan"])
 
-	if appliedChecksum == planner.PlanHash(plan) && !bytes.Equal(plan, secret.Data["appliedPlan"]) {
+	if appliedChecksum == planHash && !bytes.Equal(plan, secret.Data["appliedPlan"]) {
 		secret = secret.DeepCopy()
 		secret.Data["appliedPlan"] = plan
 		// don't return the secret at this point, we want to attempt to update the machine status later on
@@ -82,18 +109,18 @@ func (h *handler) OnChange(key string, secret *corev1.Secret) (*corev1.Secret, e
 		}
 	}
 
-	if failedChecksum == planner.PlanHash(plan) {
+	if failedChecksum == planHash {
 		logrus.Debugf("[plansecret] %s/%s: rv: %s: Detected failed plan application, reconciling machine PlanApplied condition to error", secret.Namespace, secret.Name, secret.ResourceVersion)
-		err = h.reconcileMachinePlanAppliedCondition(secret, fmt.Errorf("error applying plan -- check rancher-system-agent.service logs on node for more information"))
+		err = h.reconcileMachinePlanAppliedCondition(secret, planHash, fmt.Errorf("error applying plan -- check rancher-system-agent.service logs on node for more information"))
 		return secret, err
 	}
 
 	logrus.Debugf("[plansecret] %s/%s: rv: %s: Reconciling machine PlanApplied condition to nil", secret.Namespace, secret.Name, secret.ResourceVersion)
-	err = h.reconcileMachinePlanAppliedCondition(secret, nil)
+	err = h.reconcileMachinePlanAppliedCondition(secret, planHash, nil)
 	return secret, err
 }
 
-func (h *handler) reconcileMachinePlanAppliedCondition(secret *corev1.Secret, planAppliedErr error) error {
+func (h *handler) reconcileMachinePlanAppliedCondition(secret *corev1.Secret, planHash string, planAppliedErr error) error {
 	if secret == nil {
 		logrus.Debug("[plansecret] secret was nil when reconciling machine status")
 		return nil
@@ -113,6 +140,16 @@ func (h *handler) reconcileMachinePlanAppliedCondition(secret *corev1.Secret, pl
 
 	machine = machine.DeepCopy()
 
+	if machine.Annotations == nil {
+		machine.Annotations = map[string]string{}
+	}
+	if planStatusAnnotations(machine.Annotations, secret, planHash, time.Now()) {
+		logrus.Debugf("[plansecret] machine %s/%s: updating plan status annotations", machine.Namespace, machine.Name)
+		if machine, err = h.machinesClient.Update(machine); err != nil {
+			return err
+		}
+	}
+
 	var needsUpdate bool
 	if planAppliedErr != nil &&
 		(conditions.GetMessage(machine, condition) != planAppliedErr.Error() ||
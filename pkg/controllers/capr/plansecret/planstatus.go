@@ -0,0 +1,39 @@
+package plansecret
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/rancher/rancher/pkg/capr"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// planStatusAnnotations sets the annotations of a machine surfacing the state of its plan secret: the checksum of the
+// plan last applied, when it was applied, and how many failed applications of the desired plan, whose checksum is
+// planHash, are retried. It returns whether the annotations changed.
+func planStatusAnnotations(annotations map[string]string, secret *corev1.Secret, planHash string, now time.Time) bool {
+	appliedChecksum := string(secret.Data["applied-checksum"])
+
+	pendingReapplications := 0
+	if appliedChecksum != planHash && string(secret.Data["failed-checksum"]) == planHash {
+		// The failure count is that of the failed checksum, it's only meaningful for the desired plan.
+		pendingReapplications, _ = strconv.Atoi(string(secret.Data["failure-count"]))
+	}
+
+	changed := false
+	set := func(key, value string) {
+		if annotations[key] != value {
+			annotations[key] = value
+			changed = true
+		}
+	}
+
+	if appliedChecksum != "" {
+		if annotations[capr.AppliedPlanChecksumAnnotation] != appliedChecksum || annotations[capr.PlanAppliedTimeAnnotation] == "" {
+			set(capr.PlanAppliedTimeAnnotation, now.UTC().Format(time.RFC3339))
+		}
+		set(capr.AppliedPlanChecksumAnnotation, appliedChecksum)
+	}
+	set(capr.PlanPendingReapplicationsAnnotation, strconv.Itoa(pendingReapplications))
+	return changed
+}
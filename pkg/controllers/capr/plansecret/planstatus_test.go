@@ -0,0 +1,115 @@
+package plansecret
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPlanStatusAnnotations(t *testing.T) {
+	now := time.Date(2023, 5, 3, 1, 30, 0, 0, time.UTC)
+	earlier := now.Add(-time.Hour).Format(time.RFC3339)
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		data        map[string]string
+		expected    map[string]string
+		changed     bool
+	}{
+		{
+			name: "never applied",
+			data: map[string]string{},
+			expected: map[string]string{
+				capr.PlanPendingReapplicationsAnnotation: "0",
+			},
+			changed: true,
+		},
+		{
+			name: "applied",
+			data: map[string]string{"applied-checksum": "desired"},
+			expected: map[string]string{
+				capr.AppliedPlanChecksumAnnotation:       "desired",
+				capr.PlanAppliedTimeAnnotation:           now.Format(time.RFC3339),
+				capr.PlanPendingReapplicationsAnnotation: "0",
+			},
+			changed: true,
+		},
+		{
+			name: "still applied",
+			annotations: map[string]string{
+				capr.AppliedPlanChecksumAnnotation:       "desired",
+				capr.PlanAppliedTimeAnnotation:           earlier,
+				capr.PlanPendingReapplicationsAnnotation: "0",
+			},
+			data: map[string]string{"applied-checksum": "desired"},
+			expected: map[string]string{
+				capr.AppliedPlanChecksumAnnotation:       "desired",
+				capr.PlanAppliedTimeAnnotation:           earlier,
+				capr.PlanPendingReapplicationsAnnotation: "0",
+			},
+		},
+		{
+			name: "failing",
+			annotations: map[string]string{
+				capr.AppliedPlanChecksumAnnotation:       "previous",
+				capr.PlanAppliedTimeAnnotation:           earlier,
+				capr.PlanPendingReapplicationsAnnotation: "0",
+			},
+			data: map[string]string{"applied-checksum": "previous", "failed-checksum": "desired", "failure-count": "3"},
+			expected: map[string]string{
+				capr.AppliedPlanChecksumAnnotation:       "previous",
+				capr.PlanAppliedTimeAnnotation:           earlier,
+				capr.PlanPendingReapplicationsAnnotation: "3",
+			},
+			changed: true,
+		},
+		{
+			name: "failure of a previous plan",
+			annotations: map[string]string{
+				capr.AppliedPlanChecksumAnnotation:       "previous",
+				capr.PlanAppliedTimeAnnotation:           earlier,
+				capr.PlanPendingReapplicationsAnnotation: "3",
+			},
+			data: map[string]string{"applied-checksum": "previous", "failed-checksum": "other", "failure-count": "3"},
+			expected: map[string]string{
+				capr.AppliedPlanChecksumAnnotation:       "previous",
+				capr.PlanAppliedTimeAnnotation:           earlier,
+				capr.PlanPendingReapplicationsAnnotation: "0",
+			},
+			changed: true,
+		},
+		{
+			name: "applied after failing",
+			annotations: map[string]string{
+				capr.AppliedPlanChecksumAnnotation:       "previous",
+				capr.PlanAppliedTimeAnnotation:           earlier,
+				capr.PlanPendingReapplicationsAnnotation: "3",
+			},
+			data: map[string]string{"applied-checksum": "desired", "failed-checksum": "desired", "failure-count": "3"},
+			expected: map[string]string{
+				capr.AppliedPlanChecksumAnnotation:       "desired",
+				capr.PlanAppliedTimeAnnotation:           now.Format(time.RFC3339),
+				capr.PlanPendingReapplicationsAnnotation: "0",
+			},
+			changed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{Data: map[string][]byte{}}
+			for k, v := range tt.data {
+				secret.Data[k] = []byte(v)
+			}
+			annotations := map[string]string{}
+			for k, v := range tt.annotations {
+				annotations[k] = v
+			}
+			assert.Equal(t, tt.changed, planStatusAnnotations(annotations, secret, "desired", now))
+			assert.Equal(t, tt.expected, annotations)
+		})
+	}
+}
@@ -7,6 +7,7 @@
 	"strings"
 	"time"
 
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
 	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
 	"github.com/rancher/rancher/pkg/capr"
 	"github.com/rancher/rancher/pkg/controllers/dashboard/clusterindex"
@@ -20,7 +21,6 @@
 	"github.com/rancher/wrangler/pkg/apply"
 	"github.com/rancher/wrangler/pkg/data"
 	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
-	"github.com/rancher/wrangler/pkg/kv"
 	corev1 "k8s.io/api/core/v1"
 	apierror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -133,25 +133,56 @@ func (h *handler) onSecretChange(key string, secret *corev1.Secret) (*corev1.Sec
 }
 
 func (h *handler) createMachine(capiCluster *capi.Cluster, secret *corev1.Secret, data data.Object) error {
-	objs, err := h.createMachineObjects(capiCluster, secret.Name, data)
+	cluster, err := h.clusterCache.Get(capiCluster.Namespace, capiCluster.Name)
+	if err != nil {
+		return err
+	}
+
+	var adoption *rancherv1.CustomNodeAdoption
+	if cluster.Spec.RKEConfig != nil {
+		adoption = cluster.Spec.RKEConfig.CustomNodeAdoption
+	}
+
+	objs, err := h.createMachineObjects(capiCluster, secret.Name, data, adoption)
 	if err != nil {
 		return err
 	}
 	return h.apply.WithOwner(secret).ApplyObjects(objs...)
 }
 
-func (h *handler) createMachineObjects(capiCluster *capi.Cluster, machineName string, data data.Object) ([]runtime.Object, error) {
+// createMachineObjects returns the objects of the custom machine of a machine request. A pre-approved custom node is
+// adopted into the first adoption pool it matches, which sets its roles, labels and taints instead of its registration
+// command.
+func (h *handler) createMachineObjects(capiCluster *capi.Cluster, machineName string, data data.Object, adoption *rancherv1.CustomNodeAdoption) ([]runtime.Object, error) {
 	labels := map[string]string{}
 	annotations := map[string]string{}
 
-	if data.Bool("role-control-plane") {
+	labelsMap := capr.ParseNodeLabels(data.String("labels"))
+	etcd, controlPlane, worker := data.Bool("role-etcd"), data.Bool("role-control-plane"), data.Bool("role-worker")
+
+	var coreTaints []corev1.Taint
+	for _, taint := range data.StringSlice("taints") {
+		coreTaints = append(coreTaints, taints.GetTaintsFromStrings(strings.Split(taint, ","))...)
+	}
+
+	fingerprint := capr.CustomNodeFingerprint(data.String("id"), data.String("attested-identity"))
+	if pool := capr.AdoptionPool(adoption, fingerprint, labelsMap); pool != nil {
+		etcd, controlPlane, worker = pool.EtcdRole, pool.ControlPlaneRole, pool.WorkerRole
+		labels[capr.AdoptedPoolLabel] = pool.Name
+		for k, v := range pool.Labels {
+			labelsMap[k] = v
+		}
+		coreTaints = append(coreTaints, pool.Taints...)
+	}
+
+	if controlPlane {
 		labels[capr.ControlPlaneRoleLabel] = "true"
 		labels[capi.MachineControlPlaneLabelName] = "true"
 	}
-	if data.Bool("role-etcd") {
+	if etcd {
 		labels[capr.EtcdRoleLabel] = "true"
 	}
-	if data.Bool("role-worker") {
+	if worker {
 		labels[capr.WorkerRoleLabel] = "true"
 	}
 	if val := data.String("node-name"); val != "" {
@@ -164,17 +195,15 @@ func (h *handler) createMachineObjects(capiCluster *capi.Cluster, machineName st
 		annotations[capr.InternalAddressAnnotation] = internalAddress
 	}
 
+	if identity := data.String("attested-identity"); identity != "" {
+		labels[capr.AttestedIdentityLabel] = identity
+	}
+
 	labels[capr.MachineIDLabel] = data.String("id")
 	labels[capr.ClusterNameLabel] = capiCluster.Name
 	labels[capi.ClusterLabelName] = capiCluster.Name
 
-	labelsMap := map[string]string{}
-	for _, str := range strings.Split(data.String("labels"), ",") {
-		k, v := kv.Split(str, "=")
-		if k == "" {
-			continue
-		}
-		labelsMap[k] = v
+	for k, v := range labelsMap {
 		if _, ok := labels[k]; !ok {
 			labels[k] = v
 		}
@@ -188,11 +217,6 @@ func (h *handler) createMachineObjects(capiCluster *capi.Cluster, machineName st
 		annotations[capr.LabelsAnnotation] = string(data)
 	}
 
-	var coreTaints []corev1.Taint
-	for _, taint := range data.StringSlice("taints") {
-		coreTaints = append(coreTaints, taints.GetTaintsFromStrings(strings.Split(taint, ","))...)
-	}
-
 	if len(coreTaints) > 0 {
 		data, err := json.Marshal(coreTaints)
 		if err != nil {
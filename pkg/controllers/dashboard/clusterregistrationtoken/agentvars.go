@@ -24,14 +24,47 @@ func AgentEnvVars(cluster *v3.Cluster, envType EnvType) string {
 		if envVar.Value == "" {
 			continue
 		}
-		switch envType {
-		case Docker:
-			agentEnvVars = append(agentEnvVars, fmt.Sprintf("-e \"%s=%s\"", envVar.Name, envVar.Value))
-		case PowerShell:
-			agentEnvVars = append(agentEnvVars, fmt.Sprintf("$env:%s=\"%s\";", envVar.Name, envVar.Value))
-		default:
-			agentEnvVars = append(agentEnvVars, fmt.Sprintf("%s=\"%s\"", envVar.Name, envVar.Value))
-		}
+		agentEnvVars = append(agentEnvVars, formatEnvVar(envVar.Name, envVar.Value, envType))
 	}
 	return strings.Join(agentEnvVars, " ")
 }
+
+// ProxyEnvVars returns the environment variables of the proxy of a cluster registration token.
+func ProxyEnvVars(proxy *v3.ClusterRegistrationProxy, envType EnvType) string {
+	var proxyEnvVars []string
+	if proxy == nil {
+		return ""
+	}
+	for _, envVar := range [][2]string{
+		{"HTTP_PROXY", proxy.HTTPProxy},
+		{"HTTPS_PROXY", proxy.HTTPSProxy},
+		{"NO_PROXY", proxy.NoProxy},
+	} {
+		if envVar[1] == "" {
+			continue
+		}
+		proxyEnvVars = append(proxyEnvVars, formatEnvVar(envVar[0], envVar[1], envType))
+	}
+	return strings.Join(proxyEnvVars, " ")
+}
+
+// registrationEnvVars returns the environment variables of the agent of the cluster followed by the ones of the proxy
+// of the registration token, so that the proxy of the token takes precedence.
+func registrationEnvVars(cluster *v3.Cluster, proxy *v3.ClusterRegistrationProxy, envType EnvType) string {
+	agentEnvVars, proxyEnvVars := AgentEnvVars(cluster, envType), ProxyEnvVars(proxy, envType)
+	if agentEnvVars == "" || proxyEnvVars == "" {
+		return agentEnvVars + proxyEnvVars
+	}
+	return agentEnvVars + " " + proxyEnvVars
+}
+
+func formatEnvVar(name, value string, envType EnvType) string {
+	switch envType {
+	case Docker:
+		return fmt.Sprintf("-e \"%s=%s\"", name, value)
+	case PowerShell:
+		return fmt.Sprintf("$env:%s=\"%s\";", name, value)
+	default:
+		return fmt.Sprintf("%s=\"%s\"", name, value)
+	}
+}
@@ -75,3 +75,53 @@ func TestAgentEnvVars(t *testing.T) {
 		})
 	}
 }
+
+func TestProxyEnvVars(t *testing.T) {
+	proxy := &v3.ClusterRegistrationProxy{
+		HTTPSProxy: "http://proxy.example.com:3128",
+		NoProxy:    "127.0.0.1,.svc",
+	}
+	tests := []struct {
+		name     string
+		cluster  *v3.Cluster
+		proxy    *v3.ClusterRegistrationProxy
+		envType  EnvType
+		expected string
+	}{
+		{
+			name:     "Envvars should be an empty string without a proxy",
+			envType:  Linux,
+			expected: "",
+		},
+		{
+			name:     "Envvars should be formatted correctly for Linux",
+			proxy:    proxy,
+			envType:  Linux,
+			expected: "HTTPS_PROXY=\"http://proxy.example.com:3128\" NO_PROXY=\"127.0.0.1,.svc\"",
+		},
+		{
+			name:     "Envvars should be formatted correctly for PowerShell",
+			proxy:    proxy,
+			envType:  PowerShell,
+			expected: "$env:HTTPS_PROXY=\"http://proxy.example.com:3128\"; $env:NO_PROXY=\"127.0.0.1,.svc\";",
+		},
+		{
+			name: "Envvars of the proxy should follow the agent envvars",
+			cluster: &v3.Cluster{
+				Spec: v3.ClusterSpec{
+					ClusterSpecBase: v3.ClusterSpecBase{
+						AgentEnvVars: []corev1.EnvVar{{Name: "HTTPS_PROXY", Value: "http://0.0.0.0"}},
+					},
+				},
+			},
+			proxy:    proxy,
+			envType:  Docker,
+			expected: "-e \"HTTPS_PROXY=http://0.0.0.0\" -e \"HTTPS_PROXY=http://proxy.example.com:3128\" -e \"NO_PROXY=127.0.0.1,.svc\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, registrationEnvVars(tt.cluster, tt.proxy, tt.envType))
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package clusterregistrationtoken
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+)
+
+const (
+	// caCommandFormat adds the CA to the trust store of the Debian, RHEL or SUSE family the node is part of.
+	caCommandFormat        = `echo "%s" | base64 -d | sudo sh -c 'for dir in /usr/local/share/ca-certificates /etc/pki/ca-trust/source/anchors /etc/pki/trust/anchors; do if [ -d "$dir" ]; then cat > "$dir/rancher-registration-ca.crt"; break; fi; done; update-ca-certificates >/dev/null 2>&1 || update-ca-trust'; `
+	windowsCACommandFormat = `[IO.File]::WriteAllBytes("$env:TEMP\rancher-registration-ca.crt", [Convert]::FromBase64String("%s")); Import-Certificate -FilePath "$env:TEMP\rancher-registration-ca.crt" -CertStoreLocation Cert:\LocalMachine\Root | Out-Null; `
+)
+
+// additionalCACommand returns the command adding the additional CA of a cluster registration token to the trust store
+// of the node, run before the node command. It's empty if the token has no additional CA.
+func additionalCACommand(ca string, envType EnvType) (string, error) {
+	if ca == "" {
+		return "", nil
+	}
+	if err := validateCA(ca); err != nil {
+		return "", err
+	}
+	encoded := base64.StdEncoding.EncodeToString([]byte(ca))
+	if envType == PowerShell {
+		return fmt.Sprintf(windowsCACommandFormat, encoded), nil
+	}
+	return fmt.Sprintf(caCommandFormat, encoded), nil
+}
+
+// validateCA returns an error unless the CA is a PEM bundle of certificates.
+func validateCA(ca string) error {
+	rest := []byte(ca)
+	var certs int
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return fmt.Errorf("additional CA contains a %s instead of a certificate", block.Type)
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return fmt.Errorf("additional CA contains an invalid certificate: %w", err)
+		}
+		certs++
+	}
+	if certs == 0 {
+		return fmt.Errorf("additional CA contains no PEM encoded certificate")
+	}
+	return nil
+}
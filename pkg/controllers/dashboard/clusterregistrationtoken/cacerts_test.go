@@ -0,0 +1,56 @@
+package clusterregistrationtoken
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCA(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "proxy-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestAdditionalCACommand(t *testing.T) {
+	ca := testCA(t)
+	encoded := base64.StdEncoding.EncodeToString([]byte(ca))
+
+	command, err := additionalCACommand("", Linux)
+	require.NoError(t, err)
+	assert.Empty(t, command)
+
+	command, err = additionalCACommand(ca, Linux)
+	require.NoError(t, err)
+	assert.Contains(t, command, `echo "`+encoded+`" | base64 -d | sudo sh -c`)
+
+	command, err = additionalCACommand(ca, PowerShell)
+	require.NoError(t, err)
+	assert.Contains(t, command, `[Convert]::FromBase64String("`+encoded+`")`)
+
+	_, err = additionalCACommand("not a certificate", Linux)
+	assert.Error(t, err)
+
+	_, err = additionalCACommand(string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: []byte("key")})), Linux)
+	assert.Error(t, err)
+}
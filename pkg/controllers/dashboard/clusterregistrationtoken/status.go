@@ -25,6 +25,8 @@
 	rke2InsecureWindowsNodeCommandFormat = `%s curl.exe --insecure -fL %s -o install.ps1; Set-ExecutionPolicy Bypass -Scope Process -Force; ./install.ps1 -Server %s -Label 'cattle.io/os=windows' -Token %s -Worker%s`
 	loginCommandFormat                   = "echo \"%s\" | sudo docker login --username %s --password-stdin %s"
 	windowsNodeCommandFormat             = `PowerShell -NoLogo -NonInteractive -Command "& {docker run -v c:\:c:\host %s%s bootstrap --server %s --token %s%s%s | iex}"`
+	validateCommandFormat                = "%s curl -fL %s | %s sh -s -"
+	insecureValidateCommandFormat        = "%s curl --insecure -fL %s | %s sh -s - --insecure"
 )
 
 func (h *handler) isRKE2(clusterID string) bool {
@@ -76,27 +78,36 @@ func (h *handler) assignStatus(crt *v32.ClusterRegistrationToken) (v32.ClusterRe
 		return crt.Status, err
 	}
 
+	linuxEnvVars := registrationEnvVars(cluster, crt.Spec.Proxy, Linux)
+	validateURL := getValidateURL(rootURL, token, clusterID)
+	crtStatus.ValidateCommand = fmt.Sprintf(validateCommandFormat, linuxEnvVars, validateURL, linuxEnvVars)
+	crtStatus.InsecureValidateCommand = fmt.Sprintf(insecureValidateCommandFormat, linuxEnvVars, validateURL, linuxEnvVars)
+
 	agentImage := image.ResolveWithCluster(settings.AgentImage.Get(), cluster)
 	if h.isRKE2(clusterID) {
+		linuxCA, err := additionalCACommand(crt.Spec.AdditionalCA, Linux)
+		if err != nil {
+			return crt.Status, fmt.Errorf("invalid cluster registration token %s/%s: %w", crt.Namespace, crt.Name, err)
+		}
 		// for linux
-		crtStatus.NodeCommand = fmt.Sprintf(rke2NodeCommandFormat,
-			AgentEnvVars(cluster, Linux),
+		crtStatus.NodeCommand = linuxCA + fmt.Sprintf(rke2NodeCommandFormat,
+			linuxEnvVars,
 			rootURL+installer.SystemAgentInstallPath,
-			AgentEnvVars(cluster, Linux),
+			linuxEnvVars,
 			rootURL,
 			token,
 			ca)
-		crtStatus.InsecureNodeCommand = fmt.Sprintf(rke2InsecureNodeCommandFormat,
-			AgentEnvVars(cluster, Linux),
+		crtStatus.InsecureNodeCommand = linuxCA + fmt.Sprintf(rke2InsecureNodeCommandFormat,
+			linuxEnvVars,
 			rootURL+installer.SystemAgentInstallPath,
-			AgentEnvVars(cluster, Linux),
+			linuxEnvVars,
 			rootURL,
 			token,
 			ca)
 	} else {
 		// for linux
 		crtStatus.NodeCommand = fmt.Sprintf(nodeCommandFormat,
-			AgentEnvVars(cluster, Docker),
+			registrationEnvVars(cluster, crt.Spec.Proxy, Docker),
 			agentImage,
 			rootURL,
 			token,
@@ -104,14 +115,19 @@ func (h *handler) assignStatus(crt *v32.ClusterRegistrationToken) (v32.ClusterRe
 	}
 	// for windows
 	if h.isRKE2(clusterID) {
-		crtStatus.WindowsNodeCommand = fmt.Sprintf(rke2WindowsNodeCommandFormat,
-			AgentEnvVars(cluster, PowerShell),
+		windowsCA, err := additionalCACommand(crt.Spec.AdditionalCA, PowerShell)
+		if err != nil {
+			return crt.Status, fmt.Errorf("invalid cluster registration token %s/%s: %w", crt.Namespace, crt.Name, err)
+		}
+		windowsEnvVars := registrationEnvVars(cluster, crt.Spec.Proxy, PowerShell)
+		crtStatus.WindowsNodeCommand = windowsCA + fmt.Sprintf(rke2WindowsNodeCommandFormat,
+			windowsEnvVars,
 			rootURL+installer.WindowsRke2InstallPath,
 			rootURL,
 			token,
 			caWindows)
-		crtStatus.InsecureWindowsNodeCommand = fmt.Sprintf(rke2InsecureWindowsNodeCommandFormat,
-			AgentEnvVars(cluster, PowerShell),
+		crtStatus.InsecureWindowsNodeCommand = windowsCA + fmt.Sprintf(rke2InsecureWindowsNodeCommandFormat,
+			windowsEnvVars,
 			rootURL+installer.WindowsRke2InstallPath,
 			rootURL,
 			token,
@@ -241,3 +257,8 @@ func getURL(token, clusterID string) (string, error) {
 	serverURL = u.String()
 	return serverURL, nil
 }
+
+// getValidateURL returns the URL of the script validating a node can be registered with the token.
+func getValidateURL(rootURL, token, clusterID string) string {
+	return rootURL + "/v3/import/" + token + "_" + clusterID + "/validate.sh"
+}
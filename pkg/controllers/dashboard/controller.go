@@ -3,6 +3,7 @@
 import (
 	"context"
 
+	"github.com/rancher/rancher/pkg/admission"
 	"github.com/rancher/rancher/pkg/controllers/capi"
 	"github.com/rancher/rancher/pkg/controllers/capr"
 	"github.com/rancher/rancher/pkg/controllers/dashboard/apiservice"
@@ -67,6 +68,7 @@ func Register(ctx context.Context, wrangler *wrangler.Context, embedded bool, re
 		kubeconfigManager := kubeconfig.New(wrangler)
 		clusterindex.Register(ctx, wrangler)
 		provisioningv2.Register(ctx, wrangler, kubeconfigManager)
+		admission.Register(ctx, wrangler)
 		if features.RKE2.Enabled() {
 			capr.Register(ctx, wrangler, kubeconfigManager)
 		}
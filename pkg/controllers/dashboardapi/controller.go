@@ -5,12 +5,14 @@
 
 	"github.com/rancher/rancher/pkg/controllers/dashboard/helm"
 	"github.com/rancher/rancher/pkg/controllers/dashboardapi/feature"
+	"github.com/rancher/rancher/pkg/controllers/dashboardapi/logging"
 	"github.com/rancher/rancher/pkg/controllers/dashboardapi/settings"
 	"github.com/rancher/rancher/pkg/wrangler"
 )
 
 func Register(ctx context.Context, wrangler *wrangler.Context) error {
 	feature.Register(ctx, wrangler.Mgmt.Feature())
+	logging.Register(ctx, wrangler.Mgmt.Setting())
 	helm.RegisterReposForFollowers(ctx, wrangler.Core.Secret().Cache(), wrangler.Catalog.ClusterRepo())
 	return settings.Register(wrangler.Mgmt.Setting())
 }
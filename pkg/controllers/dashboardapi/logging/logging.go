@@ -0,0 +1,46 @@
+// Package logging applies the log-levels and log-format settings on every Rancher replica as they change.
+package logging
+
+import (
+	"context"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	managementv3 "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/logging"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/sirupsen/logrus"
+)
+
+func Register(ctx context.Context, settingController managementv3.SettingController) {
+	settingController.OnChange(ctx, "logging-settings", sync)
+}
+
+func sync(_ string, obj *v3.Setting) (*v3.Setting, error) {
+	if obj == nil || obj.DeletionTimestamp != nil {
+		return obj, nil
+	}
+
+	switch obj.Name {
+	case settings.LogLevels.Name:
+		levels, err := logging.ParseLevels(value(obj))
+		if err != nil {
+			// An invalid value is not retried as it won't be valid until the setting is changed.
+			logrus.Errorf("failed to apply setting %s: %v", obj.Name, err)
+			return obj, nil
+		}
+		logging.SetLevels(levels)
+	case settings.LogFormat.Name:
+		if err := logging.SetFormat(value(obj)); err != nil {
+			logrus.Errorf("failed to apply setting %s: %v", obj.Name, err)
+		}
+	}
+	return obj, nil
+}
+
+// value returns the effective value of a setting.
+func value(obj *v3.Setting) string {
+	if obj.Value == "" {
+		return obj.Default
+	}
+	return obj.Value
+}
@@ -48,6 +48,7 @@
 	"clusterregistrationtokens":   "management.cattle.io",
 	"clusterroletemplatebindings": "management.cattle.io",
 	"etcdbackups":                 "management.cattle.io",
+	"namespacetemplates":          "management.cattle.io",
 	"nodes":                       "management.cattle.io",
 	"nodepools":                   "management.cattle.io",
 	"notifiers":                   "management.cattle.io",
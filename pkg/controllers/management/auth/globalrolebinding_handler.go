@@ -7,11 +7,14 @@
 	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
 	"github.com/rancher/norman/httperror"
+	"github.com/rancher/norman/types/slice"
 	"github.com/rancher/rancher/pkg/clustermanager"
+	"github.com/rancher/rancher/pkg/clusterqueue"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
 	rbacv1 "github.com/rancher/rancher/pkg/generated/norman/rbac.authorization.k8s.io/v1"
 	"github.com/rancher/rancher/pkg/namespace"
 	"github.com/rancher/rancher/pkg/rbac"
+	"github.com/rancher/rancher/pkg/securityevents"
 	"github.com/rancher/rancher/pkg/types/config"
 	"github.com/sirupsen/logrus"
 	v1 "k8s.io/api/rbac/v1"
@@ -74,6 +77,9 @@ type globalRoleBindingLifecycle struct {
 
 func (grb *globalRoleBindingLifecycle) Create(obj *v3.GlobalRoleBinding) (runtime.Object, error) {
 	err := grb.reconcileGlobalRoleBinding(obj)
+	if err == nil && grb.grantsAdmin(obj) {
+		emitRoleEscalation(obj)
+	}
 	return obj, err
 }
 
@@ -90,6 +96,38 @@ func (grb *globalRoleBindingLifecycle) Remove(obj *v3.GlobalRoleBinding) (runtim
 	return obj, nil
 }
 
+// grantsAdmin returns true if the binding grants an admin global role, or a global role allowing every verb on every
+// resource.
+func (grb *globalRoleBindingLifecycle) grantsAdmin(obj *v3.GlobalRoleBinding) bool {
+	if obj.GlobalRoleName == rbac.GlobalAdmin || obj.GlobalRoleName == rbac.GlobalRestrictedAdmin {
+		return true
+	}
+	gr, err := grb.grLister.Get("", obj.GlobalRoleName)
+	if err != nil {
+		return false
+	}
+	for _, rule := range gr.Rules {
+		if slice.ContainsString(rule.Verbs, v1.VerbAll) && slice.ContainsString(rule.Resources, v1.ResourceAll) {
+			return true
+		}
+	}
+	return false
+}
+
+func emitRoleEscalation(obj *v3.GlobalRoleBinding) {
+	event := securityevents.NewEvent(securityevents.RoleEscalation, securityevents.OutcomeSuccess, nil)
+	event.User = obj.UserName
+	if event.User == "" {
+		event.User = obj.GroupPrincipalName
+	}
+	event.Resource = "globalRoleBindings/" + obj.Name
+	event.Details = map[string]string{"globalRole": obj.GlobalRoleName}
+	if creator := obj.Annotations[creatorIDAnn]; creator != "" {
+		event.Details["creator"] = creator
+	}
+	securityevents.Emit(event)
+}
+
 func (grb *globalRoleBindingLifecycle) deleteAdminBinding(obj *v3.GlobalRoleBinding) error {
 	// Explicit API call to ensure we have the most recent cluster info when deleting admin bindings
 	clusters, err := grb.clusters.List(metav1.ListOptions{})
@@ -100,17 +138,23 @@ func (grb *globalRoleBindingLifecycle) deleteAdminBinding(obj *v3.GlobalRoleBind
 	// Collect all the errors to delete as many user context bindings as possible
 	var allErrors []error
 
+	bindingName := rbac.GrbCRBName(obj)
 	for _, cluster := range clusters.Items {
 		userContext, err := grb.clusterManager.UserContext(cluster.Name)
 		if err != nil {
-			// ClusterUnavailable error indicates the record can't talk to the downstream cluster
-			if !IsClusterUnavailable(err) {
+			// ClusterUnavailable error indicates the record can't talk to the downstream cluster, the binding is deleted
+			// once its agent reconnects
+			if IsClusterUnavailable(err) {
+				clusterName := cluster.Name
+				clusterqueue.Add(clusterName, "clusterrolebinding/"+bindingName, func() error {
+					return grb.deleteClusterAdminBinding(clusterName, bindingName)
+				})
+			} else {
 				allErrors = append(allErrors, err)
 			}
 			continue
 		}
 
-		bindingName := rbac.GrbCRBName(obj)
 		b, err := userContext.RBAC.ClusterRoleBindings("").Controller().Lister().Get("", bindingName)
 		if err != nil {
 			// User context clusterRoleBinding doesn't exist
@@ -137,6 +181,20 @@ func (grb *globalRoleBindingLifecycle) deleteAdminBinding(obj *v3.GlobalRoleBind
 	return nil
 }
 
+// deleteClusterAdminBinding deletes the admin binding of a cluster whose agent was disconnected when the global role
+// binding was removed. The API is used instead of the cache, which may not be synced yet after the agent reconnects.
+func (grb *globalRoleBindingLifecycle) deleteClusterAdminBinding(clusterName, bindingName string) error {
+	userContext, err := grb.clusterManager.UserContext(clusterName)
+	if err != nil {
+		return err
+	}
+	err = userContext.RBAC.ClusterRoleBindings("").Delete(bindingName, &metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
 func (grb *globalRoleBindingLifecycle) reconcileGlobalRoleBinding(globalRoleBinding *v3.GlobalRoleBinding) error {
 	crbName, ok := globalRoleBinding.Annotations[crbNameAnnotation]
 	if !ok {
@@ -41,6 +41,7 @@
 	"clustercatalogs":         "management.cattle.io",
 	"catalogtemplates":        "management.cattle.io",
 	"catalogtemplateversions": "management.cattle.io",
+	"namespacetemplates":      "management.cattle.io",
 }
 
 type prtbLifecycle struct {
@@ -68,6 +68,7 @@ func RegisterEarly(ctx context.Context, management *config.ManagementContext, cl
 	rt := newRoleTemplateLifecycle(management, clusterManager)
 	grbLegacy := newLegacyGRBCleaner(management)
 	rtLegacy := newLegacyRTCleaner(management)
+	rta := newRoleTemplateAggregator(management)
 
 	management.Management.ClusterRoleTemplateBindings("").AddLifecycle(ctx, ctrbMGMTController, crtb)
 	management.Management.ProjectRoleTemplateBindings("").AddLifecycle(ctx, ptrbMGMTController, prtb)
@@ -84,6 +85,7 @@ func RegisterEarly(ctx context.Context, management *config.ManagementContext, cl
 	management.Management.Settings("").AddHandler(ctx, authSettingController, s.sync)
 	management.Management.GlobalRoleBindings("").AddHandler(ctx, "legacy-grb-cleaner", grbLegacy.sync)
 	management.Management.RoleTemplates("").AddHandler(ctx, "legacy-rt-cleaner", rtLegacy.sync)
+	management.Management.RoleTemplates("").AddHandler(ctx, roleTemplateAggregationController, rta.sync)
 }
 
 func RegisterLate(ctx context.Context, management *config.ManagementContext) {
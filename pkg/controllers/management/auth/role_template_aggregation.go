@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"reflect"
+
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/rbac"
+	"github.com/rancher/rancher/pkg/types/config"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const roleTemplateAggregationController = "mgmt-auth-roletemplate-aggregation"
+
+type roleTemplateAggregator struct {
+	roleTemplates      v3.RoleTemplateInterface
+	roleTemplateLister v3.RoleTemplateLister
+}
+
+func newRoleTemplateAggregator(management *config.ManagementContext) *roleTemplateAggregator {
+	return &roleTemplateAggregator{
+		roleTemplates:      management.Management.RoleTemplates(""),
+		roleTemplateLister: management.Management.RoleTemplates("").Controller().Lister(),
+	}
+}
+
+// sync sets the rules of aggregated role templates from the role templates selected by their aggregation rule. As
+// any role template can be selected, or inherited by a selected role template, every aggregated role template is
+// enqueued when another role template changes.
+func (r *roleTemplateAggregator) sync(key string, obj *v3.RoleTemplate) (runtime.Object, error) {
+	roleTemplates, err := r.roleTemplateLister.List("", labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	if obj == nil || obj.DeletionTimestamp != nil || obj.AggregationRule == nil {
+		r.enqueueAggregated(key, roleTemplates)
+		return obj, nil
+	}
+
+	rules, err := rbac.AggregatedRules(obj, roleTemplates)
+	if err != nil {
+		return obj, err
+	}
+	if reflect.DeepEqual(rules, obj.Rules) || (len(rules) == 0 && len(obj.Rules) == 0) {
+		return obj, nil
+	}
+
+	obj = obj.DeepCopy()
+	obj.Rules = rules
+	obj, err = r.roleTemplates.Update(obj)
+	if err != nil {
+		return obj, err
+	}
+	// aggregated role templates can inherit each other, so their rules are only re-aggregated when they change to
+	// not enqueue each other forever
+	r.enqueueAggregated(key, roleTemplates)
+	return obj, nil
+}
+
+func (r *roleTemplateAggregator) enqueueAggregated(key string, roleTemplates []*v3.RoleTemplate) {
+	for _, rt := range roleTemplates {
+		if rt.AggregationRule != nil && rt.Name != key {
+			r.roleTemplates.Controller().Enqueue("", rt.Name)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package canary
+
+import (
+	"context"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/canary"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/sirupsen/logrus"
+)
+
+type handler struct {
+	clusters mgmtcontrollers.ClusterClient
+}
+
+// Register registers the canary health check controller, which marks the rollouts applied to a canary cluster as
+// failed if the cluster isn't ready during their soak period, halting them for the rest of the clusters. A halted
+// rollout resumes once a new revision is rolled out, or the rollout is removed from the canary rollouts annotation of
+// the canary.
+func Register(ctx context.Context, management *config.ManagementContext) {
+	h := &handler{
+		clusters: management.Wrangler.Mgmt.Cluster(),
+	}
+	management.Wrangler.Mgmt.Cluster().OnChange(ctx, "canary-health", h.OnChange)
+}
+
+func (h *handler) OnChange(_ string, cluster *v3.Cluster) (*v3.Cluster, error) {
+	if cluster == nil || cluster.DeletionTimestamp != nil || !canary.IsCanary(cluster) {
+		return cluster, nil
+	}
+
+	updated := cluster.DeepCopy()
+	changed, err := canary.CheckHealth(updated, canary.SoakPeriod(settings.CanarySoakPeriod.Get()), time.Now())
+	if err != nil {
+		logrus.Errorf("[canary] cluster %s: %v", cluster.Name, err)
+		return cluster, nil
+	}
+	if !changed {
+		return cluster, nil
+	}
+	logrus.Warnf("[canary] cluster %s is not ready, halting the rollouts soaking on it", cluster.Name)
+	return h.clusters.Update(updated)
+}
@@ -0,0 +1,174 @@
+package clusterarchive
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/clusterarchive"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/namespace"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/types/config"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/rancher/wrangler/pkg/ticker"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	provisioningLogName = "provisioning-log"
+	syncInterval        = time.Hour
+	logPrefix           = "[cluster-archive]"
+)
+
+type handler struct {
+	archives     mgmtcontrollers.ClusterArchiveController
+	bindingCache mgmtcontrollers.ClusterRoleTemplateBindingCache
+	projectCache mgmtcontrollers.ProjectCache
+	configMaps   corecontrollers.ConfigMapClient
+}
+
+// Register registers the controllers archiving the clusters being removed, reverting changes to the archives from the
+// configmaps they're kept in, and restoring the deleted archives and deleting the expired ones periodically.
+func Register(ctx context.Context, management *config.ManagementContext) {
+	mgmt := management.Wrangler.Mgmt
+	h := &handler{
+		archives:     mgmt.ClusterArchive(),
+		bindingCache: mgmt.ClusterRoleTemplateBinding().Cache(),
+		projectCache: mgmt.Project().Cache(),
+		configMaps:   management.Wrangler.Core.ConfigMap(),
+	}
+	mgmt.Cluster().OnRemove(ctx, "cluster-archive", h.onClusterRemove)
+	h.archives.OnChange(ctx, "cluster-archive-guard", h.onArchiveChange)
+
+	go func() {
+		for range ticker.Context(ctx, syncInterval) {
+			if err := h.sync(time.Now()); err != nil {
+				logrus.Errorf("%s failed to restore or delete cluster archives: %v", logPrefix, err)
+			}
+		}
+	}()
+}
+
+// onClusterRemove archives a cluster being removed, the cluster is only removed once it's archived.
+func (h *handler) onClusterRemove(_ string, cluster *v3.Cluster) (*v3.Cluster, error) {
+	if cluster.Spec.Internal || !clusterarchive.Enabled(cluster, settings.ClusterArchiveEnabled.Get()) {
+		return cluster, nil
+	}
+	name := clusterarchive.Name(cluster)
+	if _, err := h.configMaps.Get(namespace.GlobalNamespace, clusterarchive.ConfigMapName(name), metav1.GetOptions{}); err == nil {
+		return cluster, nil
+	} else if !apierrors.IsNotFound(err) {
+		return cluster, err
+	}
+
+	bindings, err := h.bindingCache.List(cluster.Name, labels.Everything())
+	if err != nil {
+		return cluster, err
+	}
+	projects, err := h.projectCache.List(cluster.Name, labels.Everything())
+	if err != nil {
+		return cluster, err
+	}
+	var provisioningLog string
+	if configMap, err := h.configMaps.Get(cluster.Name, provisioningLogName, metav1.GetOptions{}); err == nil {
+		provisioningLog = configMap.Data["log"]
+	} else if !apierrors.IsNotFound(err) {
+		return cluster, err
+	}
+
+	archive, err := clusterarchive.Build(cluster, bindings, projects, provisioningLog, time.Now())
+	if err != nil {
+		return cluster, err
+	}
+	if err := h.keep(archive); err != nil {
+		return cluster, err
+	}
+	logrus.Infof("%s archived cluster [%s] as %s", logPrefix, cluster.Name, archive.Name)
+	return cluster, nil
+}
+
+// keep creates the configmap an archive is kept in, then the archive.
+func (h *handler) keep(archive *v3.ClusterArchive) error {
+	configMap, err := clusterarchive.ConfigMap(archive)
+	if err != nil {
+		return err
+	}
+	if _, err := h.configMaps.Create(configMap); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to keep cluster archive %s: %w", archive.Name, err)
+	}
+	if _, err := h.archives.Create(archive); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// onArchiveChange reverts the changes to the spec of an archive from the configmap it's kept in. The configmap of an
+// archive created directly is created from it.
+func (h *handler) onArchiveChange(_ string, archive *v3.ClusterArchive) (*v3.ClusterArchive, error) {
+	if archive == nil || archive.DeletionTimestamp != nil {
+		return archive, nil
+	}
+	configMap, err := h.configMaps.Get(namespace.GlobalNamespace, clusterarchive.ConfigMapName(archive.Name), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return archive, h.keep(archive)
+	} else if err != nil {
+		return archive, err
+	}
+	kept, err := clusterarchive.FromConfigMap(configMap)
+	if err != nil {
+		return archive, err
+	}
+	if reflect.DeepEqual(kept.Spec, archive.Spec) {
+		return archive, nil
+	}
+	logrus.Warnf("%s reverting changes to cluster archive %s", logPrefix, archive.Name)
+	archive = archive.DeepCopy()
+	archive.Spec = kept.Spec
+	return h.archives.Update(archive)
+}
+
+// sync restores the archives deleted before they're past the retention from the configmaps they're kept in, and
+// deletes the archives past the retention with their configmap.
+func (h *handler) sync(now time.Time) error {
+	configMaps, err := h.configMaps.List(namespace.GlobalNamespace, metav1.ListOptions{
+		LabelSelector: clusterarchive.ConfigMapLabel,
+	})
+	if err != nil {
+		return err
+	}
+
+	retention := settings.ClusterArchiveRetentionDays.GetInt()
+	for i := range configMaps.Items {
+		configMap := &configMaps.Items[i]
+		archive, err := clusterarchive.FromConfigMap(configMap)
+		if err != nil {
+			logrus.Errorf("%s %v", logPrefix, err)
+			continue
+		}
+		if clusterarchive.Expired(archive.Spec, now, retention) {
+			logrus.Infof("%s deleting cluster archive %s past the retention", logPrefix, archive.Name)
+			if err := h.archives.Delete(archive.Name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+			if err := h.configMaps.Delete(configMap.Namespace, configMap.Name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+			continue
+		}
+		if _, err := h.archives.Cache().Get(archive.Name); apierrors.IsNotFound(err) {
+			logrus.Warnf("%s restoring deleted cluster archive %s", logPrefix, archive.Name)
+			if _, err := h.archives.Create(archive); err != nil && !apierrors.IsAlreadyExists(err) {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+	}
+	return nil
+}
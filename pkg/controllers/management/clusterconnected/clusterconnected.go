@@ -9,7 +9,9 @@
 
 	"github.com/rancher/rancher/pkg/api/steve/proxy"
 	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/clusterqueue"
 	managementcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/settings"
 	"github.com/rancher/rancher/pkg/wrangler"
 	"github.com/rancher/remotedialer"
 	"github.com/rancher/wrangler/pkg/condition"
@@ -26,9 +28,11 @@
 
 func Register(ctx context.Context, wrangler *wrangler.Context) {
 	c := checker{
-		clusterCache: wrangler.Mgmt.Cluster().Cache(),
-		clusters:     wrangler.Mgmt.Cluster(),
-		tunnelServer: wrangler.TunnelServer,
+		clusterCache:      wrangler.Mgmt.Cluster().Cache(),
+		clusters:          wrangler.Mgmt.Cluster(),
+		tunnelServer:      wrangler.TunnelServer,
+		disconnectedSince: map[string]time.Time{},
+		now:               time.Now,
 	}
 
 	go func() {
@@ -44,6 +48,10 @@ type checker struct {
 	clusterCache managementcontrollers.ClusterCache
 	clusters     managementcontrollers.ClusterClient
 	tunnelServer *remotedialer.Server
+	// disconnectedSince is when the session of a cluster was first found missing, it is only accessed by the check
+	// loop.
+	disconnectedSince map[string]time.Time
+	now               func() time.Time
 }
 
 func (c *checker) check() error {
@@ -52,14 +60,37 @@ func (c *checker) check() error {
 		return err
 	}
 
+	names := map[string]bool{}
 	for _, cluster := range clusters {
+		names[cluster.Name] = true
+		if cluster.DeletionTimestamp != nil {
+			clusterqueue.Remove(cluster.Name)
+		}
 		if err := c.checkCluster(cluster); err != nil {
 			logrus.Errorf("failed to check connectivity of cluster [%s]: %v", cluster.Name, err)
 		}
 	}
+	for name := range c.disconnectedSince {
+		if !names[name] {
+			delete(c.disconnectedSince, name)
+		}
+	}
 	return nil
 }
 
+// tolerateDisconnect returns true if the session of the cluster has been missing for less than the disconnect
+// tolerance, in which case the cluster isn't reported as disconnected yet since its agent may reconnect.
+func (c *checker) tolerateDisconnect(clusterName string) bool {
+	now := c.now()
+	since, ok := c.disconnectedSince[clusterName]
+	if !ok {
+		since = now
+		c.disconnectedSince[clusterName] = since
+	}
+	tolerance := time.Duration(settings.ClusterAgentDisconnectToleranceSeconds.GetInt()) * time.Second
+	return now.Sub(since) < tolerance
+}
+
 func (c *checker) hasSession(cluster *v3.Cluster) bool {
 	clientKey := proxy.Prefix + cluster.Name
 	hasSession := c.tunnelServer.HasSession(clientKey)
@@ -95,6 +126,14 @@ func (c *checker) checkCluster(cluster *v3.Cluster) error {
 	}
 
 	hasSession := c.hasSession(cluster)
+	if hasSession {
+		delete(c.disconnectedSince, cluster.Name)
+		// run the actions queued while the agent was disconnected
+		go clusterqueue.Run(cluster.Name)
+	} else if Connected.IsTrue(cluster) && c.tolerateDisconnect(cluster.Name) {
+		return nil
+	}
+
 	// The simpler condition of hasSession == Connected.IsTrue(cluster) is not
 	// used because it treats a non-existent conditions as False
 	if hasSession && Connected.IsTrue(cluster) {
@@ -0,0 +1,34 @@
+package clusterconnected
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTolerateDisconnect(t *testing.T) {
+	require.NoError(t, settings.ClusterAgentDisconnectToleranceSeconds.Set("45"))
+	defer settings.ClusterAgentDisconnectToleranceSeconds.Set(settings.ClusterAgentDisconnectToleranceSeconds.Default)
+
+	now := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	c := checker{
+		disconnectedSince: map[string]time.Time{},
+		now: func() time.Time {
+			return now
+		},
+	}
+
+	assert.True(t, c.tolerateDisconnect("c-1"))
+	now = now.Add(30 * time.Second)
+	assert.True(t, c.tolerateDisconnect("c-1"))
+	assert.True(t, c.tolerateDisconnect("c-2"))
+	now = now.Add(15 * time.Second)
+	assert.False(t, c.tolerateDisconnect("c-1"))
+	assert.True(t, c.tolerateDisconnect("c-2"))
+
+	require.NoError(t, settings.ClusterAgentDisconnectToleranceSeconds.Set("0"))
+	assert.False(t, c.tolerateDisconnect("c-3"))
+}
@@ -14,6 +14,7 @@
 	"github.com/rancher/norman/types"
 	apimgmtv3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
 	"github.com/rancher/rancher/pkg/auth/tokens"
+	"github.com/rancher/rancher/pkg/canary"
 	util "github.com/rancher/rancher/pkg/cluster"
 	"github.com/rancher/rancher/pkg/clustermanager"
 	"github.com/rancher/rancher/pkg/features"
@@ -63,6 +64,7 @@ func Register(ctx context.Context, management *config.ManagementContext, cluster
 		systemAccountManager: systemaccount.NewManager(management),
 		userManager:          management.UserManager,
 		clusters:             management.Management.Clusters(""),
+		clusterLister:        management.Management.Clusters("").Controller().Lister(),
 		nodeLister:           management.Management.Nodes("").Controller().Lister(),
 		clusterManager:       clusterManager,
 		secretLister:         management.Core.Secrets("").Controller().Lister(),
@@ -75,6 +77,7 @@ type clusterDeploy struct {
 	systemAccountManager *systemaccount.Manager
 	userManager          user.Manager
 	clusters             v3.ClusterInterface
+	clusterLister        v3.ClusterLister
 	clusterManager       *clustermanager.Manager
 	mgmt                 *config.ManagementContext
 	nodeLister           v3.NodeLister
@@ -162,6 +165,13 @@ func (cd *clusterDeploy) doSync(cluster *apimgmtv3.Cluster) error {
 		return err
 	}
 
+	if cluster.Status.AgentImage != "" {
+		// canaries record the agent image they run, for the clusters held until it soaked on them
+		if _, err := canary.SetApplied(cluster, canary.AgentOperation, cluster.Status.AgentImage, time.Now()); err != nil {
+			return err
+		}
+	}
+
 	return cd.setNetworkPolicyAnn(cluster)
 }
 
@@ -290,6 +300,12 @@ func (cd *clusterDeploy) deployAgent(cluster *apimgmtv3.Cluster) error {
 		return nil
 	}
 
+	if cluster.Status.AgentImage != "" && cluster.Status.AgentImage != desiredAgent {
+		if proceed, err := cd.canaryGate(cluster, desiredAgent); err != nil || !proceed {
+			return err
+		}
+	}
+
 	kubeConfig, tokenName, err := cd.getKubeConfig(cluster)
 	if err != nil {
 		return err
@@ -392,6 +408,22 @@ func (cd *clusterDeploy) deployAgent(cluster *apimgmtv3.Cluster) error {
 	return nil
 }
 
+// canaryGate returns whether the agent image may be rolled out to the cluster, the cluster being enqueued again if it's
+// held until the image soaked on the canary clusters.
+func (cd *clusterDeploy) canaryGate(cluster *apimgmtv3.Cluster, desiredAgent string) (bool, error) {
+	clusters, err := cd.clusterLister.List("", labels.Everything())
+	if err != nil {
+		return false, err
+	}
+	decision := canary.Gate(cluster, clusters, canary.AgentOperation, desiredAgent, canary.SoakPeriod(settings.CanarySoakPeriod.Get()), time.Now())
+	if decision.Proceed {
+		return true, nil
+	}
+	logrus.Infof("clusterDeploy: deployAgent: holding agent image [%s] for cluster [%s]: %s", desiredAgent, cluster.Name, decision.Message)
+	cd.clusters.Controller().EnqueueAfter("", cluster.Name, decision.RetryAfter)
+	return false, nil
+}
+
 func (cd *clusterDeploy) setNetworkPolicyAnn(cluster *apimgmtv3.Cluster) error {
 	if cluster.Spec.EnableNetworkPolicy != nil {
 		return nil
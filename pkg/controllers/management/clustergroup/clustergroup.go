@@ -0,0 +1,343 @@
+package clustergroup
+
+import (
+	"context"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/canary"
+	"github.com/rancher/rancher/pkg/controllers/dashboard/clusterindex"
+	"github.com/rancher/rancher/pkg/features"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	provisioningcontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/rancher/wrangler/pkg/condition"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// PSACTAnnotation is the annotation of the clusters whose pod security admission configuration template is set
+	// by a cluster group, set to its name.
+	PSACTAnnotation = "management.cattle.io/cluster-group-psact"
+
+	clusterGroupRoleTemplateBindingAnnotation = "management.cattle.io/cluster-group-role-template-binding"
+
+	clusterGroupByCluster                  = "clusterGroupByCluster"
+	clusterGroupByChild                    = "clusterGroupByChild"
+	clusterGroupRoleTemplateBindingByGroup = "clusterGroupRoleTemplateBindingByGroup"
+)
+
+var (
+	resolved condition.Cond = "Resolved"
+	bound    condition.Cond = "Bound"
+)
+
+type handler struct {
+	clusterGroups     mgmtcontrollers.ClusterGroupController
+	clusterGroupCache mgmtcontrollers.ClusterGroupCache
+	cgrtbCache        mgmtcontrollers.ClusterGroupRoleTemplateBindingCache
+	clusters          mgmtcontrollers.ClusterController
+	clusterCache      mgmtcontrollers.ClusterCache
+	provClusters      provisioningcontrollers.ClusterClient
+	provClusterCache  provisioningcontrollers.ClusterCache
+}
+
+// Register registers the cluster group controllers, which resolve the clusters of cluster groups, bind role templates
+// on the clusters of cluster groups, and set the default pod security admission configuration templates of cluster
+// groups on their clusters.
+func Register(ctx context.Context, management *config.ManagementContext) {
+	mgmt := management.Wrangler.Mgmt
+	h := &handler{
+		clusterGroups:     mgmt.ClusterGroup(),
+		clusterGroupCache: mgmt.ClusterGroup().Cache(),
+		cgrtbCache:        mgmt.ClusterGroupRoleTemplateBinding().Cache(),
+		clusters:          mgmt.Cluster(),
+		clusterCache:      mgmt.Cluster().Cache(),
+		provClusters:      management.Wrangler.Provisioning.Cluster(),
+		provClusterCache:  management.Wrangler.Provisioning.Cluster().Cache(),
+	}
+
+	h.clusterGroupCache.AddIndexer(clusterGroupByCluster, func(obj *v3.ClusterGroup) ([]string, error) {
+		return obj.Status.Clusters, nil
+	})
+	h.clusterGroupCache.AddIndexer(clusterGroupByChild, func(obj *v3.ClusterGroup) ([]string, error) {
+		return obj.Spec.ClusterGroupNames, nil
+	})
+	h.cgrtbCache.AddIndexer(clusterGroupRoleTemplateBindingByGroup, func(obj *v3.ClusterGroupRoleTemplateBinding) ([]string, error) {
+		return []string{obj.ClusterGroupName}, nil
+	})
+
+	relatedresource.Watch(ctx, "cluster-group-trigger", h.resolveClusterGroups, h.clusterGroups, mgmt.Cluster(), mgmt.ClusterGroup())
+	mgmtcontrollers.RegisterClusterGroupStatusHandler(ctx, h.clusterGroups, resolved, "cluster-group", h.sync)
+
+	relatedresource.Watch(ctx, "cluster-group-rtb-trigger", h.resolveBindings, mgmt.ClusterGroupRoleTemplateBinding(), mgmt.ClusterGroup())
+	mgmtcontrollers.RegisterClusterGroupRoleTemplateBindingGeneratingHandler(ctx,
+		mgmt.ClusterGroupRoleTemplateBinding(),
+		management.Wrangler.Apply.
+			WithSetOwnerReference(true, true).
+			WithCacheTypes(mgmt.ClusterRoleTemplateBinding()),
+		bound,
+		"cluster-group-rtb",
+		h.bind,
+		nil)
+
+	relatedresource.Watch(ctx, "cluster-group-psact-trigger", h.resolvePSACTClusters, h.clusters, mgmt.ClusterGroup())
+	h.clusters.OnChange(ctx, "cluster-group-psact", h.OnClusterChange)
+}
+
+// resolveClusterGroups enqueues the cluster groups a cluster may be added to or removed from, and the parents of a
+// cluster group.
+func (h *handler) resolveClusterGroups(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+	switch obj.(type) {
+	case *v3.ClusterGroup:
+		parents, err := h.clusterGroupCache.GetByIndex(clusterGroupByChild, name)
+		return groupKeys(parents), err
+	case *v3.Cluster, nil:
+		if namespace != "" {
+			return nil, nil
+		}
+		// a cluster can be added to any group by its labels or name, and removed from the ones it's in. All groups are
+		// enqueued for deleted clusters and groups.
+		groups, err := h.clusterGroupCache.List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		var keys []relatedresource.Key
+		for _, group := range groups {
+			if cluster, ok := obj.(*v3.Cluster); ok && !contains(group.Status.Clusters, name) {
+				selector, err := clusterSelector(group)
+				if err == nil && !contains(group.Spec.ClusterNames, name) && !selector.Matches(labels.Set(cluster.Labels)) {
+					continue
+				}
+			}
+			keys = append(keys, relatedresource.Key{Name: group.Name})
+		}
+		return keys, nil
+	}
+	return nil, nil
+}
+
+// resolveBindings enqueues the cluster group role template bindings of a cluster group.
+func (h *handler) resolveBindings(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+	if _, ok := obj.(*v3.ClusterGroup); !ok {
+		return nil, nil
+	}
+	cgrtbs, err := h.cgrtbCache.GetByIndex(clusterGroupRoleTemplateBindingByGroup, name)
+	if err != nil {
+		return nil, err
+	}
+	var keys []relatedresource.Key
+	for _, cgrtb := range cgrtbs {
+		keys = append(keys, relatedresource.Key{Name: cgrtb.Name})
+	}
+	return keys, nil
+}
+
+// resolvePSACTClusters enqueues the clusters of a cluster group, whose pod security admission configuration template
+// it may set, or all clusters if it's deleted, as its clusters are no longer known.
+func (h *handler) resolvePSACTClusters(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+	if namespace != "" {
+		return nil, nil
+	}
+	var clusterNames []string
+	if group, ok := obj.(*v3.ClusterGroup); ok {
+		clusterNames = group.Status.Clusters
+	} else if obj == nil {
+		clusters, err := h.clusterCache.List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		for _, cluster := range clusters {
+			clusterNames = append(clusterNames, cluster.Name)
+		}
+	}
+	var keys []relatedresource.Key
+	for _, clusterName := range clusterNames {
+		keys = append(keys, relatedresource.Key{Name: clusterName})
+	}
+	return keys, nil
+}
+
+func groupKeys(groups []*v3.ClusterGroup) []relatedresource.Key {
+	var keys []relatedresource.Key
+	for _, group := range groups {
+		keys = append(keys, relatedresource.Key{Name: group.Name})
+	}
+	return keys
+}
+
+func (h *handler) sync(group *v3.ClusterGroup, status v3.ClusterGroupStatus) (v3.ClusterGroupStatus, error) {
+	if group.DeletionTimestamp != nil {
+		return status, nil
+	}
+
+	allGroups, err := h.clusterGroupCache.List(labels.Everything())
+	if err != nil {
+		return status, err
+	}
+	groups := map[string]*v3.ClusterGroup{}
+	for _, g := range allGroups {
+		groups[g.Name] = g
+	}
+	clusters, err := h.clusterCache.List(labels.Everything())
+	if err != nil {
+		return status, err
+	}
+
+	members, err := resolveClusters(group, groups, clusters)
+	if err != nil {
+		return status, err
+	}
+
+	// the clusters leaving the group may no longer get its pod security admission configuration template, those
+	// joining it are enqueued once the status is updated
+	for _, clusterName := range status.Clusters {
+		if !contains(members, clusterName) {
+			h.clusters.Enqueue(clusterName)
+		}
+	}
+
+	status.Clusters = members
+	status.ClusterCount = len(members)
+	return status, nil
+}
+
+func (h *handler) bind(cgrtb *v3.ClusterGroupRoleTemplateBinding, status v3.ClusterGroupRoleTemplateBindingStatus) ([]runtime.Object, v3.ClusterGroupRoleTemplateBindingStatus, error) {
+	if err := validateBinding(cgrtb); err != nil {
+		return nil, status, err
+	}
+
+	var clusterNames []string
+	group, err := h.clusterGroupCache.Get(cgrtb.ClusterGroupName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, status, err
+	} else if err == nil {
+		clusterNames = group.Status.Clusters
+	}
+
+	var objs []runtime.Object
+	for _, crtb := range clusterRoleTemplateBindings(cgrtb, clusterNames) {
+		objs = append(objs, crtb)
+	}
+	status.Clusters = clusterNames
+	return objs, status, nil
+}
+
+// OnClusterChange sets the default pod security admission configuration template of the cluster groups of a cluster on
+// it, on its provisioning cluster if it has one, unless a template is set on it. Templates are rolled out to the canary
+// clusters of a cluster group first.
+func (h *handler) OnClusterChange(key string, cluster *v3.Cluster) (*v3.Cluster, error) {
+	if cluster == nil || cluster.DeletionTimestamp != nil {
+		return cluster, nil
+	}
+
+	groups, err := h.clusterGroupCache.GetByIndex(clusterGroupByCluster, cluster.Name)
+	if err != nil {
+		return cluster, err
+	}
+	desired, desiredGroup := defaultPSACT(cluster.Name, groups)
+
+	if features.ProvisioningV2.Enabled() {
+		provClusters, err := h.provClusterCache.GetByIndex(clusterindex.ClusterV1ByClusterV3Reference, cluster.Name)
+		if err != nil {
+			return cluster, err
+		}
+		if len(provClusters) > 0 {
+			provCluster := provClusters[0]
+			psact, group := psactUpdate(provCluster.Spec.DefaultPodSecurityAdmissionConfigurationTemplateName, provCluster.Annotations[PSACTAnnotation], desired, desiredGroup)
+			if psact == provCluster.Spec.DefaultPodSecurityAdmissionConfigurationTemplateName && group == provCluster.Annotations[PSACTAnnotation] {
+				return h.recordCanaryPSACT(cluster, psact, group)
+			}
+			if held, err := h.canaryHeld(cluster, psact, group); err != nil || held {
+				return cluster, err
+			}
+			provCluster = provCluster.DeepCopy()
+			provCluster.Spec.DefaultPodSecurityAdmissionConfigurationTemplateName = psact
+			provCluster.Annotations = withPSACTAnnotation(provCluster.Annotations, group)
+			if _, err = h.provClusters.Update(provCluster); err != nil {
+				return cluster, err
+			}
+			return h.recordCanaryPSACT(cluster, psact, group)
+		}
+	}
+
+	psact, group := psactUpdate(cluster.Spec.DefaultPodSecurityAdmissionConfigurationTemplateName, cluster.Annotations[PSACTAnnotation], desired, desiredGroup)
+	if psact == cluster.Spec.DefaultPodSecurityAdmissionConfigurationTemplateName && group == cluster.Annotations[PSACTAnnotation] {
+		return h.recordCanaryPSACT(cluster, psact, group)
+	}
+	if held, err := h.canaryHeld(cluster, psact, group); err != nil || held {
+		return cluster, err
+	}
+	cluster = cluster.DeepCopy()
+	cluster.Spec.DefaultPodSecurityAdmissionConfigurationTemplateName = psact
+	cluster.Annotations = withPSACTAnnotation(cluster.Annotations, group)
+	if group != "" {
+		if _, err := canary.SetApplied(cluster, canary.PSACTOperation(group), psact, time.Now()); err != nil {
+			return cluster, err
+		}
+	}
+	return h.clusters.Update(cluster)
+}
+
+// canaryHeld returns whether the pod security admission configuration template of a cluster group is held for a
+// cluster until it soaked on the canary clusters of the group, the cluster being enqueued again if it is. Templates
+// unset by cluster groups aren't held.
+func (h *handler) canaryHeld(cluster *v3.Cluster, psact, group string) (bool, error) {
+	if group == "" || canary.IsCanary(cluster) {
+		return false, nil
+	}
+	clusterGroup, err := h.clusterGroupCache.Get(group)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	var members []*v3.Cluster
+	for _, clusterName := range clusterGroup.Status.Clusters {
+		member, err := h.clusterCache.Get(clusterName)
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return false, err
+		}
+		members = append(members, member)
+	}
+
+	decision := canary.Gate(cluster, members, canary.PSACTOperation(group), psact, canary.SoakPeriod(settings.CanarySoakPeriod.Get()), time.Now())
+	if decision.Proceed {
+		return false, nil
+	}
+	logrus.Infof("[cluster-group-psact] holding pod security admission configuration template %s of cluster group %s for cluster %s: %s", psact, group, cluster.Name, decision.Message)
+	h.clusters.EnqueueAfter(cluster.Name, decision.RetryAfter)
+	return true, nil
+}
+
+// recordCanaryPSACT records the pod security admission configuration template set on a canary cluster by a cluster
+// group, for the rest of the clusters of the group held until it soaked on the canary.
+func (h *handler) recordCanaryPSACT(cluster *v3.Cluster, psact, group string) (*v3.Cluster, error) {
+	if group == "" || !canary.IsCanary(cluster) {
+		return cluster, nil
+	}
+	updated := cluster.DeepCopy()
+	if changed, err := canary.SetApplied(updated, canary.PSACTOperation(group), psact, time.Now()); err != nil || !changed {
+		return cluster, err
+	}
+	return h.clusters.Update(updated)
+}
+
+func withPSACTAnnotation(annotations map[string]string, group string) map[string]string {
+	if group == "" {
+		delete(annotations, PSACTAnnotation)
+		return annotations
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[PSACTAnnotation] = group
+	return annotations
+}
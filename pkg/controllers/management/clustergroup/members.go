@@ -0,0 +1,168 @@
+package clustergroup
+
+import (
+	"fmt"
+	"sort"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/wrangler/pkg/name"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// resolveClusters returns the sorted names of the clusters of a cluster group: the existing clusters it selects or
+// lists by name, and those of its child groups. Missing child groups have no clusters, an error is returned if a group
+// is its own descendant.
+func resolveClusters(group *v3.ClusterGroup, groups map[string]*v3.ClusterGroup, clusters []*v3.Cluster) ([]string, error) {
+	members := map[string]bool{}
+	if err := addClusters(group, groups, clusters, map[string]bool{}, members); err != nil {
+		return nil, err
+	}
+	result := make([]string, 0, len(members))
+	for clusterName := range members {
+		result = append(result, clusterName)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// ContainsCluster returns whether a cluster of the given name and labels is in a cluster group, including its child
+// groups, so that clusters can be checked before they exist.
+func ContainsCluster(group *v3.ClusterGroup, groups map[string]*v3.ClusterGroup, clusterName string, clusterLabels map[string]string) bool {
+	clusters, err := resolveClusters(group, groups, []*v3.Cluster{{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterName, Labels: clusterLabels},
+	}})
+	return err == nil && len(clusters) > 0
+}
+
+func addClusters(group *v3.ClusterGroup, groups map[string]*v3.ClusterGroup, clusters []*v3.Cluster, ancestors, members map[string]bool) error {
+	if ancestors[group.Name] {
+		return fmt.Errorf("cluster group %s is its own descendant", group.Name)
+	}
+	ancestors[group.Name] = true
+	defer delete(ancestors, group.Name)
+
+	selector, err := clusterSelector(group)
+	if err != nil {
+		return err
+	}
+	listed := map[string]bool{}
+	for _, clusterName := range group.Spec.ClusterNames {
+		listed[clusterName] = true
+	}
+	for _, cluster := range clusters {
+		if listed[cluster.Name] || selector.Matches(labels.Set(cluster.Labels)) {
+			members[cluster.Name] = true
+		}
+	}
+
+	for _, childName := range group.Spec.ClusterGroupNames {
+		child, ok := groups[childName]
+		if !ok {
+			continue
+		}
+		if err := addClusters(child, groups, clusters, ancestors, members); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clusterSelector returns the selector of the clusters of a group, selecting nothing if it isn't set.
+func clusterSelector(group *v3.ClusterGroup) (labels.Selector, error) {
+	if group.Spec.ClusterSelector == nil {
+		return labels.Nothing(), nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(group.Spec.ClusterSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cluster selector of cluster group %s: %w", group.Name, err)
+	}
+	return selector, nil
+}
+
+// defaultPSACT returns the default pod security admission configuration template of a cluster and the cluster group
+// it's set by: the one of the smallest group of the cluster setting one, the first by name if several are as small.
+func defaultPSACT(clusterName string, groups []*v3.ClusterGroup) (string, string) {
+	var result *v3.ClusterGroup
+	for _, group := range groups {
+		if group.Spec.DefaultPodSecurityAdmissionConfigurationTemplateName == "" || !contains(group.Status.Clusters, clusterName) {
+			continue
+		}
+		if result == nil ||
+			group.Status.ClusterCount < result.Status.ClusterCount ||
+			group.Status.ClusterCount == result.Status.ClusterCount && group.Name < result.Name {
+			result = group
+		}
+	}
+	if result == nil {
+		return "", ""
+	}
+	return result.Spec.DefaultPodSecurityAdmissionConfigurationTemplateName, result.Name
+}
+
+// psactUpdate returns the pod security admission configuration template of a cluster and the cluster group it's set
+// by, given the current ones and the default of its cluster groups. A template not set by a cluster group is kept.
+func psactUpdate(current, currentGroup, desired, desiredGroup string) (string, string) {
+	if current != "" && currentGroup == "" {
+		return current, ""
+	}
+	return desired, desiredGroup
+}
+
+// clusterRoleTemplateBindings returns the cluster role template bindings of a cluster group role template binding,
+// one in the namespace of each cluster.
+func clusterRoleTemplateBindings(cgrtb *v3.ClusterGroupRoleTemplateBinding, clusterNames []string) []*v3.ClusterRoleTemplateBinding {
+	var result []*v3.ClusterRoleTemplateBinding
+	for _, clusterName := range clusterNames {
+		result = append(result, &v3.ClusterRoleTemplateBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name.SafeConcatName("cgrtb", cgrtb.Name),
+				Namespace: clusterName,
+				Annotations: map[string]string{
+					clusterGroupRoleTemplateBindingAnnotation: cgrtb.Name,
+				},
+			},
+			UserName:           cgrtb.UserName,
+			UserPrincipalName:  cgrtb.UserPrincipalName,
+			GroupName:          cgrtb.GroupName,
+			GroupPrincipalName: cgrtb.GroupPrincipalName,
+			ClusterName:        clusterName,
+			RoleTemplateName:   cgrtb.RoleTemplateName,
+		})
+	}
+	return result
+}
+
+// validateBinding checks that a cluster group role template binding has a role template, a cluster group and exactly
+// one subject.
+func validateBinding(cgrtb *v3.ClusterGroupRoleTemplateBinding) error {
+	if cgrtb.RoleTemplateName == "" {
+		return fmt.Errorf("role template is required")
+	}
+	if cgrtb.ClusterGroupName == "" {
+		return fmt.Errorf("cluster group is required")
+	}
+	subjects := 0
+	for _, subject := range []string{cgrtb.UserName, cgrtb.UserPrincipalName, cgrtb.GroupName, cgrtb.GroupPrincipalName} {
+		if subject != "" {
+			subjects++
+		}
+	}
+	// a user is identified by its name and principal together
+	if cgrtb.UserName != "" && cgrtb.UserPrincipalName != "" {
+		subjects--
+	}
+	if subjects != 1 {
+		return fmt.Errorf("exactly one of user, user principal, group or group principal is required")
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,221 @@
+package clustergroup
+
+import (
+	"testing"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func cluster(name string, labels map[string]string) *v3.Cluster {
+	return &v3.Cluster{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func clusterGroup(name string, spec v3.ClusterGroupSpecification, clusters ...string) *v3.ClusterGroup {
+	return &v3.ClusterGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       spec,
+		Status:     v3.ClusterGroupStatus{Clusters: clusters, ClusterCount: len(clusters)},
+	}
+}
+
+func TestResolveClusters(t *testing.T) {
+	clusters := []*v3.Cluster{
+		cluster("c-prod1", map[string]string{"env": "prod"}),
+		cluster("c-prod2", map[string]string{"env": "prod", "region": "eu"}),
+		cluster("c-dev1", map[string]string{"env": "dev"}),
+		cluster("c-edge1", nil),
+		cluster("local", nil),
+	}
+	groups := map[string]*v3.ClusterGroup{}
+	for _, group := range []*v3.ClusterGroup{
+		clusterGroup("prod", v3.ClusterGroupSpecification{
+			ClusterSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+		}),
+		clusterGroup("edge", v3.ClusterGroupSpecification{
+			ClusterNames: []string{"c-edge1", "c-removed"},
+		}),
+		clusterGroup("all", v3.ClusterGroupSpecification{
+			ClusterNames:      []string{"c-prod1"},
+			ClusterGroupNames: []string{"prod", "edge", "missing"},
+		}),
+		clusterGroup("empty", v3.ClusterGroupSpecification{}),
+		clusterGroup("cycle-a", v3.ClusterGroupSpecification{ClusterGroupNames: []string{"cycle-b"}}),
+		clusterGroup("cycle-b", v3.ClusterGroupSpecification{ClusterGroupNames: []string{"cycle-a"}}),
+		clusterGroup("invalid", v3.ClusterGroupSpecification{
+			ClusterSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "env", Operator: "Unknown"}}},
+		}),
+	} {
+		groups[group.Name] = group
+	}
+
+	tests := []struct {
+		group    string
+		expected []string
+		err      string
+	}{
+		{group: "prod", expected: []string{"c-prod1", "c-prod2"}},
+		{group: "edge", expected: []string{"c-edge1"}},
+		{group: "all", expected: []string{"c-edge1", "c-prod1", "c-prod2"}},
+		{group: "empty", expected: []string{}},
+		{group: "cycle-a", err: "cluster group cycle-a is its own descendant"},
+		{group: "invalid", err: "invalid cluster selector of cluster group invalid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.group, func(t *testing.T) {
+			result, err := resolveClusters(groups[tt.group], groups, clusters)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestContainsCluster(t *testing.T) {
+	groups := map[string]*v3.ClusterGroup{
+		"prod": clusterGroup("prod", v3.ClusterGroupSpecification{
+			ClusterSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+		}),
+		"all": clusterGroup("all", v3.ClusterGroupSpecification{
+			ClusterNames:      []string{"c-edge1"},
+			ClusterGroupNames: []string{"prod"},
+		}),
+	}
+
+	assert.True(t, ContainsCluster(groups["all"], groups, "", map[string]string{"env": "prod"}))
+	assert.True(t, ContainsCluster(groups["all"], groups, "c-edge1", nil))
+	assert.False(t, ContainsCluster(groups["prod"], groups, "c-edge1", map[string]string{"env": "dev"}))
+}
+
+func TestDefaultPSACT(t *testing.T) {
+	groups := []*v3.ClusterGroup{
+		clusterGroup("all", v3.ClusterGroupSpecification{DefaultPodSecurityAdmissionConfigurationTemplateName: "baseline"}, "c-1", "c-2", "c-3"),
+		clusterGroup("prod", v3.ClusterGroupSpecification{DefaultPodSecurityAdmissionConfigurationTemplateName: "restricted"}, "c-1", "c-2"),
+		clusterGroup("eu", v3.ClusterGroupSpecification{DefaultPodSecurityAdmissionConfigurationTemplateName: "eu-restricted"}, "c-2", "c-3"),
+		clusterGroup("team", v3.ClusterGroupSpecification{}, "c-1"),
+	}
+
+	psact, group := defaultPSACT("c-1", groups)
+	assert.Equal(t, "restricted", psact)
+	assert.Equal(t, "prod", group)
+
+	// eu and prod are as small, eu is first by name
+	psact, group = defaultPSACT("c-2", groups)
+	assert.Equal(t, "eu-restricted", psact)
+	assert.Equal(t, "eu", group)
+
+	psact, group = defaultPSACT("c-4", groups)
+	assert.Empty(t, psact)
+	assert.Empty(t, group)
+}
+
+func TestPSACTUpdate(t *testing.T) {
+	tests := []struct {
+		name                         string
+		current, currentGroup        string
+		desired, desiredGroup        string
+		expectedPSACT, expectedGroup string
+	}{
+		{
+			name:    "set on the cluster",
+			current: "privileged", desired: "restricted", desiredGroup: "prod",
+			expectedPSACT: "privileged",
+		},
+		{
+			name:    "not set",
+			desired: "restricted", desiredGroup: "prod",
+			expectedPSACT: "restricted", expectedGroup: "prod",
+		},
+		{
+			name:    "set by another group",
+			current: "baseline", currentGroup: "all", desired: "restricted", desiredGroup: "prod",
+			expectedPSACT: "restricted", expectedGroup: "prod",
+		},
+		{
+			name:    "no longer in a group",
+			current: "baseline", currentGroup: "all",
+		},
+		{
+			name: "no group",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			psact, group := psactUpdate(tt.current, tt.currentGroup, tt.desired, tt.desiredGroup)
+			assert.Equal(t, tt.expectedPSACT, psact)
+			assert.Equal(t, tt.expectedGroup, group)
+		})
+	}
+}
+
+func TestValidateBinding(t *testing.T) {
+	tests := []struct {
+		name  string
+		cgrtb v3.ClusterGroupRoleTemplateBinding
+		err   string
+	}{
+		{
+			name:  "user",
+			cgrtb: v3.ClusterGroupRoleTemplateBinding{UserName: "u-abcde", UserPrincipalName: "local://u-abcde", ClusterGroupName: "prod", RoleTemplateName: "cluster-member"},
+		},
+		{
+			name:  "group principal",
+			cgrtb: v3.ClusterGroupRoleTemplateBinding{GroupPrincipalName: "github_team://123", ClusterGroupName: "prod", RoleTemplateName: "cluster-member"},
+		},
+		{
+			name:  "no subject",
+			cgrtb: v3.ClusterGroupRoleTemplateBinding{ClusterGroupName: "prod", RoleTemplateName: "cluster-member"},
+			err:   "exactly one of user, user principal, group or group principal is required",
+		},
+		{
+			name:  "user and group",
+			cgrtb: v3.ClusterGroupRoleTemplateBinding{UserName: "u-abcde", GroupName: "g-abcde", ClusterGroupName: "prod", RoleTemplateName: "cluster-member"},
+			err:   "exactly one of user, user principal, group or group principal is required",
+		},
+		{
+			name:  "no cluster group",
+			cgrtb: v3.ClusterGroupRoleTemplateBinding{UserName: "u-abcde", RoleTemplateName: "cluster-member"},
+			err:   "cluster group is required",
+		},
+		{
+			name:  "no role template",
+			cgrtb: v3.ClusterGroupRoleTemplateBinding{UserName: "u-abcde", ClusterGroupName: "prod"},
+			err:   "role template is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBinding(&tt.cgrtb)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestClusterRoleTemplateBindings(t *testing.T) {
+	cgrtb := &v3.ClusterGroupRoleTemplateBinding{
+		ObjectMeta:       metav1.ObjectMeta{Name: "prod-members"},
+		GroupName:        "g-abcde",
+		ClusterGroupName: "prod",
+		RoleTemplateName: "cluster-member",
+	}
+	crtbs := clusterRoleTemplateBindings(cgrtb, []string{"c-prod1", "c-prod2"})
+	require.Len(t, crtbs, 2)
+	for i, clusterName := range []string{"c-prod1", "c-prod2"} {
+		assert.Equal(t, "cgrtb-prod-members", crtbs[i].Name)
+		assert.Equal(t, clusterName, crtbs[i].Namespace)
+		assert.Equal(t, clusterName, crtbs[i].ClusterName)
+		assert.Equal(t, "g-abcde", crtbs[i].GroupName)
+		assert.Equal(t, "cluster-member", crtbs[i].RoleTemplateName)
+		assert.Equal(t, "prod-members", crtbs[i].Annotations[clusterGroupRoleTemplateBindingAnnotation])
+	}
+	assert.Empty(t, clusterRoleTemplateBindings(cgrtb, nil))
+}
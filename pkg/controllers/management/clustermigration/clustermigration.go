@@ -0,0 +1,388 @@
+package clustermigration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/clustermanager"
+	"github.com/rancher/rancher/pkg/controllers/management/rbac"
+	"github.com/rancher/rancher/pkg/features"
+	catalogcontrollers "github.com/rancher/rancher/pkg/generated/controllers/catalog.cattle.io/v1"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	provcontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/types/config"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const waitInterval = 15 * time.Second
+
+type handler struct {
+	ctx              context.Context
+	manager          *clustermanager.Manager
+	migrations       mgmtcontrollers.ClusterMigrationController
+	clusterCache     mgmtcontrollers.ClusterCache
+	nodeCache        mgmtcontrollers.NodeCache
+	tokenCache       mgmtcontrollers.ClusterRegistrationTokenCache
+	projects         mgmtcontrollers.ProjectClient
+	projectCache     mgmtcontrollers.ProjectCache
+	crtbs            mgmtcontrollers.ClusterRoleTemplateBindingClient
+	crtbCache        mgmtcontrollers.ClusterRoleTemplateBindingCache
+	prtbs            mgmtcontrollers.ProjectRoleTemplateBindingClient
+	prtbCache        mgmtcontrollers.ProjectRoleTemplateBindingCache
+	managedCharts    mgmtcontrollers.ManagedChartClient
+	clusterRepoCache catalogcontrollers.ClusterRepoCache
+	provClusters     provcontrollers.ClusterClient
+	provClusterCache provcontrollers.ClusterCache
+}
+
+// Register registers the cluster-migration controller, which migrates RKE1 clusters to the RKE2 clusters it creates
+// one step at a time, reporting the progress of each step on the migrations.
+func Register(ctx context.Context, management *config.ManagementContext, manager *clustermanager.Manager) {
+	w := management.Wrangler
+	h := &handler{
+		ctx:              ctx,
+		manager:          manager,
+		migrations:       w.Mgmt.ClusterMigration(),
+		clusterCache:     w.Mgmt.Cluster().Cache(),
+		nodeCache:        w.Mgmt.Node().Cache(),
+		tokenCache:       w.Mgmt.ClusterRegistrationToken().Cache(),
+		projects:         w.Mgmt.Project(),
+		projectCache:     w.Mgmt.Project().Cache(),
+		crtbs:            w.Mgmt.ClusterRoleTemplateBinding(),
+		crtbCache:        w.Mgmt.ClusterRoleTemplateBinding().Cache(),
+		prtbs:            w.Mgmt.ProjectRoleTemplateBinding(),
+		prtbCache:        w.Mgmt.ProjectRoleTemplateBinding().Cache(),
+		managedCharts:    w.Mgmt.ManagedChart(),
+		clusterRepoCache: w.Catalog.ClusterRepo().Cache(),
+		provClusters:     w.Provisioning.Cluster(),
+		provClusterCache: w.Provisioning.Cluster().Cache(),
+	}
+
+	mgmtcontrollers.RegisterClusterMigrationStatusHandler(ctx, h.migrations, "", "cluster-migration", h.OnChange)
+}
+
+func (h *handler) OnChange(migration *v3.ClusterMigration, status v3.ClusterMigrationStatus) (v3.ClusterMigrationStatus, error) {
+	if migration.DeletionTimestamp != nil || status.State == v3.ClusterMigrationComplete || status.State == v3.ClusterMigrationFailed {
+		return status, nil
+	}
+
+	steps := []step{
+		{name: v3.ClusterMigrationStepMapConfig, run: func(status *v3.ClusterMigrationStatus) (string, string, error) {
+			return h.mapConfig(migration, status)
+		}},
+		{name: v3.ClusterMigrationStepCreateCluster, run: func(status *v3.ClusterMigrationStatus) (string, string, error) {
+			return h.createCluster(migration, status)
+		}},
+		{name: v3.ClusterMigrationStepRegisterNodes, run: func(status *v3.ClusterMigrationStatus) (string, string, error) {
+			return h.registerNodes(migration, status)
+		}},
+		{name: v3.ClusterMigrationStepWaitForCluster, run: func(status *v3.ClusterMigrationStatus) (string, string, error) {
+			return h.waitForCluster(status)
+		}},
+		{name: v3.ClusterMigrationStepMigrateProjects, run: func(status *v3.ClusterMigrationStatus) (string, string, error) {
+			return h.migrateProjects(migration, status)
+		}},
+		{name: v3.ClusterMigrationStepMigrateRBAC, run: func(status *v3.ClusterMigrationStatus) (string, string, error) {
+			return h.migrateRBAC(migration, status)
+		}},
+		{name: v3.ClusterMigrationStepMigrateApps, run: func(status *v3.ClusterMigrationStatus) (string, string, error) {
+			return h.migrateApps(migration, status)
+		}},
+	}
+
+	status, waiting, err := runSteps(status, steps, metav1.Now())
+	if waiting {
+		h.migrations.EnqueueAfter(migration.Namespace, migration.Name, waitInterval)
+	}
+	return status, err
+}
+
+func (h *handler) mapConfig(migration *v3.ClusterMigration, status *v3.ClusterMigrationStatus) (string, string, error) {
+	if migration.Spec.ClusterName == "" {
+		return v3.ClusterMigrationFailed, "the name of the RKE2 cluster is required", nil
+	}
+	if !strings.Contains(migration.Spec.KubernetesVersion, "rke2") {
+		return v3.ClusterMigrationFailed, fmt.Sprintf("%q is not an RKE2 version", migration.Spec.KubernetesVersion), nil
+	}
+	source, err := h.clusterCache.Get(migration.Spec.SourceClusterName)
+	if apierrors.IsNotFound(err) {
+		return v3.ClusterMigrationFailed, fmt.Sprintf("cluster %s not found", migration.Spec.SourceClusterName), nil
+	} else if err != nil {
+		return "", "", err
+	}
+	if source.Spec.RancherKubernetesEngineConfig == nil {
+		return v3.ClusterMigrationFailed, fmt.Sprintf("cluster %s is not an RKE1 cluster", source.Name), nil
+	}
+
+	_, status.Warnings = rke2ClusterSpec(source, migration.Spec.KubernetesVersion)
+	if len(status.Warnings) > 0 {
+		return v3.ClusterMigrationComplete, fmt.Sprintf("%d options must be migrated by hand", len(status.Warnings)), nil
+	}
+	return v3.ClusterMigrationComplete, "", nil
+}
+
+// createCluster creates the provisioning cluster of the RKE2 cluster, owned by the creator of the RKE1 cluster, and
+// completes once its management cluster exists.
+func (h *handler) createCluster(migration *v3.ClusterMigration, status *v3.ClusterMigrationStatus) (string, string, error) {
+	cluster, err := h.provClusterCache.Get(migration.Namespace, migration.Spec.ClusterName)
+	if apierrors.IsNotFound(err) {
+		source, err := h.clusterCache.Get(migration.Spec.SourceClusterName)
+		if err != nil {
+			return "", "", err
+		}
+		spec, _ := rke2ClusterSpec(source, migration.Spec.KubernetesVersion)
+		cluster = &provv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      migration.Spec.ClusterName,
+				Namespace: migration.Namespace,
+				Annotations: map[string]string{
+					MigrationAnnotation: migration.Name,
+				},
+			},
+			Spec: spec,
+		}
+		if creatorID := source.Annotations[rbac.CreatorIDAnn]; creatorID != "" {
+			cluster.Annotations[rbac.CreatorIDAnn] = creatorID
+		}
+		if _, err := h.provClusters.Create(cluster); err != nil {
+			return "", "", err
+		}
+		return v3.ClusterMigrationInProgress, fmt.Sprintf("creating cluster %s", cluster.Name), nil
+	} else if err != nil {
+		return "", "", err
+	}
+
+	if cluster.Annotations[MigrationAnnotation] != migration.Name {
+		return v3.ClusterMigrationFailed, fmt.Sprintf("cluster %s already exists", cluster.Name), nil
+	}
+	if cluster.Status.ClusterName == "" {
+		return v3.ClusterMigrationInProgress, fmt.Sprintf("waiting for management cluster of cluster %s", cluster.Name), nil
+	}
+	status.ClusterName = cluster.Status.ClusterName
+	return v3.ClusterMigrationComplete, "", nil
+}
+
+// registerNodes reports the commands registering the custom nodes of the RKE1 cluster with the RKE2 cluster, and
+// completes once they're all registered.
+func (h *handler) registerNodes(migration *v3.ClusterMigration, status *v3.ClusterMigrationStatus) (string, string, error) {
+	if !migration.Spec.ReuseNodes {
+		return v3.ClusterMigrationSkipped, "", nil
+	}
+
+	token, err := h.tokenCache.Get(status.ClusterName, "default-token")
+	if apierrors.IsNotFound(err) || (err == nil && token.Status.NodeCommand == "") {
+		return v3.ClusterMigrationInProgress, "waiting for registration command", nil
+	} else if err != nil {
+		return "", "", err
+	}
+	source, err := h.nodeCache.List(migration.Spec.SourceClusterName, labels.Everything())
+	if err != nil {
+		return "", "", err
+	}
+	target, err := h.nodeCache.List(status.ClusterName, labels.Everything())
+	if err != nil {
+		return "", "", err
+	}
+
+	status.Nodes = reusedNodes(source, target, token.Status.NodeCommand)
+	if len(status.Nodes) == 0 {
+		return v3.ClusterMigrationSkipped, fmt.Sprintf("cluster %s has no custom nodes", migration.Spec.SourceClusterName), nil
+	}
+	registered := 0
+	for _, node := range status.Nodes {
+		if node.Registered {
+			registered++
+		}
+	}
+	if registered < len(status.Nodes) {
+		return v3.ClusterMigrationInProgress, fmt.Sprintf("%d of %d nodes registered, each node must be removed from cluster %s and cleaned up before its command is run",
+			registered, len(status.Nodes), migration.Spec.SourceClusterName), nil
+	}
+	return v3.ClusterMigrationComplete, fmt.Sprintf("%d nodes registered", registered), nil
+}
+
+func (h *handler) waitForCluster(status *v3.ClusterMigrationStatus) (string, string, error) {
+	cluster, err := h.clusterCache.Get(status.ClusterName)
+	if err != nil {
+		return "", "", err
+	}
+	if !v3.ClusterConditionReady.IsTrue(cluster) {
+		return v3.ClusterMigrationInProgress, fmt.Sprintf("waiting for cluster %s to be ready", cluster.Name), nil
+	}
+	return v3.ClusterMigrationComplete, "", nil
+}
+
+// migrateProjects creates the projects of the RKE1 cluster in the RKE2 cluster, and the namespaces of the projects that
+// don't exist in the RKE2 cluster. The resources of the namespaces aren't migrated.
+func (h *handler) migrateProjects(migration *v3.ClusterMigration, status *v3.ClusterMigrationStatus) (string, string, error) {
+	if migration.Spec.SkipProjects {
+		return v3.ClusterMigrationSkipped, "", nil
+	}
+
+	sources, err := h.projectCache.List(migration.Spec.SourceClusterName, labels.Everything())
+	if err != nil {
+		return "", "", err
+	}
+	targets, err := h.projectCache.List(status.ClusterName, labels.Everything())
+	if err != nil {
+		return "", "", err
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i].Name < sources[j].Name
+	})
+
+	var projects []v3.ClusterMigrationProject
+	targetNames := map[string]string{}
+	for _, source := range sources {
+		target := targetProject(source, targets)
+		if target == nil {
+			if source.Labels[defaultProjectLabel] == "true" || source.Labels[systemProjectLabel] == "true" {
+				return v3.ClusterMigrationInProgress, "waiting for the default and system projects", nil
+			}
+			if target, err = h.projects.Create(migratedProject(source, status.ClusterName)); err != nil {
+				return "", "", err
+			}
+		}
+		projects = append(projects, v3.ClusterMigrationProject{
+			SourceName: source.Name,
+			TargetName: target.Name,
+		})
+		targetNames[source.Name] = target.Name
+	}
+	status.Projects = projects
+
+	sourceContext, err := h.manager.UserContextNoControllers(migration.Spec.SourceClusterName)
+	if err != nil {
+		return "", "", err
+	}
+	targetContext, err := h.manager.UserContextNoControllers(status.ClusterName)
+	if err != nil {
+		return "", "", err
+	}
+	namespaces, err := sourceContext.K8sClient.CoreV1().Namespaces().List(h.ctx, metav1.ListOptions{})
+	if err != nil {
+		return "", "", err
+	}
+	created := 0
+	for i := range namespaces.Items {
+		ns := &namespaces.Items[i]
+		target, ok := targetNames[sourceProject(ns, migration.Spec.SourceClusterName)]
+		if !ok || ns.DeletionTimestamp != nil {
+			continue
+		}
+		_, err := targetContext.K8sClient.CoreV1().Namespaces().Create(h.ctx, migratedNamespace(ns, status.ClusterName, target), metav1.CreateOptions{})
+		if apierrors.IsAlreadyExists(err) {
+			continue
+		} else if err != nil {
+			return "", "", err
+		}
+		created++
+	}
+	return v3.ClusterMigrationComplete, fmt.Sprintf("%d projects migrated, %d namespaces created", len(projects), created), nil
+}
+
+// migrateRBAC copies the cluster role template bindings of the RKE1 cluster, and the project role template bindings of
+// its migrated projects, to the RKE2 cluster.
+func (h *handler) migrateRBAC(migration *v3.ClusterMigration, status *v3.ClusterMigrationStatus) (string, string, error) {
+	if migration.Spec.SkipRBAC {
+		return v3.ClusterMigrationSkipped, "", nil
+	}
+
+	crtbs, err := h.crtbCache.List(migration.Spec.SourceClusterName, labels.Everything())
+	if err != nil {
+		return "", "", err
+	}
+	for _, crtb := range crtbs {
+		if crtb.DeletionTimestamp != nil {
+			continue
+		}
+		if _, err := h.crtbs.Create(migratedCRTB(crtb, status.ClusterName)); err != nil && !apierrors.IsAlreadyExists(err) {
+			return "", "", err
+		}
+	}
+
+	prtbCount := 0
+	for _, project := range status.Projects {
+		prtbs, err := h.prtbCache.List(project.SourceName, labels.Everything())
+		if err != nil {
+			return "", "", err
+		}
+		for _, prtb := range prtbs {
+			// The bindings of service accounts are specific to the RKE1 cluster.
+			if prtb.DeletionTimestamp != nil || prtb.ServiceAccount != "" {
+				continue
+			}
+			if _, err := h.prtbs.Create(migratedPRTB(prtb, status.ClusterName, project.TargetName)); err != nil && !apierrors.IsAlreadyExists(err) {
+				return "", "", err
+			}
+			prtbCount++
+		}
+	}
+	return v3.ClusterMigrationComplete, fmt.Sprintf("%d cluster and %d project role template bindings migrated", len(crtbs), prtbCount), nil
+}
+
+// migrateApps deploys the apps installed on the RKE1 cluster from cluster repositories to the RKE2 cluster, with
+// managed charts. The other apps are reported as warnings.
+func (h *handler) migrateApps(migration *v3.ClusterMigration, status *v3.ClusterMigrationStatus) (string, string, error) {
+	if migration.Spec.SkipApps {
+		return v3.ClusterMigrationSkipped, "", nil
+	}
+	if !features.Fleet.Enabled() {
+		return v3.ClusterMigrationSkipped, "apps are deployed with managed charts, which require fleet", nil
+	}
+
+	sourceContext, err := h.manager.UserContextNoControllers(migration.Spec.SourceClusterName)
+	if err != nil {
+		return "", "", err
+	}
+	apps, err := sourceContext.Catalog.V1().App().List("", metav1.ListOptions{})
+	if err != nil {
+		return "", "", err
+	}
+	sort.Slice(apps.Items, func(i, j int) bool {
+		return apps.Items[i].Namespace+"/"+apps.Items[i].Name < apps.Items[j].Namespace+"/"+apps.Items[j].Name
+	})
+
+	var migrated []string
+	for i := range apps.Items {
+		app := &apps.Items[i]
+		if rancherApp(app) {
+			continue
+		}
+		key := app.Namespace + "/" + app.Name
+		repo, err := appRepo(app)
+		if err == nil {
+			if _, repoErr := h.clusterRepoCache.Get(repo); apierrors.IsNotFound(repoErr) {
+				err = fmt.Errorf("cluster repository %s doesn't exist", repo)
+			} else if repoErr != nil {
+				return "", "", repoErr
+			}
+		}
+		if err != nil {
+			addWarning(status, fmt.Sprintf("app %s is not migrated as %v", key, err))
+			continue
+		}
+		if _, err := h.managedCharts.Create(migratedApp(migration, app, repo)); err != nil && !apierrors.IsAlreadyExists(err) {
+			return "", "", err
+		}
+		migrated = append(migrated, key)
+	}
+	status.Apps = migrated
+	return v3.ClusterMigrationComplete, fmt.Sprintf("%d apps deployed with managed charts", len(migrated)), nil
+}
+
+func addWarning(status *v3.ClusterMigrationStatus, warning string) {
+	for _, existing := range status.Warnings {
+		if existing == warning {
+			return
+		}
+	}
+	status.Warnings = append(status.Warnings, warning)
+}
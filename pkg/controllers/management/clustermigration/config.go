@@ -0,0 +1,323 @@
+package clustermigration
+
+import (
+	"fmt"
+	"sort"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	rketypes "github.com/rancher/rke/types"
+)
+
+// cniPlugins are the RKE2 CNI plugins of the RKE1 network plugins, RKE1 plugins without an RKE2 equivalent are
+// replaced by canal.
+var cniPlugins = map[string]string{
+	"":       "canal",
+	"canal":  "canal",
+	"calico": "calico",
+	"none":   "none",
+}
+
+// cloudProviders are the RKE2 cloud providers of the RKE1 cloud providers.
+var cloudProviders = map[string]string{
+	"aws":          "aws",
+	"azure":        "azure",
+	"vsphere":      "rancher-vsphere",
+	"external":     "external",
+	"external-aws": "external",
+}
+
+type mapper struct {
+	global   map[string]interface{}
+	agent    map[string]interface{}
+	disable  []string
+	warnings []string
+}
+
+func (m *mapper) warnf(format string, args ...interface{}) {
+	m.warnings = append(m.warnings, fmt.Sprintf(format, args...))
+}
+
+// service maps the extra args of an RKE1 service to the given arg of the RKE2 config, and warns about its options
+// that can't be migrated.
+func (m *mapper) service(config map[string]interface{}, name, arg string, service rketypes.BaseService) {
+	if args := serviceArgs(service); len(args) > 0 {
+		config[arg] = args
+	}
+	if service.Image != "" {
+		m.warnf("the image of %s is not migrated, RKE2 uses the images of its release", name)
+	}
+	if len(service.ExtraBinds) > 0 || len(service.ExtraEnv) > 0 {
+		m.warnf("the extra binds and env of %s are not migrated", name)
+	}
+	if len(service.WindowsExtraArgs) > 0 {
+		m.warnf("the Windows extra args of %s are not migrated", name)
+	}
+}
+
+// rke2ClusterSpec returns the spec of the RKE2 cluster equivalent to the RKE1 cluster, with the given RKE2 version, and
+// warnings about the options of the RKE1 cluster that can't be migrated.
+func rke2ClusterSpec(source *v3.Cluster, kubernetesVersion string) (provv1.ClusterSpec, []string) {
+	m := &mapper{
+		global: map[string]interface{}{},
+		agent:  map[string]interface{}{},
+	}
+	rkeConfig := &provv1.RKEConfig{}
+	spec := provv1.ClusterSpec{
+		KubernetesVersion: kubernetesVersion,
+		RKEConfig:         rkeConfig,
+		LocalClusterAuthEndpoint: rkev1.LocalClusterAuthEndpoint{
+			Enabled: source.Spec.LocalClusterAuthEndpoint.Enabled,
+			FQDN:    source.Spec.LocalClusterAuthEndpoint.FQDN,
+			CACerts: source.Spec.LocalClusterAuthEndpoint.CACerts,
+		},
+		DefaultPodSecurityAdmissionConfigurationTemplateName: source.Spec.DefaultPodSecurityAdmissionConfigurationTemplateName,
+		DefaultClusterRoleForProjectMembers:                  source.Spec.DefaultClusterRoleForProjectMembers,
+		EnableNetworkPolicy:                                  source.Spec.EnableNetworkPolicy,
+		ClusterAgentDeploymentCustomization:                  agentDeploymentCustomization(source.Spec.ClusterAgentDeploymentCustomization),
+		FleetAgentDeploymentCustomization:                    agentDeploymentCustomization(source.Spec.FleetAgentDeploymentCustomization),
+	}
+	for _, env := range source.Spec.AgentEnvVars {
+		if env.ValueFrom != nil {
+			m.warnf("agent env var %s is not migrated as it is set from a reference", env.Name)
+			continue
+		}
+		spec.AgentEnvVars = append(spec.AgentEnvVars, rkev1.EnvVar{Name: env.Name, Value: env.Value})
+	}
+	if source.Spec.DefaultPodSecurityPolicyTemplateName != "" {
+		m.warnf("pod security policy template %s is not migrated, pod security policies were removed in Kubernetes 1.25", source.Spec.DefaultPodSecurityPolicyTemplateName)
+	}
+
+	config := source.Spec.RancherKubernetesEngineConfig
+	if config == nil {
+		return spec, m.warnings
+	}
+
+	m.network(config.Network)
+	m.kubeAPI(config.Services.KubeAPI)
+	m.service(m.global, "kube-controller", "kube-controller-manager-arg", config.Services.KubeController.BaseService)
+	if config.Services.KubeController.ClusterCIDR != "" {
+		m.global["cluster-cidr"] = config.Services.KubeController.ClusterCIDR
+	}
+	if _, ok := m.global["service-cidr"]; !ok && config.Services.KubeController.ServiceClusterIPRange != "" {
+		m.global["service-cidr"] = config.Services.KubeController.ServiceClusterIPRange
+	}
+	m.service(m.global, "scheduler", "kube-scheduler-arg", config.Services.Scheduler.BaseService)
+	m.service(m.agent, "kubelet", "kubelet-arg", config.Services.Kubelet.BaseService)
+	if config.Services.Kubelet.ClusterDomain != "" {
+		m.global["cluster-domain"] = config.Services.Kubelet.ClusterDomain
+	}
+	if config.Services.Kubelet.ClusterDNSServer != "" {
+		m.global["cluster-dns"] = config.Services.Kubelet.ClusterDNSServer
+	}
+	if config.Services.Kubelet.InfraContainerImage != "" {
+		m.warnf("the infra container image of kubelet is not migrated")
+	}
+	m.service(m.agent, "kube-proxy", "kube-proxy-arg", config.Services.Kubeproxy.BaseService)
+	rkeConfig.ETCD = m.etcd(config.Services.Etcd)
+	m.addons(config, rkeConfig)
+	m.registries(config.PrivateRegistries)
+	rkeConfig.UpgradeStrategy = upgradeStrategy(config.UpgradeStrategy)
+
+	if len(config.Authentication.SANs) > 0 {
+		m.global["tls-san"] = append([]string(nil), config.Authentication.SANs...)
+	}
+	if config.CloudProvider.Name != "" {
+		if provider, ok := cloudProviders[config.CloudProvider.Name]; ok {
+			m.global["cloud-provider-name"] = provider
+			m.warnf("the config of cloud provider %s is not migrated and must be set again", config.CloudProvider.Name)
+		} else {
+			m.warnf("cloud provider %s is not migrated as RKE2 doesn't support it", config.CloudProvider.Name)
+		}
+	}
+	if config.EnableCRIDockerd != nil && *config.EnableCRIDockerd {
+		m.warnf("cri-dockerd is not migrated, RKE2 runs containerd")
+	}
+
+	if len(m.disable) > 0 {
+		m.global["disable"] = m.disable
+	}
+	if len(m.global) > 0 {
+		rkeConfig.MachineGlobalConfig = rkev1.GenericMap{Data: m.global}
+	}
+	if len(m.agent) > 0 {
+		// The args of the kubelet and kube-proxy are set on all machines, rather than only the servers.
+		rkeConfig.MachineSelectorConfig = []rkev1.RKESystemConfig{
+			{
+				Config: rkev1.GenericMap{Data: m.agent},
+			},
+		}
+	}
+	return spec, m.warnings
+}
+
+func (m *mapper) network(network rketypes.NetworkConfig) {
+	cni, ok := cniPlugins[network.Plugin]
+	if !ok {
+		cni = "canal"
+		m.warnf("network plugin %s is replaced by canal, as RKE2 doesn't support it", network.Plugin)
+	}
+	m.global["cni"] = cni
+	if len(network.Options) > 0 || network.MTU != 0 {
+		m.warnf("the options of network plugin %s are not migrated", network.Plugin)
+	}
+}
+
+func (m *mapper) kubeAPI(kubeAPI rketypes.KubeAPIService) {
+	m.service(m.global, "kube-api", "kube-apiserver-arg", kubeAPI.BaseService)
+	if kubeAPI.ServiceClusterIPRange != "" {
+		m.global["service-cidr"] = kubeAPI.ServiceClusterIPRange
+	}
+	if kubeAPI.ServiceNodePortRange != "" {
+		m.global["service-node-port-range"] = kubeAPI.ServiceNodePortRange
+	}
+	if kubeAPI.SecretsEncryptionConfig != nil && kubeAPI.SecretsEncryptionConfig.Enabled {
+		m.global["secrets-encryption"] = true
+		if kubeAPI.SecretsEncryptionConfig.CustomConfig != nil {
+			m.warnf("the custom secrets encryption config of kube-api is not migrated")
+		}
+	}
+	if kubeAPI.AuditLog != nil && kubeAPI.AuditLog.Enabled && kubeAPI.AuditLog.Configuration != nil && kubeAPI.AuditLog.Configuration.Policy != nil {
+		m.warnf("the audit policy of kube-api is not migrated, RKE2 uses its default audit policy")
+	}
+	if kubeAPI.PodSecurityPolicy {
+		m.warnf("pod security policies are not migrated, they were removed in Kubernetes 1.25")
+	}
+	if kubeAPI.AlwaysPullImages || kubeAPI.EventRateLimit != nil || kubeAPI.AdmissionConfiguration != nil {
+		m.warnf("the admission configuration of kube-api is not migrated")
+	}
+}
+
+// etcd returns the etcd config of the RKE2 cluster. The S3 credentials of the snapshots aren't migrated, as RKE2
+// clusters read them from a cloud credential.
+func (m *mapper) etcd(etcd rketypes.ETCDService) *rkev1.ETCD {
+	m.service(m.global, "etcd", "etcd-arg", etcd.BaseService)
+	if len(etcd.ExternalURLs) > 0 {
+		m.warnf("external etcd is not migrated, RKE2 runs etcd on its etcd nodes")
+	}
+
+	backup := etcd.BackupConfig
+	if backup == nil {
+		return nil
+	}
+	result := &rkev1.ETCD{}
+	if backup.Enabled != nil && !*backup.Enabled {
+		result.DisableSnapshots = true
+	}
+	if backup.IntervalHours > 0 {
+		result.SnapshotScheduleCron = fmt.Sprintf("0 */%d * * *", backup.IntervalHours)
+	}
+	result.SnapshotRetention = backup.Retention
+	if s3 := backup.S3BackupConfig; s3 != nil {
+		result.S3 = &rkev1.ETCDSnapshotS3{
+			Endpoint:   s3.Endpoint,
+			EndpointCA: s3.CustomCA,
+			Bucket:     s3.BucketName,
+			Region:     s3.Region,
+			Folder:     s3.Folder,
+		}
+		if s3.AccessKey != "" {
+			m.warnf("the S3 credentials of etcd snapshots are not migrated and must be set as a cloud credential")
+		}
+	}
+	return result
+}
+
+func (m *mapper) addons(config *rketypes.RancherKubernetesEngineConfig, rkeConfig *provv1.RKEConfig) {
+	switch config.Ingress.Provider {
+	case "none":
+		m.disable = append(m.disable, "rke2-ingress-nginx")
+	case "", "nginx":
+		if len(config.Ingress.Options) > 0 || len(config.Ingress.ExtraArgs) > 0 {
+			m.warnf("the options of the ingress controller are not migrated, they are set in the values of the rke2-ingress-nginx chart")
+		}
+	default:
+		m.warnf("ingress provider %s is replaced by nginx", config.Ingress.Provider)
+	}
+	if config.Monitoring.Provider == "none" {
+		m.disable = append(m.disable, "rke2-metrics-server")
+	}
+	if config.DNS != nil {
+		switch config.DNS.Provider {
+		case "none":
+			m.disable = append(m.disable, "rke2-coredns")
+		case "", "coredns":
+		default:
+			m.warnf("DNS provider %s is replaced by coredns", config.DNS.Provider)
+		}
+		if config.DNS.Nodelocal != nil && config.DNS.Nodelocal.IPAddress != "" {
+			rkeConfig.NodeLocalDNS = &rkev1.NodeLocalDNS{
+				Enabled:   true,
+				IPAddress: config.DNS.Nodelocal.IPAddress,
+			}
+		}
+	}
+	rkeConfig.AdditionalManifest = config.Addons
+	if len(config.AddonsInclude) > 0 {
+		m.warnf("the included addons are not migrated, their manifests must be added to the additional manifest")
+	}
+}
+
+// registries sets the default registry of the RKE1 cluster as the system default registry of the RKE2 cluster. The
+// credentials of the registries aren't migrated, as RKE2 clusters read them from secrets.
+func (m *mapper) registries(registries []rketypes.PrivateRegistry) {
+	for _, registry := range registries {
+		if registry.IsDefault {
+			m.global["system-default-registry"] = registry.URL
+		}
+		if registry.User != "" || registry.ECRCredentialPlugin != nil {
+			m.warnf("the credentials of registry %s are not migrated and must be set as a registry auth config secret", registry.URL)
+		}
+	}
+}
+
+func upgradeStrategy(strategy *rketypes.NodeUpgradeStrategy) rkev1.ClusterUpgradeStrategy {
+	var result rkev1.ClusterUpgradeStrategy
+	if strategy == nil {
+		return result
+	}
+	result.ControlPlaneConcurrency = strategy.MaxUnavailableControlplane
+	result.WorkerConcurrency = strategy.MaxUnavailableWorker
+	if strategy.Drain != nil && *strategy.Drain {
+		drain := rkev1.DrainOptions{
+			Enabled: true,
+		}
+		if input := strategy.DrainInput; input != nil {
+			drain.Force = input.Force
+			drain.IgnoreDaemonSets = input.IgnoreDaemonSets
+			drain.DeleteEmptyDirData = input.DeleteLocalData
+			drain.GracePeriod = input.GracePeriod
+			drain.Timeout = input.Timeout
+		}
+		result.ControlPlaneDrainOptions = drain
+		result.WorkerDrainOptions = drain
+	}
+	return result
+}
+
+func agentDeploymentCustomization(customization *v3.AgentDeploymentCustomization) *provv1.AgentDeploymentCustomization {
+	if customization == nil {
+		return nil
+	}
+	return &provv1.AgentDeploymentCustomization{
+		AppendTolerations:            customization.AppendTolerations,
+		OverrideAffinity:             customization.OverrideAffinity,
+		OverrideResourceRequirements: customization.OverrideResourceRequirements,
+	}
+}
+
+// serviceArgs returns the extra args of an RKE1 service as the key=value args of an RKE2 config, sorted by key.
+func serviceArgs(service rketypes.BaseService) []string {
+	var args []string
+	for k, v := range service.ExtraArgs {
+		args = append(args, fmt.Sprintf("%s=%s", k, v))
+	}
+	for k, values := range service.ExtraArgsArray {
+		for _, v := range values {
+			args = append(args, fmt.Sprintf("%s=%s", k, v))
+		}
+	}
+	sort.Strings(args)
+	return args
+}
@@ -0,0 +1,156 @@
+package clustermigration
+
+import (
+	"testing"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	rketypes "github.com/rancher/rke/types"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestRKE2ClusterSpec(t *testing.T) {
+	enabled, disabled := true, false
+	source := &v3.Cluster{
+		Spec: v3.ClusterSpec{
+			ClusterSpecBase: v3.ClusterSpecBase{
+				AgentEnvVars: []corev1.EnvVar{
+					{Name: "HTTP_PROXY", Value: "http://proxy:3128"},
+					{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{}},
+				},
+				LocalClusterAuthEndpoint: v3.LocalClusterAuthEndpoint{Enabled: true, FQDN: "cluster.example.com"},
+				RancherKubernetesEngineConfig: &rketypes.RancherKubernetesEngineConfig{
+					Network: rketypes.NetworkConfig{Plugin: "flannel"},
+					Services: rketypes.RKEConfigServices{
+						KubeAPI: rketypes.KubeAPIService{
+							BaseService: rketypes.BaseService{
+								ExtraArgs: map[string]string{"max-requests-inflight": "800", "audit-log-maxage": "30"},
+							},
+							ServiceClusterIPRange:   "10.96.0.0/12",
+							ServiceNodePortRange:    "30000-31000",
+							SecretsEncryptionConfig: &rketypes.SecretsEncryptionConfig{Enabled: true},
+						},
+						KubeController: rketypes.KubeControllerService{
+							ClusterCIDR:           "10.244.0.0/16",
+							ServiceClusterIPRange: "10.96.0.0/12",
+						},
+						Kubelet: rketypes.KubeletService{
+							BaseService: rketypes.BaseService{
+								ExtraArgsArray: map[string][]string{"system-reserved": {"cpu=500m", "memory=1Gi"}},
+							},
+							ClusterDomain:    "cluster.example",
+							ClusterDNSServer: "10.96.0.10",
+						},
+						Etcd: rketypes.ETCDService{
+							BackupConfig: &rketypes.BackupConfig{
+								Enabled:       &enabled,
+								IntervalHours: 6,
+								Retention:     10,
+								S3BackupConfig: &rketypes.S3BackupConfig{
+									AccessKey:  "key",
+									SecretKey:  "secret",
+									BucketName: "snapshots",
+									Region:     "eu-west-1",
+									Endpoint:   "s3.amazonaws.com",
+								},
+							},
+						},
+					},
+					Authentication:    rketypes.AuthnConfig{SANs: []string{"api.example.com"}},
+					Ingress:           rketypes.IngressConfig{Provider: "none"},
+					PrivateRegistries: []rketypes.PrivateRegistry{{URL: "registry.example.com", IsDefault: true, User: "user"}},
+					Addons:            "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: addons\n",
+					UpgradeStrategy: &rketypes.NodeUpgradeStrategy{
+						MaxUnavailableControlplane: "1",
+						MaxUnavailableWorker:       "10%",
+						Drain:                      &disabled,
+					},
+				},
+			},
+		},
+	}
+
+	spec, warnings := rke2ClusterSpec(source, "v1.25.9+rke2r1")
+	assert.Equal(t, "v1.25.9+rke2r1", spec.KubernetesVersion)
+	assert.Equal(t, []rkev1.EnvVar{{Name: "HTTP_PROXY", Value: "http://proxy:3128"}}, spec.AgentEnvVars)
+	assert.Equal(t, rkev1.LocalClusterAuthEndpoint{Enabled: true, FQDN: "cluster.example.com"}, spec.LocalClusterAuthEndpoint)
+	assert.Equal(t, map[string]interface{}{
+		"cni":                     "canal",
+		"kube-apiserver-arg":      []string{"audit-log-maxage=30", "max-requests-inflight=800"},
+		"service-cidr":            "10.96.0.0/12",
+		"service-node-port-range": "30000-31000",
+		"secrets-encryption":      true,
+		"cluster-cidr":            "10.244.0.0/16",
+		"cluster-domain":          "cluster.example",
+		"cluster-dns":             "10.96.0.10",
+		"tls-san":                 []string{"api.example.com"},
+		"system-default-registry": "registry.example.com",
+		"disable":                 []string{"rke2-ingress-nginx"},
+	}, spec.RKEConfig.MachineGlobalConfig.Data)
+	assert.Equal(t, []rkev1.RKESystemConfig{
+		{
+			Config: rkev1.GenericMap{Data: map[string]interface{}{
+				"kubelet-arg": []string{"system-reserved=cpu=500m", "system-reserved=memory=1Gi"},
+			}},
+		},
+	}, spec.RKEConfig.MachineSelectorConfig)
+	assert.Equal(t, &rkev1.ETCD{
+		SnapshotScheduleCron: "0 */6 * * *",
+		SnapshotRetention:    10,
+		S3: &rkev1.ETCDSnapshotS3{
+			Endpoint: "s3.amazonaws.com",
+			Bucket:   "snapshots",
+			Region:   "eu-west-1",
+		},
+	}, spec.RKEConfig.ETCD)
+	assert.Equal(t, rkev1.ClusterUpgradeStrategy{ControlPlaneConcurrency: "1", WorkerConcurrency: "10%"}, spec.RKEConfig.UpgradeStrategy)
+	assert.Equal(t, source.Spec.RancherKubernetesEngineConfig.Addons, spec.RKEConfig.AdditionalManifest)
+	assert.Equal(t, []string{
+		"agent env var TOKEN is not migrated as it is set from a reference",
+		"network plugin flannel is replaced by canal, as RKE2 doesn't support it",
+		"the S3 credentials of etcd snapshots are not migrated and must be set as a cloud credential",
+		"the credentials of registry registry.example.com are not migrated and must be set as a registry auth config secret",
+	}, warnings)
+}
+
+func TestRKE2ClusterSpecDefaults(t *testing.T) {
+	spec, warnings := rke2ClusterSpec(&v3.Cluster{
+		Spec: v3.ClusterSpec{
+			ClusterSpecBase: v3.ClusterSpecBase{
+				RancherKubernetesEngineConfig: &rketypes.RancherKubernetesEngineConfig{},
+			},
+		},
+	}, "v1.25.9+rke2r1")
+	assert.Empty(t, warnings)
+	assert.Equal(t, map[string]interface{}{"cni": "canal"}, spec.RKEConfig.MachineGlobalConfig.Data)
+	assert.Empty(t, spec.RKEConfig.MachineSelectorConfig)
+	assert.Nil(t, spec.RKEConfig.ETCD)
+}
+
+func TestUpgradeStrategy(t *testing.T) {
+	drain, ignoreDaemonSets := true, false
+	result := upgradeStrategy(&rketypes.NodeUpgradeStrategy{
+		MaxUnavailableWorker: "2",
+		Drain:                &drain,
+		DrainInput: &rketypes.NodeDrainInput{
+			Force:            true,
+			IgnoreDaemonSets: &ignoreDaemonSets,
+			DeleteLocalData:  true,
+			GracePeriod:      30,
+			Timeout:          120,
+		},
+	})
+
+	expected := rkev1.DrainOptions{
+		Enabled:            true,
+		Force:              true,
+		IgnoreDaemonSets:   &ignoreDaemonSets,
+		DeleteEmptyDirData: true,
+		GracePeriod:        30,
+		Timeout:            120,
+	}
+	assert.Equal(t, "2", result.WorkerConcurrency)
+	assert.Equal(t, expected, result.ControlPlaneDrainOptions)
+	assert.Equal(t, expected, result.WorkerDrainOptions)
+}
@@ -0,0 +1,243 @@
+package clustermigration
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	fleet "github.com/rancher/fleet/pkg/apis/fleet.cattle.io/v1alpha1"
+	catalog "github.com/rancher/rancher/pkg/apis/catalog.cattle.io/v1"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/capr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// MigratedFromAnnotation is set on the objects a migration creates to the object of the RKE1 cluster they were
+	// migrated from, as namespace/name.
+	MigratedFromAnnotation = "management.cattle.io/migrated-from"
+	// MigrationAnnotation is set on the provisioning cluster a migration creates to the name of the migration.
+	MigrationAnnotation = "management.cattle.io/cluster-migration"
+	// MigrationLabel is set on the managed charts a migration creates to the name of the migration.
+	MigrationLabel = "management.cattle.io/cluster-migration"
+
+	projectIDAnnotation = "field.cattle.io/projectId"
+	defaultProjectLabel = "authz.management.cattle.io/default-project"
+	systemProjectLabel  = "authz.management.cattle.io/system-project"
+)
+
+// systemNamespaces are the namespaces of the apps installed by Rancher, which aren't migrated.
+var systemNamespaces = map[string]bool{
+	"kube-system":                 true,
+	"cattle-system":               true,
+	"cattle-fleet-system":         true,
+	"cattle-impersonation-system": true,
+}
+
+// reusedNodes returns the custom nodes of the RKE1 cluster reused by the RKE2 cluster, with the commands registering
+// them with their roles, and whether they're registered with the RKE2 cluster.
+func reusedNodes(source, target []*v3.Node, command string) []v3.ClusterMigrationNode {
+	registered := map[string]bool{}
+	for _, node := range target {
+		registered[node.Status.NodeName] = true
+	}
+
+	var result []v3.ClusterMigrationNode
+	for _, node := range source {
+		if node.Spec.NodePoolName != "" || node.Status.NodeName == "" {
+			continue
+		}
+		var roles []string
+		if node.Spec.Etcd {
+			roles = append(roles, "etcd")
+		}
+		if node.Spec.ControlPlane {
+			roles = append(roles, "controlplane")
+		}
+		if node.Spec.Worker {
+			roles = append(roles, "worker")
+		}
+		args := []string{command}
+		for _, role := range roles {
+			args = append(args, "--"+role)
+		}
+		result = append(result, v3.ClusterMigrationNode{
+			Name:       node.Status.NodeName,
+			Roles:      roles,
+			Command:    strings.Join(args, " "),
+			Registered: registered[node.Status.NodeName],
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// targetProject returns the project of the RKE2 cluster the project of the RKE1 cluster was migrated to, nil if it
+// wasn't yet. The default and system projects are migrated to those of the RKE2 cluster.
+func targetProject(source *v3.Project, targets []*v3.Project) *v3.Project {
+	for _, target := range targets {
+		switch {
+		case source.Labels[defaultProjectLabel] == "true":
+			if target.Labels[defaultProjectLabel] == "true" {
+				return target
+			}
+		case source.Labels[systemProjectLabel] == "true":
+			if target.Labels[systemProjectLabel] == "true" {
+				return target
+			}
+		case target.Annotations[MigratedFromAnnotation] == source.Namespace+"/"+source.Name:
+			return target
+		}
+	}
+	return nil
+}
+
+// migratedProject returns the project of the RKE2 cluster a project of the RKE1 cluster is migrated to.
+func migratedProject(source *v3.Project, clusterName string) *v3.Project {
+	spec := *source.Spec.DeepCopy()
+	spec.ClusterName = clusterName
+	return &v3.Project{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "p-",
+			Namespace:    clusterName,
+			Annotations: map[string]string{
+				MigratedFromAnnotation: source.Namespace + "/" + source.Name,
+			},
+		},
+		Spec: spec,
+	}
+}
+
+// migratedNamespace returns the namespace of the RKE2 cluster a namespace of the RKE1 cluster is migrated to, in the
+// given project. The labels and annotations managed by Kubernetes and Rancher aren't copied.
+func migratedNamespace(source *corev1.Namespace, clusterName, projectName string) *corev1.Namespace {
+	result := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        source.Name,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+	}
+	for k, v := range source.Labels {
+		if !managedKey(k) {
+			result.Labels[k] = v
+		}
+	}
+	for k, v := range source.Annotations {
+		if !managedKey(k) {
+			result.Annotations[k] = v
+		}
+	}
+	result.Labels[projectIDAnnotation] = projectName
+	result.Annotations[projectIDAnnotation] = clusterName + ":" + projectName
+	return result
+}
+
+func managedKey(key string) bool {
+	return strings.Contains(key, "cattle.io/") || strings.Contains(key, "kubernetes.io/") || strings.Contains(key, "k8s.io/")
+}
+
+// sourceProject returns the name of the project of the RKE1 cluster a namespace belongs to, empty if it doesn't belong
+// to a project of the cluster.
+func sourceProject(ns *corev1.Namespace, clusterName string) string {
+	cluster, project, ok := strings.Cut(ns.Annotations[projectIDAnnotation], ":")
+	if !ok || cluster != clusterName {
+		return ""
+	}
+	return project
+}
+
+// migratedCRTB returns the cluster role template binding of the RKE2 cluster a binding of the RKE1 cluster is migrated
+// to. Its name is kept, so that it's only created once.
+func migratedCRTB(source *v3.ClusterRoleTemplateBinding, clusterName string) *v3.ClusterRoleTemplateBinding {
+	return &v3.ClusterRoleTemplateBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      source.Name,
+			Namespace: clusterName,
+			Annotations: map[string]string{
+				MigratedFromAnnotation: source.Namespace + "/" + source.Name,
+			},
+		},
+		UserName:           source.UserName,
+		UserPrincipalName:  source.UserPrincipalName,
+		GroupName:          source.GroupName,
+		GroupPrincipalName: source.GroupPrincipalName,
+		ClusterName:        clusterName,
+		RoleTemplateName:   source.RoleTemplateName,
+	}
+}
+
+// migratedPRTB returns the project role template binding of the RKE2 cluster a binding of the RKE1 cluster is migrated
+// to, in the namespace of the project it's migrated to. Its name is kept, so that it's only created once.
+func migratedPRTB(source *v3.ProjectRoleTemplateBinding, clusterName, projectName string) *v3.ProjectRoleTemplateBinding {
+	return &v3.ProjectRoleTemplateBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      source.Name,
+			Namespace: projectName,
+			Annotations: map[string]string{
+				MigratedFromAnnotation: source.Namespace + "/" + source.Name,
+			},
+		},
+		UserName:           source.UserName,
+		UserPrincipalName:  source.UserPrincipalName,
+		GroupName:          source.GroupName,
+		GroupPrincipalName: source.GroupPrincipalName,
+		ProjectName:        clusterName + ":" + projectName,
+		RoleTemplateName:   source.RoleTemplateName,
+	}
+}
+
+// rancherApp returns whether an app was installed by Rancher, and is installed on the RKE2 cluster by Rancher too.
+func rancherApp(app *catalog.App) bool {
+	return systemNamespaces[app.Namespace] || strings.HasPrefix(app.Namespace, "fleet-")
+}
+
+// appRepo returns the cluster repository an app of the RKE1 cluster was installed from, or an error if the app can't
+// be migrated.
+func appRepo(app *catalog.App) (string, error) {
+	if app.Spec.Info == nil || app.Spec.Info.Status != catalog.StatusDeployed {
+		return "", fmt.Errorf("it isn't deployed")
+	}
+	if app.Spec.Chart == nil || app.Spec.Chart.Metadata == nil {
+		return "", fmt.Errorf("its chart is unknown")
+	}
+	annotations := app.Spec.Chart.Metadata.Annotations
+	if annotations["catalog.cattle.io/ui-source-repo-type"] != "cluster" || annotations["catalog.cattle.io/ui-source-repo"] == "" {
+		return "", fmt.Errorf("it wasn't installed from a cluster repository")
+	}
+	return annotations["catalog.cattle.io/ui-source-repo"], nil
+}
+
+// migratedApp returns the managed chart deploying an app of the RKE1 cluster to the RKE2 cluster, with the same
+// release name, namespace, version and values.
+func migratedApp(migration *v3.ClusterMigration, app *catalog.App, repo string) *v3.ManagedChart {
+	return &v3.ManagedChart{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      capr.SafeConcatName(capr.MaxHelmReleaseNameLength, migration.Name, app.Namespace, app.Name),
+			Namespace: migration.Namespace,
+			Labels: map[string]string{
+				MigrationLabel: migration.Name,
+			},
+			Annotations: map[string]string{
+				MigratedFromAnnotation: app.Namespace + "/" + app.Name,
+			},
+		},
+		Spec: v3.ManagedChartSpec{
+			Chart:            app.Spec.Chart.Metadata.Name,
+			RepoName:         repo,
+			ReleaseName:      app.Spec.Name,
+			Version:          app.Spec.Chart.Metadata.Version,
+			Values:           &fleet.GenericMap{Data: app.Spec.Values},
+			DefaultNamespace: app.Namespace,
+			TargetNamespace:  app.Namespace,
+			Targets: []fleet.BundleTarget{
+				{
+					ClusterName: migration.Spec.ClusterName,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,194 @@
+package clustermigration
+
+import (
+	"testing"
+
+	catalog "github.com/rancher/rancher/pkg/apis/catalog.cattle.io/v1"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReusedNodes(t *testing.T) {
+	source := []*v3.Node{
+		{
+			Spec:   v3.NodeSpec{Worker: true},
+			Status: v3.NodeStatus{NodeName: "worker"},
+		},
+		{
+			Spec:   v3.NodeSpec{Etcd: true, ControlPlane: true},
+			Status: v3.NodeStatus{NodeName: "server"},
+		},
+		{
+			Spec:   v3.NodeSpec{Worker: true, NodePoolName: "c-abcde:pool"},
+			Status: v3.NodeStatus{NodeName: "pool-node"},
+		},
+	}
+	target := []*v3.Node{
+		{Status: v3.NodeStatus{NodeName: "server"}},
+	}
+
+	assert.Equal(t, []v3.ClusterMigrationNode{
+		{
+			Name:       "server",
+			Roles:      []string{"etcd", "controlplane"},
+			Command:    "curl | sh -s - --etcd --controlplane",
+			Registered: true,
+		},
+		{
+			Name:    "worker",
+			Roles:   []string{"worker"},
+			Command: "curl | sh -s - --worker",
+		},
+	}, reusedNodes(source, target, "curl | sh -s -"))
+}
+
+func TestTargetProject(t *testing.T) {
+	targets := []*v3.Project{
+		{ObjectMeta: metav1.ObjectMeta{Name: "p-system", Labels: map[string]string{systemProjectLabel: "true"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "p-default", Labels: map[string]string{defaultProjectLabel: "true"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "p-migrated", Annotations: map[string]string{MigratedFromAnnotation: "c-abcde/p-apps"}}},
+	}
+
+	tests := []struct {
+		name     string
+		source   *v3.Project
+		expected string
+	}{
+		{
+			name:     "default project",
+			source:   &v3.Project{ObjectMeta: metav1.ObjectMeta{Namespace: "c-abcde", Name: "p-1", Labels: map[string]string{defaultProjectLabel: "true"}}},
+			expected: "p-default",
+		},
+		{
+			name:     "system project",
+			source:   &v3.Project{ObjectMeta: metav1.ObjectMeta{Namespace: "c-abcde", Name: "p-2", Labels: map[string]string{systemProjectLabel: "true"}}},
+			expected: "p-system",
+		},
+		{
+			name:     "migrated project",
+			source:   &v3.Project{ObjectMeta: metav1.ObjectMeta{Namespace: "c-abcde", Name: "p-apps"}},
+			expected: "p-migrated",
+		},
+		{
+			name:   "project not migrated yet",
+			source: &v3.Project{ObjectMeta: metav1.ObjectMeta{Namespace: "c-abcde", Name: "p-other"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := targetProject(tt.source, targets)
+			if tt.expected == "" {
+				assert.Nil(t, target)
+				return
+			}
+			assert.Equal(t, tt.expected, target.Name)
+		})
+	}
+}
+
+func TestMigratedNamespace(t *testing.T) {
+	source := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "apps",
+			Labels: map[string]string{
+				"team":                        "payments",
+				"field.cattle.io/projectId":   "p-apps",
+				"kubernetes.io/metadata.name": "apps",
+			},
+			Annotations: map[string]string{
+				"example.com/owner":         "alice",
+				"field.cattle.io/projectId": "c-abcde:p-apps",
+				"cattle.io/status":          "{}",
+			},
+		},
+	}
+
+	assert.Equal(t, "p-apps", sourceProject(source, "c-abcde"))
+	assert.Equal(t, "", sourceProject(source, "c-fghij"))
+
+	result := migratedNamespace(source, "c-m-12345", "p-migrated")
+	assert.Equal(t, "apps", result.Name)
+	assert.Equal(t, map[string]string{
+		"team":                      "payments",
+		"field.cattle.io/projectId": "p-migrated",
+	}, result.Labels)
+	assert.Equal(t, map[string]string{
+		"example.com/owner":         "alice",
+		"field.cattle.io/projectId": "c-m-12345:p-migrated",
+	}, result.Annotations)
+}
+
+func TestMigratedPRTB(t *testing.T) {
+	source := &v3.ProjectRoleTemplateBinding{
+		ObjectMeta:       metav1.ObjectMeta{Namespace: "p-apps", Name: "prtb-abcde"},
+		UserName:         "u-abcde",
+		ProjectName:      "c-abcde:p-apps",
+		RoleTemplateName: "project-member",
+	}
+
+	result := migratedPRTB(source, "c-m-12345", "p-migrated")
+	assert.Equal(t, "p-migrated", result.Namespace)
+	assert.Equal(t, "prtb-abcde", result.Name)
+	assert.Equal(t, "c-m-12345:p-migrated", result.ProjectName)
+	assert.Equal(t, "u-abcde", result.UserName)
+	assert.Equal(t, "project-member", result.RoleTemplateName)
+	assert.Equal(t, "p-apps/prtb-abcde", result.Annotations[MigratedFromAnnotation])
+}
+
+func TestAppRepo(t *testing.T) {
+	app := func(namespace string, status catalog.Status, annotations map[string]string) *catalog.App {
+		return &catalog.App{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "app"},
+			Spec: catalog.ReleaseSpec{
+				Info:  &catalog.Info{Status: status},
+				Chart: &catalog.Chart{Metadata: &catalog.Metadata{Name: "chart", Annotations: annotations}},
+			},
+		}
+	}
+	clusterRepo := map[string]string{
+		"catalog.cattle.io/ui-source-repo-type": "cluster",
+		"catalog.cattle.io/ui-source-repo":      "rancher-charts",
+	}
+
+	repo, err := appRepo(app("apps", catalog.StatusDeployed, clusterRepo))
+	assert.NoError(t, err)
+	assert.Equal(t, "rancher-charts", repo)
+
+	_, err = appRepo(app("apps", catalog.StatusFailed, clusterRepo))
+	assert.EqualError(t, err, "it isn't deployed")
+
+	_, err = appRepo(app("apps", catalog.StatusDeployed, nil))
+	assert.EqualError(t, err, "it wasn't installed from a cluster repository")
+
+	assert.True(t, rancherApp(app("cattle-system", catalog.StatusDeployed, clusterRepo)))
+	assert.True(t, rancherApp(app("fleet-local", catalog.StatusDeployed, clusterRepo)))
+	assert.False(t, rancherApp(app("apps", catalog.StatusDeployed, clusterRepo)))
+}
+
+func TestMigratedApp(t *testing.T) {
+	migration := &v3.ClusterMigration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "fleet-default", Name: "migration"},
+		Spec:       v3.ClusterMigrationSpec{ClusterName: "rke2"},
+	}
+	app := &catalog.App{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "web"},
+		Spec: catalog.ReleaseSpec{
+			Name:   "web",
+			Chart:  &catalog.Chart{Metadata: &catalog.Metadata{Name: "nginx", Version: "1.2.3"}},
+			Values: map[string]interface{}{"replicas": 2},
+		},
+	}
+
+	result := migratedApp(migration, app, "partner-charts")
+	assert.Equal(t, "fleet-default", result.Namespace)
+	assert.Equal(t, "migration", result.Labels[MigrationLabel])
+	assert.Equal(t, "nginx", result.Spec.Chart)
+	assert.Equal(t, "partner-charts", result.Spec.RepoName)
+	assert.Equal(t, "web", result.Spec.ReleaseName)
+	assert.Equal(t, "1.2.3", result.Spec.Version)
+	assert.Equal(t, "apps", result.Spec.TargetNamespace)
+	assert.Equal(t, map[string]interface{}{"replicas": 2}, result.Spec.Values.Data)
+	assert.Equal(t, "rke2", result.Spec.Targets[0].ClusterName)
+}
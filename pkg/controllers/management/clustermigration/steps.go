@@ -0,0 +1,82 @@
+package clustermigration
+
+import (
+	"fmt"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// step is a step of a migration. run returns the state of the step, Complete or Skipped to move on to the next step,
+// Failed to fail the migration, or InProgress to run the step again later, along with a message. Errors are retried.
+type step struct {
+	name string
+	run  func(status *v3.ClusterMigrationStatus) (string, string, error)
+}
+
+// runSteps runs the steps of a migration in order, from the first that isn't complete or skipped, until one of them
+// isn't. It returns the status of the migration, and whether a step is in progress.
+func runSteps(status v3.ClusterMigrationStatus, steps []step, now metav1.Time) (v3.ClusterMigrationStatus, bool, error) {
+	status.Steps = initSteps(status.Steps, steps)
+	for i, s := range steps {
+		current := &status.Steps[i]
+		if current.State == v3.ClusterMigrationComplete || current.State == v3.ClusterMigrationSkipped {
+			continue
+		}
+
+		state, message, err := s.run(&status)
+		if err != nil {
+			setStep(current, v3.ClusterMigrationInProgress, err.Error(), now)
+			status.State = v3.ClusterMigrationInProgress
+			status.Message = fmt.Sprintf("%s: %v", s.name, err)
+			return status, false, err
+		}
+		setStep(current, state, message, now)
+		switch state {
+		case v3.ClusterMigrationComplete, v3.ClusterMigrationSkipped:
+			continue
+		case v3.ClusterMigrationFailed:
+			status.State = v3.ClusterMigrationFailed
+			status.Message = fmt.Sprintf("%s: %s", s.name, message)
+			return status, false, nil
+		default:
+			status.State = v3.ClusterMigrationInProgress
+			status.Message = fmt.Sprintf("%s: %s", s.name, message)
+			return status, true, nil
+		}
+	}
+	status.State = v3.ClusterMigrationComplete
+	status.Message = ""
+	return status, false, nil
+}
+
+// initSteps returns the progress of the steps, with the steps that haven't started pending.
+func initSteps(current []v3.ClusterMigrationStep, steps []step) []v3.ClusterMigrationStep {
+	byName := map[string]v3.ClusterMigrationStep{}
+	for _, s := range current {
+		byName[s.Name] = s
+	}
+	result := make([]v3.ClusterMigrationStep, 0, len(steps))
+	for _, s := range steps {
+		if existing, ok := byName[s.name]; ok {
+			result = append(result, existing)
+			continue
+		}
+		result = append(result, v3.ClusterMigrationStep{
+			Name:  s.name,
+			State: v3.ClusterMigrationPending,
+		})
+	}
+	return result
+}
+
+// setStep sets the state and message of a step. The update time only changes along with them, so that running a step
+// in progress again doesn't update the migration.
+func setStep(current *v3.ClusterMigrationStep, state, message string, now metav1.Time) {
+	if current.State == state && current.Message == message {
+		return
+	}
+	current.State = state
+	current.Message = message
+	current.LastUpdateTime = &now
+}
@@ -0,0 +1,100 @@
+package clustermigration
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRunSteps(t *testing.T) {
+	now := metav1.NewTime(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	var ran []string
+	result := func(name, state, message string, err error) step {
+		return step{name: name, run: func(status *v3.ClusterMigrationStatus) (string, string, error) {
+			ran = append(ran, name)
+			return state, message, err
+		}}
+	}
+
+	t.Run("waiting step", func(t *testing.T) {
+		ran = nil
+		status, waiting, err := runSteps(v3.ClusterMigrationStatus{}, []step{
+			result("first", v3.ClusterMigrationComplete, "", nil),
+			result("second", v3.ClusterMigrationSkipped, "", nil),
+			result("third", v3.ClusterMigrationInProgress, "waiting", nil),
+			result("fourth", v3.ClusterMigrationComplete, "", nil),
+		}, now)
+		assert.NoError(t, err)
+		assert.True(t, waiting)
+		assert.Equal(t, []string{"first", "second", "third"}, ran)
+		assert.Equal(t, v3.ClusterMigrationInProgress, status.State)
+		assert.Equal(t, "third: waiting", status.Message)
+		assert.Equal(t, []v3.ClusterMigrationStep{
+			{Name: "first", State: v3.ClusterMigrationComplete, LastUpdateTime: &now},
+			{Name: "second", State: v3.ClusterMigrationSkipped, LastUpdateTime: &now},
+			{Name: "third", State: v3.ClusterMigrationInProgress, Message: "waiting", LastUpdateTime: &now},
+			{Name: "fourth", State: v3.ClusterMigrationPending},
+		}, status.Steps)
+	})
+
+	t.Run("completed steps aren't run again", func(t *testing.T) {
+		ran = nil
+		earlier := metav1.NewTime(now.Add(-time.Hour))
+		status, waiting, err := runSteps(v3.ClusterMigrationStatus{
+			Steps: []v3.ClusterMigrationStep{
+				{Name: "first", State: v3.ClusterMigrationComplete, LastUpdateTime: &earlier},
+				{Name: "second", State: v3.ClusterMigrationInProgress, Message: "waiting", LastUpdateTime: &earlier},
+			},
+		}, []step{
+			result("first", v3.ClusterMigrationComplete, "", nil),
+			result("second", v3.ClusterMigrationComplete, "done", nil),
+		}, now)
+		assert.NoError(t, err)
+		assert.False(t, waiting)
+		assert.Equal(t, []string{"second"}, ran)
+		assert.Equal(t, v3.ClusterMigrationComplete, status.State)
+		assert.Equal(t, &earlier, status.Steps[0].LastUpdateTime)
+		assert.Equal(t, &now, status.Steps[1].LastUpdateTime)
+	})
+
+	t.Run("failed step", func(t *testing.T) {
+		ran = nil
+		status, waiting, err := runSteps(v3.ClusterMigrationStatus{}, []step{
+			result("first", v3.ClusterMigrationFailed, "invalid", nil),
+			result("second", v3.ClusterMigrationComplete, "", nil),
+		}, now)
+		assert.NoError(t, err)
+		assert.False(t, waiting)
+		assert.Equal(t, []string{"first"}, ran)
+		assert.Equal(t, v3.ClusterMigrationFailed, status.State)
+		assert.Equal(t, "first: invalid", status.Message)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		ran = nil
+		status, waiting, err := runSteps(v3.ClusterMigrationStatus{}, []step{
+			result("first", "", "", errors.New("unavailable")),
+		}, now)
+		assert.EqualError(t, err, "unavailable")
+		assert.False(t, waiting)
+		assert.Equal(t, v3.ClusterMigrationInProgress, status.State)
+		assert.Equal(t, "unavailable", status.Steps[0].Message)
+	})
+}
+
+func TestSetStep(t *testing.T) {
+	earlier := metav1.NewTime(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(earlier.Add(time.Minute))
+	current := v3.ClusterMigrationStep{Name: "step", State: v3.ClusterMigrationInProgress, Message: "waiting", LastUpdateTime: &earlier}
+
+	setStep(&current, v3.ClusterMigrationInProgress, "waiting", now)
+	assert.Equal(t, &earlier, current.LastUpdateTime)
+
+	setStep(&current, v3.ClusterMigrationInProgress, "still waiting", now)
+	assert.Equal(t, "still waiting", current.Message)
+	assert.Equal(t, &now, current.LastUpdateTime)
+}
@@ -6,11 +6,15 @@
 	"github.com/rancher/rancher/pkg/clustermanager"
 	"github.com/rancher/rancher/pkg/controllers/management/agentupgrade"
 	"github.com/rancher/rancher/pkg/controllers/management/auth"
+	"github.com/rancher/rancher/pkg/controllers/management/canary"
 	"github.com/rancher/rancher/pkg/controllers/management/certsexpiration"
 	"github.com/rancher/rancher/pkg/controllers/management/cloudcredential"
 	"github.com/rancher/rancher/pkg/controllers/management/cluster"
+	"github.com/rancher/rancher/pkg/controllers/management/clusterarchive"
 	"github.com/rancher/rancher/pkg/controllers/management/clusterdeploy"
 	"github.com/rancher/rancher/pkg/controllers/management/clustergc"
+	"github.com/rancher/rancher/pkg/controllers/management/clustergroup"
+	"github.com/rancher/rancher/pkg/controllers/management/clustermigration"
 	"github.com/rancher/rancher/pkg/controllers/management/clusterprovisioner"
 	"github.com/rancher/rancher/pkg/controllers/management/clusterstats"
 	"github.com/rancher/rancher/pkg/controllers/management/clusterstatus"
@@ -18,12 +22,20 @@
 	"github.com/rancher/rancher/pkg/controllers/management/drivers/kontainerdriver"
 	"github.com/rancher/rancher/pkg/controllers/management/drivers/nodedriver"
 	"github.com/rancher/rancher/pkg/controllers/management/etcdbackup"
+	"github.com/rancher/rancher/pkg/controllers/management/eventstream"
 	"github.com/rancher/rancher/pkg/controllers/management/kontainerdrivermetadata"
+	"github.com/rancher/rancher/pkg/controllers/management/lifecyclenotifier"
+	"github.com/rancher/rancher/pkg/controllers/management/managementgc"
+	"github.com/rancher/rancher/pkg/controllers/management/metering"
 	"github.com/rancher/rancher/pkg/controllers/management/node"
+	"github.com/rancher/rancher/pkg/controllers/management/nodeosadvisory"
 	"github.com/rancher/rancher/pkg/controllers/management/nodepool"
+	"github.com/rancher/rancher/pkg/controllers/management/noderegistrationbatch"
 	"github.com/rancher/rancher/pkg/controllers/management/nodetemplate"
 	"github.com/rancher/rancher/pkg/controllers/management/podsecuritypolicy"
+	"github.com/rancher/rancher/pkg/controllers/management/rancherconfig"
 	"github.com/rancher/rancher/pkg/controllers/management/rbac"
+	"github.com/rancher/rancher/pkg/controllers/management/report"
 	"github.com/rancher/rancher/pkg/controllers/management/restrictedadminrbac"
 	"github.com/rancher/rancher/pkg/controllers/management/rkeworkerupgrader"
 	"github.com/rancher/rancher/pkg/controllers/management/secretmigrator"
@@ -42,25 +54,37 @@ func Register(ctx context.Context, management *config.ManagementContext, manager
 
 	// a-z
 	agentupgrade.Register(ctx, management)
+	canary.Register(ctx, management)
 	certsexpiration.Register(ctx, management)
 	cluster.Register(ctx, management)
+	clusterarchive.Register(ctx, management)
 	clusterdeploy.Register(ctx, management, manager)
 	clustergc.Register(ctx, management)
+	clustergroup.Register(ctx, management)
+	clustermigration.Register(ctx, management, manager)
 	clusterprovisioner.Register(ctx, management)
 	clusterstats.Register(ctx, management, manager)
 	clusterstatus.Register(ctx, management)
 	kontainerdriver.Register(ctx, management)
 	kontainerdrivermetadata.Register(ctx, management)
+	lifecyclenotifier.Register(ctx, management)
+	managementgc.Register(ctx, management)
+	metering.Register(ctx, management)
 	nodedriver.Register(ctx, management)
+	nodeosadvisory.Register(ctx, management)
 	nodepool.Register(ctx, management)
+	noderegistrationbatch.Register(ctx, management)
 	cloudcredential.Register(ctx, management)
 	node.Register(ctx, management, manager)
 	podsecuritypolicy.Register(ctx, management)
 	etcdbackup.Register(ctx, management)
+	eventstream.Register(ctx, management)
 	clustertemplate.Register(ctx, management)
 	nodetemplate.Register(ctx, management)
 	rkeworkerupgrader.Register(ctx, management, manager.ScaledContext)
+	rancherconfig.Register(ctx, management)
 	rbac.Register(ctx, management)
+	report.Register(ctx, management)
 	restrictedadminrbac.Register(ctx, management, wrangler)
 	secretmigrator.Register(ctx, management)
 	settings.Register(ctx, management)
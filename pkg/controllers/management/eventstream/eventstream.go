@@ -0,0 +1,168 @@
+package eventstream
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/eventstream"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/namespace"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/rancher/wrangler/pkg/condition"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	"github.com/rancher/wrangler/pkg/ticker"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// settleDelay is how long events are held back before they're delivered, so that an event recorded slightly
+	// earlier than another one, but seen after it, is still delivered in order.
+	settleDelay = 5 * time.Second
+	// batchSize is the number of events delivered to a subscription before its progress is saved.
+	batchSize     = 100
+	pruneInterval = 10 * time.Minute
+)
+
+var delivered condition.Cond = "Delivered"
+
+type handler struct {
+	ctx           context.Context
+	subscriptions mgmtcontrollers.EventSubscriptionController
+	eventCache    mgmtcontrollers.ManagementEventCache
+	secretCache   corecontrollers.SecretCache
+}
+
+// Register registers the controllers recording management events while event subscriptions exist, and delivering them
+// to the event subscriptions.
+func Register(ctx context.Context, management *config.ManagementContext) {
+	mgmt := management.Wrangler.Mgmt
+	r := &recorder{
+		events:            mgmt.ManagementEvent(),
+		subscriptionCache: mgmt.EventSubscription().Cache(),
+		resources:         map[string]resource{},
+	}
+	mgmt.Cluster().OnChange(ctx, "event-stream-clusters", r.onCluster)
+	mgmt.Node().OnChange(ctx, "event-stream-nodes", r.onNode)
+	mgmt.User().OnChange(ctx, "event-stream-users", r.onUser)
+	mgmt.GlobalRoleBinding().OnChange(ctx, "event-stream-global-role-bindings", r.onGlobalRoleBinding)
+	mgmt.ClusterRoleTemplateBinding().OnChange(ctx, "event-stream-cluster-role-template-bindings", r.onClusterRoleTemplateBinding)
+	mgmt.ProjectRoleTemplateBinding().OnChange(ctx, "event-stream-project-role-template-bindings", r.onProjectRoleTemplateBinding)
+
+	h := &handler{
+		ctx:           ctx,
+		subscriptions: mgmt.EventSubscription(),
+		eventCache:    mgmt.ManagementEvent().Cache(),
+		secretCache:   management.Wrangler.Core.Secret().Cache(),
+	}
+	relatedresource.Watch(ctx, "event-subscription-trigger", h.resolveSubscriptions, h.subscriptions, mgmt.ManagementEvent())
+	h.subscriptions.OnChange(ctx, "event-subscription-delivery", h.onChange)
+
+	go func() {
+		for range ticker.Context(ctx, pruneInterval) {
+			if err := r.prune(h.eventCache); err != nil {
+				logrus.Errorf("[event-stream] failed to delete expired management events: %v", err)
+			}
+		}
+	}()
+}
+
+// resolveSubscriptions enqueues the subscriptions to deliver new events to.
+func (h *handler) resolveSubscriptions(_, _ string, obj runtime.Object) ([]relatedresource.Key, error) {
+	if _, ok := obj.(*v3.ManagementEvent); !ok {
+		return nil, nil
+	}
+	subscriptions, err := h.subscriptions.Cache().List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var result []relatedresource.Key
+	for _, subscription := range subscriptions {
+		if !subscription.Spec.Paused {
+			result = append(result, relatedresource.Key{Name: subscription.Name})
+		}
+	}
+	return result, nil
+}
+
+// onChange delivers the pending events of a subscription in order, saving its progress in its status so that
+// delivered events aren't delivered again. Failed deliveries are retried with the backoff of the controller, the
+// status only changing with the error of the delivery so that updating it doesn't retry right away.
+func (h *handler) onChange(_ string, subscription *v3.EventSubscription) (*v3.EventSubscription, error) {
+	if subscription == nil || subscription.DeletionTimestamp != nil || subscription.Spec.Paused {
+		return subscription, nil
+	}
+
+	updated := subscription.DeepCopy()
+	requeue, err := h.deliver(updated)
+	delivered.SetError(updated, "", err)
+	if !equality.Semantic.DeepEqual(subscription.Status, updated.Status) {
+		delivered.LastUpdated(updated, time.Now().UTC().Format(time.RFC3339))
+		result, updateErr := h.subscriptions.UpdateStatus(updated)
+		if updateErr != nil {
+			return subscription, updateErr
+		}
+		subscription = result
+	}
+	if err != nil {
+		return subscription, err
+	}
+	if requeue {
+		h.subscriptions.EnqueueAfter(subscription.Name, settleDelay)
+	}
+	return subscription, nil
+}
+
+// deliver delivers a batch of the pending events of a subscription, updating its status, and returns whether there are
+// more events to deliver.
+func (h *handler) deliver(subscription *v3.EventSubscription) (bool, error) {
+	events, err := h.eventCache.List(labels.Everything())
+	if err != nil {
+		return false, err
+	}
+	pending, unsettled := eventstream.Pending(events, subscription.Status, time.Now().Add(-settleDelay))
+	if len(pending) == 0 {
+		return unsettled, nil
+	}
+	more := unsettled || len(pending) > batchSize
+	if len(pending) > batchSize {
+		pending = pending[:batchSize]
+	}
+
+	var secret *corev1.Secret
+	if subscription.Spec.SecretName != "" {
+		secret, err = h.secretCache.Get(namespace.GlobalNamespace, subscription.Spec.SecretName)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return false, err
+		}
+	}
+	sink, err := eventstream.NewSink(subscription, secret)
+	if err != nil {
+		return false, err
+	}
+	source := settings.ServerURL.Get()
+	if source == "" {
+		source = "rancher"
+	}
+
+	status := &subscription.Status
+	for _, event := range pending {
+		if eventstream.Matches(subscription, event) {
+			if err := sink.Send(h.ctx, eventstream.ToCloudEvent(event, source)); err != nil {
+				return false, fmt.Errorf("failed to deliver event %s: %w", event.Name, err)
+			}
+			status.DeliveredCount++
+		}
+		status.LastDeliveredTime = event.Spec.Time
+		status.LastDeliveredEvent = event.Name
+	}
+	return more, nil
+}
@@ -0,0 +1,216 @@
+package eventstream
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/eventstream"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/settings"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+const creatorIDAnn = "field.cattle.io/creatorId"
+
+// resource is what is known of a resource to record its deletion, which is only observed by its key.
+type resource struct {
+	uid         string
+	clusterName string
+	data        map[string]string
+	// recorded is whether the creation of the resource was recorded, or didn't need to be.
+	recorded bool
+}
+
+// recorder records management events while event subscriptions exist.
+type recorder struct {
+	events            mgmtcontrollers.ManagementEventClient
+	subscriptionCache mgmtcontrollers.EventSubscriptionCache
+
+	lock      sync.Mutex
+	resources map[string]resource
+}
+
+func (r *recorder) enabled() (bool, error) {
+	subscriptions, err := r.subscriptionCache.List(labels.Everything())
+	return len(subscriptions) > 0, err
+}
+
+// created records the creation of a resource. Resources are observed again on every change, resync and restart, the
+// event is named after the uid of the resource so that it's only recorded once, and resources created before the
+// retention are ignored since their event would have been pruned already.
+func (r *recorder) created(eventType, subject string, obj metav1.Object, clusterName string, data map[string]string) error {
+	uid := string(obj.GetUID())
+	r.lock.Lock()
+	known := r.resources[subject]
+	r.resources[subject] = resource{uid: uid, clusterName: clusterName, data: data, recorded: known.uid == uid && known.recorded}
+	r.lock.Unlock()
+	if known.uid == uid && known.recorded {
+		return nil
+	}
+
+	if !obj.GetCreationTimestamp().Time.Before(time.Now().Add(-retention())) {
+		if err := r.record(eventType, subject, uid, clusterName, data); err != nil {
+			return err
+		}
+	}
+
+	r.lock.Lock()
+	if res, ok := r.resources[subject]; ok && res.uid == uid {
+		res.recorded = true
+		r.resources[subject] = res
+	}
+	r.lock.Unlock()
+	return nil
+}
+
+// deleted records the deletion of a resource, with the data known from its creation.
+func (r *recorder) deleted(eventType, subject, clusterName string) error {
+	r.lock.Lock()
+	res, ok := r.resources[subject]
+	delete(r.resources, subject)
+	r.lock.Unlock()
+
+	if !ok {
+		// the resource was deleted before it was observed, there's nothing to deduplicate its event with
+		res = resource{uid: string(uuid.NewUUID()), clusterName: clusterName}
+	}
+	return r.record(eventType, subject, res.uid, res.clusterName, res.data)
+}
+
+func (r *recorder) record(eventType, subject, uid, clusterName string, data map[string]string) error {
+	if ok, err := r.enabled(); err != nil || !ok {
+		return err
+	}
+	_, err := r.events.Create(&v3.ManagementEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ReplaceAll(eventType, ".", "-") + "-" + uid,
+		},
+		Spec: v3.ManagementEventSpec{
+			Type:        eventType,
+			Time:        time.Now().UTC().Format(time.RFC3339Nano),
+			Subject:     subject,
+			ClusterName: clusterName,
+			Data:        data,
+		},
+	})
+	if apierrors.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+func (r *recorder) onCluster(key string, cluster *v3.Cluster) (*v3.Cluster, error) {
+	subject := "clusters/" + key
+	if cluster == nil {
+		return nil, r.deleted(eventstream.ClusterDeleted, subject, key)
+	}
+	return cluster, r.created(eventstream.ClusterCreated, subject, cluster, cluster.Name, map[string]string{
+		"displayName": cluster.Spec.DisplayName,
+		"creatorId":   cluster.Annotations[creatorIDAnn],
+	})
+}
+
+func (r *recorder) onNode(key string, node *v3.Node) (*v3.Node, error) {
+	subject := "nodes/" + key
+	if node == nil {
+		return nil, r.deleted(eventstream.NodeDeleted, subject, strings.SplitN(key, "/", 2)[0])
+	}
+	var roles []string
+	if node.Spec.Etcd {
+		roles = append(roles, "etcd")
+	}
+	if node.Spec.ControlPlane {
+		roles = append(roles, "controlplane")
+	}
+	if node.Spec.Worker {
+		roles = append(roles, "worker")
+	}
+	return node, r.created(eventstream.NodeCreated, subject, node, node.Namespace, map[string]string{
+		"hostname":     node.Spec.RequestedHostname,
+		"nodePoolName": node.Spec.NodePoolName,
+		"roles":        strings.Join(roles, ","),
+	})
+}
+
+func (r *recorder) onUser(key string, user *v3.User) (*v3.User, error) {
+	subject := "users/" + key
+	if user == nil {
+		return nil, r.deleted(eventstream.UserDeleted, subject, "")
+	}
+	return user, r.created(eventstream.UserCreated, subject, user, "", map[string]string{
+		"username":    user.Username,
+		"displayName": user.DisplayName,
+	})
+}
+
+func (r *recorder) onGlobalRoleBinding(key string, binding *v3.GlobalRoleBinding) (*v3.GlobalRoleBinding, error) {
+	subject := "globalrolebindings/" + key
+	if binding == nil {
+		return nil, r.deleted(eventstream.RoleBindingDeleted, subject, "")
+	}
+	return binding, r.created(eventstream.RoleBindingCreated, subject, binding, "", map[string]string{
+		"kind":               "GlobalRoleBinding",
+		"userName":           binding.UserName,
+		"groupPrincipalName": binding.GroupPrincipalName,
+		"globalRoleName":     binding.GlobalRoleName,
+	})
+}
+
+func (r *recorder) onClusterRoleTemplateBinding(key string, binding *v3.ClusterRoleTemplateBinding) (*v3.ClusterRoleTemplateBinding, error) {
+	subject := "clusterroletemplatebindings/" + key
+	if binding == nil {
+		return nil, r.deleted(eventstream.RoleBindingDeleted, subject, strings.SplitN(key, "/", 2)[0])
+	}
+	return binding, r.created(eventstream.RoleBindingCreated, subject, binding, binding.ClusterName, map[string]string{
+		"kind":               "ClusterRoleTemplateBinding",
+		"userName":           binding.UserName,
+		"groupPrincipalName": binding.GroupPrincipalName,
+		"roleTemplateName":   binding.RoleTemplateName,
+	})
+}
+
+func (r *recorder) onProjectRoleTemplateBinding(key string, binding *v3.ProjectRoleTemplateBinding) (*v3.ProjectRoleTemplateBinding, error) {
+	subject := "projectroletemplatebindings/" + key
+	if binding == nil {
+		return nil, r.deleted(eventstream.RoleBindingDeleted, subject, "")
+	}
+	return binding, r.created(eventstream.RoleBindingCreated, subject, binding, binding.ObjClusterName(), map[string]string{
+		"kind":               "ProjectRoleTemplateBinding",
+		"userName":           binding.UserName,
+		"groupPrincipalName": binding.GroupPrincipalName,
+		"projectName":        binding.ProjectName,
+		"roleTemplateName":   binding.RoleTemplateName,
+	})
+}
+
+// prune deletes the events past the retention.
+func (r *recorder) prune(eventCache mgmtcontrollers.ManagementEventCache) error {
+	events, err := eventCache.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	expiry := time.Now().Add(-retention())
+	for _, event := range events {
+		t, err := eventstream.EventTime(event)
+		if err == nil && !t.Before(expiry) {
+			continue
+		}
+		if err := r.events.Delete(event.Name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func retention() time.Duration {
+	hours := settings.EventStreamRetentionHours.GetInt()
+	if hours <= 0 {
+		hours = 24
+	}
+	return time.Duration(hours) * time.Hour
+}
@@ -41,6 +41,8 @@ type MetadataURL struct {
 	// latestHash, isGit set in parseURL
 	latestHash string
 	isGit      bool
+	// verified is set if the data is loaded from a bundle or verified against a public key.
+	verified channelserver.MetadataConfig
 }
 
 const (
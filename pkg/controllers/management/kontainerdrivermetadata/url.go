@@ -8,6 +8,7 @@
 	"strings"
 
 	"github.com/rancher/norman/types/convert"
+	"github.com/rancher/rancher/pkg/channelserver"
 	"github.com/rancher/rancher/pkg/git"
 	"github.com/rancher/rancher/pkg/settings"
 	"github.com/rancher/rke/types/kdm"
@@ -17,6 +18,19 @@
 
 func parseURL(rkeData map[string]interface{}) (*MetadataURL, error) {
 	url := &MetadataURL{}
+	if bundle := convert.ToString(rkeData["bundle"]); bundle != "" || convert.ToString(rkeData["public-key"]) != "" {
+		url.verified = channelserver.MetadataConfig{
+			URL:          convert.ToString(rkeData["url"]),
+			Bundle:       bundle,
+			PublicKey:    convert.ToString(rkeData["public-key"]),
+			SignatureURL: convert.ToString(rkeData["signature-url"]),
+		}
+		url.path = url.verified.URL
+		if bundle != "" {
+			url.path = bundle
+		}
+		return url, nil
+	}
 	path, ok := rkeData["url"]
 	if !ok {
 		return nil, fmt.Errorf("url not present in settings %s", settings.RkeMetadataConfig.Get())
@@ -39,6 +53,9 @@ func parseURL(rkeData map[string]interface{}) (*MetadataURL, error) {
 }
 
 func loadData(url *MetadataURL) (kdm.Data, error) {
+	if url.verified.Verified() {
+		return getDataVerified(url.verified)
+	}
 	if url.isGit {
 		return getDataGit(url.path, url.branch)
 	}
@@ -67,6 +84,20 @@ func getDataHTTP(url string) (kdm.Data, error) {
 	return data, nil
 }
 
+// getDataVerified returns the KDM data of a bundle, or verified against the public key of the rke-metadata-config
+// setting.
+func getDataVerified(config channelserver.MetadataConfig) (kdm.Data, error) {
+	var data kdm.Data
+	content, err := channelserver.LoadMetadata(config)
+	if err != nil {
+		return data, fmt.Errorf("driverMetadata %v", err)
+	}
+	if err := json.Unmarshal(content, &data); err != nil {
+		return data, fmt.Errorf("driverMetadata %v", err)
+	}
+	return data, nil
+}
+
 func getDataGit(urlPath, branch string) (kdm.Data, error) {
 	var data kdm.Data
 
@@ -0,0 +1,85 @@
+package lifecyclenotifier
+
+import (
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/notifiers"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// clusterState is the part of a cluster lifecycle events are detected from.
+type clusterState struct {
+	provisioned       bool
+	provisioningError string
+	version           string
+	certificates      map[string]string
+}
+
+func clusterStateOf(cluster *v3.Cluster) clusterState {
+	state := clusterState{
+		provisioned:  v32.ClusterConditionProvisioned.IsTrue(cluster),
+		certificates: map[string]string{},
+	}
+	if v32.ClusterConditionProvisioned.IsFalse(cluster) {
+		state.provisioningError = v32.ClusterConditionProvisioned.GetMessage(cluster)
+	}
+	if cluster.Status.Version != nil {
+		state.version = cluster.Status.Version.GitVersion
+	}
+	for name, expiration := range cluster.Status.CertificatesExpiration {
+		state.certificates[name] = expiration.ExpirationDate
+	}
+	return state
+}
+
+// clusterEvents returns the events of the transition of a cluster from the previous to the current state. The cluster
+// fields of the events are left to the caller.
+func clusterEvents(previous, current clusterState) []notifiers.LifecycleEvent {
+	var events []notifiers.LifecycleEvent
+
+	if current.provisioned && !previous.provisioned {
+		events = append(events, notifiers.LifecycleEvent{Type: notifiers.ClusterProvisioned})
+	}
+
+	if current.provisioningError != "" && current.provisioningError != previous.provisioningError {
+		events = append(events, notifiers.LifecycleEvent{
+			Type:    notifiers.ClusterProvisioningFailed,
+			Message: current.provisioningError,
+		})
+	}
+
+	if previous.version != "" && current.version != "" && previous.version != current.version {
+		events = append(events, notifiers.LifecycleEvent{
+			Type:    notifiers.ClusterUpgraded,
+			Version: current.version,
+		})
+	}
+
+	if certificatesRotated(previous.certificates, current.certificates) {
+		events = append(events, notifiers.LifecycleEvent{Type: notifiers.CertificatesRotated})
+	}
+
+	return events
+}
+
+// certificatesRotated returns true if the expiration date of a certificate present in both states changed. Certificates
+// that are added or removed, e.g. when nodes are added or removed, are not a rotation.
+func certificatesRotated(previous, current map[string]string) bool {
+	for name, expiration := range current {
+		if previousExpiration, ok := previous[name]; ok && previousExpiration != expiration {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeUnreachable returns true and the message of the Ready condition of the node when its status is unknown, which is
+// what the node lifecycle controller of kubernetes sets when the kubelet stops reporting.
+func nodeUnreachable(node *v3.Node) (bool, string) {
+	for _, cond := range node.Status.InternalNodeStatus.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return cond.Status == corev1.ConditionUnknown, cond.Message
+		}
+	}
+	return false, ""
+}
@@ -0,0 +1,124 @@
+package lifecyclenotifier
+
+import (
+	"testing"
+
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/notifiers"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+func TestClusterEvents(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous clusterState
+		current  clusterState
+		want     []notifiers.LifecycleEvent
+	}{
+		{
+			name:     "no change",
+			previous: clusterState{provisioned: true, version: "v1.24.8"},
+			current:  clusterState{provisioned: true, version: "v1.24.8"},
+		},
+		{
+			name:     "provisioned",
+			previous: clusterState{},
+			current:  clusterState{provisioned: true, version: "v1.24.8"},
+			want:     []notifiers.LifecycleEvent{{Type: notifiers.ClusterProvisioned}},
+		},
+		{
+			name:     "provisioning failed",
+			previous: clusterState{},
+			current:  clusterState{provisioningError: "no etcd nodes"},
+			want: []notifiers.LifecycleEvent{
+				{Type: notifiers.ClusterProvisioningFailed, Message: "no etcd nodes"},
+			},
+		},
+		{
+			name:     "same provisioning error",
+			previous: clusterState{provisioningError: "no etcd nodes"},
+			current:  clusterState{provisioningError: "no etcd nodes"},
+		},
+		{
+			name:     "upgraded",
+			previous: clusterState{provisioned: true, version: "v1.24.8"},
+			current:  clusterState{provisioned: true, version: "v1.25.4"},
+			want: []notifiers.LifecycleEvent{
+				{Type: notifiers.ClusterUpgraded, Version: "v1.25.4"},
+			},
+		},
+		{
+			name:     "first version is not an upgrade",
+			previous: clusterState{provisioned: true},
+			current:  clusterState{provisioned: true, version: "v1.25.4"},
+		},
+		{
+			name:     "certificates rotated",
+			previous: clusterState{certificates: map[string]string{"kube-apiserver": "2023-01-01T00:00:00Z"}},
+			current:  clusterState{certificates: map[string]string{"kube-apiserver": "2024-01-01T00:00:00Z"}},
+			want:     []notifiers.LifecycleEvent{{Type: notifiers.CertificatesRotated}},
+		},
+		{
+			name:     "certificate added",
+			previous: clusterState{certificates: map[string]string{"kube-apiserver": "2023-01-01T00:00:00Z"}},
+			current: clusterState{certificates: map[string]string{
+				"kube-apiserver":   "2023-01-01T00:00:00Z",
+				"kube-node-worker": "2023-01-01T00:00:00Z",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, clusterEvents(tt.previous, tt.current))
+		})
+	}
+}
+
+func TestClusterStateOf(t *testing.T) {
+	cluster := &v3.Cluster{
+		Status: v32.ClusterStatus{
+			Version: &version.Info{GitVersion: "v1.25.4"},
+			CertificatesExpiration: map[string]v32.CertExpiration{
+				"kube-apiserver": {ExpirationDate: "2024-01-01T00:00:00Z"},
+			},
+		},
+	}
+	v32.ClusterConditionProvisioned.False(cluster)
+	v32.ClusterConditionProvisioned.Message(cluster, "no etcd nodes")
+
+	assert.Equal(t, clusterState{
+		provisioningError: "no etcd nodes",
+		version:           "v1.25.4",
+		certificates:      map[string]string{"kube-apiserver": "2024-01-01T00:00:00Z"},
+	}, clusterStateOf(cluster))
+
+	v32.ClusterConditionProvisioned.True(cluster)
+	state := clusterStateOf(cluster)
+	assert.True(t, state.provisioned)
+	assert.Empty(t, state.provisioningError)
+}
+
+func TestNodeUnreachable(t *testing.T) {
+	node := &v3.Node{}
+	unreachable, _ := nodeUnreachable(node)
+	assert.False(t, unreachable)
+
+	node.Status.InternalNodeStatus.Conditions = []corev1.NodeCondition{
+		{
+			Type:    corev1.NodeReady,
+			Status:  corev1.ConditionUnknown,
+			Message: "Kubelet stopped posting node status.",
+		},
+	}
+	unreachable, message := nodeUnreachable(node)
+	assert.True(t, unreachable)
+	assert.Equal(t, "Kubelet stopped posting node status.", message)
+
+	node.Status.InternalNodeStatus.Conditions[0].Status = corev1.ConditionTrue
+	unreachable, _ = nodeUnreachable(node)
+	assert.False(t, unreachable)
+}
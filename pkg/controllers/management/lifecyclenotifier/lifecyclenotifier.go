@@ -0,0 +1,224 @@
+package lifecyclenotifier
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	provisioningcontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	v1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/notifiers"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/rancher/rancher/pkg/types/config/dialer"
+	rketypes "github.com/rancher/rke/types"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	localCluster = "local"
+
+	// snapshotFailed is the status of the snapshot file of a failed rke2/k3s etcd snapshot.
+	snapshotFailed = "failed"
+)
+
+// Register starts the handlers sending cluster lifecycle events to the notifiers of the cluster that have lifecycle
+// event routes. Events are only sent for transitions observed by this process: the first state observed for an object
+// after startup is recorded without sending anything, so events aren't sent again when rancher restarts.
+func Register(ctx context.Context, management *config.ManagementContext) {
+	secretLister := management.Core.Secrets("").Controller().Lister()
+	n := &lifecycleNotifier{
+		ctx:              ctx,
+		clusterLister:    management.Management.Clusters("").Controller().Lister(),
+		notifierLister:   management.Management.Notifiers("").Controller().Lister(),
+		secretLister:     &secretLister,
+		dialerFactory:    management.Dialer,
+		provClusterCache: management.Wrangler.Provisioning.Cluster().Cache(),
+		clusters:         newStates(),
+		nodes:            newStates(),
+		etcdBackups:      newStates(),
+		etcdSnapshots:    newStates(),
+	}
+
+	management.Management.Clusters("").AddHandler(ctx, "lifecycle-notifier", n.syncCluster)
+	management.Management.Nodes("").AddHandler(ctx, "lifecycle-notifier", n.syncNode)
+	management.Management.EtcdBackups("").AddHandler(ctx, "lifecycle-notifier", n.syncEtcdBackup)
+	management.Wrangler.RKE.ETCDSnapshot().OnChange(ctx, "lifecycle-notifier", n.syncETCDSnapshot)
+}
+
+type lifecycleNotifier struct {
+	ctx              context.Context
+	clusterLister    v3.ClusterLister
+	notifierLister   v3.NotifierLister
+	secretLister     *v1.SecretLister
+	dialerFactory    dialer.Factory
+	provClusterCache provisioningcontrollers.ClusterCache
+	clusters         *states
+	nodes            *states
+	etcdBackups      *states
+	etcdSnapshots    *states
+}
+
+func (n *lifecycleNotifier) syncCluster(key string, cluster *v3.Cluster) (runtime.Object, error) {
+	if cluster == nil || cluster.DeletionTimestamp != nil {
+		n.clusters.delete(key)
+		return cluster, nil
+	}
+
+	current := clusterStateOf(cluster)
+	previous, ok := n.clusters.swap(key, current)
+	if !ok {
+		return cluster, nil
+	}
+
+	for _, event := range clusterEvents(previous.(clusterState), current) {
+		event.ClusterID = cluster.Name
+		event.ClusterName = cluster.Spec.DisplayName
+		n.notify(event)
+	}
+	return cluster, nil
+}
+
+func (n *lifecycleNotifier) syncNode(key string, node *v3.Node) (runtime.Object, error) {
+	if node == nil || node.DeletionTimestamp != nil {
+		n.nodes.delete(key)
+		return node, nil
+	}
+
+	unreachable, message := nodeUnreachable(node)
+	previous, ok := n.nodes.swap(key, unreachable)
+	if ok && unreachable && !previous.(bool) {
+		nodeName := node.Status.NodeName
+		if nodeName == "" {
+			nodeName = node.Name
+		}
+		n.notify(notifiers.LifecycleEvent{
+			Type:      notifiers.NodeUnreachable,
+			ClusterID: node.Namespace,
+			Object:    nodeName,
+			Message:   message,
+		})
+	}
+	return node, nil
+}
+
+func (n *lifecycleNotifier) syncEtcdBackup(key string, backup *v3.EtcdBackup) (runtime.Object, error) {
+	if backup == nil || backup.DeletionTimestamp != nil {
+		n.etcdBackups.delete(key)
+		return backup, nil
+	}
+
+	failed := rketypes.BackupConditionCompleted.IsFalse(backup)
+	previous, ok := n.etcdBackups.swap(key, failed)
+	if ok && failed && !previous.(bool) {
+		n.notify(notifiers.LifecycleEvent{
+			Type:      notifiers.EtcdSnapshotFailed,
+			ClusterID: backup.Spec.ClusterID,
+			Object:    backup.Name,
+			Message:   rketypes.BackupConditionCompleted.GetMessage(backup),
+		})
+	}
+	return backup, nil
+}
+
+func (n *lifecycleNotifier) syncETCDSnapshot(key string, snapshot *rkev1.ETCDSnapshot) (*rkev1.ETCDSnapshot, error) {
+	if snapshot == nil || snapshot.DeletionTimestamp != nil {
+		n.etcdSnapshots.delete(key)
+		return snapshot, nil
+	}
+
+	failed := snapshot.SnapshotFile.Status == snapshotFailed
+	previous, ok := n.etcdSnapshots.swap(key, failed)
+	if !ok || !failed || previous.(bool) {
+		return snapshot, nil
+	}
+
+	cluster, err := n.provClusterCache.Get(snapshot.Namespace, snapshot.Spec.ClusterName)
+	if err != nil {
+		// restore the previous state so the event is sent when the handler is retried
+		n.etcdSnapshots.swap(key, previous)
+		return snapshot, err
+	}
+	n.notify(notifiers.LifecycleEvent{
+		Type:      notifiers.EtcdSnapshotFailed,
+		ClusterID: cluster.Status.ClusterName,
+		Object:    snapshot.SnapshotFile.Name,
+		Message:   snapshot.SnapshotFile.Message,
+	})
+	return snapshot, nil
+}
+
+// notify sends the event to every route of the notifiers in the namespace of its cluster. Messages are sent in the
+// background and failures are logged, since handlers can't retry an event once its transition has been recorded.
+func (n *lifecycleNotifier) notify(event notifiers.LifecycleEvent) {
+	if event.ClusterID == "" {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+	if event.ClusterName == "" {
+		event.ClusterName = event.ClusterID
+		if cluster, err := n.clusterLister.Get("", event.ClusterID); err == nil && cluster.Spec.DisplayName != "" {
+			event.ClusterName = cluster.Spec.DisplayName
+		}
+	}
+
+	notifierList, err := n.notifierLister.List(event.ClusterID, labels.Everything())
+	if err != nil {
+		logrus.Errorf("[lifecycle-notifier] failed to list notifiers of cluster %s: %v", event.ClusterID, err)
+		return
+	}
+
+	for _, notifier := range notifierList {
+		messages, err := notifiers.RouteLifecycleEvent(notifier, event)
+		if err != nil {
+			logrus.Errorf("[lifecycle-notifier] %v", err)
+			continue
+		}
+		for _, routed := range messages {
+			go n.send(notifier, routed, event)
+		}
+	}
+}
+
+func (n *lifecycleNotifier) send(notifier *v3.Notifier, routed notifiers.RoutedMessage, event notifiers.LifecycleEvent) {
+	clusterDialer, err := n.dialerFactory.ClusterDialer(localCluster)
+	if err != nil {
+		logrus.Errorf("[lifecycle-notifier] failed to get dialer: %v", err)
+		return
+	}
+
+	if err := notifiers.SendMessage(n.ctx, notifier, routed.Recipient, routed.Message, clusterDialer, n.secretLister); err != nil {
+		logrus.Errorf("[lifecycle-notifier] failed to send %s event of cluster %s to notifier %s/%s: %v",
+			event.Type, event.ClusterID, notifier.Namespace, notifier.Name, err)
+	}
+}
+
+// states holds the last observed state of each object of a kind, by handler key.
+type states struct {
+	sync.Mutex
+	values map[string]interface{}
+}
+
+func newStates() *states {
+	return &states{values: map[string]interface{}{}}
+}
+
+// swap stores the current state of the key and returns its previous state, and whether there was one.
+func (s *states) swap(key string, current interface{}) (interface{}, bool) {
+	s.Lock()
+	defer s.Unlock()
+	previous, ok := s.values[key]
+	s.values[key] = current
+	return previous, ok
+}
+
+func (s *states) delete(key string) {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.values, key)
+}
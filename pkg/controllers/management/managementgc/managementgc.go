@@ -0,0 +1,57 @@
+package managementgc
+
+import (
+	"context"
+	"time"
+
+	"github.com/rancher/rancher/pkg/managementgc"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/rancher/wrangler/pkg/ticker"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	syncInterval = time.Hour
+	logPrefix    = "[management-gc]"
+)
+
+// Register registers the collection of the orphaned objects of the management cluster, periodically reporting or
+// deleting them as configured by the management-gc-policy setting.
+func Register(ctx context.Context, management *config.ManagementContext) {
+	go func() {
+		var collector *managementgc.Collector
+		for range ticker.Context(ctx, syncInterval) {
+			policy := settings.ManagementGCPolicy.Get()
+			if policy != "report" && policy != "delete" {
+				continue
+			}
+			if collector == nil {
+				var err error
+				if collector, err = managementgc.NewCollector(management.Wrangler); err != nil {
+					logrus.Errorf("%s failed to create collector: %v", logPrefix, err)
+					continue
+				}
+			}
+			collect(ctx, collector, policy == "report")
+		}
+	}()
+}
+
+func collect(ctx context.Context, collector *managementgc.Collector, dryRun bool) {
+	result, err := collector.Collect(ctx, nil, dryRun, time.Now())
+	if err != nil {
+		logrus.Errorf("%s failed to collect orphaned objects: %v", logPrefix, err)
+		return
+	}
+	for _, candidate := range result.Candidates {
+		switch {
+		case candidate.Error != "":
+			logrus.Errorf("%s failed to delete %s %s/%s: %s", logPrefix, candidate.Kind, candidate.Namespace, candidate.Name, candidate.Error)
+		case candidate.Deleted:
+			logrus.Infof("%s deleted %s %s/%s: %s", logPrefix, candidate.Kind, candidate.Namespace, candidate.Name, candidate.Reason)
+		default:
+			logrus.Infof("%s orphaned %s %s/%s: %s", logPrefix, candidate.Kind, candidate.Namespace, candidate.Name, candidate.Reason)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package metering
+
+import (
+	"context"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/metering"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/rancher/wrangler/pkg/ticker"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	sampleInterval = 5 * time.Minute
+	logPrefix      = "[metering]"
+)
+
+type sampler struct {
+	clusterCache mgmtcontrollers.ClusterCache
+	projectCache mgmtcontrollers.ProjectCache
+	records      mgmtcontrollers.MeteringRecordClient
+	recordCache  mgmtcontrollers.MeteringRecordCache
+	lastSampled  time.Time
+}
+
+// Register starts sampling the usage of clusters and projects into metering records while metering is enabled, and
+// deleting the records past the metering retention.
+func Register(ctx context.Context, management *config.ManagementContext) {
+	mgmt := management.Wrangler.Mgmt
+	s := &sampler{
+		clusterCache: mgmt.Cluster().Cache(),
+		projectCache: mgmt.Project().Cache(),
+		records:      mgmt.MeteringRecord(),
+		recordCache:  mgmt.MeteringRecord().Cache(),
+	}
+	go s.run(ctx)
+}
+
+func (s *sampler) run(ctx context.Context) {
+	for range ticker.Context(ctx, sampleInterval) {
+		if settings.MeteringEnabled.Get() != "true" {
+			s.lastSampled = time.Time{}
+			continue
+		}
+		now := time.Now()
+		if err := s.sample(now); err != nil {
+			logrus.Errorf("%s failed to record usage: %v", logPrefix, err)
+		}
+		if err := s.prune(now); err != nil {
+			logrus.Errorf("%s failed to delete expired metering records: %v", logPrefix, err)
+		}
+	}
+}
+
+// sample adds the current usage of clusters and projects to the metering record of the current period.
+func (s *sampler) sample(now time.Time) error {
+	clusters, err := s.clusterCache.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	projects, err := s.projectCache.List("", labels.Everything())
+	if err != nil {
+		return err
+	}
+	sample := metering.TakeSample(clusters, projects, settings.MeteringTeamLabel.Get())
+
+	aggregation := settings.MeteringAggregation.Get()
+	if aggregation != v3.MeteringAggregationHourly {
+		aggregation = v3.MeteringAggregationDaily
+	}
+	start, end := metering.Period(now, aggregation)
+	name := metering.RecordName(start, aggregation)
+
+	record, err := s.recordCache.Get(name)
+	if apierrors.IsNotFound(err) {
+		record = &v3.MeteringRecord{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v3.MeteringRecordSpec{
+				Aggregation: aggregation,
+				Start:       start.Format(time.RFC3339),
+				End:         end.Format(time.RFC3339),
+			},
+		}
+	} else if err != nil {
+		return err
+	} else {
+		record = record.DeepCopy()
+	}
+
+	lastSampled := s.lastSampled
+	if lastSampled.IsZero() && record.Spec.LastSampled != "" {
+		// rancher restarted or another replica took over, continue from the last sample of the record
+		lastSampled, _ = time.Parse(time.RFC3339, record.Spec.LastSampled)
+	}
+	metering.Add(&record.Spec, sample, metering.SampleDuration(lastSampled, now, sampleInterval))
+	record.Spec.LastSampled = now.UTC().Format(time.RFC3339)
+
+	if record.ResourceVersion == "" {
+		_, err = s.records.Create(record)
+	} else {
+		_, err = s.records.Update(record)
+	}
+	if err != nil {
+		return err
+	}
+	s.lastSampled = now
+	return nil
+}
+
+// prune deletes the metering records past the metering retention.
+func (s *sampler) prune(now time.Time) error {
+	retention := time.Duration(settings.MeteringRetentionDays.GetInt()) * 24 * time.Hour
+	if retention <= 0 {
+		return nil
+	}
+	records, err := s.recordCache.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		expired, err := metering.Expired(record, now, retention)
+		if err != nil {
+			logrus.Warnf("%s %v", logPrefix, err)
+			continue
+		}
+		if !expired {
+			continue
+		}
+		if err := s.records.Delete(record.Name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
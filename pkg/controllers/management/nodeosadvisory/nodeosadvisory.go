@@ -0,0 +1,244 @@
+package nodeosadvisory
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/metrics"
+	"github.com/rancher/rancher/pkg/osadvisory"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/rancher/wrangler/pkg/condition"
+	"github.com/rancher/wrangler/pkg/ticker"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	logPrefix = "[node-os-advisory]"
+	// checkInterval is how often the settings are checked for changes.
+	checkInterval = time.Minute
+	// refreshInterval is how often the advisory feed is reloaded.
+	refreshInterval = 6 * time.Hour
+	// maxNodeNames is the number of node names listed in the messages of the conditions.
+	maxNodeNames = 5
+	maxFeedSize  = 10 << 20
+)
+
+var httpClient = &http.Client{
+	Timeout: 30 * time.Second,
+}
+
+type handler struct {
+	clusters     mgmtcontrollers.ClusterController
+	clusterCache mgmtcontrollers.ClusterCache
+	nodeCache    mgmtcontrollers.NodeCache
+
+	lock       sync.RWMutex
+	advisories *osadvisory.Advisories
+	selected   []string
+	// url and loaded are the URL the advisories were loaded from and when.
+	url    string
+	loaded time.Time
+}
+
+// Register registers a controller checking the OS releases and kernels of the nodes of the clusters against the advisory
+// feed of the node-os-advisory-feed-url setting, surfacing the nodes on end-of-life OS releases and affected by CVEs
+// in the NodeOSSupported and NodeKernelsPatched conditions and in metrics of the clusters.
+func Register(ctx context.Context, management *config.ManagementContext) {
+	mgmt := management.Wrangler.Mgmt
+	h := &handler{
+		clusters:     mgmt.Cluster(),
+		clusterCache: mgmt.Cluster().Cache(),
+		nodeCache:    mgmt.Node().Cache(),
+	}
+	mgmt.Cluster().OnChange(ctx, "node-os-advisory", h.onClusterChange)
+	mgmt.Node().OnChange(ctx, "node-os-advisory", h.onNodeChange)
+
+	go func() {
+		for range ticker.Context(ctx, checkInterval) {
+			h.refresh(time.Now())
+		}
+	}()
+}
+
+// refresh reloads the advisory feed when its URL or the selected CVEs change and every refreshInterval, and enqueues
+// the clusters if the advisories changed.
+func (h *handler) refresh(now time.Time) {
+	url := strings.TrimSpace(settings.NodeOSAdvisoryFeedURL.Get())
+	selected := selectedCVEs(settings.NodeOSAdvisoryCVEs.Get())
+
+	h.lock.RLock()
+	unchanged := url == h.url && reflect.DeepEqual(selected, h.selected) && now.Sub(h.loaded) < refreshInterval
+	h.lock.RUnlock()
+	if unchanged {
+		return
+	}
+
+	var advisories *osadvisory.Advisories
+	if url != "" {
+		var err error
+		if advisories, err = loadFeed(url); err != nil {
+			logrus.Errorf("%s failed to load advisory feed %s: %v", logPrefix, url, err)
+			return
+		}
+	}
+
+	h.lock.Lock()
+	h.advisories = advisories
+	h.selected = selected
+	h.url = url
+	h.loaded = now
+	h.lock.Unlock()
+
+	clusters, err := h.clusterCache.List(labels.Everything())
+	if err != nil {
+		logrus.Errorf("%s failed to list clusters: %v", logPrefix, err)
+		return
+	}
+	for _, cluster := range clusters {
+		h.clusters.Enqueue(cluster.Name)
+	}
+}
+
+func loadFeed(url string) (*osadvisory.Advisories, error) {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxFeedSize))
+	if err != nil {
+		return nil, err
+	}
+	return osadvisory.Parse(data)
+}
+
+func selectedCVEs(value string) []string {
+	var result []string
+	for _, id := range strings.Split(value, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+func (h *handler) onNodeChange(_ string, node *v3.Node) (*v3.Node, error) {
+	if node != nil {
+		h.clusters.Enqueue(node.Namespace)
+	}
+	return node, nil
+}
+
+func (h *handler) onClusterChange(key string, cluster *v3.Cluster) (*v3.Cluster, error) {
+	if cluster == nil || cluster.DeletionTimestamp != nil {
+		metrics.RemoveNodeOSAdvisories(key)
+		return cluster, nil
+	}
+
+	h.lock.RLock()
+	advisories, selected := h.advisories, h.selected
+	h.lock.RUnlock()
+
+	updated := cluster.DeepCopy()
+	if advisories == nil {
+		removeCondition(updated, v3.ClusterConditionNodeOSSupported)
+		removeCondition(updated, v3.ClusterConditionNodeKernelsPatched)
+		metrics.RemoveNodeOSAdvisories(cluster.Name)
+	} else {
+		nodes, err := h.nodeCache.List(cluster.Name, labels.Everything())
+		if err != nil {
+			return cluster, err
+		}
+		var osNodes []osadvisory.Node
+		for _, node := range nodes {
+			info := node.Status.InternalNodeStatus.NodeInfo
+			if info.OSImage == "" {
+				continue
+			}
+			name := node.Status.NodeName
+			if name == "" {
+				name = node.Name
+			}
+			osNodes = append(osNodes, osadvisory.Node{
+				Name:          name,
+				OSImage:       info.OSImage,
+				KernelVersion: info.KernelVersion,
+			})
+		}
+		summary := advisories.Evaluate(osNodes, selected, time.Now())
+		setConditions(updated, summary)
+
+		cveNodes := map[string]int{}
+		for id, names := range summary.CVENodes {
+			cveNodes[id] = len(names)
+		}
+		metrics.SetNodeOSAdvisories(cluster.Name, len(summary.EOLNodes), cveNodes)
+	}
+
+	if reflect.DeepEqual(cluster.Status.Conditions, updated.Status.Conditions) {
+		return cluster, nil
+	}
+	return h.clusters.Update(updated)
+}
+
+// setConditions sets the NodeOSSupported and NodeKernelsPatched conditions of the cluster from the summary of its nodes.
+func setConditions(cluster *v3.Cluster, summary osadvisory.Summary) {
+	if len(summary.EOLNodes) == 0 {
+		v3.ClusterConditionNodeOSSupported.True(cluster)
+		v3.ClusterConditionNodeOSSupported.Message(cluster, "")
+	} else {
+		v3.ClusterConditionNodeOSSupported.False(cluster)
+		v3.ClusterConditionNodeOSSupported.Message(cluster, fmt.Sprintf("%d of %d nodes run an end-of-life OS release: %s",
+			len(summary.EOLNodes), summary.Nodes, nodeNames(summary.EOLNodes)))
+	}
+
+	if len(summary.CVENodes) == 0 {
+		v3.ClusterConditionNodeKernelsPatched.True(cluster)
+		v3.ClusterConditionNodeKernelsPatched.Message(cluster, "")
+		return
+	}
+	var ids []string
+	for id := range summary.CVENodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	var messages []string
+	for _, id := range ids {
+		messages = append(messages, fmt.Sprintf("%s affects %d of %d nodes: %s", id, len(summary.CVENodes[id]), summary.Nodes,
+			nodeNames(summary.CVENodes[id])))
+	}
+	v3.ClusterConditionNodeKernelsPatched.False(cluster)
+	v3.ClusterConditionNodeKernelsPatched.Message(cluster, strings.Join(messages, "; "))
+}
+
+// nodeNames lists the first names, and how many more nodes there are.
+func nodeNames(names []string) string {
+	if len(names) <= maxNodeNames {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(names[:maxNodeNames], ", "), len(names)-maxNodeNames)
+}
+
+func removeCondition(cluster *v3.Cluster, cond condition.Cond) {
+	var conditions []v3.ClusterCondition
+	for _, c := range cluster.Status.Conditions {
+		if string(c.Type) != string(cond) {
+			conditions = append(conditions, c)
+		}
+	}
+	cluster.Status.Conditions = conditions
+}
@@ -0,0 +1,138 @@
+package noderegistrationbatch
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// roles are the roles a node can register with, in the order of their flags in the registration command.
+var roles = []string{"etcd", "controlplane", "worker"}
+
+// validate returns an error if a node of the batch isn't valid, or if two nodes have the same hostname or address.
+func validate(spec v3.NodeRegistrationBatchSpec) error {
+	hostnames := map[string]bool{}
+	addresses := map[string]string{}
+	for _, node := range spec.Nodes {
+		if errs := validation.IsDNS1123Subdomain(node.Hostname); len(errs) > 0 {
+			return fmt.Errorf("invalid hostname %q: %s", node.Hostname, strings.Join(errs, ", "))
+		}
+		if hostnames[node.Hostname] {
+			return fmt.Errorf("duplicate hostname %s", node.Hostname)
+		}
+		hostnames[node.Hostname] = true
+
+		if len(node.Roles) == 0 {
+			return fmt.Errorf("node %s has no roles", node.Hostname)
+		}
+		for _, role := range node.Roles {
+			if !contains(roles, role) {
+				return fmt.Errorf("node %s has invalid role %s, must be one of %s", node.Hostname, role, strings.Join(roles, ", "))
+			}
+		}
+
+		for _, address := range []string{node.Address, node.InternalAddress} {
+			if address == "" {
+				continue
+			}
+			if net.ParseIP(address) == nil {
+				return fmt.Errorf("node %s has invalid address %s", node.Hostname, address)
+			}
+			if other, ok := addresses[address]; ok && other != node.Hostname {
+				return fmt.Errorf("nodes %s and %s have the same address %s", other, node.Hostname, address)
+			}
+			addresses[address] = node.Hostname
+		}
+
+		for key, value := range node.Labels {
+			if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+				return fmt.Errorf("node %s has invalid label %s: %s", node.Hostname, key, strings.Join(errs, ", "))
+			}
+			if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+				return fmt.Errorf("node %s has invalid label %s=%s: %s", node.Hostname, key, value, strings.Join(errs, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// nodeCommand returns the command registering a node, the registration command of the cluster with the name, roles,
+// addresses and labels of the node.
+func nodeCommand(command string, node v3.NodeRegistrationEntry) string {
+	args := []string{command, "--node-name", node.Hostname}
+	for _, role := range roles {
+		if contains(node.Roles, role) {
+			args = append(args, "--"+role)
+		}
+	}
+	if node.Address != "" {
+		args = append(args, "--address", node.Address)
+	}
+	if node.InternalAddress != "" {
+		args = append(args, "--internal-address", node.InternalAddress)
+	}
+	keys := make([]string, 0, len(node.Labels))
+	for key := range node.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		args = append(args, "--label", fmt.Sprintf("'%s=%s'", key, node.Labels[key]))
+	}
+	return strings.Join(args, " ")
+}
+
+// nodeStatuses returns the status of the nodes of the batch, each with its registration command and the registered node
+// it matches by hostname or by address, if any.
+func nodeStatuses(spec v3.NodeRegistrationBatchSpec, command string, registered []*v3.Node) []v3.NodeRegistrationNodeStatus {
+	byHostname := map[string]*v3.Node{}
+	byAddress := map[string]*v3.Node{}
+	for _, node := range registered {
+		if node.DeletionTimestamp != nil {
+			continue
+		}
+		for _, hostname := range []string{node.Status.NodeName, node.Spec.RequestedHostname} {
+			if hostname != "" {
+				byHostname[hostname] = node
+			}
+		}
+		for _, address := range node.Status.InternalNodeStatus.Addresses {
+			if address.Address != "" {
+				byAddress[address.Address] = node
+			}
+		}
+	}
+
+	result := make([]v3.NodeRegistrationNodeStatus, 0, len(spec.Nodes))
+	for _, expected := range spec.Nodes {
+		status := v3.NodeRegistrationNodeStatus{
+			Hostname: expected.Hostname,
+			Command:  nodeCommand(command, expected),
+		}
+		node := byHostname[expected.Hostname]
+		for _, address := range []string{expected.Address, expected.InternalAddress} {
+			if node == nil && address != "" {
+				node = byAddress[address]
+			}
+		}
+		if node != nil {
+			status.Registered = true
+			status.NodeName = node.Name
+		}
+		result = append(result, status)
+	}
+	return result
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+package noderegistrationbatch
+
+import (
+	"testing"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidate(t *testing.T) {
+	node := func(hostname string, roles ...string) v3.NodeRegistrationEntry {
+		return v3.NodeRegistrationEntry{Hostname: hostname, Roles: roles}
+	}
+
+	tests := []struct {
+		name  string
+		nodes []v3.NodeRegistrationEntry
+		err   string
+	}{
+		{
+			name: "valid",
+			nodes: []v3.NodeRegistrationEntry{
+				{Hostname: "server-1", Roles: []string{"etcd", "controlplane"}, Address: "10.0.0.1", InternalAddress: "192.168.0.1", Labels: map[string]string{"rack": "r1"}},
+				{Hostname: "worker-1", Roles: []string{"worker"}, Address: "10.0.0.2"},
+				{Hostname: "worker-2", Roles: []string{"worker"}, Address: "fd00::2", InternalAddress: "fd00::2"},
+			},
+		},
+		{
+			name:  "invalid hostname",
+			nodes: []v3.NodeRegistrationEntry{node("Worker_1", "worker")},
+			err:   `invalid hostname "Worker_1"`,
+		},
+		{
+			name:  "duplicate hostname",
+			nodes: []v3.NodeRegistrationEntry{node("worker-1", "worker"), node("worker-1", "etcd")},
+			err:   "duplicate hostname worker-1",
+		},
+		{
+			name:  "no roles",
+			nodes: []v3.NodeRegistrationEntry{node("worker-1")},
+			err:   "node worker-1 has no roles",
+		},
+		{
+			name:  "invalid role",
+			nodes: []v3.NodeRegistrationEntry{node("worker-1", "master")},
+			err:   "node worker-1 has invalid role master, must be one of etcd, controlplane, worker",
+		},
+		{
+			name:  "invalid address",
+			nodes: []v3.NodeRegistrationEntry{{Hostname: "worker-1", Roles: []string{"worker"}, Address: "10.0.0"}},
+			err:   "node worker-1 has invalid address 10.0.0",
+		},
+		{
+			name: "duplicate address",
+			nodes: []v3.NodeRegistrationEntry{
+				{Hostname: "worker-1", Roles: []string{"worker"}, Address: "10.0.0.1"},
+				{Hostname: "worker-2", Roles: []string{"worker"}, InternalAddress: "10.0.0.1"},
+			},
+			err: "nodes worker-1 and worker-2 have the same address 10.0.0.1",
+		},
+		{
+			name:  "invalid label",
+			nodes: []v3.NodeRegistrationEntry{{Hostname: "worker-1", Roles: []string{"worker"}, Labels: map[string]string{"rack": "r 1"}}},
+			err:   "node worker-1 has invalid label rack=r 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate(v3.NodeRegistrationBatchSpec{Nodes: tt.nodes})
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestNodeCommand(t *testing.T) {
+	assert.Equal(t,
+		"curl | sh -s - --node-name server-1 --etcd --controlplane --address 10.0.0.1 --internal-address 192.168.0.1 --label 'rack=r1' --label 'zone=a'",
+		nodeCommand("curl | sh -s -", v3.NodeRegistrationEntry{
+			Hostname:        "server-1",
+			Roles:           []string{"controlplane", "etcd"},
+			Labels:          map[string]string{"zone": "a", "rack": "r1"},
+			Address:         "10.0.0.1",
+			InternalAddress: "192.168.0.1",
+		}))
+	assert.Equal(t, "curl | sh -s - --node-name worker-1 --worker",
+		nodeCommand("curl | sh -s -", v3.NodeRegistrationEntry{Hostname: "worker-1", Roles: []string{"worker"}}))
+}
+
+func TestNodeStatuses(t *testing.T) {
+	spec := v3.NodeRegistrationBatchSpec{
+		Nodes: []v3.NodeRegistrationEntry{
+			{Hostname: "server-1", Roles: []string{"etcd"}},
+			{Hostname: "worker-1", Roles: []string{"worker"}, InternalAddress: "192.168.0.2"},
+			{Hostname: "worker-2", Roles: []string{"worker"}},
+			{Hostname: "worker-3", Roles: []string{"worker"}},
+		},
+	}
+	now := metav1.Now()
+	registered := []*v3.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "m-1"},
+			Status:     v3.NodeStatus{NodeName: "server-1"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "m-2"},
+			Status: v3.NodeStatus{
+				NodeName: "ip-192-168-0-2",
+				InternalNodeStatus: corev1.NodeStatus{
+					Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: "192.168.0.2"}},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "m-3"},
+			Spec:       v3.NodeSpec{RequestedHostname: "worker-2"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "m-4", DeletionTimestamp: &now},
+			Status:     v3.NodeStatus{NodeName: "worker-3"},
+		},
+	}
+
+	assert.Equal(t, []v3.NodeRegistrationNodeStatus{
+		{Hostname: "server-1", Command: "run --node-name server-1 --etcd", Registered: true, NodeName: "m-1"},
+		{Hostname: "worker-1", Command: "run --node-name worker-1 --worker --internal-address 192.168.0.2", Registered: true, NodeName: "m-2"},
+		{Hostname: "worker-2", Command: "run --node-name worker-2 --worker", Registered: true, NodeName: "m-3"},
+		{Hostname: "worker-3", Command: "run --node-name worker-3 --worker"},
+	}, nodeStatuses(spec, "run", registered))
+}
@@ -0,0 +1,135 @@
+package noderegistrationbatch
+
+import (
+	"context"
+	"fmt"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/rancher/wrangler/pkg/condition"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	registrationTokenName = "default-token"
+	ownerGVKAnnotation    = "objectset.rio.cattle.io/owner-gvk"
+	provisioningOwnerGVK  = "provisioning.cattle.io/v1, Kind=Cluster"
+)
+
+var registered condition.Cond = "Registered"
+
+type handler struct {
+	batches      mgmtcontrollers.NodeRegistrationBatchController
+	batchCache   mgmtcontrollers.NodeRegistrationBatchCache
+	clusterCache mgmtcontrollers.ClusterCache
+	nodeCache    mgmtcontrollers.NodeCache
+	tokenCache   mgmtcontrollers.ClusterRegistrationTokenCache
+}
+
+// Register registers the node-registration-batch controller, which reports the commands registering the nodes of
+// node registration batches with their custom clusters, and which of the nodes have registered.
+func Register(ctx context.Context, management *config.ManagementContext) {
+	mgmt := management.Wrangler.Mgmt
+	h := &handler{
+		batches:      mgmt.NodeRegistrationBatch(),
+		batchCache:   mgmt.NodeRegistrationBatch().Cache(),
+		clusterCache: mgmt.Cluster().Cache(),
+		nodeCache:    mgmt.Node().Cache(),
+		tokenCache:   mgmt.ClusterRegistrationToken().Cache(),
+	}
+
+	relatedresource.Watch(ctx, "node-registration-batch-trigger", h.resolveBatches, h.batches, mgmt.Node(), mgmt.ClusterRegistrationToken())
+	mgmtcontrollers.RegisterNodeRegistrationBatchStatusHandler(ctx, h.batches, "", "node-registration-batch", h.OnChange)
+}
+
+// resolveBatches enqueues the batches of the cluster of a node or registration token, in the namespace of the cluster.
+func (h *handler) resolveBatches(namespace, _ string, obj runtime.Object) ([]relatedresource.Key, error) {
+	switch obj.(type) {
+	case *v3.Node, *v3.ClusterRegistrationToken, nil:
+		if namespace == "" {
+			return nil, nil
+		}
+		batches, err := h.batchCache.List(namespace, labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		var keys []relatedresource.Key
+		for _, batch := range batches {
+			keys = append(keys, relatedresource.Key{Namespace: batch.Namespace, Name: batch.Name})
+		}
+		return keys, nil
+	}
+	return nil, nil
+}
+
+func (h *handler) OnChange(batch *v3.NodeRegistrationBatch, status v3.NodeRegistrationBatchStatus) (v3.NodeRegistrationBatchStatus, error) {
+	if batch.DeletionTimestamp != nil {
+		return status, nil
+	}
+	status.ExpectedCount = len(batch.Spec.Nodes)
+
+	message, err := h.check(batch)
+	if err != nil {
+		return status, err
+	}
+	if message != "" {
+		status.RegisteredCount = 0
+		status.Nodes = nil
+		registered.False(&status)
+		registered.Message(&status, message)
+		return status, nil
+	}
+
+	token, err := h.tokenCache.Get(batch.Spec.ClusterName, registrationTokenName)
+	if apierrors.IsNotFound(err) || (err == nil && token.Status.NodeCommand == "") {
+		registered.False(&status)
+		registered.Message(&status, "waiting for registration command")
+		return status, nil
+	} else if err != nil {
+		return status, err
+	}
+	command := token.Status.NodeCommand
+	if batch.Spec.Insecure && token.Status.InsecureNodeCommand != "" {
+		command = token.Status.InsecureNodeCommand
+	}
+
+	nodes, err := h.nodeCache.List(batch.Spec.ClusterName, labels.Everything())
+	if err != nil {
+		return status, err
+	}
+	status.Nodes = nodeStatuses(batch.Spec, command, nodes)
+	status.RegisteredCount = 0
+	for _, node := range status.Nodes {
+		if node.Registered {
+			status.RegisteredCount++
+		}
+	}
+
+	registered.SetStatusBool(&status, status.RegisteredCount == status.ExpectedCount)
+	registered.Message(&status, fmt.Sprintf("%d of %d nodes registered", status.RegisteredCount, status.ExpectedCount))
+	return status, nil
+}
+
+// check returns why the nodes of the batch can't be registered, empty if they can.
+func (h *handler) check(batch *v3.NodeRegistrationBatch) (string, error) {
+	if batch.Spec.ClusterName != batch.Namespace {
+		return fmt.Sprintf("batch must be in the namespace of cluster %s", batch.Spec.ClusterName), nil
+	}
+	if err := validate(batch.Spec); err != nil {
+		return err.Error(), nil
+	}
+	cluster, err := h.clusterCache.Get(batch.Spec.ClusterName)
+	if apierrors.IsNotFound(err) {
+		return fmt.Sprintf("cluster %s not found", batch.Spec.ClusterName), nil
+	} else if err != nil {
+		return "", err
+	}
+	if cluster.Spec.RancherKubernetesEngineConfig == nil && cluster.Annotations[ownerGVKAnnotation] != provisioningOwnerGVK {
+		return fmt.Sprintf("cluster %s isn't a custom cluster", cluster.Name), nil
+	}
+	return "", nil
+}
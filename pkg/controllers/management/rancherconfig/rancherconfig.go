@@ -0,0 +1,322 @@
+package rancherconfig
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rancher/norman/objectclient"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/rancher/wrangler/pkg/condition"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// ManagedByAnnotation is the annotation of the global roles created by a rancher config, set to its name.
+	ManagedByAnnotation = "management.cattle.io/rancher-config"
+
+	// resyncPeriod is the period changes made outside of rancher configs are detected at.
+	resyncPeriod = 5 * time.Minute
+)
+
+var (
+	reconciled condition.Cond = "Reconciled"
+	conflicted condition.Cond = "Conflicted"
+)
+
+type handler struct {
+	rancherConfigs  mgmtcontrollers.RancherConfigController
+	settings        mgmtcontrollers.SettingClient
+	settingCache    mgmtcontrollers.SettingCache
+	features        mgmtcontrollers.FeatureClient
+	featureCache    mgmtcontrollers.FeatureCache
+	globalRoles     mgmtcontrollers.GlobalRoleClient
+	globalRoleCache mgmtcontrollers.GlobalRoleCache
+	// AuthConfigs hold fields specific to their provider that the typed client drops, see the auth config controller.
+	authConfigs objectclient.GenericClient
+}
+
+func Register(ctx context.Context, management *config.ManagementContext) {
+	h := &handler{
+		rancherConfigs:  management.Wrangler.Mgmt.RancherConfig(),
+		settings:        management.Wrangler.Mgmt.Setting(),
+		settingCache:    management.Wrangler.Mgmt.Setting().Cache(),
+		features:        management.Wrangler.Mgmt.Feature(),
+		featureCache:    management.Wrangler.Mgmt.Feature().Cache(),
+		globalRoles:     management.Wrangler.Mgmt.GlobalRole(),
+		globalRoleCache: management.Wrangler.Mgmt.GlobalRole().Cache(),
+		authConfigs:     management.Management.AuthConfigs("").ObjectClient().UnstructuredClient(),
+	}
+
+	mgmtcontrollers.RegisterRancherConfigStatusHandler(ctx, h.rancherConfigs, reconciled, "rancher-config", h.sync)
+}
+
+func (h *handler) sync(rancherConfig *v3.RancherConfig, status v3.RancherConfigStatus) (v3.RancherConfigStatus, error) {
+	if rancherConfig.DeletionTimestamp != nil {
+		return status, nil
+	}
+	h.rancherConfigs.EnqueueAfter(rancherConfig.Name, resyncPeriod)
+
+	applied := map[string]string{}
+	for _, resource := range status.Resources {
+		applied[resourceKey(resource.Kind, resource.Name)] = resource.AppliedHash
+	}
+
+	overwrite := rancherConfig.Spec.OverwriteConflicts
+	var resources []v3.RancherConfigResourceStatus
+	for _, r := range h.declared(rancherConfig) {
+		resources = append(resources, reconcile(r, applied[resourceKey(r.kind, r.name)], overwrite))
+	}
+
+	status.ObservedGeneration = rancherConfig.Generation
+	status.Resources = resources
+
+	var conflicts, errs []string
+	for _, resource := range resources {
+		switch resource.State {
+		case v3.RancherConfigResourceConflict:
+			conflicts = append(conflicts, resourceKey(resource.Kind, resource.Name))
+		case v3.RancherConfigResourceError:
+			errs = append(errs, fmt.Sprintf("%s: %s", resourceKey(resource.Kind, resource.Name), resource.Message))
+		}
+	}
+
+	conflicted.SetStatusBool(&status, len(conflicts) > 0)
+	conflicted.Message(&status, "")
+	if len(conflicts) > 0 {
+		conflicted.Message(&status, "changed outside of the rancher config: "+strings.Join(conflicts, ", "))
+	}
+
+	if len(errs) > 0 {
+		return status, fmt.Errorf("failed to apply %s", strings.Join(errs, "; "))
+	}
+	return status, nil
+}
+
+// declared returns the resources declared by a rancher config, in a stable order.
+func (h *handler) declared(rancherConfig *v3.RancherConfig) []resource {
+	var resources []resource
+
+	settingNames := make([]string, 0, len(rancherConfig.Spec.Settings))
+	for name := range rancherConfig.Spec.Settings {
+		settingNames = append(settingNames, name)
+	}
+	sort.Strings(settingNames)
+	for _, name := range settingNames {
+		resources = append(resources, h.setting(name, rancherConfig.Spec.Settings[name]))
+	}
+
+	featureNames := make([]string, 0, len(rancherConfig.Spec.Features))
+	for name := range rancherConfig.Spec.Features {
+		featureNames = append(featureNames, name)
+	}
+	sort.Strings(featureNames)
+	for _, name := range featureNames {
+		resources = append(resources, h.feature(name, rancherConfig.Spec.Features[name]))
+	}
+
+	for _, authConfig := range rancherConfig.Spec.AuthConfigs {
+		resources = append(resources, h.authConfig(authConfig))
+	}
+
+	for _, globalRole := range rancherConfig.Spec.GlobalRoles {
+		resources = append(resources, h.globalRole(rancherConfig.Name, globalRole))
+	}
+
+	return resources
+}
+
+func (h *handler) setting(name, value string) resource {
+	return resource{
+		kind:    v3.RancherConfigResourceSetting,
+		name:    name,
+		desired: value,
+		current: func() (interface{}, error) {
+			setting, err := h.settingCache.Get(name)
+			if err != nil {
+				return nil, err
+			}
+			return setting.Value, nil
+		},
+		apply: func() error {
+			setting, err := h.settingCache.Get(name)
+			if err != nil {
+				return err
+			}
+			setting = setting.DeepCopy()
+			setting.Value = value
+			_, err = h.settings.Update(setting)
+			return err
+		},
+	}
+}
+
+func (h *handler) feature(name string, value bool) resource {
+	return resource{
+		kind:    v3.RancherConfigResourceFeature,
+		name:    name,
+		desired: value,
+		current: func() (interface{}, error) {
+			feature, err := h.featureCache.Get(name)
+			if err != nil {
+				return nil, err
+			}
+			if feature.Spec.Value == nil {
+				return nil, nil
+			}
+			return *feature.Spec.Value, nil
+		},
+		apply: func() error {
+			feature, err := h.featureCache.Get(name)
+			if err != nil {
+				return err
+			}
+			feature = feature.DeepCopy()
+			feature.Spec.Value = &value
+			_, err = h.features.Update(feature)
+			return err
+		},
+	}
+}
+
+func (h *handler) authConfig(authConfig v3.RancherConfigAuthConfig) resource {
+	desired := authConfigFields(authConfig)
+	return resource{
+		kind:    v3.RancherConfigResourceAuthConfig,
+		name:    authConfig.Name,
+		desired: desired,
+		current: func() (interface{}, error) {
+			obj, err := h.getAuthConfig(authConfig.Name)
+			if err != nil {
+				return nil, err
+			}
+			return currentFields(obj.UnstructuredContent(), desired), nil
+		},
+		apply: func() error {
+			obj, err := h.getAuthConfig(authConfig.Name)
+			if err != nil {
+				return err
+			}
+			for field, value := range desired {
+				obj.Object[field] = value
+			}
+			_, err = h.authConfigs.Update(authConfig.Name, obj)
+			return err
+		},
+	}
+}
+
+func (h *handler) getAuthConfig(name string) (*unstructured.Unstructured, error) {
+	obj, err := h.authConfigs.Get(name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("auth config %s is not an unstructured value", name)
+	}
+	return u, nil
+}
+
+// authConfigFields returns the fields of an auth config declared by a rancher config, including whether it is enabled.
+func authConfigFields(authConfig v3.RancherConfigAuthConfig) map[string]interface{} {
+	fields := map[string]interface{}{}
+	if authConfig.Fields != nil {
+		for field, value := range authConfig.Fields.Data {
+			fields[field] = value
+		}
+	}
+	fields["enabled"] = authConfig.Enabled
+	return fields
+}
+
+// currentFields returns the current values of the declared fields of an auth config, fields of the auth config that
+// aren't declared are ignored.
+func currentFields(content map[string]interface{}, declared map[string]interface{}) map[string]interface{} {
+	fields := map[string]interface{}{}
+	for field := range declared {
+		if value, ok := content[field]; ok {
+			fields[field] = value
+		}
+	}
+	return fields
+}
+
+// globalRoleValue is the declared part of a global role.
+type globalRoleValue struct {
+	DisplayName    string      `json:"displayName,omitempty"`
+	Description    string      `json:"description,omitempty"`
+	Rules          interface{} `json:"rules,omitempty"`
+	NewUserDefault bool        `json:"newUserDefault,omitempty"`
+}
+
+func (h *handler) globalRole(rancherConfigName string, globalRole v3.RancherConfigGlobalRole) resource {
+	desired := globalRoleValue{
+		DisplayName:    globalRole.DisplayName,
+		Description:    globalRole.Description,
+		NewUserDefault: globalRole.NewUserDefault,
+	}
+	if len(globalRole.Rules) > 0 {
+		desired.Rules = globalRole.Rules
+	}
+
+	return resource{
+		kind:    v3.RancherConfigResourceGlobalRole,
+		name:    globalRole.Name,
+		desired: desired,
+		current: func() (interface{}, error) {
+			gr, err := h.globalRoleCache.Get(globalRole.Name)
+			if apierrors.IsNotFound(err) {
+				return nil, nil
+			} else if err != nil {
+				return nil, err
+			}
+			current := globalRoleValue{
+				DisplayName:    gr.DisplayName,
+				Description:    gr.Description,
+				NewUserDefault: gr.NewUserDefault,
+			}
+			if len(gr.Rules) > 0 {
+				current.Rules = gr.Rules
+			}
+			return current, nil
+		},
+		apply: func() error {
+			gr, err := h.globalRoleCache.Get(globalRole.Name)
+			if apierrors.IsNotFound(err) {
+				_, err = h.globalRoles.Create(&v3.GlobalRole{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: globalRole.Name,
+						Annotations: map[string]string{
+							ManagedByAnnotation: rancherConfigName,
+						},
+					},
+					DisplayName:    globalRole.DisplayName,
+					Description:    globalRole.Description,
+					Rules:          globalRole.Rules,
+					NewUserDefault: globalRole.NewUserDefault,
+				})
+				return err
+			} else if err != nil {
+				return err
+			}
+			gr = gr.DeepCopy()
+			gr.DisplayName = globalRole.DisplayName
+			gr.Description = globalRole.Description
+			gr.Rules = globalRole.Rules
+			gr.NewUserDefault = globalRole.NewUserDefault
+			_, err = h.globalRoles.Update(gr)
+			return err
+		},
+	}
+}
+
+func resourceKey(kind v3.RancherConfigResourceKind, name string) string {
+	return string(kind) + "/" + name
+}
@@ -0,0 +1,90 @@
+package rancherconfig
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+)
+
+// resource is a resource declared by a rancher config.
+type resource struct {
+	kind    v3.RancherConfigResourceKind
+	name    string
+	desired interface{}
+	// current returns the current value of the resource, nil if the resource doesn't exist or has no value.
+	current func() (interface{}, error)
+	apply   func() error
+}
+
+type decision int
+
+const (
+	inSync decision = iota
+	outOfSync
+	conflict
+)
+
+// decide returns what to do with a declared resource from the hashes of its desired value, of its current value, and
+// of the value the rancher config last applied. A resource whose current value differs from the value last applied was
+// changed outside of the rancher config, which is a conflict unless conflicts are overwritten. A resource never applied
+// is applied, so that existing resources are adopted.
+func decide(desired, current, applied string, overwrite bool) decision {
+	if current == desired {
+		return inSync
+	}
+	if applied == "" || current == applied || overwrite {
+		return outOfSync
+	}
+	return conflict
+}
+
+// reconcile applies a declared resource if needed, and returns its status. applied is the hash of the value last
+// applied to the resource, from the previous status of the rancher config.
+func reconcile(r resource, applied string, overwrite bool) v3.RancherConfigResourceStatus {
+	status := v3.RancherConfigResourceStatus{
+		Kind:        r.kind,
+		Name:        r.name,
+		AppliedHash: applied,
+	}
+
+	current, err := r.current()
+	if err != nil {
+		status.State = v3.RancherConfigResourceError
+		status.Message = err.Error()
+		return status
+	}
+
+	desiredHash := hash(r.desired)
+	switch decide(desiredHash, hash(current), applied, overwrite) {
+	case inSync:
+		status.State = v3.RancherConfigResourceApplied
+		status.AppliedHash = desiredHash
+	case conflict:
+		status.State = v3.RancherConfigResourceConflict
+		status.Message = "changed outside of the rancher config since it was last applied"
+	case outOfSync:
+		if err := r.apply(); err != nil {
+			status.State = v3.RancherConfigResourceError
+			status.Message = err.Error()
+			return status
+		}
+		status.State = v3.RancherConfigResourceApplied
+		status.AppliedHash = desiredHash
+	}
+	return status
+}
+
+// hash returns the hash of the JSON encoding of a value, or an empty string for nil.
+func hash(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,104 @@
+package rancherconfig
+
+import (
+	"errors"
+	"testing"
+
+	fleet "github.com/rancher/fleet/pkg/apis/fleet.cattle.io/v1alpha1"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecide(t *testing.T) {
+	tests := []struct {
+		name      string
+		desired   string
+		current   string
+		applied   string
+		overwrite bool
+		want      decision
+	}{
+		{name: "in sync", desired: "a", current: "a", applied: "b", want: inSync},
+		{name: "never applied", desired: "a", current: "b", want: outOfSync},
+		{name: "missing resource", desired: "a", want: outOfSync},
+		{name: "desired value changed", desired: "a", current: "b", applied: "b", want: outOfSync},
+		{name: "changed outside", desired: "a", current: "c", applied: "a", want: conflict},
+		{name: "deleted outside", desired: "a", applied: "a", want: conflict},
+		{name: "changed outside overwritten", desired: "a", current: "c", applied: "a", overwrite: true, want: outOfSync},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, decide(tt.desired, tt.current, tt.applied, tt.overwrite))
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	current := "ui"
+	applyCalls := 0
+	r := resource{
+		kind:    v3.RancherConfigResourceSetting,
+		name:    "server-url",
+		desired: "git",
+		current: func() (interface{}, error) {
+			return current, nil
+		},
+		apply: func() error {
+			applyCalls++
+			current = "git"
+			return nil
+		},
+	}
+
+	status := reconcile(r, "", false)
+	assert.Equal(t, v3.RancherConfigResourceApplied, status.State)
+	assert.Equal(t, hash("git"), status.AppliedHash)
+	assert.Equal(t, 1, applyCalls)
+
+	current = "ui"
+	status = reconcile(r, status.AppliedHash, false)
+	assert.Equal(t, v3.RancherConfigResourceConflict, status.State)
+	assert.Equal(t, hash("git"), status.AppliedHash)
+	assert.Equal(t, 1, applyCalls)
+
+	status = reconcile(r, status.AppliedHash, true)
+	assert.Equal(t, v3.RancherConfigResourceApplied, status.State)
+	assert.Equal(t, 2, applyCalls)
+
+	r.apply = func() error {
+		return errors.New("denied")
+	}
+	current = "ui"
+	status = reconcile(r, "", false)
+	assert.Equal(t, v3.RancherConfigResourceError, status.State)
+	assert.Equal(t, "denied", status.Message)
+	assert.Empty(t, status.AppliedHash)
+}
+
+func TestAuthConfigFields(t *testing.T) {
+	declared := authConfigFields(v3.RancherConfigAuthConfig{
+		Name:    "github",
+		Enabled: true,
+		Fields: &fleet.GenericMap{Data: map[string]interface{}{
+			"hostname":          "github.com",
+			"allowedPrincipals": []interface{}{"github_user://1"},
+			"port":              float64(443),
+		}},
+	})
+
+	content := map[string]interface{}{
+		"apiVersion":        "management.cattle.io/v3",
+		"enabled":           true,
+		"hostname":          "github.com",
+		"allowedPrincipals": []interface{}{"github_user://1"},
+		"port":              int64(443),
+		"clientId":          "abc",
+	}
+	current := currentFields(content, declared)
+	assert.NotContains(t, current, "clientId")
+	assert.Equal(t, hash(declared), hash(current))
+
+	content["hostname"] = "github.example.com"
+	assert.NotEqual(t, hash(declared), hash(currentFields(content, declared)))
+}
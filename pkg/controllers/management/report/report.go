@@ -0,0 +1,218 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	normancorev1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
+	normanv3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/notifiers"
+	"github.com/rancher/rancher/pkg/ref"
+	"github.com/rancher/rancher/pkg/reports"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/rancher/rancher/pkg/types/config/dialer"
+	"github.com/rancher/wrangler/pkg/condition"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const localCluster = "local"
+
+var (
+	generated condition.Cond = "Generated"
+	emailed   condition.Cond = "Emailed"
+)
+
+type handler struct {
+	ctx                context.Context
+	reports            mgmtcontrollers.ReportController
+	clusterCache       mgmtcontrollers.ClusterCache
+	globalRoleBindings mgmtcontrollers.GlobalRoleBindingCache
+	userCache          mgmtcontrollers.UserCache
+	secrets            corecontrollers.SecretClient
+	notifierLister     normanv3.NotifierLister
+	notifierSecrets    *normancorev1.SecretLister
+	dialerFactory      dialer.Factory
+	now                func() time.Time
+}
+
+func Register(ctx context.Context, management *config.ManagementContext) {
+	notifierSecrets := management.Core.Secrets("").Controller().Lister()
+	h := &handler{
+		ctx:                ctx,
+		reports:            management.Wrangler.Mgmt.Report(),
+		clusterCache:       management.Wrangler.Mgmt.Cluster().Cache(),
+		globalRoleBindings: management.Wrangler.Mgmt.GlobalRoleBinding().Cache(),
+		userCache:          management.Wrangler.Mgmt.User().Cache(),
+		secrets:            management.Wrangler.Core.Secret(),
+		notifierLister:     management.Management.Notifiers("").Controller().Lister(),
+		notifierSecrets:    &notifierSecrets,
+		dialerFactory:      management.Dialer,
+		now:                time.Now,
+	}
+
+	mgmtcontrollers.RegisterReportStatusHandler(ctx, h.reports, generated, "report-generator", h.sync)
+}
+
+func (h *handler) sync(report *v3.Report, status v3.ReportStatus) (v3.ReportStatus, error) {
+	if report.DeletionTimestamp != nil {
+		return status, nil
+	}
+
+	now := h.now().UTC().Truncate(time.Second)
+	due, next, err := nextGeneration(report, now)
+	if err != nil {
+		return status, err
+	}
+	if !next.IsZero() {
+		h.reports.EnqueueAfter(report.Name, next.Sub(now))
+	}
+	if !due {
+		return status, nil
+	}
+
+	data, err := h.collect(report, now)
+	if err != nil {
+		return status, err
+	}
+
+	formats := report.Spec.Formats
+	if len(formats) == 0 {
+		formats = []v3.ReportFormat{v3.ReportFormatJSON}
+	}
+
+	generatedAt := now.Format(time.RFC3339)
+	var artifacts []v3.ReportArtifact
+	for _, format := range formats {
+		content, err := reports.Render(data, report.Spec.Sections, format)
+		if err != nil {
+			return status, err
+		}
+
+		artifact := v3.ReportArtifact{
+			Name:      fmt.Sprintf("%s-%s-%s", report.Name, now.Format("20060102-150405"), format),
+			Format:    format,
+			Generated: generatedAt,
+			Size:      len(content),
+		}
+		if err := h.storeArtifact(report, artifact, content); err != nil {
+			return status, err
+		}
+		artifacts = append(artifacts, artifact)
+	}
+
+	kept, removed := prune(append(artifacts, status.Artifacts...), report.Spec.Retention)
+	for _, artifact := range removed {
+		if err := h.secrets.Delete(reports.ArtifactNamespace, artifact.Name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return status, err
+		}
+	}
+	status.Artifacts = kept
+	status.LastGenerated = generatedAt
+
+	if report.Spec.Email != nil {
+		if err := h.email(report, data); err != nil {
+			logrus.Errorf("[report-generator] failed to email report %s: %v", report.Name, err)
+			emailed.SetError(&status, "", err)
+		} else {
+			emailed.SetError(&status, "", nil)
+		}
+	}
+
+	return status, nil
+}
+
+func (h *handler) collect(report *v3.Report, now time.Time) (reports.Data, error) {
+	clusters, err := h.clusterCache.List(labels.Everything())
+	if err != nil {
+		return reports.Data{}, err
+	}
+	bindings, err := h.globalRoleBindings.List(labels.Everything())
+	if err != nil {
+		return reports.Data{}, err
+	}
+	users, err := h.userCache.List(labels.Everything())
+	if err != nil {
+		return reports.Data{}, err
+	}
+	return reports.Collect(now, clusters, bindings, users, report.Spec.CertificateExpirationDays), nil
+}
+
+// storeArtifact stores the content of an artifact in a secret owned by the report, so that artifacts are deleted with
+// their report.
+func (h *handler) storeArtifact(report *v3.Report, artifact v3.ReportArtifact, content []byte) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      artifact.Name,
+			Namespace: reports.ArtifactNamespace,
+			Labels: map[string]string{
+				reports.ReportLabel: report.Name,
+			},
+			Annotations: map[string]string{
+				reports.FormatAnnotation: string(artifact.Format),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: v3.SchemeGroupVersion.String(),
+					Kind:       "Report",
+					Name:       report.Name,
+					UID:        report.UID,
+				},
+			},
+		},
+		Data: map[string][]byte{
+			reports.ArtifactDataKey: content,
+		},
+	}
+
+	_, err := h.secrets.Create(secret)
+	if apierrors.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+// email sends the report as text with the SMTP notifier of the report, to each recipient of the report.
+func (h *handler) email(report *v3.Report, data reports.Data) error {
+	ns, name := ref.Parse(report.Spec.Email.Notifier)
+	notifier, err := h.notifierLister.Get(ns, name)
+	if err != nil {
+		return err
+	}
+	if notifier.Spec.SMTPConfig == nil {
+		return fmt.Errorf("notifier %s has no SMTP config", report.Spec.Email.Notifier)
+	}
+
+	clusterDialer, err := h.dialerFactory.ClusterDialer(localCluster)
+	if err != nil {
+		return err
+	}
+
+	message := &notifiers.Message{
+		Title:   fmt.Sprintf("[Rancher] Report %s", report.Name),
+		Content: reports.RenderText(data, report.Spec.Sections),
+	}
+
+	recipients := report.Spec.Email.Recipients
+	if len(recipients) == 0 {
+		recipients = []string{""}
+	}
+	var errs []string
+	for _, recipient := range recipients {
+		if err := notifiers.SendMessage(h.ctx, notifier, recipient, message, clusterDialer, h.notifierSecrets); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("sending report: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package report
+
+import (
+	"sort"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/robfig/cron"
+)
+
+const defaultRetention = 5
+
+// nextGeneration returns whether the report is due at now, and when it is due next if it isn't. A report without a
+// schedule is only due once, and a scheduled report is due on the first tick of its schedule after its last generation,
+// or after its creation if it was never generated.
+func nextGeneration(report *v3.Report, now time.Time) (bool, time.Time, error) {
+	var last time.Time
+	if report.Status.LastGenerated != "" {
+		var err error
+		last, err = time.Parse(time.RFC3339, report.Status.LastGenerated)
+		if err != nil {
+			return false, time.Time{}, err
+		}
+	}
+
+	if report.Spec.Schedule == "" {
+		return last.IsZero(), time.Time{}, nil
+	}
+
+	schedule, err := cron.ParseStandard(report.Spec.Schedule)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	from := last
+	if from.IsZero() {
+		from = report.CreationTimestamp.Time
+	}
+	next := schedule.Next(from)
+	if !now.Before(next) {
+		return true, schedule.Next(now), nil
+	}
+	return false, next, nil
+}
+
+// prune splits the artifacts of a report into the artifacts of its retained generations, most recent first, and the
+// artifacts to delete.
+func prune(artifacts []v3.ReportArtifact, retention int) (kept []v3.ReportArtifact, removed []v3.ReportArtifact) {
+	if retention <= 0 {
+		retention = defaultRetention
+	}
+
+	sorted := append([]v3.ReportArtifact{}, artifacts...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Generated > sorted[j].Generated
+	})
+
+	generations := map[string]bool{}
+	for _, artifact := range sorted {
+		if !generations[artifact.Generated] && len(generations) == retention {
+			removed = append(removed, artifact)
+			continue
+		}
+		generations[artifact.Generated] = true
+		kept = append(kept, artifact)
+	}
+	return kept, removed
+}
@@ -0,0 +1,98 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNextGeneration(t *testing.T) {
+	created := time.Date(2023, 3, 1, 10, 30, 0, 0, time.UTC)
+	now := time.Date(2023, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		schedule      string
+		lastGenerated string
+		wantDue       bool
+		wantNext      time.Time
+		wantErr       bool
+	}{
+		{
+			name:    "unscheduled never generated",
+			wantDue: true,
+		},
+		{
+			name:          "unscheduled already generated",
+			lastGenerated: "2023-03-01T10:30:00Z",
+		},
+		{
+			name:     "scheduled never generated is due after creation",
+			schedule: "0 0 * * *",
+			wantDue:  true,
+			wantNext: time.Date(2023, 3, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "scheduled generated today",
+			schedule:      "0 0 * * *",
+			lastGenerated: "2023-03-02T00:00:00Z",
+			wantNext:      time.Date(2023, 3, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "invalid schedule",
+			schedule: "every day",
+			wantErr:  true,
+		},
+		{
+			name:          "invalid last generated",
+			schedule:      "0 0 * * *",
+			lastGenerated: "yesterday",
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := &v3.Report{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+				Spec:       v3.ReportSpec{Schedule: tt.schedule},
+				Status:     v3.ReportStatus{LastGenerated: tt.lastGenerated},
+			}
+
+			due, next, err := nextGeneration(report, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDue, due)
+			assert.True(t, tt.wantNext.Equal(next), "expected next generation at %s, got %s", tt.wantNext, next)
+		})
+	}
+}
+
+func TestPrune(t *testing.T) {
+	artifacts := []v3.ReportArtifact{
+		{Name: "r-3-json", Generated: "2023-03-03T00:00:00Z"},
+		{Name: "r-3-csv", Generated: "2023-03-03T00:00:00Z"},
+		{Name: "r-1-json", Generated: "2023-03-01T00:00:00Z"},
+		{Name: "r-2-json", Generated: "2023-03-02T00:00:00Z"},
+		{Name: "r-2-csv", Generated: "2023-03-02T00:00:00Z"},
+	}
+
+	kept, removed := prune(artifacts, 2)
+	assert.Equal(t, []v3.ReportArtifact{
+		{Name: "r-3-json", Generated: "2023-03-03T00:00:00Z"},
+		{Name: "r-3-csv", Generated: "2023-03-03T00:00:00Z"},
+		{Name: "r-2-json", Generated: "2023-03-02T00:00:00Z"},
+		{Name: "r-2-csv", Generated: "2023-03-02T00:00:00Z"},
+	}, kept)
+	assert.Equal(t, []v3.ReportArtifact{{Name: "r-1-json", Generated: "2023-03-01T00:00:00Z"}}, removed)
+
+	kept, removed = prune(artifacts, 0)
+	assert.Len(t, kept, 5)
+	assert.Empty(t, removed)
+}
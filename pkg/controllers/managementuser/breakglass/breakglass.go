@@ -0,0 +1,355 @@
+package breakglass
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/breakglass"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/rancher/pkg/capr/planencryption"
+	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
+	rkecontrollers "github.com/rancher/rancher/pkg/generated/controllers/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/namespace"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/types/config"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/rancher/wrangler/pkg/name"
+	"github.com/sirupsen/logrus"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// vaultTokenSecretName is the secret in the cattle-system namespace holding the token of vault in its token field.
+	vaultTokenSecretName = "break-glass-vault-token"
+	signTimeout          = 30 * time.Second
+)
+
+type handler struct {
+	ctx           context.Context
+	clusterName   string
+	controlPlanes rkecontrollers.RKEControlPlaneController
+	machineCache  capicontrollers.MachineCache
+	secrets       corecontrollers.SecretClient
+	secretCache   corecontrollers.SecretCache
+	encryptor     *planencryption.Encryptor
+	k8s           kubernetes.Interface
+}
+
+// Register registers the break-glass-kubeconfig controller of a provisioned cluster, which escrows a kubeconfig
+// authenticating with a client certificate signed by the cluster and bound to cluster-admin, when the
+// break-glass-kubeconfig-escrow setting is enabled. The kubeconfig is encrypted with the data key of the plans of the
+// cluster and, if a vault is configured, written to vault. It is reissued when the certificates of the cluster are
+// rotated, its CA or control plane nodes change, or two thirds of the validity of its certificate elapsed, and the
+// previous certificate loses its binding when it is.
+func Register(ctx context.Context, cluster *config.UserContext) {
+	clients := cluster.Management.Wrangler
+	h := &handler{
+		ctx:           ctx,
+		clusterName:   cluster.ClusterName,
+		controlPlanes: clients.RKE.RKEControlPlane(),
+		machineCache:  clients.CAPI.Machine().Cache(),
+		secrets:       clients.Core.Secret(),
+		secretCache:   clients.Core.Secret().Cache(),
+		encryptor:     planencryption.NewEncryptor(clients),
+		k8s:           cluster.K8sClient,
+	}
+	clients.RKE.RKEControlPlane().OnChange(ctx, "break-glass-kubeconfig-"+cluster.ClusterName, h.OnChange)
+}
+
+func (h *handler) OnChange(_ string, cp *rkev1.RKEControlPlane) (*rkev1.RKEControlPlane, error) {
+	if cp == nil || cp.DeletionTimestamp != nil || cp.Spec.ManagementClusterName != h.clusterName {
+		return cp, nil
+	}
+	if settings.BreakGlassKubeconfigEscrow.Get() != "true" || !cp.Status.Ready {
+		return cp, nil
+	}
+
+	caCert, err := h.caCert()
+	if err != nil {
+		return cp, err
+	}
+	machines, err := h.machineCache.List(cp.Namespace, labels.SelectorFromSet(labels.Set{
+		capi.ClusterLabelName:      cp.Name,
+		capr.ControlPlaneRoleLabel: "true",
+	}))
+	if err != nil {
+		return cp, err
+	}
+	servers := controlPlaneServers(machines)
+	current := breakglass.Escrow{
+		RotationGeneration: cp.Status.CertificateRotationGeneration,
+		Hash:               breakglass.Hash(caCert, servers),
+	}
+
+	secret, err := h.secretCache.Get(cp.Namespace, breakglass.SecretName(cp.Name))
+	if err != nil && !apierrors.IsNotFound(err) {
+		return cp, err
+	}
+	var escrow breakglass.Escrow
+	if secret != nil && err == nil {
+		escrow = breakglass.ParseEscrow(secret.Data)
+	} else {
+		secret = nil
+	}
+
+	now := time.Now()
+	if renewAt := escrow.RenewAt(current); !now.Before(renewAt) {
+		if secret, escrow, err = h.issue(cp, secret, current, caCert, servers); err != nil {
+			return cp, err
+		}
+	}
+
+	if err := h.bind(escrow.User); err != nil {
+		return cp, err
+	}
+	if err := h.writeVault(cp, secret, escrow); err != nil {
+		// The escrow in rancher is up to date, retry writing it to vault with the next renewal check.
+		logrus.Errorf("[break-glass-kubeconfig] rkecontrolplane %s/%s: %v", cp.Namespace, cp.Name, err)
+	}
+
+	h.controlPlanes.EnqueueAfter(cp.Namespace, cp.Name, wait.Jitter(escrow.RenewAt(escrow).Sub(now), 0.1))
+	return cp, nil
+}
+
+// caCert returns the CA of the kube-apiserver of the cluster.
+func (h *handler) caCert() ([]byte, error) {
+	configMap, err := h.k8s.CoreV1().ConfigMaps(metav1.NamespaceSystem).Get(h.ctx, "kube-root-ca.crt", metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	caCert := configMap.Data["ca.crt"]
+	if caCert == "" {
+		return nil, fmt.Errorf("configmap %s/kube-root-ca.crt of cluster %s has no CA", metav1.NamespaceSystem, h.clusterName)
+	}
+	return []byte(caCert), nil
+}
+
+// controlPlaneServers returns the control plane nodes of machines with an address, preferring their external address.
+func controlPlaneServers(machines []*capi.Machine) []breakglass.Server {
+	var servers []breakglass.Server
+	for _, machine := range machines {
+		if machine.DeletionTimestamp != nil {
+			continue
+		}
+		address := machineAddress(machine, capi.MachineExternalIP)
+		if address == "" {
+			address = machineAddress(machine, capi.MachineInternalIP)
+		}
+		if address == "" {
+			continue
+		}
+		serverName := machine.Name
+		if machine.Status.NodeRef != nil {
+			serverName = machine.Status.NodeRef.Name
+		}
+		servers = append(servers, breakglass.Server{Name: serverName, Address: address})
+	}
+	return servers
+}
+
+func machineAddress(machine *capi.Machine, addressType capi.MachineAddressType) string {
+	for _, address := range machine.Status.Addresses {
+		if address.Type == addressType && address.Address != "" {
+			return address.Address
+		}
+	}
+	return ""
+}
+
+// issue issues a new client certificate and escrows the kubeconfig using it.
+func (h *handler) issue(cp *rkev1.RKEControlPlane, secret *corev1.Secret, current breakglass.Escrow, caCert []byte, servers []breakglass.Server) (*corev1.Secret, breakglass.Escrow, error) {
+	escrow := current
+	escrow.User = breakglass.UserPrefix + rand.String(8)
+
+	logrus.Infof("[break-glass-kubeconfig] rkecontrolplane %s/%s: issuing break glass kubeconfig for user %s", cp.Namespace, cp.Name, escrow.User)
+	certPEM, keyPEM, err := h.sign(escrow.User)
+	if err != nil {
+		return nil, escrow, err
+	}
+	escrow.IssuedAt = time.Now()
+	if escrow.NotAfter, err = breakglass.NotAfter(certPEM); err != nil {
+		return nil, escrow, err
+	}
+
+	kubeconfig, err := breakglass.Kubeconfig(cp.Name, servers, caCert, certPEM, keyPEM)
+	if err != nil {
+		return nil, escrow, err
+	}
+	encrypted, err := h.encryptor.Encrypt(cp, kubeconfig)
+	if err != nil {
+		return nil, escrow, err
+	}
+
+	if secret == nil {
+		secret, err = h.secrets.Create(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      breakglass.SecretName(cp.Name),
+				Namespace: cp.Namespace,
+				Labels: map[string]string{
+					capr.ClusterNameLabel: cp.Name,
+				},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: rkev1.SchemeGroupVersion.String(),
+					Kind:       "RKEControlPlane",
+					Name:       cp.Name,
+					UID:        cp.UID,
+				}},
+			},
+			Type: breakglass.SecretType,
+			Data: escrow.Data(encrypted),
+		})
+		return secret, escrow, err
+	}
+	secret = secret.DeepCopy()
+	secret.Data = escrow.Data(encrypted)
+	secret, err = h.secrets.Update(secret)
+	return secret, escrow, err
+}
+
+// sign returns a client certificate for the user signed by the cluster, and its private key.
+func (h *handler) sign(user string) ([]byte, []byte, error) {
+	keyPEM, csrPEM, err := breakglass.NewCertificateRequest(user)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	validityDays, err := strconv.Atoi(settings.BreakGlassKubeconfigValidityDays.Get())
+	if err != nil || validityDays <= 0 {
+		validityDays = 365
+	}
+	expirationSeconds := int32(validityDays * 24 * 60 * 60)
+
+	csrs := h.k8s.CertificatesV1().CertificateSigningRequests()
+	csr, err := csrs.Create(h.ctx, &certificatesv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name.SafeConcatName("rancher-break-glass", rand.String(8)),
+		},
+		Spec: certificatesv1.CertificateSigningRequestSpec{
+			Request:           csrPEM,
+			SignerName:        certificatesv1.KubeAPIServerClientSignerName,
+			ExpirationSeconds: &expirationSeconds,
+			Usages: []certificatesv1.KeyUsage{
+				certificatesv1.UsageDigitalSignature,
+				certificatesv1.UsageClientAuth,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if err := csrs.Delete(h.ctx, csr.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			logrus.Warnf("[break-glass-kubeconfig] cluster %s: failed to delete certificate signing request %s: %v", h.clusterName, csr.Name, err)
+		}
+	}()
+
+	csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{
+		Type:    certificatesv1.CertificateApproved,
+		Status:  corev1.ConditionTrue,
+		Reason:  "RancherBreakGlass",
+		Message: "Approved by rancher for the break glass kubeconfig",
+	})
+	if _, err := csrs.UpdateApproval(h.ctx, csr.Name, csr, metav1.UpdateOptions{}); err != nil {
+		return nil, nil, err
+	}
+
+	var certPEM []byte
+	err = wait.PollImmediate(time.Second, signTimeout, func() (bool, error) {
+		csr, err := csrs.Get(h.ctx, csr.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		for _, condition := range csr.Status.Conditions {
+			if condition.Type == certificatesv1.CertificateDenied || condition.Type == certificatesv1.CertificateFailed {
+				return false, fmt.Errorf("certificate signing request %s was not signed: %s", csr.Name, condition.Message)
+			}
+		}
+		certPEM = csr.Status.Certificate
+		return len(certPEM) > 0, nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign the break glass certificate of cluster %s: %w", h.clusterName, err)
+	}
+	return certPEM, keyPEM, nil
+}
+
+// bind binds cluster-admin to the user of the escrowed kubeconfig only, so that the certificates of the kubeconfigs
+// it replaced can't be used anymore.
+func (h *handler) bind(user string) error {
+	subjects := []rbacv1.Subject{{
+		Kind:     rbacv1.UserKind,
+		APIGroup: rbacv1.GroupName,
+		Name:     user,
+	}}
+
+	crbs := h.k8s.RbacV1().ClusterRoleBindings()
+	crb, err := crbs.Get(h.ctx, breakglass.ClusterRoleBindingName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = crbs.Create(h.ctx, &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: breakglass.ClusterRoleBindingName,
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     "cluster-admin",
+			},
+			Subjects: subjects,
+		}, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	if len(crb.Subjects) == 1 && crb.Subjects[0] == subjects[0] {
+		return nil
+	}
+	crb = crb.DeepCopy()
+	crb.Subjects = subjects
+	_, err = crbs.Update(h.ctx, crb, metav1.UpdateOptions{})
+	return err
+}
+
+// writeVault writes the escrowed kubeconfig to vault if one is configured and it wasn't written yet.
+func (h *handler) writeVault(cp *rkev1.RKEControlPlane, secret *corev1.Secret, escrow breakglass.Escrow) error {
+	address := settings.BreakGlassVaultAddress.Get()
+	if address == "" || escrow.VaultSynced {
+		return nil
+	}
+
+	tokenSecret, err := h.secretCache.Get(namespace.System, vaultTokenSecretName)
+	if err != nil {
+		return fmt.Errorf("failed to get the vault token: %w", err)
+	}
+	kubeconfig, err := h.encryptor.Decrypt(cp.Namespace, cp.Name, secret.Data[breakglass.KubeconfigField])
+	if err != nil {
+		return err
+	}
+
+	vault := &breakglass.VaultClient{
+		Address: address,
+		Mount:   settings.BreakGlassVaultMount.Get(),
+		Path:    settings.BreakGlassVaultPath.Get(),
+		Token:   string(tokenSecret.Data["token"]),
+	}
+	if err := vault.Write(h.ctx, cp.Namespace, cp.Name, kubeconfig, escrow.NotAfter); err != nil {
+		return err
+	}
+
+	secret = secret.DeepCopy()
+	secret.Data[breakglass.VaultSyncedField] = []byte("true")
+	_, err = h.secrets.Update(secret)
+	return err
+}
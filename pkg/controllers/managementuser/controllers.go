@@ -4,20 +4,31 @@
 	"context"
 
 	"github.com/rancher/rancher/pkg/controllers/managementlegacy/compose/common"
+	"github.com/rancher/rancher/pkg/controllers/managementuser/breakglass"
 	"github.com/rancher/rancher/pkg/controllers/managementuser/certsexpiration"
 	"github.com/rancher/rancher/pkg/controllers/managementuser/clusterauthtoken"
+	"github.com/rancher/rancher/pkg/controllers/managementuser/controlplaneusage"
 	"github.com/rancher/rancher/pkg/controllers/managementuser/healthsyncer"
 	"github.com/rancher/rancher/pkg/controllers/managementuser/machinerole"
+	"github.com/rancher/rancher/pkg/controllers/managementuser/metadatapropagation"
+	"github.com/rancher/rancher/pkg/controllers/managementuser/namespacepolicy"
+	"github.com/rancher/rancher/pkg/controllers/managementuser/namespacetemplate"
 	"github.com/rancher/rancher/pkg/controllers/managementuser/networkpolicy"
+	"github.com/rancher/rancher/pkg/controllers/managementuser/nodelocaldns"
 	"github.com/rancher/rancher/pkg/controllers/managementuser/nodesyncer"
 	"github.com/rancher/rancher/pkg/controllers/managementuser/nsserviceaccount"
+	"github.com/rancher/rancher/pkg/controllers/managementuser/pipelinekubeconfig"
+	"github.com/rancher/rancher/pkg/controllers/managementuser/psastaging"
 	"github.com/rancher/rancher/pkg/controllers/managementuser/pspdelete"
 	"github.com/rancher/rancher/pkg/controllers/managementuser/rbac"
 	"github.com/rancher/rancher/pkg/controllers/managementuser/rbac/podsecuritypolicy"
 	"github.com/rancher/rancher/pkg/controllers/managementuser/resourcequota"
 	"github.com/rancher/rancher/pkg/controllers/managementuser/secret"
+	"github.com/rancher/rancher/pkg/controllers/managementuser/secretdistribution"
 	"github.com/rancher/rancher/pkg/controllers/managementuser/snapshotbackpopulate"
+	"github.com/rancher/rancher/pkg/controllers/managementuser/webhookoverrides"
 	"github.com/rancher/rancher/pkg/controllers/managementuser/windows"
+	"github.com/rancher/rancher/pkg/controllers/managementuser/workloaddefaults"
 	"github.com/rancher/rancher/pkg/controllers/managementuserlegacy"
 	"github.com/rancher/rancher/pkg/features"
 	managementv3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
@@ -26,9 +37,9 @@
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
-func Register(ctx context.Context, mgmt *config.ScaledContext, cluster *config.UserContext, clusterRec *managementv3.Cluster, kubeConfigGetter common.KubeConfigGetter) error {
+func Register(ctx context.Context, mgmt *config.ScaledContext, cluster *config.UserContext, clusterRec *managementv3.Cluster, kubeConfigGetter common.KubeConfigGetter, clusterManager healthsyncer.ClusterControllerLifecycle) error {
 	rbac.Register(ctx, cluster)
-	healthsyncer.Register(ctx, cluster)
+	healthsyncer.Register(ctx, cluster, clusterManager)
 	networkpolicy.Register(ctx, cluster)
 	nodesyncer.Register(ctx, cluster, kubeConfigGetter)
 	podsecuritypolicy.Register(ctx, cluster)
@@ -37,10 +48,23 @@ func Register(ctx context.Context, mgmt *config.ScaledContext, cluster *config.U
 	certsexpiration.Register(ctx, cluster)
 	windows.Register(ctx, clusterRec, cluster)
 	nsserviceaccount.Register(ctx, cluster)
+	namespacepolicy.Register(ctx, cluster)
+	namespacetemplate.Register(ctx, cluster)
+	secretdistribution.Register(ctx, cluster)
+	workloaddefaults.Register(ctx, cluster)
+	webhookoverrides.Register(ctx, cluster)
+	metadatapropagation.Register(ctx, cluster)
+	pipelinekubeconfig.Register(ctx, cluster)
+	if err := psastaging.Register(ctx, cluster); err != nil {
+		return err
+	}
 	if features.RKE2.Enabled() {
 		snapshotbackpopulate.Register(ctx, cluster)
+		breakglass.Register(ctx, cluster)
 		pspdelete.Register(ctx, cluster)
 		machinerole.Register(ctx, cluster)
+		nodelocaldns.Register(ctx, cluster)
+		controlplaneusage.Register(ctx, cluster)
 	}
 
 	// register controller for API
@@ -0,0 +1,143 @@
+package controlplaneusage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/rancher/wrangler/pkg/ticker"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	collectInterval = 5 * time.Minute
+	collectTimeout  = 30 * time.Second
+
+	controlPlaneRoleLabel = "node-role.kubernetes.io/control-plane"
+	componentLabel        = "component"
+	apiServerComponent    = "kube-apiserver"
+	etcdComponent         = "etcd"
+	componentSelector     = componentLabel + " in (" + apiServerComponent + "," + etcdComponent + ")"
+	controlPlaneNamespace = "kube-system"
+
+	podMetricsPath = "/apis/metrics.k8s.io/v1beta1/namespaces/" + controlPlaneNamespace + "/pods"
+)
+
+type collector struct {
+	ctx           context.Context
+	clusterName   string
+	clusterLister v3.ClusterLister
+	clusters      v3.ClusterInterface
+	k8s           kubernetes.Interface
+}
+
+// Register starts sampling the resource usage of the control plane of the cluster into its ControlPlaneUsage status.
+func Register(ctx context.Context, cluster *config.UserContext) {
+	c := &collector{
+		ctx:           ctx,
+		clusterName:   cluster.ClusterName,
+		clusterLister: cluster.Management.Management.Clusters("").Controller().Lister(),
+		clusters:      cluster.Management.Management.Clusters(""),
+		k8s:           cluster.K8sClient,
+	}
+
+	go c.collectPeriodically(ctx, collectInterval)
+}
+
+func (c *collector) collectPeriodically(ctx context.Context, interval time.Duration) {
+	for range ticker.Context(ctx, interval) {
+		if err := c.collect(); err != nil && !apierrors.IsConflict(err) {
+			logrus.Errorf("[controlplaneusage] failed to collect control plane usage of cluster %s: %v", c.clusterName, err)
+		}
+	}
+}
+
+func (c *collector) collect() error {
+	ctx, cancel := context.WithTimeout(c.ctx, collectTimeout)
+	defer cancel()
+
+	nodes, err := c.k8s.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: controlPlaneRoleLabel})
+	if err != nil {
+		return err
+	}
+	if len(nodes.Items) == 0 {
+		// The control plane isn't run on nodes of the cluster, as for hosted clusters.
+		return nil
+	}
+
+	pods, err := c.k8s.CoreV1().Pods(controlPlaneNamespace).List(ctx, metav1.ListOptions{LabelSelector: componentSelector})
+	if err != nil {
+		return err
+	}
+
+	usage, err := c.podUsage(ctx)
+	if err != nil {
+		// The usage of the nodes is only available if the cluster runs a metrics server.
+		logrus.Debugf("[controlplaneusage] failed to get control plane pod metrics of cluster %s: %v", c.clusterName, err)
+	}
+
+	raw, err := c.k8s.CoreV1().RESTClient().Get().AbsPath("/metrics").DoRaw(ctx)
+	if err != nil {
+		return err
+	}
+	metrics, err := parseAPIServerMetrics(bytes.NewReader(raw))
+	if err != nil {
+		return err
+	}
+
+	cluster, err := c.clusterLister.Get("", c.clusterName)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	cluster = cluster.DeepCopy()
+	cluster.Status.ControlPlaneUsage = &v32.ControlPlaneUsage{
+		CollectedAt:       now.UTC().Format(time.RFC3339),
+		Nodes:             nodeUsage(nodes.Items, pods.Items, usage),
+		EtcdDBSizeBytes:   metrics.etcdDBSizeBytes,
+		RequestCount:      metrics.requestCount,
+		RequestsPerSecond: requestRate(cluster.Status.ControlPlaneUsage, metrics.requestCount, now),
+	}
+	_, err = c.clusters.Update(cluster)
+	return err
+}
+
+type podMetricsList struct {
+	Items []struct {
+		Metadata   metav1.ObjectMeta `json:"metadata"`
+		Containers []struct {
+			Usage corev1.ResourceList `json:"usage"`
+		} `json:"containers"`
+	} `json:"items"`
+}
+
+// podUsage returns the resources used by the control plane pods of the cluster, by pod name.
+func (c *collector) podUsage(ctx context.Context) (map[string]corev1.ResourceList, error) {
+	raw, err := c.k8s.CoreV1().RESTClient().Get().AbsPath(podMetricsPath).Param("labelSelector", componentSelector).DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var metrics podMetricsList
+	if err := json.Unmarshal(raw, &metrics); err != nil {
+		return nil, err
+	}
+
+	result := map[string]corev1.ResourceList{}
+	for _, pod := range metrics.Items {
+		usage := corev1.ResourceList{}
+		for _, container := range pod.Containers {
+			addResources(usage, container.Usage)
+		}
+		result[pod.Metadata.Name] = usage
+	}
+	return result, nil
+}
@@ -0,0 +1,146 @@
+package controlplaneusage
+
+import (
+	"io"
+	"math"
+	"sort"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var (
+	// etcdDBSizeMetrics are the metrics of the kube-apiserver reporting the size of the etcd database, the second one
+	// being reported by Kubernetes versions older than 1.25.
+	etcdDBSizeMetrics = []string{"apiserver_storage_db_total_size_in_bytes", "etcd_db_total_size_in_bytes"}
+	requestsMetric    = "apiserver_request_total"
+)
+
+type apiServerMetrics struct {
+	etcdDBSizeBytes int64
+	requestCount    int64
+}
+
+// parseAPIServerMetrics returns the size of the etcd database and the number of requests served, from the metrics of a
+// kube-apiserver in the Prometheus text format.
+func parseAPIServerMetrics(r io.Reader) (apiServerMetrics, error) {
+	var result apiServerMetrics
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(r)
+	if err != nil {
+		return result, err
+	}
+
+	for _, name := range etcdDBSizeMetrics {
+		family, ok := families[name]
+		if !ok {
+			continue
+		}
+		// The size is reported for every etcd endpoint, the largest database is the one the quota applies to first.
+		for _, metric := range family.GetMetric() {
+			if size := int64(metricValue(metric)); size > result.etcdDBSizeBytes {
+				result.etcdDBSizeBytes = size
+			}
+		}
+		break
+	}
+
+	if family, ok := families[requestsMetric]; ok {
+		var total float64
+		for _, metric := range family.GetMetric() {
+			total += metricValue(metric)
+		}
+		result.requestCount = int64(total)
+	}
+	return result, nil
+}
+
+func metricValue(metric *dto.Metric) float64 {
+	switch {
+	case metric.GetGauge() != nil:
+		return metric.GetGauge().GetValue()
+	case metric.GetCounter() != nil:
+		return metric.GetCounter().GetValue()
+	case metric.GetUntyped() != nil:
+		return metric.GetUntyped().GetValue()
+	}
+	return 0
+}
+
+// requestRate returns the rate of requests served since the previous sample, 0 if there is no previous sample or if
+// the kube-apiserver restarted since.
+func requestRate(previous *v32.ControlPlaneUsage, requestCount int64, now time.Time) int64 {
+	if previous == nil || previous.RequestCount > requestCount {
+		return 0
+	}
+	collectedAt, err := time.Parse(time.RFC3339, previous.CollectedAt)
+	if err != nil {
+		return 0
+	}
+	elapsed := now.Sub(collectedAt).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return int64(math.Round(float64(requestCount-previous.RequestCount) / elapsed))
+}
+
+// nodeUsage returns the usage of the kube-apiserver and etcd of each control plane node, from the usage of their pods.
+func nodeUsage(nodes []corev1.Node, pods []corev1.Pod, podUsage map[string]corev1.ResourceList) []v32.ControlPlaneNodeUsage {
+	byNode := map[string]*v32.ControlPlaneNodeUsage{}
+	for _, node := range nodes {
+		allocatable := corev1.ResourceList{}
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			if quantity, ok := node.Status.Allocatable[name]; ok {
+				allocatable[name] = quantity.DeepCopy()
+			}
+		}
+		byNode[node.Name] = &v32.ControlPlaneNodeUsage{
+			NodeName:    node.Name,
+			Allocatable: allocatable,
+		}
+	}
+
+	for _, pod := range pods {
+		node, ok := byNode[pod.Spec.NodeName]
+		usage, hasUsage := podUsage[pod.Name]
+		if !ok || !hasUsage {
+			continue
+		}
+		switch pod.Labels[componentLabel] {
+		case apiServerComponent:
+			if node.APIServer == nil {
+				node.APIServer = corev1.ResourceList{}
+			}
+			addResources(node.APIServer, usage)
+		case etcdComponent:
+			if node.Etcd == nil {
+				node.Etcd = corev1.ResourceList{}
+			}
+			addResources(node.Etcd, usage)
+		}
+	}
+
+	result := make([]v32.ControlPlaneNodeUsage, 0, len(byNode))
+	for _, node := range byNode {
+		result = append(result, *node)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].NodeName < result[j].NodeName
+	})
+	return result
+}
+
+func addResources(total, resources corev1.ResourceList) {
+	for name, quantity := range resources {
+		if name != corev1.ResourceCPU && name != corev1.ResourceMemory {
+			continue
+		}
+		sum := total[name]
+		sum.Add(quantity)
+		total[name] = sum
+	}
+}
@@ -0,0 +1,93 @@
+package controlplaneusage
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const apiServerMetricsText = `# HELP apiserver_request_total [STABLE] Counter of apiserver requests broken out for each verb, dry run value, group, version, resource, scope, component, and HTTP response code.
+# TYPE apiserver_request_total counter
+apiserver_request_total{code="200",component="apiserver",resource="pods",verb="LIST"} 1200
+apiserver_request_total{code="201",component="apiserver",resource="events",verb="POST"} 300
+# HELP apiserver_storage_db_total_size_in_bytes [ALPHA] Total size of the storage database file physically allocated in bytes.
+# TYPE apiserver_storage_db_total_size_in_bytes gauge
+apiserver_storage_db_total_size_in_bytes{endpoint="https://127.0.0.1:2379"} 5.24288e+07
+apiserver_storage_db_total_size_in_bytes{endpoint="https://10.0.0.2:2379"} 6.5536e+07
+`
+
+func TestParseAPIServerMetrics(t *testing.T) {
+	metrics, err := parseAPIServerMetrics(strings.NewReader(apiServerMetricsText))
+	require.NoError(t, err)
+	assert.Equal(t, int64(65536000), metrics.etcdDBSizeBytes)
+	assert.Equal(t, int64(1500), metrics.requestCount)
+
+	metrics, err = parseAPIServerMetrics(strings.NewReader("# TYPE etcd_db_total_size_in_bytes gauge\netcd_db_total_size_in_bytes{endpoint=\"https://127.0.0.1:2379\"} 1024\n"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1024), metrics.etcdDBSizeBytes)
+	assert.Zero(t, metrics.requestCount)
+
+	_, err = parseAPIServerMetrics(strings.NewReader("not metrics{"))
+	assert.Error(t, err)
+}
+
+func TestRequestRate(t *testing.T) {
+	now := time.Date(2023, 5, 1, 12, 5, 0, 0, time.UTC)
+	previous := &v32.ControlPlaneUsage{
+		CollectedAt:  now.Add(-5 * time.Minute).Format(time.RFC3339),
+		RequestCount: 1000,
+	}
+
+	assert.Equal(t, int64(10), requestRate(previous, 4000, now))
+	assert.Zero(t, requestRate(nil, 4000, now), "no previous sample")
+	assert.Zero(t, requestRate(previous, 500, now), "kube-apiserver restarted")
+	assert.Zero(t, requestRate(&v32.ControlPlaneUsage{RequestCount: 1000}, 4000, now), "no collection time")
+}
+
+func TestNodeUsage(t *testing.T) {
+	node := func(name string) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("4"),
+					corev1.ResourceMemory: resource.MustParse("16Gi"),
+					corev1.ResourcePods:   resource.MustParse("110"),
+				},
+			},
+		}
+	}
+	pod := func(name, nodeName, component string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{componentLabel: component}},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+		}
+	}
+
+	usage := nodeUsage(
+		[]corev1.Node{node("cp-2"), node("cp-1")},
+		[]corev1.Pod{
+			pod("kube-apiserver-cp-1", "cp-1", apiServerComponent),
+			pod("etcd-cp-1", "cp-1", etcdComponent),
+			pod("kube-apiserver-cp-2", "cp-2", apiServerComponent),
+		},
+		map[string]corev1.ResourceList{
+			"kube-apiserver-cp-1": {corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+			"etcd-cp-1":           {corev1.ResourceCPU: resource.MustParse("100m"), corev1.ResourceMemory: resource.MustParse("256Mi")},
+		})
+
+	require.Len(t, usage, 2)
+	assert.Equal(t, "cp-1", usage[0].NodeName)
+	assert.Len(t, usage[0].Allocatable, 2)
+	assert.Equal(t, "500m", usage[0].APIServer.Cpu().String())
+	assert.Equal(t, "256Mi", usage[0].Etcd.Memory().String())
+	assert.Equal(t, "cp-2", usage[1].NodeName)
+	assert.Nil(t, usage[1].APIServer, "no pod metrics")
+}
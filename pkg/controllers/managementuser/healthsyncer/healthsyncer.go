@@ -13,6 +13,7 @@
 	"github.com/rancher/norman/condition"
 	"github.com/rancher/norman/types/slice"
 	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/clusterbreaker"
 	"github.com/rancher/rancher/pkg/controllers/management/clusterconnected"
 	corev1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
@@ -54,9 +55,12 @@ type HealthSyncer struct {
 	componentStatuses corev1.ComponentStatusInterface
 	namespaces        corev1.NamespaceInterface
 	k8s               kubernetes.Interface
+	clusterManager    ClusterControllerLifecycle
+	// unreachable is the error of the last attempt to reach the API server of the cluster, nil if it was reached.
+	unreachable error
 }
 
-func Register(ctx context.Context, workload *config.UserContext) {
+func Register(ctx context.Context, workload *config.UserContext, clusterManager ClusterControllerLifecycle) {
 	h := &HealthSyncer{
 		ctx:               ctx,
 		clusterName:       workload.ClusterName,
@@ -65,6 +69,7 @@ func Register(ctx context.Context, workload *config.UserContext) {
 		componentStatuses: workload.Core.ComponentStatuses(""),
 		namespaces:        workload.Core.Namespaces(""),
 		k8s:               workload.K8sClient,
+		clusterManager:    clusterManager,
 	}
 
 	go h.syncHealth(ctx, syncInterval)
@@ -86,7 +91,9 @@ func (h *HealthSyncer) getComponentStatus(cluster *v3.Cluster) error {
 	// Prior to k8s v1.14, we only needed to list the ComponentStatuses from the user cluster.
 	// As of k8s v1.14, kubeapi returns a successful ComponentStatuses response even if etcd is not available.
 	// To work around this, now we try to get a namespace from the API, even if not found, it means the API is up.
+	h.unreachable = nil
 	if _, err := h.k8s.CoreV1().Namespaces().Get(ctx, "kube-system", metav1.GetOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		h.unreachable = err
 		return condition.Error("ComponentStatusFetchingFailure", errors.Wrap(err, "Failed to communicate with API server during namespace check"))
 	}
 
@@ -165,6 +172,14 @@ func (h *HealthSyncer) updateClusterHealth() error {
 		v32.ClusterConditionWaiting.True(newObj)
 		v32.ClusterConditionWaiting.Message(newObj, "")
 	}
+	if h.unreachable == nil {
+		clusterbreaker.Success(h.clusterName)
+	} else if h.ctx.Err() == nil && clusterbreaker.Failure(h.clusterName, h.unreachable) {
+		// stop the controllers of the unreachable cluster, they're started again to probe it once its circuit breaker
+		// allows it
+		clusterbreaker.SetStatus(newObj.(*v3.Cluster))
+		defer h.clusterManager.Stop(cluster)
+	}
 
 	if !reflect.DeepEqual(oldCluster, newObj) {
 		if _, err := h.clusters.Update(newObj.(*v3.Cluster)); err != nil {
@@ -0,0 +1,130 @@
+package metadatapropagation
+
+import (
+	"context"
+	"reflect"
+
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/cluster"
+	provisioningcontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	v1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type handler struct {
+	clusterName     string
+	clusterCache    provisioningcontrollers.ClusterCache
+	nodes           v1.NodeInterface
+	nodeLister      v1.NodeLister
+	namespaces      v1.NamespaceInterface
+	namespaceLister v1.NamespaceLister
+
+	// applied is the metadata the nodes and namespaces were last enqueued for.
+	applied *metadata
+}
+
+// Register registers the metadata-propagation controller of a downstream cluster, which copies the labels and
+// annotations chosen by the metadata propagation of the provisioning cluster to the nodes and namespaces of the
+// cluster, and removes them once they're no longer propagated. Changes made to the copies in the downstream cluster are
+// reverted.
+func Register(ctx context.Context, userContext *config.UserContext) {
+	mgmt := userContext.Management.Wrangler
+	h := &handler{
+		clusterName:     userContext.ClusterName,
+		clusterCache:    mgmt.Provisioning.Cluster().Cache(),
+		nodes:           userContext.Core.Nodes(""),
+		nodeLister:      userContext.Core.Nodes("").Controller().Lister(),
+		namespaces:      userContext.Core.Namespaces(""),
+		namespaceLister: userContext.Core.Namespaces("").Controller().Lister(),
+	}
+
+	mgmt.Provisioning.Cluster().OnChange(ctx, "metadata-propagation-"+userContext.ClusterName, h.onCluster)
+	userContext.Core.Nodes("").AddHandler(ctx, "metadata-propagation-node", h.onNode)
+	userContext.Core.Namespaces("").AddHandler(ctx, "metadata-propagation-namespace", h.onNamespace)
+}
+
+// onCluster enqueues the nodes and namespaces when the propagated metadata of the cluster changed.
+func (h *handler) onCluster(_ string, obj *provv1.Cluster) (*provv1.Cluster, error) {
+	if obj == nil || obj.DeletionTimestamp != nil || obj.Status.ClusterName != h.clusterName {
+		return obj, nil
+	}
+
+	propagated := selected(obj)
+	if reflect.DeepEqual(propagated, h.applied) {
+		return obj, nil
+	}
+
+	nodes, err := h.nodeLister.List("", labels.Everything())
+	if err != nil {
+		return obj, err
+	}
+	for _, node := range nodes {
+		h.nodes.Controller().Enqueue("", node.Name)
+	}
+	namespaces, err := h.namespaceLister.List("", labels.Everything())
+	if err != nil {
+		return obj, err
+	}
+	for _, ns := range namespaces {
+		h.namespaces.Controller().Enqueue("", ns.Name)
+	}
+	h.applied = propagated
+	return obj, nil
+}
+
+// propagated returns the metadata of the provisioning cluster that is propagated, nil if the cluster has none.
+func (h *handler) propagated() (*metadata, error) {
+	clusters, err := h.clusterCache.GetByIndex(cluster.ByCluster, h.clusterName)
+	if err != nil || len(clusters) != 1 {
+		return nil, err
+	}
+	return selected(clusters[0]), nil
+}
+
+func (h *handler) onNode(_ string, node *corev1.Node) (runtime.Object, error) {
+	if node == nil || node.DeletionTimestamp != nil {
+		return node, nil
+	}
+
+	propagated, err := h.propagated()
+	if err != nil || propagated == nil {
+		return node, err
+	}
+
+	updated := node.DeepCopy()
+	changed, err := apply(&updated.ObjectMeta, propagated.labels, propagated.annotations)
+	if err != nil || !changed {
+		return node, err
+	}
+	logrus.Debugf("[metadata-propagation] cluster [%s]: updating metadata of node [%s]", h.clusterName, node.Name)
+	return h.nodes.Update(updated)
+}
+
+func (h *handler) onNamespace(_ string, ns *corev1.Namespace) (runtime.Object, error) {
+	if ns == nil || ns.DeletionTimestamp != nil {
+		return ns, nil
+	}
+
+	propagated, err := h.propagated()
+	if err != nil || propagated == nil {
+		return ns, err
+	}
+
+	updated := ns.DeepCopy()
+	var changed bool
+	if propagated.namespaces[ns.Name] {
+		changed, err = apply(&updated.ObjectMeta, propagated.labels, propagated.annotations)
+	} else {
+		// The metadata is removed from the namespaces removed from the list.
+		changed, err = apply(&updated.ObjectMeta, nil, nil)
+	}
+	if err != nil || !changed {
+		return ns, err
+	}
+	logrus.Debugf("[metadata-propagation] cluster [%s]: updating metadata of namespace [%s]", h.clusterName, ns.Name)
+	return h.namespaces.Update(updated)
+}
@@ -0,0 +1,162 @@
+package metadatapropagation
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PropagatedAnnotation lists the keys of the labels and annotations of a node or namespace that were copied from its
+// cluster, so that they can be removed once they're no longer propagated.
+const PropagatedAnnotation = "provisioning.cattle.io/propagated-metadata"
+
+// reservedDomains are the domains of the keys that are never propagated, as they're managed by Kubernetes.
+var reservedDomains = []string{"kubernetes.io", "k8s.io"}
+
+type propagatedKeys struct {
+	Labels      []string `json:"labels,omitempty"`
+	Annotations []string `json:"annotations,omitempty"`
+}
+
+// metadata is the metadata of a cluster that is propagated, and the namespaces it is propagated to besides the nodes.
+type metadata struct {
+	labels      map[string]string
+	annotations map[string]string
+	namespaces  map[string]bool
+}
+
+// selected returns the metadata the metadata propagation of the cluster copies to its nodes and namespaces.
+func selected(cluster *provv1.Cluster) *metadata {
+	result := &metadata{
+		labels:      map[string]string{},
+		annotations: map[string]string{},
+		namespaces:  map[string]bool{},
+	}
+	propagation := cluster.Spec.MetadataPropagation
+	if propagation == nil {
+		return result
+	}
+	for k, v := range cluster.Labels {
+		if matches(propagation.Labels, k) {
+			result.labels[k] = v
+		}
+	}
+	for k, v := range cluster.Annotations {
+		if matches(propagation.Annotations, k) {
+			result.annotations[k] = v
+		}
+	}
+	for _, namespace := range propagation.Namespaces {
+		result.namespaces[namespace] = true
+	}
+	return result
+}
+
+// matches returns whether the key is matched by one of the patterns and isn't reserved.
+func matches(patterns []string, key string) bool {
+	if reserved(key) {
+		return false
+	}
+	for _, pattern := range patterns {
+		if prefix := strings.TrimSuffix(pattern, "*"); prefix != pattern {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if pattern == key {
+			return true
+		}
+	}
+	return false
+}
+
+func reserved(key string) bool {
+	if key == PropagatedAnnotation {
+		return true
+	}
+	i := strings.Index(key, "/")
+	if i < 0 {
+		return false
+	}
+	domain := key[:i]
+	for _, reservedDomain := range reservedDomains {
+		if domain == reservedDomain || strings.HasSuffix(domain, "."+reservedDomain) {
+			return true
+		}
+	}
+	return false
+}
+
+// apply sets the labels and annotations on the object, removes those previously propagated to it that no longer are,
+// and records the keys that are propagated in its PropagatedAnnotation. It returns whether the object changed.
+func apply(obj *metav1.ObjectMeta, labels, annotations map[string]string) (bool, error) {
+	var previous propagatedKeys
+	if value := obj.Annotations[PropagatedAnnotation]; value != "" {
+		if err := json.Unmarshal([]byte(value), &previous); err != nil {
+			return false, fmt.Errorf("failed to parse %s annotation: %w", PropagatedAnnotation, err)
+		}
+	}
+
+	changed := false
+	set := func(target map[string]string, values map[string]string, previous []string) map[string]string {
+		for _, k := range previous {
+			if _, ok := values[k]; !ok {
+				if _, ok := target[k]; ok {
+					delete(target, k)
+					changed = true
+				}
+			}
+		}
+		for k, v := range values {
+			if current, ok := target[k]; ok && current == v {
+				continue
+			}
+			if target == nil {
+				target = map[string]string{}
+			}
+			target[k] = v
+			changed = true
+		}
+		return target
+	}
+	obj.Labels = set(obj.Labels, labels, previous.Labels)
+	obj.Annotations = set(obj.Annotations, annotations, previous.Annotations)
+
+	keys := propagatedKeys{
+		Labels:      sortedKeys(labels),
+		Annotations: sortedKeys(annotations),
+	}
+	value := ""
+	if len(keys.Labels) > 0 || len(keys.Annotations) > 0 {
+		data, err := json.Marshal(keys)
+		if err != nil {
+			return false, err
+		}
+		value = string(data)
+	}
+	if value == "" {
+		if _, ok := obj.Annotations[PropagatedAnnotation]; ok {
+			delete(obj.Annotations, PropagatedAnnotation)
+			changed = true
+		}
+	} else if obj.Annotations[PropagatedAnnotation] != value {
+		if obj.Annotations == nil {
+			obj.Annotations = map[string]string{}
+		}
+		obj.Annotations[PropagatedAnnotation] = value
+		changed = true
+	}
+	return changed, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
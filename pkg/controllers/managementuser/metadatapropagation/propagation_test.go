@@ -0,0 +1,97 @@
+package metadatapropagation
+
+import (
+	"testing"
+
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSelected(t *testing.T) {
+	cluster := &provv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"team":                           "payments",
+				"env":                            "prod",
+				"example.com/region":             "eu",
+				"example.com/zone":               "eu-1",
+				"node-role.kubernetes.io/worker": "true",
+			},
+			Annotations: map[string]string{
+				"example.com/owner": "alice",
+				"other":             "value",
+			},
+		},
+		Spec: provv1.ClusterSpec{
+			MetadataPropagation: &provv1.MetadataPropagation{
+				Labels:      []string{"team", "example.com/*", "node-role.kubernetes.io/worker"},
+				Annotations: []string{"example.com/owner"},
+				Namespaces:  []string{"cattle-system"},
+			},
+		},
+	}
+
+	result := selected(cluster)
+	assert.Equal(t, map[string]string{"team": "payments", "example.com/region": "eu", "example.com/zone": "eu-1"}, result.labels)
+	assert.Equal(t, map[string]string{"example.com/owner": "alice"}, result.annotations)
+	assert.Equal(t, map[string]bool{"cattle-system": true}, result.namespaces)
+
+	cluster.Spec.MetadataPropagation = nil
+	result = selected(cluster)
+	assert.Empty(t, result.labels)
+	assert.Empty(t, result.annotations)
+	assert.Empty(t, result.namespaces)
+}
+
+func TestReserved(t *testing.T) {
+	assert.True(t, reserved("kubernetes.io/hostname"))
+	assert.True(t, reserved("node.kubernetes.io/instance-type"))
+	assert.True(t, reserved("kubectl.k8s.io/default-container"))
+	assert.True(t, reserved(PropagatedAnnotation))
+	assert.False(t, reserved("team"))
+	assert.False(t, reserved("example.com/kubernetes.io"))
+	assert.False(t, reserved("notkubernetes.io/key"))
+}
+
+func TestApply(t *testing.T) {
+	obj := &metav1.ObjectMeta{
+		Labels: map[string]string{
+			"kubernetes.io/hostname": "node-1",
+			"team":                   "stale",
+		},
+	}
+
+	changed, err := apply(obj, map[string]string{"team": "payments", "env": "prod"}, map[string]string{"example.com/owner": "alice"})
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, map[string]string{"kubernetes.io/hostname": "node-1", "team": "payments", "env": "prod"}, obj.Labels)
+	assert.Equal(t, map[string]string{
+		"example.com/owner":  "alice",
+		PropagatedAnnotation: `{"labels":["env","team"],"annotations":["example.com/owner"]}`,
+	}, obj.Annotations)
+
+	// Applying the same metadata again doesn't change anything.
+	changed, err = apply(obj, map[string]string{"team": "payments", "env": "prod"}, map[string]string{"example.com/owner": "alice"})
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	// Keys that are no longer propagated are removed, the others are left alone.
+	obj.Labels["app"] = "web"
+	changed, err = apply(obj, map[string]string{"team": "payments"}, nil)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, map[string]string{"kubernetes.io/hostname": "node-1", "team": "payments", "app": "web"}, obj.Labels)
+	assert.Equal(t, map[string]string{PropagatedAnnotation: `{"labels":["team"]}`}, obj.Annotations)
+
+	changed, err = apply(obj, nil, nil)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, map[string]string{"kubernetes.io/hostname": "node-1", "app": "web"}, obj.Labels)
+	assert.Empty(t, obj.Annotations)
+
+	obj.Annotations = map[string]string{PropagatedAnnotation: "{"}
+	_, err = apply(obj, nil, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,292 @@
+package namespacepolicy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/controllers/managementuser/resourcequota"
+	v1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	namespaceutil "github.com/rancher/rancher/pkg/namespace"
+	"github.com/rancher/rancher/pkg/ref"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type handler struct {
+	clusterName         string
+	namespaces          v1.NamespaceInterface
+	namespaceController v1.NamespaceController
+	namespaceLister     v1.NamespaceLister
+	projects            v3.ProjectInterface
+	projectLister       v3.ProjectLister
+	resourceQuotas      v1.ResourceQuotaInterface
+	resourceQuotaLister v1.ResourceQuotaLister
+	limitRanges         v1.LimitRangeInterface
+	limitRangeLister    v1.LimitRangeLister
+	now                 func() time.Time
+}
+
+// Register registers the controllers applying the namespace policies of projects to their namespaces: setting default
+// labels and annotations, creating mandatory resource quotas and limit ranges, reporting namespaces not matching the
+// name pattern and deleting namespaces orphaned from deleted projects.
+func Register(ctx context.Context, cluster *config.UserContext) {
+	h := &handler{
+		clusterName:         cluster.ClusterName,
+		namespaces:          cluster.Core.Namespaces(""),
+		namespaceController: cluster.Core.Namespaces("").Controller(),
+		namespaceLister:     cluster.Core.Namespaces("").Controller().Lister(),
+		projects:            cluster.Management.Management.Projects(cluster.ClusterName),
+		projectLister:       cluster.Management.Management.Projects(cluster.ClusterName).Controller().Lister(),
+		resourceQuotas:      cluster.Core.ResourceQuotas(""),
+		resourceQuotaLister: cluster.Core.ResourceQuotas("").Controller().Lister(),
+		limitRanges:         cluster.Core.LimitRanges(""),
+		limitRangeLister:    cluster.Core.LimitRanges("").Controller().Lister(),
+		now:                 time.Now,
+	}
+	cluster.Core.Namespaces("").AddHandler(ctx, "namespace-policy", h.sync)
+	cluster.Management.Management.Projects(cluster.ClusterName).AddHandler(ctx, "namespace-policy-project", h.syncProject)
+	cluster.Core.ResourceQuotas("").AddHandler(ctx, "namespace-policy-resourcequota", func(key string, _ *corev1.ResourceQuota) (runtime.Object, error) {
+		return nil, h.enqueuePolicyResource(key)
+	})
+	cluster.Core.LimitRanges("").AddHandler(ctx, "namespace-policy-limitrange", func(key string, _ *corev1.LimitRange) (runtime.Object, error) {
+		return nil, h.enqueuePolicyResource(key)
+	})
+}
+
+// syncProject enqueues the namespaces of a project when its namespace policy may have changed.
+func (h *handler) syncProject(_ string, project *v3.Project) (runtime.Object, error) {
+	if project == nil {
+		return nil, nil
+	}
+	projectID := fmt.Sprintf("%s:%s", project.Namespace, project.Name)
+	namespaces, err := h.namespaceLister.List("", labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, ns := range namespaces {
+		if ns.Annotations[projectIDAnnotation] == projectID || ns.Annotations[orphanCleanupProjectAnnotation] == projectID {
+			h.namespaceController.Enqueue("", ns.Name)
+		}
+	}
+	return project, nil
+}
+
+// enqueuePolicyResource enqueues the namespace of the resource quota or limit range of a namespace policy, so that
+// changes to them are reverted.
+func (h *handler) enqueuePolicyResource(key string) error {
+	namespace, name, ok := strings.Cut(key, "/")
+	if ok && name == policyResourceName {
+		h.namespaceController.Enqueue("", namespace)
+	}
+	return nil
+}
+
+func (h *handler) sync(_ string, ns *corev1.Namespace) (runtime.Object, error) {
+	if ns == nil || ns.DeletionTimestamp != nil {
+		return ns, nil
+	}
+	projectID := ns.Annotations[projectIDAnnotation]
+	cleanupProjectID := ns.Annotations[orphanCleanupProjectAnnotation]
+	if projectID == "" && cleanupProjectID == "" {
+		return ns, nil
+	}
+
+	project, err := h.getProject(projectID)
+	if err != nil {
+		return ns, err
+	}
+	if project == nil {
+		if cleanupProjectID == "" {
+			return ns, nil
+		}
+		return h.syncOrphan(ns, cleanupProjectID)
+	}
+
+	policy := project.Spec.NamespacePolicy
+	toUpdate := ns.DeepCopy()
+	changed := applyPolicy(toUpdate, projectID, policy)
+	if policy != nil && policy.NamePattern != "" {
+		conditionChanged, err := setCompliance(toUpdate, policy)
+		if err != nil {
+			logrus.Warnf("[namespace-policy] project %s: %v", projectID, err)
+		}
+		changed = changed || conditionChanged
+	}
+	if changed {
+		if ns, err = h.namespaces.Update(toUpdate); err != nil {
+			return ns, err
+		}
+	}
+
+	if err := h.ensureResourceQuota(ns, policy); err != nil {
+		return ns, err
+	}
+	return ns, h.ensureLimitRange(ns, policy)
+}
+
+// getProject returns the project of this cluster with the id, nil if there's none.
+func (h *handler) getProject(projectID string) (*v3.Project, error) {
+	clusterName, projectName := ref.Parse(projectID)
+	if projectID == "" || clusterName != h.clusterName {
+		return nil, nil
+	}
+	project, err := h.projectLister.Get(clusterName, projectName)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	return project, err
+}
+
+// syncOrphan deletes a namespace, once its grace period expired, if the project whose namespace policy asked for it
+// was deleted. The namespace was moved out of the project otherwise, the policy doesn't apply anymore.
+func (h *handler) syncOrphan(ns *corev1.Namespace, cleanupProjectID string) (runtime.Object, error) {
+	clusterName, projectName := ref.Parse(cleanupProjectID)
+	if clusterName != h.clusterName {
+		return ns, nil
+	}
+	// The project isn't read from the cache, a namespace is only deleted if its project really doesn't exist.
+	project, err := h.projects.GetNamespaced(clusterName, projectName, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return ns, err
+	}
+	if err == nil && project.DeletionTimestamp == nil {
+		toUpdate := ns.DeepCopy()
+		if applyPolicy(toUpdate, "", nil) {
+			return h.namespaces.Update(toUpdate)
+		}
+		return ns, nil
+	}
+
+	now := h.now()
+	if ns.Annotations[orphanedAtAnnotation] == "" {
+		toUpdate := ns.DeepCopy()
+		toUpdate.Annotations[orphanedAtAnnotation] = now.UTC().Format(time.RFC3339)
+		if ns, err = h.namespaces.Update(toUpdate); err != nil {
+			return ns, err
+		}
+	}
+	if remaining := orphanDeletion(ns, now); remaining > 0 {
+		h.namespaceController.EnqueueAfter("", ns.Name, remaining)
+		return ns, nil
+	}
+
+	logrus.Infof("[namespace-policy] deleting namespace %s orphaned from deleted project %s", ns.Name, cleanupProjectID)
+	if err := h.namespaces.Delete(ns.Name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return ns, err
+	}
+	return ns, nil
+}
+
+// setCompliance sets the compliance condition of a namespace from whether its name matches the name pattern of the
+// namespace policy, and returns whether the condition changed.
+func setCompliance(ns *corev1.Namespace, policy *v32.NamespacePolicy) (bool, error) {
+	matches, err := namespaceutil.NamePatternMatches(policy, ns.Name)
+	if err != nil {
+		return false, err
+	}
+	set, err := namespaceutil.IsNamespaceConditionSet(ns, NamespacePolicyCompliantCondition, matches)
+	if err != nil || set {
+		return false, err
+	}
+	message := ""
+	if !matches {
+		message = fmt.Sprintf("namespace name does not match the pattern %s of the project", policy.NamePattern)
+	}
+	return true, namespaceutil.SetNamespaceCondition(ns, 0, NamespacePolicyCompliantCondition, matches, message)
+}
+
+func (h *handler) ensureResourceQuota(ns *corev1.Namespace, policy *v32.NamespacePolicy) error {
+	var spec *corev1.ResourceQuotaSpec
+	if policy != nil && policy.ResourceQuota != nil {
+		var err error
+		if spec, err = resourcequota.ResourceQuotaSpec(policy.ResourceQuota); err != nil {
+			return err
+		}
+	}
+
+	existing, err := h.resourceQuotaLister.Get(ns.Name, policyResourceName)
+	if apierrors.IsNotFound(err) {
+		existing = nil
+	} else if err != nil {
+		return err
+	}
+
+	switch {
+	case spec == nil && existing == nil:
+		return nil
+	case spec == nil:
+		err = h.resourceQuotas.DeleteNamespaced(ns.Name, policyResourceName, &metav1.DeleteOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	case existing == nil:
+		_, err = h.resourceQuotas.Create(&corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      policyResourceName,
+				Namespace: ns.Name,
+			},
+			Spec: *spec,
+		})
+		return err
+	case !apiequality.Semantic.DeepEqual(existing.Spec, *spec):
+		toUpdate := existing.DeepCopy()
+		toUpdate.Spec = *spec
+		_, err = h.resourceQuotas.Update(toUpdate)
+		return err
+	}
+	return nil
+}
+
+func (h *handler) ensureLimitRange(ns *corev1.Namespace, policy *v32.NamespacePolicy) error {
+	var spec *corev1.LimitRangeSpec
+	if policy != nil && policy.ContainerResourceLimit != nil {
+		var err error
+		if spec, err = resourcequota.LimitRangeSpec(policy.ContainerResourceLimit); err != nil {
+			return err
+		}
+	}
+
+	existing, err := h.limitRangeLister.Get(ns.Name, policyResourceName)
+	if apierrors.IsNotFound(err) {
+		existing = nil
+	} else if err != nil {
+		return err
+	}
+
+	switch {
+	case spec == nil && existing == nil:
+		return nil
+	case spec == nil:
+		err = h.limitRanges.DeleteNamespaced(ns.Name, policyResourceName, &metav1.DeleteOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	case existing == nil:
+		_, err = h.limitRanges.Create(&corev1.LimitRange{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      policyResourceName,
+				Namespace: ns.Name,
+			},
+			Spec: *spec,
+		})
+		return err
+	case !apiequality.Semantic.DeepEqual(existing.Spec, *spec):
+		toUpdate := existing.DeepCopy()
+		toUpdate.Spec = *spec
+		_, err = h.limitRanges.Update(toUpdate)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package namespacepolicy
+
+import (
+	"time"
+
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	projectIDAnnotation = "field.cattle.io/projectId"
+	// orphanCleanupProjectAnnotation is the project whose namespace policy deletes the namespace once the project is
+	// deleted, and orphanCleanupGracePeriodAnnotation how long after the project is found deleted.
+	orphanCleanupProjectAnnotation     = "field.cattle.io/orphan-cleanup-project"
+	orphanCleanupGracePeriodAnnotation = "field.cattle.io/orphan-cleanup-grace-period"
+	// orphanedAtAnnotation is when the project of the namespace was found deleted.
+	orphanedAtAnnotation = "field.cattle.io/orphaned-at"
+
+	// NamespacePolicyCompliantCondition is set on the namespaces of projects with a namespace policy, false when the
+	// name of the namespace doesn't match the name pattern of the policy.
+	NamespacePolicyCompliantCondition = "NamespacePolicyCompliant"
+	// policyResourceName is the name of the resource quota and limit range created for namespace policies.
+	policyResourceName       = "namespace-policy"
+	defaultOrphanGracePeriod = time.Hour
+)
+
+// applyPolicy sets the labels and annotations of a namespace policy missing from a namespace, and the annotations
+// deleting the namespace when its project is deleted if the policy asks for it. It returns whether the namespace was
+// changed.
+func applyPolicy(ns *corev1.Namespace, projectID string, policy *v32.NamespacePolicy) bool {
+	changed := false
+	set := func(m *map[string]string, key, value string) {
+		if current, ok := (*m)[key]; ok && current == value {
+			return
+		}
+		if *m == nil {
+			*m = map[string]string{}
+		}
+		(*m)[key] = value
+		changed = true
+	}
+	unset := func(m map[string]string, key string) {
+		if _, ok := m[key]; ok {
+			delete(m, key)
+			changed = true
+		}
+	}
+
+	if policy != nil {
+		for key, value := range policy.Labels {
+			if _, ok := ns.Labels[key]; !ok {
+				set(&ns.Labels, key, value)
+			}
+		}
+		for key, value := range policy.Annotations {
+			if _, ok := ns.Annotations[key]; !ok {
+				set(&ns.Annotations, key, value)
+			}
+		}
+	}
+
+	if policy != nil && policy.DeleteOrphanedNamespaces {
+		gracePeriod := defaultOrphanGracePeriod
+		if policy.OrphanedNamespaceGracePeriodSeconds > 0 {
+			gracePeriod = time.Duration(policy.OrphanedNamespaceGracePeriodSeconds) * time.Second
+		}
+		set(&ns.Annotations, orphanCleanupProjectAnnotation, projectID)
+		set(&ns.Annotations, orphanCleanupGracePeriodAnnotation, gracePeriod.String())
+	} else {
+		unset(ns.Annotations, orphanCleanupProjectAnnotation)
+		unset(ns.Annotations, orphanCleanupGracePeriodAnnotation)
+	}
+	unset(ns.Annotations, orphanedAtAnnotation)
+	return changed
+}
+
+// orphanDeletion returns how long until an orphaned namespace is deleted, zero or less if it should be deleted now.
+// The namespace is considered orphaned at now if it wasn't already.
+func orphanDeletion(ns *corev1.Namespace, now time.Time) time.Duration {
+	gracePeriod, err := time.ParseDuration(ns.Annotations[orphanCleanupGracePeriodAnnotation])
+	if err != nil {
+		gracePeriod = defaultOrphanGracePeriod
+	}
+	orphanedAt, err := time.Parse(time.RFC3339, ns.Annotations[orphanedAtAnnotation])
+	if err != nil {
+		orphanedAt = now
+	}
+	return orphanedAt.Add(gracePeriod).Sub(now)
+}
@@ -0,0 +1,77 @@
+package namespacepolicy
+
+import (
+	"testing"
+	"time"
+
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestApplyPolicy(t *testing.T) {
+	policy := &v32.NamespacePolicy{
+		Labels:                              map[string]string{"team": "a", "env": "dev"},
+		Annotations:                         map[string]string{"owner": "team-a"},
+		DeleteOrphanedNamespaces:            true,
+		OrphanedNamespaceGracePeriodSeconds: 600,
+	}
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "ns",
+			Labels: map[string]string{"env": "prod"},
+			Annotations: map[string]string{
+				projectIDAnnotation:  "c-1:p-1",
+				orphanedAtAnnotation: "2023-01-01T00:00:00Z",
+			},
+		},
+	}
+
+	assert.True(t, applyPolicy(ns, "c-1:p-1", policy))
+	assert.Equal(t, map[string]string{"team": "a", "env": "prod"}, ns.Labels, "existing labels are kept")
+	assert.Equal(t, "team-a", ns.Annotations["owner"])
+	assert.Equal(t, "c-1:p-1", ns.Annotations[orphanCleanupProjectAnnotation])
+	assert.Equal(t, "10m0s", ns.Annotations[orphanCleanupGracePeriodAnnotation])
+	assert.NotContains(t, ns.Annotations, orphanedAtAnnotation)
+
+	assert.False(t, applyPolicy(ns, "c-1:p-1", policy), "applying the policy again doesn't change the namespace")
+
+	policy.DeleteOrphanedNamespaces = false
+	assert.True(t, applyPolicy(ns, "c-1:p-1", policy))
+	assert.NotContains(t, ns.Annotations, orphanCleanupProjectAnnotation)
+	assert.NotContains(t, ns.Annotations, orphanCleanupGracePeriodAnnotation)
+	assert.False(t, applyPolicy(ns, "c-1:p-1", nil))
+}
+
+func TestApplyPolicyNilMaps(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}}
+	assert.False(t, applyPolicy(ns, "c-1:p-1", nil))
+	assert.True(t, applyPolicy(ns, "c-1:p-1", &v32.NamespacePolicy{
+		Labels:                   map[string]string{"team": "a"},
+		DeleteOrphanedNamespaces: true,
+	}))
+	assert.Equal(t, "a", ns.Labels["team"])
+	assert.Equal(t, defaultOrphanGracePeriod.String(), ns.Annotations[orphanCleanupGracePeriodAnnotation])
+}
+
+func TestOrphanDeletion(t *testing.T) {
+	now := time.Date(2023, 1, 1, 1, 0, 0, 0, time.UTC)
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				orphanCleanupGracePeriodAnnotation: "30m0s",
+			},
+		},
+	}
+	assert.Equal(t, 30*time.Minute, orphanDeletion(ns, now), "namespaces not orphaned yet are orphaned now")
+
+	ns.Annotations[orphanedAtAnnotation] = "2023-01-01T00:45:00Z"
+	assert.Equal(t, 15*time.Minute, orphanDeletion(ns, now))
+
+	ns.Annotations[orphanedAtAnnotation] = "2023-01-01T00:00:00Z"
+	assert.LessOrEqual(t, orphanDeletion(ns, now), time.Duration(0))
+
+	ns.Annotations[orphanCleanupGracePeriodAnnotation] = "invalid"
+	assert.Equal(t, time.Duration(0), orphanDeletion(ns, now), "invalid grace periods default to an hour")
+}
@@ -0,0 +1,321 @@
+package namespacetemplate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	v1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	netv1 "github.com/rancher/rancher/pkg/generated/norman/networking.k8s.io/v1"
+	rbacv1client "github.com/rancher/rancher/pkg/generated/norman/rbac.authorization.k8s.io/v1"
+	namespaceutil "github.com/rancher/rancher/pkg/namespace"
+	"github.com/rancher/rancher/pkg/ref"
+	"github.com/rancher/rancher/pkg/types/config"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// errConflict is returned when a resource of a namespace template has the name of a resource that wasn't created from
+// the template.
+var errConflict = errors.New("already exists and was not created from the namespace template")
+
+type handler struct {
+	clusterName         string
+	templateCache       mgmtcontrollers.NamespaceTemplateCache
+	namespaces          v1.NamespaceInterface
+	namespaceController v1.NamespaceController
+	namespaceLister     v1.NamespaceLister
+	projectLister       v3.ProjectLister
+	networkPolicies     netv1.NetworkPolicyInterface
+	networkPolicyLister netv1.NetworkPolicyLister
+	roleBindings        rbacv1client.RoleBindingInterface
+	roleBindingLister   rbacv1client.RoleBindingLister
+}
+
+// Register registers the controllers applying namespace templates to the namespaces created from them: setting the
+// labels, annotations and quotas of the template missing from the namespace, and keeping the network policies and role
+// bindings of the template in sync, as long as the project of the namespace is allowed to use the template.
+func Register(ctx context.Context, cluster *config.UserContext) {
+	mgmt := cluster.Management.Wrangler
+	h := &handler{
+		clusterName:         cluster.ClusterName,
+		templateCache:       mgmt.Mgmt.NamespaceTemplate().Cache(),
+		namespaces:          cluster.Core.Namespaces(""),
+		namespaceController: cluster.Core.Namespaces("").Controller(),
+		namespaceLister:     cluster.Core.Namespaces("").Controller().Lister(),
+		projectLister:       cluster.Management.Management.Projects(cluster.ClusterName).Controller().Lister(),
+		networkPolicies:     cluster.Networking.NetworkPolicies(""),
+		networkPolicyLister: cluster.Networking.NetworkPolicies("").Controller().Lister(),
+		roleBindings:        cluster.RBAC.RoleBindings(""),
+		roleBindingLister:   cluster.RBAC.RoleBindings("").Controller().Lister(),
+	}
+
+	cluster.Core.Namespaces("").AddHandler(ctx, "namespace-template", h.sync)
+	mgmt.Mgmt.NamespaceTemplate().OnChange(ctx, "namespace-template-"+cluster.ClusterName, h.onTemplate)
+	cluster.Management.Management.Projects(cluster.ClusterName).AddHandler(ctx, "namespace-template-project", h.onProject)
+	cluster.Networking.NetworkPolicies("").AddHandler(ctx, "namespace-template-networkpolicy", func(key string, _ *networkingv1.NetworkPolicy) (runtime.Object, error) {
+		return nil, h.enqueueNamespaceOf(key)
+	})
+	cluster.RBAC.RoleBindings("").AddHandler(ctx, "namespace-template-rolebinding", func(key string, _ *rbacv1.RoleBinding) (runtime.Object, error) {
+		return nil, h.enqueueNamespaceOf(key)
+	})
+}
+
+// onTemplate enqueues the namespaces created from a namespace template of this cluster when it changes.
+func (h *handler) onTemplate(key string, template *v32.NamespaceTemplate) (*v32.NamespaceTemplate, error) {
+	namespace, name, _ := strings.Cut(key, "/")
+	if namespace != h.clusterName {
+		return template, nil
+	}
+	return template, h.enqueueNamespaces(func(ns *corev1.Namespace) bool {
+		return ns.Annotations[NamespaceTemplateAnnotation] == ref.FromStrings(namespace, name)
+	})
+}
+
+// onProject enqueues the namespaces of a project created from a namespace template, whose labels may allow or deny
+// using the template.
+func (h *handler) onProject(_ string, project *v3.Project) (runtime.Object, error) {
+	if project == nil {
+		return nil, nil
+	}
+	projectID := ref.FromStrings(project.Namespace, project.Name)
+	return project, h.enqueueNamespaces(func(ns *corev1.Namespace) bool {
+		return ns.Annotations[projectIDAnnotation] == projectID && ns.Annotations[NamespaceTemplateAnnotation] != ""
+	})
+}
+
+func (h *handler) enqueueNamespaces(matches func(*corev1.Namespace) bool) error {
+	namespaces, err := h.namespaceLister.List("", labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, ns := range namespaces {
+		if matches(ns) {
+			h.namespaceController.Enqueue("", ns.Name)
+		}
+	}
+	return nil
+}
+
+// enqueueNamespaceOf enqueues the namespace of a network policy or role binding when it's created from a namespace
+// template, so that changes to the resources of the template are reverted.
+func (h *handler) enqueueNamespaceOf(key string) error {
+	namespace, _, ok := strings.Cut(key, "/")
+	if !ok {
+		return nil
+	}
+	ns, err := h.namespaceLister.Get("", namespace)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if ns.Annotations[NamespaceTemplateAnnotation] != "" {
+		h.namespaceController.Enqueue("", ns.Name)
+	}
+	return nil
+}
+
+func (h *handler) sync(_ string, ns *corev1.Namespace) (runtime.Object, error) {
+	if ns == nil || ns.DeletionTimestamp != nil {
+		return ns, nil
+	}
+	templateID := ns.Annotations[NamespaceTemplateAnnotation]
+	clusterName, templateName := ref.Parse(templateID)
+	if templateID != "" && clusterName != h.clusterName {
+		return ns, nil
+	}
+
+	var template *v32.NamespaceTemplate
+	message := ""
+	if templateID != "" {
+		var err error
+		if template, message, err = h.getTemplate(ns, clusterName, templateName); err != nil {
+			return ns, err
+		}
+	}
+
+	if template != nil {
+		toUpdate := ns.DeepCopy()
+		changed, err := applyTemplate(toUpdate, template)
+		if err != nil {
+			return ns, err
+		}
+		if changed {
+			if ns, err = h.namespaces.Update(toUpdate); err != nil {
+				return ns, err
+			}
+		}
+	}
+
+	// The resources of the template are removed when the namespace isn't created from it anymore, the namespace
+	// keeps its labels, annotations and quotas.
+	err := h.ensureNetworkPolicies(ns.Name, template)
+	if err == nil {
+		err = h.ensureRoleBindings(ns.Name, template)
+	}
+	if errors.Is(err, errConflict) {
+		message = err.Error()
+	} else if err != nil {
+		return ns, err
+	}
+
+	if templateID == "" {
+		return ns, nil
+	}
+	return h.setApplied(ns, message)
+}
+
+// getTemplate returns the namespace template of a namespace, or why it can't be applied to the namespace.
+func (h *handler) getTemplate(ns *corev1.Namespace, clusterName, templateName string) (*v32.NamespaceTemplate, string, error) {
+	template, err := h.templateCache.Get(clusterName, templateName)
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Sprintf("namespace template %s not found", templateName), nil
+	} else if err != nil {
+		return nil, "", err
+	}
+
+	projectNamespace, projectName := ref.Parse(ns.Annotations[projectIDAnnotation])
+	if projectName == "" || projectNamespace != h.clusterName {
+		return nil, fmt.Sprintf("namespace template %s can only be used by the namespaces of a project", templateName), nil
+	}
+	project, err := h.projectLister.Get(projectNamespace, projectName)
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Sprintf("project %s not found", projectName), nil
+	} else if err != nil {
+		return nil, "", err
+	}
+	allowed, err := projectAllowed(template, project)
+	if err != nil {
+		return nil, err.Error(), nil
+	}
+	if !allowed {
+		return nil, fmt.Sprintf("project %s is not allowed to use namespace template %s", projectName, templateName), nil
+	}
+	return template, "", nil
+}
+
+// setApplied sets the applied condition of a namespace created from a namespace template, false with the message if
+// there's one.
+func (h *handler) setApplied(ns *corev1.Namespace, message string) (runtime.Object, error) {
+	applied := message == ""
+	set, err := namespaceutil.IsNamespaceConditionSet(ns, NamespaceTemplateAppliedCondition, applied)
+	if err != nil || set {
+		return ns, err
+	}
+	toUpdate := ns.DeepCopy()
+	if err := namespaceutil.SetNamespaceCondition(toUpdate, 0, NamespaceTemplateAppliedCondition, applied, message); err != nil {
+		return ns, err
+	}
+	return h.namespaces.Update(toUpdate)
+}
+
+// ensureNetworkPolicies creates or updates the network policies of the namespace template of a namespace, and deletes
+// the network policies created from a template that it doesn't have.
+func (h *handler) ensureNetworkPolicies(ns string, template *v32.NamespaceTemplate) error {
+	existing, err := h.networkPolicyLister.List(ns, labels.Everything())
+	if err != nil {
+		return err
+	}
+	byName := map[string]*networkingv1.NetworkPolicy{}
+	for _, policy := range existing {
+		byName[policy.Name] = policy
+	}
+
+	desired := networkPolicies(ns, template)
+	wanted := map[string]bool{}
+	for _, policy := range desired {
+		wanted[policy.Name] = true
+		current, ok := byName[policy.Name]
+		switch {
+		case !ok:
+			if _, err := h.networkPolicies.Create(policy); err != nil && !apierrors.IsAlreadyExists(err) {
+				return err
+			}
+		case current.Labels[NamespaceTemplateLabel] == "":
+			return fmt.Errorf("network policy %s/%s %w", ns, policy.Name, errConflict)
+		case current.Labels[NamespaceTemplateLabel] != policy.Labels[NamespaceTemplateLabel] ||
+			!apiequality.Semantic.DeepEqual(current.Spec, policy.Spec):
+			toUpdate := current.DeepCopy()
+			toUpdate.Labels[NamespaceTemplateLabel] = policy.Labels[NamespaceTemplateLabel]
+			toUpdate.Spec = policy.Spec
+			if _, err := h.networkPolicies.Update(toUpdate); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, policy := range existing {
+		if policy.Labels[NamespaceTemplateLabel] == "" || wanted[policy.Name] {
+			continue
+		}
+		if err := h.networkPolicies.DeleteNamespaced(ns, policy.Name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureRoleBindings creates or updates the role bindings of the namespace template of a namespace, and deletes the
+// role bindings created from a template that it doesn't have.
+func (h *handler) ensureRoleBindings(ns string, template *v32.NamespaceTemplate) error {
+	existing, err := h.roleBindingLister.List(ns, labels.Everything())
+	if err != nil {
+		return err
+	}
+	byName := map[string]*rbacv1.RoleBinding{}
+	for _, binding := range existing {
+		byName[binding.Name] = binding
+	}
+
+	desired := roleBindings(ns, template)
+	wanted := map[string]bool{}
+	for _, binding := range desired {
+		wanted[binding.Name] = true
+		current, ok := byName[binding.Name]
+		if ok && current.Labels[NamespaceTemplateLabel] == "" {
+			return fmt.Errorf("role binding %s/%s %w", ns, binding.Name, errConflict)
+		}
+		// The role of a role binding can't be changed, the role binding is recreated instead.
+		if ok && current.RoleRef != binding.RoleRef {
+			if err := h.roleBindings.DeleteNamespaced(ns, binding.Name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+			ok = false
+		}
+		switch {
+		case !ok:
+			if _, err := h.roleBindings.Create(binding); err != nil && !apierrors.IsAlreadyExists(err) {
+				return err
+			}
+		case current.Labels[NamespaceTemplateLabel] != binding.Labels[NamespaceTemplateLabel] ||
+			!apiequality.Semantic.DeepEqual(current.Subjects, binding.Subjects):
+			toUpdate := current.DeepCopy()
+			toUpdate.Labels[NamespaceTemplateLabel] = binding.Labels[NamespaceTemplateLabel]
+			toUpdate.Subjects = binding.Subjects
+			if _, err := h.roleBindings.Update(toUpdate); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, binding := range existing {
+		if binding.Labels[NamespaceTemplateLabel] == "" || wanted[binding.Name] {
+			continue
+		}
+		if err := h.roleBindings.DeleteNamespaced(ns, binding.Name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
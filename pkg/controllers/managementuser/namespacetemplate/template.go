@@ -0,0 +1,135 @@
+package namespacetemplate
+
+import (
+	"encoding/json"
+	"fmt"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// NamespaceTemplateAnnotation is the namespace template a namespace is created from, as <cluster>:<template>, the
+	// template being in the namespace of the cluster.
+	NamespaceTemplateAnnotation = "field.cattle.io/namespaceTemplateId"
+	// NamespaceTemplateLabel is set on the network policies and role bindings created from a namespace template, to
+	// the name of the template.
+	NamespaceTemplateLabel = "namespacetemplate.cattle.io/template"
+	// NamespaceTemplateAppliedCondition is set on the namespaces created from a namespace template, false when the
+	// template doesn't exist or the project of the namespace isn't allowed to use it.
+	NamespaceTemplateAppliedCondition = "NamespaceTemplateApplied"
+
+	projectIDAnnotation     = "field.cattle.io/projectId"
+	resourceQuotaAnnotation = "field.cattle.io/resourceQuota"
+	limitRangeAnnotation    = "field.cattle.io/containerDefaultResourceLimit"
+)
+
+// projectAllowed returns whether the namespaces of a project can be created from a namespace template.
+func projectAllowed(template *v3.NamespaceTemplate, project *v3.Project) (bool, error) {
+	if len(template.Spec.ProjectNames) == 0 && template.Spec.ProjectSelector == nil {
+		return true, nil
+	}
+	for _, name := range template.Spec.ProjectNames {
+		if name == project.Name {
+			return true, nil
+		}
+	}
+	if template.Spec.ProjectSelector == nil {
+		return false, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(template.Spec.ProjectSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid project selector of namespace template %s/%s: %w", template.Namespace, template.Name, err)
+	}
+	return selector.Matches(labels.Set(project.Labels)), nil
+}
+
+// applyTemplate sets the labels and annotations of a namespace template missing from a namespace, and pre-fills its
+// quota and default container resource limits if it doesn't set them. It returns whether the namespace was changed.
+func applyTemplate(ns *corev1.Namespace, template *v3.NamespaceTemplate) (bool, error) {
+	changed := false
+	setMissing := func(m *map[string]string, key, value string) {
+		if _, ok := (*m)[key]; ok {
+			return
+		}
+		if *m == nil {
+			*m = map[string]string{}
+		}
+		(*m)[key] = value
+		changed = true
+	}
+
+	for key, value := range template.Spec.Labels {
+		setMissing(&ns.Labels, key, value)
+	}
+	for key, value := range template.Spec.Annotations {
+		setMissing(&ns.Annotations, key, value)
+	}
+	if template.Spec.ResourceQuota != nil {
+		quota, err := json.Marshal(template.Spec.ResourceQuota)
+		if err != nil {
+			return false, err
+		}
+		setMissing(&ns.Annotations, resourceQuotaAnnotation, string(quota))
+	}
+	if template.Spec.ContainerDefaultResourceLimit != nil {
+		limit, err := json.Marshal(template.Spec.ContainerDefaultResourceLimit)
+		if err != nil {
+			return false, err
+		}
+		setMissing(&ns.Annotations, limitRangeAnnotation, string(limit))
+	}
+	return changed, nil
+}
+
+// networkPolicies returns the network policies of a namespace created from a namespace template, none if template is
+// nil.
+func networkPolicies(ns string, template *v3.NamespaceTemplate) []*networkingv1.NetworkPolicy {
+	if template == nil {
+		return nil
+	}
+	var result []*networkingv1.NetworkPolicy
+	for _, policy := range template.Spec.NetworkPolicies {
+		result = append(result, &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      policy.Name,
+				Namespace: ns,
+				Labels: map[string]string{
+					NamespaceTemplateLabel: template.Name,
+				},
+			},
+			Spec: *policy.Spec.DeepCopy(),
+		})
+	}
+	return result
+}
+
+// roleBindings returns the role bindings of a namespace created from a namespace template, none if template is nil.
+func roleBindings(ns string, template *v3.NamespaceTemplate) []*rbacv1.RoleBinding {
+	if template == nil {
+		return nil
+	}
+	var result []*rbacv1.RoleBinding
+	for _, binding := range template.Spec.RoleBindings {
+		result = append(result, &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      binding.Name,
+				Namespace: ns,
+				Labels: map[string]string{
+					NamespaceTemplateLabel: template.Name,
+				},
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     binding.ClusterRoleName,
+			},
+			Subjects: append([]rbacv1.Subject(nil), binding.Subjects...),
+		})
+	}
+	return result
+}
@@ -0,0 +1,166 @@
+package namespacetemplate
+
+import (
+	"testing"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestProjectAllowed(t *testing.T) {
+	project := &v3.Project{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "c-abcde",
+			Name:      "p-team",
+			Labels:    map[string]string{"tier": "dev"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		spec     v3.NamespaceTemplateSpec
+		expected bool
+		err      string
+	}{
+		{
+			name:     "every project",
+			expected: true,
+		},
+		{
+			name:     "by name",
+			spec:     v3.NamespaceTemplateSpec{ProjectNames: []string{"p-other", "p-team"}},
+			expected: true,
+		},
+		{
+			name:     "other project",
+			spec:     v3.NamespaceTemplateSpec{ProjectNames: []string{"p-other"}},
+			expected: false,
+		},
+		{
+			name: "by selector",
+			spec: v3.NamespaceTemplateSpec{
+				ProjectNames:    []string{"p-other"},
+				ProjectSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "dev"}},
+			},
+			expected: true,
+		},
+		{
+			name:     "not selected",
+			spec:     v3.NamespaceTemplateSpec{ProjectSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "prod"}}},
+			expected: false,
+		},
+		{
+			name: "invalid selector",
+			spec: v3.NamespaceTemplateSpec{ProjectSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "tier", Operator: "Unknown"},
+			}}},
+			err: "invalid project selector of namespace template c-abcde/nt-dev",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := &v3.NamespaceTemplate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "c-abcde", Name: "nt-dev"},
+				Spec:       tt.spec,
+			}
+			allowed, err := projectAllowed(template, project)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, allowed)
+		})
+	}
+}
+
+func TestApplyTemplate(t *testing.T) {
+	template := &v3.NamespaceTemplate{
+		Spec: v3.NamespaceTemplateSpec{
+			Labels:      map[string]string{"team": "a", "env": "dev"},
+			Annotations: map[string]string{"owner": "team-a"},
+			ResourceQuota: &v3.NamespaceResourceQuota{
+				Limit: v3.ResourceQuotaLimit{Pods: "10"},
+			},
+			ContainerDefaultResourceLimit: &v3.ContainerResourceLimit{LimitsCPU: "500m"},
+		},
+	}
+
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "team-a-dev",
+			Labels: map[string]string{"env": "test"},
+			Annotations: map[string]string{
+				"field.cattle.io/containerDefaultResourceLimit": `{"limitsCpu":"1"}`,
+			},
+		},
+	}
+	changed, err := applyTemplate(ns, template)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, map[string]string{"team": "a", "env": "test"}, ns.Labels)
+	assert.Equal(t, map[string]string{
+		"owner":                         "team-a",
+		"field.cattle.io/resourceQuota": `{"limit":{"pods":"10"}}`,
+		"field.cattle.io/containerDefaultResourceLimit": `{"limitsCpu":"1"}`,
+	}, ns.Annotations)
+
+	// the values set on the namespace, even from the template, aren't changed
+	ns.Annotations["field.cattle.io/resourceQuota"] = `{"limit":{"pods":"5"}}`
+	changed, err = applyTemplate(ns, template)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, `{"limit":{"pods":"5"}}`, ns.Annotations["field.cattle.io/resourceQuota"])
+}
+
+func TestTemplateResources(t *testing.T) {
+	template := &v3.NamespaceTemplate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "c-abcde", Name: "nt-dev"},
+		Spec: v3.NamespaceTemplateSpec{
+			NetworkPolicies: []v3.NamespaceTemplateNetworkPolicy{
+				{
+					Name: "deny-ingress",
+					Spec: networkingv1.NetworkPolicySpec{PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}},
+				},
+			},
+			RoleBindings: []v3.NamespaceTemplateRoleBinding{
+				{
+					Name:            "developers",
+					ClusterRoleName: "edit",
+					Subjects:        []rbacv1.Subject{{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: "developers"}},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []*networkingv1.NetworkPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "deny-ingress",
+				Namespace: "team-a-dev",
+				Labels:    map[string]string{NamespaceTemplateLabel: "nt-dev"},
+			},
+			Spec: networkingv1.NetworkPolicySpec{PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}},
+		},
+	}, networkPolicies("team-a-dev", template))
+
+	assert.Equal(t, []*rbacv1.RoleBinding{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "developers",
+				Namespace: "team-a-dev",
+				Labels:    map[string]string{NamespaceTemplateLabel: "nt-dev"},
+			},
+			RoleRef:  rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "edit"},
+			Subjects: []rbacv1.Subject{{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: "developers"}},
+		},
+	}, roleBindings("team-a-dev", template))
+
+	assert.Nil(t, networkPolicies("team-a-dev", nil))
+	assert.Nil(t, roleBindings("team-a-dev", nil))
+}
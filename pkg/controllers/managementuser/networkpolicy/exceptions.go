@@ -0,0 +1,130 @@
+package networkpolicy
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/controllers/managementagent/nslabels"
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/sirupsen/logrus"
+	knetworkingv1 "k8s.io/api/networking/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// getProjectExceptions returns the peers traffic is allowed from into the namespaces of a project and the namespaces
+// of the project exempt from isolation, as set on the project network policies of the project.
+func (npmgr *netpolMgr) getProjectExceptions(projectID string) ([]knetworkingv1.NetworkPolicyPeer, map[string]bool, error) {
+	pnps, err := npmgr.pnpLister.List(projectID, labels.Everything())
+	if err != nil {
+		return nil, nil, fmt.Errorf("netpolMgr: couldn't list project network policies of project %v err=%v", projectID, err)
+	}
+	peers, exempt := projectExceptions(pnps)
+	return peers, exempt, nil
+}
+
+// isNamespaceExempt returns whether a namespace is exempt from isolation by the project network policies of its
+// project.
+func (npmgr *netpolMgr) isNamespaceExempt(namespace string) (bool, error) {
+	ns, err := npmgr.nsLister.Get("", namespace)
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("error getting ns %v", err)
+	}
+	projectID := ns.Labels[nslabels.ProjectIDFieldLabel]
+	if projectID == "" {
+		return false, nil
+	}
+	_, exempt, err := npmgr.getProjectExceptions(projectID)
+	if err != nil {
+		return false, err
+	}
+	return exempt[namespace], nil
+}
+
+// deleteNamespacePolicies deletes all the network policies Rancher created in a namespace.
+func (npmgr *netpolMgr) deleteNamespacePolicies(namespace string) error {
+	set := labels.Set(map[string]string{creatorLabel: creatorNorman})
+	nps, err := npmgr.npLister.List(namespace, set.AsSelector())
+	if err != nil {
+		return fmt.Errorf("netpolMgr: couldn't list network policies of ns %v err=%v", namespace, err)
+	}
+	for _, np := range nps {
+		if err := npmgr.delete(np.Namespace, np.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// projectExceptions converts the allowed ingress of project network policies to network policy peers, and returns
+// them along with the namespaces exempt from isolation. Invalid peers are skipped.
+func projectExceptions(pnps []*v3.ProjectNetworkPolicy) ([]knetworkingv1.NetworkPolicyPeer, map[string]bool) {
+	sorted := make([]*v3.ProjectNetworkPolicy, len(pnps))
+	copy(sorted, pnps)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	var peers []knetworkingv1.NetworkPolicyPeer
+	exempt := map[string]bool{}
+	for _, pnp := range sorted {
+		if pnp.DeletionTimestamp != nil {
+			continue
+		}
+		for _, allowed := range pnp.Spec.AllowedIngress {
+			peer, err := networkPolicyPeer(allowed)
+			if err != nil {
+				logrus.Errorf("netpolMgr: skipping allowed ingress of project network policy %s/%s: %v", pnp.Namespace, pnp.Name, err)
+				continue
+			}
+			peers = append(peers, peer)
+		}
+		for _, ns := range pnp.Spec.ExemptNamespaces {
+			exempt[ns] = true
+		}
+	}
+	return peers, exempt
+}
+
+func networkPolicyPeer(allowed v32.ProjectNetworkPolicyPeer) (knetworkingv1.NetworkPolicyPeer, error) {
+	var peer knetworkingv1.NetworkPolicyPeer
+	set := 0
+	if allowed.ProjectName != "" {
+		projectID := allowed.ProjectName
+		if i := strings.Index(projectID, ":"); i >= 0 {
+			projectID = projectID[i+1:]
+		}
+		if errs := validation.IsValidLabelValue(projectID); projectID == "" || len(errs) > 0 {
+			return peer, fmt.Errorf("invalid project %q", allowed.ProjectName)
+		}
+		peer.NamespaceSelector = &v1.LabelSelector{
+			MatchLabels: map[string]string{nslabels.ProjectIDFieldLabel: projectID},
+		}
+		set++
+	}
+	if allowed.NamespaceSelector != nil {
+		if _, err := v1.LabelSelectorAsSelector(allowed.NamespaceSelector); err != nil {
+			return peer, fmt.Errorf("invalid namespace selector: %v", err)
+		}
+		peer.NamespaceSelector = allowed.NamespaceSelector.DeepCopy()
+		set++
+	}
+	if allowed.CIDR != "" {
+		if _, _, err := net.ParseCIDR(allowed.CIDR); err != nil {
+			return peer, fmt.Errorf("invalid CIDR %q", allowed.CIDR)
+		}
+		peer.IPBlock = &knetworkingv1.IPBlock{CIDR: allowed.CIDR}
+		set++
+	}
+	if set != 1 {
+		return peer, fmt.Errorf("exactly one of projectName, namespaceSelector or cidr must be set")
+	}
+	return peer, nil
+}
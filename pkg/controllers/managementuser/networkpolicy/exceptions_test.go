@@ -0,0 +1,73 @@
+package networkpolicy
+
+import (
+	"testing"
+
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	knetworkingv1 "k8s.io/api/networking/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestProjectExceptions(t *testing.T) {
+	now := v1.Now()
+	pnps := []*v3.ProjectNetworkPolicy{
+		{
+			ObjectMeta: v1.ObjectMeta{Name: "pnp-p-abcde", Namespace: "p-abcde"},
+			Spec: v32.ProjectNetworkPolicySpec{
+				AllowedIngress: []v32.ProjectNetworkPolicyPeer{
+					{ProjectName: "c-abcde:p-fghij"},
+					{CIDR: "10.0.0.0/8"},
+					{CIDR: "10.0.0.0"},
+					{ProjectName: "p-klmno", CIDR: "10.0.0.0/8"},
+					{},
+				},
+				ExemptNamespaces: []string{"legacy"},
+			},
+		},
+		{
+			ObjectMeta: v1.ObjectMeta{Name: "monitoring", Namespace: "p-abcde"},
+			Spec: v32.ProjectNetworkPolicySpec{
+				AllowedIngress: []v32.ProjectNetworkPolicyPeer{
+					{NamespaceSelector: &v1.LabelSelector{MatchLabels: map[string]string{"name": "monitoring"}}},
+					{NamespaceSelector: &v1.LabelSelector{MatchExpressions: []v1.LabelSelectorRequirement{{Key: "name", Operator: "Unknown"}}}},
+				},
+				ExemptNamespaces: []string{"ingress"},
+			},
+		},
+		{
+			ObjectMeta: v1.ObjectMeta{Name: "deleted", Namespace: "p-abcde", DeletionTimestamp: &now},
+			Spec: v32.ProjectNetworkPolicySpec{
+				AllowedIngress:   []v32.ProjectNetworkPolicyPeer{{CIDR: "0.0.0.0/0"}},
+				ExemptNamespaces: []string{"default"},
+			},
+		},
+	}
+
+	peers, exempt := projectExceptions(pnps)
+	assert.Equal(t, []knetworkingv1.NetworkPolicyPeer{
+		{NamespaceSelector: &v1.LabelSelector{MatchLabels: map[string]string{"name": "monitoring"}}},
+		{NamespaceSelector: &v1.LabelSelector{MatchLabels: map[string]string{"field.cattle.io/projectId": "p-fghij"}}},
+		{IPBlock: &knetworkingv1.IPBlock{CIDR: "10.0.0.0/8"}},
+	}, peers)
+	assert.Equal(t, map[string]bool{"legacy": true, "ingress": true}, exempt)
+
+	peers, exempt = projectExceptions(nil)
+	assert.Nil(t, peers)
+	assert.Empty(t, exempt)
+}
+
+func TestGenerateDefaultNamespaceNetworkPolicy(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: v1.ObjectMeta{Name: "app"}}
+	allowed := []knetworkingv1.NetworkPolicyPeer{{IPBlock: &knetworkingv1.IPBlock{CIDR: "10.0.0.0/8"}}}
+
+	np := generateDefaultNamespaceNetworkPolicy(ns, "p-abcde", "p-system", allowed)
+	assert.Equal(t, "app", np.Namespace)
+	assert.Equal(t, []knetworkingv1.NetworkPolicyPeer{
+		{NamespaceSelector: &v1.LabelSelector{MatchLabels: map[string]string{"field.cattle.io/projectId": "p-abcde"}}},
+		{NamespaceSelector: &v1.LabelSelector{MatchLabels: map[string]string{"field.cattle.io/projectId": "p-system"}}},
+		{IPBlock: &knetworkingv1.IPBlock{CIDR: "10.0.0.0/8"}},
+	}, np.Spec.Ingress[0].From)
+}
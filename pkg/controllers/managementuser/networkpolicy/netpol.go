@@ -44,6 +44,7 @@ type netpolMgr struct {
 	npLister         rnetworkingv1.NetworkPolicyLister
 	npClient         rnetworkingv1.Interface
 	projLister       v3.ProjectLister
+	pnpLister        v3.ProjectNetworkPolicyLister
 	clusterNamespace string
 }
 
@@ -107,6 +108,11 @@ func (npmgr *netpolMgr) programNetworkPolicy(projectID string, clusterNamespace
 		return fmt.Errorf("netpolMgr: programNetworkPolicy getSystemNamespaces: err=%v", err)
 	}
 
+	allowedIngress, exemptNamespaces, err := npmgr.getProjectExceptions(projectID)
+	if err != nil {
+		return err
+	}
+
 	for _, aNS := range namespaces {
 		id, _ := aNS.Labels[nslabels.ProjectIDFieldLabel]
 
@@ -159,7 +165,15 @@ func (npmgr *netpolMgr) programNetworkPolicy(projectID string, clusterNamespace
 			continue
 		}
 
-		np := generateDefaultNamespaceNetworkPolicy(aNS, projectID, systemProjectID)
+		// exempt namespaces aren't isolated, so none of the network policies Rancher creates should be in them
+		if exemptNamespaces[aNS.Name] {
+			if err := npmgr.deleteNamespacePolicies(aNS.Name); err != nil {
+				return fmt.Errorf("netpolMgr: programNetworkPolicy: error deleting network policies of exempt ns=%v err=%v", aNS.Name, err)
+			}
+			continue
+		}
+
+		np := generateDefaultNamespaceNetworkPolicy(aNS, projectID, systemProjectID, allowedIngress)
 		if err := npmgr.program(np); err != nil {
 			return fmt.Errorf("netpolMgr: programNetworkPolicy: error programming default network policy for ns=%v err=%v", aNS.Name, err)
 		}
@@ -261,6 +275,14 @@ func (npmgr *netpolMgr) handleHostNetwork(clusterNamespace string) error {
 		if _, ok := aNS.Labels[nslabels.ProjectIDFieldLabel]; !ok {
 			continue
 		}
+		exempt, err := npmgr.isNamespaceExempt(aNS.Name)
+		if err != nil {
+			return err
+		}
+		if exempt {
+			npmgr.delete(aNS.Name, hostNetworkPolicyName)
+			continue
+		}
 
 		logrus.Debugf("netpolMgr: handleHostNetwork: aNS=%+v", aNS)
 
@@ -287,24 +309,24 @@ func (npmgr *netpolMgr) getClusterCNI(clusterName string) (string, error) {
 		return "", err
 	}
 
-	if cluster.Spec.Rke2Config != nil {
-		return npmgr.getRKE2ClusterCNI(cluster)
-	}
-
 	if cluster.Spec.RancherKubernetesEngineConfig != nil {
 		return cluster.Spec.RancherKubernetesEngineConfig.Network.Plugin, nil
 	}
 
-	return "", nil
+	return npmgr.getRKE2ClusterCNI(cluster)
 }
 
-// getRKE2ClusterCNI returns the RKE2 cluster CNI name if it is found, or an api error
+// getRKE2ClusterCNI returns the RKE2 or K3s cluster CNI name if it is found, or an api error. Clusters without a
+// provisioning cluster have no known CNI.
 func (npmgr *netpolMgr) getRKE2ClusterCNI(mgmtCluster *v3.Cluster) (string, error) {
 	clusters, err := npmgr.clusters.GetByIndex(cluster2.ByCluster, mgmtCluster.Name)
 	if err != nil {
 		return "", err
 	}
-	if len(clusters) != 1 {
+	if len(clusters) == 0 {
+		return "", nil
+	}
+	if len(clusters) > 1 {
 		return "", fmt.Errorf("could not map to v1.Cluster for v3.Cluster: %s", mgmtCluster.Name)
 	}
 
@@ -356,8 +378,9 @@ func (npmgr *netpolMgr) getSystemNSInfo(clusterNamespace string) (map[string]boo
 	return systemNamespaces, systemProjectID, nil
 }
 
-func generateDefaultNamespaceNetworkPolicy(aNS *corev1.Namespace, projectID string, systemProjectID string) *knetworkingv1.NetworkPolicy {
-	return &knetworkingv1.NetworkPolicy{
+func generateDefaultNamespaceNetworkPolicy(aNS *corev1.Namespace, projectID string, systemProjectID string,
+	allowedIngress []knetworkingv1.NetworkPolicyPeer) *knetworkingv1.NetworkPolicy {
+	np := &knetworkingv1.NetworkPolicy{
 		ObjectMeta: v1.ObjectMeta{
 			Name:      defaultNamespacePolicyName,
 			Namespace: aNS.Name,
@@ -390,6 +413,8 @@ func generateDefaultNamespaceNetworkPolicy(aNS *corev1.Namespace, projectID stri
 			},
 		},
 	}
+	np.Spec.Ingress[0].From = append(np.Spec.Ingress[0].From, allowedIngress...)
+	return np
 }
 
 func generateAllowAllNetworkPolicy(ns *corev1.Namespace, systemProjectID string) *knetworkingv1.NetworkPolicy {
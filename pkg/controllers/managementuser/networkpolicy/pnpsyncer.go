@@ -1,13 +1,19 @@
 package networkpolicy
 
 import (
+	"fmt"
+
+	"github.com/rancher/rancher/pkg/controllers/managementagent/nslabels"
+	v1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
 type projectNetworkPolicySyncer struct {
 	npmgr *netpolMgr
+	nses  v1.NamespaceInterface
 }
 
 // Sync invokes the Policy Handler to take care of installing the native network policies
@@ -16,5 +22,18 @@ func (pnps *projectNetworkPolicySyncer) Sync(key string, pnp *v3.ProjectNetworkP
 		return nil, nil
 	}
 	logrus.Debugf("projectNetworkPolicySyncer: Sync: pnp=%+v", pnp)
-	return nil, pnps.npmgr.programNetworkPolicy(pnp.Namespace, pnps.npmgr.clusterNamespace)
+	if err := pnps.npmgr.programNetworkPolicy(pnp.Namespace, pnps.npmgr.clusterNamespace); err != nil {
+		return nil, err
+	}
+
+	// namespaces no longer exempt need the host network, host port and node port policies as well
+	set := labels.Set(map[string]string{nslabels.ProjectIDFieldLabel: pnp.Namespace})
+	namespaces, err := pnps.npmgr.nsLister.List("", set.AsSelector())
+	if err != nil {
+		return nil, fmt.Errorf("projectNetworkPolicySyncer: couldn't list namespaces with projectID %v err=%v", pnp.Namespace, err)
+	}
+	for _, ns := range namespaces {
+		pnps.nses.Controller().Enqueue("", ns.Name)
+	}
+	return nil, nil
 }
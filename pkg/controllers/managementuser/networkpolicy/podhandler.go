@@ -46,6 +46,13 @@ func (ph *podHandler) Sync(key string, pod *corev1.Pod) (runtime.Object, error)
 	if moved {
 		return nil, nil
 	}
+	exempt, err := ph.npmgr.isNamespaceExempt(pod.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if exempt {
+		return nil, nil
+	}
 	systemNamespaces, _, err := ph.npmgr.getSystemNSInfo(ph.clusterNamespace)
 	if err != nil {
 		return nil, fmt.Errorf("netpolMgr: podHandler: getSystemNamespaces: err=%v", err)
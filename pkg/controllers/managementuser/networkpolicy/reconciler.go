@@ -0,0 +1,44 @@
+package networkpolicy
+
+import (
+	"strings"
+
+	v1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	knetworkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+/*
+networkPolicyReconciler enqueues the namespace of a network policy created by Rancher when it's modified or deleted,
+so that nsSyncer programs it again
+*/
+type networkPolicyReconciler struct {
+	nses             v1.NamespaceInterface
+	clusterLister    v3.ClusterLister
+	clusterNamespace string
+}
+
+func (r *networkPolicyReconciler) Sync(key string, np *knetworkingv1.NetworkPolicy) (runtime.Object, error) {
+	if np != nil && np.DeletionTimestamp == nil && np.Labels[creatorLabel] != creatorNorman {
+		return nil, nil
+	}
+	disabled, err := isNetworkPolicyDisabled(r.clusterNamespace, r.clusterLister)
+	if err != nil {
+		return nil, err
+	}
+	if disabled {
+		return nil, nil
+	}
+
+	namespace := ""
+	if np != nil {
+		namespace = np.Namespace
+	} else if i := strings.Index(key, "/"); i > 0 {
+		namespace = key[:i]
+	}
+	if namespace != "" {
+		r.nses.Controller().Enqueue("", namespace)
+	}
+	return nil, nil
+}
@@ -50,11 +50,11 @@ func registerDeferred(ctx context.Context, cluster *config.UserContext) {
 	npClient := cluster.Networking
 
 	npmgr := &netpolMgr{clusterLister, clusters, nsLister, nodeLister, pods, projects,
-		npLister, npClient, projectLister, cluster.ClusterName}
+		npLister, npClient, projectLister, pnpLister, cluster.ClusterName}
 	ps := &projectSyncer{pnpLister, pnps, projects, clusterLister, cluster.ClusterName}
 	nss := &nsSyncer{npmgr, clusterLister, serviceLister, podLister,
 		services, pods, cluster.ClusterName}
-	pnpsyncer := &projectNetworkPolicySyncer{npmgr}
+	pnpsyncer := &projectNetworkPolicySyncer{npmgr, nses}
 	podHandler := &podHandler{npmgr, pods, clusterLister, cluster.ClusterName}
 	serviceHandler := &serviceHandler{npmgr, clusterLister, cluster.ClusterName}
 	nodeHandler := &nodeHandler{npmgr, clusterLister, cluster.ClusterName}
@@ -62,12 +62,14 @@ func registerDeferred(ctx context.Context, cluster *config.UserContext) {
 		serviceLister, projectLister, mgmtClusters, pnps, npmgr, cluster.ClusterName}
 
 	clusterNetAnnHandler := &clusterNetAnnHandler{mgmtClusters, cluster.ClusterName}
+	npReconciler := &networkPolicyReconciler{nses, clusterLister, cluster.ClusterName}
 
 	projects.Controller().AddClusterScopedHandler(ctx, "projectSyncer", cluster.ClusterName, ps.Sync)
 	pnps.AddClusterScopedHandler(ctx, "projectNetworkPolicySyncer", cluster.ClusterName, pnpsyncer.Sync)
 	nses.AddHandler(ctx, "namespaceLifecycle", nss.Sync)
 	pods.AddHandler(ctx, "podHandler", podHandler.Sync)
 	services.AddHandler(ctx, "serviceHandler", serviceHandler.Sync)
+	cluster.Networking.NetworkPolicies("").AddHandler(ctx, "networkPolicyReconciler", npReconciler.Sync)
 
 	cluster.Management.Management.Nodes(cluster.ClusterName).Controller().AddHandler(ctx, "nodeHandler", nodeHandler.Sync)
 	mgmtClusters.AddHandler(ctx, "clusterHandler", clusterHandler.Sync)
@@ -37,6 +37,13 @@ func (sh *serviceHandler) Sync(key string, service *corev1.Service) (runtime.Obj
 	if moved {
 		return nil, nil
 	}
+	exempt, err := sh.npmgr.isNamespaceExempt(service.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if exempt {
+		return nil, nil
+	}
 	logrus.Debugf("serviceHandler: Sync: %+v", *service)
 	return nil, sh.npmgr.nodePortsUpdateHandler(service, sh.clusterNamespace)
 }
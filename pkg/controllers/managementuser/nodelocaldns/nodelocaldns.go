@@ -0,0 +1,91 @@
+package nodelocaldns
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/rancher/wrangler/pkg/condition"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	nodeCacheNamespace = "kube-system"
+	// nodeCacheDaemonSet is the daemonset of the NodeLocal DNSCache deployed by the rke2-coredns chart.
+	nodeCacheDaemonSet = "rke2-coredns-rke2-coredns-node-cache"
+)
+
+type handler struct {
+	clusterName   string
+	clusterLister v3.ClusterLister
+	clusters      v3.ClusterInterface
+}
+
+// Register registers a controller surfacing the health of the NodeLocal DNSCache of the cluster in its
+// NodeLocalDNSReady condition.
+func Register(ctx context.Context, cluster *config.UserContext) {
+	h := &handler{
+		clusterName:   cluster.ClusterName,
+		clusterLister: cluster.Management.Management.Clusters("").Controller().Lister(),
+		clusters:      cluster.Management.Management.Clusters(""),
+	}
+	cluster.Apps.DaemonSets(nodeCacheNamespace).AddHandler(ctx, "nodelocaldns-health", h.sync)
+}
+
+func (h *handler) sync(key string, ds *appsv1.DaemonSet) (runtime.Object, error) {
+	if key != nodeCacheNamespace+"/"+nodeCacheDaemonSet {
+		return ds, nil
+	}
+
+	cluster, err := h.clusterLister.Get("", h.clusterName)
+	if err != nil {
+		return ds, err
+	}
+	updated := cluster.DeepCopy()
+
+	if ds == nil || ds.DeletionTimestamp != nil {
+		removeCondition(updated, v32.ClusterConditionNodeLocalDNSReady)
+	} else if ready, message := nodeCacheReady(ds); ready {
+		v32.ClusterConditionNodeLocalDNSReady.True(updated)
+		v32.ClusterConditionNodeLocalDNSReady.Message(updated, "")
+	} else {
+		v32.ClusterConditionNodeLocalDNSReady.False(updated)
+		v32.ClusterConditionNodeLocalDNSReady.Message(updated, message)
+	}
+
+	if reflect.DeepEqual(cluster.Status.Conditions, updated.Status.Conditions) {
+		return ds, nil
+	}
+	_, err = h.clusters.Update(updated)
+	return ds, err
+}
+
+// nodeCacheReady returns true if the node cache is up to date and ready on every node it is scheduled on, or a message
+// describing its progress otherwise.
+func nodeCacheReady(ds *appsv1.DaemonSet) (bool, string) {
+	status := ds.Status
+	if ds.Generation > status.ObservedGeneration {
+		return false, "waiting for the node cache rollout to be observed"
+	}
+	if status.UpdatedNumberScheduled < status.DesiredNumberScheduled {
+		return false, fmt.Sprintf("%d of %d node cache pods updated", status.UpdatedNumberScheduled, status.DesiredNumberScheduled)
+	}
+	if status.NumberReady < status.DesiredNumberScheduled {
+		return false, fmt.Sprintf("%d of %d node cache pods ready", status.NumberReady, status.DesiredNumberScheduled)
+	}
+	return true, ""
+}
+
+func removeCondition(cluster *v32.Cluster, cond condition.Cond) {
+	var conditions []v32.ClusterCondition
+	for _, c := range cluster.Status.Conditions {
+		if string(c.Type) != string(cond) {
+			conditions = append(conditions, c)
+		}
+	}
+	cluster.Status.Conditions = conditions
+}
@@ -0,0 +1,55 @@
+package nodelocaldns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNodeCacheReady(t *testing.T) {
+	tests := []struct {
+		name        string
+		generation  int64
+		status      appsv1.DaemonSetStatus
+		wantReady   bool
+		wantMessage string
+	}{
+		{
+			name:       "ready",
+			generation: 1,
+			status:     appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberReady: 3},
+			wantReady:  true,
+		},
+		{
+			name:        "not observed",
+			generation:  2,
+			status:      appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberReady: 3},
+			wantMessage: "waiting for the node cache rollout to be observed",
+		},
+		{
+			name:        "rolling out",
+			generation:  1,
+			status:      appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 1, NumberReady: 3},
+			wantMessage: "1 of 3 node cache pods updated",
+		},
+		{
+			name:        "not ready",
+			generation:  1,
+			status:      appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberReady: 2},
+			wantMessage: "2 of 3 node cache pods ready",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ready, message := nodeCacheReady(&appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Generation: tt.generation},
+				Status:     tt.status,
+			})
+			assert.Equal(t, tt.wantReady, ready)
+			assert.Equal(t, tt.wantMessage, message)
+		})
+	}
+}
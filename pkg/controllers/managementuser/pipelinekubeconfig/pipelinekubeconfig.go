@@ -0,0 +1,87 @@
+// Package pipelinekubeconfig deletes the service accounts and role bindings backing the pipeline kubeconfigs of a
+// cluster once they expire, and the role bindings whose service account is gone.
+package pipelinekubeconfig
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
+	rbacv1controllers "github.com/rancher/rancher/pkg/generated/norman/rbac.authorization.k8s.io/v1"
+	"github.com/rancher/rancher/pkg/impersonation"
+	"github.com/rancher/rancher/pkg/pipelinekubeconfig"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+)
+
+// bindingGracePeriod is how long a role binding is kept while its service account isn't found, as the service account
+// may not be cached yet.
+const bindingGracePeriod = time.Minute
+
+type handler struct {
+	ctx                   context.Context
+	client                kubernetes.Interface
+	serviceAccounts       v1.ServiceAccountController
+	serviceAccountsLister v1.ServiceAccountLister
+	roleBindings          rbacv1controllers.RoleBindingInterface
+	roleBindingController rbacv1controllers.RoleBindingController
+}
+
+func Register(ctx context.Context, cluster *config.UserContext) {
+	h := handler{
+		ctx:                   ctx,
+		client:                cluster.K8sClient,
+		serviceAccounts:       cluster.Core.ServiceAccounts("").Controller(),
+		serviceAccountsLister: cluster.Core.ServiceAccounts("").Controller().Lister(),
+		roleBindings:          cluster.RBAC.RoleBindings(""),
+		roleBindingController: cluster.RBAC.RoleBindings("").Controller(),
+	}
+	cluster.Core.ServiceAccounts("").AddHandler(ctx, "pipeline-kubeconfig-expiration", h.onServiceAccount)
+	cluster.RBAC.RoleBindings("").AddHandler(ctx, "pipeline-kubeconfig-expiration", h.onRoleBinding)
+}
+
+// onServiceAccount deletes the service account of a pipeline kubeconfig and its role binding once the kubeconfig
+// expires, requeueing the service account for its expiration until then.
+func (h *handler) onServiceAccount(_ string, sa *corev1.ServiceAccount) (runtime.Object, error) {
+	if sa == nil || sa.DeletionTimestamp != nil || sa.Namespace != impersonation.ImpersonationNamespace || sa.Labels[pipelinekubeconfig.Label] != "true" {
+		return sa, nil
+	}
+
+	if remaining := time.Until(pipelinekubeconfig.Expires(sa)); remaining > 0 {
+		h.serviceAccounts.EnqueueAfter(sa.Namespace, sa.Name, remaining)
+		return sa, nil
+	}
+	logrus.Infof("[pipeline-kubeconfig] Deleting expired service account %s/%s created by %s", sa.Namespace, sa.Name, sa.Annotations[pipelinekubeconfig.CreatedByAnnotation])
+	return sa, pipelinekubeconfig.Delete(h.ctx, h.client, sa)
+}
+
+// onRoleBinding deletes the role binding of a pipeline kubeconfig once it expires or its service account is deleted,
+// as the role binding can't be owned by a service account of another namespace.
+func (h *handler) onRoleBinding(_ string, binding *rbacv1.RoleBinding) (runtime.Object, error) {
+	if binding == nil || binding.DeletionTimestamp != nil || binding.Labels[pipelinekubeconfig.Label] != "true" {
+		return binding, nil
+	}
+
+	_, err := h.serviceAccountsLister.Get(impersonation.ImpersonationNamespace, binding.Name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return binding, err
+	}
+	if err == nil && time.Now().Before(pipelinekubeconfig.Expires(binding)) {
+		return binding, nil
+	}
+	if remaining := time.Until(binding.CreationTimestamp.Add(bindingGracePeriod)); apierrors.IsNotFound(err) && remaining > 0 {
+		h.roleBindingController.EnqueueAfter(binding.Namespace, binding.Name, remaining)
+		return binding, nil
+	}
+	err = h.roleBindings.DeleteNamespaced(binding.Namespace, binding.Name, &metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return binding, nil
+	}
+	return binding, err
+}
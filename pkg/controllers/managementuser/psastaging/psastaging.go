@@ -0,0 +1,115 @@
+package psastaging
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
+	"github.com/rancher/rancher/pkg/podsecurity"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	psaapi "k8s.io/pod-security-admission/api"
+)
+
+type handler struct {
+	namespaces          v1.NamespaceInterface
+	namespaceController v1.NamespaceController
+	podLister           v1.PodLister
+	evaluator           *podsecurity.Evaluator
+	now                 func() time.Time
+}
+
+// Register registers a controller progressing the namespaces opted in to staged enforcement of a pod security admission
+// level through the warn, audit and enforce stages.
+func Register(ctx context.Context, cluster *config.UserContext) error {
+	evaluator, err := podsecurity.NewEvaluator()
+	if err != nil {
+		return err
+	}
+
+	h := &handler{
+		namespaces:          cluster.Core.Namespaces(""),
+		namespaceController: cluster.Core.Namespaces("").Controller(),
+		podLister:           cluster.Core.Pods("").Controller().Lister(),
+		evaluator:           evaluator,
+		now:                 time.Now,
+	}
+	cluster.Core.Namespaces("").AddHandler(ctx, "psa-staged-enforcement", h.sync)
+	return nil
+}
+
+func (h *handler) sync(key string, ns *corev1.Namespace) (runtime.Object, error) {
+	if ns == nil || ns.DeletionTimestamp != nil || ns.Annotations[podsecurity.StagedLevelAnnotation] == "" {
+		return ns, nil
+	}
+
+	level, err := psaapi.ParseLevel(ns.Annotations[podsecurity.StagedLevelAnnotation])
+	if err != nil || level == psaapi.LevelPrivileged {
+		logrus.Warnf("[psa-staged-enforcement] namespace %s has invalid staged level %q", ns.Name, ns.Annotations[podsecurity.StagedLevelAnnotation])
+		return ns, nil
+	}
+
+	interval := podsecurity.DefaultStageInterval
+	if value := ns.Annotations[podsecurity.StageIntervalAnnotation]; value != "" {
+		if interval, err = time.ParseDuration(value); err != nil {
+			return ns, fmt.Errorf("invalid stage interval of namespace %s: %w", ns.Name, err)
+		}
+	}
+
+	now := h.now().UTC()
+	current := podsecurity.Stage(ns.Annotations[podsecurity.StageAnnotation])
+	started, err := time.Parse(time.RFC3339, ns.Annotations[podsecurity.StageStartedAnnotation])
+	if err != nil {
+		// restart the stage if its start is unknown
+		started = now
+	}
+
+	rejected := 0
+	if current == podsecurity.StageAudit {
+		pods, err := h.podLister.List(ns.Name, labels.Everything())
+		if err != nil {
+			return ns, err
+		}
+		rejected = len(h.evaluator.Evaluate(psaapi.LevelVersion{Level: level, Version: psaapi.LatestVersion()}, pods, nil))
+	}
+
+	stage, message := podsecurity.NextStage(current, started, now, interval, rejected)
+	if stage != current {
+		started = now
+	}
+	if stage != podsecurity.StageEnforce {
+		next := started.Add(interval).Sub(now)
+		if next <= 0 {
+			// held back in the audit stage, check the pods of the namespace again after another interval
+			next = interval
+		}
+		h.namespaceController.EnqueueAfter("", ns.Name, next)
+	}
+
+	updated := ns.DeepCopy()
+	if updated.Labels == nil {
+		updated.Labels = map[string]string{}
+	}
+	for k, v := range podsecurity.StageLabels(stage, level) {
+		updated.Labels[k] = v
+	}
+	updated.Annotations[podsecurity.StageAnnotation] = string(stage)
+	updated.Annotations[podsecurity.StageStartedAnnotation] = started.Format(time.RFC3339)
+	if message != "" {
+		updated.Annotations[podsecurity.StageMessageAnnotation] = message
+	} else {
+		delete(updated.Annotations, podsecurity.StageMessageAnnotation)
+	}
+
+	if labels.Equals(ns.Labels, updated.Labels) && labels.Equals(ns.Annotations, updated.Annotations) {
+		return ns, nil
+	}
+	if stage != current {
+		logrus.Infof("[psa-staged-enforcement] namespace %s entering %s stage of level %s", ns.Name, stage, level)
+	}
+	return h.namespaces.Update(updated)
+}
@@ -251,6 +251,17 @@ func getProjectID(ns *corev1.Namespace) string {
 	return ""
 }
 
+// ResourceQuotaSpec returns the spec of the Kubernetes resource quota enforcing a resource quota limit.
+func ResourceQuotaSpec(limit *v32.ResourceQuotaLimit) (*corev1.ResourceQuotaSpec, error) {
+	return convertResourceLimitResourceQuotaSpec(limit)
+}
+
+// LimitRangeSpec returns the spec of the Kubernetes limit range setting default container resource requests and
+// limits, nil if the limit sets none.
+func LimitRangeSpec(limit *v32.ContainerResourceLimit) (*corev1.LimitRangeSpec, error) {
+	return convertPodResourceLimitToLimitRangeSpec(limit)
+}
+
 func convertPodResourceLimitToLimitRangeSpec(podResourceLimit *v32.ContainerResourceLimit) (*corev1.LimitRangeSpec, error) {
 	request, limit, err := convertContainerResourceLimitToResourceList(podResourceLimit)
 	if err != nil {
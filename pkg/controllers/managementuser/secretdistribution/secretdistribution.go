@@ -0,0 +1,319 @@
+package secretdistribution
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	v1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
+	"github.com/rancher/rancher/pkg/types/config"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/retry"
+)
+
+type handler struct {
+	clusterName       string
+	distributions     mgmtcontrollers.SecretDistributionController
+	distributionCache mgmtcontrollers.SecretDistributionCache
+	clusterCache      mgmtcontrollers.ClusterCache
+	managementSecrets corecontrollers.SecretCache
+	secrets           v1.SecretInterface
+	secretLister      v1.SecretLister
+	namespaceLister   v1.NamespaceLister
+}
+
+// Register registers the secret-distribution controller of a downstream cluster, which copies the management cluster
+// secrets of the secret distributions selecting the cluster to the selected namespaces. Copies are updated when their
+// secret is rotated or they're changed in the downstream cluster, and deleted when they're no longer selected.
+func Register(ctx context.Context, cluster *config.UserContext) {
+	mgmt := cluster.Management.Wrangler
+	h := &handler{
+		clusterName:       cluster.ClusterName,
+		distributions:     mgmt.Mgmt.SecretDistribution(),
+		distributionCache: mgmt.Mgmt.SecretDistribution().Cache(),
+		clusterCache:      mgmt.Mgmt.Cluster().Cache(),
+		managementSecrets: mgmt.Core.Secret().Cache(),
+		secrets:           cluster.Core.Secrets(""),
+		secretLister:      cluster.Core.Secrets("").Controller().Lister(),
+		namespaceLister:   cluster.Core.Namespaces("").Controller().Lister(),
+	}
+
+	mgmt.Mgmt.SecretDistribution().OnChange(ctx, "secret-distribution-"+cluster.ClusterName, h.OnChange)
+	relatedresource.WatchClusterScoped(ctx, "secret-distribution-trigger-"+cluster.ClusterName, h.resolveManagement,
+		mgmt.Mgmt.SecretDistribution(), mgmt.Core.Secret(), mgmt.Mgmt.Cluster())
+	cluster.Core.Namespaces("").AddHandler(ctx, "secret-distribution-namespace", h.onNamespace)
+	cluster.Core.Secrets("").AddHandler(ctx, "secret-distribution-secret", h.onSecret)
+}
+
+// resolveManagement enqueues the secret distributions copying a management cluster secret when it changes, and all of
+// them when the management cluster of this cluster changes, since its labels may be selected.
+func (h *handler) resolveManagement(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+	switch obj.(type) {
+	case *corev1.Secret:
+		return h.distributionKeys(func(distribution *v3.SecretDistribution) bool {
+			for _, source := range distribution.Spec.Secrets {
+				if source.Namespace == namespace && source.Name == name {
+					return true
+				}
+			}
+			return false
+		})
+	case *v3.Cluster:
+		if name != h.clusterName {
+			return nil, nil
+		}
+		return h.distributionKeys(func(*v3.SecretDistribution) bool { return true })
+	}
+	return nil, nil
+}
+
+func (h *handler) distributionKeys(matches func(*v3.SecretDistribution) bool) ([]relatedresource.Key, error) {
+	distributions, err := h.distributionCache.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var keys []relatedresource.Key
+	for _, distribution := range distributions {
+		if matches(distribution) {
+			keys = append(keys, relatedresource.Key{Name: distribution.Name})
+		}
+	}
+	return keys, nil
+}
+
+// onNamespace enqueues the secret distributions that may select a namespace when it changes.
+func (h *handler) onNamespace(_ string, ns *corev1.Namespace) (runtime.Object, error) {
+	if ns == nil {
+		return nil, nil
+	}
+	keys, err := h.distributionKeys(func(distribution *v3.SecretDistribution) bool {
+		if distribution.Spec.NamespaceSelector != nil {
+			return true
+		}
+		for _, name := range distribution.Spec.Namespaces {
+			if name == ns.Name {
+				return true
+			}
+		}
+		return false
+	})
+	for _, key := range keys {
+		h.distributions.Enqueue(key.Name)
+	}
+	return ns, err
+}
+
+// onSecret enqueues the secret distribution of a copied secret when it changes, so that changes made to it are
+// reverted, and the secret distributions copying secrets with its name when it is deleted.
+func (h *handler) onSecret(key string, secret *corev1.Secret) (runtime.Object, error) {
+	if secret != nil {
+		if name := secret.Labels[SecretDistributionLabel]; name != "" {
+			h.distributions.Enqueue(name)
+		}
+		return secret, nil
+	}
+
+	_, name, ok := strings.Cut(key, "/")
+	if !ok {
+		return nil, nil
+	}
+	keys, err := h.distributionKeys(func(distribution *v3.SecretDistribution) bool {
+		for _, source := range distribution.Spec.Secrets {
+			if targetName(source) == name {
+				return true
+			}
+		}
+		return false
+	})
+	for _, key := range keys {
+		h.distributions.Enqueue(key.Name)
+	}
+	return nil, err
+}
+
+func (h *handler) OnChange(key string, distribution *v3.SecretDistribution) (*v3.SecretDistribution, error) {
+	if distribution == nil || distribution.DeletionTimestamp != nil {
+		_, err := h.apply(key, nil)
+		return distribution, err
+	}
+
+	cluster, err := h.clusterCache.Get(h.clusterName)
+	if apierrors.IsNotFound(err) {
+		return distribution, nil
+	} else if err != nil {
+		return distribution, err
+	}
+
+	selected, err := clusterSelected(distribution, cluster)
+	if err != nil {
+		return h.updateStatus(distribution, &v3.SecretDistributionClusterStatus{
+			ClusterName: h.clusterName,
+			Error:       fmt.Sprintf("invalid cluster selector: %v", err),
+		})
+	}
+	if !selected {
+		if _, err := h.apply(distribution.Name, nil); err != nil {
+			return distribution, err
+		}
+		return h.updateStatus(distribution, nil)
+	}
+
+	copies, problems, err := h.copies(distribution)
+	if err != nil {
+		return distribution, err
+	}
+	conflicts, err := h.apply(distribution.Name, copies)
+	if err != nil {
+		return distribution, err
+	}
+	problems = append(problems, conflicts...)
+
+	return h.updateStatus(distribution, &v3.SecretDistributionClusterStatus{
+		ClusterName: h.clusterName,
+		Secrets:     len(copies) - len(conflicts),
+		Error:       strings.Join(problems, "; "),
+	})
+}
+
+// copies returns the copies of the secrets of the secret distribution in this cluster, and the problems preventing
+// some from being copied.
+func (h *handler) copies(distribution *v3.SecretDistribution) ([]*corev1.Secret, []string, error) {
+	var problems []string
+
+	allNamespaces, err := h.namespaceLister.List("", labels.Everything())
+	if err != nil {
+		return nil, nil, err
+	}
+	namespaces, err := targetNamespaces(distribution, allNamespaces)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("invalid namespace selector: %v", err))
+	}
+
+	sources := map[string]*corev1.Secret{}
+	for _, source := range distribution.Spec.Secrets {
+		secret, err := h.managementSecrets.Get(source.Namespace, source.Name)
+		if apierrors.IsNotFound(err) {
+			problems = append(problems, fmt.Sprintf("secret %s not found", sourceKey(source)))
+			continue
+		} else if err != nil {
+			return nil, nil, err
+		}
+		sources[sourceKey(source)] = secret
+	}
+
+	return copiedSecrets(distribution, sources, namespaces), problems, nil
+}
+
+// apply creates or updates the copies of the secrets of a secret distribution, and deletes the copies that aren't
+// anymore. Secrets with the name of a copy that aren't copies of the secret distribution aren't overwritten, they're
+// returned as conflicts.
+func (h *handler) apply(name string, copies []*corev1.Secret) ([]string, error) {
+	existing, err := h.secretLister.List("", labels.SelectorFromSet(labels.Set{SecretDistributionLabel: name}))
+	if err != nil {
+		return nil, err
+	}
+
+	var conflicts []string
+	keep := map[string]bool{}
+	for _, copied := range copies {
+		keep[copied.Namespace+"/"+copied.Name] = true
+
+		current, err := h.secretLister.Get(copied.Namespace, copied.Name)
+		if apierrors.IsNotFound(err) {
+			logrus.Infof("[secret-distribution] cluster [%s]: creating secret [%s/%s] of secret distribution [%s]", h.clusterName, copied.Namespace, copied.Name, name)
+			if _, err := h.secrets.Create(copied); err != nil && !apierrors.IsAlreadyExists(err) {
+				return nil, err
+			}
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		if current.Labels[SecretDistributionLabel] != name {
+			conflicts = append(conflicts, fmt.Sprintf("secret %s/%s already exists", copied.Namespace, copied.Name))
+			continue
+		}
+		if current.Type != copied.Type {
+			// The type of a secret is immutable.
+			if err := h.secrets.DeleteNamespaced(copied.Namespace, copied.Name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+			if _, err := h.secrets.Create(copied); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if copyUpToDate(current, copied) {
+			continue
+		}
+
+		logrus.Infof("[secret-distribution] cluster [%s]: updating secret [%s/%s] of secret distribution [%s]", h.clusterName, copied.Namespace, copied.Name, name)
+		toUpdate := current.DeepCopy()
+		toUpdate.Data = copied.Data
+		if toUpdate.Labels == nil {
+			toUpdate.Labels = map[string]string{}
+		}
+		for k, v := range copied.Labels {
+			toUpdate.Labels[k] = v
+		}
+		if toUpdate.Annotations == nil {
+			toUpdate.Annotations = map[string]string{}
+		}
+		for k, v := range copied.Annotations {
+			toUpdate.Annotations[k] = v
+		}
+		if _, err := h.secrets.Update(toUpdate); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, secret := range existing {
+		if keep[secret.Namespace+"/"+secret.Name] {
+			continue
+		}
+		logrus.Infof("[secret-distribution] cluster [%s]: deleting secret [%s/%s] of secret distribution [%s]", h.clusterName, secret.Namespace, secret.Name, name)
+		if err := h.secrets.DeleteNamespaced(secret.Namespace, secret.Name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	return conflicts, nil
+}
+
+// updateStatus sets the status of the copies of the secrets in this cluster. The status is shared by the controllers
+// of every cluster, so it is retried on conflicts.
+func (h *handler) updateStatus(distribution *v3.SecretDistribution, clusterStatus *v3.SecretDistributionClusterStatus) (*v3.SecretDistribution, error) {
+	status := distribution.Status.DeepCopy()
+	if !setClusterStatus(status, h.clusterName, clusterStatus, time.Now()) {
+		return distribution, nil
+	}
+
+	result := distribution
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		current, err := h.distributions.Get(distribution.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		current = current.DeepCopy()
+		if !setClusterStatus(&current.Status, h.clusterName, clusterStatus, time.Now()) {
+			result = current
+			return nil
+		}
+		result, err = h.distributions.UpdateStatus(current)
+		return err
+	})
+	if err != nil {
+		return distribution, err
+	}
+	return result, nil
+}
@@ -0,0 +1,161 @@
+package secretdistribution
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// SecretDistributionLabel is the name of the secret distribution of a copied secret.
+	SecretDistributionLabel = "management.cattle.io/secret-distribution"
+	// SourceAnnotation is the namespace and name of the management cluster secret a secret was copied from.
+	SourceAnnotation = "management.cattle.io/secret-distribution-source"
+)
+
+// clusterSelected returns whether the secret distribution copies its secrets to the downstream cluster of a
+// management cluster.
+func clusterSelected(distribution *v3.SecretDistribution, cluster *v3.Cluster) (bool, error) {
+	for _, name := range distribution.Spec.ClusterNames {
+		if name == cluster.Name {
+			return true, nil
+		}
+	}
+	return selectorMatches(distribution.Spec.ClusterSelector, cluster.Labels)
+}
+
+// targetNamespaces returns the names of the namespaces the secret distribution copies its secrets to, in order.
+// Namespaces being deleted are skipped.
+func targetNamespaces(distribution *v3.SecretDistribution, namespaces []*corev1.Namespace) ([]string, error) {
+	listed := map[string]bool{}
+	for _, name := range distribution.Spec.Namespaces {
+		listed[name] = true
+	}
+
+	var result []string
+	for _, ns := range namespaces {
+		if ns.DeletionTimestamp != nil {
+			continue
+		}
+		selected := listed[ns.Name]
+		if !selected {
+			var err error
+			if selected, err = selectorMatches(distribution.Spec.NamespaceSelector, ns.Labels); err != nil {
+				return nil, err
+			}
+		}
+		if selected {
+			result = append(result, ns.Name)
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// selectorMatches returns whether a label selector matches labels, a nil selector matching nothing.
+func selectorMatches(labelSelector *metav1.LabelSelector, objLabels map[string]string) (bool, error) {
+	if labelSelector == nil {
+		return false, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(objLabels)), nil
+}
+
+// sourceKey is the key of the source annotation of the copies of a secret.
+func sourceKey(source v3.SecretDistributionSource) string {
+	return source.Namespace + "/" + source.Name
+}
+
+// targetName returns the name of the copies of a secret.
+func targetName(source v3.SecretDistributionSource) string {
+	if source.TargetName != "" {
+		return source.TargetName
+	}
+	return source.Name
+}
+
+// copiedSecrets returns the copies of the secrets of the secret distribution in every namespace. Secrets missing from
+// sources, by source key, aren't copied.
+func copiedSecrets(distribution *v3.SecretDistribution, sources map[string]*corev1.Secret, namespaces []string) []*corev1.Secret {
+	var result []*corev1.Secret
+	for _, source := range distribution.Spec.Secrets {
+		secret, ok := sources[sourceKey(source)]
+		if !ok {
+			continue
+		}
+		for _, namespace := range namespaces {
+			result = append(result, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      targetName(source),
+					Namespace: namespace,
+					Labels: map[string]string{
+						SecretDistributionLabel: distribution.Name,
+					},
+					Annotations: map[string]string{
+						SourceAnnotation: sourceKey(source),
+					},
+				},
+				Type: secret.Type,
+				Data: secret.Data,
+			})
+		}
+	}
+	return result
+}
+
+// copyUpToDate returns whether an existing copy of a secret has the data, labels and annotations of the copy.
+func copyUpToDate(existing, copied *corev1.Secret) bool {
+	for k, v := range copied.Labels {
+		if existing.Labels[k] != v {
+			return false
+		}
+	}
+	for k, v := range copied.Annotations {
+		if existing.Annotations[k] != v {
+			return false
+		}
+	}
+	if len(existing.Data) == 0 && len(copied.Data) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(existing.Data, copied.Data)
+}
+
+// setClusterStatus sets the status of the copies of the secrets in a cluster, removing it if clusterStatus is nil.
+// It returns whether the status changed, the time of the last sync is only updated when it did.
+func setClusterStatus(status *v3.SecretDistributionStatus, clusterName string, clusterStatus *v3.SecretDistributionClusterStatus, now time.Time) bool {
+	for i, existing := range status.Clusters {
+		if existing.ClusterName != clusterName {
+			continue
+		}
+		if clusterStatus == nil {
+			status.Clusters = append(status.Clusters[:i], status.Clusters[i+1:]...)
+			return true
+		}
+		if existing.Secrets == clusterStatus.Secrets && existing.Error == clusterStatus.Error {
+			return false
+		}
+		status.Clusters[i] = *clusterStatus
+		status.Clusters[i].LastSyncTime = metav1.NewTime(now)
+		return true
+	}
+	if clusterStatus == nil {
+		return false
+	}
+
+	entry := *clusterStatus
+	entry.LastSyncTime = metav1.NewTime(now)
+	status.Clusters = append(status.Clusters, entry)
+	sort.Slice(status.Clusters, func(i, j int) bool {
+		return status.Clusters[i].ClusterName < status.Clusters[j].ClusterName
+	})
+	return true
+}
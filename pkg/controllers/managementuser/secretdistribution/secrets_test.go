@@ -0,0 +1,165 @@
+package secretdistribution
+
+import (
+	"testing"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClusterSelected(t *testing.T) {
+	cluster := &v3.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "c-1",
+			Labels: map[string]string{"env": "prod"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		spec     v3.SecretDistributionSpec
+		selected bool
+		err      bool
+	}{
+		{name: "nothing selected"},
+		{name: "by name", spec: v3.SecretDistributionSpec{ClusterNames: []string{"c-2", "c-1"}}, selected: true},
+		{name: "other name", spec: v3.SecretDistributionSpec{ClusterNames: []string{"c-2"}}},
+		{
+			name: "by labels",
+			spec: v3.SecretDistributionSpec{
+				ClusterSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			},
+			selected: true,
+		},
+		{
+			name: "other labels",
+			spec: v3.SecretDistributionSpec{
+				ClusterSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}},
+			},
+		},
+		{
+			name: "invalid selector",
+			spec: v3.SecretDistributionSpec{
+				ClusterSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "env", Operator: "Bad"}}},
+			},
+			err: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := clusterSelected(&v3.SecretDistribution{Spec: tt.spec}, cluster)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.selected, selected)
+		})
+	}
+}
+
+func TestTargetNamespaces(t *testing.T) {
+	now := metav1.Now()
+	namespaces := []*corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "team-b", Labels: map[string]string{"pull-secrets": "true"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"pull-secrets": "true"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "apps"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "deleting", DeletionTimestamp: &now}},
+	}
+	distribution := &v3.SecretDistribution{
+		Spec: v3.SecretDistributionSpec{
+			Namespaces:        []string{"apps", "deleting", "missing"},
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pull-secrets": "true"}},
+		},
+	}
+
+	result, err := targetNamespaces(distribution, namespaces)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"apps", "team-a", "team-b"}, result)
+}
+
+func TestCopiedSecrets(t *testing.T) {
+	distribution := &v3.SecretDistribution{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry"},
+		Spec: v3.SecretDistributionSpec{
+			Secrets: []v3.SecretDistributionSource{
+				{Namespace: "cattle-global-data", Name: "pull-secret", TargetName: "registry-creds"},
+				{Namespace: "cattle-global-data", Name: "ca-bundle"},
+				{Namespace: "cattle-global-data", Name: "missing"},
+			},
+		},
+	}
+	sources := map[string]*corev1.Secret{
+		"cattle-global-data/pull-secret": {
+			Type: corev1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{corev1.DockerConfigJsonKey: []byte("{}")},
+		},
+		"cattle-global-data/ca-bundle": {
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{"ca.crt": []byte("ca")},
+		},
+	}
+
+	copies := copiedSecrets(distribution, sources, []string{"apps", "team-a"})
+	require.Len(t, copies, 4)
+
+	assert.Equal(t, "registry-creds", copies[0].Name)
+	assert.Equal(t, "apps", copies[0].Namespace)
+	assert.Equal(t, corev1.SecretTypeDockerConfigJson, copies[0].Type)
+	assert.Equal(t, "registry", copies[0].Labels[SecretDistributionLabel])
+	assert.Equal(t, "cattle-global-data/pull-secret", copies[0].Annotations[SourceAnnotation])
+	assert.Equal(t, "team-a", copies[1].Namespace)
+
+	assert.Equal(t, "ca-bundle", copies[2].Name)
+	assert.Equal(t, []byte("ca"), copies[2].Data["ca.crt"])
+}
+
+func TestCopyUpToDate(t *testing.T) {
+	copied := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      map[string]string{SecretDistributionLabel: "registry"},
+			Annotations: map[string]string{SourceAnnotation: "cattle-global-data/ca-bundle"},
+		},
+		Data: map[string][]byte{"ca.crt": []byte("ca")},
+	}
+
+	existing := copied.DeepCopy()
+	existing.Labels["other"] = "label"
+	assert.True(t, copyUpToDate(existing, copied), "other labels are ignored")
+
+	existing.Data["ca.crt"] = []byte("rotated")
+	assert.False(t, copyUpToDate(existing, copied))
+
+	existing = copied.DeepCopy()
+	existing.Annotations[SourceAnnotation] = "cattle-global-data/other"
+	assert.False(t, copyUpToDate(existing, copied))
+}
+
+func TestSetClusterStatus(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	status := &v3.SecretDistributionStatus{}
+
+	assert.False(t, setClusterStatus(status, "c-2", nil, now))
+	assert.True(t, setClusterStatus(status, "c-2", &v3.SecretDistributionClusterStatus{ClusterName: "c-2", Secrets: 2}, now))
+	assert.True(t, setClusterStatus(status, "c-1", &v3.SecretDistributionClusterStatus{ClusterName: "c-1", Secrets: 1}, now))
+	require.Len(t, status.Clusters, 2)
+	assert.Equal(t, "c-1", status.Clusters[0].ClusterName)
+	assert.Equal(t, now, status.Clusters[0].LastSyncTime.Time)
+
+	later := now.Add(time.Hour)
+	assert.False(t, setClusterStatus(status, "c-1", &v3.SecretDistributionClusterStatus{ClusterName: "c-1", Secrets: 1}, later))
+	assert.Equal(t, now, status.Clusters[0].LastSyncTime.Time, "the last sync time is kept when nothing changed")
+
+	assert.True(t, setClusterStatus(status, "c-1", &v3.SecretDistributionClusterStatus{ClusterName: "c-1", Error: "secret cattle-global-data/ca-bundle not found"}, later))
+	assert.Equal(t, later, status.Clusters[0].LastSyncTime.Time)
+	assert.Equal(t, 0, status.Clusters[0].Secrets)
+
+	assert.True(t, setClusterStatus(status, "c-1", nil, later))
+	require.Len(t, status.Clusters, 1)
+	assert.Equal(t, "c-2", status.Clusters[0].ClusterName)
+}
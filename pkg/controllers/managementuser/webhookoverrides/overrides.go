@@ -0,0 +1,173 @@
+package webhookoverrides
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/wrangler/pkg/data"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// ConfigurationName is the name of the validating and mutating webhook configurations of the rancher-webhook.
+	ConfigurationName = "rancher.cattle.io"
+	// OriginalsAnnotation holds the webhooks of a webhook configuration as they were before rancher overrode them, so
+	// that they're restored once they're no longer overridden.
+	OriginalsAnnotation = "management.cattle.io/webhook-overrides-originals"
+
+	namespaceNameLabel = "kubernetes.io/metadata.name"
+)
+
+// validate returns the problems of the webhook overrides, which aren't applied if there are any.
+func validate(overrides *v3.WebhookOverrides) []string {
+	if overrides == nil {
+		return nil
+	}
+
+	var problems []string
+	names := map[string]bool{}
+	for _, override := range overrides.Webhooks {
+		if override.Name == "" {
+			problems = append(problems, "webhook override must have a name")
+			continue
+		}
+		if names[override.Name] {
+			problems = append(problems, fmt.Sprintf("webhook %s is overridden more than once", override.Name))
+		}
+		names[override.Name] = true
+		if override.FailurePolicy != "" && override.FailurePolicy != "Fail" && override.FailurePolicy != "Ignore" {
+			problems = append(problems, fmt.Sprintf("failure policy %s of webhook %s must be Fail or Ignore", override.FailurePolicy, override.Name))
+		}
+	}
+	for _, namespace := range overrides.ExemptNamespaces {
+		if len(validation.IsDNS1123Label(namespace)) > 0 {
+			problems = append(problems, fmt.Sprintf("exempt namespace %q is not a valid namespace name", namespace))
+		}
+	}
+	return problems
+}
+
+// reconcile returns a webhook configuration, validating or mutating, with the overrides applied, and the names of its
+// webhooks. The webhooks previously overridden are first restored from their originals, so that overrides that were
+// removed are reverted.
+func reconcile(configuration map[string]interface{}, overrides *v3.WebhookOverrides) (map[string]interface{}, map[string]bool, error) {
+	result := runtime.DeepCopyJSON(configuration)
+	obj := data.Object(result)
+
+	var originals []interface{}
+	if value := obj.String("metadata", "annotations", OriginalsAnnotation); value != "" {
+		if err := json.Unmarshal([]byte(value), &originals); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse %s annotation: %w", OriginalsAnnotation, err)
+		}
+	}
+
+	webhooks, changed, names := apply(obj.Slice("webhooks"), originals, overrides)
+	result["webhooks"] = webhooks
+
+	metadata := obj.Map("metadata")
+	if metadata == nil {
+		metadata = data.Object{}
+	}
+	annotations := metadata.Map("annotations")
+	if len(changed) == 0 {
+		delete(annotations, OriginalsAnnotation)
+	} else {
+		value, err := json.Marshal(changed)
+		if err != nil {
+			return nil, nil, err
+		}
+		if annotations == nil {
+			annotations = data.Object{}
+		}
+		annotations[OriginalsAnnotation] = string(value)
+	}
+	if len(annotations) == 0 {
+		delete(metadata, "annotations")
+	} else {
+		metadata["annotations"] = map[string]interface{}(annotations)
+	}
+	result["metadata"] = map[string]interface{}(metadata)
+	return result, names, nil
+}
+
+// apply returns the webhooks with the overrides applied, after restoring the webhooks of the originals. It also
+// returns the originals of the webhooks the overrides change, and the names of the webhooks.
+func apply(webhooks []data.Object, originals []interface{}, overrides *v3.WebhookOverrides) ([]interface{}, []interface{}, map[string]bool) {
+	var restored []interface{}
+	restoredNames := map[string]bool{}
+	for _, webhook := range webhooks {
+		name := webhook.String("name")
+		if original := find(originals, name); original != nil {
+			webhook = original
+		}
+		restoredNames[name] = true
+		restored = append(restored, map[string]interface{}(webhook))
+	}
+	for _, original := range originals {
+		// The webhooks the overrides disabled were removed.
+		if webhook, ok := original.(map[string]interface{}); ok && !restoredNames[data.Object(webhook).String("name")] {
+			restoredNames[data.Object(webhook).String("name")] = true
+			restored = append(restored, webhook)
+		}
+	}
+	if overrides == nil {
+		overrides = &v3.WebhookOverrides{}
+	}
+
+	result := []interface{}{}
+	var changed []interface{}
+	for _, value := range restored {
+		original := data.Object(value.(map[string]interface{}))
+		name := original.String("name")
+
+		override := v3.WebhookOverride{Name: name}
+		for _, o := range overrides.Webhooks {
+			if o.Name == name {
+				override = o
+			}
+		}
+		if override.Disabled {
+			changed = append(changed, map[string]interface{}(original))
+			continue
+		}
+
+		webhook := data.Object(runtime.DeepCopyJSON(original))
+		if override.FailurePolicy != "" {
+			webhook.Set("failurePolicy", override.FailurePolicy)
+		}
+		if len(overrides.ExemptNamespaces) > 0 {
+			exempt := make([]interface{}, 0, len(overrides.ExemptNamespaces))
+			for _, namespace := range overrides.ExemptNamespaces {
+				exempt = append(exempt, namespace)
+			}
+			selector := webhook.Map("namespaceSelector")
+			if selector == nil {
+				selector = data.Object{}
+			}
+			expressions, _ := selector["matchExpressions"].([]interface{})
+			selector["matchExpressions"] = append(expressions, map[string]interface{}{
+				"key":      namespaceNameLabel,
+				"operator": "NotIn",
+				"values":   exempt,
+			})
+			webhook["namespaceSelector"] = map[string]interface{}(selector)
+		}
+		if !reflect.DeepEqual(webhook, original) {
+			changed = append(changed, map[string]interface{}(original))
+		}
+		result = append(result, map[string]interface{}(webhook))
+	}
+	return result, changed, restoredNames
+}
+
+func find(webhooks []interface{}, name string) data.Object {
+	for _, value := range webhooks {
+		if webhook, ok := value.(map[string]interface{}); ok && data.Object(webhook).String("name") == name {
+			return webhook
+		}
+	}
+	return nil
+}
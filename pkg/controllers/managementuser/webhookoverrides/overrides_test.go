@@ -0,0 +1,129 @@
+package webhookoverrides
+
+import (
+	"encoding/json"
+	"testing"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfiguration = `{
+	"apiVersion": "admissionregistration.k8s.io/v1",
+	"kind": "ValidatingWebhookConfiguration",
+	"metadata": {"name": "rancher.cattle.io"},
+	"webhooks": [
+		{
+			"name": "rancher.cattle.io.namespaces",
+			"failurePolicy": "Fail",
+			"namespaceSelector": {"matchExpressions": [{"key": "app", "operator": "DoesNotExist"}]},
+			"timeoutSeconds": 10
+		},
+		{
+			"name": "rancher.cattle.io.secrets",
+			"failurePolicy": "Fail",
+			"timeoutSeconds": 10
+		}
+	]
+}`
+
+func testConfigurationMap(t *testing.T) map[string]interface{} {
+	var configuration map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(testConfiguration), &configuration))
+	return configuration
+}
+
+func webhookNames(configuration map[string]interface{}) []string {
+	var names []string
+	for _, webhook := range configuration["webhooks"].([]interface{}) {
+		names = append(names, webhook.(map[string]interface{})["name"].(string))
+	}
+	return names
+}
+
+func webhook(configuration map[string]interface{}, name string) map[string]interface{} {
+	for _, webhook := range configuration["webhooks"].([]interface{}) {
+		if webhook.(map[string]interface{})["name"] == name {
+			return webhook.(map[string]interface{})
+		}
+	}
+	return nil
+}
+
+func TestValidate(t *testing.T) {
+	assert.Empty(t, validate(nil))
+	assert.Empty(t, validate(&v3.WebhookOverrides{
+		Webhooks:         []v3.WebhookOverride{{Name: "rancher.cattle.io.namespaces", FailurePolicy: "Ignore"}, {Name: "rancher.cattle.io.secrets", Disabled: true}},
+		ExemptNamespaces: []string{"team-a"},
+	}))
+	assert.Equal(t, []string{
+		"webhook override must have a name",
+		"webhook rancher.cattle.io.secrets is overridden more than once",
+		"failure policy Skip of webhook rancher.cattle.io.secrets must be Fail or Ignore",
+		`exempt namespace "Team_A" is not a valid namespace name`,
+	}, validate(&v3.WebhookOverrides{
+		Webhooks: []v3.WebhookOverride{
+			{FailurePolicy: "Ignore"},
+			{Name: "rancher.cattle.io.secrets", Disabled: true},
+			{Name: "rancher.cattle.io.secrets", FailurePolicy: "Skip"},
+		},
+		ExemptNamespaces: []string{"Team_A"},
+	}))
+}
+
+func TestReconcile(t *testing.T) {
+	overrides := &v3.WebhookOverrides{
+		Webhooks: []v3.WebhookOverride{
+			{Name: "rancher.cattle.io.namespaces", FailurePolicy: "Ignore"},
+			{Name: "rancher.cattle.io.secrets", Disabled: true},
+			{Name: "rancher.cattle.io.unknown", Disabled: true},
+		},
+		ExemptNamespaces: []string{"team-a", "team-b"},
+	}
+
+	configuration := testConfigurationMap(t)
+	overridden, names, err := reconcile(configuration, overrides)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"rancher.cattle.io.namespaces": true, "rancher.cattle.io.secrets": true}, names)
+	assert.Equal(t, []string{"rancher.cattle.io.namespaces"}, webhookNames(overridden))
+	assert.Len(t, configuration["webhooks"], 2, "the configuration is copied")
+
+	namespaces := webhook(overridden, "rancher.cattle.io.namespaces")
+	assert.Equal(t, "Ignore", namespaces["failurePolicy"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"key": "app", "operator": "DoesNotExist"},
+		map[string]interface{}{"key": "kubernetes.io/metadata.name", "operator": "NotIn", "values": []interface{}{"team-a", "team-b"}},
+	}, namespaces["namespaceSelector"].(map[string]interface{})["matchExpressions"])
+
+	// Reapplying the overrides doesn't change the configuration.
+	reapplied, _, err := reconcile(overridden, overrides)
+	require.NoError(t, err)
+	assert.Equal(t, overridden, reapplied)
+
+	// The overrides are reapplied after the rancher-webhook reverts the configuration, keeping its annotations.
+	reverted := testConfigurationMap(t)
+	reverted["metadata"] = overridden["metadata"]
+	reapplied, _, err = reconcile(reverted, overrides)
+	require.NoError(t, err)
+	assert.Equal(t, overridden, reapplied)
+
+	// Removing the overrides restores the original webhooks.
+	restored, _, err := reconcile(overridden, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"rancher.cattle.io.namespaces", "rancher.cattle.io.secrets"}, webhookNames(restored))
+	assert.Equal(t, "Fail", webhook(restored, "rancher.cattle.io.namespaces")["failurePolicy"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"key": "app", "operator": "DoesNotExist"},
+	}, webhook(restored, "rancher.cattle.io.namespaces")["namespaceSelector"].(map[string]interface{})["matchExpressions"])
+	assert.NotContains(t, restored["metadata"], "annotations")
+}
+
+func TestReconcileInvalidOriginals(t *testing.T) {
+	configuration := testConfigurationMap(t)
+	configuration["metadata"] = map[string]interface{}{
+		"annotations": map[string]interface{}{OriginalsAnnotation: "{"},
+	}
+	_, _, err := reconcile(configuration, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,191 @@
+package webhookoverrides
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/rancher/wrangler/pkg/condition"
+	"github.com/rancher/wrangler/pkg/ticker"
+	"github.com/sirupsen/logrus"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+)
+
+// reapplyInterval is how often the overrides are reapplied, as the rancher-webhook reverts its webhook configurations
+// when it starts and when its chart is upgraded.
+const reapplyInterval = time.Minute
+
+type handler struct {
+	ctx          context.Context
+	clusterName  string
+	clusters     mgmtcontrollers.ClusterController
+	clusterCache mgmtcontrollers.ClusterCache
+	k8s          kubernetes.Interface
+}
+
+// Register registers the webhook-overrides controller of a downstream cluster, which applies the webhook overrides of
+// the cluster to the validating and mutating webhook configurations of the rancher-webhook, and reverts them once
+// they're removed. The cluster's WebhookOverridesApplied condition reports the overrides that can't be applied.
+func Register(ctx context.Context, cluster *config.UserContext) {
+	mgmt := cluster.Management.Wrangler
+	h := &handler{
+		ctx:          ctx,
+		clusterName:  cluster.ClusterName,
+		clusters:     mgmt.Mgmt.Cluster(),
+		clusterCache: mgmt.Mgmt.Cluster().Cache(),
+		k8s:          cluster.K8sClient,
+	}
+
+	mgmt.Mgmt.Cluster().OnChange(ctx, "webhook-overrides-"+cluster.ClusterName, h.OnChange)
+	go h.reapplyPeriodically(ctx)
+}
+
+func (h *handler) reapplyPeriodically(ctx context.Context) {
+	for range ticker.Context(ctx, reapplyInterval) {
+		cluster, err := h.clusterCache.Get(h.clusterName)
+		if err != nil {
+			continue
+		}
+		if cluster.Spec.WebhookOverrides != nil || hasCondition(cluster, v3.ClusterConditionWebhookOverridesApplied) {
+			h.clusters.Enqueue(h.clusterName)
+		}
+	}
+}
+
+// OnChange applies the webhook overrides of the cluster and sets its WebhookOverridesApplied condition. Invalid
+// overrides aren't applied at all, so that the webhooks are never left partially overridden.
+func (h *handler) OnChange(_ string, cluster *v3.Cluster) (*v3.Cluster, error) {
+	if cluster == nil || cluster.Name != h.clusterName || cluster.DeletionTimestamp != nil {
+		return cluster, nil
+	}
+
+	overrides := cluster.Spec.WebhookOverrides
+	problems := validate(overrides)
+	if len(problems) == 0 {
+		names := map[string]bool{}
+		for _, apply := range []func(*v3.WebhookOverrides, map[string]bool) error{h.applyValidating, h.applyMutating} {
+			if err := apply(overrides, names); err != nil {
+				return cluster, err
+			}
+		}
+		if overrides != nil {
+			for _, override := range overrides.Webhooks {
+				if !names[override.Name] {
+					problems = append(problems, fmt.Sprintf("webhook %s not found", override.Name))
+				}
+			}
+		}
+	}
+
+	updated := cluster.DeepCopy()
+	if overrides == nil {
+		removeCondition(updated, v3.ClusterConditionWebhookOverridesApplied)
+	} else if len(problems) > 0 {
+		v3.ClusterConditionWebhookOverridesApplied.False(updated)
+		v3.ClusterConditionWebhookOverridesApplied.Message(updated, strings.Join(problems, "; "))
+	} else {
+		v3.ClusterConditionWebhookOverridesApplied.True(updated)
+		v3.ClusterConditionWebhookOverridesApplied.Message(updated, "")
+	}
+	if reflect.DeepEqual(cluster.Status.Conditions, updated.Status.Conditions) {
+		return cluster, nil
+	}
+	return h.clusters.Update(updated)
+}
+
+// applyValidating applies the overrides to the validating webhook configuration of the rancher-webhook, if it's
+// installed, and adds the names of its webhooks to names.
+func (h *handler) applyValidating(overrides *v3.WebhookOverrides, names map[string]bool) error {
+	client := h.k8s.AdmissionregistrationV1().ValidatingWebhookConfigurations()
+	current, err := client.Get(h.ctx, ConfigurationName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	updated := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	if err := overridden(current, updated, overrides, names); err != nil {
+		return err
+	}
+	if reflect.DeepEqual(current.Webhooks, updated.Webhooks) && reflect.DeepEqual(current.Annotations, updated.Annotations) {
+		return nil
+	}
+	logrus.Infof("[webhook-overrides] cluster [%s]: updating validating webhook configuration [%s]", h.clusterName, ConfigurationName)
+	_, err = client.Update(h.ctx, updated, metav1.UpdateOptions{})
+	return err
+}
+
+// applyMutating applies the overrides to the mutating webhook configuration of the rancher-webhook, if it's installed,
+// and adds the names of its webhooks to names.
+func (h *handler) applyMutating(overrides *v3.WebhookOverrides, names map[string]bool) error {
+	client := h.k8s.AdmissionregistrationV1().MutatingWebhookConfigurations()
+	current, err := client.Get(h.ctx, ConfigurationName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	updated := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	if err := overridden(current, updated, overrides, names); err != nil {
+		return err
+	}
+	if reflect.DeepEqual(current.Webhooks, updated.Webhooks) && reflect.DeepEqual(current.Annotations, updated.Annotations) {
+		return nil
+	}
+	logrus.Infof("[webhook-overrides] cluster [%s]: updating mutating webhook configuration [%s]", h.clusterName, ConfigurationName)
+	_, err = client.Update(h.ctx, updated, metav1.UpdateOptions{})
+	return err
+}
+
+// overridden sets result to the webhook configuration with the overrides applied, and adds the names of its webhooks
+// to names. The result is decoded from JSON rather than converted from unstructured, as the webhooks restored from the
+// originals annotation have float numbers.
+func overridden(current, result runtime.Object, overrides *v3.WebhookOverrides, names map[string]bool) error {
+	configuration, err := runtime.DefaultUnstructuredConverter.ToUnstructured(current)
+	if err != nil {
+		return err
+	}
+	configuration, webhooks, err := reconcile(configuration, overrides)
+	if err != nil {
+		return err
+	}
+	for name := range webhooks {
+		names[name] = true
+	}
+	raw, err := json.Marshal(configuration)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, result)
+}
+
+func hasCondition(cluster *v3.Cluster, cond condition.Cond) bool {
+	for _, c := range cluster.Status.Conditions {
+		if string(c.Type) == string(cond) {
+			return true
+		}
+	}
+	return false
+}
+
+func removeCondition(cluster *v3.Cluster, cond condition.Cond) {
+	var conditions []v3.ClusterCondition
+	for _, c := range cluster.Status.Conditions {
+		if string(c.Type) != string(cond) {
+			conditions = append(conditions, c)
+		}
+	}
+	cluster.Status.Conditions = conditions
+}
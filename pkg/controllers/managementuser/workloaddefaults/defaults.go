@@ -0,0 +1,219 @@
+package workloaddefaults
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	corev1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// WorkloadDefaultsLabel marks the secrets and runtime classes of a downstream cluster managed by rancher for the
+	// workload defaults of the cluster.
+	WorkloadDefaultsLabel = "management.cattle.io/workload-defaults"
+	// SourceAnnotation is the namespace and name of the management cluster secret an image pull secret was copied from.
+	SourceAnnotation = "management.cattle.io/workload-defaults-source"
+	// PullSecretsAnnotation lists the image pull secrets rancher added to a default service account, so that they can
+	// be removed once they're no longer part of the workload defaults.
+	PullSecretsAnnotation = "management.cattle.io/workload-defaults-pull-secrets"
+	// RuntimeClassAnnotation is the runtime class rancher set on a workload, so that it can be changed or removed along
+	// with the default runtime class. It is removed when the runtime class of the workload is changed by its owner.
+	RuntimeClassAnnotation = "management.cattle.io/workload-defaults-runtime-class"
+
+	defaultServiceAccount = "default"
+)
+
+// excluded returns whether the workload defaults aren't applied to a namespace.
+func excluded(defaults *v3.WorkloadDefaults, systemNamespaces []string, namespace string) bool {
+	if defaults == nil {
+		return true
+	}
+	for _, name := range systemNamespaces {
+		if name == namespace {
+			return true
+		}
+	}
+	for _, name := range defaults.ExcludedNamespaces {
+		if name == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// validSource returns an error if an image pull secret can't be copied from the management cluster for a cluster.
+// Secrets are only copied from the namespace of the cluster and from its fleet workspace, so that clusters can't be
+// given the secrets of others.
+func validSource(source v3.ImagePullSecretSource, cluster *v3.Cluster) error {
+	if source.Namespace == "" || source.Name == "" {
+		return fmt.Errorf("image pull secrets must have a namespace and a name")
+	}
+	if source.Namespace != cluster.Name && (cluster.Spec.FleetWorkspaceName == "" || source.Namespace != cluster.Spec.FleetWorkspaceName) {
+		return fmt.Errorf("image pull secret %s/%s must be in namespace %s", source.Namespace, source.Name, allowedNamespaces(cluster))
+	}
+	return nil
+}
+
+func allowedNamespaces(cluster *v3.Cluster) string {
+	if cluster.Spec.FleetWorkspaceName == "" {
+		return cluster.Name
+	}
+	return cluster.Name + " or " + cluster.Spec.FleetWorkspaceName
+}
+
+// copiedSecrets returns the copies of the image pull secrets in a namespace, in order.
+func copiedSecrets(sources []*corev1.Secret, namespace string) []*corev1.Secret {
+	var result []*corev1.Secret
+	for _, secret := range sources {
+		result = append(result, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secret.Name,
+				Namespace: namespace,
+				Labels: map[string]string{
+					WorkloadDefaultsLabel: "true",
+				},
+				Annotations: map[string]string{
+					SourceAnnotation: secret.Namespace + "/" + secret.Name,
+				},
+			},
+			Type: secret.Type,
+			Data: secret.Data,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// copyUpToDate returns whether an existing copy of a secret has the type, data, labels and annotations of the copy.
+func copyUpToDate(existing, copied *corev1.Secret) bool {
+	if existing.Type != copied.Type {
+		return false
+	}
+	for k, v := range copied.Labels {
+		if existing.Labels[k] != v {
+			return false
+		}
+	}
+	for k, v := range copied.Annotations {
+		if existing.Annotations[k] != v {
+			return false
+		}
+	}
+	if len(existing.Data) == 0 && len(copied.Data) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(existing.Data, copied.Data)
+}
+
+// setPullSecrets makes the image pull secrets rancher added to a service account the given names, keeping those added
+// by others. It returns whether the service account changed.
+func setPullSecrets(sa *corev1.ServiceAccount, names []string) bool {
+	previous := map[string]bool{}
+	if value := sa.Annotations[PullSecretsAnnotation]; value != "" {
+		for _, name := range strings.Split(value, ",") {
+			previous[name] = true
+		}
+	}
+	desired := map[string]bool{}
+	for _, name := range names {
+		desired[name] = true
+	}
+
+	var pullSecrets []corev1.LocalObjectReference
+	present := map[string]bool{}
+	for _, ref := range sa.ImagePullSecrets {
+		if previous[ref.Name] && !desired[ref.Name] {
+			continue
+		}
+		present[ref.Name] = true
+		pullSecrets = append(pullSecrets, ref)
+	}
+	for _, name := range names {
+		if !present[name] {
+			pullSecrets = append(pullSecrets, corev1.LocalObjectReference{Name: name})
+		}
+	}
+
+	annotation := strings.Join(names, ",")
+	if reflect.DeepEqual(pullSecrets, sa.ImagePullSecrets) && annotation == sa.Annotations[PullSecretsAnnotation] {
+		return false
+	}
+	sa.ImagePullSecrets = pullSecrets
+	if annotation == "" {
+		delete(sa.Annotations, PullSecretsAnnotation)
+		return true
+	}
+	if sa.Annotations == nil {
+		sa.Annotations = map[string]string{}
+	}
+	sa.Annotations[PullSecretsAnnotation] = annotation
+	return true
+}
+
+// setRuntimeClass sets the runtime class of the pod template of a workload to the default runtime class, unless its
+// owner set one, and reverts the runtime class rancher set when the default changes. It returns whether the workload
+// changed.
+func setRuntimeClass(meta *metav1.ObjectMeta, spec *corev1.PodSpec, defaultClass string) bool {
+	current := ""
+	if spec.RuntimeClassName != nil {
+		current = *spec.RuntimeClassName
+	}
+	set, managed := meta.Annotations[RuntimeClassAnnotation]
+
+	switch {
+	case managed && current != set:
+		// The owner of the workload changed its runtime class.
+		delete(meta.Annotations, RuntimeClassAnnotation)
+		return true
+	case managed && set == defaultClass:
+		return false
+	case !managed && (current != "" || defaultClass == ""):
+		return false
+	}
+
+	if defaultClass == "" {
+		spec.RuntimeClassName = nil
+		delete(meta.Annotations, RuntimeClassAnnotation)
+		return true
+	}
+	spec.RuntimeClassName = &defaultClass
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[RuntimeClassAnnotation] = defaultClass
+	return true
+}
+
+// runtimeClass returns the runtime class of the default runtime class of a cluster.
+func runtimeClass(defaultClass *v3.DefaultRuntimeClass) *nodev1.RuntimeClass {
+	result := &nodev1.RuntimeClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: defaultClass.Name,
+			Labels: map[string]string{
+				WorkloadDefaultsLabel: "true",
+			},
+		},
+		Handler: defaultClass.Handler,
+	}
+	if len(defaultClass.NodeSelector) > 0 || len(defaultClass.Tolerations) > 0 {
+		result.Scheduling = &nodev1.Scheduling{
+			NodeSelector: defaultClass.NodeSelector,
+			Tolerations:  defaultClass.Tolerations,
+		}
+	}
+	return result
+}
+
+// runtimeClassUpToDate returns whether an existing runtime class has the handler and scheduling of the runtime class.
+func runtimeClassUpToDate(existing, desired *nodev1.RuntimeClass) bool {
+	return existing.Handler == desired.Handler &&
+		existing.Labels[WorkloadDefaultsLabel] == desired.Labels[WorkloadDefaultsLabel] &&
+		reflect.DeepEqual(existing.Scheduling, desired.Scheduling)
+}
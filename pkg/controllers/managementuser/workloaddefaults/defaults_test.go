@@ -0,0 +1,170 @@
+package workloaddefaults
+
+import (
+	"testing"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExcluded(t *testing.T) {
+	defaults := &v3.WorkloadDefaults{ExcludedNamespaces: []string{"legacy"}}
+	system := []string{"kube-system", "cattle-system"}
+
+	assert.True(t, excluded(nil, system, "app"))
+	assert.True(t, excluded(defaults, system, "kube-system"))
+	assert.True(t, excluded(defaults, system, "legacy"))
+	assert.False(t, excluded(defaults, system, "app"))
+}
+
+func TestValidSource(t *testing.T) {
+	cluster := &v3.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "c-1"}}
+	assert.NoError(t, validSource(v3.ImagePullSecretSource{Namespace: "c-1", Name: "registry"}, cluster))
+	assert.EqualError(t, validSource(v3.ImagePullSecretSource{Namespace: "fleet-default", Name: "registry"}, cluster),
+		"image pull secret fleet-default/registry must be in namespace c-1")
+	assert.EqualError(t, validSource(v3.ImagePullSecretSource{Name: "registry"}, cluster),
+		"image pull secrets must have a namespace and a name")
+
+	cluster.Spec.FleetWorkspaceName = "fleet-default"
+	assert.NoError(t, validSource(v3.ImagePullSecretSource{Namespace: "fleet-default", Name: "registry"}, cluster))
+	assert.EqualError(t, validSource(v3.ImagePullSecretSource{Namespace: "cattle-global-data", Name: "registry"}, cluster),
+		"image pull secret cattle-global-data/registry must be in namespace c-1 or fleet-default")
+}
+
+func TestCopiedSecrets(t *testing.T) {
+	sources := []*corev1.Secret{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "fleet-default", Name: "registry-b"},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte("b")},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "c-1", Name: "registry-a"},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte("a")},
+		},
+	}
+
+	copies := copiedSecrets(sources, "app")
+	assert.Len(t, copies, 2)
+	assert.Equal(t, "registry-a", copies[0].Name)
+	assert.Equal(t, "app", copies[0].Namespace)
+	assert.Equal(t, "c-1/registry-a", copies[0].Annotations[SourceAnnotation])
+	assert.Equal(t, "true", copies[0].Labels[WorkloadDefaultsLabel])
+	assert.Equal(t, corev1.SecretTypeDockerConfigJson, copies[0].Type)
+	assert.Equal(t, []byte("a"), copies[0].Data[corev1.DockerConfigJsonKey])
+	assert.Equal(t, "registry-b", copies[1].Name)
+
+	existing := copies[0].DeepCopy()
+	assert.True(t, copyUpToDate(existing, copies[0]))
+	existing.Data[corev1.DockerConfigJsonKey] = []byte("changed")
+	assert.False(t, copyUpToDate(existing, copies[0]))
+	existing = copies[0].DeepCopy()
+	existing.Type = corev1.SecretTypeOpaque
+	assert.False(t, copyUpToDate(existing, copies[0]))
+}
+
+func TestSetPullSecrets(t *testing.T) {
+	sa := &corev1.ServiceAccount{
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: "own"}},
+	}
+
+	// the image pull secrets are added after those of the owner of the service account
+	assert.True(t, setPullSecrets(sa, []string{"registry-a", "registry-b"}))
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "own"}, {Name: "registry-a"}, {Name: "registry-b"}}, sa.ImagePullSecrets)
+	assert.Equal(t, "registry-a,registry-b", sa.Annotations[PullSecretsAnnotation])
+	assert.False(t, setPullSecrets(sa, []string{"registry-a", "registry-b"}))
+
+	// removed image pull secrets are added back
+	sa.ImagePullSecrets = sa.ImagePullSecrets[:2]
+	assert.True(t, setPullSecrets(sa, []string{"registry-a", "registry-b"}))
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "own"}, {Name: "registry-a"}, {Name: "registry-b"}}, sa.ImagePullSecrets)
+
+	// image pull secrets that aren't defaults anymore are removed, unlike those of the owner
+	assert.True(t, setPullSecrets(sa, []string{"registry-b"}))
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "own"}, {Name: "registry-b"}}, sa.ImagePullSecrets)
+	assert.Equal(t, "registry-b", sa.Annotations[PullSecretsAnnotation])
+
+	assert.True(t, setPullSecrets(sa, nil))
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "own"}}, sa.ImagePullSecrets)
+	assert.NotContains(t, sa.Annotations, PullSecretsAnnotation)
+	assert.False(t, setPullSecrets(sa, nil))
+}
+
+func TestSetRuntimeClass(t *testing.T) {
+	own := "runc"
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		runtimeClass *string
+		defaultClass string
+		changed      bool
+		expected     *string
+		annotation   string
+	}{
+		{name: "no default"},
+		{name: "defaulted", defaultClass: "gvisor", changed: true, expected: strPtr("gvisor"), annotation: "gvisor"},
+		{name: "set by the owner", runtimeClass: &own, defaultClass: "gvisor", expected: &own},
+		{
+			name:         "already defaulted",
+			annotations:  map[string]string{RuntimeClassAnnotation: "gvisor"},
+			runtimeClass: strPtr("gvisor"),
+			defaultClass: "gvisor",
+			expected:     strPtr("gvisor"),
+			annotation:   "gvisor",
+		},
+		{
+			name:         "default changed",
+			annotations:  map[string]string{RuntimeClassAnnotation: "gvisor"},
+			runtimeClass: strPtr("gvisor"),
+			defaultClass: "kata",
+			changed:      true,
+			expected:     strPtr("kata"),
+			annotation:   "kata",
+		},
+		{
+			name:         "default removed",
+			annotations:  map[string]string{RuntimeClassAnnotation: "gvisor"},
+			runtimeClass: strPtr("gvisor"),
+			changed:      true,
+		},
+		{
+			name:         "changed by the owner",
+			annotations:  map[string]string{RuntimeClassAnnotation: "gvisor"},
+			runtimeClass: &own,
+			defaultClass: "gvisor",
+			changed:      true,
+			expected:     &own,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta := &metav1.ObjectMeta{Annotations: tt.annotations}
+			spec := &corev1.PodSpec{RuntimeClassName: tt.runtimeClass}
+			assert.Equal(t, tt.changed, setRuntimeClass(meta, spec, tt.defaultClass))
+			assert.Equal(t, tt.expected, spec.RuntimeClassName)
+			assert.Equal(t, tt.annotation, meta.Annotations[RuntimeClassAnnotation])
+		})
+	}
+}
+
+func TestRuntimeClass(t *testing.T) {
+	defaultClass := &v3.DefaultRuntimeClass{Name: "gvisor", Handler: "runsc"}
+	class := runtimeClass(defaultClass)
+	assert.Equal(t, "gvisor", class.Name)
+	assert.Equal(t, "runsc", class.Handler)
+	assert.Nil(t, class.Scheduling)
+
+	defaultClass.NodeSelector = map[string]string{"sandbox": "true"}
+	scheduled := runtimeClass(defaultClass)
+	assert.Equal(t, map[string]string{"sandbox": "true"}, scheduled.Scheduling.NodeSelector)
+	assert.False(t, runtimeClassUpToDate(class, scheduled))
+	assert.True(t, runtimeClassUpToDate(scheduled.DeepCopy(), scheduled))
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,511 @@
+package workloaddefaults
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	appsv1 "github.com/rancher/rancher/pkg/generated/norman/apps/v1"
+	v1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/types/config"
+	"github.com/rancher/wrangler/pkg/condition"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/sirupsen/logrus"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	nodev1client "k8s.io/client-go/kubernetes/typed/node/v1"
+)
+
+type handler struct {
+	clusterName       string
+	clusters          mgmtcontrollers.ClusterController
+	clusterCache      mgmtcontrollers.ClusterCache
+	managementSecrets corecontrollers.SecretCache
+	namespaces        v1.NamespaceInterface
+	namespaceLister   v1.NamespaceLister
+	secrets           v1.SecretInterface
+	secretLister      v1.SecretLister
+	serviceAccounts   v1.ServiceAccountInterface
+	saLister          v1.ServiceAccountLister
+	runtimeClasses    nodev1client.RuntimeClassInterface
+	deployments       appsv1.DeploymentInterface
+	statefulSets      appsv1.StatefulSetInterface
+	daemonSets        appsv1.DaemonSetInterface
+
+	// applied are the workload defaults the namespaces and workloads were last enqueued for.
+	applied *v3.WorkloadDefaults
+}
+
+// Register registers the workload-defaults controller of a downstream cluster, which copies the image pull secrets of
+// the workload defaults of the cluster to each namespace and adds them to its default service account, and sets the
+// default runtime class on the workloads that don't set one. Changes made to them in the downstream cluster are
+// reverted, and the cluster's WorkloadDefaultsApplied condition reports the defaults that can't be applied.
+func Register(ctx context.Context, cluster *config.UserContext) {
+	mgmt := cluster.Management.Wrangler
+	h := &handler{
+		clusterName:       cluster.ClusterName,
+		clusters:          mgmt.Mgmt.Cluster(),
+		clusterCache:      mgmt.Mgmt.Cluster().Cache(),
+		managementSecrets: mgmt.Core.Secret().Cache(),
+		namespaces:        cluster.Core.Namespaces(""),
+		namespaceLister:   cluster.Core.Namespaces("").Controller().Lister(),
+		secrets:           cluster.Core.Secrets(""),
+		secretLister:      cluster.Core.Secrets("").Controller().Lister(),
+		serviceAccounts:   cluster.Core.ServiceAccounts(""),
+		saLister:          cluster.Core.ServiceAccounts("").Controller().Lister(),
+		runtimeClasses:    cluster.K8sClient.NodeV1().RuntimeClasses(),
+		deployments:       cluster.Apps.Deployments(""),
+		statefulSets:      cluster.Apps.StatefulSets(""),
+		daemonSets:        cluster.Apps.DaemonSets(""),
+	}
+
+	mgmt.Mgmt.Cluster().OnChange(ctx, "workload-defaults-"+cluster.ClusterName, h.OnChange)
+	mgmt.Core.Secret().OnChange(ctx, "workload-defaults-secret-"+cluster.ClusterName, h.onManagementSecret)
+	cluster.Core.Namespaces("").AddHandler(ctx, "workload-defaults-namespace", h.onNamespace)
+	cluster.Core.Secrets("").AddHandler(ctx, "workload-defaults-secret", h.onSecret)
+	cluster.Core.ServiceAccounts("").AddHandler(ctx, "workload-defaults-service-account", h.onServiceAccount)
+	cluster.Apps.Deployments("").AddHandler(ctx, "workload-defaults-deployment", h.onDeployment)
+	cluster.Apps.StatefulSets("").AddHandler(ctx, "workload-defaults-statefulset", h.onStatefulSet)
+	cluster.Apps.DaemonSets("").AddHandler(ctx, "workload-defaults-daemonset", h.onDaemonSet)
+}
+
+// onManagementSecret enqueues the cluster and its namespaces when one of its image pull secrets changes in the
+// management cluster, so that the copies are updated.
+func (h *handler) onManagementSecret(_ string, secret *corev1.Secret) (*corev1.Secret, error) {
+	if secret == nil {
+		return nil, nil
+	}
+	cluster, err := h.clusterCache.Get(h.clusterName)
+	if apierrors.IsNotFound(err) {
+		return secret, nil
+	} else if err != nil {
+		return secret, err
+	}
+	if cluster.Spec.WorkloadDefaults == nil {
+		return secret, nil
+	}
+	for _, source := range cluster.Spec.WorkloadDefaults.ImagePullSecrets {
+		if source.Namespace == secret.Namespace && source.Name == secret.Name {
+			h.clusters.Enqueue(h.clusterName)
+			return secret, h.enqueueNamespaces()
+		}
+	}
+	return secret, nil
+}
+
+// OnChange reconciles the default runtime class of the cluster, enqueues the namespaces and workloads when the
+// workload defaults changed, and sets the WorkloadDefaultsApplied condition of the cluster.
+func (h *handler) OnChange(_ string, cluster *v3.Cluster) (*v3.Cluster, error) {
+	if cluster == nil || cluster.Name != h.clusterName || cluster.DeletionTimestamp != nil {
+		return cluster, nil
+	}
+
+	defaults := cluster.Spec.WorkloadDefaults
+	var problems []string
+	if defaults != nil {
+		_, secretProblems, err := h.pullSecrets(cluster)
+		if err != nil {
+			return cluster, err
+		}
+		problems = append(problems, secretProblems...)
+	}
+	if problem, err := h.applyRuntimeClass(defaults); err != nil {
+		return cluster, err
+	} else if problem != "" {
+		problems = append(problems, problem)
+	}
+
+	if !reflect.DeepEqual(defaults, h.applied) {
+		if err := h.enqueueNamespaces(); err != nil {
+			return cluster, err
+		}
+		if err := h.enqueueWorkloads(); err != nil {
+			return cluster, err
+		}
+		h.applied = defaults.DeepCopy()
+	}
+
+	updated := cluster.DeepCopy()
+	if defaults == nil {
+		removeCondition(updated, v3.ClusterConditionWorkloadDefaultsApplied)
+	} else if len(problems) > 0 {
+		v3.ClusterConditionWorkloadDefaultsApplied.False(updated)
+		v3.ClusterConditionWorkloadDefaultsApplied.Message(updated, strings.Join(problems, "; "))
+	} else {
+		v3.ClusterConditionWorkloadDefaultsApplied.True(updated)
+		v3.ClusterConditionWorkloadDefaultsApplied.Message(updated, "")
+	}
+	if reflect.DeepEqual(cluster.Status.Conditions, updated.Status.Conditions) {
+		return cluster, nil
+	}
+	return h.clusters.Update(updated)
+}
+
+func (h *handler) enqueueNamespaces() error {
+	namespaces, err := h.namespaceLister.List("", labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, ns := range namespaces {
+		h.namespaces.Controller().Enqueue("", ns.Name)
+	}
+	return nil
+}
+
+func (h *handler) enqueueWorkloads() error {
+	deployments, err := h.deployments.Controller().Lister().List("", labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, deployment := range deployments {
+		h.deployments.Controller().Enqueue(deployment.Namespace, deployment.Name)
+	}
+	statefulSets, err := h.statefulSets.Controller().Lister().List("", labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, statefulSet := range statefulSets {
+		h.statefulSets.Controller().Enqueue(statefulSet.Namespace, statefulSet.Name)
+	}
+	daemonSets, err := h.daemonSets.Controller().Lister().List("", labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, daemonSet := range daemonSets {
+		h.daemonSets.Controller().Enqueue(daemonSet.Namespace, daemonSet.Name)
+	}
+	return nil
+}
+
+// pullSecrets returns the management cluster secrets of the image pull secrets of the cluster, and the problems
+// preventing some from being copied.
+func (h *handler) pullSecrets(cluster *v3.Cluster) ([]*corev1.Secret, []string, error) {
+	if cluster.Spec.WorkloadDefaults == nil {
+		return nil, nil, nil
+	}
+
+	var (
+		result   []*corev1.Secret
+		problems []string
+	)
+	names := map[string]bool{}
+	for _, source := range cluster.Spec.WorkloadDefaults.ImagePullSecrets {
+		if err := validSource(source, cluster); err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		if names[source.Name] {
+			problems = append(problems, fmt.Sprintf("image pull secret %s/%s has the name of another image pull secret", source.Namespace, source.Name))
+			continue
+		}
+		secret, err := h.managementSecrets.Get(source.Namespace, source.Name)
+		if apierrors.IsNotFound(err) {
+			problems = append(problems, fmt.Sprintf("image pull secret %s/%s not found", source.Namespace, source.Name))
+			continue
+		} else if err != nil {
+			return nil, nil, err
+		}
+		if secret.Type != corev1.SecretTypeDockerConfigJson {
+			problems = append(problems, fmt.Sprintf("image pull secret %s/%s must be of type %s", source.Namespace, source.Name, corev1.SecretTypeDockerConfigJson))
+			continue
+		}
+		names[source.Name] = true
+		result = append(result, secret)
+	}
+	return result, problems, nil
+}
+
+// applyRuntimeClass creates or updates the default runtime class, and deletes the runtime classes rancher created that
+// aren't the default anymore. A runtime class with the name of the default that wasn't created by rancher is used as
+// is if it has the same handler, and is returned as a problem otherwise.
+func (h *handler) applyRuntimeClass(defaults *v3.WorkloadDefaults) (string, error) {
+	var desired *v3.DefaultRuntimeClass
+	if defaults != nil {
+		desired = defaults.DefaultRuntimeClass
+	}
+
+	existing, err := h.runtimeClasses.List(context.TODO(), metav1.ListOptions{LabelSelector: WorkloadDefaultsLabel + "=true"})
+	if err != nil {
+		return "", err
+	}
+	for _, class := range existing.Items {
+		if desired != nil && class.Name == desired.Name {
+			continue
+		}
+		logrus.Infof("[workload-defaults] cluster [%s]: deleting runtime class [%s]", h.clusterName, class.Name)
+		if err := h.runtimeClasses.Delete(context.TODO(), class.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return "", err
+		}
+	}
+	if desired == nil {
+		return "", nil
+	}
+	if desired.Name == "" || desired.Handler == "" {
+		return "default runtime class must have a name and a handler", nil
+	}
+
+	class := runtimeClass(desired)
+	current, err := h.runtimeClasses.Get(context.TODO(), class.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		logrus.Infof("[workload-defaults] cluster [%s]: creating runtime class [%s]", h.clusterName, class.Name)
+		_, err = h.runtimeClasses.Create(context.TODO(), class, metav1.CreateOptions{})
+		return "", err
+	} else if err != nil {
+		return "", err
+	}
+
+	if current.Labels[WorkloadDefaultsLabel] != "true" {
+		if current.Handler != class.Handler {
+			return fmt.Sprintf("runtime class %s already exists with handler %s", class.Name, current.Handler), nil
+		}
+		return "", nil
+	}
+	if runtimeClassUpToDate(current, class) {
+		return "", nil
+	}
+	if current.Handler != class.Handler {
+		// The handler of a runtime class is immutable.
+		logrus.Infof("[workload-defaults] cluster [%s]: recreating runtime class [%s] with handler [%s]", h.clusterName, class.Name, class.Handler)
+		if err := h.runtimeClasses.Delete(context.TODO(), class.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return "", err
+		}
+		_, err = h.runtimeClasses.Create(context.TODO(), class, metav1.CreateOptions{})
+		return "", err
+	}
+	logrus.Infof("[workload-defaults] cluster [%s]: updating runtime class [%s]", h.clusterName, class.Name)
+	toUpdate := current.DeepCopy()
+	toUpdate.Scheduling = class.Scheduling
+	_, err = h.runtimeClasses.Update(context.TODO(), toUpdate, metav1.UpdateOptions{})
+	return "", err
+}
+
+// onSecret enqueues the namespace of an image pull secret when it changes, so that changes made to it are reverted.
+func (h *handler) onSecret(key string, secret *corev1.Secret) (runtime.Object, error) {
+	if secret != nil {
+		if secret.Labels[WorkloadDefaultsLabel] == "true" {
+			h.namespaces.Controller().Enqueue("", secret.Namespace)
+		}
+		return secret, nil
+	}
+	if namespace, _, ok := strings.Cut(key, "/"); ok {
+		h.namespaces.Controller().Enqueue("", namespace)
+	}
+	return nil, nil
+}
+
+// onServiceAccount enqueues the namespace of a default service account when it changes, so that image pull secrets
+// removed from it are added back.
+func (h *handler) onServiceAccount(key string, sa *corev1.ServiceAccount) (runtime.Object, error) {
+	namespace, name, ok := strings.Cut(key, "/")
+	if ok && name == defaultServiceAccount {
+		h.namespaces.Controller().Enqueue("", namespace)
+	}
+	return sa, nil
+}
+
+// onNamespace copies the image pull secrets to a namespace and adds them to its default service account, and removes
+// the copies that aren't image pull secrets of the cluster anymore.
+func (h *handler) onNamespace(_ string, ns *corev1.Namespace) (runtime.Object, error) {
+	if ns == nil || ns.DeletionTimestamp != nil {
+		return ns, nil
+	}
+
+	cluster, err := h.clusterCache.Get(h.clusterName)
+	if apierrors.IsNotFound(err) {
+		return ns, nil
+	} else if err != nil {
+		return ns, err
+	}
+
+	var sources []*corev1.Secret
+	if !excluded(cluster.Spec.WorkloadDefaults, systemNamespaces(), ns.Name) {
+		if sources, _, err = h.pullSecrets(cluster); err != nil {
+			return ns, err
+		}
+	}
+
+	names, err := h.applySecrets(ns.Name, copiedSecrets(sources, ns.Name))
+	if err != nil {
+		return ns, err
+	}
+	return ns, h.applyServiceAccount(ns.Name, names)
+}
+
+// applySecrets creates or updates the copies of the image pull secrets in a namespace, and deletes the copies that
+// aren't anymore. Secrets with the name of a copy that aren't copies aren't overwritten. It returns the names of the
+// copies.
+func (h *handler) applySecrets(namespace string, copies []*corev1.Secret) ([]string, error) {
+	existing, err := h.secretLister.List(namespace, labels.SelectorFromSet(labels.Set{WorkloadDefaultsLabel: "true"}))
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	keep := map[string]bool{}
+	for _, copied := range copies {
+		keep[copied.Name] = true
+
+		current, err := h.secretLister.Get(namespace, copied.Name)
+		if apierrors.IsNotFound(err) {
+			logrus.Infof("[workload-defaults] cluster [%s]: creating image pull secret [%s/%s]", h.clusterName, namespace, copied.Name)
+			if _, err := h.secrets.Create(copied); err != nil && !apierrors.IsAlreadyExists(err) {
+				return nil, err
+			}
+			names = append(names, copied.Name)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		if current.Labels[WorkloadDefaultsLabel] != "true" {
+			logrus.Debugf("[workload-defaults] cluster [%s]: not overwriting secret [%s/%s]", h.clusterName, namespace, copied.Name)
+			continue
+		}
+		names = append(names, copied.Name)
+		if copyUpToDate(current, copied) {
+			continue
+		}
+		if current.Type != copied.Type {
+			// The type of a secret is immutable.
+			if err := h.secrets.DeleteNamespaced(namespace, copied.Name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+			if _, err := h.secrets.Create(copied); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		logrus.Infof("[workload-defaults] cluster [%s]: updating image pull secret [%s/%s]", h.clusterName, namespace, copied.Name)
+		toUpdate := current.DeepCopy()
+		toUpdate.Data = copied.Data
+		for k, v := range copied.Labels {
+			toUpdate.Labels[k] = v
+		}
+		if toUpdate.Annotations == nil {
+			toUpdate.Annotations = map[string]string{}
+		}
+		for k, v := range copied.Annotations {
+			toUpdate.Annotations[k] = v
+		}
+		if _, err := h.secrets.Update(toUpdate); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, secret := range existing {
+		if keep[secret.Name] {
+			continue
+		}
+		logrus.Infof("[workload-defaults] cluster [%s]: deleting image pull secret [%s/%s]", h.clusterName, namespace, secret.Name)
+		if err := h.secrets.DeleteNamespaced(namespace, secret.Name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	return names, nil
+}
+
+// applyServiceAccount makes the image pull secrets rancher added to the default service account of a namespace the
+// given names. The default service account is created by kubernetes, the namespace is enqueued again once it is.
+func (h *handler) applyServiceAccount(namespace string, names []string) error {
+	sa, err := h.saLister.Get(namespace, defaultServiceAccount)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	sa = sa.DeepCopy()
+	if !setPullSecrets(sa, names) {
+		return nil
+	}
+	logrus.Infof("[workload-defaults] cluster [%s]: updating image pull secrets of service account [%s/%s]", h.clusterName, namespace, sa.Name)
+	_, err = h.serviceAccounts.Update(sa)
+	return err
+}
+
+// defaultRuntimeClass returns the default runtime class of the workloads of a namespace, if any.
+func (h *handler) defaultRuntimeClass(namespace string) (string, error) {
+	cluster, err := h.clusterCache.Get(h.clusterName)
+	if apierrors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	defaults := cluster.Spec.WorkloadDefaults
+	if excluded(defaults, systemNamespaces(), namespace) || defaults.DefaultRuntimeClass == nil {
+		return "", nil
+	}
+	return defaults.DefaultRuntimeClass.Name, nil
+}
+
+func (h *handler) onDeployment(_ string, deployment *k8sappsv1.Deployment) (runtime.Object, error) {
+	if deployment == nil || deployment.DeletionTimestamp != nil {
+		return deployment, nil
+	}
+	defaultClass, err := h.defaultRuntimeClass(deployment.Namespace)
+	if err != nil {
+		return deployment, err
+	}
+	toUpdate := deployment.DeepCopy()
+	if !setRuntimeClass(&toUpdate.ObjectMeta, &toUpdate.Spec.Template.Spec, defaultClass) {
+		return deployment, nil
+	}
+	logrus.Infof("[workload-defaults] cluster [%s]: updating runtime class of deployment [%s/%s]", h.clusterName, deployment.Namespace, deployment.Name)
+	return h.deployments.Update(toUpdate)
+}
+
+func (h *handler) onStatefulSet(_ string, statefulSet *k8sappsv1.StatefulSet) (runtime.Object, error) {
+	if statefulSet == nil || statefulSet.DeletionTimestamp != nil {
+		return statefulSet, nil
+	}
+	defaultClass, err := h.defaultRuntimeClass(statefulSet.Namespace)
+	if err != nil {
+		return statefulSet, err
+	}
+	toUpdate := statefulSet.DeepCopy()
+	if !setRuntimeClass(&toUpdate.ObjectMeta, &toUpdate.Spec.Template.Spec, defaultClass) {
+		return statefulSet, nil
+	}
+	logrus.Infof("[workload-defaults] cluster [%s]: updating runtime class of statefulset [%s/%s]", h.clusterName, statefulSet.Namespace, statefulSet.Name)
+	return h.statefulSets.Update(toUpdate)
+}
+
+func (h *handler) onDaemonSet(_ string, daemonSet *k8sappsv1.DaemonSet) (runtime.Object, error) {
+	if daemonSet == nil || daemonSet.DeletionTimestamp != nil {
+		return daemonSet, nil
+	}
+	defaultClass, err := h.defaultRuntimeClass(daemonSet.Namespace)
+	if err != nil {
+		return daemonSet, err
+	}
+	toUpdate := daemonSet.DeepCopy()
+	if !setRuntimeClass(&toUpdate.ObjectMeta, &toUpdate.Spec.Template.Spec, defaultClass) {
+		return daemonSet, nil
+	}
+	logrus.Infof("[workload-defaults] cluster [%s]: updating runtime class of daemonset [%s/%s]", h.clusterName, daemonSet.Namespace, daemonSet.Name)
+	return h.daemonSets.Update(toUpdate)
+}
+
+func systemNamespaces() []string {
+	return strings.Split(settings.SystemNamespaces.Get(), ",")
+}
+
+func removeCondition(cluster *v3.Cluster, cond condition.Cond) {
+	var conditions []v3.ClusterCondition
+	for _, c := range cluster.Status.Conditions {
+		if string(c.Type) != string(cond) {
+			conditions = append(conditions, c)
+		}
+	}
+	cluster.Status.Conditions = conditions
+}
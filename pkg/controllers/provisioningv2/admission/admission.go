@@ -0,0 +1,132 @@
+// Package admission registers the generating handlers of provisioning clusters whose generated objects can be rejected
+// by admission webhooks or policies, such as the rancher-webhook or third-party policy engines. A rejection is recorded
+// verbatim on the cluster, in its status and AdmissionRejected condition, and the objects are applied again with an
+// increasing delay until the admission-rejection-retry-limit setting is reached.
+package admission
+
+import (
+	"context"
+	"time"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rocontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/wrangler/pkg/apply"
+	"github.com/rancher/wrangler/pkg/condition"
+	"github.com/rancher/wrangler/pkg/generic"
+	"github.com/rancher/wrangler/pkg/kv"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+type generatingHandler struct {
+	handler    rocontrollers.ClusterGeneratingHandler
+	controller rocontrollers.ClusterController
+	apply      apply.Apply
+	condition  condition.Cond
+	name       string
+	opts       generic.GeneratingHandlerOptions
+}
+
+// RegisterClusterGeneratingHandler registers a generating handler of provisioning clusters like
+// rocontrollers.RegisterClusterGeneratingHandler, the rejections of the generated objects by admission being recorded
+// on the cluster and retried with backoff rather than returned as errors.
+func RegisterClusterGeneratingHandler(ctx context.Context, controller rocontrollers.ClusterController, apply apply.Apply,
+	condition condition.Cond, name string, handler rocontrollers.ClusterGeneratingHandler, opts *generic.GeneratingHandlerOptions) {
+	h := &generatingHandler{
+		handler:    handler,
+		controller: controller,
+		apply:      apply,
+		condition:  condition,
+		name:       name,
+	}
+	if opts != nil {
+		h.opts = *opts
+	}
+	controller.OnChange(ctx, name, h.remove)
+	controller.OnChange(ctx, name, h.sync)
+}
+
+func (h *generatingHandler) remove(key string, obj *rancherv1.Cluster) (*rancherv1.Cluster, error) {
+	if obj != nil {
+		return obj, nil
+	}
+
+	obj = &rancherv1.Cluster{}
+	obj.Namespace, obj.Name = kv.RSplit(key, "/")
+	obj.SetGroupVersionKind(h.controller.GroupVersionKind())
+
+	return nil, generic.ConfigureApplyForObject(h.apply, obj, &h.opts).
+		WithOwner(obj).
+		WithSetID(h.name).
+		ApplyObjects()
+}
+
+func (h *generatingHandler) sync(_ string, obj *rancherv1.Cluster) (*rancherv1.Cluster, error) {
+	if obj == nil || !obj.DeletionTimestamp.IsZero() {
+		return obj, nil
+	}
+
+	limit := settings.AdmissionRejectionRetryLimit.GetInt()
+	if retryLimitReached(obj, h.name, limit) {
+		return obj, nil
+	}
+
+	origStatus := obj.Status.DeepCopy()
+	obj = obj.DeepCopy()
+	newStatus, err := h.generate(obj, obj.Status)
+	if err != nil {
+		// Revert to old status on error
+		newStatus = *origStatus.DeepCopy()
+	}
+
+	webhook, rejected := rejectedBy(err)
+	switch {
+	case rejected:
+		attempts := recordRejection(obj, &newStatus, h.name, webhook, err, time.Now())
+		if limit <= 0 || attempts < limit {
+			logrus.Infof("[%s] cluster %s/%s: objects rejected by admission, retrying: %v", h.name, obj.Namespace, obj.Name, err)
+			h.controller.EnqueueAfter(obj.Namespace, obj.Name, backoff(attempts))
+		} else {
+			logrus.Warnf("[%s] cluster %s/%s: objects rejected by admission %d times, waiting for the cluster to change: %v", h.name, obj.Namespace, obj.Name, attempts, err)
+		}
+		h.condition.SetError(&newStatus, RejectedReason, err)
+		err = nil
+	case err == nil:
+		clearRejection(&newStatus, h.name)
+		h.condition.SetError(&newStatus, "", nil)
+	case apierrors.IsConflict(err):
+		h.condition.SetError(&newStatus, "", nil)
+	default:
+		h.condition.SetError(&newStatus, "", err)
+	}
+	setRejectedCondition(obj, &newStatus, limit)
+
+	if !equality.Semantic.DeepEqual(origStatus, &newStatus) {
+		// Since status has changed, update the lastUpdatedTime
+		h.condition.LastUpdated(&newStatus, time.Now().UTC().Format(time.RFC3339))
+
+		obj.Status = newStatus
+		newObj, newErr := h.controller.UpdateStatus(obj)
+		if err == nil {
+			err = newErr
+		}
+		if newErr == nil {
+			obj = newObj
+		}
+	}
+	return obj, err
+}
+
+func (h *generatingHandler) generate(obj *rancherv1.Cluster, status rancherv1.ClusterStatus) (rancherv1.ClusterStatus, error) {
+	objs, newStatus, err := h.handler(obj, status)
+	if err != nil {
+		return newStatus, err
+	}
+
+	return newStatus, generic.ConfigureApplyForObject(h.apply, obj, &h.opts).
+		WithOwner(obj).
+		WithSetID(h.name).
+		ApplyObjects(objs...)
+}
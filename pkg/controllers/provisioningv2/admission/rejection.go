@@ -0,0 +1,130 @@
+package admission
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// RejectedReason is the reason of the conditions of clusters whose generated objects are rejected by admission.
+	RejectedReason = "AdmissionRejected"
+	// RetryLimitReachedReason is the reason of the AdmissionRejected condition once the objects are no longer retried.
+	RetryLimitReachedReason = "RetryLimitReached"
+
+	initialBackoff = 15 * time.Second
+	maxBackoff     = 10 * time.Minute
+)
+
+var (
+	webhookDenied = regexp.MustCompile(`admission webhook "([^"]+)" denied the request`)
+	policyDenied  = regexp.MustCompile(`ValidatingAdmissionPolicy '([^']+)'[^:]* denied request`)
+)
+
+// rejectedBy returns whether an error is a denial by an admission webhook or a validating admission policy, and the
+// name of the webhook or policy. The errors of apply are aggregated, so they're matched by their message.
+func rejectedBy(err error) (string, bool) {
+	if err == nil {
+		return "", false
+	}
+	message := err.Error()
+	if m := webhookDenied.FindStringSubmatch(message); m != nil {
+		return m[1], true
+	}
+	if m := policyDenied.FindStringSubmatch(message); m != nil {
+		return m[1], true
+	}
+	return "", false
+}
+
+// recordRejection records the rejection of the objects of a controller in the status of a cluster, and returns the
+// number of consecutive rejections for the generation of the cluster.
+func recordRejection(cluster *rancherv1.Cluster, status *rancherv1.ClusterStatus, controller, webhook string, err error, now time.Time) int {
+	rejection := rancherv1.AdmissionRejectionStatus{
+		Controller:         controller,
+		Webhook:            webhook,
+		Message:            err.Error(),
+		Attempts:           1,
+		ObservedGeneration: cluster.Generation,
+		LastRejectionTime:  metav1.NewTime(now),
+	}
+
+	for i, previous := range status.AdmissionRejections {
+		if previous.Controller != controller {
+			continue
+		}
+		if previous.ObservedGeneration == cluster.Generation {
+			rejection.Attempts = previous.Attempts + 1
+		}
+		status.AdmissionRejections[i] = rejection
+		return rejection.Attempts
+	}
+	status.AdmissionRejections = append(status.AdmissionRejections, rejection)
+	return rejection.Attempts
+}
+
+// clearRejection removes the rejection of the objects of a controller from the status of a cluster.
+func clearRejection(status *rancherv1.ClusterStatus, controller string) {
+	var rejections []rancherv1.AdmissionRejectionStatus
+	for _, rejection := range status.AdmissionRejections {
+		if rejection.Controller != controller {
+			rejections = append(rejections, rejection)
+		}
+	}
+	status.AdmissionRejections = rejections
+}
+
+// retryLimitReached returns whether the objects of a controller are no longer retried for the current generation of a
+// cluster.
+func retryLimitReached(cluster *rancherv1.Cluster, controller string, limit int) bool {
+	if limit <= 0 {
+		return false
+	}
+	for _, rejection := range cluster.Status.AdmissionRejections {
+		if rejection.Controller == controller {
+			return rejection.ObservedGeneration == cluster.Generation && rejection.Attempts >= limit
+		}
+	}
+	return false
+}
+
+// setRejectedCondition sets the AdmissionRejected condition of a cluster from its rejections, with the messages of the
+// rejections verbatim.
+func setRejectedCondition(cluster *rancherv1.Cluster, status *rancherv1.ClusterStatus, limit int) {
+	if len(status.AdmissionRejections) == 0 {
+		if capr.AdmissionRejected.GetStatus(status) != "" {
+			capr.AdmissionRejected.False(status)
+			capr.AdmissionRejected.Reason(status, "")
+			capr.AdmissionRejected.Message(status, "")
+		}
+		return
+	}
+
+	reason := RejectedReason
+	var messages []string
+	for _, rejection := range status.AdmissionRejections {
+		messages = append(messages, rejection.Message)
+		if limit > 0 && rejection.ObservedGeneration == cluster.Generation && rejection.Attempts >= limit {
+			reason = RetryLimitReachedReason
+		}
+	}
+	capr.AdmissionRejected.True(status)
+	capr.AdmissionRejected.Reason(status, reason)
+	capr.AdmissionRejected.Message(status, strings.Join(messages, "; "))
+}
+
+// backoff returns how long to wait before applying objects rejected a number of times again.
+func backoff(attempts int) time.Duration {
+	delay := initialBackoff
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		return maxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,111 @@
+package admission
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRejectedBy(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantWebhook string
+		wantOK      bool
+	}{
+		{
+			name: "no error",
+		},
+		{
+			name:        "webhook",
+			err:         errors.New(`admission webhook "rancher.cattle.io.clusters.management.cattle.io" denied the request: cluster name is reserved`),
+			wantWebhook: "rancher.cattle.io.clusters.management.cattle.io",
+			wantOK:      true,
+		},
+		{
+			name:        "aggregated apply error",
+			err:         errors.New(`failed to create fleet-default/prod cluster.x-k8s.io/v1beta1, Kind=Cluster: admission webhook "validation.gatekeeper.sh" denied the request: [required-labels] missing label team`),
+			wantWebhook: "validation.gatekeeper.sh",
+			wantOK:      true,
+		},
+		{
+			name:        "validating admission policy",
+			err:         errors.New(`clusters.cluster.x-k8s.io "prod" is forbidden: ValidatingAdmissionPolicy 'require-team' with binding 'require-team-binding' denied request: missing team`),
+			wantWebhook: "require-team",
+			wantOK:      true,
+		},
+		{
+			name: "other error",
+			err:  errors.New(`clusters.cluster.x-k8s.io "prod" is forbidden: User "system:serviceaccount:cattle-system:rancher" cannot create resource "clusters"`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhook, ok := rejectedBy(tt.err)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantWebhook, webhook)
+		})
+	}
+}
+
+func TestRecordRejection(t *testing.T) {
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	cluster := &rancherv1.Cluster{ObjectMeta: metav1.ObjectMeta{Generation: 2}}
+	status := &rancherv1.ClusterStatus{}
+	err := errors.New(`admission webhook "policy.example.com" denied the request: denied`)
+
+	assert.Equal(t, 1, recordRejection(cluster, status, "rke-cluster", "policy.example.com", err, now))
+	assert.Equal(t, 2, recordRejection(cluster, status, "rke-cluster", "policy.example.com", err, now))
+	assert.Equal(t, 1, recordRejection(cluster, status, "cluster-create", "policy.example.com", err, now))
+	assert.Equal(t, []rancherv1.AdmissionRejectionStatus{
+		{
+			Controller:         "rke-cluster",
+			Webhook:            "policy.example.com",
+			Message:            err.Error(),
+			Attempts:           2,
+			ObservedGeneration: 2,
+			LastRejectionTime:  metav1.NewTime(now),
+		},
+		{
+			Controller:         "cluster-create",
+			Webhook:            "policy.example.com",
+			Message:            err.Error(),
+			Attempts:           1,
+			ObservedGeneration: 2,
+			LastRejectionTime:  metav1.NewTime(now),
+		},
+	}, status.AdmissionRejections)
+
+	assert.False(t, retryLimitReached(&rancherv1.Cluster{ObjectMeta: cluster.ObjectMeta, Status: *status}, "rke-cluster", 3))
+	assert.True(t, retryLimitReached(&rancherv1.Cluster{ObjectMeta: cluster.ObjectMeta, Status: *status}, "rke-cluster", 2))
+	assert.False(t, retryLimitReached(&rancherv1.Cluster{ObjectMeta: cluster.ObjectMeta, Status: *status}, "rke-cluster", 0), "no limit")
+
+	setRejectedCondition(cluster, status, 2)
+	assert.True(t, capr.AdmissionRejected.IsTrue(status))
+	assert.Equal(t, RetryLimitReachedReason, capr.AdmissionRejected.GetReason(status))
+	assert.Equal(t, err.Error()+"; "+err.Error(), capr.AdmissionRejected.GetMessage(status))
+
+	cluster.Generation = 3
+	assert.False(t, retryLimitReached(&rancherv1.Cluster{ObjectMeta: cluster.ObjectMeta, Status: *status}, "rke-cluster", 2), "the cluster changed")
+	assert.Equal(t, 1, recordRejection(cluster, status, "rke-cluster", "policy.example.com", err, now), "attempts restart when the cluster changes")
+
+	clearRejection(status, "rke-cluster")
+	clearRejection(status, "cluster-create")
+	assert.Empty(t, status.AdmissionRejections)
+	setRejectedCondition(cluster, status, 2)
+	assert.True(t, capr.AdmissionRejected.IsFalse(status))
+	assert.Empty(t, capr.AdmissionRejected.GetMessage(status))
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 15*time.Second, backoff(1))
+	assert.Equal(t, 30*time.Second, backoff(2))
+	assert.Equal(t, 2*time.Minute, backoff(4))
+	assert.Equal(t, 10*time.Minute, backoff(7))
+	assert.Equal(t, 10*time.Minute, backoff(100))
+}
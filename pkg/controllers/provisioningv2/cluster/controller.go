@@ -6,8 +6,10 @@
 	"strconv"
 
 	"github.com/rancher/norman/types/convert"
+	"github.com/rancher/norman/types/slice"
 	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
 	v1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/admission"
 	"github.com/rancher/rancher/pkg/features"
 	fleetconst "github.com/rancher/rancher/pkg/fleet"
 	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
@@ -114,7 +116,7 @@ func Register(
 
 	// Register a generating handler in order to generate clusters.management.cattle.io/v3 objects based on
 	// clusters.provisioning.cattle.io/v1 objects.
-	rocontrollers.RegisterClusterGeneratingHandler(ctx,
+	admission.RegisterClusterGeneratingHandler(ctx,
 		clients.Provisioning.Cluster(),
 		clusterCreateApply,
 		"Created",
@@ -145,11 +147,21 @@ func byClusterIndex(obj *v1.Cluster) ([]string, error) {
 	return []string{obj.Status.ClusterName}, nil
 }
 
+// byCloudCredentialIndex indexes clusters by the cloud credentials of the cluster and of its machine pools.
 func byCloudCredentialIndex(obj *v1.Cluster) ([]string, error) {
-	if obj.Spec.CloudCredentialSecretName == "" {
-		return nil, nil
+	var result []string
+	if obj.Spec.CloudCredentialSecretName != "" {
+		result = append(result, obj.Spec.CloudCredentialSecretName)
+	}
+	if obj.Spec.RKEConfig == nil {
+		return result, nil
+	}
+	for _, pool := range obj.Spec.RKEConfig.MachinePools {
+		if pool.CloudCredentialSecretName != "" && !slice.ContainsString(result, pool.CloudCredentialSecretName) {
+			result = append(result, pool.CloudCredentialSecretName)
+		}
 	}
-	return []string{obj.Spec.CloudCredentialSecretName}, nil
+	return result, nil
 }
 
 func (h *handler) clusterWatch(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
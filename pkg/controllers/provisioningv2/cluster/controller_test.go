@@ -7,6 +7,7 @@
 
 	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
 	v1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
 	mgmtv3 "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
@@ -268,3 +269,25 @@ func (f *mockClusterCache) AddIndexer(indexName string, indexer mgmtv3.ClusterIn
 func (f *mockClusterCache) GetByIndex(indexName, key string) ([]*v3.Cluster, error) {
 	return nil, fmt.Errorf("unimplemented")
 }
+
+func TestByCloudCredentialIndex(t *testing.T) {
+	keys, err := byCloudCredentialIndex(&v1.Cluster{})
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+
+	cluster := &v1.Cluster{
+		Spec: v1.ClusterSpec{
+			CloudCredentialSecretName: "cattle-global-data:cc-1",
+			RKEConfig: &v1.RKEConfig{
+				MachinePools: []v1.RKEMachinePool{
+					{Name: "default"},
+					{Name: "other-account", RKECommonNodeConfig: rkev1.RKECommonNodeConfig{CloudCredentialSecretName: "cattle-global-data:cc-2"}},
+					{Name: "same-account", RKECommonNodeConfig: rkev1.RKECommonNodeConfig{CloudCredentialSecretName: "cattle-global-data:cc-1"}},
+				},
+			},
+		},
+	}
+	keys, err = byCloudCredentialIndex(cluster)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cattle-global-data:cc-1", "cattle-global-data:cc-2"}, keys)
+}
@@ -0,0 +1,155 @@
+package clusterbundle
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/clusterbundle"
+	"github.com/rancher/rancher/pkg/controllers/management/rbac"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/wrangler"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var httpClient = &http.Client{
+	Timeout: time.Minute,
+}
+
+type handler struct {
+	ctx         context.Context
+	secretCache corecontrollers.SecretCache
+
+	lock sync.Mutex
+	// artifacts are the artifacts last pulled for each cluster bundle, by <namespace>/<name>.
+	artifacts map[string]*clusterbundle.Artifact
+}
+
+// Register registers the cluster-bundle controller, which pulls the artifacts of cluster bundles from their registry,
+// verifies they're signed by one of the keys of the cluster-bundle-signing-keys setting, and instantiates their
+// cluster, machine configs and policy bundles.
+func Register(ctx context.Context, clients *wrangler.Context) {
+	h := &handler{
+		ctx:         ctx,
+		secretCache: clients.Core.Secret().Cache(),
+		artifacts:   map[string]*clusterbundle.Artifact{},
+	}
+
+	mgmtcontrollers.RegisterClusterBundleGeneratingHandler(ctx,
+		clients.Mgmt.ClusterBundle(),
+		clients.Apply.
+			WithSetOwnerReference(true, false).
+			WithDynamicLookup().
+			WithCacheTypes(
+				clients.Provisioning.Cluster(),
+				clients.Mgmt.PolicyBundle()),
+		"Ready",
+		"cluster-bundle",
+		h.OnChange,
+		nil)
+	clients.Mgmt.ClusterBundle().OnChange(ctx, "cluster-bundle-artifacts", h.onArtifactsChange)
+}
+
+// onArtifactsChange forgets the artifact of deleted cluster bundles.
+func (h *handler) onArtifactsChange(key string, obj *v3.ClusterBundle) (*v3.ClusterBundle, error) {
+	if obj == nil {
+		h.lock.Lock()
+		delete(h.artifacts, key)
+		h.lock.Unlock()
+	}
+	return obj, nil
+}
+
+func (h *handler) OnChange(obj *v3.ClusterBundle, status v3.ClusterBundleStatus) ([]runtime.Object, v3.ClusterBundleStatus, error) {
+	ref, err := clusterbundle.ParseReference(obj.Spec.Reference)
+	if err != nil {
+		return nil, status, err
+	}
+	digest := status.Digest
+	if status.Reference != obj.Spec.Reference {
+		digest = ""
+	}
+	artifact, err := h.artifact(obj, ref, digest)
+	if err != nil {
+		return nil, status, err
+	}
+
+	keys, err := clusterbundle.ParsePublicKeys(settings.ClusterBundleSigningKeys.Get())
+	if err != nil {
+		return nil, status, fmt.Errorf("invalid %s setting: %w", settings.ClusterBundleSigningKeys.Name, err)
+	}
+	if err := artifact.Verify(keys); err != nil {
+		return nil, status, fmt.Errorf("failed to verify %s: %w", ref, err)
+	}
+	bundle, err := clusterbundle.Parse(artifact.Content)
+	if err != nil {
+		return nil, status, err
+	}
+
+	clusterName := obj.Spec.ClusterName
+	if clusterName == "" {
+		clusterName = obj.Name
+	}
+	objs, err := bundle.Objects(obj.Namespace, clusterName, obj.Name, obj.Spec.CloudCredentialSecretName)
+	if err != nil {
+		return nil, status, err
+	}
+	// The cluster is created on behalf of the creator of the cluster bundle.
+	if creatorID := obj.Annotations[rbac.CreatorIDAnn]; creatorID != "" {
+		for _, o := range objs {
+			if cluster, ok := o.(*provv1.Cluster); ok {
+				cluster.Annotations = map[string]string{
+					rbac.CreatorIDAnn: creatorID,
+				}
+			}
+		}
+	}
+
+	status.ObservedGeneration = obj.Generation
+	status.Reference = obj.Spec.Reference
+	status.Digest = artifact.Digest
+	status.Version = artifact.Version
+	status.ClusterName = clusterName
+	return objs, status, nil
+}
+
+// artifact returns the artifact of the cluster bundle, pulling it unless it's the artifact of the digest last pulled.
+// The digest is pulled rather than the tag of the reference, so that the cluster only changes when the reference does.
+func (h *handler) artifact(obj *v3.ClusterBundle, ref clusterbundle.Reference, digest string) (*clusterbundle.Artifact, error) {
+	key := obj.Namespace + "/" + obj.Name
+	h.lock.Lock()
+	artifact := h.artifacts[key]
+	h.lock.Unlock()
+	if artifact != nil && digest != "" && artifact.Digest == digest {
+		return artifact, nil
+	}
+	if digest != "" {
+		ref.Digest = digest
+	}
+
+	var credentials *clusterbundle.Credentials
+	if name := obj.Spec.RegistryCredentialSecretName; name != "" {
+		secret, err := h.secretCache.Get(obj.Namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		if credentials, err = clusterbundle.CredentialsFromSecret(secret, ref.Registry); err != nil {
+			return nil, err
+		}
+	}
+
+	artifact, err := clusterbundle.Pull(h.ctx, httpClient, ref, credentials)
+	if err != nil {
+		return nil, err
+	}
+	h.lock.Lock()
+	h.artifacts[key] = artifact
+	h.lock.Unlock()
+	return artifact, nil
+}
@@ -0,0 +1,168 @@
+// Package conditionhistory records every transition of the conditions of provisioning clusters and of their machines
+// into a bounded history per cluster, stored in a ConfigMap next to the cluster.
+package conditionhistory
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
+	rocontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/wrangler"
+	corev1controllers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+type handler struct {
+	configMapCache   corev1controllers.ConfigMapCache
+	configMaps       corev1controllers.ConfigMapClient
+	clusterCache     rocontrollers.ClusterCache
+	capiMachineCache capicontrollers.MachineCache
+}
+
+func Register(ctx context.Context, clients *wrangler.Context) {
+	h := &handler{
+		configMapCache:   clients.Core.ConfigMap().Cache(),
+		configMaps:       clients.Core.ConfigMap(),
+		clusterCache:     clients.Provisioning.Cluster().Cache(),
+		capiMachineCache: clients.CAPI.Machine().Cache(),
+	}
+
+	clients.Provisioning.Cluster().OnChange(ctx, "condition-history-cluster", h.OnCluster)
+	clients.CAPI.Machine().OnChange(ctx, "condition-history-machine", h.OnMachine)
+}
+
+func (h *handler) OnCluster(_ string, cluster *rancherv1.Cluster) (*rancherv1.Cluster, error) {
+	if cluster == nil || cluster.DeletionTimestamp != nil {
+		return cluster, nil
+	}
+
+	conditions := map[string]condition{}
+	for _, cond := range cluster.Status.Conditions {
+		conditions[cond.Type] = condition{
+			Status:  string(cond.Status),
+			Reason:  cond.Reason,
+			Message: cond.Message,
+		}
+	}
+
+	machines, err := h.capiMachineCache.List(cluster.Namespace, labels.SelectorFromSet(labels.Set{
+		capi.ClusterLabelName: cluster.Name,
+	}))
+	if err != nil {
+		return cluster, err
+	}
+	machineNames := map[string]bool{}
+	for _, machine := range machines {
+		machineNames[machine.Name] = true
+	}
+
+	return cluster, h.record(cluster, func(last map[string]condition, now time.Time) ([]Entry, bool) {
+		// Forget the machines that were deleted, their transitions stay in the history.
+		forgotten := forget(last, KindMachine, func(name string) bool {
+			return machineNames[name]
+		})
+		return transitions(last, KindCluster, cluster.Name, "", conditions, now), forgotten
+	})
+}
+
+func (h *handler) OnMachine(_ string, machine *capi.Machine) (*capi.Machine, error) {
+	if machine == nil {
+		return nil, nil
+	}
+	clusterName := machine.Labels[capi.ClusterLabelName]
+	if clusterName == "" {
+		return machine, nil
+	}
+	cluster, err := h.clusterCache.Get(machine.Namespace, clusterName)
+	if apierrors.IsNotFound(err) {
+		return machine, nil
+	} else if err != nil {
+		return machine, err
+	}
+	if cluster.DeletionTimestamp != nil {
+		return machine, nil
+	}
+
+	conditions := map[string]condition{}
+	for _, cond := range machine.Status.Conditions {
+		conditions[string(cond.Type)] = condition{
+			Status:  string(cond.Status),
+			Reason:  cond.Reason,
+			Message: cond.Message,
+		}
+	}
+	var nodeName string
+	if machine.Status.NodeRef != nil {
+		nodeName = machine.Status.NodeRef.Name
+	}
+
+	return machine, h.record(cluster, func(last map[string]condition, now time.Time) ([]Entry, bool) {
+		return transitions(last, KindMachine, machine.Name, nodeName, conditions, now), false
+	})
+}
+
+// record appends the transitions returned by update to the condition history of a cluster. update is passed the last
+// seen conditions of the cluster and its machines to update, and returns the transitions and whether it changed the
+// last seen conditions otherwise.
+func (h *handler) record(cluster *rancherv1.Cluster, update func(last map[string]condition, now time.Time) ([]Entry, bool)) error {
+	cm, err := h.configMapCache.Get(cluster.Namespace, ConfigMapName(cluster.Name))
+	if apierrors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ConfigMapName(cluster.Name),
+				Namespace: cluster.Namespace,
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: rancherv1.SchemeGroupVersion.String(),
+					Kind:       "Cluster",
+					Name:       cluster.Name,
+					UID:        cluster.UID,
+				}},
+			},
+		}
+	} else if err != nil {
+		return err
+	}
+
+	history, err := History(cm)
+	if err != nil {
+		return err
+	}
+	last, err := lastConditions(cm)
+	if err != nil {
+		return err
+	}
+
+	entries, changed := update(last, time.Now())
+	if len(entries) == 0 && !changed {
+		return nil
+	}
+
+	historyData, err := json.Marshal(appendEntries(history, entries))
+	if err != nil {
+		return err
+	}
+	lastData, err := json.Marshal(last)
+	if err != nil {
+		return err
+	}
+
+	cm = cm.DeepCopy()
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[historyKey] = string(historyData)
+	cm.Data[conditionsKey] = string(lastData)
+	if cm.ResourceVersion == "" {
+		_, err = h.configMaps.Create(cm)
+	} else {
+		_, err = h.configMaps.Update(cm)
+	}
+	return err
+}
@@ -0,0 +1,128 @@
+package conditionhistory
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// KindCluster and KindMachine are the kinds of the objects condition transitions are recorded for.
+	KindCluster = "Cluster"
+	KindMachine = "Machine"
+
+	historyKey    = "history"
+	conditionsKey = "conditions"
+	// maxEntries bounds the history of a cluster, the oldest transitions are dropped first.
+	maxEntries = 1000
+)
+
+// Entry is a transition of a condition of a cluster or of one of its machines.
+type Entry struct {
+	Time     string `json:"time"`
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	NodeName string `json:"nodeName,omitempty"`
+	Type     string `json:"type"`
+	Status   string `json:"status"`
+	Reason   string `json:"reason,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
+
+// condition is the state of a condition compared to find transitions.
+type condition struct {
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// ConfigMapName returns the name of the ConfigMap the condition history of a cluster is stored in, in the namespace
+// of the cluster.
+func ConfigMapName(clusterName string) string {
+	return clusterName + "-condition-history"
+}
+
+// History returns the condition transitions stored in a condition history ConfigMap, oldest first.
+func History(cm *corev1.ConfigMap) ([]Entry, error) {
+	var entries []Entry
+	if cm == nil || cm.Data[historyKey] == "" {
+		return entries, nil
+	}
+	if err := json.Unmarshal([]byte(cm.Data[historyKey]), &entries); err != nil {
+		return nil, fmt.Errorf("decoding condition history %s/%s: %w", cm.Namespace, cm.Name, err)
+	}
+	return entries, nil
+}
+
+func lastConditions(cm *corev1.ConfigMap) (map[string]condition, error) {
+	last := map[string]condition{}
+	if cm == nil || cm.Data[conditionsKey] == "" {
+		return last, nil
+	}
+	if err := json.Unmarshal([]byte(cm.Data[conditionsKey]), &last); err != nil {
+		return nil, fmt.Errorf("decoding conditions of %s/%s: %w", cm.Namespace, cm.Name, err)
+	}
+	return last, nil
+}
+
+func conditionKey(kind, name, conditionType string) string {
+	return kind + "/" + name + "/" + conditionType
+}
+
+// transitions returns the entries for the conditions of an object that changed since they were last seen, and
+// updates last to the current conditions. Conditions are keyed by type, entries are returned sorted by type.
+func transitions(last map[string]condition, kind, name, nodeName string, conditions map[string]condition, now time.Time) []Entry {
+	var types []string
+	for conditionType := range conditions {
+		types = append(types, conditionType)
+	}
+	sort.Strings(types)
+
+	var entries []Entry
+	for _, conditionType := range types {
+		key := conditionKey(kind, name, conditionType)
+		current := conditions[conditionType]
+		if previous, ok := last[key]; ok && previous == current {
+			continue
+		}
+		last[key] = current
+		entries = append(entries, Entry{
+			Time:     now.UTC().Format(time.RFC3339),
+			Kind:     kind,
+			Name:     name,
+			NodeName: nodeName,
+			Type:     conditionType,
+			Status:   current.Status,
+			Reason:   current.Reason,
+			Message:  current.Message,
+		})
+	}
+	return entries
+}
+
+// forget removes the last seen conditions of the objects of a kind that don't exist anymore, and returns whether any
+// were removed.
+func forget(last map[string]condition, kind string, exists func(name string) bool) bool {
+	changed := false
+	for key := range last {
+		parts := strings.SplitN(key, "/", 3)
+		if len(parts) == 3 && parts[0] == kind && !exists(parts[1]) {
+			delete(last, key)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// appendEntries appends entries to a history, dropping the oldest entries beyond maxEntries.
+func appendEntries(history, entries []Entry) []Entry {
+	history = append(history, entries...)
+	if len(history) > maxEntries {
+		history = history[len(history)-maxEntries:]
+	}
+	return history
+}
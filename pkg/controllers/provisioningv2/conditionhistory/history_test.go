@@ -0,0 +1,86 @@
+package conditionhistory
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestTransitions(t *testing.T) {
+	now := time.Date(2023, 5, 7, 3, 0, 0, 0, time.UTC)
+	last := map[string]condition{}
+
+	entries := transitions(last, KindCluster, "prod", "", map[string]condition{
+		"Updated": {Status: "Unknown", Message: "waiting for cluster agent to connect"},
+		"Ready":   {Status: "False", Reason: "Waiting"},
+	}, now)
+	require.Len(t, entries, 2, "conditions seen for the first time")
+	assert.Equal(t, Entry{Time: "2023-05-07T03:00:00Z", Kind: KindCluster, Name: "prod", Type: "Ready", Status: "False", Reason: "Waiting"}, entries[0])
+	assert.Equal(t, "Updated", entries[1].Type)
+
+	entries = transitions(last, KindCluster, "prod", "", map[string]condition{
+		"Updated": {Status: "Unknown", Message: "waiting for cluster agent to connect"},
+		"Ready":   {Status: "False", Reason: "Waiting"},
+	}, now.Add(time.Minute))
+	assert.Empty(t, entries, "unchanged conditions")
+
+	entries = transitions(last, KindCluster, "prod", "", map[string]condition{
+		"Updated": {Status: "Unknown", Message: "waiting for etcd to be restored"},
+		"Ready":   {Status: "True"},
+	}, now.Add(2*time.Minute))
+	require.Len(t, entries, 2, "status and message changes")
+	assert.Equal(t, "True", entries[0].Status)
+	assert.Equal(t, "waiting for etcd to be restored", entries[1].Message)
+
+	entries = transitions(last, KindMachine, "prod-cp-1", "node-1", map[string]condition{
+		"Ready": {Status: "True"},
+	}, now)
+	require.Len(t, entries, 1, "conditions are tracked per object")
+	assert.Equal(t, "node-1", entries[0].NodeName)
+}
+
+func TestForget(t *testing.T) {
+	last := map[string]condition{
+		conditionKey(KindCluster, "prod", "Ready"):      {Status: "True"},
+		conditionKey(KindMachine, "prod-cp-1", "Ready"): {Status: "True"},
+		conditionKey(KindMachine, "prod-cp-2", "Ready"): {Status: "True"},
+	}
+	exists := func(name string) bool {
+		return name == "prod-cp-1"
+	}
+
+	assert.True(t, forget(last, KindMachine, exists))
+	assert.Len(t, last, 2)
+	assert.Contains(t, last, conditionKey(KindCluster, "prod", "Ready"))
+	assert.Contains(t, last, conditionKey(KindMachine, "prod-cp-1", "Ready"))
+	assert.False(t, forget(last, KindMachine, exists))
+}
+
+func TestAppendEntries(t *testing.T) {
+	var history []Entry
+	for i := 0; i < maxEntries+10; i++ {
+		history = appendEntries(history, []Entry{{Message: fmt.Sprint(i)}})
+	}
+	assert.Len(t, history, maxEntries)
+	assert.Equal(t, "10", history[0].Message)
+	assert.Equal(t, fmt.Sprint(maxEntries+9), history[maxEntries-1].Message)
+}
+
+func TestHistory(t *testing.T) {
+	entries, err := History(nil)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	entries, err = History(&corev1.ConfigMap{Data: map[string]string{
+		historyKey: `[{"time":"2023-05-07T03:00:00Z","kind":"Cluster","name":"prod","type":"Ready","status":"True"}]`,
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, []Entry{{Time: "2023-05-07T03:00:00Z", Kind: KindCluster, Name: "prod", Type: "Ready", Status: "True"}}, entries)
+
+	_, err = History(&corev1.ConfigMap{Data: map[string]string{historyKey: "{"}})
+	assert.Error(t, err)
+}
@@ -4,12 +4,27 @@
 	"context"
 
 	"github.com/rancher/rancher/pkg/controllers/provisioningv2/cluster"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/clusterbundle"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/conditionhistory"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/controlplaneresize"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/elemental"
 	"github.com/rancher/rancher/pkg/controllers/provisioningv2/fleetcluster"
 	"github.com/rancher/rancher/pkg/controllers/provisioningv2/fleetworkspace"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/kubeconfigdistribution"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/machineadoption"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/machineimage"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/machinepoolcredential"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/machinepoolmigration"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/machinereplace"
 	"github.com/rancher/rancher/pkg/controllers/provisioningv2/managedchart"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/orphanedresources"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/policybundle"
 	"github.com/rancher/rancher/pkg/controllers/provisioningv2/provisioningcluster"
 	"github.com/rancher/rancher/pkg/controllers/provisioningv2/provisioninglog"
 	"github.com/rancher/rancher/pkg/controllers/provisioningv2/secret"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/upgraderollback"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/upgradescan"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/workloadplacement"
 	"github.com/rancher/rancher/pkg/features"
 	"github.com/rancher/rancher/pkg/provisioningv2/kubeconfig"
 	"github.com/rancher/rancher/pkg/wrangler"
@@ -17,12 +32,30 @@
 
 func Register(ctx context.Context, clients *wrangler.Context, kubeconfigManager *kubeconfig.Manager) {
 	cluster.Register(ctx, clients, kubeconfigManager)
+	kubeconfigdistribution.Register(ctx, clients, kubeconfigManager)
 	secret.Register(ctx, clients)
 	provisioningcluster.Register(ctx, clients)
+	elemental.Register(ctx, clients)
+	machinepoolcredential.Register(ctx, clients)
+	machineimage.Register(ctx, clients)
+	machinereplace.Register(ctx, clients)
+	machinepoolmigration.Register(ctx, clients)
+	machineadoption.Register(ctx, clients)
+	upgradescan.Register(ctx, clients)
+	upgraderollback.Register(ctx, clients)
+	orphanedresources.Register(ctx, clients)
 	provisioninglog.Register(ctx, clients)
+	conditionhistory.Register(ctx, clients)
+
+	if features.MCM.Enabled() {
+		controlplaneresize.Register(ctx, clients)
+	}
 
 	if features.Fleet.Enabled() {
 		managedchart.Register(ctx, clients)
+		policybundle.Register(ctx, clients)
+		clusterbundle.Register(ctx, clients)
+		workloadplacement.Register(ctx, clients)
 		fleetcluster.Register(ctx, clients)
 		fleetworkspace.Register(ctx, clients)
 	}
@@ -0,0 +1,158 @@
+package controlplaneresize
+
+import (
+	"context"
+	"time"
+
+	"github.com/rancher/lasso/pkg/dynamic"
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/rancher/pkg/controlplaneadvisor"
+	rocontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	rkecontroller "github.com/rancher/rancher/pkg/generated/controllers/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/wrangler"
+	"github.com/rancher/wrangler/pkg/data"
+	"github.com/robfig/cron"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	defaultMaintenanceWindowDuration = time.Hour
+	// recheckInterval is how often recommendations are checked during maintenance windows.
+	recheckInterval = 10 * time.Minute
+	// resizedAtAnnotation is set on machine configs resized by rancher, with the time they were resized at. Machine
+	// configs aren't resized again until the usage of the control plane is sampled from the resized machines.
+	resizedAtAnnotation = "provisioning.cattle.io/control-plane-resized-at"
+)
+
+type handler struct {
+	clusterController    rocontrollers.ClusterController
+	rkeControlPlaneCache rkecontroller.RKEControlPlaneCache
+	advisor              *controlplaneadvisor.Advisor
+	dynamic              *dynamic.Controller
+}
+
+func Register(ctx context.Context, clients *wrangler.Context) {
+	h := &handler{
+		clusterController:    clients.Provisioning.Cluster(),
+		rkeControlPlaneCache: clients.RKE.RKEControlPlane().Cache(),
+		advisor:              controlplaneadvisor.NewAdvisor(clients),
+		dynamic:              clients.Dynamic,
+	}
+	clients.Provisioning.Cluster().OnChange(ctx, "control-plane-auto-resize", h.OnChange)
+}
+
+// OnChange resizes the control plane machine pools of clusters with automatic resize enabled, as recommended by the
+// control plane advisor, during their maintenance windows.
+func (h *handler) OnChange(_ string, cluster *rancherv1.Cluster) (*rancherv1.Cluster, error) {
+	if cluster == nil || cluster.DeletionTimestamp != nil || cluster.Spec.RKEConfig == nil || cluster.Spec.RKEConfig.ControlPlaneAutoResize == nil {
+		return cluster, nil
+	}
+	autoResize := cluster.Spec.RKEConfig.ControlPlaneAutoResize
+
+	now := time.Now()
+	inWindow, next, err := maintenanceWindow(autoResize, now)
+	if err != nil {
+		return cluster, err
+	}
+	if !inWindow {
+		h.clusterController.EnqueueAfter(cluster.Namespace, cluster.Name, next.Sub(now))
+		return cluster, nil
+	}
+	h.clusterController.EnqueueAfter(cluster.Namespace, cluster.Name, recheckInterval)
+
+	controlPlane, err := h.rkeControlPlaneCache.Get(cluster.Namespace, cluster.Name)
+	if apierrors.IsNotFound(err) {
+		return cluster, nil
+	} else if err != nil {
+		return cluster, err
+	}
+	// Resizing rolls the machines of the machine pool, it waits for the planner to complete any other change.
+	if !capr.Reconciled.IsTrue(controlPlane) {
+		return cluster, nil
+	}
+
+	advice, err := h.advisor.Advise(cluster)
+	if err != nil {
+		return cluster, err
+	}
+	for _, rec := range advice.Recommendations {
+		if !shouldResize(rec, autoResize) {
+			continue
+		}
+		if err := h.resize(cluster, rec, advice.CollectedAt, now); err != nil {
+			return cluster, err
+		}
+	}
+	return cluster, nil
+}
+
+// resize sets the size of the machines of the machine config of a machine pool to the recommended size, unless the
+// machine config was resized after the usage the recommendation is made from was sampled.
+func (h *handler) resize(cluster *rancherv1.Cluster, rec controlplaneadvisor.Recommendation, collectedAt string, now time.Time) error {
+	gvk := schema.FromAPIVersionAndKind(capr.DefaultMachineConfigAPIVersion, rec.MachineConfigKind)
+	machineConfig, err := h.dynamic.Get(gvk, cluster.Namespace, rec.MachineConfigName)
+	if err != nil {
+		return err
+	}
+	d, err := data.Convert(machineConfig.DeepCopyObject())
+	if err != nil {
+		return err
+	}
+
+	if d.String(rec.SizeField) != rec.CurrentSize || resizedSince(d.String("metadata", "annotations", resizedAtAnnotation), collectedAt) {
+		return nil
+	}
+
+	logrus.Infof("[control-plane-auto-resize] resizing machine pool %s of cluster %s/%s from %s to %s: %v",
+		rec.MachinePool, cluster.Namespace, cluster.Name, rec.CurrentSize, rec.RecommendedSize, rec.Reasons)
+	d.Set(rec.SizeField, rec.RecommendedSize)
+	d.SetNested(now.UTC().Format(time.RFC3339), "metadata", "annotations", resizedAtAnnotation)
+	_, err = h.dynamic.Update(&unstructured.Unstructured{Object: d})
+	return err
+}
+
+// maintenanceWindow returns whether now is in a maintenance window, and when the maintenance window ends if it is or
+// when the next maintenance window starts if it isn't.
+func maintenanceWindow(autoResize *rancherv1.ControlPlaneAutoResize, now time.Time) (bool, time.Time, error) {
+	schedule, err := cron.ParseStandard(autoResize.MaintenanceWindow)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	duration := defaultMaintenanceWindowDuration
+	if autoResize.MaintenanceWindowDuration != nil && autoResize.MaintenanceWindowDuration.Duration > 0 {
+		duration = autoResize.MaintenanceWindowDuration.Duration
+	}
+
+	start := schedule.Next(now.Add(-duration))
+	if !start.After(now) {
+		return true, start.Add(duration), nil
+	}
+	return false, start, nil
+}
+
+func shouldResize(rec controlplaneadvisor.Recommendation, autoResize *rancherv1.ControlPlaneAutoResize) bool {
+	if rec.RecommendedSize == "" {
+		return false
+	}
+	return rec.Action == controlplaneadvisor.ActionScaleUp || (rec.Action == controlplaneadvisor.ActionScaleDown && autoResize.ScaleDown)
+}
+
+// resizedSince returns whether a machine config was resized after the usage of the control plane was sampled.
+func resizedSince(resizedAt, collectedAt string) bool {
+	if resizedAt == "" {
+		return false
+	}
+	resized, err := time.Parse(time.RFC3339, resizedAt)
+	if err != nil {
+		return false
+	}
+	collected, err := time.Parse(time.RFC3339, collectedAt)
+	if err != nil {
+		return true
+	}
+	return !collected.After(resized)
+}
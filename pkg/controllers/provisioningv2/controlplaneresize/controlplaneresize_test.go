@@ -0,0 +1,54 @@
+package controlplaneresize
+
+import (
+	"testing"
+	"time"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/controlplaneadvisor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMaintenanceWindow(t *testing.T) {
+	// Sundays at 2am for 2 hours.
+	autoResize := &rancherv1.ControlPlaneAutoResize{
+		MaintenanceWindow:         "0 2 * * 0",
+		MaintenanceWindowDuration: &metav1.Duration{Duration: 2 * time.Hour},
+	}
+	sunday := time.Date(2023, 5, 7, 0, 0, 0, 0, time.Local)
+
+	inWindow, next, err := maintenanceWindow(autoResize, sunday.Add(3*time.Hour))
+	require.NoError(t, err)
+	assert.True(t, inWindow)
+	assert.Equal(t, sunday.Add(4*time.Hour), next, "end of the window")
+
+	inWindow, next, err = maintenanceWindow(autoResize, sunday.Add(5*time.Hour))
+	require.NoError(t, err)
+	assert.False(t, inWindow)
+	assert.Equal(t, sunday.AddDate(0, 0, 7).Add(2*time.Hour), next, "start of the next window")
+
+	inWindow, _, err = maintenanceWindow(&rancherv1.ControlPlaneAutoResize{MaintenanceWindow: "0 2 * * 0"}, sunday.Add(3*time.Hour+30*time.Minute))
+	require.NoError(t, err)
+	assert.False(t, inWindow, "default window of 1 hour")
+
+	_, _, err = maintenanceWindow(&rancherv1.ControlPlaneAutoResize{MaintenanceWindow: "sundays"}, sunday)
+	assert.Error(t, err)
+}
+
+func TestShouldResize(t *testing.T) {
+	autoResize := &rancherv1.ControlPlaneAutoResize{}
+	assert.True(t, shouldResize(controlplaneadvisor.Recommendation{Action: controlplaneadvisor.ActionScaleUp, RecommendedSize: "m5.2xlarge"}, autoResize))
+	assert.False(t, shouldResize(controlplaneadvisor.Recommendation{Action: controlplaneadvisor.ActionScaleUp}, autoResize), "no known size")
+	assert.False(t, shouldResize(controlplaneadvisor.Recommendation{Action: controlplaneadvisor.ActionScaleDown, RecommendedSize: "m5.large"}, autoResize))
+	autoResize.ScaleDown = true
+	assert.True(t, shouldResize(controlplaneadvisor.Recommendation{Action: controlplaneadvisor.ActionScaleDown, RecommendedSize: "m5.large"}, autoResize))
+}
+
+func TestResizedSince(t *testing.T) {
+	assert.False(t, resizedSince("", "2023-05-07T03:00:00Z"))
+	assert.True(t, resizedSince("2023-05-07T03:00:00Z", "2023-05-07T02:55:00Z"), "usage sampled before the resize")
+	assert.False(t, resizedSince("2023-05-07T03:00:00Z", "2023-05-07T03:05:00Z"), "usage sampled after the resize")
+	assert.True(t, resizedSince("2023-05-07T03:00:00Z", ""), "no usage")
+}
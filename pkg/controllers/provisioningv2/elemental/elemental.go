@@ -0,0 +1,345 @@
+package elemental
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rancher/lasso/pkg/dynamic"
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
+	rocontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	rkecontroller "github.com/rancher/rancher/pkg/generated/controllers/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/wrangler"
+	"github.com/rancher/wrangler/pkg/data"
+	"github.com/rancher/wrangler/pkg/generic"
+	"github.com/rancher/wrangler/pkg/name"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	machineInventoryByPool = "elementalMachineInventoryByPool"
+)
+
+var (
+	elementalGroupVersion = schema.GroupVersion{Group: "elemental.cattle.io", Version: "v1beta1"}
+
+	machineRegistrationGVK      = elementalGroupVersion.WithKind("MachineRegistration")
+	seedImageGVK                = elementalGroupVersion.WithKind("SeedImage")
+	managedOSImageGVK           = elementalGroupVersion.WithKind("ManagedOSImage")
+	managedOSVersionGVK         = elementalGroupVersion.WithKind("ManagedOSVersion")
+	machineInventoryGVK         = elementalGroupVersion.WithKind("MachineInventory")
+	machineInventorySelectorGVK = elementalGroupVersion.WithKind("MachineInventorySelector")
+)
+
+type handler struct {
+	dynamic              *dynamic.Controller
+	clusterController    rocontrollers.ClusterController
+	rkeControlPlaneCache rkecontroller.RKEControlPlaneCache
+	capiMachineCache     capicontrollers.MachineCache
+}
+
+func Register(ctx context.Context, clients *wrangler.Context) {
+	h := &handler{
+		dynamic:              clients.Dynamic,
+		clusterController:    clients.Provisioning.Cluster(),
+		rkeControlPlaneCache: clients.RKE.RKEControlPlane().Cache(),
+		capiMachineCache:     clients.CAPI.Machine().Cache(),
+	}
+
+	clients.Dynamic.AddIndexer(machineInventoryByPool, isMachineInventory, indexMachineInventoryByPool)
+	clients.Dynamic.OnChange(ctx, "elemental-trigger", isElemental, h.onElementalChange)
+
+	rocontrollers.RegisterClusterGeneratingHandler(ctx,
+		clients.Provisioning.Cluster(),
+		clients.Apply.
+			WithDynamicLookup().
+			WithGVK(machineRegistrationGVK, seedImageGVK, managedOSImageGVK),
+		"",
+		"elemental",
+		h.OnChange,
+		nil)
+}
+
+func isElemental(gvk schema.GroupVersionKind) bool {
+	return gvk.GroupVersion() == elementalGroupVersion
+}
+
+func isMachineInventory(gvk schema.GroupVersionKind) bool {
+	return gvk == machineInventoryGVK
+}
+
+func indexMachineInventoryByPool(obj runtime.Object) ([]string, error) {
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	clusterName, poolName := objMeta.GetLabels()[capr.ClusterNameLabel], objMeta.GetLabels()[capr.RKEMachinePoolNameLabel]
+	if clusterName == "" || poolName == "" {
+		return nil, nil
+	}
+	return []string{poolKey(objMeta.GetNamespace(), clusterName, poolName)}, nil
+}
+
+func poolKey(namespace, clusterName, poolName string) string {
+	return fmt.Sprintf("%s/%s/%s", namespace, clusterName, poolName)
+}
+
+// onElementalChange enqueues the cluster of the Elemental objects generated for, or registered to, its machine pools.
+func (h *handler) onElementalChange(obj runtime.Object) (runtime.Object, error) {
+	if obj == nil {
+		return nil, nil
+	}
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	if clusterName := objMeta.GetLabels()[capr.ClusterNameLabel]; clusterName != "" {
+		h.clusterController.Enqueue(objMeta.GetNamespace(), clusterName)
+	}
+	return obj, nil
+}
+
+func (h *handler) OnChange(cluster *rancherv1.Cluster, status rancherv1.ClusterStatus) ([]runtime.Object, rancherv1.ClusterStatus, error) {
+	var pools []rancherv1.RKEMachinePool
+	if cluster.Spec.RKEConfig != nil && cluster.DeletionTimestamp == nil {
+		for _, pool := range cluster.Spec.RKEConfig.MachinePools {
+			if pool.OSImage != nil {
+				pools = append(pools, pool)
+			}
+		}
+	}
+	if len(pools) == 0 && len(status.MachinePoolOSImages) == 0 {
+		// Nothing was ever generated for the cluster, don't require the Elemental CRDs to be installed.
+		return nil, status, generic.ErrSkip
+	}
+
+	controlPlane, err := h.rkeControlPlaneCache.Get(cluster.Namespace, cluster.Name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, status, err
+	}
+
+	machineNames, nodeNames, err := h.poolMachines(cluster)
+	if err != nil {
+		return nil, status, err
+	}
+
+	var (
+		objs        []runtime.Object
+		poolsStatus []rancherv1.MachinePoolOSImageStatus
+	)
+	for _, pool := range pools {
+		poolStatus := rancherv1.MachinePoolOSImageStatus{
+			Name:    pool.Name,
+			Version: rolloutVersion(pool.OSImage, previousVersion(status, pool.Name), controlPlane),
+		}
+
+		if err := h.validateVersion(cluster.Namespace, pool.OSImage.Channel, poolStatus.Version); err != nil {
+			return nil, status, fmt.Errorf("machine pool %s: %w", pool.Name, err)
+		}
+
+		registration, err := machineRegistration(cluster, pool)
+		if err != nil {
+			return nil, status, err
+		}
+		objs = append(objs, registration)
+
+		if pool.OSImage.RegistrationImageVersion != "" {
+			seedImage, url, err := h.seedImage(cluster, pool)
+			if err != nil {
+				return nil, status, fmt.Errorf("machine pool %s: %w", pool.Name, err)
+			}
+			objs = append(objs, seedImage)
+			poolStatus.RegistrationImageURL = url
+		}
+
+		if poolStatus.Version != "" {
+			osImage, err := managedOSImage(cluster, pool, controlPlane, poolStatus.Version, nodeNames[pool.Name])
+			if err != nil {
+				return nil, status, fmt.Errorf("machine pool %s: %w", pool.Name, err)
+			}
+			if osImage != nil {
+				objs = append(objs, osImage)
+			}
+		}
+
+		poolStatus.Inventory, err = h.inventory(cluster, pool.Name, machineNames)
+		if err != nil {
+			return nil, status, err
+		}
+		poolsStatus = append(poolsStatus, poolStatus)
+	}
+
+	status.MachinePoolOSImages = poolsStatus
+	return objs, status, nil
+}
+
+// poolMachines returns the names of the CAPI machines provisioned on Elemental machine inventories, by the name of their
+// machine inventory, and the names of the nodes of the machines of each machine pool.
+func (h *handler) poolMachines(cluster *rancherv1.Cluster) (map[string]string, map[string][]string, error) {
+	machines, err := h.capiMachineCache.List(cluster.Namespace, labels.SelectorFromSet(labels.Set{
+		capi.ClusterLabelName: cluster.Name,
+	}))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	machineNames := map[string]string{}
+	nodeNames := map[string][]string{}
+	for _, machine := range machines {
+		if machine.Status.NodeRef != nil {
+			pool := machine.Labels[capr.RKEMachinePoolNameLabel]
+			nodeNames[pool] = append(nodeNames[pool], machine.Status.NodeRef.Name)
+		}
+
+		ref := machine.Spec.InfrastructureRef
+		if ref.APIVersion != elementalGroupVersion.String() || ref.Kind != machineInventorySelectorGVK.Kind {
+			continue
+		}
+		selector, err := h.dynamic.Get(machineInventorySelectorGVK, machine.Namespace, ref.Name)
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, nil, err
+		}
+		d, err := data.Convert(selector)
+		if err != nil {
+			return nil, nil, err
+		}
+		if inventory := d.String("status", "machineInventoryRef", "name"); inventory != "" {
+			machineNames[inventory] = machine.Name
+		}
+	}
+	return machineNames, nodeNames, nil
+}
+
+// validateVersion verifies that an OS version is published by the channel of its machine pool.
+func (h *handler) validateVersion(namespace, channel, version string) error {
+	if version == "" {
+		return nil
+	}
+	obj, err := h.dynamic.Get(managedOSVersionGVK, namespace, version)
+	if err != nil {
+		return err
+	}
+	objMeta, err := meta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	for _, owner := range objMeta.GetOwnerReferences() {
+		if owner.Kind == "ManagedOSVersionChannel" && owner.Name == channel {
+			return nil
+		}
+	}
+	return fmt.Errorf("OS version %s is not published by channel %s", version, channel)
+}
+
+// seedImage returns the SeedImage building the registration image of a machine pool, and the URL the image can be
+// downloaded from once built.
+func (h *handler) seedImage(cluster *rancherv1.Cluster, pool rancherv1.RKEMachinePool) (runtime.Object, string, error) {
+	if err := h.validateVersion(cluster.Namespace, pool.OSImage.Channel, pool.OSImage.RegistrationImageVersion); err != nil {
+		return nil, "", err
+	}
+	version, err := h.dynamic.Get(managedOSVersionGVK, cluster.Namespace, pool.OSImage.RegistrationImageVersion)
+	if err != nil {
+		return nil, "", err
+	}
+	versionData, err := data.Convert(version)
+	if err != nil {
+		return nil, "", err
+	}
+	if versionData.String("spec", "type") != "iso" {
+		return nil, "", fmt.Errorf("OS version %s is not an iso", pool.OSImage.RegistrationImageVersion)
+	}
+
+	objName := poolObjectName(cluster, pool.Name)
+	seedImage := newObject(seedImageGVK, cluster, pool.Name, map[string]interface{}{
+		"baseImage": versionData.String("spec", "metadata", "uri"),
+		"registrationRef": map[string]interface{}{
+			"name":      objName,
+			"namespace": cluster.Namespace,
+		},
+	})
+
+	var url string
+	existing, err := h.dynamic.Get(seedImageGVK, cluster.Namespace, objName)
+	if err == nil {
+		existingData, err := data.Convert(existing)
+		if err != nil {
+			return nil, "", err
+		}
+		url = existingData.String("status", "downloadURL")
+	} else if !apierrors.IsNotFound(err) {
+		return nil, "", err
+	}
+	return seedImage, url, nil
+}
+
+// inventory returns the hardware inventory of the machines registered to a machine pool.
+func (h *handler) inventory(cluster *rancherv1.Cluster, poolName string, machineNames map[string]string) ([]rancherv1.MachineInventoryStatus, error) {
+	objs, err := h.dynamic.GetByIndex(machineInventoryGVK, machineInventoryByPool, poolKey(cluster.Namespace, cluster.Name, poolName))
+	if err != nil {
+		return nil, err
+	}
+	var result []rancherv1.MachineInventoryStatus
+	for _, obj := range objs {
+		objMeta, err := meta.Accessor(obj)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, rancherv1.MachineInventoryStatus{
+			Name:        objMeta.GetName(),
+			MachineName: machineNames[objMeta.GetName()],
+			Hardware:    hardware(objMeta.GetLabels()),
+		})
+	}
+	sortInventory(result)
+	return result, nil
+}
+
+func previousVersion(status rancherv1.ClusterStatus, poolName string) string {
+	for _, pool := range status.MachinePoolOSImages {
+		if pool.Name == poolName {
+			return pool.Version
+		}
+	}
+	return ""
+}
+
+// rolloutVersion returns the OS version to roll out to the machines of a machine pool. A new version is only rolled out
+// once the planner has reconciled the control plane and no Kubernetes upgrade is in progress, so that OS and Kubernetes
+// upgrades don't drain the machines of the cluster at the same time.
+func rolloutVersion(osImage *rancherv1.RKEMachinePoolOSImage, current string, controlPlane *rkev1.RKEControlPlane) string {
+	if current == "" || current == osImage.Version {
+		return osImage.Version
+	}
+	if !capr.ControlPlaneSettled(controlPlane) {
+		return current
+	}
+	return osImage.Version
+}
+
+func poolObjectName(cluster *rancherv1.Cluster, poolName string) string {
+	return name.SafeConcatName(cluster.Name, poolName)
+}
+
+func newObject(gvk schema.GroupVersionKind, cluster *rancherv1.Cluster, poolName string, spec map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": spec,
+	}}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(poolObjectName(cluster, poolName))
+	obj.SetNamespace(cluster.Namespace)
+	obj.SetLabels(map[string]string{
+		capr.ClusterNameLabel:        cluster.Name,
+		capr.RKEMachinePoolNameLabel: poolName,
+	})
+	return obj
+}
@@ -0,0 +1,169 @@
+package elemental
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// hardwareLabelPrefix prefixes the labels of the machine inventories of a machine pool holding the hardware information
+// of their machine.
+const hardwareLabelPrefix = "hardware.rke.cattle.io/"
+
+// hardwareLabels are the hardware information reported by machines registering to a machine pool, as Elemental label
+// templates.
+var hardwareLabels = map[string]string{
+	"manufacturer":  "${System Information/Manufacturer}",
+	"product-name":  "${System Information/Product Name}",
+	"serial-number": "${System Information/Serial Number}",
+	"uuid":          "${System Information/UUID}",
+	"cpu-cores":     "${CPU/TotalCores}",
+	"memory-bytes":  "${Memory/TotalPhysicalBytes}",
+}
+
+// machineRegistration returns the MachineRegistration machines register to a machine pool with.
+func machineRegistration(cluster *rancherv1.Cluster, pool rancherv1.RKEMachinePool) (runtime.Object, error) {
+	inventoryLabels := map[string]interface{}{
+		capr.ClusterNameLabel:        cluster.Name,
+		capr.RKEMachinePoolNameLabel: pool.Name,
+	}
+	for key, template := range hardwareLabels {
+		inventoryLabels[hardwareLabelPrefix+key] = template
+	}
+
+	spec := map[string]interface{}{
+		"machineInventoryLabels": inventoryLabels,
+	}
+	if len(pool.OSImage.RegistrationConfig.Data) > 0 {
+		spec["config"] = pool.OSImage.RegistrationConfig.Data
+	}
+	return newObject(machineRegistrationGVK, cluster, pool.Name, spec), nil
+}
+
+// managedOSImage returns the ManagedOSImage upgrading the nodes of a machine pool to an OS version, drained and with the
+// concurrency of the upgrade strategy of the cluster. Nothing is returned if the machine pool has no nodes.
+func managedOSImage(cluster *rancherv1.Cluster, pool rancherv1.RKEMachinePool, controlPlane *rkev1.RKEControlPlane, version string, nodeNames []string) (runtime.Object, error) {
+	if len(nodeNames) == 0 {
+		return nil, nil
+	}
+
+	var strategy rkev1.ClusterUpgradeStrategy
+	if controlPlane != nil {
+		strategy = controlPlane.Spec.UpgradeStrategy
+	}
+	maxConcurrency, drainOptions := strategy.WorkerConcurrency, strategy.WorkerDrainOptions
+	if pool.ControlPlaneRole || pool.EtcdRole {
+		maxConcurrency, drainOptions = strategy.ControlPlaneConcurrency, strategy.ControlPlaneDrainOptions
+	}
+	upgradeConcurrency, err := concurrency(maxConcurrency, len(nodeNames))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(nodeNames)
+	values := make([]interface{}, 0, len(nodeNames))
+	for _, nodeName := range nodeNames {
+		values = append(values, nodeName)
+	}
+
+	spec := map[string]interface{}{
+		"managedOSVersionName": version,
+		"clusterTargets": []interface{}{
+			map[string]interface{}{
+				"clusterName": cluster.Name,
+			},
+		},
+		"nodeSelector": map[string]interface{}{
+			"matchExpressions": []interface{}{
+				map[string]interface{}{
+					"key":      "kubernetes.io/hostname",
+					"operator": "In",
+					"values":   values,
+				},
+			},
+		},
+		"concurrency": upgradeConcurrency,
+		"cordon":      true,
+	}
+	if drain := drainSpec(drainOptions); drain != nil {
+		spec["drain"] = drain
+	}
+	return newObject(managedOSImageGVK, cluster, pool.Name, spec), nil
+}
+
+// concurrency returns how many of the nodes of a machine pool can be upgraded at a time, from a concurrency of the
+// upgrade strategy of the cluster: a number, 0 being infinite, or a percentage. It defaults to 1.
+func concurrency(value string, nodes int) (int64, error) {
+	if value == "" {
+		return 1, nil
+	}
+	if num, err := strconv.Atoi(value); err == nil {
+		if num <= 0 {
+			return int64(nodes), nil
+		}
+		return int64(num), nil
+	}
+	percentage, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+	if err != nil {
+		return 0, fmt.Errorf("concurrency must be a number or a percentage: %w", err)
+	}
+	num := int64(math.Ceil(float64(nodes) * (percentage / float64(100))))
+	if num < 1 {
+		return 1, nil
+	}
+	return num, nil
+}
+
+// drainSpec returns the system-upgrade-controller drain spec matching drain options, nil if nodes aren't drained.
+func drainSpec(options rkev1.DrainOptions) map[string]interface{} {
+	if !options.Enabled {
+		return nil
+	}
+	drain := map[string]interface{}{
+		"force":           options.Force,
+		"deleteLocalData": options.DeleteEmptyDirData,
+		"disableEviction": options.DisableEviction,
+	}
+	if options.IgnoreDaemonSets == nil || *options.IgnoreDaemonSets {
+		drain["ignoreDaemonSets"] = true
+	}
+	if options.GracePeriod != 0 {
+		drain["gracePeriod"] = int64(options.GracePeriod)
+	}
+	if options.Timeout > 0 {
+		drain["timeout"] = int64(time.Duration(options.Timeout) * time.Second)
+	}
+	if options.SkipWaitForDeleteTimeoutSeconds > 0 {
+		drain["skipWaitForDeleteTimeout"] = int64(options.SkipWaitForDeleteTimeoutSeconds)
+	}
+	return drain
+}
+
+// hardware returns the hardware information of a machine, from the labels of its machine inventory.
+func hardware(inventoryLabels map[string]string) map[string]string {
+	var result map[string]string
+	for key, value := range inventoryLabels {
+		if !strings.HasPrefix(key, hardwareLabelPrefix) || value == "" {
+			continue
+		}
+		if result == nil {
+			result = map[string]string{}
+		}
+		result[strings.TrimPrefix(key, hardwareLabelPrefix)] = value
+	}
+	return result
+}
+
+func sortInventory(inventory []rancherv1.MachineInventoryStatus) {
+	sort.Slice(inventory, func(i, j int) bool {
+		return inventory[i].Name < inventory[j].Name
+	})
+}
@@ -0,0 +1,172 @@
+package elemental
+
+import (
+	"testing"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestRolloutVersion(t *testing.T) {
+	osImage := &rancherv1.RKEMachinePoolOSImage{Channel: "sl-micro", Version: "v2"}
+	controlPlane := func(reconciled bool, version, appliedVersion string) *rkev1.RKEControlPlane {
+		cp := &rkev1.RKEControlPlane{
+			Spec: rkev1.RKEControlPlaneSpec{KubernetesVersion: version},
+		}
+		if appliedVersion != "" {
+			cp.Status.AppliedSpec = &rkev1.RKEControlPlaneSpec{KubernetesVersion: appliedVersion}
+		}
+		if reconciled {
+			capr.Reconciled.True(cp)
+		} else {
+			capr.Reconciled.False(cp)
+		}
+		return cp
+	}
+
+	tests := []struct {
+		name         string
+		current      string
+		controlPlane *rkev1.RKEControlPlane
+		want         string
+	}{
+		{
+			name: "initial version",
+			want: "v2",
+		},
+		{
+			name:         "unchanged version",
+			current:      "v2",
+			controlPlane: controlPlane(false, "v1.26.4+rke2r1", "v1.25.9+rke2r1"),
+			want:         "v2",
+		},
+		{
+			name:         "reconciled",
+			current:      "v1",
+			controlPlane: controlPlane(true, "v1.26.4+rke2r1", "v1.26.4+rke2r1"),
+			want:         "v2",
+		},
+		{
+			name:         "kubernetes upgrade in progress",
+			current:      "v1",
+			controlPlane: controlPlane(true, "v1.26.4+rke2r1", "v1.25.9+rke2r1"),
+			want:         "v1",
+		},
+		{
+			name:         "not reconciled",
+			current:      "v1",
+			controlPlane: controlPlane(false, "v1.26.4+rke2r1", "v1.26.4+rke2r1"),
+			want:         "v1",
+		},
+		{
+			name:         "never applied",
+			current:      "v1",
+			controlPlane: controlPlane(true, "v1.26.4+rke2r1", ""),
+			want:         "v1",
+		},
+		{
+			name:    "no control plane",
+			current: "v1",
+			want:    "v1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rolloutVersion(osImage, tt.current, tt.controlPlane))
+		})
+	}
+}
+
+func TestConcurrency(t *testing.T) {
+	tests := []struct {
+		value   string
+		nodes   int
+		want    int64
+		wantErr bool
+	}{
+		{value: "", nodes: 5, want: 1},
+		{value: "2", nodes: 5, want: 2},
+		{value: "0", nodes: 5, want: 5},
+		{value: "50%", nodes: 5, want: 3},
+		{value: "10%", nodes: 3, want: 1},
+		{value: "all", nodes: 5, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := concurrency(tt.value, tt.nodes)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHardware(t *testing.T) {
+	assert.Nil(t, hardware(map[string]string{capr.ClusterNameLabel: "c1"}))
+	assert.Equal(t, map[string]string{
+		"manufacturer": "Dell Inc.",
+		"cpu-cores":    "16",
+	}, hardware(map[string]string{
+		capr.ClusterNameLabel:                 "c1",
+		hardwareLabelPrefix + "manufacturer":  "Dell Inc.",
+		hardwareLabelPrefix + "cpu-cores":     "16",
+		hardwareLabelPrefix + "serial-number": "",
+	}))
+}
+
+func TestManagedOSImage(t *testing.T) {
+	cluster := &rancherv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "c1", Namespace: "fleet-default"}}
+	controlPlane := &rkev1.RKEControlPlane{
+		Spec: rkev1.RKEControlPlaneSpec{
+			RKEClusterSpecCommon: rkev1.RKEClusterSpecCommon{
+				UpgradeStrategy: rkev1.ClusterUpgradeStrategy{
+					ControlPlaneConcurrency: "1",
+					WorkerConcurrency:       "50%",
+					WorkerDrainOptions: rkev1.DrainOptions{
+						Enabled: true,
+						Timeout: 120,
+					},
+				},
+			},
+		},
+	}
+
+	obj, err := managedOSImage(cluster, rancherv1.RKEMachinePool{Name: "workers"}, controlPlane, "v2", nil)
+	require.NoError(t, err)
+	assert.Nil(t, obj, "no nodes")
+
+	obj, err = managedOSImage(cluster, rancherv1.RKEMachinePool{Name: "workers", WorkerRole: true}, controlPlane, "v2", []string{"node-b", "node-a", "node-c"})
+	require.NoError(t, err)
+	osImage := obj.(*unstructured.Unstructured)
+	assert.Equal(t, managedOSImageGVK, osImage.GroupVersionKind())
+	assert.Equal(t, "c1-workers", osImage.GetName())
+	assert.Equal(t, "workers", osImage.GetLabels()[capr.RKEMachinePoolNameLabel])
+	assert.Equal(t, "v2", osImage.Object["spec"].(map[string]interface{})["managedOSVersionName"])
+	assert.Equal(t, int64(2), osImage.Object["spec"].(map[string]interface{})["concurrency"])
+	assert.Equal(t, []interface{}{"node-a", "node-b", "node-c"}, osImage.Object["spec"].(map[string]interface{})["nodeSelector"].(map[string]interface{})["matchExpressions"].([]interface{})[0].(map[string]interface{})["values"])
+	assert.Equal(t, map[string]interface{}{
+		"force":            false,
+		"deleteLocalData":  false,
+		"disableEviction":  false,
+		"ignoreDaemonSets": true,
+		"timeout":          int64(120000000000),
+	}, osImage.Object["spec"].(map[string]interface{})["drain"])
+
+	obj, err = managedOSImage(cluster, rancherv1.RKEMachinePool{Name: "cp", ControlPlaneRole: true}, controlPlane, "v2", []string{"node-a"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), obj.(*unstructured.Unstructured).Object["spec"].(map[string]interface{})["concurrency"])
+	assert.NotContains(t, obj.(*unstructured.Unstructured).Object["spec"], "drain")
+
+	// The object must be deep copyable to be applied.
+	assert.NotPanics(t, func() { osImage.DeepCopy() })
+}
@@ -0,0 +1,60 @@
+package kubeconfigdistribution
+
+import (
+	"context"
+
+	v1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rocontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/provisioningv2/kubeconfig"
+	"github.com/rancher/rancher/pkg/wrangler"
+	"github.com/rancher/wrangler/pkg/generic"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type handler struct {
+	kubeconfigManager *kubeconfig.Manager
+}
+
+// Register registers the kubeconfig-distribution controller, which copies the kubeconfig of the provisioning clusters
+// opted in with spec.kubeconfigDistribution to the listed namespaces. The copies are generated objects of the cluster,
+// so they are updated when the kubeconfig secret of the cluster is recreated with a new token or CA, and deleted when
+// a namespace is removed from the list, the distribution is disabled or the cluster is deleted.
+func Register(ctx context.Context, clients *wrangler.Context, kubeconfigManager *kubeconfig.Manager) {
+	h := &handler{
+		kubeconfigManager: kubeconfigManager,
+	}
+
+	rocontrollers.RegisterClusterGeneratingHandler(ctx,
+		clients.Provisioning.Cluster(),
+		clients.Apply.WithCacheTypes(clients.Core.Secret()),
+		"",
+		"kubeconfig-distribution",
+		h.OnChange,
+		nil,
+	)
+
+	// The kubeconfig secret of a cluster is owned by the cluster.
+	relatedresource.Watch(ctx, "kubeconfig-distribution-trigger",
+		relatedresource.OwnerResolver(true, v1.SchemeGroupVersion.String(), "Cluster"),
+		clients.Provisioning.Cluster(), clients.Core.Secret())
+}
+
+func (h *handler) OnChange(cluster *v1.Cluster, status v1.ClusterStatus) ([]runtime.Object, v1.ClusterStatus, error) {
+	if cluster.Spec.KubeconfigDistribution == nil || len(cluster.Spec.KubeconfigDistribution.Namespaces) == 0 {
+		return nil, status, nil
+	}
+
+	if status.ClusterName == "" {
+		// The kubeconfig can't be generated before the management cluster exists, keep any existing copies until then.
+		return nil, status, generic.ErrSkip
+	}
+
+	secret, err := h.kubeconfigManager.GetKubeConfig(cluster, status)
+	if err != nil {
+		return nil, status, err
+	}
+
+	objs, err := distributedSecrets(cluster, secret.Data)
+	return objs, status, err
+}
@@ -0,0 +1,123 @@
+package kubeconfigdistribution
+
+import (
+	"encoding/json"
+	"fmt"
+
+	v1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	FormatKubeconfig = "kubeconfig"
+	FormatArgoCD     = "argocd"
+
+	ClusterNameLabel      = "provisioning.cattle.io/kubeconfig-cluster-name"
+	ClusterNamespaceLabel = "provisioning.cattle.io/kubeconfig-cluster-namespace"
+
+	argoCDSecretTypeLabel = "argocd.argoproj.io/secret-type"
+)
+
+// argoCDConfig is the config of an Argo CD cluster secret authenticating with a bearer token.
+type argoCDConfig struct {
+	BearerToken     string          `json:"bearerToken"`
+	TLSClientConfig argoCDTLSConfig `json:"tlsClientConfig"`
+}
+
+type argoCDTLSConfig struct {
+	CAData []byte `json:"caData,omitempty"`
+}
+
+// distributedSecrets returns the copies of the kubeconfig secret data of the cluster, one for each namespace of its
+// kubeconfig distribution, in the format of the distribution.
+func distributedSecrets(cluster *v1.Cluster, data map[string][]byte) ([]runtime.Object, error) {
+	distribution := cluster.Spec.KubeconfigDistribution
+
+	var (
+		secretData  map[string][]byte
+		extraLabels = map[string]string{}
+	)
+	switch distribution.Format {
+	case "", FormatKubeconfig:
+		secretData = map[string][]byte{
+			"value": data["value"],
+			"token": data["token"],
+		}
+	case FormatArgoCD:
+		var err error
+		secretData, err = argoCDSecretData(cluster, data["value"])
+		if err != nil {
+			return nil, err
+		}
+		extraLabels[argoCDSecretTypeLabel] = "cluster"
+	default:
+		return nil, fmt.Errorf("invalid kubeconfig distribution format %q for cluster %s/%s", distribution.Format, cluster.Namespace, cluster.Name)
+	}
+
+	var (
+		result []runtime.Object
+		seen   = map[string]bool{}
+	)
+	for _, namespace := range distribution.Namespaces {
+		if namespace == "" || seen[namespace] {
+			continue
+		}
+		seen[namespace] = true
+
+		labels := map[string]string{
+			ClusterNameLabel:      cluster.Name,
+			ClusterNamespaceLabel: cluster.Namespace,
+		}
+		for k, v := range extraLabels {
+			labels[k] = v
+		}
+
+		result = append(result, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name.SafeConcatName(cluster.Namespace, cluster.Name, "kubeconfig"),
+				Namespace: namespace,
+				Labels:    labels,
+			},
+			Data: secretData,
+		})
+	}
+
+	return result, nil
+}
+
+// argoCDSecretData converts a kubeconfig generated by the kubeconfig manager to the data of an Argo CD cluster secret.
+func argoCDSecretData(cluster *v1.Cluster, kubeconfig []byte) (map[string][]byte, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeconfigCluster, ok := config.Clusters["cluster"]
+	if !ok {
+		return nil, fmt.Errorf("kubeconfig of cluster %s/%s has no cluster", cluster.Namespace, cluster.Name)
+	}
+	user, ok := config.AuthInfos["user"]
+	if !ok {
+		return nil, fmt.Errorf("kubeconfig of cluster %s/%s has no user", cluster.Namespace, cluster.Name)
+	}
+
+	argoConfig, err := json.Marshal(argoCDConfig{
+		BearerToken: user.Token,
+		TLSClientConfig: argoCDTLSConfig{
+			CAData: kubeconfigCluster.CertificateAuthorityData,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string][]byte{
+		"name":   []byte(cluster.Name),
+		"server": []byte(kubeconfigCluster.Server),
+		"config": argoConfig,
+	}, nil
+}
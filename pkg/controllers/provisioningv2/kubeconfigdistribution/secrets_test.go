@@ -0,0 +1,106 @@
+package kubeconfigdistribution
+
+import (
+	"encoding/json"
+	"testing"
+
+	v1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func testKubeconfig(t *testing.T) []byte {
+	data, err := clientcmd.Write(clientcmdapi.Config{
+		Clusters: map[string]*clientcmdapi.Cluster{
+			"cluster": {
+				Server:                   "https://rancher.example.com/k8s/clusters/c-m-abcde",
+				CertificateAuthorityData: []byte("ca"),
+			},
+		},
+		AuthInfos: map[string]*clientcmdapi.AuthInfo{
+			"user": {
+				Token: "token",
+			},
+		},
+		Contexts: map[string]*clientcmdapi.Context{
+			"default": {
+				Cluster:  "cluster",
+				AuthInfo: "user",
+			},
+		},
+		CurrentContext: "default",
+	})
+	require.NoError(t, err)
+	return data
+}
+
+func testCluster(format string, namespaces ...string) *v1.Cluster {
+	return &v1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "prod",
+			Namespace: "fleet-default",
+		},
+		Spec: v1.ClusterSpec{
+			KubeconfigDistribution: &v1.KubeconfigDistribution{
+				Namespaces: namespaces,
+				Format:     format,
+			},
+		},
+	}
+}
+
+func TestDistributedSecretsKubeconfig(t *testing.T) {
+	kubeconfig := testKubeconfig(t)
+
+	objs, err := distributedSecrets(testCluster("", "argocd", "team-a", "argocd", ""), map[string][]byte{
+		"value": kubeconfig,
+		"token": []byte("token"),
+	})
+	require.NoError(t, err)
+	require.Len(t, objs, 2)
+
+	for i, namespace := range []string{"argocd", "team-a"} {
+		secret := objs[i].(*corev1.Secret)
+		assert.Equal(t, namespace, secret.Namespace)
+		assert.Equal(t, "fleet-default-prod-kubeconfig", secret.Name)
+		assert.Equal(t, map[string]string{
+			ClusterNameLabel:      "prod",
+			ClusterNamespaceLabel: "fleet-default",
+		}, secret.Labels)
+		assert.Equal(t, map[string][]byte{
+			"value": kubeconfig,
+			"token": []byte("token"),
+		}, secret.Data)
+	}
+}
+
+func TestDistributedSecretsArgoCD(t *testing.T) {
+	objs, err := distributedSecrets(testCluster(FormatArgoCD, "argocd"), map[string][]byte{
+		"value": testKubeconfig(t),
+		"token": []byte("token"),
+	})
+	require.NoError(t, err)
+	require.Len(t, objs, 1)
+
+	secret := objs[0].(*corev1.Secret)
+	assert.Equal(t, "cluster", secret.Labels[argoCDSecretTypeLabel])
+	assert.Equal(t, "prod", string(secret.Data["name"]))
+	assert.Equal(t, "https://rancher.example.com/k8s/clusters/c-m-abcde", string(secret.Data["server"]))
+
+	var config argoCDConfig
+	require.NoError(t, json.Unmarshal(secret.Data["config"], &config))
+	assert.Equal(t, "token", config.BearerToken)
+	assert.Equal(t, []byte("ca"), config.TLSClientConfig.CAData)
+}
+
+func TestDistributedSecretsErrors(t *testing.T) {
+	_, err := distributedSecrets(testCluster("helm", "argocd"), map[string][]byte{})
+	assert.Error(t, err)
+
+	_, err = distributedSecrets(testCluster(FormatArgoCD, "argocd"), map[string][]byte{"value": []byte("{")})
+	assert.Error(t, err)
+}
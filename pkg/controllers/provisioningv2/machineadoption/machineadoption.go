@@ -0,0 +1,71 @@
+package machineadoption
+
+import (
+	"context"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
+	rocontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/wrangler"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+type handler struct {
+	capiMachineCache capicontrollers.MachineCache
+}
+
+// Register registers the machine-adoptions controller, which tracks the adoptions of existing cloud instances into
+// machine pools requested through the API in the status of their cluster, from a machine of the pool claiming the
+// instance to the node of the instance joining the cluster.
+func Register(ctx context.Context, clients *wrangler.Context) {
+	h := &handler{
+		capiMachineCache: clients.CAPI.Machine().Cache(),
+	}
+
+	rocontrollers.RegisterClusterStatusHandler(ctx, clients.Provisioning.Cluster(), "", "machine-adoptions", h.OnChange)
+	relatedresource.Watch(ctx, "machine-adoptions-trigger", resolveMachine, clients.Provisioning.Cluster(), clients.CAPI.Machine())
+}
+
+// resolveMachine enqueues the cluster of the machines of machine pools.
+func resolveMachine(namespace, _ string, obj runtime.Object) ([]relatedresource.Key, error) {
+	if machine, ok := obj.(*capi.Machine); ok && machine.Labels[capr.RKEMachinePoolNameLabel] != "" {
+		return []relatedresource.Key{{Namespace: namespace, Name: machine.Spec.ClusterName}}, nil
+	}
+	return nil, nil
+}
+
+func (h *handler) OnChange(cluster *rancherv1.Cluster, status rancherv1.ClusterStatus) (rancherv1.ClusterStatus, error) {
+	if cluster.Spec.RKEConfig == nil || cluster.DeletionTimestamp != nil {
+		status.MachineAdoptions = nil
+		return status, nil
+	}
+	adoptions, err := capr.ParseMachineAdoptions(cluster.Annotations)
+	if err != nil {
+		logrus.Errorf("[machine-adoption] rkecluster %s/%s: %v", cluster.Namespace, cluster.Name, err)
+		status.MachineAdoptions = nil
+		return status, nil
+	}
+	if len(adoptions) == 0 {
+		status.MachineAdoptions = nil
+		return status, nil
+	}
+
+	machines, err := h.capiMachineCache.List(cluster.Namespace, labels.SelectorFromSet(labels.Set{
+		capi.ClusterLabelName: cluster.Name,
+	}))
+	if err != nil {
+		return status, err
+	}
+	byName := map[string]*capi.Machine{}
+	for _, machine := range machines {
+		byName[machine.Name] = machine
+	}
+
+	status.MachineAdoptions = adoptionStatuses(adoptions, byName)
+	return status, nil
+}
@@ -0,0 +1,50 @@
+package machineadoption
+
+import (
+	"fmt"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// adoptionStatuses returns the state of the adoptions of a cluster from its machines, by name. The adoptions claimed by
+// machines that no longer exist are left out, the machine having been removed after or while adopting the instance,
+// which can then be adopted again.
+func adoptionStatuses(adoptions []rkev1.MachineAdoption, machines map[string]*capi.Machine) []rancherv1.MachineAdoptionStatus {
+	var result []rancherv1.MachineAdoptionStatus
+	for _, adoption := range adoptions {
+		status := rancherv1.MachineAdoptionStatus{
+			ID:          adoption.ID,
+			PoolName:    adoption.PoolName,
+			InstanceID:  adoption.InstanceID,
+			ProviderID:  adoption.ProviderID,
+			MachineName: adoption.MachineName,
+		}
+
+		machine := machines[adoption.MachineName]
+		switch {
+		case adoption.MachineName == "":
+			status.Phase = rancherv1.MachineAdoptionPending
+			status.Message = fmt.Sprintf("waiting for a machine of machine pool %s to claim the instance", adoption.PoolName)
+		case machine == nil:
+			continue
+		case machine.Status.FailureMessage != nil:
+			status.Phase = rancherv1.MachineAdoptionFailed
+			status.Message = *machine.Status.FailureMessage
+		case machine.Status.NodeRef == nil:
+			status.Phase = rancherv1.MachineAdoptionInstalling
+			status.Message = fmt.Sprintf("installing the system agent on the instance at %s", adoption.Address)
+		default:
+			status.NodeName = machine.Status.NodeRef.Name
+			if providerID := machine.Spec.ProviderID; providerID != nil && *providerID != adoption.ProviderID {
+				status.Phase = rancherv1.MachineAdoptionFailed
+				status.Message = fmt.Sprintf("node %s has provider ID %s instead of the provider ID of the instance", status.NodeName, *providerID)
+				break
+			}
+			status.Phase = rancherv1.MachineAdoptionAdopted
+		}
+		result = append(result, status)
+	}
+	return result
+}
@@ -0,0 +1,80 @@
+package machineadoption
+
+import (
+	"testing"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func newMachine(name, node, providerID, failure string) *capi.Machine {
+	machine := &capi.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}
+	if node != "" {
+		machine.Status.NodeRef = &corev1.ObjectReference{Name: node}
+	}
+	if providerID != "" {
+		machine.Spec.ProviderID = &providerID
+	}
+	if failure != "" {
+		machine.Status.FailureMessage = &failure
+	}
+	return machine
+}
+
+func newAdoption(id, instance, machine string) rkev1.MachineAdoption {
+	return rkev1.MachineAdoption{
+		ID:          id,
+		PoolName:    "pool1",
+		InstanceID:  instance,
+		ProviderID:  "aws:///us-east-1a/" + instance,
+		Address:     "10.0.0.1",
+		MachineName: machine,
+	}
+}
+
+func TestAdoptionStatuses(t *testing.T) {
+	machines := map[string]*capi.Machine{
+		"pool1-installing": newMachine("pool1-installing", "", "", ""),
+		"pool1-adopted":    newMachine("pool1-adopted", "node-a", "aws:///us-east-1a/i-3", ""),
+		"pool1-failed":     newMachine("pool1-failed", "", "", "ssh: handshake failed"),
+		"pool1-mismatched": newMachine("pool1-mismatched", "node-b", "rke2://node-b", ""),
+	}
+	adoptions := []rkev1.MachineAdoption{
+		newAdoption("a1", "i-1", ""),
+		newAdoption("a2", "i-2", "pool1-installing"),
+		newAdoption("a3", "i-3", "pool1-adopted"),
+		newAdoption("a4", "i-4", "pool1-failed"),
+		newAdoption("a5", "i-5", "pool1-mismatched"),
+		newAdoption("a6", "i-6", "pool1-removed"),
+	}
+
+	statuses := adoptionStatuses(adoptions, machines)
+	assert.Equal(t, []rancherv1.MachineAdoptionStatus{
+		{
+			ID: "a1", PoolName: "pool1", InstanceID: "i-1", ProviderID: "aws:///us-east-1a/i-1",
+			Phase: rancherv1.MachineAdoptionPending, Message: "waiting for a machine of machine pool pool1 to claim the instance",
+		},
+		{
+			ID: "a2", PoolName: "pool1", InstanceID: "i-2", ProviderID: "aws:///us-east-1a/i-2", MachineName: "pool1-installing",
+			Phase: rancherv1.MachineAdoptionInstalling, Message: "installing the system agent on the instance at 10.0.0.1",
+		},
+		{
+			ID: "a3", PoolName: "pool1", InstanceID: "i-3", ProviderID: "aws:///us-east-1a/i-3", MachineName: "pool1-adopted",
+			NodeName: "node-a", Phase: rancherv1.MachineAdoptionAdopted,
+		},
+		{
+			ID: "a4", PoolName: "pool1", InstanceID: "i-4", ProviderID: "aws:///us-east-1a/i-4", MachineName: "pool1-failed",
+			Phase: rancherv1.MachineAdoptionFailed, Message: "ssh: handshake failed",
+		},
+		{
+			ID: "a5", PoolName: "pool1", InstanceID: "i-5", ProviderID: "aws:///us-east-1a/i-5", MachineName: "pool1-mismatched",
+			NodeName: "node-b", Phase: rancherv1.MachineAdoptionFailed, Message: "node node-b has provider ID rke2://node-b instead of the provider ID of the instance",
+		},
+	}, statuses)
+}
@@ -0,0 +1,106 @@
+package machineimage
+
+import (
+	"context"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
+	rocontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	rkecontroller "github.com/rancher/rancher/pkg/generated/controllers/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/wrangler"
+	"github.com/rancher/wrangler/pkg/name"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+type handler struct {
+	rkeControlPlaneCache   rkecontroller.RKEControlPlaneCache
+	capiMachineCache       capicontrollers.MachineCache
+	machineDeploymentCache capicontrollers.MachineDeploymentCache
+}
+
+// Register registers the machine-pool-images controller, which starts the rollout of the pinned image of a machine pool
+// once the pool and the control plane are healthy, and tracks the images the machines of the pool run.
+func Register(ctx context.Context, clients *wrangler.Context) {
+	h := &handler{
+		rkeControlPlaneCache:   clients.RKE.RKEControlPlane().Cache(),
+		capiMachineCache:       clients.CAPI.Machine().Cache(),
+		machineDeploymentCache: clients.CAPI.MachineDeployment().Cache(),
+	}
+
+	rocontrollers.RegisterClusterStatusHandler(ctx, clients.Provisioning.Cluster(), "", "machine-pool-images", h.OnChange)
+	relatedresource.Watch(ctx, "machine-pool-images-trigger", resolveMachine, clients.Provisioning.Cluster(), clients.CAPI.Machine(), clients.CAPI.MachineDeployment())
+}
+
+// resolveMachine enqueues the cluster of the machines, and of the machine deployments, of the machine pools pinning
+// their image.
+func resolveMachine(namespace, _ string, obj runtime.Object) ([]relatedresource.Key, error) {
+	switch obj := obj.(type) {
+	case *capi.Machine:
+		if _, ok := obj.Annotations[capr.MachineImageAnnotation]; ok {
+			return []relatedresource.Key{{Namespace: namespace, Name: obj.Spec.ClusterName}}, nil
+		}
+	case *capi.MachineDeployment:
+		if _, ok := obj.Spec.Template.Annotations[capr.MachineImageAnnotation]; ok {
+			return []relatedresource.Key{{Namespace: namespace, Name: obj.Spec.ClusterName}}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (h *handler) OnChange(cluster *rancherv1.Cluster, status rancherv1.ClusterStatus) (rancherv1.ClusterStatus, error) {
+	if cluster.Spec.RKEConfig == nil || cluster.DeletionTimestamp != nil {
+		status.MachinePoolImages = nil
+		return status, nil
+	}
+
+	controlPlane, err := h.rkeControlPlaneCache.Get(cluster.Namespace, cluster.Name)
+	if apierrors.IsNotFound(err) {
+		controlPlane = nil
+	} else if err != nil {
+		return status, err
+	}
+
+	machines, err := h.capiMachineCache.List(cluster.Namespace, labels.SelectorFromSet(labels.Set{
+		capi.ClusterLabelName: cluster.Name,
+	}))
+	if err != nil {
+		return status, err
+	}
+	poolMachines := map[string][]*capi.Machine{}
+	for _, machine := range machines {
+		pool := machine.Labels[capr.RKEMachinePoolNameLabel]
+		poolMachines[pool] = append(poolMachines[pool], machine)
+	}
+
+	var result []rancherv1.MachinePoolImageStatus
+	for _, pool := range cluster.Spec.RKEConfig.MachinePools {
+		if pool.MachineImage == nil || pool.MachineImage.Version == "" {
+			continue
+		}
+		deployment, err := h.machineDeploymentCache.Get(cluster.Namespace, name.SafeConcatName(cluster.Name, pool.Name))
+		if apierrors.IsNotFound(err) {
+			deployment = nil
+		} else if err != nil {
+			return status, err
+		}
+
+		// the pools already handled gate the others by their new state, so that a single pool starts rolling out an image
+		pools := append(append([]rancherv1.MachinePoolImageStatus{}, result...), status.MachinePoolImages...)
+		previous := previousStatus(status, pool.Name)
+		poolStatus := rollout(pool.MachineImage.Version, previous, poolMachines[pool.Name], deployment, gate(pools, pool.Name, controlPlane))
+		if poolStatus.Version != previous.Version {
+			logrus.Infof("[machine-pool-images] rkecluster %s/%s: rolling out image %s to machine pool %s",
+				cluster.Namespace, cluster.Name, poolStatus.Version, pool.Name)
+		}
+		result = append(result, poolStatus)
+	}
+
+	status.MachinePoolImages = result
+	return status, nil
+}
@@ -0,0 +1,127 @@
+package machineimage
+
+import (
+	"fmt"
+	"sort"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func previousStatus(status rancherv1.ClusterStatus, poolName string) rancherv1.MachinePoolImageStatus {
+	for _, pool := range status.MachinePoolImages {
+		if pool.Name == poolName {
+			return pool
+		}
+	}
+	return rancherv1.MachinePoolImageStatus{Name: poolName}
+}
+
+// gate returns why a new image can't be rolled out to a machine pool yet, empty once the control plane is settled and
+// no other pool of the cluster is rolling out an image according to the state of the pools.
+func gate(pools []rancherv1.MachinePoolImageStatus, poolName string, controlPlane *rkev1.RKEControlPlane) string {
+	if !capr.ControlPlaneSettled(controlPlane) {
+		return "waiting for the control plane to be reconciled"
+	}
+	for _, pool := range pools {
+		if pool.Name != poolName && (pool.Phase == rancherv1.MachineImageRollingOut || pool.Phase == rancherv1.MachineImageFailed) {
+			return fmt.Sprintf("waiting for machine pool %s to roll out image %s", pool.Name, pool.Version)
+		}
+	}
+	return ""
+}
+
+// rollout returns the state of the rollout of the image of a machine pool. The image is rolled out right away to new
+// pools and when replacing a failed rollout, such as when rolling back, otherwise once the gate passes and the machines
+// of the pool are ready.
+func rollout(image string, previous rancherv1.MachinePoolImageStatus, machines []*capi.Machine, deployment *capi.MachineDeployment, gate string) rancherv1.MachinePoolImageStatus {
+	status := rancherv1.MachinePoolImageStatus{
+		Name:     previous.Name,
+		Version:  previous.Version,
+		Machines: machineImages(machines),
+	}
+
+	if previous.Version != image && (previous.Version != "" || len(machines) > 0) && previous.Phase != rancherv1.MachineImageFailed {
+		if gate != "" {
+			status.Phase, status.Message = rancherv1.MachineImagePending, gate
+			return status
+		}
+		if !deploymentReady(deployment) {
+			status.Phase, status.Message = rancherv1.MachineImagePending, "waiting for the machines of the pool to be ready"
+			return status
+		}
+	}
+	status.Version = image
+
+	if message := failure(image, machines); message != "" {
+		status.Phase, status.Message = rancherv1.MachineImageFailed, message
+		return status
+	}
+	for _, machine := range status.Machines {
+		if machine.Version != image {
+			status.Phase = rancherv1.MachineImageRollingOut
+			return status
+		}
+	}
+	if !deploymentReady(deployment) {
+		status.Phase = rancherv1.MachineImageRollingOut
+		return status
+	}
+	status.Phase = rancherv1.MachineImageComplete
+	return status
+}
+
+func machineImages(machines []*capi.Machine) []rancherv1.MachineImageStatus {
+	var result []rancherv1.MachineImageStatus
+	for _, machine := range machines {
+		machineStatus := rancherv1.MachineImageStatus{
+			MachineName: machine.Name,
+			Version:     machine.Annotations[capr.MachineImageAnnotation],
+		}
+		if machine.Status.NodeRef != nil {
+			machineStatus.NodeName = machine.Status.NodeRef.Name
+		}
+		result = append(result, machineStatus)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].MachineName < result[j].MachineName
+	})
+	return result
+}
+
+// failure returns why a machine provisioned from the image failed, empty if none did.
+func failure(image string, machines []*capi.Machine) string {
+	for _, machine := range machines {
+		if machine.Annotations[capr.MachineImageAnnotation] != image || machine.DeletionTimestamp != nil {
+			continue
+		}
+		if machine.Status.FailureMessage != nil {
+			return fmt.Sprintf("machine %s failed: %s", machine.Name, *machine.Status.FailureMessage)
+		}
+		if machine.Status.FailureReason != nil {
+			return fmt.Sprintf("machine %s failed: %s", machine.Name, *machine.Status.FailureReason)
+		}
+		if capi.MachinePhase(machine.Status.Phase) == capi.MachinePhaseFailed {
+			return fmt.Sprintf("machine %s failed", machine.Name)
+		}
+	}
+	return ""
+}
+
+// deploymentReady returns true if all the machines of the machine deployment are up-to-date and ready, honoring its
+// rolling update.
+func deploymentReady(deployment *capi.MachineDeployment) bool {
+	if deployment == nil || deployment.Status.ObservedGeneration < deployment.Generation {
+		return false
+	}
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return deployment.Status.Replicas == replicas &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.ReadyReplicas == replicas &&
+		deployment.Status.UnavailableReplicas == 0
+}
@@ -0,0 +1,149 @@
+package machineimage
+
+import (
+	"testing"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func newMachine(name, image string) *capi.Machine {
+	machine := &capi.Machine{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if image != "" {
+		machine.Annotations = map[string]string{capr.MachineImageAnnotation: image}
+	}
+	return machine
+}
+
+func newDeployment(replicas, ready, updated int32) *capi.MachineDeployment {
+	return &capi.MachineDeployment{
+		Spec: capi.MachineDeploymentSpec{Replicas: &replicas},
+		Status: capi.MachineDeploymentStatus{
+			Replicas:            replicas,
+			ReadyReplicas:       ready,
+			UpdatedReplicas:     updated,
+			UnavailableReplicas: replicas - ready,
+		},
+	}
+}
+
+func TestRollout(t *testing.T) {
+	failure := "instance terminated"
+	failed := newMachine("m-3", "ami-2")
+	failed.Status.FailureMessage = &failure
+
+	tests := []struct {
+		name            string
+		previous        rancherv1.MachinePoolImageStatus
+		machines        []*capi.Machine
+		deployment      *capi.MachineDeployment
+		gate            string
+		expectedVersion string
+		expectedPhase   string
+		expectedMessage string
+	}{
+		{
+			name:            "new pool",
+			expectedVersion: "ami-2",
+			expectedPhase:   rancherv1.MachineImageRollingOut,
+		},
+		{
+			name:            "rolled out",
+			previous:        rancherv1.MachinePoolImageStatus{Version: "ami-2", Phase: rancherv1.MachineImageRollingOut},
+			machines:        []*capi.Machine{newMachine("m-1", "ami-2"), newMachine("m-2", "ami-2")},
+			deployment:      newDeployment(2, 2, 2),
+			expectedVersion: "ami-2",
+			expectedPhase:   rancherv1.MachineImageComplete,
+		},
+		{
+			name:            "pinned after machines were provisioned waits for the gate",
+			machines:        []*capi.Machine{newMachine("m-1", "")},
+			deployment:      newDeployment(1, 1, 1),
+			gate:            "waiting for the control plane to be reconciled",
+			expectedPhase:   rancherv1.MachineImagePending,
+			expectedMessage: "waiting for the control plane to be reconciled",
+		},
+		{
+			name:            "new image waits for the machines to be ready",
+			previous:        rancherv1.MachinePoolImageStatus{Version: "ami-1", Phase: rancherv1.MachineImageComplete},
+			machines:        []*capi.Machine{newMachine("m-1", "ami-1"), newMachine("m-2", "ami-1")},
+			deployment:      newDeployment(2, 1, 2),
+			expectedVersion: "ami-1",
+			expectedPhase:   rancherv1.MachineImagePending,
+			expectedMessage: "waiting for the machines of the pool to be ready",
+		},
+		{
+			name:            "new image rolls out",
+			previous:        rancherv1.MachinePoolImageStatus{Version: "ami-1", Phase: rancherv1.MachineImageComplete},
+			machines:        []*capi.Machine{newMachine("m-1", "ami-1"), newMachine("m-2", "ami-1")},
+			deployment:      newDeployment(2, 2, 2),
+			expectedVersion: "ami-2",
+			expectedPhase:   rancherv1.MachineImageRollingOut,
+		},
+		{
+			name:            "failed machine",
+			previous:        rancherv1.MachinePoolImageStatus{Version: "ami-2", Phase: rancherv1.MachineImageRollingOut},
+			machines:        []*capi.Machine{newMachine("m-1", "ami-1"), newMachine("m-2", "ami-2"), failed},
+			deployment:      newDeployment(2, 2, 1),
+			expectedVersion: "ami-2",
+			expectedPhase:   rancherv1.MachineImageFailed,
+			expectedMessage: "machine m-3 failed: instance terminated",
+		},
+		{
+			name:            "failed rollout is replaced without waiting for the gate",
+			previous:        rancherv1.MachinePoolImageStatus{Version: "ami-3", Phase: rancherv1.MachineImageFailed},
+			machines:        []*capi.Machine{newMachine("m-1", "ami-1"), newMachine("m-2", "ami-3")},
+			deployment:      newDeployment(2, 1, 1),
+			gate:            "waiting for the control plane to be reconciled",
+			expectedVersion: "ami-2",
+			expectedPhase:   rancherv1.MachineImageRollingOut,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.previous.Name = "pool"
+			status := rollout("ami-2", tt.previous, tt.machines, tt.deployment, tt.gate)
+			assert.Equal(t, "pool", status.Name)
+			assert.Equal(t, tt.expectedVersion, status.Version)
+			assert.Equal(t, tt.expectedPhase, status.Phase)
+			assert.Equal(t, tt.expectedMessage, status.Message)
+			assert.Len(t, status.Machines, len(tt.machines))
+		})
+	}
+}
+
+func TestGate(t *testing.T) {
+	controlPlane := &rkev1.RKEControlPlane{
+		Spec: rkev1.RKEControlPlaneSpec{KubernetesVersion: "v1.25.9+k3s1"},
+		Status: rkev1.RKEControlPlaneStatus{
+			AppliedSpec: &rkev1.RKEControlPlaneSpec{KubernetesVersion: "v1.25.9+k3s1"},
+		},
+	}
+	capr.Reconciled.SetStatus(controlPlane, string(corev1.ConditionTrue))
+
+	pools := []rancherv1.MachinePoolImageStatus{
+		{Name: "workers", Version: "ami-2", Phase: rancherv1.MachineImageRollingOut},
+		{Name: "etcd", Version: "ami-2", Phase: rancherv1.MachineImageComplete},
+	}
+	assert.Equal(t, "", gate(pools, "workers", controlPlane))
+	assert.Equal(t, "waiting for machine pool workers to roll out image ami-2", gate(pools, "etcd", controlPlane))
+	assert.Equal(t, "waiting for the control plane to be reconciled", gate(nil, "etcd", nil))
+
+	controlPlane.Spec.KubernetesVersion = "v1.26.4+k3s1"
+	assert.Equal(t, "waiting for the control plane to be reconciled", gate(nil, "etcd", controlPlane))
+}
+
+func TestMachineImages(t *testing.T) {
+	machine := newMachine("m-2", "ami-2")
+	machine.Status.NodeRef = &corev1.ObjectReference{Name: "node-2"}
+
+	assert.Equal(t, []rancherv1.MachineImageStatus{
+		{MachineName: "m-1"},
+		{MachineName: "m-2", NodeName: "node-2", Version: "ami-2"},
+	}, machineImages([]*capi.Machine{machine, newMachine("m-1", "")}))
+}
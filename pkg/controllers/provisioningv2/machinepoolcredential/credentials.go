@@ -0,0 +1,43 @@
+package machinepoolcredential
+
+import (
+	"fmt"
+	"strings"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// poolCloudCredential returns the cloud credential of a machine pool, which defaults to the cloud credential of the
+// cluster.
+func poolCloudCredential(cluster *rancherv1.Cluster, pool rancherv1.RKEMachinePool) string {
+	if pool.CloudCredentialSecretName != "" {
+		return pool.CloudCredentialSecretName
+	}
+	return cluster.Spec.CloudCredentialSecretName
+}
+
+// driverName returns the node driver of the machine config of a machine pool, such as amazonec2 for an Amazonec2Config.
+func driverName(nodeConfigKind string) string {
+	return strings.ToLower(strings.TrimSuffix(nodeConfigKind, "Config"))
+}
+
+// checkCredential returns an error if a cloud credential isn't a credential of a node driver. The fields of cloud
+// credentials are stored in their secret prefixed by the credential config of their driver, such as
+// amazonec2credentialConfig-accessKey.
+func checkCredential(secret *corev1.Secret, driver string) error {
+	prefix := driver + "credentialConfig-"
+	var found []string
+	for key := range secret.Data {
+		if strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		if i := strings.Index(key, "credentialConfig-"); i > 0 {
+			found = append(found, key[:i])
+		}
+	}
+	if len(found) == 0 {
+		return fmt.Errorf("cloud credential %s/%s has no credential config", secret.Namespace, secret.Name)
+	}
+	return fmt.Errorf("cloud credential %s/%s is a credential of driver %s, not %s", secret.Namespace, secret.Name, found[0], driver)
+}
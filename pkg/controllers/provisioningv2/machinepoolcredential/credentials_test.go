@@ -0,0 +1,39 @@
+package machinepoolcredential
+
+import (
+	"testing"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPoolCloudCredential(t *testing.T) {
+	cluster := &rancherv1.Cluster{Spec: rancherv1.ClusterSpec{CloudCredentialSecretName: "cattle-global-data:cc-1"}}
+	assert.Equal(t, "cattle-global-data:cc-1", poolCloudCredential(cluster, rancherv1.RKEMachinePool{}))
+	assert.Equal(t, "cattle-global-data:cc-2", poolCloudCredential(cluster, rancherv1.RKEMachinePool{
+		RKECommonNodeConfig: rkev1.RKECommonNodeConfig{CloudCredentialSecretName: "cattle-global-data:cc-2"},
+	}))
+}
+
+func TestDriverName(t *testing.T) {
+	assert.Equal(t, "amazonec2", driverName("Amazonec2Config"))
+	assert.Equal(t, "vmwarevsphere", driverName("VmwarevsphereConfig"))
+}
+
+func TestCheckCredential(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "cattle-global-data", Name: "cc-1"},
+		Data: map[string][]byte{
+			"amazonec2credentialConfig-accessKey": []byte("access"),
+			"amazonec2credentialConfig-secretKey": []byte("secret"),
+		},
+	}
+	assert.NoError(t, checkCredential(secret, "amazonec2"))
+	assert.EqualError(t, checkCredential(secret, "azure"), "cloud credential cattle-global-data/cc-1 is a credential of driver amazonec2, not azure")
+
+	secret.Data = map[string][]byte{"token": []byte("token")}
+	assert.EqualError(t, checkCredential(secret, "amazonec2"), "cloud credential cattle-global-data/cc-1 has no credential config")
+}
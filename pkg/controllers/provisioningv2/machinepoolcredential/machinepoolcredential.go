@@ -0,0 +1,155 @@
+package machinepoolcredential
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rancher/lasso/pkg/dynamic"
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/rancher/pkg/controllers/capr/machineprovision"
+	provcluster "github.com/rancher/rancher/pkg/controllers/provisioningv2/cluster"
+	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
+	rocontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	namespaces "github.com/rancher/rancher/pkg/namespace"
+	"github.com/rancher/rancher/pkg/wrangler"
+	"github.com/rancher/wrangler/pkg/data"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+type handler struct {
+	dynamic          *dynamic.Controller
+	clusterCache     rocontrollers.ClusterCache
+	secretCache      corecontrollers.SecretCache
+	capiMachineCache capicontrollers.MachineCache
+}
+
+// Register registers the machine-pool-credentials controller, which checks the cloud credential of each machine pool
+// of the clusters provisioned by a node driver, and switches the existing machines of a pool to its cloud credential
+// when it is changed, so that the machines are deleted with the credential the pool was rotated to.
+func Register(ctx context.Context, clients *wrangler.Context) {
+	h := &handler{
+		dynamic:          clients.Dynamic,
+		clusterCache:     clients.Provisioning.Cluster().Cache(),
+		secretCache:      clients.Core.Secret().Cache(),
+		capiMachineCache: clients.CAPI.Machine().Cache(),
+	}
+
+	rocontrollers.RegisterClusterStatusHandler(ctx, clients.Provisioning.Cluster(), "", "machine-pool-credentials", h.OnChange)
+	relatedresource.Watch(ctx, "machine-pool-credentials-trigger", h.resolveSecret, clients.Provisioning.Cluster(), clients.Core.Secret())
+}
+
+// resolveSecret enqueues the clusters using a cloud credential when its secret changes.
+func (h *handler) resolveSecret(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+	if _, ok := obj.(*corev1.Secret); !ok {
+		return nil, nil
+	}
+
+	id := name
+	if namespace == namespaces.GlobalNamespace {
+		id = namespace + ":" + name
+	}
+	clusters, err := h.clusterCache.GetByIndex(provcluster.ByCloudCred, id)
+	if err != nil {
+		return nil, err
+	}
+	var keys []relatedresource.Key
+	for _, cluster := range clusters {
+		if namespace == namespaces.GlobalNamespace || cluster.Namespace == namespace {
+			keys = append(keys, relatedresource.Key{Namespace: cluster.Namespace, Name: cluster.Name})
+		}
+	}
+	return keys, nil
+}
+
+func (h *handler) OnChange(cluster *rancherv1.Cluster, status rancherv1.ClusterStatus) (rancherv1.ClusterStatus, error) {
+	if cluster.Spec.RKEConfig == nil || cluster.DeletionTimestamp != nil {
+		status.MachinePoolCloudCredentials = nil
+		return status, nil
+	}
+
+	machines, err := h.capiMachineCache.List(cluster.Namespace, labels.SelectorFromSet(labels.Set{
+		capi.ClusterLabelName: cluster.Name,
+	}))
+	if err != nil {
+		return status, err
+	}
+	poolMachines := map[string][]*capi.Machine{}
+	for _, machine := range machines {
+		pool := machine.Labels[capr.RKEMachinePoolNameLabel]
+		poolMachines[pool] = append(poolMachines[pool], machine)
+	}
+
+	var result []rancherv1.MachinePoolCloudCredentialStatus
+	for _, pool := range cluster.Spec.RKEConfig.MachinePools {
+		credential := poolCloudCredential(cluster, pool)
+		if credential == "" || pool.NodeConfig == nil || pool.OSImage != nil {
+			continue
+		}
+
+		poolStatus := rancherv1.MachinePoolCloudCredentialStatus{
+			Name:                      pool.Name,
+			CloudCredentialSecretName: credential,
+		}
+		secret, err := machineprovision.GetCloudCredentialSecret(h.secretCache, cluster.Namespace, credential)
+		if apierrors.IsNotFound(err) {
+			poolStatus.Message = fmt.Sprintf("cloud credential %s not found", credential)
+		} else if err != nil {
+			return status, err
+		} else if err := checkCredential(secret, driverName(pool.NodeConfig.Kind)); err != nil {
+			poolStatus.Message = err.Error()
+		} else {
+			poolStatus.Ready = true
+			if err := h.rotateMachines(cluster, pool.Name, poolMachines[pool.Name], credential); err != nil {
+				return status, err
+			}
+		}
+		result = append(result, poolStatus)
+	}
+
+	status.MachinePoolCloudCredentials = result
+	return status, nil
+}
+
+// rotateMachines switches the infrastructure machines of a machine pool that use another cloud credential to the
+// cloud credential of the pool. The credential is used by the next provisioning job of the machines, such as the job
+// removing them.
+func (h *handler) rotateMachines(cluster *rancherv1.Cluster, poolName string, machines []*capi.Machine, credential string) error {
+	for _, machine := range machines {
+		ref := machine.Spec.InfrastructureRef
+		if ref.APIVersion != capr.RKEMachineAPIVersion {
+			continue
+		}
+		infra, err := h.dynamic.Get(schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind), machine.Namespace, ref.Name)
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		d, err := data.Convert(infra.DeepCopyObject())
+		if err != nil {
+			return err
+		}
+		previous := d.String("spec", "common", "cloudCredentialSecretName")
+		if previous == credential {
+			continue
+		}
+
+		logrus.Infof("[machine-pool-credentials] rkecluster %s/%s: switching machine %s of pool %s from cloud credential %s to %s",
+			cluster.Namespace, cluster.Name, ref.Name, poolName, previous, credential)
+		d.SetNested(credential, "spec", "common", "cloudCredentialSecretName")
+		if _, err := h.dynamic.Update(&unstructured.Unstructured{Object: d}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
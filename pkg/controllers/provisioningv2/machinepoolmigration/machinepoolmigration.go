@@ -0,0 +1,287 @@
+package machinepoolmigration
+
+import (
+	"context"
+	"fmt"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
+	rocontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/wrangler"
+	"github.com/rancher/wrangler/pkg/apply"
+	"github.com/rancher/wrangler/pkg/name"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+type handler struct {
+	machineDeployments     capicontrollers.MachineDeploymentClient
+	machineDeploymentCache capicontrollers.MachineDeploymentCache
+	machineSets            capicontrollers.MachineSetClient
+	machineSetCache        capicontrollers.MachineSetCache
+	machines               capicontrollers.MachineClient
+	machineCache           capicontrollers.MachineCache
+}
+
+// Register registers the machine-pool-migrations controller, which moves the machines of a renamed machine pool to the
+// machine pool instead of them being deleted and recreated. The machine deployment of the previous name is kept and
+// paused while the machine deployment of the machine pool is generated paused, then the machine sets of the previous
+// machine deployment and their machines are relabeled and handed over to the machine deployment of the machine pool,
+// and the previous machine deployment is deleted once it has no machine sets left.
+func Register(ctx context.Context, clients *wrangler.Context) {
+	h := &handler{
+		machineDeployments:     clients.CAPI.MachineDeployment(),
+		machineDeploymentCache: clients.CAPI.MachineDeployment().Cache(),
+		machineSets:            clients.CAPI.MachineSet(),
+		machineSetCache:        clients.CAPI.MachineSet().Cache(),
+		machines:               clients.CAPI.Machine(),
+		machineCache:           clients.CAPI.Machine().Cache(),
+	}
+
+	rocontrollers.RegisterClusterStatusHandler(ctx, clients.Provisioning.Cluster(), "", "machine-pool-migrations", h.OnChange)
+	relatedresource.Watch(ctx, "machine-pool-migrations-trigger", resolveMachineSet, clients.Provisioning.Cluster(), clients.CAPI.MachineDeployment(), clients.CAPI.MachineSet())
+}
+
+// resolveMachineSet enqueues the cluster of the machine deployments and machine sets of the machine pools.
+func resolveMachineSet(namespace, _ string, obj runtime.Object) ([]relatedresource.Key, error) {
+	switch obj := obj.(type) {
+	case *capi.MachineDeployment:
+		if obj.Spec.Template.Labels[capr.RKEMachinePoolNameLabel] != "" {
+			return []relatedresource.Key{{Namespace: namespace, Name: obj.Spec.ClusterName}}, nil
+		}
+	case *capi.MachineSet:
+		if obj.Spec.Template.Labels[capr.RKEMachinePoolNameLabel] != "" {
+			return []relatedresource.Key{{Namespace: namespace, Name: obj.Spec.ClusterName}}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (h *handler) OnChange(cluster *rancherv1.Cluster, status rancherv1.ClusterStatus) (rancherv1.ClusterStatus, error) {
+	if cluster.Spec.RKEConfig == nil || cluster.DeletionTimestamp != nil {
+		status.MachinePoolMigrations = nil
+		return status, nil
+	}
+
+	var migrations []rancherv1.MachinePoolMigrationStatus
+	for _, pool := range cluster.Spec.RKEConfig.MachinePools {
+		migration := findMigration(status.MachinePoolMigrations, pool.Name)
+		if pool.RenamedFrom != "" && (migration == nil || migration.From != pool.RenamedFrom) {
+			started, err := h.start(cluster, pool, status.MachinePoolMigrations)
+			if err != nil {
+				return status, err
+			}
+			migration = &started
+		}
+		if migration == nil {
+			continue
+		}
+		if migration.Phase == rancherv1.MachinePoolMigrationMoving {
+			moved, err := h.move(cluster, pool, *migration)
+			if err != nil {
+				return status, err
+			}
+			migration = &moved
+		}
+		migrations = append(migrations, *migration)
+	}
+	status.MachinePoolMigrations = migrations
+	return status, nil
+}
+
+// start starts the migration of the machines of the machine pool a machine pool was renamed from, by keeping its
+// machine deployment from being deleted when the machine deployments of the cluster are generated and pausing it. The
+// machine templates of the machine pool keep the name of those of the machine pool it was renamed from.
+func (h *handler) start(cluster *rancherv1.Cluster, pool rancherv1.RKEMachinePool, current []rancherv1.MachinePoolMigrationStatus) (rancherv1.MachinePoolMigrationStatus, error) {
+	from := name.SafeConcatName(cluster.Name, pool.RenamedFrom)
+	migration := rancherv1.MachinePoolMigrationStatus{
+		PoolName:     pool.Name,
+		From:         pool.RenamedFrom,
+		TemplateName: from,
+		Phase:        rancherv1.MachinePoolMigrationMoving,
+	}
+	if previous := findMigration(current, pool.RenamedFrom); previous != nil && previous.TemplateName != "" {
+		migration.TemplateName = previous.TemplateName
+	}
+
+	machineDeployment, err := h.machineDeploymentCache.Get(cluster.Namespace, from)
+	if apierrors.IsNotFound(err) {
+		// there are no machines to move, the machine pool provisions its own
+		migration.TemplateName = ""
+		migration.Phase = rancherv1.MachinePoolMigrationCompleted
+		return migration, nil
+	} else if err != nil {
+		return migration, err
+	}
+
+	if machineDeployment.Labels[apply.LabelPrune] != "false" || machineDeployment.Annotations[capi.PausedAnnotation] != "true" {
+		machineDeployment = machineDeployment.DeepCopy()
+		if machineDeployment.Labels == nil {
+			machineDeployment.Labels = map[string]string{}
+		}
+		if machineDeployment.Annotations == nil {
+			machineDeployment.Annotations = map[string]string{}
+		}
+		machineDeployment.Labels[apply.LabelPrune] = "false"
+		machineDeployment.Annotations[capi.PausedAnnotation] = "true"
+		if _, err := h.machineDeployments.Update(machineDeployment); err != nil {
+			return migration, err
+		}
+	}
+	logrus.Infof("rkecluster %s/%s: moving the machines of machine pool %s to machine pool %s", cluster.Namespace, cluster.Name, pool.RenamedFrom, pool.Name)
+	return migration, nil
+}
+
+// move hands the machine sets of the machine deployment of the machine pool the machine pool was renamed from over to
+// the machine deployment of the machine pool, and deletes the previous machine deployment once it has no machine sets
+// left. The migration fails, and the previous machine deployment is deleted with its machines, if a machine set can't
+// be moved.
+func (h *handler) move(cluster *rancherv1.Cluster, pool rancherv1.RKEMachinePool, migration rancherv1.MachinePoolMigrationStatus) (rancherv1.MachinePoolMigrationStatus, error) {
+	from := name.SafeConcatName(cluster.Name, migration.From)
+	target, err := h.machineDeploymentCache.Get(cluster.Namespace, name.SafeConcatName(cluster.Name, pool.Name))
+	if apierrors.IsNotFound(err) {
+		// waiting for the machine deployment of the machine pool to be generated
+		return migration, nil
+	} else if err != nil {
+		return migration, err
+	}
+
+	machineSets, err := h.machineSetCache.List(cluster.Namespace, labels.SelectorFromSet(labels.Set{
+		capi.MachineDeploymentLabelName: from,
+	}))
+	if err != nil {
+		return migration, err
+	}
+	for _, machineSet := range machineSets {
+		if err := h.moveMachineSet(machineSet, target, pool.Name); apierrors.IsConflict(err) {
+			return migration, err
+		} else if err != nil {
+			logrus.Errorf("rkecluster %s/%s: failed to move machine set %s to machine pool %s: %v", cluster.Namespace, cluster.Name, machineSet.Name, pool.Name, err)
+			migration.Phase = rancherv1.MachinePoolMigrationFailed
+			migration.Message = fmt.Sprintf("failed to move machine set %s: %v", machineSet.Name, err)
+			migration.TemplateName = ""
+			return migration, h.deleteMachineDeployment(cluster.Namespace, from)
+		}
+	}
+	if len(machineSets) > 0 {
+		return migration, nil
+	}
+
+	if err := h.deleteMachineDeployment(cluster.Namespace, from); err != nil {
+		return migration, err
+	}
+	logrus.Infof("rkecluster %s/%s: moved the machines of machine pool %s to machine pool %s", cluster.Namespace, cluster.Name, migration.From, pool.Name)
+	migration.Phase = rancherv1.MachinePoolMigrationCompleted
+	return migration, nil
+}
+
+// moveMachineSet pauses the machine set, relabels its machines and then the machine set for the machine deployment and
+// the machine pool, and makes the machine deployment its owner before resuming it.
+func (h *handler) moveMachineSet(machineSet *capi.MachineSet, target *capi.MachineDeployment, poolName string) error {
+	if machineSet.Annotations[capi.PausedAnnotation] != "true" {
+		machineSet = machineSet.DeepCopy()
+		if machineSet.Annotations == nil {
+			machineSet.Annotations = map[string]string{}
+		}
+		machineSet.Annotations[capi.PausedAnnotation] = "true"
+		var err error
+		if machineSet, err = h.machineSets.Update(machineSet); err != nil {
+			return err
+		}
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&machineSet.Spec.Selector)
+	if err != nil {
+		return err
+	}
+	machines, err := h.machineCache.List(machineSet.Namespace, selector)
+	if err != nil {
+		return err
+	}
+	for _, machine := range machines {
+		if moved, ok := relabel(machine.Labels, target.Name, poolName); ok {
+			machine = machine.DeepCopy()
+			machine.Labels = moved
+			if _, err := h.machines.Update(machine); err != nil {
+				return err
+			}
+		}
+	}
+
+	machineSet = movedMachineSet(machineSet, target, poolName)
+	_, err = h.machineSets.Update(machineSet)
+	return err
+}
+
+func (h *handler) deleteMachineDeployment(namespace, name string) error {
+	err := h.machineDeployments.Delete(namespace, name, &metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// movedMachineSet returns the machine set labeled and selecting its machines for the machine deployment and the
+// machine pool, owned by the machine deployment and resumed.
+func movedMachineSet(machineSet *capi.MachineSet, target *capi.MachineDeployment, poolName string) *capi.MachineSet {
+	machineSet = machineSet.DeepCopy()
+	machineSet.Labels, _ = relabel(machineSet.Labels, target.Name, poolName)
+	machineSet.Spec.Template.Labels, _ = relabel(machineSet.Spec.Template.Labels, target.Name, poolName)
+	if _, ok := machineSet.Spec.Selector.MatchLabels[capi.MachineDeploymentLabelName]; ok {
+		machineSet.Spec.Selector.MatchLabels, _ = relabel(machineSet.Spec.Selector.MatchLabels, target.Name, "")
+	}
+	delete(machineSet.Annotations, capi.PausedAnnotation)
+
+	controller := true
+	blockOwnerDeletion := true
+	var ownerReferences []metav1.OwnerReference
+	for _, ref := range machineSet.OwnerReferences {
+		if ref.Kind != "MachineDeployment" {
+			ownerReferences = append(ownerReferences, ref)
+		}
+	}
+	machineSet.OwnerReferences = append(ownerReferences, metav1.OwnerReference{
+		APIVersion:         capi.GroupVersion.String(),
+		Kind:               "MachineDeployment",
+		Name:               target.Name,
+		UID:                target.UID,
+		Controller:         &controller,
+		BlockOwnerDeletion: &blockOwnerDeletion,
+	})
+	return machineSet
+}
+
+// relabel returns a copy of the labels with the machine deployment and machine pool labels set to the machine
+// deployment and the machine pool, if they're set, and whether they changed. The machine pool label isn't set if the
+// pool name is empty.
+func relabel(objLabels map[string]string, machineDeploymentName, poolName string) (map[string]string, bool) {
+	result := map[string]string{}
+	for k, v := range objLabels {
+		result[k] = v
+	}
+	changed := false
+	if v, ok := result[capi.MachineDeploymentLabelName]; ok && v != machineDeploymentName {
+		result[capi.MachineDeploymentLabelName] = machineDeploymentName
+		changed = true
+	}
+	if v, ok := result[capr.RKEMachinePoolNameLabel]; ok && poolName != "" && v != poolName {
+		result[capr.RKEMachinePoolNameLabel] = poolName
+		changed = true
+	}
+	return result, changed
+}
+
+func findMigration(migrations []rancherv1.MachinePoolMigrationStatus, poolName string) *rancherv1.MachinePoolMigrationStatus {
+	for i := range migrations {
+		if migrations[i].PoolName == poolName {
+			return &migrations[i]
+		}
+	}
+	return nil
+}
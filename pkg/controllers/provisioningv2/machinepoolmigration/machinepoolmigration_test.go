@@ -0,0 +1,92 @@
+package machinepoolmigration
+
+import (
+	"testing"
+
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestRelabel(t *testing.T) {
+	original := map[string]string{
+		capi.MachineDeploymentLabelName: "c-workers",
+		capr.RKEMachinePoolNameLabel:    "workers",
+		"other":                         "value",
+	}
+
+	moved, changed := relabel(original, "c-pool2", "pool2")
+	assert.True(t, changed)
+	assert.Equal(t, map[string]string{
+		capi.MachineDeploymentLabelName: "c-pool2",
+		capr.RKEMachinePoolNameLabel:    "pool2",
+		"other":                         "value",
+	}, moved)
+	assert.Equal(t, "c-workers", original[capi.MachineDeploymentLabelName])
+
+	_, changed = relabel(moved, "c-pool2", "pool2")
+	assert.False(t, changed)
+
+	moved, changed = relabel(map[string]string{"other": "value"}, "c-pool2", "pool2")
+	assert.False(t, changed)
+	assert.Equal(t, map[string]string{"other": "value"}, moved)
+}
+
+func TestMovedMachineSet(t *testing.T) {
+	controller := true
+	machineSet := &capi.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "c-workers-abc",
+			Labels: map[string]string{
+				capi.MachineDeploymentLabelName: "c-workers",
+			},
+			Annotations: map[string]string{
+				capi.PausedAnnotation: "true",
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "MachineDeployment", Name: "c-workers", UID: "old", Controller: &controller},
+			},
+		},
+		Spec: capi.MachineSetSpec{
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					capi.MachineDeploymentLabelName: "c-workers",
+					"machine-template-hash":         "123",
+				},
+			},
+			Template: capi.MachineTemplateSpec{
+				ObjectMeta: capi.ObjectMeta{
+					Labels: map[string]string{
+						capi.MachineDeploymentLabelName: "c-workers",
+						capr.RKEMachinePoolNameLabel:    "workers",
+						"machine-template-hash":         "123",
+					},
+				},
+			},
+		},
+	}
+	target := &capi.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "c-pool2", UID: types.UID("new")},
+	}
+
+	moved := movedMachineSet(machineSet, target, "pool2")
+	assert.Equal(t, "c-pool2", moved.Labels[capi.MachineDeploymentLabelName])
+	assert.Equal(t, map[string]string{
+		capi.MachineDeploymentLabelName: "c-pool2",
+		"machine-template-hash":         "123",
+	}, moved.Spec.Selector.MatchLabels)
+	assert.Equal(t, map[string]string{
+		capi.MachineDeploymentLabelName: "c-pool2",
+		capr.RKEMachinePoolNameLabel:    "pool2",
+		"machine-template-hash":         "123",
+	}, moved.Spec.Template.Labels)
+	assert.NotContains(t, moved.Annotations, capi.PausedAnnotation)
+	if assert.Len(t, moved.OwnerReferences, 1) {
+		assert.Equal(t, "c-pool2", moved.OwnerReferences[0].Name)
+		assert.Equal(t, types.UID("new"), moved.OwnerReferences[0].UID)
+		assert.True(t, *moved.OwnerReferences[0].Controller)
+	}
+	assert.Equal(t, "c-workers", machineSet.Labels[capi.MachineDeploymentLabelName])
+}
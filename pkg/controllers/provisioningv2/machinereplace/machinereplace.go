@@ -0,0 +1,215 @@
+package machinereplace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
+	rocontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/wrangler"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/rancher/wrangler/pkg/name"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/kubectl/pkg/drain"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	drainTimeout  = 10 * time.Minute
+	detachRequeue = 15 * time.Second
+)
+
+type handler struct {
+	ctx              context.Context
+	clusterCache     rocontrollers.ClusterCache
+	machines         capicontrollers.MachineController
+	capiMachineCache capicontrollers.MachineCache
+	secretCache      corecontrollers.SecretCache
+}
+
+// Register registers the machine-replacements controller, which tracks the replacements of machines requested through
+// the API in the status of their cluster, the machine pools being scaled up by one for each replacement until the
+// machine is deleted. Once the substitute of a machine is ready, the node of the machine is drained, and the machine is
+// deleted once its data volumes are detached from the node.
+func Register(ctx context.Context, clients *wrangler.Context) {
+	h := &handler{
+		ctx:              ctx,
+		clusterCache:     clients.Provisioning.Cluster().Cache(),
+		machines:         clients.CAPI.Machine(),
+		capiMachineCache: clients.CAPI.Machine().Cache(),
+		secretCache:      clients.Core.Secret().Cache(),
+	}
+
+	rocontrollers.RegisterClusterStatusHandler(ctx, clients.Provisioning.Cluster(), "", "machine-replacements", h.OnChange)
+	relatedresource.Watch(ctx, "machine-replacements-trigger", resolveMachine, clients.Provisioning.Cluster(), clients.CAPI.Machine())
+	relatedresource.Watch(ctx, "machine-replacement-trigger", resolveCluster, clients.CAPI.Machine(), clients.Provisioning.Cluster())
+	clients.CAPI.Machine().OnChange(ctx, "machine-replacement", h.OnMachineChange)
+}
+
+// resolveMachine enqueues the cluster of the machines, as any new machine can be the substitute of a machine being
+// replaced.
+func resolveMachine(namespace, _ string, obj runtime.Object) ([]relatedresource.Key, error) {
+	if machine, ok := obj.(*capi.Machine); ok && machine.Labels[capr.RKEMachinePoolNameLabel] != "" {
+		return []relatedresource.Key{{Namespace: namespace, Name: machine.Spec.ClusterName}}, nil
+	}
+	return nil, nil
+}
+
+// resolveCluster enqueues the machines being migrated according to the status of their cluster.
+func resolveCluster(namespace, _ string, obj runtime.Object) ([]relatedresource.Key, error) {
+	cluster, ok := obj.(*rancherv1.Cluster)
+	if !ok {
+		return nil, nil
+	}
+	var keys []relatedresource.Key
+	for _, replacement := range cluster.Status.MachineReplacements {
+		if replacement.Phase == rancherv1.MachineReplacementMigrating {
+			keys = append(keys, relatedresource.Key{Namespace: namespace, Name: replacement.MachineName})
+		}
+	}
+	return keys, nil
+}
+
+func (h *handler) OnChange(cluster *rancherv1.Cluster, status rancherv1.ClusterStatus) (rancherv1.ClusterStatus, error) {
+	if cluster.Spec.RKEConfig == nil || cluster.DeletionTimestamp != nil {
+		status.MachineReplacements = nil
+		return status, nil
+	}
+
+	machines, err := h.capiMachineCache.List(cluster.Namespace, labels.SelectorFromSet(labels.Set{
+		capi.ClusterLabelName: cluster.Name,
+	}))
+	if err != nil {
+		return status, err
+	}
+	status.MachineReplacements = replacements(machines)
+	return status, nil
+}
+
+// OnMachineChange drains the node of a machine being migrated to its substitute, and marks the machine for deletion once
+// the data volumes of the node are detached from it, or records why the machine can't be replaced.
+func (h *handler) OnMachineChange(_ string, machine *capi.Machine) (*capi.Machine, error) {
+	if machine == nil || machine.Annotations[capr.MachineReplacementAnnotation] == "" || machine.Annotations[capi.DeleteMachineAnnotation] != "" ||
+		machine.Annotations[capr.ReplacementErrorAnnotation] != "" || !machine.DeletionTimestamp.IsZero() || machine.Status.NodeRef == nil {
+		return machine, nil
+	}
+	replacement, err := request(machine)
+	if err != nil {
+		return machine, nil
+	}
+	cluster, err := h.clusterCache.Get(machine.Namespace, machine.Spec.ClusterName)
+	if apierrors.IsNotFound(err) {
+		return machine, nil
+	} else if err != nil {
+		return machine, err
+	}
+	if !migrating(cluster.Status, machine.Name, replacement.ID) {
+		return machine, nil
+	}
+
+	k8s, err := h.k8sClient(machine)
+	if err != nil {
+		return machine, err
+	}
+	node := machine.Status.NodeRef.Name
+	pvs, err := k8s.CoreV1().PersistentVolumes().List(h.ctx, metav1.ListOptions{LabelSelector: capr.PreserveDataLabel + "=true"})
+	if err != nil {
+		return machine, err
+	}
+	attachments, err := k8s.StorageV1().VolumeAttachments().List(h.ctx, metav1.ListOptions{})
+	if err != nil {
+		return machine, err
+	}
+	drivers, err := k8s.StorageV1().CSIDrivers().List(h.ctx, metav1.ListOptions{})
+	if err != nil {
+		return machine, err
+	}
+	movable, pinned := dataVolumes(node, pvs.Items, attachments.Items, drivers.Items)
+	if len(pinned) > 0 && !replacement.Force {
+		return h.fail(machine, fmt.Sprintf("data volumes %s are pinned to node %s and can't be moved to the substitute, replace the machine with force to lose their data",
+			strings.Join(pinned, ", "), node))
+	}
+
+	logrus.Infof("[machine-replacement] machine %s/%s: draining node %s to move its workloads and data volumes %v to the substitute", machine.Namespace, machine.Name, node, movable)
+	if err := h.drain(k8s, node); err != nil {
+		return machine, err
+	}
+
+	attachments, err = k8s.StorageV1().VolumeAttachments().List(h.ctx, metav1.ListOptions{})
+	if err != nil {
+		return machine, err
+	}
+	if attached := stillAttached(node, movable, attachments.Items); len(attached) > 0 {
+		logrus.Infof("[machine-replacement] machine %s/%s: waiting for data volumes %v to be detached from node %s", machine.Namespace, machine.Name, attached, node)
+		h.machines.EnqueueAfter(machine.Namespace, machine.Name, detachRequeue)
+		return machine, nil
+	}
+
+	logrus.Infof("[machine-replacement] machine %s/%s: deleting the machine replaced", machine.Namespace, machine.Name)
+	machine = machine.DeepCopy()
+	machine.Annotations[capi.DeleteMachineAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	return h.machines.Update(machine)
+}
+
+func migrating(status rancherv1.ClusterStatus, machineName, id string) bool {
+	for _, replacement := range status.MachineReplacements {
+		if replacement.MachineName == machineName {
+			return replacement.ID == id && replacement.Phase == rancherv1.MachineReplacementMigrating
+		}
+	}
+	return false
+}
+
+func (h *handler) fail(machine *capi.Machine, message string) (*capi.Machine, error) {
+	logrus.Errorf("[machine-replacement] machine %s/%s: %s", machine.Namespace, machine.Name, message)
+	machine = machine.DeepCopy()
+	machine.Annotations[capr.ReplacementErrorAnnotation] = message
+	return h.machines.Update(machine)
+}
+
+func (h *handler) drain(k8s kubernetes.Interface, node string) error {
+	helper := &drain.Helper{
+		Ctx:                 h.ctx,
+		Client:              k8s,
+		GracePeriodSeconds:  -1,
+		IgnoreAllDaemonSets: true,
+		DeleteEmptyDirData:  true,
+		Timeout:             drainTimeout,
+		Out:                 os.Stdout,
+		ErrOut:              os.Stderr,
+	}
+	n, err := k8s.CoreV1().Nodes().Get(h.ctx, node, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if err := drain.RunCordonOrUncordon(helper, n, true); err != nil {
+		return err
+	}
+	return drain.RunNodeDrain(helper, node)
+}
+
+func (h *handler) k8sClient(machine *capi.Machine) (kubernetes.Interface, error) {
+	secret, err := h.secretCache.Get(machine.Namespace, name.SafeConcatName(machine.Spec.ClusterName, "kubeconfig"))
+	if err != nil {
+		return nil, err
+	}
+
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(secret.Data["value"])
+	if err != nil {
+		return nil, err
+	}
+
+	return kubernetes.NewForConfig(restConfig)
+}
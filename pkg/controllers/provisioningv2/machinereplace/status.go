@@ -0,0 +1,102 @@
+package machinereplace
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// request returns the replacement requested for the machine, nil if none is.
+func request(machine *capi.Machine) (*rkev1.MachineReplacement, error) {
+	data := machine.Annotations[capr.MachineReplacementAnnotation]
+	if data == "" {
+		return nil, nil
+	}
+	var replacement rkev1.MachineReplacement
+	if err := json.Unmarshal([]byte(data), &replacement); err != nil {
+		return nil, fmt.Errorf("invalid machine replacement request: %w", err)
+	}
+	return &replacement, nil
+}
+
+// replacements returns the state of the replacements of the machines of a cluster. The substitute of a machine is the
+// oldest machine of its pool created since the replacement was requested that doesn't replace another machine.
+func replacements(machines []*capi.Machine) []rancherv1.MachineReplacementStatus {
+	sorted := append([]*capi.Machine{}, machines...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].CreationTimestamp.Equal(&sorted[j].CreationTimestamp) {
+			return sorted[i].CreationTimestamp.Before(&sorted[j].CreationTimestamp)
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	var result []rancherv1.MachineReplacementStatus
+	substitutes := map[string]bool{}
+	for _, machine := range sorted {
+		if machine.Annotations[capr.MachineReplacementAnnotation] == "" || !machine.DeletionTimestamp.IsZero() {
+			continue
+		}
+		status := rancherv1.MachineReplacementStatus{
+			MachineName: machine.Name,
+			PoolName:    machine.Labels[capr.RKEMachinePoolNameLabel],
+		}
+		replacement, err := request(machine)
+		if err != nil {
+			status.Phase, status.Message = rancherv1.MachineReplacementFailed, err.Error()
+			result = append(result, status)
+			continue
+		}
+		status.ID = replacement.ID
+
+		if substitute := substitute(sorted, machine, replacement, substitutes); substitute != nil {
+			substitutes[substitute.Name] = true
+			status.SubstituteName = substitute.Name
+		}
+		switch {
+		case machine.Annotations[capi.DeleteMachineAnnotation] != "":
+			status.Phase, status.Message = rancherv1.MachineReplacementDeleting, "waiting for the machine to be deleted"
+		case machine.Annotations[capr.ReplacementErrorAnnotation] != "":
+			status.Phase, status.Message = rancherv1.MachineReplacementFailed, machine.Annotations[capr.ReplacementErrorAnnotation]
+		case status.SubstituteName == "":
+			status.Phase, status.Message = rancherv1.MachineReplacementProvisioning, "waiting for the substitute to be created"
+		case !ready(findMachine(sorted, status.SubstituteName)):
+			status.Phase, status.Message = rancherv1.MachineReplacementProvisioning, fmt.Sprintf("waiting for the substitute %s to be ready", status.SubstituteName)
+		default:
+			status.Phase, status.Message = rancherv1.MachineReplacementMigrating, fmt.Sprintf("draining the node and moving its data volumes to the substitute %s", status.SubstituteName)
+		}
+		result = append(result, status)
+	}
+	return result
+}
+
+func substitute(machines []*capi.Machine, machine *capi.Machine, replacement *rkev1.MachineReplacement, substitutes map[string]bool) *capi.Machine {
+	pool := machine.Labels[capr.RKEMachinePoolNameLabel]
+	for _, candidate := range machines {
+		if candidate.Name == machine.Name || substitutes[candidate.Name] || candidate.Labels[capr.RKEMachinePoolNameLabel] != pool ||
+			candidate.Annotations[capr.MachineReplacementAnnotation] != "" || !candidate.DeletionTimestamp.IsZero() ||
+			candidate.CreationTimestamp.Before(&replacement.RequestedAt) {
+			continue
+		}
+		return candidate
+	}
+	return nil
+}
+
+func findMachine(machines []*capi.Machine, name string) *capi.Machine {
+	for _, machine := range machines {
+		if machine.Name == name {
+			return machine
+		}
+	}
+	return nil
+}
+
+// ready returns true if the machine is running its node.
+func ready(machine *capi.Machine) bool {
+	return machine != nil && machine.Status.NodeRef != nil && machine.Status.GetTypedPhase() == capi.MachinePhaseRunning
+}
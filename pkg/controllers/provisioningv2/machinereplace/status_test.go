@@ -0,0 +1,169 @@
+package machinereplace
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+var start = time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func newMachine(name, pool string, created time.Duration, running bool) *capi.Machine {
+	machine := &capi.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(start.Add(created)),
+			Labels:            map[string]string{capr.RKEMachinePoolNameLabel: pool},
+			Annotations:       map[string]string{},
+		},
+	}
+	if running {
+		machine.Status.NodeRef = &corev1.ObjectReference{Name: name}
+		machine.Status.SetTypedPhase(capi.MachinePhaseRunning)
+	}
+	return machine
+}
+
+func replace(t *testing.T, machine *capi.Machine, id string) *capi.Machine {
+	data, err := json.Marshal(rkev1.MachineReplacement{ID: id, RequestedAt: metav1.NewTime(start)})
+	require.NoError(t, err)
+	machine.Annotations[capr.MachineReplacementAnnotation] = string(data)
+	return machine
+}
+
+func TestReplacements(t *testing.T) {
+	tests := []struct {
+		name     string
+		machines func(t *testing.T) []*capi.Machine
+		expected []rancherv1.MachineReplacementStatus
+	}{
+		{
+			name: "no replacement",
+			machines: func(t *testing.T) []*capi.Machine {
+				return []*capi.Machine{newMachine("m-1", "pool1", -time.Hour, true)}
+			},
+		},
+		{
+			name: "waiting for the substitute to be created",
+			machines: func(t *testing.T) []*capi.Machine {
+				return []*capi.Machine{
+					replace(t, newMachine("m-1", "pool1", -time.Hour, true), "r1"),
+					newMachine("m-2", "pool1", -time.Hour, true),
+				}
+			},
+			expected: []rancherv1.MachineReplacementStatus{{
+				MachineName: "m-1", PoolName: "pool1", ID: "r1",
+				Phase: rancherv1.MachineReplacementProvisioning, Message: "waiting for the substitute to be created",
+			}},
+		},
+		{
+			name: "waiting for the substitute to be ready",
+			machines: func(t *testing.T) []*capi.Machine {
+				return []*capi.Machine{
+					replace(t, newMachine("m-1", "pool1", -time.Hour, true), "r1"),
+					newMachine("m-2", "pool2", time.Minute, true),
+					newMachine("m-3", "pool1", time.Minute, false),
+				}
+			},
+			expected: []rancherv1.MachineReplacementStatus{{
+				MachineName: "m-1", PoolName: "pool1", ID: "r1", SubstituteName: "m-3",
+				Phase: rancherv1.MachineReplacementProvisioning, Message: "waiting for the substitute m-3 to be ready",
+			}},
+		},
+		{
+			name: "migrating to the substitute",
+			machines: func(t *testing.T) []*capi.Machine {
+				return []*capi.Machine{
+					replace(t, newMachine("m-1", "pool1", -time.Hour, true), "r1"),
+					newMachine("m-3", "pool1", time.Minute, true),
+				}
+			},
+			expected: []rancherv1.MachineReplacementStatus{{
+				MachineName: "m-1", PoolName: "pool1", ID: "r1", SubstituteName: "m-3",
+				Phase: rancherv1.MachineReplacementMigrating, Message: "draining the node and moving its data volumes to the substitute m-3",
+			}},
+		},
+		{
+			name: "each replacement gets its own substitute",
+			machines: func(t *testing.T) []*capi.Machine {
+				return []*capi.Machine{
+					newMachine("m-4", "pool1", 2*time.Minute, false),
+					replace(t, newMachine("m-2", "pool1", -time.Minute, true), "r2"),
+					replace(t, newMachine("m-1", "pool1", -time.Hour, true), "r1"),
+					newMachine("m-3", "pool1", time.Minute, true),
+				}
+			},
+			expected: []rancherv1.MachineReplacementStatus{
+				{
+					MachineName: "m-1", PoolName: "pool1", ID: "r1", SubstituteName: "m-3",
+					Phase: rancherv1.MachineReplacementMigrating, Message: "draining the node and moving its data volumes to the substitute m-3",
+				},
+				{
+					MachineName: "m-2", PoolName: "pool1", ID: "r2", SubstituteName: "m-4",
+					Phase: rancherv1.MachineReplacementProvisioning, Message: "waiting for the substitute m-4 to be ready",
+				},
+			},
+		},
+		{
+			name: "failed",
+			machines: func(t *testing.T) []*capi.Machine {
+				machine := replace(t, newMachine("m-1", "pool1", -time.Hour, true), "r1")
+				machine.Annotations[capr.ReplacementErrorAnnotation] = "data volumes pv-1 are pinned"
+				return []*capi.Machine{machine, newMachine("m-3", "pool1", time.Minute, true)}
+			},
+			expected: []rancherv1.MachineReplacementStatus{{
+				MachineName: "m-1", PoolName: "pool1", ID: "r1", SubstituteName: "m-3",
+				Phase: rancherv1.MachineReplacementFailed, Message: "data volumes pv-1 are pinned",
+			}},
+		},
+		{
+			name: "deleting",
+			machines: func(t *testing.T) []*capi.Machine {
+				machine := replace(t, newMachine("m-1", "pool1", -time.Hour, true), "r1")
+				machine.Annotations[capi.DeleteMachineAnnotation] = "2023-05-01T12:10:00Z"
+				return []*capi.Machine{machine, newMachine("m-3", "pool1", time.Minute, true)}
+			},
+			expected: []rancherv1.MachineReplacementStatus{{
+				MachineName: "m-1", PoolName: "pool1", ID: "r1", SubstituteName: "m-3",
+				Phase: rancherv1.MachineReplacementDeleting, Message: "waiting for the machine to be deleted",
+			}},
+		},
+		{
+			name: "invalid request",
+			machines: func(t *testing.T) []*capi.Machine {
+				machine := newMachine("m-1", "pool1", -time.Hour, true)
+				machine.Annotations[capr.MachineReplacementAnnotation] = "{"
+				return []*capi.Machine{machine}
+			},
+			expected: []rancherv1.MachineReplacementStatus{{
+				MachineName: "m-1", PoolName: "pool1",
+				Phase: rancherv1.MachineReplacementFailed, Message: "invalid machine replacement request: unexpected end of JSON input",
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, replacements(tt.machines(t)))
+		})
+	}
+}
+
+func TestMigrating(t *testing.T) {
+	status := rancherv1.ClusterStatus{MachineReplacements: []rancherv1.MachineReplacementStatus{
+		{MachineName: "m-1", ID: "r1", Phase: rancherv1.MachineReplacementMigrating},
+		{MachineName: "m-2", ID: "r2", Phase: rancherv1.MachineReplacementProvisioning},
+	}}
+	assert.True(t, migrating(status, "m-1", "r1"))
+	assert.False(t, migrating(status, "m-1", "r0"))
+	assert.False(t, migrating(status, "m-2", "r2"))
+	assert.False(t, migrating(status, "m-3", "r3"))
+}
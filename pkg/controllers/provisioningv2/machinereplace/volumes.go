@@ -0,0 +1,72 @@
+package machinereplace
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+)
+
+// dataVolumes returns the volumes of the node labeled to preserve their data that are moved to the substitute, which
+// are the volumes attached to the node by a CSI driver detaching them from the node once it's drained, and the ones
+// that can't be moved, which are the volumes pinned to the node, such as local volumes.
+func dataVolumes(node string, pvs []corev1.PersistentVolume, attachments []storagev1.VolumeAttachment, drivers []storagev1.CSIDriver) (movable, pinned []string) {
+	detachable := map[string]bool{}
+	for _, driver := range drivers {
+		detachable[driver.Name] = driver.Spec.AttachRequired == nil || *driver.Spec.AttachRequired
+	}
+	attached := map[string]bool{}
+	for _, attachment := range attachments {
+		if attachment.Spec.NodeName == node && attachment.Spec.Source.PersistentVolumeName != nil {
+			attached[*attachment.Spec.Source.PersistentVolumeName] = true
+		}
+	}
+
+	for _, pv := range pvs {
+		switch {
+		case pinnedTo(pv, node):
+			pinned = append(pinned, pv.Name)
+		case pv.Spec.CSI != nil && detachable[pv.Spec.CSI.Driver] && attached[pv.Name]:
+			movable = append(movable, pv.Name)
+		}
+	}
+	sort.Strings(movable)
+	sort.Strings(pinned)
+	return movable, pinned
+}
+
+// pinnedTo returns true if the node affinity of the volume requires the node.
+func pinnedTo(pv corev1.PersistentVolume, node string) bool {
+	if pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+		return false
+	}
+	for _, term := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+		for _, expression := range term.MatchExpressions {
+			if expression.Key != corev1.LabelHostname || expression.Operator != corev1.NodeSelectorOpIn {
+				continue
+			}
+			for _, value := range expression.Values {
+				if value == node {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// stillAttached returns the volumes still attached to the node.
+func stillAttached(node string, volumes []string, attachments []storagev1.VolumeAttachment) []string {
+	wanted := map[string]bool{}
+	for _, volume := range volumes {
+		wanted[volume] = true
+	}
+	var result []string
+	for _, attachment := range attachments {
+		if attachment.Spec.NodeName == node && attachment.Spec.Source.PersistentVolumeName != nil && wanted[*attachment.Spec.Source.PersistentVolumeName] {
+			result = append(result, *attachment.Spec.Source.PersistentVolumeName)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,80 @@
+package machinereplace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func csiVolume(name, driver string) corev1.PersistentVolume {
+	return corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: corev1.PersistentVolumeSpec{
+			PersistentVolumeSource: corev1.PersistentVolumeSource{CSI: &corev1.CSIPersistentVolumeSource{Driver: driver}},
+		},
+	}
+}
+
+func localVolume(name, node string) corev1.PersistentVolume {
+	return corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: corev1.PersistentVolumeSpec{
+			PersistentVolumeSource: corev1.PersistentVolumeSource{Local: &corev1.LocalVolumeSource{Path: "/mnt/data"}},
+			NodeAffinity: &corev1.VolumeNodeAffinity{Required: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: []corev1.NodeSelectorRequirement{{
+						Key:      corev1.LabelHostname,
+						Operator: corev1.NodeSelectorOpIn,
+						Values:   []string{node},
+					}},
+				}},
+			}},
+		},
+	}
+}
+
+func attachment(pv, node string) storagev1.VolumeAttachment {
+	return storagev1.VolumeAttachment{
+		Spec: storagev1.VolumeAttachmentSpec{
+			NodeName: node,
+			Source:   storagev1.VolumeAttachmentSource{PersistentVolumeName: &pv},
+		},
+	}
+}
+
+func TestDataVolumes(t *testing.T) {
+	attachRequired := false
+	pvs := []corev1.PersistentVolume{
+		csiVolume("pv-disk", "csi.vsphere.vmware.com"),
+		csiVolume("pv-other-node", "csi.vsphere.vmware.com"),
+		csiVolume("pv-nfs", "nfs.csi.k8s.io"),
+		localVolume("pv-local", "node1"),
+		localVolume("pv-local-other-node", "node2"),
+	}
+	attachments := []storagev1.VolumeAttachment{
+		attachment("pv-disk", "node1"),
+		attachment("pv-other-node", "node2"),
+		attachment("pv-nfs", "node1"),
+	}
+	drivers := []storagev1.CSIDriver{
+		{ObjectMeta: metav1.ObjectMeta{Name: "csi.vsphere.vmware.com"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "nfs.csi.k8s.io"}, Spec: storagev1.CSIDriverSpec{AttachRequired: &attachRequired}},
+	}
+
+	movable, pinned := dataVolumes("node1", pvs, attachments, drivers)
+	assert.Equal(t, []string{"pv-disk"}, movable)
+	assert.Equal(t, []string{"pv-local"}, pinned)
+}
+
+func TestStillAttached(t *testing.T) {
+	attachments := []storagev1.VolumeAttachment{
+		attachment("pv-1", "node1"),
+		attachment("pv-2", "node2"),
+		attachment("pv-3", "node1"),
+	}
+	assert.Equal(t, []string{"pv-1"}, stillAttached("node1", []string{"pv-1", "pv-2"}, attachments))
+	assert.Empty(t, stillAttached("node1", []string{"pv-2"}, attachments))
+}
@@ -0,0 +1,155 @@
+package orphanedresources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/rancher/rancher/pkg/controllers/capr/machineprovision"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ec2Client is the part of the EC2 API used to find and delete the resources of machines.
+type ec2Client interface {
+	DescribeInstancesPagesWithContext(ctx aws.Context, input *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool, opts ...request.Option) error
+	DescribeVolumesPagesWithContext(ctx aws.Context, input *ec2.DescribeVolumesInput, fn func(*ec2.DescribeVolumesOutput, bool) bool, opts ...request.Option) error
+	DescribeNetworkInterfacesPagesWithContext(ctx aws.Context, input *ec2.DescribeNetworkInterfacesInput, fn func(*ec2.DescribeNetworkInterfacesOutput, bool) bool, opts ...request.Option) error
+	TerminateInstancesWithContext(ctx aws.Context, input *ec2.TerminateInstancesInput, opts ...request.Option) (*ec2.TerminateInstancesOutput, error)
+	DeleteVolumeWithContext(ctx aws.Context, input *ec2.DeleteVolumeInput, opts ...request.Option) (*ec2.DeleteVolumeOutput, error)
+	DeleteNetworkInterfaceWithContext(ctx aws.Context, input *ec2.DeleteNetworkInterfaceInput, opts ...request.Option) (*ec2.DeleteNetworkInterfaceOutput, error)
+}
+
+// amazonec2 finds the instances, volumes and network interfaces of the machines created by the amazonec2 node driver,
+// which tags them with their cluster and machine. The volumes and network interfaces attached to an instance are
+// deleted with it, so only those left detached are listed.
+type amazonec2 struct {
+	client ec2Client
+	region string
+}
+
+func newAmazonec2(secret *corev1.Secret, region string) (cloud, error) {
+	sess, err := session.NewSession(&aws.Config{
+		Region: aws.String(region),
+		Credentials: credentials.NewStaticCredentials(
+			string(secret.Data["amazonec2credentialConfig-accessKey"]),
+			string(secret.Data["amazonec2credentialConfig-secretKey"]),
+			""),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &amazonec2{client: ec2.New(sess), region: region}, nil
+}
+
+func (a *amazonec2) list(ctx context.Context, clusterName string) ([]cloudResource, error) {
+	filters := []*ec2.Filter{
+		{
+			Name:   aws.String(fmt.Sprintf("tag:kubernetes.io/cluster/%s", clusterName)),
+			Values: []*string{aws.String("owned")},
+		},
+		{
+			Name:   aws.String("tag-key"),
+			Values: []*string{aws.String(machineprovision.InfraMachineName)},
+		},
+	}
+
+	var result []cloudResource
+	err := a.client.DescribeInstancesPagesWithContext(ctx, &ec2.DescribeInstancesInput{
+		Filters: append(filters, &ec2.Filter{
+			Name:   aws.String("instance-state-name"),
+			Values: aws.StringSlice([]string{"pending", "running", "stopping", "stopped"}),
+		}),
+	}, func(output *ec2.DescribeInstancesOutput, _ bool) bool {
+		for _, reservation := range output.Reservations {
+			for _, instance := range reservation.Instances {
+				result = append(result, cloudResource{
+					Type:        resourceInstance,
+					ID:          aws.StringValue(instance.InstanceId),
+					Region:      a.region,
+					MachineName: machineTag(instance.Tags),
+					Created:     aws.TimeValue(instance.LaunchTime),
+				})
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.client.DescribeVolumesPagesWithContext(ctx, &ec2.DescribeVolumesInput{
+		Filters: append(filters, &ec2.Filter{
+			Name:   aws.String("status"),
+			Values: aws.StringSlice([]string{ec2.VolumeStateAvailable}),
+		}),
+	}, func(output *ec2.DescribeVolumesOutput, _ bool) bool {
+		for _, volume := range output.Volumes {
+			result = append(result, cloudResource{
+				Type:        resourceVolume,
+				ID:          aws.StringValue(volume.VolumeId),
+				Region:      a.region,
+				MachineName: machineTag(volume.Tags),
+				Created:     aws.TimeValue(volume.CreateTime),
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.client.DescribeNetworkInterfacesPagesWithContext(ctx, &ec2.DescribeNetworkInterfacesInput{
+		Filters: append(filters, &ec2.Filter{
+			Name:   aws.String("status"),
+			Values: aws.StringSlice([]string{ec2.NetworkInterfaceStatusAvailable}),
+		}),
+	}, func(output *ec2.DescribeNetworkInterfacesOutput, _ bool) bool {
+		for _, networkInterface := range output.NetworkInterfaces {
+			result = append(result, cloudResource{
+				Type:        resourceNetworkInterface,
+				ID:          aws.StringValue(networkInterface.NetworkInterfaceId),
+				Region:      a.region,
+				MachineName: machineTag(networkInterface.TagSet),
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (a *amazonec2) delete(ctx context.Context, resource cloudResource) error {
+	var err error
+	switch resource.Type {
+	case resourceInstance:
+		_, err = a.client.TerminateInstancesWithContext(ctx, &ec2.TerminateInstancesInput{
+			InstanceIds: []*string{aws.String(resource.ID)},
+		})
+	case resourceVolume:
+		_, err = a.client.DeleteVolumeWithContext(ctx, &ec2.DeleteVolumeInput{
+			VolumeId: aws.String(resource.ID),
+		})
+	case resourceNetworkInterface:
+		_, err = a.client.DeleteNetworkInterfaceWithContext(ctx, &ec2.DeleteNetworkInterfaceInput{
+			NetworkInterfaceId: aws.String(resource.ID),
+		})
+	default:
+		err = fmt.Errorf("unknown resource type %s", resource.Type)
+	}
+	return err
+}
+
+func machineTag(tags []*ec2.Tag) string {
+	for _, tag := range tags {
+		if aws.StringValue(tag.Key) == machineprovision.InfraMachineName {
+			return aws.StringValue(tag.Value)
+		}
+	}
+	return ""
+}
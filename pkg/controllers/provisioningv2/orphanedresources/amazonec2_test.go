@@ -0,0 +1,90 @@
+package orphanedresources
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/rancher/rancher/pkg/controllers/capr/machineprovision"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeEC2 struct {
+	filters    [][]*ec2.Filter
+	instances  []*ec2.Instance
+	volumes    []*ec2.Volume
+	interfaces []*ec2.NetworkInterface
+	deleted    []string
+}
+
+func (f *fakeEC2) DescribeInstancesPagesWithContext(_ aws.Context, input *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool, _ ...request.Option) error {
+	f.filters = append(f.filters, input.Filters)
+	fn(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: f.instances}}}, true)
+	return nil
+}
+
+func (f *fakeEC2) DescribeVolumesPagesWithContext(_ aws.Context, input *ec2.DescribeVolumesInput, fn func(*ec2.DescribeVolumesOutput, bool) bool, _ ...request.Option) error {
+	f.filters = append(f.filters, input.Filters)
+	fn(&ec2.DescribeVolumesOutput{Volumes: f.volumes}, true)
+	return nil
+}
+
+func (f *fakeEC2) DescribeNetworkInterfacesPagesWithContext(_ aws.Context, input *ec2.DescribeNetworkInterfacesInput, fn func(*ec2.DescribeNetworkInterfacesOutput, bool) bool, _ ...request.Option) error {
+	f.filters = append(f.filters, input.Filters)
+	fn(&ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: f.interfaces}, true)
+	return nil
+}
+
+func (f *fakeEC2) TerminateInstancesWithContext(_ aws.Context, input *ec2.TerminateInstancesInput, _ ...request.Option) (*ec2.TerminateInstancesOutput, error) {
+	f.deleted = append(f.deleted, aws.StringValueSlice(input.InstanceIds)...)
+	return &ec2.TerminateInstancesOutput{}, nil
+}
+
+func (f *fakeEC2) DeleteVolumeWithContext(_ aws.Context, input *ec2.DeleteVolumeInput, _ ...request.Option) (*ec2.DeleteVolumeOutput, error) {
+	f.deleted = append(f.deleted, aws.StringValue(input.VolumeId))
+	return &ec2.DeleteVolumeOutput{}, nil
+}
+
+func (f *fakeEC2) DeleteNetworkInterfaceWithContext(_ aws.Context, input *ec2.DeleteNetworkInterfaceInput, _ ...request.Option) (*ec2.DeleteNetworkInterfaceOutput, error) {
+	f.deleted = append(f.deleted, aws.StringValue(input.NetworkInterfaceId))
+	return &ec2.DeleteNetworkInterfaceOutput{}, nil
+}
+
+func machineTags(name string) []*ec2.Tag {
+	return []*ec2.Tag{
+		{Key: aws.String("kubernetes.io/cluster/c-m-abc"), Value: aws.String("owned")},
+		{Key: aws.String(machineprovision.InfraMachineName), Value: aws.String(name)},
+	}
+}
+
+func TestAmazonec2List(t *testing.T) {
+	launched := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	client := &fakeEC2{
+		instances:  []*ec2.Instance{{InstanceId: aws.String("i-1"), LaunchTime: &launched, Tags: machineTags("pool1-a")}},
+		volumes:    []*ec2.Volume{{VolumeId: aws.String("vol-1"), CreateTime: &launched, Tags: machineTags("pool1-b")}},
+		interfaces: []*ec2.NetworkInterface{{NetworkInterfaceId: aws.String("eni-1"), TagSet: machineTags("pool1-c")}},
+	}
+	a := &amazonec2{client: client, region: "us-east-1"}
+
+	resources, err := a.list(context.Background(), "c-m-abc")
+	assert.NoError(t, err)
+	assert.Equal(t, []cloudResource{
+		{Type: resourceInstance, ID: "i-1", Region: "us-east-1", MachineName: "pool1-a", Created: launched},
+		{Type: resourceVolume, ID: "vol-1", Region: "us-east-1", MachineName: "pool1-b", Created: launched},
+		{Type: resourceNetworkInterface, ID: "eni-1", Region: "us-east-1", MachineName: "pool1-c"},
+	}, resources)
+
+	for _, filters := range client.filters {
+		assert.Equal(t, "tag:kubernetes.io/cluster/c-m-abc", aws.StringValue(filters[0].Name))
+		assert.Equal(t, machineprovision.InfraMachineName, aws.StringValue(filters[1].Values[0]))
+	}
+	assert.Equal(t, []string{ec2.VolumeStateAvailable}, aws.StringValueSlice(client.filters[1][2].Values))
+
+	for _, resource := range resources {
+		assert.NoError(t, a.delete(context.Background(), resource))
+	}
+	assert.Equal(t, []string{"i-1", "vol-1", "eni-1"}, client.deleted)
+}
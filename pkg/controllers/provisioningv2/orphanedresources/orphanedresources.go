@@ -0,0 +1,202 @@
+package orphanedresources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rancher/lasso/pkg/dynamic"
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/rancher/pkg/controllers/capr/machineprovision"
+	rocontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/wrangler"
+	"github.com/rancher/wrangler/pkg/data"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// clouds creates the cloud of the machines created by a node driver, by the kind of the machine config of their pool.
+var clouds = map[string]func(secret *corev1.Secret, region string) (cloud, error){
+	"Amazonec2Config": newAmazonec2,
+}
+
+type handler struct {
+	dynamic     *dynamic.Controller
+	clusters    rocontrollers.ClusterController
+	secretCache corecontrollers.SecretCache
+	now         func() time.Time
+
+	lock     sync.Mutex
+	lastScan map[string]time.Time
+}
+
+// Register registers the orphaned-cloud-resources controller, which periodically looks for the cloud resources of
+// the machines of a cluster left behind by failed machine provisioning, which are tagged with a machine that no longer
+// exists. Depending on the orphaned-cloud-resources-policy setting, they're listed in the status of the cluster and
+// optionally deleted.
+func Register(ctx context.Context, clients *wrangler.Context) {
+	h := &handler{
+		dynamic:     clients.Dynamic,
+		clusters:    clients.Provisioning.Cluster(),
+		secretCache: clients.Core.Secret().Cache(),
+		now:         time.Now,
+		lastScan:    map[string]time.Time{},
+	}
+
+	rocontrollers.RegisterClusterStatusHandler(ctx, clients.Provisioning.Cluster(), "", "orphaned-cloud-resources", h.OnChange)
+}
+
+func (h *handler) OnChange(cluster *rancherv1.Cluster, status rancherv1.ClusterStatus) (rancherv1.ClusterStatus, error) {
+	policy := settings.OrphanedCloudResourcesPolicy.Get()
+	if cluster.Spec.RKEConfig == nil || cluster.DeletionTimestamp != nil || cluster.Status.ClusterName == "" ||
+		(policy != PolicyReport && policy != PolicyDelete) {
+		status.OrphanedCloudResources = nil
+		return status, nil
+	}
+
+	key := cluster.Namespace + "/" + cluster.Name
+	interval := time.Duration(settings.OrphanedCloudResourcesScanIntervalMinutes.GetInt()) * time.Minute
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	h.lock.Lock()
+	next := h.lastScan[key].Add(interval)
+	h.lock.Unlock()
+	if now := h.now(); now.Before(next) {
+		h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, next.Sub(now))
+		return status, nil
+	}
+
+	resources, err := h.scan(cluster)
+	if err != nil {
+		logrus.Errorf("[orphaned-cloud-resources] rkecluster %s: failed to scan for orphaned cloud resources: %v", key, err)
+	} else {
+		status.OrphanedCloudResources = resources
+	}
+
+	h.lock.Lock()
+	h.lastScan[key] = h.now()
+	h.lock.Unlock()
+	h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, interval)
+	return status, nil
+}
+
+// scan returns the orphaned resources of the machines of a cluster, in the regions and with the cloud credentials of
+// its machine pools, deleting them if the policy is delete.
+func (h *handler) scan(cluster *rancherv1.Cluster) ([]rancherv1.OrphanedCloudResource, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	poolClouds, err := h.poolClouds(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		result []rancherv1.OrphanedCloudResource
+		now    = h.now()
+	)
+	for _, pc := range poolClouds {
+		resources, err := pc.cloud.list(ctx, cluster.Status.ClusterName)
+		if err != nil {
+			return nil, err
+		}
+		orphans, err := orphaned(resources, now, func(name string) (bool, error) {
+			_, err := h.dynamic.Get(pc.machineGVK, cluster.Namespace, name)
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return err == nil, err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		deleted := map[string]error{}
+		if settings.OrphanedCloudResourcesPolicy.Get() == PolicyDelete {
+			deleteOrder(orphans)
+			for _, resource := range orphans {
+				logrus.Infof("[orphaned-cloud-resources] rkecluster %s/%s: deleting %s %s of removed machine %s",
+					cluster.Namespace, cluster.Name, resource.Type, resource.ID, resource.MachineName)
+				deleted[resource.ID] = pc.cloud.delete(ctx, resource)
+			}
+		} else {
+			for _, resource := range orphans {
+				logrus.Warnf("[orphaned-cloud-resources] rkecluster %s/%s: %s %s of removed machine %s is orphaned",
+					cluster.Namespace, cluster.Name, resource.Type, resource.ID, resource.MachineName)
+			}
+		}
+		result = append(result, toStatus(orphans, deleted)...)
+	}
+	return result, nil
+}
+
+type poolCloud struct {
+	cloud      cloud
+	machineGVK schema.GroupVersionKind
+}
+
+// poolClouds returns the clouds of the machine pools of a cluster, one for each cloud credential and region.
+func (h *handler) poolClouds(cluster *rancherv1.Cluster) ([]poolCloud, error) {
+	var result []poolCloud
+	seen := map[string]bool{}
+	for _, pool := range cluster.Spec.RKEConfig.MachinePools {
+		if pool.NodeConfig == nil || clouds[pool.NodeConfig.Kind] == nil {
+			continue
+		}
+		credential := pool.CloudCredentialSecretName
+		if credential == "" {
+			credential = cluster.Spec.CloudCredentialSecretName
+		}
+		if credential == "" {
+			continue
+		}
+
+		config, err := h.dynamic.Get(schema.FromAPIVersionAndKind(pool.NodeConfig.APIVersion, pool.NodeConfig.Kind), cluster.Namespace, pool.NodeConfig.Name)
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		d, err := data.Convert(config)
+		if err != nil {
+			return nil, err
+		}
+		region := d.String("region")
+
+		id := fmt.Sprintf("%s/%s/%s", pool.NodeConfig.Kind, credential, region)
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		secret, err := machineprovision.GetCloudCredentialSecret(h.secretCache, cluster.Namespace, credential)
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		c, err := clouds[pool.NodeConfig.Kind](secret, region)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, poolCloud{
+			cloud:      c,
+			machineGVK: schema.FromAPIVersionAndKind(capr.RKEMachineAPIVersion, machineKind(pool.NodeConfig.Kind)),
+		})
+	}
+	return result, nil
+}
+
+// machineKind returns the kind of the machines created from a machine config, such as Amazonec2Machine for an
+// Amazonec2Config.
+func machineKind(configKind string) string {
+	return strings.TrimSuffix(configKind, "Config") + "Machine"
+}
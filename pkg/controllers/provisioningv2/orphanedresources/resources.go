@@ -0,0 +1,100 @@
+package orphanedresources
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+)
+
+const (
+	PolicyReport   = "report"
+	PolicyDelete   = "delete"
+	PolicyDisabled = "disabled"
+
+	resourceInstance         = "instance"
+	resourceVolume           = "volume"
+	resourceNetworkInterface = "networkInterface"
+
+	// gracePeriod is how old the resources of a machine that doesn't exist must be to be orphaned, so that the resources
+	// of machines being created or removed aren't reported.
+	gracePeriod = 15 * time.Minute
+)
+
+// cloudResource is a resource of a cloud tagged with an infrastructure machine.
+type cloudResource struct {
+	Type        string
+	ID          string
+	Region      string
+	MachineName string
+	// Created is when the resource was created, zero if the cloud doesn't record it.
+	Created time.Time
+}
+
+// cloud lists and deletes the resources of the machines of a cluster in a region of a cloud, with a cloud credential.
+type cloud interface {
+	list(ctx context.Context, clusterName string) ([]cloudResource, error)
+	delete(ctx context.Context, resource cloudResource) error
+}
+
+// orphaned returns the resources tagged with a machine that doesn't exist, ignoring the resources created less than
+// the grace period ago.
+func orphaned(resources []cloudResource, now time.Time, machineExists func(name string) (bool, error)) ([]cloudResource, error) {
+	exists := map[string]bool{}
+	var result []cloudResource
+	for _, resource := range resources {
+		if !resource.Created.IsZero() && now.Sub(resource.Created) < gracePeriod {
+			continue
+		}
+		found, ok := exists[resource.MachineName]
+		if !ok {
+			var err error
+			if found, err = machineExists(resource.MachineName); err != nil {
+				return nil, err
+			}
+			exists[resource.MachineName] = found
+		}
+		if !found {
+			result = append(result, resource)
+		}
+	}
+	return result, nil
+}
+
+// deleteOrder sorts resources so that instances are deleted before the volumes and network interfaces that could be
+// attached to them.
+func deleteOrder(resources []cloudResource) {
+	rank := map[string]int{resourceInstance: 0, resourceVolume: 1, resourceNetworkInterface: 2}
+	sort.SliceStable(resources, func(i, j int) bool {
+		return rank[resources[i].Type] < rank[resources[j].Type]
+	})
+}
+
+// toStatus returns the status of orphaned resources, sorted by machine.
+func toStatus(resources []cloudResource, deleted map[string]error) []rancherv1.OrphanedCloudResource {
+	var result []rancherv1.OrphanedCloudResource
+	for _, resource := range resources {
+		status := rancherv1.OrphanedCloudResource{
+			Type:        resource.Type,
+			ID:          resource.ID,
+			Region:      resource.Region,
+			MachineName: resource.MachineName,
+		}
+		if err, ok := deleted[resource.ID]; ok {
+			if err != nil {
+				status.Message = err.Error()
+			} else {
+				status.Deleted = true
+			}
+		}
+		result = append(result, status)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].MachineName != result[j].MachineName {
+			return result[i].MachineName < result[j].MachineName
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
@@ -0,0 +1,63 @@
+package orphanedresources
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrphaned(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	resources := []cloudResource{
+		{Type: resourceInstance, ID: "i-1", MachineName: "pool1-a", Created: now.Add(-time.Hour)},
+		{Type: resourceVolume, ID: "vol-1", MachineName: "pool1-a", Created: now.Add(-time.Hour)},
+		{Type: resourceInstance, ID: "i-2", MachineName: "pool1-b", Created: now.Add(-time.Hour)},
+		{Type: resourceInstance, ID: "i-3", MachineName: "pool1-c", Created: now.Add(-time.Minute)},
+		{Type: resourceNetworkInterface, ID: "eni-1", MachineName: "pool1-d"},
+	}
+
+	lookups := map[string]int{}
+	orphans, err := orphaned(resources, now, func(name string) (bool, error) {
+		lookups[name]++
+		return name == "pool1-b", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []cloudResource{resources[0], resources[1], resources[4]}, orphans)
+	assert.Equal(t, map[string]int{"pool1-a": 1, "pool1-b": 1, "pool1-d": 1}, lookups)
+
+	_, err = orphaned(resources, now, func(name string) (bool, error) {
+		return false, errors.New("unavailable")
+	})
+	assert.EqualError(t, err, "unavailable")
+}
+
+func TestDeleteOrder(t *testing.T) {
+	resources := []cloudResource{
+		{Type: resourceNetworkInterface, ID: "eni-1"},
+		{Type: resourceVolume, ID: "vol-1"},
+		{Type: resourceInstance, ID: "i-1"},
+		{Type: resourceVolume, ID: "vol-2"},
+	}
+	deleteOrder(resources)
+	assert.Equal(t, []string{"i-1", "vol-1", "vol-2", "eni-1"}, []string{resources[0].ID, resources[1].ID, resources[2].ID, resources[3].ID})
+}
+
+func TestToStatus(t *testing.T) {
+	resources := []cloudResource{
+		{Type: resourceVolume, ID: "vol-1", Region: "us-east-1", MachineName: "pool1-b"},
+		{Type: resourceInstance, ID: "i-1", Region: "us-east-1", MachineName: "pool1-a"},
+		{Type: resourceInstance, ID: "i-2", Region: "us-east-1", MachineName: "pool1-b"},
+	}
+	assert.Equal(t, []rancherv1.OrphanedCloudResource{
+		{Type: resourceInstance, ID: "i-1", Region: "us-east-1", MachineName: "pool1-a", Deleted: true},
+		{Type: resourceInstance, ID: "i-2", Region: "us-east-1", MachineName: "pool1-b", Message: "unauthorized"},
+		{Type: resourceVolume, ID: "vol-1", Region: "us-east-1", MachineName: "pool1-b"},
+	}, toStatus(resources, map[string]error{"i-1": nil, "i-2": errors.New("unauthorized")}))
+}
+
+func TestMachineKind(t *testing.T) {
+	assert.Equal(t, "Amazonec2Machine", machineKind("Amazonec2Config"))
+}
@@ -0,0 +1,172 @@
+package policybundle
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	fleet "github.com/rancher/fleet/pkg/apis/fleet.cattle.io/v1alpha1"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/wrangler/pkg/yaml"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// maxResourceMessages is the number of modified or non-ready resources listed in the message of a cluster.
+	maxResourceMessages = 5
+	deployedCondition   = "Deployed"
+)
+
+// engineGroups are the API groups of the policies of each engine, matched as suffixes.
+var engineGroups = map[string]string{
+	v3.PolicyEngineGatekeeper: "gatekeeper.sh",
+	v3.PolicyEngineKyverno:    "kyverno.io",
+}
+
+// validatePolicies checks the spec of a policy bundle, and that its policies are all resources of the API groups of
+// its engine.
+func validatePolicies(spec *v3.PolicyBundleSpec) error {
+	group, ok := engineGroups[spec.Engine]
+	if !ok {
+		return fmt.Errorf("unknown policy engine %q, must be %s or %s", spec.Engine, v3.PolicyEngineGatekeeper, v3.PolicyEngineKyverno)
+	}
+	if spec.Version == "" {
+		return fmt.Errorf("version is required")
+	}
+	if errs := validation.IsValidLabelValue(spec.Version); len(errs) > 0 {
+		return fmt.Errorf("invalid version %q: %s", spec.Version, strings.Join(errs, ", "))
+	}
+
+	names := map[string]bool{}
+	for _, policy := range spec.Policies {
+		if policy.Name == "" {
+			return fmt.Errorf("policy name is required")
+		}
+		if names[policy.Name] {
+			return fmt.Errorf("policy %s is defined more than once", policy.Name)
+		}
+		names[policy.Name] = true
+
+		objs, err := yaml.ToObjects(strings.NewReader(policy.Content))
+		if err != nil {
+			return fmt.Errorf("invalid manifests of policy %s: %w", policy.Name, err)
+		}
+		if len(objs) == 0 {
+			return fmt.Errorf("policy %s has no manifests", policy.Name)
+		}
+		for _, obj := range objs {
+			gvk := obj.GetObjectKind().GroupVersionKind()
+			if gvk.Group != group && !strings.HasSuffix(gvk.Group, "."+group) {
+				return fmt.Errorf("policy %s has a %s of API version %s, only resources of the %s API groups are allowed for %s",
+					policy.Name, gvk.Kind, gvk.GroupVersion().String(), group, spec.Engine)
+			}
+		}
+	}
+	return nil
+}
+
+// bundleResources returns the resources of the fleet bundle of a policy bundle, one per policy.
+func bundleResources(spec *v3.PolicyBundleSpec) []fleet.BundleResource {
+	var result []fleet.BundleResource
+	for _, policy := range spec.Policies {
+		result = append(result, fleet.BundleResource{
+			Name:    policy.Name + ".yaml",
+			Content: policy.Content,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// clusterStatus returns the state of the policies on the cluster of a bundle deployment, previous being the state
+// previously reported for the cluster.
+func clusterStatus(bd *fleet.BundleDeployment, clusterName string, previous v3.PolicyBundleClusterStatus) v3.PolicyBundleClusterStatus {
+	status := v3.PolicyBundleClusterStatus{
+		ClusterName: clusterName,
+		// the version is only updated once the policies are deployed
+		Version: previous.Version,
+	}
+
+	for _, cond := range bd.Status.Conditions {
+		if cond.Type == deployedCondition && cond.Status == corev1.ConditionFalse && cond.Message != "" {
+			status.State = v3.PolicyBundleError
+			status.Message = cond.Message
+			return status
+		}
+	}
+
+	if bd.Spec.DeploymentID == "" || bd.Status.AppliedDeploymentID != bd.Spec.DeploymentID {
+		status.State = v3.PolicyBundlePending
+		status.Message = "policies are being deployed"
+		return status
+	}
+	status.Version = bd.Labels[policyBundleVersionLabel]
+
+	if !bd.Status.NonModified {
+		var messages []string
+		for _, modified := range bd.Status.ModifiedStatus {
+			resource := modified.Kind + " " + modified.Name
+			if modified.Namespace != "" {
+				resource = modified.Kind + " " + modified.Namespace + "/" + modified.Name
+			}
+			switch {
+			case modified.Create:
+				messages = append(messages, resource+" is missing")
+			case modified.Delete:
+				messages = append(messages, resource+" is extra")
+			default:
+				messages = append(messages, resource+" is modified")
+			}
+		}
+		status.State = v3.PolicyBundleDrifted
+		status.Message = joinMessages(messages)
+		return status
+	}
+
+	if !bd.Status.Ready {
+		var messages []string
+		for _, nonReady := range bd.Status.NonReadyStatus {
+			messages = append(messages, nonReady.Kind+" "+nonReady.Name+" is not ready")
+		}
+		status.State = v3.PolicyBundlePending
+		status.Message = joinMessages(messages)
+		return status
+	}
+
+	status.State = v3.PolicyBundleCompliant
+	return status
+}
+
+func joinMessages(messages []string) string {
+	sort.Strings(messages)
+	if len(messages) > maxResourceMessages {
+		return fmt.Sprintf("%s and %d more", strings.Join(messages[:maxResourceMessages], ", "), len(messages)-maxResourceMessages)
+	}
+	return strings.Join(messages, ", ")
+}
+
+// clusterPolicyBundles returns the state of the policies of the policy bundles on a cluster, sorted by policy bundle.
+func clusterPolicyBundles(clusterName string, policyBundles []*v3.PolicyBundle) []v3.ClusterPolicyBundleStatus {
+	var result []v3.ClusterPolicyBundleStatus
+	for _, pb := range policyBundles {
+		for _, status := range pb.Status.Clusters {
+			if status.ClusterName != clusterName {
+				continue
+			}
+			result = append(result, v3.ClusterPolicyBundleStatus{
+				Name:    pb.Namespace + "/" + pb.Name,
+				Engine:  pb.Spec.Engine,
+				Version: status.Version,
+				State:   status.State,
+				Message: status.Message,
+			})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
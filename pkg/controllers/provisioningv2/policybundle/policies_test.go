@@ -0,0 +1,244 @@
+package policybundle
+
+import (
+	"testing"
+
+	"github.com/rancher/fleet/pkg/apis/fleet.cattle.io/v1alpha1"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/wrangler/pkg/genericcondition"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	constraintTemplate = `apiVersion: templates.gatekeeper.sh/v1
+kind: ConstraintTemplate
+metadata:
+  name: k8srequiredlabels
+`
+	constraint = `apiVersion: constraints.gatekeeper.sh/v1beta1
+kind: K8sRequiredLabels
+metadata:
+  name: ns-must-have-owner
+---
+apiVersion: constraints.gatekeeper.sh/v1beta1
+kind: K8sRequiredLabels
+metadata:
+  name: pod-must-have-owner
+`
+	clusterPolicy = `apiVersion: kyverno.io/v1
+kind: ClusterPolicy
+metadata:
+  name: require-labels
+`
+)
+
+func TestValidatePolicies(t *testing.T) {
+	tests := []struct {
+		name string
+		spec v3.PolicyBundleSpec
+		err  string
+	}{
+		{
+			name: "gatekeeper",
+			spec: v3.PolicyBundleSpec{
+				Engine:  v3.PolicyEngineGatekeeper,
+				Version: "1.2.0",
+				Policies: []v3.PolicyBundlePolicy{
+					{Name: "template", Content: constraintTemplate},
+					{Name: "constraints", Content: constraint},
+				},
+			},
+		},
+		{
+			name: "kyverno",
+			spec: v3.PolicyBundleSpec{
+				Engine:   v3.PolicyEngineKyverno,
+				Version:  "v1",
+				Policies: []v3.PolicyBundlePolicy{{Name: "labels", Content: clusterPolicy}},
+			},
+		},
+		{
+			name: "unknown engine",
+			spec: v3.PolicyBundleSpec{Engine: "opa", Version: "1"},
+			err:  `unknown policy engine "opa", must be opa-gatekeeper or kyverno`,
+		},
+		{
+			name: "no version",
+			spec: v3.PolicyBundleSpec{Engine: v3.PolicyEngineKyverno},
+			err:  "version is required",
+		},
+		{
+			name: "invalid version",
+			spec: v3.PolicyBundleSpec{Engine: v3.PolicyEngineKyverno, Version: "1.0 beta"},
+			err:  `invalid version "1.0 beta"`,
+		},
+		{
+			name: "duplicate policy",
+			spec: v3.PolicyBundleSpec{
+				Engine:  v3.PolicyEngineKyverno,
+				Version: "1",
+				Policies: []v3.PolicyBundlePolicy{
+					{Name: "labels", Content: clusterPolicy},
+					{Name: "labels", Content: clusterPolicy},
+				},
+			},
+			err: "policy labels is defined more than once",
+		},
+		{
+			name: "empty policy",
+			spec: v3.PolicyBundleSpec{
+				Engine:   v3.PolicyEngineKyverno,
+				Version:  "1",
+				Policies: []v3.PolicyBundlePolicy{{Name: "labels"}},
+			},
+			err: "policy labels has no manifests",
+		},
+		{
+			name: "policy of other engine",
+			spec: v3.PolicyBundleSpec{
+				Engine:   v3.PolicyEngineKyverno,
+				Version:  "1",
+				Policies: []v3.PolicyBundlePolicy{{Name: "template", Content: constraintTemplate}},
+			},
+			err: "policy template has a ConstraintTemplate of API version templates.gatekeeper.sh/v1, only resources of the kyverno.io API groups are allowed for kyverno",
+		},
+		{
+			name: "other resource",
+			spec: v3.PolicyBundleSpec{
+				Engine:  v3.PolicyEngineKyverno,
+				Version: "1",
+				Policies: []v3.PolicyBundlePolicy{{Name: "rbac", Content: `apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: kyverno.io
+`}},
+			},
+			err: "policy rbac has a ClusterRole of API version rbac.authorization.k8s.io/v1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePolicies(&tt.spec)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestBundleResources(t *testing.T) {
+	assert.Equal(t, []v1alpha1.BundleResource{
+		{Name: "constraints.yaml", Content: constraint},
+		{Name: "template.yaml", Content: constraintTemplate},
+	}, bundleResources(&v3.PolicyBundleSpec{
+		Policies: []v3.PolicyBundlePolicy{
+			{Name: "template", Content: constraintTemplate},
+			{Name: "constraints", Content: constraint},
+		},
+	}))
+}
+
+func TestClusterStatus(t *testing.T) {
+	deployed := func(status v1alpha1.BundleDeploymentStatus) *v1alpha1.BundleDeployment {
+		status.AppliedDeploymentID = "s-abc:123"
+		return &v1alpha1.BundleDeployment{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{policyBundleVersionLabel: "2"}},
+			Spec:       v1alpha1.BundleDeploymentSpec{DeploymentID: "s-abc:123"},
+			Status:     status,
+		}
+	}
+	previous := v3.PolicyBundleClusterStatus{ClusterName: "c-abcde", Version: "1", State: v3.PolicyBundleCompliant}
+
+	tests := []struct {
+		name     string
+		bd       *v1alpha1.BundleDeployment
+		expected v3.PolicyBundleClusterStatus
+	}{
+		{
+			name:     "compliant",
+			bd:       deployed(v1alpha1.BundleDeploymentStatus{Ready: true, NonModified: true}),
+			expected: v3.PolicyBundleClusterStatus{ClusterName: "c-abcde", Version: "2", State: v3.PolicyBundleCompliant},
+		},
+		{
+			name: "pending",
+			bd: &v1alpha1.BundleDeployment{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{policyBundleVersionLabel: "2"}},
+				Spec:       v1alpha1.BundleDeploymentSpec{DeploymentID: "s-def:123"},
+				Status:     v1alpha1.BundleDeploymentStatus{AppliedDeploymentID: "s-abc:123", Ready: true, NonModified: true},
+			},
+			expected: v3.PolicyBundleClusterStatus{ClusterName: "c-abcde", Version: "1", State: v3.PolicyBundlePending, Message: "policies are being deployed"},
+		},
+		{
+			name: "error",
+			bd: deployed(v1alpha1.BundleDeploymentStatus{Conditions: []genericcondition.GenericCondition{
+				{Type: "Deployed", Status: corev1.ConditionFalse, Message: "no matches for kind K8sRequiredLabels"},
+			}}),
+			expected: v3.PolicyBundleClusterStatus{ClusterName: "c-abcde", Version: "1", State: v3.PolicyBundleError, Message: "no matches for kind K8sRequiredLabels"},
+		},
+		{
+			name: "drifted",
+			bd: deployed(v1alpha1.BundleDeploymentStatus{
+				Ready: true,
+				ModifiedStatus: []v1alpha1.ModifiedStatus{
+					{Kind: "K8sRequiredLabels", Name: "ns-must-have-owner", Create: true},
+					{Kind: "ConstraintTemplate", Name: "k8srequiredlabels", Patch: "{}"},
+					{Kind: "Policy", Namespace: "default", Name: "extra", Delete: true},
+				},
+			}),
+			expected: v3.PolicyBundleClusterStatus{
+				ClusterName: "c-abcde",
+				Version:     "2",
+				State:       v3.PolicyBundleDrifted,
+				Message:     "ConstraintTemplate k8srequiredlabels is modified, K8sRequiredLabels ns-must-have-owner is missing, Policy default/extra is extra",
+			},
+		},
+		{
+			name: "not ready",
+			bd: deployed(v1alpha1.BundleDeploymentStatus{
+				NonModified:    true,
+				NonReadyStatus: []v1alpha1.NonReadyStatus{{Kind: "ClusterPolicy", Name: "require-labels"}},
+			}),
+			expected: v3.PolicyBundleClusterStatus{ClusterName: "c-abcde", Version: "2", State: v3.PolicyBundlePending, Message: "ClusterPolicy require-labels is not ready"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, clusterStatus(tt.bd, "c-abcde", previous))
+		})
+	}
+}
+
+func TestJoinMessages(t *testing.T) {
+	assert.Equal(t, "a, b", joinMessages([]string{"b", "a"}))
+	assert.Equal(t, "a, b, c, d, e and 2 more", joinMessages([]string{"g", "f", "e", "d", "c", "b", "a"}))
+}
+
+func TestClusterPolicyBundles(t *testing.T) {
+	policyBundles := []*v3.PolicyBundle{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "fleet-default", Name: "restricted"},
+			Spec:       v3.PolicyBundleSpec{Engine: v3.PolicyEngineKyverno},
+			Status: v3.PolicyBundleStatus{Clusters: []v3.PolicyBundleClusterStatus{
+				{ClusterName: "c-abcde", Version: "1", State: v3.PolicyBundleDrifted, Message: "ClusterPolicy require-labels is missing"},
+				{ClusterName: "c-fghij", Version: "1", State: v3.PolicyBundleCompliant},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "fleet-default", Name: "baseline"},
+			Spec:       v3.PolicyBundleSpec{Engine: v3.PolicyEngineGatekeeper},
+			Status: v3.PolicyBundleStatus{Clusters: []v3.PolicyBundleClusterStatus{
+				{ClusterName: "c-abcde", Version: "3", State: v3.PolicyBundleCompliant},
+			}},
+		},
+	}
+
+	assert.Equal(t, []v3.ClusterPolicyBundleStatus{
+		{Name: "fleet-default/baseline", Engine: v3.PolicyEngineGatekeeper, Version: "3", State: v3.PolicyBundleCompliant},
+		{Name: "fleet-default/restricted", Engine: v3.PolicyEngineKyverno, Version: "1", State: v3.PolicyBundleDrifted, Message: "ClusterPolicy require-labels is missing"},
+	}, clusterPolicyBundles("c-abcde", policyBundles))
+	assert.Nil(t, clusterPolicyBundles("c-klmno", policyBundles))
+}
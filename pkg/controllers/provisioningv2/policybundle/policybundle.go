@@ -0,0 +1,242 @@
+package policybundle
+
+import (
+	"context"
+	"sort"
+
+	fleet "github.com/rancher/fleet/pkg/apis/fleet.cattle.io/v1alpha1"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/capr"
+	fleetcontrollers "github.com/rancher/rancher/pkg/generated/controllers/fleet.cattle.io/v1alpha1"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/wrangler"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	policyBundleLabel        = "policybundle.cattle.io/name"
+	policyBundleVersionLabel = "policybundle.cattle.io/version"
+
+	bundleNameLabel      = "fleet.cattle.io/bundle-name"
+	bundleNamespaceLabel = "fleet.cattle.io/bundle-namespace"
+	clusterNameLabel     = "management.cattle.io/cluster-name"
+
+	fleetClusterByNamespace = "policyBundleFleetClusterByNamespace"
+	clusterByPolicyBundle   = "policyBundleClusterByPolicyBundle"
+)
+
+type handler struct {
+	policyBundleCache     mgmtcontrollers.PolicyBundleCache
+	bundleCache           fleetcontrollers.BundleCache
+	bundleDeploymentCache fleetcontrollers.BundleDeploymentCache
+	fleetClusterCache     fleetcontrollers.ClusterCache
+	clusterCache          mgmtcontrollers.ClusterCache
+	clusters              mgmtcontrollers.ClusterClient
+}
+
+// Register registers the policy-bundle controllers, which deploy the policies of policy bundles to the targeted
+// clusters with fleet bundles, report the state of the policies on each cluster on the policy bundles, and roll it up
+// on the management clusters.
+func Register(ctx context.Context, clients *wrangler.Context) {
+	h := &handler{
+		policyBundleCache:     clients.Mgmt.PolicyBundle().Cache(),
+		bundleCache:           clients.Fleet.Bundle().Cache(),
+		bundleDeploymentCache: clients.Fleet.BundleDeployment().Cache(),
+		fleetClusterCache:     clients.Fleet.Cluster().Cache(),
+		clusterCache:          clients.Mgmt.Cluster().Cache(),
+		clusters:              clients.Mgmt.Cluster(),
+	}
+
+	clients.Fleet.Cluster().Cache().AddIndexer(fleetClusterByNamespace, func(obj *fleet.Cluster) ([]string, error) {
+		if obj.Status.Namespace == "" {
+			return nil, nil
+		}
+		return []string{obj.Status.Namespace}, nil
+	})
+	clients.Mgmt.Cluster().Cache().AddIndexer(clusterByPolicyBundle, func(obj *v3.Cluster) ([]string, error) {
+		var result []string
+		for _, pb := range obj.Status.PolicyBundles {
+			result = append(result, pb.Name)
+		}
+		return result, nil
+	})
+
+	relatedresource.Watch(ctx,
+		"policy-bundle-trigger",
+		h.resolvePolicyBundle,
+		clients.Mgmt.PolicyBundle(),
+		clients.Fleet.Bundle(),
+		clients.Fleet.BundleDeployment())
+	mgmtcontrollers.RegisterPolicyBundleGeneratingHandler(ctx,
+		clients.Mgmt.PolicyBundle(),
+		clients.Apply.
+			WithSetOwnerReference(true, true).
+			WithCacheTypes(
+				clients.Mgmt.PolicyBundle(),
+				clients.Fleet.Bundle()),
+		"Defined",
+		"policy-bundle",
+		h.OnChange,
+		nil)
+
+	relatedresource.Watch(ctx,
+		"policy-bundle-cluster-trigger",
+		h.resolveClusters,
+		clients.Mgmt.Cluster(),
+		clients.Mgmt.PolicyBundle())
+	clients.Mgmt.Cluster().OnChange(ctx, "policy-bundle-cluster", h.OnClusterChange)
+}
+
+// resolvePolicyBundle enqueues the policy bundle of a fleet bundle or bundle deployment.
+func (h *handler) resolvePolicyBundle(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+	switch obj := obj.(type) {
+	case *fleet.Bundle:
+		if pb := obj.Labels[policyBundleLabel]; pb != "" {
+			return []relatedresource.Key{{Namespace: obj.Namespace, Name: pb}}, nil
+		}
+	case *fleet.BundleDeployment:
+		bundleNamespace, bundleName := obj.Labels[bundleNamespaceLabel], obj.Labels[bundleNameLabel]
+		if bundleNamespace == "" || bundleName == "" {
+			return nil, nil
+		}
+		bundle, err := h.bundleCache.Get(bundleNamespace, bundleName)
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if pb := bundle.Labels[policyBundleLabel]; pb != "" {
+			return []relatedresource.Key{{Namespace: bundle.Namespace, Name: pb}}, nil
+		}
+	}
+	return nil, nil
+}
+
+// resolveClusters enqueues the management clusters a policy bundle targets or targeted.
+func (h *handler) resolveClusters(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+	clusters, err := h.clusterCache.GetByIndex(clusterByPolicyBundle, namespace+"/"+name)
+	if err != nil {
+		return nil, err
+	}
+	var keys []relatedresource.Key
+	for _, cluster := range clusters {
+		keys = append(keys, relatedresource.Key{Name: cluster.Name})
+	}
+	if pb, ok := obj.(*v3.PolicyBundle); ok {
+		for _, status := range pb.Status.Clusters {
+			keys = append(keys, relatedresource.Key{Name: status.ClusterName})
+		}
+	}
+	return keys, nil
+}
+
+func (h *handler) OnChange(pb *v3.PolicyBundle, status v3.PolicyBundleStatus) ([]runtime.Object, v3.PolicyBundleStatus, error) {
+	if err := validatePolicies(&pb.Spec); err != nil {
+		return nil, status, err
+	}
+
+	bundle := &fleet.Bundle{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      capr.SafeConcatName(capr.MaxHelmReleaseNameLength, "policy", pb.Name),
+			Namespace: pb.Namespace,
+			Labels: map[string]string{
+				policyBundleLabel:        pb.Name,
+				policyBundleVersionLabel: pb.Spec.Version,
+			},
+		},
+		Spec: fleet.BundleSpec{
+			Resources:       bundleResources(&pb.Spec),
+			Paused:          pb.Spec.Paused,
+			RolloutStrategy: pb.Spec.RolloutStrategy,
+			Targets:         pb.Spec.Targets,
+		},
+	}
+
+	status, err := h.updateStatus(status, bundle)
+	return []runtime.Object{
+		bundle,
+	}, status, err
+}
+
+// updateStatus sets the state of the policies on each cluster the bundle is deployed to.
+func (h *handler) updateStatus(status v3.PolicyBundleStatus, bundle *fleet.Bundle) (v3.PolicyBundleStatus, error) {
+	bds, err := h.bundleDeploymentCache.List("", labels.SelectorFromSet(labels.Set{
+		bundleNamespaceLabel: bundle.Namespace,
+		bundleNameLabel:      bundle.Name,
+	}))
+	if err != nil {
+		return status, err
+	}
+
+	previous := map[string]v3.PolicyBundleClusterStatus{}
+	for _, cluster := range status.Clusters {
+		previous[cluster.ClusterName] = cluster
+	}
+
+	var clusters []v3.PolicyBundleClusterStatus
+	for _, bd := range bds {
+		clusterName, err := h.clusterName(bd)
+		if err != nil {
+			return status, err
+		}
+		if clusterName == "" {
+			continue
+		}
+		clusters = append(clusters, clusterStatus(bd, clusterName, previous[clusterName]))
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].ClusterName < clusters[j].ClusterName
+	})
+
+	status.Clusters = clusters
+	status.CompliantClusters, status.DriftedClusters = 0, 0
+	for _, cluster := range clusters {
+		switch cluster.State {
+		case v3.PolicyBundleCompliant:
+			status.CompliantClusters++
+		case v3.PolicyBundleDrifted:
+			status.DriftedClusters++
+		}
+	}
+	return status, nil
+}
+
+// clusterName returns the name of the management cluster of the fleet cluster a bundle deployment is in the namespace
+// of, empty if there is none.
+func (h *handler) clusterName(bd *fleet.BundleDeployment) (string, error) {
+	fleetClusters, err := h.fleetClusterCache.GetByIndex(fleetClusterByNamespace, bd.Namespace)
+	if err != nil || len(fleetClusters) == 0 {
+		return "", err
+	}
+	return fleetClusters[0].Labels[clusterNameLabel], nil
+}
+
+// OnClusterChange sets the state of the policies of the policy bundles of the fleet workspace of a cluster targeting
+// it on the cluster.
+func (h *handler) OnClusterChange(key string, cluster *v3.Cluster) (*v3.Cluster, error) {
+	if cluster == nil || cluster.DeletionTimestamp != nil {
+		return cluster, nil
+	}
+
+	var policyBundles []*v3.PolicyBundle
+	if cluster.Spec.FleetWorkspaceName != "" {
+		var err error
+		policyBundles, err = h.policyBundleCache.List(cluster.Spec.FleetWorkspaceName, labels.Everything())
+		if err != nil {
+			return cluster, err
+		}
+	}
+
+	result := clusterPolicyBundles(cluster.Name, policyBundles)
+	if equality.Semantic.DeepEqual(result, cluster.Status.PolicyBundles) {
+		return cluster, nil
+	}
+	cluster = cluster.DeepCopy()
+	cluster.Status.PolicyBundles = result
+	return h.clusters.Update(cluster)
+}
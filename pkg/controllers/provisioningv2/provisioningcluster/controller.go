@@ -11,6 +11,7 @@
 	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
 	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
 	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/rancher/pkg/controllers/provisioningv2/admission"
 	"github.com/rancher/rancher/pkg/features"
 	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
 	mgmtcontroller "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
@@ -74,7 +75,7 @@ func Register(ctx context.Context, clients *wrangler.Context) {
 	clients.Dynamic.OnChange(ctx, "rke-dynamic", matchRKENodeGroup, h.infraWatch)
 	clients.Provisioning.Cluster().Cache().AddIndexer(byNodeInfra, byNodeInfraIndex)
 
-	rocontrollers.RegisterClusterGeneratingHandler(ctx,
+	admission.RegisterClusterGeneratingHandler(ctx,
 		clients.Provisioning.Cluster(),
 		clients.Apply.
 			// Because capi wants to own objects we don't set ownerreference with apply
@@ -245,6 +246,14 @@ func reconcileClusterSpecEtcdRestore(cluster *rancherv1.Cluster, desiredSpec ran
 		changed = true
 		cluster.Spec.RKEConfig.Registries = desiredSpec.RKEConfig.Registries
 	}
+	if !equality.Semantic.DeepEqual(cluster.Spec.RKEConfig.EmbeddedRegistry, desiredSpec.RKEConfig.EmbeddedRegistry) {
+		changed = true
+		cluster.Spec.RKEConfig.EmbeddedRegistry = desiredSpec.RKEConfig.EmbeddedRegistry
+	}
+	if !equality.Semantic.DeepEqual(cluster.Spec.RKEConfig.NodeLocalDNS, desiredSpec.RKEConfig.NodeLocalDNS) {
+		changed = true
+		cluster.Spec.RKEConfig.NodeLocalDNS = desiredSpec.RKEConfig.NodeLocalDNS
+	}
 	if !equality.Semantic.DeepEqual(cluster.Spec.RKEConfig.UpgradeStrategy, desiredSpec.RKEConfig.UpgradeStrategy) {
 		changed = true
 		cluster.Spec.RKEConfig.UpgradeStrategy = desiredSpec.RKEConfig.UpgradeStrategy
@@ -304,9 +313,11 @@ func (h *handler) OnRancherClusterChange(obj *rancherv1.Cluster, status rancherv
 
 	// If the rkecontrolplane is not nil, we can check it to determine action items.
 	if rkeCP != nil {
-		// If EtcdSnapshotRestore is not nil, we need to check to see if we need to update the cluster object it.
+		// If EtcdSnapshotRestore is not nil, we need to check to see if we need to update the cluster object it. The
+		// RKE config is never restored by the restore of namespaces.
 		if obj.Spec.RKEConfig.ETCDSnapshotRestore != nil &&
 			obj.Spec.RKEConfig.ETCDSnapshotRestore.Name != "" &&
+			len(obj.Spec.RKEConfig.ETCDSnapshotRestore.Namespaces) == 0 &&
 			obj.Spec.RKEConfig.ETCDSnapshotRestore.RestoreRKEConfig != "" &&
 			obj.Spec.RKEConfig.ETCDSnapshotRestore.RestoreRKEConfig != restoreRKEConfigNone {
 			logrus.Debugf("rkecluster %s/%s: Reconciling rkeconfig against specified etcd restore snapshot metadata", obj.Namespace, obj.Name)
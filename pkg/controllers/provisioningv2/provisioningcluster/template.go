@@ -17,6 +17,8 @@
 	"github.com/rancher/rancher/pkg/capr/planner"
 	"github.com/rancher/rancher/pkg/controllers/capr/machineprovision"
 	mgmtcontroller "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/removedapis"
+	"github.com/rancher/rancher/pkg/settings"
 	"github.com/rancher/wrangler/pkg/apply"
 	"github.com/rancher/wrangler/pkg/data"
 	"github.com/rancher/wrangler/pkg/data/convert"
@@ -123,7 +125,7 @@ func takeOwnership(dynamic *dynamic.Controller, cluster *rancherv1.Cluster, node
 	return err
 }
 
-func toMachineTemplate(machinePoolName string, cluster *rancherv1.Cluster, machinePool rancherv1.RKEMachinePool,
+func toMachineTemplate(machinePoolName string, cluster *rancherv1.Cluster, machinePool rancherv1.RKEMachinePool, machineImage string,
 	dynamic *dynamic.Controller, secrets v1.SecretCache) (*unstructured.Unstructured, error) {
 	apiVersion := machinePool.NodeConfig.APIVersion
 	kind := machinePool.NodeConfig.Kind
@@ -158,6 +160,14 @@ func toMachineTemplate(machinePoolName string, cluster *rancherv1.Cluster, machi
 
 	pruneBySchema(machinePoolData, spec)
 
+	if machineImage != "" {
+		field := capr.MachineImageField(kind, machinePool.MachineImage)
+		if _, ok := spec.ResourceFields[field]; !ok {
+			return nil, fmt.Errorf("invalid machineImage of machinePool [%s], %s has no image field [%s]", machinePool.Name, kind, field)
+		}
+		machinePoolData.Set(field, machineImage)
+	}
+
 	commonData, err := convert.EncodeToMap(machinePool.RKECommonNodeConfig)
 	if err != nil {
 		return nil, err
@@ -209,6 +219,97 @@ func toMachineTemplate(machinePoolName string, cluster *rancherv1.Cluster, machi
 	return ustr, nil
 }
 
+// machineImageVersion returns the image rolled out to the machines of a machine pool pinning its image, empty if the
+// image of the machine config is used. Machine deployments aren't generated until the rollout of the pinned image has
+// been started in the status of the cluster, so that new images are only rolled out once the pool is healthy.
+func machineImageVersion(cluster *rancherv1.Cluster, mp rancherv1.RKEMachinePool) (string, error) {
+	if mp.MachineImage == nil || mp.MachineImage.Version == "" {
+		return "", nil
+	}
+	for _, pool := range cluster.Status.MachinePoolImages {
+		if pool.Name == mp.Name {
+			return pool.Version, nil
+		}
+	}
+	logrus.Debugf("rkecluster %s/%s: waiting for the rollout of the image of machine pool %s", cluster.Namespace, cluster.Name, mp.Name)
+	return "", generic.ErrSkip
+}
+
+// validateRenamedFrom returns an error if a machine pool is renamed from itself or from the name of another machine
+// pool.
+func validateRenamedFrom(cluster *rancherv1.Cluster, mp rancherv1.RKEMachinePool) error {
+	if mp.RenamedFrom == "" {
+		return nil
+	}
+	if mp.RenamedFrom == mp.Name {
+		return fmt.Errorf("invalid machinePool [%s], it can't be renamed from itself", mp.Name)
+	}
+	for _, pool := range cluster.Spec.RKEConfig.MachinePools {
+		if pool.Name == mp.RenamedFrom {
+			return fmt.Errorf("invalid machinePool [%s], it can't be renamed from existing machinePool [%s]", mp.Name, mp.RenamedFrom)
+		}
+		if pool.Name != mp.Name && pool.RenamedFrom == mp.RenamedFrom {
+			return fmt.Errorf("invalid machinePool [%s], machinePool [%s] is also renamed from [%s]", mp.Name, pool.Name, mp.RenamedFrom)
+		}
+	}
+	return nil
+}
+
+// machinePoolMigration returns the migration of the machines of the machine pool a machine pool was renamed from, nil
+// if it wasn't renamed. Machine deployments aren't generated until the migration has been started in the status of the
+// cluster, so that the machine deployment of the previous name isn't deleted before its machines are moved.
+func machinePoolMigration(cluster *rancherv1.Cluster, mp rancherv1.RKEMachinePool) (*rancherv1.MachinePoolMigrationStatus, error) {
+	for i, migration := range cluster.Status.MachinePoolMigrations {
+		if migration.PoolName == mp.Name && (mp.RenamedFrom == "" || migration.From == mp.RenamedFrom) {
+			return &cluster.Status.MachinePoolMigrations[i], nil
+		}
+	}
+	if mp.RenamedFrom == "" {
+		return nil, nil
+	}
+	logrus.Debugf("rkecluster %s/%s: waiting for the migration of machine pool %s to %s", cluster.Namespace, cluster.Name, mp.RenamedFrom, mp.Name)
+	return nil, generic.ErrSkip
+}
+
+// machineReplacementSurge returns the number of machines a machine pool is scaled up by for the substitutes of the
+// machines being replaced. A machine stops counting once it's marked for deletion, so that it's the machine deleted when
+// the pool is scaled down.
+func machineReplacementSurge(cluster *rancherv1.Cluster, poolName string) int32 {
+	var surge int32
+	for _, replacement := range cluster.Status.MachineReplacements {
+		if replacement.PoolName == poolName && replacement.ID != "" && replacement.Phase != rancherv1.MachineReplacementDeleting {
+			surge++
+		}
+	}
+	return surge
+}
+
+// machineDeploymentStrategy returns the rolling update strategy of the machine deployment of a machine pool. Machines
+// of the oldest machine sets are deleted first unless the machine pool rolls out the newest first.
+func machineDeploymentStrategy(mp rancherv1.RKEMachinePool) (*capi.MachineDeploymentStrategy, error) {
+	deletePolicy := capi.OldestMachineSetDeletePolicy
+	rollingUpdate := &capi.MachineRollingUpdateDeployment{}
+	if mp.RollingUpdate != nil {
+		rollingUpdate.MaxSurge = mp.RollingUpdate.MaxSurge
+		rollingUpdate.MaxUnavailable = mp.RollingUpdate.MaxUnavailable
+		switch mp.RollingUpdate.RolloutOrder {
+		case "", rancherv1.RKEMachinePoolRolloutOrderOldestFirst:
+		case rancherv1.RKEMachinePoolRolloutOrderNewestFirst:
+			deletePolicy = capi.NewestMachineSetDeletePolicy
+		default:
+			return nil, fmt.Errorf("invalid rollout order [%s] of machinePool [%s], must be %s or %s", mp.RollingUpdate.RolloutOrder, mp.Name,
+				rancherv1.RKEMachinePoolRolloutOrderOldestFirst, rancherv1.RKEMachinePoolRolloutOrderNewestFirst)
+		}
+	}
+	rollingUpdate.DeletePolicy = &[]string{string(deletePolicy)}[0]
+
+	return &capi.MachineDeploymentStrategy{
+		// RollingUpdate is the default, so no harm in setting it here.
+		Type:          capi.RollingUpdateMachineDeploymentStrategyType,
+		RollingUpdate: rollingUpdate,
+	}, nil
+}
+
 func populateHostnameLengthLimitAnnotation(mp rancherv1.RKEMachinePool, cluster *rancherv1.Cluster, annotations map[string]string) error {
 	if cluster == nil {
 		return errors.New("cannot add hostname length limit annotation for nil cluster")
@@ -312,15 +413,36 @@ func machineDeployments(cluster *rancherv1.Cluster, capiCluster *capi.Cluster, d
 		if machinePoolNames[machinePool.Name] {
 			return nil, fmt.Errorf("duplicate machinePool name [%s] used", machinePool.Name)
 		}
+		if err := validateRenamedFrom(cluster, machinePool); err != nil {
+			return nil, err
+		}
+		if err := capr.ValidateGarbageCollection(machinePool.GarbageCollection); err != nil {
+			return nil, fmt.Errorf("invalid garbageCollection of machinePool [%s]: %w", machinePool.Name, err)
+		}
+		machineImage, err := machineImageVersion(cluster, machinePool)
+		if err != nil {
+			return nil, err
+		}
+		migration, err := machinePoolMigration(cluster, machinePool)
+		if err != nil {
+			return nil, err
+		}
+		if err := capr.ValidateMachinePoolTaints(capr.GetRuntime(cluster.Spec.KubernetesVersion), machinePool.EtcdRole, machinePool.ControlPlaneRole, machinePool.WorkerRole, machinePool.Taints); err != nil {
+			return nil, fmt.Errorf("invalid taints of machinePool [%s]: %w", machinePool.Name, err)
+		}
 		machinePoolNames[machinePool.Name] = true
 
 		var (
 			machineDeploymentName = name.SafeConcatName(cluster.Name, machinePool.Name)
+			machineTemplateName   = machineDeploymentName
 			infraRef              corev1.ObjectReference
 		)
+		if migration != nil && migration.TemplateName != "" {
+			machineTemplateName = migration.TemplateName
+		}
 
 		if machinePool.NodeConfig.APIVersion == "" || machinePool.NodeConfig.APIVersion == "rke-machine-config.cattle.io/v1" {
-			machineTemplate, err := toMachineTemplate(machineDeploymentName, cluster, machinePool, dynamic, secrets)
+			machineTemplate, err := toMachineTemplate(machineTemplateName, cluster, machinePool, machineImage, dynamic, secrets)
 			if err != nil {
 				return nil, err
 			}
@@ -332,6 +454,8 @@ func machineDeployments(cluster *rancherv1.Cluster, capiCluster *capi.Cluster, d
 				Namespace:  machineTemplate.GetNamespace(),
 				Name:       machineTemplate.GetName(),
 			}
+		} else if machinePool.MachineImage != nil {
+			return nil, fmt.Errorf("invalid machinePool [%s], machineImage can only be pinned for machine configs of node drivers", machinePool.Name)
 		} else {
 			infraRef = *machinePool.NodeConfig
 		}
@@ -358,29 +482,47 @@ func machineDeployments(cluster *rancherv1.Cluster, capiCluster *capi.Cluster, d
 			machineSpecAnnotations[capi.ExcludeNodeDrainingAnnotation] = "true"
 		}
 
-		err := populateHostnameLengthLimitAnnotation(machinePool, cluster, machineSpecAnnotations)
+		if machineImage != "" {
+			machineSpecAnnotations[capr.MachineImageAnnotation] = machineImage
+		}
+
+		err = populateHostnameLengthLimitAnnotation(machinePool, cluster, machineSpecAnnotations)
+		if err != nil {
+			return nil, err
+		}
+
+		strategy, err := machineDeploymentStrategy(machinePool)
 		if err != nil {
 			return nil, err
 		}
 
+		replicas := machinePool.Quantity
+		if surge := machineReplacementSurge(cluster, machinePool.Name); replicas != nil && surge > 0 {
+			surged := *replicas + surge
+			replicas = &surged
+		}
+
+		machineDeploymentAnnotations := machinePool.MachineDeploymentAnnotations
+		if migration != nil && migration.Phase == rancherv1.MachinePoolMigrationMoving {
+			// the machine deployment doesn't create machines until those of the machine pool it was renamed from are moved
+			machineDeploymentAnnotations = map[string]string{}
+			for k, v := range machinePool.MachineDeploymentAnnotations {
+				machineDeploymentAnnotations[k] = v
+			}
+			machineDeploymentAnnotations[capi.PausedAnnotation] = "true"
+		}
+
 		machineDeployment := &capi.MachineDeployment{
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace:   cluster.Namespace,
 				Name:        machineDeploymentName,
 				Labels:      machineDeploymentLabels,
-				Annotations: machinePool.MachineDeploymentAnnotations,
+				Annotations: machineDeploymentAnnotations,
 			},
 			Spec: capi.MachineDeploymentSpec{
 				ClusterName: capiCluster.Name,
-				Replicas:    machinePool.Quantity,
-				Strategy: &capi.MachineDeploymentStrategy{
-					// RollingUpdate is the default, so no harm in setting it here.
-					Type: capi.RollingUpdateMachineDeploymentStrategyType,
-					RollingUpdate: &capi.MachineRollingUpdateDeployment{
-						// Delete oldest machines by default.
-						DeletePolicy: &[]string{string(capi.OldestMachineSetDeletePolicy)}[0],
-					},
-				},
+				Replicas:    replicas,
+				Strategy:    strategy,
 				Template: capi.MachineTemplateSpec{
 					ObjectMeta: capi.ObjectMeta{
 						Labels: map[string]string{
@@ -408,11 +550,6 @@ func machineDeployments(cluster *rancherv1.Cluster, capiCluster *capi.Cluster, d
 				Paused: machinePool.Paused,
 			},
 		}
-		if machinePool.RollingUpdate != nil {
-			machineDeployment.Spec.Strategy.RollingUpdate.MaxSurge = machinePool.RollingUpdate.MaxSurge
-			machineDeployment.Spec.Strategy.RollingUpdate.MaxUnavailable = machinePool.RollingUpdate.MaxUnavailable
-		}
-
 		if machinePool.EtcdRole {
 			machineDeployment.Spec.Template.Labels[capr.EtcdRoleLabel] = "true"
 		}
@@ -447,6 +584,12 @@ func machineDeployments(cluster *rancherv1.Cluster, capiCluster *capi.Cluster, d
 			}
 		}
 
+		if machinePool.GarbageCollection != nil {
+			if err := assign(machineDeployment.Spec.Template.Annotations, capr.GarbageCollectionAnnotation, machinePool.GarbageCollection); err != nil {
+				return nil, err
+			}
+		}
+
 		result = append(result, machineDeployment)
 
 		// if a health check timeout was specified create health checks for this machine pool
@@ -518,9 +661,14 @@ func rkeCluster(cluster *rancherv1.Cluster) *rkev1.RKECluster {
 
 // rkeControlPlane generates the rkecontrolplane object for a provided cluster object
 func rkeControlPlane(cluster *rancherv1.Cluster) (*rkev1.RKEControlPlane, error) {
+	kubernetesVersion, err := controlPlaneKubernetesVersion(cluster)
+	if err != nil {
+		return nil, err
+	}
 	// We need to base64/gzip encode the spec of our rancherv1.Cluster object so that we can reference it from the
 	// downstream cluster
 	filteredClusterSpec := cluster.Spec.DeepCopy()
+	filteredClusterSpec.KubernetesVersion = kubernetesVersion
 	// set the corresponding specification for various operations to nil as these cause unnecessary reconciliation.
 	filteredClusterSpec.RKEConfig.ETCDSnapshotRestore = nil
 	filteredClusterSpec.RKEConfig.ETCDSnapshotCreate = nil
@@ -550,7 +698,7 @@ func rkeControlPlane(cluster *rancherv1.Cluster) (*rkev1.RKEControlPlane, error)
 			ETCDSnapshotCreate:       rkeConfig.ETCDSnapshotCreate,
 			RotateCertificates:       rkeConfig.RotateCertificates,
 			RotateEncryptionKeys:     rkeConfig.RotateEncryptionKeys,
-			KubernetesVersion:        cluster.Spec.KubernetesVersion,
+			KubernetesVersion:        kubernetesVersion,
 			ManagementClusterName:    cluster.Status.ClusterName, // management cluster
 			AgentEnvVars:             cluster.Spec.AgentEnvVars,
 			ClusterName:              cluster.Name, // cluster name is for the CAPI cluster
@@ -558,6 +706,26 @@ func rkeControlPlane(cluster *rancherv1.Cluster) (*rkev1.RKEControlPlane, error)
 	}, nil
 }
 
+// controlPlaneKubernetesVersion returns the Kubernetes version of the control plane of a cluster, the version the
+// cluster is upgraded from while the removed API upgrade gate holds the upgrade, and waits for the upgrade scan of a new
+// version to hold it or not.
+func controlPlaneKubernetesVersion(cluster *rancherv1.Cluster) (string, error) {
+	scan := cluster.Status.UpgradeScan
+	if scan == nil || !removedapis.GateEnabled(cluster, settings.RemovedAPIUpgradeGate.Get()) {
+		return cluster.Spec.KubernetesVersion, nil
+	}
+	if scan.TargetVersion != cluster.Spec.KubernetesVersion {
+		logrus.Debugf("rkecluster %s/%s: waiting for the upgrade scan of kubernetes version %s", cluster.Namespace, cluster.Name, cluster.Spec.KubernetesVersion)
+		return "", generic.ErrSkip
+	}
+	if !scan.Passed {
+		logrus.Debugf("rkecluster %s/%s: holding the upgrade to kubernetes version %s until objects no longer use the APIs it removes",
+			cluster.Namespace, cluster.Name, cluster.Spec.KubernetesVersion)
+		return scan.CurrentVersion, nil
+	}
+	return cluster.Spec.KubernetesVersion, nil
+}
+
 func capiCluster(cluster *rancherv1.Cluster, rkeControlPlane *rkev1.RKEControlPlane, infraRef *corev1.ObjectReference) *capi.Cluster {
 	gvk, err := gvk.Get(rkeControlPlane)
 	if err != nil {
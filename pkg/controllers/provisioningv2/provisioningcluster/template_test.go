@@ -4,7 +4,11 @@
 	"testing"
 
 	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/generic"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 func TestPopulateHostnameLengthLimitAnnotation(t *testing.T) {
@@ -94,3 +98,174 @@ func TestPopulateHostnameLengthLimitAnnotation(t *testing.T) {
 		})
 	}
 }
+
+func TestMachineDeploymentStrategy(t *testing.T) {
+	maxSurge := intstr.FromString("25%")
+	maxUnavailable := intstr.FromInt(1)
+	tests := []struct {
+		name                   string
+		rollingUpdate          *provv1.RKEMachinePoolRollingUpdate
+		expectedDeletePolicy   capi.MachineSetDeletePolicy
+		expectedMaxSurge       *intstr.IntOrString
+		expectedMaxUnavailable *intstr.IntOrString
+		expectedErr            string
+	}{
+		{
+			name:                 "default",
+			expectedDeletePolicy: capi.OldestMachineSetDeletePolicy,
+		},
+		{
+			name:                   "max surge and unavailable",
+			rollingUpdate:          &provv1.RKEMachinePoolRollingUpdate{MaxSurge: &maxSurge, MaxUnavailable: &maxUnavailable},
+			expectedDeletePolicy:   capi.OldestMachineSetDeletePolicy,
+			expectedMaxSurge:       &maxSurge,
+			expectedMaxUnavailable: &maxUnavailable,
+		},
+		{
+			name:                 "oldest first",
+			rollingUpdate:        &provv1.RKEMachinePoolRollingUpdate{RolloutOrder: provv1.RKEMachinePoolRolloutOrderOldestFirst},
+			expectedDeletePolicy: capi.OldestMachineSetDeletePolicy,
+		},
+		{
+			name:                 "newest first",
+			rollingUpdate:        &provv1.RKEMachinePoolRollingUpdate{RolloutOrder: provv1.RKEMachinePoolRolloutOrderNewestFirst},
+			expectedDeletePolicy: capi.NewestMachineSetDeletePolicy,
+		},
+		{
+			name:          "invalid order",
+			rollingUpdate: &provv1.RKEMachinePoolRollingUpdate{RolloutOrder: "Random"},
+			expectedErr:   "invalid rollout order [Random] of machinePool [invalid order], must be OldestFirst or NewestFirst",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy, err := machineDeploymentStrategy(provv1.RKEMachinePool{Name: tt.name, RollingUpdate: tt.rollingUpdate})
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, capi.RollingUpdateMachineDeploymentStrategyType, strategy.Type)
+			assert.Equal(t, string(tt.expectedDeletePolicy), *strategy.RollingUpdate.DeletePolicy)
+			assert.Equal(t, tt.expectedMaxSurge, strategy.RollingUpdate.MaxSurge)
+			assert.Equal(t, tt.expectedMaxUnavailable, strategy.RollingUpdate.MaxUnavailable)
+		})
+	}
+}
+
+func TestMachineReplacementSurge(t *testing.T) {
+	cluster := &provv1.Cluster{
+		Status: provv1.ClusterStatus{
+			MachineReplacements: []provv1.MachineReplacementStatus{
+				{MachineName: "m-1", PoolName: "pool1", ID: "r1", Phase: provv1.MachineReplacementProvisioning},
+				{MachineName: "m-2", PoolName: "pool1", ID: "r2", Phase: provv1.MachineReplacementMigrating},
+				{MachineName: "m-3", PoolName: "pool1", ID: "r3", Phase: provv1.MachineReplacementDeleting},
+				{MachineName: "m-4", PoolName: "pool1", Phase: provv1.MachineReplacementFailed},
+				{MachineName: "m-5", PoolName: "pool2", ID: "r5", Phase: provv1.MachineReplacementFailed},
+			},
+		},
+	}
+	assert.Equal(t, int32(2), machineReplacementSurge(cluster, "pool1"))
+	assert.Equal(t, int32(1), machineReplacementSurge(cluster, "pool2"))
+	assert.Equal(t, int32(0), machineReplacementSurge(cluster, "pool3"))
+}
+
+func TestMachinePoolMigration(t *testing.T) {
+	cluster := &provv1.Cluster{
+		Spec: provv1.ClusterSpec{
+			RKEConfig: &provv1.RKEConfig{
+				MachinePools: []provv1.RKEMachinePool{
+					{Name: "pool1", RenamedFrom: "workers"},
+					{Name: "pool2"},
+				},
+			},
+		},
+		Status: provv1.ClusterStatus{
+			MachinePoolMigrations: []provv1.MachinePoolMigrationStatus{
+				{PoolName: "pool1", From: "workers", TemplateName: "c-workers", Phase: provv1.MachinePoolMigrationMoving},
+				{PoolName: "pool2", From: "old", TemplateName: "c-old", Phase: provv1.MachinePoolMigrationCompleted},
+			},
+		},
+	}
+
+	migration, err := machinePoolMigration(cluster, cluster.Spec.RKEConfig.MachinePools[0])
+	assert.NoError(t, err)
+	assert.Equal(t, "c-workers", migration.TemplateName)
+
+	// the template name of a completed migration is kept once the pool no longer references its previous name
+	migration, err = machinePoolMigration(cluster, cluster.Spec.RKEConfig.MachinePools[1])
+	assert.NoError(t, err)
+	assert.Equal(t, "c-old", migration.TemplateName)
+
+	migration, err = machinePoolMigration(cluster, provv1.RKEMachinePool{Name: "pool1", RenamedFrom: "other"})
+	assert.Equal(t, generic.ErrSkip, err)
+	assert.Nil(t, migration)
+
+	migration, err = machinePoolMigration(cluster, provv1.RKEMachinePool{Name: "pool3"})
+	assert.NoError(t, err)
+	assert.Nil(t, migration)
+
+	assert.NoError(t, validateRenamedFrom(cluster, cluster.Spec.RKEConfig.MachinePools[0]))
+	assert.Error(t, validateRenamedFrom(cluster, provv1.RKEMachinePool{Name: "pool3", RenamedFrom: "pool3"}))
+	assert.Error(t, validateRenamedFrom(cluster, provv1.RKEMachinePool{Name: "pool3", RenamedFrom: "pool2"}))
+	assert.Error(t, validateRenamedFrom(cluster, provv1.RKEMachinePool{Name: "pool3", RenamedFrom: "workers"}))
+}
+
+func TestControlPlaneKubernetesVersion(t *testing.T) {
+	tests := []struct {
+		name            string
+		gate            string
+		scan            *provv1.UpgradeScanStatus
+		expectedVersion string
+		expectedErr     error
+	}{
+		{
+			name:            "gate disabled",
+			gate:            "false",
+			scan:            &provv1.UpgradeScanStatus{CurrentVersion: "v1.24.13+rke2r1", TargetVersion: "v1.25.9+rke2r1"},
+			expectedVersion: "v1.25.9+rke2r1",
+		},
+		{
+			name:            "cluster without control plane",
+			gate:            "true",
+			expectedVersion: "v1.25.9+rke2r1",
+		},
+		{
+			name:        "new version not scanned",
+			gate:        "true",
+			scan:        &provv1.UpgradeScanStatus{CurrentVersion: "v1.24.13+rke2r1", TargetVersion: "v1.24.13+rke2r1", Passed: true},
+			expectedErr: generic.ErrSkip,
+		},
+		{
+			name:            "upgrade held",
+			gate:            "true",
+			scan:            &provv1.UpgradeScanStatus{CurrentVersion: "v1.24.13+rke2r1", TargetVersion: "v1.25.9+rke2r1"},
+			expectedVersion: "v1.24.13+rke2r1",
+		},
+		{
+			name:            "scan passed",
+			gate:            "true",
+			scan:            &provv1.UpgradeScanStatus{CurrentVersion: "v1.24.13+rke2r1", TargetVersion: "v1.25.9+rke2r1", Passed: true},
+			expectedVersion: "v1.25.9+rke2r1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := &provv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{provv1.RemovedAPIUpgradeGateAnnotation: tt.gate},
+				},
+				Spec:   provv1.ClusterSpec{KubernetesVersion: "v1.25.9+rke2r1"},
+				Status: provv1.ClusterStatus{UpgradeScan: tt.scan},
+			}
+			version, err := controlPlaneKubernetesVersion(cluster)
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedVersion, version)
+		})
+	}
+}
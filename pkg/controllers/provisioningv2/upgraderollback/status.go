@@ -0,0 +1,74 @@
+package upgraderollback
+
+import (
+	"fmt"
+	"sort"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// rollbackStatus returns the progress of the rollback of the upgrade of a cluster: the pre-upgrade etcd snapshot is
+// restored with the Kubernetes version it was taken at, then the control plane and the machines of all the pools are
+// reconciled to the version.
+func rollbackStatus(rollback *rkev1.UpgradeRollback, cluster *rancherv1.Cluster, controlPlane *rkev1.RKEControlPlane, machines []*capi.Machine) rancherv1.UpgradeRollbackStatus {
+	status := rancherv1.UpgradeRollbackStatus{
+		ID:           rollback.ID,
+		SnapshotName: rollback.SnapshotName,
+		FromVersion:  rollback.FromVersion,
+		ToVersion:    rollback.ToVersion,
+	}
+
+	restore := cluster.Spec.RKEConfig.ETCDSnapshotRestore
+	if restore == nil || restore.Name != rollback.SnapshotName {
+		status.Phase = rancherv1.UpgradeRollbackFailed
+		status.Message = "the etcd snapshot restore of the cluster was changed"
+		return status
+	}
+	if controlPlane == nil || controlPlane.Status.ETCDSnapshotRestore == nil || !equality.Semantic.DeepEqual(controlPlane.Status.ETCDSnapshotRestore, restore) {
+		status.Phase = rancherv1.UpgradeRollbackRestoring
+		status.Message = fmt.Sprintf("waiting for the restore of etcd snapshot %s to start", rollback.SnapshotName)
+		return status
+	}
+	switch controlPlane.Status.ETCDSnapshotRestorePhase {
+	case rkev1.ETCDSnapshotPhaseFailed:
+		status.Phase = rancherv1.UpgradeRollbackFailed
+		status.Message = fmt.Sprintf("failed to restore etcd snapshot %s", rollback.SnapshotName)
+		return status
+	case rkev1.ETCDSnapshotPhaseFinished:
+	default:
+		status.Phase = rancherv1.UpgradeRollbackRestoring
+		status.Message = fmt.Sprintf("restoring etcd snapshot %s: %s", rollback.SnapshotName, controlPlane.Status.ETCDSnapshotRestorePhase)
+		return status
+	}
+
+	if cluster.Spec.KubernetesVersion != rollback.ToVersion {
+		status.Phase = rancherv1.UpgradeRollbackFailed
+		status.Message = fmt.Sprintf("the kubernetes version of the cluster was changed to %s", cluster.Spec.KubernetesVersion)
+		return status
+	}
+	for _, machine := range machines {
+		if !machine.DeletionTimestamp.IsZero() || machine.Status.NodeInfo == nil {
+			continue
+		}
+		if machine.Status.NodeInfo.KubeletVersion != rollback.ToVersion {
+			status.PendingMachines = append(status.PendingMachines, machine.Name)
+		}
+	}
+	sort.Strings(status.PendingMachines)
+
+	switch {
+	case !capr.ControlPlaneSettled(controlPlane):
+		status.Phase = rancherv1.UpgradeRollbackReconciling
+		status.Message = fmt.Sprintf("waiting for the control plane to be reconciled to kubernetes version %s", rollback.ToVersion)
+	case len(status.PendingMachines) > 0:
+		status.Phase = rancherv1.UpgradeRollbackReconciling
+		status.Message = fmt.Sprintf("waiting for %d machines to run kubernetes version %s", len(status.PendingMachines), rollback.ToVersion)
+	default:
+		status.Phase = rancherv1.UpgradeRollbackComplete
+	}
+	return status
+}
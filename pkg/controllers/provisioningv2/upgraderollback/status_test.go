@@ -0,0 +1,137 @@
+package upgraderollback
+
+import (
+	"testing"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/wrangler/pkg/genericcondition"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	fromVersion = "v1.26.4+rke2r1"
+	toVersion   = "v1.25.9+rke2r1"
+)
+
+var (
+	rollback = &rkev1.UpgradeRollback{ID: "r1", SnapshotName: "pre-upgrade", FromVersion: fromVersion, ToVersion: toVersion}
+	restore  = rkev1.ETCDSnapshotRestore{Name: "pre-upgrade", Generation: 2, RestoreRKEConfig: "kubernetesVersion"}
+)
+
+func newCluster(kubernetesVersion string, restore *rkev1.ETCDSnapshotRestore) *rancherv1.Cluster {
+	cluster := &rancherv1.Cluster{
+		Spec: rancherv1.ClusterSpec{
+			KubernetesVersion: kubernetesVersion,
+			RKEConfig:         &rancherv1.RKEConfig{},
+		},
+	}
+	cluster.Spec.RKEConfig.ETCDSnapshotRestore = restore
+	return cluster
+}
+
+func newControlPlane(restore *rkev1.ETCDSnapshotRestore, phase rkev1.ETCDSnapshotPhase, appliedVersion string) *rkev1.RKEControlPlane {
+	controlPlane := &rkev1.RKEControlPlane{
+		Spec: rkev1.RKEControlPlaneSpec{KubernetesVersion: toVersion},
+		Status: rkev1.RKEControlPlaneStatus{
+			ETCDSnapshotRestore:      restore,
+			ETCDSnapshotRestorePhase: phase,
+			AppliedSpec:              &rkev1.RKEControlPlaneSpec{KubernetesVersion: appliedVersion},
+			Conditions: []genericcondition.GenericCondition{
+				{Type: string(capr.Reconciled), Status: corev1.ConditionTrue},
+			},
+		},
+	}
+	return controlPlane
+}
+
+func newMachine(name, kubeletVersion string) *capi.Machine {
+	machine := &capi.Machine{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if kubeletVersion != "" {
+		machine.Status.NodeInfo = &corev1.NodeSystemInfo{KubeletVersion: kubeletVersion}
+	}
+	return machine
+}
+
+func TestRollbackStatus(t *testing.T) {
+	tests := []struct {
+		name            string
+		cluster         *rancherv1.Cluster
+		controlPlane    *rkev1.RKEControlPlane
+		machines        []*capi.Machine
+		expectedPhase   string
+		expectedPending []string
+	}{
+		{
+			name:          "restore changed",
+			cluster:       newCluster(toVersion, &rkev1.ETCDSnapshotRestore{Name: "other", Generation: 3}),
+			controlPlane:  newControlPlane(&restore, rkev1.ETCDSnapshotPhaseFinished, toVersion),
+			expectedPhase: rancherv1.UpgradeRollbackFailed,
+		},
+		{
+			name:          "restore not started",
+			cluster:       newCluster(fromVersion, &restore),
+			controlPlane:  newControlPlane(nil, "", fromVersion),
+			expectedPhase: rancherv1.UpgradeRollbackRestoring,
+		},
+		{
+			name:          "restoring",
+			cluster:       newCluster(toVersion, &restore),
+			controlPlane:  newControlPlane(&restore, rkev1.ETCDSnapshotPhaseRestore, fromVersion),
+			expectedPhase: rancherv1.UpgradeRollbackRestoring,
+		},
+		{
+			name:          "restore failed",
+			cluster:       newCluster(toVersion, &restore),
+			controlPlane:  newControlPlane(&restore, rkev1.ETCDSnapshotPhaseFailed, fromVersion),
+			expectedPhase: rancherv1.UpgradeRollbackFailed,
+		},
+		{
+			name:          "version changed after the restore",
+			cluster:       newCluster("v1.26.5+rke2r1", &restore),
+			controlPlane:  newControlPlane(&restore, rkev1.ETCDSnapshotPhaseFinished, toVersion),
+			expectedPhase: rancherv1.UpgradeRollbackFailed,
+		},
+		{
+			name:            "control plane not reconciled",
+			cluster:         newCluster(toVersion, &restore),
+			controlPlane:    newControlPlane(&restore, rkev1.ETCDSnapshotPhaseFinished, fromVersion),
+			machines:        []*capi.Machine{newMachine("cp-1", fromVersion)},
+			expectedPhase:   rancherv1.UpgradeRollbackReconciling,
+			expectedPending: []string{"cp-1"},
+		},
+		{
+			name:         "workers pending",
+			cluster:      newCluster(toVersion, &restore),
+			controlPlane: newControlPlane(&restore, rkev1.ETCDSnapshotPhaseFinished, toVersion),
+			machines: []*capi.Machine{
+				newMachine("worker-2", fromVersion),
+				newMachine("cp-1", toVersion),
+				newMachine("worker-1", fromVersion),
+				newMachine("worker-3", ""),
+			},
+			expectedPhase:   rancherv1.UpgradeRollbackReconciling,
+			expectedPending: []string{"worker-1", "worker-2"},
+		},
+		{
+			name:          "complete",
+			cluster:       newCluster(toVersion, &restore),
+			controlPlane:  newControlPlane(&restore, rkev1.ETCDSnapshotPhaseFinished, toVersion),
+			machines:      []*capi.Machine{newMachine("cp-1", toVersion), newMachine("worker-1", toVersion)},
+			expectedPhase: rancherv1.UpgradeRollbackComplete,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := rollbackStatus(rollback, tt.cluster, tt.controlPlane, tt.machines)
+			assert.Equal(t, tt.expectedPhase, status.Phase, status.Message)
+			assert.Equal(t, tt.expectedPending, status.PendingMachines)
+			assert.Equal(t, "r1", status.ID)
+			assert.Equal(t, toVersion, status.ToVersion)
+		})
+	}
+}
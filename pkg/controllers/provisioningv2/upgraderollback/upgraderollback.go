@@ -0,0 +1,85 @@
+package upgraderollback
+
+import (
+	"context"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
+	rocontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	rkecontroller "github.com/rancher/rancher/pkg/generated/controllers/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/wrangler"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+type handler struct {
+	rkeControlPlaneCache rkecontroller.RKEControlPlaneCache
+	capiMachineCache     capicontrollers.MachineCache
+}
+
+// Register registers the upgrade-rollback controller, which tracks the rollback of the Kubernetes upgrade of a cluster
+// requested through the API in the status of the cluster, from the restore of the pre-upgrade etcd snapshot to the
+// machines of all the pools running the version rolled back to.
+func Register(ctx context.Context, clients *wrangler.Context) {
+	h := &handler{
+		rkeControlPlaneCache: clients.RKE.RKEControlPlane().Cache(),
+		capiMachineCache:     clients.CAPI.Machine().Cache(),
+	}
+
+	rocontrollers.RegisterClusterStatusHandler(ctx, clients.Provisioning.Cluster(), "", "upgrade-rollback", h.OnChange)
+	relatedresource.Watch(ctx, "upgrade-rollback-trigger", resolve, clients.Provisioning.Cluster(), clients.RKE.RKEControlPlane(), clients.CAPI.Machine())
+}
+
+// resolve enqueues the cluster of a control plane or a machine.
+func resolve(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+	switch obj := obj.(type) {
+	case *capi.Machine:
+		return []relatedresource.Key{{Namespace: namespace, Name: obj.Spec.ClusterName}}, nil
+	case *rkev1.RKEControlPlane:
+		return []relatedresource.Key{{Namespace: namespace, Name: name}}, nil
+	}
+	return nil, nil
+}
+
+func (h *handler) OnChange(cluster *rancherv1.Cluster, status rancherv1.ClusterStatus) (rancherv1.ClusterStatus, error) {
+	if cluster.Spec.RKEConfig == nil || cluster.DeletionTimestamp != nil {
+		status.UpgradeRollback = nil
+		return status, nil
+	}
+	rollback, err := capr.ParseUpgradeRollback(cluster.Annotations)
+	if err != nil || rollback == nil {
+		status.UpgradeRollback = nil
+		return status, nil
+	}
+	if previous := status.UpgradeRollback; previous != nil && previous.ID == rollback.ID &&
+		(previous.Phase == rancherv1.UpgradeRollbackComplete || previous.Phase == rancherv1.UpgradeRollbackFailed) {
+		return status, nil
+	}
+
+	controlPlane, err := h.rkeControlPlaneCache.Get(cluster.Namespace, cluster.Name)
+	if apierrors.IsNotFound(err) {
+		controlPlane = nil
+	} else if err != nil {
+		return status, err
+	}
+	machines, err := h.capiMachineCache.List(cluster.Namespace, labels.SelectorFromSet(labels.Set{
+		capi.ClusterLabelName: cluster.Name,
+	}))
+	if err != nil {
+		return status, err
+	}
+
+	rollbackStatus := rollbackStatus(rollback, cluster, controlPlane, machines)
+	if status.UpgradeRollback == nil || status.UpgradeRollback.Phase != rollbackStatus.Phase {
+		logrus.Infof("[upgrade-rollback] rkecluster %s/%s: rollback of the upgrade to kubernetes version %s to %s: %s %s",
+			cluster.Namespace, cluster.Name, rollback.FromVersion, rollback.ToVersion, rollbackStatus.Phase, rollbackStatus.Message)
+	}
+	status.UpgradeRollback = &rollbackStatus
+	return status, nil
+}
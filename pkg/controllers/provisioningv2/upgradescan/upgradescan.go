@@ -0,0 +1,139 @@
+package upgradescan
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	rocontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	rkecontroller "github.com/rancher/rancher/pkg/generated/controllers/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/removedapis"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/wrangler"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/rancher/wrangler/pkg/name"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	rescanInterval = 5 * time.Minute
+	// maxBlocking is the number of objects using removed APIs kept in the status of a cluster, the removed APIs report
+	// of the cluster lists all of them.
+	maxBlocking = 100
+)
+
+type handler struct {
+	ctx                  context.Context
+	clusters             rocontrollers.ClusterController
+	rkeControlPlaneCache rkecontroller.RKEControlPlaneCache
+	secretCache          corecontrollers.SecretCache
+}
+
+// Register registers the upgrade-scan controller, which scans the clusters of the removed API upgrade gate being
+// upgraded to a Kubernetes minor version for the objects using APIs removed in the new version. The upgrade of the
+// control plane is held until the scan passes, the scan being repeated until then.
+func Register(ctx context.Context, clients *wrangler.Context) {
+	h := &handler{
+		ctx:                  ctx,
+		clusters:             clients.Provisioning.Cluster(),
+		rkeControlPlaneCache: clients.RKE.RKEControlPlane().Cache(),
+		secretCache:          clients.Core.Secret().Cache(),
+	}
+
+	rocontrollers.RegisterClusterStatusHandler(ctx, clients.Provisioning.Cluster(), "", "upgrade-scan", h.OnChange)
+	relatedresource.Watch(ctx, "upgrade-scan-trigger", resolveControlPlane, clients.Provisioning.Cluster(), clients.RKE.RKEControlPlane())
+}
+
+// resolveControlPlane enqueues the cluster of a control plane, so that the scan is cleared once the upgrade is applied.
+func resolveControlPlane(namespace, name string, obj runtime.Object) ([]relatedresource.Key, error) {
+	if _, ok := obj.(*rkev1.RKEControlPlane); ok {
+		return []relatedresource.Key{{Namespace: namespace, Name: name}}, nil
+	}
+	return nil, nil
+}
+
+func (h *handler) OnChange(cluster *rancherv1.Cluster, status rancherv1.ClusterStatus) (rancherv1.ClusterStatus, error) {
+	if cluster.Spec.RKEConfig == nil || cluster.DeletionTimestamp != nil || !removedapis.GateEnabled(cluster, settings.RemovedAPIUpgradeGate.Get()) {
+		status.UpgradeScan = nil
+		return status, nil
+	}
+
+	controlPlane, err := h.rkeControlPlaneCache.Get(cluster.Namespace, cluster.Name)
+	if apierrors.IsNotFound(err) {
+		status.UpgradeScan = nil
+		return status, nil
+	} else if err != nil {
+		return status, err
+	}
+
+	current, target := controlPlane.Spec.KubernetesVersion, cluster.Spec.KubernetesVersion
+	if !removedapis.MinorUpgrade(current, target) {
+		status.UpgradeScan = &rancherv1.UpgradeScanStatus{
+			CurrentVersion: current,
+			TargetVersion:  target,
+			Passed:         true,
+		}
+		return status, nil
+	}
+
+	if previous := status.UpgradeScan; previous != nil && previous.CurrentVersion == current && previous.TargetVersion == target && previous.ScannedAt != "" {
+		if previous.Passed {
+			return status, nil
+		}
+		if scannedAt, err := time.Parse(time.RFC3339, previous.ScannedAt); err == nil && time.Since(scannedAt) < rescanInterval {
+			h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, rescanInterval-time.Since(scannedAt))
+			return status, nil
+		}
+	}
+
+	report, err := h.scan(cluster, current, target)
+	if err != nil {
+		logrus.Errorf("[upgrade-scan] rkecluster %s/%s: failed to scan for the APIs removed in kubernetes version %s: %v", cluster.Namespace, cluster.Name, target, err)
+		report = &rancherv1.UpgradeScanStatus{
+			CurrentVersion: current,
+			TargetVersion:  target,
+			ScannedAt:      time.Now().UTC().Format(time.RFC3339),
+			Message:        fmt.Sprintf("failed to scan the cluster: %v", err),
+		}
+	} else if !report.Passed {
+		logrus.Warnf("[upgrade-scan] rkecluster %s/%s: holding the upgrade to kubernetes version %s, %d objects use APIs it removes",
+			cluster.Namespace, cluster.Name, target, len(report.Blocking))
+	}
+	if !report.Passed {
+		h.clusters.EnqueueAfter(cluster.Namespace, cluster.Name, rescanInterval)
+	}
+	status.UpgradeScan = truncate(report)
+	return status, nil
+}
+
+func (h *handler) scan(cluster *rancherv1.Cluster, current, target string) (*rancherv1.UpgradeScanStatus, error) {
+	secret, err := h.secretCache.Get(cluster.Namespace, name.SafeConcatName(cluster.Name, "kubeconfig"))
+	if err != nil {
+		return nil, err
+	}
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(secret.Data["value"])
+	if err != nil {
+		return nil, err
+	}
+	return removedapis.Scan(h.ctx, restConfig, current, target)
+}
+
+// truncate returns the report with at most maxBlocking objects using removed APIs.
+func truncate(report *rancherv1.UpgradeScanStatus) *rancherv1.UpgradeScanStatus {
+	if len(report.Blocking) <= maxBlocking {
+		return report
+	}
+	more := fmt.Sprintf("%d more objects use removed APIs", len(report.Blocking)-maxBlocking)
+	if report.Message != "" {
+		more = report.Message + ", " + more
+	}
+	report.Blocking = report.Blocking[:maxBlocking]
+	report.Message = more
+	return report
+}
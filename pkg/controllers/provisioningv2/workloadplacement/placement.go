@@ -0,0 +1,149 @@
+package workloadplacement
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	fleet "github.com/rancher/fleet/pkg/apis/fleet.cattle.io/v1alpha1"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/wrangler/pkg/genericcondition"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// maxClusterMessages is the number of non-ready clusters listed in the message of a workload placement.
+	maxClusterMessages = 5
+	definedCondition   = "Defined"
+	defaultTargetName  = "default"
+)
+
+// validatePlacement checks the spec of a workload placement.
+func validatePlacement(spec *v3.WorkloadPlacementSpec) error {
+	if spec.RepoName == "" || spec.Chart == "" {
+		return fmt.Errorf("repoName and chart are required")
+	}
+	if spec.ClusterSelector == nil {
+		return fmt.Errorf("clusterSelector is required")
+	}
+	if _, err := metav1.LabelSelectorAsSelector(spec.ClusterSelector); err != nil {
+		return fmt.Errorf("invalid clusterSelector: %w", err)
+	}
+	for i, override := range spec.Overrides {
+		if override.ClusterName == "" && override.ClusterSelector == nil {
+			return fmt.Errorf("override %d must have a clusterName or a clusterSelector", i)
+		}
+		if override.ClusterSelector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(override.ClusterSelector); err != nil {
+				return fmt.Errorf("invalid clusterSelector of override %d: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// targets returns the fleet targets of a workload placement: one per override, restricted to the selected clusters,
+// followed by one for all the selected clusters. Fleet deploys to each cluster with the first target matching it,
+// merging the values of the target over those of the workload.
+func targets(spec *v3.WorkloadPlacementSpec) []fleet.BundleTarget {
+	var result []fleet.BundleTarget
+	for i, override := range spec.Overrides {
+		target := fleet.BundleTarget{
+			Name:            fmt.Sprintf("override-%d", i),
+			ClusterName:     override.ClusterName,
+			ClusterSelector: andSelectors(spec.ClusterSelector, override.ClusterSelector),
+		}
+		if override.Values != nil {
+			target.Helm = &fleet.HelmOptions{
+				Values: override.Values.DeepCopy(),
+			}
+		}
+		result = append(result, target)
+	}
+	return append(result, fleet.BundleTarget{
+		Name:            defaultTargetName,
+		ClusterSelector: spec.ClusterSelector.DeepCopy(),
+	})
+}
+
+// andSelectors returns a label selector selecting the objects both selectors select, b being optional.
+func andSelectors(a, b *metav1.LabelSelector) *metav1.LabelSelector {
+	result := a.DeepCopy()
+	if b == nil {
+		return result
+	}
+	for key, value := range b.MatchLabels {
+		if existing, ok := result.MatchLabels[key]; ok && existing != value {
+			result.MatchExpressions = append(result.MatchExpressions, metav1.LabelSelectorRequirement{
+				Key:      key,
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   []string{value},
+			})
+			continue
+		}
+		if result.MatchLabels == nil {
+			result.MatchLabels = map[string]string{}
+		}
+		result.MatchLabels[key] = value
+	}
+	for _, expr := range b.MatchExpressions {
+		result.MatchExpressions = append(result.MatchExpressions, *expr.DeepCopy())
+	}
+	return result
+}
+
+// placementStatus sets the rollout state of a workload placement from the status of its managed chart.
+func placementStatus(status v3.WorkloadPlacementStatus, mcc *v3.ManagedChart) v3.WorkloadPlacementStatus {
+	summary := mcc.Status.Summary
+	status.DesiredReadyClusters = summary.DesiredReady
+	status.ReadyClusters = summary.Ready
+	status.Message = ""
+
+	if message := definedError(mcc.Status.Conditions); message != "" {
+		status.State = v3.WorkloadPlacementError
+		status.Message = message
+		return status
+	}
+
+	switch {
+	case summary.DesiredReady == 0:
+		status.State = v3.WorkloadPlacementNoClusters
+		status.Message = "no cluster matches the cluster selector"
+	case summary.ErrApplied > 0:
+		status.State = v3.WorkloadPlacementError
+		status.Message = nonReadyMessage(summary.NonReadyResources)
+	case summary.Ready == summary.DesiredReady:
+		status.State = v3.WorkloadPlacementReady
+	default:
+		status.State = v3.WorkloadPlacementRollingOut
+		status.Message = nonReadyMessage(summary.NonReadyResources)
+	}
+	return status
+}
+
+func definedError(conditions []genericcondition.GenericCondition) string {
+	for _, cond := range conditions {
+		if cond.Type == definedCondition && cond.Status == corev1.ConditionFalse && cond.Message != "" {
+			return cond.Message
+		}
+	}
+	return ""
+}
+
+// nonReadyMessage lists the clusters the workload isn't ready on with their state.
+func nonReadyMessage(resources []fleet.NonReadyResource) string {
+	var messages []string
+	for _, resource := range resources {
+		message := fmt.Sprintf("%s is %s", resource.Name, resource.State)
+		if resource.Message != "" {
+			message += ": " + resource.Message
+		}
+		messages = append(messages, message)
+	}
+	sort.Strings(messages)
+	if len(messages) > maxClusterMessages {
+		return fmt.Sprintf("%s and %d more", strings.Join(messages[:maxClusterMessages], ", "), len(messages)-maxClusterMessages)
+	}
+	return strings.Join(messages, ", ")
+}
@@ -0,0 +1,190 @@
+package workloadplacement
+
+import (
+	"testing"
+
+	fleet "github.com/rancher/fleet/pkg/apis/fleet.cattle.io/v1alpha1"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/wrangler/pkg/genericcondition"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidatePlacement(t *testing.T) {
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}
+	invalidSelector := &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "env", Operator: "Unknown"}}}
+
+	tests := []struct {
+		name string
+		spec v3.WorkloadPlacementSpec
+		err  string
+	}{
+		{
+			name: "valid",
+			spec: v3.WorkloadPlacementSpec{
+				RepoName:        "rancher-charts",
+				Chart:           "rancher-monitoring",
+				ClusterSelector: selector,
+				Overrides:       []v3.WorkloadPlacementOverride{{ClusterName: "prod-eu"}},
+			},
+		},
+		{
+			name: "no chart",
+			spec: v3.WorkloadPlacementSpec{RepoName: "rancher-charts", ClusterSelector: selector},
+			err:  "repoName and chart are required",
+		},
+		{
+			name: "no selector",
+			spec: v3.WorkloadPlacementSpec{RepoName: "rancher-charts", Chart: "rancher-monitoring"},
+			err:  "clusterSelector is required",
+		},
+		{
+			name: "invalid selector",
+			spec: v3.WorkloadPlacementSpec{RepoName: "rancher-charts", Chart: "rancher-monitoring", ClusterSelector: invalidSelector},
+			err:  "invalid clusterSelector",
+		},
+		{
+			name: "override without clusters",
+			spec: v3.WorkloadPlacementSpec{
+				RepoName:        "rancher-charts",
+				Chart:           "rancher-monitoring",
+				ClusterSelector: selector,
+				Overrides:       []v3.WorkloadPlacementOverride{{ClusterName: "prod-eu"}, {}},
+			},
+			err: "override 1 must have a clusterName or a clusterSelector",
+		},
+		{
+			name: "override with invalid selector",
+			spec: v3.WorkloadPlacementSpec{
+				RepoName:        "rancher-charts",
+				Chart:           "rancher-monitoring",
+				ClusterSelector: selector,
+				Overrides:       []v3.WorkloadPlacementOverride{{ClusterSelector: invalidSelector}},
+			},
+			err: "invalid clusterSelector of override 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePlacement(&tt.spec)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestTargets(t *testing.T) {
+	values := &fleet.GenericMap{Data: map[string]interface{}{"replicas": 3}}
+	spec := &v3.WorkloadPlacementSpec{
+		ClusterSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+		Overrides: []v3.WorkloadPlacementOverride{
+			{ClusterName: "prod-eu", Values: values},
+			{
+				ClusterSelector: &metav1.LabelSelector{
+					MatchLabels:      map[string]string{"env": "staging", "region": "us"},
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: metav1.LabelSelectorOpExists}},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []fleet.BundleTarget{
+		{
+			Name:                    "override-0",
+			ClusterName:             "prod-eu",
+			ClusterSelector:         &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			BundleDeploymentOptions: fleet.BundleDeploymentOptions{Helm: &fleet.HelmOptions{Values: values}},
+		},
+		{
+			Name: "override-1",
+			ClusterSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"env": "prod", "region": "us"},
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "env", Operator: metav1.LabelSelectorOpIn, Values: []string{"staging"}},
+					{Key: "tier", Operator: metav1.LabelSelectorOpExists},
+				},
+			},
+		},
+		{
+			Name:            "default",
+			ClusterSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+		},
+	}, targets(spec))
+}
+
+func TestPlacementStatus(t *testing.T) {
+	managedChart := func(status fleet.BundleStatus) *v3.ManagedChart {
+		return &v3.ManagedChart{Status: v3.ManagedChartStatus{BundleStatus: status}}
+	}
+
+	tests := []struct {
+		name     string
+		mcc      *v3.ManagedChart
+		expected v3.WorkloadPlacementStatus
+	}{
+		{
+			name:     "ready",
+			mcc:      managedChart(fleet.BundleStatus{Summary: fleet.BundleSummary{DesiredReady: 2, Ready: 2}}),
+			expected: v3.WorkloadPlacementStatus{State: v3.WorkloadPlacementReady, DesiredReadyClusters: 2, ReadyClusters: 2},
+		},
+		{
+			name:     "no clusters",
+			mcc:      managedChart(fleet.BundleStatus{}),
+			expected: v3.WorkloadPlacementStatus{State: v3.WorkloadPlacementNoClusters, Message: "no cluster matches the cluster selector"},
+		},
+		{
+			name: "rolling out",
+			mcc: managedChart(fleet.BundleStatus{Summary: fleet.BundleSummary{
+				DesiredReady: 3,
+				Ready:        1,
+				NonReadyResources: []fleet.NonReadyResource{
+					{Name: "fleet-default/prod-us", State: "WaitApplied"},
+					{Name: "fleet-default/prod-eu", State: "NotReady", Message: "deployment rancher-monitoring is not ready"},
+				},
+			}}),
+			expected: v3.WorkloadPlacementStatus{
+				State:                v3.WorkloadPlacementRollingOut,
+				Message:              "fleet-default/prod-eu is NotReady: deployment rancher-monitoring is not ready, fleet-default/prod-us is WaitApplied",
+				DesiredReadyClusters: 3,
+				ReadyClusters:        1,
+			},
+		},
+		{
+			name: "error applying",
+			mcc: managedChart(fleet.BundleStatus{Summary: fleet.BundleSummary{
+				DesiredReady:      1,
+				ErrApplied:        1,
+				NonReadyResources: []fleet.NonReadyResource{{Name: "fleet-default/prod-us", State: "ErrApplied", Message: "invalid values"}},
+			}}),
+			expected: v3.WorkloadPlacementStatus{
+				State:                v3.WorkloadPlacementError,
+				Message:              "fleet-default/prod-us is ErrApplied: invalid values",
+				DesiredReadyClusters: 1,
+			},
+		},
+		{
+			name: "chart not found",
+			mcc: managedChart(fleet.BundleStatus{Conditions: []genericcondition.GenericCondition{
+				{Type: "Defined", Status: corev1.ConditionFalse, Message: "chart rancher-monitoring not found"},
+			}}),
+			expected: v3.WorkloadPlacementStatus{State: v3.WorkloadPlacementError, Message: "chart rancher-monitoring not found"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, placementStatus(v3.WorkloadPlacementStatus{Message: "previous"}, tt.mcc))
+		})
+	}
+}
+
+func TestNonReadyMessage(t *testing.T) {
+	var resources []fleet.NonReadyResource
+	for _, name := range []string{"g", "f", "e", "d", "c", "b", "a"} {
+		resources = append(resources, fleet.NonReadyResource{Name: name, State: "Pending"})
+	}
+	assert.Equal(t, "a is Pending, b is Pending, c is Pending, d is Pending, e is Pending and 2 more", nonReadyMessage(resources))
+}
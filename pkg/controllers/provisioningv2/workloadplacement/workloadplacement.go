@@ -0,0 +1,89 @@
+package workloadplacement
+
+import (
+	"context"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/capr"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/wrangler"
+	"github.com/rancher/wrangler/pkg/relatedresource"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type handler struct {
+	mccCache mgmtcontrollers.ManagedChartCache
+}
+
+// Register registers the workload-placement controller, which deploys the workload of workload placements to the
+// selected clusters with managed charts, and summarizes their rollout on the workload placements.
+func Register(ctx context.Context, clients *wrangler.Context) {
+	h := &handler{
+		mccCache: clients.Mgmt.ManagedChart().Cache(),
+	}
+
+	relatedresource.Watch(ctx,
+		"workload-placement-trigger",
+		relatedresource.OwnerResolver(true, v3.SchemeGroupVersion.String(), "WorkloadPlacement"),
+		clients.Mgmt.WorkloadPlacement(),
+		clients.Mgmt.ManagedChart())
+	mgmtcontrollers.RegisterWorkloadPlacementGeneratingHandler(ctx,
+		clients.Mgmt.WorkloadPlacement(),
+		clients.Apply.
+			WithSetOwnerReference(true, true).
+			WithCacheTypes(
+				clients.Mgmt.WorkloadPlacement(),
+				clients.Mgmt.ManagedChart()),
+		"Defined",
+		"workload-placement",
+		h.OnChange,
+		nil)
+}
+
+func (h *handler) OnChange(wp *v3.WorkloadPlacement, status v3.WorkloadPlacementStatus) ([]runtime.Object, v3.WorkloadPlacementStatus, error) {
+	if err := validatePlacement(&wp.Spec); err != nil {
+		return nil, status, err
+	}
+
+	releaseName := wp.Spec.ReleaseName
+	if releaseName == "" {
+		releaseName = wp.Name
+	}
+	mcc := &v3.ManagedChart{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      capr.SafeConcatName(capr.MaxHelmReleaseNameLength, "wp", wp.Name),
+			Namespace: wp.Namespace,
+		},
+		Spec: v3.ManagedChartSpec{
+			Paused:           wp.Spec.Paused,
+			Chart:            wp.Spec.Chart,
+			RepoName:         wp.Spec.RepoName,
+			ReleaseName:      releaseName,
+			Version:          wp.Spec.Version,
+			Values:           wp.Spec.Values,
+			DefaultNamespace: wp.Spec.Namespace,
+			TargetNamespace:  wp.Spec.Namespace,
+			RolloutStrategy:  wp.Spec.RolloutStrategy,
+			Targets:          targets(&wp.Spec),
+		},
+	}
+
+	status, err := h.updateStatus(status, mcc)
+	return []runtime.Object{
+		mcc,
+	}, status, err
+}
+
+func (h *handler) updateStatus(status v3.WorkloadPlacementStatus, mcc *v3.ManagedChart) (v3.WorkloadPlacementStatus, error) {
+	mcc, err := h.mccCache.Get(mcc.Namespace, mcc.Name)
+	if apierrors.IsNotFound(err) {
+		status.State = v3.WorkloadPlacementRollingOut
+		status.Message = "workload is being deployed"
+		return status, nil
+	} else if err != nil {
+		return status, err
+	}
+	return placementStatus(status, mcc), nil
+}
@@ -0,0 +1,136 @@
+// Package controlplaneadvisor recommends machine size changes for the control plane machine pools of clusters, from the
+// usage of their control plane sampled into the ControlPlaneUsage status of their management cluster.
+package controlplaneadvisor
+
+import (
+	"github.com/rancher/lasso/pkg/dynamic"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/wrangler"
+	"github.com/rancher/wrangler/pkg/data"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// Advice is the recommendations for the control plane machine pools of a cluster.
+type Advice struct {
+	ClusterName string `json:"clusterName"`
+	// CollectedAt is the time the usage the recommendations are made from was sampled at, empty if the usage of the
+	// control plane of the cluster isn't known.
+	CollectedAt     string           `json:"collectedAt,omitempty"`
+	Recommendations []Recommendation `json:"recommendations"`
+}
+
+type Advisor struct {
+	mgmtClusterCache mgmtcontrollers.ClusterCache
+	capiMachineCache capicontrollers.MachineCache
+	dynamic          *dynamic.Controller
+}
+
+func NewAdvisor(clients *wrangler.Context) *Advisor {
+	return &Advisor{
+		mgmtClusterCache: clients.Mgmt.Cluster().Cache(),
+		capiMachineCache: clients.CAPI.Machine().Cache(),
+		dynamic:          clients.Dynamic,
+	}
+}
+
+// Advise returns the recommendations for the control plane machine pools of a cluster.
+func (a *Advisor) Advise(cluster *rancherv1.Cluster) (*Advice, error) {
+	advice := &Advice{
+		ClusterName:     cluster.Name,
+		Recommendations: []Recommendation{},
+	}
+	if cluster.Spec.RKEConfig == nil || cluster.Status.ClusterName == "" {
+		return advice, nil
+	}
+
+	mgmtCluster, err := a.mgmtClusterCache.Get(cluster.Status.ClusterName)
+	if err != nil {
+		return nil, err
+	}
+	usage := mgmtCluster.Status.ControlPlaneUsage
+	if usage == nil {
+		return advice, nil
+	}
+	advice.CollectedAt = usage.CollectedAt
+
+	nodeUsage := map[string]v3.ControlPlaneNodeUsage{}
+	for _, node := range usage.Nodes {
+		nodeUsage[node.NodeName] = node
+	}
+
+	machines, err := a.capiMachineCache.List(cluster.Namespace, labels.SelectorFromSet(labels.Set{
+		capi.ClusterLabelName: cluster.Name,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	poolNodes := map[string][]string{}
+	for _, machine := range machines {
+		if machine.Status.NodeRef != nil {
+			pool := machine.Labels[capr.RKEMachinePoolNameLabel]
+			poolNodes[pool] = append(poolNodes[pool], machine.Status.NodeRef.Name)
+		}
+	}
+
+	for _, pool := range cluster.Spec.RKEConfig.MachinePools {
+		if !pool.ControlPlaneRole && !pool.EtcdRole {
+			continue
+		}
+
+		var nodes []v3.ControlPlaneNodeUsage
+		for _, nodeName := range poolNodes[pool.Name] {
+			if node, ok := nodeUsage[nodeName]; ok {
+				nodes = append(nodes, node)
+			}
+		}
+
+		rec := recommend(pool.Name, nodes, usage)
+		rec.Nodes = poolNodes[pool.Name]
+		if err := a.setSize(cluster, pool, &rec); err != nil {
+			return nil, err
+		}
+		advice.Recommendations = append(advice.Recommendations, rec)
+	}
+	return advice, nil
+}
+
+// setSize sets the current and recommended machine sizes of a recommendation, for machine pools provisioned by a node
+// driver with known machine sizes.
+func (a *Advisor) setSize(cluster *rancherv1.Cluster, pool rancherv1.RKEMachinePool, rec *Recommendation) error {
+	if pool.NodeConfig == nil {
+		return nil
+	}
+	apiVersion := pool.NodeConfig.APIVersion
+	if apiVersion == "" {
+		apiVersion = capr.DefaultMachineConfigAPIVersion
+	}
+	field, ok := sizeFields[pool.NodeConfig.Kind]
+	if apiVersion != capr.DefaultMachineConfigAPIVersion || !ok {
+		return nil
+	}
+
+	machineConfig, err := a.dynamic.Get(schema.FromAPIVersionAndKind(apiVersion, pool.NodeConfig.Kind), cluster.Namespace, pool.NodeConfig.Name)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	d, err := data.Convert(machineConfig)
+	if err != nil {
+		return err
+	}
+
+	rec.MachineConfigKind = pool.NodeConfig.Kind
+	rec.MachineConfigName = pool.NodeConfig.Name
+	rec.SizeField = field
+	rec.CurrentSize = d.String(field)
+	rec.RecommendedSize = resize(pool.NodeConfig.Kind, rec.CurrentSize, rec.Action)
+	return nil
+}
@@ -0,0 +1,127 @@
+package controlplaneadvisor
+
+import (
+	"fmt"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type Action string
+
+const (
+	ActionNone      Action = "none"
+	ActionScaleUp   Action = "scaleUp"
+	ActionScaleDown Action = "scaleDown"
+)
+
+const (
+	// scaleUpUtilization is the percentage of the CPU or memory of a node used by its kube-apiserver and etcd above
+	// which larger machines are recommended, and scaleDownUtilization the percentage of both below which smaller
+	// machines are recommended.
+	scaleUpUtilization   = 70
+	scaleDownUtilization = 20
+	// maxEtcdDBMemoryPercent is the percentage of the memory of a node the etcd database can grow to, etcd keeps its
+	// database mapped in memory.
+	maxEtcdDBMemoryPercent = 25
+	// maxRequestsPerCPU is the rate of requests a kube-apiserver can serve per CPU of its node.
+	maxRequestsPerCPU = 250
+)
+
+// Recommendation is the machine size change recommended for a control plane machine pool.
+type Recommendation struct {
+	MachinePool string   `json:"machinePool"`
+	Nodes       []string `json:"nodes,omitempty"`
+	Action      Action   `json:"action"`
+	Reasons     []string `json:"reasons,omitempty"`
+
+	// CPUUtilization and MemoryUtilization are the peak percentages of the CPU and memory of the nodes of the machine
+	// pool used by their kube-apiserver and etcd.
+	CPUUtilization    int64 `json:"cpuUtilization"`
+	MemoryUtilization int64 `json:"memoryUtilization"`
+
+	// MachineConfigKind, MachineConfigName and SizeField locate the size of the machines of the machine pool, for
+	// machine pools provisioned by a node driver machines can be resized for. RecommendedSize is the size the machines
+	// should be resized to, empty if there's no known size for the action.
+	MachineConfigKind string `json:"machineConfigKind,omitempty"`
+	MachineConfigName string `json:"machineConfigName,omitempty"`
+	SizeField         string `json:"sizeField,omitempty"`
+	CurrentSize       string `json:"currentSize,omitempty"`
+	RecommendedSize   string `json:"recommendedSize,omitempty"`
+}
+
+// recommend returns the recommendation for a control plane machine pool, from the usage of the control plane
+// components of its nodes.
+func recommend(poolName string, nodes []v3.ControlPlaneNodeUsage, usage *v3.ControlPlaneUsage) Recommendation {
+	rec := Recommendation{
+		MachinePool: poolName,
+		Action:      ActionNone,
+	}
+
+	var (
+		measured            int
+		minCPU, minMemory   int64
+		peakCPU, peakMemory int64
+	)
+	for _, node := range nodes {
+		allocatableCPU, allocatableMemory := node.Allocatable.Cpu().MilliValue(), node.Allocatable.Memory().Value()
+		if (node.APIServer == nil && node.Etcd == nil) || allocatableCPU == 0 || allocatableMemory == 0 {
+			continue
+		}
+		measured++
+
+		used := corev1.ResourceList{}
+		for _, resources := range []corev1.ResourceList{node.APIServer, node.Etcd} {
+			for name, quantity := range resources {
+				sum := used[name]
+				sum.Add(quantity)
+				used[name] = sum
+			}
+		}
+		peakCPU = maxInt64(peakCPU, used.Cpu().MilliValue()*100/allocatableCPU)
+		peakMemory = maxInt64(peakMemory, used.Memory().Value()*100/allocatableMemory)
+		if minCPU == 0 || allocatableCPU < minCPU {
+			minCPU = allocatableCPU
+		}
+		if minMemory == 0 || allocatableMemory < minMemory {
+			minMemory = allocatableMemory
+		}
+	}
+	if measured == 0 {
+		rec.Reasons = append(rec.Reasons, "no usage was reported for the nodes of the machine pool, the cluster may not run a metrics server")
+		return rec
+	}
+	rec.CPUUtilization, rec.MemoryUtilization = peakCPU, peakMemory
+
+	if peakCPU >= scaleUpUtilization {
+		rec.Reasons = append(rec.Reasons, fmt.Sprintf("kube-apiserver and etcd use %d%% of the CPU of a node", peakCPU))
+	}
+	if peakMemory >= scaleUpUtilization {
+		rec.Reasons = append(rec.Reasons, fmt.Sprintf("kube-apiserver and etcd use %d%% of the memory of a node", peakMemory))
+	}
+	etcdDBMemoryPercent := usage.EtcdDBSizeBytes * 100 / minMemory
+	if etcdDBMemoryPercent >= maxEtcdDBMemoryPercent {
+		rec.Reasons = append(rec.Reasons, fmt.Sprintf("the etcd database of %d MiB is %d%% of the memory of a node", usage.EtcdDBSizeBytes/(1<<20), etcdDBMemoryPercent))
+	}
+	if maxRequests := maxRequestsPerCPU * minCPU / 1000; usage.RequestsPerSecond > maxRequests {
+		rec.Reasons = append(rec.Reasons, fmt.Sprintf("%d requests per second exceed the %d a node can serve", usage.RequestsPerSecond, maxRequests))
+	}
+	if len(rec.Reasons) > 0 {
+		rec.Action = ActionScaleUp
+		return rec
+	}
+
+	if peakCPU < scaleDownUtilization && peakMemory < scaleDownUtilization && etcdDBMemoryPercent < maxEtcdDBMemoryPercent/2 &&
+		usage.RequestsPerSecond < maxRequestsPerCPU*minCPU/2000 {
+		rec.Action = ActionScaleDown
+		rec.Reasons = append(rec.Reasons, fmt.Sprintf("kube-apiserver and etcd use less than %d%% of the CPU and memory of the nodes", scaleDownUtilization))
+	}
+	return rec
+}
+
+func maxInt64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,108 @@
+package controlplaneadvisor
+
+import (
+	"testing"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func nodeUsage(name, apiServerCPU, apiServerMemory, etcdCPU, etcdMemory string) v3.ControlPlaneNodeUsage {
+	return v3.ControlPlaneNodeUsage{
+		NodeName: name,
+		Allocatable: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("4"),
+			corev1.ResourceMemory: resource.MustParse("16Gi"),
+		},
+		APIServer: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(apiServerCPU),
+			corev1.ResourceMemory: resource.MustParse(apiServerMemory),
+		},
+		Etcd: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(etcdCPU),
+			corev1.ResourceMemory: resource.MustParse(etcdMemory),
+		},
+	}
+}
+
+func TestRecommend(t *testing.T) {
+	tests := []struct {
+		name           string
+		nodes          []v3.ControlPlaneNodeUsage
+		usage          v3.ControlPlaneUsage
+		action         Action
+		reasons        int
+		cpuUtilization int64
+	}{
+		{
+			name:   "no usage",
+			action: ActionNone,
+		},
+		{
+			name: "balanced",
+			nodes: []v3.ControlPlaneNodeUsage{
+				nodeUsage("cp-1", "1", "4Gi", "500m", "2Gi"),
+				nodeUsage("cp-2", "800m", "4Gi", "400m", "2Gi"),
+			},
+			usage:          v3.ControlPlaneUsage{EtcdDBSizeBytes: 100 << 20, RequestsPerSecond: 200},
+			action:         ActionNone,
+			cpuUtilization: 37,
+		},
+		{
+			name: "peak cpu on one node",
+			nodes: []v3.ControlPlaneNodeUsage{
+				nodeUsage("cp-1", "2500m", "4Gi", "500m", "2Gi"),
+				nodeUsage("cp-2", "800m", "4Gi", "400m", "2Gi"),
+			},
+			usage:          v3.ControlPlaneUsage{EtcdDBSizeBytes: 100 << 20},
+			action:         ActionScaleUp,
+			reasons:        1,
+			cpuUtilization: 75,
+		},
+		{
+			name: "large etcd database and request rate",
+			nodes: []v3.ControlPlaneNodeUsage{
+				nodeUsage("cp-1", "1", "4Gi", "500m", "2Gi"),
+			},
+			usage:          v3.ControlPlaneUsage{EtcdDBSizeBytes: 6 << 30, RequestsPerSecond: 1500},
+			action:         ActionScaleUp,
+			reasons:        2,
+			cpuUtilization: 37,
+		},
+		{
+			name: "idle",
+			nodes: []v3.ControlPlaneNodeUsage{
+				nodeUsage("cp-1", "200m", "1Gi", "100m", "512Mi"),
+			},
+			usage:          v3.ControlPlaneUsage{EtcdDBSizeBytes: 50 << 20, RequestsPerSecond: 20},
+			action:         ActionScaleDown,
+			reasons:        1,
+			cpuUtilization: 7,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := recommend("cp", tt.nodes, &tt.usage)
+			assert.Equal(t, "cp", rec.MachinePool)
+			assert.Equal(t, tt.action, rec.Action)
+			assert.Equal(t, tt.cpuUtilization, rec.CPUUtilization)
+			if tt.nodes != nil {
+				assert.Len(t, rec.Reasons, tt.reasons)
+			}
+		})
+	}
+}
+
+func TestResize(t *testing.T) {
+	assert.Equal(t, "m5.2xlarge", resize("Amazonec2Config", "m5.xlarge", ActionScaleUp))
+	assert.Equal(t, "m5.large", resize("Amazonec2Config", "m5.xlarge", ActionScaleDown))
+	assert.Equal(t, "", resize("Amazonec2Config", "m5.large", ActionScaleDown), "smallest size")
+	assert.Equal(t, "", resize("Amazonec2Config", "m5.8xlarge", ActionScaleUp), "largest size")
+	assert.Equal(t, "", resize("Amazonec2Config", "m5.xlarge", ActionNone))
+	assert.Equal(t, "", resize("Amazonec2Config", "x1.16xlarge", ActionScaleUp), "unknown size")
+	assert.Equal(t, "Standard_D8s_v3", resize("AzureConfig", "Standard_D4s_v3", ActionScaleUp))
+	assert.Equal(t, "", resize("VmwarevsphereConfig", "4", ActionScaleUp), "unknown machine config")
+}
@@ -0,0 +1,57 @@
+package controlplaneadvisor
+
+// sizeFields are the fields of the machine configs of node drivers holding the size of machines, by machine config
+// kind.
+var sizeFields = map[string]string{
+	"Amazonec2Config":    "instanceType",
+	"AzureConfig":        "size",
+	"DigitaloceanConfig": "size",
+	"LinodeConfig":       "instanceType",
+}
+
+// sizeFamilies are the machine sizes machine pools can be resized between, by machine config kind. Sizes are ordered
+// from the smallest, and machine pools are only resized within the family of their current size so that the type of
+// machines doesn't change.
+var sizeFamilies = map[string][][]string{
+	"Amazonec2Config": {
+		{"t3.medium", "t3.large", "t3.xlarge", "t3.2xlarge"},
+		{"t3a.medium", "t3a.large", "t3a.xlarge", "t3a.2xlarge"},
+		{"m5.large", "m5.xlarge", "m5.2xlarge", "m5.4xlarge", "m5.8xlarge"},
+		{"m6i.large", "m6i.xlarge", "m6i.2xlarge", "m6i.4xlarge", "m6i.8xlarge"},
+		{"c5.large", "c5.xlarge", "c5.2xlarge", "c5.4xlarge"},
+		{"r5.large", "r5.xlarge", "r5.2xlarge", "r5.4xlarge"},
+	},
+	"AzureConfig": {
+		{"Standard_D2s_v3", "Standard_D4s_v3", "Standard_D8s_v3", "Standard_D16s_v3", "Standard_D32s_v3"},
+		{"Standard_D2s_v5", "Standard_D4s_v5", "Standard_D8s_v5", "Standard_D16s_v5", "Standard_D32s_v5"},
+		{"Standard_E2s_v3", "Standard_E4s_v3", "Standard_E8s_v3", "Standard_E16s_v3"},
+	},
+	"DigitaloceanConfig": {
+		{"s-2vcpu-4gb", "s-4vcpu-8gb", "s-8vcpu-16gb"},
+		{"g-2vcpu-8gb", "g-4vcpu-16gb", "g-8vcpu-32gb", "g-16vcpu-64gb"},
+	},
+	"LinodeConfig": {
+		{"g6-standard-2", "g6-standard-4", "g6-standard-6", "g6-standard-8", "g6-standard-16"},
+		{"g6-dedicated-2", "g6-dedicated-4", "g6-dedicated-8", "g6-dedicated-16"},
+	},
+}
+
+// resize returns the size the machines of a machine pool should be resized to, from their current size, empty if the
+// current size isn't known or can't be changed in the direction of the action.
+func resize(kind, size string, action Action) string {
+	for _, family := range sizeFamilies[kind] {
+		for i, familySize := range family {
+			if familySize != size {
+				continue
+			}
+			switch {
+			case action == ActionScaleUp && i+1 < len(family):
+				return family[i+1]
+			case action == ActionScaleDown && i > 0:
+				return family[i-1]
+			}
+			return ""
+		}
+	}
+	return ""
+}
@@ -79,6 +79,98 @@ func List(cfg *rest.Config) (_ []crd.CRD, err error) {
 				WithColumn("Value", ".value")
 		}),
 		FeatureCRD(),
+		newCRD(&v3.ClusterGroup{}, func(c crd.CRD) crd.CRD {
+			c.NonNamespace = true
+			return c.
+				WithStatus().
+				WithColumn("Display Name", ".spec.displayName").
+				WithColumn("Clusters", ".status.clusterCount")
+		}),
+		newCRD(&v3.ClusterGroupRoleTemplateBinding{}, func(c crd.CRD) crd.CRD {
+			c.NonNamespace = true
+			return c.
+				WithStatus().
+				WithColumn("Cluster Group", ".clusterGroupName").
+				WithColumn("Role Template", ".roleTemplateName")
+		}),
+		newCRD(&v3.ClusterMigration{}, func(c crd.CRD) crd.CRD {
+			return c.
+				WithStatus().
+				WithColumn("Source", ".spec.sourceClusterName").
+				WithColumn("Cluster", ".spec.clusterName").
+				WithColumn("State", ".status.state").
+				WithColumn("Message", ".status.message")
+		}),
+		newCRD(&v3.ClusterArchive{}, func(c crd.CRD) crd.CRD {
+			c.NonNamespace = true
+			return c.
+				WithColumn("Cluster", ".spec.clusterName").
+				WithColumn("Display Name", ".spec.displayName").
+				WithColumn("Removed", ".spec.removedAt")
+		}),
+		newCRD(&v3.EventSubscription{}, func(c crd.CRD) crd.CRD {
+			c.NonNamespace = true
+			return c.
+				WithStatus().
+				WithColumn("Protocol", ".spec.protocol").
+				WithColumn("URL", ".spec.url").
+				WithColumn("Delivered", ".status.deliveredCount").
+				WithColumn("Last Delivered", ".status.lastDeliveredTime")
+		}),
+		newCRD(&v3.LandingPreference{}, func(c crd.CRD) crd.CRD {
+			c.NonNamespace = true
+			return c.
+				WithColumn("User", ".spec.userName").
+				WithColumn("Group", ".spec.groupPrincipalName").
+				WithColumn("Default Cluster", ".spec.defaultClusterName")
+		}),
+		newCRD(&v3.ManagementEvent{}, func(c crd.CRD) crd.CRD {
+			c.NonNamespace = true
+			return c.
+				WithColumn("Type", ".spec.type").
+				WithColumn("Subject", ".spec.subject").
+				WithColumn("Time", ".spec.time")
+		}),
+		newCRD(&v3.MeteringRecord{}, func(c crd.CRD) crd.CRD {
+			c.NonNamespace = true
+			return c.
+				WithColumn("Aggregation", ".spec.aggregation").
+				WithColumn("Start", ".spec.start").
+				WithColumn("Last Sampled", ".spec.lastSampled")
+		}),
+		newCRD(&v3.NodeRegistrationBatch{}, func(c crd.CRD) crd.CRD {
+			return c.
+				WithStatus().
+				WithColumn("Cluster", ".spec.clusterName").
+				WithColumn("Expected", ".status.expectedCount").
+				WithColumn("Registered", ".status.registeredCount")
+		}),
+		newCRD(&v3.ProvisioningQuota{}, func(c crd.CRD) crd.CRD {
+			c.NonNamespace = true
+			return c.
+				WithColumn("Display Name", ".spec.displayName").
+				WithColumn("Max Clusters", ".spec.maxClusters").
+				WithColumn("Max Nodes", ".spec.maxNodes")
+		}),
+		newCRD(&v3.RancherConfig{}, func(c crd.CRD) crd.CRD {
+			c.NonNamespace = true
+			return c.WithStatus()
+		}),
+		newCRD(&v3.Report{}, func(c crd.CRD) crd.CRD {
+			c.NonNamespace = true
+			return c.
+				WithStatus().
+				WithColumn("Schedule", ".spec.schedule").
+				WithColumn("Last Generated", ".status.lastGenerated")
+		}),
+		newCRD(&v3.SecretDistribution{}, func(c crd.CRD) crd.CRD {
+			c.NonNamespace = true
+			return c.WithStatus()
+		}),
+		newCRD(&v3.NamespaceTemplate{}, func(c crd.CRD) crd.CRD {
+			return c.
+				WithColumn("Display Name", ".spec.displayName")
+		}),
 		newCRD(&catalogv1.ClusterRepo{}, func(c crd.CRD) crd.CRD {
 			c.NonNamespace = true
 			return c.
@@ -118,6 +210,26 @@ func List(cfg *rest.Config) (_ []crd.CRD, err error) {
 			result = append(result, crd.CRD{
 				SchemaObject: v3.ManagedChart{},
 			}.WithStatus())
+			result = append(result, crd.CRD{
+				SchemaObject: v3.PolicyBundle{},
+			}.WithStatus().
+				WithColumn("Engine", ".spec.engine").
+				WithColumn("Version", ".spec.version").
+				WithColumn("Compliant", ".status.compliantClusters").
+				WithColumn("Drifted", ".status.driftedClusters"))
+			result = append(result, crd.CRD{
+				SchemaObject: v3.ClusterBundle{},
+			}.WithStatus().
+				WithColumn("Reference", ".spec.reference").
+				WithColumn("Version", ".status.version").
+				WithColumn("Cluster", ".status.clusterName"))
+			result = append(result, crd.CRD{
+				SchemaObject: v3.WorkloadPlacement{},
+			}.WithStatus().
+				WithColumn("Chart", ".spec.chart").
+				WithColumn("State", ".status.state").
+				WithColumn("Ready", ".status.readyClusters").
+				WithColumn("Desired", ".status.desiredReadyClusters"))
 		}
 	}
 
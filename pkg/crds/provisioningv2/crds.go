@@ -110,6 +110,15 @@ func rke2() []crd.CRD {
 			}
 			return clusterIndexed(c)
 		}),
+		newRKECRD(&rkev1.ClusterRecovery{}, func(c crd.CRD) crd.CRD {
+			c.Labels = map[string]string{
+				"cluster.x-k8s.io/v1beta1": "v1",
+			}
+			return clusterIndexed(c).
+				WithColumn("Cluster", ".spec.clusterName").
+				WithColumn("Phase", ".status.phase").
+				WithColumn("Message", ".status.message")
+		}),
 	}
 }
 
@@ -160,6 +169,9 @@ func capi() []crd.CRD {
 			}
 			labels["auth.cattle.io/cluster-indexed"] = "true"
 			unstr.SetLabels(labels)
+			if data.Object(unstr.Object).String("spec", "names", "kind") == "Machine" {
+				addMachinePlanColumns(unstr)
+			}
 			result = append(result, crd.CRD{
 				Override: obj,
 			})
@@ -169,6 +181,36 @@ func capi() []crd.CRD {
 	return result
 }
 
+// addMachinePlanColumns adds the plan status annotations the plan secret controller sets on machines as columns, so
+// that the machines lagging behind their desired plan stand out when listing them.
+func addMachinePlanColumns(obj *unstructured.Unstructured) {
+	columns := []interface{}{
+		map[string]interface{}{
+			"description": "Checksum of the plan last applied to the machine",
+			"jsonPath":    `.metadata.annotations.rke\.cattle\.io/applied-plan-checksum`,
+			"name":        "Applied Plan",
+			"priority":    int64(1),
+			"type":        "string",
+		},
+		map[string]interface{}{
+			"description": "Time the plan last applied to the machine was applied",
+			"jsonPath":    `.metadata.annotations.rke\.cattle\.io/plan-applied-time`,
+			"name":        "Plan Applied",
+			"type":        "date",
+		},
+		map[string]interface{}{
+			"description": "Failed applications of the desired plan of the machine being retried",
+			"jsonPath":    `.metadata.annotations.rke\.cattle\.io/plan-pending-reapplications`,
+			"name":        "Pending Reapplications",
+			"type":        "string",
+		},
+	}
+	for _, version := range data.Object(obj.Object).Slice("spec", "versions") {
+		existing, _ := version["additionalPrinterColumns"].([]interface{})
+		version["additionalPrinterColumns"] = append(existing, columns...)
+	}
+}
+
 func newRKECRD(obj interface{}, customize func(crd.CRD) crd.CRD) crd.CRD {
 	crd := crd.CRD{
 		GVK: schema.GroupVersionKind{
@@ -0,0 +1,124 @@
+// Package eventstream delivers the management events recorded for event subscriptions to external systems, as
+// CloudEvents posted to HTTP endpoints or published to NATS servers.
+package eventstream
+
+import (
+	"sort"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+)
+
+// The types of management events.
+const (
+	ClusterCreated     = "cluster.created"
+	ClusterDeleted     = "cluster.deleted"
+	NodeCreated        = "node.created"
+	NodeDeleted        = "node.deleted"
+	UserCreated        = "user.created"
+	UserDeleted        = "user.deleted"
+	RoleBindingCreated = "rolebinding.created"
+	RoleBindingDeleted = "rolebinding.deleted"
+
+	// cloudEventTypePrefix is prepended to the type of management events to form the type of CloudEvents, as
+	// CloudEvents types are prefixed with a reverse DNS name.
+	cloudEventTypePrefix = "io.cattle.management."
+	cloudEventsVersion   = "1.0"
+)
+
+// CloudEvent is a management event in the JSON format of CloudEvents 1.0.
+type CloudEvent struct {
+	SpecVersion     string            `json:"specversion"`
+	ID              string            `json:"id"`
+	Source          string            `json:"source"`
+	Type            string            `json:"type"`
+	Subject         string            `json:"subject,omitempty"`
+	Time            string            `json:"time"`
+	DataContentType string            `json:"datacontenttype,omitempty"`
+	Data            map[string]string `json:"data,omitempty"`
+	// ClusterName is an extension attribute holding the cluster the event is related to.
+	ClusterName string `json:"clustername,omitempty"`
+}
+
+// ToCloudEvent returns the CloudEvent of a management event emitted by the rancher server at source.
+func ToCloudEvent(event *v3.ManagementEvent, source string) CloudEvent {
+	result := CloudEvent{
+		SpecVersion: cloudEventsVersion,
+		ID:          event.Name,
+		Source:      source,
+		Type:        cloudEventTypePrefix + event.Spec.Type,
+		Subject:     event.Spec.Subject,
+		Time:        event.Spec.Time,
+		Data:        event.Spec.Data,
+		ClusterName: event.Spec.ClusterName,
+	}
+	if len(result.Data) > 0 {
+		result.DataContentType = "application/json"
+	}
+	return result
+}
+
+// Matches returns whether a subscription delivers an event.
+func Matches(subscription *v3.EventSubscription, event *v3.ManagementEvent) bool {
+	if len(subscription.Spec.EventTypes) > 0 && !contains(subscription.Spec.EventTypes, event.Spec.Type) {
+		return false
+	}
+	if len(subscription.Spec.ClusterNames) > 0 && !contains(subscription.Spec.ClusterNames, event.Spec.ClusterName) {
+		return false
+	}
+	return true
+}
+
+// Pending returns the events recorded after the last event delivered to a subscription and before settled, in the
+// order they were recorded, and whether there are events recorded after settled. Only events recorded before settled
+// are returned, so that an event recorded slightly earlier than another but seen after it isn't skipped.
+func Pending(events []*v3.ManagementEvent, status v3.EventSubscriptionStatus, settled time.Time) ([]*v3.ManagementEvent, bool) {
+	var lastTime time.Time
+	if status.LastDeliveredTime != "" {
+		lastTime, _ = time.Parse(time.RFC3339Nano, status.LastDeliveredTime)
+	}
+
+	var result []*v3.ManagementEvent
+	unsettled := false
+	for _, event := range events {
+		t, err := EventTime(event)
+		if err != nil {
+			continue
+		}
+		if t.Before(lastTime) || (t.Equal(lastTime) && event.Name <= status.LastDeliveredEvent) {
+			continue
+		}
+		if t.After(settled) {
+			unsettled = true
+			continue
+		}
+		result = append(result, event)
+	}
+	sortEvents(result)
+	return result, unsettled
+}
+
+// EventTime returns the time an event was recorded at.
+func EventTime(event *v3.ManagementEvent) (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, event.Spec.Time)
+}
+
+func sortEvents(events []*v3.ManagementEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		ti, _ := EventTime(events[i])
+		tj, _ := EventTime(events[j])
+		if !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return events[i].Name < events[j].Name
+	})
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
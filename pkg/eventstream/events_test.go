@@ -0,0 +1,110 @@
+package eventstream
+
+import (
+	"testing"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newEvent(name, eventType, t, clusterName string) *v3.ManagementEvent {
+	return &v3.ManagementEvent{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v3.ManagementEventSpec{
+			Type:        eventType,
+			Time:        t,
+			Subject:     "clusters/" + clusterName,
+			ClusterName: clusterName,
+		},
+	}
+}
+
+func names(events []*v3.ManagementEvent) []string {
+	var result []string
+	for _, event := range events {
+		result = append(result, event.Name)
+	}
+	return result
+}
+
+func TestToCloudEvent(t *testing.T) {
+	event := newEvent("cluster-created-1234", ClusterCreated, "2026-10-16T12:00:00.5Z", "c-m-abcde")
+	event.Spec.Data = map[string]string{"displayName": "prod"}
+
+	assert.Equal(t, CloudEvent{
+		SpecVersion:     "1.0",
+		ID:              "cluster-created-1234",
+		Source:          "https://rancher.example.com",
+		Type:            "io.cattle.management.cluster.created",
+		Subject:         "clusters/c-m-abcde",
+		Time:            "2026-10-16T12:00:00.5Z",
+		DataContentType: "application/json",
+		Data:            map[string]string{"displayName": "prod"},
+		ClusterName:     "c-m-abcde",
+	}, ToCloudEvent(event, "https://rancher.example.com"))
+}
+
+func TestMatches(t *testing.T) {
+	clusterEvent := newEvent("a", ClusterCreated, "2026-10-16T12:00:00Z", "c-1")
+	userEvent := newEvent("b", UserCreated, "2026-10-16T12:00:00Z", "")
+
+	tests := []struct {
+		name     string
+		spec     v3.EventSubscriptionSpec
+		expected []bool
+	}{
+		{
+			name:     "all events",
+			expected: []bool{true, true},
+		},
+		{
+			name:     "event types",
+			spec:     v3.EventSubscriptionSpec{EventTypes: []string{UserCreated, UserDeleted}},
+			expected: []bool{false, true},
+		},
+		{
+			name:     "clusters",
+			spec:     v3.EventSubscriptionSpec{ClusterNames: []string{"c-1"}},
+			expected: []bool{true, false},
+		},
+		{
+			name:     "other cluster",
+			spec:     v3.EventSubscriptionSpec{ClusterNames: []string{"c-2"}, EventTypes: []string{ClusterCreated}},
+			expected: []bool{false, false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscription := &v3.EventSubscription{Spec: tt.spec}
+			assert.Equal(t, tt.expected, []bool{Matches(subscription, clusterEvent), Matches(subscription, userEvent)})
+		})
+	}
+}
+
+func TestPending(t *testing.T) {
+	events := []*v3.ManagementEvent{
+		newEvent("d", NodeCreated, "2026-10-16T12:00:03Z", "c-1"),
+		newEvent("b", NodeCreated, "2026-10-16T12:00:01.5Z", "c-1"),
+		newEvent("a", NodeCreated, "2026-10-16T12:00:01.5Z", "c-1"),
+		newEvent("c", NodeCreated, "2026-10-16T12:00:02Z", "c-1"),
+		newEvent("invalid", NodeCreated, "", "c-1"),
+	}
+	settled := time.Date(2026, 10, 16, 12, 0, 2, 0, time.UTC)
+
+	pending, unsettled := Pending(events, v3.EventSubscriptionStatus{}, settled)
+	assert.Equal(t, []string{"a", "b", "c"}, names(pending))
+	assert.True(t, unsettled)
+
+	pending, _ = Pending(events, v3.EventSubscriptionStatus{LastDeliveredTime: "2026-10-16T12:00:01.5Z", LastDeliveredEvent: "a"}, settled)
+	assert.Equal(t, []string{"b", "c"}, names(pending))
+
+	pending, unsettled = Pending(events, v3.EventSubscriptionStatus{LastDeliveredTime: "2026-10-16T12:00:02Z", LastDeliveredEvent: "c"}, settled.Add(time.Minute))
+	assert.Equal(t, []string{"d"}, names(pending))
+	assert.False(t, unsettled)
+
+	// replaying from a time delivers the events at that time
+	pending, _ = Pending(events, v3.EventSubscriptionStatus{LastDeliveredTime: "2026-10-16T12:00:01.5Z"}, settled)
+	assert.Equal(t, []string{"a", "b", "c"}, names(pending))
+}
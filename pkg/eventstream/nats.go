@@ -0,0 +1,123 @@
+package eventstream
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const defaultNATSPort = "4222"
+
+// natsSink publishes each event to the subject of its type under the subject of the subscription, speaking the NATS
+// client protocol over a connection per event: events are rare enough that keeping connections open isn't worth it.
+// Publishing is confirmed by a PING round trip, the server answering the publication with an error before the PONG if
+// it's denied.
+type natsSink struct {
+	url      *url.URL
+	subject  string
+	token    string
+	username string
+	password string
+}
+
+func (n *natsSink) Send(ctx context.Context, event CloudEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	conn, err := n.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	deadline := time.Now().Add(sendTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("failed to read server info: %w", err)
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return fmt.Errorf("unexpected response from server: %s", strings.TrimSpace(line))
+	}
+
+	connect, err := json.Marshal(n.connectOptions())
+	if err != nil {
+		return err
+	}
+	subject := n.subject + "." + strings.TrimPrefix(event.Type, cloudEventTypePrefix)
+	if _, err := fmt.Fprintf(conn, "CONNECT %s\r\nPUB %s %d\r\n%s\r\nPING\r\n", connect, subject, len(payload), payload); err != nil {
+		return err
+	}
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read server response: %w", err)
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("server error: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
+
+func (n *natsSink) dial(ctx context.Context) (net.Conn, error) {
+	host := n.url.Host
+	if n.url.Port() == "" {
+		host = net.JoinHostPort(n.url.Hostname(), defaultNATSPort)
+	}
+	dialer := &net.Dialer{Timeout: sendTimeout}
+	if n.url.Scheme == "tls" {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: n.url.Hostname()}}
+		return tlsDialer.DialContext(ctx, "tcp", host)
+	}
+	return dialer.DialContext(ctx, "tcp", host)
+}
+
+type connectOptions struct {
+	Verbose   bool   `json:"verbose"`
+	Pedantic  bool   `json:"pedantic"`
+	Name      string `json:"name"`
+	Lang      string `json:"lang"`
+	Version   string `json:"version"`
+	AuthToken string `json:"auth_token,omitempty"`
+	User      string `json:"user,omitempty"`
+	Pass      string `json:"pass,omitempty"`
+}
+
+func (n *natsSink) connectOptions() connectOptions {
+	options := connectOptions{
+		Name:      "rancher",
+		Lang:      "go",
+		Version:   "1.0.0",
+		AuthToken: n.token,
+		User:      n.username,
+		Pass:      n.password,
+	}
+	if options.User == "" && n.url.User != nil {
+		options.User = n.url.User.Username()
+		options.Pass, _ = n.url.User.Password()
+	}
+	return options
+}
@@ -0,0 +1,107 @@
+package eventstream
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// The keys of the credentials in the secret of a subscription.
+	authorizationKey = "authorization"
+	tokenKey         = "token"
+	usernameKey      = "username"
+	passwordKey      = "password"
+
+	defaultSubject = "rancher"
+	sendTimeout    = 30 * time.Second
+)
+
+// Sink delivers events to the endpoint of a subscription.
+type Sink interface {
+	Send(ctx context.Context, event CloudEvent) error
+}
+
+// NewSink returns the sink of a subscription, with the credentials of its secret, which may be nil.
+func NewSink(subscription *v3.EventSubscription, secret *corev1.Secret) (Sink, error) {
+	u, err := url.Parse(subscription.Spec.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+	credential := func(key string) string {
+		if secret == nil {
+			return ""
+		}
+		return string(secret.Data[key])
+	}
+
+	switch subscription.Spec.Protocol {
+	case v3.EventSubscriptionProtocolHTTP:
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, fmt.Errorf("url of http subscriptions must be http or https, not %q", u.Scheme)
+		}
+		return &httpSink{
+			url:           u.String(),
+			authorization: credential(authorizationKey),
+		}, nil
+	case v3.EventSubscriptionProtocolNATS:
+		if u.Scheme != "nats" && u.Scheme != "tls" {
+			return nil, fmt.Errorf("url of nats subscriptions must be nats or tls, not %q", u.Scheme)
+		}
+		subject := subscription.Spec.Subject
+		if subject == "" {
+			subject = defaultSubject
+		}
+		return &natsSink{
+			url:      u,
+			subject:  subject,
+			token:    credential(tokenKey),
+			username: credential(usernameKey),
+			password: credential(passwordKey),
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported protocol %q, must be %s or %s", subscription.Spec.Protocol,
+		v3.EventSubscriptionProtocolHTTP, v3.EventSubscriptionProtocolNATS)
+}
+
+// httpSink posts each event in the structured content mode of the HTTP binding of CloudEvents.
+type httpSink struct {
+	url           string
+	authorization string
+	client        http.Client
+}
+
+func (h *httpSink) Send(ctx context.Context, event CloudEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/cloudevents+json; charset=utf-8")
+	if h.authorization != "" {
+		req.Header.Set("Authorization", h.authorization)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package eventstream
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func subscription(protocol, url string) *v3.EventSubscription {
+	return &v3.EventSubscription{Spec: v3.EventSubscriptionSpec{Protocol: protocol, URL: url}}
+}
+
+func TestNewSink(t *testing.T) {
+	_, err := NewSink(subscription(v3.EventSubscriptionProtocolHTTP, "https://example.com/events"), nil)
+	assert.NoError(t, err)
+	_, err = NewSink(subscription(v3.EventSubscriptionProtocolNATS, "tls://nats.example.com"), nil)
+	assert.NoError(t, err)
+
+	_, err = NewSink(subscription(v3.EventSubscriptionProtocolHTTP, "nats://nats.example.com"), nil)
+	assert.Error(t, err)
+	_, err = NewSink(subscription(v3.EventSubscriptionProtocolNATS, "https://example.com"), nil)
+	assert.Error(t, err)
+	_, err = NewSink(subscription("kafka", "https://example.com"), nil)
+	assert.Error(t, err)
+}
+
+func TestHTTPSink(t *testing.T) {
+	var received CloudEvent
+	var contentType, authorization string
+	status := http.StatusAccepted
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		authorization = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &received)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	secret := &corev1.Secret{Data: map[string][]byte{"authorization": []byte("Bearer secret")}}
+	sink, err := NewSink(subscription(v3.EventSubscriptionProtocolHTTP, server.URL), secret)
+	require.NoError(t, err)
+
+	event := CloudEvent{SpecVersion: "1.0", ID: "1", Type: "io.cattle.management.user.created"}
+	require.NoError(t, sink.Send(context.Background(), event))
+	assert.Equal(t, event, received)
+	assert.Equal(t, "application/cloudevents+json; charset=utf-8", contentType)
+	assert.Equal(t, "Bearer secret", authorization)
+
+	status = http.StatusServiceUnavailable
+	assert.Error(t, sink.Send(context.Background(), event))
+}
+
+// fakeNATSServer accepts a connection, answers a publication with the reply and returns the lines it received.
+func fakeNATSServer(t *testing.T, reply string) (string, chan []string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	received := make(chan []string, 1)
+	go func() {
+		defer listener.Close()
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		fmt.Fprint(conn, "INFO {\"server_id\":\"test\"}\r\n")
+		reader := bufio.NewReader(conn)
+		var lines []string
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				break
+			}
+			lines = append(lines, strings.TrimSpace(line))
+			if line == "PING\r\n" {
+				fmt.Fprint(conn, reply)
+				break
+			}
+		}
+		received <- lines
+	}()
+	return listener.Addr().String(), received
+}
+
+func TestNATSSink(t *testing.T) {
+	addr, received := fakeNATSServer(t, "PONG\r\n")
+	sub := subscription(v3.EventSubscriptionProtocolNATS, "nats://"+addr)
+	sub.Spec.Subject = "mgmt"
+	secret := &corev1.Secret{Data: map[string][]byte{"token": []byte("s3cr3t")}}
+	sink, err := NewSink(sub, secret)
+	require.NoError(t, err)
+
+	event := CloudEvent{SpecVersion: "1.0", ID: "1", Type: "io.cattle.management.cluster.deleted"}
+	require.NoError(t, sink.Send(context.Background(), event))
+
+	lines := <-received
+	require.Len(t, lines, 4)
+	assert.True(t, strings.HasPrefix(lines[0], "CONNECT "))
+	var options connectOptions
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(lines[0], "CONNECT ")), &options))
+	assert.Equal(t, "s3cr3t", options.AuthToken)
+	payload, _ := json.Marshal(event)
+	assert.Equal(t, fmt.Sprintf("PUB mgmt.cluster.deleted %d", len(payload)), lines[1])
+	assert.Equal(t, string(payload), lines[2])
+	assert.Equal(t, "PING", lines[3])
+}
+
+func TestNATSSinkError(t *testing.T) {
+	addr, received := fakeNATSServer(t, "-ERR 'Permissions Violation for Publish to rancher.user.created'\r\n")
+	sink, err := NewSink(subscription(v3.EventSubscriptionProtocolNATS, "nats://"+addr), nil)
+	require.NoError(t, err)
+
+	err = sink.Send(context.Background(), CloudEvent{Type: "io.cattle.management.user.created"})
+	assert.EqualError(t, err, "server error: 'Permissions Violation for Publish to rancher.user.created'")
+	<-received
+}
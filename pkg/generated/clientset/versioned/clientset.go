@@ -23,8 +23,6 @@
 	"net/http"
 
 	catalogv1 "github.com/rancher/rancher/pkg/generated/clientset/versioned/typed/catalog.cattle.io/v1"
-	provisioningv1 "github.com/rancher/rancher/pkg/generated/clientset/versioned/typed/provisioning.cattle.io/v1"
-	rkev1 "github.com/rancher/rancher/pkg/generated/clientset/versioned/typed/rke.cattle.io/v1"
 	upgradev1 "github.com/rancher/rancher/pkg/generated/clientset/versioned/typed/upgrade.cattle.io/v1"
 	discovery "k8s.io/client-go/discovery"
 	rest "k8s.io/client-go/rest"
@@ -34,8 +32,6 @@
 type Interface interface {
 	Discovery() discovery.DiscoveryInterface
 	CatalogV1() catalogv1.CatalogV1Interface
-	ProvisioningV1() provisioningv1.ProvisioningV1Interface
-	RkeV1() rkev1.RkeV1Interface
 	UpgradeV1() upgradev1.UpgradeV1Interface
 }
 
@@ -43,10 +39,8 @@ type Interface interface {
 // version included in a Clientset.
 type Clientset struct {
 	*discovery.DiscoveryClient
-	catalogV1      *catalogv1.CatalogV1Client
-	provisioningV1 *provisioningv1.ProvisioningV1Client
-	rkeV1          *rkev1.RkeV1Client
-	upgradeV1      *upgradev1.UpgradeV1Client
+	catalogV1 *catalogv1.CatalogV1Client
+	upgradeV1 *upgradev1.UpgradeV1Client
 }
 
 // CatalogV1 retrieves the CatalogV1Client
@@ -54,16 +48,6 @@ func (c *Clientset) CatalogV1() catalogv1.CatalogV1Interface {
 	return c.catalogV1
 }
 
-// ProvisioningV1 retrieves the ProvisioningV1Client
-func (c *Clientset) ProvisioningV1() provisioningv1.ProvisioningV1Interface {
-	return c.provisioningV1
-}
-
-// RkeV1 retrieves the RkeV1Client
-func (c *Clientset) RkeV1() rkev1.RkeV1Interface {
-	return c.rkeV1
-}
-
 // UpgradeV1 retrieves the UpgradeV1Client
 func (c *Clientset) UpgradeV1() upgradev1.UpgradeV1Interface {
 	return c.upgradeV1
@@ -117,14 +101,6 @@ func NewForConfigAndClient(c *rest.Config, httpClient *http.Client) (*Clientset,
 	if err != nil {
 		return nil, err
 	}
-	cs.provisioningV1, err = provisioningv1.NewForConfigAndClient(&configShallowCopy, httpClient)
-	if err != nil {
-		return nil, err
-	}
-	cs.rkeV1, err = rkev1.NewForConfigAndClient(&configShallowCopy, httpClient)
-	if err != nil {
-		return nil, err
-	}
 	cs.upgradeV1, err = upgradev1.NewForConfigAndClient(&configShallowCopy, httpClient)
 	if err != nil {
 		return nil, err
@@ -151,8 +127,6 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 func New(c rest.Interface) *Clientset {
 	var cs Clientset
 	cs.catalogV1 = catalogv1.New(c)
-	cs.provisioningV1 = provisioningv1.New(c)
-	cs.rkeV1 = rkev1.New(c)
 	cs.upgradeV1 = upgradev1.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
@@ -31,6 +31,7 @@ func init() {
 
 type Interface interface {
 	Bundle() BundleController
+	BundleDeployment() BundleDeploymentController
 	Cluster() ClusterController
 }
 
@@ -47,6 +48,9 @@ type version struct {
 func (c *version) Bundle() BundleController {
 	return NewBundleController(schema.GroupVersionKind{Group: "fleet.cattle.io", Version: "v1alpha1", Kind: "Bundle"}, "bundles", true, c.controllerFactory)
 }
+func (c *version) BundleDeployment() BundleDeploymentController {
+	return NewBundleDeploymentController(schema.GroupVersionKind{Group: "fleet.cattle.io", Version: "v1alpha1", Kind: "BundleDeployment"}, "bundledeployments", true, c.controllerFactory)
+}
 func (c *version) Cluster() ClusterController {
 	return NewClusterController(schema.GroupVersionKind{Group: "fleet.cattle.io", Version: "v1alpha1", Kind: "Cluster"}, "clusters", true, c.controllerFactory)
 }
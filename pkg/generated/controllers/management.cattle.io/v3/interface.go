@@ -44,8 +44,13 @@ type Interface interface {
 	ClusterAlert() ClusterAlertController
 	ClusterAlertGroup() ClusterAlertGroupController
 	ClusterAlertRule() ClusterAlertRuleController
+	ClusterArchive() ClusterArchiveController
+	ClusterBundle() ClusterBundleController
 	ClusterCatalog() ClusterCatalogController
+	ClusterGroup() ClusterGroupController
+	ClusterGroupRoleTemplateBinding() ClusterGroupRoleTemplateBindingController
 	ClusterLogging() ClusterLoggingController
+	ClusterMigration() ClusterMigrationController
 	ClusterMonitorGraph() ClusterMonitorGraphController
 	ClusterRegistrationToken() ClusterRegistrationTokenController
 	ClusterRoleTemplateBinding() ClusterRoleTemplateBindingController
@@ -54,6 +59,7 @@ type Interface interface {
 	ComposeConfig() ComposeConfigController
 	DynamicSchema() DynamicSchemaController
 	EtcdBackup() EtcdBackupController
+	EventSubscription() EventSubscriptionController
 	Feature() FeatureController
 	FleetWorkspace() FleetWorkspaceController
 	FreeIpaProvider() FreeIpaProviderController
@@ -66,14 +72,19 @@ type Interface interface {
 	Group() GroupController
 	GroupMember() GroupMemberController
 	KontainerDriver() KontainerDriverController
+	LandingPreference() LandingPreferenceController
 	LocalProvider() LocalProviderController
 	ManagedChart() ManagedChartController
+	ManagementEvent() ManagementEventController
+	MeteringRecord() MeteringRecordController
 	MonitorMetric() MonitorMetricController
 	MultiClusterApp() MultiClusterAppController
 	MultiClusterAppRevision() MultiClusterAppRevisionController
+	NamespaceTemplate() NamespaceTemplateController
 	Node() NodeController
 	NodeDriver() NodeDriverController
 	NodePool() NodePoolController
+	NodeRegistrationBatch() NodeRegistrationBatchController
 	NodeTemplate() NodeTemplateController
 	Notifier() NotifierController
 	OIDCProvider() OIDCProviderController
@@ -81,6 +92,7 @@ type Interface interface {
 	PodSecurityAdmissionConfigurationTemplate() PodSecurityAdmissionConfigurationTemplateController
 	PodSecurityPolicyTemplate() PodSecurityPolicyTemplateController
 	PodSecurityPolicyTemplateProjectBinding() PodSecurityPolicyTemplateProjectBindingController
+	PolicyBundle() PolicyBundleController
 	Preference() PreferenceController
 	Principal() PrincipalController
 	Project() ProjectController
@@ -92,13 +104,17 @@ type Interface interface {
 	ProjectMonitorGraph() ProjectMonitorGraphController
 	ProjectNetworkPolicy() ProjectNetworkPolicyController
 	ProjectRoleTemplateBinding() ProjectRoleTemplateBindingController
+	ProvisioningQuota() ProvisioningQuotaController
+	RancherConfig() RancherConfigController
 	RancherUserNotification() RancherUserNotificationController
+	Report() ReportController
 	RkeAddon() RkeAddonController
 	RkeK8sServiceOption() RkeK8sServiceOptionController
 	RkeK8sSystemImage() RkeK8sSystemImageController
 	RoleTemplate() RoleTemplateController
 	SamlProvider() SamlProviderController
 	SamlToken() SamlTokenController
+	SecretDistribution() SecretDistributionController
 	Setting() SettingController
 	Template() TemplateController
 	TemplateContent() TemplateContentController
@@ -106,6 +122,7 @@ type Interface interface {
 	Token() TokenController
 	User() UserController
 	UserAttribute() UserAttributeController
+	WorkloadPlacement() WorkloadPlacementController
 }
 
 func New(controllerFactory controller.SharedControllerFactory) Interface {
@@ -160,12 +177,27 @@ func (c *version) ClusterAlertGroup() ClusterAlertGroupController {
 func (c *version) ClusterAlertRule() ClusterAlertRuleController {
 	return NewClusterAlertRuleController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "ClusterAlertRule"}, "clusteralertrules", true, c.controllerFactory)
 }
+func (c *version) ClusterArchive() ClusterArchiveController {
+	return NewClusterArchiveController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "ClusterArchive"}, "clusterarchives", false, c.controllerFactory)
+}
+func (c *version) ClusterBundle() ClusterBundleController {
+	return NewClusterBundleController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "ClusterBundle"}, "clusterbundles", true, c.controllerFactory)
+}
 func (c *version) ClusterCatalog() ClusterCatalogController {
 	return NewClusterCatalogController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "ClusterCatalog"}, "clustercatalogs", true, c.controllerFactory)
 }
+func (c *version) ClusterGroup() ClusterGroupController {
+	return NewClusterGroupController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "ClusterGroup"}, "clustergroups", false, c.controllerFactory)
+}
+func (c *version) ClusterGroupRoleTemplateBinding() ClusterGroupRoleTemplateBindingController {
+	return NewClusterGroupRoleTemplateBindingController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "ClusterGroupRoleTemplateBinding"}, "clustergrouproletemplatebindings", false, c.controllerFactory)
+}
 func (c *version) ClusterLogging() ClusterLoggingController {
 	return NewClusterLoggingController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "ClusterLogging"}, "clusterloggings", true, c.controllerFactory)
 }
+func (c *version) ClusterMigration() ClusterMigrationController {
+	return NewClusterMigrationController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "ClusterMigration"}, "clustermigrations", true, c.controllerFactory)
+}
 func (c *version) ClusterMonitorGraph() ClusterMonitorGraphController {
 	return NewClusterMonitorGraphController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "ClusterMonitorGraph"}, "clustermonitorgraphs", true, c.controllerFactory)
 }
@@ -190,6 +222,9 @@ func (c *version) DynamicSchema() DynamicSchemaController {
 func (c *version) EtcdBackup() EtcdBackupController {
 	return NewEtcdBackupController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "EtcdBackup"}, "etcdbackups", true, c.controllerFactory)
 }
+func (c *version) EventSubscription() EventSubscriptionController {
+	return NewEventSubscriptionController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "EventSubscription"}, "eventsubscriptions", false, c.controllerFactory)
+}
 func (c *version) Feature() FeatureController {
 	return NewFeatureController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "Feature"}, "features", false, c.controllerFactory)
 }
@@ -226,12 +261,21 @@ func (c *version) GroupMember() GroupMemberController {
 func (c *version) KontainerDriver() KontainerDriverController {
 	return NewKontainerDriverController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "KontainerDriver"}, "kontainerdrivers", false, c.controllerFactory)
 }
+func (c *version) LandingPreference() LandingPreferenceController {
+	return NewLandingPreferenceController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "LandingPreference"}, "landingpreferences", false, c.controllerFactory)
+}
 func (c *version) LocalProvider() LocalProviderController {
 	return NewLocalProviderController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "LocalProvider"}, "localproviders", false, c.controllerFactory)
 }
 func (c *version) ManagedChart() ManagedChartController {
 	return NewManagedChartController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "ManagedChart"}, "managedcharts", true, c.controllerFactory)
 }
+func (c *version) ManagementEvent() ManagementEventController {
+	return NewManagementEventController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "ManagementEvent"}, "managementevents", false, c.controllerFactory)
+}
+func (c *version) MeteringRecord() MeteringRecordController {
+	return NewMeteringRecordController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "MeteringRecord"}, "meteringrecords", false, c.controllerFactory)
+}
 func (c *version) MonitorMetric() MonitorMetricController {
 	return NewMonitorMetricController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "MonitorMetric"}, "monitormetrics", true, c.controllerFactory)
 }
@@ -241,6 +285,9 @@ func (c *version) MultiClusterApp() MultiClusterAppController {
 func (c *version) MultiClusterAppRevision() MultiClusterAppRevisionController {
 	return NewMultiClusterAppRevisionController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "MultiClusterAppRevision"}, "multiclusterapprevisions", true, c.controllerFactory)
 }
+func (c *version) NamespaceTemplate() NamespaceTemplateController {
+	return NewNamespaceTemplateController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "NamespaceTemplate"}, "namespacetemplates", true, c.controllerFactory)
+}
 func (c *version) Node() NodeController {
 	return NewNodeController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "Node"}, "nodes", true, c.controllerFactory)
 }
@@ -250,6 +297,9 @@ func (c *version) NodeDriver() NodeDriverController {
 func (c *version) NodePool() NodePoolController {
 	return NewNodePoolController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "NodePool"}, "nodepools", true, c.controllerFactory)
 }
+func (c *version) NodeRegistrationBatch() NodeRegistrationBatchController {
+	return NewNodeRegistrationBatchController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "NodeRegistrationBatch"}, "noderegistrationbatches", true, c.controllerFactory)
+}
 func (c *version) NodeTemplate() NodeTemplateController {
 	return NewNodeTemplateController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "NodeTemplate"}, "nodetemplates", true, c.controllerFactory)
 }
@@ -271,6 +321,9 @@ func (c *version) PodSecurityPolicyTemplate() PodSecurityPolicyTemplateControlle
 func (c *version) PodSecurityPolicyTemplateProjectBinding() PodSecurityPolicyTemplateProjectBindingController {
 	return NewPodSecurityPolicyTemplateProjectBindingController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "PodSecurityPolicyTemplateProjectBinding"}, "podsecuritypolicytemplateprojectbindings", true, c.controllerFactory)
 }
+func (c *version) PolicyBundle() PolicyBundleController {
+	return NewPolicyBundleController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "PolicyBundle"}, "policybundles", true, c.controllerFactory)
+}
 func (c *version) Preference() PreferenceController {
 	return NewPreferenceController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "Preference"}, "preferences", true, c.controllerFactory)
 }
@@ -304,9 +357,18 @@ func (c *version) ProjectNetworkPolicy() ProjectNetworkPolicyController {
 func (c *version) ProjectRoleTemplateBinding() ProjectRoleTemplateBindingController {
 	return NewProjectRoleTemplateBindingController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "ProjectRoleTemplateBinding"}, "projectroletemplatebindings", true, c.controllerFactory)
 }
+func (c *version) ProvisioningQuota() ProvisioningQuotaController {
+	return NewProvisioningQuotaController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "ProvisioningQuota"}, "provisioningquotas", false, c.controllerFactory)
+}
+func (c *version) RancherConfig() RancherConfigController {
+	return NewRancherConfigController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "RancherConfig"}, "rancherconfigs", false, c.controllerFactory)
+}
 func (c *version) RancherUserNotification() RancherUserNotificationController {
 	return NewRancherUserNotificationController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "RancherUserNotification"}, "rancherusernotifications", false, c.controllerFactory)
 }
+func (c *version) Report() ReportController {
+	return NewReportController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "Report"}, "reports", false, c.controllerFactory)
+}
 func (c *version) RkeAddon() RkeAddonController {
 	return NewRkeAddonController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "RkeAddon"}, "rkeaddons", true, c.controllerFactory)
 }
@@ -325,6 +387,9 @@ func (c *version) SamlProvider() SamlProviderController {
 func (c *version) SamlToken() SamlTokenController {
 	return NewSamlTokenController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "SamlToken"}, "samltokens", false, c.controllerFactory)
 }
+func (c *version) SecretDistribution() SecretDistributionController {
+	return NewSecretDistributionController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "SecretDistribution"}, "secretdistributions", false, c.controllerFactory)
+}
 func (c *version) Setting() SettingController {
 	return NewSettingController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "Setting"}, "settings", false, c.controllerFactory)
 }
@@ -346,3 +411,6 @@ func (c *version) User() UserController {
 func (c *version) UserAttribute() UserAttributeController {
 	return NewUserAttributeController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "UserAttribute"}, "userattributes", false, c.controllerFactory)
 }
+func (c *version) WorkloadPlacement() WorkloadPlacementController {
+	return NewWorkloadPlacementController(schema.GroupVersionKind{Group: "management.cattle.io", Version: "v3", Kind: "WorkloadPlacement"}, "workloadplacements", true, c.controllerFactory)
+}
@@ -30,6 +30,7 @@ func init() {
 }
 
 type Interface interface {
+	ClusterRecovery() ClusterRecoveryController
 	CustomMachine() CustomMachineController
 	ETCDSnapshot() ETCDSnapshotController
 	RKEBootstrap() RKEBootstrapController
@@ -48,6 +49,9 @@ type version struct {
 	controllerFactory controller.SharedControllerFactory
 }
 
+func (c *version) ClusterRecovery() ClusterRecoveryController {
+	return NewClusterRecoveryController(schema.GroupVersionKind{Group: "rke.cattle.io", Version: "v1", Kind: "ClusterRecovery"}, "clusterrecoveries", true, c.controllerFactory)
+}
 func (c *version) CustomMachine() CustomMachineController {
 	return NewCustomMachineController(schema.GroupVersionKind{Group: "rke.cattle.io", Version: "v1", Kind: "CustomMachine"}, "custommachines", true, c.controllerFactory)
 }
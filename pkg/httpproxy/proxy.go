@@ -16,16 +16,18 @@
 	provv1 "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
 	v1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/settings"
 	"github.com/rancher/rancher/pkg/types/config"
 	"github.com/rancher/steve/pkg/auth"
-	"github.com/sirupsen/logrus"
 	"k8s.io/apiserver/pkg/authentication/user"
 	"k8s.io/apiserver/pkg/authorization/authorizer"
 	"k8s.io/apiserver/pkg/authorization/authorizerfactory"
 	"k8s.io/apiserver/pkg/endpoints/request"
 )
 
+var logger = logging.Logger(logging.Proxy)
+
 const (
 	ForwardProto = "X-Forwarded-Proto"
 	APIAuth      = "X-API-Auth-Header"
@@ -113,7 +115,7 @@ func NewProxy(prefix string, validHosts Supplier, scaledContext *config.ScaledCo
 	return &httputil.ReverseProxy{
 		Director: func(req *http.Request) {
 			if err := p.proxy(req); err != nil {
-				logrus.Infof("Failed to proxy: %v", err)
+				logger.Infof("Failed to proxy: %v", err)
 			}
 		},
 		ModifyResponse: setModifiedHeaders,
@@ -281,7 +283,7 @@ func (p *proxy) checkIndirectAccessViaCluster(req *http.Request, user user.Info,
 	}
 
 	for _, c := range provClusters {
-		if c.Spec.CloudCredentialSecretName != credID {
+		if !usesCloudCredential(c, credID) {
 			continue
 		}
 
@@ -295,6 +297,23 @@ func (p *proxy) checkIndirectAccessViaCluster(req *http.Request, user user.Info,
 	return authorizer.DecisionDeny, nil
 }
 
+// usesCloudCredential returns whether the cloud credential is the credential of the provisioning cluster or of one of
+// its machine pools.
+func usesCloudCredential(cluster *prov.Cluster, credID string) bool {
+	if cluster.Spec.CloudCredentialSecretName == credID {
+		return true
+	}
+	if cluster.Spec.RKEConfig == nil {
+		return false
+	}
+	for _, pool := range cluster.Spec.RKEConfig.MachinePools {
+		if pool.CloudCredentialSecretName == credID {
+			return true
+		}
+	}
+	return false
+}
+
 func (p *proxy) checkAccessToV3ClusterWithID(req *http.Request, user user.Info, clusterID string) (authorizer.Decision, error) {
 	decision, _, err := p.authorizer.Authorize(req.Context(), authorizer.AttributesRecord{
 		User:            user,
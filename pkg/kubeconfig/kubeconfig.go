@@ -4,6 +4,7 @@
 	"bytes"
 	"encoding/base64"
 	"fmt"
+	"net"
 	"regexp"
 	"strings"
 
@@ -38,6 +39,7 @@ type data struct {
 	Username        string
 	Password        string
 	Token           string
+	Namespace       string
 	EndpointEnabled bool
 	Nodes           []kubeNode
 }
@@ -117,6 +119,23 @@ func ForTokenBased(clusterName, clusterID, host, token string) (string, error) {
 	return buf.String(), err
 }
 
+// ForServiceAccountToken returns a kubeconfig connecting directly to the server of a cluster with the token of a
+// service account, in a namespace. caCert is the base64 encoded CA certificates of the server, if any.
+func ForServiceAccountToken(clusterName, server, caCert, namespace, user, token string) (string, error) {
+	data := &data{
+		ClusterName: clusterName,
+		Host:        server,
+		Cert:        formatCertString(caCert),
+		User:        user,
+		Token:       token,
+		Namespace:   namespace,
+	}
+
+	buf := &bytes.Buffer{}
+	err := serviceAccountTemplate.Execute(buf, data)
+	return buf.String(), err
+}
+
 func ForClusterTokenBased(cluster *managementv3.Cluster, nodes []*mgmtv3.Node, clusterID, host, token string) (string, error) {
 	clusterName := cluster.Name
 	if clusterName == "" {
@@ -140,7 +159,7 @@ func ForClusterTokenBased(cluster *managementv3.Cluster, nodes []*mgmtv3.Node, c
 				nodeName := clusterName + "-" + strings.TrimPrefix(n.Spec.RequestedHostname, clusterName+"-")
 				clusterNode := kubeNode{
 					ClusterName: nodeName,
-					Server:      "https://" + node.GetEndpointNodeIP(n) + ":6443",
+					Server:      "https://" + net.JoinHostPort(node.GetEndpointNodeIP(n), "6443"),
 					Cert:        formatCertString(cluster.CACert),
 					User:        clusterName,
 				}
@@ -1,6 +1,9 @@
 package kubeconfig
 
-import "html/template"
+import (
+	"html/template"
+	texttemplate "text/template"
+)
 
 const (
 	tokenTemplateText = `apiVersion: v1
@@ -41,6 +44,31 @@
     cluster: "{{.ClusterName}}"
 {{- end}}
 
+current-context: "{{.ClusterName}}"
+`
+
+	serviceAccountTemplateText = `apiVersion: v1
+kind: Config
+clusters:
+- name: "{{.ClusterName}}"
+  cluster:
+    server: "{{.Host}}"
+{{- if ne .Cert "" }}
+    certificate-authority-data: "{{.Cert}}"
+{{- end }}
+
+users:
+- name: "{{.User}}"
+  user:
+    token: "{{.Token}}"
+
+contexts:
+- name: "{{.ClusterName}}"
+  context:
+    user: "{{.User}}"
+    cluster: "{{.ClusterName}}"
+    namespace: "{{.Namespace}}"
+
 current-context: "{{.ClusterName}}"
 `
 
@@ -71,4 +99,7 @@
 var (
 	basicTemplate = template.Must(template.New("basicTemplate").Parse(basicTemplateText))
 	tokenTemplate = template.Must(template.New("tokenTemplate").Parse(tokenTemplateText))
+
+	// serviceAccountTemplate isn't HTML escaped, as the CA certificates and tokens it's executed with contain + and =.
+	serviceAccountTemplate = texttemplate.Must(texttemplate.New("serviceAccountTemplate").Parse(serviceAccountTemplateText))
 )
@@ -0,0 +1,132 @@
+// Package landingpreference resolves the landing preference of a user, the defaults the user lands on in the UI and
+// CLI, from the preference of the user and the default preferences of the groups of the user.
+package landingpreference
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// MaxEntries is the maximum number of favorite projects and of pinned namespaces of a preference.
+	MaxEntries = 100
+
+	DefaultClusterField   = "defaultClusterName"
+	FavoriteProjectsField = "favoriteProjects"
+	PinnedNamespacesField = "pinnedNamespaces"
+)
+
+// Preference is the landing preference of a user, its fields the user didn't set inherited from the default
+// preferences of the groups of the user.
+type Preference struct {
+	DefaultClusterName string   `json:"defaultClusterName"`
+	FavoriteProjects   []string `json:"favoriteProjects"`
+	PinnedNamespaces   []string `json:"pinnedNamespaces"`
+	// InheritedFrom maps the fields inherited from the default preference of a group to the group principal.
+	InheritedFrom map[string]string `json:"inheritedFrom"`
+}
+
+// Name returns the name of the landing preference of a user.
+func Name(userName string) string {
+	return userName
+}
+
+// Select returns the preference of the user among the preferences, nil if the user has none, and the default
+// preferences of the groups of the user, sorted by name.
+func Select(preferences []*v3.LandingPreference, userName string, groupPrincipals []string) (*v3.LandingPreference, []*v3.LandingPreference) {
+	groups := map[string]bool{}
+	for _, group := range groupPrincipals {
+		groups[group] = true
+	}
+
+	var user *v3.LandingPreference
+	var groupPreferences []*v3.LandingPreference
+	for _, preference := range preferences {
+		switch {
+		case preference.Spec.UserName != "":
+			if preference.Spec.UserName == userName && preference.Name == Name(userName) {
+				user = preference
+			}
+		case groups[preference.Spec.GroupPrincipalName]:
+			groupPreferences = append(groupPreferences, preference)
+		}
+	}
+	sort.Slice(groupPreferences, func(i, j int) bool {
+		return groupPreferences[i].Name < groupPreferences[j].Name
+	})
+	return user, groupPreferences
+}
+
+// Resolve returns the landing preference of a user from the preference of the user, nil if the user has none, and the
+// default preferences of the groups of the user. Each field the user didn't set is inherited from the first group
+// preference setting it.
+func Resolve(user *v3.LandingPreference, groups []*v3.LandingPreference) Preference {
+	result := Preference{
+		FavoriteProjects: []string{},
+		PinnedNamespaces: []string{},
+		InheritedFrom:    map[string]string{},
+	}
+	if user != nil {
+		result.DefaultClusterName = user.Spec.DefaultClusterName
+		result.FavoriteProjects = append(result.FavoriteProjects, user.Spec.FavoriteProjects...)
+		result.PinnedNamespaces = append(result.PinnedNamespaces, user.Spec.PinnedNamespaces...)
+	}
+
+	for _, group := range groups {
+		if result.DefaultClusterName == "" && group.Spec.DefaultClusterName != "" {
+			result.DefaultClusterName = group.Spec.DefaultClusterName
+			result.InheritedFrom[DefaultClusterField] = group.Spec.GroupPrincipalName
+		}
+		if len(result.FavoriteProjects) == 0 && len(group.Spec.FavoriteProjects) > 0 {
+			result.FavoriteProjects = append(result.FavoriteProjects, group.Spec.FavoriteProjects...)
+			result.InheritedFrom[FavoriteProjectsField] = group.Spec.GroupPrincipalName
+		}
+		if len(result.PinnedNamespaces) == 0 && len(group.Spec.PinnedNamespaces) > 0 {
+			result.PinnedNamespaces = append(result.PinnedNamespaces, group.Spec.PinnedNamespaces...)
+			result.InheritedFrom[PinnedNamespacesField] = group.Spec.GroupPrincipalName
+		}
+	}
+	return result
+}
+
+// Validate returns an error if the fields of a preference aren't valid: favorite projects must be clusterName:projectName
+// and pinned namespaces clusterName/namespace, without duplicates and at most MaxEntries of each.
+func Validate(spec v3.LandingPreferenceSpec) error {
+	if spec.DefaultClusterName != "" {
+		if errs := validation.IsDNS1123Subdomain(spec.DefaultClusterName); len(errs) > 0 {
+			return fmt.Errorf("invalid default cluster %s: %s", spec.DefaultClusterName, strings.Join(errs, ", "))
+		}
+	}
+	if err := validateEntries("favorite project", spec.FavoriteProjects, ":", validation.IsDNS1123Subdomain); err != nil {
+		return err
+	}
+	return validateEntries("pinned namespace", spec.PinnedNamespaces, "/", validation.IsDNS1123Label)
+}
+
+func validateEntries(kind string, entries []string, separator string, validateName func(string) []string) error {
+	if len(entries) > MaxEntries {
+		return fmt.Errorf("at most %d %ss can be set, got %d", MaxEntries, kind, len(entries))
+	}
+	seen := map[string]bool{}
+	for _, entry := range entries {
+		cluster, name, ok := strings.Cut(entry, separator)
+		if !ok || cluster == "" || name == "" {
+			return fmt.Errorf("invalid %s %s: must be clusterName%sname", kind, entry, separator)
+		}
+		if errs := validation.IsDNS1123Subdomain(cluster); len(errs) > 0 {
+			return fmt.Errorf("invalid %s %s: %s", kind, entry, strings.Join(errs, ", "))
+		}
+		if errs := validateName(name); len(errs) > 0 {
+			return fmt.Errorf("invalid %s %s: %s", kind, entry, strings.Join(errs, ", "))
+		}
+		if seen[entry] {
+			return fmt.Errorf("duplicate %s %s", kind, entry)
+		}
+		seen[entry] = true
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package landingpreference
+
+import (
+	"fmt"
+	"testing"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSelect(t *testing.T) {
+	preferences := []*v3.LandingPreference{
+		{ObjectMeta: metav1.ObjectMeta{Name: "u-a"}, Spec: v3.LandingPreferenceSpec{UserName: "u-a"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "u-b"}, Spec: v3.LandingPreferenceSpec{UserName: "u-b"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "other"}, Spec: v3.LandingPreferenceSpec{UserName: "u-a"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "sre"}, Spec: v3.LandingPreferenceSpec{GroupPrincipalName: "github_team://2"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "developers"}, Spec: v3.LandingPreferenceSpec{GroupPrincipalName: "github_team://1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "qa"}, Spec: v3.LandingPreferenceSpec{GroupPrincipalName: "github_team://3"}},
+	}
+
+	user, groups := Select(preferences, "u-a", []string{"github_team://1", "github_team://2", "system:authenticated"})
+	assert.Equal(t, "u-a", user.Name)
+	var names []string
+	for _, group := range groups {
+		names = append(names, group.Name)
+	}
+	assert.Equal(t, []string{"developers", "sre"}, names)
+
+	user, groups = Select(preferences, "u-c", nil)
+	assert.Nil(t, user)
+	assert.Empty(t, groups)
+}
+
+func TestResolve(t *testing.T) {
+	groups := []*v3.LandingPreference{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "developers"},
+			Spec: v3.LandingPreferenceSpec{
+				GroupPrincipalName: "github_team://1",
+				FavoriteProjects:   []string{"c-1:p-apps"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "sre"},
+			Spec: v3.LandingPreferenceSpec{
+				GroupPrincipalName: "github_team://2",
+				DefaultClusterName: "c-2",
+				FavoriteProjects:   []string{"c-2:p-system"},
+				PinnedNamespaces:   []string{"c-2/monitoring"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		user     *v3.LandingPreference
+		groups   []*v3.LandingPreference
+		expected Preference
+	}{
+		{
+			name: "no preferences",
+			expected: Preference{
+				FavoriteProjects: []string{},
+				PinnedNamespaces: []string{},
+				InheritedFrom:    map[string]string{},
+			},
+		},
+		{
+			name:   "inherited from groups",
+			groups: groups,
+			expected: Preference{
+				DefaultClusterName: "c-2",
+				FavoriteProjects:   []string{"c-1:p-apps"},
+				PinnedNamespaces:   []string{"c-2/monitoring"},
+				InheritedFrom: map[string]string{
+					DefaultClusterField:   "github_team://2",
+					FavoriteProjectsField: "github_team://1",
+					PinnedNamespacesField: "github_team://2",
+				},
+			},
+		},
+		{
+			name: "set by the user",
+			user: &v3.LandingPreference{
+				Spec: v3.LandingPreferenceSpec{
+					UserName:           "u-a",
+					DefaultClusterName: "c-1",
+					PinnedNamespaces:   []string{"c-1/default"},
+				},
+			},
+			groups: groups,
+			expected: Preference{
+				DefaultClusterName: "c-1",
+				FavoriteProjects:   []string{"c-1:p-apps"},
+				PinnedNamespaces:   []string{"c-1/default"},
+				InheritedFrom: map[string]string{
+					FavoriteProjectsField: "github_team://1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Resolve(tt.user, tt.groups))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tooMany := make([]string, MaxEntries+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("c-1/ns-%d", i)
+	}
+
+	tests := []struct {
+		name string
+		spec v3.LandingPreferenceSpec
+		err  string
+	}{
+		{
+			name: "valid",
+			spec: v3.LandingPreferenceSpec{
+				DefaultClusterName: "c-m-abcde",
+				FavoriteProjects:   []string{"c-1:p-apps", "local:p-system"},
+				PinnedNamespaces:   []string{"c-1/default", "local/cattle-system"},
+			},
+		},
+		{
+			name: "empty",
+		},
+		{
+			name: "invalid default cluster",
+			spec: v3.LandingPreferenceSpec{DefaultClusterName: "C_1"},
+			err:  "invalid default cluster C_1",
+		},
+		{
+			name: "project without cluster",
+			spec: v3.LandingPreferenceSpec{FavoriteProjects: []string{"p-apps"}},
+			err:  "invalid favorite project p-apps: must be clusterName:name",
+		},
+		{
+			name: "namespace with project separator",
+			spec: v3.LandingPreferenceSpec{PinnedNamespaces: []string{"c-1:default"}},
+			err:  "invalid pinned namespace c-1:default: must be clusterName/name",
+		},
+		{
+			name: "invalid namespace",
+			spec: v3.LandingPreferenceSpec{PinnedNamespaces: []string{"c-1/a.b"}},
+			err:  "invalid pinned namespace c-1/a.b",
+		},
+		{
+			name: "duplicate project",
+			spec: v3.LandingPreferenceSpec{FavoriteProjects: []string{"c-1:p-apps", "c-1:p-apps"}},
+			err:  "duplicate favorite project c-1:p-apps",
+		},
+		{
+			name: "too many namespaces",
+			spec: v3.LandingPreferenceSpec{PinnedNamespaces: tooMany},
+			err:  "at most 100 pinned namespaces can be set, got 101",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.spec)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
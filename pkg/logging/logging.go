@@ -0,0 +1,190 @@
+// Package logging provides the loggers of the subsystems of Rancher. The level of each subsystem can be changed at
+// runtime with the log-levels setting, independently of the global level, while the output, format and hooks of the
+// standard logger are shared by all of them.
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ehazlett/simplelog"
+	"github.com/sirupsen/logrus"
+)
+
+// The subsystems whose level can be set.
+const (
+	Planner = "planner"
+	Auth    = "auth"
+	Agent   = "agent"
+	Catalog = "catalog"
+	Proxy   = "proxy"
+)
+
+// Subsystems are the names of the subsystems whose level can be set.
+var Subsystems = []string{Planner, Auth, Agent, Catalog, Proxy}
+
+var (
+	lock          sync.Mutex
+	loggers       = map[string]*logrus.Logger{}
+	levels        = map[string]logrus.Level{}
+	defaultFormat = "simple"
+)
+
+// Logger returns the logger of a subsystem, whose entries have a subsystem field. It logs at the level of the
+// subsystem if one is set, and at the global level otherwise.
+func Logger(subsystem string) *logrus.Entry {
+	lock.Lock()
+	defer lock.Unlock()
+
+	logger, ok := loggers[subsystem]
+	if !ok {
+		logger = &logrus.Logger{
+			Out:          standardOutput{},
+			Formatter:    standardFormatter{},
+			Hooks:        logrus.LevelHooks{},
+			ExitFunc:     logrus.StandardLogger().ExitFunc,
+			ReportCaller: logrus.StandardLogger().ReportCaller,
+		}
+		logger.AddHook(standardHooks{})
+		loggers[subsystem] = logger
+		apply(subsystem, logger)
+	}
+	return logger.WithField("subsystem", subsystem)
+}
+
+// SetLevel sets the global level, used by the subsystems without a level of their own.
+func SetLevel(level logrus.Level) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	logrus.SetLevel(level)
+	for subsystem, logger := range loggers {
+		apply(subsystem, logger)
+	}
+}
+
+// SetLevels sets the levels of the subsystems, replacing the ones previously set.
+func SetLevels(subsystemLevels map[string]logrus.Level) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	levels = map[string]logrus.Level{}
+	for subsystem, level := range subsystemLevels {
+		levels[subsystem] = level
+	}
+	for subsystem, logger := range loggers {
+		apply(subsystem, logger)
+	}
+}
+
+// ParseLevels parses the value of the log-levels setting, a JSON object mapping subsystems to levels, such as
+// {"planner": "debug", "auth": "trace"}.
+func ParseLevels(value string) (map[string]logrus.Level, error) {
+	result := map[string]logrus.Level{}
+	if strings.TrimSpace(value) == "" {
+		return result, nil
+	}
+
+	var subsystemLevels map[string]string
+	if err := json.Unmarshal([]byte(value), &subsystemLevels); err != nil {
+		return nil, fmt.Errorf("invalid log levels: %w", err)
+	}
+	for subsystem, value := range subsystemLevels {
+		if !isSubsystem(subsystem) {
+			return nil, fmt.Errorf("unknown logging subsystem %q, must be one of %s", subsystem, strings.Join(Subsystems, ", "))
+		}
+		level, err := logrus.ParseLevel(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log level of subsystem %s: %w", subsystem, err)
+		}
+		result[subsystem] = level
+	}
+	return result, nil
+}
+
+// SetDefaultFormat sets the format of the logs, used when the log-format setting is empty.
+func SetDefaultFormat(format string) error {
+	formatter, err := newFormatter(format)
+	if err != nil {
+		return err
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	defaultFormat = format
+	logrus.SetFormatter(formatter)
+	return nil
+}
+
+// SetFormat sets the format of the logs to simple, text or json, or to the default format if it's empty.
+func SetFormat(format string) error {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if format == "" {
+		format = defaultFormat
+	}
+	formatter, err := newFormatter(format)
+	if err != nil {
+		return err
+	}
+	logrus.SetFormatter(formatter)
+	return nil
+}
+
+func newFormatter(format string) (logrus.Formatter, error) {
+	switch format {
+	case "simple":
+		return &simplelog.StandardFormatter{}, nil
+	case "text":
+		return &logrus.TextFormatter{}, nil
+	case "json":
+		return &logrus.JSONFormatter{}, nil
+	}
+	return nil, fmt.Errorf("unknown log format %q, must be one of simple, text or json", format)
+}
+
+// apply sets the level of the logger of a subsystem, the lock being held.
+func apply(subsystem string, logger *logrus.Logger) {
+	if level, ok := levels[subsystem]; ok {
+		logger.SetLevel(level)
+		return
+	}
+	logger.SetLevel(logrus.GetLevel())
+}
+
+func isSubsystem(name string) bool {
+	for _, subsystem := range Subsystems {
+		if subsystem == name {
+			return true
+		}
+	}
+	return false
+}
+
+// standardOutput writes to the output of the standard logger, which can be changed after the loggers are created.
+type standardOutput struct{}
+
+func (standardOutput) Write(p []byte) (int, error) {
+	return logrus.StandardLogger().Out.Write(p)
+}
+
+// standardFormatter formats entries with the formatter of the standard logger.
+type standardFormatter struct{}
+
+func (standardFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	return logrus.StandardLogger().Formatter.Format(entry)
+}
+
+// standardHooks fires the hooks of the standard logger.
+type standardHooks struct{}
+
+func (standardHooks) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (standardHooks) Fire(entry *logrus.Entry) error {
+	return logrus.StandardLogger().Hooks.Fire(entry.Level, entry)
+}
@@ -0,0 +1,119 @@
+package logging
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLevels(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string]logrus.Level
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			value: "",
+			want:  map[string]logrus.Level{},
+		},
+		{
+			name:  "empty object",
+			value: "{}",
+			want:  map[string]logrus.Level{},
+		},
+		{
+			name:  "levels",
+			value: `{"planner": "debug", "auth": "trace", "proxy": "warn"}`,
+			want: map[string]logrus.Level{
+				Planner: logrus.DebugLevel,
+				Auth:    logrus.TraceLevel,
+				Proxy:   logrus.WarnLevel,
+			},
+		},
+		{
+			name:    "unknown subsystem",
+			value:   `{"scheduler": "debug"}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid level",
+			value:   `{"planner": "verbose"}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid JSON",
+			value:   "planner=debug",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLevels(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLevels(t *testing.T) {
+	out := &bytes.Buffer{}
+	defer resetStandardLogger(logrus.StandardLogger().Out, logrus.GetLevel())
+	logrus.SetOutput(out)
+	SetLevel(logrus.InfoLevel)
+
+	planner := Logger(Planner)
+	auth := Logger(Auth)
+
+	SetLevels(map[string]logrus.Level{Planner: logrus.DebugLevel})
+	planner.Debug("planner debug")
+	auth.Debug("auth debug")
+	assert.Contains(t, out.String(), "planner debug")
+	assert.Contains(t, out.String(), "subsystem=planner")
+	assert.NotContains(t, out.String(), "auth debug")
+
+	SetLevel(logrus.DebugLevel)
+	auth.Debug("auth follows global level")
+	assert.Contains(t, out.String(), "auth follows global level")
+
+	SetLevels(map[string]logrus.Level{Auth: logrus.ErrorLevel})
+	auth.Info("auth info")
+	planner.Debug("planner follows global level")
+	assert.NotContains(t, out.String(), "auth info")
+	assert.Contains(t, out.String(), "planner follows global level")
+}
+
+func TestSetFormat(t *testing.T) {
+	out := &bytes.Buffer{}
+	defer resetStandardLogger(logrus.StandardLogger().Out, logrus.GetLevel())
+	logrus.SetOutput(out)
+	SetLevel(logrus.InfoLevel)
+	require.NoError(t, SetDefaultFormat("text"))
+
+	require.NoError(t, SetFormat("json"))
+	Logger(Catalog).Info("json")
+	assert.Contains(t, out.String(), `"subsystem":"catalog"`)
+
+	out.Reset()
+	require.NoError(t, SetFormat(""))
+	Logger(Catalog).Info("text")
+	assert.Contains(t, out.String(), "subsystem=catalog")
+
+	assert.Error(t, SetFormat("xml"))
+}
+
+func resetStandardLogger(out io.Writer, level logrus.Level) {
+	logrus.SetOutput(out)
+	logrus.SetFormatter(&logrus.TextFormatter{})
+	SetLevels(nil)
+	SetLevel(level)
+}
@@ -0,0 +1,76 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+)
+
+// TraceIDHeader is the header carrying the trace ID of a request. It's set on the responses of Rancher, and kept on the
+// requests proxied to downstream clusters so that their logs can be correlated with the ones of Rancher.
+const TraceIDHeader = "X-Rancher-Trace-Id"
+
+// maxTraceIDLength bounds the length of the trace IDs accepted from clients.
+const maxTraceIDLength = 64
+
+type traceIDKey struct{}
+
+// WithTraceID returns a copy of a context carrying a trace ID.
+func WithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey{}, traceID)
+}
+
+// TraceID returns the trace ID of a context, or an empty string if it has none.
+func TraceID(ctx context.Context) string {
+	traceID, _ := ctx.Value(traceIDKey{}).(string)
+	return traceID
+}
+
+// FromContext returns the logger of a subsystem, whose entries have the trace ID of the context if it has one.
+func FromContext(ctx context.Context, subsystem string) *logrus.Entry {
+	logger := Logger(subsystem)
+	if traceID := TraceID(ctx); traceID != "" {
+		return logger.WithField("traceID", traceID)
+	}
+	return logger
+}
+
+// TraceMiddleware assigns a trace ID to each request, keeping the one sent by the client if it's valid. The trace ID is
+// added to the context and the headers of the request, so that it's forwarded by the proxies, and to the response.
+func TraceMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		traceID := req.Header.Get(TraceIDHeader)
+		if !validTraceID(traceID) {
+			traceID = newTraceID()
+		}
+		req = req.WithContext(WithTraceID(req.Context(), traceID))
+		req.Header.Set(TraceIDHeader, traceID)
+		rw.Header().Set(TraceIDHeader, traceID)
+		next.ServeHTTP(rw, req)
+	})
+}
+
+func newTraceID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+// validTraceID returns whether a trace ID sent by a client is short and only made of letters, digits, dashes and
+// underscores, so that it can be logged and forwarded safely.
+func validTraceID(traceID string) bool {
+	if traceID == "" || len(traceID) > maxTraceIDLength {
+		return false
+	}
+	for _, c := range traceID {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
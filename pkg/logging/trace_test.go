@@ -0,0 +1,61 @@
+package logging
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraceMiddleware(t *testing.T) {
+	tests := []struct {
+		name    string
+		traceID string
+		keep    bool
+	}{
+		{
+			name: "no trace ID",
+		},
+		{
+			name:    "valid trace ID",
+			traceID: "4bf92f3577b34da6-a3ce929d0e0e4736",
+			keep:    true,
+		},
+		{
+			name:    "invalid characters",
+			traceID: "id\nlevel=error",
+		},
+		{
+			name:    "too long",
+			traceID: strings.Repeat("a", maxTraceIDLength+1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var contextTraceID, headerTraceID string
+			handler := TraceMiddleware(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				contextTraceID = TraceID(req.Context())
+				headerTraceID = req.Header.Get(TraceIDHeader)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/management.cattle.io.clusters", nil)
+			if tt.traceID != "" {
+				req.Header.Set(TraceIDHeader, tt.traceID)
+			}
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, req)
+
+			assert.NotEmpty(t, contextTraceID)
+			assert.Equal(t, contextTraceID, headerTraceID)
+			assert.Equal(t, contextTraceID, rw.Header().Get(TraceIDHeader))
+			if tt.keep {
+				assert.Equal(t, tt.traceID, contextTraceID)
+			} else {
+				assert.NotEqual(t, tt.traceID, contextTraceID)
+				assert.Len(t, contextTraceID, 32)
+			}
+		})
+	}
+}
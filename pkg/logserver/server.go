@@ -6,6 +6,7 @@
 	"net/http"
 	"os"
 
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/sirupsen/logrus"
 )
 
@@ -64,7 +65,7 @@ func (s *Server) loglevel(rw http.ResponseWriter, req *http.Request) {
 			rw.WriteHeader(http.StatusInternalServerError)
 			rw.Write([]byte(fmt.Sprintf("Failed to parse loglevel: %v\n", err)))
 		} else {
-			logrus.SetLevel(level)
+			logging.SetLevel(level)
 			rw.Write([]byte("OK\n"))
 		}
 	}
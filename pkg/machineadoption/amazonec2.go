@@ -0,0 +1,116 @@
+package machineadoption
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/rancher/wrangler/pkg/data"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ec2Client is the part of the EC2 API used to find the instances to adopt.
+type ec2Client interface {
+	DescribeInstancesPagesWithContext(ctx aws.Context, input *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool, opts ...request.Option) error
+}
+
+// amazonec2 finds the instances to adopt in the region of the machine config of a pool.
+type amazonec2 struct {
+	client ec2Client
+}
+
+func newAmazonec2(secret *corev1.Secret, config data.Object) (cloud, error) {
+	sess, err := session.NewSession(&aws.Config{
+		Region: aws.String(config.String("region")),
+		Credentials: credentials.NewStaticCredentials(
+			string(secret.Data["amazonec2credentialConfig-accessKey"]),
+			string(secret.Data["amazonec2credentialConfig-secretKey"]),
+			""),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &amazonec2{client: ec2.New(sess)}, nil
+}
+
+// instances returns the running instances with the IDs, and those with all the tags. The provider ID of an instance is
+// the one set by the AWS cloud provider, aws:///<availability zone>/<instance ID>.
+func (a *amazonec2) instances(ctx context.Context, ids []string, tags map[string]string) ([]Instance, error) {
+	running := &ec2.Filter{
+		Name:   aws.String("instance-state-name"),
+		Values: aws.StringSlice([]string{ec2.InstanceStateNameRunning}),
+	}
+
+	var inputs []*ec2.DescribeInstancesInput
+	if len(ids) > 0 {
+		inputs = append(inputs, &ec2.DescribeInstancesInput{
+			Filters: []*ec2.Filter{
+				running,
+				{
+					Name:   aws.String("instance-id"),
+					Values: aws.StringSlice(ids),
+				},
+			},
+		})
+	}
+	if len(tags) > 0 {
+		filters := []*ec2.Filter{running}
+		var keys []string
+		for key := range tags {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			filters = append(filters, &ec2.Filter{
+				Name:   aws.String("tag:" + key),
+				Values: []*string{aws.String(tags[key])},
+			})
+		}
+		inputs = append(inputs, &ec2.DescribeInstancesInput{Filters: filters})
+	}
+
+	var result []Instance
+	found := map[string]bool{}
+	for _, input := range inputs {
+		err := a.client.DescribeInstancesPagesWithContext(ctx, input, func(output *ec2.DescribeInstancesOutput, _ bool) bool {
+			for _, reservation := range output.Reservations {
+				for _, instance := range reservation.Instances {
+					id := aws.StringValue(instance.InstanceId)
+					if found[id] {
+						continue
+					}
+					found[id] = true
+					address := aws.StringValue(instance.PublicIpAddress)
+					if address == "" {
+						address = aws.StringValue(instance.PrivateIpAddress)
+					}
+					var zone string
+					if instance.Placement != nil {
+						zone = aws.StringValue(instance.Placement.AvailabilityZone)
+					}
+					result = append(result, Instance{
+						ID:         id,
+						ProviderID: fmt.Sprintf("aws:///%s/%s", zone, id),
+						Address:    address,
+					})
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, id := range ids {
+		if !found[id] {
+			return nil, fmt.Errorf("instance %s is not running", id)
+		}
+	}
+	return result, nil
+}
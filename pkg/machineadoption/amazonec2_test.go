@@ -0,0 +1,101 @@
+package machineadoption
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/rancher/wrangler/pkg/slice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEC2 returns the instances matching the instance-id and tag filters of the requests.
+type fakeEC2 struct {
+	inputs    []*ec2.DescribeInstancesInput
+	instances []*ec2.Instance
+}
+
+func (f *fakeEC2) DescribeInstancesPagesWithContext(_ aws.Context, input *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool, _ ...request.Option) error {
+	f.inputs = append(f.inputs, input)
+	var instances []*ec2.Instance
+	for _, instance := range f.instances {
+		if matches(instance, input.Filters) {
+			instances = append(instances, instance)
+		}
+	}
+	fn(&ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: instances}}}, true)
+	return nil
+}
+
+func matches(instance *ec2.Instance, filters []*ec2.Filter) bool {
+	for _, filter := range filters {
+		name := aws.StringValue(filter.Name)
+		values := aws.StringValueSlice(filter.Values)
+		switch {
+		case name == "instance-id":
+			if !slice.ContainsString(values, aws.StringValue(instance.InstanceId)) {
+				return false
+			}
+		case strings.HasPrefix(name, "tag:"):
+			matched := false
+			for _, tag := range instance.Tags {
+				if aws.StringValue(tag.Key) == strings.TrimPrefix(name, "tag:") && slice.ContainsString(values, aws.StringValue(tag.Value)) {
+					matched = true
+				}
+			}
+			if !matched {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func testInstance(id, publicIP, privateIP string, tags map[string]string) *ec2.Instance {
+	instance := &ec2.Instance{
+		InstanceId:       aws.String(id),
+		Placement:        &ec2.Placement{AvailabilityZone: aws.String("us-east-1a")},
+		PrivateIpAddress: aws.String(privateIP),
+	}
+	if publicIP != "" {
+		instance.PublicIpAddress = aws.String(publicIP)
+	}
+	for key, value := range tags {
+		instance.Tags = append(instance.Tags, &ec2.Tag{Key: aws.String(key), Value: aws.String(value)})
+	}
+	return instance
+}
+
+func TestAmazonec2Instances(t *testing.T) {
+	client := &fakeEC2{
+		instances: []*ec2.Instance{
+			testInstance("i-1", "3.3.3.1", "10.0.0.1", nil),
+			testInstance("i-2", "", "10.0.0.2", map[string]string{"team": "data", "env": "prod"}),
+			testInstance("i-3", "", "10.0.0.3", map[string]string{"team": "data", "env": "dev"}),
+		},
+	}
+	a := &amazonec2{client: client}
+
+	instances, err := a.instances(context.Background(), []string{"i-1", "i-2"}, map[string]string{"team": "data", "env": "prod"})
+	require.NoError(t, err)
+	assert.Equal(t, []Instance{
+		{ID: "i-1", ProviderID: "aws:///us-east-1a/i-1", Address: "3.3.3.1"},
+		{ID: "i-2", ProviderID: "aws:///us-east-1a/i-2", Address: "10.0.0.2"},
+	}, instances)
+
+	for _, input := range client.inputs {
+		assert.Equal(t, "instance-state-name", aws.StringValue(input.Filters[0].Name))
+		assert.Equal(t, []string{ec2.InstanceStateNameRunning}, aws.StringValueSlice(input.Filters[0].Values))
+	}
+}
+
+func TestAmazonec2InstancesNotRunning(t *testing.T) {
+	a := &amazonec2{client: &fakeEC2{instances: []*ec2.Instance{testInstance("i-1", "", "10.0.0.1", nil)}}}
+
+	_, err := a.instances(context.Background(), []string{"i-1", "i-2"}, nil)
+	assert.EqualError(t, err, "instance i-2 is not running")
+}
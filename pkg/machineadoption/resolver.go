@@ -0,0 +1,100 @@
+package machineadoption
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rancher/lasso/pkg/dynamic"
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/controllers/capr/machineprovision"
+	"github.com/rancher/rancher/pkg/wrangler"
+	"github.com/rancher/wrangler/pkg/data"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// resolveTimeout bounds the time spent finding the instances to adopt in their cloud.
+const resolveTimeout = 30 * time.Second
+
+// Instance is a cloud instance to adopt into a machine pool.
+type Instance struct {
+	// ID is the ID of the instance in its cloud.
+	ID string
+	// ProviderID is the provider ID of the node of the instance, as set by the cloud provider of the cloud.
+	ProviderID string
+	// Address is the address the system agent is installed through, the public address of the instance if it has one.
+	Address string
+}
+
+// cloud finds the instances to adopt in a cloud.
+type cloud interface {
+	instances(ctx context.Context, ids []string, tags map[string]string) ([]Instance, error)
+}
+
+// clouds are the clouds of the kinds of machine configs whose machine pools can adopt instances, created with a cloud
+// credential and the machine config of the pool.
+var clouds = map[string]func(secret *corev1.Secret, config data.Object) (cloud, error){
+	"Amazonec2Config": newAmazonec2,
+}
+
+// CanAdopt returns whether machine pools with machine configs of the kind can adopt instances.
+func CanAdopt(kind string) bool {
+	return clouds[kind] != nil
+}
+
+// Resolver finds the instances to adopt into the machine pools of clusters in their cloud.
+type Resolver struct {
+	dynamic     *dynamic.Controller
+	secretCache corecontrollers.SecretCache
+}
+
+func NewResolver(clients *wrangler.Context) *Resolver {
+	return &Resolver{
+		dynamic:     clients.Dynamic,
+		secretCache: clients.Core.Secret().Cache(),
+	}
+}
+
+// Resolve returns the running instances with the IDs or all the tags, in the cloud, region and account of the machine
+// pool, and the SSH user of the machine config of the pool.
+func (r *Resolver) Resolve(ctx context.Context, cluster *provv1.Cluster, pool provv1.RKEMachinePool, ids []string, tags map[string]string) ([]Instance, string, error) {
+	if pool.NodeConfig == nil || !CanAdopt(pool.NodeConfig.Kind) {
+		return nil, "", fmt.Errorf("machine pool %s can't adopt instances", pool.Name)
+	}
+	credential := pool.CloudCredentialSecretName
+	if credential == "" {
+		credential = cluster.Spec.CloudCredentialSecretName
+	}
+	if credential == "" {
+		return nil, "", fmt.Errorf("machine pool %s has no cloud credential", pool.Name)
+	}
+
+	config, err := r.dynamic.Get(schema.FromAPIVersionAndKind(pool.NodeConfig.APIVersion, pool.NodeConfig.Kind), cluster.Namespace, pool.NodeConfig.Name)
+	if err != nil {
+		return nil, "", err
+	}
+	d, err := data.Convert(config)
+	if err != nil {
+		return nil, "", err
+	}
+	secret, err := machineprovision.GetCloudCredentialSecret(r.secretCache, cluster.Namespace, credential)
+	if err != nil {
+		return nil, "", err
+	}
+
+	c, err := clouds[pool.NodeConfig.Kind](secret, d)
+	if err != nil {
+		return nil, "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, resolveTimeout)
+	defer cancel()
+
+	instances, err := c.instances(ctx, ids, tags)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to find the instances to adopt into machine pool %s: %w", pool.Name, err)
+	}
+	return instances, d.String("sshUser"), nil
+}
@@ -0,0 +1,109 @@
+package machineconfigvalidation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rancher/lasso/pkg/dynamic"
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/controllers/capr/machineprovision"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/wrangler"
+	"github.com/rancher/wrangler/pkg/data"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// checkTimeout bounds the time spent checking the machine configs of a cluster against their clouds. It is well within
+// the timeout of the admission webhook, so that slow clouds don't fail the requests.
+const checkTimeout = 5 * time.Second
+
+// Checker checks the machine configs of the machine pools of clusters against their clouds.
+type Checker struct {
+	dynamic     *dynamic.Controller
+	secretCache corecontrollers.SecretCache
+}
+
+func NewChecker(clients *wrangler.Context) *Checker {
+	return &Checker{
+		dynamic:     clients.Dynamic,
+		secretCache: clients.Core.Secret().Cache(),
+	}
+}
+
+// Check returns the violations of the machine configs of the machine pools of a cluster found by checking them against
+// their cloud with the cloud credentials of the pools, if the machine-config-live-validation setting is enabled. On
+// update, if oldCluster is set, only the pools whose machine config or cloud credential changed are checked. The pools
+// whose check couldn't finish, such as when their cloud is unreachable or too slow, are returned as warnings instead.
+func (c *Checker) Check(ctx context.Context, cluster, oldCluster *provv1.Cluster) ([]string, []string, error) {
+	if settings.MachineConfigLiveValidation.Get() != "true" || cluster.Spec.RKEConfig == nil {
+		return nil, nil, nil
+	}
+
+	checked := map[string]bool{}
+	if oldCluster != nil && oldCluster.Spec.RKEConfig != nil {
+		for _, pool := range oldCluster.Spec.RKEConfig.MachinePools {
+			checked[poolKey(oldCluster, pool)] = true
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	var violations, warnings []string
+	for _, pool := range cluster.Spec.RKEConfig.MachinePools {
+		if pool.NodeConfig == nil || !CanCheck(pool.NodeConfig.Kind) {
+			continue
+		}
+		key := poolKey(cluster, pool)
+		credential := poolCredential(cluster, pool)
+		if checked[key] || credential == "" {
+			continue
+		}
+		checked[key] = true
+
+		config, err := c.dynamic.Get(schema.FromAPIVersionAndKind(pool.NodeConfig.APIVersion, pool.NodeConfig.Kind), cluster.Namespace, pool.NodeConfig.Name)
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, nil, err
+		}
+		d, err := data.Convert(config)
+		if err != nil {
+			return nil, nil, err
+		}
+		secret, err := machineprovision.GetCloudCredentialSecret(c.secretCache, cluster.Namespace, credential)
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, nil, err
+		}
+
+		poolViolations, err := Check(ctx, pool.NodeConfig.Kind, d, secret)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("the machine config of machine pool %s couldn't be checked against its cloud: %v", pool.Name, err))
+			continue
+		}
+		for _, violation := range poolViolations {
+			violations = append(violations, fmt.Sprintf("machine pool %s: %s", pool.Name, violation))
+		}
+	}
+	return violations, warnings, nil
+}
+
+// poolCredential returns the cloud credential of a machine pool, the cloud credential of its cluster by default.
+func poolCredential(cluster *provv1.Cluster, pool provv1.RKEMachinePool) string {
+	if pool.CloudCredentialSecretName != "" {
+		return pool.CloudCredentialSecretName
+	}
+	return cluster.Spec.CloudCredentialSecretName
+}
+
+func poolKey(cluster *provv1.Cluster, pool provv1.RKEMachinePool) string {
+	if pool.NodeConfig == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s/%s", pool.NodeConfig.Kind, pool.NodeConfig.Name, poolCredential(cluster, pool))
+}
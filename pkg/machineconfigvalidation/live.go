@@ -0,0 +1,101 @@
+package machineconfigvalidation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/rancher/wrangler/pkg/data"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// cloud checks machine configs against a cloud.
+type cloud interface {
+	check(ctx context.Context, config data.Object) ([]string, error)
+}
+
+// clouds are the clouds of the kinds of machine configs checked against their cloud, created with a cloud credential.
+var clouds = map[string]func(secret *corev1.Secret, config data.Object) (cloud, error){
+	"Amazonec2Config": newAmazonec2,
+}
+
+// CanCheck returns whether machine configs of the kind can be checked against their cloud.
+func CanCheck(kind string) bool {
+	return clouds[kind] != nil
+}
+
+// Check returns the violations of a machine config found by checking it against its cloud, with a cloud credential.
+// Machine configs that aren't valid aren't checked.
+func Check(ctx context.Context, kind string, config data.Object, secret *corev1.Secret) ([]string, error) {
+	newCloud := clouds[kind]
+	if newCloud == nil || len(Validate(kind, config)) > 0 {
+		return nil, nil
+	}
+	c, err := newCloud(secret, config)
+	if err != nil {
+		return nil, err
+	}
+	return c.check(ctx, config)
+}
+
+// ec2Client is the part of the EC2 API used to check machine configs.
+type ec2Client interface {
+	DescribeInstanceTypeOfferingsWithContext(ctx aws.Context, input *ec2.DescribeInstanceTypeOfferingsInput, opts ...request.Option) (*ec2.DescribeInstanceTypeOfferingsOutput, error)
+}
+
+// amazonec2 checks the instance type of machine configs is offered in their region, and in their availability zone if
+// they have one.
+type amazonec2 struct {
+	client ec2Client
+}
+
+func newAmazonec2(secret *corev1.Secret, config data.Object) (cloud, error) {
+	sess, err := session.NewSession(&aws.Config{
+		Region: aws.String(config.String("region")),
+		Credentials: credentials.NewStaticCredentials(
+			string(secret.Data["amazonec2credentialConfig-accessKey"]),
+			string(secret.Data["amazonec2credentialConfig-secretKey"]),
+			""),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &amazonec2{client: ec2.New(sess)}, nil
+}
+
+func (a *amazonec2) check(ctx context.Context, config data.Object) ([]string, error) {
+	region, instanceType := config.String("region"), config.String("instanceType")
+	if region == "" || instanceType == "" {
+		// left to the defaults of the node driver
+		return nil, nil
+	}
+	location, locationType := region, ec2.LocationTypeRegion
+	if zone := config.String("zone"); zone != "" {
+		location, locationType = region+zone, ec2.LocationTypeAvailabilityZone
+	}
+
+	output, err := a.client.DescribeInstanceTypeOfferingsWithContext(ctx, &ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: aws.String(locationType),
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("instance-type"),
+				Values: []*string{aws.String(instanceType)},
+			},
+			{
+				Name:   aws.String("location"),
+				Values: []*string{aws.String(location)},
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe the offerings of instance type %s in %s: %w", instanceType, location, err)
+	}
+	if len(output.InstanceTypeOfferings) == 0 {
+		return []string{fmt.Sprintf("instance type %s is not offered in %s", instanceType, location)}, nil
+	}
+	return nil, nil
+}
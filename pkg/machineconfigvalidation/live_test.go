@@ -0,0 +1,63 @@
+package machineconfigvalidation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/rancher/wrangler/pkg/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeEC2 struct {
+	offerings map[string][]string
+	inputs    []*ec2.DescribeInstanceTypeOfferingsInput
+}
+
+func (f *fakeEC2) DescribeInstanceTypeOfferingsWithContext(_ aws.Context, input *ec2.DescribeInstanceTypeOfferingsInput, _ ...request.Option) (*ec2.DescribeInstanceTypeOfferingsOutput, error) {
+	f.inputs = append(f.inputs, input)
+	instanceType, location := aws.StringValue(input.Filters[0].Values[0]), aws.StringValue(input.Filters[1].Values[0])
+	output := &ec2.DescribeInstanceTypeOfferingsOutput{}
+	for _, offered := range f.offerings[location] {
+		if offered == instanceType {
+			output.InstanceTypeOfferings = append(output.InstanceTypeOfferings, &ec2.InstanceTypeOffering{
+				InstanceType: aws.String(instanceType),
+				Location:     aws.String(location),
+				LocationType: input.LocationType,
+			})
+		}
+	}
+	return output, nil
+}
+
+func TestAmazonec2Check(t *testing.T) {
+	client := &fakeEC2{
+		offerings: map[string][]string{
+			"us-east-1":  {"t3.medium", "m5.large"},
+			"us-east-1a": {"t3.medium"},
+		},
+	}
+	cloud := &amazonec2{client: client}
+
+	violations, err := cloud.check(context.Background(), data.Object{"region": "us-east-1", "instanceType": "m5.large"})
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+	assert.Equal(t, ec2.LocationTypeRegion, aws.StringValue(client.inputs[0].LocationType))
+
+	violations, err = cloud.check(context.Background(), data.Object{"region": "us-east-1", "zone": "a", "instanceType": "m5.large"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"instance type m5.large is not offered in us-east-1a"}, violations)
+	assert.Equal(t, ec2.LocationTypeAvailabilityZone, aws.StringValue(client.inputs[1].LocationType))
+
+	violations, err = cloud.check(context.Background(), data.Object{"region": "us-west-2", "instanceType": "t3.medium"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"instance type t3.medium is not offered in us-west-2"}, violations)
+
+	violations, err = cloud.check(context.Background(), data.Object{"region": "us-west-2"})
+	require.NoError(t, err)
+	assert.Empty(t, violations, "the default instance type of the node driver isn't checked")
+	assert.Len(t, client.inputs, 3)
+}
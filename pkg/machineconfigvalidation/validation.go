@@ -0,0 +1,284 @@
+// Package machineconfigvalidation validates the machine configs of the node drivers, such as Amazonec2Config, so that
+// invalid configs are rejected when they're created or updated through the API rather than once their machines fail
+// to be created. The machine configs of the machine pools of a cluster can also be checked against their cloud, with
+// the cloud credentials of the pools.
+package machineconfigvalidation
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rancher/wrangler/pkg/data"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// rule is a rule of the fields of machine configs.
+type rule struct {
+	// fields are the fields the rule checks.
+	fields []string
+	// check returns the violation of the rule by a machine config, or an empty string.
+	check func(config data.Object) string
+}
+
+var (
+	ec2Region       = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-\d+$`)
+	ec2InstanceType = regexp.MustCompile(`^[a-z0-9-]+\.[a-z0-9]+$`)
+	ec2AMI          = regexp.MustCompile(`^ami-([0-9a-f]{8}|[0-9a-f]{17})$`)
+	ec2VPC          = regexp.MustCompile(`^vpc-([0-9a-f]{8}|[0-9a-f]{17})$`)
+	ec2Subnet       = regexp.MustCompile(`^subnet-([0-9a-f]{8}|[0-9a-f]{17})$`)
+	ec2Zone         = regexp.MustCompile(`^[a-z]$`)
+	azureLocation   = regexp.MustCompile(`^[a-z0-9]+$`)
+	azureSize       = regexp.MustCompile(`^(Standard|Basic)_[A-Za-z0-9_-]+$`)
+	azureImage      = regexp.MustCompile(`^([^:]+:){3}[^:]+$|^/subscriptions/`)
+	doRegion        = regexp.MustCompile(`^[a-z]{3}\d$`)
+	doSize          = regexp.MustCompile(`^[a-z0-9-]+$`)
+	linodeRegion    = regexp.MustCompile(`^[a-z]{2}-[a-z]+(-\d+)?$`)
+	linodeType      = regexp.MustCompile(`^g\d+-[a-z0-9-]+$`)
+	linodeImage     = regexp.MustCompile(`^(linode|private)/[A-Za-z0-9._-]+$`)
+)
+
+// rules are the rules of the kinds of machine configs. Only the fields without which machines can't be created are
+// required, as the others default to the defaults of the node drivers.
+var rules = map[string][]rule{
+	"Amazonec2Config": {
+		matches("region", ec2Region, "an AWS region, such as us-east-1"),
+		matches("instanceType", ec2InstanceType, "an EC2 instance type, such as t3.medium"),
+		matches("ami", ec2AMI, "an AMI ID, such as ami-0123456789abcdef0"),
+		matches("vpcId", ec2VPC, "a VPC ID, such as vpc-0123456789abcdef0"),
+		matches("subnetId", ec2Subnet, "a subnet ID, such as subnet-0123456789abcdef0"),
+		matches("zone", ec2Zone, "the letter of an availability zone, such as a"),
+		positiveInt("rootSize"),
+	},
+	"AzureConfig": {
+		matches("location", azureLocation, "an Azure location, such as westus"),
+		matches("size", azureSize, "an Azure VM size, such as Standard_D2_v2"),
+		matches("image", azureImage, "publisher:offer:sku:version or the ID of a managed image"),
+		oneOf("environment", "AzurePublicCloud", "AzureChinaCloud", "AzureUSGovernmentCloud", "AzureGermanCloud"),
+		positiveInt("diskSize"),
+		ports("openPort"),
+	},
+	"DigitaloceanConfig": {
+		matches("region", doRegion, "a DigitalOcean region, such as nyc3"),
+		matches("size", doSize, "a DigitalOcean size, such as s-2vcpu-4gb"),
+	},
+	"HarvesterConfig": {
+		required("vmNamespace"),
+		dnsLabel("vmNamespace"),
+		positiveInt("cpuCount"),
+		positiveInt("memorySize"),
+		positiveInt("diskSize"),
+		requiredOneOf("imageName", "diskInfo"),
+		requiredOneOf("networkName", "networkInfo"),
+	},
+	"LinodeConfig": {
+		matches("region", linodeRegion, "a Linode region, such as us-east"),
+		matches("instanceType", linodeType, "a Linode type, such as g6-standard-2"),
+		matches("image", linodeImage, "a Linode image, such as linode/ubuntu22.04"),
+	},
+	"VmwarevsphereConfig": {
+		required("vcenter"),
+		host("vcenter"),
+		port("vcenterPort"),
+		oneOf("creationType", "vm", "template", "library", "legacy"),
+		cloneSource(),
+		positiveInt("cpuCount"),
+		positiveInt("memorySize"),
+		positiveInt("diskSize"),
+	},
+}
+
+// Kinds returns the kinds of machine configs validated.
+func Kinds() []string {
+	var result []string
+	for kind := range rules {
+		result = append(result, kind)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Validate returns the violations of the rules of its kind by a machine config. Configs of other kinds have no rules.
+func Validate(kind string, config data.Object) []string {
+	return ValidateUpdate(kind, config, nil)
+}
+
+// ValidateUpdate returns the violations of the rules of its kind by a machine config updated from oldConfig, only
+// checking the rules of the fields that changed, so that configs valid when they were created can still be updated
+// once the rules change. All the rules are checked if oldConfig is nil.
+func ValidateUpdate(kind string, config, oldConfig data.Object) []string {
+	var violations []string
+	for _, rule := range rules[kind] {
+		if oldConfig != nil && !changed(rule.fields, config, oldConfig) {
+			continue
+		}
+		if violation := rule.check(config); violation != "" {
+			violations = append(violations, violation)
+		}
+	}
+	return violations
+}
+
+func changed(fields []string, config, oldConfig data.Object) bool {
+	for _, field := range fields {
+		if !reflect.DeepEqual(config[field], oldConfig[field]) {
+			return true
+		}
+	}
+	return false
+}
+
+func required(field string) rule {
+	return rule{
+		fields: []string{field},
+		check: func(config data.Object) string {
+			if strings.TrimSpace(config.String(field)) == "" {
+				return fmt.Sprintf("%s is required", field)
+			}
+			return ""
+		},
+	}
+}
+
+func requiredOneOf(fields ...string) rule {
+	return rule{
+		fields: fields,
+		check: func(config data.Object) string {
+			for _, field := range fields {
+				if strings.TrimSpace(config.String(field)) != "" {
+					return ""
+				}
+			}
+			return fmt.Sprintf("one of %s is required", strings.Join(fields, ", "))
+		},
+	}
+}
+
+// matches checks the field is a match of the regular expression if it's set.
+func matches(field string, re *regexp.Regexp, description string) rule {
+	return rule{
+		fields: []string{field},
+		check: func(config data.Object) string {
+			value := config.String(field)
+			if value == "" || re.MatchString(value) {
+				return ""
+			}
+			return fmt.Sprintf("%s %q must be %s", field, value, description)
+		},
+	}
+}
+
+func oneOf(field string, values ...string) rule {
+	return rule{
+		fields: []string{field},
+		check: func(config data.Object) string {
+			value := config.String(field)
+			if value == "" {
+				return ""
+			}
+			for _, v := range values {
+				if value == v {
+					return ""
+				}
+			}
+			return fmt.Sprintf("%s %q must be one of %s", field, value, strings.Join(values, ", "))
+		},
+	}
+}
+
+// positiveInt checks the field is a positive integer if it's set. The fields of machine configs are strings or numbers.
+func positiveInt(field string) rule {
+	return rule{
+		fields: []string{field},
+		check: func(config data.Object) string {
+			value := strings.TrimSpace(config.String(field))
+			if value == "" {
+				return ""
+			}
+			if n, err := strconv.Atoi(value); err != nil || n <= 0 {
+				return fmt.Sprintf("%s %q must be a positive integer", field, value)
+			}
+			return ""
+		},
+	}
+}
+
+func port(field string) rule {
+	return rule{
+		fields: []string{field},
+		check: func(config data.Object) string {
+			value := strings.TrimSpace(config.String(field))
+			if value == "" {
+				return ""
+			}
+			if n, err := strconv.Atoi(value); err != nil || n < 1 || n > 65535 {
+				return fmt.Sprintf("%s %q must be a port between 1 and 65535", field, value)
+			}
+			return ""
+		},
+	}
+}
+
+// ports checks the ports of a list field, which may have a protocol, such as 8080/tcp.
+func ports(field string) rule {
+	return rule{
+		fields: []string{field},
+		check: func(config data.Object) string {
+			for _, value := range config.StringSlice(field) {
+				p, protocol, _ := strings.Cut(value, "/")
+				if n, err := strconv.Atoi(p); err != nil || n < 1 || n > 65535 || (protocol != "" && protocol != "tcp" && protocol != "udp") {
+					return fmt.Sprintf("%s %q must be a port between 1 and 65535, with an optional tcp or udp protocol", field, value)
+				}
+			}
+			return ""
+		},
+	}
+}
+
+func dnsLabel(field string) rule {
+	return rule{
+		fields: []string{field},
+		check: func(config data.Object) string {
+			value := config.String(field)
+			if value == "" {
+				return ""
+			}
+			if len(validation.IsDNS1123Label(value)) > 0 {
+				return fmt.Sprintf("%s %q must be a namespace name, a lowercase RFC 1123 label", field, value)
+			}
+			return ""
+		},
+	}
+}
+
+func host(field string) rule {
+	return rule{
+		fields: []string{field},
+		check: func(config data.Object) string {
+			value := config.String(field)
+			if value == "" || net.ParseIP(value) != nil || len(validation.IsDNS1123Subdomain(strings.ToLower(value))) == 0 {
+				return ""
+			}
+			return fmt.Sprintf("%s %q must be a hostname or an IP address, without a scheme or port", field, value)
+		},
+	}
+}
+
+// cloneSource checks the VM, template or content library item vSphere machines are cloned from is set, unless they're
+// created from an ISO.
+func cloneSource() rule {
+	return rule{
+		fields: []string{"creationType", "cloneFrom"},
+		check: func(config data.Object) string {
+			creationType := config.String("creationType")
+			if creationType == "" || creationType == "legacy" || config.String("cloneFrom") != "" {
+				return ""
+			}
+			return fmt.Sprintf("cloneFrom is required when creationType is %s", creationType)
+		},
+	}
+}
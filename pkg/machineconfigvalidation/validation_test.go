@@ -0,0 +1,201 @@
+package machineconfigvalidation
+
+import (
+	"testing"
+
+	"github.com/rancher/wrangler/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		kind   string
+		config data.Object
+		want   []string
+	}{
+		{
+			name: "valid amazonec2",
+			kind: "Amazonec2Config",
+			config: data.Object{
+				"region":       "us-gov-west-1",
+				"instanceType": "m5d.2xlarge",
+				"ami":          "ami-0123456789abcdef0",
+				"vpcId":        "vpc-0123abcd",
+				"subnetId":     "subnet-0123456789abcdef0",
+				"zone":         "a",
+				"rootSize":     "32",
+			},
+		},
+		{
+			name: "invalid amazonec2",
+			kind: "Amazonec2Config",
+			config: data.Object{
+				"region":   "us-east",
+				"ami":      "ubuntu",
+				"zone":     "us-east-1a",
+				"rootSize": "0",
+			},
+			want: []string{
+				`region "us-east" must be an AWS region, such as us-east-1`,
+				`ami "ubuntu" must be an AMI ID, such as ami-0123456789abcdef0`,
+				`zone "us-east-1a" must be the letter of an availability zone, such as a`,
+				`rootSize "0" must be a positive integer`,
+			},
+		},
+		{
+			name: "valid azure",
+			kind: "AzureConfig",
+			config: data.Object{
+				"location":    "westus2",
+				"size":        "Standard_D2s_v3",
+				"image":       "canonical:UbuntuServer:18.04-LTS:latest",
+				"environment": "AzurePublicCloud",
+				"diskSize":    int64(30),
+				"openPort":    []interface{}{"6443/tcp", "8472/udp", "10250"},
+			},
+		},
+		{
+			name: "invalid azure",
+			kind: "AzureConfig",
+			config: data.Object{
+				"location":    "West US",
+				"image":       "ubuntu",
+				"environment": "AzureCloud",
+				"openPort":    []interface{}{"6443/sctp"},
+			},
+			want: []string{
+				`location "West US" must be an Azure location, such as westus`,
+				`image "ubuntu" must be publisher:offer:sku:version or the ID of a managed image`,
+				`environment "AzureCloud" must be one of AzurePublicCloud, AzureChinaCloud, AzureUSGovernmentCloud, AzureGermanCloud`,
+				`openPort "6443/sctp" must be a port between 1 and 65535, with an optional tcp or udp protocol`,
+			},
+		},
+		{
+			name: "invalid digitalocean",
+			kind: "DigitaloceanConfig",
+			config: data.Object{
+				"region": "New York",
+				"size":   "s-2vcpu-4gb",
+			},
+			want: []string{
+				`region "New York" must be a DigitalOcean region, such as nyc3`,
+			},
+		},
+		{
+			name: "valid harvester",
+			kind: "HarvesterConfig",
+			config: data.Object{
+				"vmNamespace": "default",
+				"cpuCount":    "2",
+				"memorySize":  "4",
+				"diskInfo":    `{"disks":[{"imageName":"default/ubuntu","size":40}]}`,
+				"networkName": "default/vlan1",
+			},
+		},
+		{
+			name: "invalid harvester",
+			kind: "HarvesterConfig",
+			config: data.Object{
+				"vmNamespace": "Default",
+				"cpuCount":    "two",
+			},
+			want: []string{
+				`vmNamespace "Default" must be a namespace name, a lowercase RFC 1123 label`,
+				`cpuCount "two" must be a positive integer`,
+				"one of imageName, diskInfo is required",
+				"one of networkName, networkInfo is required",
+			},
+		},
+		{
+			name: "invalid linode",
+			kind: "LinodeConfig",
+			config: data.Object{
+				"region":       "us-east",
+				"instanceType": "standard-2",
+				"image":        "ubuntu22.04",
+			},
+			want: []string{
+				`instanceType "standard-2" must be a Linode type, such as g6-standard-2`,
+				`image "ubuntu22.04" must be a Linode image, such as linode/ubuntu22.04`,
+			},
+		},
+		{
+			name: "valid vsphere",
+			kind: "VmwarevsphereConfig",
+			config: data.Object{
+				"vcenter":      "vcenter.example.com",
+				"vcenterPort":  "443",
+				"creationType": "template",
+				"cloneFrom":    "/dc/vm/ubuntu-template",
+				"cpuCount":     "4",
+				"memorySize":   "8192",
+				"diskSize":     "40000",
+			},
+		},
+		{
+			name: "vsphere from iso",
+			kind: "VmwarevsphereConfig",
+			config: data.Object{
+				"vcenter":      "10.0.0.10",
+				"creationType": "legacy",
+			},
+		},
+		{
+			name: "invalid vsphere",
+			kind: "VmwarevsphereConfig",
+			config: data.Object{
+				"vcenter":      "https://vcenter.example.com:443",
+				"vcenterPort":  "70000",
+				"creationType": "library",
+			},
+			want: []string{
+				`vcenter "https://vcenter.example.com:443" must be a hostname or an IP address, without a scheme or port`,
+				`vcenterPort "70000" must be a port between 1 and 65535`,
+				"cloneFrom is required when creationType is library",
+			},
+		},
+		{
+			name:   "defaults of the node driver",
+			kind:   "LinodeConfig",
+			config: data.Object{},
+		},
+		{
+			name:   "other kind",
+			kind:   "OpenstackConfig",
+			config: data.Object{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Validate(tt.kind, tt.config))
+		})
+	}
+}
+
+func TestValidateUpdate(t *testing.T) {
+	oldConfig := data.Object{
+		"region":       "us-east",
+		"instanceType": "t3.medium",
+		"ami":          "ubuntu",
+	}
+
+	assert.Empty(t, ValidateUpdate("Amazonec2Config", oldConfig, oldConfig), "unchanged fields aren't validated")
+
+	config := data.Object{
+		"region":       "us-east",
+		"instanceType": "t3.large",
+		"ami":          "ami-0123456789abcdef0",
+		"zone":         "us-east-1a",
+	}
+	assert.Equal(t, []string{`zone "us-east-1a" must be the letter of an availability zone, such as a`}, ValidateUpdate("Amazonec2Config", config, oldConfig))
+	assert.Len(t, Validate("Amazonec2Config", config), 2)
+
+	assert.Equal(t, []string{"cloneFrom is required when creationType is vm"}, ValidateUpdate("VmwarevsphereConfig",
+		data.Object{"vcenter": "10.0.0.10", "creationType": "vm"},
+		data.Object{"vcenter": "10.0.0.10", "creationType": "legacy"}))
+}
+
+func TestKinds(t *testing.T) {
+	assert.Equal(t, []string{"Amazonec2Config", "AzureConfig", "DigitaloceanConfig", "HarvesterConfig", "LinodeConfig", "VmwarevsphereConfig"}, Kinds())
+}
@@ -0,0 +1,404 @@
+// Package managementgc finds and deletes the objects of the management cluster that are left behind once what they
+// were created for is gone, and that nothing else removes: plan secrets of removed machines, machine templates no
+// longer used by any machine set, tokens of deleted users and the leftovers of finished helm operations. Long-lived
+// installations otherwise accrete tens of thousands of them.
+package managementgc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	capicontrollers "github.com/rancher/rancher/pkg/generated/controllers/cluster.x-k8s.io/v1beta1"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	rkecontrollers "github.com/rancher/rancher/pkg/generated/controllers/rke.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/namespace"
+	"github.com/rancher/rancher/pkg/wrangler"
+	apiextcontrollers "github.com/rancher/wrangler/pkg/generated/controllers/apiextensions.k8s.io/v1"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	CategoryPlanSecrets      = "plan-secrets"
+	CategoryMachineTemplates = "machine-templates"
+	CategoryTokens           = "tokens"
+	CategoryHelmOperations   = "helm-operations"
+
+	// gracePeriod is how old objects must be to be collected, so that objects being created, whose owner or users may
+	// not exist yet, aren't.
+	gracePeriod = time.Hour
+	// helmOperationRetention is how long the pods of helm operations are kept once finished, for their logs.
+	helmOperationRetention = 24 * time.Hour
+	helmOperationPrefix    = "helm-operation-"
+)
+
+// Categories are the categories of objects collected, in the order they're collected.
+var Categories = []string{CategoryPlanSecrets, CategoryMachineTemplates, CategoryTokens, CategoryHelmOperations}
+
+// Candidate is an object to collect.
+type Candidate struct {
+	Category   string `json:"category"`
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Resource   string `json:"-"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	Reason     string `json:"reason"`
+	Deleted    bool   `json:"deleted,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Result is the outcome of a collection. The candidates of a dry run are only listed.
+type Result struct {
+	DryRun     bool        `json:"dryRun"`
+	Candidates []Candidate `json:"candidates"`
+}
+
+// Collector finds and deletes the objects to collect.
+type Collector struct {
+	secretCache            corecontrollers.SecretCache
+	bootstrapCache         rkecontrollers.RKEBootstrapCache
+	machineSetCache        capicontrollers.MachineSetCache
+	machineDeploymentCache capicontrollers.MachineDeploymentCache
+	crdCache               apiextcontrollers.CustomResourceDefinitionCache
+	tokenCache             mgmtcontrollers.TokenCache
+	userCache              mgmtcontrollers.UserCache
+	k8s                    kubernetes.Interface
+	dynamic                dynamic.Interface
+}
+
+func NewCollector(clients *wrangler.Context) (*Collector, error) {
+	dynamicClient, err := dynamic.NewForConfig(clients.RESTConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &Collector{
+		secretCache:            clients.Core.Secret().Cache(),
+		bootstrapCache:         clients.RKE.RKEBootstrap().Cache(),
+		machineSetCache:        clients.CAPI.MachineSet().Cache(),
+		machineDeploymentCache: clients.CAPI.MachineDeployment().Cache(),
+		crdCache:               clients.CRD.CustomResourceDefinition().Cache(),
+		tokenCache:             clients.Mgmt.Token().Cache(),
+		userCache:              clients.Mgmt.User().Cache(),
+		k8s:                    clients.K8s,
+		dynamic:                dynamicClient,
+	}, nil
+}
+
+// ValidateCategories returns an error if any of the categories is unknown.
+func ValidateCategories(categories []string) error {
+	for _, category := range categories {
+		if !contains(Categories, category) {
+			return fmt.Errorf("unknown category %s, must be one of %s", category, strings.Join(Categories, ", "))
+		}
+	}
+	return nil
+}
+
+// Collect finds the objects of the categories to collect, all categories if none are given, and deletes them unless
+// it's a dry run. Failing to delete an object is recorded on its candidate, rather than stopping the collection.
+func (c *Collector) Collect(ctx context.Context, categories []string, dryRun bool, now time.Time) (*Result, error) {
+	if err := ValidateCategories(categories); err != nil {
+		return nil, err
+	}
+	if len(categories) == 0 {
+		categories = Categories
+	}
+
+	finders := map[string]func(ctx context.Context, now time.Time) ([]Candidate, error){
+		CategoryPlanSecrets:      c.planSecrets,
+		CategoryMachineTemplates: c.machineTemplates,
+		CategoryTokens:           c.tokens,
+		CategoryHelmOperations:   c.helmOperations,
+	}
+	result := &Result{DryRun: dryRun, Candidates: []Candidate{}}
+	for _, category := range Categories {
+		if !contains(categories, category) {
+			continue
+		}
+		candidates, err := finders[category](ctx, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find %s to collect: %w", category, err)
+		}
+		result.Candidates = append(result.Candidates, candidates...)
+	}
+
+	if !dryRun {
+		for i := range result.Candidates {
+			if err := c.delete(ctx, result.Candidates[i]); err != nil {
+				result.Candidates[i].Error = err.Error()
+			} else {
+				result.Candidates[i].Deleted = true
+			}
+		}
+	}
+	return result, nil
+}
+
+func (c *Collector) delete(ctx context.Context, candidate Candidate) error {
+	gvr := schema.FromAPIVersionAndKind(candidate.APIVersion, candidate.Kind).GroupVersion().WithResource(candidate.Resource)
+	propagation := metav1.DeletePropagationBackground
+	err := c.dynamic.Resource(gvr).Namespace(candidate.Namespace).Delete(ctx, candidate.Name, metav1.DeleteOptions{
+		PropagationPolicy: &propagation,
+	})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (c *Collector) planSecrets(_ context.Context, now time.Time) ([]Candidate, error) {
+	secrets, err := c.secretCache.List("", labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Candidate
+	for _, secret := range secrets {
+		if secret.Type != capr.SecretTypeMachinePlan || now.Sub(secret.CreationTimestamp.Time) < gracePeriod {
+			continue
+		}
+		reason := stalePlanSecret(secret, func(namespace, name string) (types.UID, bool) {
+			bootstrap, err := c.bootstrapCache.Get(namespace, name)
+			if err != nil {
+				return "", false
+			}
+			return bootstrap.UID, true
+		})
+		if reason != "" {
+			result = append(result, candidate(CategoryPlanSecrets, "v1", "Secret", "secrets", secret, reason))
+		}
+	}
+	return sorted(result), nil
+}
+
+func (c *Collector) machineTemplates(ctx context.Context, now time.Time) ([]Candidate, error) {
+	referenced, err := c.referencedMachineTemplates()
+	if err != nil {
+		return nil, err
+	}
+	crds, err := c.crdCache.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Candidate
+	for _, crd := range crds {
+		gvk := schema.FromAPIVersionAndKind(capr.RKEMachineAPIVersion, crd.Spec.Names.Kind)
+		if crd.Spec.Group != gvk.Group || !strings.HasSuffix(crd.Spec.Names.Kind, "MachineTemplate") {
+			continue
+		}
+		templates, err := c.dynamic.Resource(gvk.GroupVersion().WithResource(crd.Spec.Names.Plural)).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for i := range templates.Items {
+			template := &templates.Items[i]
+			key := machineTemplateKey(template.GetNamespace(), template.GetKind(), template.GetName())
+			if reason := orphanedMachineTemplate(template, referenced[key], now); reason != "" {
+				result = append(result, candidate(CategoryMachineTemplates, capr.RKEMachineAPIVersion, crd.Spec.Names.Kind, crd.Spec.Names.Plural, template, reason))
+			}
+		}
+	}
+	return sorted(result), nil
+}
+
+// referencedMachineTemplates returns the machine templates the machine sets and machine deployments are created from.
+func (c *Collector) referencedMachineTemplates() (map[string]bool, error) {
+	result := map[string]bool{}
+	machineSets, err := c.machineSetCache.List("", labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, machineSet := range machineSets {
+		ref := machineSet.Spec.Template.Spec.InfrastructureRef
+		result[machineTemplateKey(machineSet.Namespace, ref.Kind, ref.Name)] = true
+	}
+	machineDeployments, err := c.machineDeploymentCache.List("", labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, machineDeployment := range machineDeployments {
+		ref := machineDeployment.Spec.Template.Spec.InfrastructureRef
+		result[machineTemplateKey(machineDeployment.Namespace, ref.Kind, ref.Name)] = true
+	}
+	return result, nil
+}
+
+func (c *Collector) tokens(_ context.Context, now time.Time) ([]Candidate, error) {
+	tokens, err := c.tokenCache.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Candidate
+	for _, token := range tokens {
+		if now.Sub(token.CreationTimestamp.Time) < gracePeriod {
+			continue
+		}
+		userExists := true
+		if token.UserID != "" {
+			if _, err := c.userCache.Get(token.UserID); apierrors.IsNotFound(err) {
+				userExists = false
+			} else if err != nil {
+				return nil, err
+			}
+		}
+		if reason := orphanedToken(token, userExists); reason != "" {
+			result = append(result, candidate(CategoryTokens, v3.SchemeGroupVersion.String(), "Token", v3.TokenResourceName, token, reason))
+		}
+	}
+	return sorted(result), nil
+}
+
+func (c *Collector) helmOperations(ctx context.Context, now time.Time) ([]Candidate, error) {
+	pods, err := c.k8s.CoreV1().Pods(namespace.System).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Candidate
+	seen := map[string]bool{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		reason := finishedHelmOperation(pod, now)
+		if reason == "" {
+			continue
+		}
+		// The role of the operation owns its pod, configmaps, secret and service account, which are deleted with it.
+		if owner := roleOwner(pod); owner != "" {
+			if !seen[owner] {
+				seen[owner] = true
+				result = append(result, Candidate{
+					Category:   CategoryHelmOperations,
+					APIVersion: rbacv1.SchemeGroupVersion.String(),
+					Kind:       "ClusterRole",
+					Resource:   "clusterroles",
+					Name:       owner,
+					Reason:     reason,
+				})
+			}
+			continue
+		}
+		result = append(result, candidate(CategoryHelmOperations, "v1", "Pod", "pods", pod, reason))
+	}
+	return sorted(result), nil
+}
+
+// stalePlanSecret returns why a plan secret is stale, if it isn't owned by an existing bootstrap. The plan secrets of
+// machines are owned by their bootstrap, and deleted with it unless the owner reference was lost.
+func stalePlanSecret(secret *corev1.Secret, bootstrapUID func(namespace, name string) (types.UID, bool)) string {
+	for _, owner := range secret.OwnerReferences {
+		if owner.Kind != "RKEBootstrap" || owner.APIVersion != rkev1.SchemeGroupVersion.String() {
+			continue
+		}
+		uid, ok := bootstrapUID(secret.Namespace, owner.Name)
+		if !ok {
+			return fmt.Sprintf("bootstrap %s of machine %s was removed", owner.Name, secret.Labels[capr.MachineNameLabel])
+		}
+		if uid != owner.UID {
+			return fmt.Sprintf("bootstrap %s was replaced", owner.Name)
+		}
+		return ""
+	}
+	return "not owned by a bootstrap"
+}
+
+// orphanedMachineTemplate returns why a machine template is orphaned, if no machine set or machine deployment is
+// created from it. A new machine template is created whenever a machine pool changes, and the previous ones aren't
+// removed.
+func orphanedMachineTemplate(template metav1.Object, referenced bool, now time.Time) string {
+	if referenced || now.Sub(template.GetCreationTimestamp().Time) < gracePeriod {
+		return ""
+	}
+	if len(template.GetOwnerReferences()) == 0 {
+		return "no owner and not used by any machine set"
+	}
+	return "not used by any machine set"
+}
+
+// orphanedToken returns why a token is orphaned, if its user was deleted.
+func orphanedToken(token *v3.Token, userExists bool) string {
+	if token.UserID == "" || userExists {
+		return ""
+	}
+	return fmt.Sprintf("user %s was deleted", token.UserID)
+}
+
+// finishedHelmOperation returns why the pod of a helm operation can be collected, if it finished more than the
+// retention ago.
+func finishedHelmOperation(pod *corev1.Pod, now time.Time) string {
+	if !strings.HasPrefix(pod.Name, helmOperationPrefix) ||
+		(pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed) {
+		return ""
+	}
+	finished := pod.CreationTimestamp.Time
+	for _, status := range pod.Status.ContainerStatuses {
+		if terminated := status.State.Terminated; terminated != nil && terminated.FinishedAt.After(finished) {
+			finished = terminated.FinishedAt.Time
+		}
+	}
+	if now.Sub(finished) < helmOperationRetention {
+		return ""
+	}
+	return fmt.Sprintf("helm operation pod %s %s at %s", pod.Name, strings.ToLower(string(pod.Status.Phase)), finished.UTC().Format(time.RFC3339))
+}
+
+// roleOwner returns the name of the cluster role owning a pod, if any.
+func roleOwner(pod *corev1.Pod) string {
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == "ClusterRole" && owner.APIVersion == rbacv1.SchemeGroupVersion.String() {
+			return owner.Name
+		}
+	}
+	return ""
+}
+
+func candidate(category, apiVersion, kind, resource string, obj metav1.Object, reason string) Candidate {
+	return Candidate{
+		Category:   category,
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Resource:   resource,
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		Reason:     reason,
+	}
+}
+
+func machineTemplateKey(namespace, kind, name string) string {
+	return namespace + "/" + kind + "/" + name
+}
+
+func sorted(candidates []Candidate) []Candidate {
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Namespace != candidates[j].Namespace {
+			return candidates[i].Namespace < candidates[j].Namespace
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	return candidates
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
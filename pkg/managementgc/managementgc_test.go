@@ -0,0 +1,134 @@
+package managementgc
+
+import (
+	"testing"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var now = time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func TestStalePlanSecret(t *testing.T) {
+	bootstraps := map[string]types.UID{"fleet-default/pool-abcde": "bootstrap-uid"}
+	bootstrapUID := func(namespace, name string) (types.UID, bool) {
+		uid, ok := bootstraps[namespace+"/"+name]
+		return uid, ok
+	}
+	planSecret := func(owners ...metav1.OwnerReference) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "pool-abcde-machine-plan",
+				Namespace:       "fleet-default",
+				Labels:          map[string]string{capr.MachineNameLabel: "pool-abcde"},
+				OwnerReferences: owners,
+			},
+			Type: capr.SecretTypeMachinePlan,
+		}
+	}
+	bootstrap := func(name string, uid types.UID) metav1.OwnerReference {
+		return metav1.OwnerReference{APIVersion: "rke.cattle.io/v1", Kind: "RKEBootstrap", Name: name, UID: uid}
+	}
+
+	tests := []struct {
+		name   string
+		secret *corev1.Secret
+		want   string
+	}{
+		{
+			name:   "owned by existing bootstrap",
+			secret: planSecret(bootstrap("pool-abcde", "bootstrap-uid")),
+		},
+		{
+			name:   "bootstrap removed",
+			secret: planSecret(bootstrap("pool-fghij", "other-uid")),
+			want:   "bootstrap pool-fghij of machine pool-abcde was removed",
+		},
+		{
+			name:   "bootstrap replaced",
+			secret: planSecret(bootstrap("pool-abcde", "old-uid")),
+			want:   "bootstrap pool-abcde was replaced",
+		},
+		{
+			name:   "no owner",
+			secret: planSecret(),
+			want:   "not owned by a bootstrap",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, stalePlanSecret(tt.secret, bootstrapUID))
+		})
+	}
+}
+
+func TestOrphanedMachineTemplate(t *testing.T) {
+	template := func(age time.Duration, owners ...metav1.OwnerReference) *metav1.ObjectMeta {
+		return &metav1.ObjectMeta{
+			Name:              "pool-template-abcde",
+			CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			OwnerReferences:   owners,
+		}
+	}
+	owner := metav1.OwnerReference{APIVersion: "provisioning.cattle.io/v1", Kind: "Cluster", Name: "cluster"}
+
+	assert.Empty(t, orphanedMachineTemplate(template(2*time.Hour), true, now), "referenced")
+	assert.Empty(t, orphanedMachineTemplate(template(time.Minute), false, now), "within grace period")
+	assert.Equal(t, "no owner and not used by any machine set", orphanedMachineTemplate(template(2*time.Hour), false, now))
+	assert.Equal(t, "not used by any machine set", orphanedMachineTemplate(template(2*time.Hour, owner), false, now))
+}
+
+func TestOrphanedToken(t *testing.T) {
+	token := &v3.Token{UserID: "u-abcde"}
+	assert.Empty(t, orphanedToken(token, true))
+	assert.Equal(t, "user u-abcde was deleted", orphanedToken(token, false))
+	assert.Empty(t, orphanedToken(&v3.Token{}, false), "tokens without a user aren't collected")
+}
+
+func TestFinishedHelmOperation(t *testing.T) {
+	pod := func(name string, phase corev1.PodPhase, finished time.Time) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "cattle-system",
+				CreationTimestamp: metav1.NewTime(now.Add(-72 * time.Hour)),
+			},
+			Status: corev1.PodStatus{
+				Phase: phase,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{FinishedAt: metav1.NewTime(finished)},
+					},
+				}},
+			},
+		}
+	}
+
+	assert.Equal(t, "helm operation pod helm-operation-abcde succeeded at 2023-04-29T12:00:00Z",
+		finishedHelmOperation(pod("helm-operation-abcde", corev1.PodSucceeded, now.Add(-48*time.Hour)), now))
+	assert.Equal(t, "helm operation pod helm-operation-abcde failed at 2023-04-29T12:00:00Z",
+		finishedHelmOperation(pod("helm-operation-abcde", corev1.PodFailed, now.Add(-48*time.Hour)), now))
+	assert.Empty(t, finishedHelmOperation(pod("helm-operation-abcde", corev1.PodRunning, now.Add(-48*time.Hour)), now), "running")
+	assert.Empty(t, finishedHelmOperation(pod("helm-operation-abcde", corev1.PodSucceeded, now.Add(-time.Hour)), now), "within retention")
+	assert.Empty(t, finishedHelmOperation(pod("rancher-abcde", corev1.PodSucceeded, now.Add(-48*time.Hour)), now), "not a helm operation")
+}
+
+func TestRoleOwner(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{
+		{APIVersion: "v1", Kind: "ConfigMap", Name: "other"},
+		{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole", Name: "pod-impersonation-helm-op-abcde"},
+	}}}
+	assert.Equal(t, "pod-impersonation-helm-op-abcde", roleOwner(pod))
+	assert.Empty(t, roleOwner(&corev1.Pod{}))
+}
+
+func TestValidateCategories(t *testing.T) {
+	assert.NoError(t, ValidateCategories(nil))
+	assert.NoError(t, ValidateCategories([]string{CategoryTokens, CategoryHelmOperations}))
+	assert.Error(t, ValidateCategories([]string{"pods"}))
+}
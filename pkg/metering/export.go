@@ -0,0 +1,149 @@
+package metering
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+)
+
+const (
+	GroupByCluster = "cluster"
+	GroupByTeam    = "team"
+	GroupByProject = "project"
+)
+
+// Row is the usage of a cluster, team or project over the period of a metering record.
+type Row struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// Key is the cluster, team or project.
+	Key string `json:"key"`
+	// Clusters is the number of clusters the usage is accounted from.
+	Clusters           int     `json:"clusters"`
+	NodeHours          float64 `json:"nodeHours"`
+	CoreHours          float64 `json:"coreHours"`
+	MaxNodes           int     `json:"maxNodes"`
+	RequestedCoreHours float64 `json:"requestedCoreHours"`
+}
+
+// Aggregate returns the usage of metering records grouped by cluster, team or project, sorted by period and key.
+// Projects don't have node and core usage, only the CPU requested by their workloads.
+func Aggregate(records []*v3.MeteringRecord, groupBy string) ([]Row, error) {
+	var result []Row
+	for _, record := range records {
+		rows := map[string]*Row{}
+		clusters := map[string]map[string]bool{}
+		row := func(key string) *Row {
+			if r, ok := rows[key]; ok {
+				return r
+			}
+			r := &Row{Start: record.Spec.Start, End: record.Spec.End, Key: key}
+			rows[key] = r
+			clusters[key] = map[string]bool{}
+			return r
+		}
+
+		switch groupBy {
+		case GroupByCluster, GroupByTeam:
+			for _, usage := range record.Spec.Clusters {
+				key := usage.ClusterName
+				if groupBy == GroupByTeam {
+					key = usage.Team
+				}
+				r := row(key)
+				clusters[key][usage.ClusterName] = true
+				r.NodeHours += hours(usage.NodeSeconds)
+				r.CoreHours += hours(usage.CoreSeconds)
+				r.MaxNodes += usage.MaxNodes
+			}
+			for _, usage := range record.Spec.Projects {
+				key := usage.ClusterName
+				if groupBy == GroupByTeam {
+					key = usage.Team
+				}
+				r := row(key)
+				clusters[key][usage.ClusterName] = true
+				r.RequestedCoreHours += hours(usage.RequestedMilliCoreSeconds) / 1000
+			}
+		case GroupByProject:
+			for _, usage := range record.Spec.Projects {
+				r := row(usage.ProjectName)
+				clusters[usage.ProjectName][usage.ClusterName] = true
+				r.RequestedCoreHours += hours(usage.RequestedMilliCoreSeconds) / 1000
+			}
+		default:
+			return nil, fmt.Errorf("invalid groupBy %q, must be %s, %s or %s", groupBy, GroupByCluster, GroupByTeam, GroupByProject)
+		}
+
+		for key, r := range rows {
+			r.Clusters = len(clusters[key])
+			result = append(result, *r)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Start != result[j].Start {
+			return result[i].Start < result[j].Start
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result, nil
+}
+
+// Between returns the metering records of the periods starting from from and before to, sorted by start. Zero times
+// don't bound the periods.
+func Between(records []*v3.MeteringRecord, from, to time.Time) []*v3.MeteringRecord {
+	var result []*v3.MeteringRecord
+	for _, record := range records {
+		start, err := time.Parse(time.RFC3339, record.Spec.Start)
+		if err != nil {
+			continue
+		}
+		if (!from.IsZero() && start.Before(from)) || (!to.IsZero() && !start.Before(to)) {
+			continue
+		}
+		result = append(result, record)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Spec.Start < result[j].Spec.Start
+	})
+	return result
+}
+
+func hours(seconds int64) float64 {
+	return float64(seconds) / 3600
+}
+
+// WriteCSV writes rows as CSV with a header naming the key column after what the rows are grouped by.
+func WriteCSV(w io.Writer, groupBy string, rows []Row) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"start", "end", groupBy, "clusters", "nodeHours", "coreHours", "maxNodes", "requestedCoreHours"}); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		err := writer.Write([]string{
+			r.Start,
+			r.End,
+			r.Key,
+			strconv.Itoa(r.Clusters),
+			formatHours(r.NodeHours),
+			formatHours(r.CoreHours),
+			strconv.Itoa(r.MaxNodes),
+			formatHours(r.RequestedCoreHours),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatHours(h float64) string {
+	return strconv.FormatFloat(h, 'f', 3, 64)
+}
@@ -0,0 +1,86 @@
+package metering
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func testRecords() []*v3.MeteringRecord {
+	return []*v3.MeteringRecord{
+		{
+			Spec: v3.MeteringRecordSpec{
+				Start: "2026-10-16T00:00:00Z",
+				End:   "2026-10-17T00:00:00Z",
+				Clusters: []v3.MeteringClusterUsage{
+					{ClusterName: "c-1", Team: "payments", NodeSeconds: 3 * 3600, CoreSeconds: 12 * 3600, MaxNodes: 3},
+					{ClusterName: "c-2", Team: "payments", NodeSeconds: 3600, CoreSeconds: 2 * 3600, MaxNodes: 1},
+				},
+				Projects: []v3.MeteringProjectUsage{
+					{ProjectName: "c-1:p-1", ClusterName: "c-1", Team: "payments", RequestedMilliCoreSeconds: 1500 * 3600},
+					{ProjectName: "c-1:p-2", ClusterName: "c-1", Team: "search", RequestedMilliCoreSeconds: 500 * 3600},
+				},
+			},
+		},
+		{
+			Spec: v3.MeteringRecordSpec{
+				Start: "2026-10-15T00:00:00Z",
+				End:   "2026-10-16T00:00:00Z",
+				Clusters: []v3.MeteringClusterUsage{
+					{ClusterName: "c-1", Team: "payments", NodeSeconds: 1800, CoreSeconds: 7200, MaxNodes: 1},
+				},
+			},
+		},
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	records := testRecords()
+
+	rows, err := Aggregate(records, GroupByCluster)
+	assert.NoError(t, err)
+	assert.Equal(t, []Row{
+		{Start: "2026-10-15T00:00:00Z", End: "2026-10-16T00:00:00Z", Key: "c-1", Clusters: 1, NodeHours: 0.5, CoreHours: 2, MaxNodes: 1},
+		{Start: "2026-10-16T00:00:00Z", End: "2026-10-17T00:00:00Z", Key: "c-1", Clusters: 1, NodeHours: 3, CoreHours: 12, MaxNodes: 3, RequestedCoreHours: 2},
+		{Start: "2026-10-16T00:00:00Z", End: "2026-10-17T00:00:00Z", Key: "c-2", Clusters: 1, NodeHours: 1, CoreHours: 2, MaxNodes: 1},
+	}, rows)
+
+	rows, err = Aggregate(records[:1], GroupByTeam)
+	assert.NoError(t, err)
+	assert.Equal(t, []Row{
+		{Start: "2026-10-16T00:00:00Z", End: "2026-10-17T00:00:00Z", Key: "payments", Clusters: 2, NodeHours: 4, CoreHours: 14, MaxNodes: 4, RequestedCoreHours: 1.5},
+		{Start: "2026-10-16T00:00:00Z", End: "2026-10-17T00:00:00Z", Key: "search", Clusters: 1, RequestedCoreHours: 0.5},
+	}, rows)
+
+	rows, err = Aggregate(records[:1], GroupByProject)
+	assert.NoError(t, err)
+	assert.Equal(t, []Row{
+		{Start: "2026-10-16T00:00:00Z", End: "2026-10-17T00:00:00Z", Key: "c-1:p-1", Clusters: 1, RequestedCoreHours: 1.5},
+		{Start: "2026-10-16T00:00:00Z", End: "2026-10-17T00:00:00Z", Key: "c-1:p-2", Clusters: 1, RequestedCoreHours: 0.5},
+	}, rows)
+
+	_, err = Aggregate(records, "namespace")
+	assert.Error(t, err)
+}
+
+func TestBetween(t *testing.T) {
+	records := testRecords()
+	from := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, []*v3.MeteringRecord{records[1], records[0]}, Between(records, time.Time{}, time.Time{}))
+	assert.Equal(t, []*v3.MeteringRecord{records[0]}, Between(records, from, time.Time{}))
+	assert.Equal(t, []*v3.MeteringRecord{records[1]}, Between(records, time.Time{}, from))
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteCSV(&buf, GroupByTeam, []Row{
+		{Start: "2026-10-16T00:00:00Z", End: "2026-10-17T00:00:00Z", Key: "payments", Clusters: 2, NodeHours: 4, CoreHours: 14.25, MaxNodes: 4, RequestedCoreHours: 1.5},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "start,end,team,clusters,nodeHours,coreHours,maxNodes,requestedCoreHours\n"+
+		"2026-10-16T00:00:00Z,2026-10-17T00:00:00Z,payments,2,4.000,14.250,4,1.500\n", buf.String())
+}
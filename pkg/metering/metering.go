@@ -0,0 +1,169 @@
+// Package metering records the usage of clusters and projects in metering records and aggregates the records for
+// export.
+package metering
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Sample is the usage of the clusters and projects at a point in time.
+type Sample struct {
+	Clusters []ClusterSample
+	Projects []ProjectSample
+}
+
+type ClusterSample struct {
+	ClusterName string
+	Team        string
+	Nodes       int
+	Cores       int64
+}
+
+type ProjectSample struct {
+	ProjectName         string
+	ClusterName         string
+	Team                string
+	RequestedMilliCores int64
+}
+
+// TakeSample returns the usage of clusters and projects. The team of a project is the value of its team label, or else
+// the team of its cluster.
+func TakeSample(clusters []*v3.Cluster, projects []*v3.Project, teamLabel string) Sample {
+	var result Sample
+	teams := map[string]string{}
+	for _, cluster := range clusters {
+		if cluster.DeletionTimestamp != nil {
+			continue
+		}
+		sample := ClusterSample{
+			ClusterName: cluster.Name,
+			Team:        cluster.Labels[teamLabel],
+			Nodes:       cluster.Status.NodeCount,
+		}
+		if cpu, ok := cluster.Status.Capacity["cpu"]; ok {
+			sample.Cores = cpu.Value()
+		}
+		teams[cluster.Name] = sample.Team
+		result.Clusters = append(result.Clusters, sample)
+	}
+
+	for _, project := range projects {
+		team, ok := teams[project.Namespace]
+		if !ok || project.DeletionTimestamp != nil {
+			continue
+		}
+		if projectTeam := project.Labels[teamLabel]; projectTeam != "" {
+			team = projectTeam
+		}
+		sample := ProjectSample{
+			ProjectName: project.Namespace + ":" + project.Name,
+			ClusterName: project.Namespace,
+			Team:        team,
+		}
+		if quota := project.Spec.ResourceQuota; quota != nil && quota.UsedLimit.RequestsCPU != "" {
+			if cpu, err := resource.ParseQuantity(quota.UsedLimit.RequestsCPU); err == nil {
+				sample.RequestedMilliCores = cpu.MilliValue()
+			}
+		}
+		result.Projects = append(result.Projects, sample)
+	}
+	return result
+}
+
+// Period returns the start and end of the aggregation period containing t, in UTC.
+func Period(t time.Time, aggregation string) (time.Time, time.Time) {
+	t = t.UTC()
+	if aggregation == v3.MeteringAggregationHourly {
+		start := t.Truncate(time.Hour)
+		return start, start.Add(time.Hour)
+	}
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 0, 1)
+}
+
+// RecordName returns the name of the metering record of the period starting at start.
+func RecordName(start time.Time, aggregation string) string {
+	if aggregation == v3.MeteringAggregationHourly {
+		return "hourly-" + start.UTC().Format("20060102-15")
+	}
+	return "daily-" + start.UTC().Format("20060102")
+}
+
+// SampleDuration returns how long a sample taken at now accounts for, given the time of the previous sample. Samples
+// account for the time since the previous sample, and for a single interval after a gap in sampling, for example while
+// rancher was down, so that usage that wasn't observed isn't charged.
+func SampleDuration(lastSampled, now time.Time, interval time.Duration) time.Duration {
+	if lastSampled.IsZero() {
+		return interval
+	}
+	d := now.Sub(lastSampled)
+	if d <= 0 {
+		return 0
+	}
+	if d > 2*interval {
+		return interval
+	}
+	return d
+}
+
+// Add adds a sample accounting for duration to the usage of a metering record.
+func Add(spec *v3.MeteringRecordSpec, sample Sample, duration time.Duration) {
+	seconds := int64(duration / time.Second)
+
+	clusters := map[string]int{}
+	for i, usage := range spec.Clusters {
+		clusters[usage.ClusterName] = i
+	}
+	for _, s := range sample.Clusters {
+		i, ok := clusters[s.ClusterName]
+		if !ok {
+			i = len(spec.Clusters)
+			clusters[s.ClusterName] = i
+			spec.Clusters = append(spec.Clusters, v3.MeteringClusterUsage{ClusterName: s.ClusterName})
+		}
+		usage := &spec.Clusters[i]
+		usage.Team = s.Team
+		usage.NodeSeconds += int64(s.Nodes) * seconds
+		usage.CoreSeconds += s.Cores * seconds
+		if s.Nodes > usage.MaxNodes {
+			usage.MaxNodes = s.Nodes
+		}
+	}
+
+	projects := map[string]int{}
+	for i, usage := range spec.Projects {
+		projects[usage.ProjectName] = i
+	}
+	for _, s := range sample.Projects {
+		i, ok := projects[s.ProjectName]
+		if !ok {
+			i = len(spec.Projects)
+			projects[s.ProjectName] = i
+			spec.Projects = append(spec.Projects, v3.MeteringProjectUsage{ProjectName: s.ProjectName, ClusterName: s.ClusterName})
+		}
+		usage := &spec.Projects[i]
+		usage.Team = s.Team
+		usage.RequestedMilliCoreSeconds += s.RequestedMilliCores * seconds
+	}
+
+	sort.Slice(spec.Clusters, func(i, j int) bool {
+		return spec.Clusters[i].ClusterName < spec.Clusters[j].ClusterName
+	})
+	sort.Slice(spec.Projects, func(i, j int) bool {
+		return spec.Projects[i].ProjectName < spec.Projects[j].ProjectName
+	})
+}
+
+// Expired returns whether a metering record ended more than retention before now.
+func Expired(record *v3.MeteringRecord, now time.Time, retention time.Duration) (bool, error) {
+	end, err := time.Parse(time.RFC3339, record.Spec.End)
+	if err != nil {
+		return false, fmt.Errorf("invalid end of metering record %s: %w", record.Name, err)
+	}
+	return end.Add(retention).Before(now), nil
+}
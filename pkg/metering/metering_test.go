@@ -0,0 +1,121 @@
+package metering
+
+import (
+	"testing"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTakeSample(t *testing.T) {
+	now := metav1.Now()
+	clusters := []*v3.Cluster{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "c-1", Labels: map[string]string{"team": "payments"}},
+			Status: v3.ClusterStatus{
+				NodeCount: 3,
+				Capacity:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("12")},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "c-2"},
+			Status:     v3.ClusterStatus{NodeCount: 1},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "c-3", DeletionTimestamp: &now},
+			Status:     v3.ClusterStatus{NodeCount: 5},
+		},
+	}
+	projects := []*v3.Project{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "c-1", Name: "p-1"},
+			Spec: v3.ProjectSpec{ResourceQuota: &v3.ProjectResourceQuota{
+				UsedLimit: v3.ResourceQuotaLimit{RequestsCPU: "1500m"},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "c-1", Name: "p-2", Labels: map[string]string{"team": "search"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "c-3", Name: "p-3"},
+		},
+	}
+
+	assert.Equal(t, Sample{
+		Clusters: []ClusterSample{
+			{ClusterName: "c-1", Team: "payments", Nodes: 3, Cores: 12},
+			{ClusterName: "c-2", Nodes: 1},
+		},
+		Projects: []ProjectSample{
+			{ProjectName: "c-1:p-1", ClusterName: "c-1", Team: "payments", RequestedMilliCores: 1500},
+			{ProjectName: "c-1:p-2", ClusterName: "c-1", Team: "search"},
+		},
+	}, TakeSample(clusters, projects, "team"))
+}
+
+func TestPeriod(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 35, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	start, end := Period(now, v3.MeteringAggregationDaily)
+	assert.Equal(t, time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), end)
+	assert.Equal(t, "daily-20261016", RecordName(start, v3.MeteringAggregationDaily))
+
+	start, end = Period(now, v3.MeteringAggregationHourly)
+	assert.Equal(t, time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2026, 10, 16, 13, 0, 0, 0, time.UTC), end)
+	assert.Equal(t, "hourly-20261016-12", RecordName(start, v3.MeteringAggregationHourly))
+}
+
+func TestSampleDuration(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	interval := 5 * time.Minute
+
+	assert.Equal(t, interval, SampleDuration(time.Time{}, now, interval))
+	assert.Equal(t, 6*time.Minute, SampleDuration(now.Add(-6*time.Minute), now, interval))
+	assert.Equal(t, interval, SampleDuration(now.Add(-time.Hour), now, interval))
+	assert.Equal(t, time.Duration(0), SampleDuration(now.Add(time.Minute), now, interval))
+}
+
+func TestAdd(t *testing.T) {
+	spec := &v3.MeteringRecordSpec{}
+	Add(spec, Sample{
+		Clusters: []ClusterSample{{ClusterName: "c-2", Nodes: 1, Cores: 2}, {ClusterName: "c-1", Team: "payments", Nodes: 3, Cores: 12}},
+		Projects: []ProjectSample{{ProjectName: "c-1:p-1", ClusterName: "c-1", Team: "payments", RequestedMilliCores: 1500}},
+	}, 5*time.Minute)
+	Add(spec, Sample{
+		Clusters: []ClusterSample{{ClusterName: "c-1", Team: "search", Nodes: 4, Cores: 16}},
+		Projects: []ProjectSample{{ProjectName: "c-1:p-1", ClusterName: "c-1", Team: "payments", RequestedMilliCores: 500}},
+	}, 10*time.Minute)
+
+	assert.Equal(t, []v3.MeteringClusterUsage{
+		{ClusterName: "c-1", Team: "search", NodeSeconds: 3*300 + 4*600, CoreSeconds: 12*300 + 16*600, MaxNodes: 4},
+		{ClusterName: "c-2", NodeSeconds: 300, CoreSeconds: 600, MaxNodes: 1},
+	}, spec.Clusters)
+	assert.Equal(t, []v3.MeteringProjectUsage{
+		{ProjectName: "c-1:p-1", ClusterName: "c-1", Team: "payments", RequestedMilliCoreSeconds: 1500*300 + 500*600},
+	}, spec.Projects)
+}
+
+func TestExpired(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	record := func(end string) *v3.MeteringRecord {
+		return &v3.MeteringRecord{ObjectMeta: metav1.ObjectMeta{Name: "daily"}, Spec: v3.MeteringRecordSpec{End: end}}
+	}
+	retention := 7 * 24 * time.Hour
+
+	expired, err := Expired(record("2026-10-09T00:00:00Z"), now, retention)
+	assert.NoError(t, err)
+	assert.True(t, expired)
+
+	expired, err = Expired(record("2026-10-10T00:00:00Z"), now, retention)
+	assert.NoError(t, err)
+	assert.False(t, expired)
+
+	_, err = Expired(record(""), now, retention)
+	assert.Error(t, err)
+}
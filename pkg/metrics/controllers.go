@@ -0,0 +1,289 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	controllerLabel = "controller"
+
+	// RecentRetriesMinutes is the period over which the recent retries of a ControllerSummary are counted.
+	RecentRetriesMinutes = 15
+)
+
+var (
+	controllerQueueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: "controller",
+			Name:      "queue_depth",
+			Help:      "Number of objects waiting in the workqueue of each controller",
+		}, []string{controllerLabel},
+	)
+
+	controllerQueueAdds = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: "controller",
+			Name:      "queue_adds_total",
+			Help:      "Number of objects added to the workqueue of each controller",
+		}, []string{controllerLabel},
+	)
+
+	controllerRetries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: "controller",
+			Name:      "reconcile_retries_total",
+			Help:      "Number of reconciles of each controller that failed and were retried",
+		}, []string{controllerLabel},
+	)
+
+	controllerQueueSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: "controller",
+			Name:      "queue_seconds",
+			Help:      "Seconds objects waited in the workqueue of each controller before being reconciled",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+		}, []string{controllerLabel},
+	)
+
+	controllerReconcileSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: "controller",
+			Name:      "reconcile_seconds",
+			Help:      "Seconds each controller took to reconcile objects",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+		}, []string{controllerLabel},
+	)
+
+	controllerUnfinishedSeconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: "controller",
+			Name:      "unfinished_reconcile_seconds",
+			Help:      "Seconds the reconciles in progress of each controller have been running for",
+		}, []string{controllerLabel},
+	)
+
+	controllerLongestReconcileSeconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: "controller",
+			Name:      "longest_running_reconcile_seconds",
+			Help:      "Seconds the longest running reconcile of each controller has been running for",
+		}, []string{controllerLabel},
+	)
+
+	controllerStats = newControllerTracker(time.Now)
+)
+
+// ControllerSummary summarizes the workqueue of a controller since Rancher started.
+type ControllerSummary struct {
+	// Controller is the name of the workqueue of the controller, the resource it reconciles.
+	Controller string `json:"controller"`
+	QueueDepth int64  `json:"queueDepth"`
+	Adds       uint64 `json:"adds"`
+	Reconciles uint64 `json:"reconciles"`
+	Retries    uint64 `json:"retries"`
+	// RecentRetries are the retries of the last RecentRetriesMinutes minutes.
+	RecentRetries           uint64  `json:"recentRetries"`
+	AverageReconcileSeconds float64 `json:"averageReconcileSeconds"`
+	// ErrorRate is the ratio of the reconciles that failed and were retried.
+	ErrorRate float64 `json:"errorRate"`
+}
+
+// SetupControllerMetrics sets the provider of the metrics of the workqueues of the controllers. It must be called
+// before any controller is started, as the workqueues created before get no metrics.
+func SetupControllerMetrics() {
+	workqueue.SetProvider(workqueueMetricsProvider{})
+}
+
+// TopControllers returns the summaries of at most limit controllers, those with the most recent retries first, then
+// those with the most retries and the deepest queues.
+func TopControllers(limit int) []ControllerSummary {
+	summaries := controllerStats.summaries()
+	if limit > 0 && len(summaries) > limit {
+		summaries = summaries[:limit]
+	}
+	return summaries
+}
+
+func registerControllerMetrics() {
+	prometheus.MustRegister(controllerQueueDepth)
+	prometheus.MustRegister(controllerQueueAdds)
+	prometheus.MustRegister(controllerRetries)
+	prometheus.MustRegister(controllerQueueSeconds)
+	prometheus.MustRegister(controllerReconcileSeconds)
+	prometheus.MustRegister(controllerUnfinishedSeconds)
+	prometheus.MustRegister(controllerLongestReconcileSeconds)
+}
+
+type queueStats struct {
+	depth            int64
+	adds             uint64
+	reconciles       uint64
+	reconcileSeconds float64
+	retries          uint64
+	// recentRetries are the retries of each of the last minutes, recentMinutes the minutes they were counted in.
+	recentRetries [RecentRetriesMinutes]uint64
+	recentMinutes [RecentRetriesMinutes]int64
+}
+
+// controllerTracker keeps the statistics of the workqueues of the controllers, summarized by the diagnostics API.
+type controllerTracker struct {
+	sync.Mutex
+	now    func() time.Time
+	queues map[string]*queueStats
+}
+
+func newControllerTracker(now func() time.Time) *controllerTracker {
+	return &controllerTracker{
+		now:    now,
+		queues: map[string]*queueStats{},
+	}
+}
+
+func (t *controllerTracker) update(name string, f func(*queueStats)) {
+	t.Lock()
+	defer t.Unlock()
+	stats, ok := t.queues[name]
+	if !ok {
+		stats = &queueStats{}
+		t.queues[name] = stats
+	}
+	f(stats)
+}
+
+func (t *controllerTracker) retry(name string) {
+	minute := t.now().Unix() / 60
+	t.update(name, func(stats *queueStats) {
+		stats.retries++
+		i := minute % RecentRetriesMinutes
+		if stats.recentMinutes[i] != minute {
+			stats.recentMinutes[i] = minute
+			stats.recentRetries[i] = 0
+		}
+		stats.recentRetries[i]++
+	})
+}
+
+func (t *controllerTracker) summaries() []ControllerSummary {
+	minute := t.now().Unix() / 60
+	t.Lock()
+	result := make([]ControllerSummary, 0, len(t.queues))
+	for name, stats := range t.queues {
+		summary := ControllerSummary{
+			Controller: name,
+			QueueDepth: stats.depth,
+			Adds:       stats.adds,
+			Reconciles: stats.reconciles,
+			Retries:    stats.retries,
+		}
+		for i, m := range stats.recentMinutes {
+			if minute-m < RecentRetriesMinutes {
+				summary.RecentRetries += stats.recentRetries[i]
+			}
+		}
+		if stats.reconciles > 0 {
+			summary.AverageReconcileSeconds = stats.reconcileSeconds / float64(stats.reconciles)
+			summary.ErrorRate = float64(stats.retries) / float64(stats.reconciles)
+		}
+		result = append(result, summary)
+	}
+	t.Unlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].RecentRetries != result[j].RecentRetries {
+			return result[i].RecentRetries > result[j].RecentRetries
+		}
+		if result[i].Retries != result[j].Retries {
+			return result[i].Retries > result[j].Retries
+		}
+		if result[i].QueueDepth != result[j].QueueDepth {
+			return result[i].QueueDepth > result[j].QueueDepth
+		}
+		return result[i].Controller < result[j].Controller
+	})
+	return result
+}
+
+// workqueueMetricsProvider implements workqueue.MetricsProvider, the workqueues being named after the resources
+// reconciled by their controllers.
+type workqueueMetricsProvider struct{}
+
+func (workqueueMetricsProvider) NewDepthMetric(name string) workqueue.GaugeMetric {
+	return depthMetric{name: name, gauge: controllerQueueDepth.WithLabelValues(name)}
+}
+
+func (workqueueMetricsProvider) NewAddsMetric(name string) workqueue.CounterMetric {
+	return addsMetric{name: name, counter: controllerQueueAdds.WithLabelValues(name)}
+}
+
+func (workqueueMetricsProvider) NewLatencyMetric(name string) workqueue.HistogramMetric {
+	return controllerQueueSeconds.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewWorkDurationMetric(name string) workqueue.HistogramMetric {
+	return reconcileMetric{name: name, histogram: controllerReconcileSeconds.WithLabelValues(name)}
+}
+
+func (workqueueMetricsProvider) NewUnfinishedWorkSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return controllerUnfinishedSeconds.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewLongestRunningProcessorSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return controllerLongestReconcileSeconds.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewRetriesMetric(name string) workqueue.CounterMetric {
+	return retriesMetric{name: name, counter: controllerRetries.WithLabelValues(name)}
+}
+
+type depthMetric struct {
+	name  string
+	gauge prometheus.Gauge
+}
+
+func (m depthMetric) Inc() {
+	m.gauge.Inc()
+	controllerStats.update(m.name, func(stats *queueStats) { stats.depth++ })
+}
+
+func (m depthMetric) Dec() {
+	m.gauge.Dec()
+	controllerStats.update(m.name, func(stats *queueStats) { stats.depth-- })
+}
+
+type addsMetric struct {
+	name    string
+	counter prometheus.Counter
+}
+
+func (m addsMetric) Inc() {
+	m.counter.Inc()
+	controllerStats.update(m.name, func(stats *queueStats) { stats.adds++ })
+}
+
+type retriesMetric struct {
+	name    string
+	counter prometheus.Counter
+}
+
+func (m retriesMetric) Inc() {
+	m.counter.Inc()
+	controllerStats.retry(m.name)
+}
+
+type reconcileMetric struct {
+	name      string
+	histogram prometheus.Observer
+}
+
+func (m reconcileMetric) Observe(seconds float64) {
+	m.histogram.Observe(seconds)
+	controllerStats.update(m.name, func(stats *queueStats) {
+		stats.reconciles++
+		stats.reconcileSeconds += seconds
+	})
+}
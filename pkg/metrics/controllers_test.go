@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestControllerTracker(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	tracker := newControllerTracker(func() time.Time { return now })
+
+	reconcile := func(name string, seconds float64) {
+		tracker.update(name, func(stats *queueStats) {
+			stats.adds++
+			stats.reconciles++
+			stats.reconcileSeconds += seconds
+		})
+	}
+
+	reconcile("management.cattle.io/v3, Kind=Cluster", 1)
+	reconcile("management.cattle.io/v3, Kind=Cluster", 3)
+	tracker.retry("management.cattle.io/v3, Kind=Cluster")
+
+	reconcile("management.cattle.io/v3, Kind=Node", 1)
+	tracker.update("management.cattle.io/v3, Kind=Node", func(stats *queueStats) { stats.depth = 5 })
+
+	// retries older than the period aren't recent
+	now = now.Add(-time.Hour)
+	tracker.retry("provisioning.cattle.io/v1, Kind=Cluster")
+	tracker.retry("provisioning.cattle.io/v1, Kind=Cluster")
+	now = now.Add(time.Hour)
+	reconcile("provisioning.cattle.io/v1, Kind=Cluster", 2)
+	tracker.retry("provisioning.cattle.io/v1, Kind=Cluster")
+	now = now.Add((RecentRetriesMinutes - 1) * time.Minute)
+	tracker.retry("provisioning.cattle.io/v1, Kind=Cluster")
+
+	assert.Equal(t, []ControllerSummary{
+		{
+			Controller:              "provisioning.cattle.io/v1, Kind=Cluster",
+			Adds:                    1,
+			Reconciles:              1,
+			Retries:                 4,
+			RecentRetries:           2,
+			AverageReconcileSeconds: 2,
+			ErrorRate:               4,
+		},
+		{
+			Controller:              "management.cattle.io/v3, Kind=Cluster",
+			Adds:                    2,
+			Reconciles:              2,
+			Retries:                 1,
+			RecentRetries:           1,
+			AverageReconcileSeconds: 2,
+			ErrorRate:               0.5,
+		},
+		{
+			Controller:              "management.cattle.io/v3, Kind=Node",
+			QueueDepth:              5,
+			Adds:                    1,
+			Reconciles:              1,
+			AverageReconcileSeconds: 1,
+		},
+	}, tracker.summaries())
+
+	now = now.Add(time.Minute)
+	summaries := tracker.summaries()
+	assert.Equal(t, "provisioning.cattle.io/v1, Kind=Cluster", summaries[0].Controller)
+	assert.Equal(t, uint64(1), summaries[0].RecentRetries)
+	assert.Equal(t, uint64(0), summaries[1].RecentRetries)
+}
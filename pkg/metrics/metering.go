@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/metering"
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/wrangler/pkg/ticker"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	meteringClusterIDLabel = "cluster_id"
+	meteringProjectIDLabel = "project_id"
+	meteringTeamLabel      = "team"
+
+	meteringReportInterval = time.Minute
+	meteringLogPrefix      = "[prometheus-metering-metrics]"
+)
+
+var (
+	meteringNodeHours = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: "cluster_manager",
+			Name:      "metering_node_hours",
+			Help:      "Node hours of rancher managed clusters in the current metering period",
+		}, []string{meteringClusterIDLabel, meteringTeamLabel},
+	)
+	meteringCoreHours = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: "cluster_manager",
+			Name:      "metering_core_hours",
+			Help:      "Node core hours of rancher managed clusters in the current metering period",
+		}, []string{meteringClusterIDLabel, meteringTeamLabel},
+	)
+	meteringRequestedCoreHours = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: "cluster_manager",
+			Name:      "metering_project_requested_core_hours",
+			Help:      "CPU core hours requested by the workloads of projects in the current metering period",
+		}, []string{meteringProjectIDLabel, meteringClusterIDLabel, meteringTeamLabel},
+	)
+)
+
+type meteringMetrics struct {
+	recordCache mgmtcontrollers.MeteringRecordCache
+}
+
+// collect reports the usage recorded in the metering record of the current period.
+func (m *meteringMetrics) collect(ctx context.Context) {
+	for range ticker.Context(ctx, meteringReportInterval) {
+		if settings.MeteringEnabled.Get() != "true" {
+			setMeteringMetrics(nil)
+			continue
+		}
+
+		aggregation := settings.MeteringAggregation.Get()
+		if aggregation != v3.MeteringAggregationHourly {
+			aggregation = v3.MeteringAggregationDaily
+		}
+		start, _ := metering.Period(time.Now(), aggregation)
+		record, err := m.recordCache.Get(metering.RecordName(start, aggregation))
+		if apierrors.IsNotFound(err) {
+			record = nil
+		} else if err != nil {
+			logrus.Errorf("%s couldn't get metering record: %v", meteringLogPrefix, err)
+			continue
+		}
+		setMeteringMetrics(record)
+	}
+
+	logrus.Debugf("%s context cancelled, exiting", meteringLogPrefix)
+}
+
+func setMeteringMetrics(record *v3.MeteringRecord) {
+	// clusters and projects may have been deleted, and the period may have changed since the previous report
+	meteringNodeHours.Reset()
+	meteringCoreHours.Reset()
+	meteringRequestedCoreHours.Reset()
+	if record == nil {
+		return
+	}
+
+	for _, usage := range record.Spec.Clusters {
+		l := prometheus.Labels{
+			meteringClusterIDLabel: usage.ClusterName,
+			meteringTeamLabel:      usage.Team,
+		}
+		meteringNodeHours.With(l).Set(float64(usage.NodeSeconds) / 3600)
+		meteringCoreHours.With(l).Set(float64(usage.CoreSeconds) / 3600)
+	}
+	for _, usage := range record.Spec.Projects {
+		l := prometheus.Labels{
+			meteringProjectIDLabel: usage.ProjectName,
+			meteringClusterIDLabel: usage.ClusterName,
+			meteringTeamLabel:      usage.Team,
+		}
+		meteringRequestedCoreHours.With(l).Set(float64(usage.RequestedMilliCoreSeconds) / 3600 / 1000)
+	}
+}
@@ -226,6 +226,21 @@ func Register(ctx context.Context, scaledContext *config.ScaledContext) {
 	prometheus.MustRegister(numNodes)
 	prometheus.MustRegister(numCores)
 
+	// metering metrics
+	prometheus.MustRegister(meteringNodeHours)
+	prometheus.MustRegister(meteringCoreHours)
+	prometheus.MustRegister(meteringRequestedCoreHours)
+
+	// machine provisioning metrics
+	prometheus.MustRegister(machineProvisioningPhaseSeconds)
+
+	// node OS advisory metrics
+	prometheus.MustRegister(nodesEOLOS)
+	prometheus.MustRegister(nodesKernelCVE)
+
+	// controller workqueue metrics
+	registerControllerMetrics()
+
 	gc := metricGarbageCollector{
 		clusterLister:  scaledContext.Management.Clusters("").Controller().Lister(),
 		nodeLister:     scaledContext.Management.Nodes("").Controller().Lister(),
@@ -237,6 +252,10 @@ func Register(ctx context.Context, scaledContext *config.ScaledContext) {
 		clusterCache: scaledContext.Wrangler.Mgmt.Cluster().Cache(),
 	}
 
+	mm := &meteringMetrics{
+		recordCache: scaledContext.Wrangler.Mgmt.MeteringRecord().Cache(),
+	}
+
 	go func(ctx context.Context) {
 		for range ticker.Context(ctx, gcInterval) {
 			gc.metricGarbageCollection()
@@ -244,6 +263,7 @@ func Register(ctx context.Context, scaledContext *config.ScaledContext) {
 	}(ctx)
 
 	go nm.collect(ctx)
+	go mm.collect(ctx)
 }
 
 func SetClusterOwner(id, clusterID string) {
@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	osAdvisoryClusterIDLabel = "cluster_id"
+	osAdvisoryCVELabel       = "cve"
+)
+
+var (
+	nodesEOLOS = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: "cluster_manager",
+			Name:      "nodes_eol_os",
+			Help:      "Number of nodes in rancher managed clusters running an end-of-life OS release of the advisory feed",
+		}, []string{osAdvisoryClusterIDLabel},
+	)
+	nodesKernelCVE = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: "cluster_manager",
+			Name:      "nodes_kernel_cve",
+			Help:      "Number of nodes in rancher managed clusters running a kernel affected by a CVE of the advisory feed",
+		}, []string{osAdvisoryClusterIDLabel, osAdvisoryCVELabel},
+	)
+)
+
+// SetNodeOSAdvisories sets the number of nodes of the cluster running an end-of-life OS release, and the number of its
+// nodes affected by each CVE. The CVEs no longer affecting the cluster are removed.
+func SetNodeOSAdvisories(clusterID string, eolNodes int, cveNodes map[string]int) {
+	if !prometheusMetrics {
+		return
+	}
+	nodesEOLOS.With(prometheus.Labels{osAdvisoryClusterIDLabel: clusterID}).Set(float64(eolNodes))
+	nodesKernelCVE.DeletePartialMatch(prometheus.Labels{osAdvisoryClusterIDLabel: clusterID})
+	for id, count := range cveNodes {
+		nodesKernelCVE.With(prometheus.Labels{
+			osAdvisoryClusterIDLabel: clusterID,
+			osAdvisoryCVELabel:       id,
+		}).Set(float64(count))
+	}
+}
+
+// RemoveNodeOSAdvisories removes the advisory metrics of the cluster.
+func RemoveNodeOSAdvisories(clusterID string) {
+	if !prometheusMetrics {
+		return
+	}
+	nodesEOLOS.DeletePartialMatch(prometheus.Labels{osAdvisoryClusterIDLabel: clusterID})
+	nodesKernelCVE.DeletePartialMatch(prometheus.Labels{osAdvisoryClusterIDLabel: clusterID})
+}
@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	provisioningClusterNamespaceLabel = "cluster_namespace"
+	provisioningClusterNameLabel      = "cluster_name"
+	provisioningPhaseLabel            = "phase"
+)
+
+var machineProvisioningPhaseSeconds = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Subsystem: "cluster_manager",
+		Name:      "machine_provisioning_phase_seconds",
+		Help:      "Seconds from the creation of the machines of RKE2 and K3s clusters to each of their provisioning phases",
+		Buckets:   []float64{10, 30, 60, 120, 180, 300, 450, 600, 900, 1200, 1800, 3600},
+	}, []string{provisioningClusterNamespaceLabel, provisioningClusterNameLabel, provisioningPhaseLabel},
+)
+
+// ObserveMachineProvisioningPhase observes how long a machine of a cluster took to reach a provisioning phase since
+// it was created.
+func ObserveMachineProvisioningPhase(clusterNamespace, clusterName, phase string, seconds float64) {
+	if prometheusMetrics {
+		machineProvisioningPhaseSeconds.With(
+			prometheus.Labels{
+				provisioningClusterNamespaceLabel: clusterNamespace,
+				provisioningClusterNameLabel:      clusterName,
+				provisioningPhaseLabel:            phase,
+			}).Observe(seconds)
+	}
+}
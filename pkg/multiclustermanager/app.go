@@ -25,6 +25,7 @@
 	"github.com/rancher/rancher/pkg/jailer"
 	"github.com/rancher/rancher/pkg/metrics"
 	"github.com/rancher/rancher/pkg/namespace"
+	"github.com/rancher/rancher/pkg/securityevents"
 	"github.com/rancher/rancher/pkg/systemtokens"
 	"github.com/rancher/rancher/pkg/telemetry"
 	"github.com/rancher/rancher/pkg/tunnelserver/mcmauthorizer"
@@ -118,6 +119,8 @@ func newMCM(ctx context.Context, wranglerContext *wrangler.Context, cfg *Options
 		metrics.Register(ctx, scaledContext)
 	}
 
+	securityevents.Start(ctx, scaledContext.Core.Secrets("").Controller().Lister())
+
 	mcm := &mcm{
 		router:              router,
 		ScaledContext:       scaledContext,
@@ -14,6 +14,25 @@
 	"github.com/rancher/rancher/pkg/api/norman/customization/oci"
 	"github.com/rancher/rancher/pkg/api/norman/customization/vsphere"
 	managementapi "github.com/rancher/rancher/pkg/api/norman/server"
+	"github.com/rancher/rancher/pkg/api/steve/breakglass"
+	"github.com/rancher/rancher/pkg/api/steve/clusterarchive"
+	"github.com/rancher/rancher/pkg/api/steve/clustergroups"
+	"github.com/rancher/rancher/pkg/api/steve/conditionhistory"
+	"github.com/rancher/rancher/pkg/api/steve/controllerdiagnostics"
+	"github.com/rancher/rancher/pkg/api/steve/controlplaneadvisor"
+	"github.com/rancher/rancher/pkg/api/steve/eventstream"
+	"github.com/rancher/rancher/pkg/api/steve/landingpreferences"
+	"github.com/rancher/rancher/pkg/api/steve/managementgc"
+	"github.com/rancher/rancher/pkg/api/steve/metering"
+	"github.com/rancher/rancher/pkg/api/steve/multifactor"
+	"github.com/rancher/rancher/pkg/api/steve/pipelinekubeconfig"
+	"github.com/rancher/rancher/pkg/api/steve/provisioningops"
+	"github.com/rancher/rancher/pkg/api/steve/psactanalysis"
+	"github.com/rancher/rancher/pkg/api/steve/rbacsimulation"
+	"github.com/rancher/rancher/pkg/api/steve/removedapis"
+	"github.com/rancher/rancher/pkg/api/steve/reportartifacts"
+	"github.com/rancher/rancher/pkg/api/steve/roletemplates"
+	"github.com/rancher/rancher/pkg/api/steve/streamsessions"
 	"github.com/rancher/rancher/pkg/api/steve/supportconfigs"
 	"github.com/rancher/rancher/pkg/auth/providers/publicapi"
 	"github.com/rancher/rancher/pkg/auth/providers/saml"
@@ -43,6 +62,10 @@ func router(ctx context.Context, localClusterEnabled bool, tunnelAuthorizer *mcm
 		connectHandler       = scaledContext.Dialer.(*rancherdialer.Factory).TunnelServer
 		connectConfigHandler = rkenodeconfigserver.Handler(tunnelAuthorizer, scaledContext)
 		clusterImport        = clusterregistrationtokens.ClusterImport{Clusters: scaledContext.Management.Clusters("")}
+		clusterValidate      = clusterregistrationtokens.ClusterValidate{
+			Clusters:                  scaledContext.Management.Clusters(""),
+			ClusterRegistrationTokens: scaledContext.Management.ClusterRegistrationTokens(""),
+		}
 	)
 
 	tokenAPI, err := tokens.NewAPIHandler(ctx, scaledContext, norman.ConfigureAPIUI)
@@ -70,6 +93,28 @@ func router(ctx context.Context, localClusterEnabled bool, tunnelAuthorizer *mcm
 	channelserver := channelserver.NewHandler(ctx)
 
 	supportConfigGenerator := supportconfigs.NewHandler(scaledContext)
+	reportArtifacts := reportartifacts.NewHandler(scaledContext)
+	psactAnalysis := psactanalysis.NewHandler(scaledContext, clusterManager)
+	controlPlaneAdvisor := controlplaneadvisor.NewHandler(scaledContext)
+	conditionHistory := conditionhistory.NewHandler(scaledContext)
+	streamSessions := streamsessions.NewHandler(scaledContext)
+	breakGlass := breakglass.NewHandler(scaledContext)
+	mfaEnrollment := multifactor.NewHandler(scaledContext)
+	roleTemplateResolution := roletemplates.NewHandler(scaledContext)
+	clusterGroupOperations := clustergroups.NewHandler(scaledContext)
+	meteringExport := metering.NewHandler(scaledContext)
+	eventSubscriptionReplay := eventstream.NewHandler(scaledContext)
+	clusterArchiveSearch := clusterarchive.NewHandler(scaledContext)
+	landingPreferences := landingpreferences.NewHandler(scaledContext)
+	controllerDiagnostics := controllerdiagnostics.NewHandler(scaledContext)
+	removedAPIsReport := removedapis.NewHandler(scaledContext, clusterManager)
+	pipelineKubeconfig := pipelinekubeconfig.NewHandler(scaledContext, clusterManager)
+	managementGC := managementgc.NewHandler(scaledContext)
+	rbacSimulation := rbacsimulation.NewHandler(scaledContext)
+	provisioningOps, err := provisioningops.NewHandler(scaledContext)
+	if err != nil {
+		return nil, err
+	}
 	// Unauthenticated routes
 	unauthed := mux.NewRouter()
 	unauthed.UseEncodedPath()
@@ -79,6 +124,8 @@ func router(ctx context.Context, localClusterEnabled bool, tunnelAuthorizer *mcm
 	unauthed.Handle("/v3/connect", connectHandler)
 	unauthed.Handle("/v3/connect/register", connectHandler)
 	unauthed.Handle("/v3/import/{token}_{clusterId}.yaml", http.HandlerFunc(clusterImport.ClusterImportHandler))
+	unauthed.Handle("/v3/import/{token}_{clusterId}/validate.sh", http.HandlerFunc(clusterValidate.ValidateScriptHandler)).Methods(http.MethodGet)
+	unauthed.Handle("/v3/import/{token}_{clusterId}/validate", http.HandlerFunc(clusterValidate.ValidateReportHandler)).Methods(http.MethodPost)
 	unauthed.Handle("/v3/settings/cacerts", managementAPI).MatcherFunc(onlyGet)
 	unauthed.Handle("/v3/settings/first-login", managementAPI).MatcherFunc(onlyGet)
 	unauthed.Handle("/v3/settings/ui-banners", managementAPI).MatcherFunc(onlyGet)
@@ -91,6 +138,7 @@ func router(ctx context.Context, localClusterEnabled bool, tunnelAuthorizer *mcm
 	unauthed.PathPrefix("/v1-{prefix}-release/release").Handler(channelserver)
 	unauthed.PathPrefix("/v1-saml").Handler(saml.AuthHandler())
 	unauthed.PathPrefix("/v3-public").Handler(publicAPI)
+	unauthed.Path(multifactor.ChallengeEndpoint).Methods(http.MethodPost).Handler(&multifactor.ChallengeHandler{})
 
 	// Authenticated routes
 	authed := mux.NewRouter()
@@ -109,6 +157,26 @@ func router(ctx context.Context, localClusterEnabled bool, tunnelAuthorizer *mcm
 	authed.Path("/v3/tokenreview").Methods(http.MethodPost).Handler(&webhook.TokenReviewer{})
 	authed.Path("/metrics/{clusterID}").Handler(metricsHandler)
 	authed.Path(supportconfigs.Endpoint).Handler(&supportConfigGenerator)
+	authed.Path(reportartifacts.Endpoint).Methods(http.MethodGet).Handler(&reportArtifacts)
+	authed.Path(psactanalysis.Endpoint).Methods(http.MethodGet).Handler(&psactAnalysis)
+	authed.Path(controlplaneadvisor.Endpoint).Methods(http.MethodGet).Handler(&controlPlaneAdvisor)
+	authed.Path(conditionhistory.Endpoint).Methods(http.MethodGet).Handler(&conditionHistory)
+	authed.Path(streamsessions.Endpoint).Methods(http.MethodGet).Handler(&streamSessions)
+	authed.Path(streamsessions.SessionEndpoint).Methods(http.MethodDelete).Handler(&streamSessions)
+	authed.Path(breakglass.Endpoint).Methods(http.MethodGet).Handler(&breakGlass)
+	authed.Path(roletemplates.Endpoint).Methods(http.MethodGet).Handler(&roleTemplateResolution)
+	authed.Path(clustergroups.Endpoint).Methods(http.MethodPost).Handler(&clusterGroupOperations)
+	authed.Path(metering.Endpoint).Methods(http.MethodGet).Handler(&meteringExport)
+	authed.Path(eventstream.Endpoint).Methods(http.MethodPost).Handler(&eventSubscriptionReplay)
+	authed.Path(clusterarchive.Endpoint).Methods(http.MethodGet).Handler(&clusterArchiveSearch)
+	authed.Path(controllerdiagnostics.Endpoint).Methods(http.MethodGet).Handler(&controllerDiagnostics)
+	authed.Path(landingpreferences.Endpoint).Methods(http.MethodGet, http.MethodPut, http.MethodDelete).Handler(&landingPreferences)
+	authed.Path(removedapis.Endpoint).Methods(http.MethodGet).Handler(&removedAPIsReport)
+	authed.Path(pipelinekubeconfig.Endpoint).Methods(http.MethodPost).Handler(&pipelineKubeconfig)
+	authed.Path(managementgc.Endpoint).Methods(http.MethodGet, http.MethodPost).Handler(&managementGC)
+	authed.Path(rbacsimulation.Endpoint).Methods(http.MethodPost).Handler(&rbacSimulation)
+	authed.PathPrefix(provisioningops.Endpoint).Handler(provisioningOps)
+	authed.PathPrefix(multifactor.Endpoint).Handler(mfaEnrollment)
 	authed.PathPrefix("/k8s/clusters/").Handler(k8sProxy)
 	authed.PathPrefix("/meta/proxy").Handler(metaProxy)
 	authed.PathPrefix("/v1-telemetry").Handler(telemetry.NewProxy())
@@ -0,0 +1,21 @@
+package namespace
+
+import (
+	"fmt"
+	"regexp"
+
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+)
+
+// NamePatternMatches returns whether the name of a namespace matches the name pattern of a namespace policy, the
+// pattern must match the whole name.
+func NamePatternMatches(policy *v32.NamespacePolicy, name string) (bool, error) {
+	if policy == nil || policy.NamePattern == "" {
+		return true, nil
+	}
+	pattern, err := regexp.Compile("^(?:" + policy.NamePattern + ")$")
+	if err != nil {
+		return false, fmt.Errorf("invalid namespace name pattern %q: %w", policy.NamePattern, err)
+	}
+	return pattern.MatchString(name), nil
+}
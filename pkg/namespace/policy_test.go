@@ -0,0 +1,37 @@
+package namespace
+
+import (
+	"testing"
+
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamePatternMatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *v32.NamespacePolicy
+		ns      string
+		matches bool
+		err     bool
+	}{
+		{name: "no policy", ns: "anything", matches: true},
+		{name: "no pattern", policy: &v32.NamespacePolicy{}, ns: "anything", matches: true},
+		{name: "match", policy: &v32.NamespacePolicy{NamePattern: "team-a-.*"}, ns: "team-a-web", matches: true},
+		{name: "partial match", policy: &v32.NamespacePolicy{NamePattern: "team-a"}, ns: "team-a-web"},
+		{name: "alternation is anchored", policy: &v32.NamespacePolicy{NamePattern: "a|b"}, ns: "ab"},
+		{name: "invalid pattern", policy: &v32.NamespacePolicy{NamePattern: "("}, ns: "team-a", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := NamePatternMatches(tt.policy, tt.ns)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.matches, matches)
+		})
+	}
+}
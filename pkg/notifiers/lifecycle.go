@@ -0,0 +1,116 @@
+package notifiers
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"time"
+
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+)
+
+// EventType is the type of a cluster lifecycle event.
+type EventType string
+
+const (
+	ClusterProvisioned        EventType = "ClusterProvisioned"
+	ClusterProvisioningFailed EventType = "ClusterProvisioningFailed"
+	ClusterUpgraded           EventType = "ClusterUpgraded"
+	CertificatesRotated       EventType = "CertificatesRotated"
+	EtcdSnapshotFailed        EventType = "EtcdSnapshotFailed"
+	NodeUnreachable           EventType = "NodeUnreachable"
+)
+
+var defaultTemplates = map[EventType]string{
+	ClusterProvisioned:        `Cluster {{.ClusterName}} ({{.ClusterID}}) has been provisioned.`,
+	ClusterProvisioningFailed: `Provisioning of cluster {{.ClusterName}} ({{.ClusterID}}) failed: {{.Message}}`,
+	ClusterUpgraded:           `Cluster {{.ClusterName}} ({{.ClusterID}}) has been upgraded to {{.Version}}.`,
+	CertificatesRotated:       `Certificates of cluster {{.ClusterName}} ({{.ClusterID}}) have been rotated.`,
+	EtcdSnapshotFailed:        `Etcd snapshot {{.Object}} of cluster {{.ClusterName}} ({{.ClusterID}}) failed: {{.Message}}`,
+	NodeUnreachable:           `Node {{.Object}} of cluster {{.ClusterName}} ({{.ClusterID}}) is unreachable: {{.Message}}`,
+}
+
+// LifecycleEvent is a cluster lifecycle event, it is the data message templates are rendered with.
+type LifecycleEvent struct {
+	Type        EventType
+	ClusterID   string
+	ClusterName string
+	// Object is the name of the node or etcd snapshot the event is about, if any.
+	Object  string
+	Version string
+	Message string
+	Time    time.Time
+}
+
+// Title returns the title of the message of the event, used as the subject of emails.
+func (e LifecycleEvent) Title() string {
+	return fmt.Sprintf("[Rancher] %s: %s", e.Type, e.ClusterName)
+}
+
+// RoutedMessage is a message rendered for a recipient of a notifier. An empty recipient is the default recipient of the
+// notifier.
+type RoutedMessage struct {
+	Recipient string
+	Message   *Message
+}
+
+// RouteLifecycleEvent renders the event for every lifecycle event route of the notifier matching its type. Routes to
+// the same recipient are only rendered once, with the first matching route.
+func RouteLifecycleEvent(notifier *v32.Notifier, event LifecycleEvent) ([]RoutedMessage, error) {
+	var messages []RoutedMessage
+	recipients := map[string]bool{}
+	for _, route := range notifier.Spec.LifecycleEvents {
+		if !routeMatches(route, event.Type) || recipients[route.Recipient] {
+			continue
+		}
+
+		content, err := RenderLifecycleEvent(route.Template, event)
+		if err != nil {
+			return nil, fmt.Errorf("rendering %s event for notifier %s/%s: %w", event.Type, notifier.Namespace, notifier.Name, err)
+		}
+
+		recipients[route.Recipient] = true
+		messages = append(messages, RoutedMessage{
+			Recipient: route.Recipient,
+			Message: &Message{
+				Title:   event.Title(),
+				Content: content,
+			},
+		})
+	}
+	return messages, nil
+}
+
+// RenderLifecycleEvent renders the event with the go template text, or with the default template of its type if text
+// is empty.
+func RenderLifecycleEvent(text string, event LifecycleEvent) (string, error) {
+	if text == "" {
+		text = defaultTemplates[event.Type]
+	}
+	if text == "" {
+		return "", fmt.Errorf("no template for event type %s", event.Type)
+	}
+
+	tmpl, err := template.New(string(event.Type)).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, event); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func routeMatches(route v32.LifecycleEventRoute, eventType EventType) bool {
+	if len(route.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range route.EventTypes {
+		if EventType(t) == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package notifiers
+
+import (
+	"testing"
+
+	v32 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderLifecycleEvent(t *testing.T) {
+	event := LifecycleEvent{
+		Type:        ClusterUpgraded,
+		ClusterID:   "c-abcde",
+		ClusterName: "prod",
+		Version:     "v1.25.4+rke2r1",
+	}
+
+	content, err := RenderLifecycleEvent("", event)
+	assert.NoError(t, err)
+	assert.Equal(t, "Cluster prod (c-abcde) has been upgraded to v1.25.4+rke2r1.", content)
+
+	content, err = RenderLifecycleEvent("{{.ClusterName}} is now {{.Version}}", event)
+	assert.NoError(t, err)
+	assert.Equal(t, "prod is now v1.25.4+rke2r1", content)
+
+	_, err = RenderLifecycleEvent("{{.Unknown}}", event)
+	assert.Error(t, err)
+
+	_, err = RenderLifecycleEvent("{{.ClusterName", event)
+	assert.Error(t, err)
+
+	_, err = RenderLifecycleEvent("", LifecycleEvent{Type: "Unknown"})
+	assert.Error(t, err)
+}
+
+func TestRouteLifecycleEvent(t *testing.T) {
+	notifier := &v32.Notifier{
+		Spec: v32.NotifierSpec{
+			LifecycleEvents: []v32.LifecycleEventRoute{
+				{
+					EventTypes: []string{string(NodeUnreachable)},
+					Recipient:  "#nodes",
+					Template:   "node {{.Object}} down",
+				},
+				{
+					EventTypes: []string{string(EtcdSnapshotFailed), string(NodeUnreachable)},
+					Recipient:  "#ops",
+				},
+				{
+					Recipient: "#ops",
+					Template:  "duplicate recipient",
+				},
+				{
+					Template: "{{.Type}}",
+				},
+			},
+		},
+	}
+
+	messages, err := RouteLifecycleEvent(notifier, LifecycleEvent{
+		Type:        NodeUnreachable,
+		ClusterID:   "c-abcde",
+		ClusterName: "prod",
+		Object:      "worker-1",
+		Message:     "Kubelet stopped posting node status.",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []RoutedMessage{
+		{
+			Recipient: "#nodes",
+			Message:   &Message{Title: "[Rancher] NodeUnreachable: prod", Content: "node worker-1 down"},
+		},
+		{
+			Recipient: "#ops",
+			Message:   &Message{Title: "[Rancher] NodeUnreachable: prod", Content: "Node worker-1 of cluster prod (c-abcde) is unreachable: Kubelet stopped posting node status."},
+		},
+		{
+			Recipient: "",
+			Message:   &Message{Title: "[Rancher] NodeUnreachable: prod", Content: "NodeUnreachable"},
+		},
+	}, messages)
+
+	messages, err = RouteLifecycleEvent(notifier, LifecycleEvent{Type: ClusterProvisioned, ClusterName: "prod"})
+	assert.NoError(t, err)
+	assert.Len(t, messages, 2)
+	assert.Equal(t, "#ops", messages[0].Recipient)
+	assert.Equal(t, "duplicate recipient", messages[0].Message.Content)
+
+	messages, err = RouteLifecycleEvent(&v32.Notifier{}, LifecycleEvent{Type: ClusterProvisioned})
+	assert.NoError(t, err)
+	assert.Empty(t, messages)
+}
@@ -0,0 +1,214 @@
+// Package osadvisory correlates the OS releases and kernel versions of nodes against an advisory feed, listing the
+// nodes running end-of-life OS releases and the nodes whose kernel is affected by CVEs of the feed.
+package osadvisory
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const dateFormat = "2006-01-02"
+
+// Feed is the advisory feed, as served by the URL of the node-os-advisory-feed-url setting.
+type Feed struct {
+	// EOLReleases are the OS releases reaching their end of life.
+	EOLReleases []EOLRelease `json:"eolReleases,omitempty"`
+	// CVEs are the kernel CVEs.
+	CVEs []CVE `json:"cves,omitempty"`
+}
+
+// EOLRelease is an OS release reaching its end of life.
+type EOLRelease struct {
+	// OSImage is a regular expression matching the OS images of the release, as reported by the kubelet from the
+	// PRETTY_NAME of the os-release of the nodes, such as "^CentOS Linux 7".
+	OSImage string `json:"osImage"`
+	// EOLDate is the day the release reaches its end of life, such as "2024-06-30".
+	EOLDate string `json:"eolDate"`
+}
+
+// CVE is a vulnerability of the kernel.
+type CVE struct {
+	ID string `json:"id"`
+	// OSImage is a regular expression matching the OS images whose kernels are affected, all OS images if empty.
+	OSImage string `json:"osImage,omitempty"`
+	// Affected are the ranges of the affected kernel versions.
+	Affected []KernelRange `json:"affected"`
+}
+
+// KernelRange is a range of kernel versions, from the version introducing the vulnerability to the version fixing it.
+// Both versions are compared numerically by their dotted and dashed components, so that "5.15.0-76-generic" is fixed
+// by "5.15.0-78".
+type KernelRange struct {
+	// Introduced is the first affected version, all versions before Fixed are affected if empty.
+	Introduced string `json:"introduced,omitempty"`
+	// Fixed is the first version not affected, all versions after Introduced are affected if empty.
+	Fixed string `json:"fixed,omitempty"`
+}
+
+// Node is the OS release and kernel version of a node.
+type Node struct {
+	Name string
+	// OSImage and KernelVersion are reported by the kubelet, for example "Ubuntu 22.04.2 LTS" and "5.15.0-76-generic".
+	OSImage       string
+	KernelVersion string
+}
+
+// Summary lists the nodes of a cluster running end-of-life OS releases or affected by CVEs.
+type Summary struct {
+	Nodes int
+	// EOLNodes are the names of the nodes running an OS release past its end of life.
+	EOLNodes []string
+	// CVENodes are the names of the affected nodes, by CVE ID.
+	CVENodes map[string][]string
+}
+
+type eolRelease struct {
+	osImage *regexp.Regexp
+	eolDate time.Time
+}
+
+type cve struct {
+	id       string
+	osImage  *regexp.Regexp
+	affected []KernelRange
+}
+
+// Advisories are the compiled advisories of a feed.
+type Advisories struct {
+	eolReleases []eolRelease
+	cves        []cve
+}
+
+// Parse parses and compiles the advisories of a feed.
+func Parse(data []byte) (*Advisories, error) {
+	var feed Feed
+	if err := json.Unmarshal(data, &feed); err != nil {
+		return nil, fmt.Errorf("invalid advisory feed: %w", err)
+	}
+	return New(feed)
+}
+
+// New compiles the advisories of a feed.
+func New(feed Feed) (*Advisories, error) {
+	result := &Advisories{}
+	for _, r := range feed.EOLReleases {
+		osImage, err := regexp.Compile(r.OSImage)
+		if err != nil || r.OSImage == "" {
+			return nil, fmt.Errorf("invalid OS image %q of end-of-life release", r.OSImage)
+		}
+		eolDate, err := time.Parse(dateFormat, r.EOLDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end-of-life date %q of release %q: %w", r.EOLDate, r.OSImage, err)
+		}
+		result.eolReleases = append(result.eolReleases, eolRelease{osImage: osImage, eolDate: eolDate})
+	}
+	for _, c := range feed.CVEs {
+		if c.ID == "" {
+			return nil, fmt.Errorf("CVE has no ID")
+		}
+		compiled := cve{id: c.ID, affected: c.Affected}
+		if c.OSImage != "" {
+			osImage, err := regexp.Compile(c.OSImage)
+			if err != nil {
+				return nil, fmt.Errorf("invalid OS image %q of %s: %w", c.OSImage, c.ID, err)
+			}
+			compiled.osImage = osImage
+		}
+		result.cves = append(result.cves, compiled)
+	}
+	return result, nil
+}
+
+// Evaluate returns the summary of the nodes at the given time. Only the selected CVEs are checked, or all CVEs of the
+// feed if none are selected.
+func (a *Advisories) Evaluate(nodes []Node, selected []string, now time.Time) Summary {
+	summary := Summary{
+		Nodes:    len(nodes),
+		CVENodes: map[string][]string{},
+	}
+	selectedIDs := map[string]bool{}
+	for _, id := range selected {
+		selectedIDs[strings.ToUpper(strings.TrimSpace(id))] = true
+	}
+
+	for _, node := range nodes {
+		for _, r := range a.eolReleases {
+			if r.osImage.MatchString(node.OSImage) && !now.Before(r.eolDate) {
+				summary.EOLNodes = append(summary.EOLNodes, node.Name)
+				break
+			}
+		}
+		if node.KernelVersion == "" {
+			continue
+		}
+		for _, c := range a.cves {
+			if len(selectedIDs) > 0 && !selectedIDs[strings.ToUpper(c.id)] {
+				continue
+			}
+			if c.osImage != nil && !c.osImage.MatchString(node.OSImage) {
+				continue
+			}
+			if affected(c.affected, node.KernelVersion) {
+				summary.CVENodes[c.id] = append(summary.CVENodes[c.id], node.Name)
+			}
+		}
+	}
+
+	sort.Strings(summary.EOLNodes)
+	for _, names := range summary.CVENodes {
+		sort.Strings(names)
+	}
+	return summary
+}
+
+// affected returns true if the kernel version is in any of the ranges.
+func affected(ranges []KernelRange, version string) bool {
+	for _, r := range ranges {
+		if r.Introduced != "" && compareKernelVersions(version, r.Introduced) < 0 {
+			continue
+		}
+		if r.Fixed != "" && compareKernelVersions(version, r.Fixed) >= 0 {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// compareKernelVersions compares the numeric components of kernel versions, ignoring their suffixes such as
+// "-generic" or ".el7.x86_64". Missing components are lower than present ones, so that "5.15" is before "5.15.0".
+func compareKernelVersions(a, b string) int {
+	x, y := kernelVersionComponents(a), kernelVersionComponents(b)
+	for i := 0; i < len(x) && i < len(y); i++ {
+		if x[i] != y[i] {
+			if x[i] < y[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case len(x) < len(y):
+		return -1
+	case len(x) > len(y):
+		return 1
+	}
+	return 0
+}
+
+func kernelVersionComponents(version string) []int {
+	var components []int
+	for _, field := range strings.FieldsFunc(version, func(r rune) bool { return r == '.' || r == '-' || r == '+' || r == '_' }) {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			break
+		}
+		components = append(components, n)
+	}
+	return components
+}
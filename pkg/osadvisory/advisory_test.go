@@ -0,0 +1,64 @@
+package osadvisory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const feed = `{
+	"eolReleases": [
+		{"osImage": "^CentOS Linux 7", "eolDate": "2024-06-30"},
+		{"osImage": "^Ubuntu 18\\.04", "eolDate": "2023-05-31"}
+	],
+	"cves": [
+		{"id": "CVE-2023-0001", "affected": [{"introduced": "5.15", "fixed": "5.15.0-78"}]},
+		{"id": "CVE-2023-0002", "osImage": "^CentOS", "affected": [{"fixed": "3.10.0-1160.90"}]}
+	]
+}`
+
+func TestCompareKernelVersions(t *testing.T) {
+	assert.Equal(t, -1, compareKernelVersions("5.15.0-76-generic", "5.15.0-78"))
+	assert.Equal(t, 0, compareKernelVersions("5.15.0-78-generic", "5.15.0-78"))
+	assert.Equal(t, 1, compareKernelVersions("5.15.0-78", "5.15"))
+	assert.Equal(t, 1, compareKernelVersions("3.10.0-1160.90.1.el7.x86_64", "3.10.0-1160.90"))
+	assert.Equal(t, -1, compareKernelVersions("4.18.0", "5.4.0"))
+}
+
+func TestParse(t *testing.T) {
+	_, err := Parse([]byte(`{"eolReleases": [{"osImage": "(", "eolDate": "2024-06-30"}]}`))
+	assert.Error(t, err)
+	_, err = Parse([]byte(`{"eolReleases": [{"osImage": "^CentOS", "eolDate": "June 2024"}]}`))
+	assert.Error(t, err)
+	_, err = Parse([]byte(`{"cves": [{"affected": [{"fixed": "5.15"}]}]}`))
+	assert.Error(t, err)
+}
+
+func TestEvaluate(t *testing.T) {
+	advisories, err := Parse([]byte(feed))
+	require.NoError(t, err)
+
+	nodes := []Node{
+		{Name: "centos", OSImage: "CentOS Linux 7 (Core)", KernelVersion: "3.10.0-1160.88.1.el7.x86_64"},
+		{Name: "ubuntu-old", OSImage: "Ubuntu 18.04.6 LTS", KernelVersion: "4.15.0-212-generic"},
+		{Name: "ubuntu-affected", OSImage: "Ubuntu 22.04.2 LTS", KernelVersion: "5.15.0-76-generic"},
+		{Name: "ubuntu-fixed", OSImage: "Ubuntu 22.04.3 LTS", KernelVersion: "5.15.0-78-generic"},
+		{Name: "unknown", OSImage: "Ubuntu 22.04.2 LTS"},
+	}
+
+	summary := advisories.Evaluate(nodes, nil, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, 5, summary.Nodes)
+	assert.Equal(t, []string{"ubuntu-old"}, summary.EOLNodes)
+	assert.Equal(t, map[string][]string{
+		"CVE-2023-0001": {"ubuntu-affected"},
+		"CVE-2023-0002": {"centos"},
+	}, summary.CVENodes)
+
+	summary = advisories.Evaluate(nodes, []string{"cve-2023-0001"}, time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, []string{"centos", "ubuntu-old"}, summary.EOLNodes)
+	assert.Equal(t, map[string][]string{
+		"CVE-2023-0001": {"ubuntu-affected"},
+	}, summary.CVENodes)
+}
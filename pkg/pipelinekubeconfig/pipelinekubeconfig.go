@@ -0,0 +1,274 @@
+// Package pipelinekubeconfig mints short-lived kubeconfigs scoped to a namespace of a downstream cluster, for CI
+// pipelines that deploy to the namespace without a Rancher token. Each kubeconfig is backed by a service account
+// created for it in the impersonation namespace of the cluster and bound to the pipeline-kubeconfig-cluster-role in the
+// namespace. The service account and its binding are deleted once the kubeconfig expires.
+package pipelinekubeconfig
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/impersonation"
+	"github.com/rancher/rancher/pkg/kubeconfig"
+	"github.com/rancher/rancher/pkg/settings"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// Label marks the service accounts and role bindings backing pipeline kubeconfigs.
+	Label = "authz.cluster.cattle.io/pipeline-kubeconfig"
+	// ExpiresAnnotation is when the service account or role binding of a pipeline kubeconfig is deleted, in RFC 3339.
+	ExpiresAnnotation = "authz.cluster.cattle.io/pipeline-kubeconfig-expires"
+	// NamespaceAnnotation is the namespace the service account of a pipeline kubeconfig is bound in.
+	NamespaceAnnotation = "authz.cluster.cattle.io/pipeline-kubeconfig-namespace"
+	// CreatedByAnnotation is the Rancher user that minted a pipeline kubeconfig.
+	CreatedByAnnotation = "authz.cluster.cattle.io/pipeline-kubeconfig-created-by"
+
+	serviceAccountPrefix = "cattle-pipeline-"
+	// minTTL is the shortest expiration of service account tokens accepted by Kubernetes.
+	minTTL = 10 * time.Minute
+)
+
+// Clients are the clients of the downstream cluster used to mint a kubeconfig. The access of the Rancher user minting
+// the kubeconfig is reviewed, and the role binding created, by User, a client impersonating the user, so that
+// Kubernetes checks the user is allowed to grant the role in the namespace.
+type Clients struct {
+	Admin kubernetes.Interface
+	User  kubernetes.Interface
+}
+
+// Request is a request to mint a kubeconfig for a namespace.
+type Request struct {
+	Namespace string
+	TTL       time.Duration
+	User      string
+}
+
+// Credentials are the service account and token backing a pipeline kubeconfig.
+type Credentials struct {
+	ServiceAccount string
+	Token          string
+	Expires        time.Time
+}
+
+// TTL returns the lifetime of a kubeconfig for the requested number of seconds, or the
+// pipeline-kubeconfig-default-ttl-seconds setting if none is requested.
+func TTL(seconds string) (time.Duration, error) {
+	if seconds == "" {
+		seconds = settings.PipelineKubeconfigDefaultTTLSeconds.Get()
+	}
+	n, err := strconv.Atoi(seconds)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ttl %q: %w", seconds, err)
+	}
+	ttl := time.Duration(n) * time.Second
+	if ttl < minTTL {
+		return 0, fmt.Errorf("ttl must be at least %d seconds", int(minTTL.Seconds()))
+	}
+	if max := time.Duration(settings.PipelineKubeconfigMaxTTLSeconds.GetInt()) * time.Second; max > 0 && ttl > max {
+		return 0, fmt.Errorf("ttl must be at most %d seconds", int(max.Seconds()))
+	}
+	return ttl, nil
+}
+
+// Mint creates a service account bound to the pipeline-kubeconfig-cluster-role in the namespace of the request, and a
+// token for it expiring with the service account. The cluster isn't accessed with the admin client unless the user is
+// allowed to grant the role in the namespace. The service account is deleted if it can't be bound or its token can't
+// be created.
+func Mint(ctx context.Context, clients Clients, req Request, now time.Time) (*Credentials, error) {
+	if err := reviewAccess(ctx, clients.User, req.Namespace); err != nil {
+		return nil, err
+	}
+	if _, err := clients.Admin.CoreV1().Namespaces().Get(ctx, req.Namespace, metav1.GetOptions{}); err != nil {
+		return nil, err
+	}
+	if err := ensureNamespace(ctx, clients.Admin); err != nil {
+		return nil, err
+	}
+
+	expires := now.Add(req.TTL).UTC().Truncate(time.Second)
+	annotations := map[string]string{
+		ExpiresAnnotation:   expires.Format(time.RFC3339),
+		NamespaceAnnotation: req.Namespace,
+		CreatedByAnnotation: req.User,
+	}
+	sa, err := clients.Admin.CoreV1().ServiceAccounts(impersonation.ImpersonationNamespace).Create(ctx, &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: serviceAccountPrefix,
+			Namespace:    impersonation.ImpersonationNamespace,
+			Labels:       map[string]string{Label: "true"},
+			Annotations:  annotations,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create service account: %w", err)
+	}
+
+	_, err = clients.User.RbacV1().RoleBindings(req.Namespace).Create(ctx, &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        sa.Name,
+			Namespace:   req.Namespace,
+			Labels:      map[string]string{Label: "true"},
+			Annotations: annotations,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     settings.PipelineKubeconfigClusterRole.Get(),
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      sa.Name,
+				Namespace: sa.Namespace,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, cleanup(ctx, clients.Admin, sa, err)
+	}
+
+	expirationSeconds := int64(req.TTL.Seconds())
+	token, err := clients.Admin.CoreV1().ServiceAccounts(sa.Namespace).CreateToken(ctx, sa.Name, &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			ExpirationSeconds: &expirationSeconds,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, cleanup(ctx, clients.Admin, sa, fmt.Errorf("failed to create token: %w", err))
+	}
+
+	return &Credentials{
+		ServiceAccount: sa.Name,
+		Token:          token.Status.Token,
+		Expires:        expires,
+	}, nil
+}
+
+// reviewAccess returns a forbidden error unless the user of the client is allowed to create role bindings in the
+// namespace and to bind the pipeline-kubeconfig-cluster-role.
+func reviewAccess(ctx context.Context, client kubernetes.Interface, namespace string) error {
+	role := settings.PipelineKubeconfigClusterRole.Get()
+	for _, attributes := range []authorizationv1.ResourceAttributes{
+		{
+			Namespace: namespace,
+			Verb:      "create",
+			Group:     rbacv1.GroupName,
+			Resource:  "rolebindings",
+		},
+		{
+			Namespace: namespace,
+			Verb:      "bind",
+			Group:     rbacv1.GroupName,
+			Resource:  "clusterroles",
+			Name:      role,
+		},
+	} {
+		attributes := attributes
+		review, err := client.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &attributes,
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to review access: %w", err)
+		}
+		if !review.Status.Allowed {
+			resource := attributes.Resource
+			if attributes.Name != "" {
+				resource += "/" + attributes.Name
+			}
+			return apierrors.NewForbidden(schema.GroupResource{Group: rbacv1.GroupName, Resource: "rolebindings"}, "",
+				fmt.Errorf("user can't %s %s in namespace %s", attributes.Verb, resource, namespace))
+		}
+	}
+	return nil
+}
+
+// cleanup deletes the service account and role binding of a kubeconfig that couldn't be minted, returning the error
+// that prevented it.
+func cleanup(ctx context.Context, client kubernetes.Interface, sa *corev1.ServiceAccount, err error) error {
+	if cleanupErr := Delete(ctx, client, sa); cleanupErr != nil {
+		return fmt.Errorf("encountered error while deleting service account %s: %v, original error: %w", sa.Name, cleanupErr, err)
+	}
+	return err
+}
+
+// Delete deletes the service account of a pipeline kubeconfig and its role binding.
+func Delete(ctx context.Context, client kubernetes.Interface, sa *corev1.ServiceAccount) error {
+	if namespace := sa.Annotations[NamespaceAnnotation]; namespace != "" {
+		err := client.RbacV1().RoleBindings(namespace).Delete(ctx, sa.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	err := client.CoreV1().ServiceAccounts(sa.Namespace).Delete(ctx, sa.Name, metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// Expires returns when the service account or role binding of a pipeline kubeconfig expires. Objects without a valid
+// expiration are expired.
+func Expires(obj metav1.Object) time.Time {
+	expires, err := time.Parse(time.RFC3339, obj.GetAnnotations()[ExpiresAnnotation])
+	if err != nil {
+		return time.Time{}
+	}
+	return expires
+}
+
+// Server returns the server and the base64 encoded CA certificates the pipeline kubeconfigs of a cluster connect to:
+// the authorized cluster endpoint of the cluster if it's enabled, its API endpoint otherwise, since the service account
+// tokens aren't accepted by Rancher.
+func Server(cluster *v3.Cluster) (string, string, error) {
+	server, caCert := cluster.Status.APIEndpoint, cluster.Status.CACert
+	if ace := cluster.Spec.LocalClusterAuthEndpoint; ace.Enabled && ace.FQDN != "" {
+		server, caCert = "https://"+ace.FQDN, ""
+		if ace.CACerts != "" {
+			caCert = base64.StdEncoding.EncodeToString([]byte(ace.CACerts))
+		}
+	}
+	if server == "" {
+		return "", "", fmt.Errorf("the API endpoint of cluster %s is unknown", cluster.Name)
+	}
+	return server, caCert, nil
+}
+
+// Kubeconfig returns the kubeconfig of the credentials for the namespace of a cluster.
+func Kubeconfig(cluster *v3.Cluster, namespace string, creds *Credentials) (string, error) {
+	server, caCert, err := Server(cluster)
+	if err != nil {
+		return "", err
+	}
+
+	clusterName := cluster.Spec.DisplayName
+	if clusterName == "" {
+		clusterName = cluster.Name
+	}
+	return kubeconfig.ForServiceAccountToken(clusterName, server, caCert, namespace, creds.ServiceAccount, creds.Token)
+}
+
+func ensureNamespace(ctx context.Context, client kubernetes.Interface) error {
+	_, err := client.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: impersonation.ImpersonationNamespace,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package pipelinekubeconfig
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/yaml"
+)
+
+func TestTTL(t *testing.T) {
+	tests := []struct {
+		name    string
+		seconds string
+		want    time.Duration
+		wantErr bool
+	}{
+		{
+			name: "default",
+			want: time.Hour,
+		},
+		{
+			name:    "requested",
+			seconds: "900",
+			want:    15 * time.Minute,
+		},
+		{
+			name:    "maximum",
+			seconds: "86400",
+			want:    24 * time.Hour,
+		},
+		{
+			name:    "too short",
+			seconds: "60",
+			wantErr: true,
+		},
+		{
+			name:    "too long",
+			seconds: "86401",
+			wantErr: true,
+		},
+		{
+			name:    "not a number",
+			seconds: "1h",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ttl, err := TTL(tt.seconds)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ttl)
+		})
+	}
+}
+
+func TestExpires(t *testing.T) {
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{ExpiresAnnotation: "2023-05-01T12:00:00Z"},
+		},
+	}
+	assert.Equal(t, time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC), Expires(sa).UTC())
+
+	sa.Annotations[ExpiresAnnotation] = "tomorrow"
+	assert.True(t, Expires(sa).Before(time.Now()), "invalid expirations are expired")
+}
+
+func TestKubeconfig(t *testing.T) {
+	cluster := &v3.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "c-m-abcd1234"},
+	}
+	cluster.Spec.DisplayName = "prod"
+	creds := &Credentials{ServiceAccount: "cattle-pipeline-x7k2p", Token: "header.payload+/=.signature"}
+
+	_, err := Kubeconfig(cluster, "shop", creds)
+	assert.Error(t, err, "no API endpoint")
+
+	cluster.Status.APIEndpoint = "https://10.0.0.1:6443"
+	cluster.Status.CACert = base64.StdEncoding.EncodeToString([]byte("cluster-ca"))
+	config := parse(t, cluster, creds)
+	assert.Equal(t, "https://10.0.0.1:6443", config.Clusters[0].Cluster.Server)
+	assert.Equal(t, "cluster-ca", string(config.Clusters[0].Cluster.CertificateAuthorityData))
+	assert.Equal(t, "prod", config.CurrentContext)
+	assert.Equal(t, "shop", config.Contexts[0].Context.Namespace)
+	assert.Equal(t, "cattle-pipeline-x7k2p", config.AuthInfos[0].Name)
+	assert.Equal(t, creds.Token, config.AuthInfos[0].AuthInfo.Token)
+
+	cluster.Spec.LocalClusterAuthEndpoint = v3.LocalClusterAuthEndpoint{
+		Enabled: true,
+		FQDN:    "prod.example.com",
+		CACerts: "fqdn-ca",
+	}
+	config = parse(t, cluster, creds)
+	assert.Equal(t, "https://prod.example.com", config.Clusters[0].Cluster.Server)
+	assert.Equal(t, "fqdn-ca", string(config.Clusters[0].Cluster.CertificateAuthorityData))
+}
+
+// kubeconfigFile is the part of a kubeconfig checked by the tests.
+type kubeconfigFile struct {
+	Clusters []struct {
+		Cluster struct {
+			Server                   string `json:"server"`
+			CertificateAuthorityData []byte `json:"certificate-authority-data"`
+		} `json:"cluster"`
+	} `json:"clusters"`
+	AuthInfos []struct {
+		Name     string `json:"name"`
+		AuthInfo struct {
+			Token string `json:"token"`
+		} `json:"user"`
+	} `json:"users"`
+	Contexts []struct {
+		Context struct {
+			Namespace string `json:"namespace"`
+		} `json:"context"`
+	} `json:"contexts"`
+	CurrentContext string `json:"current-context"`
+}
+
+func parse(t *testing.T, cluster *v3.Cluster, creds *Credentials) kubeconfigFile {
+	t.Helper()
+	data, err := Kubeconfig(cluster, "shop", creds)
+	require.NoError(t, err)
+	var config kubeconfigFile
+	require.NoError(t, yaml.Unmarshal([]byte(data), &config))
+	require.Len(t, config.Clusters, 1)
+	require.Len(t, config.AuthInfos, 1)
+	require.Len(t, config.Contexts, 1)
+	return config
+}
+
+func TestMintReviewsAccessFirst(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed map[string]bool
+	}{
+		{
+			name: "can't create role bindings",
+		},
+		{
+			name:    "can't bind the role",
+			allowed: map[string]bool{"create": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			admin := fake.NewSimpleClientset()
+			user := fake.NewSimpleClientset()
+			var reviewed []string
+			user.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+				attributes := review.Spec.ResourceAttributes
+				assert.Equal(t, "ci", attributes.Namespace)
+				reviewed = append(reviewed, attributes.Verb+" "+attributes.Resource)
+				review.Status.Allowed = tt.allowed[attributes.Verb]
+				return true, review, nil
+			})
+
+			_, err := Mint(context.Background(), Clients{Admin: admin, User: user}, Request{
+				Namespace: "ci",
+				TTL:       time.Hour,
+				User:      "u-abcde",
+			}, time.Now())
+			assert.True(t, apierrors.IsForbidden(err))
+			assert.Empty(t, admin.Actions(), "the cluster isn't accessed as admin before the access of the user is reviewed")
+			assert.Len(t, reviewed, len(tt.allowed)+1)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+// Package podsecurity evaluates the workloads of a cluster against pod security admission levels, to assist the
+// migration of clusters and namespaces to a stricter pod security admission configuration.
+package podsecurity
+
+import (
+	"fmt"
+	"sort"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	corev1 "k8s.io/api/core/v1"
+	psaapi "k8s.io/pod-security-admission/api"
+	"k8s.io/pod-security-admission/policy"
+)
+
+// Analysis is the result of evaluating the pods of a cluster against a pod security admission configuration template.
+type Analysis struct {
+	Template     string              `json:"template"`
+	Level        string              `json:"level"`
+	Version      string              `json:"version"`
+	RejectedPods int                 `json:"rejectedPods"`
+	Namespaces   []NamespaceAnalysis `json:"namespaces"`
+}
+
+// NamespaceAnalysis is the result of evaluating the pods of a namespace at its effective level. The level of a
+// namespace is the level of its enforce label if it has one, or the level of the template otherwise.
+type NamespaceAnalysis struct {
+	Name     string        `json:"name"`
+	Level    string        `json:"level,omitempty"`
+	Version  string        `json:"version,omitempty"`
+	Exempt   bool          `json:"exempt,omitempty"`
+	Pods     int           `json:"pods"`
+	Rejected []RejectedPod `json:"rejected,omitempty"`
+}
+
+// RejectedPod is a pod that would be rejected, with the reasons of its rejection.
+type RejectedPod struct {
+	Name    string   `json:"name"`
+	Reasons []string `json:"reasons"`
+}
+
+// Evaluator evaluates pods against pod security admission levels.
+type Evaluator struct {
+	evaluator policy.Evaluator
+}
+
+func NewEvaluator() (*Evaluator, error) {
+	evaluator, err := policy.NewEvaluator(policy.DefaultChecks())
+	if err != nil {
+		return nil, err
+	}
+	return &Evaluator{evaluator: evaluator}, nil
+}
+
+// Analyze evaluates the pods of the namespaces against the enforce level of the template, honoring the namespace and
+// runtime class exemptions of the template as well as the enforce labels already set on the namespaces.
+func (e *Evaluator) Analyze(template *v3.PodSecurityAdmissionConfigurationTemplate, namespaces []*corev1.Namespace, pods []*corev1.Pod) (*Analysis, error) {
+	defaults := template.Configuration.Defaults
+	templateLevel, err := ParseLevelVersion(defaults.Enforce, defaults.EnforceVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid enforce level of template %s: %w", template.Name, err)
+	}
+
+	exemptNamespaces := toSet(template.Configuration.Exemptions.Namespaces)
+	exemptRuntimeClasses := toSet(template.Configuration.Exemptions.RuntimeClasses)
+
+	podsByNamespace := map[string][]*corev1.Pod{}
+	for _, pod := range pods {
+		podsByNamespace[pod.Namespace] = append(podsByNamespace[pod.Namespace], pod)
+	}
+
+	analysis := &Analysis{
+		Template: template.Name,
+		Level:    string(templateLevel.Level),
+		Version:  templateLevel.Version.String(),
+	}
+	for _, namespace := range namespaces {
+		result := NamespaceAnalysis{
+			Name: namespace.Name,
+			Pods: len(podsByNamespace[namespace.Name]),
+		}
+		if exemptNamespaces[namespace.Name] {
+			result.Exempt = true
+			analysis.Namespaces = append(analysis.Namespaces, result)
+			continue
+		}
+
+		level := templateLevel
+		if namespaceLevel, ok := namespace.Labels[psaapi.EnforceLevelLabel]; ok {
+			level, err = ParseLevelVersion(namespaceLevel, namespace.Labels[psaapi.EnforceVersionLabel])
+			if err != nil {
+				// the api server falls back to the most restrictive level on invalid labels
+				level = psaapi.LevelVersion{Level: psaapi.LevelRestricted, Version: psaapi.LatestVersion()}
+			}
+		}
+		result.Level = string(level.Level)
+		result.Version = level.Version.String()
+		result.Rejected = e.Evaluate(level, podsByNamespace[namespace.Name], exemptRuntimeClasses)
+		analysis.RejectedPods += len(result.Rejected)
+		analysis.Namespaces = append(analysis.Namespaces, result)
+	}
+
+	sort.Slice(analysis.Namespaces, func(i, j int) bool {
+		return analysis.Namespaces[i].Name < analysis.Namespaces[j].Name
+	})
+	return analysis, nil
+}
+
+// Evaluate returns the pods that would be rejected at the level, sorted by name. Pods that completed and pods of an
+// exempt runtime class are never rejected.
+func (e *Evaluator) Evaluate(level psaapi.LevelVersion, pods []*corev1.Pod, exemptRuntimeClasses map[string]bool) []RejectedPod {
+	if level.Level == psaapi.LevelPrivileged {
+		return nil
+	}
+
+	var rejected []RejectedPod
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if pod.Spec.RuntimeClassName != nil && exemptRuntimeClasses[*pod.Spec.RuntimeClassName] {
+			continue
+		}
+
+		var reasons []string
+		for _, result := range e.evaluator.EvaluatePod(level, &pod.ObjectMeta, &pod.Spec) {
+			if result.Allowed {
+				continue
+			}
+			reason := result.ForbiddenReason
+			if result.ForbiddenDetail != "" {
+				reason = fmt.Sprintf("%s (%s)", reason, result.ForbiddenDetail)
+			}
+			reasons = append(reasons, reason)
+		}
+		if len(reasons) > 0 {
+			rejected = append(rejected, RejectedPod{Name: pod.Name, Reasons: reasons})
+		}
+	}
+
+	sort.Slice(rejected, func(i, j int) bool {
+		return rejected[i].Name < rejected[j].Name
+	})
+	return rejected
+}
+
+// ParseLevelVersion parses a pod security admission level and version, defaulting to the privileged level and the
+// latest version.
+func ParseLevelVersion(level, version string) (psaapi.LevelVersion, error) {
+	result := psaapi.LevelVersion{Level: psaapi.LevelPrivileged, Version: psaapi.LatestVersion()}
+	if level != "" {
+		parsed, err := psaapi.ParseLevel(level)
+		if err != nil {
+			return result, err
+		}
+		result.Level = parsed
+	}
+	if version != "" {
+		parsed, err := psaapi.ParseVersion(version)
+		if err != nil {
+			return result, err
+		}
+		result.Version = parsed
+	}
+	return result, nil
+}
+
+func toSet(values []string) map[string]bool {
+	result := make(map[string]bool, len(values))
+	for _, value := range values {
+		result[value] = true
+	}
+	return result
+}
@@ -0,0 +1,99 @@
+package podsecurity
+
+import (
+	"testing"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	psaapi "k8s.io/pod-security-admission/api"
+)
+
+func namespace(name string, labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func pod(namespace, name string, privileged bool) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:            "main",
+				SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+			}},
+		},
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	evaluator, err := NewEvaluator()
+	require.NoError(t, err)
+
+	template := &v3.PodSecurityAdmissionConfigurationTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline"},
+		Configuration: v3.PodSecurityAdmissionConfigurationTemplateSpec{
+			Defaults: v3.PodSecurityAdmissionConfigurationTemplateDefaults{
+				Enforce:        "baseline",
+				EnforceVersion: "latest",
+			},
+			Exemptions: v3.PodSecurityAdmissionConfigurationTemplateExemptions{
+				Namespaces:     []string{"kube-system"},
+				RuntimeClasses: []string{"kata"},
+			},
+		},
+	}
+
+	kata := "kata"
+	completed := pod("default", "job", true)
+	completed.Status.Phase = corev1.PodSucceeded
+	exemptRuntimeClass := pod("default", "sandboxed", true)
+	exemptRuntimeClass.Spec.RuntimeClassName = &kata
+
+	analysis, err := evaluator.Analyze(template,
+		[]*corev1.Namespace{
+			namespace("kube-system", nil),
+			namespace("default", nil),
+			namespace("legacy", map[string]string{psaapi.EnforceLevelLabel: "privileged"}),
+		},
+		[]*corev1.Pod{
+			pod("kube-system", "proxy", true),
+			pod("default", "web", false),
+			pod("default", "agent", true),
+			completed,
+			exemptRuntimeClass,
+			pod("legacy", "agent", true),
+		})
+	require.NoError(t, err)
+
+	assert.Equal(t, "baseline", analysis.Level)
+	assert.Equal(t, "latest", analysis.Version)
+	assert.Equal(t, 1, analysis.RejectedPods)
+	require.Len(t, analysis.Namespaces, 3)
+
+	assert.Equal(t, "default", analysis.Namespaces[0].Name)
+	assert.Equal(t, "baseline", analysis.Namespaces[0].Level)
+	assert.Equal(t, 4, analysis.Namespaces[0].Pods)
+	require.Len(t, analysis.Namespaces[0].Rejected, 1)
+	assert.Equal(t, "agent", analysis.Namespaces[0].Rejected[0].Name)
+	assert.Contains(t, analysis.Namespaces[0].Rejected[0].Reasons[0], "privileged")
+
+	assert.Equal(t, NamespaceAnalysis{Name: "kube-system", Exempt: true, Pods: 1}, analysis.Namespaces[1])
+
+	assert.Equal(t, "legacy", analysis.Namespaces[2].Name)
+	assert.Equal(t, "privileged", analysis.Namespaces[2].Level)
+	assert.Empty(t, analysis.Namespaces[2].Rejected)
+}
+
+func TestAnalyzeInvalidTemplate(t *testing.T) {
+	evaluator, err := NewEvaluator()
+	require.NoError(t, err)
+
+	_, err = evaluator.Analyze(&v3.PodSecurityAdmissionConfigurationTemplate{
+		Configuration: v3.PodSecurityAdmissionConfigurationTemplateSpec{
+			Defaults: v3.PodSecurityAdmissionConfigurationTemplateDefaults{Enforce: "strict"},
+		},
+	}, nil, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,76 @@
+package podsecurity
+
+import (
+	"fmt"
+	"time"
+
+	psaapi "k8s.io/pod-security-admission/api"
+)
+
+const (
+	// StagedLevelAnnotation opts a namespace in to staged enforcement of the level, either baseline or restricted.
+	StagedLevelAnnotation = "psa.cattle.io/staged-level"
+	// StageIntervalAnnotation is the minimum duration of the warn and audit stages, defaults to DefaultStageInterval.
+	StageIntervalAnnotation = "psa.cattle.io/stage-interval"
+	// StageAnnotation is the current stage of a namespace.
+	StageAnnotation = "psa.cattle.io/stage"
+	// StageStartedAnnotation is the time, in RFC 3339, the namespace entered its current stage.
+	StageStartedAnnotation = "psa.cattle.io/stage-started"
+	// StageMessageAnnotation explains why a namespace doesn't progress to the enforce stage.
+	StageMessageAnnotation = "psa.cattle.io/stage-message"
+
+	DefaultStageInterval = 24 * time.Hour
+)
+
+// Stage is a stage of the staged enforcement of a pod security admission level on a namespace. Each stage sets the
+// labels of the previous stages as well.
+type Stage string
+
+const (
+	StageWarn    Stage = "warn"
+	StageAudit   Stage = "audit"
+	StageEnforce Stage = "enforce"
+)
+
+// NextStage returns the stage of a namespace that entered its current stage at started, and a message explaining why
+// it doesn't progress if it is held back. A namespace progresses from warn to audit after the interval, and from audit
+// to enforce after the interval only if none of its pods would be rejected.
+func NextStage(current Stage, started, now time.Time, interval time.Duration, rejected int) (Stage, string) {
+	elapsed := now.Sub(started) >= interval
+	switch current {
+	case StageWarn:
+		if elapsed {
+			return StageAudit, ""
+		}
+		return StageWarn, ""
+	case StageAudit:
+		if !elapsed {
+			return StageAudit, ""
+		}
+		if rejected > 0 {
+			return StageAudit, fmt.Sprintf("%d pods would be rejected", rejected)
+		}
+		return StageEnforce, ""
+	case StageEnforce:
+		return StageEnforce, ""
+	default:
+		return StageWarn, ""
+	}
+}
+
+// StageLabels returns the pod security admission labels of a namespace at the stage for the level.
+func StageLabels(stage Stage, level psaapi.Level) map[string]string {
+	labels := map[string]string{
+		psaapi.WarnLevelLabel:   string(level),
+		psaapi.WarnVersionLabel: psaapi.VersionLatest,
+	}
+	if stage == StageAudit || stage == StageEnforce {
+		labels[psaapi.AuditLevelLabel] = string(level)
+		labels[psaapi.AuditVersionLabel] = psaapi.VersionLatest
+	}
+	if stage == StageEnforce {
+		labels[psaapi.EnforceLevelLabel] = string(level)
+		labels[psaapi.EnforceVersionLabel] = psaapi.VersionLatest
+	}
+	return labels
+}
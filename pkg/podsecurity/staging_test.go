@@ -0,0 +1,58 @@
+package podsecurity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	psaapi "k8s.io/pod-security-admission/api"
+)
+
+func TestNextStage(t *testing.T) {
+	started := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	before := started.Add(time.Hour)
+	after := started.Add(DefaultStageInterval)
+
+	tests := []struct {
+		name        string
+		current     Stage
+		now         time.Time
+		rejected    int
+		wantStage   Stage
+		wantMessage string
+	}{
+		{name: "not started", current: "", now: before, wantStage: StageWarn},
+		{name: "warn before interval", current: StageWarn, now: before, wantStage: StageWarn},
+		{name: "warn after interval", current: StageWarn, now: after, rejected: 2, wantStage: StageAudit},
+		{name: "audit before interval", current: StageAudit, now: before, wantStage: StageAudit},
+		{name: "audit with rejected pods", current: StageAudit, now: after, rejected: 2, wantStage: StageAudit, wantMessage: "2 pods would be rejected"},
+		{name: "audit without rejected pods", current: StageAudit, now: after, wantStage: StageEnforce},
+		{name: "enforce", current: StageEnforce, now: after, rejected: 2, wantStage: StageEnforce},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stage, message := NextStage(tt.current, started, tt.now, DefaultStageInterval, tt.rejected)
+			assert.Equal(t, tt.wantStage, stage)
+			assert.Equal(t, tt.wantMessage, message)
+		})
+	}
+}
+
+func TestStageLabels(t *testing.T) {
+	assert.Equal(t, map[string]string{
+		psaapi.WarnLevelLabel:   "restricted",
+		psaapi.WarnVersionLabel: "latest",
+	}, StageLabels(StageWarn, psaapi.LevelRestricted))
+
+	assert.Len(t, StageLabels(StageAudit, psaapi.LevelRestricted), 4)
+
+	assert.Equal(t, map[string]string{
+		psaapi.WarnLevelLabel:      "baseline",
+		psaapi.WarnVersionLabel:    "latest",
+		psaapi.AuditLevelLabel:     "baseline",
+		psaapi.AuditVersionLabel:   "latest",
+		psaapi.EnforceLevelLabel:   "baseline",
+		psaapi.EnforceVersionLabel: "latest",
+	}, StageLabels(StageEnforce, psaapi.LevelBaseline))
+}
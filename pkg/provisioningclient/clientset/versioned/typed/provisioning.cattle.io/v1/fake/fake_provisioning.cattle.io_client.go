@@ -19,7 +19,7 @@
 package fake
 
 import (
-	v1 "github.com/rancher/rancher/pkg/generated/clientset/versioned/typed/provisioning.cattle.io/v1"
+	v1 "github.com/rancher/rancher/pkg/provisioningclient/clientset/versioned/typed/provisioning.cattle.io/v1"
 	rest "k8s.io/client-go/rest"
 	testing "k8s.io/client-go/testing"
 )
@@ -22,7 +22,7 @@
 	"net/http"
 
 	v1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
-	"github.com/rancher/rancher/pkg/generated/clientset/versioned/scheme"
+	"github.com/rancher/rancher/pkg/provisioningclient/clientset/versioned/scheme"
 	rest "k8s.io/client-go/rest"
 )
 
@@ -19,7 +19,7 @@
 package fake
 
 import (
-	v1 "github.com/rancher/rancher/pkg/generated/clientset/versioned/typed/rke.cattle.io/v1"
+	v1 "github.com/rancher/rancher/pkg/provisioningclient/clientset/versioned/typed/rke.cattle.io/v1"
 	rest "k8s.io/client-go/rest"
 	testing "k8s.io/client-go/testing"
 )
@@ -28,6 +28,10 @@ type FakeRkeV1 struct {
 	*testing.Fake
 }
 
+func (c *FakeRkeV1) ClusterRecoveries(namespace string) v1.ClusterRecoveryInterface {
+	return &FakeClusterRecoveries{c, namespace}
+}
+
 func (c *FakeRkeV1) CustomMachines(namespace string) v1.CustomMachineInterface {
 	return &FakeCustomMachines{c, namespace}
 }
@@ -18,6 +18,8 @@
 
 package v1
 
+type ClusterRecoveryExpansion interface{}
+
 type CustomMachineExpansion interface{}
 
 type ETCDSnapshotExpansion interface{}
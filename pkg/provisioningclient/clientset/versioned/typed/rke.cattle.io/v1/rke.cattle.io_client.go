@@ -22,12 +22,13 @@
 	"net/http"
 
 	v1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
-	"github.com/rancher/rancher/pkg/generated/clientset/versioned/scheme"
+	"github.com/rancher/rancher/pkg/provisioningclient/clientset/versioned/scheme"
 	rest "k8s.io/client-go/rest"
 )
 
 type RkeV1Interface interface {
 	RESTClient() rest.Interface
+	ClusterRecoveriesGetter
 	CustomMachinesGetter
 	ETCDSnapshotsGetter
 	RKEBootstrapsGetter
@@ -41,6 +42,10 @@ type RkeV1Client struct {
 	restClient rest.Interface
 }
 
+func (c *RkeV1Client) ClusterRecoveries(namespace string) ClusterRecoveryInterface {
+	return newClusterRecoveries(c, namespace)
+}
+
 func (c *RkeV1Client) CustomMachines(namespace string) CustomMachineInterface {
 	return newCustomMachines(c, namespace)
 }
@@ -23,7 +23,7 @@
 	"time"
 
 	v1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
-	scheme "github.com/rancher/rancher/pkg/generated/clientset/versioned/scheme"
+	scheme "github.com/rancher/rancher/pkg/provisioningclient/clientset/versioned/scheme"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
 	watch "k8s.io/apimachinery/pkg/watch"
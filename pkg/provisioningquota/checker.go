@@ -0,0 +1,311 @@
+// Package provisioningquota checks the provisioning clusters users create and scale, and the machine configs their
+// machine pools use, against the provisioning quotas of the users, their groups and the cluster groups of the clusters.
+package provisioningquota
+
+import (
+	"fmt"
+
+	"github.com/rancher/lasso/pkg/dynamic"
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/rancher/pkg/controllers/management/clustergroup"
+	mgmtcontrollers "github.com/rancher/rancher/pkg/generated/controllers/management.cattle.io/v3"
+	provcontrollers "github.com/rancher/rancher/pkg/generated/controllers/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/wrangler"
+	"github.com/rancher/wrangler/pkg/data"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/authentication/user"
+)
+
+const creatorIDAnn = "field.cattle.io/creatorId"
+
+type Checker struct {
+	quotaCache         mgmtcontrollers.ProvisioningQuotaCache
+	clusterCache       mgmtcontrollers.ClusterCache
+	clusterGroupCache  mgmtcontrollers.ClusterGroupCache
+	userAttributeCache mgmtcontrollers.UserAttributeCache
+	provClusterCache   provcontrollers.ClusterCache
+	dynamic            *dynamic.Controller
+}
+
+func NewChecker(clients *wrangler.Context) *Checker {
+	return &Checker{
+		quotaCache:         clients.Mgmt.ProvisioningQuota().Cache(),
+		clusterCache:       clients.Mgmt.Cluster().Cache(),
+		clusterGroupCache:  clients.Mgmt.ClusterGroup().Cache(),
+		userAttributeCache: clients.Mgmt.UserAttribute().Cache(),
+		provClusterCache:   clients.Provisioning.Cluster().Cache(),
+		dynamic:            clients.Dynamic,
+	}
+}
+
+// Check returns the reasons the provisioning quotas deny the creation of a cluster, or its update if oldCluster is set.
+// Clusters are accounted to the user that created them, or the requesting user if that isn't known.
+func (c *Checker) Check(userInfo user.Info, cluster, oldCluster *provv1.Cluster) ([]string, error) {
+	quotas, err := c.quotaCache.List(labels.Everything())
+	if err != nil || len(quotas) == 0 {
+		return nil, err
+	}
+	req, err := c.request(cluster, oldCluster)
+	if err != nil {
+		return nil, err
+	}
+	return c.check(quotas, userInfo, cluster, oldCluster, req)
+}
+
+// CheckMachineConfig returns the reasons the provisioning quotas deny the update of a machine config, checked as a
+// change of the machine pools using it in the clusters of its namespace. Only updates changing the instance type or
+// region of the machine config are checked, as they're otherwise only checked once a machine pool starts using it.
+func (c *Checker) CheckMachineConfig(userInfo user.Info, kind, namespace, name string, config, oldConfig data.Object) ([]string, error) {
+	instanceType, region := machineConfigFields(kind, config)
+	oldInstanceType, oldRegion := machineConfigFields(kind, oldConfig)
+	if instanceType == oldInstanceType && region == oldRegion {
+		return nil, nil
+	}
+
+	quotas, err := c.quotaCache.List(labels.Everything())
+	if err != nil || len(quotas) == 0 {
+		return nil, err
+	}
+	clusters, err := c.provClusterCache.List(namespace, labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, cluster := range clusters {
+		pools := machineConfigPools(cluster, kind, name)
+		if len(pools) == 0 || cluster.DeletionTimestamp != nil {
+			continue
+		}
+		nodes := machinePoolNodes(cluster)
+		req := &request{
+			nodes:    nodes,
+			oldNodes: nodes,
+		}
+		for _, pool := range pools {
+			req.machinePools = append(req.machinePools, machinePool{
+				name:         pool.Name,
+				instanceType: instanceType,
+				region:       region,
+			})
+		}
+		violations, err := c.check(quotas, userInfo, cluster, cluster, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, violation := range violations {
+			result = append(result, fmt.Sprintf("cluster %s: %s", cluster.Name, violation))
+		}
+	}
+	return result, nil
+}
+
+// check returns the reasons the quotas deny a request to create or update a cluster.
+func (c *Checker) check(quotas []*v3.ProvisioningQuota, userInfo user.Info, cluster, oldCluster *provv1.Cluster, req *request) ([]string, error) {
+	creator, creatorGroups, err := c.creator(userInfo, cluster, oldCluster)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := c.accounts(oldCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, quota := range quotas {
+		var usages []usage
+		if contains(quota.Spec.UserNames, creator) {
+			usages = append(usages, existing.usage("user "+creator, func(cluster *account) bool {
+				return cluster.creator == creator
+			}))
+		}
+		for _, group := range quota.Spec.GroupPrincipalNames {
+			if !contains(creatorGroups, group) {
+				continue
+			}
+			group := group
+			usages = append(usages, existing.usage("group "+group, func(cluster *account) bool {
+				return contains(cluster.creatorGroups, group)
+			}))
+		}
+		for _, groupName := range quota.Spec.ClusterGroupNames {
+			clusterGroup, ok := existing.clusterGroups[groupName]
+			if !ok || !clustergroup.ContainsCluster(clusterGroup, existing.clusterGroups, mgmtClusterName(cluster), cluster.Labels) {
+				continue
+			}
+			usages = append(usages, existing.usage("cluster group "+groupName, func(cluster *account) bool {
+				return contains(clusterGroup.Status.Clusters, cluster.clusterName)
+			}))
+		}
+		result = append(result, violations(quota, req, usages)...)
+	}
+	return result, nil
+}
+
+// creator returns the user a cluster is accounted to and its groups.
+func (c *Checker) creator(userInfo user.Info, cluster, oldCluster *provv1.Cluster) (string, []string, error) {
+	creator := cluster.Annotations[creatorIDAnn]
+	if creator == "" && oldCluster != nil {
+		creator = oldCluster.Annotations[creatorIDAnn]
+	}
+	if creator == "" || creator == userInfo.GetName() {
+		return userInfo.GetName(), userInfo.GetGroups(), nil
+	}
+	groups, err := c.userGroups(creator)
+	return creator, groups, err
+}
+
+// userGroups returns the group principals of a user, as last refreshed from its auth provider.
+func (c *Checker) userGroups(userName string) ([]string, error) {
+	attribs, err := c.userAttributeCache.Get(userName)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var groups []string
+	for _, principals := range attribs.GroupPrincipals {
+		for _, principal := range principals.Items {
+			groups = append(groups, principal.Name)
+		}
+	}
+	return groups, nil
+}
+
+func (c *Checker) request(cluster, oldCluster *provv1.Cluster) (*request, error) {
+	req := &request{
+		create:   oldCluster == nil,
+		nodes:    machinePoolNodes(cluster),
+		oldNodes: machinePoolNodes(oldCluster),
+	}
+	for _, pool := range changedMachinePools(cluster, oldCluster) {
+		instanceType, region, err := c.machineConfig(cluster.Namespace, pool)
+		if err != nil {
+			return nil, err
+		}
+		req.machinePools = append(req.machinePools, machinePool{
+			name:         pool.Name,
+			instanceType: instanceType,
+			region:       region,
+		})
+	}
+	return req, nil
+}
+
+// machineConfig returns the instance type and region of the machine config of a machine pool, empty if they aren't
+// known for its node driver.
+func (c *Checker) machineConfig(namespace string, pool provv1.RKEMachinePool) (string, string, error) {
+	if pool.NodeConfig == nil {
+		return "", "", nil
+	}
+	apiVersion := pool.NodeConfig.APIVersion
+	if apiVersion == "" {
+		apiVersion = capr.DefaultMachineConfigAPIVersion
+	}
+	if apiVersion != capr.DefaultMachineConfigAPIVersion {
+		return "", "", nil
+	}
+
+	machineConfig, err := c.dynamic.Get(schema.FromAPIVersionAndKind(apiVersion, pool.NodeConfig.Kind), namespace, pool.NodeConfig.Name)
+	if apierrors.IsNotFound(err) {
+		return "", "", nil
+	} else if err != nil {
+		return "", "", err
+	}
+	d, err := data.Convert(machineConfig)
+	if err != nil {
+		return "", "", err
+	}
+	instanceType, region := machineConfigFields(pool.NodeConfig.Kind, d)
+	return instanceType, region, nil
+}
+
+// account is an existing cluster, as accounted in the usage of quotas.
+type account struct {
+	clusterName   string
+	creator       string
+	creatorGroups []string
+	nodes         int
+}
+
+type accounts struct {
+	clusters      []*account
+	clusterGroups map[string]*v3.ClusterGroup
+}
+
+// accounts returns the existing clusters other than the one being updated, and the cluster groups.
+func (c *Checker) accounts(oldCluster *provv1.Cluster) (*accounts, error) {
+	clusters, err := c.clusterCache.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	provClusters, err := c.provClusterCache.List("", labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	byMgmtName := map[string]*provv1.Cluster{}
+	for _, provCluster := range provClusters {
+		if provCluster.Status.ClusterName != "" {
+			byMgmtName[provCluster.Status.ClusterName] = provCluster
+		}
+	}
+	clusterGroups, err := c.clusterGroupCache.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &accounts{clusterGroups: map[string]*v3.ClusterGroup{}}
+	for _, clusterGroup := range clusterGroups {
+		result.clusterGroups[clusterGroup.Name] = clusterGroup
+	}
+
+	groups := map[string][]string{}
+	excluded := mgmtClusterName(oldCluster)
+	for _, cluster := range clusters {
+		if cluster.Name == excluded && excluded != "" {
+			continue
+		}
+		acc := &account{
+			clusterName: cluster.Name,
+			creator:     cluster.Annotations[creatorIDAnn],
+			nodes:       cluster.Status.NodeCount,
+		}
+		// machines being provisioned count as nodes
+		if provCluster := byMgmtName[cluster.Name]; machinePoolNodes(provCluster) > 0 {
+			acc.nodes = machinePoolNodes(provCluster)
+		}
+		if acc.creator != "" {
+			if _, ok := groups[acc.creator]; !ok {
+				if groups[acc.creator], err = c.userGroups(acc.creator); err != nil {
+					return nil, err
+				}
+			}
+			acc.creatorGroups = groups[acc.creator]
+		}
+		result.clusters = append(result.clusters, acc)
+	}
+	return result, nil
+}
+
+// usage returns the usage of the subject of a quota the selected clusters are accounted to.
+func (a *accounts) usage(subject string, selected func(cluster *account) bool) usage {
+	result := usage{subject: subject}
+	for _, cluster := range a.clusters {
+		if selected(cluster) {
+			result.clusters++
+			result.nodes += cluster.nodes
+		}
+	}
+	return result
+}
+
+func mgmtClusterName(cluster *provv1.Cluster) string {
+	if cluster == nil {
+		return ""
+	}
+	return cluster.Status.ClusterName
+}
@@ -0,0 +1,194 @@
+package provisioningquota
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/wrangler/pkg/data"
+)
+
+// instanceTypeFields and regionFields are the fields of the machine configs of node drivers holding the instance type
+// and region of machines, by machine config kind.
+var (
+	instanceTypeFields = map[string]string{
+		"Amazonec2Config":    "instanceType",
+		"AzureConfig":        "size",
+		"DigitaloceanConfig": "size",
+		"LinodeConfig":       "instanceType",
+	}
+	regionFields = map[string]string{
+		"Amazonec2Config":    "region",
+		"AzureConfig":        "location",
+		"DigitaloceanConfig": "region",
+		"LinodeConfig":       "region",
+	}
+)
+
+// Kinds returns the kinds of machine configs whose instance type and region are known.
+func Kinds() []string {
+	var result []string
+	for kind := range instanceTypeFields {
+		result = append(result, kind)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// machineConfigFields returns the instance type and region of a machine config of a kind, empty if they aren't known
+// for its node driver.
+func machineConfigFields(kind string, config data.Object) (string, string) {
+	var instanceType, region string
+	if field, ok := instanceTypeFields[kind]; ok {
+		instanceType = config.String(field)
+	}
+	if field, ok := regionFields[kind]; ok {
+		region = config.String(field)
+	}
+	return instanceType, region
+}
+
+// request is a cluster being created or scaled.
+type request struct {
+	create bool
+	// nodes and oldNodes are the number of nodes of the cluster after and before the change.
+	nodes    int
+	oldNodes int
+	// machinePools are the machine pools added or changed.
+	machinePools []machinePool
+}
+
+type machinePool struct {
+	name string
+	// instanceType and region are empty if they can't be read from the machine config.
+	instanceType string
+	region       string
+}
+
+// usage is the usage of a subject of a quota, excluding the cluster being created or scaled.
+type usage struct {
+	// subject describes the user, group or cluster group, for example "user u-abcde".
+	subject  string
+	clusters int
+	nodes    int
+}
+
+// violations returns the reasons a quota denies a request, given the usage of the subjects of the quota the cluster is
+// accounted to. The number of clusters and nodes is only checked when it increases, so that clusters can still be
+// scaled down when a quota is lowered.
+func violations(quota *v3.ProvisioningQuota, req *request, usages []usage) []string {
+	if len(usages) == 0 {
+		return nil
+	}
+
+	var result []string
+	for _, u := range usages {
+		if limit := quota.Spec.MaxClusters; limit != nil && req.create && u.clusters+1 > *limit {
+			result = append(result, fmt.Sprintf("quota %s allows %d clusters for %s, which already has %d",
+				quota.Name, *limit, u.subject, u.clusters))
+		}
+		if limit := quota.Spec.MaxNodes; limit != nil && req.nodes > req.oldNodes && u.nodes+req.nodes > *limit {
+			result = append(result, fmt.Sprintf("quota %s allows %d nodes for %s, which would have %d",
+				quota.Name, *limit, u.subject, u.nodes+req.nodes))
+		}
+	}
+
+	for _, pool := range req.machinePools {
+		if allowed := quota.Spec.AllowedInstanceTypes; len(allowed) > 0 && !contains(allowed, pool.instanceType) {
+			result = append(result, notAllowed(quota.Name, "instance type", pool.name, pool.instanceType, allowed))
+		}
+		if allowed := quota.Spec.AllowedRegions; len(allowed) > 0 && !contains(allowed, pool.region) {
+			result = append(result, notAllowed(quota.Name, "region", pool.name, pool.region, allowed))
+		}
+	}
+	return result
+}
+
+func notAllowed(quotaName, field, poolName, value string, allowed []string) string {
+	if value == "" {
+		return fmt.Sprintf("quota %s restricts the %s of machine pools, which can't be determined for machine pool %s",
+			quotaName, field, poolName)
+	}
+	return fmt.Sprintf("quota %s doesn't allow %s %s of machine pool %s, allowed: %s",
+		quotaName, field, value, poolName, strings.Join(allowed, ", "))
+}
+
+// machinePoolNodes returns the number of nodes of the machine pools of a cluster.
+func machinePoolNodes(cluster *provv1.Cluster) int {
+	if cluster == nil || cluster.Spec.RKEConfig == nil {
+		return 0
+	}
+	nodes := 0
+	for _, pool := range cluster.Spec.RKEConfig.MachinePools {
+		if pool.Quantity == nil {
+			// machine deployments default to one replica
+			nodes++
+		} else {
+			nodes += int(*pool.Quantity)
+		}
+	}
+	return nodes
+}
+
+// changedMachinePools returns the machine pools of a cluster that are new or use another machine config than before,
+// sorted by name.
+func changedMachinePools(cluster, oldCluster *provv1.Cluster) []provv1.RKEMachinePool {
+	if cluster.Spec.RKEConfig == nil {
+		return nil
+	}
+	old := map[string]provv1.RKEMachinePool{}
+	if oldCluster != nil && oldCluster.Spec.RKEConfig != nil {
+		for _, pool := range oldCluster.Spec.RKEConfig.MachinePools {
+			old[pool.Name] = pool
+		}
+	}
+
+	var result []provv1.RKEMachinePool
+	for _, pool := range cluster.Spec.RKEConfig.MachinePools {
+		if oldPool, ok := old[pool.Name]; ok && sameMachineConfig(pool, oldPool) {
+			continue
+		}
+		result = append(result, pool)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// machineConfigPools returns the machine pools of a cluster that use a machine config of a node driver.
+func machineConfigPools(cluster *provv1.Cluster, kind, name string) []provv1.RKEMachinePool {
+	if cluster.Spec.RKEConfig == nil {
+		return nil
+	}
+	var result []provv1.RKEMachinePool
+	for _, pool := range cluster.Spec.RKEConfig.MachinePools {
+		if pool.NodeConfig == nil || pool.NodeConfig.Kind != kind || pool.NodeConfig.Name != name {
+			continue
+		}
+		if pool.NodeConfig.APIVersion != "" && pool.NodeConfig.APIVersion != capr.DefaultMachineConfigAPIVersion {
+			continue
+		}
+		result = append(result, pool)
+	}
+	return result
+}
+
+func sameMachineConfig(pool, oldPool provv1.RKEMachinePool) bool {
+	if pool.NodeConfig == nil || oldPool.NodeConfig == nil {
+		return pool.NodeConfig == oldPool.NodeConfig
+	}
+	return pool.NodeConfig.Kind == oldPool.NodeConfig.Kind && pool.NodeConfig.Name == oldPool.NodeConfig.Name
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
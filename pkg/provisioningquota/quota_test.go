@@ -0,0 +1,156 @@
+package provisioningquota
+
+import (
+	"testing"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/wrangler/pkg/data"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func TestViolations(t *testing.T) {
+	quota := &v3.ProvisioningQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a"},
+		Spec: v3.ProvisioningQuotaSpec{
+			MaxClusters:          intPtr(2),
+			MaxNodes:             intPtr(10),
+			AllowedInstanceTypes: []string{"t3.large", "m5.large"},
+			AllowedRegions:       []string{"eu-west-1"},
+		},
+	}
+	user := usage{subject: "user u-abcde", clusters: 2, nodes: 6}
+
+	tests := []struct {
+		name     string
+		req      request
+		usages   []usage
+		expected []string
+	}{
+		{
+			name:   "not applicable",
+			req:    request{create: true, nodes: 20, machinePools: []machinePool{{name: "pool1", instanceType: "m5.8xlarge"}}},
+			usages: nil,
+		},
+		{
+			name: "create",
+			req: request{
+				create:       true,
+				nodes:        5,
+				machinePools: []machinePool{{name: "pool1", instanceType: "m5.8xlarge", region: "eu-west-1"}},
+			},
+			usages: []usage{user},
+			expected: []string{
+				"quota team-a allows 2 clusters for user u-abcde, which already has 2",
+				"quota team-a allows 10 nodes for user u-abcde, which would have 11",
+				"quota team-a doesn't allow instance type m5.8xlarge of machine pool pool1, allowed: t3.large, m5.large",
+			},
+		},
+		{
+			name:   "within quota",
+			req:    request{create: true, nodes: 3, machinePools: []machinePool{{name: "pool1", instanceType: "t3.large", region: "eu-west-1"}}},
+			usages: []usage{{subject: "group github_team://1", clusters: 1, nodes: 7}},
+		},
+		{
+			name:   "scale up",
+			req:    request{nodes: 5, oldNodes: 4},
+			usages: []usage{user},
+			expected: []string{
+				"quota team-a allows 10 nodes for user u-abcde, which would have 11",
+			},
+		},
+		{
+			name:   "scale down over quota",
+			req:    request{nodes: 5, oldNodes: 6},
+			usages: []usage{user},
+		},
+		{
+			name:   "unknown machine config",
+			req:    request{nodes: 1, oldNodes: 1, machinePools: []machinePool{{name: "pool2"}}},
+			usages: []usage{user},
+			expected: []string{
+				"quota team-a restricts the instance type of machine pools, which can't be determined for machine pool pool2",
+				"quota team-a restricts the region of machine pools, which can't be determined for machine pool pool2",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, violations(quota, &tt.req, tt.usages))
+		})
+	}
+}
+
+func TestMachinePools(t *testing.T) {
+	pool := func(name, config string, quantity *int32) provv1.RKEMachinePool {
+		return provv1.RKEMachinePool{
+			Name:       name,
+			NodeConfig: &corev1.ObjectReference{Kind: "Amazonec2Config", Name: config},
+			Quantity:   quantity,
+		}
+	}
+	cluster := func(pools ...provv1.RKEMachinePool) *provv1.Cluster {
+		return &provv1.Cluster{Spec: provv1.ClusterSpec{RKEConfig: &provv1.RKEConfig{MachinePools: pools}}}
+	}
+
+	old := cluster(pool("cp", "nc-cp", int32Ptr(3)), pool("worker", "nc-worker", int32Ptr(2)))
+	updated := cluster(pool("worker", "nc-worker-large", int32Ptr(4)), pool("cp", "nc-cp", int32Ptr(3)), pool("gpu", "nc-gpu", nil))
+
+	assert.Equal(t, 5, machinePoolNodes(old))
+	assert.Equal(t, 8, machinePoolNodes(updated))
+	assert.Equal(t, 0, machinePoolNodes(nil))
+	assert.Equal(t, 0, machinePoolNodes(&provv1.Cluster{}))
+
+	var names []string
+	for _, pool := range changedMachinePools(updated, old) {
+		names = append(names, pool.Name)
+	}
+	assert.Equal(t, []string{"gpu", "worker"}, names)
+	assert.Len(t, changedMachinePools(old, nil), 2)
+	assert.Empty(t, changedMachinePools(old, old))
+}
+
+func TestMachineConfigPools(t *testing.T) {
+	cluster := &provv1.Cluster{Spec: provv1.ClusterSpec{RKEConfig: &provv1.RKEConfig{MachinePools: []provv1.RKEMachinePool{
+		{Name: "cp", NodeConfig: &corev1.ObjectReference{Kind: "Amazonec2Config", Name: "nc-shared"}},
+		{Name: "worker", NodeConfig: &corev1.ObjectReference{Kind: "Amazonec2Config", Name: "nc-shared", APIVersion: "rke-machine-config.cattle.io/v1"}},
+		{Name: "gpu", NodeConfig: &corev1.ObjectReference{Kind: "Amazonec2Config", Name: "nc-gpu"}},
+		{Name: "azure", NodeConfig: &corev1.ObjectReference{Kind: "AzureConfig", Name: "nc-shared"}},
+		{Name: "custom", NodeConfig: &corev1.ObjectReference{Kind: "Amazonec2Config", Name: "nc-shared", APIVersion: "example.com/v1"}},
+		{Name: "none"},
+	}}}}
+
+	var names []string
+	for _, pool := range machineConfigPools(cluster, "Amazonec2Config", "nc-shared") {
+		names = append(names, pool.Name)
+	}
+	assert.Equal(t, []string{"cp", "worker"}, names)
+	assert.Empty(t, machineConfigPools(cluster, "Amazonec2Config", "nc-unused"))
+	assert.Empty(t, machineConfigPools(&provv1.Cluster{}, "Amazonec2Config", "nc-shared"))
+}
+
+func TestMachineConfigFields(t *testing.T) {
+	instanceType, region := machineConfigFields("AzureConfig", data.Object{"size": "Standard_D2_v2", "location": "westus"})
+	assert.Equal(t, "Standard_D2_v2", instanceType)
+	assert.Equal(t, "westus", region)
+
+	instanceType, region = machineConfigFields("VmwarevsphereConfig", data.Object{"size": "large"})
+	assert.Empty(t, instanceType)
+	assert.Empty(t, region)
+
+	instanceType, region = machineConfigFields("Amazonec2Config", nil)
+	assert.Empty(t, instanceType)
+	assert.Empty(t, region)
+
+	assert.Equal(t, []string{"Amazonec2Config", "AzureConfig", "DigitaloceanConfig", "LinodeConfig"}, Kinds())
+}
@@ -31,10 +31,14 @@
 	dashboarddata "github.com/rancher/rancher/pkg/data/dashboard"
 	"github.com/rancher/rancher/pkg/features"
 	mgmntv3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/logging"
+	"github.com/rancher/rancher/pkg/metrics"
 	"github.com/rancher/rancher/pkg/multiclustermanager"
 	"github.com/rancher/rancher/pkg/namespace"
 	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/standby"
 	"github.com/rancher/rancher/pkg/tls"
+	"github.com/rancher/rancher/pkg/tracing"
 	"github.com/rancher/rancher/pkg/ui"
 	"github.com/rancher/rancher/pkg/websocket"
 	"github.com/rancher/rancher/pkg/wrangler"
@@ -79,6 +83,9 @@ type Options struct {
 	AuditLevel        int
 	Features          string
 	ClusterRegistry   string
+	// Standby runs the replica in standby, serving the UI, read requests and proxied requests to clusters without
+	// running the controllers.
+	Standby bool
 }
 
 type Rancher struct {
@@ -116,6 +123,9 @@ func New(ctx context.Context, clientConfg clientcmd.ClientConfig, opts *Options)
 		return nil, err
 	}
 
+	// The workqueues of the controllers get their metrics from the provider set before they're created
+	metrics.SetupControllerMetrics()
+
 	wranglerContext, err := wrangler.NewContext(ctx, clientConfg, restConfig)
 	if err != nil {
 		return nil, err
@@ -218,10 +228,20 @@ func New(ctx context.Context, clientConfg clientcmd.ClientConfig, opts *Options)
 	}
 	aggregationMiddleware := aggregation.NewMiddleware(ctx, wranglerContext.Mgmt.APIService(), wranglerContext.TunnelServer)
 
+	readOnly := func(next http.Handler) http.Handler { return next }
+	if opts.Standby {
+		logrus.Info("Running in standby, serving read requests without running the controllers")
+		wranglerContext.Standby = true
+		readOnly = standby.ReadOnly
+	} else {
+		wranglerContext.LeadershipGate = newLeadershipGate(wranglerContext, opts).Wait
+	}
+
 	return &Rancher{
 		Auth: authServer.Authenticator.Chain(
 			auditFilter),
 		Handler: responsewriter.Chain{
+			readOnly,
 			auth.SetXAPICattleAuthHeader,
 			responsewriter.ContentTypeOptions,
 			responsewriter.NoCache,
@@ -248,6 +268,11 @@ func (r *Rancher) Start(ctx context.Context) error {
 		return err
 	}
 
+	if err := tracing.Start(ctx); err != nil {
+		// tracing is optional, so misconfigured settings leave it disabled rather than failing the start of rancher
+		logrus.Errorf("Tracing is disabled: %v", err)
+	}
+
 	if err := steveapi.Setup(ctx, r.Steve, r.Wrangler); err != nil {
 		return err
 	}
@@ -276,6 +301,7 @@ func (r *Rancher) Start(ctx context.Context) error {
 	}
 
 	r.Wrangler.OnLeader(r.authServer.OnLeader)
+	r.Wrangler.OnLeader(newVersions(r.Wrangler).Record)
 	r.auditLog.Start(ctx)
 
 	return r.Wrangler.Start(ctx)
@@ -291,7 +317,7 @@ func (r *Rancher) ListenAndServe(ctx context.Context) error {
 	r.startAggregation(ctx)
 	go r.Steve.StartAggregation(ctx)
 	if err := tls.ListenAndServe(ctx, r.Wrangler.RESTConfig,
-		r.Auth(r.Handler),
+		logging.TraceMiddleware(tracing.Middleware(r.Auth(r.Handler))),
 		r.opts.BindHost,
 		r.opts.HTTPSListenPort,
 		r.opts.HTTPListenPort,
@@ -304,6 +330,38 @@ func (r *Rancher) ListenAndServe(ctx context.Context) error {
 	return ctx.Err()
 }
 
+func newVersions(wranglerContext *wrangler.Context) *standby.Versions {
+	return &standby.Versions{
+		ConfigMaps: wranglerContext.Core.ConfigMap(),
+		Version:    settings.ServerVersion.Get(),
+	}
+}
+
+// newLeadershipGate returns the gate a replica passes before running the controllers: it serves requests, reaches the
+// kubernetes API server for the controller handoff delay, and no newer version runs the controllers. Rancher only serves
+// requests once the controllers added the initial data, so the gate is bypassed until then.
+func newLeadershipGate(wranglerContext *wrangler.Context, opts *Options) *standby.Gate {
+	gate := &standby.Gate{
+		Bypass: func(ctx context.Context) bool {
+			_, err := wranglerContext.Core.Namespace().Get(namespace.GlobalNamespace, metav1.GetOptions{})
+			return k8serror.IsNotFound(err)
+		},
+		Checks: []standby.Check{standby.APIServer(wranglerContext.K8s.Discovery())},
+		Duration: func() time.Duration {
+			return time.Duration(settings.ControllerHandoffDelaySeconds.GetInt()) * time.Second
+		},
+		CanLead: newVersions(wranglerContext).CanLead,
+	}
+	if opts.HTTPListenPort != 0 {
+		host := opts.BindHost
+		if host == "" {
+			host = "127.0.0.1"
+		}
+		gate.Checks = append(gate.Checks, standby.Ping(fmt.Sprintf("http://%s:%d", host, opts.HTTPListenPort)))
+	}
+	return gate
+}
+
 func (r *Rancher) startAggregation(ctx context.Context) {
 	aggregation2.Watch(ctx, r.Wrangler.Core.Secret(), namespace.System, "stv-aggregation", r.Handler)
 }
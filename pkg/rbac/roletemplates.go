@@ -0,0 +1,199 @@
+package rbac
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ResolvedRoleTemplate is a role template with the rules it grants once its inherited and aggregated role templates are
+// resolved, and the tree of the role templates it inherits.
+type ResolvedRoleTemplate struct {
+	RoleTemplateNode
+	// ResolvedRules are the de-duplicated rules of the role template and of every role template of its tree.
+	ResolvedRules []rbacv1.PolicyRule `json:"resolvedRules"`
+	// ExternalRoleTemplates are the external role templates of the tree, whose rules are those of the cluster role of
+	// the same name in each downstream cluster and can't be resolved in the local cluster.
+	ExternalRoleTemplates []string `json:"externalRoleTemplates,omitempty"`
+}
+
+// RoleTemplateNode is a role template of the inheritance tree of a role template.
+type RoleTemplateNode struct {
+	Name        string              `json:"name"`
+	DisplayName string              `json:"displayName,omitempty"`
+	Context     string              `json:"context,omitempty"`
+	External    bool                `json:"external,omitempty"`
+	Rules       []rbacv1.PolicyRule `json:"rules,omitempty"`
+	// Inherits are the role templates of RoleTemplateNames.
+	Inherits []*RoleTemplateNode `json:"inherits,omitempty"`
+	// Aggregates are the role templates selected by the aggregation rule, whose rules are already in Rules.
+	Aggregates []*RoleTemplateNode `json:"aggregates,omitempty"`
+	// Missing is set when the role template doesn't exist.
+	Missing bool `json:"missing,omitempty"`
+	// Cycle is set when the role template is already one of its ancestors in the tree, and isn't expanded again.
+	Cycle bool `json:"cycle,omitempty"`
+}
+
+// ResolveRoleTemplate resolves the role template named name from all the role templates.
+func ResolveRoleTemplate(name string, roleTemplates []*v3.RoleTemplate) (*ResolvedRoleTemplate, error) {
+	return resolveRoleTemplate(name, roleTemplates, map[string]bool{})
+}
+
+// resolveRoleTemplate resolves the role template named name, ignoring the rules of the role templates of ancestors.
+func resolveRoleTemplate(name string, roleTemplates []*v3.RoleTemplate, ancestors map[string]bool) (*ResolvedRoleTemplate, error) {
+	byName := make(map[string]*v3.RoleTemplate, len(roleTemplates))
+	for _, rt := range roleTemplates {
+		byName[rt.Name] = rt
+	}
+	if byName[name] == nil {
+		return nil, fmt.Errorf("role template %s not found", name)
+	}
+
+	r := &resolver{
+		roleTemplates: roleTemplates,
+		byName:        byName,
+		external:      map[string]bool{},
+	}
+	node, err := r.node(name, ancestors)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := &ResolvedRoleTemplate{
+		RoleTemplateNode: *node,
+		ResolvedRules:    r.rules,
+	}
+	for name := range r.external {
+		resolved.ExternalRoleTemplates = append(resolved.ExternalRoleTemplates, name)
+	}
+	sort.Strings(resolved.ExternalRoleTemplates)
+	return resolved, nil
+}
+
+type resolver struct {
+	roleTemplates []*v3.RoleTemplate
+	byName        map[string]*v3.RoleTemplate
+	external      map[string]bool
+	rules         []rbacv1.PolicyRule
+}
+
+func (r *resolver) node(name string, ancestors map[string]bool) (*RoleTemplateNode, error) {
+	rt := r.byName[name]
+	if rt == nil {
+		return &RoleTemplateNode{Name: name, Missing: true}, nil
+	}
+	node := &RoleTemplateNode{
+		Name:        rt.Name,
+		DisplayName: rt.DisplayName,
+		Context:     rt.Context,
+		External:    rt.External,
+		Rules:       rt.Rules,
+	}
+	if ancestors[name] {
+		node.Cycle = true
+		return node, nil
+	}
+	ancestors[name] = true
+	defer delete(ancestors, name)
+
+	if rt.External {
+		r.external[name] = true
+	}
+	r.rules = appendUniqueRules(r.rules, rt.Rules...)
+
+	for _, inherited := range rt.RoleTemplateNames {
+		child, err := r.node(inherited, ancestors)
+		if err != nil {
+			return nil, err
+		}
+		node.Inherits = append(node.Inherits, child)
+	}
+
+	selected, err := SelectedRoleTemplates(rt, r.roleTemplates)
+	if err != nil {
+		return nil, err
+	}
+	for _, aggregated := range selected {
+		child, err := r.node(aggregated.Name, ancestors)
+		if err != nil {
+			return nil, err
+		}
+		node.Aggregates = append(node.Aggregates, child)
+	}
+	return node, nil
+}
+
+// SelectedRoleTemplates returns the role templates selected by the aggregation rule of rt, sorted by name. Only the
+// role templates of the same context as rt that aren't aggregated themselves are selected.
+func SelectedRoleTemplates(rt *v3.RoleTemplate, roleTemplates []*v3.RoleTemplate) ([]*v3.RoleTemplate, error) {
+	if rt.AggregationRule == nil {
+		return nil, nil
+	}
+	var selectors []labels.Selector
+	for i := range rt.AggregationRule.RoleTemplateSelectors {
+		selector, err := metav1.LabelSelectorAsSelector(&rt.AggregationRule.RoleTemplateSelectors[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid aggregation rule of role template %s: %w", rt.Name, err)
+		}
+		selectors = append(selectors, selector)
+	}
+
+	var result []*v3.RoleTemplate
+	for _, candidate := range roleTemplates {
+		if candidate.Name == rt.Name || candidate.AggregationRule != nil || candidate.Context != rt.Context {
+			continue
+		}
+		for _, selector := range selectors {
+			// an empty selector selects nothing, like for cluster roles
+			if !selector.Empty() && selector.Matches(labels.Set(candidate.Labels)) {
+				result = append(result, candidate)
+				break
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// AggregatedRules returns the rules of an aggregated role template: the de-duplicated rules of the role templates
+// selected by its aggregation rule, including the rules they inherit.
+func AggregatedRules(rt *v3.RoleTemplate, roleTemplates []*v3.RoleTemplate) ([]rbacv1.PolicyRule, error) {
+	selected, err := SelectedRoleTemplates(rt, roleTemplates)
+	if err != nil {
+		return nil, err
+	}
+	var rules []rbacv1.PolicyRule
+	for _, s := range selected {
+		// the rules of rt are ignored if a selected role template inherits it, so that they're only the result of
+		// the aggregation
+		resolved, err := resolveRoleTemplate(s.Name, roleTemplates, map[string]bool{rt.Name: true})
+		if err != nil {
+			return nil, err
+		}
+		rules = appendUniqueRules(rules, resolved.ResolvedRules...)
+	}
+	return rules, nil
+}
+
+func appendUniqueRules(rules []rbacv1.PolicyRule, toAdd ...rbacv1.PolicyRule) []rbacv1.PolicyRule {
+	for _, rule := range toAdd {
+		found := false
+		for _, existing := range rules {
+			if reflect.DeepEqual(existing, rule) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
@@ -0,0 +1,135 @@
+package rbac
+
+import (
+	"testing"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func roleTemplate(name, context string, labels map[string]string, inherits []string, rules ...rbacv1.PolicyRule) *v3.RoleTemplate {
+	return &v3.RoleTemplate{
+		ObjectMeta:        metav1.ObjectMeta{Name: name, Labels: labels},
+		Context:           context,
+		RoleTemplateNames: inherits,
+		Rules:             rules,
+	}
+}
+
+func podRule(verbs ...string) rbacv1.PolicyRule {
+	return rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: verbs}
+}
+
+func TestSelectedRoleTemplates(t *testing.T) {
+	aggregated := roleTemplate("aggregated", "project", nil, nil)
+	aggregated.AggregationRule = &v3.RoleTemplateAggregationRule{
+		RoleTemplateSelectors: []metav1.LabelSelector{
+			{MatchLabels: map[string]string{"aggregate-to": "aggregated"}},
+			{},
+		},
+	}
+	other := roleTemplate("other-aggregated", "project", map[string]string{"aggregate-to": "aggregated"}, nil)
+	other.AggregationRule = &v3.RoleTemplateAggregationRule{}
+	roleTemplates := []*v3.RoleTemplate{
+		aggregated,
+		other,
+		roleTemplate("view", "project", map[string]string{"aggregate-to": "aggregated"}, nil),
+		roleTemplate("edit", "project", map[string]string{"aggregate-to": "aggregated"}, nil),
+		roleTemplate("cluster-view", "cluster", map[string]string{"aggregate-to": "aggregated"}, nil),
+		roleTemplate("unlabeled", "project", nil, nil),
+	}
+
+	selected, err := SelectedRoleTemplates(aggregated, roleTemplates)
+	require.NoError(t, err)
+	var names []string
+	for _, rt := range selected {
+		names = append(names, rt.Name)
+	}
+	assert.Equal(t, []string{"edit", "view"}, names)
+
+	selected, err = SelectedRoleTemplates(roleTemplates[2], roleTemplates)
+	assert.NoError(t, err)
+	assert.Empty(t, selected)
+
+	aggregated.AggregationRule.RoleTemplateSelectors = []metav1.LabelSelector{
+		{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "aggregate-to", Operator: "Unknown"}}},
+	}
+	_, err = SelectedRoleTemplates(aggregated, roleTemplates)
+	assert.ErrorContains(t, err, "invalid aggregation rule of role template aggregated")
+}
+
+func TestAggregatedRules(t *testing.T) {
+	aggregated := roleTemplate("aggregated", "project", map[string]string{"team": "a"}, nil, podRule("delete"))
+	aggregated.AggregationRule = &v3.RoleTemplateAggregationRule{
+		RoleTemplateSelectors: []metav1.LabelSelector{{MatchLabels: map[string]string{"team": "a"}}},
+	}
+	roleTemplates := []*v3.RoleTemplate{
+		aggregated,
+		roleTemplate("view", "project", map[string]string{"team": "a"}, nil, podRule("get")),
+		roleTemplate("edit", "project", map[string]string{"team": "a"}, []string{"base", "aggregated"}, podRule("update"), podRule("get")),
+		roleTemplate("base", "project", nil, nil, podRule("list")),
+	}
+
+	rules, err := AggregatedRules(aggregated, roleTemplates)
+	require.NoError(t, err)
+	// the rules of aggregated are ignored even if edit inherits it
+	assert.Equal(t, []rbacv1.PolicyRule{podRule("update"), podRule("get"), podRule("list")}, rules)
+
+	rules, err = AggregatedRules(roleTemplates[1], roleTemplates)
+	assert.NoError(t, err)
+	assert.Empty(t, rules)
+}
+
+func TestResolveRoleTemplate(t *testing.T) {
+	aggregated := roleTemplate("aggregated", "cluster", nil, nil, podRule("get"))
+	aggregated.AggregationRule = &v3.RoleTemplateAggregationRule{
+		RoleTemplateSelectors: []metav1.LabelSelector{{MatchLabels: map[string]string{"team": "a"}}},
+	}
+	external := roleTemplate("external", "cluster", nil, nil)
+	external.External = true
+	roleTemplates := []*v3.RoleTemplate{
+		roleTemplate("owner", "cluster", nil, []string{"member", "aggregated", "missing"}, podRule("*")),
+		roleTemplate("member", "cluster", nil, []string{"owner", "external"}, podRule("list")),
+		aggregated,
+		external,
+		roleTemplate("view", "cluster", map[string]string{"team": "a"}, nil, podRule("get")),
+	}
+
+	resolved, err := ResolveRoleTemplate("owner", roleTemplates)
+	require.NoError(t, err)
+	assert.Equal(t, &ResolvedRoleTemplate{
+		RoleTemplateNode: RoleTemplateNode{
+			Name:    "owner",
+			Context: "cluster",
+			Rules:   []rbacv1.PolicyRule{podRule("*")},
+			Inherits: []*RoleTemplateNode{
+				{
+					Name:    "member",
+					Context: "cluster",
+					Rules:   []rbacv1.PolicyRule{podRule("list")},
+					Inherits: []*RoleTemplateNode{
+						{Name: "owner", Context: "cluster", Rules: []rbacv1.PolicyRule{podRule("*")}, Cycle: true},
+						{Name: "external", Context: "cluster", External: true},
+					},
+				},
+				{
+					Name:    "aggregated",
+					Context: "cluster",
+					Rules:   []rbacv1.PolicyRule{podRule("get")},
+					Aggregates: []*RoleTemplateNode{
+						{Name: "view", Context: "cluster", Rules: []rbacv1.PolicyRule{podRule("get")}},
+					},
+				},
+				{Name: "missing", Missing: true},
+			},
+		},
+		ResolvedRules:         []rbacv1.PolicyRule{podRule("*"), podRule("list"), podRule("get")},
+		ExternalRoleTemplates: []string{"external"},
+	}, resolved)
+
+	_, err = ResolveRoleTemplate("unknown", roleTemplates)
+	assert.EqualError(t, err, "role template unknown not found")
+}
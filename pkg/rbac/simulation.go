@@ -0,0 +1,288 @@
+package rbac
+
+import (
+	"sort"
+	"strings"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// Permission is a verb granted on the resources of an API group, optionally restricted to a resource name, or on a
+// non-resource URL.
+type Permission struct {
+	APIGroup       string `json:"apiGroup,omitempty"`
+	Resource       string `json:"resource,omitempty"`
+	ResourceName   string `json:"resourceName,omitempty"`
+	NonResourceURL string `json:"nonResourceURL,omitempty"`
+	Verb           string `json:"verb"`
+}
+
+// PermissionDelta are the permissions a subject gains and loses in a cluster, or in a project if ProjectName is set.
+type PermissionDelta struct {
+	ClusterName string       `json:"clusterName"`
+	ProjectName string       `json:"projectName,omitempty"`
+	Added       []Permission `json:"added,omitempty"`
+	Removed     []Permission `json:"removed,omitempty"`
+}
+
+// BindingSubject is the subject of role template bindings: a user and the groups it's a member of, or a group.
+type BindingSubject struct {
+	UserName            string
+	UserPrincipalName   string
+	GroupPrincipalNames []string
+}
+
+// RoleTemplateBindings are the cluster and project role template bindings granting permissions to a subject.
+type RoleTemplateBindings struct {
+	CRTBs []*v3.ClusterRoleTemplateBinding
+	PRTBs []*v3.ProjectRoleTemplateBinding
+}
+
+// SubjectBindings returns the bindings of the subject, those bound to the user or to one of the groups of the subject.
+// Bindings being deleted are ignored.
+func SubjectBindings(subject BindingSubject, crtbs []*v3.ClusterRoleTemplateBinding, prtbs []*v3.ProjectRoleTemplateBinding) RoleTemplateBindings {
+	var result RoleTemplateBindings
+	for _, crtb := range crtbs {
+		if crtb.DeletionTimestamp == nil && subject.matches(crtb.UserName, crtb.UserPrincipalName, crtb.GroupPrincipalName) {
+			result.CRTBs = append(result.CRTBs, crtb)
+		}
+	}
+	for _, prtb := range prtbs {
+		if prtb.DeletionTimestamp == nil && subject.matches(prtb.UserName, prtb.UserPrincipalName, prtb.GroupPrincipalName) {
+			result.PRTBs = append(result.PRTBs, prtb)
+		}
+	}
+	return result
+}
+
+func (s BindingSubject) matches(userName, userPrincipalName, groupPrincipalName string) bool {
+	switch {
+	case userName != "" || userPrincipalName != "":
+		return (s.UserName != "" && userName == s.UserName) ||
+			(s.UserPrincipalName != "" && userPrincipalName == s.UserPrincipalName)
+	case groupPrincipalName != "":
+		for _, group := range s.GroupPrincipalNames {
+			if group == groupPrincipalName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// BindingsDelta returns the net permissions a subject gains and loses when its bindings change from before to after, by
+// cluster and by project, sorted by cluster and project. The permissions of a project already granted, or changed, in
+// its cluster aren't part of the delta of the project. It also returns the external role templates of the bindings,
+// whose permissions are those of the cluster role of the same name in each downstream cluster and can't be resolved.
+func BindingsDelta(before, after RoleTemplateBindings, roleTemplates []*v3.RoleTemplate) ([]PermissionDelta, []string, error) {
+	r := &permissionResolver{
+		roleTemplates: roleTemplates,
+		byName:        map[string]bool{},
+		resolved:      map[string][]Permission{},
+		external:      map[string]bool{},
+	}
+	for _, rt := range roleTemplates {
+		r.byName[rt.Name] = true
+	}
+
+	beforeGrants, err := r.grants(before)
+	if err != nil {
+		return nil, nil, err
+	}
+	afterGrants, err := r.grants(after)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scopes := map[scope]bool{}
+	for s := range beforeGrants {
+		scopes[s] = true
+	}
+	for s := range afterGrants {
+		scopes[s] = true
+	}
+
+	clusterDeltas := map[string]PermissionDelta{}
+	for s := range scopes {
+		if s.project == "" {
+			clusterDeltas[s.cluster] = delta(s, beforeGrants[s], afterGrants[s])
+		}
+	}
+
+	var result []PermissionDelta
+	for s := range scopes {
+		d := clusterDeltas[s.cluster]
+		if s.project != "" {
+			clusterScope := scope{cluster: s.cluster}
+			d = delta(s,
+				append(append([]Permission{}, beforeGrants[clusterScope]...), beforeGrants[s]...),
+				append(append([]Permission{}, afterGrants[clusterScope]...), afterGrants[s]...))
+			d.Added = uncovered(d.Added, clusterDeltas[s.cluster].Added)
+			d.Removed = uncovered(d.Removed, clusterDeltas[s.cluster].Removed)
+		}
+		if len(d.Added) > 0 || len(d.Removed) > 0 {
+			result = append(result, d)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ClusterName != result[j].ClusterName {
+			return result[i].ClusterName < result[j].ClusterName
+		}
+		return result[i].ProjectName < result[j].ProjectName
+	})
+
+	var external []string
+	for name := range r.external {
+		external = append(external, name)
+	}
+	sort.Strings(external)
+	return result, external, nil
+}
+
+// PolicyRulePermissions returns the permissions granted by the rules, de-duplicated.
+func PolicyRulePermissions(rules []rbacv1.PolicyRule) []Permission {
+	var result []Permission
+	seen := map[Permission]bool{}
+	add := func(p Permission) {
+		if !seen[p] {
+			seen[p] = true
+			result = append(result, p)
+		}
+	}
+	for _, rule := range rules {
+		for _, verb := range rule.Verbs {
+			for _, url := range rule.NonResourceURLs {
+				add(Permission{NonResourceURL: url, Verb: verb})
+			}
+			for _, group := range rule.APIGroups {
+				for _, resource := range rule.Resources {
+					if len(rule.ResourceNames) == 0 {
+						add(Permission{APIGroup: group, Resource: resource, Verb: verb})
+					}
+					for _, name := range rule.ResourceNames {
+						add(Permission{APIGroup: group, Resource: resource, ResourceName: name, Verb: verb})
+					}
+				}
+			}
+		}
+	}
+	return result
+}
+
+// Covers returns whether permission p grants everything permission q grants.
+func (p Permission) Covers(q Permission) bool {
+	if p.Verb != rbacv1.VerbAll && p.Verb != q.Verb {
+		return false
+	}
+	if p.NonResourceURL != "" || q.NonResourceURL != "" {
+		if p.NonResourceURL == "" || q.NonResourceURL == "" {
+			return false
+		}
+		if strings.HasSuffix(p.NonResourceURL, "*") {
+			return strings.HasPrefix(q.NonResourceURL, strings.TrimSuffix(p.NonResourceURL, "*"))
+		}
+		return p.NonResourceURL == q.NonResourceURL
+	}
+	return (p.APIGroup == rbacv1.APIGroupAll || p.APIGroup == q.APIGroup) &&
+		(p.Resource == rbacv1.ResourceAll || p.Resource == q.Resource) &&
+		(p.ResourceName == "" || p.ResourceName == q.ResourceName)
+}
+
+type scope struct {
+	cluster string
+	project string
+}
+
+type permissionResolver struct {
+	roleTemplates []*v3.RoleTemplate
+	byName        map[string]bool
+	resolved      map[string][]Permission
+	external      map[string]bool
+}
+
+func (r *permissionResolver) grants(bindings RoleTemplateBindings) (map[scope][]Permission, error) {
+	result := map[scope][]Permission{}
+	for _, crtb := range bindings.CRTBs {
+		permissions, err := r.permissions(crtb.RoleTemplateName)
+		if err != nil {
+			return nil, err
+		}
+		s := scope{cluster: crtb.ClusterName}
+		result[s] = append(result[s], permissions...)
+	}
+	for _, prtb := range bindings.PRTBs {
+		permissions, err := r.permissions(prtb.RoleTemplateName)
+		if err != nil {
+			return nil, err
+		}
+		s := scope{cluster: prtb.ObjClusterName(), project: prtb.ProjectName}
+		result[s] = append(result[s], permissions...)
+	}
+	return result, nil
+}
+
+// permissions returns the permissions of the resolved rules of a role template, none if it doesn't exist.
+func (r *permissionResolver) permissions(name string) ([]Permission, error) {
+	if permissions, ok := r.resolved[name]; ok || !r.byName[name] {
+		return permissions, nil
+	}
+	resolved, err := ResolveRoleTemplate(name, r.roleTemplates)
+	if err != nil {
+		return nil, err
+	}
+	for _, external := range resolved.ExternalRoleTemplates {
+		r.external[external] = true
+	}
+	permissions := PolicyRulePermissions(resolved.ResolvedRules)
+	r.resolved[name] = permissions
+	return permissions, nil
+}
+
+func delta(s scope, before, after []Permission) PermissionDelta {
+	return PermissionDelta{
+		ClusterName: s.cluster,
+		ProjectName: s.project,
+		Added:       uncovered(after, before),
+		Removed:     uncovered(before, after),
+	}
+}
+
+// uncovered returns the permissions not covered by any of the covering permissions, de-duplicated and sorted.
+func uncovered(permissions, covering []Permission) []Permission {
+	var result []Permission
+	seen := map[Permission]bool{}
+	for _, p := range permissions {
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		covered := false
+		for _, c := range covering {
+			if c.Covers(p) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			result = append(result, p)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.NonResourceURL != b.NonResourceURL {
+			return a.NonResourceURL < b.NonResourceURL
+		}
+		if a.APIGroup != b.APIGroup {
+			return a.APIGroup < b.APIGroup
+		}
+		if a.Resource != b.Resource {
+			return a.Resource < b.Resource
+		}
+		if a.ResourceName != b.ResourceName {
+			return a.ResourceName < b.ResourceName
+		}
+		return a.Verb < b.Verb
+	})
+	return result
+}
@@ -0,0 +1,152 @@
+package rbac
+
+import (
+	"testing"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func crtb(name, cluster, roleTemplate string) *v3.ClusterRoleTemplateBinding {
+	return &v3.ClusterRoleTemplateBinding{
+		ObjectMeta:       metav1.ObjectMeta{Name: name, Namespace: cluster},
+		UserName:         "u-1",
+		ClusterName:      cluster,
+		RoleTemplateName: roleTemplate,
+	}
+}
+
+func prtb(name, project, roleTemplate string) *v3.ProjectRoleTemplateBinding {
+	return &v3.ProjectRoleTemplateBinding{
+		ObjectMeta:       metav1.ObjectMeta{Name: name},
+		UserName:         "u-1",
+		ProjectName:      project,
+		RoleTemplateName: roleTemplate,
+	}
+}
+
+func TestPolicyRulePermissions(t *testing.T) {
+	assert.Equal(t, []Permission{
+		{APIGroup: "", Resource: "pods", Verb: "get"},
+		{APIGroup: "", Resource: "pods", Verb: "list"},
+		{APIGroup: "apps", Resource: "deployments", ResourceName: "web", Verb: "update"},
+		{NonResourceURL: "/healthz", Verb: "get"},
+	}, PolicyRulePermissions([]rbacv1.PolicyRule{
+		podRule("get", "list"),
+		podRule("get"),
+		{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, ResourceNames: []string{"web"}, Verbs: []string{"update"}},
+		{NonResourceURLs: []string{"/healthz"}, Verbs: []string{"get"}},
+	}))
+}
+
+func TestPermissionCovers(t *testing.T) {
+	getPods := Permission{Resource: "pods", Verb: "get"}
+	assert.True(t, getPods.Covers(getPods))
+	assert.True(t, getPods.Covers(Permission{Resource: "pods", ResourceName: "web", Verb: "get"}))
+	assert.False(t, Permission{Resource: "pods", ResourceName: "web", Verb: "get"}.Covers(getPods))
+	assert.False(t, getPods.Covers(Permission{Resource: "pods", Verb: "delete"}))
+	assert.True(t, Permission{APIGroup: "*", Resource: "*", Verb: "*"}.Covers(Permission{APIGroup: "apps", Resource: "deployments", Verb: "delete"}))
+	assert.False(t, Permission{APIGroup: "*", Resource: "*", Verb: "*"}.Covers(Permission{NonResourceURL: "/healthz", Verb: "get"}))
+	assert.True(t, Permission{NonResourceURL: "/metrics/*", Verb: "get"}.Covers(Permission{NonResourceURL: "/metrics/cadvisor", Verb: "get"}))
+	assert.False(t, Permission{NonResourceURL: "/metrics", Verb: "get"}.Covers(Permission{NonResourceURL: "/metrics/cadvisor", Verb: "get"}))
+}
+
+func TestSubjectBindings(t *testing.T) {
+	deleting := crtb("deleting", "c-1", "view")
+	deleting.DeletionTimestamp = &metav1.Time{}
+	byPrincipal := crtb("by-principal", "c-1", "view")
+	byPrincipal.UserName = ""
+	byPrincipal.UserPrincipalName = "local://u-1"
+	group := crtb("group", "c-1", "view")
+	group.UserName = ""
+	group.GroupPrincipalName = "github_team://1"
+	otherGroup := crtb("other-group", "c-1", "view")
+	otherGroup.UserName = ""
+	otherGroup.GroupPrincipalName = "github_team://2"
+	otherUser := prtb("other-user", "c-1:p-1", "view")
+	otherUser.UserName = "u-2"
+
+	bindings := SubjectBindings(BindingSubject{
+		UserName:            "u-1",
+		UserPrincipalName:   "local://u-1",
+		GroupPrincipalNames: []string{"github_team://1"},
+	}, []*v3.ClusterRoleTemplateBinding{
+		crtb("user", "c-1", "view"), deleting, byPrincipal, group, otherGroup,
+	}, []*v3.ProjectRoleTemplateBinding{
+		prtb("user", "c-1:p-1", "view"), otherUser,
+	})
+
+	var names []string
+	for _, b := range bindings.CRTBs {
+		names = append(names, b.Name)
+	}
+	for _, b := range bindings.PRTBs {
+		names = append(names, "prtb/"+b.Name)
+	}
+	assert.Equal(t, []string{"user", "by-principal", "group", "prtb/user"}, names)
+}
+
+func TestBindingsDelta(t *testing.T) {
+	roleTemplates := []*v3.RoleTemplate{
+		roleTemplate("view", "project", nil, nil, podRule("get", "list")),
+		roleTemplate("edit", "project", nil, []string{"view"}, podRule("create", "delete")),
+		roleTemplate("owner", "cluster", nil, nil, rbacv1.PolicyRule{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}),
+		roleTemplate("nodes-view", "cluster", nil, []string{"external-view"}, rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"nodes"}, Verbs: []string{"get"}}),
+		roleTemplate("external-view", "cluster", nil, nil),
+	}
+	roleTemplates[4].External = true
+
+	before := RoleTemplateBindings{
+		CRTBs: []*v3.ClusterRoleTemplateBinding{crtb("nodes", "c-1", "nodes-view")},
+		PRTBs: []*v3.ProjectRoleTemplateBinding{prtb("view", "c-1:p-1", "view"), prtb("view", "c-2:p-2", "view")},
+	}
+
+	t.Run("project binding", func(t *testing.T) {
+		after := RoleTemplateBindings{
+			CRTBs: before.CRTBs,
+			PRTBs: []*v3.ProjectRoleTemplateBinding{prtb("edit", "c-1:p-1", "edit"), prtb("view", "c-2:p-2", "view")},
+		}
+		deltas, external, err := BindingsDelta(before, after, roleTemplates)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"external-view"}, external)
+		assert.Equal(t, []PermissionDelta{
+			{
+				ClusterName: "c-1",
+				ProjectName: "c-1:p-1",
+				Added:       []Permission{{Resource: "pods", Verb: "create"}, {Resource: "pods", Verb: "delete"}},
+			},
+		}, deltas)
+	})
+
+	t.Run("cluster binding covering project permissions", func(t *testing.T) {
+		after := RoleTemplateBindings{
+			CRTBs: []*v3.ClusterRoleTemplateBinding{crtb("owner", "c-2", "owner")},
+			PRTBs: before.PRTBs,
+		}
+		deltas, _, err := BindingsDelta(before, after, roleTemplates)
+		require.NoError(t, err)
+		assert.Equal(t, []PermissionDelta{
+			{
+				ClusterName: "c-1",
+				Removed:     []Permission{{Resource: "nodes", Verb: "get"}},
+			},
+			{
+				ClusterName: "c-2",
+				Added:       []Permission{{APIGroup: "*", Resource: "*", Verb: "*"}},
+			},
+		}, deltas)
+	})
+
+	t.Run("missing role template", func(t *testing.T) {
+		after := RoleTemplateBindings{
+			CRTBs: append([]*v3.ClusterRoleTemplateBinding{crtb("missing", "c-1", "missing")}, before.CRTBs...),
+			PRTBs: before.PRTBs,
+		}
+		deltas, _, err := BindingsDelta(before, after, roleTemplates)
+		require.NoError(t, err)
+		assert.Empty(t, deltas)
+	})
+}
@@ -0,0 +1,125 @@
+// Package removedapis scans clusters being upgraded to a Kubernetes minor version for the objects written through, and
+// the clients requesting, the APIs removed in the new version, which break once the cluster is upgraded.
+package removedapis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// API is a version of a Kubernetes resource removed in a minor version.
+type API struct {
+	schema.GroupVersionResource
+	Kind string
+	// RemovedIn is the minor version the API is removed in, such as 1.25.
+	RemovedIn string
+	// Replacement is the group version to migrate the objects to, empty if the resource is removed without
+	// replacement.
+	Replacement string
+}
+
+// ListVersion returns the group version resource the objects of the API are listed through, the replacement as the API
+// itself may no longer be served.
+func (a API) ListVersion() schema.GroupVersionResource {
+	if a.Replacement == "" {
+		return a.GroupVersionResource
+	}
+	gv, _ := schema.ParseGroupVersion(a.Replacement)
+	return gv.WithResource(a.Resource)
+}
+
+func api(group, version, resource, kind, removedIn, replacement string) API {
+	return API{
+		GroupVersionResource: schema.GroupVersionResource{Group: group, Version: version, Resource: resource},
+		Kind:                 kind,
+		RemovedIn:            removedIn,
+		Replacement:          replacement,
+	}
+}
+
+// apis are the persisted APIs removed since the oldest Kubernetes version clusters are upgraded from. The replacements
+// of APIs removed in successive versions are the version served by both the version upgraded from and to.
+var apis = []API{
+	api("admissionregistration.k8s.io", "v1beta1", "mutatingwebhookconfigurations", "MutatingWebhookConfiguration", "1.22", "admissionregistration.k8s.io/v1"),
+	api("admissionregistration.k8s.io", "v1beta1", "validatingwebhookconfigurations", "ValidatingWebhookConfiguration", "1.22", "admissionregistration.k8s.io/v1"),
+	api("apiextensions.k8s.io", "v1beta1", "customresourcedefinitions", "CustomResourceDefinition", "1.22", "apiextensions.k8s.io/v1"),
+	api("apiregistration.k8s.io", "v1beta1", "apiservices", "APIService", "1.22", "apiregistration.k8s.io/v1"),
+	api("certificates.k8s.io", "v1beta1", "certificatesigningrequests", "CertificateSigningRequest", "1.22", "certificates.k8s.io/v1"),
+	api("coordination.k8s.io", "v1beta1", "leases", "Lease", "1.22", "coordination.k8s.io/v1"),
+	api("extensions", "v1beta1", "ingresses", "Ingress", "1.22", "networking.k8s.io/v1"),
+	api("networking.k8s.io", "v1beta1", "ingresses", "Ingress", "1.22", "networking.k8s.io/v1"),
+	api("networking.k8s.io", "v1beta1", "ingressclasses", "IngressClass", "1.22", "networking.k8s.io/v1"),
+	api("rbac.authorization.k8s.io", "v1beta1", "clusterroles", "ClusterRole", "1.22", "rbac.authorization.k8s.io/v1"),
+	api("rbac.authorization.k8s.io", "v1beta1", "clusterrolebindings", "ClusterRoleBinding", "1.22", "rbac.authorization.k8s.io/v1"),
+	api("rbac.authorization.k8s.io", "v1beta1", "roles", "Role", "1.22", "rbac.authorization.k8s.io/v1"),
+	api("rbac.authorization.k8s.io", "v1beta1", "rolebindings", "RoleBinding", "1.22", "rbac.authorization.k8s.io/v1"),
+	api("scheduling.k8s.io", "v1beta1", "priorityclasses", "PriorityClass", "1.22", "scheduling.k8s.io/v1"),
+	api("storage.k8s.io", "v1beta1", "csidrivers", "CSIDriver", "1.22", "storage.k8s.io/v1"),
+	api("storage.k8s.io", "v1beta1", "csinodes", "CSINode", "1.22", "storage.k8s.io/v1"),
+	api("storage.k8s.io", "v1beta1", "storageclasses", "StorageClass", "1.22", "storage.k8s.io/v1"),
+	api("storage.k8s.io", "v1beta1", "volumeattachments", "VolumeAttachment", "1.22", "storage.k8s.io/v1"),
+	api("batch", "v1beta1", "cronjobs", "CronJob", "1.25", "batch/v1"),
+	api("discovery.k8s.io", "v1beta1", "endpointslices", "EndpointSlice", "1.25", "discovery.k8s.io/v1"),
+	api("autoscaling", "v2beta1", "horizontalpodautoscalers", "HorizontalPodAutoscaler", "1.25", "autoscaling/v2"),
+	api("policy", "v1beta1", "poddisruptionbudgets", "PodDisruptionBudget", "1.25", "policy/v1"),
+	api("policy", "v1beta1", "podsecuritypolicies", "PodSecurityPolicy", "1.25", ""),
+	api("node.k8s.io", "v1beta1", "runtimeclasses", "RuntimeClass", "1.25", "node.k8s.io/v1"),
+	api("autoscaling", "v2beta2", "horizontalpodautoscalers", "HorizontalPodAutoscaler", "1.26", "autoscaling/v2"),
+	api("flowcontrol.apiserver.k8s.io", "v1beta1", "flowschemas", "FlowSchema", "1.26", "flowcontrol.apiserver.k8s.io/v1beta2"),
+	api("flowcontrol.apiserver.k8s.io", "v1beta1", "prioritylevelconfigurations", "PriorityLevelConfiguration", "1.26", "flowcontrol.apiserver.k8s.io/v1beta2"),
+	api("storage.k8s.io", "v1beta1", "csistoragecapacities", "CSIStorageCapacity", "1.27", "storage.k8s.io/v1"),
+	api("flowcontrol.apiserver.k8s.io", "v1beta2", "flowschemas", "FlowSchema", "1.29", "flowcontrol.apiserver.k8s.io/v1beta3"),
+	api("flowcontrol.apiserver.k8s.io", "v1beta2", "prioritylevelconfigurations", "PriorityLevelConfiguration", "1.29", "flowcontrol.apiserver.k8s.io/v1beta3"),
+	api("flowcontrol.apiserver.k8s.io", "v1beta3", "flowschemas", "FlowSchema", "1.32", "flowcontrol.apiserver.k8s.io/v1"),
+	api("flowcontrol.apiserver.k8s.io", "v1beta3", "prioritylevelconfigurations", "PriorityLevelConfiguration", "1.32", "flowcontrol.apiserver.k8s.io/v1"),
+}
+
+// Between returns the APIs removed in the minor versions after the current version up to the target version, none if
+// the target version isn't a newer minor version.
+func Between(currentVersion, targetVersion string) ([]API, error) {
+	current, target, err := minors(currentVersion, targetVersion)
+	if err != nil {
+		return nil, err
+	}
+	var result []API
+	for _, api := range apis {
+		if removed, err := minor(api.RemovedIn); err == nil && removed > current && removed <= target {
+			result = append(result, api)
+		}
+	}
+	return result, nil
+}
+
+// MinorUpgrade returns true if the target version is a newer minor version than the current version.
+func MinorUpgrade(currentVersion, targetVersion string) bool {
+	current, target, err := minors(currentVersion, targetVersion)
+	return err == nil && target > current
+}
+
+func minors(currentVersion, targetVersion string) (int, int, error) {
+	current, err := minor(currentVersion)
+	if err != nil {
+		return 0, 0, err
+	}
+	target, err := minor(targetVersion)
+	if err != nil {
+		return 0, 0, err
+	}
+	return current, target, nil
+}
+
+// minor returns the minor version of a Kubernetes version such as v1.25.9+rke2r1 or 1.25.
+func minor(version string) (int, error) {
+	parts := strings.SplitN(strings.TrimPrefix(version, "v"), ".", 3)
+	if len(parts) < 2 || parts[0] != "1" {
+		return 0, fmt.Errorf("invalid kubernetes version %s", version)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid kubernetes version %s", version)
+	}
+	return minor, nil
+}
@@ -0,0 +1,86 @@
+package removedapis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		target  string
+		want    []string
+	}{
+		{
+			name:    "minor upgrade",
+			current: "v1.24.13+rke2r1",
+			target:  "v1.25.9+rke2r1",
+			want:    []string{"batch/v1beta1/cronjobs", "discovery.k8s.io/v1beta1/endpointslices", "autoscaling/v2beta1/horizontalpodautoscalers", "policy/v1beta1/poddisruptionbudgets", "policy/v1beta1/podsecuritypolicies", "node.k8s.io/v1beta1/runtimeclasses"},
+		},
+		{
+			name:    "minor upgrade without removed apis",
+			current: "v1.23.17+k3s1",
+			target:  "v1.24.13+k3s1",
+		},
+		{
+			name:    "patch upgrade",
+			current: "v1.25.8+rke2r1",
+			target:  "v1.25.9+rke2r1",
+		},
+		{
+			name:    "downgrade",
+			current: "v1.26.4+rke2r1",
+			target:  "v1.25.9+rke2r1",
+		},
+		{
+			name:    "minor versions without patch",
+			current: "1.26",
+			target:  "1.27",
+			want:    []string{"storage.k8s.io/v1beta1/csistoragecapacities"},
+		},
+		{
+			name:    "upgrade across minor versions",
+			current: "v1.27.6+k3s1",
+			target:  "v1.29.1+k3s1",
+			want:    []string{"flowcontrol.apiserver.k8s.io/v1beta2/flowschemas", "flowcontrol.apiserver.k8s.io/v1beta2/prioritylevelconfigurations"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apis, err := Between(tt.current, tt.target)
+			require.NoError(t, err)
+			var got []string
+			for _, api := range apis {
+				got = append(got, api.GroupVersion().String()+"/"+api.Resource)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBetweenInvalidVersion(t *testing.T) {
+	_, err := Between("v1.25.9+rke2r1", "latest")
+	assert.Error(t, err)
+	_, err = Between("v2.0.0", "v1.25.9")
+	assert.Error(t, err)
+}
+
+func TestMinorUpgrade(t *testing.T) {
+	assert.True(t, MinorUpgrade("v1.25.9+rke2r1", "v1.26.4+rke2r1"))
+	assert.True(t, MinorUpgrade("v1.24.13+k3s1", "v1.26.4+k3s1"))
+	assert.False(t, MinorUpgrade("v1.25.8+rke2r1", "v1.25.9+rke2r1"))
+	assert.False(t, MinorUpgrade("v1.26.4+rke2r1", "v1.25.9+rke2r1"))
+	assert.False(t, MinorUpgrade("", "v1.25.9+rke2r1"))
+}
+
+func TestListVersion(t *testing.T) {
+	cronJobs := api("batch", "v1beta1", "cronjobs", "CronJob", "1.25", "batch/v1")
+	assert.Equal(t, schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "cronjobs"}, cronJobs.ListVersion())
+
+	podSecurityPolicies := api("policy", "v1beta1", "podsecuritypolicies", "PodSecurityPolicy", "1.25", "")
+	assert.Equal(t, schema.GroupVersionResource{Group: "policy", Version: "v1beta1", Resource: "podsecuritypolicies"}, podSecurityPolicies.ListVersion())
+}
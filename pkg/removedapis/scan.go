@@ -0,0 +1,192 @@
+package removedapis
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// requestedMetric is the metric of the API server set for the deprecated APIs requested since it started.
+	requestedMetric = "apiserver_requested_deprecated_apis"
+	listLimit       = 500
+)
+
+// GateEnabled returns true if minor upgrades of the cluster are held while objects use APIs removed in the new version,
+// according to its removed API upgrade gate annotation or else the removed-api-upgrade-gate setting.
+func GateEnabled(cluster *rancherv1.Cluster, setting string) bool {
+	if value, ok := cluster.Annotations[rancherv1.RemovedAPIUpgradeGateAnnotation]; ok {
+		return value == "true"
+	}
+	return setting == "true"
+}
+
+// Scan returns the report of the scan of a cluster upgraded from the current to the target version, for the objects
+// last written through the APIs removed in the target version and the clients requesting them. The scan passes if no
+// object uses a removed API, as the requests are counted since the API server started and can't be told apart from the
+// requests of the clients since migrated.
+func Scan(ctx context.Context, restConfig *rest.Config, currentVersion, targetVersion string) (*rancherv1.UpgradeScanStatus, error) {
+	removed, err := Between(currentVersion, targetVersion)
+	if err != nil {
+		return nil, err
+	}
+	client, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	k8s, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &rancherv1.UpgradeScanStatus{
+		CurrentVersion: currentVersion,
+		TargetVersion:  targetVersion,
+		ScannedAt:      time.Now().UTC().Format(time.RFC3339),
+	}
+	for _, api := range removed {
+		objects, err := list(ctx, client, api)
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", api.ListVersion(), err)
+		}
+		report.Blocking = append(report.Blocking, Usages(api, objects)...)
+	}
+
+	metrics, err := k8s.Discovery().RESTClient().Get().AbsPath("/metrics").DoRaw(ctx)
+	if err != nil {
+		report.Message = fmt.Sprintf("failed to get the requests to the removed APIs from the metrics of the API server: %v", err)
+	} else if report.Requested, err = Requests(metrics, currentVersion, targetVersion); err != nil {
+		report.Message = err.Error()
+	}
+	report.Passed = len(report.Blocking) == 0
+	return report, nil
+}
+
+func list(ctx context.Context, client dynamic.Interface, api API) ([]unstructured.Unstructured, error) {
+	var (
+		result []unstructured.Unstructured
+		opts   = metav1.ListOptions{Limit: listLimit}
+	)
+	for {
+		objects, err := client.Resource(api.ListVersion()).List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, objects.Items...)
+		if objects.GetContinue() == "" {
+			return result, nil
+		}
+		opts.Continue = objects.GetContinue()
+	}
+}
+
+// Usages returns the objects of the API written through it according to their managed fields, all the objects if the
+// API is removed without replacement.
+func Usages(api API, objects []unstructured.Unstructured) []rancherv1.RemovedAPIUsage {
+	apiVersion := api.GroupVersion().String()
+	var result []rancherv1.RemovedAPIUsage
+	for _, obj := range objects {
+		var managers []string
+		for _, entry := range obj.GetManagedFields() {
+			if entry.APIVersion == apiVersion && entry.Manager != "" {
+				managers = append(managers, entry.Manager)
+			}
+		}
+		if len(managers) == 0 && api.Replacement != "" {
+			continue
+		}
+		result = append(result, rancherv1.RemovedAPIUsage{
+			APIVersion:  apiVersion,
+			Kind:        api.Kind,
+			Namespace:   obj.GetNamespace(),
+			Name:        obj.GetName(),
+			Managers:    managers,
+			RemovedIn:   api.RemovedIn,
+			Replacement: api.Replacement,
+		})
+	}
+	return result
+}
+
+// Requests returns the APIs removed after the current version up to the target version that were requested according
+// to the metrics of the API server.
+func Requests(metrics []byte, currentVersion, targetVersion string) ([]rancherv1.RemovedAPIRequest, error) {
+	current, target, err := minors(currentVersion, targetVersion)
+	if err != nil {
+		return nil, err
+	}
+	var result []rancherv1.RemovedAPIRequest
+	scanner := bufio.NewScanner(bytes.NewReader(metrics))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, requestedMetric+"{") {
+			continue
+		}
+		end := strings.LastIndex(line, "}")
+		if end < 0 || strings.TrimSpace(line[end+1:]) != "1" {
+			continue
+		}
+		labels := parseLabels(line[len(requestedMetric)+1 : end])
+		removed, err := minor(labels["removed_release"])
+		if err != nil || removed <= current || removed > target {
+			continue
+		}
+		resource := labels["resource"]
+		if labels["subresource"] != "" {
+			resource += "/" + labels["subresource"]
+		}
+		apiVersion := labels["version"]
+		if labels["group"] != "" {
+			apiVersion = labels["group"] + "/" + apiVersion
+		}
+		result = append(result, rancherv1.RemovedAPIRequest{
+			APIVersion: apiVersion,
+			Resource:   resource,
+			RemovedIn:  labels["removed_release"],
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read the metrics of the API server: %w", err)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].APIVersion != result[j].APIVersion {
+			return result[i].APIVersion < result[j].APIVersion
+		}
+		return result[i].Resource < result[j].Resource
+	})
+	return result, nil
+}
+
+// parseLabels returns the labels of a metric from the text between its braces, such as group="batch",version="v1beta1".
+func parseLabels(text string) map[string]string {
+	labels := map[string]string{}
+	for text != "" {
+		eq := strings.Index(text, `="`)
+		if eq < 0 {
+			break
+		}
+		key := strings.TrimLeft(text[:eq], ", ")
+		text = text[eq+2:]
+		end := strings.Index(text, `"`)
+		if end < 0 {
+			break
+		}
+		labels[key] = text[:end]
+		text = text[end+1:]
+	}
+	return labels
+}
@@ -0,0 +1,101 @@
+package removedapis
+
+import (
+	"testing"
+
+	rancherv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func object(namespace, name string, managedFields ...metav1.ManagedFieldsEntry) unstructured.Unstructured {
+	obj := unstructured.Unstructured{}
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetManagedFields(managedFields)
+	return obj
+}
+
+func TestGateEnabled(t *testing.T) {
+	cluster := &rancherv1.Cluster{}
+	assert.False(t, GateEnabled(cluster, "false"))
+	assert.True(t, GateEnabled(cluster, "true"))
+
+	cluster.Annotations = map[string]string{rancherv1.RemovedAPIUpgradeGateAnnotation: "false"}
+	assert.False(t, GateEnabled(cluster, "true"))
+	cluster.Annotations[rancherv1.RemovedAPIUpgradeGateAnnotation] = "true"
+	assert.True(t, GateEnabled(cluster, "false"))
+}
+
+func TestUsages(t *testing.T) {
+	cronJobs := api("batch", "v1beta1", "cronjobs", "CronJob", "1.25", "batch/v1")
+	objects := []unstructured.Unstructured{
+		object("default", "migrated", metav1.ManagedFieldsEntry{Manager: "helm", APIVersion: "batch/v1"}),
+		object("default", "legacy",
+			metav1.ManagedFieldsEntry{Manager: "kubectl-client-side-apply", APIVersion: "batch/v1beta1"},
+			metav1.ManagedFieldsEntry{Manager: "kube-controller-manager", APIVersion: "batch/v1"}),
+		object("default", "unmanaged"),
+	}
+
+	assert.Equal(t, []rancherv1.RemovedAPIUsage{
+		{
+			APIVersion:  "batch/v1beta1",
+			Kind:        "CronJob",
+			Namespace:   "default",
+			Name:        "legacy",
+			Managers:    []string{"kubectl-client-side-apply"},
+			RemovedIn:   "1.25",
+			Replacement: "batch/v1",
+		},
+	}, Usages(cronJobs, objects))
+}
+
+func TestUsagesWithoutReplacement(t *testing.T) {
+	podSecurityPolicies := api("policy", "v1beta1", "podsecuritypolicies", "PodSecurityPolicy", "1.25", "")
+	objects := []unstructured.Unstructured{
+		object("", "restricted", metav1.ManagedFieldsEntry{Manager: "helm", APIVersion: "policy/v1beta1"}),
+		object("", "unmanaged"),
+	}
+
+	usages := Usages(podSecurityPolicies, objects)
+	require.Len(t, usages, 2)
+	assert.Equal(t, "restricted", usages[0].Name)
+	assert.Equal(t, []string{"helm"}, usages[0].Managers)
+	assert.Equal(t, "unmanaged", usages[1].Name)
+	assert.Empty(t, usages[1].Replacement)
+}
+
+func TestRequests(t *testing.T) {
+	metrics := []byte(`# HELP apiserver_requested_deprecated_apis [STABLE] Gauge of deprecated APIs that have been requested, broken out by API group, version, resource, subresource, and removed_release.
+# TYPE apiserver_requested_deprecated_apis gauge
+apiserver_requested_deprecated_apis{group="policy",removed_release="1.25",resource="podsecuritypolicies",subresource="",version="v1beta1"} 1
+apiserver_requested_deprecated_apis{group="batch",removed_release="1.25",resource="cronjobs",subresource="status",version="v1beta1"} 1
+apiserver_requested_deprecated_apis{group="flowcontrol.apiserver.k8s.io",removed_release="1.26",resource="flowschemas",subresource="",version="v1beta1"} 1
+apiserver_requested_deprecated_apis{group="storage.k8s.io",removed_release="",resource="csistoragecapacities",subresource="",version="v1beta1"} 1
+apiserver_requested_deprecated_apis{group="autoscaling",removed_release="1.25",resource="horizontalpodautoscalers",subresource="",version="v2beta1"} 0
+apiserver_request_total{code="200",group="batch",resource="cronjobs",version="v1beta1"} 12
+`)
+
+	requests, err := Requests(metrics, "v1.24.13+rke2r1", "v1.25.9+rke2r1")
+	require.NoError(t, err)
+	assert.Equal(t, []rancherv1.RemovedAPIRequest{
+		{APIVersion: "batch/v1beta1", Resource: "cronjobs/status", RemovedIn: "1.25"},
+		{APIVersion: "policy/v1beta1", Resource: "podsecuritypolicies", RemovedIn: "1.25"},
+	}, requests)
+
+	requests, err = Requests(metrics, "v1.25.9+rke2r1", "v1.26.4+rke2r1")
+	require.NoError(t, err)
+	assert.Equal(t, []rancherv1.RemovedAPIRequest{
+		{APIVersion: "flowcontrol.apiserver.k8s.io/v1beta1", Resource: "flowschemas", RemovedIn: "1.26"},
+	}, requests)
+
+	_, err = Requests(metrics, "", "v1.26.4+rke2r1")
+	assert.Error(t, err)
+}
+
+func TestParseLabels(t *testing.T) {
+	assert.Equal(t, map[string]string{"group": "", "resource": "pods", "version": "v1"}, parseLabels(`group="",resource="pods",version="v1"`))
+	assert.Empty(t, parseLabels(""))
+}
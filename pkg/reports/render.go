@@ -0,0 +1,217 @@
+package reports
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+)
+
+// ContentTypes are the content types of the artifacts of each format.
+var ContentTypes = map[v3.ReportFormat]string{
+	v3.ReportFormatJSON: "application/json",
+	v3.ReportFormatCSV:  "text/csv",
+	v3.ReportFormatPDF:  "application/pdf",
+}
+
+// Table is a section of a report rendered as rows, used by the CSV and PDF formats.
+type Table struct {
+	Section v3.ReportSection
+	Title   string
+	Header  []string
+	Rows    [][]string
+}
+
+type clusterVersion struct {
+	ID                string `json:"id"`
+	Name              string `json:"name"`
+	Provider          string `json:"provider,omitempty"`
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+}
+
+type nodeCount struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Nodes int    `json:"nodes"`
+}
+
+type psactCompliance struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	PSACT     string `json:"psact,omitempty"`
+	Compliant bool   `json:"compliant"`
+}
+
+// Render renders the sections of the data in a format. All sections are rendered when sections is empty.
+func Render(data Data, sections []v3.ReportSection, format v3.ReportFormat) ([]byte, error) {
+	if len(sections) == 0 {
+		sections = AllSections
+	}
+	for _, section := range sections {
+		if !isSection(section) {
+			return nil, fmt.Errorf("unsupported report section %q", section)
+		}
+	}
+
+	switch format {
+	case v3.ReportFormatJSON, "":
+		return renderJSON(data, sections)
+	case v3.ReportFormatCSV:
+		return renderCSV(data, sections)
+	case v3.ReportFormatPDF:
+		return renderPDF(data, sections)
+	}
+	return nil, fmt.Errorf("unsupported report format %q", format)
+}
+
+// RenderText renders the sections of the data as plain text, used as the body of report emails.
+func RenderText(data Data, sections []v3.ReportSection) string {
+	if len(sections) == 0 {
+		sections = AllSections
+	}
+	return string(bytes.Join(textLines(data, sections), []byte("\n")))
+}
+
+// Tables returns the table of each section of the data.
+func Tables(data Data, sections []v3.ReportSection) []Table {
+	var tables []Table
+	for _, section := range sections {
+		table := Table{Section: section}
+		switch section {
+		case v3.ReportSectionClusterVersions:
+			table.Title = "Cluster versions"
+			table.Header = []string{"Cluster ID", "Cluster", "Provider", "Kubernetes version"}
+			for _, c := range data.Clusters {
+				table.Rows = append(table.Rows, []string{c.ID, c.Name, c.Provider, c.KubernetesVersion})
+			}
+		case v3.ReportSectionNodeCounts:
+			table.Title = "Node counts"
+			table.Header = []string{"Cluster ID", "Cluster", "Nodes"}
+			for _, c := range data.Clusters {
+				table.Rows = append(table.Rows, []string{c.ID, c.Name, strconv.Itoa(c.Nodes)})
+			}
+		case v3.ReportSectionExpiringCertificates:
+			table.Title = "Expiring certificates"
+			table.Header = []string{"Cluster ID", "Cluster", "Certificate", "Expiration date"}
+			for _, c := range data.ExpiringCertificates {
+				table.Rows = append(table.Rows, []string{c.ClusterID, c.ClusterName, c.Name, c.ExpirationDate})
+			}
+		case v3.ReportSectionAdminUsers:
+			table.Title = "Users with admin"
+			table.Header = []string{"User ID", "Username", "Display name"}
+			for _, u := range data.AdminUsers {
+				table.Rows = append(table.Rows, []string{u.ID, u.Username, u.DisplayName})
+			}
+		case v3.ReportSectionPSACTCompliance:
+			table.Title = "Pod security admission compliance"
+			table.Header = []string{"Cluster ID", "Cluster", "PSACT", "Compliant"}
+			for _, c := range data.Clusters {
+				table.Rows = append(table.Rows, []string{c.ID, c.Name, c.PSACT, strconv.FormatBool(c.PSACTCompliant())})
+			}
+		default:
+			continue
+		}
+		tables = append(tables, table)
+	}
+	return tables
+}
+
+func isSection(section v3.ReportSection) bool {
+	for _, s := range AllSections {
+		if s == section {
+			return true
+		}
+	}
+	return false
+}
+
+func renderJSON(data Data, sections []v3.ReportSection) ([]byte, error) {
+	result := map[string]interface{}{
+		"generated": data.Generated.Format(time.RFC3339),
+	}
+	for _, section := range sections {
+		switch section {
+		case v3.ReportSectionClusterVersions:
+			versions := []clusterVersion{}
+			for _, c := range data.Clusters {
+				versions = append(versions, clusterVersion{ID: c.ID, Name: c.Name, Provider: c.Provider, KubernetesVersion: c.KubernetesVersion})
+			}
+			result[string(section)] = versions
+		case v3.ReportSectionNodeCounts:
+			counts := []nodeCount{}
+			for _, c := range data.Clusters {
+				counts = append(counts, nodeCount{ID: c.ID, Name: c.Name, Nodes: c.Nodes})
+			}
+			result[string(section)] = counts
+		case v3.ReportSectionExpiringCertificates:
+			result[string(section)] = append([]Certificate{}, data.ExpiringCertificates...)
+		case v3.ReportSectionAdminUsers:
+			result[string(section)] = append([]User{}, data.AdminUsers...)
+		case v3.ReportSectionPSACTCompliance:
+			compliance := []psactCompliance{}
+			for _, c := range data.Clusters {
+				compliance = append(compliance, psactCompliance{ID: c.ID, Name: c.Name, PSACT: c.PSACT, Compliant: c.PSACTCompliant()})
+			}
+			result[string(section)] = compliance
+		}
+	}
+	return json.MarshalIndent(result, "", "  ")
+}
+
+// renderCSV renders every section as a block of rows prefixed by the section name, with a header row per section.
+func renderCSV(data Data, sections []v3.ReportSection) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	for _, table := range Tables(data, sections) {
+		if err := w.Write(append([]string{"section"}, table.Header...)); err != nil {
+			return nil, err
+		}
+		for _, row := range table.Rows {
+			if err := w.Write(append([]string{string(table.Section)}, row...)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+func renderPDF(data Data, sections []v3.ReportSection) ([]byte, error) {
+	var lines []string
+	for _, line := range textLines(data, sections) {
+		lines = append(lines, string(line))
+	}
+	return newPDF(lines), nil
+}
+
+func textLines(data Data, sections []v3.ReportSection) [][]byte {
+	lines := [][]byte{[]byte("Rancher report generated " + data.Generated.Format(time.RFC3339))}
+	for _, table := range Tables(data, sections) {
+		lines = append(lines, nil, []byte(table.Title))
+		if len(table.Rows) == 0 {
+			lines = append(lines, []byte("  none"))
+			continue
+		}
+		widths := make([]int, len(table.Header))
+		for _, row := range append([][]string{table.Header}, table.Rows...) {
+			for i, cell := range row {
+				if len(cell) > widths[i] {
+					widths[i] = len(cell)
+				}
+			}
+		}
+		for _, row := range append([][]string{table.Header}, table.Rows...) {
+			var line bytes.Buffer
+			line.WriteString(" ")
+			for i, cell := range row {
+				fmt.Fprintf(&line, " %-*s", widths[i], cell)
+			}
+			lines = append(lines, bytes.TrimRight(line.Bytes(), " "))
+		}
+	}
+	return lines
+}
@@ -0,0 +1,151 @@
+// Package reports collects the inventory and compliance data of the clusters managed by rancher, and renders it as the
+// artifacts of a Report.
+package reports
+
+import (
+	"sort"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/namespace"
+)
+
+const (
+	// ArtifactNamespace is the namespace of the secrets storing the artifacts of reports.
+	ArtifactNamespace = namespace.System
+	// ReportLabel is the label of the secrets of the artifacts of a report, set to the name of the report.
+	ReportLabel = "management.cattle.io/report"
+	// FormatAnnotation is the annotation of the secret of an artifact with its format.
+	FormatAnnotation = "management.cattle.io/report-format"
+	// ArtifactDataKey is the key of the content of an artifact in its secret.
+	ArtifactDataKey = "report"
+
+	// DefaultCertificateExpirationDays is the number of days before their expiration certificates are reported when
+	// the report doesn't set it.
+	DefaultCertificateExpirationDays = 30
+
+	adminGlobalRole = "admin"
+)
+
+// AllSections are the sections of a report that doesn't list any.
+var AllSections = []v3.ReportSection{
+	v3.ReportSectionClusterVersions,
+	v3.ReportSectionNodeCounts,
+	v3.ReportSectionExpiringCertificates,
+	v3.ReportSectionAdminUsers,
+	v3.ReportSectionPSACTCompliance,
+}
+
+// Data is the data of a report, rendered in every format of the report.
+type Data struct {
+	Generated            time.Time     `json:"generated"`
+	Clusters             []Cluster     `json:"clusters,omitempty"`
+	ExpiringCertificates []Certificate `json:"expiringCertificates,omitempty"`
+	AdminUsers           []User        `json:"adminUsers,omitempty"`
+}
+
+type Cluster struct {
+	ID                string `json:"id"`
+	Name              string `json:"name"`
+	Provider          string `json:"provider,omitempty"`
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	Nodes             int    `json:"nodes"`
+	PSACT             string `json:"psact,omitempty"`
+}
+
+// PSACTCompliant returns true if the cluster has a default pod security admission configuration template.
+func (c Cluster) PSACTCompliant() bool {
+	return c.PSACT != ""
+}
+
+type Certificate struct {
+	ClusterID      string `json:"clusterId"`
+	ClusterName    string `json:"clusterName"`
+	Name           string `json:"name"`
+	ExpirationDate string `json:"expirationDate"`
+}
+
+type User struct {
+	ID          string `json:"id"`
+	Username    string `json:"username,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// Collect builds the data of a report from the clusters, global role bindings and users of rancher. Certificates of the
+// clusters expiring before expirationDays days from now are reported, including expired certificates.
+func Collect(now time.Time, clusters []*v3.Cluster, bindings []*v3.GlobalRoleBinding, users []*v3.User, expirationDays int) Data {
+	if expirationDays <= 0 {
+		expirationDays = DefaultCertificateExpirationDays
+	}
+	expirationLimit := now.AddDate(0, 0, expirationDays)
+
+	data := Data{Generated: now.UTC()}
+	for _, cluster := range clusters {
+		if cluster.DeletionTimestamp != nil {
+			continue
+		}
+
+		info := Cluster{
+			ID:       cluster.Name,
+			Name:     cluster.Spec.DisplayName,
+			Provider: cluster.Status.Provider,
+			Nodes:    cluster.Status.NodeCount,
+			PSACT:    cluster.Spec.DefaultPodSecurityAdmissionConfigurationTemplateName,
+		}
+		if cluster.Status.Version != nil {
+			info.KubernetesVersion = cluster.Status.Version.GitVersion
+		}
+		data.Clusters = append(data.Clusters, info)
+
+		for name, expiration := range cluster.Status.CertificatesExpiration {
+			date, err := time.Parse(time.RFC3339, expiration.ExpirationDate)
+			if err != nil || date.After(expirationLimit) {
+				continue
+			}
+			data.ExpiringCertificates = append(data.ExpiringCertificates, Certificate{
+				ClusterID:      cluster.Name,
+				ClusterName:    cluster.Spec.DisplayName,
+				Name:           name,
+				ExpirationDate: expiration.ExpirationDate,
+			})
+		}
+	}
+
+	usersByID := map[string]*v3.User{}
+	for _, user := range users {
+		usersByID[user.Name] = user
+	}
+	admins := map[string]bool{}
+	for _, binding := range bindings {
+		if binding.GlobalRoleName != adminGlobalRole || binding.UserName == "" || admins[binding.UserName] {
+			continue
+		}
+		admins[binding.UserName] = true
+
+		user := User{ID: binding.UserName}
+		if u, ok := usersByID[binding.UserName]; ok {
+			user.Username = u.Username
+			user.DisplayName = u.DisplayName
+		}
+		data.AdminUsers = append(data.AdminUsers, user)
+	}
+
+	sort.Slice(data.Clusters, func(i, j int) bool {
+		return data.Clusters[i].ID < data.Clusters[j].ID
+	})
+	sort.Slice(data.ExpiringCertificates, func(i, j int) bool {
+		a, b := data.ExpiringCertificates[i], data.ExpiringCertificates[j]
+		if a.ExpirationDate != b.ExpirationDate {
+			return a.ExpirationDate < b.ExpirationDate
+		}
+		if a.ClusterID != b.ClusterID {
+			return a.ClusterID < b.ClusterID
+		}
+		return a.Name < b.Name
+	})
+	sort.Slice(data.AdminUsers, func(i, j int) bool {
+		return data.AdminUsers[i].ID < data.AdminUsers[j].ID
+	})
+
+	return data
+}
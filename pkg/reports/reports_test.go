@@ -0,0 +1,126 @@
+package reports
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	v3 "github.com/rancher/rancher/pkg/apis/management.cattle.io/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+var now = time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func testData() Data {
+	clusters := []*v3.Cluster{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "local"},
+			Spec: v3.ClusterSpec{
+				DisplayName: "local",
+			},
+			Status: v3.ClusterStatus{
+				Provider:  "rke2",
+				NodeCount: 3,
+				Version:   &version.Info{GitVersion: "v1.24.8+rke2r1"},
+				CertificatesExpiration: map[string]v3.CertExpiration{
+					"kube-apiserver": {ExpirationDate: "2023-03-10T00:00:00Z"},
+					"kube-proxy":     {ExpirationDate: "2024-03-10T00:00:00Z"},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "c-abcde"},
+			Spec: v3.ClusterSpec{
+				DisplayName: "prod",
+				ClusterSpecBase: v3.ClusterSpecBase{
+					DefaultPodSecurityAdmissionConfigurationTemplateName: "rancher-restricted",
+				},
+			},
+			Status: v3.ClusterStatus{
+				Provider:  "k3s",
+				NodeCount: 1,
+				CertificatesExpiration: map[string]v3.CertExpiration{
+					"kube-etcd": {ExpirationDate: "2023-02-01T00:00:00Z"},
+				},
+			},
+		},
+	}
+	bindings := []*v3.GlobalRoleBinding{
+		{UserName: "u-admin", GlobalRoleName: "admin"},
+		{UserName: "u-admin", GlobalRoleName: "admin"},
+		{UserName: "u-other", GlobalRoleName: "user"},
+		{GroupPrincipalName: "local://g-admins", GlobalRoleName: "admin"},
+	}
+	users := []*v3.User{
+		{ObjectMeta: metav1.ObjectMeta{Name: "u-admin"}, Username: "admin", DisplayName: "Default Admin"},
+	}
+	return Collect(now, clusters, bindings, users, 0)
+}
+
+func TestCollect(t *testing.T) {
+	data := testData()
+
+	assert.Equal(t, now, data.Generated)
+	assert.Equal(t, []Cluster{
+		{ID: "c-abcde", Name: "prod", Provider: "k3s", Nodes: 1, PSACT: "rancher-restricted"},
+		{ID: "local", Name: "local", Provider: "rke2", KubernetesVersion: "v1.24.8+rke2r1", Nodes: 3},
+	}, data.Clusters)
+	assert.Equal(t, []Certificate{
+		{ClusterID: "c-abcde", ClusterName: "prod", Name: "kube-etcd", ExpirationDate: "2023-02-01T00:00:00Z"},
+		{ClusterID: "local", ClusterName: "local", Name: "kube-apiserver", ExpirationDate: "2023-03-10T00:00:00Z"},
+	}, data.ExpiringCertificates)
+	assert.Equal(t, []User{{ID: "u-admin", Username: "admin", DisplayName: "Default Admin"}}, data.AdminUsers)
+}
+
+func TestRenderJSON(t *testing.T) {
+	content, err := Render(testData(), []v3.ReportSection{v3.ReportSectionNodeCounts, v3.ReportSectionPSACTCompliance}, v3.ReportFormatJSON)
+	require.NoError(t, err)
+
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal(content, &result))
+	assert.Equal(t, "2023-03-01T12:00:00Z", result["generated"])
+	assert.Len(t, result["nodeCounts"], 2)
+	assert.Len(t, result["psactCompliance"], 2)
+	assert.NotContains(t, result, "adminUsers")
+}
+
+func TestRenderCSV(t *testing.T) {
+	content, err := Render(testData(), []v3.ReportSection{v3.ReportSectionClusterVersions, v3.ReportSectionAdminUsers}, v3.ReportFormatCSV)
+	require.NoError(t, err)
+	assert.Equal(t, `section,Cluster ID,Cluster,Provider,Kubernetes version
+clusterVersions,c-abcde,prod,k3s,
+clusterVersions,local,local,rke2,v1.24.8+rke2r1
+section,User ID,Username,Display name
+adminUsers,u-admin,admin,Default Admin
+`, string(content))
+}
+
+func TestRenderPDF(t *testing.T) {
+	content, err := Render(testData(), nil, v3.ReportFormatPDF)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(content, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(content, []byte("%%EOF\n")))
+	assert.Contains(t, string(content), "(Users with admin) '")
+}
+
+func TestRenderErrors(t *testing.T) {
+	_, err := Render(testData(), nil, "xml")
+	assert.Error(t, err)
+
+	_, err = Render(testData(), []v3.ReportSection{"unknown"}, v3.ReportFormatJSON)
+	assert.Error(t, err)
+}
+
+func TestNewPDFPages(t *testing.T) {
+	lines := make([]string, 150)
+	for i := range lines {
+		lines[i] = "line (with parens) \\ and a ü"
+	}
+	content := string(newPDF(lines))
+	assert.Contains(t, content, "/Count 3")
+	assert.Contains(t, content, `(line \(with parens\) \\ and a ?) '`)
+}
@@ -8,6 +8,7 @@
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"net/http"
 	"os"
 	"regexp"
@@ -19,6 +20,7 @@
 	"github.com/rancher/rancher/pkg/rkeworker"
 	"github.com/rancher/rke/types"
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 const (
@@ -67,6 +69,8 @@ func ConfigClient(ctx context.Context, url string, header http.Header, writeCert
 	nodeOrClusterNotFoundRetryLimit := 3
 	interval := 120
 	requestedRenewedCert := false
+	// retry with jitter so that agents don't all hit rancher at once when it comes back from an outage
+	backoff := RetryBackoff()
 	for {
 		nc, err := getConfig(client, url, header)
 		if err != nil {
@@ -80,7 +84,7 @@ func ConfigClient(ctx context.Context, url string, header http.Header, writeCert
 			}
 
 			logrus.Warnf("Error while getting agent config: %v", err)
-			time.Sleep(5 * time.Second)
+			time.Sleep(backoff.Step())
 			continue
 		}
 
@@ -151,6 +155,18 @@ func ConfigClient(ctx context.Context, url string, header http.Header, writeCert
 	}
 }
 
+// RetryBackoff returns the backoff of the agent retrying requests to rancher while it is unreachable, starting at 5
+// seconds and capped at 2 minutes, with each delay jittered by up to half of it.
+func RetryBackoff() wait.Backoff {
+	return wait.Backoff{
+		Duration: 5 * time.Second,
+		Factor:   2,
+		Jitter:   0.5,
+		Steps:    math.MaxInt32,
+		Cap:      2 * time.Minute,
+	}
+}
+
 func getConfig(client *http.Client, url string, header http.Header) (*rkeworker.NodeConfig, error) {
 	req, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
@@ -101,7 +101,18 @@ func credTypes(schemas *types.Schemas) *types.Schemas {
 			&m.AnnotationField{Field: "name"},
 			&m.AnnotationField{Field: "description"},
 			&m.Drop{Field: "namespaceId"}).
-		MustImport(&Version, v3.CloudCredential{})
+		MustImport(&Version, v3.CloudCredentialDependentsOutput{}).
+		MustImport(&Version, v3.CloudCredentialRotateInput{}).
+		MustImport(&Version, v3.CloudCredentialRotateOutput{}).
+		MustImportAndCustomize(&Version, v3.CloudCredential{}, func(schema *types.Schema) {
+			schema.ResourceActions[v3.CloudCredentialActionDependents] = types.Action{
+				Output: "cloudCredentialDependentsOutput",
+			}
+			schema.ResourceActions[v3.CloudCredentialActionRotate] = types.Action{
+				Input:  "cloudCredentialRotateInput",
+				Output: "cloudCredentialRotateOutput",
+			}
+		})
 }
 
 func mgmtSecretTypes(schemas *types.Schemas) *types.Schemas {
@@ -0,0 +1,63 @@
+package securityevents
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rancher/rancher/pkg/version"
+)
+
+var names = map[Type]string{
+	LoginFailed:        "Login failed",
+	TokenCreated:       "Token created",
+	RoleEscalation:     "Role escalation",
+	KubeconfigDownload: "Kubeconfig downloaded",
+	EventsDropped:      "Security events dropped",
+}
+
+var (
+	headerEscaper    = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ")
+	extensionEscaper = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`)
+)
+
+// FormatCEF formats the event in the ArcSight Common Event Format.
+func FormatCEF(event Event) string {
+	name := names[event.Type]
+	if name == "" {
+		name = string(event.Type)
+	}
+
+	var extension []string
+	add := func(key, value string) {
+		if value != "" {
+			extension = append(extension, key+"="+extensionEscaper.Replace(value))
+		}
+	}
+	add("rt", fmt.Sprint(event.Time.UnixMilli()))
+	add("outcome", string(event.Outcome))
+	add("suser", event.User)
+	add("src", event.SourceIP)
+	add("requestClientApplication", event.UserAgent)
+	if event.Resource != "" {
+		add("cs1Label", "resource")
+		add("cs1", event.Resource)
+	}
+	if len(event.Details) > 0 {
+		var details []string
+		for key, value := range event.Details {
+			details = append(details, key+":"+value)
+		}
+		sort.Strings(details)
+		add("cs2Label", "details")
+		add("cs2", strings.Join(details, ";"))
+	}
+	add("msg", event.Message)
+
+	return fmt.Sprintf("CEF:0|Rancher|Rancher|%s|%s|%s|%d|%s",
+		headerEscaper.Replace(version.Version),
+		headerEscaper.Replace(string(event.Type)),
+		headerEscaper.Replace(name),
+		event.Severity,
+		strings.Join(extension, " "))
+}
@@ -0,0 +1,46 @@
+package securityevents
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatCEF(t *testing.T) {
+	event := Event{
+		Time:     time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC),
+		Type:     LoginFailed,
+		Severity: 5,
+		Outcome:  OutcomeFailure,
+		User:     "admin",
+		SourceIP: "10.0.0.1",
+		Resource: "localProviders/local",
+		Message:  "bad password=secret\nagain",
+		Details:  map[string]string{"provider": "local", "b": "c|d"},
+	}
+
+	assert.Regexp(t, `^CEF:0\|Rancher\|Rancher\|[^|]*\|LoginFailed\|Login failed\|5\|`, FormatCEF(event))
+	assert.Contains(t, FormatCEF(event), `rt=1677672000000 outcome=failure suser=admin src=10.0.0.1 cs1Label=resource cs1=localProviders/local cs2Label=details cs2=b:c|d;provider:local msg=bad password\=secret\nagain`)
+}
+
+func TestEncode(t *testing.T) {
+	events := []Event{{Type: TokenCreated}, {Type: RoleEscalation}}
+
+	body, contentType, err := encode(events, FormatJSON)
+	require.NoError(t, err)
+	assert.Equal(t, "application/json", contentType)
+	var decoded []Event
+	require.NoError(t, json.Unmarshal(body, &decoded))
+	assert.Len(t, decoded, 2)
+
+	body, contentType, err = encode(events, FormatCEF)
+	require.NoError(t, err)
+	assert.Equal(t, "text/plain", contentType)
+	assert.Regexp(t, `^CEF:0\|.*\|TokenCreated\|.*\nCEF:0\|.*\|RoleEscalation\|.*\n$`, string(body))
+
+	_, _, err = encode(events, "xml")
+	assert.Error(t, err)
+}
@@ -0,0 +1,82 @@
+// Package securityevents emits normalized security events, such as failed logins or role escalations, to an external
+// SIEM. Events are queued and delivered in batches by a Pipeline, so that emitting an event never blocks the request or
+// the controller that emits it.
+package securityevents
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/rancher/rancher/pkg/auth/util"
+)
+
+// Type is the type of a security event.
+type Type string
+
+const (
+	LoginFailed        Type = "LoginFailed"
+	TokenCreated       Type = "TokenCreated"
+	RoleEscalation     Type = "RoleEscalation"
+	KubeconfigDownload Type = "KubeconfigDownload"
+	// BreakGlassKubeconfigRetrieval is emitted when the escrowed break glass kubeconfig of a cluster is retrieved.
+	BreakGlassKubeconfigRetrieval Type = "BreakGlassKubeconfigRetrieval"
+	// PipelineKubeconfigMinted is emitted when a kubeconfig scoped to a namespace of a cluster is minted for a CI
+	// pipeline.
+	PipelineKubeconfigMinted Type = "PipelineKubeconfigMinted"
+	// EventsDropped is emitted by the pipeline once it delivers events again after dropping events because its queue
+	// was full.
+	EventsDropped Type = "EventsDropped"
+)
+
+// Outcome is the outcome of the action of a security event.
+type Outcome string
+
+const (
+	OutcomeSuccess Outcome = "success"
+	OutcomeFailure Outcome = "failure"
+)
+
+// severities are the CEF severities, from 0 to 10, of the types of events.
+var severities = map[Type]int{
+	LoginFailed:                   5,
+	TokenCreated:                  3,
+	RoleEscalation:                8,
+	KubeconfigDownload:            4,
+	EventsDropped:                 6,
+	BreakGlassKubeconfigRetrieval: 9,
+	PipelineKubeconfigMinted:      4,
+}
+
+// Event is a normalized security event.
+type Event struct {
+	Time      time.Time         `json:"time"`
+	Type      Type              `json:"type"`
+	Severity  int               `json:"severity"`
+	Outcome   Outcome           `json:"outcome"`
+	User      string            `json:"user,omitempty"`
+	SourceIP  string            `json:"sourceIp,omitempty"`
+	UserAgent string            `json:"userAgent,omitempty"`
+	Resource  string            `json:"resource,omitempty"`
+	Message   string            `json:"message,omitempty"`
+	Details   map[string]string `json:"details,omitempty"`
+}
+
+// NewEvent returns an event of the type for the request, which may be nil for events emitted by controllers.
+func NewEvent(eventType Type, outcome Outcome, req *http.Request) Event {
+	event := Event{
+		Time:     time.Now().UTC(),
+		Type:     eventType,
+		Severity: severities[eventType],
+		Outcome:  outcome,
+	}
+	if req != nil {
+		event.SourceIP = util.SourceIP(req)
+		event.UserAgent = req.UserAgent()
+	}
+	return event
+}
+
+// Emit queues the event in the default pipeline.
+func Emit(event Event) {
+	Default.Emit(event)
+}
@@ -0,0 +1,160 @@
+package securityevents
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultCapacity   = 10000
+	defaultBatchSize  = 100
+	defaultMinBackoff = time.Second
+	defaultMaxBackoff = time.Minute
+)
+
+// Sink delivers batches of events to a SIEM.
+type Sink interface {
+	// Enabled returns false if events should not be queued.
+	Enabled() bool
+	Send(ctx context.Context, events []Event) error
+}
+
+var (
+	defaultSink = &WebhookSink{}
+	// Default is the pipeline of the events emitted by rancher, delivered to the webhook of the security-events
+	// settings.
+	Default = NewPipeline(defaultSink)
+)
+
+// Start delivers the events of the default pipeline until the context is done.
+func Start(ctx context.Context, secretLister v1.SecretLister) {
+	defaultSink.secretLister = secretLister
+	Default.Start(ctx)
+}
+
+// Pipeline queues events and delivers them in order to its sink. A batch is retried with an exponential backoff until
+// it is delivered, meanwhile events are queued up to the capacity of the pipeline and further events are dropped
+// rather than blocking their emitters. The number of dropped events is delivered as an EventsDropped event.
+type Pipeline struct {
+	sink       Sink
+	capacity   int
+	batchSize  int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+
+	lock    sync.Mutex
+	queue   []Event
+	dropped int
+	notify  chan struct{}
+}
+
+func NewPipeline(sink Sink) *Pipeline {
+	return &Pipeline{
+		sink:       sink,
+		capacity:   defaultCapacity,
+		batchSize:  defaultBatchSize,
+		minBackoff: defaultMinBackoff,
+		maxBackoff: defaultMaxBackoff,
+		notify:     make(chan struct{}, 1),
+	}
+}
+
+// Emit queues the event, it never blocks.
+func (p *Pipeline) Emit(event Event) {
+	if !p.sink.Enabled() {
+		return
+	}
+
+	p.lock.Lock()
+	if len(p.queue) >= p.capacity {
+		p.dropped++
+		p.lock.Unlock()
+		return
+	}
+	p.queue = append(p.queue, event)
+	p.lock.Unlock()
+
+	select {
+	case p.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Start delivers the queued events until the context is done.
+func (p *Pipeline) Start(ctx context.Context) {
+	go p.run(ctx)
+}
+
+func (p *Pipeline) run(ctx context.Context) {
+	for {
+		batch, queued := p.next()
+		if len(batch) == 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-p.notify:
+			}
+			continue
+		}
+
+		if err := p.deliver(ctx, batch); err != nil {
+			return
+		}
+		p.ack(queued)
+	}
+}
+
+// next returns the next batch of events, without removing them from the queue, and the number of queued events in the
+// batch. Dropped events are reported at the beginning of the batch.
+func (p *Pipeline) next() ([]Event, int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var batch []Event
+	if p.dropped > 0 {
+		event := NewEvent(EventsDropped, OutcomeFailure, nil)
+		event.Message = fmt.Sprintf("%d security events were dropped because the delivery queue was full", p.dropped)
+		event.Details = map[string]string{"count": fmt.Sprint(p.dropped)}
+		batch = append(batch, event)
+		p.dropped = 0
+	}
+
+	queued := len(p.queue)
+	if queued > p.batchSize-len(batch) {
+		queued = p.batchSize - len(batch)
+	}
+	return append(batch, p.queue[:queued]...), queued
+}
+
+func (p *Pipeline) ack(queued int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.queue = append(p.queue[:0:0], p.queue[queued:]...)
+}
+
+// deliver sends the batch until it is delivered, it only returns an error if the context is done.
+func (p *Pipeline) deliver(ctx context.Context, batch []Event) error {
+	backoff := p.minBackoff
+	for {
+		err := p.sink.Send(ctx, batch)
+		if err == nil {
+			return nil
+		}
+		logrus.Warnf("[security-events] Failed to deliver %d events, retrying in %s: %v", len(batch), backoff, err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > p.maxBackoff {
+			backoff = p.maxBackoff
+		}
+	}
+}
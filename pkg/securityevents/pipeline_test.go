@@ -0,0 +1,93 @@
+package securityevents
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSink struct {
+	lock     sync.Mutex
+	failures int
+	batches  [][]Event
+}
+
+func (f *fakeSink) Enabled() bool {
+	return true
+}
+
+func (f *fakeSink) Send(_ context.Context, events []Event) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("unavailable")
+	}
+	f.batches = append(f.batches, append([]Event{}, events...))
+	return nil
+}
+
+func (f *fakeSink) delivered() []Event {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	var events []Event
+	for _, batch := range f.batches {
+		events = append(events, batch...)
+	}
+	return events
+}
+
+func newTestPipeline(sink Sink) *Pipeline {
+	p := NewPipeline(sink)
+	p.capacity = 3
+	p.batchSize = 2
+	p.minBackoff = time.Millisecond
+	p.maxBackoff = time.Millisecond
+	return p
+}
+
+func TestPipelineRetriesAndDrops(t *testing.T) {
+	sink := &fakeSink{failures: 3}
+	p := newTestPipeline(sink)
+
+	for _, user := range []string{"a", "b", "c", "d", "e"} {
+		p.Emit(Event{Type: LoginFailed, User: user})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p.Start(ctx)
+
+	assert.Eventually(t, func() bool {
+		return len(sink.delivered()) == 4
+	}, 5*time.Second, 10*time.Millisecond)
+
+	events := sink.delivered()
+	assert.Equal(t, EventsDropped, events[0].Type)
+	assert.Equal(t, "2", events[0].Details["count"])
+	assert.Equal(t, []string{"a", "b", "c"}, []string{events[1].User, events[2].User, events[3].User})
+	assert.Len(t, sink.batches[0], 2, "batches are limited to the batch size including the dropped event")
+}
+
+func TestPipelineDeliversLaterEvents(t *testing.T) {
+	sink := &fakeSink{}
+	p := newTestPipeline(sink)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p.Start(ctx)
+
+	p.Emit(Event{Type: TokenCreated, User: "a"})
+	assert.Eventually(t, func() bool {
+		return len(sink.delivered()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	p.Emit(Event{Type: TokenCreated, User: "b"})
+	assert.Eventually(t, func() bool {
+		return len(sink.delivered()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,106 @@
+package securityevents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	v1 "github.com/rancher/rancher/pkg/generated/norman/core/v1"
+	"github.com/rancher/rancher/pkg/namespace"
+	"github.com/rancher/rancher/pkg/settings"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	FormatJSON = "json"
+	FormatCEF  = "cef"
+
+	// webhookSecretName is the name of the optional secret holding the Authorization header of webhook requests, in
+	// its authorization key.
+	webhookSecretName = "security-events-webhook"
+	authorizationKey  = "authorization"
+	webhookTimeout    = 30 * time.Second
+)
+
+// WebhookSink posts batches of events to the URL of the security-events-webhook-url setting, as a JSON array or as
+// newline separated CEF records depending on the security-events-format setting.
+type WebhookSink struct {
+	secretLister v1.SecretLister
+	client       http.Client
+}
+
+// Enabled returns true if a webhook URL is set.
+func (w *WebhookSink) Enabled() bool {
+	return settings.SecurityEventsWebhookURL.Get() != ""
+}
+
+func (w *WebhookSink) Send(ctx context.Context, events []Event) error {
+	url := settings.SecurityEventsWebhookURL.Get()
+	if url == "" {
+		// events queued before the webhook was unset are discarded
+		return nil
+	}
+
+	body, contentType, err := encode(events, settings.SecurityEventsFormat.Get())
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	authorization, err := w.authorization()
+	if err != nil {
+		return err
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (w *WebhookSink) authorization() (string, error) {
+	if w.secretLister == nil {
+		return "", nil
+	}
+	secret, err := w.secretLister.Get(namespace.System, webhookSecretName)
+	if apierrors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return string(secret.Data[authorizationKey]), nil
+}
+
+func encode(events []Event, format string) ([]byte, string, error) {
+	switch format {
+	case FormatCEF:
+		var records []string
+		for _, event := range events {
+			records = append(records, FormatCEF(event))
+		}
+		return []byte(strings.Join(records, "\n") + "\n"), "text/plain", nil
+	case FormatJSON, "":
+		body, err := json.Marshal(events)
+		return body, "application/json", err
+	}
+	return nil, "", fmt.Errorf("unsupported security events format %s", format)
+}
@@ -60,6 +60,7 @@
 	CLIURLDarwin                        = NewSetting("cli-url-darwin", "https://releases.rancher.com/cli/v1.0.0-alpha8/rancher-darwin-amd64-v1.0.0-alpha8.tar.gz")
 	CLIURLLinux                         = NewSetting("cli-url-linux", "https://releases.rancher.com/cli/v1.0.0-alpha8/rancher-linux-amd64-v1.0.0-alpha8.tar.gz")
 	CLIURLWindows                       = NewSetting("cli-url-windows", "https://releases.rancher.com/cli/v1.0.0-alpha8/rancher-windows-386-v1.0.0-alpha8.zip")
+	CanarySoakPeriod                    = NewSetting("canary-soak-period", "1h")
 	ClusterControllerStartCount         = NewSetting("cluster-controller-start-count", "50")
 	EngineInstallURL                    = NewSetting("engine-install-url", "https://releases.rancher.com/install-docker/23.0.sh")
 	EngineISOURL                        = NewSetting("engine-iso-url", "https://releases.rancher.com/os/latest/rancheros-vmware.iso")
@@ -144,6 +145,217 @@
 	// AuthUserSessionTTLMinutes represents the time to live for tokens used for login sessions in minutes.
 	AuthUserSessionTTLMinutes = NewSetting("auth-user-session-ttl-minutes", "960") // 16 hours
 
+	// DisableInactiveTokensAfterDays is the number of days after which tokens that didn't authenticate any request are
+	// disabled.
+	DisableInactiveTokensAfterDays = NewSetting("disable-inactive-tokens-after-days", "0") // never disable
+
+	// MFARequired requires every local user to authenticate with a second factor, users without one can only enroll it.
+	MFARequired = NewSetting("mfa-required", "false")
+
+	// MFARequiredGlobalRoles is a comma separated list of global roles whose local users are required to authenticate
+	// with a second factor.
+	MFARequiredGlobalRoles = NewSetting("mfa-required-global-roles", "")
+
+	// SecurityEventsWebhookURL is the URL security events are posted to, security events are not emitted if it is empty.
+	SecurityEventsWebhookURL = NewSetting("security-events-webhook-url", "")
+
+	// SecurityEventsFormat is the format of the security events posted to the webhook, json or cef.
+	SecurityEventsFormat = NewSetting("security-events-format", "json")
+
+	// TrustedProxies is a comma separated list of the IPs and CIDRs of the proxies and load balancers in front of
+	// rancher. The X-Forwarded-For header is only used to determine the source IP of the requests they forward, which
+	// is otherwise the remote address of the request.
+	TrustedProxies = NewSetting("trusted-proxies", "")
+
+	// ClusterAgentDisconnectToleranceSeconds is the number of seconds the agent of a cluster can be disconnected before
+	// the cluster is reported as disconnected, so that short outages don't churn the state of clusters.
+	ClusterAgentDisconnectToleranceSeconds = NewSetting("cluster-agent-disconnect-tolerance-seconds", "45")
+
+	// ClusterCircuitBreakerFailureThreshold is the number of times in a row a cluster can fail to be reached before its
+	// circuit breaker opens, stopping its controllers and health checks until it is probed again.
+	ClusterCircuitBreakerFailureThreshold = NewSetting("cluster-circuit-breaker-failure-threshold", "5")
+
+	// ClusterCircuitBreakerMaxProbeIntervalSeconds is the longest interval unreachable clusters are probed at, the
+	// interval doubling from 30 seconds with each failed probe.
+	ClusterCircuitBreakerMaxProbeIntervalSeconds = NewSetting("cluster-circuit-breaker-max-probe-interval-seconds", "1800")
+
+	// PlanEncryptionKeyProvider is the provider of the keys wrapping the keys plan secrets are encrypted with, kms to
+	// wrap them with an AWS KMS key. Clusters can't encrypt their plan secrets until the provider is configured.
+	PlanEncryptionKeyProvider = NewSetting("plan-encryption-key-provider", "kms")
+
+	// PlanEncryptionKMSKeyID is the ID or ARN of the AWS KMS key used by the kms plan encryption key provider.
+	PlanEncryptionKMSKeyID = NewSetting("plan-encryption-kms-key-id", "")
+
+	// PlanOutputRedactionPatterns is a JSON list of regular expressions whose matches are redacted from the output of
+	// plan instructions, on top of the tokens, private keys and passwords always redacted.
+	PlanOutputRedactionPatterns = NewSetting("plan-output-redaction-patterns", "[]")
+
+	// ProxyStreamSessionsPerUser is the number of exec, attach and port-forward sessions a user can have open through
+	// the cluster proxy of a rancher replica, 0 for no limit.
+	ProxyStreamSessionsPerUser = NewSetting("proxy-stream-sessions-per-user", "20")
+
+	// ProxyStreamSessionsPerCluster is the number of exec, attach and port-forward sessions that can be open to a
+	// cluster through the cluster proxy of a rancher replica, 0 for no limit.
+	ProxyStreamSessionsPerCluster = NewSetting("proxy-stream-sessions-per-cluster", "200")
+
+	// ProxyStreamIdleTimeoutSeconds is the number of seconds after which exec, attach and port-forward sessions with
+	// no traffic are closed by the cluster proxy, 0 to never close idle sessions.
+	ProxyStreamIdleTimeoutSeconds = NewSetting("proxy-stream-idle-timeout-seconds", "1800")
+
+	// ProxyStreamBandwidthBytesPerSecond caps the bytes per second exec, attach and port-forward sessions can transfer
+	// in each direction through the cluster proxy, 0 for no cap.
+	ProxyStreamBandwidthBytesPerSecond = NewSetting("proxy-stream-bandwidth-bytes-per-second", "0")
+
+	// BreakGlassKubeconfigEscrow enables escrowing an emergency admin kubeconfig for provisioned clusters, connecting
+	// to their control plane nodes directly so that it can be used when rancher is down.
+	BreakGlassKubeconfigEscrow = NewSetting("break-glass-kubeconfig-escrow", "false")
+
+	// BreakGlassKubeconfigValidityDays is how many days the client certificates of break glass kubeconfigs are valid,
+	// they're reissued once two thirds of it elapsed. Clusters may sign certificates for less.
+	BreakGlassKubeconfigValidityDays = NewSetting("break-glass-kubeconfig-validity-days", "365")
+
+	// BreakGlassVaultAddress is the address of a HashiCorp Vault the break glass kubeconfigs are also written to, with
+	// the token in the token field of the cattle-system/break-glass-vault-token secret. Empty to not write them to vault.
+	BreakGlassVaultAddress = NewSetting("break-glass-vault-address", "")
+
+	// BreakGlassVaultMount is the mount path of the KV version 2 secrets engine break glass kubeconfigs are written to.
+	BreakGlassVaultMount = NewSetting("break-glass-vault-mount", "secret")
+
+	// BreakGlassVaultPath is the path under the mount break glass kubeconfigs are written to.
+	BreakGlassVaultPath = NewSetting("break-glass-vault-path", "rancher/break-glass")
+
+	// MachineProvisionConcurrency is the number of machines of node driver clusters rancher creates at the same time,
+	// the creation of the other machines is queued. 0 for no limit.
+	MachineProvisionConcurrency = NewSetting("machine-provision-concurrency", "0")
+
+	// MachineProvisionConcurrencyPerCloudCredential is the number of machines rancher creates at the same time with a
+	// cloud credential, so that creating many clusters at once doesn't exceed the rate limits of the cloud API. 0 for
+	// no limit.
+	MachineProvisionConcurrencyPerCloudCredential = NewSetting("machine-provision-concurrency-per-cloud-credential", "0")
+
+	// OrphanedCloudResourcesPolicy is what rancher does with the cloud resources left behind by failed machine
+	// provisioning: report to list them in the status of their cluster, delete to also delete them, or disabled.
+	OrphanedCloudResourcesPolicy = NewSetting("orphaned-cloud-resources-policy", "report")
+
+	// OrphanedCloudResourcesScanIntervalMinutes is how often the cloud resources of the machines of each cluster are
+	// scanned for orphaned resources.
+	OrphanedCloudResourcesScanIntervalMinutes = NewSetting("orphaned-cloud-resources-scan-interval-minutes", "60")
+
+	// MeteringEnabled enables recording the node, core and requested CPU usage of clusters and projects in metering
+	// records, exported from the metering endpoint and as prometheus metrics for chargeback.
+	MeteringEnabled = NewSetting("metering-enabled", "false")
+
+	// MeteringAggregation is the period usage is aggregated over in metering records, hourly or daily.
+	MeteringAggregation = NewSetting("metering-aggregation", "daily")
+
+	// MeteringRetentionDays is how many days metering records are kept.
+	MeteringRetentionDays = NewSetting("metering-retention-days", "90")
+
+	// MeteringTeamLabel is the label of clusters and projects naming the team their usage is accounted to, the label
+	// of a project takes precedence over the label of its cluster.
+	MeteringTeamLabel = NewSetting("metering-team-label", "team")
+
+	// EventStreamRetentionHours is how many hours management events are kept to be delivered and replayed to event
+	// subscriptions.
+	EventStreamRetentionHours = NewSetting("event-stream-retention-hours", "24")
+
+	// ClusterArchiveEnabled enables archiving removed clusters, keeping a record of the cluster once its infrastructure
+	// and objects are removed. The management.cattle.io/archive-on-removal annotation of a cluster overrides it.
+	ClusterArchiveEnabled = NewSetting("cluster-archive-enabled", "false")
+
+	// ClusterArchiveRetentionDays is how many days the archives of removed clusters are kept, 0 to keep them until
+	// they're deleted.
+	ClusterArchiveRetentionDays = NewSetting("cluster-archive-retention-days", "0")
+
+	// ControllerHandoffDelaySeconds is how many seconds a replica must be healthy before it campaigns for the
+	// leadership running the controllers, so that the controllers of a new version only take over from the replicas of
+	// the previous version once the new replica serves its API.
+	ControllerHandoffDelaySeconds = NewSetting("controller-handoff-delay-seconds", "30")
+
+	// RemovedAPIUpgradeGate holds the Kubernetes minor upgrades of RKE2 and K3s clusters while objects of the cluster
+	// use APIs removed in the new version. The provisioning.cattle.io/removed-api-upgrade-gate annotation of a cluster
+	// overrides it.
+	RemovedAPIUpgradeGate = NewSetting("removed-api-upgrade-gate", "false")
+
+	// PlannerBehaviorDefaults overrides the default of the planner behaviors of RKE2 and K3s clusters, which are
+	// disabled by default, as a comma separated list of name=true or name=false, such as custom-probes=true. The
+	// plannerBehaviors of a cluster override it, so that newer behaviors can be enabled on test clusters first.
+	PlannerBehaviorDefaults = NewSetting("planner-behavior-defaults", "")
+
+	// AdditionalServerURLs is a comma separated list of URLs rancher is also reachable at, such as the URLs of other
+	// regions or load balancers. Agents fail over to them, in order, when the server-url is unreachable, and switch back
+	// once it is healthy again.
+	AdditionalServerURLs = NewSetting("additional-server-urls", "")
+
+	// KubernetesCompatibilityRules is a JSON list of known bad combinations of Kubernetes versions with CNIs, machine OS,
+	// OS images and containerd versions, which clusters are rejected for on top of the built-in ones.
+	KubernetesCompatibilityRules = NewSetting("kubernetes-compatibility-rules", "[]")
+
+	// NodeOSAdvisoryFeedURL is the URL of the advisory feed listing end-of-life OS releases and kernel CVEs, which the
+	// OS releases and kernels of the nodes of all clusters are checked against. Nodes aren't checked if empty.
+	NodeOSAdvisoryFeedURL = NewSetting("node-os-advisory-feed-url", "")
+
+	// NodeOSAdvisoryCVEs is a comma separated list of the IDs of the CVEs of the advisory feed the kernels of the nodes
+	// are checked against, all CVEs of the feed if empty.
+	NodeOSAdvisoryCVEs = NewSetting("node-os-advisory-cves", "")
+
+	// ClusterBundleSigningKeys are the PEM encoded public keys trusted to sign cluster bundles. Clusters are only
+	// instantiated from the cluster bundles signed by one of them.
+	ClusterBundleSigningKeys = NewSetting("cluster-bundle-signing-keys", "")
+
+	// SteveReadCacheTTLSeconds is how long the lists of settings, clusters and projects served by Steve are cached per
+	// user and query, unless they change meanwhile. 0 disables the cache.
+	SteveReadCacheTTLSeconds = NewSetting("steve-read-cache-ttl-seconds", "5")
+
+	// LogLevels is a JSON object setting the log levels of subsystems, such as {"planner": "debug"}. The subsystems not
+	// listed log at the global level.
+	LogLevels = NewSetting("log-levels", "{}")
+
+	// LogFormat is the format of the logs, one of simple, text or json. When empty, the format of the --log-format flag
+	// is used.
+	LogFormat = NewSetting("log-format", "")
+
+	// TracingOTLPEndpoint is the host:port of the OTLP gRPC collector the spans of the provisioning controllers and of
+	// the API proxy are exported to. Tracing is disabled when it's empty. Changes are applied when Rancher restarts.
+	TracingOTLPEndpoint = NewSetting("tracing-otlp-endpoint", "")
+
+	// TracingOTLPInsecure disables TLS to the OTLP collector.
+	TracingOTLPInsecure = NewSetting("tracing-otlp-insecure", "false")
+
+	// TracingSampleRatio is the ratio of API requests traced, between 0 and 1. The work done on clusters by the
+	// provisioning controllers is always traced when tracing is enabled.
+	TracingSampleRatio = NewSetting("tracing-sample-ratio", "0.1")
+
+	// AdmissionRejectionRetryLimit is the number of consecutive times the objects generated for a cluster are applied
+	// while admission webhooks or policies reject them, with an increasing delay, before waiting for the cluster to
+	// change. 0 retries without limit.
+	AdmissionRejectionRetryLimit = NewSetting("admission-rejection-retry-limit", "10")
+
+	// PipelineKubeconfigClusterRole is the cluster role bound in its namespace to the service accounts backing the
+	// kubeconfigs minted for CI pipelines.
+	PipelineKubeconfigClusterRole = NewSetting("pipeline-kubeconfig-cluster-role", "edit")
+
+	// PipelineKubeconfigDefaultTTLSeconds is the lifetime of the kubeconfigs minted for CI pipelines when none is
+	// requested.
+	PipelineKubeconfigDefaultTTLSeconds = NewSetting("pipeline-kubeconfig-default-ttl-seconds", "3600")
+
+	// PipelineKubeconfigMaxTTLSeconds is the longest lifetime of the kubeconfigs minted for CI pipelines.
+	PipelineKubeconfigMaxTTLSeconds = NewSetting("pipeline-kubeconfig-max-ttl-seconds", "86400")
+
+	// MachineConfigLiveValidation enables checking the machine configs of the machine pools of clusters against their
+	// cloud, such as whether the instance type of an Amazonec2Config is offered in its region, when the clusters are
+	// created or their pools changed.
+	MachineConfigLiveValidation = NewSetting("machine-config-live-validation", "false")
+
+	// HarvesterLiveMigrationCordon enables cordoning the nodes of the machines of Harvester clusters while their VMs are
+	// live migrated, and uncordoning them once the migration completes.
+	HarvesterLiveMigrationCordon = NewSetting("harvester-live-migration-cordon", "false")
+
+	// ManagementGCPolicy is what rancher does periodically with the orphaned objects of the management cluster, such as
+	// the plan secrets of removed machines and the tokens of deleted users: report to log them, delete to delete them,
+	// or disabled. They can also be listed and deleted on demand through /v1/managementgc.
+	ManagementGCPolicy = NewSetting("management-gc-policy", "disabled")
+
 	// ConfigMapName name of the configmap that stores rancher configuration information.
 	ConfigMapName = NewSetting("config-map-name", "rancher-config")
 
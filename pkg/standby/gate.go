@@ -0,0 +1,125 @@
+package standby
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/discovery"
+)
+
+// checkInterval is how often the health of the replica is checked by the gate.
+const checkInterval = 5 * time.Second
+
+// Check checks the health of the replica, returning an error if it's unhealthy.
+type Check func(ctx context.Context) error
+
+// Gate passes once a replica has been healthy for a duration and its version can run the controllers.
+type Gate struct {
+	// Checks are the checks of the health of the replica, all of them passing for it to be healthy.
+	Checks []Check
+	// Duration returns how long the replica must be healthy without interruption for the gate to pass.
+	Duration func() time.Duration
+	// CanLead returns an error if the replica can't run the controllers yet, such as while a newer version runs them.
+	CanLead Check
+	// Bypass, if set, returns true if the replica runs the controllers without passing the gate, such as while rancher
+	// is installed and the API isn't served until the controllers add the initial data.
+	Bypass func(ctx context.Context) bool
+	// Interval is how often the checks are run, checkInterval if zero.
+	Interval time.Duration
+}
+
+// Wait blocks until the gate passes, returning an error only if the context is done first.
+func (g *Gate) Wait(ctx context.Context) error {
+	interval := g.Interval
+	if interval == 0 {
+		interval = checkInterval
+	}
+
+	var healthySince time.Time
+	for {
+		if g.Bypass != nil && g.Bypass(ctx) {
+			logrus.Infof("[standby] Running the controllers without waiting for the replica to be ready")
+			return nil
+		}
+		err := g.check(ctx)
+		now := time.Now()
+		switch {
+		case err != nil:
+			if !healthySince.IsZero() {
+				logrus.Infof("[standby] Replica is no longer ready to run the controllers: %v", err)
+			} else {
+				logrus.Debugf("[standby] Replica is not ready to run the controllers: %v", err)
+			}
+			healthySince = time.Time{}
+		case healthySince.IsZero():
+			healthySince = now
+			fallthrough
+		default:
+			if now.Sub(healthySince) >= g.duration() {
+				logrus.Infof("[standby] Replica is ready to run the controllers")
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (g *Gate) duration() time.Duration {
+	if g.Duration == nil {
+		return 0
+	}
+	return g.Duration()
+}
+
+func (g *Gate) check(ctx context.Context) error {
+	for _, check := range g.Checks {
+		if err := check(ctx); err != nil {
+			return err
+		}
+	}
+	if g.CanLead != nil {
+		return g.CanLead(ctx)
+	}
+	return nil
+}
+
+// Ping returns a check of the replica answering pong at the ping endpoint of url, the HTTP listener of the replica.
+func Ping(url string) Check {
+	client := &http.Client{Timeout: checkInterval}
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(url, "/")+"/ping", nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("replica is not serving requests: %w", err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64))
+		if resp.StatusCode != http.StatusOK || string(body) != "pong" {
+			return fmt.Errorf("replica answered ping with status %d", resp.StatusCode)
+		}
+		return nil
+	}
+}
+
+// APIServer returns a check of the kubernetes API server of the local cluster being reachable.
+func APIServer(client discovery.ServerVersionInterface) Check {
+	return func(_ context.Context) error {
+		if _, err := client.ServerVersion(); err != nil {
+			return fmt.Errorf("kubernetes API server is not reachable: %w", err)
+		}
+		return nil
+	}
+}
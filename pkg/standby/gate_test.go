@@ -0,0 +1,65 @@
+package standby
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGateWait(t *testing.T) {
+	var results []error
+	check := func(_ context.Context) error {
+		if len(results) == 0 {
+			return nil
+		}
+		err := results[0]
+		results = results[1:]
+		return err
+	}
+	checks := 0
+	counted := func(_ context.Context) error {
+		checks++
+		return nil
+	}
+	gate := &Gate{
+		Checks:   []Check{check, counted},
+		Duration: func() time.Duration { return 20 * time.Millisecond },
+		Interval: 10 * time.Millisecond,
+	}
+
+	// an unhealthy check restarts the duration the replica must be healthy for
+	results = []error{nil, errors.New("unhealthy"), nil}
+	assert.NoError(t, gate.Wait(context.Background()))
+	assert.GreaterOrEqual(t, checks, 4)
+
+	gate.CanLead = func(_ context.Context) error { return errors.New("newer version") }
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, gate.Wait(ctx), context.DeadlineExceeded)
+
+	gate.Bypass = func(_ context.Context) bool { return true }
+	assert.NoError(t, gate.Wait(context.Background()))
+}
+
+func TestPing(t *testing.T) {
+	answer := "pong"
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/ping" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		rw.Write([]byte(answer))
+	}))
+	defer server.Close()
+
+	assert.NoError(t, Ping(server.URL)(context.Background()))
+	answer = "unexpected"
+	assert.Error(t, Ping(server.URL)(context.Background()))
+	server.Close()
+	assert.Error(t, Ping(server.URL)(context.Background()))
+}
@@ -0,0 +1,83 @@
+// Package standby runs rancher replicas in standby, serving the UI, the read requests of the APIs and the proxied
+// requests to clusters without running the controllers, and gates the replicas running the controllers on their
+// health and version, so that rancher can be upgraded by rolling its replicas without downtime.
+package standby
+
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/rancher/rancher/pkg/auth/util"
+)
+
+// retryAfterSeconds is the Retry-After of the mutating requests refused by standby replicas.
+const retryAfterSeconds = "30"
+
+// allowedPrefixes are the paths whose mutating requests are served by standby replicas, as they don't change the
+// management resources or change resources of the downstream clusters: the logins, the connections of the agents, the
+// admission webhooks and the proxied requests to clusters. The mutating requests of any other path are refused.
+var allowedPrefixes = []string{
+	"/v1-public/",
+	"/v3-public/",
+	"/v1-saml/",
+	"/v1-admission/",
+	"/k8s/clusters/",
+	"/v3/connect",
+}
+
+// localClusterPrefix is the path of the proxied requests to the local cluster, which holds the management resources.
+// Its mutating requests are refused by standby replicas, although those to other clusters are served.
+const localClusterPrefix = "/k8s/clusters/local"
+
+// allowedActions are the actions served by standby replicas, as they don't change the management resources.
+var allowedActions = map[string]string{
+	"/v3/tokens":     "logout",
+	"/v3/principals": "search",
+}
+
+// IsReadOnly returns true if a standby replica serves the request.
+func IsReadOnly(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	p := req.URL.Path
+	if p != "" {
+		p = path.Clean(p)
+	}
+	if hasPathPrefix(p, localClusterPrefix) {
+		return false
+	}
+	for _, prefix := range allowedPrefixes {
+		if hasPathPrefix(p, prefix) {
+			return true
+		}
+	}
+	if action, ok := allowedActions[strings.TrimSuffix(p, "/")]; ok && req.URL.Query().Get("action") == action {
+		return true
+	}
+	return false
+}
+
+// hasPathPrefix returns whether a path is the prefix or under it. Prefixes ending with a slash are matched as is.
+func hasPathPrefix(p, prefix string) bool {
+	if strings.HasSuffix(prefix, "/") {
+		return strings.HasPrefix(p, prefix)
+	}
+	return p == prefix || strings.HasPrefix(p, prefix+"/")
+}
+
+// ReadOnly refuses the requests changing the management resources with 503 Service Unavailable, so that clients retry
+// them on a replica running the controllers.
+func ReadOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if IsReadOnly(req) {
+			next.ServeHTTP(rw, req)
+			return
+		}
+		rw.Header().Set("Retry-After", retryAfterSeconds)
+		util.ReturnHTTPError(rw, req, http.StatusServiceUnavailable, "rancher replica is in standby and only serves read requests")
+	})
+}
@@ -0,0 +1,61 @@
+package standby
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsReadOnly(t *testing.T) {
+	tests := []struct {
+		method   string
+		url      string
+		expected bool
+	}{
+		{method: http.MethodGet, url: "/v3/clusters", expected: true},
+		{method: http.MethodGet, url: "/dashboard/", expected: true},
+		{method: http.MethodPost, url: "/v3/clusters", expected: false},
+		{method: http.MethodPut, url: "/v1/management.cattle.io.settings/server-url", expected: false},
+		{method: http.MethodDelete, url: "/apis/management.cattle.io/v3/users/u-abcde", expected: false},
+		{method: http.MethodPatch, url: "/api/v1/namespaces/default", expected: false},
+		{method: http.MethodPost, url: "/v3-public/localProviders/local?action=login", expected: true},
+		{method: http.MethodPost, url: "/v1-public/login", expected: true},
+		{method: http.MethodPost, url: "/v3/tokens?action=logout", expected: true},
+		{method: http.MethodPost, url: "/v3/tokens", expected: false},
+		{method: http.MethodPost, url: "/v3/principals?action=search", expected: true},
+		{method: http.MethodPost, url: "/k8s/clusters/c-m-abcde/v1/apps.deployments", expected: true},
+		{method: http.MethodGet, url: "/k8s/clusters/local/v1/management.cattle.io.settings", expected: true},
+		{method: http.MethodPut, url: "/k8s/clusters/local/v1/management.cattle.io.settings/server-url", expected: false},
+		{method: http.MethodPost, url: "/k8s/clusters/local", expected: false},
+		{method: http.MethodDelete, url: "/k8s/clusters/c-m-abcde/../local/apis/management.cattle.io/v3/users/u-abcde", expected: false},
+		{method: http.MethodPost, url: "/k8s/clusters/local-abcde/v1/apps.deployments", expected: true},
+		{method: http.MethodPost, url: "/v3/import/token_c-abcde/validate", expected: false},
+		{method: http.MethodPost, url: "/dashboard/", expected: false},
+		{method: http.MethodPost, url: "/v3/connect/register", expected: true},
+		{method: http.MethodPost, url: "/v3/connectors", expected: false},
+		{method: http.MethodPost, url: "/v1-admission/provisioningquota", expected: true},
+		{method: http.MethodPost, url: "/v1-saml/adfs/saml/acs", expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.url, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsReadOnly(httptest.NewRequest(tt.method, tt.url, nil)))
+		})
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	handler := ReadOnly(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v3/clusters", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v3/clusters", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, retryAfterSeconds, rec.Header().Get("Retry-After"))
+}
@@ -0,0 +1,117 @@
+package standby
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/rancher/rancher/pkg/namespace"
+	corecontrollers "github.com/rancher/wrangler/pkg/generated/controllers/core/v1"
+	"github.com/rancher/wrangler/pkg/ticker"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// VersionConfigMapName is the name of the configmap in the cattle-system namespace recording the version of the
+	// replica running the controllers.
+	VersionConfigMapName = "cattle-controllers-version"
+
+	versionKey   = "version"
+	renewTimeKey = "renewTime"
+
+	// renewInterval is how often the replica running the controllers renews the record of its version.
+	renewInterval = time.Minute
+	// versionTTL is how long the record of the version is honored without being renewed, after which replicas of
+	// older versions run the controllers again, for example once rancher is rolled back.
+	versionTTL = 3 * renewInterval
+)
+
+// Versions records the version of the replica running the controllers, so that the replicas of an older version don't
+// take the controllers back from a newer version during a rolling upgrade.
+type Versions struct {
+	ConfigMaps corecontrollers.ConfigMapClient
+	// Version is the version of this replica.
+	Version string
+}
+
+// CanLead is a Check returning an error while a newer version than the one of this replica runs the controllers.
+func (v *Versions) CanLead(_ context.Context) error {
+	configMap, err := v.ConfigMaps.Get(namespace.System, VersionConfigMapName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return canLead(v.Version, configMap, time.Now())
+}
+
+// Record records the version of this replica as the one running the controllers, renewing the record until ctx is
+// done. It is meant to be run when the replica becomes the leader.
+func (v *Versions) Record(ctx context.Context) error {
+	if err := v.record(); err != nil {
+		return err
+	}
+	go func() {
+		for range ticker.Context(ctx, renewInterval) {
+			if err := v.record(); err != nil {
+				logrus.Errorf("[standby] Failed to renew the record of the version running the controllers: %v", err)
+			}
+		}
+	}()
+	return nil
+}
+
+func (v *Versions) record() error {
+	data := map[string]string{
+		versionKey:   v.Version,
+		renewTimeKey: time.Now().UTC().Format(time.RFC3339),
+	}
+	configMap, err := v.ConfigMaps.Get(namespace.System, VersionConfigMapName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = v.ConfigMaps.Create(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      VersionConfigMapName,
+				Namespace: namespace.System,
+			},
+			Data: data,
+		})
+		return err
+	} else if err != nil {
+		return err
+	}
+	configMap = configMap.DeepCopy()
+	configMap.Data = data
+	_, err = v.ConfigMaps.Update(configMap)
+	return err
+}
+
+// canLead returns an error if the configmap records a newer version than version, renewed within the version TTL.
+// Versions that aren't semantic versions, such as development builds, are never older than another version.
+func canLead(version string, configMap *corev1.ConfigMap, now time.Time) error {
+	recorded := configMap.Data[versionKey]
+	renewTime, err := time.Parse(time.RFC3339, configMap.Data[renewTimeKey])
+	if err != nil || now.Sub(renewTime) > versionTTL {
+		return nil
+	}
+	if Older(version, recorded) {
+		return fmt.Errorf("controllers are run by the newer version %s", recorded)
+	}
+	return nil
+}
+
+// Older returns true if version and other are both semantic versions, and version is older than other.
+func Older(version, other string) bool {
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return false
+	}
+	o, err := semver.NewVersion(other)
+	if err != nil {
+		return false
+	}
+	return v.LessThan(o)
+}
@@ -0,0 +1,35 @@
+package standby
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestOlder(t *testing.T) {
+	assert.True(t, Older("v2.7.1", "v2.7.2"))
+	assert.True(t, Older("v2.7.2-rc1", "v2.7.2"))
+	assert.False(t, Older("v2.7.2", "v2.7.2"))
+	assert.False(t, Older("v2.8.0", "v2.7.2"))
+	assert.False(t, Older("dev", "v2.7.2"))
+	assert.False(t, Older("v2.7.1", "dev"))
+}
+
+func TestCanLead(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	record := func(version string, renewed time.Time) *corev1.ConfigMap {
+		return &corev1.ConfigMap{Data: map[string]string{
+			versionKey:   version,
+			renewTimeKey: renewed.Format(time.RFC3339),
+		}}
+	}
+
+	assert.NoError(t, canLead("v2.7.2", record("v2.7.2", now), now))
+	assert.NoError(t, canLead("v2.7.3", record("v2.7.2", now), now))
+	assert.EqualError(t, canLead("v2.7.1", record("v2.7.2", now.Add(-time.Minute)), now), "controllers are run by the newer version v2.7.2")
+	// the newer version no longer runs the controllers, for example once rancher is rolled back
+	assert.NoError(t, canLead("v2.7.1", record("v2.7.2", now.Add(-versionTTL-time.Second)), now))
+	assert.NoError(t, canLead("v2.7.1", &corev1.ConfigMap{}, now))
+}
@@ -40,6 +40,7 @@ type context struct {
 	URL                   string
 	Namespace             string
 	URLPlain              string
+	AdditionalURLs        string
 	IsWindowsCluster      bool
 	IsRKE                 bool
 	PrivateRegistryConfig string
@@ -133,6 +134,7 @@ func SystemTemplate(resp io.Writer, agentImage, authImage, namespace, token, url
 		URL:                   base64.StdEncoding.EncodeToString([]byte(url)),
 		Namespace:             base64.StdEncoding.EncodeToString([]byte(namespace)),
 		URLPlain:              url,
+		AdditionalURLs:        strings.Join(AdditionalServerURLs(), ","),
 		IsWindowsCluster:      isWindowsCluster,
 		IsRKE:                 cluster != nil && cluster.Status.Driver == apimgmtv3.ClusterDriverRKE,
 		PrivateRegistryConfig: registryConfig,
@@ -167,6 +169,17 @@ func ForCluster(cluster *apimgmtv3.Cluster, token string, taints []corev1.Taint,
 	return buf.Bytes(), err
 }
 
+// AdditionalServerURLs returns the URLs of the additional-server-urls setting, agents fail over to in order.
+func AdditionalServerURLs() []string {
+	var urls []string
+	for _, u := range strings.Split(settings.AdditionalServerURLs.Get(), ",") {
+		if u = strings.TrimSuffix(strings.TrimSpace(u), "/"); u != "" {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
 func InternalCAChecksum() string {
 	ca := settings.InternalCACerts.Get()
 	if ca != "" {
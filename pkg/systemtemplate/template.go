@@ -164,6 +164,10 @@
             value: "{{.IsRKE}}"
           - name: CATTLE_SERVER
             value: "{{.URLPlain}}"
+          {{- if .AdditionalURLs }}
+          - name: CATTLE_ADDITIONAL_SERVERS
+            value: "{{.AdditionalURLs}}"
+          {{- end }}
           - name: CATTLE_CA_CHECKSUM
             value: "{{.CAChecksum}}"
           - name: CATTLE_CLUSTER
@@ -236,6 +240,10 @@
               fieldPath: spec.nodeName
         - name: CATTLE_SERVER
           value: "{{.URLPlain}}"
+        {{- if .AdditionalURLs }}
+        - name: CATTLE_ADDITIONAL_SERVERS
+          value: "{{.AdditionalURLs}}"
+        {{- end }}
         - name: CATTLE_CA_CHECKSUM
           value: "{{.CAChecksum}}"
         - name: CATTLE_CLUSTER
@@ -336,6 +344,10 @@
               fieldPath: spec.nodeName
         - name: CATTLE_SERVER
           value: "{{.URLPlain}}"
+        {{- if .AdditionalURLs }}
+        - name: CATTLE_ADDITIONAL_SERVERS
+          value: "{{.AdditionalURLs}}"
+        {{- end }}
         - name: CATTLE_CA_CHECKSUM
           value: "{{.CAChecksum}}"
         - name: CATTLE_CLUSTER
@@ -0,0 +1,126 @@
+package systemtemplate
+
+import (
+	"io"
+	"text/template"
+)
+
+var validateTemplate = template.Must(template.New("validate").Parse(validateSource))
+
+type validateContext struct {
+	URL        string
+	ReportURL  string
+	CAChecksum string
+	Ports      []int
+}
+
+// ValidateScript writes the script checking a node can reach rancher at url and be registered, without registering it,
+// and posting the results of the checks to reportURL. Ports are the ports the node must have free to join the cluster.
+func ValidateScript(resp io.Writer, url, reportURL string, ports []int) error {
+	return validateTemplate.Execute(resp, &validateContext{
+		URL:        url,
+		ReportURL:  reportURL,
+		CAChecksum: CAChecksum(),
+		Ports:      ports,
+	})
+}
+
+var validateSource = `#!/bin/sh
+# Checks this node can be registered in rancher without registering it, and reports the results to rancher.
+SERVER_URL="{{.URL}}"
+REPORT_URL="{{.ReportURL}}"
+CA_CHECKSUM="{{.CAChecksum}}"
+CURL="curl -s --connect-timeout 10"
+if [ "$1" = "--insecure" ]; then
+	REPORT_CURL="$CURL --insecure"
+else
+	REPORT_CURL="$CURL"
+fi
+
+checks=""
+failed=0
+
+json_escape() {
+	printf '%s' "$1" | tr -d '\r\n' | sed -e 's/\\/\\\\/g' -e 's/"/\\"/g'
+}
+
+result() {
+	if [ "$2" = true ]; then
+		echo "[PASS] $1: $3"
+	else
+		echo "[FAIL] $1: $3"
+		failed=1
+	fi
+	if [ -n "$checks" ]; then
+		checks="$checks,"
+	fi
+	checks="$checks{\"name\":\"$1\",\"passed\":$2,\"message\":\"$(json_escape "$3")\"}"
+}
+
+host=$(echo "$SERVER_URL" | sed -e 's#^[a-z]*://##' -e 's#[/:].*$##')
+if address=$(getent hosts "$host" 2>/dev/null | awk '{print $1; exit}') && [ -n "$address" ]; then
+	result dns true "$host resolves to $address"
+elif [ -n "$HTTPS_PROXY$https_proxy" ]; then
+	result dns true "$host does not resolve on the node and is resolved by the proxy"
+else
+	result dns false "$host does not resolve"
+fi
+
+code=$($CURL --insecure -o /dev/null -w '%{http_code}' "$SERVER_URL/ping")
+if [ "$code" != "000" ]; then
+	result connectivity true "$SERVER_URL is reachable"
+else
+	result connectivity false "$SERVER_URL is not reachable"
+fi
+
+if error=$($CURL -S -o /dev/null "$SERVER_URL/ping" 2>&1); then
+	result certificate true "certificate of $SERVER_URL is trusted by the node"
+elif [ -n "$CA_CHECKSUM" ]; then
+	checksum=$($CURL --insecure "$SERVER_URL/cacerts" | sha256sum | awk '{print $1}')
+	if [ "$checksum" = "$CA_CHECKSUM" ]; then
+		result certificate true "CA certificates of $SERVER_URL match the CA checksum"
+	else
+		result certificate false "CA certificates of $SERVER_URL do not match the CA checksum $CA_CHECKSUM: $error"
+	fi
+else
+	result certificate false "certificate of $SERVER_URL is not trusted by the node: $error"
+fi
+
+pong=$($REPORT_CURL "$SERVER_URL/ping")
+if [ "$pong" = "pong" ]; then
+	result ping true "rancher answers at $SERVER_URL"
+else
+	result ping false "rancher does not answer at $SERVER_URL"
+fi
+
+if command -v ss >/dev/null 2>&1; then
+	listening=$(ss -ltnH 2>/dev/null | awk '{print $4}')
+elif command -v netstat >/dev/null 2>&1; then
+	listening=$(netstat -ltn 2>/dev/null | awk 'NR > 2 {print $4}')
+else
+	listening="unknown"
+fi
+if [ "$listening" = "unknown" ]; then
+	result ports true "neither ss nor netstat is installed to check the ports"
+else
+	used=""
+	for port in{{range .Ports}} {{.}}{{end}}; do
+		if echo "$listening" | grep -q ":$port\$"; then
+			used="$used $port"
+		fi
+	done
+	if [ -z "$used" ]; then
+		result ports true "ports{{range .Ports}} {{.}}{{end}} are free"
+	else
+		result ports false "ports$used are in use"
+	fi
+fi
+
+report="{\"nodeName\":\"$(json_escape "$(hostname)")\",\"checks\":[$checks]}"
+if $REPORT_CURL -f -o /dev/null -X POST -H 'Content-Type: application/json' --data "$report" "$REPORT_URL"; then
+	echo "Reported the results to rancher"
+else
+	echo "Failed to report the results to rancher" >&2
+fi
+exit $failed
+`
@@ -122,10 +122,9 @@ func ListenAndServe(ctx context.Context, restConfig *rest.Config, handler http.H
 	if clusterIP != "" {
 		hostIPs = append(hostIPs, clusterIP)
 	}
-	if len(hostIPs) > 0 {
-		serverOptions.TLSListenerConfig = dynamiclistener.Config{
-			SANs: hostIPs,
-		}
+	// the Kubernetes API server reaches the admission webhooks through the rancher service
+	serverOptions.TLSListenerConfig = dynamiclistener.Config{
+		SANs: append(hostIPs, fmt.Sprintf("%s.%s.svc", commonName, namespace.System)),
 	}
 
 	internalAPICtx := context.WithValue(ctx, InternalAPI, true)
@@ -0,0 +1,45 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/rancher/rancher/pkg/logging"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestTraceIDKey is the attribute holding the trace ID logged for a request, to find the logs of a traced request.
+const RequestTraceIDKey = attribute.Key("rancher.trace_id")
+
+// Middleware starts a span for each request, continuing the trace of the client if it sent one.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+		ctx, span := otel.Tracer(tracerName).Start(ctx, "HTTP "+req.Method, trace.WithSpanKind(trace.SpanKindServer))
+		defer span.End()
+		span.SetAttributes(
+			semconv.HTTPMethodKey.String(req.Method),
+			semconv.HTTPTargetKey.String(req.URL.Path),
+		)
+		if traceID := logging.TraceID(ctx); traceID != "" {
+			span.SetAttributes(RequestTraceIDKey.String(traceID))
+		}
+		next.ServeHTTP(rw, req.WithContext(ctx))
+	})
+}
+
+// StartProxySpan starts the span of a request proxied to a downstream cluster, and propagates it to the cluster in the
+// headers of the request. It returns the request to proxy.
+func StartProxySpan(req *http.Request, clusterName string) (*http.Request, trace.Span) {
+	ctx, span := otel.Tracer(tracerName).Start(req.Context(), "proxy "+req.Method, trace.WithSpanKind(trace.SpanKindClient))
+	span.SetAttributes(
+		ClusterNameKey.String(clusterName),
+		semconv.HTTPMethodKey.String(req.Method),
+		semconv.HTTPTargetKey.String(req.URL.Path),
+	)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	return req.WithContext(ctx), span
+}
@@ -0,0 +1,164 @@
+// Package tracing exports OpenTelemetry spans of the provisioning controllers and of the API proxy over OTLP, so that
+// the creation of a cluster can be followed across controllers. The spans of the work done on a cluster share a trace
+// derived from the UID and generation of the RKE control plane of the cluster, as the controllers only communicate
+// through the objects they watch, so that each revision of the spec of a cluster is traced on its own.
+package tracing
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/rancher/rancher/pkg/settings"
+	"github.com/rancher/rancher/pkg/version"
+	"github.com/rancher/wrangler/pkg/generic"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	tracerName      = "github.com/rancher/rancher"
+	shutdownTimeout = 5 * time.Second
+)
+
+// Attribute keys of the spans.
+const (
+	ClusterNamespaceKey  = attribute.Key("rancher.cluster.namespace")
+	ClusterNameKey       = attribute.Key("rancher.cluster.name")
+	ClusterGenerationKey = attribute.Key("rancher.cluster.generation")
+	MachineNameKey       = attribute.Key("rancher.machine.name")
+	RequeuedKey          = attribute.Key("rancher.requeued")
+)
+
+// Start exports the spans to the collector of the tracing-otlp-endpoint setting until the context is done. It does
+// nothing if the setting is empty, the spans being dropped by the default tracer provider. The spans are dropped as well
+// if an error is returned.
+func Start(ctx context.Context) error {
+	endpoint := settings.TracingOTLPEndpoint.Get()
+	if endpoint == "" {
+		return nil
+	}
+
+	ratio, err := strconv.ParseFloat(settings.TracingSampleRatio.Get(), 64)
+	if err != nil || ratio < 0 || ratio > 1 {
+		return fmt.Errorf("invalid setting %s %q: must be a number between 0 and 1", settings.TracingSampleRatio.Name, settings.TracingSampleRatio.Get())
+	}
+
+	options := []otlpgrpc.Option{otlpgrpc.WithEndpoint(endpoint)}
+	if settings.TracingOTLPInsecure.Get() == "true" {
+		options = append(options, otlpgrpc.WithInsecure())
+	}
+	exporter, err := otlp.NewExporter(ctx, otlpgrpc.NewDriver(options...))
+	if err != nil {
+		return fmt.Errorf("creating OTLP exporter for %s: %w", endpoint, err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.ServiceNameKey.String("rancher"),
+			semconv.ServiceVersionKey.String(version.Version),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	logrus.Infof("Exporting traces to %s", endpoint)
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := provider.Shutdown(shutdownCtx); err != nil {
+			logrus.Warnf("failed to flush traces: %v", err)
+		}
+	}()
+	return nil
+}
+
+// StartSpan starts a span, child of the span of the context if it has one.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, name)
+	span.SetAttributes(attrs...)
+	return ctx, span
+}
+
+// StartClusterSpan starts a span of the work done on a cluster, in the trace of the current generation of its RKE
+// control plane. The span isn't part of the trace of a cluster if its control plane is unknown.
+func StartClusterSpan(ctx context.Context, controlPlane metav1.Object, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if controlPlane == nil {
+		return StartSpan(ctx, name, attrs...)
+	}
+	ctx = trace.ContextWithRemoteSpanContext(ctx, clusterSpanContext(controlPlane.GetUID(), controlPlane.GetGeneration()))
+	return StartSpan(ctx, name, append(clusterAttributes(controlPlane), attrs...)...)
+}
+
+// RecordClusterSpan records a span of a cluster which started and ended in the past, such as a provisioning phase of a
+// machine, in the trace of the current generation of its RKE control plane.
+func RecordClusterSpan(ctx context.Context, controlPlane metav1.Object, name string, start, end time.Time, attrs ...attribute.KeyValue) {
+	if controlPlane != nil {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, clusterSpanContext(controlPlane.GetUID(), controlPlane.GetGeneration()))
+		attrs = append(clusterAttributes(controlPlane), attrs...)
+	}
+	_, span := otel.Tracer(tracerName).Start(ctx, name, trace.WithTimestamp(start))
+	span.SetAttributes(attrs...)
+	span.End(trace.WithTimestamp(end))
+}
+
+// End ends a span, recording the error it ended with. Errors requeuing the object being handled aren't recorded as
+// failures.
+func End(span trace.Span, err error) {
+	switch {
+	case err == nil:
+	case errors.Is(err, generic.ErrSkip):
+		span.SetAttributes(RequeuedKey.Bool(true))
+	default:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// clusterSpanContext returns the sampled remote parent of the spans of a cluster. Its trace ID and span ID are derived
+// from the UID and generation of the RKE control plane of the cluster, so that the spans of the same revision of the
+// cluster are in the same trace, and a cluster recreated with the same name doesn't reuse the traces of the previous
+// one.
+func clusterSpanContext(uid types.UID, generation int64) trace.SpanContext {
+	sum := sha256.Sum256([]byte(string(uid) + "/" + strconv.FormatInt(generation, 10)))
+	var (
+		traceID trace.TraceID
+		spanID  trace.SpanID
+	)
+	copy(traceID[:], sum[:16])
+	copy(spanID[:], sum[16:24])
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+}
+
+// clusterAttributes returns the attributes of the spans of the cluster of an RKE control plane, which is named after
+// its cluster.
+func clusterAttributes(controlPlane metav1.Object) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		ClusterNamespaceKey.String(controlPlane.GetNamespace()),
+		ClusterNameKey.String(controlPlane.GetName()),
+		ClusterGenerationKey.Int64(controlPlane.GetGeneration()),
+	}
+}
@@ -0,0 +1,20 @@
+package tracing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterSpanContext(t *testing.T) {
+	spanContext := clusterSpanContext("3f8a2c1e-5b7d-4e09-9a61-0c2d4b8e7f10", 2)
+	assert.True(t, spanContext.IsValid())
+	assert.True(t, spanContext.IsRemote())
+	assert.True(t, spanContext.IsSampled())
+
+	assert.Equal(t, spanContext, clusterSpanContext("3f8a2c1e-5b7d-4e09-9a61-0c2d4b8e7f10", 2), "the spans of a revision of a cluster share a trace")
+	assert.NotEqual(t, spanContext.TraceID(), clusterSpanContext("3f8a2c1e-5b7d-4e09-9a61-0c2d4b8e7f10", 3).TraceID(),
+		"each revision of a cluster has its own trace")
+	assert.NotEqual(t, spanContext.TraceID(), clusterSpanContext("a41c0d77-2e35-4f8b-b1d6-98e5c3f20a4b", 2).TraceID(),
+		"a cluster recreated with the same name has its own traces")
+}
@@ -4,10 +4,12 @@
 	"fmt"
 	"net/http"
 
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/remotedialer"
-	"github.com/sirupsen/logrus"
 )
 
+var logger = logging.Logger(logging.Agent)
+
 type Authorizers struct {
 	chain []remotedialer.Authorizer
 }
@@ -18,8 +20,8 @@ func ErrorWriter(rw http.ResponseWriter, req *http.Request, code int, err error)
 	if forwardedFor != "" {
 		fullAddress = fmt.Sprintf("%s (X-Forwarded-For: %s)", req.RemoteAddr, forwardedFor)
 	}
-	logrus.Errorf("Failed to handle tunnel request from remote address %s: response %d: %v", fullAddress, code, err)
-	logrus.Tracef("ErrorWriter: response code: %d, request: %v", code, req)
+	logger.Errorf("Failed to handle tunnel request from remote address %s: response %d: %v", fullAddress, code, err)
+	logger.Tracef("ErrorWriter: response code: %d, request: %v", code, req)
 	remotedialer.DefaultErrorWriter(rw, req, code, err)
 }
 
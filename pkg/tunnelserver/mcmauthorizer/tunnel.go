@@ -20,15 +20,17 @@
 	"github.com/rancher/norman/types/convert"
 	client "github.com/rancher/rancher/pkg/client/generated/management/v3"
 	v3 "github.com/rancher/rancher/pkg/generated/norman/management.cattle.io/v3"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/taints"
 	"github.com/rancher/rancher/pkg/types/config"
-	"github.com/sirupsen/logrus"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/cache"
 )
 
+var logger = logging.Logger(logging.Agent)
+
 const (
 	crtKeyIndex  = "crtKeyIndex"
 	nodeKeyIndex = "nodeKeyIndex"
@@ -115,7 +117,7 @@ func (t *Authorizer) AuthorizeTunnel(req *http.Request) (string, bool, error) {
 func (t *Authorizer) Authorize(req *http.Request) (*Client, bool, error) {
 	token := req.Header.Get(Token)
 	if token == "" {
-		logrus.Debugf("Authorize: Token header [%s] is empty", Token)
+		logger.Debugf("Authorize: Token header [%s] is empty", Token)
 		return nil, false, nil
 	}
 
@@ -165,7 +167,7 @@ func (t *Authorizer) Authorize(req *http.Request) (*Client, bool, error) {
 
 func (t *Authorizer) getMachine(cluster *v3.Cluster, inNode *client.Node) (*v3.Node, error) {
 	machineName := machineName(inNode)
-	logrus.Tracef("getMachine: looking up machine [%s] in cluster [%s]", machineName, cluster.Name)
+	logger.Tracef("getMachine: looking up machine [%s] in cluster [%s]", machineName, cluster.Name)
 	machine, err := t.machineLister.Get(cluster.Name, machineName)
 	if apierrors.IsNotFound(err) {
 		if objs, err := t.nodeIndexer.ByIndex(nodeKeyIndex, fmt.Sprintf("%s/%s", cluster.Name, inNode.RequestedHostname)); err == nil {
@@ -174,17 +176,17 @@ func (t *Authorizer) getMachine(cluster *v3.Cluster, inNode *client.Node) (*v3.N
 			}
 		}
 
-		logrus.Tracef("getMachine: looking up [%s] as node name in cluster [%s]", inNode.RequestedHostname, cluster.Name)
+		logger.Tracef("getMachine: looking up [%s] as node name in cluster [%s]", inNode.RequestedHostname, cluster.Name)
 		machine, err := t.machineLister.Get(cluster.Name, inNode.RequestedHostname)
 		if err == nil {
-			logrus.Debugf("Found [%s] as node name in cluster [%s], error: %v", inNode.RequestedHostname, cluster.Name, err)
+			logger.Debugf("Found [%s] as node name in cluster [%s], error: %v", inNode.RequestedHostname, cluster.Name, err)
 			return machine, nil
 		}
 
-		logrus.Tracef("getMachine: looking up [%s] as RequestedHostname in cluster [%s]", inNode.RequestedHostname, cluster.Name)
+		logger.Tracef("getMachine: looking up [%s] as RequestedHostname in cluster [%s]", inNode.RequestedHostname, cluster.Name)
 		machines, _ := t.machineLister.List(cluster.Name, labels.NewSelector())
 		for _, machine := range machines {
-			logrus.Tracef("getMachine: comparing machine.Spec.RequestedHostname [%s] to inNode.RequestedHostname [%s]", machine.Spec.RequestedHostname, inNode.RequestedHostname)
+			logger.Tracef("getMachine: comparing machine.Spec.RequestedHostname [%s] to inNode.RequestedHostname [%s]", machine.Spec.RequestedHostname, inNode.RequestedHostname)
 			if machine.Spec.RequestedHostname == inNode.RequestedHostname {
 				return machine, nil
 			}
@@ -215,7 +217,7 @@ func (t *Authorizer) authorizeNode(register bool, cluster *v3.Cluster, inNode *c
 		}
 	}
 
-	logrus.Tracef("updateDockerInfo: cluster [%s] node [%s] dockerInfo [%v]", cluster.Name, machine.Name, inNode.DockerInfo)
+	logger.Tracef("updateDockerInfo: cluster [%s] node [%s] dockerInfo [%v]", cluster.Name, machine.Name, inNode.DockerInfo)
 	machine, err = t.updateDockerInfo(machine, inNode)
 	return machine, true, err
 }
@@ -297,7 +299,7 @@ func (t *Authorizer) authorizeCluster(cluster *v3.Cluster, inCluster *cluster, r
 			return cluster, true, err
 		}
 		if driver == "" {
-			logrus.Tracef("Setting the driver to imported for cluster %v %v", cluster.Name, cluster.Spec.DisplayName)
+			logger.Tracef("Setting the driver to imported for cluster %v %v", cluster.Name, cluster.Spec.DisplayName)
 			cluster.Status.Driver = v32.ClusterDriverImported
 			changed = true
 		}
@@ -320,7 +322,7 @@ func (t *Authorizer) authorizeCluster(cluster *v3.Cluster, inCluster *cluster, r
 				return cluster, true, err
 			}
 			if currentSecret != nil && secret.GetResourceVersion() != currentSecret.GetResourceVersion() {
-				logrus.Infof("updated service account token for cluster %s (%s)", cluster.Name, cluster.Spec.DisplayName)
+				logger.Infof("updated service account token for cluster %s (%s)", cluster.Name, cluster.Spec.DisplayName)
 			}
 			cluster.Status.APIEndpoint = apiEndpoint
 			cluster.Status.ServiceAccountTokenSecret = secret.Name
@@ -383,7 +385,7 @@ func (t *Authorizer) readInput(cluster *v3.Cluster, req *http.Request) (*input,
 func machineName(machine *client.Node) string {
 	digest := md5.Sum([]byte(machine.RequestedHostname))
 	machineNameMD5 := fmt.Sprintf("m-%s", hex.EncodeToString(digest[:])[:12])
-	logrus.Tracef("machineName: returning [%s] for node with RequestedHostname [%s]", machineNameMD5, machine.RequestedHostname)
+	logger.Tracef("machineName: returning [%s] for node with RequestedHostname [%s]", machineNameMD5, machine.RequestedHostname)
 	return machineNameMD5
 }
 
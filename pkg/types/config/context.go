@@ -16,6 +16,7 @@
 	"github.com/rancher/norman/restwatch"
 	"github.com/rancher/norman/store/proxy"
 	"github.com/rancher/norman/types"
+	"github.com/rancher/rancher/pkg/auth/mfa"
 	"github.com/rancher/rancher/pkg/catalog/manager"
 	"github.com/rancher/rancher/pkg/controllers"
 	"github.com/rancher/rancher/pkg/generated/controllers/catalog.cattle.io"
@@ -74,6 +75,9 @@ type ScaledContext struct {
 	UserManager       user.Manager
 	PeerManager       peermanager.PeerManager
 	CatalogManager    manager.CatalogManager
+	// MFA is the multi-factor authentication manager shared by the authenticators, providers and token managers of
+	// the scaled context.
+	MFA *mfa.Manager
 
 	Management managementv3.Interface
 	Project    projectv3.Interface
@@ -141,6 +145,11 @@ func NewScaledContext(config rest.Config, opts *ScaleContextOptions) (*ScaledCon
 	context.RBAC = rbacv1.NewFromControllerFactory(context.ControllerFactory)
 	context.Core = corev1.NewFromControllerFactory(context.ControllerFactory)
 
+	context.MFA, err = mfa.NewManager(context.Core, context.Management)
+	if err != nil {
+		return nil, err
+	}
+
 	context.K8sClient, err = kubernetes.NewForConfig(&config)
 	if err != nil {
 		return nil, err
@@ -141,6 +141,12 @@ type Context struct {
 	leadership              *leader.Manager
 	controllerLock          *sync.Mutex
 
+	// Standby is true if the replica never campaigns for the leadership, not running the controllers.
+	Standby bool
+	// LeadershipGate, if set, must return before the replica campaigns for the leadership running the controllers.
+	LeadershipGate func(ctx context.Context) error
+	leadershipOnce *sync.Once
+
 	RESTClientGetter      genericclioptions.RESTClientGetter
 	CatalogContentManager *content.Manager
 	HelmOperations        *helmop.Operations
@@ -209,10 +215,30 @@ func (w *Context) Start(ctx context.Context) error {
 	if err := w.ControllerFactory.Start(ctx, 50); err != nil {
 		return err
 	}
-	w.leadership.Start(ctx)
+	w.startLeadership(ctx)
 	return nil
 }
 
+// startLeadership campaigns for the leadership, once the leadership gate passes.
+func (w *Context) startLeadership(ctx context.Context) {
+	if w.Standby {
+		return
+	}
+	if w.LeadershipGate == nil {
+		w.leadership.Start(ctx)
+		return
+	}
+	w.leadershipOnce.Do(func() {
+		go func() {
+			if err := w.LeadershipGate(ctx); err != nil {
+				logrus.Errorf("Not campaigning for the leadership running the controllers: %v", err)
+				return
+			}
+			w.leadership.Start(ctx)
+		}()
+	})
+}
+
 // WithAgent returns a shallow copy of the Context that has been configured to use a user agent in its
 // clients that is the given userAgent appended to "rancher-%s-%s".
 func (w *Context) WithAgent(userAgent string) *Context {
@@ -435,6 +461,7 @@ func NewContext(ctx context.Context, clientConfig clientcmd.ClientConfig, restCo
 		RESTMapper:              restMapper,
 		leadership:              leadership,
 		controllerLock:          &sync.Mutex{},
+		leadershipOnce:          &sync.Once{},
 		PeerManager:             peerManager,
 		RESTClientGetter:        restClientGetter,
 		CatalogContentManager:   content,
@@ -1,7 +1,7 @@
 package provisioning
 
 import (
-	provisionClientV1 "github.com/rancher/rancher/pkg/generated/clientset/versioned/typed/provisioning.cattle.io/v1"
+	provisionClientV1 "github.com/rancher/rancher/pkg/provisioningclient/clientset/versioned/typed/provisioning.cattle.io/v1"
 	"github.com/rancher/rancher/tests/framework/pkg/session"
 	"k8s.io/client-go/rest"
 )
@@ -0,0 +1,14 @@
+package client
+
+const (
+	LifecycleEventRouteType            = "lifecycleEventRoute"
+	LifecycleEventRouteFieldEventTypes = "eventTypes"
+	LifecycleEventRouteFieldRecipient  = "recipient"
+	LifecycleEventRouteFieldTemplate   = "template"
+)
+
+type LifecycleEventRoute struct {
+	EventTypes []string `json:"eventTypes,omitempty" yaml:"eventTypes,omitempty"`
+	Recipient  string   `json:"recipient,omitempty" yaml:"recipient,omitempty"`
+	Template   string   `json:"template,omitempty" yaml:"template,omitempty"`
+}
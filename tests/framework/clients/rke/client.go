@@ -1,7 +1,7 @@
 package rke
 
 import (
-	rkeClientV1 "github.com/rancher/rancher/pkg/generated/clientset/versioned/typed/rke.cattle.io/v1"
+	rkeClientV1 "github.com/rancher/rancher/pkg/provisioningclient/clientset/versioned/typed/rke.cattle.io/v1"
 	"github.com/rancher/rancher/tests/framework/pkg/session"
 	"k8s.io/client-go/rest"
 )
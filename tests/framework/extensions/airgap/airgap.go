@@ -0,0 +1,196 @@
+package airgap
+
+import (
+	"fmt"
+	"strings"
+
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/tests/framework/clients/rancher"
+	"github.com/rancher/rancher/tests/framework/extensions/secrets"
+	"github.com/rancher/rancher/tests/framework/pkg/config"
+	namegen "github.com/rancher/rancher/tests/framework/pkg/namegenerator"
+	"github.com/rancher/rancher/tests/framework/pkg/nodes"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	registryAuthSecretPrefix = "airgap-registry-auth-"
+	egressChain              = "RANCHER-AIRGAP"
+)
+
+// LoadConfig loads the airgap configuration from the config file, and reads the ssh key of the bastion node if one
+// was configured.
+func LoadConfig() (*Config, error) {
+	airgapConfig := new(Config)
+	config.LoadConfig(ConfigurationFileKey, airgapConfig)
+
+	if airgapConfig.RegistryFQDN == "" {
+		return nil, fmt.Errorf("airgap: registryFQDN must be set")
+	}
+
+	if airgapConfig.Bastion != nil && airgapConfig.Bastion.SSHKeyName != "" {
+		sshKey, err := nodes.GetSSHKey(airgapConfig.Bastion.SSHKeyName)
+		if err != nil {
+			return nil, err
+		}
+		airgapConfig.Bastion.SSHKey = sshKey
+	}
+
+	return airgapConfig, nil
+}
+
+// SeedRegistry pulls every image in `images`, as well as every image listed in the files found at `imageListURLs`,
+// on the bastion node and pushes them into the private registry. The bastion node must have docker installed and
+// egress to the public internet.
+func SeedRegistry(airgapConfig *Config) error {
+	if airgapConfig.Bastion == nil {
+		return fmt.Errorf("airgap: a bastion node is required to seed the registry")
+	}
+
+	bastion := airgapConfig.Bastion
+	if airgapConfig.RegistryUsername != "" {
+		logrus.Infof("Logging into registry %s from bastion %s", airgapConfig.RegistryFQDN, bastion.NodeID)
+		command := fmt.Sprintf("sudo docker login %s -u %s --password-stdin <<< '%s'", airgapConfig.RegistryFQDN, airgapConfig.RegistryUsername, airgapConfig.RegistryPassword)
+		if _, err := bastion.ExecuteCommand(fmt.Sprintf("bash -c %q", command)); err != nil {
+			return err
+		}
+	}
+
+	images := append([]string{}, airgapConfig.SeedImages...)
+	for _, url := range airgapConfig.ImageListURLs {
+		output, err := bastion.ExecuteCommand(fmt.Sprintf("curl -sfL %s", url))
+		if err != nil {
+			return fmt.Errorf("airgap: failed to download image list %s: %w", url, err)
+		}
+		for _, image := range strings.Split(output, "\n") {
+			image = strings.TrimSpace(image)
+			if image != "" {
+				images = append(images, image)
+			}
+		}
+	}
+
+	for _, image := range images {
+		target := MirroredImageName(airgapConfig.RegistryFQDN, image)
+		logrus.Infof("Seeding registry with image %s as %s", image, target)
+		command := fmt.Sprintf("sudo docker pull %s && sudo docker tag %s %s && sudo docker push %s", image, image, target, target)
+		if output, err := bastion.ExecuteCommand(fmt.Sprintf("bash -c %q", command)); err != nil {
+			return fmt.Errorf("airgap: failed to seed image %s: %s: %w", image, output, err)
+		}
+	}
+
+	return nil
+}
+
+// MirroredImageName returns the name an image is pushed as in the private registry, the registry host (if any) of the
+// original image is stripped so that the mirror configuration of the container runtime can resolve it.
+func MirroredImageName(registryFQDN, image string) string {
+	parts := strings.SplitN(image, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		image = parts[1]
+	}
+	return registryFQDN + "/" + image
+}
+
+// DisableEgress configures iptables on the node so that all outbound traffic is dropped, with the exception of the
+// loopback interface, established connections, and the `allowedCIDRs` (which at a minimum should contain the
+// registry, the proxy, and the Rancher server networks).
+func DisableEgress(node *nodes.Node, allowedCIDRs []string) error {
+	logrus.Infof("Disabling egress on node %s", node.NodeID)
+
+	commands := []string{
+		fmt.Sprintf("sudo iptables -N %s || sudo iptables -F %s", egressChain, egressChain),
+		fmt.Sprintf("sudo iptables -A %s -o lo -j ACCEPT", egressChain),
+		fmt.Sprintf("sudo iptables -A %s -m state --state ESTABLISHED,RELATED -j ACCEPT", egressChain),
+	}
+	for _, cidr := range allowedCIDRs {
+		commands = append(commands, fmt.Sprintf("sudo iptables -A %s -d %s -j ACCEPT", egressChain, cidr))
+	}
+	commands = append(commands,
+		fmt.Sprintf("sudo iptables -A %s -j DROP", egressChain),
+		fmt.Sprintf("sudo iptables -C OUTPUT -j %s || sudo iptables -I OUTPUT -j %s", egressChain, egressChain),
+	)
+
+	for _, command := range commands {
+		if output, err := node.ExecuteCommand(fmt.Sprintf("bash -c %q", command)); err != nil {
+			return fmt.Errorf("airgap: failed to disable egress on node %s: %s: %w", node.NodeID, output, err)
+		}
+	}
+
+	return nil
+}
+
+// VerifyNoEgress returns an error if the node is able to reach the public internet.
+func VerifyNoEgress(node *nodes.Node) error {
+	_, err := node.ExecuteCommand("curl -s --connect-timeout 5 https://registry-1.docker.io/v2/")
+	if err == nil {
+		return fmt.Errorf("airgap: node %s is able to reach the public internet", node.NodeID)
+	}
+	return nil
+}
+
+// CreateRegistryAuthSecret creates the rke.cattle.io/auth-config secret used by the registry configuration in the
+// given namespace, and returns its name. If no credentials are configured an empty name is returned.
+func CreateRegistryAuthSecret(client *rancher.Client, namespace string, airgapConfig *Config) (string, error) {
+	if airgapConfig.RegistryUsername == "" {
+		return "", nil
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      namegen.AppendRandomString(registryAuthSecretPrefix),
+			Namespace: namespace,
+		},
+		Type: rkev1.AuthConfigSecretType,
+		StringData: map[string]string{
+			rkev1.UsernameAuthConfigSecretKey: airgapConfig.RegistryUsername,
+			rkev1.PasswordAuthConfigSecretKey: airgapConfig.RegistryPassword,
+		},
+	}
+
+	resp, err := client.Steve.SteveType(secrets.SecretSteveType).Create(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Name, nil
+}
+
+// NewRegistryConfig returns the registries configuration for a v2 provisioning cluster that mirrors every configured
+// upstream registry to the private registry.
+func NewRegistryConfig(airgapConfig *Config, authSecretName string) *rkev1.Registry {
+	endpoint := "https://" + airgapConfig.RegistryFQDN
+
+	registry := &rkev1.Registry{
+		Mirrors: map[string]rkev1.Mirror{},
+		Configs: map[string]rkev1.RegistryConfig{
+			airgapConfig.RegistryFQDN: {
+				AuthConfigSecretName: authSecretName,
+				InsecureSkipVerify:   airgapConfig.RegistryInsecure,
+			},
+		},
+	}
+	for _, upstream := range airgapConfig.MirroredRegistries {
+		registry.Mirrors[upstream] = rkev1.Mirror{
+			Endpoints: []string{endpoint},
+		}
+	}
+
+	return registry
+}
+
+// NewProxyEnvVars returns the agent environment variables needed for the rancher agents to reach Rancher through the
+// configured proxy. If no proxy is configured, nil is returned.
+func NewProxyEnvVars(airgapConfig *Config) []rkev1.EnvVar {
+	if airgapConfig.ProxyURL == "" {
+		return nil
+	}
+
+	return []rkev1.EnvVar{
+		{Name: "HTTP_PROXY", Value: airgapConfig.ProxyURL},
+		{Name: "HTTPS_PROXY", Value: airgapConfig.ProxyURL},
+		{Name: "NO_PROXY", Value: airgapConfig.NoProxy},
+	}
+}
@@ -0,0 +1,25 @@
+package airgap
+
+import (
+	"github.com/rancher/rancher/tests/framework/pkg/nodes"
+)
+
+// The json/yaml config key for the airgap environment config
+const ConfigurationFileKey = "airgap"
+
+// Config is the configuration needed to stand up an airgapped environment. The registry is expected to be reachable
+// from the cluster nodes, while the bastion node is the only node that is allowed to reach the public internet. The
+// bastion is used to seed the private registry with the images the downstream clusters need.
+type Config struct {
+	Bastion            *nodes.Node `json:"bastion" yaml:"bastion"`
+	RegistryFQDN       string      `json:"registryFQDN" yaml:"registryFQDN"`
+	RegistryUsername   string      `json:"registryUsername" yaml:"registryUsername"`
+	RegistryPassword   string      `json:"registryPassword" yaml:"registryPassword"`
+	RegistryInsecure   bool        `json:"registryInsecure" yaml:"registryInsecure"`
+	SeedImages         []string    `json:"seedImages" yaml:"seedImages" default:"[]"`
+	ImageListURLs      []string    `json:"imageListURLs" yaml:"imageListURLs" default:"[]"`
+	MirroredRegistries []string    `json:"mirroredRegistries" yaml:"mirroredRegistries" default:"[\"docker.io\"]"`
+	ProxyURL           string      `json:"proxyURL" yaml:"proxyURL"`
+	NoProxy            string      `json:"noProxy" yaml:"noProxy" default:"127.0.0.0/8,10.0.0.0/8,172.16.0.0/12,192.168.0.0/16,.svc,.cluster.local"`
+	AllowedCIDRs       []string    `json:"allowedCIDRs" yaml:"allowedCIDRs" default:"[]"`
+}
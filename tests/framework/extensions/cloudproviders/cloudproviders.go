@@ -0,0 +1,202 @@
+package cloudproviders
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rancher/norman/types"
+	"github.com/rancher/rancher/tests/framework/clients/rancher"
+	management "github.com/rancher/rancher/tests/framework/clients/rancher/generated/management/v3"
+	"github.com/rancher/rancher/tests/framework/extensions/cloudcredentials"
+	"github.com/rancher/rancher/tests/framework/extensions/rke1/nodetemplates"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ErrNotSupported is returned by the operations a cloud provider does not support, e.g. RKE1 node templates for
+// digital ocean.
+var ErrNotSupported = errors.New("operation not supported by the cloud provider")
+
+// CloudProvider is the abstraction over the node driver cloud providers used by the provisioning suites, so that a
+// suite can run against any registered provider by name.
+type CloudProvider interface {
+	// Name is the name the provider is registered with e.g. "aws".
+	Name() string
+	// CreateCredential creates a cloud credential for the provider from the config file.
+	CreateCredential(client *rancher.Client) (*cloudcredentials.CloudCredential, error)
+	// MachineConfigFor returns the v2 provisioning machine config for a machine pool from the config file.
+	MachineConfigFor(generatedPoolName, namespace string) *unstructured.Unstructured
+	// MachineConfigPoolResourceSteveType is the steve type of the machine configs of the provider.
+	MachineConfigPoolResourceSteveType() string
+	// NodeTemplateFor creates an RKE1 node template for the provider from the config file.
+	NodeTemplateFor(client *rancher.Client) (*nodetemplates.NodeTemplate, error)
+	// Cleanup deletes the credentials and node templates created through the provider so far. Resources are still
+	// cleaned up by the session, Cleanup is used to free them before the session ends.
+	Cleanup(client *rancher.Client) error
+	// Quota returns the configured quota of the provider.
+	Quota() Quota
+}
+
+// CloudCredFunc creates a cloud credential from the config file.
+type CloudCredFunc func(rancherClient *rancher.Client) (*cloudcredentials.CloudCredential, error)
+
+// MachineConfigFunc returns a machine config from the config file.
+type MachineConfigFunc func(generatedPoolName, namespace string) *unstructured.Unstructured
+
+// NodeTemplateFunc creates an RKE1 node template from the config file.
+type NodeTemplateFunc func(rancherClient *rancher.Client) (*nodetemplates.NodeTemplate, error)
+
+// provider is the CloudProvider implementation of the built-in providers, built from their existing helpers.
+type provider struct {
+	name                               string
+	machineConfigPoolResourceSteveType string
+	cloudCredFunc                      CloudCredFunc
+	machineConfigFunc                  MachineConfigFunc
+	nodeTemplateFunc                   NodeTemplateFunc
+
+	mu      sync.Mutex
+	created []createdResource
+}
+
+type createdResource struct {
+	schemaType string
+	resource   types.Resource
+}
+
+// NewCloudProvider is a constructor that creates a CloudProvider from the helpers of a provider. nodeTemplateFunc
+// can be nil for providers that don't support RKE1.
+func NewCloudProvider(name, machineConfigPoolResourceSteveType string, cloudCredFunc CloudCredFunc, machineConfigFunc MachineConfigFunc, nodeTemplateFunc NodeTemplateFunc) CloudProvider {
+	return &provider{
+		name:                               name,
+		machineConfigPoolResourceSteveType: machineConfigPoolResourceSteveType,
+		cloudCredFunc:                      cloudCredFunc,
+		machineConfigFunc:                  machineConfigFunc,
+		nodeTemplateFunc:                   nodeTemplateFunc,
+	}
+}
+
+func (p *provider) Name() string {
+	return p.name
+}
+
+func (p *provider) CreateCredential(client *rancher.Client) (*cloudcredentials.CloudCredential, error) {
+	if err := p.Quota().checkCredentials(p.countCreated(management.CloudCredentialType)); err != nil {
+		return nil, err
+	}
+
+	credential, err := p.cloudCredFunc(client)
+	if err != nil {
+		return nil, err
+	}
+
+	p.track(management.CloudCredentialType, credential.Resource)
+	return credential, nil
+}
+
+func (p *provider) MachineConfigFor(generatedPoolName, namespace string) *unstructured.Unstructured {
+	return p.machineConfigFunc(generatedPoolName, namespace)
+}
+
+func (p *provider) MachineConfigPoolResourceSteveType() string {
+	return p.machineConfigPoolResourceSteveType
+}
+
+func (p *provider) NodeTemplateFor(client *rancher.Client) (*nodetemplates.NodeTemplate, error) {
+	if p.nodeTemplateFunc == nil {
+		return nil, fmt.Errorf("%s node templates: %w", p.name, ErrNotSupported)
+	}
+
+	nodeTemplate, err := p.nodeTemplateFunc(client)
+	if err != nil {
+		return nil, err
+	}
+
+	p.track(management.NodeTemplateType, nodeTemplate.Resource)
+	return nodeTemplate, nil
+}
+
+func (p *provider) Cleanup(client *rancher.Client) error {
+	p.mu.Lock()
+	created := p.created
+	p.created = nil
+	p.mu.Unlock()
+
+	var errs []string
+	for i := len(created) - 1; i >= 0; i-- {
+		resource := created[i].resource
+		err := client.Management.APIBaseClient.Ops.DoResourceDelete(created[i].schemaType, &resource)
+		if err != nil && !strings.Contains(err.Error(), "404 Not Found") {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to clean up %s resources: %s", p.name, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (p *provider) Quota() Quota {
+	return LoadConfig().Quotas[p.name]
+}
+
+func (p *provider) track(schemaType string, resource types.Resource) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.created = append(p.created, createdResource{schemaType: schemaType, resource: resource})
+}
+
+func (p *provider) countCreated(schemaType string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	count := 0
+	for _, created := range p.created {
+		if created.schemaType == schemaType {
+			count++
+		}
+	}
+	return count
+}
+
+var (
+	registryLock sync.RWMutex
+	registry     = map[string]CloudProvider{}
+)
+
+// Register registers a cloud provider by its name, replacing any provider previously registered with the same name.
+func Register(cloudProvider CloudProvider) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	registry[cloudProvider.Name()] = cloudProvider
+}
+
+// Get returns the cloud provider registered with the given name.
+func Get(name string) (CloudProvider, error) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	cloudProvider, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("cloud provider %s is not registered, registered providers are %s", name, strings.Join(namesLocked(), ", "))
+	}
+	return cloudProvider, nil
+}
+
+// Names returns the sorted names of the registered cloud providers.
+func Names() []string {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	return namesLocked()
+}
+
+func namesLocked() []string {
+	var names []string
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
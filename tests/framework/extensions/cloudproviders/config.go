@@ -0,0 +1,45 @@
+package cloudproviders
+
+import (
+	"fmt"
+
+	"github.com/rancher/rancher/tests/framework/pkg/config"
+)
+
+// The json/yaml config key for the cloud providers config
+const ConfigurationFileKey = "cloudProviders"
+
+// Config is the configuration of the cloud providers, keyed by provider name.
+type Config struct {
+	Quotas map[string]Quota `json:"quotas" yaml:"quotas"`
+}
+
+// Quota limits the resources a suite creates through a cloud provider. Zero means unlimited.
+type Quota struct {
+	// MaxCredentials is the maximum number of cloud credentials created through the provider at once.
+	MaxCredentials int `json:"maxCredentials" yaml:"maxCredentials"`
+	// MaxNodes is the maximum number of nodes provisioned with the provider by a single cluster.
+	MaxNodes int `json:"maxNodes" yaml:"maxNodes"`
+}
+
+// LoadConfig reads the cloud providers config from the config file.
+func LoadConfig() *Config {
+	cloudProvidersConfig := new(Config)
+	config.LoadConfig(ConfigurationFileKey, cloudProvidersConfig)
+	return cloudProvidersConfig
+}
+
+// CheckNodes returns an error if the number of nodes exceeds the quota.
+func (q Quota) CheckNodes(nodes int) error {
+	if q.MaxNodes > 0 && nodes > q.MaxNodes {
+		return fmt.Errorf("%d nodes exceed the quota of %d nodes", nodes, q.MaxNodes)
+	}
+	return nil
+}
+
+func (q Quota) checkCredentials(existing int) error {
+	if q.MaxCredentials > 0 && existing >= q.MaxCredentials {
+		return fmt.Errorf("creating a cloud credential would exceed the quota of %d credentials", q.MaxCredentials)
+	}
+	return nil
+}
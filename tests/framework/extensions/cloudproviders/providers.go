@@ -0,0 +1,34 @@
+package cloudproviders
+
+import (
+	"github.com/rancher/rancher/tests/framework/extensions/cloudcredentials/aws"
+	"github.com/rancher/rancher/tests/framework/extensions/cloudcredentials/azure"
+	"github.com/rancher/rancher/tests/framework/extensions/cloudcredentials/digitalocean"
+	"github.com/rancher/rancher/tests/framework/extensions/cloudcredentials/harvester"
+	"github.com/rancher/rancher/tests/framework/extensions/cloudcredentials/linode"
+	"github.com/rancher/rancher/tests/framework/extensions/cloudcredentials/vsphere"
+	"github.com/rancher/rancher/tests/framework/extensions/machinepools"
+	awsnodetemplates "github.com/rancher/rancher/tests/framework/extensions/rke1/nodetemplates/aws"
+	azurenodetemplates "github.com/rancher/rancher/tests/framework/extensions/rke1/nodetemplates/azure"
+	harvesternodetemplates "github.com/rancher/rancher/tests/framework/extensions/rke1/nodetemplates/harvester"
+	linodenodetemplates "github.com/rancher/rancher/tests/framework/extensions/rke1/nodetemplates/linode"
+	vspherenodetemplates "github.com/rancher/rancher/tests/framework/extensions/rke1/nodetemplates/vsphere"
+)
+
+const (
+	AWSProviderName       = "aws"
+	AzureProviderName     = "azure"
+	DOProviderName        = "do"
+	HarvesterProviderName = "harvester"
+	LinodeProviderName    = "linode"
+	VsphereProviderName   = "vsphere"
+)
+
+func init() {
+	Register(NewCloudProvider(AWSProviderName, machinepools.AWSPoolType, aws.CreateAWSCloudCredentials, machinepools.NewAWSMachineConfig, awsnodetemplates.CreateAWSNodeTemplate))
+	Register(NewCloudProvider(AzureProviderName, machinepools.AzurePoolType, azure.CreateAzureCloudCredentials, machinepools.NewAzureMachineConfig, azurenodetemplates.CreateAzureNodeTemplate))
+	Register(NewCloudProvider(DOProviderName, machinepools.DOPoolType, digitalocean.CreateDigitalOceanCloudCredentials, machinepools.NewDigitalOceanMachineConfig, nil))
+	Register(NewCloudProvider(HarvesterProviderName, machinepools.HarvesterPoolType, harvester.CreateHarvesterCloudCredentials, machinepools.NewHarvesterMachineConfig, harvesternodetemplates.CreateHarvesterNodeTemplate))
+	Register(NewCloudProvider(LinodeProviderName, machinepools.LinodePoolType, linode.CreateLinodeCloudCredentials, machinepools.NewLinodeMachineConfig, linodenodetemplates.CreateLinodeNodeTemplate))
+	Register(NewCloudProvider(VsphereProviderName, machinepools.VmwarevsphereType, vsphere.CreateVsphereCloudCredentials, machinepools.NewVSphereMachineConfig, vspherenodetemplates.CreateVSphereNodeTemplate))
+}
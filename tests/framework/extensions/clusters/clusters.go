@@ -421,23 +421,23 @@ func UpdateK3SRKE2Cluster(client *rancher.Client, cluster *v1.SteveAPIObject, up
 		return nil, err
 	}
 
-	err = kwait.Poll(500*time.Millisecond, 5*time.Minute, func() (done bool, err error) {
+	err = wait.Poll(client.Session.Context(), wait.ClusterUpdateOperation, 5*time.Minute, func(context.Context) (bool, string, error) {
 		client, err = client.ReLogin()
 		if err != nil {
-			return false, err
+			return false, "", err
 		}
 
 		clusterResp, err := client.Steve.SteveType(ProvisioningSteveResouceType).ByID(cluster.ID)
 		if err != nil {
-			return false, err
+			return false, "", err
 		}
 
 		if clusterResp.ObjectMeta.State.Name == "active" {
 			logrus.Infof("Cluster YAML has successfully been updated!")
-			return true, nil
-		} else {
-			return false, nil
+			return true, "", nil
 		}
+
+		return false, fmt.Sprintf("cluster %s is %s: %s", cluster.ID, clusterResp.ObjectMeta.State.Name, clusterResp.ObjectMeta.State.Message), nil
 	})
 
 	if err != nil {
@@ -564,3 +564,32 @@ func WatchAndWaitForCluster(steveClient *v1.Client, kubeProvisioningClient *kube
 	err = wait.WatchWait(result, IsProvisioningClusterReady)
 	return err
 }
+
+// WaitForControlPlaneWaitingReason waits for the Ready condition of the RKE control plane of a cluster to have the given
+// reason, one of the waiting reasons the planner sets such as "WaitingForDrain" or "WaitingForEtcdJoin".
+func WaitForControlPlaneWaitingReason(client *rancher.Client, namespace, clusterName, reason string) error {
+	rkeClient, err := client.GetKubeAPIRKEClient()
+	if err != nil {
+		return err
+	}
+	result, err := rkeClient.RKEControlPlanes(namespace).Watch(context.TODO(), metav1.ListOptions{
+		FieldSelector:  "metadata.name=" + clusterName,
+		TimeoutSeconds: &defaults.WatchTimeoutSeconds,
+	})
+	if err != nil {
+		return err
+	}
+
+	return wait.WatchWait(result, func(event watch.Event) (bool, error) {
+		controlPlane, ok := event.Object.(*rkev1.RKEControlPlane)
+		if !ok {
+			return false, fmt.Errorf("expected an RKEControlPlane, got %T", event.Object)
+		}
+		for _, condition := range controlPlane.Status.Conditions {
+			if condition.Type == "Ready" {
+				return condition.Reason == reason, nil
+			}
+		}
+		return false, nil
+	})
+}
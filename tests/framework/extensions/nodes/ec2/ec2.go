@@ -1,6 +1,7 @@
 package ec2
 
 import (
+	"encoding/base64"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -11,6 +12,19 @@
 
 const (
 	nodeBaseName = "rancher-automation"
+
+	// windowsUserData enables the OpenSSH server on Windows instances, with powershell as the default shell, and
+	// authorizes the public key of the instance key pair so that the nodes can be bootstrapped over ssh.
+	windowsUserData = `<powershell>
+Add-WindowsCapability -Online -Name OpenSSH.Server~~~~0.0.1.0
+Set-Service -Name sshd -StartupType Automatic
+Start-Service sshd
+New-ItemProperty -Path "HKLM:\SOFTWARE\OpenSSH" -Name DefaultShell -Value "C:\Windows\System32\WindowsPowerShell\v1.0\powershell.exe" -PropertyType String -Force
+$key = Invoke-RestMethod -Uri http://169.254.169.254/latest/meta-data/public-keys/0/openssh-key
+Set-Content -Path C:\ProgramData\ssh\administrators_authorized_keys -Value $key
+icacls.exe C:\ProgramData\ssh\administrators_authorized_keys /inheritance:r /grant "Administrators:F" /grant "SYSTEM:F"
+New-NetFirewallRule -Name sshd -DisplayName 'OpenSSH Server (sshd)' -Enabled True -Direction Inbound -Protocol TCP -Action Allow -LocalPort 22
+</powershell>`
 )
 
 // CreateNodes creates `numOfInstances` (and/or `numOfWinInstances` when using multiple node configurations - e.g. Windows nodes) number of ec2 instances
@@ -21,12 +35,24 @@ func CreateNodes(client *rancher.Client, numOfInstances int, numOfWinInstances i
 	}
 
 	for _, config := range ec2Client.ClientConfig.AWSEC2Config {
+		if config.IsWindows && !multiconfig {
+			continue
+		}
+
+		instanceCount := numOfInstances
+		if config.IsWindows {
+			instanceCount = numOfWinInstances
+		}
+		if instanceCount == 0 {
+			continue
+		}
+
 		sshName := getSSHKeyName(config.AWSSSHKeyName)
 		runInstancesInput := &ec2.RunInstancesInput{
 			ImageId:      aws.String(config.AWSAMI),
 			InstanceType: aws.String(config.InstanceType),
-			MinCount:     aws.Int64(int64(numOfInstances)),
-			MaxCount:     aws.Int64(int64(numOfInstances)),
+			MinCount:     aws.Int64(int64(instanceCount)),
+			MaxCount:     aws.Int64(int64(instanceCount)),
 			KeyName:      aws.String(sshName),
 			BlockDeviceMappings: []*ec2.BlockDeviceMapping{
 				{
@@ -66,6 +92,10 @@ func CreateNodes(client *rancher.Client, numOfInstances int, numOfWinInstances i
 			},
 		}
 
+		if config.IsWindows {
+			runInstancesInput.UserData = aws.String(base64.StdEncoding.EncodeToString([]byte(windowsUserData)))
+		}
+
 		reservation, err := ec2Client.SVC.RunInstances(runInstancesInput)
 		if err != nil {
 			return nil, nil, err
@@ -109,38 +139,18 @@ func CreateNodes(client *rancher.Client, numOfInstances int, numOfWinInstances i
 		}
 
 		for _, readyInstance := range readyInstances {
+			ec2Node := &nodes.Node{
+				NodeID:          *readyInstance.InstanceId,
+				PublicIPAddress: *readyInstance.PublicIpAddress,
+				SSHUser:         config.AWSUser,
+				SSHKey:          sshKey,
+				IsWindows:       config.IsWindows,
+			}
 
-			if multiconfig {
-				if config.AWSAMI == ec2Client.ClientConfig.AWSEC2Config[0].AWSAMI &&
-					config.AWSUser == ec2Client.ClientConfig.AWSEC2Config[0].AWSUser {
-					ec2Node := &nodes.Node{
-						NodeID:          *readyInstance.InstanceId,
-						PublicIPAddress: *readyInstance.PublicIpAddress,
-						SSHUser:         config.AWSUser,
-						SSHKey:          sshKey,
-					}
-					ec2Nodes = append(ec2Nodes, ec2Node)
-				}
-
-				if config.AWSAMI == ec2Client.ClientConfig.AWSEC2Config[1].AWSAMI &&
-					config.AWSUser == ec2Client.ClientConfig.AWSEC2Config[1].AWSUser {
-					ec2Node2 := &nodes.Node{
-						NodeID:          *readyInstance.InstanceId,
-						PublicIPAddress: *readyInstance.PublicIpAddress,
-						SSHUser:         config.AWSUser,
-						SSHKey:          sshKey,
-					}
-					winEC2Nodes = append(winEC2Nodes, ec2Node2)
-				}
+			if multiconfig && config.IsWindows {
+				winEC2Nodes = append(winEC2Nodes, ec2Node)
 			} else {
-				ec2Node := &nodes.Node{
-					NodeID:          *readyInstance.InstanceId,
-					PublicIPAddress: *readyInstance.PublicIpAddress,
-					SSHUser:         config.AWSUser,
-					SSHKey:          sshKey,
-				}
 				ec2Nodes = append(ec2Nodes, ec2Node)
-				winEC2Nodes = nil
 			}
 		}
 
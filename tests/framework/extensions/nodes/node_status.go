@@ -1,12 +1,14 @@
 package nodes
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	"github.com/rancher/norman/types"
 	"github.com/rancher/rancher/tests/framework/clients/rancher"
+	"github.com/rancher/rancher/tests/framework/pkg/wait"
 	"github.com/sirupsen/logrus"
-	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 const (
@@ -16,31 +18,31 @@
 // IsNodeReady is a helper method that will loop and check if the node is ready in the RKE1 cluster.
 // It will return an error if the node is not ready after set amount of time.
 func IsNodeReady(client *rancher.Client, ClusterID string) error {
-	err := wait.Poll(500*time.Millisecond, 30*time.Minute, func() (bool, error) {
+	err := wait.Poll(client.Session.Context(), wait.NodesReadyOperation, 30*time.Minute, func(context.Context) (bool, string, error) {
 		nodes, err := client.Management.Node.ListAll(&types.ListOpts{
 			Filters: map[string]interface{}{
 				"clusterId": ClusterID,
 			},
 		})
 		if err != nil {
-			return false, err
+			return false, "", err
 		}
 
 		for _, node := range nodes.Data {
 			node, err := client.Management.Node.ByID(node.ID)
 			if err != nil {
-				return false, err
+				return false, "", err
 			}
 
 			if node.State == active {
 				logrus.Infof("All nodes in the cluster are in an active state!")
-				return true, nil
+				return true, "", nil
 			}
 
-			return false, nil
+			return false, fmt.Sprintf("node %s is %s: %s", node.NodeName, node.State, node.TransitioningMessage), nil
 		}
 
-		return false, nil
+		return false, "no nodes registered", nil
 	})
 
 	return err
@@ -0,0 +1,12 @@
+package rancherha
+
+// The json/yaml config key for the rancher HA config
+const ConfigurationFileKey = "rancherHA"
+
+// Config is the configuration of the rancher HA suite. Rancher is scaled to Replicas for the suite, and a failover of
+// the leader is expected to take at most MaxFailoverSeconds.
+type Config struct {
+	Replicas               int32 `json:"replicas" yaml:"replicas" default:"3"`
+	MaxFailoverSeconds     int   `json:"maxFailoverSeconds" yaml:"maxFailoverSeconds" default:"120"`
+	FailoverTimeoutSeconds int   `json:"failoverTimeoutSeconds" yaml:"failoverTimeoutSeconds" default:"600"`
+}
@@ -0,0 +1,169 @@
+package rancherha
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rancher/rancher/tests/framework/clients/rancher"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// LeaseName and LeaseNamespace are the lease rancher replicas acquire to run the controllers, its holder is the
+	// name of the pod of the leader.
+	LeaseName      = "cattle-controllers"
+	LeaseNamespace = "kube-system"
+
+	RancherNamespace      = "cattle-system"
+	RancherDeploymentName = "rancher"
+	rancherPodSelector    = "app=rancher"
+)
+
+// LeaseGroupVersionResource is the required Group Version Resource for accessing leases in a cluster, using the
+// dynamic client.
+var LeaseGroupVersionResource = schema.GroupVersionResource{
+	Group:    "coordination.k8s.io",
+	Version:  "v1",
+	Resource: "leases",
+}
+
+var podGroupVersionResource = corev1.SchemeGroupVersion.WithResource("pods")
+
+// Failover is a failover of the rancher leader, from the killed leader to the replica elected in its place.
+type Failover struct {
+	PreviousLeader string
+	NewLeader      string
+	// Duration is the time from the leader pod being killed to another replica acquiring the lease.
+	Duration time.Duration
+}
+
+// GetLeader returns the name of the rancher pod holding the controllers lease of the local cluster.
+func GetLeader(client *rancher.Client) (string, error) {
+	dynamicClient, err := client.GetRancherDynamicClient()
+	if err != nil {
+		return "", err
+	}
+
+	lease, err := dynamicClient.Resource(LeaseGroupVersionResource).Namespace(LeaseNamespace).Get(context.TODO(), LeaseName, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	holder, _, err := unstructured.NestedString(lease.Object, "spec", "holderIdentity")
+	return holder, err
+}
+
+// ListRancherPods returns the rancher pods of the local cluster.
+func ListRancherPods(client *rancher.Client) ([]corev1.Pod, error) {
+	dynamicClient, err := client.GetRancherDynamicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	unstructuredPods, err := dynamicClient.Resource(podGroupVersionResource).Namespace(RancherNamespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: rancherPodSelector,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var pods []corev1.Pod
+	for _, unstructuredPod := range unstructuredPods.Items {
+		pod := corev1.Pod{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredPod.Object, &pod); err != nil {
+			return nil, err
+		}
+		pods = append(pods, pod)
+	}
+
+	return pods, nil
+}
+
+// KillLeader force deletes the rancher pod holding the controllers lease, without a grace period so that the lease
+// isn't released, and returns its name.
+func KillLeader(client *rancher.Client) (string, error) {
+	leader, err := GetLeader(client)
+	if err != nil {
+		return "", err
+	}
+	if leader == "" {
+		return "", fmt.Errorf("lease %s/%s has no holder", LeaseNamespace, LeaseName)
+	}
+
+	dynamicClient, err := client.GetRancherDynamicClient()
+	if err != nil {
+		return "", err
+	}
+
+	gracePeriod := int64(0)
+	logrus.Infof("Killing rancher leader %s", leader)
+	err = dynamicClient.Resource(podGroupVersionResource).Namespace(RancherNamespace).Delete(context.TODO(), leader, metav1.DeleteOptions{
+		GracePeriodSeconds: &gracePeriod,
+	})
+	return leader, err
+}
+
+// WaitForNewLeader waits for a running rancher pod other than the previous leader to acquire the controllers lease and
+// returns its name. The rancher API can be briefly unavailable during a failover, so errors are retried until the
+// timeout.
+func WaitForNewLeader(client *rancher.Client, previousLeader string, timeout time.Duration) (string, error) {
+	var newLeader string
+	err := kwait.Poll(time.Second, timeout, func() (done bool, err error) {
+		leader, err := GetLeader(client)
+		if err != nil {
+			logrus.Debugf("Waiting for a new rancher leader: %v", err)
+			return false, nil
+		}
+		if leader == "" || leader == previousLeader {
+			return false, nil
+		}
+
+		pods, err := ListRancherPods(client)
+		if err != nil {
+			logrus.Debugf("Waiting for a new rancher leader: %v", err)
+			return false, nil
+		}
+		for _, pod := range pods {
+			if pod.Name == leader && pod.DeletionTimestamp == nil && pod.Status.Phase == corev1.PodRunning {
+				newLeader = leader
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("no rancher replica took over from leader %s: %w", previousLeader, err)
+	}
+
+	return newLeader, nil
+}
+
+// KillLeaderAndWaitForFailover kills the rancher leader and waits for another replica to take over, measuring the time
+// the failover took.
+func KillLeaderAndWaitForFailover(client *rancher.Client, timeout time.Duration) (*Failover, error) {
+	previousLeader, err := KillLeader(client)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+
+	newLeader, err := WaitForNewLeader(client, previousLeader, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	failover := &Failover{
+		PreviousLeader: previousLeader,
+		NewLeader:      newLeader,
+		Duration:       time.Since(start),
+	}
+	logrus.Infof("Rancher leader failed over from %s to %s in %s", failover.PreviousLeader, failover.NewLeader, failover.Duration.Round(time.Second))
+	return failover, nil
+}
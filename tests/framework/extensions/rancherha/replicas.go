@@ -0,0 +1,102 @@
+package rancherha
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rancher/rancher/tests/framework/clients/rancher"
+	"github.com/rancher/rancher/tests/framework/extensions/kubeapi/workloads/deployments"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+// ScaleRancher scales the rancher deployment of the local cluster to the given replicas, waits for them to be ready,
+// and registers a cleanup func scaling it back to its previous replicas.
+func ScaleRancher(client *rancher.Client, replicas int32, timeout time.Duration) error {
+	previous, err := setRancherReplicas(client, replicas)
+	if err != nil {
+		return err
+	}
+
+	if previous != replicas {
+		client.Session.RegisterCleanupFunc(func() error {
+			if _, err := setRancherReplicas(client, previous); err != nil {
+				return err
+			}
+			return WaitForRancherReplicas(client, previous, timeout)
+		})
+	}
+
+	return WaitForRancherReplicas(client, replicas, timeout)
+}
+
+func setRancherReplicas(client *rancher.Client, replicas int32) (int32, error) {
+	dynamicClient, err := client.GetRancherDynamicClient()
+	if err != nil {
+		return 0, err
+	}
+
+	deploymentResource := dynamicClient.Resource(deployments.DeploymentGroupVersionResource).Namespace(RancherNamespace)
+	deployment, err := deploymentResource.Get(context.TODO(), RancherDeploymentName, metav1.GetOptions{})
+	if err != nil {
+		return 0, err
+	}
+
+	previous, _, err := unstructured.NestedInt64(deployment.Object, "spec", "replicas")
+	if err != nil {
+		return 0, err
+	}
+	if int32(previous) == replicas {
+		return replicas, nil
+	}
+
+	logrus.Infof("Scaling rancher from %d to %d replicas", previous, replicas)
+	if err := unstructured.SetNestedField(deployment.Object, int64(replicas), "spec", "replicas"); err != nil {
+		return 0, err
+	}
+	_, err = deploymentResource.Update(context.TODO(), deployment, metav1.UpdateOptions{})
+	return int32(previous), err
+}
+
+// WaitForRancherReplicas waits for the given replicas of the rancher deployment of the local cluster to be ready, and
+// for one of them to hold the controllers lease.
+func WaitForRancherReplicas(client *rancher.Client, replicas int32, timeout time.Duration) error {
+	err := kwait.Poll(5*time.Second, timeout, func() (done bool, err error) {
+		pods, err := ListRancherPods(client)
+		if err != nil {
+			logrus.Debugf("Waiting for rancher replicas: %v", err)
+			return false, nil
+		}
+
+		ready := map[string]bool{}
+		for _, pod := range pods {
+			if pod.DeletionTimestamp != nil {
+				continue
+			}
+			for _, condition := range pod.Status.Conditions {
+				if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+					ready[pod.Name] = true
+				}
+			}
+		}
+		if int32(len(ready)) != replicas {
+			return false, nil
+		}
+
+		leader, err := GetLeader(client)
+		if err != nil {
+			logrus.Debugf("Waiting for rancher replicas: %v", err)
+			return false, nil
+		}
+		return ready[leader], nil
+	})
+	if err != nil {
+		return fmt.Errorf("rancher did not reach %d ready replicas: %w", replicas, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package rbacmatrix
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rancher/rancher/tests/framework/clients/rancher"
+	management "github.com/rancher/rancher/tests/framework/clients/rancher/generated/management/v3"
+	"github.com/stretchr/testify/assert"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ErrDenied is returned by operations that detect a denial that is not reported as a 403, e.g. a list that filters
+// out the resources the user is not allowed to see.
+var ErrDenied = errors.New("denied")
+
+// API is the API an operation is run against.
+type API string
+
+const (
+	Norman API = "norman"
+	Steve  API = "steve"
+)
+
+// Target is the cluster, project and namespace operations are run against. The namespace belongs to the project.
+type Target struct {
+	Cluster   *management.Cluster
+	Project   *management.Project
+	Namespace string
+}
+
+// Operation is a single API operation of a permission matrix. Do returns nil if the operation was allowed, and a
+// forbidden error or ErrDenied if it was denied. Allowed lists the personas expected to be allowed, every other persona
+// is expected to be denied.
+type Operation struct {
+	Name    string
+	API     API
+	Do      func(client *rancher.Client, target Target) error
+	Allowed []Persona
+}
+
+// Matrix is a permission matrix of operations and personas. When RoleTemplateID is set, a user bound to the role
+// template is added as the RoleTemplatePersona, and RoleTemplateAllowed lists the names of the operations it is
+// expected to be allowed.
+type Matrix struct {
+	Operations          []Operation
+	RoleTemplateID      string
+	RoleTemplateAllowed []string
+}
+
+// Case is a single generated test of a matrix.
+type Case struct {
+	Persona       Persona
+	Operation     Operation
+	ExpectAllowed bool
+}
+
+// Name returns the subtest name of the case.
+func (c Case) Name() string {
+	expectation := "denied"
+	if c.ExpectAllowed {
+		expectation = "allowed"
+	}
+	return fmt.Sprintf("%s %s %s is %s", c.Persona, c.Operation.API, c.Operation.Name, expectation)
+}
+
+// Cases generates one case per operation and persona of the matrix.
+func (m Matrix) Cases() []Case {
+	personas := DefaultPersonas
+	if m.RoleTemplateID != "" {
+		personas = append(append([]Persona{}, DefaultPersonas...), RoleTemplatePersona)
+	}
+
+	var cases []Case
+	for _, operation := range m.Operations {
+		for _, persona := range personas {
+			expectAllowed := contains(operation.Allowed, persona)
+			if persona == RoleTemplatePersona {
+				expectAllowed = containsString(m.RoleTemplateAllowed, operation.Name)
+			}
+
+			cases = append(cases, Case{
+				Persona:       persona,
+				Operation:     operation,
+				ExpectAllowed: expectAllowed,
+			})
+		}
+	}
+	return cases
+}
+
+// Run runs every case of the matrix as a subtest, with the client of the persona of the case.
+func (m Matrix) Run(t *testing.T, clients map[Persona]*rancher.Client, target Target) {
+	for _, c := range m.Cases() {
+		c := c
+		client, ok := clients[c.Persona]
+		if !ok {
+			continue
+		}
+
+		t.Run(c.Name(), func(t *testing.T) {
+			allowed, err := Allowed(c.Operation.Do(client, target))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assert.Equal(t, c.ExpectAllowed, allowed)
+		})
+	}
+}
+
+// Allowed returns whether the error of an operation means it was allowed or denied. Errors that are neither nil nor a
+// denial are returned.
+func Allowed(err error) (bool, error) {
+	if err == nil {
+		return true, nil
+	}
+	if IsDenied(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// IsDenied returns true if the error is a denial from Norman, Steve or the kubernetes API.
+func IsDenied(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrDenied) || k8sErrors.IsForbidden(err) {
+		return true
+	}
+
+	message := err.Error()
+	return strings.Contains(message, "403 Forbidden") || strings.Contains(message, "forbidden")
+}
+
+func contains(personas []Persona, persona Persona) bool {
+	for _, p := range personas {
+		if p == persona {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package rbacmatrix
+
+import (
+	"github.com/rancher/rancher/tests/framework/clients/rancher"
+	management "github.com/rancher/rancher/tests/framework/clients/rancher/generated/management/v3"
+	"github.com/rancher/rancher/tests/framework/extensions/configmaps"
+	"github.com/rancher/rancher/tests/framework/extensions/namespaces"
+	namegen "github.com/rancher/rancher/tests/framework/pkg/namegenerator"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const nodeSteveType = "node"
+
+// DefaultOperations returns the standard operations of a permission matrix, covering cluster, project and namespace
+// scoped resources on both the Norman and Steve APIs.
+func DefaultOperations() []Operation {
+	return []Operation{
+		{
+			Name:    "get cluster",
+			API:     Norman,
+			Do:      getCluster,
+			Allowed: []Persona{Admin, ClusterOwner, ProjectMember, ReadOnly},
+		},
+		{
+			Name:    "get project",
+			API:     Norman,
+			Do:      getProject,
+			Allowed: []Persona{Admin, ClusterOwner, ProjectMember, ReadOnly},
+		},
+		{
+			Name:    "create project",
+			API:     Norman,
+			Do:      createProject,
+			Allowed: []Persona{Admin, ClusterOwner},
+		},
+		{
+			Name:    "list nodes",
+			API:     Steve,
+			Do:      listNodes,
+			Allowed: []Persona{Admin, ClusterOwner},
+		},
+		{
+			Name:    "get namespace",
+			API:     Steve,
+			Do:      getNamespace,
+			Allowed: []Persona{Admin, ClusterOwner, ProjectMember, ReadOnly},
+		},
+		{
+			Name:    "create namespace",
+			API:     Steve,
+			Do:      createNamespace,
+			Allowed: []Persona{Admin, ClusterOwner, ProjectMember},
+		},
+		{
+			Name:    "create configmap",
+			API:     Steve,
+			Do:      createConfigMap,
+			Allowed: []Persona{Admin, ClusterOwner, ProjectMember},
+		},
+	}
+}
+
+func getCluster(client *rancher.Client, target Target) error {
+	_, err := client.Management.Cluster.ByID(target.Cluster.ID)
+	return err
+}
+
+func getProject(client *rancher.Client, target Target) error {
+	_, err := client.Management.Project.ByID(target.Project.ID)
+	return err
+}
+
+func createProject(client *rancher.Client, target Target) error {
+	_, err := client.Management.Project.Create(&management.Project{
+		ClusterID: target.Cluster.ID,
+		Name:      namegen.AppendRandomString("rbacmatrix-"),
+	})
+	return err
+}
+
+// listNodes lists the nodes of the downstream cluster. Steve filters out the resources a user can't see instead of
+// returning a 403, and every cluster has nodes, so an empty list is a denial.
+func listNodes(client *rancher.Client, target Target) error {
+	steveClient, err := client.Steve.ProxyDownstream(target.Cluster.ID)
+	if err != nil {
+		return err
+	}
+
+	nodes, err := steveClient.SteveType(nodeSteveType).List(nil)
+	if err != nil {
+		return err
+	}
+	if len(nodes.Data) == 0 {
+		return ErrDenied
+	}
+	return nil
+}
+
+func getNamespace(client *rancher.Client, target Target) error {
+	steveClient, err := client.Steve.ProxyDownstream(target.Cluster.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = steveClient.SteveType(namespaces.NamespaceSteveType).ByID(target.Namespace)
+	return err
+}
+
+func createNamespace(client *rancher.Client, target Target) error {
+	_, err := namespaces.CreateNamespace(client, namegen.AppendRandomString("rbacmatrix-"), "", nil, nil, target.Project)
+	return err
+}
+
+func createConfigMap(client *rancher.Client, target Target) error {
+	steveClient, err := client.Steve.ProxyDownstream(target.Cluster.ID)
+	if err != nil {
+		return err
+	}
+
+	configMap := &coreV1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      namegen.AppendRandomString("rbacmatrix-"),
+			Namespace: target.Namespace,
+		},
+		Data: map[string]string{"key": "value"},
+	}
+	_, err = steveClient.SteveType(configmaps.ConfigMapSteveType).Create(configMap)
+	return err
+}
@@ -0,0 +1,103 @@
+package rbacmatrix
+
+import (
+	"fmt"
+
+	"github.com/rancher/rancher/tests/framework/clients/rancher"
+	management "github.com/rancher/rancher/tests/framework/clients/rancher/generated/management/v3"
+	"github.com/rancher/rancher/tests/framework/extensions/users"
+	password "github.com/rancher/rancher/tests/framework/extensions/users/passwordgenerator"
+	namegen "github.com/rancher/rancher/tests/framework/pkg/namegenerator"
+)
+
+// Persona is a user with a well known set of permissions.
+type Persona string
+
+const (
+	Admin               Persona = "admin"
+	ClusterOwner        Persona = "cluster-owner"
+	ProjectMember       Persona = "project-member"
+	ReadOnly            Persona = "read-only"
+	RoleTemplatePersona Persona = "role-template"
+
+	standardUser = "user"
+)
+
+// DefaultPersonas are the personas of every matrix.
+var DefaultPersonas = []Persona{Admin, ClusterOwner, ProjectMember, ReadOnly}
+
+// personaRoles are the role templates bound to the standard user of each persona. Admin uses the admin client.
+var personaRoles = map[Persona]string{
+	ClusterOwner:  "cluster-owner",
+	ProjectMember: "project-member",
+	ReadOnly:      "read-only",
+}
+
+// SetupPersonas creates a standard user per persona, binds it to the role template of the persona in the cluster or
+// project of the target, and returns a client per persona. When roleTemplateID is set, a user bound to it is returned
+// as the RoleTemplatePersona, in the cluster or project depending on the context of the role template.
+func SetupPersonas(client *rancher.Client, target Target, roleTemplateID string) (map[Persona]*rancher.Client, error) {
+	clients := map[Persona]*rancher.Client{
+		Admin: client,
+	}
+
+	for _, persona := range []Persona{ClusterOwner, ProjectMember, ReadOnly} {
+		context := "project"
+		if persona == ClusterOwner {
+			context = "cluster"
+		}
+
+		personaClient, err := newPersonaClient(client, target, personaRoles[persona], context)
+		if err != nil {
+			return nil, fmt.Errorf("setting up persona %s: %w", persona, err)
+		}
+		clients[persona] = personaClient
+	}
+
+	if roleTemplateID != "" {
+		roleTemplate, err := client.Management.RoleTemplate.ByID(roleTemplateID)
+		if err != nil {
+			return nil, err
+		}
+
+		personaClient, err := newPersonaClient(client, target, roleTemplateID, roleTemplate.Context)
+		if err != nil {
+			return nil, fmt.Errorf("setting up persona for role template %s: %w", roleTemplateID, err)
+		}
+		clients[RoleTemplatePersona] = personaClient
+	}
+
+	return clients, nil
+}
+
+func newPersonaClient(client *rancher.Client, target Target, roleTemplateID, context string) (*rancher.Client, error) {
+	enabled := true
+	username := namegen.AppendRandomString("testuser-")
+	testpassword := password.GenerateUserPassword("testpass-")
+	user := &management.User{
+		Username: username,
+		Password: testpassword,
+		Name:     username,
+		Enabled:  &enabled,
+	}
+
+	newUser, err := users.CreateUserWithRole(client, user, standardUser)
+	if err != nil {
+		return nil, err
+	}
+	newUser.Password = user.Password
+
+	switch context {
+	case "cluster":
+		err = users.AddClusterRoleToUser(client, target.Cluster, newUser, roleTemplateID)
+	case "project":
+		err = users.AddProjectMember(client, target.Project, newUser, roleTemplateID)
+	default:
+		err = fmt.Errorf("role template %s has unsupported context %q", roleTemplateID, context)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return client.AsUser(newUser)
+}
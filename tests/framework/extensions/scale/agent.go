@@ -0,0 +1,261 @@
+package scale
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rancher/remotedialer"
+	"github.com/rancher/wrangler/pkg/randomtoken"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	tokenHeader  = "X-API-Tunnel-Token"
+	paramsHeader = "X-API-Tunnel-Params"
+
+	fakeKubernetesVersion = "v1.25.9"
+)
+
+// Agent is a fake cluster agent of a synthetic cluster. It connects to rancher with the registration token of its
+// cluster like the cattle-cluster-agent does, and tunnels the requests of rancher to a fake kubernetes API, which
+// serves empty lists, idle watches and accepts writes. The controllers of rancher register and connect the cluster,
+// but there are no workloads, nodes or agent deployment in it.
+type Agent struct {
+	ClusterID string
+	server    *httptest.Server
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// StartAgent starts a fake agent for the cluster, which reconnects to rancher until it's stopped.
+func StartAgent(rancherHost, clusterID, registrationToken string, insecure bool) (*Agent, error) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(fakeKubernetesAPI))
+	server.StartTLS()
+
+	token, err := randomtoken.Generate()
+	if err != nil {
+		server.Close()
+		return nil, err
+	}
+
+	params, err := json.Marshal(map[string]interface{}{
+		"cluster": map[string]interface{}{
+			"address": server.Listener.Addr().String(),
+			"token":   token,
+			"caCert":  base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})),
+		},
+	})
+	if err != nil {
+		server.Close()
+		return nil, err
+	}
+
+	headers := http.Header{
+		tokenHeader:  {registrationToken},
+		paramsHeader: {base64.StdEncoding.EncodeToString(params)},
+	}
+	dialer := &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: 30 * time.Second,
+		TLSClientConfig:  &tls.Config{InsecureSkipVerify: insecure},
+	}
+	wsURL := fmt.Sprintf("wss://%s/v3/connect", rancherHost)
+	address := server.Listener.Addr().String()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	agent := &Agent{
+		ClusterID: clusterID,
+		server:    server,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+
+	go func() {
+		defer close(agent.done)
+		for {
+			// Rancher may only dial the fake kubernetes API of the agent.
+			err := remotedialer.ClientConnect(ctx, wsURL, headers, dialer, func(proto, dialAddress string) bool {
+				return proto == "tcp" && dialAddress == address
+			}, nil)
+			if ctx.Err() != nil {
+				return
+			}
+			logrus.Debugf("Fake agent of cluster %s reconnecting: %v", clusterID, err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(5 * time.Second):
+			}
+		}
+	}()
+
+	return agent, nil
+}
+
+// Stop disconnects the agent and stops its fake kubernetes API.
+func (a *Agent) Stop() {
+	a.cancel()
+	<-a.done
+	a.server.Close()
+}
+
+// fakeKubernetesAPI serves the discovery, health and version endpoints of a kubernetes API with only the core group,
+// an empty list for every list request, an idle watch for every watch request, and echoes written objects back.
+func fakeKubernetesAPI(rw http.ResponseWriter, req *http.Request) {
+	switch req.URL.Path {
+	case "/healthz", "/livez", "/readyz":
+		_, _ = rw.Write([]byte("ok"))
+		return
+	case "/version":
+		writeJSON(rw, http.StatusOK, map[string]interface{}{
+			"major":      "1",
+			"minor":      "25",
+			"gitVersion": fakeKubernetesVersion,
+			"platform":   "linux/amd64",
+		})
+		return
+	case "/api":
+		writeJSON(rw, http.StatusOK, map[string]interface{}{
+			"kind":     "APIVersions",
+			"versions": []string{"v1"},
+		})
+		return
+	case "/api/v1":
+		writeJSON(rw, http.StatusOK, coreResources())
+		return
+	case "/apis":
+		writeJSON(rw, http.StatusOK, map[string]interface{}{
+			"kind":       "APIGroupList",
+			"apiVersion": "v1",
+			"groups":     []interface{}{},
+		})
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		if req.URL.Query().Get("watch") == "true" || req.URL.Query().Get("watch") == "1" {
+			idleWatch(rw, req)
+			return
+		}
+		if isList(req.URL) {
+			writeJSON(rw, http.StatusOK, map[string]interface{}{
+				"kind":       "List",
+				"apiVersion": "v1",
+				"metadata":   map[string]interface{}{"resourceVersion": "1"},
+				"items":      []interface{}{},
+			})
+			return
+		}
+		writeStatus(rw, http.StatusNotFound, "NotFound")
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		body, err := io.ReadAll(req.Body)
+		if err != nil || !json.Valid(body) {
+			writeStatus(rw, http.StatusBadRequest, "BadRequest")
+			return
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		if req.Method == http.MethodPost {
+			rw.WriteHeader(http.StatusCreated)
+		}
+		_, _ = rw.Write(body)
+	case http.MethodDelete:
+		writeStatus(rw, http.StatusOK, "")
+	default:
+		writeStatus(rw, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+// isList returns whether the path is a collection of the core group, such as /api/v1/pods or
+// /api/v1/namespaces/default/pods, rather than a single object.
+func isList(u *url.URL) bool {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(u.Path, "/api/v1"), "/"), "/")
+	if len(parts) == 3 && parts[0] == "namespaces" {
+		return true
+	}
+	return len(parts) == 1 && parts[0] != ""
+}
+
+// idleWatch holds a watch open without events until its timeout or the request is cancelled.
+func idleWatch(rw http.ResponseWriter, req *http.Request) {
+	timeout := 5 * time.Minute
+	if seconds := req.URL.Query().Get("timeoutSeconds"); seconds != "" {
+		if n, err := strconv.Atoi(seconds); err == nil {
+			timeout = time.Duration(n) * time.Second
+		}
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusOK)
+	if flusher, ok := rw.(http.Flusher); ok {
+		flusher.Flush()
+	}
+
+	select {
+	case <-req.Context().Done():
+	case <-time.After(timeout):
+	}
+}
+
+func coreResources() map[string]interface{} {
+	var resources []interface{}
+	for _, resource := range []struct {
+		name, kind string
+		namespaced bool
+	}{
+		{"configmaps", "ConfigMap", true},
+		{"endpoints", "Endpoints", true},
+		{"events", "Event", true},
+		{"namespaces", "Namespace", false},
+		{"nodes", "Node", false},
+		{"pods", "Pod", true},
+		{"secrets", "Secret", true},
+		{"serviceaccounts", "ServiceAccount", true},
+		{"services", "Service", true},
+	} {
+		resources = append(resources, map[string]interface{}{
+			"name":         resource.name,
+			"singularName": "",
+			"namespaced":   resource.namespaced,
+			"kind":         resource.kind,
+			"verbs":        []string{"create", "delete", "get", "list", "patch", "update", "watch"},
+		})
+	}
+	return map[string]interface{}{
+		"kind":         "APIResourceList",
+		"groupVersion": "v1",
+		"resources":    resources,
+	}
+}
+
+func writeStatus(rw http.ResponseWriter, code int, reason string) {
+	status := "Success"
+	if code >= http.StatusBadRequest {
+		status = "Failure"
+	}
+	writeJSON(rw, code, map[string]interface{}{
+		"kind":       "Status",
+		"apiVersion": "v1",
+		"status":     status,
+		"reason":     reason,
+		"code":       code,
+	})
+}
+
+func writeJSON(rw http.ResponseWriter, code int, obj interface{}) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(code)
+	_ = json.NewEncoder(rw).Encode(obj)
+}
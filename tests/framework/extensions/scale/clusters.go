@@ -0,0 +1,205 @@
+package scale
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rancher/norman/types"
+	apisV1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/tests/framework/clients/rancher"
+	v1 "github.com/rancher/rancher/tests/framework/clients/rancher/v1"
+	"github.com/rancher/rancher/tests/framework/extensions/clusters"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// SyntheticLabel marks the synthetic clusters of scale runs.
+	SyntheticLabel = "scale.cattle.io/synthetic"
+
+	namespace = "fleet-default"
+)
+
+// ClusterResult is the result of registering a single synthetic cluster.
+type ClusterResult struct {
+	Name      string
+	ClusterID string
+	// Registered is the time from creating the cluster to the controllers creating its management cluster and
+	// registration token.
+	Registered time.Duration
+	// Connected is the time from creating the cluster to the controllers marking it connected through its fake agent.
+	Connected time.Duration
+	Err       error
+}
+
+type registration struct {
+	result  ClusterResult
+	cluster *v1.SteveAPIObject
+	agent   *Agent
+}
+
+// RegisterSyntheticClusters creates count synthetic imported clusters named after the prefix, concurrency at a time,
+// starts a fake agent for each of them, and waits for them to be connected. The clusters are deleted and their agents
+// stopped when the session of the client is cleaned up. It returns the result of each cluster and how long registering
+// all of them took.
+func RegisterSyntheticClusters(client *rancher.Client, prefix string, count, concurrency int, timeout time.Duration) ([]ClusterResult, time.Duration) {
+	names := make(chan string)
+	registrations := make(chan registration)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range names {
+				registrations <- registerSyntheticCluster(client, name, timeout)
+			}
+		}()
+	}
+
+	start := time.Now()
+	go func() {
+		for i := 0; i < count; i++ {
+			names <- fmt.Sprintf("%s-%d", prefix, i)
+		}
+		close(names)
+		wg.Wait()
+		close(registrations)
+	}()
+
+	// The cleanup funcs are registered here as the session isn't safe for concurrent use.
+	var results []ClusterResult
+	for r := range registrations {
+		if r.cluster != nil {
+			cluster := r.cluster
+			client.Session.RegisterCleanupFunc(func() error {
+				return client.Steve.SteveType(clusters.ProvisioningSteveResouceType).Delete(cluster)
+			})
+		}
+		if r.agent != nil {
+			agent := r.agent
+			client.Session.RegisterCleanupFunc(func() error {
+				agent.Stop()
+				return nil
+			})
+		}
+		if r.result.Err != nil {
+			logrus.Errorf("Synthetic cluster %s: %v", r.result.Name, r.result.Err)
+		}
+		results = append(results, r.result)
+	}
+
+	return results, time.Since(start)
+}
+
+func registerSyntheticCluster(client *rancher.Client, name string, timeout time.Duration) (r registration) {
+	r.result.Name = name
+	start := time.Now()
+
+	cluster := &apisV1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				SyntheticLabel: "true",
+			},
+		},
+	}
+	r.cluster, r.result.Err = client.Steve.SteveType(clusters.ProvisioningSteveResouceType).Create(cluster)
+	if r.result.Err != nil {
+		return
+	}
+
+	if r.result.ClusterID, r.result.Err = waitForManagementCluster(client, name, timeout); r.result.Err != nil {
+		return
+	}
+	token, err := waitForRegistrationToken(client, r.result.ClusterID, timeout)
+	if err != nil {
+		r.result.Err = err
+		return
+	}
+	r.result.Registered = time.Since(start)
+
+	insecure := client.RancherConfig.Insecure != nil && *client.RancherConfig.Insecure
+	if r.agent, r.result.Err = StartAgent(client.RancherConfig.Host, r.result.ClusterID, token, insecure); r.result.Err != nil {
+		return
+	}
+	if r.result.Err = waitForConnected(client, r.result.ClusterID, timeout); r.result.Err != nil {
+		return
+	}
+	r.result.Connected = time.Since(start)
+
+	return
+}
+
+func waitForManagementCluster(client *rancher.Client, name string, timeout time.Duration) (string, error) {
+	var clusterID string
+	err := kwait.Poll(time.Second, timeout, func() (done bool, err error) {
+		cluster, err := client.Steve.SteveType(clusters.ProvisioningSteveResouceType).ByID(namespace + "/" + name)
+		if err != nil {
+			return false, err
+		}
+
+		status := &apisV1.ClusterStatus{}
+		if err := v1.ConvertToK8sType(cluster.Status, status); err != nil {
+			return false, err
+		}
+		clusterID = status.ClusterName
+		return clusterID != "", nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("management cluster of %s was not created: %w", name, err)
+	}
+
+	return clusterID, nil
+}
+
+func waitForRegistrationToken(client *rancher.Client, clusterID string, timeout time.Duration) (string, error) {
+	var token string
+	err := kwait.Poll(time.Second, timeout, func() (done bool, err error) {
+		collection, err := client.Management.ClusterRegistrationToken.List(&types.ListOpts{
+			Filters: map[string]interface{}{
+				"clusterId": clusterID,
+			},
+		})
+		if err != nil {
+			return false, err
+		}
+
+		for _, registrationToken := range collection.Data {
+			if registrationToken.Token != "" {
+				token = registrationToken.Token
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("registration token of cluster %s was not created: %w", clusterID, err)
+	}
+
+	return token, nil
+}
+
+func waitForConnected(client *rancher.Client, clusterID string, timeout time.Duration) error {
+	err := kwait.Poll(2*time.Second, timeout, func() (done bool, err error) {
+		cluster, err := client.Management.Cluster.ByID(clusterID)
+		if err != nil {
+			return false, err
+		}
+
+		for _, condition := range cluster.Conditions {
+			if condition.Type == "Connected" {
+				return condition.Status == "True", nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("cluster %s was not connected: %w", clusterID, err)
+	}
+
+	return nil
+}
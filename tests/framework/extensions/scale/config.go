@@ -0,0 +1,26 @@
+package scale
+
+// The json/yaml config key for the scale config
+const ConfigurationFileKey = "scale"
+
+// Config is the configuration of the scale test mode. Clusters synthetic clusters are registered Concurrency at a time,
+// each with a fake agent, and the run fails if any of the Thresholds is exceeded.
+type Config struct {
+	Clusters       int        `json:"clusters" yaml:"clusters" default:"50"`
+	Concurrency    int        `json:"concurrency" yaml:"concurrency" default:"10"`
+	ClusterPrefix  string     `json:"clusterPrefix" yaml:"clusterPrefix" default:"scale"`
+	LatencySamples int        `json:"latencySamples" yaml:"latencySamples" default:"20"`
+	TimeoutSeconds int        `json:"timeoutSeconds" yaml:"timeoutSeconds" default:"1800"`
+	ReportFile     string     `json:"reportFile" yaml:"reportFile"`
+	Thresholds     Thresholds `json:"thresholds" yaml:"thresholds"`
+}
+
+// Thresholds are the limits a scale run must stay within, a zero threshold isn't checked.
+type Thresholds struct {
+	// MaxAPILatencyP95Milliseconds is the maximum 95th percentile latency of the API requests.
+	MaxAPILatencyP95Milliseconds int64 `json:"maxApiLatencyP95Milliseconds" yaml:"maxApiLatencyP95Milliseconds"`
+	// MaxRancherMemoryMiB is the maximum memory used by a rancher pod once the clusters are registered.
+	MaxRancherMemoryMiB int64 `json:"maxRancherMemoryMiB" yaml:"maxRancherMemoryMiB"`
+	// MinClustersPerMinute is the minimum rate at which the controllers register and connect clusters.
+	MinClustersPerMinute float64 `json:"minClustersPerMinute" yaml:"minClustersPerMinute"`
+}
@@ -0,0 +1,230 @@
+package scale
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/rancher/rancher/tests/framework/clients/rancher"
+	"github.com/rancher/rancher/tests/framework/extensions/clusters"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	rancherNamespace   = "cattle-system"
+	rancherPodSelector = "app=rancher"
+	mib                = 1024 * 1024
+)
+
+// PodMetricsGroupVersionResource is the required Group Version Resource for accessing the metrics of pods in a
+// cluster, using the dynamic client.
+var PodMetricsGroupVersionResource = schema.GroupVersionResource{
+	Group:    "metrics.k8s.io",
+	Version:  "v1beta1",
+	Resource: "pods",
+}
+
+// Latency summarizes the latencies of the requests to an API endpoint.
+type Latency struct {
+	Requests int           `json:"requests"`
+	Errors   int           `json:"errors"`
+	P50      time.Duration `json:"p50"`
+	P95      time.Duration `json:"p95"`
+	Max      time.Duration `json:"max"`
+}
+
+// Report is the result of a scale run.
+type Report struct {
+	Clusters          int                `json:"clusters"`
+	Connected         int                `json:"connected"`
+	Failed            int                `json:"failed"`
+	Duration          time.Duration      `json:"duration"`
+	ClustersPerMinute float64            `json:"clustersPerMinute"`
+	Registration      Latency            `json:"registration"`
+	Connection        Latency            `json:"connection"`
+	APILatency        map[string]Latency `json:"apiLatency"`
+	RancherMemoryMiB  map[string]int64   `json:"rancherMemoryMiB"`
+}
+
+// NewReport returns the report of the registration of the synthetic clusters, which took duration.
+func NewReport(results []ClusterResult, duration time.Duration) *Report {
+	report := &Report{
+		Clusters: len(results),
+		Duration: duration,
+	}
+
+	var registered, connected []time.Duration
+	for _, result := range results {
+		if result.Err != nil {
+			report.Failed++
+			continue
+		}
+		report.Connected++
+		registered = append(registered, result.Registered)
+		connected = append(connected, result.Connected)
+	}
+	report.Registration = summarize(registered, 0)
+	report.Connection = summarize(connected, 0)
+	if duration > 0 {
+		report.ClustersPerMinute = float64(report.Connected) / duration.Minutes()
+	}
+
+	return report
+}
+
+// apiRequests are the API requests whose latency is measured, listing the resources that grow with the clusters.
+var apiRequests = map[string]func(client *rancher.Client) error{
+	"steve provisioning clusters": func(client *rancher.Client) error {
+		_, err := client.Steve.SteveType(clusters.ProvisioningSteveResouceType).List(nil)
+		return err
+	},
+	"steve management clusters": func(client *rancher.Client) error {
+		_, err := client.Steve.SteveType("management.cattle.io.cluster").List(nil)
+		return err
+	},
+	"norman clusters": func(client *rancher.Client) error {
+		_, err := client.Management.Cluster.List(nil)
+		return err
+	},
+	"norman cluster registration tokens": func(client *rancher.Client) error {
+		_, err := client.Management.ClusterRegistrationToken.List(nil)
+		return err
+	},
+}
+
+// MeasureAPILatency sends samples requests to each of the measured API endpoints and returns their latencies.
+func MeasureAPILatency(client *rancher.Client, samples int) map[string]Latency {
+	result := map[string]Latency{}
+	for name, request := range apiRequests {
+		var durations []time.Duration
+		errors := 0
+		for i := 0; i < samples; i++ {
+			start := time.Now()
+			if err := request(client); err != nil {
+				errors++
+				continue
+			}
+			durations = append(durations, time.Since(start))
+		}
+		result[name] = summarize(durations, errors)
+	}
+	return result
+}
+
+// RancherMemoryMiB returns the memory used by each rancher pod of the local cluster, from the metrics API.
+func RancherMemoryMiB(client *rancher.Client) (map[string]int64, error) {
+	dynamicClient, err := client.GetRancherDynamicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	podMetrics, err := dynamicClient.Resource(PodMetricsGroupVersionResource).Namespace(rancherNamespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: rancherPodSelector,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]int64{}
+	for _, podMetric := range podMetrics.Items {
+		containers, _, err := unstructured.NestedSlice(podMetric.Object, "containers")
+		if err != nil {
+			return nil, err
+		}
+
+		var bytes int64
+		for _, container := range containers {
+			usage, _, _ := unstructured.NestedString(container.(map[string]interface{}), "usage", "memory")
+			quantity, err := resource.ParseQuantity(usage)
+			if err != nil {
+				return nil, fmt.Errorf("invalid memory usage of pod %s: %w", podMetric.GetName(), err)
+			}
+			bytes += quantity.Value()
+		}
+		result[podMetric.GetName()] = bytes / mib
+	}
+
+	return result, nil
+}
+
+// Violations returns a description of each threshold the report exceeds.
+func (r *Report) Violations(thresholds Thresholds) []string {
+	var violations []string
+	if r.Failed > 0 {
+		violations = append(violations, fmt.Sprintf("%d of %d clusters failed to register", r.Failed, r.Clusters))
+	}
+	if limit := thresholds.MaxAPILatencyP95Milliseconds; limit > 0 {
+		var names []string
+		for name := range r.APILatency {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			latency := r.APILatency[name]
+			if latency.P95 > time.Duration(limit)*time.Millisecond {
+				violations = append(violations, fmt.Sprintf("p95 latency of %s is %s, more than %dms", name, latency.P95, limit))
+			}
+			if latency.Errors > 0 {
+				violations = append(violations, fmt.Sprintf("%d of %d requests to %s failed", latency.Errors, latency.Requests, name))
+			}
+		}
+	}
+	if limit := thresholds.MaxRancherMemoryMiB; limit > 0 {
+		var pods []string
+		for pod := range r.RancherMemoryMiB {
+			pods = append(pods, pod)
+		}
+		sort.Strings(pods)
+		for _, pod := range pods {
+			if memory := r.RancherMemoryMiB[pod]; memory > limit {
+				violations = append(violations, fmt.Sprintf("rancher pod %s uses %dMiB of memory, more than %dMiB", pod, memory, limit))
+			}
+		}
+	}
+	if limit := thresholds.MinClustersPerMinute; limit > 0 && r.ClustersPerMinute < limit {
+		violations = append(violations, fmt.Sprintf("%.2f clusters were registered per minute, less than %.2f", r.ClustersPerMinute, limit))
+	}
+	return violations
+}
+
+// Write writes the report as JSON to the file.
+func (r *Report) Write(file string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, data, 0644)
+}
+
+// summarize returns the latency of the durations of the successful requests, and the number of failed requests.
+func summarize(durations []time.Duration, errors int) Latency {
+	latency := Latency{
+		Requests: len(durations) + errors,
+		Errors:   errors,
+	}
+	if len(durations) == 0 {
+		return latency
+	}
+
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	latency.P50 = percentile(sorted, 50)
+	latency.P95 = percentile(sorted, 95)
+	latency.Max = sorted[len(sorted)-1]
+	return latency
+}
+
+// percentile returns the nearest-rank percentile of the sorted durations.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,268 @@
+package scenarios
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/creasty/defaults"
+	"github.com/rancher/rancher/tests/framework/extensions/clusters"
+	"github.com/rancher/rancher/tests/framework/extensions/machinepools"
+	nodepools "github.com/rancher/rancher/tests/framework/extensions/rke1/nodepools"
+	"github.com/rancher/rancher/tests/framework/pkg/config"
+	"gopkg.in/yaml.v2"
+)
+
+// The json/yaml config key for the scenarios config
+const ConfigurationFileKey = "scenarios"
+
+const (
+	AdminUser    = "admin"
+	StandardUser = "standard"
+)
+
+// Config lists the scenario files to run. Each entry is a glob, relative paths are resolved from the directory of the
+// config file.
+type Config struct {
+	Files []string `json:"files" yaml:"files" default:"[]"`
+}
+
+// Node is a group of nodes sharing the same roles in a scenario. For node driver clusters each group is a machine or
+// node pool, for custom clusters each node of the group is registered with the given roles.
+type Node struct {
+	ControlPlane bool  `json:"controlplane,omitempty" yaml:"controlplane,omitempty"`
+	Etcd         bool  `json:"etcd,omitempty" yaml:"etcd,omitempty"`
+	Worker       bool  `json:"worker,omitempty" yaml:"worker,omitempty"`
+	Windows      bool  `json:"windows,omitempty" yaml:"windows,omitempty"`
+	Quantity     int32 `json:"quantity" yaml:"quantity" default:"1"`
+}
+
+// Scenario is a declarative provisioning test scenario. A scenario is run once per permutation of its kubernetes
+// versions, CNIs and users.
+type Scenario struct {
+	Name               string               `json:"name" yaml:"name"`
+	ClusterType        clusters.ClusterType `json:"clusterType" yaml:"clusterType"`
+	Provider           string               `json:"provider" yaml:"provider"`
+	NodeProvider       string               `json:"nodeProvider" yaml:"nodeProvider"`
+	KubernetesVersions []string             `json:"kubernetesVersions" yaml:"kubernetesVersions"`
+	CNIs               []string             `json:"cnis" yaml:"cnis"`
+	PSACT              string               `json:"psact" yaml:"psact"`
+	Hardened           bool                 `json:"hardened" yaml:"hardened"`
+	Users              []string             `json:"users" yaml:"users" default:"[\"admin\"]"`
+	Nodes              []Node               `json:"nodes" yaml:"nodes"`
+}
+
+// Permutation is a single run of a scenario.
+type Permutation struct {
+	Scenario          *Scenario
+	KubernetesVersion string
+	CNI               string
+	User              string
+}
+
+// Name returns the name of the subtest of the permutation.
+func (p Permutation) Name() string {
+	name := fmt.Sprintf("%s %s user Kubernetes version: %s", p.Scenario.Name, p.User, p.KubernetesVersion)
+	if p.CNI != "" {
+		name += " cni: " + p.CNI
+	}
+	return name
+}
+
+// LoadScenarios loads and validates every scenario of the files listed in the scenarios config.
+func LoadScenarios() ([]*Scenario, error) {
+	scenariosConfig := new(Config)
+	config.LoadConfig(ConfigurationFileKey, scenariosConfig)
+
+	baseDir := filepath.Dir(os.Getenv("CATTLE_TEST_CONFIG"))
+
+	var result []*Scenario
+	for _, pattern := range scenariosConfig.Files {
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(baseDir, pattern)
+		}
+
+		files, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			scenarios, err := LoadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, scenarios...)
+		}
+	}
+
+	return result, nil
+}
+
+// LoadFile loads and validates the scenarios of a single file. A file contains either a single scenario or a list of
+// scenarios.
+func LoadFile(file string) ([]*Scenario, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var scenarios []*Scenario
+	if err := yaml.Unmarshal(content, &scenarios); err != nil {
+		scenario := new(Scenario)
+		if err := yaml.Unmarshal(content, scenario); err != nil {
+			return nil, fmt.Errorf("failed to parse scenario file %s: %w", file, err)
+		}
+		scenarios = []*Scenario{scenario}
+	}
+
+	for _, scenario := range scenarios {
+		if err := defaults.Set(scenario); err != nil {
+			return nil, err
+		}
+		for i := range scenario.Nodes {
+			if err := defaults.Set(&scenario.Nodes[i]); err != nil {
+				return nil, err
+			}
+		}
+		if scenario.Name == "" {
+			scenario.Name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		}
+		if err := scenario.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid scenario %s in %s: %w", scenario.Name, file, err)
+		}
+	}
+
+	return scenarios, nil
+}
+
+// Validate returns an error if the scenario can't be run.
+func (s *Scenario) Validate() error {
+	switch s.ClusterType {
+	case clusters.RKE1ClusterType, clusters.RKE2ClusterType, clusters.K3SClusterType:
+	default:
+		return fmt.Errorf("clusterType must be one of %s, %s or %s", clusters.RKE1ClusterType, clusters.RKE2ClusterType, clusters.K3SClusterType)
+	}
+
+	if (s.Provider == "") == (s.NodeProvider == "") {
+		return fmt.Errorf("exactly one of provider or nodeProvider must be set")
+	}
+
+	if len(s.Nodes) == 0 {
+		return fmt.Errorf("at least one node must be defined")
+	}
+
+	var etcd, controlPlane, worker bool
+	for _, node := range s.Nodes {
+		if !node.ControlPlane && !node.Etcd && !node.Worker {
+			return fmt.Errorf("every node must have at least one role")
+		}
+		if node.Windows {
+			if s.ClusterType != clusters.RKE2ClusterType || !s.IsCustom() {
+				return fmt.Errorf("windows nodes are only supported for rke2 custom clusters")
+			}
+			if node.ControlPlane || node.Etcd || !node.Worker {
+				return fmt.Errorf("windows nodes can only have the worker role")
+			}
+		}
+		etcd = etcd || node.Etcd
+		controlPlane = controlPlane || node.ControlPlane
+		worker = worker || node.Worker
+	}
+	if !etcd || !controlPlane || !worker {
+		return fmt.Errorf("the nodes must cover the etcd, controlplane and worker roles")
+	}
+
+	for _, user := range s.Users {
+		if user != AdminUser && user != StandardUser {
+			return fmt.Errorf("users must be %s or %s", AdminUser, StandardUser)
+		}
+	}
+
+	return nil
+}
+
+// IsCustom returns true if the scenario provisions a custom cluster.
+func (s *Scenario) IsCustom() bool {
+	return s.NodeProvider != ""
+}
+
+// Permutations returns every permutation of the scenario. The kubernetes versions are expected to have been resolved
+// by the runner, since an empty list means the default version of the cluster type.
+func (s *Scenario) Permutations(kubernetesVersions []string) []Permutation {
+	cnis := s.CNIs
+	if len(cnis) == 0 || s.ClusterType == clusters.K3SClusterType {
+		cnis = []string{""}
+	}
+
+	var permutations []Permutation
+	for _, user := range s.Users {
+		for _, kubernetesVersion := range kubernetesVersions {
+			for _, cni := range cnis {
+				permutations = append(permutations, Permutation{
+					Scenario:          s,
+					KubernetesVersion: kubernetesVersion,
+					CNI:               cni,
+					User:              user,
+				})
+			}
+		}
+	}
+
+	return permutations
+}
+
+// MachinePools returns the node roles of the scenario as v2 provisioning machine pools.
+func (s *Scenario) MachinePools() []machinepools.NodeRoles {
+	var result []machinepools.NodeRoles
+	for _, node := range s.Nodes {
+		result = append(result, machinepools.NodeRoles{
+			ControlPlane: node.ControlPlane,
+			Etcd:         node.Etcd,
+			Worker:       node.Worker,
+			Quantity:     node.Quantity,
+		})
+	}
+	return result
+}
+
+// NodePools returns the node roles of the scenario as RKE1 node pools.
+func (s *Scenario) NodePools() []nodepools.NodeRoles {
+	var result []nodepools.NodeRoles
+	for _, node := range s.Nodes {
+		result = append(result, nodepools.NodeRoles{
+			ControlPlane: node.ControlPlane,
+			Etcd:         node.Etcd,
+			Worker:       node.Worker,
+			Quantity:     int64(node.Quantity),
+		})
+	}
+	return result
+}
+
+// CustomNodeRoles returns the registration command role flags of every linux node of a custom cluster, as well as the
+// number of windows nodes.
+func (s *Scenario) CustomNodeRoles() (roles []string, windowsCount int) {
+	for _, node := range s.Nodes {
+		if node.Windows {
+			windowsCount += int(node.Quantity)
+			continue
+		}
+
+		var flags []string
+		if node.Etcd {
+			flags = append(flags, "--etcd")
+		}
+		if node.ControlPlane {
+			flags = append(flags, "--controlplane")
+		}
+		if node.Worker {
+			flags = append(flags, "--worker")
+		}
+		for i := int32(0); i < node.Quantity; i++ {
+			roles = append(roles, strings.Join(flags, " "))
+		}
+	}
+	return roles, windowsCount
+}
@@ -0,0 +1,128 @@
+package windows
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rancher/rancher/tests/framework/clients/rancher"
+	management "github.com/rancher/rancher/tests/framework/clients/rancher/generated/management/v3"
+	steveV1 "github.com/rancher/rancher/tests/framework/clients/rancher/v1"
+	"github.com/rancher/rancher/tests/framework/extensions/workloads"
+	"github.com/rancher/rancher/tests/framework/pkg/nodes"
+	"github.com/rancher/rancher/tests/framework/pkg/wait"
+	"github.com/sirupsen/logrus"
+	appv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// CalicoCNI is the only CNI supported for mixed Linux/Windows RKE2 clusters
+	CalicoCNI = "calico"
+
+	containerName  = "iis"
+	deploymentName = "windows-iis"
+	imageName      = "mcr.microsoft.com/windows/servercore/iis"
+	namespace      = "default"
+	workload       = "windows-workload"
+
+	calicoHNSNetworkName = "Calico"
+	rke2ServiceName      = "rke2"
+)
+
+// WaitForSSH waits until the Windows node accepts ssh connections. Windows instances take noticeably longer than
+// Linux instances to run their user-data and to start the OpenSSH server.
+func WaitForSSH(node *nodes.Node, timeout time.Duration) error {
+	return wait.Poll(context.TODO(), wait.SSHOperation, timeout, func(context.Context) (bool, string, error) {
+		_, err := node.ExecutePowershellCommand("hostname")
+		if err != nil {
+			return false, fmt.Sprintf("ssh on Windows node %s: %v", node.NodeID, err), nil
+		}
+		return true, "", nil
+	})
+}
+
+// RegisterNodes runs the Windows registration command of the cluster registration token on every given Windows node.
+func RegisterNodes(winNodes []*nodes.Node, token *management.ClusterRegistrationToken, insecure bool) error {
+	command := token.WindowsNodeCommand
+	if insecure {
+		command = token.InsecureWindowsNodeCommand
+	}
+
+	for _, winNode := range winNodes {
+		logrus.Infof("Execute Registration Command for Windows node %s", winNode.NodeID)
+		output, err := winNode.ExecuteCommand("powershell.exe" + command)
+		if err != nil {
+			return fmt.Errorf("failed to register Windows node %s: %s: %w", winNode.NodeID, output, err)
+		}
+		logrus.Infof(output)
+	}
+
+	return nil
+}
+
+// VerifyCalico checks that the rke2 service is running on the Windows node and that Calico for Windows has created its
+// HNS network, which is required for Windows pods to get an IP address.
+func VerifyCalico(winNode *nodes.Node) error {
+	return wait.Poll(context.TODO(), wait.WindowsServicesOperation, 10*time.Minute, func(context.Context) (bool, string, error) {
+		output, err := winNode.ExecutePowershellCommand(fmt.Sprintf("(Get-Service %s).Status", rke2ServiceName))
+		if err != nil || !strings.Contains(output, "Running") {
+			return false, fmt.Sprintf("service %s on Windows node %s is not running", rke2ServiceName, winNode.NodeID), nil
+		}
+
+		output, err = winNode.ExecutePowershellCommand("Get-HnsNetwork | Select-Object -ExpandProperty Name")
+		if err != nil || !strings.Contains(output, calicoHNSNetworkName) {
+			return false, fmt.Sprintf("the %s HNS network does not exist on Windows node %s", calicoHNSNetworkName, winNode.NodeID), nil
+		}
+
+		return true, "", nil
+	})
+}
+
+// CreateIISDeployment creates an IIS deployment scheduled on the Windows nodes of the cluster, and waits for all of its
+// replicas to become available.
+func CreateIISDeployment(client *rancher.Client, clusterID string) (*appv1.Deployment, error) {
+	labels := map[string]string{}
+	labels["workload.user.cattle.io/workloadselector"] = fmt.Sprintf("apps.deployment-%v-%v", namespace, workload)
+
+	containerTemplate := workloads.NewContainer(containerName, imageName, corev1.PullIfNotPresent, []corev1.VolumeMount{}, []corev1.EnvFromSource{})
+	podTemplate := workloads.NewPodTemplate([]corev1.Container{containerTemplate}, []corev1.Volume{}, []corev1.LocalObjectReference{}, labels)
+	podTemplate.Spec.NodeSelector = map[string]string{
+		corev1.LabelOSStable: "windows",
+	}
+	deploymentTemplate := workloads.NewDeploymentTemplate(deploymentName, namespace, podTemplate, true, labels)
+
+	steveClient, err := client.Steve.ProxyDownstream(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = steveClient.SteveType(workloads.DeploymentSteveType).Create(deploymentTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment := &appv1.Deployment{}
+	// Windows container images are large, so pulling them takes a while on a fresh node
+	err = wait.Poll(client.Session.Context(), wait.DeploymentReadyOperation, 20*time.Minute, func(context.Context) (bool, string, error) {
+		deploymentResp, err := steveClient.SteveType(workloads.DeploymentSteveType).ByID(deploymentTemplate.Namespace + "/" + deploymentTemplate.Name)
+		if err != nil {
+			return false, "", err
+		}
+
+		err = steveV1.ConvertToK8sType(deploymentResp.JSONResp, deployment)
+		if err != nil {
+			return false, "", err
+		}
+
+		if deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == deployment.Status.AvailableReplicas {
+			logrus.Infof("Windows deployment %s is available", deployment.Name)
+			return true, "", nil
+		}
+
+		return false, fmt.Sprintf("deployment %s has %d available replicas", deployment.Name, deployment.Status.AvailableReplicas), nil
+	})
+
+	return deployment, err
+}
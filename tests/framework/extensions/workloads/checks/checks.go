@@ -0,0 +1,234 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rancher/rancher/tests/framework/clients/rancher"
+	steveV1 "github.com/rancher/rancher/tests/framework/clients/rancher/v1"
+	"github.com/rancher/rancher/tests/framework/extensions/namespaces"
+	"github.com/rancher/rancher/tests/framework/extensions/workloads"
+	namegen "github.com/rancher/rancher/tests/framework/pkg/namegenerator"
+	"github.com/rancher/rancher/tests/framework/pkg/wait"
+	"github.com/sirupsen/logrus"
+	appv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	JobSteveType                   = "batch.job"
+	PersistentVolumeClaimSteveType = "persistentvolumeclaim"
+	StorageClassSteveType          = "storage.k8s.io.storageclass"
+	NetworkPolicySteveType         = "networking.k8s.io.networkpolicy"
+
+	// WorkloadReadyOperation is the wait operation of the checks, its deadline can be overridden in the wait config.
+	WorkloadReadyOperation = "workloadReady"
+
+	defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+	workloadSelectorLabel         = "workload.user.cattle.io/workloadselector"
+
+	nginxImage   = "nginx"
+	busyboxImage = "busybox"
+	nginxPort    = 80
+
+	workloadTimeout = 5 * time.Minute
+)
+
+// Check is a single workload check run against a downstream cluster.
+type Check string
+
+const (
+	PersistentVolumeCheck Check = "persistentVolume"
+	IngressCheck          Check = "ingress"
+	DNSCheck              Check = "dns"
+	HostPortCheck         Check = "hostPort"
+	NetworkPolicyCheck    Check = "networkPolicy"
+)
+
+// AllChecks are the checks run by ValidateCluster when no checks are skipped.
+var AllChecks = []Check{PersistentVolumeCheck, IngressCheck, DNSCheck, HostPortCheck, NetworkPolicyCheck}
+
+// Options are the options of ValidateCluster.
+type Options struct {
+	// Skip lists checks that can't pass on the cluster, e.g. NetworkPolicyCheck for CNIs that don't enforce network
+	// policies.
+	Skip []Check
+}
+
+// OptionsForCNI returns the options of ValidateCluster for a cluster using the given CNI.
+func OptionsForCNI(cni string) Options {
+	opts := Options{}
+	// flannel on its own doesn't enforce network policies
+	if cni == "flannel" {
+		opts.Skip = append(opts.Skip, NetworkPolicyCheck)
+	}
+	return opts
+}
+
+func (o Options) skipped(check Check) bool {
+	for _, skip := range o.Skip {
+		if skip == check {
+			return true
+		}
+	}
+	return false
+}
+
+// validator holds the downstream client and the namespace the checks run in.
+type validator struct {
+	client      *rancher.Client
+	steveClient *steveV1.Client
+	namespace   string
+}
+
+// ValidateCluster deploys a standard battery of workloads to the downstream cluster and asserts that they work, to
+// confirm that a cluster is actually usable rather than merely active. The checks run in a fresh namespace that is
+// removed by the session cleanup. The namespace is labeled as privileged, since the hostPort and DNS checks
+// are not allowed under a restricted pod security admission config.
+func ValidateCluster(client *rancher.Client, clusterID string, opts Options) error {
+	steveClient, err := client.Steve.ProxyDownstream(clusterID)
+	if err != nil {
+		return err
+	}
+
+	namespaceName := namegen.AppendRandomString("workload-checks")
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: namespaceName,
+			Labels: map[string]string{
+				"pod-security.kubernetes.io/enforce": "privileged",
+			},
+		},
+	}
+	_, err = steveClient.SteveType(namespaces.NamespaceSteveType).Create(namespace)
+	if err != nil {
+		return err
+	}
+
+	v := &validator{
+		client:      client,
+		steveClient: steveClient,
+		namespace:   namespaceName,
+	}
+
+	checkFuncs := map[Check]func() error{
+		PersistentVolumeCheck: v.checkPersistentVolume,
+		IngressCheck:          v.checkIngress,
+		DNSCheck:              v.checkDNS,
+		HostPortCheck:         v.checkHostPort,
+		NetworkPolicyCheck:    v.checkNetworkPolicy,
+	}
+
+	for _, check := range AllChecks {
+		if opts.skipped(check) {
+			logrus.Infof("Skipping %s workload check on cluster %s", check, clusterID)
+			continue
+		}
+
+		logrus.Infof("Running %s workload check on cluster %s", check, clusterID)
+		if err := checkFuncs[check](); err != nil {
+			return fmt.Errorf("%s workload check failed on cluster %s: %w", check, clusterID, err)
+		}
+	}
+
+	return nil
+}
+
+// createDeployment creates a deployment of the pod template and waits until all of its replicas are available.
+func (v *validator) createDeployment(name string, podTemplate corev1.PodTemplateSpec) (*appv1.Deployment, error) {
+	deploymentTemplate := workloads.NewDeploymentTemplate(name, v.namespace, podTemplate, true, nil)
+	_, err := v.steveClient.SteveType(workloads.DeploymentSteveType).Create(deploymentTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment := &appv1.Deployment{}
+	err = wait.Poll(v.client.Session.Context(), WorkloadReadyOperation, workloadTimeout, func(context.Context) (bool, string, error) {
+		deploymentResp, err := v.steveClient.SteveType(workloads.DeploymentSteveType).ByID(v.namespace + "/" + name)
+		if err != nil {
+			return false, "", err
+		}
+
+		err = steveV1.ConvertToK8sType(deploymentResp.JSONResp, deployment)
+		if err != nil {
+			return false, "", err
+		}
+
+		if deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == deployment.Status.AvailableReplicas {
+			return true, "", nil
+		}
+
+		return false, fmt.Sprintf("deployment %s/%s has %d available replicas", v.namespace, name, deployment.Status.AvailableReplicas), nil
+	})
+
+	return deployment, err
+}
+
+// selectorFor returns the pod selector of a deployment created by createDeployment.
+func (v *validator) selectorFor(deploymentName string) map[string]string {
+	return map[string]string{workloadSelectorLabel: fmt.Sprintf("apps.deployment-%v-%v", v.namespace, deploymentName)}
+}
+
+// runJob runs a single container job with the given shell command and waits until it completes. It returns an error
+// if the job fails, unless expectFailure is set, in which case it returns an error if the job succeeds.
+func (v *validator) runJob(name, command string, expectFailure bool) error {
+	container := workloads.NewContainer(name, busyboxImage, corev1.PullIfNotPresent, []corev1.VolumeMount{}, []corev1.EnvFromSource{})
+	container.Command = []string{"sh", "-c", command}
+	container.Env = []corev1.EnvVar{
+		{
+			Name: "HOST_IP",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: "status.hostIP"},
+			},
+		},
+	}
+
+	podTemplate := workloads.NewPodTemplate([]corev1.Container{container}, []corev1.Volume{}, []corev1.LocalObjectReference{}, map[string]string{"app": name})
+	podTemplate.Spec.RestartPolicy = corev1.RestartPolicyNever
+	podTemplate.Spec.NodeSelector = map[string]string{corev1.LabelOSStable: "linux"}
+
+	backoffLimit := int32(0)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: v.namespace,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template:     podTemplate,
+		},
+	}
+
+	_, err := v.steveClient.SteveType(JobSteveType).Create(job)
+	if err != nil {
+		return err
+	}
+
+	return wait.Poll(v.client.Session.Context(), WorkloadReadyOperation, workloadTimeout, func(context.Context) (bool, string, error) {
+		jobResp, err := v.steveClient.SteveType(JobSteveType).ByID(v.namespace + "/" + name)
+		if err != nil {
+			return false, "", err
+		}
+
+		err = steveV1.ConvertToK8sType(jobResp.JSONResp, job)
+		if err != nil {
+			return false, "", err
+		}
+
+		switch {
+		case job.Status.Succeeded > 0 && expectFailure:
+			return false, "", fmt.Errorf("job %s/%s succeeded, it was expected to fail", v.namespace, name)
+		case job.Status.Succeeded > 0:
+			return true, "", nil
+		case job.Status.Failed > 0 && expectFailure:
+			return true, "", nil
+		case job.Status.Failed > 0:
+			return false, "", fmt.Errorf("job %s/%s failed", v.namespace, name)
+		}
+
+		return false, fmt.Sprintf("job %s/%s is running", v.namespace, name), nil
+	})
+}
@@ -0,0 +1,205 @@
+package checks
+
+import (
+	"fmt"
+	"net/url"
+
+	steveV1 "github.com/rancher/rancher/tests/framework/clients/rancher/v1"
+	"github.com/rancher/rancher/tests/framework/extensions/ingresses"
+	"github.com/rancher/rancher/tests/framework/extensions/services"
+	"github.com/rancher/rancher/tests/framework/extensions/workloads"
+	"github.com/rancher/rancher/tests/framework/extensions/workloads/pods"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	hostPort = 30080
+
+	// retryCommand retries the wget of a job for up to two minutes, since ingress rules, DNS records and network
+	// policies take a moment to be applied.
+	retryCommand = "for i in $(seq 1 24); do %s && exit 0; sleep 5; done; exit 1"
+)
+
+// checkPersistentVolume creates a PVC of the default storage class and a deployment that writes to it. Clusters
+// without a default storage class are skipped, since provisioning doesn't install one on every provider.
+func (v *validator) checkPersistentVolume() error {
+	storageClasses, err := v.steveClient.SteveType(StorageClassSteveType).List(nil)
+	if err != nil {
+		return err
+	}
+
+	hasDefault := false
+	for _, storageClass := range storageClasses.Data {
+		if storageClass.Annotations[defaultStorageClassAnnotation] == "true" {
+			hasDefault = true
+			break
+		}
+	}
+	if !hasDefault {
+		logrus.Infof("No default storage class, skipping %s workload check", PersistentVolumeCheck)
+		return nil
+	}
+
+	name := "pvc-check"
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: v.namespace,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("1Gi"),
+				},
+			},
+		},
+	}
+	_, err = v.steveClient.SteveType(PersistentVolumeClaimSteveType).Create(pvc)
+	if err != nil {
+		return err
+	}
+
+	volumeMount := corev1.VolumeMount{Name: name, MountPath: "/data"}
+	container := workloads.NewContainer(name, busyboxImage, corev1.PullIfNotPresent, []corev1.VolumeMount{volumeMount}, []corev1.EnvFromSource{})
+	container.Command = []string{"sh", "-c", "echo ok > /data/check && cat /data/check && sleep 3600"}
+	volume := corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: name},
+		},
+	}
+	podTemplate := workloads.NewPodTemplate([]corev1.Container{container}, []corev1.Volume{volume}, []corev1.LocalObjectReference{}, nil)
+
+	_, err = v.createDeployment(name, podTemplate)
+	return err
+}
+
+// checkIngress exposes an nginx deployment through a service and an ingress, and requests it through the ingress
+// controller of the node a job runs on.
+func (v *validator) checkIngress() error {
+	name := "ingress-check"
+	if err := v.createNginxService(name); err != nil {
+		return err
+	}
+
+	hostname := fmt.Sprintf("%s.%s.local", name, v.namespace)
+	path := ingresses.NewIngressPathTemplate(networkingv1.PathTypePrefix, "/", name, nginxPort)
+	ingress := ingresses.NewIngressTemplate(name, v.namespace, hostname, []networkingv1.HTTPIngressPath{path})
+	_, err := v.steveClient.SteveType(ingresses.IngressSteveType).Create(ingress)
+	if err != nil {
+		return err
+	}
+
+	wget := fmt.Sprintf("wget -q -T 5 -O /dev/null --header 'Host: %s' http://$HOST_IP/", hostname)
+	return v.runJob(name+"-client", fmt.Sprintf(retryCommand, wget), false)
+}
+
+// checkDNS resolves the kubernetes service and an external name from a pod.
+func (v *validator) checkDNS() error {
+	nslookup := "nslookup kubernetes.default.svc.cluster.local && nslookup rancher.com"
+	return v.runJob("dns-check", fmt.Sprintf(retryCommand, nslookup), false)
+}
+
+// checkHostPort runs nginx with a host port, and requests it through the IP of the node it runs on.
+func (v *validator) checkHostPort() error {
+	name := "hostport-check"
+	container := workloads.NewContainer(name, nginxImage, corev1.PullIfNotPresent, []corev1.VolumeMount{}, []corev1.EnvFromSource{})
+	container.Ports = []corev1.ContainerPort{
+		{
+			ContainerPort: nginxPort,
+			HostPort:      hostPort,
+			Protocol:      corev1.ProtocolTCP,
+		},
+	}
+	podTemplate := workloads.NewPodTemplate([]corev1.Container{container}, []corev1.Volume{}, []corev1.LocalObjectReference{}, nil)
+	podTemplate.Spec.NodeSelector = map[string]string{corev1.LabelOSStable: "linux"}
+
+	_, err := v.createDeployment(name, podTemplate)
+	if err != nil {
+		return err
+	}
+
+	query := url.Values{"labelSelector": {labels.SelectorFromSet(v.selectorFor(name)).String()}}
+	podList, err := v.steveClient.SteveType(pods.PodResourceSteveType).NamespacedSteveClient(v.namespace).List(query)
+	if err != nil {
+		return err
+	}
+	if len(podList.Data) == 0 {
+		return fmt.Errorf("no pods found for deployment %s/%s", v.namespace, name)
+	}
+
+	podStatus := &corev1.PodStatus{}
+	err = steveV1.ConvertToK8sType(podList.Data[0].Status, podStatus)
+	if err != nil {
+		return err
+	}
+
+	wget := fmt.Sprintf("wget -q -T 5 -O /dev/null http://%s:%d/", podStatus.HostIP, hostPort)
+	return v.runJob(name+"-client", fmt.Sprintf(retryCommand, wget), false)
+}
+
+// checkNetworkPolicy checks that nginx is reachable through its service, then denies all ingress traffic to it with a
+// network policy and checks that it becomes unreachable.
+func (v *validator) checkNetworkPolicy() error {
+	name := "netpol-check"
+	if err := v.createNginxService(name); err != nil {
+		return err
+	}
+
+	serviceURL := fmt.Sprintf("http://%s.%s.svc.cluster.local/", name, v.namespace)
+	wget := fmt.Sprintf("wget -q -T 5 -O /dev/null %s", serviceURL)
+	if err := v.runJob(name+"-allowed", fmt.Sprintf(retryCommand, wget), false); err != nil {
+		return err
+	}
+
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: v.namespace,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: v.selectorFor(name)},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+	_, err := v.steveClient.SteveType(NetworkPolicySteveType).Create(networkPolicy)
+	if err != nil {
+		return err
+	}
+
+	// the job fails as soon as a request is blocked, and succeeds if the policy is never enforced
+	blocked := fmt.Sprintf("for i in $(seq 1 24); do %s || exit 1; sleep 5; done; exit 0", wget)
+	return v.runJob(name+"-denied", blocked, true)
+}
+
+// createNginxService creates an nginx deployment and a ClusterIP service with the same name in front of it.
+func (v *validator) createNginxService(name string) error {
+	container := workloads.NewContainer(name, nginxImage, corev1.PullIfNotPresent, []corev1.VolumeMount{}, []corev1.EnvFromSource{})
+	container.Ports = []corev1.ContainerPort{{ContainerPort: nginxPort, Protocol: corev1.ProtocolTCP}}
+	podTemplate := workloads.NewPodTemplate([]corev1.Container{container}, []corev1.Volume{}, []corev1.LocalObjectReference{}, nil)
+	podTemplate.Spec.NodeSelector = map[string]string{corev1.LabelOSStable: "linux"}
+
+	_, err := v.createDeployment(name, podTemplate)
+	if err != nil {
+		return err
+	}
+
+	ports := []corev1.ServicePort{
+		{
+			Name:       "http",
+			Port:       nginxPort,
+			TargetPort: intstr.FromInt(nginxPort),
+			Protocol:   corev1.ProtocolTCP,
+		},
+	}
+	service := services.NewServiceTemplate(name, v.namespace, corev1.ServiceTypeClusterIP, ports, v.selectorFor(name))
+	_, err = v.steveClient.SteveType(services.ServiceSteveType).Create(service)
+	return err
+}
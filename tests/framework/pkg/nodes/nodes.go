@@ -1,6 +1,7 @@
 package nodes
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"os/user"
@@ -30,6 +31,7 @@ type Node struct {
 	PublicIPAddress string `json:"publicIPAddress" yaml:"publicIPAddress"`
 	SSHUser         string `json:"sshUser" yaml:"sshUser"`
 	SSHKeyName      string `json:"sshKeyName" yaml:"sshKeyName"`
+	IsWindows       bool   `json:"isWindows" yaml:"isWindows"`
 	SSHKey          []byte
 }
 
@@ -120,6 +122,12 @@ func (n *Node) ExecuteCommand(command string) (string, error) {
 	return output_string, err
 }
 
+// ExecutePowershellCommand executes `command` with powershell in the specific node created. The node is expected to be
+// a Windows node that has OpenSSH server enabled.
+func (n *Node) ExecutePowershellCommand(command string) (string, error) {
+	return n.ExecuteCommand(fmt.Sprintf("powershell.exe -NoLogo -NonInteractive -Command %q", command))
+}
+
 // GetSSHKey reads in the ssh file from the .ssh directory, returns the key in []byte format
 func GetSSHKey(sshKeyname string) ([]byte, error) {
 	var keyPath string
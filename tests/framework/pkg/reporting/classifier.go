@@ -0,0 +1,92 @@
+package reporting
+
+import (
+	"regexp"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Classification is the category a test failure falls in.
+type Classification string
+
+const (
+	// ProductBug is the classification of every failure that doesn't match a known signature.
+	ProductBug Classification = "product-bug"
+	// KnownFlake is the classification of failures caused by known test or product flakiness, they are retried.
+	KnownFlake Classification = "known-flake"
+	// Infrastructure is the classification of failures caused by the infrastructure the tests run on (e.g. cloud
+	// provider capacity or rate limits), they are retried.
+	Infrastructure Classification = "infrastructure"
+)
+
+// Retryable returns true if failures of the classification should be retried.
+func (c Classification) Retryable() bool {
+	return c == KnownFlake || c == Infrastructure
+}
+
+// defaultSignatures are the signatures of known flaky failures that are always classified.
+var defaultSignatures = []Signature{
+	{
+		Name:           "ec2-insufficient-capacity",
+		Pattern:        `InsufficientInstanceCapacity`,
+		Classification: Infrastructure,
+	},
+	{
+		Name:           "cloud-rate-limit",
+		Pattern:        `(RequestLimitExceeded|Throttling|rate limit exceeded)`,
+		Classification: Infrastructure,
+	},
+	{
+		Name:           "ssh-handshake",
+		Pattern:        `ssh: handshake failed`,
+		Classification: Infrastructure,
+	},
+	{
+		Name:           "webhook-stale-cache",
+		Pattern:        `admission webhook "rancher\.cattle\.io.*" denied the request.*not found`,
+		Classification: KnownFlake,
+	},
+	{
+		Name:           "watch-closed",
+		Pattern:        `(very short watch|watch closed before UntilWithoutRetry timeout)`,
+		Classification: KnownFlake,
+	},
+}
+
+// Classifier classifies failures by matching them against known failure signatures.
+type Classifier struct {
+	signatures []Signature
+	patterns   []*regexp.Regexp
+}
+
+// NewClassifier is a constructor that creates a Classifier from the given signatures. Signatures with an invalid
+// pattern are logged and ignored.
+func NewClassifier(signatures []Signature) *Classifier {
+	classifier := &Classifier{}
+	for _, signature := range signatures {
+		pattern, err := regexp.Compile(signature.Pattern)
+		if err != nil {
+			logrus.Errorf("ignoring failure signature %s with invalid pattern: %v", signature.Name, err)
+			continue
+		}
+		classifier.signatures = append(classifier.signatures, signature)
+		classifier.patterns = append(classifier.patterns, pattern)
+	}
+	return classifier
+}
+
+// Classify returns the classification of the failure and the name of the signature it matched. Failures that don't
+// match any signature are product bugs.
+func (c *Classifier) Classify(err error) (Classification, string) {
+	if err == nil {
+		return "", ""
+	}
+
+	for i, pattern := range c.patterns {
+		if pattern.MatchString(err.Error()) {
+			return c.signatures[i].Classification, c.signatures[i].Name
+		}
+	}
+
+	return ProductBug, ""
+}
@@ -0,0 +1,32 @@
+package reporting
+
+import (
+	"github.com/rancher/rancher/tests/framework/pkg/config"
+)
+
+// The json/yaml config key for the reporting config
+const ConfigurationFileKey = "reporting"
+
+// Config is the configuration of the result reporting of the suites. Results are written to OutputDir, one JUnit XML
+// file and one JSON summary per suite.
+type Config struct {
+	OutputDir       string      `json:"outputDir" yaml:"outputDir" default:"results"`
+	MaxRetries      int         `json:"maxRetries" yaml:"maxRetries" default:"1"`
+	FlakySignatures []Signature `json:"flakySignatures" yaml:"flakySignatures"`
+}
+
+// Signature is a known failure signature. Failures whose message matches Pattern are classified with Classification,
+// and retried if the classification is retryable.
+type Signature struct {
+	Name           string         `json:"name" yaml:"name"`
+	Pattern        string         `json:"pattern" yaml:"pattern"`
+	Classification Classification `json:"classification" yaml:"classification"`
+}
+
+// LoadConfig reads the reporting config from the config file, and appends the default signatures of known flakes.
+func LoadConfig() *Config {
+	reportingConfig := new(Config)
+	config.LoadConfig(ConfigurationFileKey, reportingConfig)
+	reportingConfig.FlakySignatures = append(reportingConfig.FlakySignatures, defaultSignatures...)
+	return reportingConfig
+}
@@ -0,0 +1,284 @@
+package reporting
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Phase is the phase of a test a failure happened in.
+type Phase string
+
+const (
+	SetupPhase        Phase = "setup"
+	ProvisioningPhase Phase = "provisioning"
+	ValidationPhase   Phase = "validation"
+	UpgradePhase      Phase = "upgrade"
+	CleanupPhase      Phase = "cleanup"
+)
+
+// Status is the final status of a test.
+type Status string
+
+const (
+	Passed  Status = "passed"
+	Failed  Status = "failed"
+	Flaky   Status = "flaky"
+	Skipped Status = "skipped"
+)
+
+// Result is the machine readable result of a single test. The test fills in the cluster name, the versions and the
+// phase as it progresses, so that a failure records where it happened.
+type Result struct {
+	Name              string         `json:"name"`
+	ClusterName       string         `json:"clusterName,omitempty"`
+	KubernetesVersion string         `json:"kubernetesVersion,omitempty"`
+	RancherVersion    string         `json:"rancherVersion,omitempty"`
+	Phase             Phase          `json:"phase,omitempty"`
+	Status            Status         `json:"status"`
+	Classification    Classification `json:"classification,omitempty"`
+	Signature         string         `json:"signature,omitempty"`
+	Attempts          int            `json:"attempts"`
+	Duration          time.Duration  `json:"duration"`
+	Message           string         `json:"message,omitempty"`
+}
+
+// Summary is the JSON summary written for a suite.
+type Summary struct {
+	Suite     string        `json:"suite"`
+	StartTime time.Time     `json:"startTime"`
+	Duration  time.Duration `json:"duration"`
+	Passed    int           `json:"passed"`
+	Failed    int           `json:"failed"`
+	Flaky     int           `json:"flaky"`
+	Skipped   int           `json:"skipped"`
+	Results   []*Result     `json:"results"`
+}
+
+// Reporter collects the results of the tests of a suite, and writes them as JUnit XML and JSON summary.
+type Reporter struct {
+	suite      string
+	config     *Config
+	classifier *Classifier
+	startTime  time.Time
+
+	mu      sync.Mutex
+	results []*Result
+}
+
+// NewReporter is a constructor that creates a Reporter for the given suite, using the reporting config of the config
+// file.
+func NewReporter(suite string) *Reporter {
+	reportingConfig := LoadConfig()
+	return &Reporter{
+		suite:      suite,
+		config:     reportingConfig,
+		classifier: NewClassifier(reportingConfig.FlakySignatures),
+		startTime:  time.Now(),
+	}
+}
+
+// Run runs the operation, retrying it when it fails with a retryable classification, up to the configured max
+// retries. The result of the operation is recorded by the reporter, failures that only succeeded after
+// a retry are recorded as flaky instead of failed.
+func (r *Reporter) Run(t *testing.T, result *Result, operation func(result *Result) error) {
+	t.Helper()
+
+	start := time.Now()
+	var err error
+	for {
+		result.Attempts++
+		err = operation(result)
+		if err == nil {
+			break
+		}
+
+		result.Classification, result.Signature = r.classifier.Classify(err)
+		if !result.Classification.Retryable() || result.Attempts > r.config.MaxRetries {
+			break
+		}
+
+		logrus.Warnf("[%s] attempt %d failed with %s (%s), retrying: %v", result.Name, result.Attempts, result.Classification, result.Signature, err)
+	}
+	result.Duration = time.Since(start)
+
+	switch {
+	case err != nil:
+		result.Status = Failed
+		result.Message = err.Error()
+	case result.Attempts > 1:
+		result.Status = Flaky
+	default:
+		result.Status = Passed
+	}
+	r.Record(result)
+
+	if err != nil {
+		t.Errorf("[%s] failed during %s phase after %d attempt(s), classified as %s: %v", result.Name, result.Phase, result.Attempts, result.Classification, err)
+	}
+}
+
+// RecordSubtest records the result of a subtest that was run with suite.Run, for tests that fail through require
+// rather than by returning an error.
+func (r *Reporter) RecordSubtest(result *Result, passed bool, start time.Time) {
+	result.Attempts++
+	result.Duration = time.Since(start)
+	result.Status = Passed
+	if !passed {
+		result.Status = Failed
+		result.Classification = ProductBug
+	}
+	r.Record(result)
+}
+
+// Record adds a result to the reporter.
+func (r *Reporter) Record(result *Result) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results = append(r.results, result)
+}
+
+// Summary returns the summary of the results recorded so far.
+func (r *Reporter) Summary() *Summary {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	summary := &Summary{
+		Suite:     r.suite,
+		StartTime: r.startTime,
+		Duration:  time.Since(r.startTime),
+		Results:   append([]*Result{}, r.results...),
+	}
+	for _, result := range r.results {
+		switch result.Status {
+		case Passed:
+			summary.Passed++
+		case Failed:
+			summary.Failed++
+		case Flaky:
+			summary.Flaky++
+		case Skipped:
+			summary.Skipped++
+		}
+	}
+	return summary
+}
+
+// Write writes the JUnit XML file and the JSON summary of the suite to the configured output directory. It is meant to
+// be called from the TearDownSuite of the suite.
+func (r *Reporter) Write() error {
+	if err := os.MkdirAll(r.config.OutputDir, 0755); err != nil {
+		return err
+	}
+
+	summary := r.Summary()
+
+	summaryJSON, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(r.config.OutputDir, r.suite+"-summary.json"), summaryJSON, 0644); err != nil {
+		return err
+	}
+
+	junitXML, err := xml.MarshalIndent(newJUnitSuites(summary), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(r.config.OutputDir, r.suite+"-junit.xml"), append([]byte(xml.Header), junitXML...), 0644)
+}
+
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name      string      `xml:"name,attr"`
+	Tests     int         `xml:"tests,attr"`
+	Failures  int         `xml:"failures,attr"`
+	Skipped   int         `xml:"skipped,attr"`
+	Time      string      `xml:"time,attr"`
+	Timestamp string      `xml:"timestamp,attr"`
+	TestCases []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name       string          `xml:"name,attr"`
+	ClassName  string          `xml:"classname,attr"`
+	Time       string          `xml:"time,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Failure    *junitFailure   `xml:"failure,omitempty"`
+	Skipped    *struct{}       `xml:"skipped,omitempty"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+func newJUnitSuites(summary *Summary) *junitSuites {
+	suite := junitSuite{
+		Name:      summary.Suite,
+		Tests:     len(summary.Results),
+		Failures:  summary.Failed,
+		Skipped:   summary.Skipped,
+		Time:      fmt.Sprintf("%.3f", summary.Duration.Seconds()),
+		Timestamp: summary.StartTime.Format(time.RFC3339),
+	}
+
+	for _, result := range summary.Results {
+		testCase := junitCase{
+			Name:      result.Name,
+			ClassName: summary.Suite,
+			Time:      fmt.Sprintf("%.3f", result.Duration.Seconds()),
+		}
+
+		for name, value := range map[string]string{
+			"clusterName":       result.ClusterName,
+			"kubernetesVersion": result.KubernetesVersion,
+			"rancherVersion":    result.RancherVersion,
+			"phase":             string(result.Phase),
+			"classification":    string(result.Classification),
+			"signature":         result.Signature,
+			"status":            string(result.Status),
+		} {
+			if value != "" {
+				testCase.Properties = append(testCase.Properties, junitProperty{Name: name, Value: value})
+			}
+		}
+		sort.Slice(testCase.Properties, func(i, j int) bool {
+			return testCase.Properties[i].Name < testCase.Properties[j].Name
+		})
+
+		switch result.Status {
+		case Failed:
+			testCase.Failure = &junitFailure{
+				Message: fmt.Sprintf("failed during %s phase", result.Phase),
+				Type:    string(result.Classification),
+				Body:    result.Message,
+			}
+		case Skipped:
+			testCase.Skipped = &struct{}{}
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	return &junitSuites{Suites: []junitSuite{suite}}
+}
@@ -1,6 +1,7 @@
 package session
 
 import (
+	"context"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -15,17 +16,31 @@ type Session struct {
 	CleanupEnabled bool
 	cleanupQueue   []CleanupFunc
 	open           bool
+	ctx            context.Context
+	cancel         context.CancelFunc
 }
 
 // NewSession is a constructor instantiates a new `Session`
 func NewSession() *Session {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &Session{
 		CleanupEnabled: true,
 		cleanupQueue:   []CleanupFunc{},
 		open:           true,
+		ctx:            ctx,
+		cancel:         cancel,
 	}
 }
 
+// Context returns a context that is cancelled when the session is cleaned up, so that waits still running when a suite
+// tears down are aborted. Cleanup functions must not use it, since it is cancelled before they are called.
+func (ts *Session) Context() context.Context {
+	if ts.ctx == nil {
+		return context.Background()
+	}
+	return ts.ctx
+}
+
 // RegisterCleanupFunc is function registers clean up functions in the `Session` queue.
 // Functions passed to this method will be called in the order they are added when `Cleanup` is called.
 // If Session is closed, it will cause a panic if a new cleanup function is registered.
@@ -40,6 +55,10 @@ func (ts *Session) RegisterCleanupFunc(f CleanupFunc) {
 
 // Cleanup this method will call all registered cleanup functions in order and close the test session.
 func (ts *Session) Cleanup() {
+	if ts.cancel != nil {
+		ts.cancel()
+	}
+
 	if ts.CleanupEnabled {
 		ts.open = false
 
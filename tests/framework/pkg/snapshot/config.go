@@ -0,0 +1,36 @@
+package snapshot
+
+import (
+	"os"
+
+	"github.com/rancher/rancher/tests/framework/pkg/config"
+)
+
+// The json/yaml config key for the snapshot config
+const ConfigurationFileKey = "snapshots"
+
+// UpdateEnvVar is the environment variable that, when set to "true", makes Assert write the golden files instead of
+// comparing against them.
+const UpdateEnvVar = "UPDATE_SNAPSHOTS"
+
+// Config is the configuration of the snapshot assertions. Golden files are read from and written to Dir.
+type Config struct {
+	Dir    string `json:"dir" yaml:"dir" default:"snapshots"`
+	Update bool   `json:"update" yaml:"update"`
+}
+
+// LoadConfig reads the snapshot config from the config file. The UPDATE_SNAPSHOTS environment variable takes
+// precedence over the update field of the config.
+func LoadConfig() *Config {
+	snapshotConfig := new(Config)
+	config.LoadConfig(ConfigurationFileKey, snapshotConfig)
+
+	if snapshotConfig.Dir == "" {
+		snapshotConfig.Dir = "snapshots"
+	}
+	if os.Getenv(UpdateEnvVar) == "true" {
+		snapshotConfig.Update = true
+	}
+
+	return snapshotConfig
+}
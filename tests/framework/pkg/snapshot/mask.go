@@ -0,0 +1,87 @@
+package snapshot
+
+import (
+	"strings"
+)
+
+// MaskedValue replaces the value of every masked field in a snapshot.
+const MaskedValue = "<masked>"
+
+// DefaultMaskedFields are the fields that are masked at any depth of every snapshot, since their values change on each
+// run. It covers the dynamic fields of both Steve (kubernetes metadata) and Norman (v3) objects.
+var DefaultMaskedFields = []string{
+	// kubernetes metadata
+	"uid",
+	"resourceVersion",
+	"creationTimestamp",
+	"deletionTimestamp",
+	"generation",
+	"managedFields",
+	"selfLink",
+	// norman
+	"uuid",
+	"created",
+	"createdTS",
+	"links",
+	"actions",
+	"lastUpdateTime",
+	"lastTransitionTime",
+	"lastHeartbeatTime",
+	"lastProbeTime",
+}
+
+// maskFields replaces the value of every key of the object named after one of the fields, at any depth.
+func maskFields(obj interface{}, fields map[string]bool) {
+	switch typed := obj.(type) {
+	case map[string]interface{}:
+		for key, value := range typed {
+			if fields[key] {
+				typed[key] = MaskedValue
+				continue
+			}
+			maskFields(value, fields)
+		}
+	case []interface{}:
+		for _, value := range typed {
+			maskFields(value, fields)
+		}
+	}
+}
+
+// maskPath replaces the value at the dot separated path of the object. A "*" segment matches every key of a map and
+// every element of a list.
+func maskPath(obj interface{}, path string) {
+	maskSegments(obj, strings.Split(path, "."))
+}
+
+func maskSegments(obj interface{}, segments []string) {
+	if len(segments) == 0 {
+		return
+	}
+
+	segment, rest := segments[0], segments[1:]
+	switch typed := obj.(type) {
+	case map[string]interface{}:
+		for key, value := range typed {
+			if segment != "*" && segment != key {
+				continue
+			}
+			if len(rest) == 0 {
+				typed[key] = MaskedValue
+				continue
+			}
+			maskSegments(value, rest)
+		}
+	case []interface{}:
+		if segment != "*" {
+			return
+		}
+		for i, value := range typed {
+			if len(rest) == 0 {
+				typed[i] = MaskedValue
+				continue
+			}
+			maskSegments(value, rest)
+		}
+	}
+}
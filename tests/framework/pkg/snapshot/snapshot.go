@@ -0,0 +1,126 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var invalidFileChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// Options are the options of a snapshot assertion.
+type Options struct {
+	// Paths are dot separated paths of values to mask, "*" matches every key of a map or element of a list
+	// e.g. "spec.rkeConfig.machinePools.*.name".
+	Paths []string
+	// Fields are field names masked at any depth, in addition to DefaultMaskedFields.
+	Fields []string
+	// DisableDefaultMasks disables the masking of DefaultMaskedFields.
+	DisableDefaultMasks bool
+}
+
+// Option is a function that sets an option of a snapshot assertion.
+type Option func(*Options)
+
+// WithMaskedPaths masks the values at the given dot separated paths.
+func WithMaskedPaths(paths ...string) Option {
+	return func(o *Options) {
+		o.Paths = append(o.Paths, paths...)
+	}
+}
+
+// WithMaskedFields masks the given fields at any depth.
+func WithMaskedFields(fields ...string) Option {
+	return func(o *Options) {
+		o.Fields = append(o.Fields, fields...)
+	}
+}
+
+// WithoutDefaultMasks disables the masking of DefaultMaskedFields, for snapshots of objects whose metadata matters.
+func WithoutDefaultMasks() Option {
+	return func(o *Options) {
+		o.DisableDefaultMasks = true
+	}
+}
+
+// Normalize returns the indented JSON of the object with its dynamic values masked. Map keys are sorted by the JSON
+// encoding, so the result is stable across runs. The object can be any value that can be marshalled to JSON, e.g. a
+// Steve object, a Norman object or a list of schemas.
+func Normalize(obj interface{}, opts ...Option) ([]byte, error) {
+	options := &Options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return nil, err
+	}
+
+	fields := map[string]bool{}
+	if !options.DisableDefaultMasks {
+		for _, field := range DefaultMaskedFields {
+			fields[field] = true
+		}
+	}
+	for _, field := range options.Fields {
+		fields[field] = true
+	}
+	maskFields(generic, fields)
+
+	for _, path := range options.Paths {
+		maskPath(generic, path)
+	}
+
+	normalized, err := json.MarshalIndent(generic, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(normalized, '\n'), nil
+}
+
+// Assert compares the normalized object against the golden file of the snapshot name in the configured snapshot
+// directory, and fails the test with a diff if they don't match. When updating is enabled through the config or the
+// UPDATE_SNAPSHOTS environment variable, the golden file is written instead. A missing golden file fails the test.
+func Assert(t *testing.T, name string, obj interface{}, opts ...Option) {
+	t.Helper()
+
+	snapshotConfig := LoadConfig()
+
+	actual, err := Normalize(obj, opts...)
+	require.NoError(t, err)
+
+	path := filepath.Join(snapshotConfig.Dir, FileName(name))
+
+	if snapshotConfig.Update {
+		require.NoError(t, os.MkdirAll(snapshotConfig.Dir, 0755))
+		require.NoError(t, os.WriteFile(path, actual, 0644))
+		logrus.Infof("Updated snapshot %s", path)
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		require.Failf(t, "missing snapshot", "snapshot %s does not exist, run with %s=true to create it", path, UpdateEnvVar)
+	}
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expected), string(actual), "snapshot %s does not match, run with %s=true to update it if the change is intended", path, UpdateEnvVar)
+}
+
+// FileName returns the golden file name of the snapshot name.
+func FileName(name string) string {
+	return invalidFileChars.ReplaceAllString(name, "_") + ".json"
+}
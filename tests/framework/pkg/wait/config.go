@@ -0,0 +1,58 @@
+package wait
+
+import (
+	"time"
+
+	"github.com/rancher/rancher/tests/framework/pkg/config"
+)
+
+// The json/yaml config key for the wait config
+const ConfigurationFileKey = "waits"
+
+// Config is the configuration of Poll. Timeouts overrides the deadline of single operations by name, e.g.
+//
+//	waits:
+//	  defaultTimeout: 15m
+//	  timeouts:
+//	    clusterReady: 45m
+type Config struct {
+	DefaultTimeout   time.Duration            `json:"defaultTimeout" yaml:"defaultTimeout" default:"10m"`
+	InitialInterval  time.Duration            `json:"initialInterval" yaml:"initialInterval" default:"500ms"`
+	MaxInterval      time.Duration            `json:"maxInterval" yaml:"maxInterval" default:"30s"`
+	ProgressInterval time.Duration            `json:"progressInterval" yaml:"progressInterval" default:"1m"`
+	Timeouts         map[string]time.Duration `json:"timeouts" yaml:"timeouts"`
+}
+
+// LoadConfig reads the wait config from the config file. Unset intervals fall back to their defaults, since defaults
+// are not applied when no config file is set.
+func LoadConfig() *Config {
+	waitConfig := new(Config)
+	config.LoadConfig(ConfigurationFileKey, waitConfig)
+
+	if waitConfig.DefaultTimeout <= 0 {
+		waitConfig.DefaultTimeout = 10 * time.Minute
+	}
+	if waitConfig.InitialInterval <= 0 {
+		waitConfig.InitialInterval = 500 * time.Millisecond
+	}
+	if waitConfig.MaxInterval < waitConfig.InitialInterval {
+		waitConfig.MaxInterval = waitConfig.InitialInterval
+	}
+	if waitConfig.ProgressInterval <= 0 {
+		waitConfig.ProgressInterval = time.Minute
+	}
+
+	return waitConfig
+}
+
+// Timeout returns the deadline of the operation. The timeout of the operation in the config takes precedence over the
+// given default, which takes precedence over the default timeout of the config.
+func (c *Config) Timeout(operation string, defaultTimeout time.Duration) time.Duration {
+	if timeout, ok := c.Timeouts[operation]; ok && timeout > 0 {
+		return timeout
+	}
+	if defaultTimeout > 0 {
+		return defaultTimeout
+	}
+	return c.DefaultTimeout
+}
@@ -0,0 +1,97 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Operation names used by the extensions, their deadlines can be overridden in the wait config.
+const (
+	ClusterReadyOperation    = "clusterReady"
+	ClusterUpdateOperation   = "clusterUpdate"
+	NodesReadyOperation      = "nodesReady"
+	SSHOperation             = "ssh"
+	WindowsServicesOperation = "windowsServices"
+	DeploymentReadyOperation = "deploymentReady"
+)
+
+// ConditionFunc is the function type of `condition` needed for Poll. Besides whether the wait is done, it returns a
+// short description of the current state (e.g. the current cluster condition) that is logged periodically and
+// included in the timeout error.
+type ConditionFunc func(ctx context.Context) (done bool, progress string, err error)
+
+// Poll calls `condition` with an exponential backoff until it is done, it returns an error, the deadline of the
+// operation is reached or the context is cancelled, e.g. by the cleanup of the session. The deadline is read from the
+// wait config, falling back to `defaultTimeout`. Progress is logged every progress interval so hung waits can be
+// diagnosed from the test output.
+//
+//	err := wait.Poll(client.Session.Context(), wait.NodesReadyOperation, 30*time.Minute, func(ctx context.Context) (bool, string, error) {
+//		node, err := client.Management.Node.ByID(nodeID)
+//		if err != nil {
+//			return false, "", err
+//		}
+//		return node.State == "active", "node state " + node.State, nil
+//	})
+func Poll(ctx context.Context, operation string, defaultTimeout time.Duration, condition ConditionFunc) error {
+	waitConfig := LoadConfig()
+	return poll(ctx, waitConfig, operation, waitConfig.Timeout(operation, defaultTimeout), condition)
+}
+
+func poll(ctx context.Context, waitConfig *Config, operation string, timeout time.Duration, condition ConditionFunc) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	lastLog := start
+	interval := waitConfig.InitialInterval
+	progress := ""
+
+	for {
+		done, currentProgress, err := condition(ctx)
+		if currentProgress != "" {
+			progress = currentProgress
+		}
+		if err != nil {
+			return fmt.Errorf("waiting for %s: %w", operation, err)
+		}
+		if done {
+			logrus.Debugf("[%s] done after %s", operation, time.Since(start).Round(time.Second))
+			return nil
+		}
+
+		if time.Since(lastLog) >= waitConfig.ProgressInterval {
+			lastLog = time.Now()
+			logrus.Infof("[%s] still waiting after %s (timeout %s): %s", operation, time.Since(start).Round(time.Second), timeout, progressOrUnknown(progress))
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if ctx.Err() == context.DeadlineExceeded {
+				return fmt.Errorf("timed out after %s waiting for %s, last state: %s", timeout, operation, progressOrUnknown(progress))
+			}
+			return fmt.Errorf("waiting for %s cancelled, last state: %s: %w", operation, progressOrUnknown(progress), ctx.Err())
+		case <-timer.C:
+		}
+
+		interval *= 2
+		if interval > waitConfig.MaxInterval {
+			interval = waitConfig.MaxInterval
+		}
+	}
+}
+
+func progressOrUnknown(progress string) string {
+	if progress == "" {
+		return "unknown"
+	}
+	return progress
+}
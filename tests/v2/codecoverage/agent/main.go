@@ -29,6 +29,7 @@
 	"github.com/rancher/rancher/pkg/agent/node"
 	"github.com/rancher/rancher/pkg/agent/rancher"
 	"github.com/rancher/rancher/pkg/features"
+	"github.com/rancher/rancher/pkg/logging"
 	"github.com/rancher/rancher/pkg/logserver"
 	"github.com/rancher/rancher/pkg/rkenodeconfigclient"
 	"github.com/rancher/rancher/pkg/rkenodeconfigserver"
@@ -70,7 +71,7 @@ func runAgent(ctx context.Context) {
 		logrus.SetOutput(colorable.NewColorableStdout())
 		logserver.StartServerWithDefaults()
 		if os.Getenv("CATTLE_DEBUG") == "true" || os.Getenv("RANCHER_DEBUG") == "true" {
-			logrus.SetLevel(logrus.DebugLevel)
+			logging.SetLevel(logrus.DebugLevel)
 		}
 
 		initFeatures()
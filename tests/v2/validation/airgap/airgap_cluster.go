@@ -0,0 +1,116 @@
+package airgap
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	apiv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/tests/framework/clients/rancher"
+	v1 "github.com/rancher/rancher/tests/framework/clients/rancher/v1"
+	"github.com/rancher/rancher/tests/framework/extensions/airgap"
+	"github.com/rancher/rancher/tests/framework/extensions/clusters"
+	"github.com/rancher/rancher/tests/framework/extensions/defaults"
+	"github.com/rancher/rancher/tests/framework/extensions/registries"
+	"github.com/rancher/rancher/tests/framework/extensions/tokenregistration"
+	"github.com/rancher/rancher/tests/framework/extensions/workloads/pods"
+	namegen "github.com/rancher/rancher/tests/framework/pkg/namegenerator"
+	"github.com/rancher/rancher/tests/framework/pkg/wait"
+	provisioning "github.com/rancher/rancher/tests/v2/validation/provisioning"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	namespace = "fleet-default"
+)
+
+// TestProvisioningAirgapCustomCluster provisions a K3s or RKE2 custom cluster on nodes that have no egress to the
+// public internet, pulling every image from the private registry, and validates that the cluster and its agents
+// become ready.
+func TestProvisioningAirgapCustomCluster(t *testing.T, client *rancher.Client, airgapConfig *airgap.Config, externalNodeProvider provisioning.ExternalNodeProvider, nodesAndRoles []string, kubeVersion, cni string) {
+	adminClient, err := rancher.NewClient(client.RancherConfig.AdminToken, client.Session)
+	require.NoError(t, err)
+
+	linuxNodes, _, err := externalNodeProvider.NodeCreationFunc(client, len(nodesAndRoles), 0, false)
+	require.NoError(t, err)
+
+	for _, node := range linuxNodes {
+		err = airgap.DisableEgress(node, airgapConfig.AllowedCIDRs)
+		require.NoError(t, err)
+
+		err = airgap.VerifyNoEgress(node)
+		require.NoError(t, err)
+	}
+
+	authSecretName, err := airgap.CreateRegistryAuthSecret(client, namespace, airgapConfig)
+	require.NoError(t, err)
+
+	clusterName := namegen.AppendRandomString("airgap")
+	cluster := clusters.NewK3SRKE2ClusterConfig(clusterName, namespace, cni, "", kubeVersion, "", nil)
+	cluster.Spec.RKEConfig.Registries = airgap.NewRegistryConfig(airgapConfig, authSecretName)
+	cluster.Spec.AgentEnvVars = airgap.NewProxyEnvVars(airgapConfig)
+	cluster.Spec.RKEConfig.MachineGlobalConfig.Data["system-default-registry"] = airgapConfig.RegistryFQDN
+
+	clusterResp, err := clusters.CreateK3SRKE2Cluster(client, cluster)
+	require.NoError(t, err)
+
+	client, err = client.ReLogin()
+	require.NoError(t, err)
+
+	customCluster, err := client.Steve.SteveType(clusters.ProvisioningSteveResouceType).ByID(clusterResp.ID)
+	require.NoError(t, err)
+
+	clusterStatus := &apiv1.ClusterStatus{}
+	err = v1.ConvertToK8sType(customCluster.Status, clusterStatus)
+	require.NoError(t, err)
+
+	token, err := tokenregistration.GetRegistrationToken(client, clusterStatus.ClusterName)
+	require.NoError(t, err)
+
+	for key, linuxNode := range linuxNodes {
+		t.Logf("Execute Registration Command for node %s", linuxNode.NodeID)
+		command := fmt.Sprintf("%s %s", token.InsecureNodeCommand, nodesAndRoles[key])
+
+		output, err := linuxNode.ExecuteCommand(command)
+		require.NoError(t, err)
+
+		t.Logf(output)
+	}
+
+	kubeProvisioningClient, err := adminClient.GetKubeAPIProvisioningClient()
+	require.NoError(t, err)
+
+	result, err := kubeProvisioningClient.Clusters(namespace).Watch(context.TODO(), metav1.ListOptions{
+		FieldSelector:  "metadata.name=" + clusterName,
+		TimeoutSeconds: &defaults.WatchTimeoutSeconds,
+	})
+	require.NoError(t, err)
+
+	checkFunc := clusters.IsProvisioningClusterReady
+	err = wait.WatchWait(result, checkFunc)
+	require.NoError(t, err)
+	assert.Equal(t, clusterName, clusterResp.ObjectMeta.Name)
+
+	clusterID, err := clusters.GetClusterIDByName(adminClient, clusterName)
+	require.NoError(t, err)
+
+	clusterToken, err := clusters.CheckServiceAccountTokenSecret(client, clusterName)
+	require.NoError(t, err)
+	assert.NotEmpty(t, clusterToken)
+
+	podResults, podErrors := pods.StatusPods(client, clusterID)
+	assert.NotEmpty(t, podResults)
+	assert.Empty(t, podErrors)
+
+	downstreamClient, err := adminClient.Steve.ProxyDownstream(clusterID)
+	require.NoError(t, err)
+
+	podsList, err := downstreamClient.SteveType(pods.PodResourceSteveType).List(nil)
+	require.NoError(t, err)
+
+	havePrefix, err := registries.CheckAllClusterPodsForRegistryPrefix(podsList, airgapConfig.RegistryFQDN)
+	require.NoError(t, err)
+	assert.True(t, havePrefix)
+}
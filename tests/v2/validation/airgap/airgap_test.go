@@ -0,0 +1,123 @@
+package airgap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rancher/rancher/tests/framework/clients/rancher"
+	"github.com/rancher/rancher/tests/framework/extensions/airgap"
+	"github.com/rancher/rancher/tests/framework/extensions/clusters"
+	"github.com/rancher/rancher/tests/framework/extensions/clusters/kubernetesversions"
+	"github.com/rancher/rancher/tests/framework/pkg/config"
+	"github.com/rancher/rancher/tests/framework/pkg/reporting"
+	"github.com/rancher/rancher/tests/framework/pkg/session"
+	provisioning "github.com/rancher/rancher/tests/v2/validation/provisioning"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type AirgapProvisioningTestSuite struct {
+	suite.Suite
+	client                 *rancher.Client
+	session                *session.Session
+	reporter               *reporting.Reporter
+	airgapConfig           *airgap.Config
+	rke2KubernetesVersions []string
+	k3sKubernetesVersions  []string
+	cnis                   []string
+	nodeProviders          []string
+}
+
+func (a *AirgapProvisioningTestSuite) TearDownSuite() {
+	a.session.Cleanup()
+
+	err := a.reporter.Write()
+	require.NoError(a.T(), err)
+}
+
+func (a *AirgapProvisioningTestSuite) SetupSuite() {
+	testSession := session.NewSession()
+	a.session = testSession
+	a.reporter = reporting.NewReporter("airgap-provisioning")
+
+	airgapConfig, err := airgap.LoadConfig()
+	require.NoError(a.T(), err)
+	a.airgapConfig = airgapConfig
+
+	clustersConfig := new(provisioning.Config)
+	config.LoadConfig(provisioning.ConfigurationFileKey, clustersConfig)
+
+	a.cnis = clustersConfig.CNIs
+	a.nodeProviders = clustersConfig.NodeProviders
+
+	client, err := rancher.NewClient("", testSession)
+	require.NoError(a.T(), err)
+
+	a.client = client
+
+	a.rke2KubernetesVersions, err = kubernetesversions.Default(a.client, clusters.RKE2ClusterType.String(), clustersConfig.RKE2KubernetesVersions)
+	require.NoError(a.T(), err)
+
+	a.k3sKubernetesVersions, err = kubernetesversions.Default(a.client, clusters.K3SClusterType.String(), clustersConfig.K3SKubernetesVersions)
+	require.NoError(a.T(), err)
+
+	if airgapConfig.Bastion != nil {
+		err = airgap.SeedRegistry(airgapConfig)
+		require.NoError(a.T(), err)
+	}
+}
+
+func (a *AirgapProvisioningTestSuite) TestProvisioningAirgapCustomCluster() {
+	nodeRoles0 := []string{
+		"--etcd --controlplane --worker",
+	}
+
+	nodeRoles1 := []string{
+		"--etcd",
+		"--controlplane",
+		"--worker",
+	}
+
+	tests := []struct {
+		name               string
+		nodeRoles          []string
+		kubernetesVersions []string
+		cnis               []string
+	}{
+		{"RKE2 1 Node all roles", nodeRoles0, a.rke2KubernetesVersions, a.cnis},
+		{"RKE2 3 nodes - 1 role per node", nodeRoles1, a.rke2KubernetesVersions, a.cnis},
+		{"K3S 1 Node all roles", nodeRoles0, a.k3sKubernetesVersions, []string{""}},
+		{"K3S 3 nodes - 1 role per node", nodeRoles1, a.k3sKubernetesVersions, []string{""}},
+	}
+
+	for _, tt := range tests {
+		testSession := session.NewSession()
+		defer testSession.Cleanup()
+
+		client, err := a.client.WithSession(testSession)
+		require.NoError(a.T(), err)
+
+		for _, nodeProviderName := range a.nodeProviders {
+			externalNodeProvider := provisioning.ExternalNodeProviderSetup(nodeProviderName)
+			for _, kubeVersion := range tt.kubernetesVersions {
+				for _, cni := range tt.cnis {
+					name := tt.name + " Node Provider: " + nodeProviderName + " Kubernetes version: " + kubeVersion
+					if cni != "" {
+						name += " cni: " + cni
+					}
+					start := time.Now()
+					passed := a.Run(name, func() {
+						TestProvisioningAirgapCustomCluster(a.T(), client, a.airgapConfig, externalNodeProvider, tt.nodeRoles, kubeVersion, cni)
+					})
+					a.reporter.RecordSubtest(&reporting.Result{Name: name, KubernetesVersion: kubeVersion}, passed, start)
+				}
+			}
+		}
+	}
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestAirgapProvisioningTestSuite(t *testing.T) {
+	suite.Run(t, new(AirgapProvisioningTestSuite))
+}
@@ -0,0 +1,232 @@
+package ha
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	apiv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	rkev1 "github.com/rancher/rancher/pkg/apis/rke.cattle.io/v1"
+	"github.com/rancher/rancher/tests/framework/clients/rancher"
+	v1 "github.com/rancher/rancher/tests/framework/clients/rancher/v1"
+	"github.com/rancher/rancher/tests/framework/extensions/clusters"
+	"github.com/rancher/rancher/tests/framework/extensions/clusters/kubernetesversions"
+	"github.com/rancher/rancher/tests/framework/extensions/defaults"
+	"github.com/rancher/rancher/tests/framework/extensions/machinepools"
+	"github.com/rancher/rancher/tests/framework/extensions/rancherha"
+	"github.com/rancher/rancher/tests/framework/extensions/sshkeys"
+	"github.com/rancher/rancher/tests/framework/pkg/config"
+	namegen "github.com/rancher/rancher/tests/framework/pkg/namegenerator"
+	"github.com/rancher/rancher/tests/framework/pkg/session"
+	"github.com/rancher/rancher/tests/framework/pkg/wait"
+	"github.com/rancher/rancher/tests/v2/validation/provisioning"
+	"github.com/rancher/rancher/tests/v2/validation/provisioning/rke2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kwait "k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const namespace = "fleet-default"
+
+type RancherHATestSuite struct {
+	suite.Suite
+	client             *rancher.Client
+	session            *session.Session
+	haConfig           *rancherha.Config
+	provisioningConfig *provisioning.Config
+}
+
+func (h *RancherHATestSuite) TearDownSuite() {
+	h.session.Cleanup()
+}
+
+func (h *RancherHATestSuite) SetupSuite() {
+	testSession := session.NewSession()
+	h.session = testSession
+
+	h.haConfig = new(rancherha.Config)
+	config.LoadConfig(rancherha.ConfigurationFileKey, h.haConfig)
+
+	h.provisioningConfig = new(provisioning.Config)
+	config.LoadConfig(provisioning.ConfigurationFileKey, h.provisioningConfig)
+
+	client, err := rancher.NewClient("", testSession)
+	require.NoError(h.T(), err)
+
+	h.client = client
+
+	require.Greaterf(h.T(), h.haConfig.Replicas, int32(1), "rancher needs multiple replicas to fail over")
+	err = rancherha.ScaleRancher(client, h.haConfig.Replicas, h.failoverTimeout())
+	require.NoError(h.T(), err)
+}
+
+func (h *RancherHATestSuite) failoverTimeout() time.Duration {
+	return time.Duration(h.haConfig.FailoverTimeoutSeconds) * time.Second
+}
+
+// failover kills the rancher leader, asserts another replica takes over within the configured maximum failover time,
+// and waits for the killed replica to be replaced.
+func (h *RancherHATestSuite) failover() {
+	failover, err := rancherha.KillLeaderAndWaitForFailover(h.client, h.failoverTimeout())
+	require.NoError(h.T(), err)
+	assert.NotEqual(h.T(), failover.PreviousLeader, failover.NewLeader)
+	assert.LessOrEqualf(h.T(), failover.Duration, time.Duration(h.haConfig.MaxFailoverSeconds)*time.Second,
+		"failover from %s to %s took %s", failover.PreviousLeader, failover.NewLeader, failover.Duration)
+
+	err = rancherha.WaitForRancherReplicas(h.client, h.haConfig.Replicas, h.failoverTimeout())
+	require.NoError(h.T(), err)
+}
+
+func (h *RancherHATestSuite) TestLeaderFailover() {
+	h.failover()
+
+	// The new leader fails over in turn.
+	h.failover()
+}
+
+func (h *RancherHATestSuite) TestFailoverDuringProvisioningAndCertRotation() {
+	if len(h.provisioningConfig.Providers) == 0 {
+		h.T().Skip("no providers configured")
+	}
+
+	kubernetesVersions, err := kubernetesversions.Default(h.client, clusters.RKE2ClusterType.String(), h.provisioningConfig.RKE2KubernetesVersions)
+	require.NoError(h.T(), err)
+
+	nodesAndRoles := h.provisioningConfig.NodesAndRoles
+	if len(nodesAndRoles) == 0 {
+		nodesAndRoles = []machinepools.NodeRoles{
+			{
+				ControlPlane: true,
+				Etcd:         true,
+				Worker:       true,
+				Quantity:     1,
+			},
+		}
+	}
+
+	for _, providerName := range h.provisioningConfig.Providers {
+		provider := rke2.CreateProvider(providerName)
+		name := fmt.Sprintf("Provider_%s/Kubernetes_Version_%s", provider.Name, kubernetesVersions[0])
+
+		h.Run(name, func() {
+			subSession := h.session.NewSession()
+			defer subSession.Cleanup()
+
+			client, err := h.client.WithSession(subSession)
+			require.NoError(h.T(), err)
+
+			cloudCredential, err := provider.CloudCredFunc(client)
+			require.NoError(h.T(), err)
+
+			clusterName := namegen.AppendRandomString(fmt.Sprintf("ha-%s", provider.Name))
+			generatedPoolName := fmt.Sprintf("nc-%s-pool1-", clusterName)
+			machinePoolConfig := provider.MachinePoolFunc(generatedPoolName, namespace)
+
+			machineConfigResp, err := client.Steve.SteveType(provider.MachineConfigPoolResourceSteveType).Create(machinePoolConfig)
+			require.NoError(h.T(), err)
+
+			machinePools := machinepools.RKEMachinePoolSetup(nodesAndRoles, machineConfigResp)
+
+			cluster := clusters.NewK3SRKE2ClusterConfig(clusterName, namespace, "calico", cloudCredential.ID, kubernetesVersions[0], "", machinePools)
+			clusterResp, err := clusters.CreateK3SRKE2Cluster(client, cluster)
+			require.NoError(h.T(), err)
+
+			// The leader is killed once the machines are being provisioned.
+			require.NoError(h.T(), waitForMachines(client, clusterName))
+			h.failover()
+			require.NoError(h.T(), waitForClusterReady(client, clusterName))
+
+			// The leader is killed while the certificates are being rotated.
+			require.NoError(h.T(), rotateCertificates(client, clusterResp.ID, 1))
+			h.failover()
+			require.NoError(h.T(), waitForCertificateRotation(client, clusterName, 1))
+			require.NoError(h.T(), waitForClusterReady(client, clusterName))
+		})
+	}
+}
+
+// waitForMachines waits for the machines of the cluster to be created.
+func waitForMachines(client *rancher.Client, clusterName string) error {
+	query := url.Values{"labelSelector": {capi.ClusterLabelName + "=" + clusterName}}
+	return kwait.Poll(5*time.Second, 10*time.Minute, func() (done bool, err error) {
+		machines, err := client.Steve.SteveType(sshkeys.ClusterMachineConstraintResourceSteveType).NamespacedSteveClient(namespace).List(query)
+		if err != nil {
+			return false, err
+		}
+		return len(machines.Data) > 0, nil
+	})
+}
+
+func waitForClusterReady(client *rancher.Client, clusterName string) error {
+	kubeProvisioningClient, err := client.GetKubeAPIProvisioningClient()
+	if err != nil {
+		return err
+	}
+
+	result, err := kubeProvisioningClient.Clusters(namespace).Watch(context.TODO(), metav1.ListOptions{
+		FieldSelector:  "metadata.name=" + clusterName,
+		TimeoutSeconds: &defaults.WatchTimeoutSeconds,
+	})
+	if err != nil {
+		return err
+	}
+
+	return wait.WatchWait(result, clusters.IsProvisioningClusterReady)
+}
+
+func rotateCertificates(client *rancher.Client, id string, generation int64) error {
+	cluster, err := client.Steve.SteveType(clusters.ProvisioningSteveResouceType).ByID(id)
+	if err != nil {
+		return err
+	}
+
+	clusterSpec := &apiv1.ClusterSpec{}
+	if err := v1.ConvertToK8sType(cluster.Spec, clusterSpec); err != nil {
+		return err
+	}
+
+	clusterSpec.RKEConfig.RotateCertificates = &rkev1.RotateCertificates{
+		Generation: generation,
+	}
+
+	updatedCluster := *cluster
+	updatedCluster.Spec = *clusterSpec
+
+	_, err = client.Steve.SteveType(clusters.ProvisioningSteveResouceType).Update(cluster, updatedCluster)
+	return err
+}
+
+func waitForCertificateRotation(client *rancher.Client, clusterName string, generation int64) error {
+	kubeRKEClient, err := client.GetKubeAPIRKEClient()
+	if err != nil {
+		return err
+	}
+
+	result, err := kubeRKEClient.RKEControlPlanes(namespace).Watch(context.TODO(), metav1.ListOptions{
+		FieldSelector:  "metadata.name=" + clusterName,
+		TimeoutSeconds: &defaults.WatchTimeoutSeconds,
+	})
+	if err != nil {
+		return err
+	}
+
+	return wait.WatchWait(result, func(event watch.Event) (bool, error) {
+		controlPlane, ok := event.Object.(*rkev1.RKEControlPlane)
+		if !ok {
+			return false, nil
+		}
+		return controlPlane.Status.CertificateRotationGeneration == generation, nil
+	})
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestRancherHATestSuite(t *testing.T) {
+	suite.Run(t, new(RancherHATestSuite))
+}
@@ -59,4 +59,6 @@ type Config struct {
 	NodeProviders          []string                 `json:"nodeProviders" yaml:"nodeProviders"`
 	PSACT                  string                   `json:"psact" yaml:"psact"`
 	Hardened               bool                     `json:"hardened" yaml:"hardened"`
+	IPv6ClusterCIDR        string                   `json:"ipv6ClusterCIDR" yaml:"ipv6ClusterCIDR"`
+	IPv6ServiceCIDR        string                   `json:"ipv6ServiceCIDR" yaml:"ipv6ServiceCIDR"`
 }
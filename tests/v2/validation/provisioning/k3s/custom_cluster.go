@@ -15,6 +15,7 @@
 	"github.com/rancher/rancher/tests/framework/extensions/pipeline"
 	psadeploy "github.com/rancher/rancher/tests/framework/extensions/psact"
 	"github.com/rancher/rancher/tests/framework/extensions/tokenregistration"
+	"github.com/rancher/rancher/tests/framework/extensions/workloads/checks"
 	"github.com/rancher/rancher/tests/framework/extensions/workloads/pods"
 	"github.com/rancher/rancher/tests/framework/pkg/environmentflag"
 	namegen "github.com/rancher/rancher/tests/framework/pkg/namegenerator"
@@ -96,6 +97,9 @@ func TestProvisioningK3SCustomCluster(t *testing.T, client *rancher.Client, exte
 	assert.NotEmpty(t, podResults)
 	assert.Empty(t, podErrors)
 
+	err = checks.ValidateCluster(client, clusterIDName, checks.OptionsForCNI(""))
+	require.NoError(t, err)
+
 	if hardened && kubeVersion <= string(provisioning.HardenedKubeVersion) {
 		err = hardening.HardeningNodes(client, hardened, nodes, nodesAndRoles)
 		require.NoError(t, err)
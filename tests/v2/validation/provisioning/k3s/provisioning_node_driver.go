@@ -12,6 +12,7 @@
 	nodestat "github.com/rancher/rancher/tests/framework/extensions/nodes"
 	"github.com/rancher/rancher/tests/framework/extensions/pipeline"
 	psadeploy "github.com/rancher/rancher/tests/framework/extensions/psact"
+	"github.com/rancher/rancher/tests/framework/extensions/workloads/checks"
 	"github.com/rancher/rancher/tests/framework/extensions/workloads/pods"
 	"github.com/rancher/rancher/tests/framework/pkg/environmentflag"
 	namegen "github.com/rancher/rancher/tests/framework/pkg/namegenerator"
@@ -27,6 +28,12 @@
 )
 
 func TestProvisioningK3SCluster(t *testing.T, client *rancher.Client, provider Provider, nodesAndRoles []machinepools.NodeRoles, kubeVersion string, psact string) {
+	nodeCount := 0
+	for _, nodeRoles := range nodesAndRoles {
+		nodeCount += int(nodeRoles.Quantity)
+	}
+	require.NoError(t, provider.CloudProvider.Quota().CheckNodes(nodeCount))
+
 	cloudCredential, err := provider.CloudCredFunc(client)
 	require.NoError(t, err)
 
@@ -80,6 +87,9 @@ func TestProvisioningK3SCluster(t *testing.T, client *rancher.Client, provider P
 	assert.NotEmpty(t, podResults)
 	assert.Empty(t, podErrors)
 
+	err = checks.ValidateCluster(client, clusterIDName, checks.OptionsForCNI(""))
+	require.NoError(t, err)
+
 	if psact == string(provisioning.RancherPrivileged) || psact == string(provisioning.RancherRestricted) {
 		err = psadeploy.CheckPSACT(client, clusterName)
 		require.NoError(t, err)
@@ -56,6 +56,7 @@ func ExternalNodeProviderSetup(providerType string) ExternalNodeProvider {
 						}
 
 						node2.SSHKey = sshKey
+						node2.IsWindows = true
 					}
 				} else {
 					for _, node := range nodesList {
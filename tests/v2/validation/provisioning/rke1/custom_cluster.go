@@ -12,6 +12,7 @@
 	"github.com/rancher/rancher/tests/framework/extensions/pipeline"
 	psadeploy "github.com/rancher/rancher/tests/framework/extensions/psact"
 	"github.com/rancher/rancher/tests/framework/extensions/tokenregistration"
+	"github.com/rancher/rancher/tests/framework/extensions/workloads/checks"
 	"github.com/rancher/rancher/tests/framework/extensions/workloads/pods"
 	"github.com/rancher/rancher/tests/framework/pkg/environmentflag"
 	namegen "github.com/rancher/rancher/tests/framework/pkg/namegenerator"
@@ -83,6 +84,9 @@ func TestProvisioningRKE1CustomCluster(t *testing.T, client *rancher.Client, ext
 	assert.NotEmpty(t, podResults)
 	assert.Empty(t, podErrors)
 
+	err = checks.ValidateCluster(client, clusterResp.ID, checks.OptionsForCNI(cni))
+	require.NoError(t, err)
+
 	if psact == string(provisioning.RancherPrivileged) || psact == string(provisioning.RancherRestricted) {
 		err = psadeploy.CheckPSACT(client, clusterName)
 		require.NoError(t, err)
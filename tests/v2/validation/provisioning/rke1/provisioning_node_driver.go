@@ -12,6 +12,7 @@
 	psadeploy "github.com/rancher/rancher/tests/framework/extensions/psact"
 	nodepools "github.com/rancher/rancher/tests/framework/extensions/rke1/nodepools"
 	"github.com/rancher/rancher/tests/framework/extensions/rke1/nodetemplates"
+	"github.com/rancher/rancher/tests/framework/extensions/workloads/checks"
 	"github.com/rancher/rancher/tests/framework/extensions/workloads/pods"
 	"github.com/rancher/rancher/tests/framework/pkg/environmentflag"
 	namegen "github.com/rancher/rancher/tests/framework/pkg/namegenerator"
@@ -23,6 +24,12 @@
 )
 
 func TestProvisioningRKE1Cluster(t *testing.T, client *rancher.Client, provider Provider, nodesAndRoles []nodepools.NodeRoles, psact string, kubeVersion, cni string, nodeTemplate *nodetemplates.NodeTemplate) (*management.Cluster, error) {
+	nodeCount := 0
+	for _, nodeRoles := range nodesAndRoles {
+		nodeCount += int(nodeRoles.Quantity)
+	}
+	require.NoError(t, provider.CloudProvider.Quota().CheckNodes(nodeCount))
+
 	clusterName := namegen.AppendRandomString(provider.Name.String())
 	cluster := clusters.NewRKE1ClusterConfig(clusterName, cni, kubeVersion, psact, client)
 	clusterResp, err := clusters.CreateRKE1Cluster(client, cluster)
@@ -66,6 +73,9 @@ func TestProvisioningRKE1Cluster(t *testing.T, client *rancher.Client, provider
 	assert.NotEmpty(t, podResults)
 	assert.Empty(t, podErrors)
 
+	err = checks.ValidateCluster(client, clusterResp.ID, checks.OptionsForCNI(cni))
+	require.NoError(t, err)
+
 	if psact == string(provisioning.RancherPrivileged) || psact == string(provisioning.RancherRestricted) {
 		err = psadeploy.CheckPSACT(client, clusterName)
 		require.NoError(t, err)
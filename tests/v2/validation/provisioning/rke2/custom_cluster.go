@@ -4,6 +4,7 @@
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	apiv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
 	"github.com/rancher/rancher/tests/framework/clients/rancher"
@@ -15,6 +16,8 @@
 	"github.com/rancher/rancher/tests/framework/extensions/pipeline"
 	psadeploy "github.com/rancher/rancher/tests/framework/extensions/psact"
 	"github.com/rancher/rancher/tests/framework/extensions/tokenregistration"
+	"github.com/rancher/rancher/tests/framework/extensions/windows"
+	"github.com/rancher/rancher/tests/framework/extensions/workloads/checks"
 	"github.com/rancher/rancher/tests/framework/extensions/workloads/pods"
 	"github.com/rancher/rancher/tests/framework/pkg/environmentflag"
 	namegen "github.com/rancher/rancher/tests/framework/pkg/namegenerator"
@@ -26,7 +29,8 @@
 )
 
 const (
-	namespace = "fleet-default"
+	namespace         = "fleet-default"
+	windowsSSHTimeout = 15 * time.Minute
 )
 
 func TestProvisioningRKE2CustomCluster(t *testing.T, client *rancher.Client, externalNodeProvider provisioning.ExternalNodeProvider, nodesAndRoles []string, psact, kubeVersion, cni string, hardened bool, nodeCountWin int, hasWindows bool) {
@@ -86,14 +90,13 @@ func TestProvisioningRKE2CustomCluster(t *testing.T, client *rancher.Client, ext
 
 	if hasWindows {
 		for _, winNode := range winNodes {
-			t.Logf("Execute Registration Command for node %s", winNode.NodeID)
-
-			output, err := winNode.ExecuteCommand("powershell.exe" + token.InsecureWindowsNodeCommand)
+			err = windows.WaitForSSH(winNode, windowsSSHTimeout)
 			require.NoError(t, err)
-
-			t.Logf(string(output[:]))
 		}
 
+		err = windows.RegisterNodes(winNodes, token, true)
+		require.NoError(t, err)
+
 		kubeWinProvisioningClient, err := adminClient.GetKubeAPIProvisioningClient()
 		require.NoError(t, err)
 
@@ -108,6 +111,13 @@ func TestProvisioningRKE2CustomCluster(t *testing.T, client *rancher.Client, ext
 		err = wait.WatchWait(result, checkFunc)
 		assert.NoError(t, err)
 		assert.Equal(t, clusterName, clusterResp.ObjectMeta.Name)
+
+		if cni == windows.CalicoCNI {
+			for _, winNode := range winNodes {
+				err = windows.VerifyCalico(winNode)
+				require.NoError(t, err)
+			}
+		}
 	}
 
 	clusterIDName, err := clusters.GetClusterIDByName(adminClient, clusterName)
@@ -124,6 +134,14 @@ func TestProvisioningRKE2CustomCluster(t *testing.T, client *rancher.Client, ext
 	assert.NotEmpty(t, podResults)
 	assert.Empty(t, podErrors)
 
+	err = checks.ValidateCluster(client, clusterIDName, checks.OptionsForCNI(cni))
+	require.NoError(t, err)
+
+	if hasWindows {
+		_, err = windows.CreateIISDeployment(client, clusterIDName)
+		require.NoError(t, err)
+	}
+
 	if hardened && kubeVersion <= string(provisioning.HardenedKubeVersion) {
 		err = hardening.HardeningNodes(client, hardened, linuxNodes, nodesAndRoles)
 		require.NoError(t, err)
@@ -0,0 +1,129 @@
+package rke2
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"testing"
+
+	provv1 "github.com/rancher/rancher/pkg/apis/provisioning.cattle.io/v1"
+	"github.com/rancher/rancher/pkg/capr"
+	"github.com/rancher/rancher/tests/framework/clients/rancher"
+	steveV1 "github.com/rancher/rancher/tests/framework/clients/rancher/v1"
+	"github.com/rancher/rancher/tests/framework/extensions/clusters"
+	nodestat "github.com/rancher/rancher/tests/framework/extensions/nodes"
+	"github.com/rancher/rancher/tests/framework/extensions/workloads/pods"
+	"github.com/rancher/rancher/tests/framework/pkg/wait"
+	"github.com/rancher/rancher/tests/integration/pkg/defaults"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capi "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// TestIPv6Provisioning provisions an IPv6-only node driver cluster, with the cluster and service CIDRs given, and
+// validates that its nodes only have IPv6 addresses and join each other over bracketed IPv6 join URLs.
+func TestIPv6Provisioning(t *testing.T, client *rancher.Client, provider Provider, kubeVersion, cni, clusterCIDR, serviceCIDR string) {
+	cloudCredential, err := provider.CloudCredFunc(client)
+	require.NoError(t, err)
+
+	machinePoolConfig := provider.MachinePoolFunc("nc-ipv6-", namespace)
+	machineConfigResp, err := client.Steve.SteveType(provider.MachineConfigPoolResourceSteveType).Create(machinePoolConfig)
+	require.NoError(t, err)
+
+	quantity := int32(3)
+	machinePools := []provv1.RKEMachinePool{{
+		Name:             "ipv6",
+		EtcdRole:         true,
+		ControlPlaneRole: true,
+		WorkerRole:       true,
+		Quantity:         &quantity,
+		NodeConfig: &corev1.ObjectReference{
+			Kind: machineConfigResp.Kind,
+			Name: machineConfigResp.Name,
+		},
+	}}
+
+	cluster := clusters.NewK3SRKE2ClusterConfig("", namespace, cni, cloudCredential.ID, kubeVersion, "", machinePools)
+	cluster.GenerateName = "t-ipv6-"
+	cluster.Spec.RKEConfig.MachineGlobalConfig.Data["cluster-cidr"] = clusterCIDR
+	cluster.Spec.RKEConfig.MachineGlobalConfig.Data["service-cidr"] = serviceCIDR
+	clusterResp, err := clusters.CreateK3SRKE2Cluster(client, cluster)
+	require.NoError(t, err)
+
+	require.NoError(t, steveV1.ConvertToK8sType(clusterResp.JSONResp, cluster))
+	clusterName := cluster.Name
+
+	kubeProvisioningClient, err := client.GetKubeAPIProvisioningClient()
+	require.NoError(t, err)
+
+	result, err := kubeProvisioningClient.Clusters(namespace).Watch(context.TODO(), metav1.ListOptions{
+		FieldSelector:  "metadata.name=" + clusterName,
+		TimeoutSeconds: &defaults.WatchTimeoutSeconds,
+	})
+	require.NoError(t, err)
+
+	err = wait.WatchWait(result, clusters.IsProvisioningClusterReady)
+	require.NoError(t, err)
+
+	clusterIDName, err := clusters.GetClusterIDByName(client, clusterName)
+	require.NoError(t, err)
+
+	err = nodestat.IsNodeReady(client, clusterIDName)
+	require.NoError(t, err)
+
+	podResults, podErrors := pods.StatusPods(client, clusterIDName)
+	assert.NotEmpty(t, podResults)
+	assert.Empty(t, podErrors)
+
+	query, err := url.ParseQuery(fmt.Sprintf("labelSelector=%s=%s", capi.ClusterLabelName, clusterName))
+	require.NoError(t, err)
+
+	machineResp, err := client.Steve.SteveType("cluster.x-k8s.io.machine").List(query)
+	require.NoError(t, err)
+	require.Len(t, machineResp.Data, int(quantity))
+
+	for i := range machineResp.Data {
+		machine := &capi.Machine{}
+		require.NoError(t, steveV1.ConvertToK8sType(machineResp.Data[i].JSONResp, machine))
+
+		var internalAddresses int
+		for _, address := range machine.Status.Addresses {
+			if address.Type != capi.MachineInternalIP && address.Type != capi.MachineExternalIP {
+				continue
+			}
+			ip := net.ParseIP(address.Address)
+			require.NotNil(t, ip, "machine %s has an invalid address %s", machine.Name, address.Address)
+			assert.Nil(t, ip.To4(), "machine %s has the IPv4 address %s", machine.Name, address.Address)
+			if address.Type == capi.MachineInternalIP {
+				internalAddresses++
+			}
+		}
+		assert.NotZero(t, internalAddresses, "machine %s has no internal address", machine.Name)
+	}
+
+	secretQuery, err := url.ParseQuery(fmt.Sprintf("labelSelector=%s=%s", capr.ClusterNameLabel, clusterName))
+	require.NoError(t, err)
+
+	secretResp, err := client.Steve.SteveType("secret").NamespacedSteveClient(namespace).List(secretQuery)
+	require.NoError(t, err)
+
+	var joinURLs int
+	for i := range secretResp.Data {
+		secret := &corev1.Secret{}
+		require.NoError(t, steveV1.ConvertToK8sType(secretResp.Data[i].JSONResp, secret))
+		if secret.Type != capr.SecretTypeMachinePlan || secret.Annotations[capr.JoinURLAnnotation] == "" {
+			continue
+		}
+		joinURLs++
+
+		joinURL, err := url.Parse(secret.Annotations[capr.JoinURLAnnotation])
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(joinURL.Host, "["), "join URL %s is not a bracketed IPv6 address", joinURL)
+		assert.Nil(t, net.ParseIP(joinURL.Hostname()).To4(), "join URL %s is not an IPv6 address", joinURL)
+	}
+	assert.NotZero(t, joinURLs, "no plan secret has a join URL")
+}
@@ -0,0 +1,84 @@
+package rke2
+
+import (
+	"testing"
+
+	"github.com/rancher/rancher/tests/framework/clients/rancher"
+	"github.com/rancher/rancher/tests/framework/pkg/config"
+	"github.com/rancher/rancher/tests/framework/pkg/session"
+	"github.com/rancher/rancher/tests/v2/validation/provisioning"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	defaultIPv6ClusterCIDR = "fd00:42::/56"
+	defaultIPv6ServiceCIDR = "fd00:43::/112"
+)
+
+type IPv6ProvisioningTestSuite struct {
+	suite.Suite
+	client             *rancher.Client
+	session            *session.Session
+	kubernetesVersions []string
+	cnis               []string
+	providers          []string
+	clusterCIDR        string
+	serviceCIDR        string
+}
+
+func (r *IPv6ProvisioningTestSuite) TearDownSuite() {
+	r.session.Cleanup()
+}
+
+func (r *IPv6ProvisioningTestSuite) SetupSuite() {
+	testSession := session.NewSession()
+	r.session = testSession
+
+	clustersConfig := new(provisioning.Config)
+	config.LoadConfig(provisioning.ConfigurationFileKey, clustersConfig)
+
+	r.kubernetesVersions = clustersConfig.RKE2KubernetesVersions
+	r.cnis = clustersConfig.CNIs
+	r.providers = clustersConfig.Providers
+
+	r.clusterCIDR = clustersConfig.IPv6ClusterCIDR
+	if r.clusterCIDR == "" {
+		r.clusterCIDR = defaultIPv6ClusterCIDR
+	}
+	r.serviceCIDR = clustersConfig.IPv6ServiceCIDR
+	if r.serviceCIDR == "" {
+		r.serviceCIDR = defaultIPv6ServiceCIDR
+	}
+
+	client, err := rancher.NewClient("", testSession)
+	require.NoError(r.T(), err)
+
+	r.client = client
+}
+
+func (r *IPv6ProvisioningTestSuite) TestProvisioningRKE2IPv6Cluster() {
+	for _, providerName := range r.providers {
+		provider := CreateProvider(providerName)
+		for _, kubeVersion := range r.kubernetesVersions {
+			for _, cni := range r.cnis {
+				name := "IPv6-only Node Provider: " + provider.Name.String() + " Kubernetes version: " + kubeVersion + " cni: " + cni
+				r.Run(name, func() {
+					subSession := r.session.NewSession()
+					defer subSession.Cleanup()
+
+					client, err := r.client.WithSession(subSession)
+					require.NoError(r.T(), err)
+
+					TestIPv6Provisioning(r.T(), client, provider, kubeVersion, cni, r.clusterCIDR, r.serviceCIDR)
+				})
+			}
+		}
+	}
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestIPv6ProvisioningTestSuite(t *testing.T) {
+	suite.Run(t, new(IPv6ProvisioningTestSuite))
+}
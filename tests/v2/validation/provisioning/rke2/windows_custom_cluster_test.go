@@ -0,0 +1,94 @@
+package rke2
+
+import (
+	"testing"
+
+	"github.com/rancher/rancher/tests/framework/clients/rancher"
+	"github.com/rancher/rancher/tests/framework/extensions/clusters"
+	"github.com/rancher/rancher/tests/framework/extensions/clusters/kubernetesversions"
+	"github.com/rancher/rancher/tests/framework/extensions/windows"
+	"github.com/rancher/rancher/tests/framework/pkg/config"
+	"github.com/rancher/rancher/tests/framework/pkg/session"
+	provisioning "github.com/rancher/rancher/tests/v2/validation/provisioning"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// WindowsCustomClusterProvisioningTestSuite provisions mixed Linux/Windows RKE2 custom clusters. Calico is the only
+// CNI supported on Windows nodes, so the configured CNIs are ignored.
+type WindowsCustomClusterProvisioningTestSuite struct {
+	suite.Suite
+	client             *rancher.Client
+	session            *session.Session
+	kubernetesVersions []string
+	nodeProviders      []string
+	psact              string
+}
+
+func (w *WindowsCustomClusterProvisioningTestSuite) TearDownSuite() {
+	w.session.Cleanup()
+}
+
+func (w *WindowsCustomClusterProvisioningTestSuite) SetupSuite() {
+	testSession := session.NewSession()
+	w.session = testSession
+
+	clustersConfig := new(provisioning.Config)
+	config.LoadConfig(provisioning.ConfigurationFileKey, clustersConfig)
+
+	w.nodeProviders = clustersConfig.NodeProviders
+	w.psact = clustersConfig.PSACT
+
+	client, err := rancher.NewClient("", testSession)
+	require.NoError(w.T(), err)
+
+	w.client = client
+
+	w.kubernetesVersions, err = kubernetesversions.Default(w.client, clusters.RKE2ClusterType.String(), clustersConfig.RKE2KubernetesVersions)
+	require.NoError(w.T(), err)
+}
+
+func (w *WindowsCustomClusterProvisioningTestSuite) TestProvisioningRKE2WindowsCustomCluster() {
+	nodeRoles0 := []string{
+		"--etcd --controlplane --worker",
+	}
+
+	nodeRoles1 := []string{
+		"--etcd --controlplane",
+		"--worker",
+	}
+
+	tests := []struct {
+		name         string
+		nodeRoles    []string
+		nodeCountWin int
+	}{
+		{"1 Node all roles + 1 Windows Worker", nodeRoles0, 1},
+		{"2 nodes - etcd/cp roles per 1 node + 1 Windows Worker", nodeRoles1, 1},
+		{"2 nodes - etcd/cp roles per 1 node + 2 Windows Workers", nodeRoles1, 2},
+	}
+
+	for _, tt := range tests {
+		testSession := session.NewSession()
+		defer testSession.Cleanup()
+
+		client, err := w.client.WithSession(testSession)
+		require.NoError(w.T(), err)
+
+		for _, nodeProviderName := range w.nodeProviders {
+			externalNodeProvider := provisioning.ExternalNodeProviderSetup(nodeProviderName)
+			for _, kubeVersion := range w.kubernetesVersions {
+				name := tt.name + " Node Provider: " + nodeProviderName + " Kubernetes version: " + kubeVersion
+				w.Run(name, func() {
+					TestProvisioningRKE2CustomCluster(w.T(), client, externalNodeProvider, tt.nodeRoles, w.psact, kubeVersion, windows.CalicoCNI, false, tt.nodeCountWin, true)
+				})
+			}
+		}
+	}
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestWindowsCustomClusterRKE2ProvisioningTestSuite(t *testing.T) {
+	suite.Run(t, new(WindowsCustomClusterProvisioningTestSuite))
+}
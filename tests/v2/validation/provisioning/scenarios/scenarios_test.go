@@ -0,0 +1,141 @@
+package scenarios
+
+import (
+	"testing"
+
+	"github.com/rancher/rancher/tests/framework/clients/rancher"
+	management "github.com/rancher/rancher/tests/framework/clients/rancher/generated/management/v3"
+	"github.com/rancher/rancher/tests/framework/extensions/clusters"
+	"github.com/rancher/rancher/tests/framework/extensions/clusters/kubernetesversions"
+	"github.com/rancher/rancher/tests/framework/extensions/scenarios"
+	"github.com/rancher/rancher/tests/framework/extensions/users"
+	password "github.com/rancher/rancher/tests/framework/extensions/users/passwordgenerator"
+	namegen "github.com/rancher/rancher/tests/framework/pkg/namegenerator"
+	"github.com/rancher/rancher/tests/framework/pkg/session"
+	provisioning "github.com/rancher/rancher/tests/v2/validation/provisioning"
+	"github.com/rancher/rancher/tests/v2/validation/provisioning/k3s"
+	"github.com/rancher/rancher/tests/v2/validation/provisioning/rke1"
+	"github.com/rancher/rancher/tests/v2/validation/provisioning/rke2"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ScenarioProvisioningTestSuite struct {
+	suite.Suite
+	client             *rancher.Client
+	session            *session.Session
+	standardUserClient *rancher.Client
+	scenarios          []*scenarios.Scenario
+}
+
+func (s *ScenarioProvisioningTestSuite) TearDownSuite() {
+	s.session.Cleanup()
+}
+
+func (s *ScenarioProvisioningTestSuite) SetupSuite() {
+	testSession := session.NewSession()
+	s.session = testSession
+
+	loadedScenarios, err := scenarios.LoadScenarios()
+	require.NoError(s.T(), err)
+
+	s.scenarios = loadedScenarios
+
+	client, err := rancher.NewClient("", testSession)
+	require.NoError(s.T(), err)
+
+	s.client = client
+
+	enabled := true
+	var testuser = namegen.AppendRandomString("testuser-")
+	var testpassword = password.GenerateUserPassword("testpass-")
+	user := &management.User{
+		Username: testuser,
+		Password: testpassword,
+		Name:     testuser,
+		Enabled:  &enabled,
+	}
+
+	newUser, err := users.CreateUserWithRole(client, user, "user")
+	require.NoError(s.T(), err)
+
+	newUser.Password = user.Password
+
+	standardUserClient, err := client.AsUser(newUser)
+	require.NoError(s.T(), err)
+
+	s.standardUserClient = standardUserClient
+}
+
+func (s *ScenarioProvisioningTestSuite) TestProvisioningScenarios() {
+	if len(s.scenarios) == 0 {
+		s.T().Skip("no scenario files configured")
+	}
+
+	for _, scenario := range s.scenarios {
+		kubernetesVersions, err := kubernetesversions.Default(s.client, scenario.ClusterType.String(), scenario.KubernetesVersions)
+		require.NoError(s.T(), err)
+
+		for _, permutation := range scenario.Permutations(kubernetesVersions) {
+			permutation := permutation
+
+			subSession := s.session.NewSession()
+			defer subSession.Cleanup()
+
+			userClient := s.client
+			if permutation.User == scenarios.StandardUser {
+				userClient = s.standardUserClient
+			}
+
+			client, err := userClient.WithSession(subSession)
+			require.NoError(s.T(), err)
+
+			s.Run(permutation.Name(), func() {
+				runPermutation(s.T(), client, permutation)
+			})
+		}
+	}
+}
+
+// runPermutation provisions and validates the cluster of a single scenario permutation with the existing provisioning
+// helpers of its cluster type.
+func runPermutation(t *testing.T, client *rancher.Client, permutation scenarios.Permutation) {
+	scenario := permutation.Scenario
+
+	if scenario.IsCustom() {
+		externalNodeProvider := provisioning.ExternalNodeProviderSetup(scenario.NodeProvider)
+		nodesAndRoles, windowsCount := scenario.CustomNodeRoles()
+
+		switch scenario.ClusterType {
+		case clusters.RKE1ClusterType:
+			rke1.TestProvisioningRKE1CustomCluster(t, client, externalNodeProvider, nodesAndRoles, scenario.PSACT, permutation.KubernetesVersion, permutation.CNI)
+		case clusters.RKE2ClusterType:
+			rke2.TestProvisioningRKE2CustomCluster(t, client, externalNodeProvider, nodesAndRoles, scenario.PSACT, permutation.KubernetesVersion, permutation.CNI, scenario.Hardened, windowsCount, windowsCount > 0)
+		case clusters.K3SClusterType:
+			k3s.TestProvisioningK3SCustomCluster(t, client, externalNodeProvider, nodesAndRoles, permutation.KubernetesVersion, scenario.Hardened, scenario.PSACT)
+		}
+		return
+	}
+
+	switch scenario.ClusterType {
+	case clusters.RKE1ClusterType:
+		provider := rke1.CreateProvider(scenario.Provider)
+		nodeTemplate, err := provider.NodeTemplateFunc(client)
+		require.NoError(t, err)
+
+		_, err = rke1.TestProvisioningRKE1Cluster(t, client, provider, scenario.NodePools(), scenario.PSACT, permutation.KubernetesVersion, permutation.CNI, nodeTemplate)
+		require.NoError(t, err)
+	case clusters.RKE2ClusterType:
+		provider := rke2.CreateProvider(scenario.Provider)
+		rke2.TestProvisioningRKE2Cluster(t, client, provider, scenario.MachinePools(), permutation.KubernetesVersion, permutation.CNI, scenario.PSACT)
+	case clusters.K3SClusterType:
+		provider := k3s.CreateProvider(scenario.Provider)
+		k3s.TestProvisioningK3SCluster(t, client, provider, scenario.MachinePools(), permutation.KubernetesVersion, scenario.PSACT)
+	}
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestScenarioProvisioningTestSuite(t *testing.T) {
+	suite.Run(t, new(ScenarioProvisioningTestSuite))
+}
@@ -0,0 +1,88 @@
+package rbac
+
+import (
+	"testing"
+
+	"github.com/rancher/rancher/tests/framework/clients/rancher"
+	"github.com/rancher/rancher/tests/framework/extensions/clusters"
+	"github.com/rancher/rancher/tests/framework/extensions/namespaces"
+	"github.com/rancher/rancher/tests/framework/extensions/rbacmatrix"
+	"github.com/rancher/rancher/tests/framework/pkg/config"
+	namegen "github.com/rancher/rancher/tests/framework/pkg/namegenerator"
+	"github.com/rancher/rancher/tests/framework/pkg/session"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const rbacMatrixConfigurationFileKey = "rbacMatrix"
+
+// rbacMatrixConfig optionally adds a role template under test to the matrix, with the names of the operations it is
+// expected to be allowed.
+type rbacMatrixConfig struct {
+	RoleTemplateID      string   `json:"roleTemplateId" yaml:"roleTemplateId"`
+	RoleTemplateAllowed []string `json:"roleTemplateAllowed" yaml:"roleTemplateAllowed"`
+}
+
+type RBACMatrixTestSuite struct {
+	suite.Suite
+	client  *rancher.Client
+	session *session.Session
+	matrix  rbacmatrix.Matrix
+	clients map[rbacmatrix.Persona]*rancher.Client
+	target  rbacmatrix.Target
+}
+
+func (rm *RBACMatrixTestSuite) TearDownSuite() {
+	rm.session.Cleanup()
+}
+
+func (rm *RBACMatrixTestSuite) SetupSuite() {
+	testSession := session.NewSession()
+	rm.session = testSession
+
+	matrixConfig := new(rbacMatrixConfig)
+	config.LoadConfig(rbacMatrixConfigurationFileKey, matrixConfig)
+
+	client, err := rancher.NewClient("", testSession)
+	require.NoError(rm.T(), err)
+
+	rm.client = client
+
+	clusterName := client.RancherConfig.ClusterName
+	require.NotEmptyf(rm.T(), clusterName, "Cluster name to install should be set")
+	clusterID, err := clusters.GetClusterIDByName(rm.client, clusterName)
+	require.NoError(rm.T(), err, "Error getting cluster ID")
+	cluster, err := rm.client.Management.Cluster.ByID(clusterID)
+	require.NoError(rm.T(), err)
+
+	project, err := createProject(rm.client, cluster.ID)
+	require.NoError(rm.T(), err)
+
+	namespace, err := namespaces.CreateNamespace(rm.client, namegen.AppendRandomString("testns-"), "", nil, nil, project)
+	require.NoError(rm.T(), err)
+
+	rm.target = rbacmatrix.Target{
+		Cluster:   cluster,
+		Project:   project,
+		Namespace: namespace.Name,
+	}
+
+	rm.matrix = rbacmatrix.Matrix{
+		Operations:          rbacmatrix.DefaultOperations(),
+		RoleTemplateID:      matrixConfig.RoleTemplateID,
+		RoleTemplateAllowed: matrixConfig.RoleTemplateAllowed,
+	}
+
+	rm.clients, err = rbacmatrix.SetupPersonas(rm.client, rm.target, matrixConfig.RoleTemplateID)
+	require.NoError(rm.T(), err)
+}
+
+func (rm *RBACMatrixTestSuite) TestPermissionMatrix() {
+	rm.matrix.Run(rm.T(), rm.clients, rm.target)
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestRBACMatrixTestSuite(t *testing.T) {
+	suite.Run(t, new(RBACMatrixTestSuite))
+}
@@ -0,0 +1,83 @@
+package scale
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rancher/rancher/tests/framework/clients/rancher"
+	"github.com/rancher/rancher/tests/framework/extensions/scale"
+	"github.com/rancher/rancher/tests/framework/pkg/config"
+	"github.com/rancher/rancher/tests/framework/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ScaleTestSuite struct {
+	suite.Suite
+	client      *rancher.Client
+	session     *session.Session
+	scaleConfig *scale.Config
+}
+
+func (s *ScaleTestSuite) TearDownSuite() {
+	s.session.Cleanup()
+}
+
+func (s *ScaleTestSuite) SetupSuite() {
+	testSession := session.NewSession()
+	s.session = testSession
+
+	s.scaleConfig = new(scale.Config)
+	config.LoadConfig(scale.ConfigurationFileKey, s.scaleConfig)
+
+	client, err := rancher.NewClient("", testSession)
+	require.NoError(s.T(), err)
+
+	s.client = client
+}
+
+func (s *ScaleTestSuite) TestSyntheticClusters() {
+	if s.scaleConfig.Clusters <= 0 {
+		s.T().Skip("no synthetic clusters to register")
+	}
+	require.Greater(s.T(), s.scaleConfig.Concurrency, 0)
+
+	baseline := scale.MeasureAPILatency(s.client, s.scaleConfig.LatencySamples)
+	for name, latency := range baseline {
+		s.T().Logf("Baseline latency of %s: p50 %s, p95 %s", name, latency.P50, latency.P95)
+	}
+
+	results, duration := scale.RegisterSyntheticClusters(s.client, s.scaleConfig.ClusterPrefix, s.scaleConfig.Clusters,
+		s.scaleConfig.Concurrency, time.Duration(s.scaleConfig.TimeoutSeconds)*time.Second)
+
+	report := scale.NewReport(results, duration)
+	report.APILatency = scale.MeasureAPILatency(s.client, s.scaleConfig.LatencySamples)
+
+	memory, err := scale.RancherMemoryMiB(s.client)
+	if s.scaleConfig.Thresholds.MaxRancherMemoryMiB > 0 {
+		require.NoError(s.T(), err)
+	} else if err != nil {
+		s.T().Logf("Rancher memory is not reported: %v", err)
+	}
+	report.RancherMemoryMiB = memory
+
+	s.T().Logf("Registered %d of %d clusters in %s, %.2f clusters per minute, connection p95 %s",
+		report.Connected, report.Clusters, report.Duration, report.ClustersPerMinute, report.Connection.P95)
+	for name, latency := range report.APILatency {
+		s.T().Logf("Latency of %s: p50 %s, p95 %s, max %s", name, latency.P50, latency.P95, latency.Max)
+	}
+	for pod, mib := range report.RancherMemoryMiB {
+		s.T().Logf("Memory of %s: %dMiB", pod, mib)
+	}
+
+	if s.scaleConfig.ReportFile != "" {
+		require.NoError(s.T(), report.Write(s.scaleConfig.ReportFile))
+	}
+
+	assert.Empty(s.T(), report.Violations(s.scaleConfig.Thresholds))
+}
+
+func TestScaleTestSuite(t *testing.T) {
+	suite.Run(t, new(ScaleTestSuite))
+}